@@ -0,0 +1,171 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/acai-travel/tech-challenge/internal/auth"
+	"github.com/acai-travel/tech-challenge/internal/pb"
+	"github.com/twitchtv/twirp"
+)
+
+// client calls the Twirp API for conversations and search, and the streaming API to send
+// messages.
+type client struct {
+	url    string
+	header http.Header
+	rpc    pb.ChatService
+}
+
+func newClient(url string, header http.Header) *client {
+	return &client{url: url, header: header, rpc: pb.NewChatServiceJSONClient(url, http.DefaultClient)}
+}
+
+func (c *client) context(ctx context.Context) context.Context {
+	ctx, _ = twirp.WithHTTPRequestHeaders(ctx, c.header)
+	return ctx
+}
+
+// startSession starts a session of the user, whose token then goes with every request.
+func (c *client) startSession(ctx context.Context) error {
+	c.header.Set(auth.DeviceHeader, "chat-tui")
+	resp, err := c.rpc.StartSession(c.context(ctx), &pb.StartSessionRequest{})
+	if err != nil {
+		return err
+	}
+	c.header.Set(auth.SessionHeader, resp.GetToken())
+	return nil
+}
+
+func (c *client) listConversations(ctx context.Context) ([]*pb.Conversation, error) {
+	resp, err := c.rpc.ListConversations(c.context(ctx), &pb.ListConversationsRequest{IncludePreview: true})
+	if err != nil {
+		return nil, err
+	}
+	return resp.GetConversations(), nil
+}
+
+func (c *client) describeConversation(ctx context.Context, id string) (*pb.Conversation, error) {
+	resp, err := c.rpc.DescribeConversation(c.context(ctx), &pb.DescribeConversationRequest{ConversationId: id})
+	if err != nil {
+		return nil, err
+	}
+	_, _ = c.rpc.MarkRead(c.context(ctx), &pb.MarkReadRequest{ConversationId: id})
+	return resp.GetConversation(), nil
+}
+
+// searchResult is a conversation matching a search, with the matching text.
+type searchResult struct {
+	conv  *pb.Conversation
+	match string
+}
+
+// search matches the titles of convs, then messages by meaning if the server has semantic
+// search. semantic reports whether it has.
+func (c *client) search(ctx context.Context, convs []*pb.Conversation, query string) (results []searchResult, semantic bool, err error) {
+	seen := make(map[string]bool)
+	lower := strings.ToLower(query)
+	for _, conv := range convs {
+		if strings.Contains(strings.ToLower(conv.GetTitle()), lower) {
+			results = append(results, searchResult{conv: conv, match: conv.GetPreview().GetLastMessage()})
+			seen[conv.GetId()] = true
+		}
+	}
+
+	resp, err := c.rpc.SearchSemantic(c.context(ctx), &pb.SearchSemanticRequest{Query: query, Limit: 20})
+	var terr twirp.Error
+	if errors.As(err, &terr) && terr.Code() == twirp.Unimplemented {
+		return results, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	byID := make(map[string]*pb.Conversation, len(convs))
+	for _, conv := range convs {
+		byID[conv.GetId()] = conv
+	}
+	for _, r := range resp.GetResults() {
+		if seen[r.GetConversationId()] {
+			continue
+		}
+		seen[r.GetConversationId()] = true
+		conv := byID[r.GetConversationId()]
+		if conv == nil {
+			conv = &pb.Conversation{Id: r.GetConversationId(), Title: "Conversation " + r.GetConversationId()}
+		}
+		results = append(results, searchResult{conv: conv, match: r.GetMessage().GetContent()})
+	}
+	return results, true, nil
+}
+
+// streamEvent is a server-sent event of the streaming API.
+type streamEvent struct {
+	name string
+	data []byte
+}
+
+// streamedMessage is a message in the events of the streaming API.
+type streamedMessage struct {
+	ID        string `json:"id"`
+	Role      string `json:"role"`
+	Content   string `json:"content"`
+	CreatedAt int64  `json:"created_at"`
+}
+
+// send sends message to the conversation with id, or starts one if id is empty, and sends
+// the events of the reply to events until the stream ends. The stream ends after "reply"
+// or "error".
+func (c *client) send(ctx context.Context, id, message string, events chan<- streamEvent) error {
+	body, _ := json.Marshal(map[string]string{"conversation_id": id, "message": message})
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimSuffix(c.url, "/")+"/stream/messages", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	for k, v := range c.header {
+		req.Header[k] = v
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "text/event-stream")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		var terr struct {
+			Code string `json:"code"`
+			Msg  string `json:"msg"`
+		}
+		if json.NewDecoder(resp.Body).Decode(&terr) != nil || terr.Msg == "" {
+			return fmt.Errorf("sending message: %s", resp.Status)
+		}
+		return fmt.Errorf("sending message: %s", terr.Msg)
+	}
+
+	var ev streamEvent
+	sc := bufio.NewScanner(resp.Body)
+	sc.Buffer(make([]byte, 0, 64<<10), 1<<20)
+	for sc.Scan() {
+		line := sc.Text()
+		switch {
+		case line == "":
+			if ev.name != "" {
+				events <- ev
+			}
+			ev = streamEvent{}
+		case strings.HasPrefix(line, "event: "):
+			ev.name = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: "):
+			ev.data = append(ev.data, strings.TrimPrefix(line, "data: ")...)
+		}
+	}
+	return sc.Err()
+}
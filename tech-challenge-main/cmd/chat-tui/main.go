@@ -0,0 +1,40 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/acai-travel/tech-challenge/internal/auth"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func main() {
+	url := "http://localhost:8080"
+	if v := os.Getenv("API_URL"); v != "" {
+		url = v
+	}
+
+	header := make(http.Header)
+	if v := os.Getenv("USER_ID"); v != "" {
+		header.Set(auth.UserHeader, v)
+	}
+	if v := os.Getenv("API_KEY"); v != "" {
+		header.Set("Authorization", "Bearer "+v)
+	}
+
+	c := newClient(url, header)
+	if header.Get(auth.UserHeader) != "" {
+		if err := c.startSession(context.Background()); err != nil {
+			fmt.Printf("Error starting session: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	p := tea.NewProgram(newModel(c), tea.WithAltScreen())
+	if _, err := p.Run(); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+}
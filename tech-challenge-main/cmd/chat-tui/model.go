@@ -0,0 +1,577 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/acai-travel/tech-challenge/internal/pb"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/textarea"
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// requestTimeout bounds the Twirp calls; streamed replies run as long as the server takes.
+const requestTimeout = 30 * time.Second
+
+type focus int
+
+const (
+	focusList focus = iota
+	focusSearch
+	focusInput
+)
+
+var (
+	titleStyle    = lipgloss.NewStyle().Bold(true)
+	mutedStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("8"))
+	selectedStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("12")).Bold(true)
+	userStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("12")).Bold(true)
+	botStyle      = lipgloss.NewStyle().Foreground(lipgloss.Color("10")).Bold(true)
+	errorStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("9"))
+	paneStyle     = lipgloss.NewStyle().BorderStyle(lipgloss.NormalBorder()).BorderRight(true).PaddingRight(1)
+)
+
+// listItem is a conversation in the list pane.
+type listItem struct {
+	id, title, detail string
+	unread            bool
+}
+
+// message is a message of the open conversation.
+type message struct {
+	user    bool
+	content string
+	at      time.Time
+}
+
+// stream is a reply being streamed, to the conversation with convID once it is known.
+type stream struct {
+	convID string
+	events chan streamEvent
+	err    error
+	// draft is the text of the reply so far, from its deltas
+	draft string
+}
+
+type (
+	conversationsMsg []*pb.Conversation
+	openedMsg        *pb.Conversation
+	searchMsg        struct {
+		query    string
+		results  []searchResult
+		semantic bool
+	}
+	streamEventMsg streamEvent
+	streamDoneMsg  struct{ err error }
+	errMsg         struct{ err error }
+)
+
+type model struct {
+	client *client
+
+	width, height int
+	focus         focus
+
+	convs  []*pb.Conversation
+	items  []listItem
+	cursor int
+	query  string // the search the list shows, if any
+	note   string
+
+	currentID string // empty for a new conversation
+	title     string
+	messages  []message
+
+	streaming *stream
+	status    string
+	err       error
+
+	search  textinput.Model
+	input   textarea.Model
+	view    viewport.Model
+	spinner spinner.Model
+}
+
+func newModel(c *client) model {
+	search := textinput.New()
+	search.Prompt = "/ "
+	search.Placeholder = "search conversations"
+
+	input := textarea.New()
+	input.Placeholder = "Type a message, enter to send"
+	input.ShowLineNumbers = false
+	input.SetHeight(3)
+	input.CharLimit = 4000
+	input.KeyMap.InsertNewline = key.NewBinding(key.WithKeys("alt+enter", "ctrl+j"))
+
+	return model{
+		client:  c,
+		focus:   focusList,
+		title:   "New conversation",
+		search:  search,
+		input:   input,
+		view:    viewport.New(0, 0),
+		spinner: spinner.New(spinner.WithSpinner(spinner.Dot)),
+	}
+}
+
+func (m model) Init() tea.Cmd {
+	return m.loadConversations()
+}
+
+func (m model) loadConversations() tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+		defer cancel()
+		convs, err := m.client.listConversations(ctx)
+		if err != nil {
+			return errMsg{fmt.Errorf("listing conversations: %w", err)}
+		}
+		return conversationsMsg(convs)
+	}
+}
+
+func (m model) openConversation(id string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+		defer cancel()
+		conv, err := m.client.describeConversation(ctx, id)
+		if err != nil {
+			return errMsg{fmt.Errorf("opening conversation: %w", err)}
+		}
+		return openedMsg(conv)
+	}
+}
+
+func (m model) runSearch(query string) tea.Cmd {
+	convs := m.convs
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+		defer cancel()
+		results, semantic, err := m.client.search(ctx, convs, query)
+		if err != nil {
+			return errMsg{fmt.Errorf("searching: %w", err)}
+		}
+		return searchMsg{query: query, results: results, semantic: semantic}
+	}
+}
+
+// startStream sends text and returns the command waiting for the first event of the reply.
+func (m model) startStream(s *stream, text string) tea.Cmd {
+	go func() {
+		s.err = m.client.send(context.Background(), s.convID, text, s.events)
+		close(s.events)
+	}()
+	return waitForEvent(s)
+}
+
+func waitForEvent(s *stream) tea.Cmd {
+	return func() tea.Msg {
+		ev, ok := <-s.events
+		if !ok {
+			return streamDoneMsg{s.err}
+		}
+		return streamEventMsg(ev)
+	}
+}
+
+func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		m.resize()
+		return m, nil
+
+	case tea.KeyMsg:
+		return m.handleKey(msg)
+
+	case conversationsMsg:
+		m.convs = msg
+		for _, c := range m.convs {
+			if c.GetId() == m.currentID {
+				m.title = c.GetTitle() // new conversations get their title with the reply
+			}
+		}
+		if m.query == "" {
+			m.showConversations()
+		}
+		return m, nil
+
+	case openedMsg:
+		conv := (*pb.Conversation)(msg)
+		m.currentID, m.title = conv.GetId(), conv.GetTitle()
+		m.messages = nil
+		for _, msg := range conv.GetMessages() {
+			m.messages = append(m.messages, message{
+				user:    msg.GetRole() == pb.Conversation_USER,
+				content: msg.GetContent(),
+				at:      msg.GetTimestamp().AsTime(),
+			})
+		}
+		if conv.GetFailedReply() != "" && m.streaming == nil {
+			m.err = errors.New(conv.GetFailedReply())
+		}
+		m.render()
+		return m, nil
+
+	case searchMsg:
+		if msg.query != m.query {
+			return m, nil // a newer search is running
+		}
+		m.items = nil
+		for _, r := range msg.results {
+			m.items = append(m.items, listItem{id: r.conv.GetId(), title: r.conv.GetTitle(), detail: r.match})
+		}
+		m.cursor = 0
+		m.note = ""
+		if !msg.semantic {
+			m.note = "Semantic search is not enabled: matching titles only."
+		}
+		return m, nil
+
+	case streamEventMsg:
+		return m.handleStreamEvent(streamEvent(msg))
+
+	case streamDoneMsg:
+		if msg.err != nil {
+			m.err = msg.err
+		}
+		m.streaming, m.status = nil, ""
+		return m, m.loadConversations()
+
+	case errMsg:
+		m.err = msg.err
+		return m, nil
+
+	case spinner.TickMsg:
+		if m.streaming == nil {
+			return m, nil
+		}
+		var cmd tea.Cmd
+		m.spinner, cmd = m.spinner.Update(msg)
+		return m, cmd
+	}
+	return m, nil
+}
+
+func (m model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return m, tea.Quit
+	case "ctrl+n":
+		m.currentID, m.title, m.messages, m.err = "", "New conversation", nil, nil
+		m.render()
+		cmd := m.setFocus(focusInput)
+		return m, cmd
+	case "ctrl+r":
+		return m, m.loadConversations()
+	case "pgup", "pgdown":
+		var cmd tea.Cmd
+		m.view, cmd = m.view.Update(msg)
+		return m, cmd
+	case "tab":
+		if m.focus == focusInput {
+			cmd := m.setFocus(focusList)
+			return m, cmd
+		}
+		cmd := m.setFocus(focusInput)
+		return m, cmd
+	}
+
+	switch m.focus {
+	case focusSearch:
+		switch msg.String() {
+		case "esc":
+			m.search.Reset()
+			m.showConversations()
+			cmd := m.setFocus(focusList)
+			return m, cmd
+		case "enter":
+			m.query = strings.TrimSpace(m.search.Value())
+			if m.query == "" {
+				m.showConversations()
+				cmd := m.setFocus(focusList)
+				return m, cmd
+			}
+			cmd := m.setFocus(focusList)
+			return m, tea.Batch(cmd, m.runSearch(m.query))
+		}
+		var cmd tea.Cmd
+		m.search, cmd = m.search.Update(msg)
+		return m, cmd
+
+	case focusList:
+		switch msg.String() {
+		case "up", "k":
+			m.cursor = max(m.cursor-1, 0)
+		case "down", "j":
+			m.cursor = min(m.cursor+1, len(m.items)-1)
+		case "/":
+			cmd := m.setFocus(focusSearch)
+			return m, cmd
+		case "esc":
+			if m.query != "" {
+				m.search.Reset()
+				m.showConversations()
+			}
+		case "enter":
+			if m.cursor < len(m.items) {
+				m.err = nil
+				cmd := m.setFocus(focusInput)
+				return m, tea.Batch(m.openConversation(m.items[m.cursor].id), cmd)
+			}
+		}
+		return m, nil
+
+	case focusInput:
+		switch msg.String() {
+		case "esc":
+			cmd := m.setFocus(focusList)
+			return m, cmd
+		case "enter":
+			return m.send()
+		}
+		var cmd tea.Cmd
+		m.input, cmd = m.input.Update(msg)
+		return m, cmd
+	}
+	return m, nil
+}
+
+func (m model) send() (tea.Model, tea.Cmd) {
+	text := strings.TrimSpace(m.input.Value())
+	if text == "" {
+		return m, nil
+	}
+	if m.streaming != nil {
+		m.err = errors.New("wait for the reply before sending another message")
+		return m, nil
+	}
+	m.input.Reset()
+	m.err = nil
+	m.messages = append(m.messages, message{user: true, content: text, at: time.Now()})
+	m.render()
+
+	m.streaming = &stream{convID: m.currentID, events: make(chan streamEvent, 16)}
+	m.status = "Thinking…"
+	return m, tea.Batch(m.startStream(m.streaming, text), m.spinner.Tick)
+}
+
+func (m model) handleStreamEvent(ev streamEvent) (tea.Model, tea.Cmd) {
+	s := m.streaming
+	if s == nil {
+		return m, nil
+	}
+	next := waitForEvent(s)
+
+	switch ev.name {
+	case "conversation":
+		var started struct {
+			ID string `json:"id"`
+		}
+		if json.Unmarshal(ev.data, &started) == nil && s.convID == "" {
+			s.convID = started.ID
+			if m.currentID == "" {
+				m.currentID = started.ID
+			}
+		}
+	case "progress":
+		var p struct {
+			Message string `json:"message"`
+		}
+		if json.Unmarshal(ev.data, &p) == nil && p.Message != "" {
+			m.status = p.Message
+		}
+	case "delta":
+		var d struct {
+			Text string `json:"text"`
+		}
+		if json.Unmarshal(ev.data, &d) == nil {
+			s.draft += d.Text
+			m.render()
+		}
+	case "reply":
+		var reply streamedMessage
+		if err := json.Unmarshal(ev.data, &reply); err != nil {
+			m.err = fmt.Errorf("reading reply: %w", err)
+			break
+		}
+		// The reply replaces the draft, as the reply policies may have changed it.
+		s.draft = ""
+		// The user may have switched conversations while waiting.
+		if m.currentID == s.convID {
+			m.messages = append(m.messages, message{content: reply.Content, at: time.Unix(reply.CreatedAt, 0)})
+		}
+		m.render()
+	case "error", "cancelled":
+		var e struct {
+			Message string `json:"message"`
+		}
+		_ = json.Unmarshal(ev.data, &e)
+		m.err = errors.New(e.Message)
+		s.draft = ""
+		m.render()
+	}
+	return m, next
+}
+
+func (m *model) setFocus(f focus) tea.Cmd {
+	m.focus = f
+	m.search.Blur()
+	m.input.Blur()
+	switch f {
+	case focusSearch:
+		return m.search.Focus()
+	case focusInput:
+		return m.input.Focus()
+	}
+	return nil
+}
+
+// showConversations lists every conversation, clearing the search.
+func (m *model) showConversations() {
+	m.query, m.note = "", ""
+	m.items = nil
+	for _, c := range m.convs {
+		m.items = append(m.items, listItem{
+			id:     c.GetId(),
+			title:  c.GetTitle(),
+			detail: c.GetPreview().GetLastMessage(),
+			unread: c.GetPreview().GetUnread(),
+		})
+	}
+	m.cursor = min(m.cursor, max(len(m.items)-1, 0))
+}
+
+func (m model) listWidth() int {
+	return min(36, m.width/3)
+}
+
+func (m *model) resize() {
+	width := m.width - m.listWidth() - 2
+	m.input.SetWidth(width)
+	m.view.Width = width
+	// Title, status, input with its margin and the help line.
+	m.view.Height = max(m.height-1-1-(m.input.Height()+1)-1, 1)
+	m.render()
+}
+
+// render lays the messages of the open conversation out in the viewport.
+func (m *model) render() {
+	if m.view.Width <= 0 {
+		return
+	}
+	var b strings.Builder
+	wrap := lipgloss.NewStyle().Width(m.view.Width)
+	for _, msg := range m.messages {
+		label := botStyle.Render("Assistant")
+		if msg.user {
+			label = userStyle.Render("You")
+		}
+		fmt.Fprintf(&b, "%s %s\n%s\n\n", label, mutedStyle.Render(msg.at.Local().Format("Jan 2 15:04")), wrap.Render(msg.content))
+	}
+	if s := m.streaming; s != nil && s.draft != "" && s.convID == m.currentID {
+		fmt.Fprintf(&b, "%s %s\n%s\n\n", botStyle.Render("Assistant"), mutedStyle.Render("writing…"), wrap.Render(s.draft))
+	}
+	if len(m.messages) == 0 {
+		b.WriteString(mutedStyle.Render("Start the conversation below."))
+	}
+	m.view.SetContent(b.String())
+	m.view.GotoBottom()
+}
+
+func (m model) View() string {
+	if m.width == 0 {
+		return "Loading…"
+	}
+	return lipgloss.JoinVertical(lipgloss.Left,
+		lipgloss.JoinHorizontal(lipgloss.Top, m.listView(), " ", m.conversationView()),
+		mutedStyle.Render(m.help()),
+	)
+}
+
+func (m model) listView() string {
+	width := m.listWidth()
+	height := m.height - 1
+	var lines []string
+	if m.focus == focusSearch || m.query != "" {
+		lines = append(lines, m.search.View())
+	} else {
+		lines = append(lines, titleStyle.Render("Conversations"))
+	}
+	if m.note != "" {
+		lines = append(lines, mutedStyle.Render(truncate(m.note, width)))
+	}
+	if len(m.items) == 0 {
+		empty := "No conversations yet."
+		if m.query != "" {
+			empty = "No matches."
+		}
+		lines = append(lines, mutedStyle.Render(empty))
+	}
+
+	// Each item takes two lines; keep the cursor in view.
+	visible := max((height-len(lines))/2, 1)
+	first := max(0, m.cursor-visible+1)
+	for i := first; i < len(m.items) && len(lines)+2 <= height; i++ {
+		it := m.items[i]
+		title := it.title
+		if it.unread {
+			title = "* " + title
+		}
+		style := lipgloss.NewStyle()
+		if i == m.cursor {
+			style = selectedStyle
+			if m.focus == focusList {
+				title = "> " + title
+			}
+		}
+		lines = append(lines, style.Render(truncate(title, width)), mutedStyle.Render(truncate(it.detail, width)))
+	}
+	return paneStyle.Width(width).Height(height).Render(strings.Join(lines, "\n"))
+}
+
+func (m model) conversationView() string {
+	status := ""
+	switch {
+	case m.err != nil:
+		status = errorStyle.Render(truncate("Error: "+m.err.Error(), m.view.Width))
+	case m.streaming != nil:
+		status = m.spinner.View() + " " + mutedStyle.Render(truncate(m.status, m.view.Width-2))
+	}
+	return lipgloss.JoinVertical(lipgloss.Left,
+		titleStyle.Render(truncate(m.title, m.view.Width)),
+		m.view.View(),
+		status,
+		" ",
+		m.input.View(),
+	)
+}
+
+func (m model) help() string {
+	switch m.focus {
+	case focusSearch:
+		return "enter search • esc cancel • ctrl+c quit"
+	case focusInput:
+		return "enter send • alt+enter newline • pgup/pgdown scroll • tab/esc conversations • ctrl+n new • ctrl+c quit"
+	}
+	return "↑/↓ select • enter open • / search • tab message • ctrl+n new • ctrl+r refresh • ctrl+c quit"
+}
+
+// truncate shortens s to a single line of at most width characters.
+func truncate(s string, width int) string {
+	s = strings.Join(strings.Fields(s), " ")
+	r := []rune(s)
+	if width <= 1 || len(r) <= width {
+		return s
+	}
+	return string(r[:width-1]) + "…"
+}
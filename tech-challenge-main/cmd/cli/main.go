@@ -2,14 +2,19 @@ package main
 
 import (
 	"bufio"
+	"cmp"
 	"context"
 	"flag"
 	"fmt"
 	"net/http"
 	"os"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/acai-travel/tech-challenge/internal/auth"
 	"github.com/acai-travel/tech-challenge/internal/pb"
+	"github.com/twitchtv/twirp"
 )
 
 func main() {
@@ -19,6 +24,16 @@ func main() {
 		fmt.Println("  ask        Create a new conversation with assistant or continue an existing one")
 		fmt.Println("  list       List existing conversations")
 		fmt.Println("  show       Show conversation by ID")
+		fmt.Println("  retry      Retry a failed reply in a conversation by ID")
+		fmt.Println("  cancel     Stop generating the reply in a conversation by ID")
+		fmt.Println("  export     Save a conversation by ID as Markdown, JSON or PDF")
+		fmt.Println("  search     Search past messages by meaning")
+		fmt.Println("  pins       List, add or remove the pinned messages of a conversation")
+		fmt.Println("  rate       Rate a reply of a conversation up or down, or clear its rating")
+		fmt.Println("  places     List, set or delete saved places, e.g. \"home\"")
+		fmt.Println("  calendars  List, link or unlink personal ICS calendars, e.g. \"work\", or connect a CalDAV calendar")
+		fmt.Println("  notifications  Show or change how you are notified, e.g. quiet hours")
+		fmt.Println("  replay     Answer a conversation's message again with another prompt or model (admin)")
 	}
 
 	if len(os.Args) < 2 {
@@ -36,6 +51,32 @@ func main() {
 	cli := pb.NewChatServiceJSONClient(url, http.DefaultClient)
 	ctx := context.Background()
 
+	header := make(http.Header)
+	if v := os.Getenv("USER_ID"); v != "" {
+		header.Set(auth.UserHeader, v)
+	}
+	if v := os.Getenv("API_KEY"); v != "" {
+		header.Set("Authorization", "Bearer "+v)
+	}
+	ctx, _ = twirp.WithHTTPRequestHeaders(ctx, header)
+
+	// Identified users need a session: SESSION_TOKEN reuses one, otherwise each run starts its own.
+	if header.Get(auth.UserHeader) != "" {
+		token := os.Getenv("SESSION_TOKEN")
+		if token == "" {
+			header.Set(auth.DeviceHeader, "cli")
+			ctx, _ = twirp.WithHTTPRequestHeaders(ctx, header)
+			resp, err := cli.StartSession(ctx, &pb.StartSessionRequest{})
+			if err != nil {
+				fmt.Printf("Error starting session: %v\n", err)
+				os.Exit(1)
+			}
+			token = resp.GetToken()
+		}
+		header.Set(auth.SessionHeader, token)
+		ctx, _ = twirp.WithHTTPRequestHeaders(ctx, header)
+	}
+
 	switch os.Args[1] {
 	case "ask":
 		fmt.Println("Press CMD+C to exit.")
@@ -109,7 +150,7 @@ func main() {
 		}
 
 	case "list":
-		resp, err := cli.ListConversations(ctx, &pb.ListConversationsRequest{})
+		resp, err := cli.ListConversations(ctx, &pb.ListConversationsRequest{IncludePreview: true})
 		if err != nil {
 			fmt.Printf("Error listing conversations: %v\n", err)
 			os.Exit(1)
@@ -120,9 +161,17 @@ func main() {
 			return
 		}
 
-		fmt.Println("ID                         TITLE")
+		fmt.Println("ID                           MSGS  TITLE")
 		for _, conv := range resp.Conversations {
-			fmt.Printf("%s   %s\n", conv.GetId(), conv.GetTitle())
+			unread := " "
+			if conv.GetPreview().GetUnread() {
+				unread = "*"
+			}
+			title := conv.GetTitle()
+			if conv.GetIcon() != "" {
+				title = conv.GetIcon() + " " + title
+			}
+			fmt.Printf("%s %s %4d  %s\n", conv.GetId(), unread, conv.GetPreview().GetMessageCount(), title)
 		}
 	case "show":
 		if len(os.Args) < 3 {
@@ -144,7 +193,370 @@ func main() {
 		fmt.Println("Timestamp:", resp.GetConversation().GetTimestamp().AsTime().Format(time.RFC1123))
 		fmt.Println("")
 		for _, msg := range resp.GetConversation().GetMessages() {
-			fmt.Printf("%s, %s:\n%s\n\n", msg.GetRole(), msg.GetTimestamp().AsTime().Format(time.TimeOnly), msg.GetContent())
+			pinned := ""
+			if msg.GetPinnedAt() != nil {
+				pinned = " (pinned)"
+			}
+			fmt.Printf("%s, %s%s:\n%s\n\n", msg.GetRole(), msg.GetTimestamp().AsTime().Format(time.TimeOnly), pinned, msg.GetContent())
+		}
+		if notice := resp.GetConversation().GetFailedReply(); notice != "" {
+			fmt.Printf("%s\n\n", notice)
+		}
+
+		if _, err := cli.MarkRead(ctx, &pb.MarkReadRequest{ConversationId: os.Args[2]}); err != nil {
+			fmt.Printf("Error marking conversation as read: %v\n", err)
+		}
+	case "retry":
+		if len(os.Args) < 3 {
+			fmt.Println("Error: Conversation ID is required")
+			os.Exit(1)
+		}
+
+		out, err := cli.RetryFailedReply(ctx, &pb.RetryFailedReplyRequest{
+			ConversationId: os.Args[2],
+		})
+
+		if err != nil {
+			fmt.Printf("Error retrying reply: %v\n", err)
+			os.Exit(1)
+		}
+
+		fmt.Printf("ASSISTANT:\n%s\n\n", out.GetReply())
+	case "cancel":
+		if len(os.Args) < 3 {
+			fmt.Println("Error: Conversation ID is required")
+			os.Exit(1)
+		}
+
+		out, err := cli.CancelGeneration(ctx, &pb.CancelGenerationRequest{
+			ConversationId: os.Args[2],
+		})
+
+		if err != nil {
+			fmt.Printf("Error cancelling reply: %v\n", err)
+			os.Exit(1)
+		}
+
+		if out.GetCancelled() {
+			fmt.Println("Reply cancelled; use retry to generate it again.")
+		} else {
+			fmt.Println("No reply was being generated.")
+		}
+	case "export":
+		if len(os.Args) < 3 {
+			fmt.Println("Usage: export <conversation-id> [markdown|json|pdf] [--tools]")
+			os.Exit(1)
+		}
+
+		req := &pb.ExportConversationRequest{ConversationId: os.Args[2]}
+		for _, arg := range os.Args[3:] {
+			if arg == "--tools" {
+				req.IncludeToolTraces = true
+				continue
+			}
+			format, ok := pb.ExportConversationRequest_Format_value[strings.ToUpper(arg)]
+			if !ok {
+				fmt.Printf("Error: Unknown format %q\n", arg)
+				os.Exit(1)
+			}
+			req.Format = pb.ExportConversationRequest_Format(format)
+		}
+
+		out, err := cli.ExportConversation(ctx, req)
+		if err != nil {
+			fmt.Printf("Error exporting conversation: %v\n", err)
+			os.Exit(1)
+		}
+
+		if err := os.WriteFile(out.GetFilename(), out.GetData(), 0o644); err != nil {
+			fmt.Printf("Error saving export: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Println("Saved", out.GetFilename())
+	case "search":
+		if len(os.Args) < 3 {
+			fmt.Println("Error: Search query is required")
+			os.Exit(1)
+		}
+
+		out, err := cli.SearchSemantic(ctx, &pb.SearchSemanticRequest{
+			Query: strings.Join(os.Args[2:], " "),
+		})
+
+		if err != nil {
+			fmt.Printf("Error searching messages: %v\n", err)
+			os.Exit(1)
+		}
+
+		for _, r := range out.GetResults() {
+			msg := r.GetMessage()
+			fmt.Printf("%s %.2f %s, %s:\n%s\n\n", r.GetConversationId(), r.GetScore(), msg.GetRole(), msg.GetTimestamp().AsTime().Format(time.DateOnly), msg.GetContent())
+		}
+	case "pins":
+		switch {
+		case len(os.Args) == 3:
+			out, err := cli.ListPinnedMessages(ctx, &pb.ListPinnedMessagesRequest{ConversationId: os.Args[2]})
+			if err != nil {
+				fmt.Printf("Error listing pinned messages: %v\n", err)
+				os.Exit(1)
+			}
+
+			for _, msg := range out.GetMessages() {
+				fmt.Printf("%s %s, %s:\n%s\n\n", msg.GetId(), msg.GetRole(), msg.GetTimestamp().AsTime().Format(time.DateTime), msg.GetContent())
+			}
+		case len(os.Args) == 5 && (os.Args[3] == "add" || os.Args[3] == "remove"):
+			_, err := cli.PinMessage(ctx, &pb.PinMessageRequest{ConversationId: os.Args[2], MessageId: messageID(ctx, cli, os.Args[2], os.Args[4]), Unpin: os.Args[3] == "remove"})
+			if err != nil {
+				fmt.Printf("Error pinning message: %v\n", err)
+				os.Exit(1)
+			}
+		default:
+			fmt.Println("Usage: pins <conversation-id> | pins <conversation-id> add|remove <message-id|last>")
+			os.Exit(1)
+		}
+	case "rate":
+		ratings := map[string]pb.MessageRating{
+			"up":    pb.MessageRating_MESSAGE_RATING_POSITIVE,
+			"down":  pb.MessageRating_MESSAGE_RATING_NEGATIVE,
+			"clear": pb.MessageRating_MESSAGE_RATING_UNSPECIFIED,
+		}
+		rating, ok := pb.MessageRating(0), false
+		if len(os.Args) == 5 {
+			rating, ok = ratings[os.Args[4]]
 		}
+		if !ok {
+			fmt.Println("Usage: rate <conversation-id> <message-id|last> up|down|clear")
+			os.Exit(1)
+		}
+
+		_, err := cli.RateMessage(ctx, &pb.RateMessageRequest{ConversationId: os.Args[2], MessageId: messageID(ctx, cli, os.Args[2], os.Args[3]), Rating: rating})
+		if err != nil {
+			fmt.Printf("Error rating message: %v\n", err)
+			os.Exit(1)
+		}
+	case "places":
+		switch {
+		case len(os.Args) == 2:
+			out, err := cli.ListLocationAliases(ctx, &pb.ListLocationAliasesRequest{})
+			if err != nil {
+				fmt.Printf("Error listing places: %v\n", err)
+				os.Exit(1)
+			}
+
+			for _, a := range out.GetAliases() {
+				fmt.Printf("%-20s %9.4f %9.4f  %s\n", a.GetName(), a.GetLatitude(), a.GetLongitude(), a.GetLabel())
+			}
+		case os.Args[2] == "set" && len(os.Args) >= 6:
+			lat, latErr := strconv.ParseFloat(os.Args[4], 64)
+			lon, lonErr := strconv.ParseFloat(os.Args[5], 64)
+			if latErr != nil || lonErr != nil {
+				fmt.Println("Error: Latitude and longitude must be numbers")
+				os.Exit(1)
+			}
+
+			_, err := cli.SetLocationAlias(ctx, &pb.SetLocationAliasRequest{Alias: &pb.LocationAlias{
+				Name:      os.Args[3],
+				Latitude:  lat,
+				Longitude: lon,
+				Label:     strings.Join(os.Args[6:], " "),
+			}})
+			if err != nil {
+				fmt.Printf("Error saving place: %v\n", err)
+				os.Exit(1)
+			}
+		case os.Args[2] == "delete" && len(os.Args) == 4:
+			if _, err := cli.DeleteLocationAlias(ctx, &pb.DeleteLocationAliasRequest{Name: os.Args[3]}); err != nil {
+				fmt.Printf("Error deleting place: %v\n", err)
+				os.Exit(1)
+			}
+		default:
+			fmt.Println("Usage: places | places set <name> <latitude> <longitude> [label] | places delete <name>")
+			os.Exit(1)
+		}
+	case "calendars":
+		switch {
+		case len(os.Args) == 2:
+			out, err := cli.ListUserCalendars(ctx, &pb.ListUserCalendarsRequest{})
+			if err != nil {
+				fmt.Printf("Error listing calendars: %v\n", err)
+				os.Exit(1)
+			}
+
+			for _, c := range out.GetCalendars() {
+				fmt.Printf("%-20s %s\n", c.GetName(), c.GetUrl())
+			}
+			if a := out.GetCaldav(); a != nil {
+				fmt.Printf("%-20s %s (CalDAV, as %s)\n", "(events added to)", a.GetUrl(), a.GetUsername())
+			}
+		case os.Args[2] == "set" && len(os.Args) == 5:
+			_, err := cli.SetUserCalendar(ctx, &pb.SetUserCalendarRequest{Calendar: &pb.UserCalendar{
+				Name: os.Args[3],
+				Url:  os.Args[4],
+			}})
+			if err != nil {
+				fmt.Printf("Error linking calendar: %v\n", err)
+				os.Exit(1)
+			}
+		case os.Args[2] == "delete" && len(os.Args) == 4:
+			if _, err := cli.DeleteUserCalendar(ctx, &pb.DeleteUserCalendarRequest{Name: os.Args[3]}); err != nil {
+				fmt.Printf("Error unlinking calendar: %v\n", err)
+				os.Exit(1)
+			}
+		case os.Args[2] == "connect" && len(os.Args) == 5:
+			// The password is read from the environment, so it isn't kept in the shell history.
+			password := os.Getenv("CALDAV_PASSWORD")
+			if password == "" {
+				fmt.Println("Error: set CALDAV_PASSWORD to the password of the CalDAV account")
+				os.Exit(1)
+			}
+			_, err := cli.SetCalDAVAccount(ctx, &pb.SetCalDAVAccountRequest{
+				Account:  &pb.CalDAVAccount{Url: os.Args[3], Username: os.Args[4]},
+				Password: password,
+			})
+			if err != nil {
+				fmt.Printf("Error connecting calendar: %v\n", err)
+				os.Exit(1)
+			}
+		case os.Args[2] == "disconnect" && len(os.Args) == 3:
+			if _, err := cli.DeleteCalDAVAccount(ctx, &pb.DeleteCalDAVAccountRequest{}); err != nil {
+				fmt.Printf("Error disconnecting calendar: %v\n", err)
+				os.Exit(1)
+			}
+		default:
+			fmt.Println("Usage: calendars | calendars set <name> <url> | calendars delete <name> | calendars connect <caldav-url> <username> | calendars disconnect")
+			os.Exit(1)
+		}
+	case "notifications":
+		notifications(ctx, cli)
+	case "replay":
+		replay(ctx)
+	}
+}
+
+// notifications shows the notification settings, or changes one of them and keeps the others.
+func notifications(ctx context.Context, cli pb.ChatService) {
+	out, err := cli.GetNotificationSettings(ctx, &pb.GetNotificationSettingsRequest{})
+	if err != nil {
+		fmt.Printf("Error getting notification settings: %v\n", err)
+		os.Exit(1)
+	}
+	settings := out.GetSettings()
+
+	channels := func(arg string) []string {
+		if arg == "none" {
+			return nil
+		}
+		return strings.Split(arg, ",")
+	}
+	switch {
+	case len(os.Args) == 2:
+		fmt.Printf("Channels:    %s (available: %s)\n", strings.Join(settings.GetChannels(), ", "), strings.Join(out.GetAvailableChannels(), ", "))
+		for _, r := range settings.GetRoutes() {
+			fmt.Printf("  %-12s %s\n", r.GetKind()+":", cmp.Or(strings.Join(r.GetChannels(), ", "), "none"))
+		}
+		if settings.GetEmail() != "" {
+			fmt.Printf("Email:       %s\n", settings.GetEmail())
+		}
+		if q := settings.GetQuietHours(); q != nil {
+			fmt.Printf("Quiet hours: %s to %s %s\n", q.GetStart(), q.GetEnd(), cmp.Or(q.GetTimezone(), "UTC"))
+		}
+		return
+	case os.Args[2] == "channels" && len(os.Args) == 4:
+		settings.Channels = channels(os.Args[3])
+	case os.Args[2] == "route" && len(os.Args) == 5:
+		routes := []*pb.NotificationRoute{{Kind: os.Args[3], Channels: channels(os.Args[4])}}
+		for _, r := range settings.GetRoutes() {
+			if r.GetKind() != os.Args[3] {
+				routes = append(routes, r)
+			}
+		}
+		settings.Routes = routes
+	case os.Args[2] == "email" && len(os.Args) == 4:
+		settings.Email = os.Args[3]
+	case os.Args[2] == "quiet" && len(os.Args) == 4 && os.Args[3] == "off":
+		settings.QuietHours = nil
+	case os.Args[2] == "quiet" && (len(os.Args) == 5 || len(os.Args) == 6):
+		settings.QuietHours = &pb.QuietHours{Start: os.Args[3], End: os.Args[4]}
+		if len(os.Args) == 6 {
+			settings.QuietHours.Timezone = os.Args[5]
+		}
+	default:
+		fmt.Println("Usage: notifications | notifications channels <channel,...|none> | notifications route <kind> <channel,...|none> | notifications email <address> | notifications quiet <HH:MM> <HH:MM> [timezone] | notifications quiet off")
+		os.Exit(1)
+	}
+
+	if _, err := cli.SetNotificationSettings(ctx, &pb.SetNotificationSettingsRequest{Settings: settings}); err != nil {
+		fmt.Printf("Error saving notification settings: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// messageID returns id, or the ID of the latest reply of the conversation if id is "last".
+func messageID(ctx context.Context, cli pb.ChatService, conversationID, id string) string {
+	if id != "last" {
+		return id
+	}
+	conv, err := cli.DescribeConversation(ctx, &pb.DescribeConversationRequest{ConversationId: conversationID})
+	if err != nil {
+		fmt.Printf("Error describing conversation: %v\n", err)
+		os.Exit(1)
+	}
+	id = ""
+	for _, msg := range conv.GetConversation().GetMessages() {
+		if msg.GetRole() == pb.Conversation_ASSISTANT {
+			id = msg.GetId()
+		}
+	}
+	return id
+}
+
+// replay answers a message again through the admin RPCs at ADMIN_URL (default
+// http://localhost:6060), and prints how the reply changed.
+func replay(ctx context.Context) {
+	fs := flag.NewFlagSet("replay", flag.ExitOnError)
+	tenant := fs.String("tenant", "", "tenant of the conversation")
+	message := fs.String("message", "", "ID of the user message to answer; the last one by default")
+	assistant := fs.String("assistant", "", "assistant to answer with, e.g. travel")
+	promptFile := fs.String("prompt-file", "", "file with the system prompt to answer with")
+	model := fs.String("model", "", "model to answer with")
+	if len(os.Args) < 3 {
+		fmt.Println("Usage: replay <conversation-id> [--message id] [--assistant name] [--prompt-file path] [--model name] [--tenant id]")
+		os.Exit(1)
+	}
+	_ = fs.Parse(os.Args[3:])
+
+	req := &pb.ReplayConversationRequest{
+		TenantId:       *tenant,
+		ConversationId: os.Args[2],
+		MessageId:      *message,
+		Assistant:      *assistant,
+		Model:          *model,
+	}
+	if *promptFile != "" {
+		b, err := os.ReadFile(*promptFile)
+		if err != nil {
+			fmt.Printf("Error reading prompt: %v\n", err)
+			os.Exit(1)
+		}
+		req.Prompt = string(b)
+	}
+
+	url := "http://localhost:6060"
+	if v := os.Getenv("ADMIN_URL"); v != "" {
+		url = v
+	}
+	out, err := pb.NewAdminServiceJSONClient(url, http.DefaultClient).ReplayConversation(ctx, req)
+	if err != nil {
+		fmt.Printf("Error replaying conversation: %v\n", err)
+		os.Exit(1)
+	}
+
+	for _, t := range out.GetToolCalls() {
+		fmt.Printf("TOOL: %s\n", t.GetCall())
+	}
+	if out.GetError() != "" {
+		fmt.Printf("Error generating the reply: %s\n", out.GetError())
+		os.Exit(1)
 	}
+	fmt.Printf("DIFF (%d tokens, %dms):\n%s", out.GetTokens(), out.GetDurationMs(), out.GetDiff())
 }
@@ -0,0 +1,130 @@
+// Command doctor checks the server's configuration, from the same environment, and the
+// services it depends on: that configuration files parse, that the OpenAI and WeatherAPI
+// keys work, that MongoDB is reachable with the indexes it needs and that the holiday
+// calendar loads. It prints what to fix and exits with status 1 if a required check fails.
+package main
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/acai-travel/tech-challenge/internal/chat/assistant"
+	"github.com/acai-travel/tech-challenge/internal/chat/model"
+	"github.com/acai-travel/tech-challenge/internal/doctor"
+	"github.com/acai-travel/tech-challenge/internal/httpx"
+	"github.com/acai-travel/tech-challenge/internal/mongox"
+	"github.com/acai-travel/tech-challenge/internal/pii"
+	"github.com/acai-travel/tech-challenge/internal/postprocess"
+	"github.com/acai-travel/tech-challenge/internal/quota"
+	"github.com/acai-travel/tech-challenge/internal/safety"
+	"github.com/acai-travel/tech-challenge/internal/tenant"
+)
+
+func main() {
+	timeout := flag.Duration("timeout", 10*time.Second, "how long each check may take")
+	flag.Parse()
+	// Only problems are worth interleaving with the report.
+	slog.SetLogLoggerLevel(slog.LevelWarn)
+
+	checks := configChecks()
+
+	// Provider checks go through the configured proxy, CA and pins, as the server's requests do.
+	if outbound, err := httpx.OutboundFromEnv(); err == nil {
+		httpx.SetOutbound(outbound)
+	}
+
+	var tenants []*tenant.Config
+	if path := os.Getenv("TENANTS_FILE"); path != "" {
+		tenants, _ = tenant.Load(path) // reported by the config checks
+	}
+
+	mongo := mongox.MustConnect()
+	var vectorIndex string
+	if os.Getenv("VECTOR_STORE") == "atlas" {
+		vectorIndex = cmp.Or(os.Getenv("VECTOR_SEARCH_INDEX"), "message_embeddings_vector")
+	}
+	if tenants == nil {
+		checks = append(checks, model.New(mongo).Checks(vectorIndex)...)
+		checks = append(checks, assistant.NewWithProfile(assistant.GeneralProfile).Checks()...)
+	}
+	for _, t := range tenants {
+		db := mongo
+		if t.MongoDatabase != "" {
+			db = mongo.Client().Database(t.MongoDatabase)
+		}
+		a := assistant.NewWithCredentials(assistant.GeneralProfile, assistant.Credentials{
+			OpenAIAPIKey:  t.OpenAIAPIKey,
+			WeatherAPIKey: t.WeatherAPIKey,
+		})
+		for _, c := range append(model.NewWithPrefix(db, t.CollectionPrefix).Checks(vectorIndex), a.Checks()...) {
+			c.Name = t.ID + ": " + c.Name
+			checks = append(checks, c)
+		}
+	}
+
+	results := doctor.Run(context.Background(), *timeout, checks...)
+	if err := doctor.Write(os.Stdout, results); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if doctor.Failed(results) {
+		os.Exit(1)
+	}
+}
+
+// configChecks verify the settings the server reads at startup, which it refuses to start
+// with when invalid.
+func configChecks() []doctor.Check {
+	ignore := func(_ any, err error) error { return err }
+	checks := []doctor.Check{
+		{
+			Name: "config OUTBOUND_*",
+			Run:  func(context.Context) error { return ignore(httpx.OutboundFromEnv()) },
+			Fix:  "correct the variable named in the error, as described under Outbound requests in the README",
+		},
+		{
+			Name: "config PII_MODE",
+			Run:  func(context.Context) error { return ignore(pii.FromEnv()) },
+			Fix:  "set PII_MODE to off, tag or mask and PII_KINDS to known kinds",
+		},
+		{
+			Name: "config OPENAI_API_KEY",
+			Run: func(context.Context) error {
+				if os.Getenv("OPENAI_API_KEY") == "" && os.Getenv("TENANTS_FILE") == "" {
+					return errors.New("OPENAI_API_KEY is not set")
+				}
+				return nil
+			},
+			Fix: "set OPENAI_API_KEY to an OpenAI API key",
+		},
+	}
+
+	// Files are only checked when configured.
+	files := []struct {
+		env  string
+		load func(path string) error
+		what string
+	}{
+		{"SAFETY_POLICY_FILE", func(path string) error { return ignore(safety.Load(path)) }, "safety policy"},
+		{"POST_PROCESSING_FILE", func(path string) error { return ignore(postprocess.Load(path)) }, "post-processing configuration"},
+		{"QUOTAS_FILE", func(path string) error { return ignore(quota.Load(path)) }, "plans file"},
+		{"TENANTS_FILE", func(path string) error { return ignore(tenant.Load(path)) }, "tenants file"},
+		{"SHADOW_PROMPT_FILE", func(path string) error { return ignore(os.ReadFile(path)) }, "prompt"},
+	}
+	for _, f := range files {
+		if path := os.Getenv(f.env); path != "" {
+			checks = append(checks, doctor.Check{
+				Name: "config " + f.env,
+				Run:  func(context.Context) error { return f.load(path) },
+				Fix:  fmt.Sprintf("point %s to a readable, valid %s, or unset it", f.env, f.what),
+			})
+		}
+	}
+	return checks
+}
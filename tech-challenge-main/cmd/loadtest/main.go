@@ -0,0 +1,170 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/acai-travel/tech-challenge/internal/chat"
+	"github.com/acai-travel/tech-challenge/internal/chat/model"
+	"github.com/acai-travel/tech-challenge/internal/mongox"
+	"github.com/acai-travel/tech-challenge/internal/pb"
+	"github.com/twitchtv/twirp"
+)
+
+var defaultMessages = []string{
+	"What is the weather like in Barcelona?",
+	"Is next Monday a public holiday?",
+	"Suggest a long weekend trip for next month.",
+	"What's today's date?",
+}
+
+func main() {
+	var (
+		target      = flag.String("target", "http://localhost:8080", "base URL of the server under test; API_URL overrides the default")
+		concurrency = flag.Int("c", 10, "number of concurrent conversations")
+		duration    = flag.Duration("d", 30*time.Second, "how long to generate traffic")
+		turns       = flag.Int("turns", 2, "ContinueConversation calls per conversation after StartConversation")
+		messages    = flag.String("messages", "", "file with one user message per line; defaults to a built-in set")
+		fake        = flag.Bool("fake", false, "serve an in-process server with a fake assistant instead of calling -target (needs MongoDB)")
+		fakeLatency = flag.Duration("fake-latency", 500*time.Millisecond, "reply latency of the fake assistant")
+	)
+	flag.Parse()
+
+	if v := os.Getenv("API_URL"); v != "" && !isFlagSet("target") {
+		*target = v
+	}
+
+	msgs := defaultMessages
+	if *messages != "" {
+		var err error
+		if msgs, err = readLines(*messages); err != nil {
+			fmt.Printf("Error reading messages: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	if *fake {
+		repo := model.New(mongox.MustConnect())
+		srv := httptest.NewServer(pb.NewChatServiceServer(chat.NewServer(repo, &fakeAssistant{latency: *fakeLatency}), twirp.WithServerJSONSkipDefaults(true)))
+		defer srv.Close()
+		*target = srv.URL
+	}
+
+	// Quiet the in-process server's request logs so they don't drown the report.
+	slog.SetDefault(slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelWarn})))
+
+	client := &http.Client{Transport: &http.Transport{MaxIdleConnsPerHost: *concurrency}}
+	cli := pb.NewChatServiceProtobufClient(*target, client)
+
+	fmt.Printf("Running %d concurrent conversations against %s for %s...\n", *concurrency, *target, *duration)
+
+	ctx, cancel := context.WithTimeout(context.Background(), *duration)
+	defer cancel()
+
+	rec := newRecorder()
+	start := time.Now()
+
+	var wg sync.WaitGroup
+	for w := 0; w < *concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := w; ctx.Err() == nil; i += *concurrency {
+				converse(ctx, cli, rec, msgs, i, *turns)
+			}
+		}()
+	}
+	wg.Wait()
+
+	rec.Report(os.Stdout, time.Since(start))
+}
+
+// converse runs one conversation: a StartConversation followed by turns continuations.
+func converse(ctx context.Context, cli pb.ChatService, rec *recorder, msgs []string, seq, turns int) {
+	// Requests in flight when the run ends are cut short; they would skew the error rate.
+	reqCtx := context.WithoutCancel(ctx)
+
+	t := time.Now()
+	out, err := cli.StartConversation(reqCtx, &pb.StartConversationRequest{Message: msgs[seq%len(msgs)]})
+	rec.Record("StartConversation", time.Since(t), err)
+	if err != nil {
+		return
+	}
+
+	for i := 1; i <= turns && ctx.Err() == nil; i++ {
+		t := time.Now()
+		_, err := cli.ContinueConversation(reqCtx, &pb.ContinueConversationRequest{
+			ConversationId: out.GetConversationId(),
+			Message:        msgs[(seq+i)%len(msgs)],
+		})
+		rec.Record("ContinueConversation", time.Since(t), err)
+		if err != nil {
+			return
+		}
+	}
+}
+
+func readLines(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var out []string
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		if line := strings.TrimSpace(sc.Text()); line != "" {
+			out = append(out, line)
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	if len(out) == 0 {
+		return nil, fmt.Errorf("%s contains no messages", path)
+	}
+	return out, nil
+}
+
+func isFlagSet(name string) bool {
+	set := false
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			set = true
+		}
+	})
+	return set
+}
+
+// fakeAssistant answers instantly apart from a fixed latency, so a run measures the
+// server and database rather than OpenAI.
+type fakeAssistant struct {
+	latency time.Duration
+}
+
+func (f *fakeAssistant) Title(ctx context.Context, conv *model.Conversation) (string, error) {
+	return "Load test conversation", f.wait(ctx, f.latency/2)
+}
+
+func (f *fakeAssistant) Reply(ctx context.Context, conv *model.Conversation) (string, error) {
+	return fmt.Sprintf("Fake reply to: %s", conv.Messages[len(conv.Messages)-1].Content), f.wait(ctx, f.latency)
+}
+
+func (f *fakeAssistant) wait(ctx context.Context, d time.Duration) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(d):
+		return nil
+	}
+}
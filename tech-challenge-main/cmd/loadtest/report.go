@@ -0,0 +1,89 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/twitchtv/twirp"
+)
+
+// recorder collects request latencies and errors per RPC.
+type recorder struct {
+	mu  sync.Mutex
+	ops map[string]*opStats
+}
+
+type opStats struct {
+	latencies []time.Duration
+	errors    map[string]int
+}
+
+func newRecorder() *recorder {
+	return &recorder{ops: make(map[string]*opStats)}
+}
+
+func (r *recorder) Record(op string, d time.Duration, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	s, ok := r.ops[op]
+	if !ok {
+		s = &opStats{errors: make(map[string]int)}
+		r.ops[op] = s
+	}
+
+	s.latencies = append(s.latencies, d)
+	if err != nil {
+		code := "transport"
+		var te twirp.Error
+		if errors.As(err, &te) {
+			code = string(te.Code())
+		}
+		s.errors[code]++
+	}
+}
+
+func (r *recorder) Report(w io.Writer, elapsed time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	names := make([]string, 0, len(r.ops))
+	for name := range r.ops {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fmt.Fprintf(w, "\n%-22s %8s %8s %8s %10s %10s %10s\n", "RPC", "REQS", "RPS", "ERRORS", "P50", "P95", "P99")
+	for _, name := range names {
+		s := r.ops[name]
+		slices.Sort(s.latencies)
+
+		var errs int
+		for _, n := range s.errors {
+			errs += n
+		}
+
+		n := len(s.latencies)
+		fmt.Fprintf(w, "%-22s %8d %8.1f %7.1f%% %10s %10s %10s\n", name, n,
+			float64(n)/elapsed.Seconds(), 100*float64(errs)/float64(n),
+			percentile(s.latencies, 50), percentile(s.latencies, 95), percentile(s.latencies, 99))
+
+		for code, count := range s.errors {
+			fmt.Fprintf(w, "    %s: %d\n", code, count)
+		}
+	}
+}
+
+// percentile returns the p-th percentile of sorted latencies, rounded for display.
+func percentile(sorted []time.Duration, p int) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	i := (len(sorted)*p + 99) / 100
+	return sorted[max(i-1, 0)].Round(time.Millisecond)
+}
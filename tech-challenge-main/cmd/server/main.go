@@ -1,40 +1,302 @@
 package main
 
 import (
+	"cmp"
+	"context"
+	"encoding/base64"
 	"fmt"
 	"log/slog"
+	"maps"
 	"net/http"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/acai-travel/tech-challenge/internal/abuse"
+	"github.com/acai-travel/tech-challenge/internal/admin"
+	"github.com/acai-travel/tech-challenge/internal/audit"
+	"github.com/acai-travel/tech-challenge/internal/auth"
+	"github.com/acai-travel/tech-challenge/internal/blob"
 	"github.com/acai-travel/tech-challenge/internal/chat"
 	"github.com/acai-travel/tech-challenge/internal/chat/assistant"
+	"github.com/acai-travel/tech-challenge/internal/chat/intent"
 	"github.com/acai-travel/tech-challenge/internal/chat/model"
+	"github.com/acai-travel/tech-challenge/internal/doctor"
+	"github.com/acai-travel/tech-challenge/internal/features"
 	"github.com/acai-travel/tech-challenge/internal/httpx"
+	"github.com/acai-travel/tech-challenge/internal/llmdebug"
+	"github.com/acai-travel/tech-challenge/internal/logx"
 	"github.com/acai-travel/tech-challenge/internal/mongox"
 	"github.com/acai-travel/tech-challenge/internal/pb"
+	"github.com/acai-travel/tech-challenge/internal/pii"
+	"github.com/acai-travel/tech-challenge/internal/postprocess"
+	"github.com/acai-travel/tech-challenge/internal/push"
+	"github.com/acai-travel/tech-challenge/internal/quota"
+	"github.com/acai-travel/tech-challenge/internal/report"
+	"github.com/acai-travel/tech-challenge/internal/safety"
+	"github.com/acai-travel/tech-challenge/internal/tenant"
+	"github.com/acai-travel/tech-challenge/internal/webui"
 	"github.com/gorilla/mux"
 	"github.com/twitchtv/twirp"
+	"go.mongodb.org/mongo-driver/mongo"
 )
 
 func main() {
+	// LOG_* pick the log format and level; message contents are redacted unless sampled.
+	logOptions, err := logx.FromEnv()
+	if err != nil {
+		panic(err)
+	}
+	slog.SetDefault(slog.New(logx.NewHandler(os.Stderr, logOptions)))
+
+	// OUTBOUND_* optionally route requests to OpenAI, WeatherAPI and the other providers
+	// through a proxy, trust a private CA and tune connection pools and timeouts.
+	outbound, err := httpx.OutboundFromEnv()
+	if err != nil {
+		panic(err)
+	}
+	httpx.SetOutbound(outbound)
+
 	mongo := mongox.MustConnect()
+	flags := features.NewStore(mongo)
 
-	repo := model.New(mongo)
-	assist := assistant.New()
+	// Every RPC, and every request to the threads and streaming APIs, is recorded in the audit
+	// log unless AUDIT_LOG=false.
+	twirpOptions := []interface{}{twirp.WithServerJSONSkipDefaults(true)}
+	var events *audit.Log
+	if os.Getenv("AUDIT_LOG") != "false" {
+		events = audit.NewLog(mongo)
+		twirpOptions = append(twirpOptions, twirp.WithServerHooks(audit.Hooks(events)))
+	}
 
-	server := chat.NewServer(repo, assist)
+	// SAFETY_POLICY_FILE optionally configures refused topics and disclaimers, and
+	// POST_PROCESSING_FILE how replies are transformed; tenants may override both.
+	var defaults replyPolicies
+	if path := os.Getenv("SAFETY_POLICY_FILE"); path != "" {
+		var err error
+		if defaults.safety, err = safety.Load(path); err != nil {
+			panic(err)
+		}
+	}
+	if path := os.Getenv("POST_PROCESSING_FILE"); path != "" {
+		c, err := postprocess.Load(path)
+		if err != nil {
+			panic(err)
+		}
+		defaults.post = c.Chain()
+	}
+
+	// CHAT_TITLE_ICONS=true gives conversations the emoji of their category next to the title.
+	defaults.titleIcons = os.Getenv("CHAT_TITLE_ICONS") == "true"
+
+	// PII_MODE optionally tags or masks personal data in user messages.
+	if defaults.personalData, err = pii.FromEnv(); err != nil {
+		panic(err)
+	}
+
+	// QUOTAS=true limits users to the daily allowances of the built-in free and pro plans,
+	// QUOTAS_FILE to those of the plans it configures.
+	if path := os.Getenv("QUOTAS_FILE"); path != "" {
+		if defaults.quotas, err = quota.Load(path); err != nil {
+			panic(err)
+		}
+	} else if os.Getenv("QUOTAS") == "true" {
+		defaults.quotas = quota.Defaults()
+	}
+
+	// LLM_DEBUG_STORE=true keeps the completion requests and responses of replies, for the
+	// tenants and users with the debug_capture flag, for operators to inspect.
+	if defaults.debug, err = llmdebug.FromEnv(mongo); err != nil {
+		panic(err)
+	}
+	if defaults.debug != nil {
+		go defaults.debug.Run(context.Background())
+	}
+
+	// Single tenant from the environment, unless TENANTS_FILE configures several.
+	servers := map[string]*chat.Server{}
+	var tenants []*tenant.Config
+	if path := os.Getenv("TENANTS_FILE"); path != "" {
+		var err error
+		if tenants, err = tenant.Load(path); err != nil {
+			panic(err)
+		}
+		for _, t := range tenants {
+			db := mongo
+			if t.MongoDatabase != "" {
+				db = mongo.Client().Database(t.MongoDatabase)
+			}
+			policies := defaults
+			if t.SafetyPolicy != nil {
+				policies.safety = t.SafetyPolicy
+			}
+			if t.PostProcessing != nil {
+				policies.post = t.PostProcessing.Chain()
+			}
+			if t.TitleIcons != nil {
+				policies.titleIcons = *t.TitleIcons
+			}
+			servers[t.ID] = newChatServer(db, t.CollectionPrefix, assistant.Credentials{
+				OpenAIAPIKey:  t.OpenAIAPIKey,
+				WeatherAPIKey: t.WeatherAPIKey,
+			}, policies)
+			servers[t.ID].AllowModels(t.AllowedModels)
+		}
+		slog.Info("Serving tenants", "count", len(tenants))
+	} else {
+		servers[auth.DefaultTenant] = newChatServer(mongo, "", assistant.Credentials{}, defaults)
+		enableEmail(servers[auth.DefaultTenant])
+		enableWhatsApp(servers[auth.DefaultTenant])
+		enableWidget(servers[auth.DefaultTenant])
+	}
+
+	// Users whose requests look abusive are throttled, and their detections audited, unless
+	// ABUSE_DETECTION=false.
+	if os.Getenv("ABUSE_DETECTION") != "false" {
+		var recorder audit.Recorder
+		if events != nil {
+			recorder = events
+		}
+		for _, server := range servers {
+			server.EnableAbuseDetection(abuse.Defaults(), recorder)
+		}
+	}
+
+	// Users can connect a CalDAV calendar for the assistant to add events to when
+	// CALDAV_CREDENTIALS_KEY, the base64 of 32 random bytes, is set to encrypt their passwords.
+	if v := os.Getenv("CALDAV_CREDENTIALS_KEY"); v != "" {
+		key, err := base64.StdEncoding.DecodeString(v)
+		if err != nil || len(key) != 32 {
+			panic("CALDAV_CREDENTIALS_KEY must be the base64 of 32 bytes, e.g. from `openssl rand -base64 32`")
+		}
+		for _, server := range servers {
+			server.EnableCalDAV(key)
+		}
+		slog.Info("CalDAV calendars enabled")
+	}
+
+	// Users can register their devices for push notifications when FCM or APNs is configured.
+	if fcm, apns := pushProviders(); fcm != nil || apns != nil {
+		for _, server := range servers {
+			server.EnablePush(fcm, apns)
+		}
+		slog.Info("Push notifications enabled", "fcm", fcm != nil, "apns", apns != nil)
+	}
+
+	// Dependencies are checked at startup and problems logged with what to fix, without
+	// delaying the server; STARTUP_CHECKS=strict waits for the checks and exits if a required
+	// one fails, off skips them.
+	switch mode := os.Getenv("STARTUP_CHECKS"); mode {
+	case "off":
+	case "strict":
+		if !startupChecks(servers) {
+			slog.Error("Startup checks failed, exiting; run cmd/doctor for a report")
+			os.Exit(1)
+		}
+	default:
+		go startupChecks(servers)
+	}
 
 	// Configure handler
 	handler := mux.NewRouter()
 	handler.Use(
+		httpx.RequestID(),
 		httpx.Logger(),
 		httpx.Recovery(),
+		auth.Middleware(),
 	)
 
 	handler.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
 		_, _ = fmt.Fprint(w, "Hi, my name is Clippy!")
 	})
 
-	handler.PathPrefix("/twirp/").Handler(pb.NewChatServiceServer(server, twirp.WithServerJSONSkipDefaults(true)))
+	// The built-in web UI calls the API below from the browser; WEB_UI=false disables it.
+	if os.Getenv("WEB_UI") != "false" {
+		handler.Handle("/ui", http.RedirectHandler("/ui/", http.StatusMovedPermanently))
+		handler.PathPrefix("/ui/").Handler(webui.Handler("/ui/"))
+	}
+
+	// Each API routes to the server of the caller's tenant, which tracks the caller's sessions
+	// and throttles abusive callers.
+	route := func(handlerFor func(*chat.Server) http.Handler) http.Handler {
+		handlers := make(map[string]http.Handler, len(servers))
+		for id, server := range servers {
+			handlers[id] = server.TrackSessions(server.DetectAbuse(handlerFor(server)))
+		}
+		if tenants == nil {
+			return features.Middleware(flags)(handlers[auth.DefaultTenant])
+		}
+		return tenant.Authenticate(tenants)(features.Middleware(flags)(tenant.Router(handlers)))
+	}
+	handler.PathPrefix("/twirp/").Handler(route(func(s *chat.Server) http.Handler {
+		return pb.NewChatServiceServer(s, twirpOptions...)
+	}))
+	// The threads and streaming APIs aren't served by Twirp, so middleware audits them.
+	audited := func(handlerFor func(*chat.Server) http.Handler) func(*chat.Server) http.Handler {
+		if events == nil {
+			return handlerFor
+		}
+		return func(s *chat.Server) http.Handler { return audit.Middleware(events)(handlerFor(s)) }
+	}
+	handler.PathPrefix("/exports/").Handler(route((*chat.Server).ExportHandler))
+	// OpenAI Assistants API compatible threads, for tooling built against it.
+	handler.PathPrefix("/v1/threads").Handler(route(audited((*chat.Server).ThreadsHandler)))
+	// Replies streamed as server-sent events, for interactive clients such as cmd/chat-tui.
+	handler.PathPrefix("/stream/").Handler(route(audited((*chat.Server).StreamHandler)))
+	// Email providers, WhatsApp and website visitors can't send tenant API keys, so only a
+	// single tenant has these channels.
+	if tenants == nil {
+		handler.Handle("/email/inbound", features.Middleware(flags)(servers[auth.DefaultTenant].EmailHandler()))
+		handler.Handle("/whatsapp/webhook", features.Middleware(flags)(servers[auth.DefaultTenant].WhatsAppHandler()))
+		handler.PathPrefix("/widget/").Handler(features.Middleware(flags)(servers[auth.DefaultTenant].WidgetHandler()))
+	}
+
+	// Weekly usage reports are built from the audit log unless USAGE_REPORTS=false, whether or
+	// not the admin server is there to read them.
+	var reports *report.Store
+	if os.Getenv("USAGE_REPORTS") != "false" {
+		notifiers := reportNotifiers()
+		switch {
+		case events != nil:
+			reports = report.NewStore(mongo)
+			go report.NewJob(events, reports, notifiers...).Run(context.Background())
+		case len(notifiers) > 0:
+			panic("usage report delivery is configured, but reports are built from the audit log, which AUDIT_LOG=false disables; set USAGE_REPORTS=false to turn them off")
+		}
+	}
+
+	// Diagnostics and admin RPCs on an internal port only: ADMIN_ADDR (default localhost:6060), "off" disables.
+	// Operators identify themselves with the user header, for the audit log.
+	if addr := os.Getenv("ADMIN_ADDR"); addr != "off" {
+		if addr == "" {
+			addr = "localhost:6060"
+		}
+		stats := func() map[string]any {
+			out := make(map[string]any, len(servers))
+			for id, server := range servers {
+				out[id] = server.Stats()
+			}
+			return map[string]any{"tenants": out}
+		}
+
+		adminHandler := http.NewServeMux()
+		adminHandler.Handle("/debug/", httpx.Admin(stats))
+		adminServer := admin.NewServer(flags, events, reports, defaults.debug)
+		adminServer.EnableChats(servers)
+		adminHandler.Handle(pb.AdminServicePathPrefix, pb.NewAdminServiceServer(adminServer, twirpOptions...))
+		if events != nil {
+			adminHandler.Handle("/audit/export", audit.ExportHandler(events))
+		}
+
+		go func() {
+			slog.Info("Starting the admin server...", "addr", addr)
+			if err := http.ListenAndServe(addr, auth.Middleware()(adminHandler)); err != nil {
+				slog.Error("Admin server stopped", "error", err)
+			}
+		}()
+	}
 
 	// Start the server
 	slog.Info("Starting the server...")
@@ -42,3 +304,405 @@ func main() {
 		panic(err)
 	}
 }
+
+// intentClassifier returns the intent classifier selected by INTENT_CLASSIFIER: "keyword"
+// (the default, free but English only), "embedding" or "llm".
+func intentClassifier(a *assistant.Assistant) intent.Classifier {
+	switch v := os.Getenv("INTENT_CLASSIFIER"); v {
+	case "embedding":
+		return intent.NewEmbeddings(a)
+	case "llm":
+		return intent.ClassifierFunc(a.LabelIntent)
+	default:
+		if v != "" && v != "keyword" {
+			slog.Warn("Unknown INTENT_CLASSIFIER, using keywords", "value", v)
+		}
+		return intent.Keywords{}
+	}
+}
+
+// vectorStore searches embeddings as chosen by VECTOR_STORE: with a full scan (scan, the
+// default), in process with an HNSW graph (memory), or with the Atlas Vector Search index
+// named VECTOR_SEARCH_INDEX (atlas).
+func vectorStore(repo *model.Repository) chat.VectorStore {
+	switch v := os.Getenv("VECTOR_STORE"); v {
+	case "atlas":
+		return chat.NewAtlasVectorStore(repo, cmp.Or(os.Getenv("VECTOR_SEARCH_INDEX"), "message_embeddings_vector"))
+	case "memory":
+		return chat.NewMemoryVectorStore(repo)
+	default:
+		if v != "" && v != "scan" {
+			slog.Warn("Unknown VECTOR_STORE, scanning embeddings", "value", v)
+		}
+		return chat.NewScanVectorStore(repo)
+	}
+}
+
+// reportNotifiers send usage reports to REPORT_WEBHOOK_URL, and by email to the
+// comma-separated REPORT_EMAIL_TO through REPORT_SMTP_ADDR (host:port), from
+// REPORT_EMAIL_FROM, authenticating with REPORT_SMTP_USER and REPORT_SMTP_PASSWORD if set.
+func reportNotifiers() []report.Notifier {
+	var out []report.Notifier
+	if url := os.Getenv("REPORT_WEBHOOK_URL"); url != "" {
+		out = append(out, report.NewWebhookNotifier(url))
+	}
+	if addr, to := os.Getenv("REPORT_SMTP_ADDR"), os.Getenv("REPORT_EMAIL_TO"); addr != "" && to != "" {
+		out = append(out, report.NewEmailNotifier(addr, os.Getenv("REPORT_SMTP_USER"), os.Getenv("REPORT_SMTP_PASSWORD"),
+			os.Getenv("REPORT_EMAIL_FROM"), strings.Split(strings.ReplaceAll(to, " ", ""), ",")))
+	}
+	return out
+}
+
+// notificationChannels are the channels users may route notifications to besides the
+// conversation and push: NOTIFY_WEBHOOK_URL, and email from NOTIFY_EMAIL_FROM through
+// NOTIFY_SMTP_ADDR (host:port), authenticating with NOTIFY_SMTP_USER and NOTIFY_SMTP_PASSWORD
+// if set.
+func notificationChannels() []chat.NotificationChannel {
+	var out []chat.NotificationChannel
+	if url := os.Getenv("NOTIFY_WEBHOOK_URL"); url != "" {
+		out = append(out, chat.NewWebhookNotifier(url))
+	}
+	if from, addr := os.Getenv("NOTIFY_EMAIL_FROM"), os.Getenv("NOTIFY_SMTP_ADDR"); from != "" || addr != "" {
+		if from == "" || addr == "" {
+			panic("NOTIFY_EMAIL_FROM and NOTIFY_SMTP_ADDR must be set together")
+		}
+		out = append(out, chat.NewEmailNotifier(from, chat.NewSMTPSender(addr, os.Getenv("NOTIFY_SMTP_USER"), os.Getenv("NOTIFY_SMTP_PASSWORD"))))
+	}
+	return out
+}
+
+// pushProviders send push notifications through FCM with the service account key file at
+// FCM_SERVICE_ACCOUNT_FILE, and through APNs with the .p8 key at APNS_KEY_FILE, whose
+// APNS_KEY_ID, APNS_TEAM_ID and app bundle ID APNS_TOPIC are required with it;
+// APNS_SANDBOX=true selects the development environment. Both are nil when not configured.
+func pushProviders() (fcm, apns chat.PushProvider) {
+	if path := os.Getenv("FCM_SERVICE_ACCOUNT_FILE"); path != "" {
+		sa, err := os.ReadFile(path)
+		if err != nil {
+			panic(fmt.Sprintf("failed to read FCM_SERVICE_ACCOUNT_FILE: %v", err))
+		}
+		p, err := push.NewFCM(sa)
+		if err != nil {
+			panic(fmt.Sprintf("invalid FCM_SERVICE_ACCOUNT_FILE: %v", err))
+		}
+		fcm = p
+	}
+	if path := os.Getenv("APNS_KEY_FILE"); path != "" {
+		key, err := os.ReadFile(path)
+		if err != nil {
+			panic(fmt.Sprintf("failed to read APNS_KEY_FILE: %v", err))
+		}
+		p, err := push.NewAPNs(key, os.Getenv("APNS_KEY_ID"), os.Getenv("APNS_TEAM_ID"), os.Getenv("APNS_TOPIC"), os.Getenv("APNS_SANDBOX") == "true")
+		if err != nil {
+			panic(fmt.Sprintf("invalid APNs configuration: %v", err))
+		}
+		apns = p
+	}
+	return fcm, apns
+}
+
+// enableEmail lets users email the assistant at EMAIL_ADDRESS, if set: the inbound webhook
+// of the email provider must carry EMAIL_WEBHOOK_SECRET, and replies are sent through
+// EMAIL_SMTP_ADDR (host:port), authenticating with EMAIL_SMTP_USER and EMAIL_SMTP_PASSWORD
+// if set.
+func enableEmail(server *chat.Server) {
+	address := os.Getenv("EMAIL_ADDRESS")
+	if address == "" {
+		return
+	}
+	secret, smtpAddr := os.Getenv("EMAIL_WEBHOOK_SECRET"), os.Getenv("EMAIL_SMTP_ADDR")
+	if secret == "" || smtpAddr == "" {
+		panic("EMAIL_ADDRESS requires EMAIL_WEBHOOK_SECRET and EMAIL_SMTP_ADDR")
+	}
+	server.EnableEmail(address, chat.NewSMTPSender(smtpAddr, os.Getenv("EMAIL_SMTP_USER"), os.Getenv("EMAIL_SMTP_PASSWORD")), secret)
+	slog.Info("Email channel enabled", "address", address)
+}
+
+// enableWhatsApp lets users chat with the assistant on WhatsApp, if WHATSAPP_PHONE_NUMBER_ID
+// is set: replies are sent from that business number with WHATSAPP_TOKEN, and the webhook
+// is verified with WHATSAPP_VERIFY_TOKEN and signed with WHATSAPP_APP_SECRET.
+func enableWhatsApp(server *chat.Server) {
+	phoneNumberID := os.Getenv("WHATSAPP_PHONE_NUMBER_ID")
+	if phoneNumberID == "" {
+		return
+	}
+	token, verifyToken, appSecret := os.Getenv("WHATSAPP_TOKEN"), os.Getenv("WHATSAPP_VERIFY_TOKEN"), os.Getenv("WHATSAPP_APP_SECRET")
+	if token == "" || verifyToken == "" || appSecret == "" {
+		panic("WHATSAPP_PHONE_NUMBER_ID requires WHATSAPP_TOKEN, WHATSAPP_VERIFY_TOKEN and WHATSAPP_APP_SECRET")
+	}
+	server.EnableWhatsApp(chat.NewWhatsAppCloudClient(phoneNumberID, token), verifyToken, appSecret)
+	slog.Info("WhatsApp channel enabled", "phone_number_id", phoneNumberID)
+}
+
+// enableWidget serves the chat widget to the websites of WIDGET_ORIGINS (comma-separated),
+// if set, signing sessions with WIDGET_SECRET. WIDGET_RATE_LIMIT bounds the requests of each
+// website a minute (default 60).
+func enableWidget(server *chat.Server) {
+	origins := os.Getenv("WIDGET_ORIGINS")
+	if origins == "" {
+		return
+	}
+	secret := os.Getenv("WIDGET_SECRET")
+	if len(secret) < 32 {
+		panic("WIDGET_ORIGINS requires a WIDGET_SECRET of at least 32 characters")
+	}
+	perMinute := 60
+	if v := os.Getenv("WIDGET_RATE_LIMIT"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			panic("invalid WIDGET_RATE_LIMIT: " + v)
+		}
+		perMinute = n
+	}
+	list := strings.Split(strings.ReplaceAll(origins, " ", ""), ",")
+	server.EnableWidget([]byte(secret), list, perMinute)
+	slog.Info("Chat widget enabled", "origins", list, "rate_limit", perMinute)
+}
+
+// profiles are the assistants every tenant registers, by name.
+var profiles = map[string]assistant.Profile{
+	"":        assistant.GeneralProfile,
+	"general": assistant.GeneralProfile,
+	"travel":  assistant.TravelProfile,
+	"support": assistant.SupportProfile,
+}
+
+// enableShadowing has a candidate also answer SHADOW_PERCENT of the messages to the assistant
+// SHADOW_ASSISTANT (default general), if set, for evaluation: the assistant with the prompt
+// in SHADOW_PROMPT_FILE and the model SHADOW_MODEL, either of which may be left unchanged.
+// SHADOW_NAME labels the candidate's replies (default "candidate").
+func enableShadowing(server *chat.Server, newAssistant func(assistant.Profile) *assistant.Assistant) {
+	v := os.Getenv("SHADOW_PERCENT")
+	if v == "" {
+		return
+	}
+	percent, err := strconv.ParseFloat(v, 64)
+	if err != nil || percent <= 0 || percent > 100 {
+		panic("invalid SHADOW_PERCENT: " + v)
+	}
+
+	of := os.Getenv("SHADOW_ASSISTANT")
+	profile, ok := profiles[of]
+	if !ok {
+		panic("unknown SHADOW_ASSISTANT: " + of)
+	}
+	if path := os.Getenv("SHADOW_PROMPT_FILE"); path != "" {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			panic(err)
+		}
+		profile.Prompt = string(b)
+	}
+	if m := os.Getenv("SHADOW_MODEL"); m != "" {
+		profile.Model = m
+	}
+	name := cmp.Or(os.Getenv("SHADOW_NAME"), "candidate")
+	profile.Name += "/" + name
+
+	server.EnableShadowing(chat.ShadowCandidate{Name: name, Assistant: newAssistant(profile), Of: of, Percent: percent})
+	slog.Info("Shadowing replies", "candidate", name, "assistant", profile.Name, "percent", percent)
+}
+
+// enablePromptRollout replaces the prompt of the assistant ROLLOUT_ASSISTANT (default general)
+// with the one in ROLLOUT_PROMPT_FILE, if set, for ROLLOUT_PERCENT of its conversations (default
+// 100). It is rolled back if, within ROLLOUT_WINDOW (default 24h), more replies than
+// ROLLOUT_MAX_ERROR_RATE fail or ROLLOUT_MAX_REFUSAL_RATE are refused, or fewer than
+// ROLLOUT_MIN_POSITIVE_RATIO of the ratings are positive. ROLLOUT_NAME is required: it
+// identifies the version, so a rolled back one isn't tried again after a restart.
+func enablePromptRollout(server *chat.Server, newAssistant func(assistant.Profile) *assistant.Assistant) {
+	path := os.Getenv("ROLLOUT_PROMPT_FILE")
+	if path == "" {
+		return
+	}
+	name := os.Getenv("ROLLOUT_NAME")
+	if name == "" {
+		panic("ROLLOUT_NAME is required with ROLLOUT_PROMPT_FILE")
+	}
+
+	of := os.Getenv("ROLLOUT_ASSISTANT")
+	profile, ok := profiles[of]
+	if !ok {
+		panic("unknown ROLLOUT_ASSISTANT: " + of)
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		panic(err)
+	}
+	profile.Prompt = string(b)
+	profile.Name += "/" + name
+
+	rate := func(key string, max float64) float64 {
+		v := os.Getenv(key)
+		if v == "" {
+			return 0
+		}
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil || f <= 0 || f > max {
+			panic(fmt.Sprintf("invalid %s: %s", key, v))
+		}
+		return f
+	}
+	var window time.Duration
+	if v := os.Getenv("ROLLOUT_WINDOW"); v != "" {
+		if window, err = time.ParseDuration(v); err != nil || window <= 0 {
+			panic("invalid ROLLOUT_WINDOW: " + v)
+		}
+	}
+
+	server.EnablePromptRollout(chat.PromptRollout{
+		Name:      name,
+		Assistant: newAssistant(profile),
+		Of:        of,
+		Percent:   rate("ROLLOUT_PERCENT", 100),
+		Window:    window,
+		Thresholds: chat.RolloutThresholds{
+			MaxErrorRate:     rate("ROLLOUT_MAX_ERROR_RATE", 1),
+			MaxRefusalRate:   rate("ROLLOUT_MAX_REFUSAL_RATE", 1),
+			MinPositiveRatio: rate("ROLLOUT_MIN_POSITIVE_RATIO", 1),
+		},
+	})
+	go server.RunPromptRollout(context.Background())
+	slog.Info("Rolling out prompt", "rollout", name, "assistant", profile.Name)
+}
+
+// replyPolicies configure what a tenant's assistants may say and how replies and titles
+// are handled; nil fields apply no policy.
+type replyPolicies struct {
+	safety       *safety.Policy
+	personalData *pii.Policy
+	post         postprocess.Chain
+	quotas       quota.Plans
+	debug        *llmdebug.Store
+	titleIcons   bool
+}
+
+// startupChecks runs the checks of every tenant's server, logging those that didn't pass. It
+// reports whether the required ones passed.
+func startupChecks(servers map[string]*chat.Server) bool {
+	var checks []doctor.Check
+	for _, id := range slices.Sorted(maps.Keys(servers)) {
+		for _, c := range servers[id].Checks() {
+			if len(servers) > 1 {
+				c.Name = id + ": " + c.Name
+			}
+			checks = append(checks, c)
+		}
+	}
+
+	results := doctor.Run(context.Background(), 10*time.Second, checks...)
+	for _, r := range results {
+		switch r.Status {
+		case doctor.StatusWarn:
+			slog.Warn("Startup check failed, continuing degraded", "check", r.Name, "error", r.Err, "fix", r.Fix)
+		case doctor.StatusFail:
+			slog.Error("Startup check failed", "check", r.Name, "error", r.Err, "fix", r.Fix)
+		}
+	}
+	return !doctor.Failed(results)
+}
+
+// newChatServer builds the chat server of one tenant with its assistants, storing its data
+// in db under collections named with prefix. Every assistant follows policies.
+func newChatServer(db *mongo.Database, prefix string, creds assistant.Credentials, policies replyPolicies) *chat.Server {
+	repo := model.NewWithPrefix(db, prefix)
+	// The tenant's assistants share the credentials, so they can share deterministic completions
+	// and tool results.
+	cache := assistant.ResponseCacheFromEnv()
+	tools := assistant.ToolCacheFromEnv()
+	flights, hotels := assistant.TravelSearchFromEnv()
+	newAssistant := func(p assistant.Profile) *assistant.Assistant {
+		a := assistant.NewWithCredentials(p, creds)
+		a.SetSafetyPolicy(policies.safety)
+		a.SetPIIPolicy(policies.personalData)
+		a.SetPostProcessing(policies.post)
+		a.SetResponseCache(cache)
+		a.SetToolCache(tools)
+		a.SetDebugStore(policies.debug)
+		if flights != nil {
+			a.SetFlightSearchProvider(flights)
+		}
+		if hotels != nil {
+			a.SetHotelSearchProvider(hotels)
+		}
+		return a
+	}
+	assist := newAssistant(assistant.GeneralProfile)
+
+	// Warm up connections before serving, so the first requests after a deploy are fast.
+	if os.Getenv("ASSISTANT_WARMUP") != "false" {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		if err := assist.Warmup(ctx); err != nil {
+			slog.Warn("Warmup incomplete, continuing", "error", err)
+		}
+		cancel()
+	}
+
+	server := chat.NewServer(repo, assist)
+	server.EnablePIIDetection(policies.personalData)
+	if policies.quotas != nil {
+		server.EnableQuotas(policies.quotas)
+	}
+	if os.Getenv("CHAT_SEMANTIC_SEARCH") != "false" {
+		server.EnableSemanticSearch(assist, vectorStore(repo))
+	}
+	if os.Getenv("CHAT_SUMMARIES") != "false" {
+		server.EnableSummaries(assist)
+	}
+	if os.Getenv("CHAT_LANGUAGE_LOCK") != "false" {
+		server.EnableLanguageDetection(assist)
+	}
+	if os.Getenv("CHAT_INTENT_ANALYTICS") != "false" {
+		server.EnableIntentAnalytics(intentClassifier(assist))
+	}
+	if policies.titleIcons {
+		// Icons are the intent analytics label of the first message.
+		if os.Getenv("CHAT_INTENT_ANALYTICS") == "false" {
+			slog.Warn("Title icons need intent analytics; conversations get no icon")
+		}
+		server.EnableTitleIcons()
+	}
+	if os.Getenv("CHAT_QUICK_REPLIES") != "false" {
+		server.EnableQuickReplies(assist)
+	}
+	if os.Getenv("CHAT_ATTACHMENTS") != "false" {
+		server.EnableAttachments(blob.NewGridFS(db, prefix+"attachments"), nil)
+	}
+	if os.Getenv("CHAT_EXPORTS") != "false" {
+		// EXPORT_WEBHOOK_URL optionally receives finished exports.
+		var notify chat.JobNotifier
+		if url := os.Getenv("EXPORT_WEBHOOK_URL"); url != "" {
+			notify = chat.NewWebhookNotifier(url)
+		}
+		server.EnableExportArchives(blob.NewGridFS(db, prefix+"exports"), notify)
+	}
+	if os.Getenv("CHAT_DIGESTS") != "false" {
+		// DIGEST_WEBHOOK_URL optionally receives the digests of users who choose webhook delivery.
+		var notify chat.DigestNotifier
+		if url := os.Getenv("DIGEST_WEBHOOK_URL"); url != "" {
+			notify = chat.NewWebhookNotifier(url)
+		}
+		server.EnableDigests(assist, notify)
+		go server.RunDigests(context.Background())
+	}
+	if os.Getenv("CHAT_NOTIFICATIONS") != "false" {
+		server.EnableNotifications(notificationChannels()...)
+		go server.RunNotifications(context.Background())
+	}
+	// Replies interrupted by the previous shutdown will never come; tell their users.
+	go server.RecoverGenerations(context.Background())
+	// Bulk jobs, e.g. exports, lost by the previous shutdown are failed so they can be rerun.
+	go server.RecoverBulkJobs(context.Background())
+	server.RegisterAssistant("travel", newAssistant(assistant.TravelProfile))
+	server.RegisterAssistant("support", newAssistant(assistant.SupportProfile))
+	enableShadowing(server, newAssistant)
+	enablePromptRollout(server, newAssistant)
+	server.EnablePromptReplays(func(name, prompt string) (chat.Assistant, error) {
+		profile, ok := profiles[name]
+		if !ok {
+			return nil, fmt.Errorf("no profile for assistant %q", name)
+		}
+		profile.Prompt = prompt
+		return newAssistant(profile), nil
+	})
+	return server
+}
@@ -0,0 +1,213 @@
+// Package abuse detects abusive use of the assistant from the recent requests of each user:
+// the same message sent over and over, extremely long inputs and scripted scraping. Each
+// detection is a strike; strikes throttle the user for longer and longer, and enough of them
+// require the user to authenticate again. State is kept in memory, per Detector.
+package abuse
+
+import (
+	"hash/fnv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+)
+
+// Signals of abuse, as named in verdicts and the audit log.
+const (
+	Repeated  = "repeated_messages"
+	LongInput = "long_input"
+	Scraping  = "scraping"
+)
+
+// Policy tunes the heuristics. Zero limits disable their heuristic.
+type Policy struct {
+	// RepeatLimit is how many identical messages may be sent within RepeatWindow.
+	RepeatLimit  int
+	RepeatWindow time.Duration
+	// MaxInput is the longest message, in characters.
+	MaxInput int
+	// ReadsPerMinute is how many conversations, searches and exports may be read a minute.
+	ReadsPerMinute int
+	// Throttle is how long the first strike throttles a user; each further strike within
+	// StrikeWindow doubles it, up to MaxThrottle.
+	Throttle     time.Duration
+	MaxThrottle  time.Duration
+	StrikeWindow time.Duration
+	// ReauthAfter is the number of strikes within StrikeWindow that require re-authentication.
+	ReauthAfter int
+}
+
+// Defaults returns a policy that leaves people alone and stops scripts quickly.
+func Defaults() Policy {
+	return Policy{
+		RepeatLimit:    3,
+		RepeatWindow:   time.Minute,
+		MaxInput:       32_000,
+		ReadsPerMinute: 120,
+		Throttle:       time.Minute,
+		MaxThrottle:    time.Hour,
+		StrikeWindow:   time.Hour,
+		ReauthAfter:    3,
+	}
+}
+
+// Verdict is what a Detector concluded from a request. The zero Verdict found nothing.
+type Verdict struct {
+	Signal string
+	// Strikes counts the user's strikes within the policy's StrikeWindow, this one included.
+	Strikes int
+	// Until is when the user's throttle ends.
+	Until time.Time
+	// Reauth requires the user to authenticate again.
+	Reauth bool
+}
+
+// Detector keeps the recent activity of users. It is safe for concurrent use.
+type Detector struct {
+	policy Policy
+
+	mu    sync.Mutex
+	users *lru.Cache[string, *activity]
+}
+
+type activity struct {
+	messages  []sent
+	readsFrom time.Time
+	reads     int
+	strikes   []time.Time
+	throttled time.Time
+}
+
+type sent struct {
+	hash uint64
+	at   time.Time
+}
+
+// New returns a detector applying p to the 10k most recently active users.
+func New(p Policy) *Detector {
+	users, _ := lru.New[string, *activity](10_000)
+	return &Detector{policy: p, users: users}
+}
+
+func (d *Detector) activity(userID string) *activity {
+	a, ok := d.users.Get(userID)
+	if !ok {
+		a = &activity{}
+		d.users.Add(userID, a)
+	}
+	return a
+}
+
+// Throttled reports when the throttle of a user ends, if they are throttled at now.
+func (d *Detector) Throttled(userID string, now time.Time) (time.Time, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	a, ok := d.users.Peek(userID)
+	if !ok || !now.Before(a.throttled) {
+		return time.Time{}, false
+	}
+	return a.throttled, true
+}
+
+// Block throttles a user until until, e.g. one who can't authenticate again, unless they
+// already are for longer.
+func (d *Detector) Block(userID string, until time.Time) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if a := d.activity(userID); until.After(a.throttled) {
+		a.throttled = until
+	}
+}
+
+// Message checks a message a user sends at now.
+func (d *Detector) Message(userID, content string, now time.Time) Verdict {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	a := d.activity(userID)
+	if d.policy.MaxInput > 0 && utf8.RuneCountInString(content) > d.policy.MaxInput {
+		return d.strike(a, LongInput, now)
+	}
+	if d.policy.RepeatLimit <= 0 {
+		return Verdict{}
+	}
+
+	h := fingerprint(content)
+	kept, same := a.messages[:0], 1
+	for _, m := range a.messages {
+		if now.Sub(m.at) >= d.policy.RepeatWindow {
+			continue
+		}
+		kept = append(kept, m)
+		if m.hash == h {
+			same++
+		}
+	}
+	a.messages = append(kept, sent{hash: h, at: now})
+	if same <= d.policy.RepeatLimit {
+		return Verdict{}
+	}
+	// Each burst is a single strike.
+	a.messages = nil
+	return d.strike(a, Repeated, now)
+}
+
+// Read counts a request of a user at now reading their conversations.
+func (d *Detector) Read(userID string, now time.Time) Verdict {
+	if d.policy.ReadsPerMinute <= 0 {
+		return Verdict{}
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	a := d.activity(userID)
+	if now.Sub(a.readsFrom) >= time.Minute {
+		a.readsFrom, a.reads = now, 0
+	}
+	a.reads++
+	if a.reads <= d.policy.ReadsPerMinute {
+		return Verdict{}
+	}
+	a.readsFrom, a.reads = now, 0
+	return d.strike(a, Scraping, now)
+}
+
+// strike throttles a for the signal detected at now.
+func (d *Detector) strike(a *activity, signal string, now time.Time) Verdict {
+	kept := a.strikes[:0]
+	for _, at := range a.strikes {
+		if now.Sub(at) < d.policy.StrikeWindow {
+			kept = append(kept, at)
+		}
+	}
+	a.strikes = append(kept, now)
+	n := len(a.strikes)
+
+	throttle := d.policy.Throttle
+	for i := 1; i < n && (d.policy.MaxThrottle == 0 || throttle < d.policy.MaxThrottle); i++ {
+		throttle *= 2
+	}
+	if d.policy.MaxThrottle > 0 {
+		throttle = min(throttle, d.policy.MaxThrottle)
+	}
+	if until := now.Add(throttle); until.After(a.throttled) {
+		a.throttled = until
+	}
+	return Verdict{
+		Signal:  signal,
+		Strikes: n,
+		Until:   a.throttled,
+		Reauth:  d.policy.ReauthAfter > 0 && n >= d.policy.ReauthAfter,
+	}
+}
+
+// fingerprint hashes a message ignoring case and spacing, so trivially varied copies match.
+func fingerprint(content string) uint64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(strings.ToLower(strings.Join(strings.Fields(content), " "))))
+	return h.Sum64()
+}
@@ -0,0 +1,116 @@
+package abuse
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDetector_Repeated(t *testing.T) {
+	d := New(Defaults())
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	for i := range 3 {
+		if v := d.Message("alice", "Weather in Oslo?", now.Add(time.Duration(i)*time.Second)); v.Signal != "" {
+			t.Fatalf("message %d: got %+v, want nothing detected", i+1, v)
+		}
+	}
+	// Other messages and users don't count.
+	if v := d.Message("alice", "Weather in Bergen?", now); v.Signal != "" {
+		t.Errorf("different message: got %+v", v)
+	}
+	if v := d.Message("bob", "Weather in Oslo?", now); v.Signal != "" {
+		t.Errorf("different user: got %+v", v)
+	}
+
+	v := d.Message("alice", "  weather IN oslo? ", now.Add(5*time.Second))
+	if v.Signal != Repeated || v.Strikes != 1 || v.Reauth || !v.Until.Equal(now.Add(5*time.Second+time.Minute)) {
+		t.Fatalf("4th identical message: got %+v, want a one minute throttle", v)
+	}
+	if until, ok := d.Throttled("alice", now.Add(30*time.Second)); !ok || !until.Equal(v.Until) {
+		t.Errorf("Throttled = %v, %v; want until %v", until, ok, v.Until)
+	}
+	if _, ok := d.Throttled("alice", v.Until); ok {
+		t.Error("still throttled once the throttle ended")
+	}
+	if _, ok := d.Throttled("bob", now); ok {
+		t.Error("bob throttled")
+	}
+
+	// Copies sent further apart than the window are fine.
+	later := now.Add(time.Hour)
+	for i := range 6 {
+		if v := d.Message("bob", "hi", later.Add(time.Duration(i)*30*time.Second)); v.Signal != "" {
+			t.Fatalf("spaced out message %d: got %+v", i+1, v)
+		}
+	}
+}
+
+func TestDetector_LongInput(t *testing.T) {
+	d := New(Policy{MaxInput: 10, Throttle: time.Minute})
+	now := time.Now()
+	if v := d.Message("alice", strings.Repeat("é", 10), now); v.Signal != "" {
+		t.Errorf("message at the limit: got %+v", v)
+	}
+	if v := d.Message("alice", strings.Repeat("a", 11), now); v.Signal != LongInput {
+		t.Errorf("message over the limit: got %+v, want %s", v, LongInput)
+	}
+}
+
+func TestDetector_Scraping(t *testing.T) {
+	d := New(Policy{ReadsPerMinute: 5, Throttle: time.Minute})
+	now := time.Now()
+	for i := range 5 {
+		if v := d.Read("alice", now); v.Signal != "" {
+			t.Fatalf("read %d: got %+v", i+1, v)
+		}
+	}
+	if v := d.Read("alice", now); v.Signal != Scraping {
+		t.Errorf("6th read within a minute: got %+v, want %s", v, Scraping)
+	}
+	if v := d.Read("alice", now.Add(2*time.Minute)); v.Signal != "" {
+		t.Errorf("read a minute later: got %+v", v)
+	}
+}
+
+func TestDetector_Escalates(t *testing.T) {
+	d := New(Policy{ReadsPerMinute: 1, Throttle: time.Minute, MaxThrottle: 3 * time.Minute, StrikeWindow: time.Hour, ReauthAfter: 3})
+	now := time.Now()
+	wants := []struct {
+		throttle time.Duration
+		reauth   bool
+	}{
+		{time.Minute, false},
+		{2 * time.Minute, false},
+		{3 * time.Minute, true},
+	}
+	for i, want := range wants {
+		at := now.Add(time.Duration(i) * 10 * time.Minute)
+		d.Read("alice", at)
+		v := d.Read("alice", at)
+		if v.Strikes != i+1 || !v.Until.Equal(at.Add(want.throttle)) || v.Reauth != want.reauth {
+			t.Errorf("strike %d: got %+v, want a %v throttle, re-auth %v", i+1, v, want.throttle, want.reauth)
+		}
+	}
+
+	// Strikes expire after the window.
+	at := now.Add(3 * time.Hour)
+	d.Read("alice", at)
+	if v := d.Read("alice", at); v.Strikes != 1 || v.Reauth {
+		t.Errorf("strike after the window: got %+v, want the first", v)
+	}
+}
+
+func TestDetector_Block(t *testing.T) {
+	d := New(Policy{RepeatLimit: 1, RepeatWindow: time.Minute, Throttle: time.Minute})
+	now := time.Now()
+	d.Block("alice", now.Add(time.Hour))
+	if until, ok := d.Throttled("alice", now.Add(59*time.Minute)); !ok || !until.Equal(now.Add(time.Hour)) {
+		t.Errorf("Throttled = %v, %v; want blocked for an hour", until, ok)
+	}
+	// A shorter block doesn't shorten a longer one.
+	d.Block("alice", now.Add(time.Minute))
+	if _, ok := d.Throttled("alice", now.Add(30*time.Minute)); !ok {
+		t.Error("expected the longer block to hold")
+	}
+}
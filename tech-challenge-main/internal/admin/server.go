@@ -0,0 +1,277 @@
+// Package admin implements the operator RPCs served on the internal admin port.
+package admin
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/acai-travel/tech-challenge/internal/audit"
+	"github.com/acai-travel/tech-challenge/internal/auth"
+	"github.com/acai-travel/tech-challenge/internal/chat"
+	"github.com/acai-travel/tech-challenge/internal/features"
+	"github.com/acai-travel/tech-challenge/internal/llmdebug"
+	"github.com/acai-travel/tech-challenge/internal/pb"
+	"github.com/acai-travel/tech-challenge/internal/report"
+	"github.com/twitchtv/twirp"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var _ pb.AdminService = (*Server)(nil)
+
+type Server struct {
+	flags   *features.Store
+	events  *audit.Log
+	reports *report.Store
+	traces  *llmdebug.Store
+	chats   map[string]*chat.Server
+}
+
+// NewServer returns the admin server; events may be nil when auditing is disabled, reports
+// when usage reports are, and traces when the debug store is.
+func NewServer(flags *features.Store, events *audit.Log, reports *report.Store, traces *llmdebug.Store) *Server {
+	return &Server{flags: flags, events: events, reports: reports, traces: traces}
+}
+
+// EnableChats lets ReplayConversation and GetIntentStats reach the conversations of the chat
+// servers, by tenant ID. It should be called at startup.
+func (s *Server) EnableChats(chats map[string]*chat.Server) {
+	s.chats = chats
+}
+
+func (s *Server) SetFeatureFlag(ctx context.Context, req *pb.SetFeatureFlagRequest) (*pb.SetFeatureFlagResponse, error) {
+	if req.GetTenantId() == "" {
+		return nil, twirp.RequiredArgumentError("tenant_id")
+	}
+	if req.GetFlag() == "" {
+		return nil, twirp.RequiredArgumentError("flag")
+	}
+
+	flag := features.Flag(req.GetFlag())
+	value := req.GetValue()
+	if value == "" {
+		value = features.Default(flag) // validates the name when removing an override
+	}
+	if err := features.Validate(flag, value); err != nil {
+		return nil, twirp.InvalidArgumentError("flag", err.Error())
+	}
+
+	audit.Detail(ctx, "tenant_id", req.GetTenantId())
+	audit.Detail(ctx, "user_id", req.GetUserId())
+	audit.Detail(ctx, "flag", req.GetFlag())
+	audit.Detail(ctx, "value", req.GetValue())
+
+	if err := s.flags.SetFlag(ctx, req.GetTenantId(), req.GetUserId(), flag, req.GetValue()); err != nil {
+		return nil, twirp.InternalErrorWith(err)
+	}
+
+	return &pb.SetFeatureFlagResponse{}, nil
+}
+
+func (s *Server) ListFeatureFlags(ctx context.Context, req *pb.ListFeatureFlagsRequest) (*pb.ListFeatureFlagsResponse, error) {
+	if req.GetTenantId() == "" {
+		return nil, twirp.RequiredArgumentError("tenant_id")
+	}
+
+	overrides, err := s.flags.List(ctx, req.GetTenantId())
+	if err != nil {
+		return nil, twirp.InternalErrorWith(err)
+	}
+
+	resp := &pb.ListFeatureFlagsResponse{}
+	for _, f := range features.Known() {
+		resp.Flags = append(resp.Flags, &pb.ListFeatureFlagsResponse_Flag{Name: string(f), DefaultValue: features.Default(f)})
+	}
+
+	for _, o := range overrides {
+		flags := make(map[string]string, len(o.Flags))
+		for f, v := range o.Flags {
+			flags[string(f)] = v
+		}
+		resp.Overrides = append(resp.Overrides, &pb.ListFeatureFlagsResponse_Override{
+			UserId:    o.UserID,
+			Flags:     flags,
+			UpdatedAt: timestamppb.New(o.UpdatedAt),
+		})
+	}
+
+	return resp, nil
+}
+
+func (s *Server) ListAuditEvents(ctx context.Context, req *pb.ListAuditEventsRequest) (*pb.ListAuditEventsResponse, error) {
+	if s.events == nil {
+		return nil, twirp.NewError(twirp.Unimplemented, "audit log is disabled")
+	}
+
+	size := int(req.GetPageSize())
+	switch {
+	case size < 0:
+		return nil, twirp.InvalidArgumentError("page_size", "must not be negative")
+	case size == 0:
+		size = 100
+	case size > 1000:
+		size = 1000
+	}
+
+	f := audit.Filter{
+		TenantID:       req.GetTenantId(),
+		UserID:         req.GetUserId(),
+		ConversationID: req.GetConversationId(),
+		Action:         req.GetAction(),
+	}
+	if req.GetSince() != nil {
+		f.Since = req.GetSince().AsTime()
+	}
+	if req.GetUntil() != nil {
+		f.Until = req.GetUntil().AsTime()
+	}
+
+	events, next, err := s.events.List(ctx, f, size, req.GetPageToken())
+	if errors.Is(err, audit.ErrInvalidPageToken) {
+		return nil, twirp.InvalidArgumentError("page_token", err.Error())
+	}
+	if err != nil {
+		return nil, twirp.InternalErrorWith(err)
+	}
+
+	resp := &pb.ListAuditEventsResponse{NextPageToken: next}
+	for _, e := range events {
+		resp.Events = append(resp.Events, e.Proto())
+	}
+	return resp, nil
+}
+
+func (s *Server) GetUsageReport(ctx context.Context, req *pb.GetUsageReportRequest) (*pb.GetUsageReportResponse, error) {
+	if s.reports == nil {
+		return nil, twirp.NewError(twirp.Unimplemented, "usage reports are disabled")
+	}
+
+	var week time.Time
+	if req.GetWeek() != nil {
+		week = req.GetWeek().AsTime()
+	}
+
+	r, err := s.reports.Find(ctx, week)
+	if errors.Is(err, report.ErrNotFound) {
+		return nil, twirp.NotFoundError(err.Error())
+	}
+	if err != nil {
+		return nil, twirp.InternalErrorWith(err)
+	}
+
+	return &pb.GetUsageReportResponse{Report: r.Proto()}, nil
+}
+
+func (s *Server) ListCompletionTraces(ctx context.Context, req *pb.ListCompletionTracesRequest) (*pb.ListCompletionTracesResponse, error) {
+	if s.traces == nil {
+		return nil, twirp.NewError(twirp.Unimplemented, "the debug store is disabled")
+	}
+	if req.GetConversationId() == "" {
+		return nil, twirp.RequiredArgumentError("conversation_id")
+	}
+
+	limit := int(req.GetLimit())
+	switch {
+	case limit < 0:
+		return nil, twirp.InvalidArgumentError("limit", "must not be negative")
+	case limit == 0:
+		limit = 50
+	case limit > 200:
+		limit = 200
+	}
+
+	tenantID := cmp.Or(req.GetTenantId(), auth.DefaultTenant)
+	audit.Detail(ctx, "tenant_id", tenantID)
+	audit.Conversation(ctx, req.GetConversationId())
+	traces, err := s.traces.List(ctx, tenantID, req.GetConversationId(), limit)
+	if err != nil {
+		return nil, twirp.InternalErrorWith(err)
+	}
+
+	resp := &pb.ListCompletionTracesResponse{}
+	for _, t := range traces {
+		resp.Traces = append(resp.Traces, t.Proto())
+	}
+	return resp, nil
+}
+
+func (s *Server) ReplayConversation(ctx context.Context, req *pb.ReplayConversationRequest) (*pb.ReplayConversationResponse, error) {
+	if s.chats == nil {
+		return nil, twirp.NewError(twirp.Unimplemented, "replays are disabled")
+	}
+	tenantID := cmp.Or(req.GetTenantId(), auth.DefaultTenant)
+	server, ok := s.chats[tenantID]
+	if !ok {
+		return nil, twirp.NotFoundError("tenant not found")
+	}
+
+	audit.Detail(ctx, "tenant_id", tenantID)
+	audit.Detail(ctx, "assistant", req.GetAssistant())
+	audit.Detail(ctx, "model", req.GetModel())
+
+	r, err := server.Replay(ctx, chat.ReplayOptions{
+		ConversationID: req.GetConversationId(),
+		MessageID:      req.GetMessageId(),
+		Assistant:      req.GetAssistant(),
+		Prompt:         req.GetPrompt(),
+		Model:          req.GetModel(),
+	})
+	if err != nil {
+		if _, ok := err.(twirp.Error); ok {
+			return nil, err
+		}
+		return nil, twirp.InternalErrorWith(err)
+	}
+
+	resp := &pb.ReplayConversationResponse{
+		MessageId:     r.MessageID.Hex(),
+		OriginalReply: r.Original,
+		Reply:         r.Reply,
+		Diff:          r.Diff,
+		Tokens:        r.Usage.Tokens(),
+		DurationMs:    r.LatencyMs,
+		Error:         r.Error,
+	}
+	for _, t := range r.ToolCalls {
+		resp.ToolCalls = append(resp.ToolCalls, &pb.ReplayConversationResponse_ToolCall{Tool: t.Tool, Call: t.Call, Result: t.Result, Failed: t.Failed})
+	}
+	return resp, nil
+}
+
+func (s *Server) GetIntentStats(ctx context.Context, req *pb.GetIntentStatsRequest) (*pb.GetIntentStatsResponse, error) {
+	if s.chats == nil {
+		return nil, twirp.NewError(twirp.Unimplemented, "intent stats are disabled")
+	}
+	tenantID := cmp.Or(req.GetTenantId(), auth.DefaultTenant)
+	server, ok := s.chats[tenantID]
+	if !ok {
+		return nil, twirp.NotFoundError("tenant not found")
+	}
+	audit.Detail(ctx, "tenant_id", tenantID)
+
+	var since, until time.Time
+	if req.GetSince() != nil {
+		since = req.GetSince().AsTime()
+	}
+	if req.GetUntil() != nil {
+		until = req.GetUntil().AsTime()
+	}
+
+	counts, err := server.IntentStats(ctx, since, until, req.GetAssistant())
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &pb.GetIntentStatsResponse{Unlabeled: counts[""]}
+	for label, n := range counts {
+		if label != "" {
+			resp.Counts = append(resp.Counts, &pb.GetIntentStatsResponse_Count{Intent: label, Count: n})
+		}
+	}
+	slices.SortFunc(resp.Counts, func(a, b *pb.GetIntentStatsResponse_Count) int {
+		return cmp.Or(cmp.Compare(b.GetCount(), a.GetCount()), cmp.Compare(a.GetIntent(), b.GetIntent()))
+	})
+	return resp, nil
+}
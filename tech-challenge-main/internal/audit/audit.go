@@ -0,0 +1,181 @@
+// Package audit records an append-only trail of the calls served, RPCs and HTTP requests:
+// who called what, the conversation affected and the models and tools used to answer.
+package audit
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/acai-travel/tech-challenge/internal/auth"
+	"github.com/acai-travel/tech-challenge/internal/pb"
+	"github.com/twitchtv/twirp"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// StatusOK is the status of calls that succeeded; failed calls have their Twirp error code.
+const StatusOK = "ok"
+
+// Event is one RPC call. Events are only ever inserted, never updated or deleted.
+type Event struct {
+	ID             primitive.ObjectID `bson:"_id" json:"id"`
+	Time           time.Time          `bson:"time" json:"time"`
+	TenantID       string             `bson:"tenant_id" json:"tenant_id"`
+	UserID         string             `bson:"user_id" json:"user_id"`
+	Action         string             `bson:"action" json:"action"`
+	ConversationID string             `bson:"conversation_id,omitempty" json:"conversation_id,omitempty"`
+	Models         []string           `bson:"models,omitempty" json:"models,omitempty"`
+	Tools          []string           `bson:"tools,omitempty" json:"tools,omitempty"`
+	Tokens         int64              `bson:"tokens,omitempty" json:"tokens,omitempty"`
+	Status         string             `bson:"status" json:"status"`
+	DurationMs     int64              `bson:"duration_ms" json:"duration_ms"`
+	Details        map[string]string  `bson:"details,omitempty" json:"details,omitempty"`
+}
+
+func (e *Event) Proto() *pb.AuditEvent {
+	return &pb.AuditEvent{
+		Id:             e.ID.Hex(),
+		Time:           timestamppb.New(e.Time),
+		TenantId:       e.TenantID,
+		UserId:         e.UserID,
+		Action:         e.Action,
+		ConversationId: e.ConversationID,
+		Models:         e.Models,
+		Tools:          e.Tools,
+		Tokens:         e.Tokens,
+		Status:         e.Status,
+		DurationMs:     e.DurationMs,
+		Details:        e.Details,
+	}
+}
+
+// Recorder stores events; *Log is the production implementation.
+type Recorder interface {
+	Record(e *Event)
+}
+
+type entryKey struct{}
+
+// entry is the event of the call in progress, filled in by handlers through the context.
+type entry struct {
+	mu    sync.Mutex
+	event Event
+}
+
+func current(ctx context.Context) *entry {
+	e, _ := ctx.Value(entryKey{}).(*entry)
+	return e
+}
+
+func (e *entry) update(f func(ev *Event)) {
+	if e == nil {
+		return
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	f(&e.event)
+}
+
+// Detach returns ctx outside of the call in progress, for work on the side, e.g. shadow
+// replies, that mustn't be recorded as part of it.
+func Detach(ctx context.Context) context.Context {
+	return context.WithValue(ctx, entryKey{}, (*entry)(nil))
+}
+
+// Conversation records the conversation the call in ctx acts on. Like the other helpers,
+// it does nothing outside an audited call.
+func Conversation(ctx context.Context, id string) {
+	current(ctx).update(func(ev *Event) { ev.ConversationID = id })
+}
+
+// Model records a model used to answer the call in ctx.
+func Model(ctx context.Context, name string) {
+	current(ctx).update(func(ev *Event) {
+		if name != "" && !slices.Contains(ev.Models, name) {
+			ev.Models = append(ev.Models, name)
+		}
+	})
+}
+
+// Tool records a tool run to answer the call in ctx.
+func Tool(ctx context.Context, name string) {
+	current(ctx).update(func(ev *Event) {
+		if !slices.Contains(ev.Tools, name) {
+			ev.Tools = append(ev.Tools, name)
+		}
+	})
+}
+
+// Tokens adds model tokens, prompt and completion, used to answer the call in ctx.
+func Tokens(ctx context.Context, n int64) {
+	current(ctx).update(func(ev *Event) { ev.Tokens += n })
+}
+
+// Failed records that the call in ctx failed with code.
+func Failed(ctx context.Context, code twirp.ErrorCode) {
+	current(ctx).update(func(ev *Event) { ev.Status = string(code) })
+}
+
+// Detail records an action specific detail of the call in ctx, e.g. the flag an admin changed.
+func Detail(ctx context.Context, key, value string) {
+	current(ctx).update(func(ev *Event) {
+		if ev.Details == nil {
+			ev.Details = make(map[string]string)
+		}
+		ev.Details[key] = value
+	})
+}
+
+// Hooks returns Twirp server hooks recording an event per call in r. The caller's tenant
+// and user are taken from the context, so auth.Middleware must run first.
+func Hooks(r Recorder) *twirp.ServerHooks {
+	return &twirp.ServerHooks{
+		RequestRouted: func(ctx context.Context) (context.Context, error) {
+			service, _ := twirp.ServiceName(ctx)
+			method, _ := twirp.MethodName(ctx)
+			return begin(ctx, service+"/"+method), nil
+		},
+		Error: func(ctx context.Context, err twirp.Error) context.Context {
+			Failed(ctx, err.Code())
+			return ctx
+		},
+		ResponseSent: func(ctx context.Context) {
+			if e := current(ctx); e != nil { // nil if not routed, e.g. an unknown method
+				e.record(r)
+			}
+		},
+	}
+}
+
+// begin starts the event of a call to action by the caller in ctx.
+func begin(ctx context.Context, action string) context.Context {
+	return context.WithValue(ctx, entryKey{}, &entry{event: Event{
+		Time:     time.Now().UTC(),
+		TenantID: auth.Tenant(ctx),
+		UserID:   auth.User(ctx),
+		Action:   action,
+		Status:   StatusOK,
+	}})
+}
+
+// record stores the event of the call in r, once it was answered.
+func (e *entry) record(r Recorder) {
+	// Background work may outlive the call and keep updating the entry.
+	e.mu.Lock()
+	ev := e.event
+	ev.Models = slices.Clone(ev.Models)
+	ev.Tools = slices.Clone(ev.Tools)
+	ev.Details = maps.Clone(ev.Details)
+	e.mu.Unlock()
+
+	ev.ID = primitive.NewObjectID()
+	ev.DurationMs = time.Since(ev.Time).Milliseconds()
+	r.Record(&ev)
+}
+
+// ErrInvalidPageToken is returned by List for tokens it didn't issue.
+var ErrInvalidPageToken = errors.New("invalid page token")
@@ -0,0 +1,139 @@
+package audit_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/acai-travel/tech-challenge/internal/admin"
+	"github.com/acai-travel/tech-challenge/internal/audit"
+	"github.com/acai-travel/tech-challenge/internal/auth"
+	"github.com/acai-travel/tech-challenge/internal/pb"
+	"github.com/gorilla/mux"
+	"github.com/twitchtv/twirp"
+)
+
+type recorder struct {
+	mu     sync.Mutex
+	events []*audit.Event
+}
+
+func (r *recorder) Record(e *audit.Event) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, e)
+}
+
+func TestHooks_RecordsCalls(t *testing.T) {
+	rec := &recorder{}
+	server := pb.NewAdminServiceServer(admin.NewServer(nil, nil, nil, nil), twirp.WithServerHooks(audit.Hooks(rec)))
+	srv := httptest.NewServer(auth.Middleware()(server))
+	defer srv.Close()
+
+	client := pb.NewAdminServiceJSONClient(srv.URL, srv.Client())
+	ctx, err := twirp.WithHTTPRequestHeaders(context.Background(), map[string][]string{
+		auth.UserHeader:   {"ops-1"},
+		auth.TenantHeader: {"acme"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The audit log is disabled on this server, so the call fails.
+	if _, err := client.ListAuditEvents(ctx, &pb.ListAuditEventsRequest{}); err == nil {
+		t.Fatal("expected an error with the audit log disabled")
+	}
+
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+	if len(rec.events) != 1 {
+		t.Fatalf("recorded %d events, want 1", len(rec.events))
+	}
+	e := rec.events[0]
+	if e.Action != "AdminService/ListAuditEvents" || e.UserID != "ops-1" || e.TenantID != "acme" {
+		t.Errorf("event = %+v, want the caller and method", e)
+	}
+	if e.Status != string(twirp.Unimplemented) {
+		t.Errorf("status = %q, want %q", e.Status, twirp.Unimplemented)
+	}
+	if e.ID.IsZero() || e.Time.IsZero() {
+		t.Error("event should have an ID and a time")
+	}
+}
+
+func TestHooks_CollectsDetails(t *testing.T) {
+	rec := &recorder{}
+	hooks := audit.Hooks(rec)
+
+	ctx, err := hooks.RequestRouted(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	audit.Conversation(ctx, "c1")
+	audit.Model(ctx, "gpt-4.1")
+	audit.Model(ctx, "gpt-4.1")
+	audit.Model(ctx, "gpt-4o-mini")
+	audit.Tool(ctx, "get_weather")
+	audit.Detail(ctx, "flag", "weather")
+	// Detached work isn't part of the call.
+	audit.Model(audit.Detach(ctx), "o1")
+	hooks.ResponseSent(ctx)
+
+	// Background work outliving the call must not change what was recorded.
+	audit.Tool(ctx, "get_today_date")
+
+	e := rec.events[0]
+	if e.ConversationID != "c1" || e.Status != audit.StatusOK {
+		t.Errorf("event = %+v", e)
+	}
+	if !slices.Equal(e.Models, []string{"gpt-4.1", "gpt-4o-mini"}) {
+		t.Errorf("models = %v, want each model once", e.Models)
+	}
+	if !slices.Equal(e.Tools, []string{"get_weather"}) || e.Details["flag"] != "weather" {
+		t.Errorf("tools = %v, details = %v", e.Tools, e.Details)
+	}
+	if e.UserID != auth.Anonymous || e.TenantID != auth.DefaultTenant {
+		t.Errorf("caller = %s/%s, want the defaults", e.TenantID, e.UserID)
+	}
+}
+
+func TestHelpers_OutsideCall(t *testing.T) {
+	// Code shared with unaudited paths calls the helpers too; they must be no-ops.
+	audit.Conversation(context.Background(), "c1")
+	audit.Model(context.Background(), "gpt-4.1")
+	audit.Detail(context.Background(), "k", "v")
+}
+
+func TestMiddleware_RecordsRequests(t *testing.T) {
+	rec := &recorder{}
+	router := mux.NewRouter()
+	router.Use(audit.Route)
+	router.HandleFunc("/v1/threads/{thread}/runs", func(w http.ResponseWriter, r *http.Request) {
+		audit.Conversation(r.Context(), mux.Vars(r)["thread"])
+		audit.Failed(r.Context(), twirp.ResourceExhausted)
+		w.WriteHeader(http.StatusTooManyRequests)
+	}).Methods(http.MethodPost)
+	handler := auth.Middleware()(audit.Middleware(rec)(router))
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/threads/t1/runs", nil)
+	req.Header.Set(auth.UserHeader, "ana")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/v1/unknown", nil))
+
+	if len(rec.events) != 2 {
+		t.Fatalf("recorded %d events, want 2", len(rec.events))
+	}
+	e := rec.events[0]
+	if e.Action != "POST /v1/threads/{thread}/runs" || e.ConversationID != "t1" || e.UserID != "ana" {
+		t.Errorf("event = %+v, want the route, conversation and caller", e)
+	}
+	if e.Status != string(twirp.ResourceExhausted) {
+		t.Errorf("status = %q, want %q", e.Status, twirp.ResourceExhausted)
+	}
+	if e := rec.events[1]; e.Action != "GET /v1/unknown" {
+		t.Errorf("unrouted action = %q, want the method and path", e.Action)
+	}
+}
@@ -0,0 +1,35 @@
+package audit
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// Middleware returns HTTP middleware recording an event per request in r, for the APIs
+// served outside Twirp. The action is the request's method and path, unless Route names it
+// after the route; handlers report failures with Failed. Like Hooks, it must run after
+// auth.Middleware.
+func Middleware(r Recorder) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			ctx := begin(req.Context(), req.Method+" "+req.URL.Path)
+			next.ServeHTTP(w, req.WithContext(ctx))
+			current(ctx).record(r)
+		})
+	}
+}
+
+// Route is gorilla/mux middleware naming the action of requests after their route, e.g.
+// "POST /v1/threads/{thread}/runs", so that events of a route share it whatever the IDs
+// in the path.
+func Route(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if route := mux.CurrentRoute(req); route != nil {
+			if path, err := route.GetPathTemplate(); err == nil {
+				current(req.Context()).update(func(ev *Event) { ev.Action = req.Method + " " + path })
+			}
+		}
+		next.ServeHTTP(w, req)
+	})
+}
@@ -0,0 +1,182 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const eventCollection = "audit_events"
+
+// Log keeps events in MongoDB. Events are written in the background so auditing doesn't
+// add a write to every call, but never dropped: when the queue is full the caller writes.
+type Log struct {
+	conn  *mongo.Database
+	queue chan *Event
+	once  sync.Once
+}
+
+func NewLog(conn *mongo.Database) *Log {
+	return &Log{conn: conn, queue: make(chan *Event, 1024)}
+}
+
+// Filter selects events; zero fields match every event.
+type Filter struct {
+	TenantID       string
+	UserID         string
+	ConversationID string
+	Action         string
+	Since, Until   time.Time
+}
+
+func (f Filter) query() map[string]any {
+	q := map[string]any{}
+	for field, v := range map[string]string{
+		"tenant_id":       f.TenantID,
+		"user_id":         f.UserID,
+		"conversation_id": f.ConversationID,
+		"action":          f.Action,
+	} {
+		if v != "" {
+			q[field] = v
+		}
+	}
+
+	t := map[string]any{}
+	if !f.Since.IsZero() {
+		t["$gte"] = f.Since
+	}
+	if !f.Until.IsZero() {
+		t["$lt"] = f.Until
+	}
+	if len(t) > 0 {
+		q["time"] = t
+	}
+	return q
+}
+
+// Record stores an event, in the background unless the queue is full.
+func (l *Log) Record(e *Event) {
+	l.once.Do(func() { go l.run() })
+
+	select {
+	case l.queue <- e:
+	default:
+		l.insert(e)
+	}
+}
+
+func (l *Log) run() {
+	for e := range l.queue {
+		l.insert(e)
+	}
+}
+
+func (l *Log) insert(e *Event) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if _, err := l.conn.Collection(eventCollection).InsertOne(ctx, e); err != nil {
+		slog.Error("Failed to record audit event", "action", e.Action, "user_id", e.UserID, "error", err)
+	}
+}
+
+// List returns up to limit events matching f, newest first, and the token of the next page,
+// empty on the last one.
+func (l *Log) List(ctx context.Context, f Filter, limit int, pageToken string) ([]*Event, string, error) {
+	q := f.query()
+	if pageToken != "" {
+		before, err := primitive.ObjectIDFromHex(pageToken)
+		if err != nil {
+			return nil, "", ErrInvalidPageToken
+		}
+		q["_id"] = map[string]any{"$lt": before}
+	}
+
+	cursor, err := l.conn.Collection(eventCollection).Find(ctx, q,
+		options.Find().SetSort(bson.D{{Key: "_id", Value: -1}}).SetLimit(int64(limit)+1))
+	if err != nil {
+		return nil, "", err
+	}
+
+	var events []*Event
+	if err := cursor.All(ctx, &events); err != nil {
+		return nil, "", err
+	}
+
+	var next string
+	if len(events) > limit {
+		events = events[:limit]
+		next = events[limit-1].ID.Hex()
+	}
+	return events, next, nil
+}
+
+// Each calls fn for every event matching f, oldest first, stopping at the first error.
+func (l *Log) Each(ctx context.Context, f Filter, fn func(*Event) error) error {
+	cursor, err := l.conn.Collection(eventCollection).Find(ctx, f.query(),
+		options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}))
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = cursor.Close(ctx)
+	}()
+
+	for cursor.Next(ctx) {
+		var e Event
+		if err := cursor.Decode(&e); err != nil {
+			return err
+		}
+		if err := fn(&e); err != nil {
+			return err
+		}
+	}
+	return cursor.Err()
+}
+
+// ExportHandler streams the events matching the query parameters tenant_id, user_id,
+// conversation_id, action, since and until (RFC 3339) as newline-delimited JSON, oldest
+// first. It must only be served on an internal port.
+func ExportHandler(l *Log) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		f := Filter{
+			TenantID:       q.Get("tenant_id"),
+			UserID:         q.Get("user_id"),
+			ConversationID: q.Get("conversation_id"),
+			Action:         q.Get("action"),
+		}
+		for name, t := range map[string]*time.Time{"since": &f.Since, "until": &f.Until} {
+			if v := q.Get(name); v != "" {
+				var err error
+				if *t, err = time.Parse(time.RFC3339, v); err != nil {
+					http.Error(w, name+" must be an RFC 3339 timestamp", http.StatusBadRequest)
+					return
+				}
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		enc := json.NewEncoder(w)
+		written := 0
+		err := l.Each(r.Context(), f, func(e *Event) error {
+			written++
+			return enc.Encode(e)
+		})
+		if err != nil {
+			slog.ErrorContext(r.Context(), "Audit export failed", "exported", written, "error", err)
+			if written == 0 { // otherwise the status is sent already and the body is truncated
+				http.Error(w, "export failed", http.StatusInternalServerError)
+			}
+		}
+	})
+}
@@ -0,0 +1,126 @@
+// Package auth carries the identity of the caller through request contexts.
+package auth
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+// UserHeader carries the caller's user ID. It is trusted as-is, so the server must sit
+// behind a gateway that authenticates users and sets it.
+const UserHeader = "X-User-ID"
+
+// TenantHeader carries the caller's tenant, trusted like UserHeader.
+const TenantHeader = "X-Tenant-ID"
+
+// PlanHeader carries the billing plan of the caller, e.g. "pro", trusted like UserHeader.
+const PlanHeader = "X-User-Plan"
+
+// DeviceHeader carries an ID the client picks once per device or installation, e.g. a
+// random UUID kept in local storage, so a user's sessions can be told apart.
+const DeviceHeader = "X-Device-ID"
+
+// SessionHeader carries the token of the caller's session, which the server issued when it
+// started, so the session can be revoked.
+const SessionHeader = "X-Session-Token"
+
+// Anonymous is the user ID of requests that don't identify a user.
+const Anonymous = "anonymous"
+
+// DefaultTenant is the tenant of requests that don't identify one.
+const DefaultTenant = "default"
+
+type (
+	userKey    struct{}
+	tenantKey  struct{}
+	deviceKey  struct{}
+	sessionKey struct{}
+	planKey    struct{}
+)
+
+// WithUser returns a context carrying the user ID.
+func WithUser(ctx context.Context, userID string) context.Context {
+	return context.WithValue(ctx, userKey{}, userID)
+}
+
+// User returns the user ID carried by ctx, or Anonymous.
+func User(ctx context.Context) string {
+	if id, ok := ctx.Value(userKey{}).(string); ok && id != "" {
+		return id
+	}
+	return Anonymous
+}
+
+// WithTenant returns a context carrying the tenant ID.
+func WithTenant(ctx context.Context, tenantID string) context.Context {
+	return context.WithValue(ctx, tenantKey{}, tenantID)
+}
+
+// Tenant returns the tenant ID carried by ctx, or DefaultTenant.
+func Tenant(ctx context.Context) string {
+	if id, ok := ctx.Value(tenantKey{}).(string); ok && id != "" {
+		return id
+	}
+	return DefaultTenant
+}
+
+// WithDevice returns a context carrying the device ID.
+func WithDevice(ctx context.Context, deviceID string) context.Context {
+	return context.WithValue(ctx, deviceKey{}, deviceID)
+}
+
+// Device returns the device ID carried by ctx, or "" if the client didn't send one.
+func Device(ctx context.Context) string {
+	id, _ := ctx.Value(deviceKey{}).(string)
+	return id
+}
+
+// WithSession returns a context carrying the session token.
+func WithSession(ctx context.Context, token string) context.Context {
+	return context.WithValue(ctx, sessionKey{}, token)
+}
+
+// Session returns the session token carried by ctx, or "" if the client didn't send one.
+func Session(ctx context.Context) string {
+	token, _ := ctx.Value(sessionKey{}).(string)
+	return token
+}
+
+// WithPlan returns a context carrying the caller's plan.
+func WithPlan(ctx context.Context, plan string) context.Context {
+	return context.WithValue(ctx, planKey{}, plan)
+}
+
+// Plan returns the plan carried by ctx, or "" if the gateway didn't set one.
+func Plan(ctx context.Context) string {
+	plan, _ := ctx.Value(planKey{}).(string)
+	return plan
+}
+
+// Middleware puts the user, tenant and device IDs from UserHeader, TenantHeader and
+// DeviceHeader, the session token from SessionHeader and the plan from PlanHeader, into the
+// request context.
+func Middleware() func(handler http.Handler) http.Handler {
+	return func(handler http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+			if id := strings.TrimSpace(r.Header.Get(UserHeader)); id != "" {
+				ctx = WithUser(ctx, id)
+			}
+			if id := strings.TrimSpace(r.Header.Get(TenantHeader)); id != "" {
+				ctx = WithTenant(ctx, id)
+			}
+			if id := strings.TrimSpace(r.Header.Get(DeviceHeader)); id != "" {
+				ctx = WithDevice(ctx, id)
+			}
+			if token := strings.TrimSpace(r.Header.Get(SessionHeader)); token != "" {
+				ctx = WithSession(ctx, token)
+			}
+			if plan := strings.TrimSpace(r.Header.Get(PlanHeader)); plan != "" {
+				ctx = WithPlan(ctx, plan)
+			}
+			handler.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
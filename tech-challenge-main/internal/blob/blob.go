@@ -0,0 +1,63 @@
+// Package blob stores binary objects, such as attachments, outside the conversation documents.
+package blob
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"sync"
+)
+
+// ErrNotFound is returned when no object is stored under a key.
+var ErrNotFound = errors.New("blob not found")
+
+// Store keeps binary objects by key. Implementations must be safe for concurrent use.
+type Store interface {
+	// Put stores the object read from r under key, replacing any previous one.
+	Put(ctx context.Context, key string, r io.Reader) error
+	// Get opens the object stored under key; the caller must close it.
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete removes the object stored under key. Deleting a missing object is not an error.
+	Delete(ctx context.Context, key string) error
+}
+
+// Memory is an in-process Store, for tests and local development.
+type Memory struct {
+	mu      sync.RWMutex
+	objects map[string][]byte
+}
+
+func NewMemory() *Memory {
+	return &Memory{objects: map[string][]byte{}}
+}
+
+func (m *Memory) Put(ctx context.Context, key string, r io.Reader) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.objects[key] = data
+	return nil
+}
+
+func (m *Memory) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	data, ok := m.objects[key]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+func (m *Memory) Delete(ctx context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.objects, key)
+	return nil
+}
@@ -0,0 +1,44 @@
+package blob
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestMemory(t *testing.T) {
+	ctx := context.Background()
+	m := NewMemory()
+
+	if _, err := m.Get(ctx, "a"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+
+	for _, v := range []string{"first", "second"} {
+		if err := m.Put(ctx, "a", strings.NewReader(v)); err != nil {
+			t.Fatalf("Put error: %v", err)
+		}
+	}
+
+	r, err := m.Get(ctx, "a")
+	if err != nil {
+		t.Fatalf("Get error: %v", err)
+	}
+	data, _ := io.ReadAll(r)
+	_ = r.Close()
+	if string(data) != "second" {
+		t.Fatalf("expected the last put to win, got %q", data)
+	}
+
+	if err := m.Delete(ctx, "a"); err != nil {
+		t.Fatalf("Delete error: %v", err)
+	}
+	if err := m.Delete(ctx, "a"); err != nil {
+		t.Fatalf("deleting a missing object should not fail: %v", err)
+	}
+	if _, err := m.Get(ctx, "a"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound after delete, got %v", err)
+	}
+}
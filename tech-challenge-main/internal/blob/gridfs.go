@@ -0,0 +1,80 @@
+package blob
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/gridfs"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// GridFS stores objects in a MongoDB GridFS bucket, keyed by file ID.
+type GridFS struct {
+	db   *mongo.Database
+	name string
+}
+
+// NewGridFS returns a store using the GridFS bucket name of db.
+func NewGridFS(db *mongo.Database, name string) *GridFS {
+	return &GridFS{db: db, name: name}
+}
+
+// bucket opens the bucket with the deadline of ctx. Deadlines are per bucket in the driver,
+// so each operation gets its own; opening one is cheap.
+func (g *GridFS) bucket(ctx context.Context, write bool) (*gridfs.Bucket, error) {
+	b, err := gridfs.NewBucket(g.db, options.GridFSBucket().SetName(g.name))
+	if err != nil {
+		return nil, err
+	}
+
+	if dl, ok := ctx.Deadline(); ok {
+		if write {
+			err = b.SetWriteDeadline(dl)
+		} else {
+			err = b.SetReadDeadline(dl)
+		}
+	}
+	return b, err
+}
+
+func (g *GridFS) Put(ctx context.Context, key string, r io.Reader) error {
+	if err := g.Delete(ctx, key); err != nil {
+		return err
+	}
+
+	b, err := g.bucket(ctx, true)
+	if err != nil {
+		return err
+	}
+	return b.UploadFromStreamWithID(key, key, r)
+}
+
+func (g *GridFS) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	b, err := g.bucket(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := b.OpenDownloadStream(key)
+	if errors.Is(err, gridfs.ErrFileNotFound) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (g *GridFS) Delete(ctx context.Context, key string) error {
+	b, err := g.bucket(ctx, true)
+	if err != nil {
+		return err
+	}
+
+	if err := b.DeleteContext(ctx, key); err != nil && !errors.Is(err, gridfs.ErrFileNotFound) {
+		return err
+	}
+	return nil
+}
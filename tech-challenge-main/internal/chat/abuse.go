@@ -0,0 +1,221 @@
+package chat
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/acai-travel/tech-challenge/internal/abuse"
+	"github.com/acai-travel/tech-challenge/internal/audit"
+	"github.com/acai-travel/tech-challenge/internal/auth"
+	"github.com/acai-travel/tech-challenge/internal/chat/model"
+	"github.com/twitchtv/twirp"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// abuseGuard applies an abuse.Policy to the requests of identified users.
+type abuseGuard struct {
+	policy   abuse.Policy
+	detector *abuse.Detector
+	events   audit.Recorder
+}
+
+// channelBlock is how long email and WhatsApp senders are blocked once they'd have to
+// authenticate again, which they can't.
+const channelBlock = 24 * time.Hour
+
+// readMethods start the names of the RPCs counted as reads by DetectAbuse.
+var readMethods = []string{"List", "Describe", "Search", "Export", "Get"}
+
+// EnableAbuseDetection throttles identified users sending the same message over and over,
+// extremely long messages or reading conversations faster than people do, as p tunes, and
+// revokes the session of those who keep at it so they have to authenticate again, or blocks
+// them on channels without sessions. Each detection is recorded in events, if not nil.
+func (s *Server) EnableAbuseDetection(p abuse.Policy, events audit.Recorder) {
+	s.abuse = &abuseGuard{policy: p, detector: abuse.New(p), events: events}
+}
+
+// DetectAbuse rejects the requests of throttled users and counts the reads of the others.
+// TrackSessions must run first, so the session abuse revokes is known.
+func (s *Server) DetectAbuse(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		userID := auth.User(ctx)
+		if s.abuse == nil || userID == auth.Anonymous {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		now := time.Now()
+		if until, ok := s.abuse.detector.Throttled(userID, now); ok {
+			writeAbuseError(w, throttledError(until, now))
+			return
+		}
+		if isRead(r) {
+			if v := s.abuse.detector.Read(userID, now); v.Signal != "" {
+				writeAbuseError(w, s.actOnAbuse(ctx, v, throttledError(v.Until, now), r.URL.Path))
+				return
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// checkAbuse refuses a message of a throttled user, or one that makes them so. The APIs and
+// channels check it before checkQuota, so refused messages count against no quota.
+func (s *Server) checkAbuse(ctx context.Context, content string) error {
+	userID := auth.User(ctx)
+	if s.abuse == nil || userID == auth.Anonymous {
+		return nil
+	}
+
+	now := time.Now()
+	if until, ok := s.abuse.detector.Throttled(userID, now); ok {
+		return throttledError(until, now)
+	}
+	v := s.abuse.detector.Message(userID, content, now)
+	if v.Signal == "" {
+		return nil
+	}
+
+	refusal := throttledError(v.Until, now)
+	if v.Signal == abuse.LongInput {
+		refusal = twirp.InvalidArgumentError("message", fmt.Sprintf("must be at most %d characters", s.abuse.policy.MaxInput))
+	}
+	method, _ := twirp.MethodName(ctx)
+	return s.actOnAbuse(ctx, v, refusal, method)
+}
+
+// actOnAbuse records a detection and returns the error refusing the request: refusal, an
+// Unauthenticated error once the caller's session had to be revoked, or a PermissionDenied
+// error once a channel sender, who has no session, had to be blocked.
+func (s *Server) actOnAbuse(ctx context.Context, v abuse.Verdict, refusal twirp.Error, request string) twirp.Error {
+	err := refusal.WithMeta("abuse", v.Signal)
+	var revoked *model.Session
+	var blockedUntil time.Time
+	if v.Reauth && auth.Session(ctx) == "" {
+		blockedUntil = time.Now().Add(channelBlock)
+		s.abuse.detector.Block(auth.User(ctx), blockedUntil)
+		err = twirp.NewError(twirp.PermissionDenied, "blocked after suspicious activity").WithMeta("abuse", v.Signal)
+	} else if v.Reauth {
+		var rerr error
+		if revoked, rerr = s.revokeAbusiveSession(ctx, time.Now()); rerr != nil {
+			// Still throttled, so the session can be revoked at the next strike.
+			slog.ErrorContext(ctx, "Failed to revoke an abusive session", "error", rerr)
+		}
+		if revoked != nil {
+			err = twirp.NewError(twirp.Unauthenticated, "session revoked after suspicious activity, sign in again").
+				WithMeta("abuse", v.Signal)
+		}
+	}
+
+	slog.WarnContext(ctx, "Abuse detected", "signal", v.Signal, "strikes", v.Strikes, "status", err.Code())
+	audit.Detail(ctx, "abuse", v.Signal)
+	if s.abuse.events == nil {
+		return err
+	}
+	details := map[string]string{
+		"strikes":         strconv.Itoa(v.Strikes),
+		"throttled_until": v.Until.UTC().Format(time.RFC3339),
+	}
+	if request != "" {
+		details["request"] = request
+	}
+	if device := auth.Device(ctx); device != "" {
+		details["device_id"] = device
+	}
+	if revoked != nil {
+		details["session_id"] = revoked.ID.Hex()
+	}
+	if !blockedUntil.IsZero() {
+		details["blocked_until"] = blockedUntil.UTC().Format(time.RFC3339)
+	}
+	s.abuse.events.Record(&audit.Event{
+		ID:       primitive.NewObjectID(),
+		Time:     time.Now().UTC(),
+		TenantID: auth.Tenant(ctx),
+		UserID:   auth.User(ctx),
+		Action:   "abuse/" + v.Signal,
+		Status:   string(err.Code()),
+		Details:  details,
+	})
+	return err
+}
+
+// revokeAbusiveSession revokes the caller's session. It returns nil when there is none to
+// revoke.
+func (s *Server) revokeAbusiveSession(ctx context.Context, now time.Time) (*model.Session, error) {
+	userID, token := auth.User(ctx), auth.Session(ctx)
+	if s.repo == nil || token == "" {
+		return nil, nil
+	}
+
+	tokenHash := hashSessionToken(token)
+	key := sessionKey(userID, tokenHash)
+	sess, ok := s.sessions.Get(key)
+	if !ok {
+		// Evicted from the cache since TrackSessions saved it.
+		sessions, err := s.repo.ListSessions(ctx, userID, false)
+		if err != nil {
+			return nil, err
+		}
+		i := slices.IndexFunc(sessions, func(sess *model.Session) bool { return sess.TokenHash == tokenHash })
+		if i < 0 {
+			return nil, nil
+		}
+		sess = sessions[i]
+	}
+	revoked, err := s.repo.RevokeSession(ctx, sess.ID.Hex(), userID, now)
+	if err != nil {
+		return nil, err
+	}
+	s.sessions.Add(key, revoked)
+	return revoked, nil
+}
+
+// abuseNotice returns the reply to users of channels refused by checkAbuse, or false if they
+// get none: throttled and blocked senders aren't answered, so they get nothing out of it.
+func abuseNotice(err error) (string, bool) {
+	var te twirp.Error
+	if errors.As(err, &te) && te.Code() == twirp.InvalidArgument {
+		return "Sorry, your message is too long. Please send a shorter one.", true
+	}
+	return "", false
+}
+
+func throttledError(until, now time.Time) twirp.Error {
+	wait := (until.Sub(now) + time.Second - 1).Truncate(time.Second)
+	return twirp.NewError(twirp.ResourceExhausted, fmt.Sprintf("too many suspicious requests, try again in %s", wait)).
+		WithMeta("retry_after", strconv.Itoa(int(wait.Seconds())))
+}
+
+func writeAbuseError(w http.ResponseWriter, err twirp.Error) {
+	if after := err.Meta("retry_after"); after != "" {
+		w.Header().Set("Retry-After", after)
+	}
+	_ = twirp.WriteError(w, err)
+}
+
+// isRead reports whether r reads conversations: GET requests and the RPCs of readMethods.
+func isRead(r *http.Request) bool {
+	if r.Method == http.MethodGet {
+		return true
+	}
+	if !strings.HasPrefix(r.URL.Path, "/twirp/") {
+		return false
+	}
+	method := path.Base(r.URL.Path)
+	for _, prefix := range readMethods {
+		if strings.HasPrefix(method, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,170 @@
+package chat
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/acai-travel/tech-challenge/internal/abuse"
+	"github.com/acai-travel/tech-challenge/internal/audit"
+	"github.com/acai-travel/tech-challenge/internal/auth"
+	. "github.com/acai-travel/tech-challenge/internal/chat/testing"
+	"github.com/acai-travel/tech-challenge/internal/pb"
+	"github.com/twitchtv/twirp"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type eventRecorder struct {
+	mu     sync.Mutex
+	events []*audit.Event
+}
+
+func (r *eventRecorder) Record(e *audit.Event) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, e)
+}
+
+// abuseRequest sends a request for the RPC method as user through DetectAbuse, and returns
+// the response.
+func abuseRequest(srv *Server, method, user string) *httptest.ResponseRecorder {
+	h := auth.Middleware()(srv.DetectAbuse(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})))
+	req := httptest.NewRequest(http.MethodPost, "/twirp/acai.chat.ChatService/"+method, nil)
+	if user != "" {
+		req.Header.Set(auth.UserHeader, user)
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestDetectAbuse_Scraping(t *testing.T) {
+	srv := NewServer(nil, &fakeAssistant{})
+	events := &eventRecorder{}
+	srv.EnableAbuseDetection(abuse.Policy{ReadsPerMinute: 2, Throttle: time.Minute}, events)
+
+	for i := range 2 {
+		if rec := abuseRequest(srv, "DescribeConversation", "alice"); rec.Code != http.StatusNoContent {
+			t.Fatalf("read %d: got status %d", i+1, rec.Code)
+		}
+	}
+	// Writes aren't reads.
+	if rec := abuseRequest(srv, "DeleteConversation", "alice"); rec.Code != http.StatusNoContent {
+		t.Fatalf("write: got status %d", rec.Code)
+	}
+	rec := abuseRequest(srv, "ListConversations", "alice")
+	if rec.Code != http.StatusTooManyRequests || rec.Header().Get("Retry-After") != "60" {
+		t.Fatalf("3rd read: got status %d, Retry-After %q; want 429 for a minute", rec.Code, rec.Header().Get("Retry-After"))
+	}
+	// Throttled users can't do anything else either, unlike other users.
+	if rec := abuseRequest(srv, "StartConversation", "alice"); rec.Code != http.StatusTooManyRequests {
+		t.Errorf("throttled write: got status %d", rec.Code)
+	}
+	if rec := abuseRequest(srv, "ListConversations", "bob"); rec.Code != http.StatusNoContent {
+		t.Errorf("another user: got status %d", rec.Code)
+	}
+	for range 5 {
+		if rec := abuseRequest(srv, "ListConversations", ""); rec.Code != http.StatusNoContent {
+			t.Fatalf("anonymous read: got status %d", rec.Code)
+		}
+	}
+
+	if len(events.events) != 1 {
+		t.Fatalf("recorded %d events, want 1", len(events.events))
+	}
+	ev := events.events[0]
+	if ev.UserID != "alice" || ev.Action != "abuse/"+abuse.Scraping || ev.Status != string(twirp.ResourceExhausted) ||
+		ev.Details["request"] != "/twirp/acai.chat.ChatService/ListConversations" || ev.Details["strikes"] != "1" {
+		t.Errorf("recorded %+v", ev)
+	}
+}
+
+func TestCheckAbuse(t *testing.T) {
+	srv := NewServer(nil, &fakeAssistant{})
+	if err := srv.checkAbuse(auth.WithUser(context.Background(), "alice"), "hi"); err != nil {
+		t.Errorf("checkAbuse before EnableAbuseDetection: %v", err)
+	}
+	srv.EnableAbuseDetection(abuse.Policy{RepeatLimit: 2, RepeatWindow: time.Minute, MaxInput: 100, Throttle: time.Minute}, nil)
+
+	alice := auth.WithUser(context.Background(), "alice")
+	for i := range 2 {
+		if err := srv.checkAbuse(alice, "Weather in Oslo?"); err != nil {
+			t.Fatalf("message %d: %v", i+1, err)
+		}
+	}
+	err := srv.checkAbuse(alice, "Weather in Oslo?")
+	if twirpCode(err) != twirp.ResourceExhausted || err.(twirp.Error).Meta("abuse") != abuse.Repeated {
+		t.Fatalf("3rd identical message: expected ResourceExhausted for repeats, got %v", err)
+	}
+	if err := srv.checkAbuse(alice, "Weather in Bergen?"); twirpCode(err) != twirp.ResourceExhausted {
+		t.Errorf("message while throttled: expected ResourceExhausted, got %v", err)
+	}
+
+	bob := auth.WithUser(context.Background(), "bob")
+	if err := srv.checkAbuse(bob, strings.Repeat("a", 101)); twirpCode(err) != twirp.InvalidArgument {
+		t.Errorf("long message: expected InvalidArgument, got %v", err)
+	}
+	if err := srv.checkAbuse(context.Background(), strings.Repeat("a", 101)); err != nil {
+		t.Errorf("anonymous long message: %v", err)
+	}
+}
+
+func TestCheckAbuse_ChannelSender(t *testing.T) {
+	srv := NewServer(nil, &fakeAssistant{})
+	events := &eventRecorder{}
+	srv.EnableAbuseDetection(abuse.Policy{RepeatLimit: 1, RepeatWindow: time.Minute, Throttle: time.Millisecond, ReauthAfter: 1}, events)
+
+	// Channel senders have no session, so they are blocked instead.
+	sender := auth.WithUser(context.Background(), whatsAppUser("34600000000"))
+	if err := srv.checkAbuse(sender, "Hi"); err != nil {
+		t.Fatal(err)
+	}
+	err := srv.checkAbuse(sender, "Hi")
+	if twirpCode(err) != twirp.PermissionDenied {
+		t.Fatalf("repeated message: expected PermissionDenied, got %v", err)
+	}
+	if _, ok := abuseNotice(err); ok {
+		t.Error("expected blocked senders not to be answered")
+	}
+	time.Sleep(10 * time.Millisecond)
+	if err := srv.checkAbuse(sender, "Another message"); twirpCode(err) != twirp.ResourceExhausted {
+		t.Errorf("message after the throttle: expected the sender still blocked, got %v", err)
+	}
+	if len(events.events) != 1 || events.events[0].Details["blocked_until"] == "" {
+		t.Errorf("recorded %+v, want the block", events.events)
+	}
+}
+
+func TestAbuse(t *testing.T) {
+	t.Run("requires re-authentication after repeated strikes", WithFixture(func(t *testing.T, f *Fixture) {
+		srv := NewServer(f.Repository, &fakeAssistant{})
+		events := &eventRecorder{}
+		srv.EnableAbuseDetection(abuse.Policy{ReadsPerMinute: 1, Throttle: time.Second, ReauthAfter: 1}, events)
+		user := "alice-" + primitive.NewObjectID().Hex()
+
+		laptop := startSession(t, srv, user, "laptop")
+		sessionRequest(srv, "ListConversations", user, laptop)
+		if code := sessionRequest(srv, "ListConversations", user, laptop); code != http.StatusUnauthorized {
+			t.Fatalf("scraping: got status %d, want 401", code)
+		}
+		if len(events.events) != 1 || events.events[0].Details["session_id"] == "" {
+			t.Errorf("recorded %+v, want the revoked session", events.events)
+		}
+
+		time.Sleep(time.Second)
+		if code := sessionRequest(srv, "StartConversation", user, laptop); code != http.StatusUnauthorized {
+			t.Errorf("revoked session: got status %d", code)
+		}
+		ctx := auth.WithUser(context.Background(), user)
+		list, err := srv.ListSessions(ctx, &pb.ListSessionsRequest{IncludeRevoked: true})
+		if err != nil || len(list.GetSessions()) != 1 || list.GetSessions()[0].GetRevokedAt() == nil {
+			t.Errorf("ListSessions = %v, %v; want the laptop revoked", list, err)
+		}
+	}))
+}
@@ -0,0 +1,169 @@
+package chat
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/acai-travel/tech-challenge/internal/auth"
+	"github.com/acai-travel/tech-challenge/internal/chat/model"
+	"github.com/acai-travel/tech-challenge/internal/pb"
+	"github.com/twitchtv/twirp"
+)
+
+const (
+	// maxLocationAliases bounds how many places a user may save.
+	maxLocationAliases = 50
+	// maxAliasNameLength and maxAliasLabelLength bound the alias fields, in characters.
+	maxAliasNameLength  = 64
+	maxAliasLabelLength = 200
+)
+
+// profileLoader looks up the saved places and preferences of a user for the assistant's
+// tools. The profile is loaded on first use, so replies that don't need it don't pay for it.
+type profileLoader struct {
+	repo   *model.Repository
+	userID string
+	// caldav opens the user's CalDAV password; nil unless CalDAV is enabled.
+	caldav *credentialBox
+
+	mu      sync.Mutex
+	profile *model.UserProfile
+}
+
+func (r *profileLoader) load(ctx context.Context) (*model.UserProfile, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.profile == nil {
+		p, err := r.repo.FindUserProfile(ctx, r.userID)
+		if err != nil {
+			return nil, err
+		}
+		r.profile = p
+	}
+	return r.profile, nil
+}
+
+func (r *profileLoader) ResolveAlias(ctx context.Context, name string) (*model.LocationAlias, error) {
+	p, err := r.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return p.Alias(name), nil
+}
+
+func (r *profileLoader) HealthAdvisories(ctx context.Context) (bool, error) {
+	p, err := r.load(ctx)
+	if err != nil {
+		return false, err
+	}
+	return p.Preferences.HealthAdvisoriesEnabled(), nil
+}
+
+// Timezone is the user's time zone preference or, without one, that of their quiet hours.
+func (r *profileLoader) Timezone(ctx context.Context) (string, error) {
+	p, err := r.load(ctx)
+	if err != nil {
+		return "", err
+	}
+	if tz := p.Preferences.Timezone; tz != nil && *tz != "" {
+		return *tz, nil
+	}
+	if n := p.Notifications; n != nil && n.QuietHours != nil {
+		return n.QuietHours.Timezone, nil
+	}
+	return "", nil
+}
+
+// requireUser returns the caller's user ID, rejecting anonymous callers, whose saved data
+// would be shared by everyone.
+func requireUser(ctx context.Context) (string, error) {
+	userID := auth.User(ctx)
+	if userID == auth.Anonymous {
+		return "", twirp.NewError(twirp.Unauthenticated, "saving places and calendars requires an identified user")
+	}
+	return userID, nil
+}
+
+// validateLocationAlias checks an alias from a request and returns it with its name normalized.
+func validateLocationAlias(in *pb.LocationAlias) (model.LocationAlias, error) {
+	if in == nil {
+		return model.LocationAlias{}, twirp.RequiredArgumentError("alias")
+	}
+
+	alias := model.LocationAlias{
+		Name:  model.NormalizeAliasName(in.GetName()),
+		Lat:   in.GetLatitude(),
+		Lon:   in.GetLongitude(),
+		Label: strings.TrimSpace(in.GetLabel()),
+	}
+
+	switch {
+	case alias.Name == "":
+		return alias, twirp.RequiredArgumentError("alias.name")
+	case utf8.RuneCountInString(alias.Name) > maxAliasNameLength:
+		return alias, twirp.InvalidArgumentError("alias.name", fmt.Sprintf("must be at most %d characters", maxAliasNameLength))
+	case utf8.RuneCountInString(alias.Label) > maxAliasLabelLength:
+		return alias, twirp.InvalidArgumentError("alias.label", fmt.Sprintf("must be at most %d characters", maxAliasLabelLength))
+	case math.IsNaN(alias.Lat) || alias.Lat < -90 || alias.Lat > 90:
+		return alias, twirp.InvalidArgumentError("alias.latitude", "must be between -90 and 90")
+	case math.IsNaN(alias.Lon) || alias.Lon < -180 || alias.Lon > 180:
+		return alias, twirp.InvalidArgumentError("alias.longitude", "must be between -180 and 180")
+	}
+	return alias, nil
+}
+
+func (s *Server) SetLocationAlias(ctx context.Context, req *pb.SetLocationAliasRequest) (*pb.SetLocationAliasResponse, error) {
+	alias, err := validateLocationAlias(req.GetAlias())
+	if err != nil {
+		return nil, err
+	}
+	userID, err := requireUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ok, err := s.repo.SetLocationAlias(ctx, userID, alias, maxLocationAliases)
+	if err != nil {
+		return nil, twirp.InternalErrorWith(err)
+	}
+	if !ok {
+		return nil, twirp.NewError(twirp.ResourceExhausted, fmt.Sprintf("at most %d places can be saved", maxLocationAliases))
+	}
+
+	return &pb.SetLocationAliasResponse{Alias: alias.Proto()}, nil
+}
+
+func (s *Server) DeleteLocationAlias(ctx context.Context, req *pb.DeleteLocationAliasRequest) (*pb.DeleteLocationAliasResponse, error) {
+	name := model.NormalizeAliasName(req.GetName())
+	if name == "" {
+		return nil, twirp.RequiredArgumentError("name")
+	}
+	userID, err := requireUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.DeleteLocationAlias(ctx, userID, name); err != nil {
+		return nil, err
+	}
+
+	return &pb.DeleteLocationAliasResponse{}, nil
+}
+
+func (s *Server) ListLocationAliases(ctx context.Context, _ *pb.ListLocationAliasesRequest) (*pb.ListLocationAliasesResponse, error) {
+	p, err := s.repo.FindUserProfile(ctx, auth.User(ctx))
+	if err != nil {
+		return nil, twirp.InternalErrorWith(err)
+	}
+
+	resp := &pb.ListLocationAliasesResponse{}
+	for i := range p.Aliases {
+		resp.Aliases = append(resp.Aliases, p.Aliases[i].Proto())
+	}
+	return resp, nil
+}
@@ -0,0 +1,95 @@
+package chat
+
+import (
+	"context"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/acai-travel/tech-challenge/internal/auth"
+	"github.com/acai-travel/tech-challenge/internal/chat/model"
+	. "github.com/acai-travel/tech-challenge/internal/chat/testing"
+	"github.com/acai-travel/tech-challenge/internal/pb"
+	"github.com/twitchtv/twirp"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestValidateLocationAlias(t *testing.T) {
+	got, err := validateLocationAlias(&pb.LocationAlias{Name: "  My   Home ", Latitude: 41.4, Longitude: 2.17, Label: " Barcelona "})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := (model.LocationAlias{Name: "home", Lat: 41.4, Lon: 2.17, Label: "Barcelona"}); got != want {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+
+	for name, in := range map[string]*pb.LocationAlias{
+		"missing":        nil,
+		"empty name":     {Name: "   "},
+		"long name":      {Name: strings.Repeat("a", maxAliasNameLength+1)},
+		"long label":     {Name: "home", Label: strings.Repeat("a", maxAliasLabelLength+1)},
+		"latitude":       {Name: "home", Latitude: 91},
+		"longitude":      {Name: "home", Longitude: -180.5},
+		"NaN coordinate": {Name: "home", Latitude: math.NaN()},
+	} {
+		if _, err := validateLocationAlias(in); err == nil {
+			t.Errorf("%s: expected an error", name)
+		} else if te, ok := err.(twirp.Error); !ok || te.Code() != twirp.InvalidArgument {
+			t.Errorf("%s: expected InvalidArgument, got %v", name, err)
+		}
+	}
+}
+
+func TestServer_LocationAliases(t *testing.T) {
+	srv := NewServer(model.New(ConnectMongo()), nil)
+	alice := auth.WithUser(context.Background(), "alice-"+primitive.NewObjectID().Hex())
+
+	t.Run("anonymous callers can't save places", func(t *testing.T) {
+		_, err := srv.SetLocationAlias(context.Background(), &pb.SetLocationAliasRequest{Alias: &pb.LocationAlias{Name: "home"}})
+		if te, ok := err.(twirp.Error); !ok || te.Code() != twirp.Unauthenticated {
+			t.Fatalf("expected Unauthenticated, got %v", err)
+		}
+	})
+
+	t.Run("set, replace, resolve and delete", WithFixture(func(t *testing.T, f *Fixture) {
+		for _, a := range []*pb.LocationAlias{
+			{Name: "Home", Latitude: 1, Longitude: 1},
+			{Name: "the office", Latitude: 2, Longitude: 2},
+			{Name: "my home", Latitude: 41.4, Longitude: 2.17, Label: "Barcelona"},
+		} {
+			if _, err := srv.SetLocationAlias(alice, &pb.SetLocationAliasRequest{Alias: a}); err != nil {
+				t.Fatalf("SetLocationAlias(%v) error: %v", a, err)
+			}
+		}
+
+		out, err := srv.ListLocationAliases(alice, &pb.ListLocationAliasesRequest{})
+		if err != nil {
+			t.Fatalf("ListLocationAliases error: %v", err)
+		}
+		if len(out.GetAliases()) != 2 {
+			t.Fatalf("expected 2 aliases, got %v", out.GetAliases())
+		}
+
+		r := &profileLoader{repo: srv.repo, userID: auth.User(alice)}
+		home, err := r.ResolveAlias(alice, "HOME")
+		if err != nil || home == nil || home.Label != "Barcelona" {
+			t.Fatalf("expected the replaced home alias, got %+v, %v", home, err)
+		}
+		if a, _ := r.ResolveAlias(alice, "Madrid"); a != nil {
+			t.Errorf("expected no alias for Madrid, got %+v", a)
+		}
+
+		if _, err := srv.DeleteLocationAlias(alice, &pb.DeleteLocationAliasRequest{Name: "Office"}); err != nil {
+			t.Fatalf("DeleteLocationAlias error: %v", err)
+		}
+		_, err = srv.DeleteLocationAlias(alice, &pb.DeleteLocationAliasRequest{Name: "office"})
+		if te, ok := err.(twirp.Error); !ok || te.Code() != twirp.NotFound {
+			t.Fatalf("expected NotFound deleting twice, got %v", err)
+		}
+
+		bob := auth.WithUser(context.Background(), "bob-"+primitive.NewObjectID().Hex())
+		if out, err := srv.ListLocationAliases(bob, &pb.ListLocationAliasesRequest{}); err != nil || len(out.GetAliases()) != 0 {
+			t.Errorf("expected no aliases for another user, got %v, %v", out.GetAliases(), err)
+		}
+	}))
+}
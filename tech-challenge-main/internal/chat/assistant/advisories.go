@@ -0,0 +1,147 @@
+package assistant
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+)
+
+// PreferenceSource looks up the preferences of the user being answered.
+type PreferenceSource interface {
+	// HealthAdvisories reports whether weather answers should add UV and pollen advisories.
+	HealthAdvisories(ctx context.Context) (bool, error)
+	// Timezone returns the IANA name of the user's time zone, or "" if it isn't known.
+	Timezone(ctx context.Context) (string, error)
+}
+
+type preferenceSourceKey struct{}
+
+// WithPreferences attaches the user's preferences to the context, for the tools that
+// follow them.
+func WithPreferences(ctx context.Context, p PreferenceSource) context.Context {
+	return context.WithValue(ctx, preferenceSourceKey{}, p)
+}
+
+// healthAdvisories reports whether the user wants health advisories with the weather. They
+// are on unless the user turned them off, including when the preference can't be read.
+func healthAdvisories(ctx context.Context) bool {
+	p, _ := ctx.Value(preferenceSourceKey{}).(PreferenceSource)
+	if p == nil {
+		return true
+	}
+	ok, err := p.HealthAdvisories(ctx)
+	if err != nil {
+		slog.WarnContext(ctx, "Failed to read health advisory preference", "error", err)
+		return true
+	}
+	return ok
+}
+
+// userLocation returns the user's time zone, or UTC if it isn't known.
+func userLocation(ctx context.Context) *time.Location {
+	p, _ := ctx.Value(preferenceSourceKey{}).(PreferenceSource)
+	if p == nil {
+		return time.UTC
+	}
+	name, err := p.Timezone(ctx)
+	if err != nil {
+		slog.WarnContext(ctx, "Failed to read the user's time zone", "error", err)
+		return time.UTC
+	}
+	if name == "" {
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		slog.WarnContext(ctx, "Unknown time zone of the user", "timezone", name, "error", err)
+		return time.UTC
+	}
+	return loc
+}
+
+// uvCategory names a UV index on the WHO scale.
+func uvCategory(uv float64) string {
+	switch {
+	case uv < 3:
+		return "low"
+	case uv < 6:
+		return "moderate"
+	case uv < 8:
+		return "high"
+	case uv < 11:
+		return "very high"
+	default:
+		return "extreme"
+	}
+}
+
+// pollenPlant is a plant WeatherAPI reports pollen for, with the counts in grains/m³ from
+// which its level is moderate, high and very high.
+type pollenPlant struct {
+	name                     string
+	moderate, high, veryHigh float64
+}
+
+// pollenPlants lists the plants in report order, with the thresholds of the National
+// Allergy Bureau for trees, grasses and weeds.
+var pollenPlants = []pollenPlant{
+	{"Hazel", 15, 90, 1500},
+	{"Alder", 15, 90, 1500},
+	{"Birch", 15, 90, 1500},
+	{"Oak", 15, 90, 1500},
+	{"Grass", 5, 20, 200},
+	{"Mugwort", 10, 50, 500},
+	{"Ragweed", 10, 50, 500},
+}
+
+func (p pollenPlant) level(count float64) string {
+	switch {
+	case count >= p.veryHigh:
+		return "very high"
+	case count >= p.high:
+		return "high"
+	case count >= p.moderate:
+		return "moderate"
+	default:
+		return "low"
+	}
+}
+
+// formatPollen lists the pollen counts present, e.g. "Grass 25 (high), Birch 3 (low)", or
+// returns "" when the plan reports no pollen.
+func formatPollen(pollen map[string]float64) string {
+	var parts []string
+	for _, p := range pollenPlants {
+		if count, ok := pollen[p.name]; ok && count > 0 {
+			parts = append(parts, fmt.Sprintf("%s %.0f (%s)", p.name, count, p.level(count)))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// healthAdvisory returns a one-line advisory when the UV index or a pollen count is high,
+// or "" when neither is.
+func healthAdvisory(uv float64, pollen map[string]float64) string {
+	var parts []string
+	if uv >= 6 {
+		parts = append(parts, fmt.Sprintf("UV is %s (%.0f): use sunscreen, a hat and sunglasses, and seek shade around midday", uvCategory(uv), uv))
+	}
+
+	var high []string
+	for _, p := range pollenPlants {
+		if count := pollen[p.name]; count >= p.high {
+			high = append(high, strings.ToLower(p.name))
+		}
+	}
+	if len(high) > 0 {
+		parts = append(parts, "pollen is high ("+strings.Join(high, ", ")+"): people with allergies should limit time outdoors")
+	}
+
+	if len(parts) == 0 {
+		return ""
+	}
+	s := strings.Join(parts, "; ")
+	return strings.ToUpper(s[:1]) + s[1:] + "."
+}
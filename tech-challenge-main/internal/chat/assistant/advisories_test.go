@@ -0,0 +1,69 @@
+package assistant
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestHealthAdvisory(t *testing.T) {
+	cases := []struct {
+		name   string
+		uv     float64
+		pollen map[string]float64
+		want   string
+	}{
+		{"low levels", 4, map[string]float64{"Grass": 12, "Birch": 3}, ""},
+		{"high UV", 7, nil, "UV is high (7): use sunscreen, a hat and sunglasses, and seek shade around midday."},
+		{"high pollen", 2, map[string]float64{"Grass": 25, "Birch": 120, "Oak": 20}, "Pollen is high (birch, grass): people with allergies should limit time outdoors."},
+		{"both", 11, map[string]float64{"Ragweed": 600}, "UV is extreme (11): use sunscreen, a hat and sunglasses, and seek shade around midday; pollen is high (ragweed): people with allergies should limit time outdoors."},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := healthAdvisory(tc.uv, tc.pollen); got != tc.want {
+				t.Errorf("healthAdvisory = %q, want %q", got, tc.want)
+			}
+		})
+	}
+
+	if got, want := formatPollen(map[string]float64{"Grass": 25, "Birch": 3, "Oak": 0}), "Birch 3 (low), Grass 25 (high)"; got != want {
+		t.Errorf("formatPollen = %q, want %q", got, want)
+	}
+}
+
+type fakePreferences bool
+
+func (f fakePreferences) HealthAdvisories(context.Context) (bool, error) { return bool(f), nil }
+
+func (f fakePreferences) Timezone(context.Context) (string, error) { return "", nil }
+
+func TestWeatherService_HealthAdvisories(t *testing.T) {
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"location":{"name":"Seville","country":"Spain"},"current":{"temp_c":31,"uv":9,"pollen":{"Grass":40}}}`)
+	}))
+	defer api.Close()
+
+	service := NewWeatherService(t.Name())
+	service.baseURL = api.URL
+
+	got, err := service.GetCurrentWeather(context.Background(), "Seville")
+	if err != nil {
+		t.Fatalf("GetCurrentWeather error: %v", err)
+	}
+	for _, want := range []string{"**UV Index:** 9.0 (very high)", "**Pollen:** Grass 40 (high)", "**Advisory:** UV is very high (9)"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected %q in:\n%s", want, got)
+		}
+	}
+
+	got, err = service.GetCurrentWeather(WithPreferences(context.Background(), fakePreferences(false)), "Seville")
+	if err != nil {
+		t.Fatalf("GetCurrentWeather error: %v", err)
+	}
+	if strings.Contains(got, "**Advisory:**") || !strings.Contains(got, "**UV Index:** 9.0") {
+		t.Errorf("expected the indices without an advisory when turned off:\n%s", got)
+	}
+}
@@ -0,0 +1,156 @@
+package assistant
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/acai-travel/tech-challenge/internal/httpx"
+)
+
+const (
+	travelAdvisoryURL = "https://www.travel-advisory.info/api"
+	// advisoryTTL is how long fetched advisories are reused; the feed is updated daily.
+	advisoryTTL = 24 * time.Hour
+)
+
+// travelAdvisory is the aggregated government travel advisory for a country.
+type travelAdvisory struct {
+	Code string
+	Name string
+	// Score is the risk from 0 (safe) to 5 (avoid travel), averaged over Sources advisories.
+	Score   float64
+	Sources int
+	Updated time.Time
+	Source  string
+}
+
+// advisoryFeed fetches the advisories of all countries at once and caches them for
+// advisoryTTL. Failures are not cached.
+type advisoryFeed struct {
+	url    string
+	client *http.Client
+
+	mu        sync.Mutex
+	byCode    map[string]travelAdvisory
+	fetchedAt time.Time
+}
+
+// travelAdvisories is shared by all assistants, so tenants don't each fetch the feed.
+var travelAdvisories = newAdvisoryFeed()
+
+func newAdvisoryFeed() *advisoryFeed {
+	url := os.Getenv("TRAVEL_ADVISORY_URL")
+	if url == "" {
+		url = travelAdvisoryURL
+	}
+	return &advisoryFeed{url: url, client: httpx.Client(10 * time.Second)}
+}
+
+// Find returns the advisory of a country, by English name or ISO 3166-1 alpha-2 code.
+func (f *advisoryFeed) Find(ctx context.Context, country string) (travelAdvisory, bool, error) {
+	all, err := f.load(ctx)
+	if err != nil {
+		return travelAdvisory{}, false, err
+	}
+
+	key := strings.Join(strings.Fields(strings.ToLower(country)), " ")
+	key = strings.TrimPrefix(key, "the ")
+	if alias, ok := countryAliases[key]; ok {
+		key = alias
+	}
+	if a, ok := all[strings.ToUpper(key)]; ok && len(key) == 2 {
+		return a, true, nil
+	}
+	for _, a := range all {
+		if strings.EqualFold(a.Name, key) {
+			return a, true, nil
+		}
+	}
+	return travelAdvisory{}, false, nil
+}
+
+// countryAliases maps common names the feed doesn't use to ISO codes.
+var countryAliases = map[string]string{
+	"uk": "gb", "england": "gb", "scotland": "gb", "wales": "gb", "great britain": "gb", "britain": "gb",
+	"usa": "us", "united states of america": "us", "america": "us",
+	"uae": "ae", "holland": "nl", "czechia": "cz", "south korea": "kr", "north korea": "kp",
+	"russia": "ru", "vietnam": "vn", "ivory coast": "ci", "türkiye": "tr", "turkey": "tr",
+}
+
+func (f *advisoryFeed) load(ctx context.Context) (map[string]travelAdvisory, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.byCode != nil && time.Since(f.fetchedAt) < advisoryTTL {
+		return f.byCode, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, f.url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch travel advisories: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("travel advisories returned status %d", resp.StatusCode)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 8<<20))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read travel advisories: %w", err)
+	}
+
+	var feed struct {
+		Data map[string]struct {
+			Code     string `json:"iso_alpha2"`
+			Name     string `json:"name"`
+			Advisory struct {
+				Score   float64 `json:"score"`
+				Sources int     `json:"sources_active"`
+				Updated string  `json:"updated"`
+				Source  string  `json:"source"`
+			} `json:"advisory"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(body, &feed); err != nil {
+		return nil, fmt.Errorf("failed to parse travel advisories: %w", err)
+	}
+
+	byCode := make(map[string]travelAdvisory, len(feed.Data))
+	for code, c := range feed.Data {
+		updated, _ := time.Parse(time.DateTime, c.Advisory.Updated)
+		byCode[strings.ToUpper(code)] = travelAdvisory{
+			Code:    strings.ToUpper(code),
+			Name:    c.Name,
+			Score:   c.Advisory.Score,
+			Sources: c.Advisory.Sources,
+			Updated: updated,
+			Source:  c.Advisory.Source,
+		}
+	}
+	f.byCode, f.fetchedAt = byCode, time.Now()
+	return byCode, nil
+}
+
+// advisoryLevel describes a risk score, on the scale of travel-advisory.info.
+func advisoryLevel(score float64) string {
+	switch {
+	case score < 2.5:
+		return "low risk: travelling is relatively safe"
+	case score < 3.5:
+		return "medium risk: use some caution"
+	case score < 4.5:
+		return "high risk: reconsider the need to travel"
+	default:
+		return "extreme warning: avoid any trips"
+	}
+}
@@ -0,0 +1,53 @@
+package assistant
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/acai-travel/tech-challenge/internal/chat/model"
+)
+
+// AliasResolver looks up the places saved by the user being answered, e.g. "home".
+type AliasResolver interface {
+	// ResolveAlias returns the place saved under name, or nil if there is none.
+	ResolveAlias(ctx context.Context, name string) (*model.LocationAlias, error)
+}
+
+type aliasResolverKey struct{}
+
+// WithAliasResolver attaches the user's saved places to the context, so location arguments
+// of tools may name them.
+func WithAliasResolver(ctx context.Context, r AliasResolver) context.Context {
+	return context.WithValue(ctx, aliasResolverKey{}, r)
+}
+
+func aliasResolverFromContext(ctx context.Context) AliasResolver {
+	r, _ := ctx.Value(aliasResolverKey{}).(AliasResolver)
+	return r
+}
+
+// resolveLocation turns a location argument naming a saved place into its coordinates. Other
+// locations are returned unchanged. note tells the model which place an alias stands for,
+// and is empty for other locations.
+func resolveLocation(ctx context.Context, location string) (query, note string, err error) {
+	r := aliasResolverFromContext(ctx)
+	if r == nil {
+		return location, "", nil
+	}
+
+	alias, err := r.ResolveAlias(ctx, location)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to look up the user's saved places: %w", err)
+	}
+	if alias == nil {
+		return location, "", nil
+	}
+
+	query = fmt.Sprintf("%.4f,%.4f", alias.Lat, alias.Lon)
+	note = fmt.Sprintf("%q is the user's saved place", location)
+	if alias.Label != "" {
+		note += " " + alias.Label
+	}
+	note += " at " + query + ".\n\n"
+	return query, note, nil
+}
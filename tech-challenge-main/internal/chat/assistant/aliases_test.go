@@ -0,0 +1,66 @@
+package assistant
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/acai-travel/tech-challenge/internal/chat/model"
+)
+
+type fakeAliases map[string]*model.LocationAlias
+
+func (f fakeAliases) ResolveAlias(_ context.Context, name string) (*model.LocationAlias, error) {
+	if a, ok := f[model.NormalizeAliasName(name)]; ok {
+		return a, nil
+	}
+	if name == "broken" {
+		return nil, errors.New("database is down")
+	}
+	return nil, nil
+}
+
+func TestWeatherToolResolvesAliases(t *testing.T) {
+	var queries []string
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query().Get("q")
+		queries = append(queries, q)
+		fmt.Fprintf(w, `{"location":{"name":%q,"country":"Spain"},"current":{"temp_c":21}}`, q)
+	}))
+	defer api.Close()
+
+	weather := NewWeatherService(t.Name())
+	weather.baseURL = api.URL
+	tool := &weatherTool{service: weather}
+
+	ctx := WithAliasResolver(context.Background(), fakeAliases{
+		"home": {Name: "home", Lat: 41.40359, Lon: 2.17436, Label: "Carrer de Mallorca 401, Barcelona"},
+	})
+
+	out, err := tool.Call(ctx, `{"location":"My Home"}`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.HasPrefix(out, `"My Home" is the user's saved place Carrer de Mallorca 401, Barcelona at 41.4036,2.1744.`) {
+		t.Errorf("expected the alias to be explained, got:\n%s", out)
+	}
+
+	if _, err := tool.Call(ctx, `{"location":"Madrid"}`); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := tool.Call(context.Background(), `{"location":"home"}`); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if want := []string{"41.4036,2.1744", "Madrid", "home"}; strings.Join(queries, "|") != strings.Join(want, "|") {
+		t.Errorf("queries: got %q, want %q", queries, want)
+	}
+
+	if _, err := tool.Call(ctx, `{"location":"broken"}`); err == nil || !strings.Contains(err.Error(), "saved places") {
+		t.Errorf("expected a lookup error, got %v", err)
+	}
+}
@@ -0,0 +1,107 @@
+package assistant
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// decodeArgs unmarshals the JSON arguments of a tool call into v. Its errors are sent back
+// to the model, so they name the offending argument instead of echoing the JSON decoder.
+// Empty arguments decode as an empty object.
+func decodeArgs(args string, v any) error {
+	if strings.TrimSpace(args) == "" {
+		args = "{}"
+	}
+
+	err := json.Unmarshal([]byte(args), v)
+	if err == nil {
+		return nil
+	}
+
+	var (
+		syntaxErr *json.SyntaxError
+		typeErr   *json.UnmarshalTypeError
+		timeErr   *time.ParseError
+		dateErr   *dateArgError
+	)
+	switch {
+	case errors.As(err, &syntaxErr):
+		return errors.New("invalid arguments: not a valid JSON object")
+	case errors.As(err, &typeErr) && typeErr.Field == "":
+		return errors.New("invalid arguments: expected a JSON object, got " + typeErr.Value)
+	case errors.As(err, &typeErr):
+		return fmt.Errorf("invalid argument %q: expected %s, got %s", typeErr.Field, jsonKind(typeErr.Type.String()), typeErr.Value)
+	case errors.As(err, &dateErr):
+		return dateErr
+	case errors.As(err, &timeErr):
+		return fmt.Errorf("invalid date %s: dates must be in RFC3339 format, e.g. 2025-01-02T00:00:00Z", timeErr.Value)
+	default:
+		return errors.New("invalid arguments: " + err.Error())
+	}
+}
+
+// jsonKind describes a Go type in the JSON terms the model works with.
+func jsonKind(goType string) string {
+	goType = strings.TrimPrefix(goType, "*")
+	switch {
+	case strings.HasPrefix(goType, "int"):
+		return "an integer"
+	case strings.HasPrefix(goType, "float"):
+		return "a number"
+	case goType == "string", goType == "time.Time", goType == "assistant.dateArg":
+		return "a string"
+	case goType == "bool":
+		return "a boolean"
+	default:
+		return goType
+	}
+}
+
+// dateArg is a date argument the model may give as an RFC3339 timestamp, a YYYY-MM-DD date
+// or an offset from today that compute_date understands, e.g. "next month" or "in 2 weeks".
+// Its schema format is "date", which validateArgs checks with parseDateArg.
+type dateArg struct {
+	// Time is the zero time when the argument is not given.
+	Time time.Time
+}
+
+func (d *dateArg) UnmarshalText(b []byte) error {
+	t, err := parseDateArg(string(b), time.Now())
+	if err != nil {
+		return err
+	}
+	d.Time = t
+	return nil
+}
+
+// dateArgError is the correction sent back to the model for a date argument no format matches.
+type dateArgError struct {
+	value string
+}
+
+func (e *dateArgError) Error() string {
+	return fmt.Sprintf(`invalid date %q: use YYYY-MM-DD (e.g. 2025-01-02), RFC3339 (e.g. 2025-01-02T00:00:00Z) or an offset from today like "next month" or "in 2 weeks"`, e.value)
+}
+
+// parseDateArg parses a date argument, taking offsets from the UTC day of now. Empty is
+// the zero time.
+func parseDateArg(s string, now time.Time) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse(time.DateOnly, s); err == nil {
+		return t, nil
+	}
+	// Offsets are parsed strictly, "soon" or "01/02/2025" are not guessed at.
+	if t, err := computeDate(day(now.UTC()), s); err == nil {
+		return t, nil
+	}
+	return time.Time{}, &dateArgError{value: s}
+}
@@ -0,0 +1,138 @@
+package assistant
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDecodeArgs(t *testing.T) {
+	tests := []struct {
+		args string
+		want string
+	}{
+		{args: ``, want: ""},
+		{args: `{"location":"Barcelona"}`, want: ""},
+		{args: `{"location":`, want: "not a valid JSON object"},
+		{args: `{"location":"Barcelona"} {}`, want: "not a valid JSON object"},
+		{args: `["Barcelona"]`, want: "expected a JSON object, got array"},
+		{args: `{"location":"Barcelona","forecast_days":"three"}`, want: `invalid argument "forecast_days": expected an integer, got string`},
+		{args: `{"location":"Barcelona","forecast_days":2.5}`, want: `invalid argument "forecast_days": expected an integer, got number 2.5`},
+	}
+
+	for _, tt := range tests {
+		var payload weatherArgs
+		err := decodeArgs(tt.args, &payload)
+		if tt.want == "" {
+			if err != nil {
+				t.Errorf("decodeArgs(%q) = %v, want nil", tt.args, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("decodeArgs(%q) = %v, want error containing %q", tt.args, err, tt.want)
+		}
+	}
+
+	var h holidaysArgs
+	if err := decodeArgs(`{"after_date":"sometime soon"}`, &h); err == nil || !strings.Contains(err.Error(), "RFC3339") {
+		t.Errorf("decodeArgs(bad date) = %v, want RFC3339 hint", err)
+	}
+}
+
+func TestParseDateArg(t *testing.T) {
+	// A Wednesday.
+	now := time.Date(2025, 1, 29, 15, 4, 5, 0, time.UTC)
+
+	for _, tt := range []struct {
+		in, want string
+	}{
+		{"2025-03-01T10:00:00+01:00", "2025-03-01"},
+		{"2025-03-01", "2025-03-01"},
+		{" 2025-03-01 ", "2025-03-01"},
+		{"next month", "2025-02-28"},
+		{"in 2 weeks", "2025-02-12"},
+		{"next friday", "2025-01-31"},
+		{"today", "2025-01-29"},
+	} {
+		got, err := parseDateArg(tt.in, now)
+		if err != nil || got.Format(time.DateOnly) != tt.want {
+			t.Errorf("parseDateArg(%q) = %v, %v, want %s", tt.in, got, err, tt.want)
+		}
+	}
+
+	if got, err := parseDateArg("", now); err != nil || !got.IsZero() {
+		t.Errorf("parseDateArg(\"\") = %v, %v, want the zero time", got, err)
+	}
+
+	for _, in := range []string{"01/02/2025", "soon", "2025-13-01"} {
+		if _, err := parseDateArg(in, now); err == nil || !strings.Contains(err.Error(), `like "next month"`) {
+			t.Errorf("parseDateArg(%q) = %v, want a correction naming the accepted formats", in, err)
+		}
+	}
+
+	var h holidaysArgs
+	if err := decodeArgs(`{"after_date":"2025-01-01","before_date":20250101}`, &h); err == nil || !strings.Contains(err.Error(), `"before_date": expected a string`) {
+		t.Errorf("decodeArgs(numeric date) = %v, want the argument named", err)
+	}
+}
+
+// checkArgsError fails if an argument error leaks decoder internals instead of explaining
+// the problem to the model.
+func checkArgsError(t *testing.T, args string, err error) {
+	if err == nil {
+		return
+	}
+	msg := err.Error()
+	if strings.HasPrefix(msg, "json:") || strings.Contains(msg, "Go value") || strings.Contains(msg, "struct") {
+		t.Errorf("args %q: error leaks decoder details: %s", args, msg)
+	}
+}
+
+func FuzzParseWeatherArgs(f *testing.F) {
+	for _, seed := range []string{
+		``, `{}`, `null`, `[]`, `"Barcelona"`,
+		`{"location":"Barcelona"}`,
+		`{"location":"London,UK","forecast_days":3}`,
+		`{"location":"40.7128,-74.0060","forecast_days":-1}`,
+		`{"location":123}`, `{"location":"Paris","forecast_days":"3"}`,
+		`{"location":"Paris",}`, `{"location":"Paris"`,
+	} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, args string) {
+		payload, err := parseWeatherArgs(args)
+		checkArgsError(t, args, err)
+		if err == nil && strings.TrimSpace(payload.Location) == "" {
+			t.Errorf("args %q: accepted without a location", args)
+		}
+	})
+}
+
+func FuzzParseHolidaysArgs(f *testing.F) {
+	for _, seed := range []string{
+		``, `{}`, `null`, `[]`,
+		`{"after_date":"2025-01-01T00:00:00Z","before_date":"2025-12-31T00:00:00Z"}`,
+		`{"before_date":"2025-01-01T00:00:00Z","after_date":"2025-12-31T00:00:00Z"}`,
+		`{"after_date":"2025-01-01"}`, `{"after_date":20250101}`,
+		`{"max_count":-3}`, `{"max_count":"five"}`,
+		`{"country":"Spain","region":"Catalonia"}`,
+	} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, args string) {
+		payload, err := parseHolidaysArgs(args)
+		checkArgsError(t, args, err)
+		if err != nil {
+			return
+		}
+		if payload.MaxCount < 0 {
+			t.Errorf("args %q: accepted negative max_count", args)
+		}
+		if !payload.BeforeDate.Time.IsZero() && payload.BeforeDate.Time.Before(payload.AfterDate.Time) {
+			t.Errorf("args %q: accepted before_date earlier than after_date", args)
+		}
+	})
+}
@@ -2,52 +2,243 @@ package assistant
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
+	"fmt"
 	"log/slog"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/acai-travel/tech-challenge/internal/audit"
 	"github.com/acai-travel/tech-challenge/internal/chat/model"
-	ics "github.com/arran4/golang-ical"
+	"github.com/acai-travel/tech-challenge/internal/httpx"
+	"github.com/acai-travel/tech-challenge/internal/llmdebug"
+	"github.com/acai-travel/tech-challenge/internal/pii"
+	"github.com/acai-travel/tech-challenge/internal/postprocess"
+	"github.com/acai-travel/tech-challenge/internal/safety"
+	"github.com/acai-travel/tech-challenge/internal/textx"
 	"github.com/openai/openai-go/v2"
+	"github.com/openai/openai-go/v2/option"
+	"github.com/openai/openai-go/v2/packages/param"
 )
 
-// isWeatherQuery checks if a message is asking about weather
-func isWeatherQuery(content string) bool {
-	content = strings.ToLower(content)
-	weatherKeywords := []string{
-		"weather", "temperature", "forecast", "climate", "hot", "cold", "rain", "snow",
-		"sunny", "cloudy", "wind", "humidity", "°c", "°f", "celsius", "fahrenheit",
-	}
-
-	for _, keyword := range weatherKeywords {
-		if strings.Contains(content, keyword) {
-			return true
-		}
-	}
-	return false
+// CompletionProvider creates chat completions. The OpenAI client's completion service is the
+// production one; assistanttest.LLM scripts them for tests.
+type CompletionProvider interface {
+	New(ctx context.Context, params openai.ChatCompletionNewParams, opts ...option.RequestOption) (*openai.ChatCompletion, error)
 }
 
 type Assistant struct {
 	cli            openai.Client
+	completions    CompletionProvider
 	weatherService *WeatherService
+	tools          Toolset
+	policy         serverToolPolicy
+	router         *modelRouter
+	verbosity      Verbosity
+	// maxOutputTokens caps every reply; 0 is no cap.
+	maxOutputTokens int64
+	prompt          string
+	persona         string
+	model           string
+	safety          *safety.Policy
+	pii             *pii.Policy
+	post            postprocess.Chain
+	cache           *ResponseCache
+	toolCache       *ToolCache
+	debug           *llmdebug.Store
 }
 
+// New returns the general-purpose assistant.
 func New() *Assistant {
-	weatherAPIKey := os.Getenv("WEATHER_API_KEY")
+	return NewWithProfile(GeneralProfile)
+}
+
+// Credentials are the provider API keys an assistant uses. Empty keys fall back to the
+// environment: OPENAI_API_KEY and WEATHER_API_KEY.
+type Credentials struct {
+	OpenAIAPIKey  string
+	WeatherAPIKey string
+}
+
+// NewWithProfile returns an assistant with the prompt, tools and model of a profile, using
+// the API keys from the environment.
+func NewWithProfile(p Profile) *Assistant {
+	return NewWithCredentials(p, Credentials{})
+}
+
+// NewWithCredentials is NewWithProfile with explicit API keys, e.g. those of a tenant.
+func NewWithCredentials(p Profile, creds Credentials) *Assistant {
+	weatherAPIKey := creds.WeatherAPIKey
+	if weatherAPIKey == "" {
+		weatherAPIKey = os.Getenv("WEATHER_API_KEY")
+	}
 	var weatherService *WeatherService
 	if weatherAPIKey != "" {
 		weatherService = NewWeatherService(weatherAPIKey)
 	}
 
-	return &Assistant{
-		cli:            openai.NewClient(),
-		weatherService: weatherService,
+	clientOpts := []option.RequestOption{option.WithHTTPClient(httpx.Client(0))}
+	if creds.OpenAIAPIKey != "" {
+		clientOpts = append(clientOpts, option.WithAPIKey(creds.OpenAIAPIKey))
+	}
+
+	// A nil *WeatherService must not become a non-nil SnowProvider or RouteProvider.
+	var (
+		snow   SnowProvider
+		routes RouteProvider
+	)
+	if weatherService != nil {
+		snow = weatherService
+		routes = estimatedRoutes{weather: weatherService}
+	}
+
+	var transit TransitProvider
+	if feeds := loadTransitFeeds(); feeds != nil {
+		transit = feeds
+	}
+
+	tools := Toolset{
+		&weatherTool{service: weatherService},
+		&tripWeatherTool{service: weatherService},
+		&routeWeatherTool{weather: weatherService, routes: routes},
+		&skiConditionsTool{snow: snow},
+		&marineConditionsTool{service: weatherService},
+		&transitTool{provider: transit},
+		&travelAdvisoryTool{feed: travelAdvisories},
+		&visaTool{provider: visaRequirements},
+		&flightSearchTool{},
+		&hotelSearchTool{},
+		&placesTool{provider: placeSearch, weather: weatherService},
+		&tripBudgetTool{rates: euroRates},
+		&todayDateTool{weather: weatherService},
+		&computeDateTool{},
+		&holidaysTool{},
+		&freeSlotsTool{},
+		&addCalendarEventTool{},
+		&updateCalendarEventTool{},
+		&longWeekendsTool{weather: weatherService},
+		&travelDatesTool{weather: weatherService},
+		&recallTool{},
+	}
+	if p.Tools != nil {
+		tools = tools.Only(p.Tools...)
+	}
+
+	policy := loadServerToolPolicy()
+	for intent, name := range policy.intentTools {
+		// Forcing a tool the profile doesn't offer, or get_weather without a weather service,
+		// would only waste a classification round trip.
+		if tools.Get(name) == nil || (name == "get_weather" && weatherService == nil) {
+			delete(policy.intentTools, intent)
+		}
+	}
+
+	model := p.Model
+	if model == "" {
+		model = DefaultModel
+	}
+
+	a := &Assistant{
+		cli:             openai.NewClient(clientOpts...),
+		weatherService:  weatherService,
+		tools:           tools,
+		policy:          policy,
+		router:          loadModelRouter(),
+		verbosity:       ParseVerbosity(os.Getenv("ASSISTANT_VERBOSITY")),
+		maxOutputTokens: envMaxOutputTokens(),
+		prompt:          p.Prompt,
+		persona:         p.Persona,
+		model:           model,
+	}
+	a.completions = openAICompletions{&a.cli.Chat.Completions}
+	return a
+}
+
+// Model returns the model replies are generated with unless the conversation overrides it.
+func (a *Assistant) Model() string {
+	return a.model
+}
+
+// SetSafetyPolicy makes replies follow an operator's safety policy: it is added to the
+// system prompt, and each reply is checked against it before being returned. It should be
+// called at startup, before the assistant is used, and so should the other setters.
+func (a *Assistant) SetSafetyPolicy(p *safety.Policy) {
+	a.safety = p
+}
+
+// SetCompletionProvider creates every completion, of replies, titles and classifications,
+// with p instead of OpenAI, e.g. an assistanttest.LLM.
+func (a *Assistant) SetCompletionProvider(p CompletionProvider) {
+	a.completions = p
+}
+
+// SetSnowProvider reports ski conditions from p instead of the weather service, e.g. a snow
+// provider that knows the snow depth at resorts.
+func (a *Assistant) SetSnowProvider(p SnowProvider) {
+	if t, ok := a.tools.Get("get_ski_conditions").(*skiConditionsTool); ok {
+		t.snow = p
+	}
+}
+
+// SetRouteProvider plans the drives of get_route_weather with p instead of estimating them
+// from a straight line.
+func (a *Assistant) SetRouteProvider(p RouteProvider) {
+	if t, ok := a.tools.Get("get_route_weather").(*routeWeatherTool); ok {
+		t.routes = p
+	}
+}
+
+// SetTransitProvider reports transit disruptions from p, e.g. the API of a city, instead of
+// the feeds in TRANSIT_FEEDS.
+func (a *Assistant) SetTransitProvider(p TransitProvider) {
+	if t, ok := a.tools.Get("get_transit_status").(*transitTool); ok {
+		t.provider = p
+	}
+}
+
+// SetVisaProvider looks up visa requirements with p instead of the Passport Index dataset,
+// caching its answers for a day.
+func (a *Assistant) SetVisaProvider(p VisaProvider) {
+	if t, ok := a.tools.Get("check_visa_requirements").(*visaTool); ok {
+		t.provider = newCachedVisaProvider(p)
+	}
+}
+
+// SetPlacesProvider finds places with p instead of OpenStreetMap.
+func (a *Assistant) SetPlacesProvider(p PlacesProvider) {
+	if t, ok := a.tools.Get("find_places").(*placesTool); ok {
+		t.provider = p
+	}
+}
+
+// SetFlightSearchProvider offers search_flights with offers from p.
+func (a *Assistant) SetFlightSearchProvider(p FlightSearchProvider) {
+	if t, ok := a.tools.Get("search_flights").(*flightSearchTool); ok {
+		t.provider = p
 	}
 }
 
+// SetHotelSearchProvider offers search_hotels with offers from p.
+func (a *Assistant) SetHotelSearchProvider(p HotelSearchProvider) {
+	if t, ok := a.tools.Get("search_hotels").(*hotelSearchTool); ok {
+		t.provider = p
+	}
+}
+
+// SetPIIPolicy masks personal data in everything the assistant sends to the model, if the
+// policy's mode is pii.ModeMask, and keeps it out of the assistant's logs.
+func (a *Assistant) SetPIIPolicy(p *pii.Policy) {
+	a.pii = p
+}
+
+// SetPostProcessing runs every reply through c before the safety check, so disclaimers
+// survive length clamping.
+func (a *Assistant) SetPostProcessing(c postprocess.Chain) {
+	a.post = c
+}
+
 func (a *Assistant) Title(ctx context.Context, conv *model.Conversation) (string, error) {
 	if len(conv.Messages) == 0 {
 		return "An empty conversation", nil
@@ -86,13 +277,14 @@ You: Setting up a PostgreSQL replica`
 	for _, m := range conv.Messages {
 		switch m.Role {
 		case model.RoleUser:
-			msgs = append(msgs, openai.UserMessage(m.Content))
+			msgs = append(msgs, openai.UserMessage(a.pii.ForModel(m.Content)))
 		case model.RoleAssistant:
-			msgs = append(msgs, openai.AssistantMessage(m.Content))
+			msgs = append(msgs, openai.AssistantMessage(a.pii.ForModel(m.Content)))
 		}
 	}
 
-	resp, err := a.cli.Chat.Completions.New(ctx, openai.ChatCompletionNewParams{
+	audit.Model(ctx, openai.ChatModelO1)
+	resp, err := a.completeDeterministic(ctx, openai.ChatCompletionNewParams{
 		Model:    openai.ChatModelO1,
 		Messages: msgs,
 	})
@@ -105,15 +297,43 @@ You: Setting up a PostgreSQL replica`
 		return "", errors.New("empty response from OpenAI for title generation")
 	}
 
-	title := resp.Choices[0].Message.Content
-	title = strings.ReplaceAll(title, "\n", " ")
-	title = strings.Trim(title, " \t\r\n-\"'")
+	return textx.Title(resp.Choices[0].Message.Content, 80), nil
+}
 
-	if len(title) > 80 {
-		title = title[:80]
+// contextMessages assembles what the model is sent to reply to conv: the system prompt, the
+// summary and pinned messages standing in for compacted history, then the rest of the
+// conversation. It also returns the last user message, for routing.
+func (a *Assistant) contextMessages(conv *model.Conversation, prompt string) ([]openai.ChatCompletionMessageParamUnion, string) {
+	msgs := []openai.ChatCompletionMessageParamUnion{
+		openai.SystemMessage(prompt),
 	}
 
-	return title, nil
+	// Long conversations are compacted in the background; the summary stands in for the
+	// messages it covers.
+	if conv.Summary != nil {
+		msgs = append(msgs, openai.SystemMessage("SUMMARY OF THE EARLIER CONVERSATION\n"+conv.Summary.Content))
+	}
+	// Users pin key answers, e.g. a final itinerary, so a summary must not lose their details.
+	if pinned := conv.PinnedSummarized(); len(pinned) > 0 {
+		var b strings.Builder
+		b.WriteString("PINNED MESSAGES FROM THE EARLIER CONVERSATION")
+		for _, m := range pinned {
+			b.WriteString("\n\n" + string(m.Role) + ": " + a.pii.ForModel(m.Content))
+		}
+		msgs = append(msgs, openai.SystemMessage(b.String()))
+	}
+
+	var lastUser string
+	for _, m := range conv.Unsummarized() {
+		switch m.Role {
+		case model.RoleUser:
+			lastUser = a.pii.ForModel(m.Content)
+			msgs = append(msgs, openai.UserMessage(a.pii.ForModel(userContent(m))))
+		case model.RoleAssistant:
+			msgs = append(msgs, openai.AssistantMessage(a.pii.ForModel(m.Content)))
+		}
+	}
+	return msgs, lastUser
 }
 
 func (a *Assistant) Reply(ctx context.Context, conv *model.Conversation) (string, error) {
@@ -134,200 +354,128 @@ func (a *Assistant) Reply(ctx context.Context, conv *model.Conversation) (string
 	// All weather-related requests are handled via the get_weather tool to avoid
 	// brittle heuristics and ensure the model extracts location + forecast_days.
 
-	msgs := []openai.ChatCompletionMessageParamUnion{
-		openai.SystemMessage(`You are a helpful AI assistant with access to specialized tools.
-
-WEATHER – TOOL USE
-1) Always call **get_weather** for weather/temperature/forecast/climate questions. Never invent weather.
-2) Args for get_weather:
-   • **location**: extract from the user message (city, "City,Country", or "lat,lon").
-   • **forecast_days**:
-     – If the user asks for a specific **weekday or date** (e.g., "Friday", "Sep 5"), first call **get_today_date**, compute the day difference from today, then set **forecast_days = diff + 1** (clamp 1–10). After receiving data, answer **only for that target day** (not the whole range).
-     – Otherwise, default to a **short forecast** (1–3 days). Do NOT request 7+ days unless explicitly asked.
-   • If the location is missing or ambiguous, ask one brief clarifying question.
-
-RESPONSE STYLE (IMPORTANT)
-3) Write a concise, readable answer tailored to the user’s request. Do **not** just echo tool output.
-   • Start with a single line header: **<City, Country> — <Day label>** (e.g., **Barcelona, Spain — Friday**).
-   • Then 3–5 short bullet points covering:
-     – Conditions (e.g., Sunny / Light rain).
-     – Temperatures: High/Low in °C (add °F only if the user used °F).
-     – Rain chance/precip if available; otherwise omit.
-     – Wind (speed + direction if available).
-   • Keep numbers clean (no excessive decimals). Avoid long paragraphs.
-   • If the user specifies part of day (e.g., "morning"), focus the summary on that period; if hourly detail isn’t available, state what’s most likely and include the day’s range.
-
-OTHER TOOLS
-4) Use **get_today_date** for current date/time questions.
-5) Use **get_holidays** for holiday/calendar questions.
-6) For non-tool queries, answer normally.`),
+	style := a.styleProfile(verbosityFromContext(ctx))
+
+	// Conversations over budget keep being answered, by a cheaper model and more briefly.
+	limited := conv.OverBudget()
+	settings := conv.Settings
+	if limited {
+		settings = limitedSettings(conv.Settings)
 	}
 
-	for _, m := range conv.Messages {
-		switch m.Role {
-		case model.RoleUser:
-			// Force function usage for weather-related queries
-			content := m.Content
-			if isWeatherQuery(content) {
-				content = "IMPORTANT: You MUST use the get_weather function to answer this question. Do NOT generate weather information from your training data. Extract the location and forecast_days (if any) from the user's text. Question: " + content
-				slog.InfoContext(ctx, "Weather query detected, forcing function usage", "original", m.Content, "modified", content)
-			}
-			msgs = append(msgs, openai.UserMessage(content))
-		case model.RoleAssistant:
-			msgs = append(msgs, openai.AssistantMessage(m.Content))
+	msgs, lastUser := a.contextMessages(conv, "")
+
+	// Weather questions must be answered from live data, so the policy may force a tool on
+	// the first completion instead of rewriting the user's message.
+	turn, err := a.resolveTools(ctx, lastUser)
+	if err != nil {
+		return "", err
+	}
+
+	// The system prompt only describes the tools this reply offers.
+	prompt := a.systemPrompt(ctx, turn.tools) + style.prompt + a.safety.Prompt()
+	if limited {
+		prompt += limitedPrompt
+	}
+	if conv.Language != "" {
+		prompt += languageInstruction(conv.Language)
+	}
+	msgs[0] = openai.SystemMessage(prompt)
+
+	var routed string
+	if limited {
+		routed = a.limitedModel()
+		slog.InfoContext(ctx, "Conversation over budget, limiting reply", "conversation_id", conv.ID, "model", routed)
+	} else {
+		var complexity Complexity
+		routed, complexity = a.route(ctx, conv, lastUser, turn)
+		if complexity != "" {
+			slog.InfoContext(ctx, "Routed reply by complexity", "complexity", complexity, "model", routed)
 		}
 	}
 
+	used := model.Usage{}
+	if limited {
+		used.LimitedReplies = 1
+	}
+	defer func() { reportUsage(ctx, used) }()
+
+	var loop toolLoop
+	defer loop.record()
+
 	for i := 0; i < 15; i++ {
-		resp, err := a.cli.Chat.Completions.New(ctx, openai.ChatCompletionNewParams{
-			Model:    openai.ChatModelO1,
-			Messages: msgs,
-			Tools: []openai.ChatCompletionToolUnionParam{
-				openai.ChatCompletionFunctionTool(openai.FunctionDefinitionParam{
-					Name:        "get_weather",
-					Description: openai.String("ALWAYS use this function when users ask about weather, temperature, forecast, or climate conditions. Do NOT generate weather information from training data. This function provides real-time weather data from WeatherAPI."),
-					Parameters: openai.FunctionParameters{
-						"type": "object",
-						"properties": map[string]any{
-							"location": map[string]string{
-								"type":        "string",
-								"description": "City name, coordinates, or location query (e.g., 'Barcelona', 'London,UK', '40.7128,-74.0060')",
-							},
-							"forecast_days": map[string]any{
-								"type":        "integer",
-								"description": "Number of forecast days (1-14). If not provided, returns only current weather.",
-							},
-						},
-						"required": []string{"location"},
-					},
-				}),
-				openai.ChatCompletionFunctionTool(openai.FunctionDefinitionParam{
-					Name:        "get_today_date",
-					Description: openai.String("Get today's date and time in RFC3339 format"),
-				}),
-				openai.ChatCompletionFunctionTool(openai.FunctionDefinitionParam{
-					Name:        "get_holidays",
-					Description: openai.String("Gets local bank and public holidays. Each line is a single holiday in the format 'YYYY-MM-DD: Holiday Name'."),
-					Parameters: openai.FunctionParameters{
-						"type": "object",
-						"properties": map[string]any{
-							"before_date": map[string]string{
-								"type":        "string",
-								"description": "Optional date in RFC3339 format to get holidays before this date. If not provided, all holidays will be returned.",
-							},
-							"after_date": map[string]string{
-								"type":        "string",
-								"description": "Optional date in RFC3339 format to get holidays after this date. If not provided, all holidays will be returned.",
-							},
-							"max_count": map[string]string{
-								"type":        "integer",
-								"description": "Optional maximum number of holidays to return. If not provided, all holidays will be returned.",
-							},
-						},
-					},
-				}),
-			},
-		})
+		params := openai.ChatCompletionNewParams{
+			Messages:   msgs,
+			ToolChoice: turn.choice,
+		}
+		applySettings(&params, routed, settings, style, a.outputCap(ctx))
+		if len(turn.tools) > 0 {
+			params.Tools = turn.tools.localizedParams(conv.Language)
+		}
 
+		audit.Model(ctx, params.Model)
+		started := time.Now()
+		resp, err := a.complete(ctx, params)
+		if err == nil && params.MaxCompletionTokens.Valid() && truncatedToolCall(resp) {
+			// The output cap is on the answer: a tool call it cut short is made again without it.
+			addCompletionUsage(&used, params.Model, resp.Usage)
+			audit.Tokens(ctx, resp.Usage.TotalTokens)
+			params.MaxCompletionTokens = param.Opt[int64]{}
+			resp, err = a.complete(ctx, params)
+		}
+		took := time.Since(started)
+		loop.llm += took
+		trace := a.traceCompletion(ctx, conv, i, params, resp, err, took)
 		if err != nil {
+			a.recordTrace(trace)
 			return "", err
 		}
 
 		if len(resp.Choices) == 0 {
+			a.recordTrace(trace)
 			return "", errors.New("no choices returned by OpenAI")
 		}
+		if !limited {
+			a.recordRoutingSavings(routed, resp.Usage)
+		}
+		audit.Tokens(ctx, resp.Usage.TotalTokens)
+		addCompletionUsage(&used, params.Model, resp.Usage)
 
 		if message := resp.Choices[0].Message; len(message.ToolCalls) > 0 {
 			slog.InfoContext(ctx, "Tool calls detected", "count", len(message.ToolCalls))
 			msgs = append(msgs, message.ToParam())
 
+			// Forcing only applies to the first turn; afterwards the model must be free to answer.
+			turn.choice = openai.ChatCompletionToolChoiceOptionUnionParam{}
+
+			toolsStarted := time.Now()
 			for _, call := range message.ToolCalls {
-				slog.InfoContext(ctx, "Tool call received", "name", call.Function.Name, "args", call.Function.Arguments)
-
-				switch call.Function.Name {
-				case "get_weather":
-					var payload struct {
-						Location     string `json:"location"`
-						ForecastDays *int   `json:"forecast_days,omitempty"`
-					}
-
-					if err := json.Unmarshal([]byte(call.Function.Arguments), &payload); err != nil {
-						msgs = append(msgs, openai.ToolMessage("failed to parse weather request arguments: "+err.Error(), call.ID))
-						break
-					}
-
-					if a.weatherService == nil {
-						msgs = append(msgs, openai.ToolMessage("Weather service is not configured. Please set WEATHER_API_KEY environment variable.", call.ID))
-						break
-					}
-
-					var weatherInfo string
-					var err error
-
-					if payload.ForecastDays != nil && *payload.ForecastDays > 0 {
-						weatherInfo, err = a.weatherService.GetForecast(ctx, payload.Location, *payload.ForecastDays)
-					} else {
-						weatherInfo, err = a.weatherService.GetCurrentWeather(ctx, payload.Location)
-					}
-
-					if err != nil {
-						msgs = append(msgs, openai.ToolMessage("Failed to get weather information: "+err.Error(), call.ID))
-						break
-					}
-
-					msgs = append(msgs, openai.ToolMessage(weatherInfo, call.ID))
-				case "get_today_date":
-					msgs = append(msgs, openai.ToolMessage(time.Now().Format(time.RFC3339), call.ID))
-				case "get_holidays":
-					link := "https://www.officeholidays.com/ics/spain/catalonia"
-					if v := os.Getenv("HOLIDAY_CALENDAR_LINK"); v != "" {
-						link = v
-					}
-
-					events, err := LoadCalendar(ctx, link)
-					if err != nil {
-						msgs = append(msgs, openai.ToolMessage("failed to load holiday events", call.ID))
-						break
-					}
-
-					var payload struct {
-						BeforeDate time.Time `json:"before_date,omitempty"`
-						AfterDate  time.Time `json:"after_date,omitempty"`
-						MaxCount   int       `json:"max_count,omitempty"`
-					}
-
-					if err := json.Unmarshal([]byte(call.Function.Arguments), &payload); err != nil {
-						msgs = append(msgs, openai.ToolMessage("failed to parse tool call arguments: "+err.Error(), call.ID))
-						break
-					}
-
-					var holidays []string
-					for _, event := range events {
-						date, err := event.GetAllDayStartAt()
-						if err != nil {
-							continue
-						}
-
-						if payload.MaxCount > 0 && len(holidays) >= payload.MaxCount {
-							break
-						}
-
-						if !payload.BeforeDate.IsZero() && date.After(payload.BeforeDate) {
-							continue
-						}
-
-						if !payload.AfterDate.IsZero() && date.Before(payload.AfterDate) {
-							continue
-						}
-
-						holidays = append(holidays, date.Format(time.DateOnly)+": "+event.GetProperty(ics.ComponentPropertySummary).Value)
-					}
-
-					msgs = append(msgs, openai.ToolMessage(strings.Join(holidays, "\n"), call.ID))
-				default:
+				slog.InfoContext(ctx, "Tool call received", "name", call.Function.Name, "args", a.pii.Redact(call.Function.Arguments))
+
+				tool := turn.tools.Get(call.Function.Name)
+				if tool == nil {
+					a.recordTrace(trace)
 					return "", errors.New("unknown tool call: " + call.Function.Name)
 				}
+
+				audit.Tool(ctx, tool.Name())
+				reportProgress(ctx, toolCalledProgress(tool, call.Function.Arguments))
+				result, err := a.toolCache.call(ctx, tool, call.Function.Arguments)
+				reportProgress(ctx, toolDoneProgress(tool, err))
+				if err != nil {
+					result = err.Error()
+				}
+				// Tools like recall_past_conversations return what users wrote before.
+				result = a.pii.ForModel(textx.Clean(result))
+
+				msgs = append(msgs, openai.ToolMessage(result, call.ID))
 			}
+			toolsTook := time.Since(toolsStarted)
+			loop.turn(len(message.ToolCalls), toolsTook)
+			if trace != nil {
+				trace.ToolCalls, trace.ToolMs = len(message.ToolCalls), toolsTook.Milliseconds()
+			}
+			a.recordTrace(trace)
 
 			continue
 		}
@@ -337,8 +485,63 @@ OTHER TOOLS
 			slog.InfoContext(ctx, "No tool calls made - OpenAI generated direct response", "content_length", len(resp.Choices[0].Message.Content))
 		}
 
-		return resp.Choices[0].Message.Content, nil
+		a.recordTrace(trace)
+		toolLoopIterations.Add(strconv.Itoa(i+1), 1)
+		reply := a.enforceSafety(ctx, conv, a.post.Process(resp.Choices[0].Message.Content))
+		if limited && conv.Usage.LimitedReplies == 0 {
+			reply += "\n\n" + budgetNotice
+		}
+		return reply, nil
 	}
 
+	toolLoopIterations.Add("exhausted", 1)
 	return "", errors.New("too many tool calls, unable to generate reply")
 }
+
+// RefusalFunc receives the topic of a reply the safety policy replaced with its refusal.
+type RefusalFunc func(topic string)
+
+type refusalKey struct{}
+
+// WithRefusals makes replies generated with the context report to fn when the safety policy
+// refuses them, e.g. to watch the refusal rate of a new prompt.
+func WithRefusals(ctx context.Context, fn RefusalFunc) context.Context {
+	return context.WithValue(ctx, refusalKey{}, fn)
+}
+
+// enforceSafety applies the post-generation check of the safety policy to a reply.
+func (a *Assistant) enforceSafety(ctx context.Context, conv *model.Conversation, reply string) string {
+	reply, verdict := a.safety.Enforce(reply)
+	if verdict.Refused != "" {
+		safetyInterventions.Add("refused", 1)
+		slog.WarnContext(ctx, "Reply replaced by the safety policy", "conversation_id", conv.ID, "topic", verdict.Refused)
+		if fn, _ := ctx.Value(refusalKey{}).(RefusalFunc); fn != nil {
+			fn(verdict.Refused)
+		}
+	}
+	if len(verdict.Disclaimers) > 0 {
+		safetyInterventions.Add("disclaimer", 1)
+		slog.InfoContext(ctx, "Disclaimers added by the safety policy", "conversation_id", conv.ID, "topics", verdict.Disclaimers)
+	}
+	return reply
+}
+
+// userContent is the text of a user message as sent to the model. Attachment contents
+// aren't sent yet, but the model should know they exist rather than ignore them.
+func userContent(m *model.Message) string {
+	if len(m.Attachments) == 0 {
+		return m.Content
+	}
+
+	var b strings.Builder
+	b.WriteString(m.Content)
+	b.WriteString("\n\n[Attached files, not readable by you: ")
+	for i, a := range m.Attachments {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		fmt.Fprintf(&b, "%s (%s)", a.Filename, a.ContentType)
+	}
+	b.WriteString("]")
+	return b.String()
+}
@@ -0,0 +1,211 @@
+// Package assistanttest scripts the model an assistant.Assistant talks to, so the tool loop
+// of Reply can be tested deterministically and without network access:
+//
+//	llm := assistanttest.New(
+//		assistanttest.Call("compute_date", `{"offset":"tomorrow"}`),
+//		assistanttest.Answer("Tomorrow is Friday."),
+//	)
+//	a.SetCompletionProvider(llm)
+package assistanttest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/openai/openai-go/v2"
+	"github.com/openai/openai-go/v2/option"
+)
+
+// Step is the outcome of one completion: tool calls, a final answer, or an error.
+type Step struct {
+	Content   string
+	ToolCalls []ToolCall
+	Err       error
+	// PromptTokens and CompletionTokens are the usage reported with the completion.
+	PromptTokens, CompletionTokens int64
+	// FinishReason is why the completion ended, e.g. "length"; empty is "tool_calls" for
+	// tool calls and "stop" otherwise.
+	FinishReason string
+
+	repeat bool
+}
+
+// ToolCall is a call of a tool by the model, with its JSON arguments as the model wrote
+// them, valid or not.
+type ToolCall struct {
+	Name      string
+	Arguments string
+}
+
+// Answer is a step answering content.
+func Answer(content string) Step {
+	return Step{Content: content, PromptTokens: 10, CompletionTokens: 5}
+}
+
+// Call is a step calling a tool.
+func Call(name, arguments string) Step {
+	return Calls(ToolCall{Name: name, Arguments: arguments})
+}
+
+// Calls is a step calling several tools at once.
+func Calls(calls ...ToolCall) Step {
+	return Step{ToolCalls: calls, PromptTokens: 10, CompletionTokens: 5}
+}
+
+// Fail is a step failing with err, as a request to OpenAI would.
+func Fail(err error) Step {
+	return Step{Err: err}
+}
+
+// Forever repeats s for every completion after it, e.g. a model that never stops calling
+// tools. Steps after it are never reached.
+func Forever(s Step) Step {
+	s.repeat = true
+	return s
+}
+
+// LLM returns the completions of a script, one step per completion, and records the
+// requests. Completions beyond the script fail. It is safe for concurrent use.
+type LLM struct {
+	mu       sync.Mutex
+	script   []Step
+	requests []openai.ChatCompletionNewParams
+}
+
+// New returns an LLM playing steps in order.
+func New(steps ...Step) *LLM {
+	return &LLM{script: steps}
+}
+
+// New implements assistant.CompletionProvider.
+func (l *LLM) New(ctx context.Context, params openai.ChatCompletionNewParams, _ ...option.RequestOption) (*openai.ChatCompletion, error) {
+	n, step, err := l.next(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	return completion(n, step)
+}
+
+// NewStreamed implements assistant.CompletionStreamer, streaming answers a word at a time.
+func (l *LLM) NewStreamed(ctx context.Context, params openai.ChatCompletionNewParams, onDelta func(string)) (*openai.ChatCompletion, error) {
+	n, step, err := l.next(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	if len(step.ToolCalls) == 0 {
+		for _, word := range strings.SplitAfter(step.Content, " ") {
+			if word != "" {
+				onDelta(word)
+			}
+		}
+	}
+	return completion(n, step)
+}
+
+// next records a request and returns the step answering it, the nth.
+func (l *LLM) next(ctx context.Context, params openai.ChatCompletionNewParams) (int, Step, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, Step{}, err
+	}
+
+	l.mu.Lock()
+	n := len(l.requests)
+	l.requests = append(l.requests, params)
+	var step Step
+	var ok bool
+	if len(l.script) > 0 {
+		step, ok = l.script[0], true
+		if !step.repeat {
+			l.script = l.script[1:]
+		}
+	}
+	l.mu.Unlock()
+
+	if !ok {
+		return n, step, fmt.Errorf("assistanttest: unexpected completion %d, the script has ended", n+1)
+	}
+	return n, step, step.Err
+}
+
+// completion builds the response of step to the nth request. It is decoded from JSON, as
+// the OpenAI client does, so the response behaves like a real one.
+func completion(n int, step Step) (*openai.ChatCompletion, error) {
+	type function struct {
+		Name      string `json:"name"`
+		Arguments string `json:"arguments"`
+	}
+	type toolCall struct {
+		ID       string   `json:"id"`
+		Type     string   `json:"type"`
+		Function function `json:"function"`
+	}
+	calls := make([]toolCall, 0, len(step.ToolCalls))
+	for i, c := range step.ToolCalls {
+		calls = append(calls, toolCall{
+			ID:       fmt.Sprintf("call_%d_%d", n+1, i+1),
+			Type:     "function",
+			Function: function{Name: c.Name, Arguments: c.Arguments},
+		})
+	}
+
+	finish := "stop"
+	if len(calls) > 0 {
+		finish = "tool_calls"
+	}
+	if step.FinishReason != "" {
+		finish = step.FinishReason
+	}
+	message := map[string]any{"role": "assistant", "content": step.Content, "refusal": ""}
+	if len(calls) > 0 {
+		message["tool_calls"] = calls
+	}
+	body, err := json.Marshal(map[string]any{
+		"id":      fmt.Sprintf("chatcmpl-test-%d", n+1),
+		"object":  "chat.completion",
+		"created": 0,
+		"model":   "assistanttest",
+		"choices": []map[string]any{{"index": 0, "finish_reason": finish, "message": message, "logprobs": nil}},
+		"usage": map[string]any{
+			"prompt_tokens":     step.PromptTokens,
+			"completion_tokens": step.CompletionTokens,
+			"total_tokens":      step.PromptTokens + step.CompletionTokens,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var out openai.ChatCompletion
+	if err := json.Unmarshal(body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// Requests returns the requests received, in order.
+func (l *LLM) Requests() []openai.ChatCompletionNewParams {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]openai.ChatCompletionNewParams(nil), l.requests...)
+}
+
+// ToolResults returns the tool results sent with the latest request, by tool call ID, e.g.
+// "call_1_1" for the first call of the first completion.
+func (l *LLM) ToolResults() map[string]string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	out := map[string]string{}
+	if len(l.requests) == 0 {
+		return out
+	}
+	for _, m := range l.requests[len(l.requests)-1].Messages {
+		if m.OfTool != nil {
+			out[m.OfTool.ToolCallID] = m.OfTool.Content.OfString.Value
+		}
+	}
+	return out
+}
@@ -0,0 +1,61 @@
+package assistant
+
+import (
+	"context"
+
+	"github.com/acai-travel/tech-challenge/internal/chat/model"
+	"github.com/openai/openai-go/v2"
+)
+
+// UsageFunc receives what a reply consumed, once it is generated or has failed.
+type UsageFunc func(model.Usage)
+
+type usageKey struct{}
+
+// WithUsage makes replies generated with the context report their usage to fn, e.g. to
+// add it to the conversation's budget.
+func WithUsage(ctx context.Context, fn UsageFunc) context.Context {
+	return context.WithValue(ctx, usageKey{}, fn)
+}
+
+func reportUsage(ctx context.Context, u model.Usage) {
+	if fn, _ := ctx.Value(usageKey{}).(UsageFunc); fn != nil {
+		fn(u)
+	}
+}
+
+// limitedMaxTokens caps the replies of conversations over budget.
+const limitedMaxTokens = 400
+
+const limitedPrompt = "\n\nBUDGET\n- This conversation has spent its budget. Answer in a few sentences at most."
+
+// budgetNotice tells users why replies changed, on the first reply over budget.
+const budgetNotice = "_This conversation has reached its budget, so replies are now shorter and use a smaller model._"
+
+// limitedModel is the model answering conversations over budget: the routing cheap model,
+// or gpt-4o-mini, unless the assistant's own model is cheaper still.
+func (a *Assistant) limitedModel() string {
+	cheap := openai.ChatModelGPT4oMini
+	if a.router != nil {
+		cheap = a.router.cheap
+	}
+	own, ok1 := modelPrices[a.model]
+	alt, ok2 := modelPrices[cheap]
+	if ok1 && ok2 && own.input+own.output <= alt.input+alt.output {
+		return a.model
+	}
+	return cheap
+}
+
+// limitedSettings are the generation settings of a conversation over budget: the model it
+// selected is replaced and outputs are capped, keeping a lower cap of its own.
+func limitedSettings(s *model.GenerationSettings) *model.GenerationSettings {
+	out := &model.GenerationSettings{MaxOutputTokens: limitedMaxTokens}
+	if s != nil {
+		out.Temperature = s.Temperature
+		if s.MaxOutputTokens > 0 && s.MaxOutputTokens < limitedMaxTokens {
+			out.MaxOutputTokens = s.MaxOutputTokens
+		}
+	}
+	return out
+}
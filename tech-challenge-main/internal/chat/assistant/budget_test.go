@@ -0,0 +1,36 @@
+package assistant
+
+import (
+	"testing"
+
+	"github.com/acai-travel/tech-challenge/internal/chat/model"
+	"github.com/openai/openai-go/v2"
+)
+
+func TestLimitedModel(t *testing.T) {
+	a := &Assistant{model: openai.ChatModelO1}
+	if got := a.limitedModel(); got != openai.ChatModelGPT4oMini {
+		t.Errorf("limitedModel() = %s, want gpt-4o-mini", got)
+	}
+
+	a.router = &modelRouter{cheap: openai.ChatModelGPT4_1Nano}
+	if got := a.limitedModel(); got != openai.ChatModelGPT4_1Nano {
+		t.Errorf("limitedModel() = %s, want the routing cheap model", got)
+	}
+
+	a = &Assistant{model: openai.ChatModelGPT4_1Nano}
+	if got := a.limitedModel(); got != openai.ChatModelGPT4_1Nano {
+		t.Errorf("limitedModel() = %s, want the assistant's cheaper model", got)
+	}
+}
+
+func TestLimitedSettings(t *testing.T) {
+	temp := 0.3
+	got := limitedSettings(&model.GenerationSettings{Model: openai.ChatModelGPT4o, Temperature: &temp, MaxOutputTokens: 2000})
+	if got.Model != "" || got.MaxOutputTokens != limitedMaxTokens || got.Temperature != &temp {
+		t.Errorf("limitedSettings() = %+v", got)
+	}
+	if got := limitedSettings(&model.GenerationSettings{MaxOutputTokens: 100}); got.MaxOutputTokens != 100 {
+		t.Errorf("a lower output cap was raised to %d", got.MaxOutputTokens)
+	}
+}
@@ -0,0 +1,109 @@
+package assistant
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"log/slog"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/acai-travel/tech-challenge/internal/audit"
+	"github.com/hashicorp/golang-lru/v2/expirable"
+	"github.com/openai/openai-go/v2"
+	"golang.org/x/sync/singleflight"
+)
+
+// ResponseCache keeps the completions of deterministic prompts, such as titles, summaries
+// and classifications, keyed by a hash of the whole request. Identical requests from any
+// feature or assistant sharing the cache are sent once. It is safe for concurrent use.
+type ResponseCache struct {
+	lru *expirable.LRU[string, *openai.ChatCompletion]
+	sf  singleflight.Group
+}
+
+// NewResponseCache keeps up to size completions for ttl each.
+func NewResponseCache(size int, ttl time.Duration) *ResponseCache {
+	return &ResponseCache{lru: expirable.NewLRU[string, *openai.ChatCompletion](size, nil, ttl)}
+}
+
+// ResponseCacheFromEnv returns the cache configured by LLM_CACHE_SIZE (default 5000
+// completions) and LLM_CACHE_TTL (default 1h), or nil if LLM_CACHE=false.
+func ResponseCacheFromEnv() *ResponseCache {
+	if os.Getenv("LLM_CACHE") == "false" {
+		return nil
+	}
+
+	size := 5_000
+	if v, err := strconv.Atoi(os.Getenv("LLM_CACHE_SIZE")); err == nil && v > 0 {
+		size = v
+	}
+	ttl := time.Hour
+	if v, err := time.ParseDuration(os.Getenv("LLM_CACHE_TTL")); err == nil && v > 0 {
+		ttl = v
+	}
+	return NewResponseCache(size, ttl)
+}
+
+// Len reports how many completions are cached.
+func (c *ResponseCache) Len() int {
+	return c.lru.Len()
+}
+
+// complete returns the cached completion of params, or the one returned by call. Failed
+// calls are not cached; a nil cache calls through.
+func (c *ResponseCache) complete(ctx context.Context, params openai.ChatCompletionNewParams, call func() (*openai.ChatCompletion, error)) (*openai.ChatCompletion, error) {
+	if c == nil {
+		return call()
+	}
+
+	data, err := json.Marshal(params)
+	if err != nil {
+		slog.WarnContext(ctx, "Failed to hash completion request, not caching it", "error", err)
+		return call()
+	}
+	sum := sha256.Sum256(data)
+	key := hex.EncodeToString(sum[:])
+
+	if resp, ok := c.lru.Get(key); ok {
+		responseCacheStats.Add("hit", 1)
+		return resp, nil
+	}
+
+	v, err, shared := c.sf.Do(key, func() (any, error) {
+		resp, err := call()
+		if err == nil {
+			c.lru.Add(key, resp)
+		}
+		return resp, err
+	})
+	if shared {
+		responseCacheStats.Add("shared", 1)
+	} else {
+		responseCacheStats.Add("miss", 1)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return v.(*openai.ChatCompletion), nil
+}
+
+// SetResponseCache sends titles, summaries, classifications and language detections through
+// c. Assistants with the same API key may share one.
+func (a *Assistant) SetResponseCache(c *ResponseCache) {
+	a.cache = c
+}
+
+// completeDeterministic creates a completion whose result only depends on params, from the
+// response cache if one is set.
+func (a *Assistant) completeDeterministic(ctx context.Context, params openai.ChatCompletionNewParams) (*openai.ChatCompletion, error) {
+	return a.cache.complete(ctx, params, func() (*openai.ChatCompletion, error) {
+		resp, err := a.completions.New(ctx, params)
+		if err == nil {
+			audit.Tokens(ctx, resp.Usage.TotalTokens)
+		}
+		return resp, err
+	})
+}
@@ -0,0 +1,106 @@
+package assistant
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/openai/openai-go/v2"
+)
+
+func cacheParams(content string) openai.ChatCompletionNewParams {
+	return openai.ChatCompletionNewParams{
+		Model:       openai.ChatModelGPT4oMini,
+		Messages:    []openai.ChatCompletionMessageParamUnion{openai.SystemMessage(classifierPrompt), openai.UserMessage(content)},
+		Temperature: openai.Float(0),
+	}
+}
+
+func TestResponseCache(t *testing.T) {
+	ctx := context.Background()
+	c := NewResponseCache(10, time.Hour)
+
+	var calls atomic.Int32
+	call := func(answer string) func() (*openai.ChatCompletion, error) {
+		return func() (*openai.ChatCompletion, error) {
+			calls.Add(1)
+			return &openai.ChatCompletion{Choices: []openai.ChatCompletionChoice{{Message: openai.ChatCompletionMessage{Content: answer}}}}, nil
+		}
+	}
+
+	for i := 0; i < 3; i++ {
+		resp, err := c.complete(ctx, cacheParams("Rain in Oslo?"), call("weather"))
+		if err != nil || resp.Choices[0].Message.Content != "weather" {
+			t.Fatalf("complete = %v, %v", resp, err)
+		}
+	}
+	if calls.Load() != 1 {
+		t.Errorf("identical requests made %d calls, want 1", calls.Load())
+	}
+
+	if _, err := c.complete(ctx, cacheParams("Hot laptop"), call("other")); err != nil || calls.Load() != 2 {
+		t.Errorf("a different request should be sent: %d calls, %v", calls.Load(), err)
+	}
+
+	fail := func() (*openai.ChatCompletion, error) { calls.Add(1); return nil, errors.New("rate limited") }
+	_, _ = c.complete(ctx, cacheParams("Snow in Bern?"), fail)
+	if _, err := c.complete(ctx, cacheParams("Snow in Bern?"), call("weather")); err != nil || calls.Load() != 4 {
+		t.Errorf("failures must not be cached: %d calls, %v", calls.Load(), err)
+	}
+}
+
+func TestResponseCache_Concurrent(t *testing.T) {
+	c := NewResponseCache(10, time.Hour)
+	release := make(chan struct{})
+	var calls atomic.Int32
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _ = c.complete(context.Background(), cacheParams("Rain in Oslo?"), func() (*openai.ChatCompletion, error) {
+				calls.Add(1)
+				<-release
+				return &openai.ChatCompletion{}, nil
+			})
+		}()
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if calls.Load() != 1 {
+		t.Errorf("concurrent identical requests made %d calls, want 1", calls.Load())
+	}
+}
+
+func TestResponseCache_Expiry(t *testing.T) {
+	c := NewResponseCache(10, 20*time.Millisecond)
+	calls := 0
+	call := func() (*openai.ChatCompletion, error) { calls++; return &openai.ChatCompletion{}, nil }
+
+	_, _ = c.complete(context.Background(), cacheParams("Rain in Oslo?"), call)
+	time.Sleep(50 * time.Millisecond)
+	_, _ = c.complete(context.Background(), cacheParams("Rain in Oslo?"), call)
+	if calls != 2 {
+		t.Errorf("expired completion was served from the cache (%d calls)", calls)
+	}
+}
+
+func TestResponseCache_Nil(t *testing.T) {
+	var c *ResponseCache
+	calls := 0
+	for i := 0; i < 2; i++ {
+		_, _ = c.complete(context.Background(), cacheParams("Rain in Oslo?"), func() (*openai.ChatCompletion, error) {
+			calls++
+			return &openai.ChatCompletion{}, nil
+		})
+	}
+	if calls != 2 {
+		t.Errorf("nil cache made %d calls, want 2", calls)
+	}
+}
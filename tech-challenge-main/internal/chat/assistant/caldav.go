@@ -0,0 +1,154 @@
+package assistant
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/acai-travel/tech-challenge/internal/httpx"
+	ics "github.com/arran4/golang-ical"
+)
+
+// CalDAVAccount is the CalDAV calendar collection a user lets the assistant add events to,
+// with its credentials in the clear.
+type CalDAVAccount struct {
+	URL      string
+	Username string
+	Password string
+}
+
+// CalDAVSource looks up the CalDAV account of the user being answered. It returns nil when
+// they have none connected.
+type CalDAVSource interface {
+	CalDAVAccount(ctx context.Context) (*CalDAVAccount, error)
+}
+
+type caldavSourceKey struct{}
+
+// WithCalDAV attaches the user's CalDAV account to the context, so add_calendar_event and
+// update_calendar_event can write to their calendar.
+func WithCalDAV(ctx context.Context, s CalDAVSource) context.Context {
+	return context.WithValue(ctx, caldavSourceKey{}, s)
+}
+
+// WithoutCalDAV returns ctx without the user's CalDAV account, for replies on the side, e.g.
+// shadow replies, that mustn't write to the user's calendar.
+func WithoutCalDAV(ctx context.Context) context.Context {
+	return context.WithValue(ctx, caldavSourceKey{}, CalDAVSource(nil))
+}
+
+func caldavFromContext(ctx context.Context) CalDAVSource {
+	s, _ := ctx.Value(caldavSourceKey{}).(CalDAVSource)
+	return s
+}
+
+// caldavHTTPClient connects to the addresses users give, so only to public ones; tests
+// replace it to reach local servers.
+var caldavHTTPClient = httpx.PublicClient(20 * time.Second)
+
+var (
+	errCalDAVNotFound = errors.New("caldav: event not found")
+	errCalDAVConflict = errors.New("caldav: event changed meanwhile")
+)
+
+// caldavClient writes events to a CalDAV calendar collection, each as a resource named
+// after its UID, as RFC 4791 recommends.
+type caldavClient struct {
+	account CalDAVAccount
+	http    *http.Client
+}
+
+// connectCalDAV returns a client for the account of the user being answered, or an error
+// for the model when they have none.
+func connectCalDAV(ctx context.Context) (*caldavClient, error) {
+	s := caldavFromContext(ctx)
+	if s == nil {
+		return nil, errors.New("The user has no calendar connected that events can be added to.")
+	}
+	acc, err := s.CalDAVAccount(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up the user's CalDAV account: %w", err)
+	}
+	if acc == nil {
+		return nil, errors.New("The user has no calendar connected that events can be added to. Tell the user they can connect one with its CalDAV address, username and password.")
+	}
+	return &caldavClient{account: *acc, http: caldavHTTPClient}, nil
+}
+
+func (c *caldavClient) eventURL(uid string) string {
+	return strings.TrimSuffix(c.account.URL, "/") + "/" + url.PathEscape(uid) + ".ics"
+}
+
+// get fetches the event with uid and the ETag to update it with.
+func (c *caldavClient) get(ctx context.Context, uid string) (*ics.Calendar, string, error) {
+	resp, err := c.do(ctx, http.MethodGet, uid, nil, nil)
+	if err != nil {
+		return nil, "", err
+	}
+	defer resp.Body.Close()
+
+	cal, err := ics.ParseCalendar(resp.Body)
+	if err != nil {
+		return nil, "", fmt.Errorf("caldav: failed to parse event: %w", err)
+	}
+	return cal, resp.Header.Get("ETag"), nil
+}
+
+// put stores cal as the event with uid. An empty etag creates the event, failing if one
+// with uid exists; otherwise the event is only replaced if it is still at etag.
+func (c *caldavClient) put(ctx context.Context, uid string, cal *ics.Calendar, etag string) error {
+	header := http.Header{"Content-Type": {"text/calendar; charset=utf-8"}}
+	if etag == "" {
+		header.Set("If-None-Match", "*")
+	} else {
+		header.Set("If-Match", etag)
+	}
+
+	resp, err := c.do(ctx, http.MethodPut, uid, header, strings.NewReader(cal.Serialize()))
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+func (c *caldavClient) do(ctx context.Context, method, uid string, header http.Header, body io.Reader) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, c.eventURL(uid), body)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	req.SetBasicAuth(c.account.Username, c.account.Password)
+
+	// The account URL may name the user; only its host is logged.
+	slog.InfoContext(ctx, "CalDAV request", "method", method, "host", req.URL.Host)
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("caldav: %w", err)
+	}
+	if resp.StatusCode < 300 {
+		return resp, nil
+	}
+
+	msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+	resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusNotFound:
+		return nil, errCalDAVNotFound
+	case http.StatusPreconditionFailed:
+		return nil, errCalDAVConflict
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return nil, errors.New("The user's calendar server rejected the connected credentials. Tell the user to connect their calendar again.")
+	default:
+		return nil, fmt.Errorf("caldav: %s %s: %s", method, resp.Status, bytes.TrimSpace(msg))
+	}
+}
@@ -4,17 +4,114 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
 
+	"github.com/acai-travel/tech-challenge/internal/httpx"
 	"github.com/arran4/golang-ical"
+	lru "github.com/hashicorp/golang-lru/v2"
 )
 
+const holidayCalendarBaseURL = "https://www.officeholidays.com/ics"
+
+var calendarClient = httpx.Client(20 * time.Second)
+
+// userCalendarClient fetches the feeds users link, which may point anywhere; it's
+// replaceable in tests, whose feeds are served locally.
+var userCalendarClient = httpx.PublicClient(20 * time.Second)
+
 func LoadCalendar(ctx context.Context, link string) ([]*ics.VEvent, error) {
-	slog.InfoContext(ctx, "Loading calendar", "link", link)
+	return fetchCalendar(ctx, calendarClient, link)
+}
 
-	cal, err := ics.ParseCalendarFromUrl(link, ctx)
+// fetchCalendar loads the events of the feed at link with client.
+func fetchCalendar(ctx context.Context, client *http.Client, link string) ([]*ics.VEvent, error) {
+	// Users' feed links may hold a secret token, so only their host is logged.
+	host := link
+	if u, err := url.Parse(link); err == nil {
+		host = u.Host
+	}
+	slog.InfoContext(ctx, "Loading calendar", "host", host)
+
+	cal, err := ics.ParseCalendarFromUrl(link, ctx, client)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse calendar: %w", err)
 	}
 
 	return cal.Events(), nil
 }
+
+// calendarTTL is how long a fetched holiday feed is reused. Feeds change a few times a year.
+const calendarTTL = 12 * time.Hour
+
+// maxCachedCalendars bounds the feeds kept in memory, the least recently used going first:
+// users link theirs, so there's no telling how many there are.
+const maxCachedCalendars = 1024
+
+type cachedCalendar struct {
+	events    []*ics.VEvent
+	fetchedAt time.Time
+}
+
+// calendarKey is a cached feed: its link, and whether it was fetched as a user's feed.
+type calendarKey struct {
+	link string
+	user bool
+}
+
+var calendarCache, _ = lru.New[calendarKey, cachedCalendar](maxCachedCalendars)
+
+// loadCalendarCached is LoadCalendar with an in-memory cache per link. Failures are not cached.
+func loadCalendarCached(ctx context.Context, link string) ([]*ics.VEvent, error) {
+	return loadCalendarWithin(ctx, calendarKey{link: link}, calendarTTL)
+}
+
+// loadUserCalendar is loadCalendarCached for a feed a user linked, fetched with
+// userCalendarClient and reused within userCalendarTTL.
+func loadUserCalendar(ctx context.Context, link string) ([]*ics.VEvent, error) {
+	return loadCalendarWithin(ctx, calendarKey{link: link, user: true}, userCalendarTTL)
+}
+
+// loadCalendarWithin loads the feed of key, reusing it if fetched within ttl.
+func loadCalendarWithin(ctx context.Context, key calendarKey, ttl time.Duration) ([]*ics.VEvent, error) {
+	if c, ok := calendarCache.Get(key); ok && time.Since(c.fetchedAt) < ttl {
+		return c.events, nil
+	}
+
+	client := calendarClient
+	if key.user {
+		client = userCalendarClient
+	}
+	events, err := fetchCalendar(ctx, client, key.link)
+	if err != nil {
+		return nil, err
+	}
+
+	calendarCache.Add(key, cachedCalendar{events: events, fetchedAt: time.Now()})
+	return events, nil
+}
+
+// holidayCalendarLink returns the holiday feed for a country (and optional region), or the
+// configured local calendar when no country is given.
+func holidayCalendarLink(country, region string) string {
+	if country = slug(country); country == "" {
+		if v := os.Getenv("HOLIDAY_CALENDAR_LINK"); v != "" {
+			return v
+		}
+		return holidayCalendarBaseURL + "/spain/catalonia"
+	}
+
+	link := holidayCalendarBaseURL + "/" + country
+	if region = slug(region); region != "" {
+		link += "/" + region
+	}
+	return link
+}
+
+// slug turns "United Kingdom" into "united-kingdom", the format used by the feed URLs.
+func slug(s string) string {
+	return strings.Join(strings.Fields(strings.ToLower(s)), "-")
+}
@@ -0,0 +1,74 @@
+package assistant
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/acai-travel/tech-challenge/internal/httpx"
+)
+
+const testCalendar = `BEGIN:VCALENDAR
+VERSION:2.0
+PRODID:test
+BEGIN:VEVENT
+UID:1
+DTSTART;VALUE=DATE:20251225
+SUMMARY:Christmas Day
+END:VEVENT
+END:VCALENDAR
+`
+
+func TestLoadCalendarCached(t *testing.T) {
+	var hits atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		_, _ = w.Write([]byte(testCalendar))
+	}))
+	defer srv.Close()
+
+	for i := 0; i < 3; i++ {
+		events, err := loadCalendarCached(context.Background(), srv.URL)
+		if err != nil {
+			t.Fatalf("loadCalendarCached: %v", err)
+		}
+		if len(events) != 1 {
+			t.Fatalf("got %d events, want 1", len(events))
+		}
+	}
+
+	if n := hits.Load(); n != 1 {
+		t.Errorf("calendar fetched %d times, want 1", n)
+	}
+}
+
+// allowLocalUserCalendars lets the test fetch users' feeds from local servers, which
+// userCalendarClient refuses.
+func allowLocalUserCalendars(t *testing.T) {
+	client := userCalendarClient
+	userCalendarClient = calendarClient
+	t.Cleanup(func() { userCalendarClient = client })
+}
+
+func TestLoadUserCalendar_NotPublic(t *testing.T) {
+	var hits atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		_, _ = w.Write([]byte(testCalendar))
+	}))
+	defer srv.Close()
+
+	// The server's own holiday feed may be local, the feeds users link may not.
+	if _, err := loadCalendarCached(context.Background(), srv.URL+"/public"); err != nil {
+		t.Fatalf("loadCalendarCached: %v", err)
+	}
+	if _, err := loadUserCalendar(context.Background(), srv.URL+"/public"); !errors.Is(err, httpx.ErrNotPublic) {
+		t.Errorf("loadUserCalendar of a local feed: got %v, want ErrNotPublic", err)
+	}
+	if n := hits.Load(); n != 1 {
+		t.Errorf("local server hit %d times, want once for the holiday feed", n)
+	}
+}
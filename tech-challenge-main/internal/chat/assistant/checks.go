@@ -0,0 +1,70 @@
+package assistant
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/acai-travel/tech-challenge/internal/doctor"
+	"github.com/openai/openai-go/v2"
+)
+
+// Checks verify the assistant's OpenAI key and model, its WeatherAPI key and the local
+// holiday calendar, for doctor.Run.
+func (a *Assistant) Checks() []doctor.Check {
+	return []doctor.Check{
+		{
+			Name: "openai",
+			Run: func(ctx context.Context) error {
+				if _, err := a.cli.Models.Get(ctx, a.model); err != nil {
+					var apiErr *openai.Error
+					if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+						return fmt.Errorf("model %s is not available to the API key: %w", a.model, err)
+					}
+					return err
+				}
+				return nil
+			},
+			Fix: "check that OPENAI_API_KEY (or the tenant's openai_api_key) is a key of a project that may use " + a.model + ", and that api.openai.com is reachable",
+		},
+		{
+			Name:     "weatherapi",
+			Run:      a.checkWeather,
+			Fix:      "set WEATHER_API_KEY (or the tenant's weather_api_key) to a key from https://www.weatherapi.com/my/ with calls left, and check that api.weatherapi.com is reachable",
+			Optional: true,
+		},
+		{
+			Name: "holiday calendar",
+			Run: func(ctx context.Context) error {
+				link := holidayCalendarLink("", "")
+				if _, err := loadCalendarCached(ctx, link); err != nil {
+					return fmt.Errorf("%s: %w", link, err)
+				}
+				return nil
+			},
+			Fix:      "set HOLIDAY_CALENDAR_LINK to a reachable iCalendar (.ics) URL, or unset it for the default calendar",
+			Optional: true,
+		},
+	}
+}
+
+func (a *Assistant) checkWeather(ctx context.Context) error {
+	if a.weatherService == nil {
+		return errors.New("WEATHER_API_KEY is not set: weather tools are disabled")
+	}
+	_, err := a.weatherService.Current(ctx, "London")
+	var apiErr *WeatherAPIError
+	switch {
+	case err == nil:
+		return nil
+	case errors.As(err, &apiErr) && (apiErr.Code == 1002 || apiErr.Code == 2006):
+		return fmt.Errorf("the API key is invalid: %w", err)
+	case errors.As(err, &apiErr) && (apiErr.Code == 2008 || apiErr.Code == 2009):
+		return fmt.Errorf("the API key is disabled or its plan doesn't allow these calls: %w", err)
+	case errors.Is(err, ErrQuotaExceeded):
+		return fmt.Errorf("the API key has no calls left this month: %w", err)
+	default:
+		return err
+	}
+}
@@ -0,0 +1,28 @@
+package assistant
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestCheckWeather(t *testing.T) {
+	api := newFakeWeatherAPI(t)
+	a := NewWithProfile(Profile{Name: "test"})
+
+	for key, want := range map[string]string{
+		"":          "WEATHER_API_KEY is not set",
+		"invalid":   "the API key is invalid",
+		"exhausted": "no calls left",
+		"valid":     "",
+	} {
+		a.weatherService = nil
+		if key != "" {
+			a.weatherService = api.service(key)
+		}
+		err := a.checkWeather(context.Background())
+		if (want == "" && err != nil) || (want != "" && (err == nil || !strings.Contains(err.Error(), want))) {
+			t.Errorf("key %q: got %v, want %q", key, err, want)
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package assistant
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/acai-travel/tech-challenge/internal/audit"
+	"github.com/acai-travel/tech-challenge/internal/chat/intent"
+	"github.com/openai/openai-go/v2"
+)
+
+// Intent is a coarse category of a user turn, used to steer tool usage.
+type Intent string
+
+const (
+	IntentWeather Intent = "weather"
+	IntentOther   Intent = "other"
+)
+
+const classifierPrompt = `You are an intent classifier for a personal assistant.
+
+TASK
+- Decide whether the user's message asks for weather information (current conditions, forecast, temperature, rain, wind, etc.) for some place or time.
+
+FORMAT
+- Reply with exactly one word: weather or other.
+
+EXAMPLES
+User: What's the weather like in Barcelona tomorrow?
+You: weather
+
+User: Will it rain in London on Friday?
+You: weather
+
+User: Why does my Go program panic with a nil map?
+You: coding
+
+User: My laptop is running hot, what should I do?
+You: other
+
+User: Is next Monday a holiday?
+You: other`
+
+// classifyIntent asks a small, fast model whether the message needs live weather data.
+// It replaces keyword matching, which misfired on phrases like "my laptop is running hot".
+func (a *Assistant) classifyIntent(ctx context.Context, content string) (Intent, error) {
+	audit.Model(ctx, openai.ChatModelGPT4oMini)
+	resp, err := a.completeDeterministic(ctx, openai.ChatCompletionNewParams{
+		Model: openai.ChatModelGPT4oMini,
+		Messages: []openai.ChatCompletionMessageParamUnion{
+			openai.SystemMessage(classifierPrompt),
+			openai.UserMessage(content),
+		},
+		Temperature:         openai.Float(0),
+		MaxCompletionTokens: openai.Int(3),
+	})
+	if err != nil {
+		return IntentOther, err
+	}
+
+	if len(resp.Choices) == 0 {
+		return IntentOther, errors.New("empty response from OpenAI for intent classification")
+	}
+
+	return parseIntent(resp.Choices[0].Message.Content), nil
+}
+
+// parseIntent maps the classifier output to a known intent, defaulting to IntentOther.
+func parseIntent(s string) Intent {
+	s = strings.ToLower(strings.Trim(s, " \t\r\n.\"'"))
+	if s == string(IntentWeather) {
+		return IntentWeather
+	}
+	return IntentOther
+}
+
+const intentLabelPrompt = `You label messages sent to a personal assistant for analytics.
+
+TASK
+- Decide what the user's message asks for.
+
+FORMAT
+- Reply with exactly one label:
+  weather (conditions, forecasts, temperature for some place or time),
+  holidays (public holidays, long weekends, days off),
+  travel-planning (trips, itineraries, flights, hotels, packing, places to visit),
+  coding (programming, code, debugging, queries, developer tools),
+  general (any other question or request), or
+  other (greetings, thanks, messages that ask for nothing).
+
+EXAMPLES
+User: Will it rain in London on Friday?
+You: weather
+
+User: Plan a 3-day trip to Rome
+You: travel-planning
+
+User: My laptop is running hot, what should I do?
+You: general
+
+User: Thanks!
+You: other`
+
+// LabelIntent labels a user message for intent analytics. Unlike classifyIntent, which
+// only decides whether to force the weather tool, it knows every analytics label.
+func (a *Assistant) LabelIntent(ctx context.Context, content string) (intent.Label, error) {
+	resp, err := a.completeDeterministic(ctx, openai.ChatCompletionNewParams{
+		Model: openai.ChatModelGPT4oMini,
+		Messages: []openai.ChatCompletionMessageParamUnion{
+			openai.SystemMessage(intentLabelPrompt),
+			openai.UserMessage(a.pii.ForModel(content)),
+		},
+		Temperature:         openai.Float(0),
+		MaxCompletionTokens: openai.Int(5),
+	})
+	if err != nil {
+		return intent.Other, err
+	}
+
+	if len(resp.Choices) == 0 {
+		return intent.Other, errors.New("empty response from OpenAI for intent labeling")
+	}
+
+	return intent.Parse(resp.Choices[0].Message.Content), nil
+}
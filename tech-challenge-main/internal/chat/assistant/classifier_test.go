@@ -0,0 +1,23 @@
+package assistant
+
+import "testing"
+
+func TestParseIntent(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Intent
+	}{
+		{"weather", IntentWeather},
+		{" Weather.\n", IntentWeather},
+		{"\"weather\"", IntentWeather},
+		{"other", IntentOther},
+		{"", IntentOther},
+		{"it is about the weather", IntentOther},
+	}
+
+	for _, tt := range tests {
+		if got := parseIntent(tt.in); got != tt.want {
+			t.Errorf("parseIntent(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package assistant
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	_ "embed"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/acai-travel/tech-challenge/internal/httpx"
+)
+
+// costIndexCSV lists the average daily costs of a mid-range trip to popular cities, in EUR:
+// lodging is per room and night, the others per person and day.
+//
+//go:embed cost_index.csv
+var costIndexCSV []byte
+
+// cityCosts are the mid-range costs of a city, in EUR.
+type cityCosts struct {
+	City, Country                        string
+	Lodging, Food, Transport, Activities float64
+}
+
+var costIndex = sync.OnceValue(func() map[string]cityCosts {
+	records, err := csv.NewReader(bytes.NewReader(costIndexCSV)).ReadAll()
+	if err != nil {
+		panic("invalid cost index: " + err.Error())
+	}
+	index := make(map[string]cityCosts, len(records))
+	for _, r := range records[1:] {
+		c := cityCosts{City: r[0], Country: r[1]}
+		for i, v := range []*float64{&c.Lodging, &c.Food, &c.Transport, &c.Activities} {
+			if *v, err = strconv.ParseFloat(r[2+i], 64); err != nil {
+				panic(fmt.Sprintf("invalid cost index entry for %s: %v", r[0], err))
+			}
+		}
+		index[foldAccents(normalizeCity(c.City))] = c
+	}
+	return index
+})
+
+// lookupCityCosts returns the costs of a city, matched ignoring case and accents.
+func lookupCityCosts(city string) (cityCosts, bool) {
+	c, ok := costIndex()[foldAccents(normalizeCity(city))]
+	return c, ok
+}
+
+var accentFolder = strings.NewReplacer(
+	"á", "a", "à", "a", "ä", "a", "â", "a", "ã", "a", "é", "e", "è", "e", "ë", "e", "ê", "e",
+	"í", "i", "ï", "i", "ó", "o", "ö", "o", "ô", "o", "õ", "o", "ø", "o", "ú", "u", "ü", "u",
+	"ç", "c", "ñ", "n", "ł", "l", "ś", "s", "ż", "z", "å", "a", "æ", "ae",
+)
+
+// foldAccents replaces the accented letters of lower-case city names, so "krakow" matches
+// "Kraków".
+func foldAccents(s string) string { return accentFolder.Replace(s) }
+
+// budgetStyles scale the mid-range costs of the index for each travel style, for lodging,
+// food, transport and activities.
+var budgetStyles = map[string][4]float64{
+	"budget": {0.45, 0.55, 0.7, 0.5},
+	"mid":    {1, 1, 1, 1},
+	"luxury": {2.8, 2.2, 2.5, 2.2},
+}
+
+const (
+	exchangeRatesURL = "https://api.frankfurter.app/latest"
+	// exchangeRatesTTL is how long fetched rates are reused; the ECB publishes them daily.
+	exchangeRatesTTL = 12 * time.Hour
+)
+
+// exchangeRates are the euro reference rates of the European Central Bank, fetched from
+// the Frankfurter API and cached for exchangeRatesTTL. Failures are not cached.
+type exchangeRates struct {
+	url    string
+	client *http.Client
+
+	mu        sync.Mutex
+	rates     map[string]float64
+	date      string
+	fetchedAt time.Time
+}
+
+// euroRates is shared by all assistants, so tenants don't each fetch the rates.
+var euroRates = &exchangeRates{
+	url:    cmp.Or(os.Getenv("EXCHANGE_RATES_URL"), exchangeRatesURL),
+	client: httpx.Client(10 * time.Second),
+}
+
+// FromEUR returns how many units of currency one euro buys, and the day of the rate.
+// ok is false for currencies without a reference rate.
+func (r *exchangeRates) FromEUR(ctx context.Context, currency string) (rate float64, date string, ok bool, err error) {
+	currency = strings.ToUpper(currency)
+	if currency == "EUR" {
+		return 1, "", true, nil
+	}
+	rates, date, err := r.load(ctx)
+	if err != nil {
+		return 0, "", false, err
+	}
+	rate, ok = rates[currency]
+	return rate, date, ok, nil
+}
+
+func (r *exchangeRates) load(ctx context.Context) (map[string]float64, string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.rates != nil && time.Since(r.fetchedAt) < exchangeRatesTTL {
+		return r.rates, r.date, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, r.url+"?"+url.Values{"from": {"EUR"}}.Encode(), nil)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to create request: %w", err)
+	}
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to fetch exchange rates: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", fmt.Errorf("exchange rates returned status %d", resp.StatusCode)
+	}
+
+	var body struct {
+		Date  string             `json:"date"`
+		Rates map[string]float64 `json:"rates"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&body); err != nil {
+		return nil, "", fmt.Errorf("failed to parse exchange rates: %w", err)
+	}
+	r.rates, r.date, r.fetchedAt = body.Rates, body.Date, time.Now()
+	return r.rates, r.date, nil
+}
@@ -0,0 +1,56 @@
+package assistant
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/acai-travel/tech-challenge/internal/auth"
+	"github.com/acai-travel/tech-challenge/internal/chat/model"
+	"github.com/acai-travel/tech-challenge/internal/features"
+	"github.com/acai-travel/tech-challenge/internal/llmdebug"
+	"github.com/openai/openai-go/v2"
+)
+
+// SetDebugStore records the completion requests of replies, and the model's raw responses,
+// in s for the tenants and users with the debug_capture flag.
+func (a *Assistant) SetDebugStore(s *llmdebug.Store) {
+	a.debug = s
+}
+
+// traceCompletion returns the trace of completion turn of a reply in conv, if the caller
+// has debug_capture, for recordTrace once the turn's tools ran; nil otherwise.
+func (a *Assistant) traceCompletion(ctx context.Context, conv *model.Conversation, turn int, params openai.ChatCompletionNewParams, resp *openai.ChatCompletion, err error, took time.Duration) *llmdebug.Trace {
+	if a.debug == nil || !features.FromContext(ctx).Enabled(features.DebugCapture) {
+		return nil
+	}
+
+	t := &llmdebug.Trace{
+		Time:           time.Now().Add(-took),
+		TenantID:       auth.Tenant(ctx),
+		UserID:         auth.User(ctx),
+		ConversationID: conv.ID.Hex(),
+		Model:          params.Model,
+		Turn:           turn,
+		DurationMs:     took.Milliseconds(),
+	}
+	if body, err := json.Marshal(params); err == nil {
+		t.Request = string(body)
+	}
+	if err != nil {
+		t.Error = err.Error()
+	} else if raw := resp.RawJSON(); raw != "" {
+		t.Response = raw
+	} else if body, err := json.Marshal(resp); err == nil {
+		// Completions that didn't come from the API, e.g. scripted ones, have no raw JSON.
+		t.Response = string(body)
+	}
+	return t
+}
+
+// recordTrace stores t, if not nil, in the debug store.
+func (a *Assistant) recordTrace(t *llmdebug.Trace) {
+	if t != nil {
+		a.debug.Record(t)
+	}
+}
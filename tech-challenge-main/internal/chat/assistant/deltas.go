@@ -0,0 +1,116 @@
+package assistant
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/openai/openai-go/v2"
+)
+
+// DeltaFunc receives the text of a reply as the model writes it, a piece at a time.
+type DeltaFunc func(text string)
+
+type deltaKey struct{}
+
+// WithDeltas makes replies generated with the context stream their text to fn as the model
+// writes it, if the completion provider is a CompletionStreamer. The text is the model's
+// draft: the reply Reply returns may differ, e.g. when the safety policy replaced it.
+func WithDeltas(ctx context.Context, fn DeltaFunc) context.Context {
+	return context.WithValue(ctx, deltaKey{}, fn)
+}
+
+// CompletionStreamer is implemented by completion providers that stream completions,
+// passing each piece of their content to onDelta as it arrives. Content written before the
+// model calls tools isn't passed on.
+type CompletionStreamer interface {
+	NewStreamed(ctx context.Context, params openai.ChatCompletionNewParams, onDelta func(string)) (*openai.ChatCompletion, error)
+}
+
+// complete creates a completion of a reply, streaming it to the DeltaFunc of ctx if there is
+// one and the provider can.
+func (a *Assistant) complete(ctx context.Context, params openai.ChatCompletionNewParams) (*openai.ChatCompletion, error) {
+	if fn, _ := ctx.Value(deltaKey{}).(DeltaFunc); fn != nil {
+		if s, ok := a.completions.(CompletionStreamer); ok {
+			return s.NewStreamed(ctx, params, fn)
+		}
+	}
+	return a.completions.New(ctx, params)
+}
+
+// openAICompletions is the completion service of the OpenAI client, streaming with
+// NewStreaming.
+type openAICompletions struct {
+	*openai.ChatCompletionService
+}
+
+func (c openAICompletions) NewStreamed(ctx context.Context, params openai.ChatCompletionNewParams, onDelta func(string)) (*openai.ChatCompletion, error) {
+	params.StreamOptions = openai.ChatCompletionStreamOptionsParam{IncludeUsage: openai.Bool(true)}
+	stream := c.NewStreaming(ctx, params)
+	defer stream.Close()
+
+	var acc openai.ChatCompletionAccumulator
+	calling := false
+	for stream.Next() {
+		chunk := stream.Current()
+		acc.AddChunk(chunk)
+		if len(chunk.Choices) == 0 {
+			continue
+		}
+		delta := chunk.Choices[0].Delta
+		calling = calling || len(delta.ToolCalls) > 0
+		if delta.Content != "" && !calling {
+			onDelta(delta.Content)
+		}
+	}
+	if err := stream.Err(); err != nil {
+		return nil, err
+	}
+	return decodedCompletion(&acc.ChatCompletion)
+}
+
+// decodedCompletion returns an accumulated completion as if it was decoded from a response:
+// tool calls, and the raw JSON traces record, are only read from the JSON of a response.
+func decodedCompletion(acc *openai.ChatCompletion) (*openai.ChatCompletion, error) {
+	type function struct {
+		Name      string `json:"name"`
+		Arguments string `json:"arguments"`
+	}
+	type toolCall struct {
+		ID       string   `json:"id"`
+		Type     string   `json:"type"`
+		Function function `json:"function"`
+	}
+	choices := make([]map[string]any, 0, len(acc.Choices))
+	for _, c := range acc.Choices {
+		calls := make([]toolCall, 0, len(c.Message.ToolCalls))
+		for _, t := range c.Message.ToolCalls {
+			calls = append(calls, toolCall{ID: t.ID, Type: "function", Function: function{Name: t.Function.Name, Arguments: t.Function.Arguments}})
+		}
+		message := map[string]any{"role": "assistant", "content": c.Message.Content, "refusal": c.Message.Refusal}
+		if len(calls) > 0 {
+			message["tool_calls"] = calls
+		}
+		choices = append(choices, map[string]any{"index": c.Index, "finish_reason": c.FinishReason, "message": message, "logprobs": nil})
+	}
+	body, err := json.Marshal(map[string]any{
+		"id":      acc.ID,
+		"object":  "chat.completion",
+		"created": acc.Created,
+		"model":   acc.Model,
+		"choices": choices,
+		"usage": map[string]any{
+			"prompt_tokens":     acc.Usage.PromptTokens,
+			"completion_tokens": acc.Usage.CompletionTokens,
+			"total_tokens":      acc.Usage.TotalTokens,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var out openai.ChatCompletion
+	if err := json.Unmarshal(body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
@@ -0,0 +1,67 @@
+package assistant
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/acai-travel/tech-challenge/internal/chat/assistant/assistanttest"
+	"github.com/openai/openai-go/v2"
+	"github.com/openai/openai-go/v2/option"
+)
+
+func TestReply_Deltas(t *testing.T) {
+	a, _ := newScriptedAssistant(
+		assistanttest.Call("compute_date", `{"offset":"+3 days","base_date":"2025-01-01"}`),
+		assistanttest.Answer("That's Saturday, January 4."),
+	)
+
+	var deltas []string
+	ctx := WithDeltas(context.Background(), func(text string) { deltas = append(deltas, text) })
+	reply, err := a.Reply(ctx, question("What's the date 3 days after New Year?"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(deltas, ""); got != reply || len(deltas) < 2 {
+		t.Errorf("deltas = %q; want the answer in pieces", deltas)
+	}
+}
+
+func TestOpenAICompletions_NewStreamed(t *testing.T) {
+	chunks := []string{
+		`{"id":"c1","object":"chat.completion.chunk","model":"m","choices":[{"index":0,"delta":{"role":"assistant","tool_calls":[{"index":0,"id":"call_1","type":"function","function":{"name":"compute_date","arguments":""}}]}}]}`,
+		`{"id":"c1","object":"chat.completion.chunk","model":"m","choices":[{"index":0,"delta":{"tool_calls":[{"index":0,"function":{"arguments":"{\"offset\":\"tomorrow\"}"}}]},"finish_reason":"tool_calls"}]}`,
+		`{"id":"c1","object":"chat.completion.chunk","model":"m","choices":[],"usage":{"prompt_tokens":7,"completion_tokens":3,"total_tokens":10}}`,
+	}
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		for _, c := range chunks {
+			fmt.Fprintf(w, "data: %s\n\n", c)
+		}
+		fmt.Fprint(w, "data: [DONE]\n\n")
+	}))
+	defer api.Close()
+
+	cli := openai.NewClient(option.WithBaseURL(api.URL), option.WithAPIKey("test"), option.WithMaxRetries(0))
+	var deltas []string
+	resp, err := openAICompletions{&cli.Chat.Completions}.NewStreamed(context.Background(), openai.ChatCompletionNewParams{Model: "m"},
+		func(text string) { deltas = append(deltas, text) })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(deltas) != 0 {
+		t.Errorf("deltas = %q; want none for a tool call", deltas)
+	}
+	// The assistant sends the tool calls back with the results, so they must survive.
+	calls := resp.Choices[0].Message.ToParam().OfAssistant.ToolCalls
+	if len(calls) != 1 || calls[0].OfFunction == nil || calls[0].OfFunction.ID != "call_1" ||
+		calls[0].OfFunction.Function.Arguments != `{"offset":"tomorrow"}` {
+		t.Errorf("tool calls = %+v", calls)
+	}
+	if resp.Usage.TotalTokens != 10 || resp.RawJSON() == "" {
+		t.Errorf("usage = %+v, raw JSON %q", resp.Usage, resp.RawJSON())
+	}
+}
@@ -0,0 +1,83 @@
+package assistant
+
+import (
+	"expvar"
+	"strconv"
+	"time"
+)
+
+// toolLoopIterations counts replies by the number of completions they took, keyed "1".."15",
+// plus "exhausted" for replies that gave up. Exposed on the admin port under /debug/vars.
+var toolLoopIterations = expvar.NewMap("assistant_tool_loop_iterations")
+
+// toolsPerTurn counts the completions of replies that called tools by how many they
+// called, keyed "1".."4" and "5+".
+var toolsPerTurn = expvar.NewMap("assistant_tools_per_turn")
+
+// toolLoopTime counts replies by the time their completions ("llm/...") and their tool
+// calls ("tools/...") took, in buckets from "le_250ms" to "le_30s" and "gt_30s", and adds
+// both up in "llm_ms" and "tools_ms".
+var toolLoopTime = expvar.NewMap("assistant_tool_loop_time")
+
+// weatherErrors counts failed WeatherService calls by class: location_not_found,
+// quota_exceeded, provider_unavailable or other.
+var weatherErrors = expvar.NewMap("assistant_weather_errors")
+
+// toolInvalidArgs counts tool calls rejected by schema validation, keyed by tool name.
+var toolInvalidArgs = expvar.NewMap("assistant_tool_invalid_args")
+
+// weatherCache counts WeatherService queries by how they were served: fresh (from the cache),
+// refreshed (from the provider, replacing a cached entry), miss (from the provider) or stale.
+var weatherCache = expvar.NewMap("assistant_weather_cache")
+
+// safetyInterventions counts replies changed by the safety policy: refused (replaced by the
+// refusal message) or disclaimer (disclaimers appended).
+var safetyInterventions = expvar.NewMap("assistant_safety_interventions")
+
+// responseCacheStats counts deterministic completions by how they were served: hit (from
+// the response cache), shared (waiting for an identical request in flight) or miss.
+var responseCacheStats = expvar.NewMap("assistant_response_cache")
+
+// toolCacheStats counts calls of cacheable tools by "tool/outcome": hit (from the tool
+// cache), shared (waiting for an identical call in flight) or miss.
+var toolCacheStats = expvar.NewMap("assistant_tool_cache")
+
+// routingDecisions counts routed replies by complexity: simple, tools or complex. Its
+// cost_saved_usd entry adds up what the completions of replies routed to the cheap model
+// would have cost more with the assistant's own model.
+var routingDecisions = expvar.NewMap("assistant_model_routing")
+
+// toolLoop adds up where the tool loop of a reply spent its time, for toolLoopTime.
+type toolLoop struct {
+	llm, tools time.Duration
+}
+
+// turn counts a completion whose calls tools took took.
+func (l *toolLoop) turn(calls int, took time.Duration) {
+	l.tools += took
+	key := "5+"
+	if calls < 5 {
+		key = strconv.Itoa(calls)
+	}
+	toolsPerTurn.Add(key, 1)
+}
+
+// record counts the reply in toolLoopTime.
+func (l *toolLoop) record() {
+	toolLoopTime.Add("llm/"+durationBucket(l.llm), 1)
+	toolLoopTime.Add("tools/"+durationBucket(l.tools), 1)
+	toolLoopTime.Add("llm_ms", l.llm.Milliseconds())
+	toolLoopTime.Add("tools_ms", l.tools.Milliseconds())
+}
+
+var durationBuckets = []time.Duration{250 * time.Millisecond, 500 * time.Millisecond, time.Second, 2 * time.Second, 5 * time.Second, 10 * time.Second, 30 * time.Second}
+
+// durationBucket labels the smallest bucket of durationBuckets d fits in, e.g. "le_500ms".
+func durationBucket(d time.Duration) string {
+	for _, b := range durationBuckets {
+		if d <= b {
+			return "le_" + b.String()
+		}
+	}
+	return "gt_" + durationBuckets[len(durationBuckets)-1].String()
+}
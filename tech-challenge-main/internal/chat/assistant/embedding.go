@@ -0,0 +1,61 @@
+package assistant
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/acai-travel/tech-challenge/internal/audit"
+	"github.com/acai-travel/tech-challenge/internal/textx"
+	"github.com/openai/openai-go/v2"
+)
+
+// EmbeddingModel produces the vectors used to search past conversations. Vectors from
+// different models are not comparable, so changing it requires re-indexing.
+const EmbeddingModel = openai.EmbeddingModelTextEmbedding3Small
+
+// maxEmbeddingInput bounds the characters embedded per text, well within the model's
+// token limit for any language.
+const maxEmbeddingInput = 8000
+
+// EmbeddingModel reports the model Embed uses.
+func (a *Assistant) EmbeddingModel() string { return EmbeddingModel }
+
+// Embed returns one vector per text, in order.
+func (a *Assistant) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	if len(texts) == 0 {
+		return nil, nil
+	}
+
+	input := make([]string, len(texts))
+	for i, t := range texts {
+		// The API rejects empty input.
+		if input[i] = textx.Truncate(a.pii.ForModel(t), maxEmbeddingInput); input[i] == "" {
+			input[i] = " "
+		}
+	}
+
+	audit.Model(ctx, EmbeddingModel)
+	resp, err := a.cli.Embeddings.New(ctx, openai.EmbeddingNewParams{
+		Model: EmbeddingModel,
+		Input: openai.EmbeddingNewParamsInputUnion{OfArrayOfStrings: input},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Data) != len(texts) {
+		return nil, fmt.Errorf("expected %d embeddings, got %d", len(texts), len(resp.Data))
+	}
+
+	out := make([][]float32, len(texts))
+	for _, d := range resp.Data {
+		if d.Index < 0 || int(d.Index) >= len(out) {
+			return nil, fmt.Errorf("embedding index %d out of range", d.Index)
+		}
+		v := make([]float32, len(d.Embedding))
+		for i, f := range d.Embedding {
+			v[i] = float32(f)
+		}
+		out[d.Index] = v
+	}
+	return out, nil
+}
@@ -0,0 +1,149 @@
+package assistant
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/acai-travel/tech-challenge/internal/audit"
+	"github.com/openai/openai-go/v2"
+	"golang.org/x/text/language"
+	"golang.org/x/text/language/display"
+)
+
+const languagePrompt = `You detect the language of a message sent to a personal assistant.
+
+FORMAT
+- Reply with only the ISO 639-1 code of the language the message is written in, e.g. en, es, de.
+- Reply und if the message is too short or mixed to tell, e.g. "ok", "Paris?" or "👍".`
+
+// DetectLanguage asks a small, fast model which language a message is written in. It
+// returns the BCP 47 tag, or "" if the message doesn't tell.
+func (a *Assistant) DetectLanguage(ctx context.Context, content string) (string, error) {
+	audit.Model(ctx, openai.ChatModelGPT4oMini)
+	resp, err := a.completeDeterministic(ctx, openai.ChatCompletionNewParams{
+		Model: openai.ChatModelGPT4oMini,
+		Messages: []openai.ChatCompletionMessageParamUnion{
+			openai.SystemMessage(languagePrompt),
+			openai.UserMessage(a.pii.ForModel(content)),
+		},
+		Temperature:         openai.Float(0),
+		MaxCompletionTokens: openai.Int(5),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	if len(resp.Choices) == 0 {
+		return "", errors.New("empty response from OpenAI for language detection")
+	}
+
+	tag, _ := CanonicalLanguage(strings.Trim(resp.Choices[0].Message.Content, " \t\r\n.\"'`"))
+	return tag, nil
+}
+
+// CanonicalLanguage returns the canonical form of a BCP 47 tag, e.g. "pt-BR" for "pt_br",
+// and false if s isn't a known language.
+func CanonicalLanguage(s string) (string, bool) {
+	tag, err := language.Parse(strings.ReplaceAll(strings.TrimSpace(s), "_", "-"))
+	if err != nil {
+		return "", false
+	}
+	// Tags without a language, like "und" or "und-US", only guess one.
+	if _, conf := tag.Base(); conf != language.Exact {
+		return "", false
+	}
+	return tag.String(), true
+}
+
+// languageInstruction is the system prompt addendum locking replies to a language.
+func languageInstruction(tag string) string {
+	name := tag
+	if t, err := language.Parse(tag); err == nil {
+		if n := display.English.Tags().Name(t); n != "" {
+			name = fmt.Sprintf("%s (%s)", n, tag)
+		}
+	}
+	instruction := fmt.Sprintf("\n\nLANGUAGE\nThis conversation is in %s. Always reply in it, even when the user's latest message is short, a name or otherwise ambiguous, unless the user explicitly asks you to use another language.", name)
+	if l, ok := toolLocaleFor(tag); ok {
+		// Clarifying questions are part of the reply; an example keeps them in the language too.
+		instruction += fmt.Sprintf(" Ask clarifying questions in it too, e.g. %q when a place is missing or ambiguous.", l.askPlace)
+	}
+	return instruction
+}
+
+// toolLocale helps the model extract tool arguments, such as places and dates, from
+// conversations in a language other than English.
+type toolLocale struct {
+	// places is added to the descriptions of the tools taking a place.
+	places string
+	// dates is added to the description of compute_date.
+	dates string
+	// askPlace is an example of how to ask which place the user means.
+	askPlace string
+}
+
+// toolLocales are the tool locales by base language.
+var toolLocales = map[string]toolLocale{
+	"es": {
+		places:   "The user writes in Spanish: pass places by their usual English or local name, e.g. 'London' for 'Londres', and add the country in English when the user gives it, e.g. 'Valencia, Spain' for 'Valencia (España)'.",
+		dates:    "Offsets may be passed in Spanish as the user wrote them, e.g. 'el próximo viernes', 'pasado mañana' or 'dentro de 3 días'.",
+		askPlace: "¿De qué ciudad me hablas?",
+	},
+	"ca": {
+		places:   "The user writes in Catalan: pass places by their usual English or local name, e.g. 'London' for 'Londres' or 'Zaragoza' for 'Saragossa', and add the country in English when the user gives it, e.g. 'Perpignan, France' for 'Perpinyà (França)'.",
+		dates:    "Offsets may be passed in Catalan as the user wrote them, e.g. 'divendres vinent', 'demà passat' or 'd'aquí a 3 dies'.",
+		askPlace: "De quina ciutat parles?",
+	},
+	"fr": {
+		places:   "The user writes in French: pass places by their usual English or local name, e.g. 'London' for 'Londres', and add the country in English when the user gives it, e.g. 'Valence, Spain' for 'Valence (Espagne)'.",
+		dates:    "Offsets may be passed in French as the user wrote them, e.g. 'vendredi prochain', 'après-demain' or 'dans 3 jours'.",
+		askPlace: "De quelle ville parlez-vous ?",
+	},
+}
+
+// placeTools are the tools taking a place, whose descriptions get a locale's places hint.
+var placeTools = map[string]bool{
+	"get_weather": true, "get_trip_weather": true, "get_route_weather": true, "get_marine_conditions": true,
+	"get_ski_conditions": true, "get_transit_status": true, "find_places": true, "get_today_date": true,
+	"find_long_weekends": true, "suggest_travel_dates": true, "search_flights": true, "search_hotels": true,
+	"estimate_trip_budget": true,
+}
+
+// toolLocaleFor returns the tool locale of a conversation language, if it has one.
+func toolLocaleFor(tag string) (toolLocale, bool) {
+	t, err := language.Parse(tag)
+	if err != nil {
+		return toolLocale{}, false
+	}
+	base, _ := t.Base()
+	l, ok := toolLocales[base.String()]
+	return l, ok
+}
+
+// localizedParams is Toolset.Params with the descriptions of tools augmented for
+// conversations in lang, so arguments are extracted as reliably as from English messages.
+func (ts Toolset) localizedParams(lang string) []openai.ChatCompletionToolUnionParam {
+	l, ok := toolLocaleFor(lang)
+	if !ok {
+		return ts.Params()
+	}
+
+	params := make([]openai.ChatCompletionToolUnionParam, 0, len(ts))
+	for _, t := range ts {
+		def := t.Definition()
+		hint := ""
+		switch {
+		case def.Name == "compute_date":
+			hint = l.dates
+		case placeTools[def.Name]:
+			hint = l.places
+		}
+		if hint != "" {
+			def.Description = openai.String(strings.TrimSpace(def.Description.Value + " " + hint))
+		}
+		params = append(params, openai.ChatCompletionFunctionTool(def))
+	}
+	return params
+}
@@ -0,0 +1,66 @@
+package assistant
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCanonicalLanguage(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+		ok   bool
+	}{
+		{"es", "es", true},
+		{" EN ", "en", true},
+		{"pt_br", "pt-BR", true},
+		{"und", "", false},
+		{"", "", false},
+		{"not a language", "", false},
+	}
+
+	for _, tt := range tests {
+		if got, ok := CanonicalLanguage(tt.in); got != tt.want || ok != tt.ok {
+			t.Errorf("CanonicalLanguage(%q) = %q, %v, want %q, %v", tt.in, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestLanguageInstruction(t *testing.T) {
+	if got := languageInstruction("es"); !strings.Contains(got, "Spanish (es)") {
+		t.Errorf("expected the language name in the instruction, got %q", got)
+	}
+	if got := languageInstruction("ca-ES"); !strings.Contains(got, "De quina ciutat parles?") {
+		t.Errorf("expected a clarifying question in Catalan, got %q", got)
+	}
+	if got := languageInstruction("de"); strings.Contains(got, "clarifying") {
+		t.Errorf("expected no clarifying example without a tool locale, got %q", got)
+	}
+}
+
+func TestLocalizedParams(t *testing.T) {
+	tools := Toolset{&weatherTool{}, &computeDateTool{}, &holidaysTool{}}
+	descriptions := func(lang string) map[string]string {
+		out := map[string]string{}
+		for _, p := range tools.localizedParams(lang) {
+			out[p.OfFunction.Function.Name] = p.OfFunction.Function.Description.Value
+		}
+		return out
+	}
+
+	en := descriptions("en")
+	for name, want := range map[string]string{"get_weather": "'London' for 'Londres'", "compute_date": "'el próximo viernes'"} {
+		got := descriptions("es-419")[name]
+		if !strings.Contains(got, want) || !strings.HasPrefix(got, en[name]) {
+			t.Errorf("Spanish description of %s = %q, want the English one plus %q", name, got, want)
+		}
+	}
+	if fr := descriptions("fr"); fr["get_holidays"] != en["get_holidays"] || !strings.Contains(fr["compute_date"], "'vendredi prochain'") {
+		t.Errorf("French descriptions = %v", fr)
+	}
+	for _, lang := range []string{"", "de", "not a language"} {
+		if got := descriptions(lang); got["get_weather"] != en["get_weather"] {
+			t.Errorf("description in %q = %q, want the English one", lang, got["get_weather"])
+		}
+	}
+}
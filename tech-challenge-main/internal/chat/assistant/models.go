@@ -0,0 +1,125 @@
+package assistant
+
+import (
+	"context"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/acai-travel/tech-challenge/internal/chat/model"
+	"github.com/openai/openai-go/v2"
+)
+
+// DefaultModel generates replies unless the profile or conversation overrides it.
+const DefaultModel = openai.ChatModelO1
+
+// SupportsTemperature reports whether a model accepts a sampling temperature. Reasoning
+// models (o-series) only run at their fixed default and reject the parameter.
+func SupportsTemperature(name string) bool {
+	return !(len(name) > 1 && name[0] == 'o' && name[1] >= '0' && name[1] <= '9')
+}
+
+// applySettings applies a conversation's generation overrides to a completion request.
+// An explicit output cap takes precedence over the style profile's, and maxOutput, e.g. of
+// the request or the server, lowers either; 0 is no cap.
+func applySettings(params *openai.ChatCompletionNewParams, defaultModel string, s *model.GenerationSettings, style styleProfile, maxOutput int64) {
+	params.Model = defaultModel
+	maxTokens := style.maxTokens
+
+	if s != nil {
+		if m := strings.TrimSpace(s.Model); m != "" {
+			params.Model = m
+		}
+		if s.Temperature != nil && SupportsTemperature(params.Model) {
+			params.Temperature = openai.Float(*s.Temperature)
+		}
+		if s.MaxOutputTokens > 0 {
+			maxTokens = s.MaxOutputTokens
+		}
+	}
+
+	if maxTokens = lowerCap(maxTokens, maxOutput); maxTokens > 0 {
+		params.MaxCompletionTokens = openai.Int(maxTokens)
+	}
+}
+
+// lowerCap returns the lower of two caps, where 0 is no cap.
+func lowerCap(a, b int64) int64 {
+	if a == 0 || (b > 0 && b < a) {
+		return b
+	}
+	return a
+}
+
+type maxOutputKey struct{}
+
+// WithMaxOutputTokens caps the output tokens of replies generated with ctx, e.g. for a
+// client with a small display area, lowering the conversation's cap if it has one.
+func WithMaxOutputTokens(ctx context.Context, n int64) context.Context {
+	return context.WithValue(ctx, maxOutputKey{}, n)
+}
+
+// SetMaxOutputTokens caps the output tokens of every reply, whatever the conversation or
+// request asks for; 0 is no cap.
+func (a *Assistant) SetMaxOutputTokens(n int64) {
+	a.maxOutputTokens = max(n, 0)
+}
+
+// envMaxOutputTokens reads ASSISTANT_MAX_OUTPUT_TOKENS, the server's cap on every reply.
+func envMaxOutputTokens() int64 {
+	if n, err := strconv.ParseInt(os.Getenv("ASSISTANT_MAX_OUTPUT_TOKENS"), 10, 64); err == nil && n > 0 {
+		return n
+	}
+	return 0
+}
+
+// outputCap is the cap of replies generated with ctx besides the conversation's: the lower
+// of the request's and the assistant's.
+func (a *Assistant) outputCap(ctx context.Context) int64 {
+	n, _ := ctx.Value(maxOutputKey{}).(int64)
+	return lowerCap(max(n, 0), a.maxOutputTokens)
+}
+
+// modelPrice is the list price of a model in USD per million tokens.
+type modelPrice struct {
+	input, output float64
+}
+
+// modelPrices are OpenAI's list prices for the models conversations may select, to
+// estimate what replies cost. Unknown models have no estimate.
+var modelPrices = map[string]modelPrice{
+	openai.ChatModelO1:          {input: 15, output: 60},
+	openai.ChatModelGPT4o:       {input: 2.5, output: 10},
+	openai.ChatModelGPT4oMini:   {input: 0.15, output: 0.6},
+	openai.ChatModelGPT4_1:      {input: 2, output: 8},
+	openai.ChatModelGPT4_1Mini:  {input: 0.4, output: 1.6},
+	openai.ChatModelGPT4_1Nano:  {input: 0.1, output: 0.4},
+	openai.ChatModelO3Mini:      {input: 1.1, output: 4.4},
+	openai.ChatModelO4Mini:      {input: 1.1, output: 4.4},
+	openai.ChatModelGPT3_5Turbo: {input: 0.5, output: 1.5},
+}
+
+// completionCost estimates the cost in USD of a completion by a model, and reports false
+// if the model's price is unknown.
+func completionCost(name string, usage openai.CompletionUsage) (float64, bool) {
+	p, ok := modelPrices[name]
+	if !ok {
+		return 0, false
+	}
+	return (float64(usage.PromptTokens)*p.input + float64(usage.CompletionTokens)*p.output) / 1e6, true
+}
+
+// addCompletionUsage adds the tokens and estimated cost of a completion by a model to u.
+func addCompletionUsage(u *model.Usage, name string, usage openai.CompletionUsage) {
+	u.PromptTokens += usage.PromptTokens
+	u.CompletionTokens += usage.CompletionTokens
+	if cost, ok := completionCost(name, usage); ok {
+		u.CostUSD += cost
+	}
+}
+
+// truncatedToolCall reports whether resp calls tools but ran out of output tokens, so the
+// arguments of its calls may be cut short.
+func truncatedToolCall(resp *openai.ChatCompletion) bool {
+	return len(resp.Choices) > 0 && resp.Choices[0].FinishReason == "length" && len(resp.Choices[0].Message.ToolCalls) > 0
+}
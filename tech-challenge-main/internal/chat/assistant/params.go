@@ -0,0 +1,117 @@
+package assistant
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/openai/openai-go/v2"
+)
+
+// paramsCache holds the parameters derived by paramsOf, by argument type.
+var paramsCache sync.Map
+
+// paramsOf derives the parameters of a tool definition from the struct its arguments are
+// decoded into, so the two can't drift apart. Fields are named by their json tag and
+// described by their desc tag; their validate tag holds comma-separated rules:
+//   - required: the argument must be given;
+//   - min=N, max=N: bounds of a number;
+//   - oneof=a b c: the allowed values, separated by spaces.
+//
+// Strings, booleans, integers, floats, time.Time (an RFC3339 date-time), dateArg (a date
+// as parseDateArg accepts it), slices and nested
+// structs are supported, through pointers too. It panics on other types, and on invalid
+// rules, as definitions are fixed at compile time.
+func paramsOf(args any) openai.FunctionParameters {
+	t := reflect.TypeOf(args)
+	if p, ok := paramsCache.Load(t); ok {
+		return p.(openai.FunctionParameters)
+	}
+	p := openai.FunctionParameters(schemaOf(t))
+	paramsCache.Store(t, p)
+	return p
+}
+
+var (
+	timeType    = reflect.TypeFor[time.Time]()
+	dateArgType = reflect.TypeFor[dateArg]()
+)
+
+func schemaOf(t reflect.Type) map[string]any {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch {
+	case t == timeType:
+		return map[string]any{"type": "string", "format": "date-time"}
+	case t == dateArgType:
+		return map[string]any{"type": "string", "format": "date"}
+	case t.Kind() == reflect.String:
+		return map[string]any{"type": "string"}
+	case t.Kind() == reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case t.Kind() >= reflect.Int && t.Kind() <= reflect.Uint64:
+		return map[string]any{"type": "integer"}
+	case t.Kind() == reflect.Float32 || t.Kind() == reflect.Float64:
+		return map[string]any{"type": "number"}
+	case t.Kind() == reflect.Slice:
+		return map[string]any{"type": "array", "items": schemaOf(t.Elem())}
+	case t.Kind() == reflect.Struct:
+		return objectSchema(t)
+	default:
+		panic(fmt.Sprintf("tool arguments: unsupported type %s", t))
+	}
+}
+
+func objectSchema(t reflect.Type) map[string]any {
+	properties := map[string]any{}
+	required := []string{}
+	for i := range t.NumField() {
+		f := t.Field(i)
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if !f.IsExported() || name == "-" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+
+		prop := schemaOf(f.Type)
+		if desc := f.Tag.Get("desc"); desc != "" {
+			prop["description"] = desc
+		}
+		for _, rule := range strings.Split(f.Tag.Get("validate"), ",") {
+			key, value, _ := strings.Cut(rule, "=")
+			switch key {
+			case "":
+			case "required":
+				required = append(required, name)
+			case "min", "max":
+				n, err := strconv.ParseFloat(value, 64)
+				if err != nil {
+					panic(fmt.Sprintf("tool arguments: invalid rule %q of %s.%s", rule, t, f.Name))
+				}
+				prop[map[string]string{"min": "minimum", "max": "maximum"}[key]] = n
+			case "oneof":
+				var enum []any
+				for _, v := range strings.Fields(value) {
+					enum = append(enum, v)
+				}
+				prop["enum"] = enum
+			default:
+				panic(fmt.Sprintf("tool arguments: unknown rule %q of %s.%s", rule, t, f.Name))
+			}
+		}
+		properties[name] = prop
+	}
+
+	s := map[string]any{"type": "object", "properties": properties}
+	if len(required) > 0 {
+		s["required"] = required
+	}
+	return s
+}
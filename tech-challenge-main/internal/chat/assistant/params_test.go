@@ -0,0 +1,57 @@
+package assistant
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestParamsOf(t *testing.T) {
+	type stop struct {
+		City   string    `json:"city" validate:"required"`
+		Arrive time.Time `json:"arrive,omitempty"`
+	}
+	type args struct {
+		Stops    []stop   `json:"stops" validate:"required" desc:"Stops in order"`
+		Budget   *float64 `json:"budget,omitempty" validate:"min=0.5"`
+		Units    string   `json:"units,omitempty" validate:"oneof=metric imperial"`
+		Detailed bool     `json:"detailed,omitempty"`
+		internal int
+		Ignored  string `json:"-"`
+	}
+
+	got, err := json.Marshal(paramsOf(args{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"properties":{` +
+		`"budget":{"minimum":0.5,"type":"number"},` +
+		`"detailed":{"type":"boolean"},` +
+		`"stops":{"description":"Stops in order","items":{"properties":{"arrive":{"format":"date-time","type":"string"},"city":{"type":"string"}},"required":["city"],"type":"object"},"type":"array"},` +
+		`"units":{"enum":["metric","imperial"],"type":"string"}},` +
+		`"required":["stops"],"type":"object"}`
+	if string(got) != want {
+		t.Errorf("paramsOf =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestParamsOf_Weather(t *testing.T) {
+	// The schema validateArgs checks calls against comes from the arguments struct.
+	if err := validateArgs((&weatherTool{}).Definition(), `{"location":"Oslo","forecast_days":15}`); err == nil {
+		t.Error("expected forecast_days above the struct's max to be rejected")
+	}
+	if err := validateArgs((&weatherTool{}).Definition(), `{"forecast_days":3}`); err == nil {
+		t.Error("expected the required location to be enforced")
+	}
+}
+
+func TestParamsOf_InvalidRule(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected a panic for an unknown rule")
+		}
+	}()
+	paramsOf(struct {
+		Days int `json:"days" validate:"positive"`
+	}{})
+}
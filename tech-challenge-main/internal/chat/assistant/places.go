@@ -0,0 +1,369 @@
+package assistant
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/acai-travel/tech-challenge/internal/httpx"
+	"github.com/hashicorp/golang-lru/v2/expirable"
+)
+
+// PlacesProvider finds restaurants and attractions for the find_places tool. The default
+// searches OpenStreetMap through the Overpass API; providers with ratings, e.g. Google
+// Places or Foursquare, can be set with SetPlacesProvider.
+type PlacesProvider interface {
+	// SearchPlaces returns the places matching q, closest first, or an error wrapping
+	// ErrLocationNotFound if q.Near can't be found.
+	SearchPlaces(ctx context.Context, q PlaceQuery) ([]Place, error)
+}
+
+// PlaceQuery is a search for places near a location.
+type PlaceQuery struct {
+	// Category is one of placeCategories, e.g. "restaurant".
+	Category string
+	// Keywords narrow the search, e.g. "tapas" or "vegan"; empty for any place.
+	Keywords string
+	// Near is a place name, address or "lat,lon", e.g. "Sagrada Familia, Barcelona".
+	Near    string
+	RadiusM int
+	// OpenAt is the local time at the place the result must be open at; zero for any time.
+	// Only its wall clock is used.
+	OpenAt time.Time
+	// MinRating is the lowest rating out of 5 to return; 0 for any.
+	MinRating  float64
+	MaxResults int
+}
+
+// Place is a result of a place search.
+type Place struct {
+	Name     string
+	Category string
+	// Details describe the place, e.g. its cuisine.
+	Details   string
+	Address   string
+	DistanceM int
+	// Rating is out of 5, from Reviews reviews; 0 when the provider has no ratings.
+	Rating  float64
+	Reviews int
+	// OpeningHours are as published, e.g. "Mo-Sa 12:00-23:00".
+	OpeningHours string
+	// Open is whether the place is open at the query's OpenAt; nil when unknown or not asked.
+	Open    *bool
+	Website string
+}
+
+// placeCategories are the categories find_places searches.
+var placeCategories = []string{"restaurant", "cafe", "bar", "museum", "attraction", "park"}
+
+// osmCategoryTags are the OpenStreetMap tags of each of placeCategories.
+var osmCategoryTags = map[string][][2]string{
+	"restaurant": {{"amenity", "restaurant"}},
+	"cafe":       {{"amenity", "cafe"}},
+	"bar":        {{"amenity", "bar"}, {"amenity", "pub"}},
+	"museum":     {{"tourism", "museum"}, {"tourism", "gallery"}},
+	"attraction": {{"tourism", "attraction"}, {"tourism", "viewpoint"}, {"historic", "monument"}},
+	"park":       {{"leisure", "park"}, {"leisure", "garden"}},
+}
+
+const (
+	overpassURL  = "https://overpass-api.de/api/interpreter"
+	nominatimURL = "https://nominatim.openstreetmap.org"
+	// geocodeTTL is how long geocoded places are reused; Nominatim allows one request a
+	// second.
+	geocodeTTL = 24 * time.Hour
+	// osmUserAgent identifies the assistant, as required by the Nominatim usage policy.
+	osmUserAgent = "personal-ai-assistant (+https://github.com/acai-travel/tech-challenge)"
+)
+
+// osmPlaces searches OpenStreetMap: Nominatim geocodes the location, and Overpass finds
+// the places around it. OpenStreetMap has no ratings.
+type osmPlaces struct {
+	overpassURL  string
+	nominatimURL string
+	client       *http.Client
+	geocoded     *expirable.LRU[string, RoutePoint]
+}
+
+// placeSearch is shared by all assistants, so tenants share geocoded locations.
+var placeSearch = newOSMPlaces()
+
+func newOSMPlaces() *osmPlaces {
+	return &osmPlaces{
+		overpassURL:  cmp.Or(os.Getenv("OVERPASS_URL"), overpassURL),
+		nominatimURL: cmp.Or(os.Getenv("NOMINATIM_URL"), nominatimURL),
+		client:       httpx.Client(20 * time.Second),
+		geocoded:     expirable.NewLRU[string, RoutePoint](1024, nil, geocodeTTL),
+	}
+}
+
+func (p *osmPlaces) SearchPlaces(ctx context.Context, q PlaceQuery) ([]Place, error) {
+	tags, ok := osmCategoryTags[q.Category]
+	if !ok {
+		return nil, fmt.Errorf("unknown place category %q", q.Category)
+	}
+	center, err := p.geocode(ctx, q.Near)
+	if err != nil {
+		return nil, err
+	}
+
+	var ql strings.Builder
+	ql.WriteString("[out:json][timeout:15];(")
+	for _, tag := range tags {
+		fmt.Fprintf(&ql, "nwr[%q=%q][name](around:%d,%.6f,%.6f);", tag[0], tag[1], q.RadiusM, center.Lat, center.Lon)
+	}
+	ql.WriteString(");out center tags 300;")
+
+	var result struct {
+		Elements []struct {
+			Lat, Lon float64
+			Center   *RoutePoint `json:"center"`
+			Tags     map[string]string
+		} `json:"elements"`
+	}
+	if err := p.get(ctx, p.overpassURL+"?"+url.Values{"data": {ql.String()}}.Encode(), &result); err != nil {
+		return nil, err
+	}
+
+	keywords := strings.Fields(strings.ToLower(q.Keywords))
+	var out []Place
+	for _, e := range result.Elements {
+		at := RoutePoint{Lat: e.Lat, Lon: e.Lon}
+		if e.Center != nil {
+			at = *e.Center
+		}
+		place := osmPlace(q.Category, e.Tags)
+		if !matchesKeywords(e.Tags, keywords) {
+			continue
+		}
+		place.DistanceM = int(distanceKm(center, at) * 1000)
+		if !q.OpenAt.IsZero() && place.OpeningHours != "" {
+			if open, known := openingHoursOpen(place.OpeningHours, q.OpenAt); known {
+				if !open {
+					continue
+				}
+				place.Open = &open
+			}
+		}
+		out = append(out, place)
+	}
+
+	// Places known to be open come before those with unknown hours, then closest first.
+	slices.SortStableFunc(out, func(a, b Place) int {
+		return cmp.Or(cmp.Compare(btoi(a.Open == nil), btoi(b.Open == nil)), cmp.Compare(a.DistanceM, b.DistanceM))
+	})
+	return out[:min(len(out), q.MaxResults)], nil
+}
+
+func btoi(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func osmPlace(category string, tags map[string]string) Place {
+	place := Place{
+		Name:         tags["name"],
+		Category:     category,
+		OpeningHours: tags["opening_hours"],
+		Website:      cmp.Or(tags["website"], tags["contact:website"]),
+	}
+	if cuisine := tags["cuisine"]; cuisine != "" {
+		place.Details = strings.ReplaceAll(strings.ReplaceAll(cuisine, ";", ", "), "_", " ") + " cuisine"
+	}
+	if street := tags["addr:street"]; street != "" {
+		place.Address = strings.TrimSpace(street + " " + tags["addr:housenumber"])
+	}
+	return place
+}
+
+// matchesKeywords reports whether all keywords appear in the name, cuisine or description
+// of a place.
+func matchesKeywords(tags map[string]string, keywords []string) bool {
+	text := strings.ToLower(tags["name"] + " " + tags["cuisine"] + " " + tags["description"] + " " + tags["diet:vegan"])
+	for _, k := range keywords {
+		if !strings.Contains(text, k) {
+			return false
+		}
+	}
+	return true
+}
+
+// geocode resolves a location to coordinates, parsing "lat,lon" without a request.
+func (p *osmPlaces) geocode(ctx context.Context, location string) (RoutePoint, error) {
+	if lat, lon, ok := strings.Cut(location, ","); ok {
+		la, err1 := strconv.ParseFloat(strings.TrimSpace(lat), 64)
+		lo, err2 := strconv.ParseFloat(strings.TrimSpace(lon), 64)
+		if err1 == nil && err2 == nil {
+			return RoutePoint{Lat: la, Lon: lo}, nil
+		}
+	}
+
+	key := strings.ToLower(strings.Join(strings.Fields(location), " "))
+	if at, ok := p.geocoded.Get(key); ok {
+		return at, nil
+	}
+	var results []struct {
+		Lat string `json:"lat"`
+		Lon string `json:"lon"`
+	}
+	params := url.Values{"q": {location}, "format": {"jsonv2"}, "limit": {"1"}}
+	if err := p.get(ctx, p.nominatimURL+"/search?"+params.Encode(), &results); err != nil {
+		return RoutePoint{}, err
+	}
+	if len(results) == 0 {
+		return RoutePoint{}, fmt.Errorf("%w: %s", ErrLocationNotFound, location)
+	}
+	lat, err1 := strconv.ParseFloat(results[0].Lat, 64)
+	lon, err2 := strconv.ParseFloat(results[0].Lon, 64)
+	if err := errors.Join(err1, err2); err != nil {
+		return RoutePoint{}, fmt.Errorf("invalid coordinates for %s: %w", location, err)
+	}
+	at := RoutePoint{Lat: lat, Lon: lon}
+	p.geocoded.Add(key, at)
+	return at, nil
+}
+
+func (p *osmPlaces) get(ctx context.Context, url string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", osmUserAgent)
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to search OpenStreetMap: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("OpenStreetMap returned status %d", resp.StatusCode)
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 8<<20)).Decode(v); err != nil {
+		return fmt.Errorf("invalid OpenStreetMap response: %w", err)
+	}
+	return nil
+}
+
+// osmWeekdays are the OpenStreetMap abbreviations of the days of the week, from Sunday as
+// time.Weekday.
+var osmWeekdays = []string{"Su", "Mo", "Tu", "We", "Th", "Fr", "Sa"}
+
+// openingHoursOpen reports whether a place with the OpenStreetMap opening_hours spec is
+// open at the wall clock of t. Only the common "Mo-Fr 09:00-18:00; Sa 10:00-14:00; Su off"
+// form is understood: known is false for other specs, e.g. with public holidays or months.
+// As in OpenStreetMap, later rules replace earlier ones for the days they name.
+func openingHoursOpen(spec string, t time.Time) (open, known bool) {
+	spec = strings.TrimSpace(spec)
+	if spec == "24/7" {
+		return true, true
+	}
+	minute := t.Hour()*60 + t.Minute()
+	today, yesterday := int(t.Weekday()), (int(t.Weekday())+6)%7
+
+	var todayRanges, yesterdayRanges [][2]int
+	seenToday, seenYesterday := false, false
+	for _, rule := range strings.Split(spec, ";") {
+		rule = strings.TrimSpace(rule)
+		if rule == "" {
+			continue
+		}
+		days, hours, ok := strings.Cut(rule, " ")
+		if !ok || strings.ContainsAny(days, ":") {
+			// Times without days apply every day.
+			days, hours = "Mo-Su", rule
+		}
+		set, ok := parseOSMDays(days)
+		if !ok {
+			return false, false
+		}
+		var ranges [][2]int
+		if h := strings.TrimSpace(hours); h != "off" && h != "closed" {
+			if ranges, ok = parseOSMTimes(h); !ok {
+				return false, false
+			}
+		}
+		if set[today] {
+			todayRanges, seenToday = ranges, true
+		}
+		if set[yesterday] {
+			yesterdayRanges, seenYesterday = ranges, true
+		}
+	}
+	if !seenToday && !seenYesterday {
+		return false, true
+	}
+	for _, r := range todayRanges {
+		if minute >= r[0] && (minute < r[1] || r[1] <= r[0]) {
+			return true, true
+		}
+	}
+	// Ranges past midnight, e.g. "20:00-02:00", run into the next day.
+	for _, r := range yesterdayRanges {
+		if r[1] <= r[0] && minute < r[1] {
+			return true, true
+		}
+	}
+	return false, true
+}
+
+// parseOSMDays parses a list of days such as "Mo-Fr,Su" into a set indexed by
+// time.Weekday.
+func parseOSMDays(s string) (set [7]bool, ok bool) {
+	for _, part := range strings.Split(s, ",") {
+		from, to, isRange := strings.Cut(strings.TrimSpace(part), "-")
+		i, j := slices.Index(osmWeekdays, from), slices.Index(osmWeekdays, to)
+		if !isRange {
+			j = i
+		}
+		if i < 0 || j < 0 {
+			return set, false
+		}
+		for d := i; ; d = (d + 1) % 7 {
+			set[d] = true
+			if d == j {
+				break
+			}
+		}
+	}
+	return set, true
+}
+
+// parseOSMTimes parses a list of times such as "12:00-16:00,19:30-23:30" into minutes of
+// the day. A range ending at or before its start runs past midnight.
+func parseOSMTimes(s string) ([][2]int, bool) {
+	var out [][2]int
+	for _, part := range strings.Split(s, ",") {
+		from, to, ok := strings.Cut(strings.TrimSpace(part), "-")
+		if !ok {
+			return nil, false
+		}
+		a, okA := parseOSMTime(from)
+		b, okB := parseOSMTime(to)
+		if !okA || !okB {
+			return nil, false
+		}
+		out = append(out, [2]int{a, b % (24 * 60)})
+	}
+	return out, true
+}
+
+func parseOSMTime(s string) (int, bool) {
+	h, m, ok := strings.Cut(s, ":")
+	hh, err1 := strconv.Atoi(h)
+	mm, err2 := strconv.Atoi(m)
+	if !ok || err1 != nil || err2 != nil || hh < 0 || hh > 24 || mm < 0 || mm > 59 {
+		return 0, false
+	}
+	return hh*60 + mm, true
+}
@@ -0,0 +1,112 @@
+package assistant
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/golang-lru/v2/expirable"
+)
+
+func TestOpeningHoursOpen(t *testing.T) {
+	sunday := func(hhmm string) time.Time {
+		tm, _ := time.Parse("2006-01-02 15:04", "2026-05-03 "+hhmm) // a Sunday
+		return tm
+	}
+	cases := []struct {
+		spec  string
+		at    time.Time
+		open  bool
+		known bool
+		name  string
+	}{
+		{"24/7", sunday("03:00"), true, true, "always open"},
+		{"Mo-Sa 12:00-23:00", sunday("13:00"), false, true, "closed on unlisted days"},
+		{"Mo-Su 12:00-16:00,19:30-23:30", sunday("20:00"), true, true, "second range"},
+		{"Mo-Su 12:00-16:00,19:30-23:30", sunday("17:00"), false, true, "between ranges"},
+		{"Mo-Su 10:00-20:00; Su off", sunday("13:00"), false, true, "later rule wins"},
+		{"Fr-Sa 20:00-03:00", sunday("02:00"), true, true, "past midnight from Saturday"},
+		{"Fr-Sa 20:00-03:00", sunday("04:00"), false, true, "after the night closes"},
+		{"12:00-24:00", sunday("23:59"), true, true, "times without days"},
+		{"Mo-Fr 09:00-17:00; PH off", sunday("10:00"), false, false, "public holidays"},
+		{"sunrise-sunset", sunday("10:00"), false, false, "unparsed times"},
+	}
+	for _, tc := range cases {
+		open, known := openingHoursOpen(tc.spec, tc.at)
+		if open != tc.open || known != tc.known {
+			t.Errorf("%s: openingHoursOpen(%q, %s) = %v, %v; want %v, %v", tc.name, tc.spec, tc.at.Format("Mon 15:04"), open, known, tc.open, tc.known)
+		}
+	}
+}
+
+func TestOSMPlaces(t *testing.T) {
+	var geocodes int
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("User-Agent") == "" {
+			t.Error("request without a User-Agent")
+		}
+		switch r.URL.Path {
+		case "/search":
+			geocodes++
+			if !strings.Contains(r.URL.Query().Get("q"), "Sagrada") {
+				fmt.Fprint(w, `[]`)
+				return
+			}
+			fmt.Fprint(w, `[{"lat":"41.4036","lon":"2.1744"}]`)
+		case "/interpreter":
+			if q := r.URL.Query().Get("data"); !strings.Contains(q, `["amenity"="restaurant"]`) || !strings.Contains(q, "around:800,41.403600,2.174400") {
+				t.Errorf("unexpected Overpass query %q", q)
+			}
+			fmt.Fprint(w, `{"elements":[
+				{"lat":41.4050,"lon":2.1744,"tags":{"name":"Bar Far","cuisine":"tapas","opening_hours":"Mo-Su 12:00-23:00"}},
+				{"lat":41.4040,"lon":2.1744,"tags":{"name":"Tapas Near","cuisine":"spanish;tapas","addr:street":"Carrer de Mallorca","addr:housenumber":"401"}},
+				{"center":{"lat":41.4037,"lon":2.1744},"tags":{"name":"Closed Sundays","cuisine":"tapas","opening_hours":"Mo-Sa 12:00-23:00"}},
+				{"lat":41.4036,"lon":2.1745,"tags":{"name":"Pizza Place","cuisine":"pizza"}}
+			]}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer api.Close()
+
+	p := &osmPlaces{
+		overpassURL:  api.URL + "/interpreter",
+		nominatimURL: api.URL,
+		client:       &http.Client{Timeout: time.Second},
+		geocoded:     expirable.NewLRU[string, RoutePoint](16, nil, time.Hour),
+	}
+	tool := &placesTool{provider: p}
+	args := `{"category":"restaurant","near":"Sagrada Familia, Barcelona","keywords":"tapas","open_at":"2026-05-03T13:00:00+02:00","min_rating":4,"radius_m":800}`
+
+	got, err := tool.Call(context.Background(), args)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		`Restaurants matching "tapas" open Sunday 13:00 within 800 m of Sagrada Familia, Barcelona`,
+		"1. Bar Far, tapas cuisine, 155 m away, open at that time, hours Mo-Su 12:00-23:00",
+		"2. Tapas Near, spanish, tapas cuisine, 44 m away, Carrer de Mallorca 401, hours unknown",
+		"min_rating was not applied",
+		"unknown hours may be closed",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Call() = %q; want it to contain %q", got, want)
+		}
+	}
+	for _, unwanted := range []string{"Closed Sundays", "Pizza Place"} {
+		if strings.Contains(got, unwanted) {
+			t.Errorf("Call() = %q; want %q left out", got, unwanted)
+		}
+	}
+
+	if _, err := tool.Call(context.Background(), args); err != nil || geocodes != 1 {
+		t.Errorf("expected the location to be geocoded once, got %d (%v)", geocodes, err)
+	}
+	if _, err := tool.Call(context.Background(), `{"category":"restaurant","near":"Nowhere"}`); err == nil || !strings.Contains(err.Error(), "No location matches") {
+		t.Errorf("expected a location not found error, got %v", err)
+	}
+}
@@ -0,0 +1,130 @@
+package assistant
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/acai-travel/tech-challenge/internal/audit"
+	"github.com/acai-travel/tech-challenge/internal/features"
+	"github.com/openai/openai-go/v2"
+)
+
+// ErrInvalidToolPolicy is returned by Reply when a requested tool policy cannot be honored,
+// e.g. it forces a tool that does not exist or is denied.
+var ErrInvalidToolPolicy = errors.New("invalid tool policy")
+
+// ToolPolicy constrains which tools the model may use for a single turn.
+type ToolPolicy struct {
+	// Force names a tool the model must call on its first completion.
+	Force string
+	// Deny lists tools removed from the turn.
+	Deny []string
+	// Disable removes all tools from the turn.
+	Disable bool
+}
+
+type toolPolicyKey struct{}
+
+// WithToolPolicy attaches a per-request tool policy to the context. It is combined with the
+// server policy: denials from both apply, and a forced tool overrides intent-based forcing.
+func WithToolPolicy(ctx context.Context, p ToolPolicy) context.Context {
+	return context.WithValue(ctx, toolPolicyKey{}, p)
+}
+
+func toolPolicyFromContext(ctx context.Context) ToolPolicy {
+	p, _ := ctx.Value(toolPolicyKey{}).(ToolPolicy)
+	return p
+}
+
+// serverToolPolicy is the deployment-wide tool policy.
+type serverToolPolicy struct {
+	// deny lists tools never offered to the model.
+	deny []string
+	// intentTools maps a classified intent to the tool forced for it.
+	intentTools map[Intent]string
+}
+
+// loadServerToolPolicy reads the server policy from the environment:
+//   - ASSISTANT_DENY_TOOLS: comma-separated tool names never offered to the model.
+//   - ASSISTANT_FORCE_WEATHER_TOOL: set to "false" to stop forcing get_weather on weather intent.
+func loadServerToolPolicy() serverToolPolicy {
+	p := serverToolPolicy{
+		deny:        splitList(os.Getenv("ASSISTANT_DENY_TOOLS")),
+		intentTools: map[Intent]string{IntentWeather: "get_weather"},
+	}
+
+	if strings.EqualFold(os.Getenv("ASSISTANT_FORCE_WEATHER_TOOL"), "false") {
+		delete(p.intentTools, IntentWeather)
+	}
+
+	return p
+}
+
+// turnTools is the resolved set of tools and tool choice for a turn.
+type turnTools struct {
+	tools  Toolset
+	choice openai.ChatCompletionToolChoiceOptionUnionParam
+	// forced names the tool the first completion must call, if any.
+	forced string
+}
+
+// resolveTools combines the server policy, the request policy and (lazily) the classified
+// intent of the last user message into the tools offered for this turn.
+func (a *Assistant) resolveTools(ctx context.Context, lastUser string) (turnTools, error) {
+	req := toolPolicyFromContext(ctx)
+
+	if req.Disable {
+		return turnTools{}, nil
+	}
+
+	tools := a.tools.Enabled(features.FromContext(ctx)).Available(ctx).Without(a.policy.deny...).Without(req.Deny...)
+
+	force := req.Force
+	if force != "" && tools.Get(force) == nil {
+		return turnTools{}, fmt.Errorf("%w: tool %q is not available", ErrInvalidToolPolicy, force)
+	}
+
+	// Classify only when it can change the outcome, it costs an extra round trip.
+	if force == "" && a.policy.canForce(tools) && lastUser != "" {
+		intent, err := a.classifyIntent(ctx, lastUser)
+		if err != nil {
+			slog.WarnContext(ctx, "Intent classification failed; letting the model choose tools", "error", err)
+		} else {
+			audit.Detail(ctx, "intent", string(intent))
+		}
+		if name, ok := a.policy.intentTools[intent]; ok && tools.Get(name) != nil {
+			slog.InfoContext(ctx, "Intent detected, forcing tool", "intent", intent, "tool", name)
+			force = name
+		}
+	}
+
+	out := turnTools{tools: tools, forced: force}
+	if force != "" {
+		out.choice = openai.ToolChoiceOptionFunctionToolChoice(openai.ChatCompletionNamedToolChoiceFunctionParam{Name: force})
+	}
+	return out, nil
+}
+
+// canForce reports whether any intent would force one of tools.
+func (p serverToolPolicy) canForce(tools Toolset) bool {
+	for _, name := range p.intentTools {
+		if tools.Get(name) != nil {
+			return true
+		}
+	}
+	return false
+}
+
+func splitList(s string) []string {
+	var out []string
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			out = append(out, v)
+		}
+	}
+	return out
+}
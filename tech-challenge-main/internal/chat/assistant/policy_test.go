@@ -0,0 +1,96 @@
+package assistant
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/acai-travel/tech-challenge/internal/features"
+)
+
+func TestResolveTools(t *testing.T) {
+	a := &Assistant{
+		tools:  Toolset{&weatherTool{}, &todayDateTool{}, &holidaysTool{}},
+		policy: serverToolPolicy{deny: []string{"get_holidays"}},
+	}
+
+	names := func(ts Toolset) []string {
+		var out []string
+		for _, t := range ts {
+			out = append(out, t.Name())
+		}
+		return out
+	}
+
+	t.Run("server deny removes tools", func(t *testing.T) {
+		turn, err := a.resolveTools(context.Background(), "hi")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got := names(turn.tools); len(got) != 2 || got[0] != "get_weather" || got[1] != "get_today_date" {
+			t.Fatalf("unexpected tools: %v", got)
+		}
+	})
+
+	t.Run("request deny and force", func(t *testing.T) {
+		ctx := WithToolPolicy(context.Background(), ToolPolicy{Force: "get_today_date", Deny: []string{"get_weather"}})
+		turn, err := a.resolveTools(ctx, "hi")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got := names(turn.tools); len(got) != 1 || got[0] != "get_today_date" {
+			t.Fatalf("unexpected tools: %v", got)
+		}
+		if fc := turn.choice.OfFunctionToolChoice; fc == nil || fc.Function.Name != "get_today_date" {
+			t.Fatalf("expected get_today_date to be forced, got %+v", turn.choice)
+		}
+	})
+
+	t.Run("forcing a server-denied tool fails", func(t *testing.T) {
+		ctx := WithToolPolicy(context.Background(), ToolPolicy{Force: "get_holidays"})
+		if _, err := a.resolveTools(ctx, "hi"); !errors.Is(err, ErrInvalidToolPolicy) {
+			t.Fatalf("expected ErrInvalidToolPolicy, got %v", err)
+		}
+	})
+
+	t.Run("disable removes all tools", func(t *testing.T) {
+		ctx := WithToolPolicy(context.Background(), ToolPolicy{Disable: true})
+		turn, err := a.resolveTools(ctx, "hi")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(turn.tools) != 0 {
+			t.Fatalf("expected no tools, got %v", names(turn.tools))
+		}
+	})
+	t.Run("feature flags gate tools", func(t *testing.T) {
+		ctx := features.WithSet(context.Background(), features.Set{features.Weather: "false"})
+		turn, err := a.resolveTools(ctx, "hi")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got := names(turn.tools); len(got) != 1 || got[0] != "get_today_date" {
+			t.Fatalf("unexpected tools: %v", got)
+		}
+	})
+
+	t.Run("recall needs a recaller", func(t *testing.T) {
+		a := &Assistant{tools: Toolset{&todayDateTool{}, &recallTool{}}}
+
+		turn, err := a.resolveTools(context.Background(), "hi")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got := names(turn.tools); len(got) != 1 || got[0] != "get_today_date" {
+			t.Fatalf("unexpected tools without recaller: %v", got)
+		}
+
+		turn, err = a.resolveTools(WithRecaller(context.Background(), fakeRecaller(nil)), "hi")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got := names(turn.tools); len(got) != 2 || got[1] != "recall_past_conversations" {
+			t.Fatalf("unexpected tools with recaller: %v", got)
+		}
+	})
+}
@@ -0,0 +1,44 @@
+package assistant
+
+// Profile defines a named assistant persona: its instructions, tools and model.
+type Profile struct {
+	// Name identifies the assistant, e.g. in logs.
+	Name string
+	// Prompt replaces the system prompt composed from the offered tools, e.g. to try a new
+	// prompt; empty composes it.
+	Prompt string
+	// Persona is added to the composed system prompt to specialize the assistant.
+	Persona string
+	// Tools lists the tool names offered; nil offers every tool, and an empty list none.
+	Tools []string
+	// Model generates replies unless the conversation overrides it; empty uses DefaultModel.
+	Model string
+}
+
+// GeneralProfile is the default all-purpose assistant.
+var GeneralProfile = Profile{Name: "general"}
+
+// TravelProfile focuses on trip planning, with holiday, weather and date tools.
+var TravelProfile = Profile{
+	Name: "travel",
+	Persona: `- You are a travel planning assistant. Proactively consider weather, public holidays and long
+  weekends at the destination when suggesting dates or activities.
+- Keep suggestions practical: when to go, what to pack, and what might be closed on holidays.`,
+	Tools: []string{"get_weather", "get_trip_weather", "get_route_weather", "get_ski_conditions", "get_marine_conditions", "get_transit_status", "get_travel_advisory", "check_visa_requirements", "search_flights", "search_hotels", "find_places", "estimate_trip_budget", "get_today_date", "compute_date", "get_holidays", "find_free_slots", "add_calendar_event", "update_calendar_event", "find_long_weekends", "suggest_travel_dates", "recall_past_conversations"},
+}
+
+// SupportProfile answers questions about using this assistant, without tools.
+var SupportProfile = Profile{
+	Name: "support",
+	Prompt: `You are the support assistant for a personal AI assistant service.
+
+TASK
+- Help users understand what the assistant can do: current date and time, real-time weather and
+  forecasts, ski and sea conditions, public holidays, long weekend planning, travel dates that make
+  the most of days off, and general questions.
+- Explain how to start, continue, list and view conversations, and how to retry a failed reply.
+- If you don't know the answer, say so and suggest contacting the operator of the service.
+- You cannot look up live data yourself; suggest asking the general assistant instead.`,
+	Tools: []string{},
+	Model: "gpt-4o-mini",
+}
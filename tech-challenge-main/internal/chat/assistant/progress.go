@@ -0,0 +1,127 @@
+package assistant
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/acai-travel/tech-challenge/internal/textx"
+)
+
+// ProgressKind is the stage of a reply a Progress event reports.
+type ProgressKind string
+
+const (
+	// ProgressToolCalled is reported before a tool runs.
+	ProgressToolCalled ProgressKind = "tool_called"
+	// ProgressToolDone is reported when a tool returned a result.
+	ProgressToolDone ProgressKind = "tool_done"
+	// ProgressToolFailed is reported when a tool failed; the model is told and may retry.
+	ProgressToolFailed ProgressKind = "tool_failed"
+)
+
+// Progress is an intermediate event of a reply, for clients to show what the assistant is
+// doing during multi-second tool loops.
+type Progress struct {
+	Kind ProgressKind
+	Tool string
+	// Message describes the event to the user, e.g. "calling get_weather(Barcelona, 3 days)".
+	Message string
+}
+
+// ProgressFunc receives the progress of a reply. It is called synchronously from the reply
+// loop, so it must not block.
+type ProgressFunc func(Progress)
+
+type progressKey struct{}
+
+// WithProgress makes replies generated with the context report their progress to fn, after
+// any function the context already reports to.
+func WithProgress(ctx context.Context, fn ProgressFunc) context.Context {
+	if prev, _ := ctx.Value(progressKey{}).(ProgressFunc); prev != nil {
+		next := fn
+		fn = func(p Progress) {
+			prev(p)
+			next(p)
+		}
+	}
+	return context.WithValue(ctx, progressKey{}, fn)
+}
+
+// WithoutCallbacks returns ctx without the progress, usage, refusal and delta functions
+// attached to it, for replies on the side, e.g. shadow replies, that mustn't report to the
+// caller's.
+func WithoutCallbacks(ctx context.Context) context.Context {
+	ctx = context.WithValue(ctx, progressKey{}, ProgressFunc(nil))
+	ctx = context.WithValue(ctx, usageKey{}, UsageFunc(nil))
+	ctx = context.WithValue(ctx, refusalKey{}, RefusalFunc(nil))
+	return context.WithValue(ctx, deltaKey{}, DeltaFunc(nil))
+}
+
+func reportProgress(ctx context.Context, p Progress) {
+	if fn, _ := ctx.Value(progressKey{}).(ProgressFunc); fn != nil {
+		fn(p)
+	}
+}
+
+// progressDescriber is implemented by tools describing their calls better than the generic
+// list of argument values, and what they return.
+type progressDescriber interface {
+	DescribeCall(args string) string
+	DescribeResult() string
+}
+
+func toolCalledProgress(t Tool, args string) Progress {
+	msg := ""
+	if d, ok := t.(progressDescriber); ok {
+		msg = d.DescribeCall(args)
+	}
+	if msg == "" {
+		msg = fmt.Sprintf("calling %s(%s)", t.Name(), strings.Join(argValues(args), ", "))
+	}
+	return Progress{Kind: ProgressToolCalled, Tool: t.Name(), Message: msg}
+}
+
+func toolDoneProgress(t Tool, err error) Progress {
+	if err != nil {
+		return Progress{Kind: ProgressToolFailed, Tool: t.Name(), Message: t.Name() + " failed"}
+	}
+	msg := t.Name() + " finished"
+	if d, ok := t.(progressDescriber); ok {
+		msg = d.DescribeResult()
+	}
+	return Progress{Kind: ProgressToolDone, Tool: t.Name(), Message: msg}
+}
+
+// maxArgValue keeps descriptions of calls with long arguments readable.
+const maxArgValue = 40
+
+// argValues returns the top-level values of a JSON object in the order the model wrote
+// them, or nothing if args isn't one.
+func argValues(args string) []string {
+	dec := json.NewDecoder(strings.NewReader(args))
+	dec.UseNumber()
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return nil
+	}
+
+	var values []string
+	for dec.More() {
+		if _, err := dec.Token(); err != nil { // key
+			return values
+		}
+		var v json.RawMessage
+		if err := dec.Decode(&v); err != nil {
+			return values
+		}
+
+		s := string(bytes.TrimSpace(v))
+		if strings.HasPrefix(s, `"`) {
+			_ = json.Unmarshal(v, &s)
+		}
+		values = append(values, textx.Truncate(s, maxArgValue))
+	}
+	return values
+}
@@ -0,0 +1,66 @@
+package assistant
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestToolProgress(t *testing.T) {
+	weather := &weatherTool{}
+	tests := []struct {
+		tool Tool
+		args string
+		want string
+	}{
+		{weather, `{"location":"Barcelona","forecast_days":3}`, "calling get_weather(Barcelona, 3 days)"},
+		{weather, `{"location":"Barcelona"}`, "calling get_weather(Barcelona)"},
+		{weather, `{}`, "calling get_weather()"},
+		{&todayDateTool{}, `{"location":"Lisbon, Portugal","utc_offset":1}`, "calling get_today_date(Lisbon, Portugal, 1)"},
+	}
+	for _, tt := range tests {
+		if got := toolCalledProgress(tt.tool, tt.args); got.Message != tt.want || got.Kind != ProgressToolCalled {
+			t.Errorf("toolCalledProgress(%s) = %+v, want %q", tt.args, got, tt.want)
+		}
+	}
+
+	if got := toolDoneProgress(weather, nil); got.Message != "weather received" || got.Kind != ProgressToolDone {
+		t.Errorf("done = %+v", got)
+	}
+	if got := toolDoneProgress(&todayDateTool{}, nil); got.Message != "get_today_date finished" {
+		t.Errorf("done = %+v", got)
+	}
+	if got := toolDoneProgress(weather, errors.New("boom")); got.Kind != ProgressToolFailed {
+		t.Errorf("failed = %+v", got)
+	}
+}
+
+func TestArgValues(t *testing.T) {
+	if got := argValues(`{"b":"x","a":[1, 2],"c":null}`); !slices.Equal(got, []string{"x", "[1, 2]", "null"}) {
+		t.Errorf("argValues = %q, want values in written order", got)
+	}
+	if got := argValues(`not json`); got != nil {
+		t.Errorf("argValues(invalid) = %q, want nil", got)
+	}
+}
+
+func TestReportProgress(t *testing.T) {
+	reportProgress(context.Background(), Progress{}) // no listener
+
+	var got []Progress
+	ctx := WithProgress(context.Background(), func(p Progress) { got = append(got, p) })
+	reportProgress(ctx, Progress{Kind: ProgressToolCalled, Tool: "get_weather"})
+	if len(got) != 1 || got[0].Tool != "get_weather" {
+		t.Errorf("reported %+v", got)
+	}
+
+	// A listener added later is called too, after the earlier one.
+	var order []string
+	ctx = WithProgress(WithProgress(context.Background(), func(Progress) { order = append(order, "outer") }),
+		func(Progress) { order = append(order, "inner") })
+	reportProgress(ctx, Progress{})
+	if !slices.Equal(order, []string{"outer", "inner"}) {
+		t.Errorf("listeners called in order %q", order)
+	}
+}
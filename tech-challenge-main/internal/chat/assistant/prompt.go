@@ -0,0 +1,77 @@
+package assistant
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// guidedTool is implemented by tools that tell the model when and how to use them. Their
+// guidance is only part of the system prompt when they are offered, so a profile or
+// feature flag without a tool doesn't describe it.
+type guidedTool interface {
+	Guidance() string
+}
+
+const corePrompt = `You are a helpful AI assistant with access to specialized tools.`
+
+const weatherStylePrompt = `
+
+WEATHER RESPONSE STYLE (IMPORTANT)
+- Write a concise, readable answer tailored to the user’s request. Do **not** just echo tool output.
+   • Start with a single line header: **<City, Country> — <Day label>** (e.g., **Barcelona, Spain — Friday**).
+   • Then 3–5 short bullet points covering:
+     – Conditions (e.g., Sunny / Light rain).
+     – Temperatures: High/Low in °C (add °F only if the user used °F).
+     – Rain chance/precip if available; otherwise omit.
+     – Wind (speed + direction if available).
+   • Keep numbers clean (no excessive decimals). Avoid long paragraphs.
+   • If the user specifies part of day (e.g., "morning"), focus the summary on that period; if hourly detail isn’t available, state what’s most likely and include the day’s range.`
+
+const savedPlacesPrompt = `- When the user refers to a personal place ("home", "the office", "grandma's"), pass it as said as the location of a tool: it may be one of their saved places.`
+
+const userCalendarsPrompt = `- The user may have linked their own calendars, e.g. work or school holidays. When they ask whether they have anything on ("do I have anything on the next long weekend?"), call **get_holidays** with calendar "all", or the calendar they name, for those dates.`
+
+// systemPrompt composes the system prompt of a reply offering tools: the core behavior, the
+// profile's persona, the guidance of each offered tool, the weather response style when
+// get_weather is offered, and what the assistant knows of the user: their saved places and
+// calendars. A profile's own Prompt is used as is instead.
+func (a *Assistant) systemPrompt(ctx context.Context, tools Toolset) string {
+	if a.prompt != "" {
+		return a.prompt
+	}
+
+	var b strings.Builder
+	b.WriteString(corePrompt)
+	if a.persona != "" {
+		b.WriteString("\n\nPERSONA\n" + a.persona)
+	}
+
+	if len(tools) > 0 {
+		b.WriteString("\n\nTOOL USE")
+		n := 0
+		for _, t := range tools {
+			if g, ok := t.(guidedTool); ok {
+				n++
+				fmt.Fprintf(&b, "\n%d) %s", n, g.Guidance())
+			}
+		}
+		fmt.Fprintf(&b, "\n%d) For non-tool queries, answer normally.", n+1)
+	}
+
+	if tools.Get("get_weather") != nil {
+		b.WriteString(weatherStylePrompt)
+	}
+
+	var memory []string
+	if aliasResolverFromContext(ctx) != nil && len(tools) > 0 {
+		memory = append(memory, savedPlacesPrompt)
+	}
+	if userCalendarsFromContext(ctx) != nil && tools.Get("get_holidays") != nil {
+		memory = append(memory, userCalendarsPrompt)
+	}
+	if len(memory) > 0 {
+		b.WriteString("\n\nUSER MEMORY\n" + strings.Join(memory, "\n"))
+	}
+	return b.String()
+}
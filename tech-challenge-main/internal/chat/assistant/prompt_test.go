@@ -0,0 +1,80 @@
+package assistant
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/acai-travel/tech-challenge/internal/chat/assistant/assistanttest"
+)
+
+func TestSystemPrompt(t *testing.T) {
+	ctx := context.Background()
+	tools := Toolset{&weatherTool{}, &computeDateTool{}}
+
+	t.Run("describes the offered tools", func(t *testing.T) {
+		got := (&Assistant{}).systemPrompt(ctx, tools)
+		for _, want := range []string{"1) Always call **get_weather**", "2) Use **compute_date**", "3) For non-tool queries", "WEATHER RESPONSE STYLE"} {
+			if !strings.Contains(got, want) {
+				t.Errorf("prompt is missing %q:\n%s", want, got)
+			}
+		}
+		if strings.Contains(got, "get_holidays") || strings.Contains(got, "PERSONA") || strings.Contains(got, "USER MEMORY") {
+			t.Errorf("prompt describes what isn't offered:\n%s", got)
+		}
+	})
+
+	t.Run("drops the weather sections without get_weather", func(t *testing.T) {
+		got := (&Assistant{}).systemPrompt(ctx, tools.Without("get_weather"))
+		if strings.Contains(got, "get_weather") || strings.Contains(got, "WEATHER RESPONSE STYLE") {
+			t.Errorf("prompt describes weather:\n%s", got)
+		}
+	})
+
+	t.Run("no tools", func(t *testing.T) {
+		if got := (&Assistant{}).systemPrompt(ctx, nil); got != corePrompt {
+			t.Errorf("prompt = %q, want only the core behavior", got)
+		}
+	})
+
+	t.Run("persona and saved places", func(t *testing.T) {
+		a := NewWithProfile(TravelProfile)
+		ctx := WithAliasResolver(ctx, fakeAliases{})
+		got := a.systemPrompt(ctx, tools)
+		if !strings.Contains(got, "PERSONA\n- You are a travel planning assistant") {
+			t.Errorf("prompt is missing the travel persona:\n%s", got)
+		}
+		if !strings.Contains(got, "USER MEMORY\n- When the user refers to a personal place") {
+			t.Errorf("prompt is missing the saved places:\n%s", got)
+		}
+	})
+
+	t.Run("user calendars", func(t *testing.T) {
+		ctx := WithUserCalendars(ctx, fakeCalendars{})
+		if got := (&Assistant{}).systemPrompt(ctx, Toolset{&holidaysTool{}}); !strings.Contains(got, "USER MEMORY\n- The user may have linked their own calendars") {
+			t.Errorf("prompt is missing the user's calendars:\n%s", got)
+		}
+		if got := (&Assistant{}).systemPrompt(ctx, tools); strings.Contains(got, "calendars") {
+			t.Errorf("prompt mentions calendars without get_holidays:\n%s", got)
+		}
+	})
+
+	t.Run("profile prompt replaces it", func(t *testing.T) {
+		a := NewWithProfile(Profile{Name: "test", Prompt: "Answer in haiku."})
+		if got := a.systemPrompt(ctx, tools); got != "Answer in haiku." {
+			t.Errorf("prompt = %q, want the profile's", got)
+		}
+	})
+}
+
+func TestReply_SystemPrompt(t *testing.T) {
+	a, llm := newScriptedAssistant(assistanttest.Answer("It's Tuesday."))
+	if _, err := a.Reply(context.Background(), question("What day is it?")); err != nil {
+		t.Fatal(err)
+	}
+
+	got := llm.Requests()[0].Messages[0].OfSystem.Content.OfString.Value
+	if !strings.Contains(got, "**get_today_date**") || strings.Contains(got, "get_weather") {
+		t.Errorf("system prompt should only describe the profile's tools:\n%s", got)
+	}
+}
@@ -0,0 +1,131 @@
+package assistant
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+
+	"github.com/acai-travel/tech-challenge/internal/audit"
+	"github.com/acai-travel/tech-challenge/internal/chat/model"
+	"github.com/acai-travel/tech-challenge/internal/textx"
+	"github.com/openai/openai-go/v2"
+)
+
+// QuickReply is a follow-up users can send with a tap, e.g. "3-day forecast" sending
+// "Show me the 3 day forecast".
+type QuickReply struct {
+	Label   string `json:"label"`
+	Message string `json:"message"`
+}
+
+const (
+	maxQuickReplies  = 3
+	maxQuickLabel    = 30
+	maxQuickMessage  = 200
+	quickRepliesName = "quick_replies"
+)
+
+const quickRepliesPrompt = `You suggest follow-ups for a chat UI to show as tappable chips under an AI assistant's reply.
+
+TASK
+- Suggest up to 3 messages the user is likely to send next, given their message and the reply.
+- Each is written as the user, e.g. "Show me the 3 day forecast", and must make sense on its own.
+- Suggest nothing when the conversation is finished or no follow-up is natural.
+
+FORMAT
+- label: at most 4 words, e.g. "3-day forecast".
+- message: one short sentence.
+- Write both in the language of the conversation.`
+
+// quickRepliesSchema constrains the suggestions through structured outputs.
+var quickRepliesSchema = map[string]any{
+	"type": "object",
+	"properties": map[string]any{
+		"quick_replies": map[string]any{
+			"type": "array",
+			"items": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"label":   map[string]any{"type": "string"},
+					"message": map[string]any{"type": "string"},
+				},
+				"required":             []string{"label", "message"},
+				"additionalProperties": false,
+			},
+		},
+	},
+	"required":             []string{"quick_replies"},
+	"additionalProperties": false,
+}
+
+// SuggestQuickReplies suggests follow-ups to reply, the latest answer in conv.
+func (a *Assistant) SuggestQuickReplies(ctx context.Context, conv *model.Conversation, reply string) ([]QuickReply, error) {
+	var lastUser string
+	for _, m := range conv.Messages {
+		if m.Role == model.RoleUser {
+			lastUser = m.Content
+		}
+	}
+
+	prompt := quickRepliesPrompt
+	if conv.Language != "" {
+		prompt += languageInstruction(conv.Language)
+	}
+
+	audit.Model(ctx, openai.ChatModelGPT4oMini)
+	resp, err := a.completeDeterministic(ctx, openai.ChatCompletionNewParams{
+		Model: openai.ChatModelGPT4oMini,
+		Messages: []openai.ChatCompletionMessageParamUnion{
+			openai.SystemMessage(prompt),
+			openai.UserMessage("USER\n" + a.pii.ForModel(lastUser) + "\n\nASSISTANT\n" + a.pii.ForModel(reply)),
+		},
+		ResponseFormat: openai.ChatCompletionNewParamsResponseFormatUnion{
+			OfJSONSchema: &openai.ResponseFormatJSONSchemaParam{
+				JSONSchema: openai.ResponseFormatJSONSchemaJSONSchemaParam{
+					Name:   quickRepliesName,
+					Strict: openai.Bool(true),
+					Schema: quickRepliesSchema,
+				},
+			},
+		},
+		Temperature:         openai.Float(0),
+		MaxCompletionTokens: openai.Int(200),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(resp.Choices) == 0 {
+		return nil, errors.New("empty response from OpenAI for quick replies")
+	}
+
+	return parseQuickReplies(resp.Choices[0].Message.Content)
+}
+
+// parseQuickReplies decodes the structured output, dropping empty suggestions and
+// duplicates and shortening long ones for chips.
+func parseQuickReplies(content string) ([]QuickReply, error) {
+	var out struct {
+		QuickReplies []QuickReply `json:"quick_replies"`
+	}
+	if err := json.Unmarshal([]byte(content), &out); err != nil {
+		return nil, err
+	}
+
+	var replies []QuickReply
+	seen := map[string]bool{}
+	for _, q := range out.QuickReplies {
+		q.Label = textx.Title(strings.TrimSpace(q.Label), maxQuickLabel)
+		q.Message = textx.Title(strings.TrimSpace(q.Message), maxQuickMessage)
+		if q.Label == "" || q.Message == "" || seen[strings.ToLower(q.Message)] {
+			continue
+		}
+		seen[strings.ToLower(q.Message)] = true
+		replies = append(replies, q)
+		if len(replies) == maxQuickReplies {
+			break
+		}
+	}
+	return replies, nil
+}
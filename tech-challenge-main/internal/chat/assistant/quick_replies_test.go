@@ -0,0 +1,38 @@
+package assistant
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseQuickReplies(t *testing.T) {
+	got, err := parseQuickReplies(`{"quick_replies":[
+		{"label":"3-day forecast","message":"Show me the 3 day forecast"},
+		{"label":"","message":"No label"},
+		{"label":"Again","message":"show me the 3 day forecast"},
+		{"label":"Weekend","message":"What about the weekend?"},
+		{"label":"Rain","message":"Will it rain?"},
+		{"label":"` + strings.Repeat("long ", 20) + `","message":"Tell me more"}
+	]}`)
+	if err != nil {
+		t.Fatalf("parseQuickReplies error: %v", err)
+	}
+
+	want := []QuickReply{
+		{Label: "3-day forecast", Message: "Show me the 3 day forecast"},
+		{Label: "Weekend", Message: "What about the weekend?"},
+		{Label: "Rain", Message: "Will it rain?"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d quick replies, want %d: %+v", len(got), len(want), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("quick reply %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+
+	if _, err := parseQuickReplies("not json"); err == nil {
+		t.Error("expected an error for malformed output")
+	}
+}
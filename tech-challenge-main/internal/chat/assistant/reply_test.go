@@ -0,0 +1,320 @@
+package assistant
+
+import (
+	"context"
+	"errors"
+	"expvar"
+	"log/slog"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/acai-travel/tech-challenge/internal/chat/assistant/assistanttest"
+	"github.com/acai-travel/tech-challenge/internal/chat/model"
+	"github.com/acai-travel/tech-challenge/internal/safety"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func newScriptedAssistant(steps ...assistanttest.Step) (*Assistant, *assistanttest.LLM) {
+	a := NewWithProfile(Profile{Name: "test", Tools: []string{"compute_date", "get_today_date"}})
+	llm := assistanttest.New(steps...)
+	a.SetCompletionProvider(llm)
+	return a, llm
+}
+
+func question(content string) *model.Conversation {
+	return &model.Conversation{
+		ID:       primitive.NewObjectID(),
+		Messages: []*model.Message{{ID: primitive.NewObjectID(), Role: model.RoleUser, Content: content}},
+	}
+}
+
+func TestReply_ToolLoop(t *testing.T) {
+	a, llm := newScriptedAssistant(
+		assistanttest.Call("compute_date", `{"offset":"+3 days","base_date":"2025-01-01"}`),
+		assistanttest.Answer("That's Saturday, January 4."),
+	)
+
+	var progress []ProgressKind
+	var used model.Usage
+	ctx := WithProgress(context.Background(), func(p Progress) { progress = append(progress, p.Kind) })
+	ctx = WithUsage(ctx, func(u model.Usage) { used = u })
+
+	reply, err := a.Reply(ctx, question("What's the date 3 days after New Year?"))
+	if err != nil || reply != "That's Saturday, January 4." {
+		t.Fatalf("Reply = %q, %v", reply, err)
+	}
+	if n := len(llm.Requests()); n != 2 {
+		t.Fatalf("got %d completions, want 2", n)
+	}
+	if got := llm.ToolResults()["call_1_1"]; !strings.Contains(got, "2025-01-04") {
+		t.Errorf("tool result sent back = %q, want the computed date", got)
+	}
+	if len(progress) != 2 || progress[0] != ProgressToolCalled || progress[1] != ProgressToolDone {
+		t.Errorf("progress = %v, want the call and its result", progress)
+	}
+	if used.PromptTokens != 20 || used.CompletionTokens != 10 {
+		t.Errorf("usage = %+v, want both completions", used)
+	}
+	// The profile's tools stay offered after a tool call.
+	if req := llm.Requests()[1]; len(req.Tools) != 2 {
+		t.Errorf("second request offered %d tools, want 2", len(req.Tools))
+	}
+}
+
+func TestReply_MaxOutputTokens(t *testing.T) {
+	for _, tt := range []struct {
+		name                          string
+		server, conversation, request int64
+		verbosity                     Verbosity
+		want                          int64
+	}{
+		{name: "no cap"},
+		{name: "conversation", conversation: 500, want: 500},
+		{name: "request lowers the conversation's", conversation: 500, request: 200, want: 200},
+		{name: "request doesn't raise the conversation's", conversation: 500, request: 5000, want: 500},
+		{name: "server caps both", server: 300, conversation: 500, request: 1000, want: 300},
+		{name: "server caps the style's", server: 1000, verbosity: VerbosityConcise, want: 1000},
+		{name: "style", verbosity: VerbosityConcise, want: 2048},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			a, llm := newScriptedAssistant(assistanttest.Answer("Sunny."))
+			a.SetMaxOutputTokens(tt.server)
+
+			conv := question("Weather in Lisbon?")
+			if tt.conversation > 0 {
+				conv.Settings = &model.GenerationSettings{MaxOutputTokens: tt.conversation}
+			}
+			ctx := WithVerbosity(context.Background(), tt.verbosity)
+			if tt.request > 0 {
+				ctx = WithMaxOutputTokens(ctx, tt.request)
+			}
+
+			if _, err := a.Reply(ctx, conv); err != nil {
+				t.Fatalf("Reply: %v", err)
+			}
+			if got := llm.Requests()[0].MaxCompletionTokens.Value; got != tt.want {
+				t.Errorf("max_completion_tokens = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReply_MaxOutputTokensSparesToolCalls(t *testing.T) {
+	truncated := assistanttest.Call("compute_date", `{"offset":"+3`)
+	truncated.FinishReason = "length"
+	a, llm := newScriptedAssistant(
+		truncated,
+		assistanttest.Call("compute_date", `{"offset":"+3 days","base_date":"2025-01-01"}`),
+		assistanttest.Answer("Saturday."),
+	)
+
+	var used model.Usage
+	ctx := WithUsage(WithMaxOutputTokens(context.Background(), 100), func(u model.Usage) { used = u })
+	if reply, err := a.Reply(ctx, question("What's the date 3 days after New Year?")); err != nil || reply != "Saturday." {
+		t.Fatalf("Reply = %q, %v", reply, err)
+	}
+
+	reqs := llm.Requests()
+	if len(reqs) != 3 {
+		t.Fatalf("got %d completions, want the truncated call made again, then the answer", len(reqs))
+	}
+	if len(reqs[1].Messages) != len(reqs[0].Messages) || reqs[1].MaxCompletionTokens.Valid() {
+		t.Errorf("repeated call: %d messages, max_completion_tokens %v; want the same messages without a cap", len(reqs[1].Messages), reqs[1].MaxCompletionTokens)
+	}
+	if got := reqs[2].MaxCompletionTokens.Value; got != 100 {
+		t.Errorf("answer: max_completion_tokens = %d, want 100", got)
+	}
+	if used.PromptTokens != 30 || used.CompletionTokens != 15 {
+		t.Errorf("usage = %+v, want all three completions", used)
+	}
+}
+
+func TestReply_InvalidArguments(t *testing.T) {
+	a, llm := newScriptedAssistant(
+		assistanttest.Calls(
+			assistanttest.ToolCall{Name: "compute_date", Arguments: `{"offset": 3}`},
+			assistanttest.ToolCall{Name: "compute_date", Arguments: `{"offset":`},
+			assistanttest.ToolCall{Name: "compute_date", Arguments: `{"offset":"someday"}`},
+		),
+		assistanttest.Answer("Sorry, I couldn't work that out."),
+	)
+
+	if _, err := a.Reply(context.Background(), question("When is someday?")); err != nil {
+		t.Fatalf("Reply error: %v", err)
+	}
+	// Failed calls are reported to the model, which may retry or apologise, rather than
+	// failing the reply.
+	results := llm.ToolResults()
+	if len(results) != 3 {
+		t.Fatalf("tool results = %v, want one per call", results)
+	}
+	for id, result := range results {
+		if result == "" || strings.Contains(result, "weekday") {
+			t.Errorf("result of %s = %q, want an error", id, result)
+		}
+	}
+}
+
+func TestReply_Failures(t *testing.T) {
+	tests := []struct {
+		name    string
+		steps   []assistanttest.Step
+		wantErr string
+		calls   int
+	}{
+		{
+			name:    "completion error",
+			steps:   []assistanttest.Step{assistanttest.Fail(errors.New("rate limited"))},
+			wantErr: "rate limited",
+			calls:   1,
+		},
+		{
+			name:    "unknown tool",
+			steps:   []assistanttest.Step{assistanttest.Call("get_weather", `{"location":"Oslo"}`)},
+			wantErr: "unknown tool call: get_weather",
+			calls:   1,
+		},
+		{
+			name:    "iteration cap",
+			steps:   []assistanttest.Step{assistanttest.Forever(assistanttest.Call("get_today_date", `{}`))},
+			wantErr: "too many tool calls",
+			calls:   15,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, llm := newScriptedAssistant(tt.steps...)
+			_, err := a.Reply(context.Background(), question("What's the weather in Oslo?"))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Reply error = %v, want %q", err, tt.wantErr)
+			}
+			if n := len(llm.Requests()); n != tt.calls {
+				t.Errorf("got %d completions, want %d", n, tt.calls)
+			}
+		})
+	}
+}
+
+// longConversation is a compacted conversation of n messages, the first half summarized,
+// with a few pinned answers.
+func longConversation(n int) *model.Conversation {
+	conv := &model.Conversation{ID: primitive.NewObjectID()}
+	pinned := time.Now()
+	for i := range n {
+		m := &model.Message{ID: primitive.NewObjectID(), Role: model.RoleUser, Content: "What's the weather in Lisbon next week? I'm flying from london@example.com's office."}
+		if i%2 == 1 {
+			m.Role = model.RoleAssistant
+			m.Content = strings.Repeat("Lisbon will be sunny and around 24°C all week, with a light breeze in the afternoons. ", 6)
+		}
+		if i%25 == 1 {
+			m.PinnedAt = &pinned
+		}
+		conv.Messages = append(conv.Messages, m)
+	}
+	conv.Summary = &model.Summary{Content: strings.Repeat("The user is planning a trip to Lisbon. ", 20), UpToMessageID: conv.Messages[n/2].ID}
+	return conv
+}
+
+func BenchmarkContextMessages(b *testing.B) {
+	a := NewWithProfile(Profile{Name: "test"})
+	for _, n := range []int{10, 100, 500} {
+		conv := longConversation(n)
+		b.Run(strconv.Itoa(n), func(b *testing.B) {
+			b.ReportAllocs()
+			for b.Loop() {
+				a.contextMessages(conv, a.prompt)
+			}
+		})
+	}
+}
+
+// BenchmarkTitle_Cached measures titles served by the response cache, i.e. building the
+// request and its cache key.
+func BenchmarkTitle_Cached(b *testing.B) {
+	quietLogs(b)
+	a, _ := newScriptedAssistant(assistanttest.Forever(assistanttest.Answer("Weather in Lisbon")))
+	a.SetResponseCache(NewResponseCache(10, time.Hour))
+	ctx := context.Background()
+	for _, n := range []int{2, 20, 100} {
+		conv := longConversation(n)
+		conv.Summary = nil
+		if _, err := a.Title(ctx, conv); err != nil {
+			b.Fatal(err)
+		}
+		b.Run(strconv.Itoa(n), func(b *testing.B) {
+			b.ReportAllocs()
+			for b.Loop() {
+				if _, err := a.Title(ctx, conv); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+// quietLogs discards logs for the rest of b, so benchmarks don't measure the terminal.
+func quietLogs(b *testing.B) {
+	prev := slog.Default()
+	slog.SetDefault(slog.New(slog.DiscardHandler))
+	b.Cleanup(func() { slog.SetDefault(prev) })
+}
+
+func TestReply_ToolLoopMetrics(t *testing.T) {
+	count := func(m interface{ Get(string) expvar.Var }, key string) int64 {
+		if v, ok := m.Get(key).(*expvar.Int); ok {
+			return v.Value()
+		}
+		return 0
+	}
+	turns, replies := count(toolsPerTurn, "2"), count(toolLoopTime, "llm/le_250ms")
+
+	a, _ := newScriptedAssistant(
+		assistanttest.Calls(
+			assistanttest.ToolCall{Name: "compute_date", Arguments: `{"offset":"+3 days","base_date":"2025-01-01"}`},
+			assistanttest.ToolCall{Name: "get_today_date", Arguments: `{}`},
+		),
+		assistanttest.Answer("January 4."),
+	)
+	if _, err := a.Reply(context.Background(), question("What's the date 3 days after New Year?")); err != nil {
+		t.Fatalf("Reply error: %v", err)
+	}
+
+	if count(toolsPerTurn, "2") != turns+1 {
+		t.Errorf("tools per turn not counted: %v", toolsPerTurn)
+	}
+	if count(toolLoopTime, "llm/le_250ms") != replies+1 {
+		t.Errorf("reply not counted by LLM time: %v", toolLoopTime)
+	}
+}
+
+func TestDurationBucket(t *testing.T) {
+	for d, want := range map[time.Duration]string{
+		100 * time.Millisecond: "le_250ms",
+		time.Second:            "le_1s",
+		3 * time.Second:        "le_5s",
+		time.Minute:            "gt_30s",
+	} {
+		if got := durationBucket(d); got != want {
+			t.Errorf("durationBucket(%v) = %q, want %q", d, got, want)
+		}
+	}
+}
+
+func TestReply_Refusals(t *testing.T) {
+	a, _ := newScriptedAssistant(assistanttest.Answer("Buy the stock now."), assistanttest.Answer("It's Tuesday."))
+	a.SetSafetyPolicy(&safety.Policy{Refuse: []safety.Topic{{Name: "investment", Keywords: []string{"stock"}}}})
+
+	var refused []string
+	ctx := WithRefusals(context.Background(), func(topic string) { refused = append(refused, topic) })
+	for _, q := range []string{"Should I invest?", "What day is it?"} {
+		if _, err := a.Reply(ctx, question(q)); err != nil {
+			t.Fatalf("Reply error: %v", err)
+		}
+	}
+	if !slices.Equal(refused, []string{"investment"}) {
+		t.Errorf("refusals = %v, want only the investment advice", refused)
+	}
+}
@@ -0,0 +1,128 @@
+package assistant
+
+import (
+	"context"
+	"math"
+	"net/url"
+	"time"
+)
+
+// RouteProvider plans driving routes for get_route_weather. The default estimates routes
+// along the great circle between the two places; a routing service can be set with
+// SetRouteProvider for the roads actually driven.
+type RouteProvider interface {
+	Route(ctx context.Context, origin, destination string) (*Route, error)
+}
+
+// Route is a driving route between two places.
+type Route struct {
+	// Origin and Destination are the resolved place names.
+	Origin, Destination string
+	DistanceKm          float64
+	Duration            time.Duration
+	// Path is the route from origin to destination, with at least both ends.
+	Path []RoutePoint
+	// Estimated is set when the route is not planned on roads.
+	Estimated bool
+}
+
+// RoutePoint is a point along a route.
+type RoutePoint struct {
+	Lat, Lon float64
+}
+
+const (
+	// roadFactor is how much longer roads are than the great circle, on average.
+	roadFactor = 1.3
+	// drivingSpeedKph is the average speed of a long drive, including short stops.
+	drivingSpeedKph = 85.0
+	earthRadiusKm   = 6371.0
+)
+
+// estimatedRoutes estimates routes from the coordinates of both ends, looked up with the
+// weather service.
+type estimatedRoutes struct {
+	weather *WeatherService
+}
+
+func (r estimatedRoutes) Route(ctx context.Context, origin, destination string) (*Route, error) {
+	from, fromName, err := r.weather.locate(ctx, origin)
+	if err != nil {
+		return nil, err
+	}
+	to, toName, err := r.weather.locate(ctx, destination)
+	if err != nil {
+		return nil, err
+	}
+
+	km := distanceKm(from, to) * roadFactor
+	return &Route{
+		Origin:      fromName,
+		Destination: toName,
+		DistanceKm:  km,
+		Duration:    time.Duration(km / drivingSpeedKph * float64(time.Hour)),
+		Path:        []RoutePoint{from, to},
+		Estimated:   true,
+	}, nil
+}
+
+// locate resolves a location query to the coordinates of the place WeatherAPI matches, and
+// its name as "City, Country".
+func (w *WeatherService) locate(ctx context.Context, location string) (RoutePoint, string, error) {
+	params := url.Values{}
+	params.Set("q", location)
+
+	weather, err := w.fetch(ctx, "/timezone.json", params)
+	if err != nil {
+		return RoutePoint{}, "", err
+	}
+	loc := weather.Location
+	return RoutePoint{Lat: loc.Lat, Lon: loc.Lon}, loc.Name + ", " + loc.Country, nil
+}
+
+// distanceKm is the great-circle distance between two points.
+func distanceKm(a, b RoutePoint) float64 {
+	lat1, lat2 := a.Lat*math.Pi/180, b.Lat*math.Pi/180
+	dLat, dLon := lat2-lat1, (b.Lon-a.Lon)*math.Pi/180
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadiusKm * math.Asin(math.Sqrt(h))
+}
+
+// routeWaypoint is a sampled point of a route, with how far along it is, from 0 to 1.
+type routeWaypoint struct {
+	RoutePoint
+	Fraction float64
+}
+
+// sampleRoute returns n points evenly spaced by distance along path, from its start to its
+// end. Straight segments are interpolated linearly, which is precise enough for the
+// spacing of weather samples.
+func sampleRoute(path []RoutePoint, n int) []routeWaypoint {
+	if len(path) == 0 || n < 2 {
+		return nil
+	}
+
+	cum := make([]float64, len(path))
+	for i := 1; i < len(path); i++ {
+		cum[i] = cum[i-1] + distanceKm(path[i-1], path[i])
+	}
+	total := cum[len(cum)-1]
+
+	out := make([]routeWaypoint, 0, n)
+	seg := 0
+	for i := range n {
+		f := float64(i) / float64(n-1)
+		target := f * total
+		for seg < len(path)-2 && cum[seg+1] < target {
+			seg++
+		}
+		p := path[min(seg, len(path)-1)]
+		if seg+1 < len(path) && cum[seg+1] > cum[seg] {
+			t := (target - cum[seg]) / (cum[seg+1] - cum[seg])
+			q := path[seg+1]
+			p = RoutePoint{Lat: p.Lat + (q.Lat-p.Lat)*t, Lon: p.Lon + (q.Lon-p.Lon)*t}
+		}
+		out = append(out, routeWaypoint{RoutePoint: p, Fraction: f})
+	}
+	return out
+}
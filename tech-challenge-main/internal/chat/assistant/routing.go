@@ -0,0 +1,189 @@
+package assistant
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/acai-travel/tech-challenge/internal/audit"
+	"github.com/acai-travel/tech-challenge/internal/chat/model"
+	"github.com/openai/openai-go/v2"
+)
+
+// Complexity is how demanding a user turn is to answer.
+type Complexity string
+
+const (
+	// ComplexitySimple turns are short factual or conversational ones.
+	ComplexitySimple Complexity = "simple"
+	// ComplexityTools turns need tools, such as weather or dates, which do the hard part.
+	ComplexityTools Complexity = "tools"
+	// ComplexityComplex turns need reasoning: planning, comparisons, explanations.
+	ComplexityComplex Complexity = "complex"
+)
+
+// modelRouter picks the model replying to each turn: the cheap model for simple and tool
+// turns, the assistant's own model for complex ones.
+type modelRouter struct {
+	cheap string
+	// classify asks a small model instead of using heuristics.
+	classify bool
+}
+
+// loadModelRouter reads the routing configuration from the environment, returning nil when
+// routing is disabled:
+//   - MODEL_ROUTING: "heuristic" or "model" (classify with a small model); off by default.
+//   - MODEL_ROUTING_CHEAP_MODEL: the model for simple turns, gpt-4o-mini by default.
+func loadModelRouter() *modelRouter {
+	mode := strings.ToLower(strings.TrimSpace(os.Getenv("MODEL_ROUTING")))
+	if mode != "heuristic" && mode != "model" {
+		if mode != "" && mode != "off" {
+			slog.Warn("Unknown MODEL_ROUTING, routing disabled", "value", mode)
+		}
+		return nil
+	}
+
+	cheap := strings.TrimSpace(os.Getenv("MODEL_ROUTING_CHEAP_MODEL"))
+	if cheap == "" {
+		cheap = openai.ChatModelGPT4oMini
+	}
+	return &modelRouter{cheap: cheap, classify: mode == "model"}
+}
+
+// route returns the model replying to a turn of conv and the complexity it was routed by.
+// Conversations selecting a model are not routed.
+func (a *Assistant) route(ctx context.Context, conv *model.Conversation, lastUser string, turn turnTools) (string, Complexity) {
+	if a.router == nil || a.router.cheap == a.model || (conv.Settings != nil && strings.TrimSpace(conv.Settings.Model) != "") {
+		return a.model, ""
+	}
+
+	c := heuristicComplexity(lastUser, turn)
+	if a.router.classify && turn.forced == "" {
+		if classified, err := a.classifyComplexity(ctx, lastUser); err != nil {
+			slog.WarnContext(ctx, "Complexity classification failed; using heuristics", "error", err)
+		} else {
+			c = classified
+		}
+	}
+
+	routingDecisions.Add(string(c), 1)
+	if c == ComplexityComplex {
+		return a.model, c
+	}
+	return a.router.cheap, c
+}
+
+// recordRoutingSavings adds what a completion by routed would have cost more with the
+// assistant's own model.
+func (a *Assistant) recordRoutingSavings(routed string, usage openai.CompletionUsage) {
+	if routed == a.model {
+		return
+	}
+	cheap, ok1 := completionCost(routed, usage)
+	full, ok2 := completionCost(a.model, usage)
+	if ok1 && ok2 {
+		routingDecisions.AddFloat("cost_saved_usd", full-cheap)
+	}
+}
+
+// complexWords mark requests for reasoning rather than facts.
+var complexWords = []string{
+	"why", "explain", "compare", "comparison", "analyze", "analyse", "plan", "itinerary",
+	"strategy", "pros and cons", "trade-off", "tradeoff", "step by step", "recommend",
+	"should i", "best way", "optimize", "evaluate", "summarize", "write",
+}
+
+// toolWords mark requests the tools answer.
+var toolWords = []string{
+	"weather", "forecast", "rain", "temperature", "snow", "wind", "holiday", "long weekend",
+	"today", "tomorrow", "date", "what day", "last time", "remember", "earlier",
+}
+
+// heuristicComplexity classifies a turn without a model call. Long or multi-part messages,
+// code and reasoning requests are complex, even when they also need tools.
+func heuristicComplexity(msg string, turn turnTools) Complexity {
+	lower := strings.ToLower(msg)
+	if len([]rune(msg)) > 600 || strings.Count(msg, "?") >= 3 || strings.Contains(msg, "```") || containsWord(lower, complexWords) {
+		return ComplexityComplex
+	}
+	if turn.forced != "" || containsWord(lower, toolWords) {
+		return ComplexityTools
+	}
+	return ComplexitySimple
+}
+
+// containsWord reports whether s contains any of words, not as part of a longer word.
+func containsWord(s string, words []string) bool {
+	for _, w := range words {
+		for i := strings.Index(s, w); i >= 0; {
+			end := i + len(w)
+			if (i == 0 || !isWordByte(s[i-1])) && (end == len(s) || !isWordByte(s[end])) {
+				return true
+			}
+			next := strings.Index(s[i+1:], w)
+			if next < 0 {
+				break
+			}
+			i += next + 1
+		}
+	}
+	return false
+}
+
+func isWordByte(b byte) bool {
+	return b >= 'a' && b <= 'z' || b >= '0' && b <= '9' || b >= 0x80
+}
+
+const complexityPrompt = `You route messages sent to a personal assistant to the right model.
+
+TASK
+- Decide how demanding the user's message is to answer.
+
+FORMAT
+- Reply with exactly one word:
+  simple (greetings, short factual questions, small talk),
+  tools (weather, dates, holidays or past conversations, answered by looking something up), or
+  complex (planning, comparisons, explanations, writing, multi-step reasoning).
+
+EXAMPLES
+User: What's the capital of Portugal?
+You: simple
+
+User: Will it rain in London on Friday?
+You: tools
+
+User: Plan a 5-day trip to Japan in spring with a mid-range budget
+You: complex`
+
+// classifyComplexity asks a small model how demanding a message is.
+func (a *Assistant) classifyComplexity(ctx context.Context, content string) (Complexity, error) {
+	audit.Model(ctx, openai.ChatModelGPT4oMini)
+	resp, err := a.completeDeterministic(ctx, openai.ChatCompletionNewParams{
+		Model: openai.ChatModelGPT4oMini,
+		Messages: []openai.ChatCompletionMessageParamUnion{
+			openai.SystemMessage(complexityPrompt),
+			openai.UserMessage(content),
+		},
+		Temperature:         openai.Float(0),
+		MaxCompletionTokens: openai.Int(3),
+	})
+	if err != nil {
+		return ComplexityComplex, err
+	}
+	if len(resp.Choices) == 0 {
+		return ComplexityComplex, nil
+	}
+	return parseComplexity(resp.Choices[0].Message.Content), nil
+}
+
+// parseComplexity maps the classifier output to a complexity. Anything unexpected is
+// complex: a wasted expensive reply is better than a bad cheap one.
+func parseComplexity(s string) Complexity {
+	switch c := Complexity(strings.ToLower(strings.Trim(s, " \t\r\n.\"'"))); c {
+	case ComplexitySimple, ComplexityTools:
+		return c
+	default:
+		return ComplexityComplex
+	}
+}
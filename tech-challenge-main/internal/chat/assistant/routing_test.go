@@ -0,0 +1,96 @@
+package assistant
+
+import (
+	"context"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/acai-travel/tech-challenge/internal/chat/model"
+	"github.com/openai/openai-go/v2"
+)
+
+func TestHeuristicComplexity(t *testing.T) {
+	tests := []struct {
+		msg  string
+		turn turnTools
+		want Complexity
+	}{
+		{msg: "Hi there!", want: ComplexitySimple},
+		{msg: "What's the capital of Portugal?", want: ComplexitySimple},
+		{msg: "Can you update me?", want: ComplexitySimple},
+		{msg: "Will it rain in Oslo tomorrow?", want: ComplexityTools},
+		{msg: "Lisbon please", turn: turnTools{forced: "get_weather"}, want: ComplexityTools},
+		{msg: "Why is the sky blue?", want: ComplexityComplex},
+		{msg: "Plan a 5-day itinerary for Japan", want: ComplexityComplex},
+		{msg: "Compare the weather in Rome and Madrid", want: ComplexityComplex},
+		{msg: "Where? When? How much?", want: ComplexityComplex},
+		{msg: "Fix this:\n```go\nfmt.Println()\n```", want: ComplexityComplex},
+		{msg: strings.Repeat("tell me about it ", 40), want: ComplexityComplex},
+	}
+	for _, tt := range tests {
+		if got := heuristicComplexity(tt.msg, tt.turn); got != tt.want {
+			t.Errorf("heuristicComplexity(%q) = %s, want %s", tt.msg, got, tt.want)
+		}
+	}
+}
+
+func TestParseComplexity(t *testing.T) {
+	for in, want := range map[string]Complexity{
+		"simple":  ComplexitySimple,
+		" Tools.": ComplexityTools,
+		"complex": ComplexityComplex,
+		"maybe":   ComplexityComplex,
+		"":        ComplexityComplex,
+	} {
+		if got := parseComplexity(in); got != want {
+			t.Errorf("parseComplexity(%q) = %s, want %s", in, got, want)
+		}
+	}
+}
+
+func TestRoute(t *testing.T) {
+	ctx := context.Background()
+	a := &Assistant{model: openai.ChatModelO1, router: &modelRouter{cheap: openai.ChatModelGPT4oMini}}
+	conv := &model.Conversation{}
+
+	if got, c := a.route(ctx, conv, "Hi!", turnTools{}); got != openai.ChatModelGPT4oMini || c != ComplexitySimple {
+		t.Errorf("simple turn routed to %s (%s), want the cheap model", got, c)
+	}
+	if got, _ := a.route(ctx, conv, "Explain the offside rule", turnTools{}); got != openai.ChatModelO1 {
+		t.Errorf("complex turn routed to %s, want the assistant's model", got)
+	}
+
+	pinned := &model.Conversation{Settings: &model.GenerationSettings{Model: openai.ChatModelGPT4o}}
+	if got, c := a.route(ctx, pinned, "Hi!", turnTools{}); got != openai.ChatModelO1 || c != "" {
+		t.Errorf("conversation selecting a model was routed to %s (%s)", got, c)
+	}
+
+	a.router = nil
+	if got, c := a.route(ctx, conv, "Hi!", turnTools{}); got != openai.ChatModelO1 || c != "" {
+		t.Errorf("routing disabled, but got %s (%s)", got, c)
+	}
+}
+
+func TestLoadModelRouter(t *testing.T) {
+	t.Setenv("MODEL_ROUTING", "")
+	if r := loadModelRouter(); r != nil {
+		t.Errorf("routing enabled by default: %+v", r)
+	}
+
+	t.Setenv("MODEL_ROUTING", "model")
+	t.Setenv("MODEL_ROUTING_CHEAP_MODEL", "gpt-4.1-nano")
+	if r := loadModelRouter(); r == nil || r.cheap != "gpt-4.1-nano" || !r.classify {
+		t.Errorf("loadModelRouter() = %+v", r)
+	}
+}
+
+func TestCompletionCost(t *testing.T) {
+	usage := openai.CompletionUsage{PromptTokens: 1_000_000, CompletionTokens: 500_000}
+	if got, ok := completionCost(openai.ChatModelGPT4oMini, usage); !ok || math.Abs(got-0.45) > 1e-9 {
+		t.Errorf("completionCost(gpt-4o-mini) = %v, %v, want 0.45", got, ok)
+	}
+	if _, ok := completionCost("my-fine-tune", usage); ok {
+		t.Error("completionCost of an unknown model should report false")
+	}
+}
@@ -0,0 +1,129 @@
+package assistant
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxOccurrences bounds rule expansion so a malformed rule can't spin forever.
+const maxOccurrences = 10_000
+
+// recurrence is the subset of an RFC 5545 RRULE used by holiday calendars: a fixed
+// frequency with optional INTERVAL, COUNT and UNTIL. BY* parts are not supported.
+type recurrence struct {
+	freq     string
+	interval int
+	count    int
+	until    time.Time
+}
+
+func parseRRule(s string) (recurrence, error) {
+	r := recurrence{interval: 1}
+
+	for _, part := range strings.Split(strings.TrimPrefix(s, "RRULE:"), ";") {
+		if part == "" {
+			continue
+		}
+
+		key, value, ok := strings.Cut(part, "=")
+		if !ok {
+			return recurrence{}, fmt.Errorf("malformed rule part %q", part)
+		}
+
+		switch strings.ToUpper(key) {
+		case "FREQ":
+			r.freq = strings.ToUpper(value)
+		case "INTERVAL":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 {
+				return recurrence{}, fmt.Errorf("invalid INTERVAL %q", value)
+			}
+			r.interval = n
+		case "COUNT":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 {
+				return recurrence{}, fmt.Errorf("invalid COUNT %q", value)
+			}
+			r.count = n
+		case "UNTIL":
+			until, err := parseICSDate(value)
+			if err != nil {
+				return recurrence{}, fmt.Errorf("invalid UNTIL %q: %w", value, err)
+			}
+			r.until = until
+		case "WKST":
+			// Only affects BY* expansion, which is not supported.
+		default:
+			return recurrence{}, fmt.Errorf("unsupported rule part %q", key)
+		}
+	}
+
+	switch r.freq {
+	case "DAILY", "WEEKLY", "MONTHLY", "YEARLY":
+		return r, nil
+	default:
+		return recurrence{}, fmt.Errorf("unsupported FREQ %q", r.freq)
+	}
+}
+
+// occurrences returns the dates of a rule starting at start that fall within [from, to],
+// compared by calendar day. A zero from means no lower bound; to is required.
+func (r recurrence) occurrences(start, from, to time.Time) []time.Time {
+	start, from, to = day(start), day(from), day(to)
+
+	var out []time.Time
+	for n := 0; n < maxOccurrences; n++ {
+		if r.count > 0 && n >= r.count {
+			break
+		}
+
+		var date time.Time
+		step := n * r.interval
+		switch r.freq {
+		case "DAILY":
+			date = start.AddDate(0, 0, step)
+		case "WEEKLY":
+			date = start.AddDate(0, 0, 7*step)
+		case "MONTHLY":
+			date = start.AddDate(0, step, 0)
+		case "YEARLY":
+			date = start.AddDate(step, 0, 0)
+		}
+
+		if date.After(to) || (!r.until.IsZero() && date.After(day(r.until))) {
+			break
+		}
+
+		// AddDate normalizes Jan 31 + 1 month to Mar 3; RFC 5545 skips such dates instead.
+		if (r.freq == "MONTHLY" || r.freq == "YEARLY") && date.Day() != start.Day() {
+			continue
+		}
+
+		if !from.IsZero() && date.Before(from) {
+			continue
+		}
+
+		out = append(out, date)
+	}
+
+	return out
+}
+
+func parseICSDate(s string) (time.Time, error) {
+	for _, layout := range []string{"20060102T150405Z", "20060102T150405", "20060102"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unrecognized date format")
+}
+
+// day truncates t to midnight UTC of its calendar day, the granularity of holidays.
+func day(t time.Time) time.Time {
+	if t.IsZero() {
+		return t
+	}
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,119 @@
+package assistant
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	ics "github.com/arran4/golang-ical"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestRecurrenceOccurrences(t *testing.T) {
+	date := func(s string) time.Time {
+		d, err := time.Parse(time.DateOnly, s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return d
+	}
+
+	tests := []struct {
+		name     string
+		rule     string
+		start    string
+		from, to string
+		want     []string
+	}{
+		{
+			name:  "yearly within range",
+			rule:  "FREQ=YEARLY",
+			start: "2020-12-25", from: "2024-01-01", to: "2026-12-31",
+			want: []string{"2024-12-25", "2025-12-25", "2026-12-25"},
+		},
+		{
+			name:  "count limits occurrences",
+			rule:  "FREQ=YEARLY;COUNT=2",
+			start: "2024-05-01", to: "2030-01-01",
+			want: []string{"2024-05-01", "2025-05-01"},
+		},
+		{
+			name:  "until is inclusive",
+			rule:  "FREQ=WEEKLY;INTERVAL=2;UNTIL=20240115T000000Z",
+			start: "2024-01-01", to: "2024-12-31",
+			want: []string{"2024-01-01", "2024-01-15"},
+		},
+		{
+			name:  "monthly skips short months",
+			rule:  "FREQ=MONTHLY;COUNT=3",
+			start: "2024-01-31", to: "2024-12-31",
+			want: []string{"2024-01-31", "2024-03-31"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := parseRRule(tt.rule)
+			if err != nil {
+				t.Fatalf("parseRRule(%q): %v", tt.rule, err)
+			}
+
+			var from time.Time
+			if tt.from != "" {
+				from = date(tt.from)
+			}
+
+			var got []string
+			for _, d := range r.occurrences(date(tt.start), from, date(tt.to)) {
+				got = append(got, d.Format(time.DateOnly))
+			}
+
+			if !cmp.Equal(got, tt.want) {
+				t.Errorf("occurrences mismatch (-got +want):\n%s", cmp.Diff(got, tt.want))
+			}
+		})
+	}
+}
+
+func TestParseRRule_Unsupported(t *testing.T) {
+	for _, rule := range []string{"FREQ=YEARLY;BYDAY=1MO", "FREQ=HOURLY", "INTERVAL=2"} {
+		if _, err := parseRRule(rule); err == nil {
+			t.Errorf("parseRRule(%q): expected error", rule)
+		}
+	}
+}
+
+func TestExpandHolidays(t *testing.T) {
+	newEvent := func(id, start, summary, rule string) *ics.VEvent {
+		e := ics.NewEvent(id)
+		e.AddProperty(ics.ComponentPropertyDtStart, start, ics.WithValue(string(ics.ValueDataTypeDate)))
+		e.SetSummary(summary)
+		if rule != "" {
+			e.AddRrule(rule)
+		}
+		return e
+	}
+
+	events := []*ics.VEvent{
+		newEvent("1", "20251225", "Christmas Day", ""),
+		newEvent("2", "20200101", "New Year's Day", "FREQ=YEARLY"),
+		newEvent("3", "20251208", "Immaculate Conception", ""),
+	}
+
+	from := time.Date(2025, 12, 1, 15, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+
+	var got []string
+	for _, h := range expandHolidays(context.Background(), events, from, to) {
+		got = append(got, h.Date.Format(time.DateOnly)+" "+h.Date.Weekday().String()+" "+h.Name)
+	}
+
+	want := []string{
+		"2025-12-08 Monday Immaculate Conception",
+		"2025-12-25 Thursday Christmas Day",
+		"2026-01-01 Thursday New Year's Day",
+	}
+	if !cmp.Equal(got, want) {
+		t.Errorf("expandHolidays mismatch (-got +want):\n%s", cmp.Diff(got, want))
+	}
+}
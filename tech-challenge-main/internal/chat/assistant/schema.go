@@ -0,0 +1,184 @@
+package assistant
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/openai/openai-go/v2"
+)
+
+// schema is the subset of JSON Schema used by tool definitions.
+type schema struct {
+	Type       string             `json:"type"`
+	Properties map[string]*schema `json:"properties"`
+	Required   []string           `json:"required"`
+	Enum       []any              `json:"enum"`
+	Minimum    *float64           `json:"minimum"`
+	Maximum    *float64           `json:"maximum"`
+	Format     string             `json:"format"`
+	Items      *schema            `json:"items"`
+}
+
+// ArgsError lists the problems found in the arguments of a tool call. Its message is sent
+// back to the model as the tool result, so it can correct the call.
+type ArgsError struct {
+	Tool     string
+	Problems []string
+}
+
+func (e *ArgsError) Error() string {
+	return fmt.Sprintf("invalid arguments for %s, fix them and call it again:\n- %s", e.Tool, strings.Join(e.Problems, "\n- "))
+}
+
+// validateArgs checks the JSON arguments of a tool call against the parameters declared in
+// its definition, so tools never run with inputs the model was told are invalid. Tools
+// without parameters accept anything.
+func validateArgs(def openai.FunctionDefinitionParam, args string) error {
+	if def.Parameters == nil {
+		return nil
+	}
+
+	raw, err := json.Marshal(def.Parameters)
+	if err != nil {
+		return fmt.Errorf("invalid parameters schema for %s: %w", def.Name, err)
+	}
+	var s schema
+	if err := json.Unmarshal(raw, &s); err != nil {
+		return fmt.Errorf("invalid parameters schema for %s: %w", def.Name, err)
+	}
+
+	if strings.TrimSpace(args) == "" {
+		args = "{}"
+	}
+
+	// UseNumber keeps integers exact, so 3 and 3.5 can be told apart.
+	dec := json.NewDecoder(bytes.NewReader([]byte(args)))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil || dec.More() {
+		return &ArgsError{Tool: def.Name, Problems: []string{"arguments must be a single valid JSON object"}}
+	}
+
+	var problems []string
+	s.check("", v, &problems)
+	if len(problems) > 0 {
+		return &ArgsError{Tool: def.Name, Problems: problems}
+	}
+	return nil
+}
+
+// check appends to problems every way v violates the schema; path names v in messages.
+func (s *schema) check(path string, v any, problems *[]string) {
+	name := "arguments"
+	if path != "" {
+		name = fmt.Sprintf("%q", path)
+	}
+	fail := func(format string, a ...any) {
+		*problems = append(*problems, name+" "+fmt.Sprintf(format, a...))
+	}
+
+	switch s.Type {
+	case "object":
+		obj, ok := v.(map[string]any)
+		if !ok {
+			fail("must be an object")
+			return
+		}
+		for _, r := range s.Required {
+			if val, ok := obj[r]; !ok || val == nil || val == "" {
+				*problems = append(*problems, fmt.Sprintf("%q is required", join(path, r)))
+			}
+		}
+		keys := make([]string, 0, len(obj))
+		for k := range obj {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			// Unknown arguments are ignored by the tools, and null means "not provided".
+			if prop, ok := s.Properties[k]; ok && obj[k] != nil {
+				prop.check(join(path, k), obj[k], problems)
+			}
+		}
+		return
+
+	case "array":
+		arr, ok := v.([]any)
+		if !ok {
+			fail("must be an array")
+			return
+		}
+		if s.Items != nil {
+			for i, item := range arr {
+				s.Items.check(fmt.Sprintf("%s[%d]", path, i), item, problems)
+			}
+		}
+		return
+
+	case "string":
+		str, ok := v.(string)
+		if !ok {
+			fail("must be a string")
+			return
+		}
+		if s.Format == "date-time" && str != "" {
+			if _, err := time.Parse(time.RFC3339, str); err != nil {
+				fail("must be a date in RFC3339 format, e.g. 2025-01-02T00:00:00Z (got %q)", str)
+			}
+		}
+		if s.Format == "date" {
+			if _, err := parseDateArg(str, time.Now()); err != nil {
+				fail(`must be a date as YYYY-MM-DD or RFC3339, or an offset from today like "next month" (got %q)`, str)
+			}
+		}
+
+	case "integer", "number":
+		n, ok := v.(json.Number)
+		if !ok {
+			fail("must be %s", map[string]string{"integer": "an integer", "number": "a number"}[s.Type])
+			return
+		}
+		f, err := n.Float64()
+		if err != nil {
+			fail("must be a finite number")
+			return
+		}
+		if s.Type == "integer" && f != math.Trunc(f) {
+			fail("must be an integer (got %s)", n)
+			return
+		}
+		if s.Minimum != nil && f < *s.Minimum {
+			fail("must be at least %v (got %s)", *s.Minimum, n)
+		}
+		if s.Maximum != nil && f > *s.Maximum {
+			fail("must be at most %v (got %s)", *s.Maximum, n)
+		}
+
+	case "boolean":
+		if _, ok := v.(bool); !ok {
+			fail("must be a boolean")
+			return
+		}
+	}
+
+	if len(s.Enum) > 0 && !slices.ContainsFunc(s.Enum, func(e any) bool { return fmt.Sprint(e) == fmt.Sprint(v) }) {
+		options := make([]string, len(s.Enum))
+		for i, e := range s.Enum {
+			options[i] = fmt.Sprint(e)
+		}
+		fail("must be one of: %s (got %v)", strings.Join(options, ", "), v)
+	}
+}
+
+func join(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
@@ -0,0 +1,78 @@
+package assistant
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/openai/openai-go/v2"
+)
+
+func TestValidateArgs(t *testing.T) {
+	cases := []struct {
+		name    string
+		tool    Tool
+		args    string
+		problem string // substring of the expected problem; empty means valid
+	}{
+		{name: "valid weather", tool: &weatherTool{}, args: `{"location":"Barcelona","forecast_days":3}`},
+		{name: "null is not provided", tool: &weatherTool{}, args: `{"location":"Barcelona","forecast_days":null}`},
+		{name: "unknown arguments are ignored", tool: &weatherTool{}, args: `{"location":"Barcelona","units":"metric"}`},
+		{name: "missing required", tool: &weatherTool{}, args: `{"forecast_days":3}`, problem: `"location" is required`},
+		{name: "empty required", tool: &weatherTool{}, args: `{"location":""}`, problem: `"location" is required`},
+		{name: "above maximum", tool: &weatherTool{}, args: `{"location":"Oslo","forecast_days":30}`, problem: `"forecast_days" must be at most 14 (got 30)`},
+		{name: "below minimum", tool: &weatherTool{}, args: `{"location":"Oslo","forecast_days":0}`, problem: `"forecast_days" must be at least 1`},
+		{name: "fractional integer", tool: &weatherTool{}, args: `{"location":"Oslo","forecast_days":2.5}`, problem: `"forecast_days" must be an integer`},
+		{name: "wrong type", tool: &weatherTool{}, args: `{"location":42}`, problem: `"location" must be a string`},
+		{name: "not an object", tool: &weatherTool{}, args: `["Oslo"]`, problem: `arguments must be an object`},
+		{name: "invalid JSON", tool: &weatherTool{}, args: `{"location":`, problem: `valid JSON object`},
+		{name: "trailing data", tool: &weatherTool{}, args: `{"location":"Oslo"} {}`, problem: `valid JSON object`},
+		{name: "valid dates", tool: &holidaysTool{}, args: `{"after_date":"2025-01-01T00:00:00Z","max_count":0}`},
+		{name: "date only", tool: &holidaysTool{}, args: `{"after_date":"2025-01-01","before_date":"2025-01-31"}`},
+		{name: "date offset", tool: &holidaysTool{}, args: `{"after_date":"next month"}`},
+		{name: "bad date format", tool: &holidaysTool{}, args: `{"after_date":"01/02/2025"}`, problem: `"after_date" must be a date as YYYY-MM-DD or RFC3339, or an offset from today like "next month" (got "01/02/2025")`},
+		{name: "negative count", tool: &holidaysTool{}, args: `{"max_count":-1}`, problem: `"max_count" must be at least 0`},
+		{name: "empty args", tool: &longWeekendsTool{}, args: ``},
+		{name: "months range", tool: &longWeekendsTool{}, args: `{"months":13}`, problem: `"months" must be at most 12`},
+		{name: "long weekends date only", tool: &longWeekendsTool{}, args: `{"after_date":"2025-04-01","months":2}`},
+		{name: "long weekends bad date", tool: &longWeekendsTool{}, args: `{"after_date":"soon"}`, problem: `"after_date" must be a date`},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateArgs(tc.tool.Definition(), tc.args)
+			if tc.problem == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+
+			var argsErr *ArgsError
+			if !errors.As(err, &argsErr) {
+				t.Fatalf("expected *ArgsError, got %v", err)
+			}
+			if argsErr.Tool != tc.tool.Name() {
+				t.Errorf("tool: got %q want %q", argsErr.Tool, tc.tool.Name())
+			}
+			if !strings.Contains(err.Error(), tc.problem) {
+				t.Errorf("expected %q in error, got:\n%s", tc.problem, err)
+			}
+		})
+	}
+}
+
+func TestValidateArgsWithoutParameters(t *testing.T) {
+	if err := validateArgs(openai.FunctionDefinitionParam{Name: "ping"}, `whatever`); err != nil {
+		t.Fatalf("expected tools without parameters to accept anything, got %v", err)
+	}
+}
+
+func TestValidateArgsReportsAllProblems(t *testing.T) {
+	err := validateArgs((&holidaysTool{}).Definition(), `{"after_date":"soon","before_date":"later","max_count":-2}`)
+
+	var argsErr *ArgsError
+	if !errors.As(err, &argsErr) || len(argsErr.Problems) != 3 {
+		t.Fatalf("expected 3 problems, got %v", err)
+	}
+}
@@ -0,0 +1,68 @@
+package assistant
+
+import (
+	"context"
+	"time"
+)
+
+// SnowProvider reports snow conditions at ski resorts. The weather service is the default
+// provider; a dedicated snow provider can be set with SetSnowProvider for the snow depth
+// and open runs, which WeatherAPI does not report.
+type SnowProvider interface {
+	SnowReport(ctx context.Context, resort string, days int) (*SnowReport, error)
+}
+
+// SnowReport is the snow at a resort and the snowfall forecast for the following days.
+type SnowReport struct {
+	// Resort is the resolved place name, as "Name, Country".
+	Resort string
+	// DepthCm is the snow depth on the slopes, and OpenRuns the share of runs open, 0 to
+	// 100, when the provider reports them.
+	DepthCm  *float64
+	OpenRuns *int
+	// UpdatedAt is when the provider last updated the report.
+	UpdatedAt time.Time
+	// Stale is set when cached data was served because the provider is unavailable.
+	Stale bool
+	Days  []SnowDay
+}
+
+// SnowDay is the forecast of one day at a resort.
+type SnowDay struct {
+	Date         time.Time
+	SnowfallCm   float64
+	ChanceOfSnow int
+	MinTempC     float64
+	MaxTempC     float64
+	Condition    string
+}
+
+// SnowReport builds a snow report from the forecast: the daily snowfall and temperatures,
+// without the snow depth.
+func (w *WeatherService) SnowReport(ctx context.Context, resort string, days int) (*SnowReport, error) {
+	weather, err := w.Forecast(ctx, resort, days)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &SnowReport{
+		Resort:    weather.Location.Name + ", " + weather.Location.Country,
+		UpdatedAt: weather.FetchedAt,
+		Stale:     weather.Stale,
+	}
+	for _, fd := range weather.Forecast.Forecastday {
+		date, err := time.Parse(time.DateOnly, fd.Date)
+		if err != nil {
+			continue
+		}
+		out.Days = append(out.Days, SnowDay{
+			Date:         date,
+			SnowfallCm:   fd.Day.TotalsnowCm,
+			ChanceOfSnow: fd.Day.ChanceOfSnow,
+			MinTempC:     fd.Day.MintempC,
+			MaxTempC:     fd.Day.MaxtempC,
+			Condition:    fd.Day.Condition.Text,
+		})
+	}
+	return out, nil
+}
@@ -0,0 +1,80 @@
+package assistant
+
+import (
+	"context"
+	"strings"
+)
+
+// Verbosity selects a response style profile for a reply.
+type Verbosity string
+
+const (
+	VerbosityDefault  Verbosity = ""
+	VerbosityConcise  Verbosity = "concise"
+	VerbosityDetailed Verbosity = "detailed"
+	VerbosityBullet   Verbosity = "bullet"
+)
+
+// ParseVerbosity maps a configuration value to a Verbosity, defaulting to VerbosityDefault.
+func ParseVerbosity(s string) Verbosity {
+	switch v := Verbosity(strings.ToLower(strings.TrimSpace(s))); v {
+	case VerbosityConcise, VerbosityDetailed, VerbosityBullet:
+		return v
+	default:
+		return VerbosityDefault
+	}
+}
+
+// styleProfile is the prompt addendum and output cap for a verbosity.
+type styleProfile struct {
+	prompt string
+	// maxTokens caps completion tokens; 0 means no cap. Reasoning models spend part of
+	// this budget on hidden reasoning, so caps are well above the visible answer length.
+	maxTokens int64
+}
+
+var styleProfiles = map[Verbosity]styleProfile{
+	VerbosityDefault: {},
+	VerbosityConcise: {
+		prompt: `
+
+RESPONSE LENGTH (OVERRIDES RESPONSE STYLE)
+- The answer is shown in a small UI surface. Reply in at most 2 short sentences.
+- No headers, no bullet points, no follow-up questions unless information is missing.`,
+		maxTokens: 2048,
+	},
+	VerbosityBullet: {
+		prompt: `
+
+RESPONSE LENGTH (OVERRIDES RESPONSE STYLE)
+- Reply only with 3–5 short bullet points, one fact per bullet, no intro or outro.`,
+		maxTokens: 3072,
+	},
+	VerbosityDetailed: {
+		prompt: `
+
+RESPONSE LENGTH (OVERRIDES RESPONSE STYLE)
+- Give a thorough answer: explain the reasoning, include relevant details and caveats,
+  and use short sections with headers where it helps readability.`,
+	},
+}
+
+type verbosityKey struct{}
+
+// WithVerbosity selects the response style for replies generated with ctx.
+func WithVerbosity(ctx context.Context, v Verbosity) context.Context {
+	return context.WithValue(ctx, verbosityKey{}, v)
+}
+
+func verbosityFromContext(ctx context.Context) Verbosity {
+	v, _ := ctx.Value(verbosityKey{}).(Verbosity)
+	return v
+}
+
+// styleProfile returns the profile for v, falling back to the server default.
+func (a *Assistant) styleProfile(v Verbosity) styleProfile {
+	if v == VerbosityDefault {
+		v = a.verbosity
+	}
+	return styleProfiles[v]
+}
@@ -0,0 +1,59 @@
+package assistant
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/acai-travel/tech-challenge/internal/audit"
+	"github.com/acai-travel/tech-challenge/internal/chat/model"
+	"github.com/openai/openai-go/v2"
+)
+
+const summaryPrompt = `You maintain a running summary of a conversation between a user and an AI assistant.
+
+TASK
+- Merge the previous summary (if any) with the new messages into one updated summary.
+- Keep every fact a later reply might need: names, places, dates, bookings, preferences,
+  decisions, open questions and anything the assistant promised.
+- Drop greetings, small talk and details that were superseded.
+
+FORMAT
+- Plain text, at most 300 words, written in the third person ("The user ...").
+- Do NOT answer the user or add anything that was not said.`
+
+// Summarize folds msgs into the previous summary of a conversation, so replies can send the
+// summary instead of the messages it covers.
+func (a *Assistant) Summarize(ctx context.Context, previous string, msgs []*model.Message) (string, error) {
+	var b strings.Builder
+	if previous != "" {
+		b.WriteString("PREVIOUS SUMMARY\n")
+		b.WriteString(previous)
+		b.WriteString("\n\n")
+	}
+	b.WriteString("NEW MESSAGES\n")
+	for _, m := range msgs {
+		b.WriteString(string(m.Role))
+		b.WriteString(": ")
+		b.WriteString(a.pii.ForModel(m.Content))
+		b.WriteString("\n")
+	}
+
+	audit.Model(ctx, openai.ChatModelGPT4oMini)
+	resp, err := a.completeDeterministic(ctx, openai.ChatCompletionNewParams{
+		Model: openai.ChatModelGPT4oMini,
+		Messages: []openai.ChatCompletionMessageParamUnion{
+			openai.SystemMessage(summaryPrompt),
+			openai.UserMessage(b.String()),
+		},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	if len(resp.Choices) == 0 || strings.TrimSpace(resp.Choices[0].Message.Content) == "" {
+		return "", errors.New("empty response from OpenAI for conversation summary")
+	}
+
+	return strings.TrimSpace(resp.Choices[0].Message.Content), nil
+}
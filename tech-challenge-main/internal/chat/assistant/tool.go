@@ -0,0 +1,120 @@
+package assistant
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+
+	"github.com/acai-travel/tech-challenge/internal/features"
+	"github.com/openai/openai-go/v2"
+)
+
+// Tool is a function the model can call while generating a reply.
+//
+// Call receives the raw JSON arguments produced by the model. Errors are not fatal to the
+// reply: their message is sent back to the model as the tool result, so they should read
+// as an explanation the model can act on.
+type Tool interface {
+	Name() string
+	Definition() openai.FunctionDefinitionParam
+	Call(ctx context.Context, args string) (string, error)
+}
+
+// gatedTool is implemented by tools that are only offered when a feature flag is enabled.
+type gatedTool interface {
+	Feature() features.Flag
+}
+
+// availableTool is implemented by tools that depend on request-scoped dependencies and are
+// only offered when those are present.
+type availableTool interface {
+	Available(ctx context.Context) bool
+}
+
+// callTool runs a tool after validating the model's arguments against its declared schema.
+// Invalid arguments are returned as an *ArgsError without running the tool.
+func callTool(ctx context.Context, t Tool, args string) (string, error) {
+	if err := validateArgs(t.Definition(), args); err != nil {
+		toolInvalidArgs.Add(t.Name(), 1)
+		slog.WarnContext(ctx, "Rejected tool call arguments", "name", t.Name(), "error", err)
+		return "", err
+	}
+	return t.Call(ctx, args)
+}
+
+// Toolset is an ordered collection of tools, addressable by name.
+type Toolset []Tool
+
+// Get returns the tool with the given name, or nil if there is none.
+func (ts Toolset) Get(name string) Tool {
+	for _, t := range ts {
+		if t.Name() == name {
+			return t
+		}
+	}
+	return nil
+}
+
+// Without returns a copy of the toolset excluding the named tools.
+func (ts Toolset) Without(names ...string) Toolset {
+	if len(names) == 0 {
+		return ts
+	}
+
+	deny := make(map[string]bool, len(names))
+	for _, n := range names {
+		deny[n] = true
+	}
+
+	out := make(Toolset, 0, len(ts))
+	for _, t := range ts {
+		if !deny[t.Name()] {
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+// Only returns a copy of the toolset with just the named tools.
+func (ts Toolset) Only(names ...string) Toolset {
+	out := make(Toolset, 0, len(names))
+	for _, t := range ts {
+		if slices.Contains(names, t.Name()) {
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+// Enabled returns a copy of the toolset without the tools whose feature flag is off.
+func (ts Toolset) Enabled(flags features.Set) Toolset {
+	out := make(Toolset, 0, len(ts))
+	for _, t := range ts {
+		if g, ok := t.(gatedTool); ok && !flags.Enabled(g.Feature()) {
+			continue
+		}
+		out = append(out, t)
+	}
+	return out
+}
+
+// Available returns a copy of the toolset without the tools unavailable for the request.
+func (ts Toolset) Available(ctx context.Context) Toolset {
+	out := make(Toolset, 0, len(ts))
+	for _, t := range ts {
+		if a, ok := t.(availableTool); ok && !a.Available(ctx) {
+			continue
+		}
+		out = append(out, t)
+	}
+	return out
+}
+
+// Params converts the toolset into the OpenAI tool definitions for a completion request.
+func (ts Toolset) Params() []openai.ChatCompletionToolUnionParam {
+	params := make([]openai.ChatCompletionToolUnionParam, 0, len(ts))
+	for _, t := range ts {
+		params = append(params, openai.ChatCompletionFunctionTool(t.Definition()))
+	}
+	return params
+}
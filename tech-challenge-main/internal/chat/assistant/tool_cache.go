@@ -0,0 +1,94 @@
+package assistant
+
+import (
+	"context"
+	"os"
+	"strconv"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+	"golang.org/x/sync/singleflight"
+)
+
+// cacheableTool is implemented by tools whose results can be reused for identical calls.
+// CacheKey identifies the result of a call, beyond the tool, and says how long it may be
+// reused; ok is false when it mustn't be shared, e.g. because it depends on the user.
+type cacheableTool interface {
+	CacheKey(ctx context.Context, args string) (key string, ttl time.Duration, ok bool)
+}
+
+// ToolCache keeps the results of cacheable tools, so calls repeated within a reply, or by
+// other users, don't fetch the same data again. Failed calls are not cached. It is safe for
+// concurrent use.
+type ToolCache struct {
+	lru *lru.Cache[string, toolResult]
+	sf  singleflight.Group
+}
+
+type toolResult struct {
+	result  string
+	expires time.Time
+}
+
+// NewToolCache keeps up to size tool results.
+func NewToolCache(size int) *ToolCache {
+	c, _ := lru.New[string, toolResult](size)
+	return &ToolCache{lru: c}
+}
+
+// ToolCacheFromEnv returns the cache configured by TOOL_CACHE_SIZE (default 2000 results),
+// or nil if TOOL_CACHE=false.
+func ToolCacheFromEnv() *ToolCache {
+	if os.Getenv("TOOL_CACHE") == "false" {
+		return nil
+	}
+
+	size := 2_000
+	if v, err := strconv.Atoi(os.Getenv("TOOL_CACHE_SIZE")); err == nil && v > 0 {
+		size = v
+	}
+	return NewToolCache(size)
+}
+
+// Len reports how many results are cached.
+func (c *ToolCache) Len() int {
+	return c.lru.Len()
+}
+
+// call runs t through callTool, or returns its cached result for args; a nil cache calls through.
+func (c *ToolCache) call(ctx context.Context, t Tool, args string) (string, error) {
+	ct, ok := t.(cacheableTool)
+	if c == nil || !ok {
+		return callTool(ctx, t, args)
+	}
+	key, ttl, ok := ct.CacheKey(ctx, args)
+	if !ok {
+		return callTool(ctx, t, args)
+	}
+	key = t.Name() + "\x00" + key
+
+	if r, ok := c.lru.Get(key); ok && time.Now().Before(r.expires) {
+		toolCacheStats.Add(t.Name()+"/hit", 1)
+		return r.result, nil
+	}
+
+	v, err, shared := c.sf.Do(key, func() (any, error) {
+		result, err := callTool(ctx, t, args)
+		if err == nil {
+			c.lru.Add(key, toolResult{result: result, expires: time.Now().Add(ttl)})
+		}
+		return result, err
+	})
+	if shared {
+		toolCacheStats.Add(t.Name()+"/shared", 1)
+	} else {
+		toolCacheStats.Add(t.Name()+"/miss", 1)
+	}
+	return v.(string), err
+}
+
+// SetToolCache reuses the results of cacheable tools, such as get_weather and get_holidays,
+// from c. Assistants with the same API keys may share one.
+func (a *Assistant) SetToolCache(c *ToolCache) {
+	a.toolCache = c
+}
@@ -0,0 +1,87 @@
+package assistant
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/openai/openai-go/v2"
+)
+
+// countingTool is a cacheable tool answering with its argument, or failing on "fail".
+type countingTool struct {
+	calls int
+	ttl   time.Duration
+}
+
+func (t *countingTool) Name() string { return "count" }
+
+func (t *countingTool) Definition() openai.FunctionDefinitionParam {
+	return openai.FunctionDefinitionParam{Name: t.Name()}
+}
+
+func (t *countingTool) Call(_ context.Context, args string) (string, error) {
+	t.calls++
+	if args == "fail" {
+		return "", errors.New("provider unavailable")
+	}
+	return "result for " + args, nil
+}
+
+func (t *countingTool) CacheKey(_ context.Context, args string) (string, time.Duration, bool) {
+	return args, t.ttl, args != "private"
+}
+
+func TestToolCache(t *testing.T) {
+	ctx := context.Background()
+	c := NewToolCache(10)
+	tool := &countingTool{ttl: time.Hour}
+
+	for range 3 {
+		if out, err := c.call(ctx, tool, "Oslo"); err != nil || out != "result for Oslo" {
+			t.Fatalf("call = %q, %v", out, err)
+		}
+	}
+	if tool.calls != 1 {
+		t.Errorf("tool ran %d times for identical calls, want 1", tool.calls)
+	}
+
+	for _, args := range []string{"fail", "fail", "private", "private"} {
+		c.call(ctx, tool, args)
+	}
+	if tool.calls != 5 {
+		t.Errorf("tool ran %d times, want failures and uncacheable calls to run every time", tool.calls)
+	}
+
+	// Results expire after the tool's TTL.
+	expiring := &countingTool{ttl: time.Nanosecond}
+	c.call(ctx, expiring, "Bergen")
+	time.Sleep(time.Millisecond)
+	c.call(ctx, expiring, "Bergen")
+	if expiring.calls != 2 {
+		t.Errorf("tool ran %d times across expiry, want 2", expiring.calls)
+	}
+
+	var none *ToolCache
+	none.call(ctx, tool, "Oslo")
+	if tool.calls != 6 {
+		t.Error("a nil cache didn't call through")
+	}
+}
+
+func TestWeatherToolCacheKey(t *testing.T) {
+	tool := &weatherTool{service: NewWeatherService(t.Name())}
+	ctx := WithAliasResolver(context.Background(), fakeAliases{"home": {Name: "home", Lat: 41.4, Lon: 2.17}})
+
+	k1, ttl, ok := tool.CacheKey(ctx, `{"location":"Oslo","forecast_days":3}`)
+	k2, _, _ := tool.CacheKey(ctx, `{"location":" oslo ","forecast_days":3}`)
+	k3, _, _ := tool.CacheKey(ctx, `{"location":"Oslo"}`)
+	if !ok || ttl != 10*time.Minute || k1 != k2 || k1 == k3 {
+		t.Errorf("keys %q, %q, %q (ttl %v, ok %v): want the same place and days to share a key", k1, k2, k3, ttl, ok)
+	}
+
+	if _, _, ok := tool.CacheKey(ctx, `{"location":"home"}`); ok {
+		t.Error("the weather of a saved place must not be shared between users")
+	}
+}
@@ -0,0 +1,352 @@
+package assistant
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	ics "github.com/arran4/golang-ical"
+	"github.com/google/uuid"
+	"github.com/openai/openai-go/v2"
+)
+
+// caldavProductID names the assistant in the events it writes.
+const caldavProductID = "-//acai-travel//assistant//EN"
+
+// caldavCreatorProperty marks the events add_calendar_event creates, the only ones
+// update_calendar_event changes. Unlike PRODID, it belongs to the event, so it survives
+// calendar clients rewriting the resource.
+const caldavCreatorProperty = ics.ComponentProperty("X-ACAI-CREATED-BY")
+
+type addCalendarEventTool struct {
+	// now is the clock, replaceable in tests.
+	now func() time.Time
+}
+
+func (t *addCalendarEventTool) Name() string { return "add_calendar_event" }
+
+func (t *addCalendarEventTool) Guidance() string {
+	return "Use **add_calendar_event** to put an event on the user's calendar, e.g. a flight or a trip planning session, only once the user agreed to its title and time; pass the user's **timezone** when known. Keep the event_id it returns to change the event later with **update_calendar_event**."
+}
+
+// Available offers the tool only to users who may connect a CalDAV calendar.
+func (t *addCalendarEventTool) Available(ctx context.Context) bool {
+	return caldavFromContext(ctx) != nil
+}
+
+func (t *addCalendarEventTool) Definition() openai.FunctionDefinitionParam {
+	return openai.FunctionDefinitionParam{
+		Name:        t.Name(),
+		Description: openai.String("Adds an event to the user's connected calendar and returns its event_id. A start date without a time adds an all-day event."),
+		Parameters:  paramsOf(addCalendarEventArgs{}),
+	}
+}
+
+type addCalendarEventArgs struct {
+	Title           string `json:"title" validate:"required" desc:"Title of the event, e.g. 'Flight to Lisbon'."`
+	Start           string `json:"start" validate:"required" desc:"Start as local time YYYY-MM-DDTHH:MM in timezone, or RFC3339; a date YYYY-MM-DD alone adds an all-day event."`
+	End             string `json:"end,omitempty" desc:"Optional end, in the same format as start. Defaults to start plus duration_minutes, or the end of the day for all-day events."`
+	DurationMinutes int    `json:"duration_minutes,omitempty" validate:"min=1,max=1440" desc:"Optional length of the event in minutes when no end is given. Defaults to 60."`
+	Location        string `json:"location,omitempty" desc:"Optional place of the event, e.g. 'Lisbon Airport'."`
+	Description     string `json:"description,omitempty" desc:"Optional notes for the event."`
+	Timezone        string `json:"timezone,omitempty" desc:"Optional IANA time zone of local times, e.g. 'Europe/Madrid'. Defaults to UTC."`
+}
+
+func (t *addCalendarEventTool) Call(ctx context.Context, args string) (string, error) {
+	var payload addCalendarEventArgs
+	if err := decodeArgs(args, &payload); err != nil {
+		return "", err
+	}
+	if strings.TrimSpace(payload.Title) == "" {
+		return "", errors.New(`invalid argument "title": must not be empty`)
+	}
+	loc, err := eventLocation(payload.Timezone)
+	if err != nil {
+		return "", err
+	}
+
+	start, allDay, err := parseEventTime("start", payload.Start, loc)
+	if err != nil {
+		return "", err
+	}
+	var end time.Time
+	if payload.End != "" {
+		if end, _, err = parseEventTime("end", payload.End, loc); err != nil {
+			return "", err
+		}
+	} else if allDay {
+		end = start.AddDate(0, 0, 1)
+	} else {
+		minutes := payload.DurationMinutes
+		if minutes == 0 {
+			minutes = 60
+		}
+		end = start.Add(time.Duration(minutes) * time.Minute)
+	}
+	if !end.After(start) {
+		return "", errors.New(`invalid arguments: "end" must be after "start"`)
+	}
+
+	client, err := connectCalDAV(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	uid := uuid.NewString()
+	now := time.Now
+	if t.now != nil {
+		now = t.now
+	}
+	cal := ics.NewCalendar()
+	cal.SetProductId(caldavProductID)
+	event := cal.AddEvent(uid)
+	event.SetDtStampTime(now())
+	event.SetProperty(caldavCreatorProperty, "assistant")
+	event.SetSummary(payload.Title)
+	setEventTimes(event, start, end, allDay)
+	if payload.Location != "" {
+		event.SetLocation(payload.Location)
+	}
+	if payload.Description != "" {
+		event.SetDescription(payload.Description)
+	}
+
+	if err := client.put(ctx, uid, cal, ""); errors.Is(err, errCalDAVNotFound) {
+		return "", errors.New("The user's calendar was not found at its connected address. Tell the user to connect it again.")
+	} else if err != nil {
+		return "", caldavToolError(err, uid)
+	}
+	return fmt.Sprintf("Added %q to the user's calendar: %s. Its event_id is %q.", payload.Title, describeEventTimes(start, end, allDay, loc), uid), nil
+}
+
+type updateCalendarEventTool struct {
+	// now is the clock, replaceable in tests.
+	now func() time.Time
+}
+
+func (t *updateCalendarEventTool) Name() string { return "update_calendar_event" }
+
+func (t *updateCalendarEventTool) Guidance() string {
+	return "Use **update_calendar_event** to move, rename or annotate an event added with **add_calendar_event**, never other events of the user's calendar, passing its **event_id** and only what changes."
+}
+
+// Available offers the tool only to users who may connect a CalDAV calendar.
+func (t *updateCalendarEventTool) Available(ctx context.Context) bool {
+	return caldavFromContext(ctx) != nil
+}
+
+func (t *updateCalendarEventTool) Definition() openai.FunctionDefinitionParam {
+	return openai.FunctionDefinitionParam{
+		Name:        t.Name(),
+		Description: openai.String("Updates an event add_calendar_event added to the user's connected calendar, by the event_id it returned. Only the fields given change; moving the start without an end keeps the event's length."),
+		Parameters:  paramsOf(updateCalendarEventArgs{}),
+	}
+}
+
+type updateCalendarEventArgs struct {
+	EventID     string `json:"event_id" validate:"required" desc:"The event_id returned by add_calendar_event."`
+	Title       string `json:"title,omitempty" desc:"Optional new title."`
+	Start       string `json:"start,omitempty" desc:"Optional new start, as local time YYYY-MM-DDTHH:MM in timezone, or RFC3339; a date YYYY-MM-DD alone makes it an all-day event."`
+	End         string `json:"end,omitempty" desc:"Optional new end, in the same format as start."`
+	Location    string `json:"location,omitempty" desc:"Optional new place."`
+	Description string `json:"description,omitempty" desc:"Optional new notes."`
+	Timezone    string `json:"timezone,omitempty" desc:"Optional IANA time zone of local times, e.g. 'Europe/Madrid'. Defaults to UTC."`
+}
+
+func (t *updateCalendarEventTool) Call(ctx context.Context, args string) (string, error) {
+	var payload updateCalendarEventArgs
+	if err := decodeArgs(args, &payload); err != nil {
+		return "", err
+	}
+	uid := strings.TrimSpace(payload.EventID)
+	if uid == "" {
+		return "", errors.New(`invalid argument "event_id": must not be empty`)
+	}
+	loc, err := eventLocation(payload.Timezone)
+	if err != nil {
+		return "", err
+	}
+
+	client, err := connectCalDAV(ctx)
+	if err != nil {
+		return "", err
+	}
+	cal, etag, err := client.get(ctx, uid)
+	if err != nil {
+		return "", caldavToolError(err, uid)
+	}
+	var event *ics.VEvent
+	for _, e := range cal.Events() {
+		if e.Id() == uid {
+			event = e
+			break
+		}
+	}
+	if event == nil {
+		return "", caldavToolError(errCalDAVNotFound, uid)
+	}
+	if p := event.GetProperty(caldavCreatorProperty); p == nil || p.Value != "assistant" {
+		return "", fmt.Errorf("The event %q wasn't added by the assistant, so this tool can't change it. Tell the user to change it in their calendar.", uid)
+	}
+
+	start, end, allDay, err := currentEventTimes(event, loc)
+	if err != nil {
+		return "", fmt.Errorf("The event %q has no start time this tool can read, so it can't be updated.", uid)
+	}
+	if payload.Start != "" {
+		length, wasAllDay := end.Sub(start), allDay
+		if start, allDay, err = parseEventTime("start", payload.Start, loc); err != nil {
+			return "", err
+		}
+		switch {
+		case allDay == wasAllDay:
+			end = start.Add(length)
+		case allDay:
+			end = start.AddDate(0, 0, 1)
+		default:
+			end = start.Add(defaultEventLength)
+		}
+	}
+	if payload.End != "" {
+		if end, _, err = parseEventTime("end", payload.End, loc); err != nil {
+			return "", err
+		}
+	}
+	if !end.After(start) {
+		return "", errors.New(`invalid arguments: "end" must be after "start"`)
+	}
+
+	if payload.Title != "" {
+		event.SetSummary(payload.Title)
+	}
+	if payload.Location != "" {
+		event.SetLocation(payload.Location)
+	}
+	if payload.Description != "" {
+		event.SetDescription(payload.Description)
+	}
+	event.RemoveProperty(ics.ComponentPropertyDuration)
+	setEventTimes(event, start, end, allDay)
+
+	now := time.Now
+	if t.now != nil {
+		now = t.now
+	}
+	event.SetDtStampTime(now())
+	event.SetLastModifiedAt(now())
+	sequence := 0
+	if p := event.GetProperty(ics.ComponentPropertySequence); p != nil {
+		sequence, _ = strconv.Atoi(p.Value)
+	}
+	event.SetSequence(sequence + 1)
+
+	if err := client.put(ctx, uid, cal, etag); err != nil {
+		return "", caldavToolError(err, uid)
+	}
+
+	var title string
+	if p := event.GetProperty(ics.ComponentPropertySummary); p != nil {
+		title = p.Value
+	}
+	return fmt.Sprintf("Updated %q on the user's calendar: %s.", title, describeEventTimes(start, end, allDay, loc)), nil
+}
+
+func eventLocation(timezone string) (*time.Location, error) {
+	if timezone == "" {
+		return time.UTC, nil
+	}
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, fmt.Errorf(`invalid argument "timezone": unknown time zone %q, use an IANA name like "Europe/Madrid"`, timezone)
+	}
+	return loc, nil
+}
+
+// parseEventTime parses an event's start or end: RFC3339, a local time in loc, or a date
+// alone for all-day events.
+func parseEventTime(arg, s string, loc *time.Location) (t time.Time, allDay bool, err error) {
+	s = strings.TrimSpace(s)
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, false, nil
+	}
+	for _, layout := range []string{"2006-01-02T15:04", "2006-01-02T15:04:05", "2006-01-02 15:04"} {
+		if t, err := time.ParseInLocation(layout, s, loc); err == nil {
+			return t, false, nil
+		}
+	}
+	if t, err := time.ParseInLocation(time.DateOnly, s, loc); err == nil {
+		return t, true, nil
+	}
+	return time.Time{}, false, fmt.Errorf(`invalid argument %q: must be a local time as YYYY-MM-DDTHH:MM, RFC3339 or a date as YYYY-MM-DD (got %q)`, arg, s)
+}
+
+// currentEventTimes reads the start and end of an event. An event without an end lasts an
+// hour, or its day for all-day events.
+func currentEventTimes(event *ics.VEvent, loc *time.Location) (start, end time.Time, allDay bool, err error) {
+	if p := event.GetProperty(ics.ComponentPropertyDtStart); p != nil && !strings.Contains(p.Value, "T") {
+		if start, err = event.GetAllDayStartAt(); err != nil {
+			return
+		}
+		start = time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, loc)
+		end = start.AddDate(0, 0, 1)
+		if e, err := event.GetAllDayEndAt(); err == nil {
+			end = time.Date(e.Year(), e.Month(), e.Day(), 0, 0, 0, 0, loc)
+		}
+		return start, end, true, nil
+	}
+
+	if start, err = eventTime(event, ics.ComponentPropertyDtStart, loc); err != nil {
+		return
+	}
+	end = start.Add(defaultEventLength)
+	if e, err := eventTime(event, ics.ComponentPropertyDtEnd, loc); err == nil {
+		end = e
+	}
+	return start, end, false, nil
+}
+
+func setEventTimes(event *ics.VEvent, start, end time.Time, allDay bool) {
+	event.RemoveProperty(ics.ComponentPropertyDtStart)
+	event.RemoveProperty(ics.ComponentPropertyDtEnd)
+	if allDay {
+		event.SetAllDayStartAt(start)
+		event.SetAllDayEndAt(end)
+		return
+	}
+	event.SetStartAt(start)
+	event.SetEndAt(end)
+}
+
+// describeEventTimes describes when an event is in loc, e.g. "2025-10-15 (Wednesday)
+// 14:00–17:00 Europe/Madrid".
+func describeEventTimes(start, end time.Time, allDay bool, loc *time.Location) string {
+	if allDay {
+		last := end.AddDate(0, 0, -1)
+		if !last.After(start) {
+			return fmt.Sprintf("%s (%s), all day", start.Format(time.DateOnly), start.Weekday())
+		}
+		return fmt.Sprintf("%s (%s) to %s (%s), all day", start.Format(time.DateOnly), start.Weekday(), last.Format(time.DateOnly), last.Weekday())
+	}
+
+	start, end = start.In(loc), end.In(loc)
+	if day(start).Equal(day(end)) {
+		return fmt.Sprintf("%s (%s) %s–%s %s", start.Format(time.DateOnly), start.Weekday(), start.Format("15:04"), end.Format("15:04"), loc)
+	}
+	return fmt.Sprintf("%s (%s) %s to %s (%s) %s %s", start.Format(time.DateOnly), start.Weekday(), start.Format("15:04"),
+		end.Format(time.DateOnly), end.Weekday(), end.Format("15:04"), loc)
+}
+
+// caldavToolError turns a CalDAV failure into an error for the model.
+func caldavToolError(err error, uid string) error {
+	switch {
+	case errors.Is(err, errCalDAVNotFound):
+		return fmt.Errorf("No event with event_id %q is on the user's calendar; it may have been deleted, or the calendar's address changed.", uid)
+	case errors.Is(err, errCalDAVConflict):
+		return fmt.Errorf("The event %q changed on the user's calendar meanwhile. Call the tool again to apply the change to its latest version.", uid)
+	default:
+		return err
+	}
+}
@@ -0,0 +1,197 @@
+package assistant
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/acai-travel/tech-challenge/internal/httpx"
+)
+
+// fakeCalDAV is a CalDAV calendar collection holding events in memory, as a server would.
+type fakeCalDAV struct {
+	mu     sync.Mutex
+	events map[string]string
+	etags  map[string]int
+}
+
+func newFakeCalDAV(t *testing.T) (*fakeCalDAV, *httptest.Server) {
+	f := &fakeCalDAV{events: map[string]string{}, etags: map[string]int{}}
+	srv := httptest.NewServer(f)
+	t.Cleanup(srv.Close)
+	return f, srv
+}
+
+func (f *fakeCalDAV) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if user, pass, _ := r.BasicAuth(); user != "alice" || pass != "secret" {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+	path, ok := strings.CutPrefix(r.URL.Path, "/calendars/alice/work/")
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	etag := fmt.Sprintf(`"%d"`, f.etags[path])
+	_, exists := f.events[path]
+
+	switch r.Method {
+	case http.MethodGet:
+		if !exists {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("ETag", etag)
+		_, _ = io.WriteString(w, f.events[path])
+	case http.MethodPut:
+		if (r.Header.Get("If-None-Match") == "*" && exists) || (r.Header.Get("If-Match") != "" && r.Header.Get("If-Match") != etag) {
+			http.Error(w, "precondition failed", http.StatusPreconditionFailed)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		f.events[path] = string(body)
+		f.etags[path]++
+		w.WriteHeader(http.StatusCreated)
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// event returns the stored event with uid, unfolding its lines.
+func (f *fakeCalDAV) event(uid string) string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return strings.ReplaceAll(f.events[uid+".ics"], "\r\n ", "")
+}
+
+type fakeCalDAVSource struct{ account *CalDAVAccount }
+
+func (f fakeCalDAVSource) CalDAVAccount(context.Context) (*CalDAVAccount, error) {
+	return f.account, nil
+}
+
+// allowLocalCalDAV lets the test write to local CalDAV servers, which caldavHTTPClient
+// refuses.
+func allowLocalCalDAV(t *testing.T) {
+	client := caldavHTTPClient
+	caldavHTTPClient = httpx.Client(20 * time.Second)
+	t.Cleanup(func() { caldavHTTPClient = client })
+}
+
+var eventIDPattern = regexp.MustCompile(`event_id is "([^"]+)"`)
+
+func TestCalendarEventTools(t *testing.T) {
+	allowLocalCalDAV(t)
+	calendar, srv := newFakeCalDAV(t)
+	ctx := WithCalDAV(context.Background(), fakeCalDAVSource{&CalDAVAccount{URL: srv.URL + "/calendars/alice/work/", Username: "alice", Password: "secret"}})
+	now := func() time.Time { return time.Date(2025, 10, 14, 8, 0, 0, 0, time.UTC) }
+	add, update := &addCalendarEventTool{now: now}, &updateCalendarEventTool{now: now}
+
+	got, err := add.Call(ctx, `{"title":"Trip planning","start":"2025-10-15T14:00","duration_minutes":180,"location":"Office","timezone":"Europe/Madrid"}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := eventIDPattern.FindStringSubmatch(got)
+	if m == nil || !strings.HasPrefix(got, `Added "Trip planning" to the user's calendar: 2025-10-15 (Wednesday) 14:00–17:00 Europe/Madrid.`) {
+		t.Fatalf("add_calendar_event = %q", got)
+	}
+	uid := m[1]
+	ev := calendar.event(uid)
+	for _, want := range []string{"SUMMARY:Trip planning", "DTSTART:20251015T120000Z", "DTEND:20251015T150000Z", "LOCATION:Office", "UID:" + uid} {
+		if !strings.Contains(ev, want) {
+			t.Errorf("stored event is missing %q:\n%s", want, ev)
+		}
+	}
+
+	t.Run("move keeps the length", func(t *testing.T) {
+		got, err := update.Call(ctx, fmt.Sprintf(`{"event_id":%q,"start":"2025-10-16T10:30","title":"Lisbon planning","timezone":"Europe/Madrid"}`, uid))
+		if want := `Updated "Lisbon planning" on the user's calendar: 2025-10-16 (Thursday) 10:30–13:30 Europe/Madrid.`; err != nil || got != want {
+			t.Fatalf("update_calendar_event = %q, %v, want %q", got, err, want)
+		}
+		ev := calendar.event(uid)
+		for _, want := range []string{"SUMMARY:Lisbon planning", "DTSTART:20251016T083000Z", "DTEND:20251016T113000Z", "LOCATION:Office", "SEQUENCE:1"} {
+			if !strings.Contains(ev, want) {
+				t.Errorf("updated event is missing %q:\n%s", want, ev)
+			}
+		}
+		if strings.Count(ev, "DTSTART") != 1 {
+			t.Errorf("updated event has several starts:\n%s", ev)
+		}
+	})
+
+	t.Run("all-day events", func(t *testing.T) {
+		got, err := add.Call(ctx, `{"title":"Lisbon","start":"2025-10-17","end":"2025-10-20"}`)
+		if err != nil || !strings.HasPrefix(got, `Added "Lisbon" to the user's calendar: 2025-10-17 (Friday) to 2025-10-19 (Sunday), all day.`) {
+			t.Fatalf("add_calendar_event = %q, %v", got, err)
+		}
+		ev := calendar.event(eventIDPattern.FindStringSubmatch(got)[1])
+		if !strings.Contains(ev, "DTSTART;VALUE=DATE:20251017") || !strings.Contains(ev, "DTEND;VALUE=DATE:20251020") {
+			t.Errorf("stored event isn't all-day:\n%s", ev)
+		}
+	})
+
+	t.Run("other events", func(t *testing.T) {
+		foreign := "BEGIN:VCALENDAR\r\nVERSION:2.0\r\nPRODID:-//Example//Calendar//EN\r\nBEGIN:VEVENT\r\nUID:dentist\r\nDTSTAMP:20251001T080000Z\r\nDTSTART:20251020T090000Z\r\nSUMMARY:Dentist\r\nEND:VEVENT\r\nEND:VCALENDAR\r\n"
+		calendar.mu.Lock()
+		calendar.events["dentist.ics"] = foreign
+		calendar.mu.Unlock()
+
+		if _, err := update.Call(ctx, `{"event_id":"dentist","title":"Cancelled"}`); err == nil || !strings.Contains(err.Error(), "wasn't added by the assistant") {
+			t.Errorf("updating an event the assistant didn't add: %v", err)
+		}
+		if got := calendar.event("dentist"); got != foreign {
+			t.Errorf("the event changed:\n%s", got)
+		}
+	})
+
+	for _, tt := range []struct {
+		name string
+		ctx  context.Context
+		tool Tool
+		args string
+		want string
+	}{
+		{"unknown event", ctx, update, `{"event_id":"nope","title":"x"}`, `No event with event_id "nope"`},
+		{"end before start", ctx, add, `{"title":"x","start":"2025-10-15T14:00","end":"2025-10-15T13:00"}`, `"end" must be after "start"`},
+		{"bad start", ctx, add, `{"title":"x","start":"next tuesday"}`, "YYYY-MM-DDTHH:MM"},
+		{"no account", WithCalDAV(context.Background(), fakeCalDAVSource{}), add, `{"title":"x","start":"2025-10-15"}`, "connect one with its CalDAV address"},
+		{"wrong password", WithCalDAV(context.Background(), fakeCalDAVSource{&CalDAVAccount{URL: srv.URL + "/calendars/alice/work/", Username: "alice", Password: "old"}}), add, `{"title":"x","start":"2025-10-15"}`, "rejected the connected credentials"},
+		{"moved calendar", WithCalDAV(context.Background(), fakeCalDAVSource{&CalDAVAccount{URL: srv.URL + "/calendars/alice/old/", Username: "alice", Password: "secret"}}), add, `{"title":"x","start":"2025-10-15"}`, "not found at its connected address"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.tool.Call(tt.ctx, tt.args); err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Call(%s) = %v, want an error containing %q", tt.args, err, tt.want)
+			}
+		})
+	}
+
+	if add.Available(context.Background()) || update.Available(context.Background()) {
+		t.Error("calendar event tools must not be offered without CalDAV")
+	}
+	if add.Available(WithoutCalDAV(ctx)) || update.Available(WithoutCalDAV(ctx)) {
+		t.Error("calendar event tools must not be offered once CalDAV is removed")
+	}
+}
+
+func TestCalendarEventTools_NotPublic(t *testing.T) {
+	calendar, srv := newFakeCalDAV(t)
+	ctx := WithCalDAV(context.Background(), fakeCalDAVSource{&CalDAVAccount{URL: srv.URL + "/calendars/alice/work/", Username: "alice", Password: "secret"}})
+
+	if _, err := (&addCalendarEventTool{}).Call(ctx, `{"title":"x","start":"2025-10-15"}`); !errors.Is(err, httpx.ErrNotPublic) {
+		t.Errorf("adding an event to a loopback address: got %v, want ErrNotPublic", err)
+	}
+	if len(calendar.events) != 0 {
+		t.Errorf("stored %d events, want none", len(calendar.events))
+	}
+}
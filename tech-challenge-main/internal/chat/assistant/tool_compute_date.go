@@ -0,0 +1,275 @@
+package assistant
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/openai/openai-go/v2"
+)
+
+type computeDateTool struct {
+	// now is the clock, replaceable in tests.
+	now func() time.Time
+}
+
+func (t *computeDateTool) Name() string { return "compute_date" }
+
+func (t *computeDateTool) Guidance() string {
+	return `Use **compute_date** for any weekday or date arithmetic ("next Friday", "in 3 weeks"); never compute dates yourself.`
+}
+
+func (t *computeDateTool) Definition() openai.FunctionDefinitionParam {
+	return openai.FunctionDefinitionParam{
+		Name:        t.Name(),
+		Description: openai.String("Computes a calendar date from a base date and a relative offset, e.g. 'next Friday' or '+3 days'. ALWAYS use this instead of doing weekday or date arithmetic yourself. Returns the date, its weekday and how many days it is from the base date."),
+		Parameters: openai.FunctionParameters{
+			"type": "object",
+			"properties": map[string]any{
+				"offset": map[string]string{
+					"type":        "string",
+					"description": "Relative offset in English, Spanish, Catalan or French: 'today', 'tomorrow', 'yesterday', '+3 days', '-2 weeks', 'in 1 month', '10 days ago', 'next week', a weekday ('Friday' is the next Friday on or after the base date), 'next Friday' (the first Friday after the base date) or 'last Friday' (the last Friday before it), or their translations, e.g. 'el próximo viernes', 'demà passat' or 'dans 3 jours'.",
+				},
+				"base_date": map[string]string{
+					"type":        "string",
+					"description": "Optional date to compute from, as YYYY-MM-DD or RFC3339. Defaults to today.",
+				},
+				"timezone": map[string]string{
+					"type":        "string",
+					"description": "Optional IANA time zone deciding what 'today' is, e.g. 'Europe/Madrid'. Defaults to UTC.",
+				},
+			},
+			"required": []string{"offset"},
+		},
+	}
+}
+
+type computeDateArgs struct {
+	Offset   string `json:"offset"`
+	BaseDate string `json:"base_date,omitempty"`
+	Timezone string `json:"timezone,omitempty"`
+}
+
+func (t *computeDateTool) Call(ctx context.Context, args string) (string, error) {
+	var payload computeDateArgs
+	if err := decodeArgs(args, &payload); err != nil {
+		return "", err
+	}
+
+	loc := time.UTC
+	if payload.Timezone != "" {
+		var err error
+		if loc, err = time.LoadLocation(payload.Timezone); err != nil {
+			return "", fmt.Errorf(`invalid argument "timezone": unknown time zone %q, use an IANA name like "Europe/Madrid"`, payload.Timezone)
+		}
+	}
+
+	now := time.Now
+	if t.now != nil {
+		now = t.now
+	}
+	base := day(now().In(loc))
+	if payload.BaseDate != "" {
+		var err error
+		if base, err = parseBaseDate(payload.BaseDate, loc); err != nil {
+			return "", err
+		}
+	}
+
+	date, err := computeDate(base, payload.Offset)
+	if err != nil {
+		return "", err
+	}
+
+	diff := int((date.Unix() - base.Unix()) / 86400)
+	return fmt.Sprintf("%s (%s) is %s %s (%s)",
+		date.Format(time.DateOnly), date.Weekday(), describeDays(diff), base.Format(time.DateOnly), base.Weekday()), nil
+}
+
+// parseBaseDate accepts a date or an RFC3339 timestamp, taking the calendar day in loc.
+func parseBaseDate(s string, loc *time.Location) (time.Time, error) {
+	if d, err := time.ParseInLocation(time.DateOnly, s, loc); err == nil {
+		return day(d), nil
+	}
+	if ts, err := time.Parse(time.RFC3339, s); err == nil {
+		return day(ts.In(loc)), nil
+	}
+	return time.Time{}, fmt.Errorf(`invalid argument "base_date": expected YYYY-MM-DD or RFC3339, got %q`, s)
+}
+
+func describeDays(n int) string {
+	switch {
+	case n == 0:
+		return "the same day as"
+	case n == 1:
+		return "1 day after"
+	case n == -1:
+		return "1 day before"
+	case n > 0:
+		return fmt.Sprintf("%d days after", n)
+	default:
+		return fmt.Sprintf("%d days before", -n)
+	}
+}
+
+var (
+	relativeOffset = regexp.MustCompile(`^(?:in )?([+-]?\d+) ?(day|week|month|year)s?( ago| later| from now)?$`)
+	weekdayOffset  = regexp.MustCompile(`^(?:(next|this|coming|last|previous) )?(monday|tuesday|wednesday|thursday|friday|saturday|sunday)$`)
+	periodOffset   = regexp.MustCompile(`^(next|last) (week|month|year)$`)
+)
+
+var weekdays = map[string]time.Weekday{
+	"sunday": time.Sunday, "monday": time.Monday, "tuesday": time.Tuesday, "wednesday": time.Wednesday,
+	"thursday": time.Thursday, "friday": time.Friday, "saturday": time.Saturday,
+}
+
+// offsetPhrases are the phrases of Spanish, Catalan and French offsets that translate to
+// English as a whole, replaced before offsetWords.
+var offsetPhrases = strings.NewReplacer(
+	"pasado mañana", "day after tomorrow", "demà passat", "day after tomorrow",
+	"abans d'ahir", "day before yesterday",
+	"que viene", "next", "que ve", "next",
+	"d'aquí a", "in", "il y a", "ago",
+)
+
+// offsetWords translates the words of Spanish, Catalan and French offsets, e.g. "el próximo
+// viernes" or "dans 3 jours", to English ones; empty words are dropped.
+var offsetWords = map[string]string{
+	// Spanish
+	"hoy": "today", "mañana": "tomorrow", "manana": "tomorrow", "ayer": "yesterday", "anteayer": "day before yesterday",
+	"lunes": "monday", "martes": "tuesday", "miércoles": "wednesday", "miercoles": "wednesday", "jueves": "thursday",
+	"viernes": "friday", "sábado": "saturday", "sabado": "saturday", "domingo": "sunday",
+	"próximo": "next", "proximo": "next", "próxima": "next", "proxima": "next", "siguiente": "next",
+	"pasado": "last", "pasada": "last", "este": "this", "esta": "this",
+	"día": "day", "días": "days", "dias": "days", "semana": "week", "semanas": "weeks",
+	"meses": "months", "año": "year", "años": "years",
+	"dentro": "in", "en": "in", "hace": "ago",
+	"el": "", "la": "", "los": "", "las": "", "de": "", "del": "",
+	// Catalan
+	"avui": "today", "demà": "tomorrow", "dema": "tomorrow", "ahir": "yesterday",
+	"dilluns": "monday", "dimarts": "tuesday", "dimecres": "wednesday", "dijous": "thursday",
+	"divendres": "friday", "dissabte": "saturday", "diumenge": "sunday",
+	"vinent": "next", "proper": "next", "propera": "next", "passat": "last", "passada": "last",
+	"aquest": "this", "aquesta": "this",
+	"dia": "day", "dies": "days", "setmana": "week", "setmanes": "weeks", "mes": "month", "mesos": "months",
+	"any": "year", "anys": "years", "fa": "ago", "a": "",
+	// French
+	"aujourd'hui": "today", "demain": "tomorrow", "hier": "yesterday",
+	"après-demain": "day after tomorrow", "avant-hier": "day before yesterday",
+	"lundi": "monday", "mardi": "tuesday", "mercredi": "wednesday", "jeudi": "thursday",
+	"vendredi": "friday", "samedi": "saturday", "dimanche": "sunday",
+	"prochain": "next", "prochaine": "next", "dernier": "last", "dernière": "last", "ce": "this", "cette": "this",
+	"jour": "day", "jours": "days", "semaine": "week", "semaines": "weeks", "mois": "month",
+	"an": "year", "ans": "years", "année": "year", "années": "years", "dans": "in", "le": "", "les": "",
+	// What the phrases translate to.
+	"next": "next", "last": "last", "ago": "ago",
+}
+
+// translateOffset rewrites an offset in Spanish, Catalan or French as the English offset
+// computeDate parses, e.g. "divendres vinent" as "next friday" and "hace 2 semanas" as
+// "2 weeks ago". Other offsets are only normalized.
+func translateOffset(o string) string {
+	o = offsetPhrases.Replace(o)
+
+	var modifier, rest []string
+	ago := false
+	for _, w := range strings.Fields(o) {
+		w = strings.TrimPrefix(w, "l'")
+		e, ok := offsetWords[w]
+		switch {
+		case !ok:
+			rest = append(rest, w)
+		case e == "ago":
+			ago = true
+		case e == "next", e == "last", e == "this":
+			// Modifiers may follow the weekday or period: "vendredi prochain".
+			modifier = []string{e}
+		case e != "":
+			rest = append(rest, e)
+		}
+	}
+	if ago {
+		rest = append(rest, "ago")
+	}
+	return strings.Join(append(modifier, rest...), " ")
+}
+
+// computeDate applies a relative offset, as described in the tool definition, to a day.
+func computeDate(base time.Time, offset string) (time.Time, error) {
+	o := translateOffset(strings.ToLower(offset))
+
+	switch o {
+	case "", "today", "now":
+		return base, nil
+	case "tomorrow":
+		return base.AddDate(0, 0, 1), nil
+	case "yesterday":
+		return base.AddDate(0, 0, -1), nil
+	case "day after tomorrow", "the day after tomorrow":
+		return base.AddDate(0, 0, 2), nil
+	case "day before yesterday", "the day before yesterday":
+		return base.AddDate(0, 0, -2), nil
+	}
+
+	if m := relativeOffset.FindStringSubmatch(o); m != nil {
+		n, err := strconv.Atoi(m[1])
+		if err != nil || n > 100_000 || n < -100_000 {
+			return time.Time{}, fmt.Errorf(`invalid argument "offset": %q is out of range`, offset)
+		}
+		if m[3] == " ago" {
+			n = -n
+		}
+		return addUnits(base, m[2], n), nil
+	}
+
+	if m := periodOffset.FindStringSubmatch(o); m != nil {
+		n := 1
+		if m[1] == "last" {
+			n = -1
+		}
+		return addUnits(base, m[2], n), nil
+	}
+
+	if m := weekdayOffset.FindStringSubmatch(o); m != nil {
+		target := weekdays[m[2]]
+		ahead := (int(target) - int(base.Weekday()) + 7) % 7
+		switch m[1] {
+		case "", "this", "coming":
+			return base.AddDate(0, 0, ahead), nil
+		case "next":
+			if ahead == 0 {
+				ahead = 7
+			}
+			return base.AddDate(0, 0, ahead), nil
+		default: // last, previous
+			behind := (int(base.Weekday()) - int(target) + 7) % 7
+			if behind == 0 {
+				behind = 7
+			}
+			return base.AddDate(0, 0, -behind), nil
+		}
+	}
+
+	return time.Time{}, errors.New(`invalid argument "offset": use e.g. "+3 days", "-1 week", "in 2 months", "next Friday" or "tomorrow"`)
+}
+
+// addUnits adds n days, weeks, months or years. Months and years keep the day of month,
+// clamped to the end of shorter months: Jan 31 + 1 month is Feb 28 (or 29), not Mar 3.
+func addUnits(base time.Time, unit string, n int) time.Time {
+	switch unit {
+	case "day":
+		return base.AddDate(0, 0, n)
+	case "week":
+		return base.AddDate(0, 0, 7*n)
+	case "year":
+		n *= 12
+	}
+
+	first := time.Date(base.Year(), base.Month(), 1, 0, 0, 0, 0, base.Location()).AddDate(0, n, 0)
+	last := first.AddDate(0, 1, -1).Day()
+	return first.AddDate(0, 0, min(base.Day(), last)-1)
+}
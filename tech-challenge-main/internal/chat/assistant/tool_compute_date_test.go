@@ -0,0 +1,141 @@
+package assistant
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestComputeDate(t *testing.T) {
+	// A Wednesday.
+	base := time.Date(2025, 1, 29, 0, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		offset string
+		want   string
+	}{
+		{"today", "2025-01-29"},
+		{"", "2025-01-29"},
+		{"Tomorrow", "2025-01-30"},
+		{"yesterday", "2025-01-28"},
+		{"the day after tomorrow", "2025-01-31"},
+		{"+3 days", "2025-02-01"},
+		{"3 days", "2025-02-01"},
+		{"-1 day", "2025-01-28"},
+		{"in 2 weeks", "2025-02-12"},
+		{"10 days ago", "2025-01-19"},
+		{"+1 month", "2025-02-28"}, // clamped, not March 3
+		{"in 1 year", "2026-01-29"},
+		{"next week", "2025-02-05"},
+		{"last month", "2024-12-29"},
+		{"friday", "2025-01-31"},
+		{"this Wednesday", "2025-01-29"},
+		{"next Wednesday", "2025-02-05"},
+		{"next  Friday", "2025-01-31"},
+		{"last Wednesday", "2025-01-22"},
+		{"previous monday", "2025-01-27"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.offset, func(t *testing.T) {
+			got, err := computeDate(base, tc.offset)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got.Format(time.DateOnly) != tc.want {
+				t.Fatalf("computeDate(%q) = %s, want %s", tc.offset, got.Format(time.DateOnly), tc.want)
+			}
+		})
+	}
+
+	for _, offset := range []string{"soon", "next fortnight", "+3", "999999 days"} {
+		if _, err := computeDate(base, offset); err == nil {
+			t.Errorf("expected an error for %q", offset)
+		}
+	}
+}
+
+func TestComputeDate_Languages(t *testing.T) {
+	// A Wednesday.
+	base := time.Date(2025, 1, 29, 0, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		offset string
+		want   string
+	}{
+		// Spanish
+		{"hoy", "2025-01-29"},
+		{"mañana", "2025-01-30"},
+		{"pasado mañana", "2025-01-31"},
+		{"el viernes", "2025-01-31"},
+		{"el próximo miércoles", "2025-02-05"},
+		{"el miércoles que viene", "2025-02-05"},
+		{"el lunes pasado", "2025-01-27"},
+		{"dentro de 3 días", "2025-02-01"},
+		{"hace 2 semanas", "2025-01-15"},
+		{"la semana que viene", "2025-02-05"},
+		{"el mes pasado", "2024-12-29"},
+		// Catalan
+		{"demà", "2025-01-30"},
+		{"demà passat", "2025-01-31"},
+		{"divendres", "2025-01-31"},
+		{"dimecres vinent", "2025-02-05"},
+		{"dissabte passat", "2025-01-25"},
+		{"d'aquí a 3 dies", "2025-02-01"},
+		{"fa 10 dies", "2025-01-19"},
+		{"l'any vinent", "2026-01-29"},
+		// French
+		{"aujourd'hui", "2025-01-29"},
+		{"après-demain", "2025-01-31"},
+		{"vendredi", "2025-01-31"},
+		{"mercredi prochain", "2025-02-05"},
+		{"lundi dernier", "2025-01-27"},
+		{"dans 2 semaines", "2025-02-12"},
+		{"il y a 3 jours", "2025-01-26"},
+		{"le mois prochain", "2025-02-28"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.offset, func(t *testing.T) {
+			got, err := computeDate(base, tc.offset)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got.Format(time.DateOnly) != tc.want {
+				t.Fatalf("computeDate(%q) = %s, want %s", tc.offset, got.Format(time.DateOnly), tc.want)
+			}
+		})
+	}
+}
+
+func TestComputeDateTool(t *testing.T) {
+	// 23:30 UTC on a Sunday is already Monday in Madrid.
+	tool := &computeDateTool{now: func() time.Time { return time.Date(2025, 9, 14, 23, 30, 0, 0, time.UTC) }}
+
+	cases := []struct {
+		args string
+		want string
+	}{
+		{`{"offset":"next Friday"}`, "2025-09-19 (Friday) is 5 days after 2025-09-14 (Sunday)"},
+		{`{"offset":"today","timezone":"Europe/Madrid"}`, "2025-09-15 (Monday) is the same day as 2025-09-15 (Monday)"},
+		{`{"offset":"-1 day","base_date":"2025-03-01"}`, "2025-02-28 (Friday) is 1 day before 2025-03-01 (Saturday)"},
+		{`{"offset":"tomorrow","base_date":"2025-03-01T23:00:00Z","timezone":"Asia/Tokyo"}`, "2025-03-03 (Monday) is 1 day after 2025-03-02 (Sunday)"},
+	}
+
+	for _, tc := range cases {
+		got, err := tool.Call(context.Background(), tc.args)
+		if err != nil {
+			t.Fatalf("Call(%s): unexpected error: %v", tc.args, err)
+		}
+		if got != tc.want {
+			t.Errorf("Call(%s) = %q, want %q", tc.args, got, tc.want)
+		}
+	}
+
+	for _, args := range []string{`{"offset":"today","timezone":"Mars/Olympus"}`, `{"offset":"today","base_date":"03/01/2025"}`} {
+		if _, err := tool.Call(context.Background(), args); err == nil || !strings.Contains(err.Error(), "invalid argument") {
+			t.Errorf("Call(%s): expected an invalid argument error, got %v", args, err)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package assistant
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/openai/openai-go/v2"
+)
+
+type todayDateTool struct {
+	// weather resolves locations to time zones; nil disables the location argument.
+	weather *WeatherService
+	// now is the clock, replaceable in tests.
+	now func() time.Time
+}
+
+func (t *todayDateTool) Name() string { return "get_today_date" }
+
+func (t *todayDateTool) Guidance() string {
+	return "Use **get_today_date** for current date/time questions, passing the user's **location** or **timezone** when known."
+}
+
+func (t *todayDateTool) Definition() openai.FunctionDefinitionParam {
+	return openai.FunctionDefinitionParam{
+		Name:        t.Name(),
+		Description: openai.String("Get today's date and time in RFC3339 format, with the weekday and UTC offset. Defaults to UTC, so pass the user's timezone or location when known."),
+		Parameters: openai.FunctionParameters{
+			"type": "object",
+			"properties": map[string]any{
+				"timezone": map[string]string{
+					"type":        "string",
+					"description": "Optional IANA time zone, e.g. 'America/New_York'. Takes precedence over location.",
+				},
+				"location": map[string]string{
+					"type":        "string",
+					"description": "Optional city or place whose local time to return, e.g. 'Tokyo', 'London,UK' or a place the user saved like 'home'.",
+				},
+			},
+		},
+	}
+}
+
+type todayDateArgs struct {
+	Timezone string `json:"timezone,omitempty"`
+	Location string `json:"location,omitempty"`
+}
+
+func (t *todayDateTool) Call(ctx context.Context, args string) (string, error) {
+	var payload todayDateArgs
+	if err := decodeArgs(args, &payload); err != nil {
+		return "", err
+	}
+
+	now := time.Now
+	if t.now != nil {
+		now = t.now
+	}
+
+	loc, place := time.UTC, ""
+	switch {
+	case payload.Timezone != "":
+		var err error
+		if loc, err = time.LoadLocation(payload.Timezone); err != nil {
+			return "", fmt.Errorf(`invalid argument "timezone": unknown time zone %q, use an IANA name like "Europe/Madrid"`, payload.Timezone)
+		}
+	case payload.Location != "":
+		if t.weather == nil {
+			return "", errors.New(`looking up locations is not available, pass "timezone" instead`)
+		}
+		query, _, err := resolveLocation(ctx, payload.Location)
+		if err != nil {
+			return "", err
+		}
+		if loc, place, err = t.weather.Timezone(ctx, query); errors.Is(err, ErrLocationNotFound) {
+			return "", fmt.Errorf("could not find the time zone of %q: ask the user to be more specific, or pass \"timezone\"", payload.Location)
+		} else if err != nil {
+			return "", fmt.Errorf("could not find the time zone of %q: %w", payload.Location, err)
+		}
+	}
+
+	local := now().In(loc)
+	out := fmt.Sprintf("%s (%s), time zone %s (UTC%s)", local.Format(time.RFC3339), local.Weekday(), loc, local.Format("-07:00"))
+	if place != "" {
+		out += " in " + place
+	}
+	return out, nil
+}
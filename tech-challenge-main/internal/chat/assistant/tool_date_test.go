@@ -0,0 +1,66 @@
+package assistant
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTodayDateTool(t *testing.T) {
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/timezone.json" {
+			http.NotFound(w, r)
+			return
+		}
+		if q := r.URL.Query().Get("q"); q != "Tokyo" {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"error":{"code":1006,"message":"No matching location found."}}`)
+			return
+		}
+		fmt.Fprint(w, `{"location":{"name":"Tokyo","country":"Japan","tz_id":"Asia/Tokyo"}}`)
+	}))
+	defer api.Close()
+
+	weather := NewWeatherService(t.Name())
+	weather.baseURL = api.URL
+
+	// Late Sunday evening in UTC, already Monday in Asia.
+	now := func() time.Time { return time.Date(2025, 9, 14, 22, 0, 0, 0, time.UTC) }
+	tool := &todayDateTool{weather: weather, now: now}
+
+	cases := []struct {
+		args string
+		want string
+	}{
+		{``, "2025-09-14T22:00:00Z (Sunday), time zone UTC (UTC+00:00)"},
+		{`{"timezone":"America/New_York"}`, "2025-09-14T18:00:00-04:00 (Sunday), time zone America/New_York (UTC-04:00)"},
+		{`{"location":"Tokyo"}`, "2025-09-15T07:00:00+09:00 (Monday), time zone Asia/Tokyo (UTC+09:00) in Tokyo, Japan"},
+		{`{"timezone":"Europe/Madrid","location":"Tokyo"}`, "2025-09-15T00:00:00+02:00 (Monday), time zone Europe/Madrid (UTC+02:00)"},
+	}
+	for _, tc := range cases {
+		got, err := tool.Call(context.Background(), tc.args)
+		if err != nil {
+			t.Fatalf("Call(%s): unexpected error: %v", tc.args, err)
+		}
+		if got != tc.want {
+			t.Errorf("Call(%s) = %q, want %q", tc.args, got, tc.want)
+		}
+	}
+
+	for args, want := range map[string]string{
+		`{"timezone":"Mars/Olympus"}`: "unknown time zone",
+		`{"location":"Atlantis"}`:     "could not find the time zone",
+	} {
+		if _, err := tool.Call(context.Background(), args); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("Call(%s): expected error containing %q, got %v", args, want, err)
+		}
+	}
+
+	if _, err := (&todayDateTool{now: now}).Call(context.Background(), `{"location":"Tokyo"}`); err == nil {
+		t.Error("expected an error for a location without a weather service")
+	}
+}
@@ -0,0 +1,296 @@
+package assistant
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/acai-travel/tech-challenge/internal/chat/model"
+	ics "github.com/arran4/golang-ical"
+	"github.com/openai/openai-go/v2"
+)
+
+const (
+	// defaultFreeSlotDays is how many days are searched when no before_date is given.
+	defaultFreeSlotDays = 7
+	// maxFreeSlotDays bounds the searched range, so the answer stays readable.
+	maxFreeSlotDays = 31
+	// defaultEventLength is how long an event without an end blocks time.
+	defaultEventLength = time.Hour
+	// slotStep is what the start of a slot today is rounded up to.
+	slotStep = 15 * time.Minute
+)
+
+type freeSlotsTool struct {
+	// now is the clock, replaceable in tests.
+	now func() time.Time
+}
+
+func (t *freeSlotsTool) Name() string { return "find_free_slots" }
+
+func (t *freeSlotsTool) Guidance() string {
+	return `Use **find_free_slots** when the user asks when they are free ("when am I free for a 3-hour planning session this week?"). Pass the length asked for as **duration_minutes**, and the user's **timezone** when known; only suggest slots it returns.`
+}
+
+// Available offers the tool only to users whose calendars are attached to the context.
+func (t *freeSlotsTool) Available(ctx context.Context) bool {
+	return userCalendarsFromContext(ctx) != nil
+}
+
+func (t *freeSlotsTool) Definition() openai.FunctionDefinitionParam {
+	return openai.FunctionDefinitionParam{
+		Name:        t.Name(),
+		Description: openai.String("Finds the user's free time windows of at least a given length between the events of their own calendars, e.g. their work calendar, within a date range. Each line is a day in the format 'YYYY-MM-DD (Weekday): 09:00–12:30 (3h 30m), …', listing only days with a free window. All-day events, cancelled events and events marked as free don't block time."),
+		Parameters:  paramsOf(freeSlotsArgs{}),
+	}
+}
+
+type freeSlotsArgs struct {
+	DurationMinutes int     `json:"duration_minutes" validate:"required,min=15,max=720" desc:"Length of the free window needed, in minutes, e.g. 180 for 3 hours."`
+	AfterDate       dateArg `json:"after_date,omitempty" desc:"Optional first day to search, as YYYY-MM-DD, RFC3339 or an offset from today like 'next monday'. Defaults to today."`
+	BeforeDate      dateArg `json:"before_date,omitempty" desc:"Optional last day to search, as YYYY-MM-DD, RFC3339 or an offset from today like 'next friday'. Defaults to 6 days after after_date; at most 31 days are searched."`
+	DayStart        string  `json:"day_start,omitempty" desc:"Optional time of day free windows may start at, as HH:MM. Defaults to 09:00."`
+	DayEnd          string  `json:"day_end,omitempty" desc:"Optional time of day free windows must end by, as HH:MM. Defaults to 18:00."`
+	Calendar        string  `json:"calendar,omitempty" desc:"Optional name of one of the user's calendars to check, e.g. 'work'. Defaults to all of them."`
+	Timezone        string  `json:"timezone,omitempty" desc:"Optional IANA time zone of the user, e.g. 'Europe/Madrid', in which days and times are given. Defaults to UTC."`
+}
+
+// interval is a span of time, [start, end).
+type interval struct {
+	start, end time.Time
+}
+
+func (t *freeSlotsTool) Call(ctx context.Context, args string) (string, error) {
+	var payload freeSlotsArgs
+	if err := decodeArgs(args, &payload); err != nil {
+		return "", err
+	}
+	if payload.DurationMinutes < 15 || payload.DurationMinutes > 720 {
+		return "", errors.New(`invalid argument "duration_minutes": must be between 15 and 720`)
+	}
+	length := time.Duration(payload.DurationMinutes) * time.Minute
+
+	loc := time.UTC
+	if payload.Timezone != "" {
+		var err error
+		if loc, err = time.LoadLocation(payload.Timezone); err != nil {
+			return "", fmt.Errorf(`invalid argument "timezone": unknown time zone %q, use an IANA name like "Europe/Madrid"`, payload.Timezone)
+		}
+	}
+
+	dayStart, err := parseClock("day_start", payload.DayStart, 9*time.Hour)
+	if err != nil {
+		return "", err
+	}
+	dayEnd, err := parseClock("day_end", payload.DayEnd, 18*time.Hour)
+	if err != nil {
+		return "", err
+	}
+	if dayEnd-dayStart < length {
+		return "", fmt.Errorf(`invalid arguments: %s is shorter than "duration_minutes"`, clockRange(dayStart, dayEnd))
+	}
+
+	now := time.Now
+	if t.now != nil {
+		now = t.now
+	}
+	at := now().In(loc)
+
+	from := payload.AfterDate.Time
+	if from.IsZero() {
+		from = day(at)
+	}
+	to := payload.BeforeDate.Time
+	if to.IsZero() {
+		to = from.AddDate(0, 0, defaultFreeSlotDays-1)
+	}
+	if to.Before(from) {
+		return "", errors.New(`invalid arguments: "before_date" is earlier than "after_date"`)
+	}
+	if days := int(to.Sub(from).Hours()/24) + 1; days > maxFreeSlotDays {
+		return "", fmt.Errorf(`invalid arguments: the range spans %d days, search at most %d at a time`, days, maxFreeSlotDays)
+	}
+
+	name := model.NormalizeAliasName(payload.Calendar)
+	if name == "" {
+		name = allCalendars
+	}
+	feeds, notes, err := loadUserFeeds(ctx, name)
+	if err != nil {
+		return "", err
+	}
+	if len(feeds) == 0 && len(notes) == 0 {
+		return "", errors.New("The user has no calendars linked, so their free time is unknown. Tell the user they can link one, e.g. their work calendar, as an ICS feed.")
+	}
+
+	first := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, loc)
+	last := time.Date(to.Year(), to.Month(), to.Day()+1, 0, 0, 0, 0, loc)
+	var busy []interval
+	for _, f := range feeds {
+		busy = append(busy, busyIntervals(ctx, f.events, loc, first, last)...)
+	}
+	slices.SortFunc(busy, func(a, b interval) int { return a.start.Compare(b.start) })
+
+	lines := append([]string{}, notes...)
+	found := false
+	for d := from; !d.After(to); d = d.AddDate(0, 0, 1) {
+		// By wall clock, so days when clocks change keep their hours.
+		window := interval{
+			start: time.Date(d.Year(), d.Month(), d.Day(), 0, int(dayStart.Minutes()), 0, 0, loc),
+			end:   time.Date(d.Year(), d.Month(), d.Day(), 0, int(dayEnd.Minutes()), 0, 0, loc),
+		}
+		if window.start.Before(at) {
+			window.start = at.Add(slotStep - time.Nanosecond).Truncate(slotStep)
+		}
+
+		slots := freeWithin(window, busy, length)
+		if len(slots) == 0 {
+			continue
+		}
+		found = true
+		parts := make([]string, len(slots))
+		for i, s := range slots {
+			parts[i] = fmt.Sprintf("%s–%s (%s)", s.start.In(loc).Format("15:04"), s.end.In(loc).Format("15:04"), formatLength(s.end.Sub(s.start)))
+		}
+		lines = append(lines, fmt.Sprintf("%s (%s): %s", d.Format(time.DateOnly), d.Weekday(), strings.Join(parts, ", ")))
+	}
+
+	if !found {
+		lines = append(lines, fmt.Sprintf("No free slot of %s or more within %s (%s) from %s to %s.",
+			formatLength(length), clockRange(dayStart, dayEnd), loc, from.Format(time.DateOnly), to.Format(time.DateOnly)))
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// freeWithin returns the gaps of at least length in window between the busy intervals,
+// which are sorted by start.
+func freeWithin(window interval, busy []interval, length time.Duration) []interval {
+	var out []interval
+	cursor := window.start
+	for _, b := range busy {
+		if !b.end.After(cursor) {
+			continue
+		}
+		if !b.start.Before(window.end) {
+			break
+		}
+		if b.start.Sub(cursor) >= length {
+			out = append(out, interval{cursor, b.start})
+		}
+		cursor = b.end
+	}
+	if window.end.Sub(cursor) >= length {
+		out = append(out, interval{cursor, window.end})
+	}
+	return out
+}
+
+// busyIntervals returns the times blocked by events within [from, to), expanding recurring
+// events. All-day, cancelled and transparent events don't block time, and events with
+// unsupported rules count once. Floating times are read in loc.
+func busyIntervals(ctx context.Context, events []*ics.VEvent, loc *time.Location, from, to time.Time) []interval {
+	var out []interval
+	for _, event := range events {
+		if !blocksTime(event) {
+			continue
+		}
+		start, err := eventTime(event, ics.ComponentPropertyDtStart, loc)
+		if err != nil {
+			continue
+		}
+		length := defaultEventLength
+		if end, err := eventTime(event, ics.ComponentPropertyDtEnd, loc); err == nil && end.After(start) {
+			length = end.Sub(start)
+		}
+
+		starts := []time.Time{start}
+		if p := event.GetProperty(ics.ComponentPropertyRrule); p != nil {
+			rule, err := parseRRule(p.Value)
+			if err != nil {
+				slog.WarnContext(ctx, "Ignoring unsupported recurrence rule", "rule", p.Value, "error", err)
+			} else {
+				dates := rule.occurrences(start, from.AddDate(0, 0, -1), to)
+				starts = make([]time.Time, len(dates))
+				for i, d := range dates {
+					starts[i] = time.Date(d.Year(), d.Month(), d.Day(), start.Hour(), start.Minute(), start.Second(), 0, start.Location())
+				}
+			}
+		}
+
+		for _, s := range starts {
+			if e := s.Add(length); e.After(from) && s.Before(to) {
+				out = append(out, interval{s, e})
+			}
+		}
+	}
+	return out
+}
+
+func blocksTime(event *ics.VEvent) bool {
+	if p := event.GetProperty(ics.ComponentPropertyTransp); p != nil && strings.EqualFold(p.Value, "TRANSPARENT") {
+		return false
+	}
+	if p := event.GetProperty(ics.ComponentPropertyStatus); p != nil && strings.EqualFold(p.Value, "CANCELLED") {
+		return false
+	}
+	p := event.GetProperty(ics.ComponentPropertyDtStart)
+	if p == nil {
+		return false
+	}
+	if v := p.ICalParameters["VALUE"]; len(v) == 1 && strings.EqualFold(v[0], "DATE") {
+		return false
+	}
+	return strings.Contains(p.Value, "T")
+}
+
+// eventTime reads a timed property of an event; times with neither a TZID nor a Z are
+// floating, and read in loc.
+func eventTime(event *ics.VEvent, prop ics.ComponentProperty, loc *time.Location) (time.Time, error) {
+	p := event.GetProperty(prop)
+	if p == nil {
+		return time.Time{}, fmt.Errorf("no %s", prop)
+	}
+	if _, ok := p.ICalParameters["TZID"]; !ok && !strings.HasSuffix(p.Value, "Z") {
+		return time.ParseInLocation("20060102T150405", p.Value, loc)
+	}
+	if prop == ics.ComponentPropertyDtEnd {
+		return event.GetEndAt()
+	}
+	return event.GetStartAt()
+}
+
+// parseClock parses a time of day as HH:MM into its offset from midnight.
+func parseClock(arg, s string, fallback time.Duration) (time.Duration, error) {
+	if s == "" {
+		return fallback, nil
+	}
+	if s == "24:00" {
+		return 24 * time.Hour, nil
+	}
+	c, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, fmt.Errorf(`invalid argument %q: must be a time of day as HH:MM, e.g. "09:30" (got %q)`, arg, s)
+	}
+	return time.Duration(c.Hour())*time.Hour + time.Duration(c.Minute())*time.Minute, nil
+}
+
+func clockRange(start, end time.Duration) string {
+	return fmt.Sprintf("%02d:%02d–%02d:%02d", int(start.Hours()), int(start.Minutes())%60, int(end.Hours()), int(end.Minutes())%60)
+}
+
+// formatLength formats a duration in hours and minutes, e.g. "3h 30m".
+func formatLength(d time.Duration) string {
+	h, m := int(d.Hours()), int(d.Minutes())%60
+	switch {
+	case h == 0:
+		return fmt.Sprintf("%dm", m)
+	case m == 0:
+		return fmt.Sprintf("%dh", h)
+	default:
+		return fmt.Sprintf("%dh %dm", h, m)
+	}
+}
@@ -0,0 +1,139 @@
+package assistant
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+const testWorkCalendar = `BEGIN:VCALENDAR
+VERSION:2.0
+PRODID:test
+BEGIN:VEVENT
+UID:standup
+DTSTART;TZID=Europe/Madrid:20251013T090000
+DTEND;TZID=Europe/Madrid:20251013T093000
+RRULE:FREQ=DAILY;COUNT=10
+SUMMARY:Standup
+END:VEVENT
+BEGIN:VEVENT
+UID:review
+DTSTART:20251014T110000Z
+DTEND:20251014T120000Z
+SUMMARY:Review
+END:VEVENT
+BEGIN:VEVENT
+UID:offsite
+DTSTART;VALUE=DATE:20251015
+SUMMARY:Offsite
+END:VEVENT
+BEGIN:VEVENT
+UID:focus
+DTSTART:20251015T080000Z
+DTEND:20251015T160000Z
+TRANSP:TRANSPARENT
+SUMMARY:Focus time
+END:VEVENT
+BEGIN:VEVENT
+UID:cancelled
+DTSTART:20251016T070000Z
+DTEND:20251016T090000Z
+STATUS:CANCELLED
+SUMMARY:Cancelled
+END:VEVENT
+BEGIN:VEVENT
+UID:workshop
+DTSTART:20251016T073000Z
+DTEND:20251016T150000Z
+SUMMARY:Workshop
+END:VEVENT
+END:VCALENDAR
+`
+
+func TestFreeSlotsTool(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/work" {
+			http.Error(w, "gone", http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(testWorkCalendar))
+	}))
+	defer srv.Close()
+	allowLocalUserCalendars(t)
+
+	// 10:05 on Tuesday 2025-10-14 in Madrid.
+	tool := &freeSlotsTool{now: func() time.Time { return time.Date(2025, 10, 14, 8, 5, 0, 0, time.UTC) }}
+	ctx := WithUserCalendars(context.Background(), fakeCalendars{
+		{Name: "work", URL: srv.URL + "/work"},
+		{Name: "school", URL: srv.URL + "/school"},
+	})
+
+	for _, tt := range []struct {
+		name, args string
+		want       string
+	}{
+		{
+			name: "this week",
+			args: `{"duration_minutes":180,"before_date":"2025-10-16","timezone":"Europe/Madrid"}`,
+			want: `The user's calendar "school" could not be loaded and is not included.` + "\n" +
+				"2025-10-14 (Tuesday): 14:00–18:00 (4h)\n" +
+				"2025-10-15 (Wednesday): 09:30–18:00 (8h 30m)",
+		},
+		{
+			name: "later in the day",
+			args: `{"duration_minutes":60,"after_date":"2025-10-16","before_date":"2025-10-16","day_end":"19:30","calendar":"work","timezone":"Europe/Madrid"}`,
+			want: "2025-10-16 (Thursday): 17:00–19:30 (2h 30m)",
+		},
+		{
+			name: "nothing free",
+			args: `{"duration_minutes":120,"after_date":"2025-10-16","before_date":"2025-10-16","calendar":"work","timezone":"Europe/Madrid"}`,
+			want: "No free slot of 2h or more within 09:00–18:00 (Europe/Madrid) from 2025-10-16 to 2025-10-16.",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tool.Call(ctx, tt.args)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("find_free_slots mismatch (-got +want):\n%s", cmp.Diff(got, tt.want))
+			}
+		})
+	}
+
+	for _, tt := range []struct{ args, want string }{
+		{`{"duration_minutes":600}`, "is shorter than"},
+		{`{"duration_minutes":60,"day_start":"9am"}`, "HH:MM"},
+		{`{"duration_minutes":60,"before_date":"2025-12-31"}`, "at most 31"},
+		{`{"duration_minutes":60,"calendar":"gym"}`, `Their calendars are "work", "school"`},
+	} {
+		if _, err := tool.Call(ctx, tt.args); err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("Call(%s) = %v, want an error containing %q", tt.args, err, tt.want)
+		}
+	}
+
+	if _, err := tool.Call(WithUserCalendars(context.Background(), fakeCalendars{}), `{"duration_minutes":60}`); err == nil || !strings.Contains(err.Error(), "they can link one") {
+		t.Errorf("no calendars = %v, want how to link one", err)
+	}
+	if tool.Available(context.Background()) {
+		t.Error("find_free_slots must not be offered without the user's calendars")
+	}
+}
+
+func TestFormatLength(t *testing.T) {
+	for d, want := range map[time.Duration]string{
+		45 * time.Minute:              "45m",
+		3 * time.Hour:                 "3h",
+		3*time.Hour + 30*time.Minute:  "3h 30m",
+		12*time.Hour + 15*time.Minute: "12h 15m",
+	} {
+		if got := formatLength(d); got != want {
+			t.Errorf("formatLength(%v) = %q, want %q", d, got, want)
+		}
+	}
+}
@@ -0,0 +1,225 @@
+package assistant
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/acai-travel/tech-challenge/internal/chat/model"
+	ics "github.com/arran4/golang-ical"
+	"github.com/openai/openai-go/v2"
+)
+
+// defaultHolidayWindow bounds recurring event expansion when no before_date is given.
+const defaultHolidayWindow = 1 // years
+
+type holidaysTool struct {
+	// now is the clock, replaceable in tests.
+	now func() time.Time
+}
+
+func (t *holidaysTool) Name() string { return "get_holidays" }
+
+func (t *holidaysTool) Guidance() string {
+	return "Use **get_holidays** for holiday/calendar questions. Pass **country** (and **region** if relevant) when the user names a place; to check a specific day, set after_date and before_date to that day. Holidays come with how many days away they are: tell which one is next from them, never by counting yourself."
+}
+
+func (t *holidaysTool) Definition() openai.FunctionDefinitionParam {
+	return openai.FunctionDefinitionParam{
+		Name:        t.Name(),
+		Description: openai.String("Gets bank and public holidays, by default for Catalonia, Spain, or the events of the user's own calendars, e.g. their work calendar. Each line is a single holiday in the format 'YYYY-MM-DD (Weekday, in N days): Holiday Name', sorted by date from today unless after_date is given; the distance is 'today', 'tomorrow', 'in N days' or 'N days ago' for past holidays."),
+		Parameters:  paramsOf(holidaysArgs{}),
+	}
+}
+
+type holidaysArgs struct {
+	BeforeDate dateArg `json:"before_date,omitempty" desc:"Optional date as YYYY-MM-DD, RFC3339 or an offset from today like 'next month'; only holidays on or before this day are returned. If not provided, holidays up to one year after after_date (or today) are returned."`
+	AfterDate  dateArg `json:"after_date,omitempty" desc:"Optional date as YYYY-MM-DD, RFC3339 or an offset from today like 'next month'; only holidays on or after this day are returned. If not provided, holidays from today on are returned; pass a past date to include past holidays."`
+	MaxCount   int     `json:"max_count,omitempty" validate:"min=0" desc:"Optional maximum number of holidays to return. If not provided, all holidays will be returned."`
+	Country    string  `json:"country,omitempty" desc:"Optional country name in English, e.g. 'Spain', 'France', 'United Kingdom'. Defaults to the configured local calendar."`
+	Region     string  `json:"region,omitempty" desc:"Optional region within the country for regional holidays, e.g. 'Catalonia'. Only used together with country."`
+	Calendar   string  `json:"calendar,omitempty" desc:"Optional name of one of the user's own calendars, e.g. 'work' or 'school', to list its events instead of public holidays, or 'all' for public holidays and all of the user's calendars. Each event then ends with its calendar in brackets, e.g. 'Offsite [work]'."`
+}
+
+func parseHolidaysArgs(args string) (holidaysArgs, error) {
+	var payload holidaysArgs
+	if err := decodeArgs(args, &payload); err != nil {
+		return payload, err
+	}
+	if payload.MaxCount < 0 {
+		return payload, errors.New(`invalid argument "max_count": must not be negative`)
+	}
+	if !payload.BeforeDate.Time.IsZero() && payload.BeforeDate.Time.Before(payload.AfterDate.Time) {
+		return payload, errors.New(`invalid arguments: "before_date" is earlier than "after_date"`)
+	}
+	return payload, nil
+}
+
+// CacheKey shares holidays for 6 hours. Results run from, and count days from, today, so
+// the key includes it. The events of users' calendars are not shared.
+func (t *holidaysTool) CacheKey(ctx context.Context, args string) (string, time.Duration, bool) {
+	payload, err := parseHolidaysArgs(args)
+	if err != nil || payload.Calendar != "" {
+		return "", 0, false
+	}
+	key := strings.Join([]string{
+		t.today(ctx).Format(time.DateOnly),
+		strings.ToLower(strings.TrimSpace(payload.Country)),
+		strings.ToLower(strings.TrimSpace(payload.Region)),
+		payload.AfterDate.Time.Format(time.DateOnly),
+		payload.BeforeDate.Time.Format(time.DateOnly),
+		strconv.Itoa(payload.MaxCount),
+	}, "|")
+	return key, 6 * time.Hour, true
+}
+
+func (t *holidaysTool) Call(ctx context.Context, args string) (string, error) {
+	payload, err := parseHolidaysArgs(args)
+	if err != nil {
+		return "", err
+	}
+
+	today := t.today(ctx)
+	from := payload.AfterDate.Time
+	if from.IsZero() {
+		from = today
+	}
+	to := payload.BeforeDate.Time
+	if to.IsZero() {
+		base := from
+		if base.Before(today) {
+			base = today
+		}
+		to = base.AddDate(defaultHolidayWindow, 0, 0)
+	}
+
+	var (
+		holidays []holiday
+		notes    []string
+	)
+	if name := model.NormalizeAliasName(payload.Calendar); name != "" {
+		holidays, notes, err = loadUserCalendarEvents(ctx, name, payload.Country, payload.Region, from, to)
+	} else {
+		holidays, err = loadHolidays(ctx, payload.Country, payload.Region, from, to)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	if payload.MaxCount > 0 && len(holidays) > payload.MaxCount {
+		holidays = holidays[:payload.MaxCount]
+	}
+
+	lines := make([]string, 0, len(holidays)+len(notes))
+	lines = append(lines, notes...)
+	for _, h := range holidays {
+		days := int(h.Date.Sub(today).Hours() / 24)
+		lines = append(lines, fmt.Sprintf("%s (%s, %s): %s", h.Date.Format(time.DateOnly), h.Date.Weekday(), daysAway(days), h.Name))
+	}
+
+	return strings.Join(lines, "\n"), nil
+}
+
+// today is the current day in the user's time zone, or UTC, the default start of the
+// holidays returned.
+func (t *holidaysTool) today(ctx context.Context) time.Time {
+	now := time.Now
+	if t.now != nil {
+		now = t.now
+	}
+	return day(now().In(userLocation(ctx)))
+}
+
+// daysAway describes a distance of n days from today.
+func daysAway(n int) string {
+	switch {
+	case n == 0:
+		return "today"
+	case n == 1:
+		return "tomorrow"
+	case n == -1:
+		return "yesterday"
+	case n > 0:
+		return fmt.Sprintf("in %d days", n)
+	default:
+		return fmt.Sprintf("%d days ago", -n)
+	}
+}
+
+type holiday struct {
+	Date time.Time
+	Name string
+}
+
+// Holiday is a bank or public holiday.
+type Holiday struct {
+	Date time.Time `json:"date"`
+	Name string    `json:"name"`
+}
+
+// Holidays returns the holidays of a country, or region of it, within [from, to], sorted by
+// date. An empty country uses the configured local calendar, as in the get_holidays tool.
+func (a *Assistant) Holidays(ctx context.Context, country, region string, from, to time.Time) ([]Holiday, error) {
+	holidays, err := loadHolidays(ctx, country, region, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]Holiday, len(holidays))
+	for i, h := range holidays {
+		out[i] = Holiday(h)
+	}
+	return out, nil
+}
+
+// loadHolidays fetches the holiday calendar for a country and returns its holidays within [from, to].
+func loadHolidays(ctx context.Context, country, region string, from, to time.Time) ([]holiday, error) {
+	events, err := loadCalendarCached(ctx, holidayCalendarLink(country, region))
+	if err != nil {
+		return nil, errors.New("failed to load holiday events")
+	}
+
+	return expandHolidays(ctx, events, from, to), nil
+}
+
+// expandHolidays returns the holidays within [from, to] by calendar day, expanding
+// recurring events, sorted by date. Events with unsupported rules count once, on their start date.
+func expandHolidays(ctx context.Context, events []*ics.VEvent, from, to time.Time) []holiday {
+	var out []holiday
+	for _, event := range events {
+		start, err := event.GetAllDayStartAt()
+		if err != nil {
+			continue
+		}
+
+		var name string
+		if p := event.GetProperty(ics.ComponentPropertySummary); p != nil {
+			name = p.Value
+		}
+
+		dates := []time.Time{day(start)}
+		if p := event.GetProperty(ics.ComponentPropertyRrule); p != nil {
+			rule, err := parseRRule(p.Value)
+			if err != nil {
+				slog.WarnContext(ctx, "Ignoring unsupported recurrence rule", "rule", p.Value, "error", err)
+			} else {
+				dates = rule.occurrences(start, from, to)
+			}
+		}
+
+		for _, d := range dates {
+			if d.After(day(to)) || (!from.IsZero() && d.Before(day(from))) {
+				continue
+			}
+			out = append(out, holiday{Date: d, Name: name})
+		}
+	}
+
+	slices.SortStableFunc(out, func(a, b holiday) int { return a.Date.Compare(b.Date) })
+	return out
+}
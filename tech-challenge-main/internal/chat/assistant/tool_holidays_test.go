@@ -0,0 +1,160 @@
+package assistant
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/acai-travel/tech-challenge/internal/chat/model"
+	"github.com/google/go-cmp/cmp"
+)
+
+const testHolidayCalendar = `BEGIN:VCALENDAR
+VERSION:2.0
+PRODID:test
+BEGIN:VEVENT
+UID:1
+DTSTART;VALUE=DATE:20251012
+SUMMARY:Hispanic Day
+END:VEVENT
+BEGIN:VEVENT
+UID:2
+DTSTART;VALUE=DATE:20251101
+SUMMARY:All Saints' Day
+END:VEVENT
+BEGIN:VEVENT
+UID:3
+DTSTART;VALUE=DATE:20251015
+SUMMARY:Test Day
+END:VEVENT
+BEGIN:VEVENT
+UID:4
+DTSTART;VALUE=DATE:20251014
+SUMMARY:Other Test Day
+END:VEVENT
+END:VCALENDAR
+`
+
+func TestHolidaysTool(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(testHolidayCalendar))
+	}))
+	defer srv.Close()
+	t.Setenv("HOLIDAY_CALENDAR_LINK", srv.URL)
+
+	// 23:00 on 2025-10-14, UTC.
+	tool := &holidaysTool{now: func() time.Time { return time.Date(2025, 10, 14, 23, 0, 0, 0, time.UTC) }}
+
+	for _, tt := range []struct {
+		name, args string
+		want       string
+	}{
+		{
+			name: "from today by default",
+			args: `{}`,
+			want: "2025-10-14 (Tuesday, today): Other Test Day\n" +
+				"2025-10-15 (Wednesday, tomorrow): Test Day\n" +
+				"2025-11-01 (Saturday, in 18 days): All Saints' Day",
+		},
+		{
+			name: "past holidays when asked",
+			args: `{"after_date":"2025-10-01","max_count":2}`,
+			want: "2025-10-12 (Sunday, 2 days ago): Hispanic Day\n" +
+				"2025-10-14 (Tuesday, today): Other Test Day",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tool.Call(context.Background(), tt.args)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("get_holidays mismatch (-got +want):\n%s", cmp.Diff(got, tt.want))
+			}
+		})
+	}
+
+	// Distances change daily, so the cache mustn't keep them past midnight.
+	k1, _, _ := tool.CacheKey(context.Background(), `{}`)
+	tomorrow := &holidaysTool{now: func() time.Time { return time.Date(2025, 10, 15, 1, 0, 0, 0, time.UTC) }}
+	if k2, _, _ := tomorrow.CacheKey(context.Background(), `{}`); k1 == k2 {
+		t.Errorf("cache key %q is the same on different days", k1)
+	}
+
+	// It's already past midnight in Madrid.
+	madrid := WithPreferences(context.Background(), timezonePreferences("Europe/Madrid"))
+	got, err := tool.Call(madrid, `{"max_count":2}`)
+	if want := "2025-10-15 (Wednesday, today): Test Day\n2025-11-01 (Saturday, in 17 days): All Saints' Day"; err != nil || got != want {
+		t.Errorf("get_holidays in Madrid = %q, %v; want %q", got, err, want)
+	}
+	if k2, _, _ := tool.CacheKey(madrid, `{}`); k1 == k2 {
+		t.Errorf("cache key %q is the same on different days in different time zones", k1)
+	}
+}
+
+// timezonePreferences are the preferences of a user in a time zone.
+type timezonePreferences string
+
+func (p timezonePreferences) HealthAdvisories(context.Context) (bool, error) { return true, nil }
+
+func (p timezonePreferences) Timezone(context.Context) (string, error) { return string(p), nil }
+
+// fakeCalendars are the linked calendars of a user.
+type fakeCalendars []model.UserCalendar
+
+func (f fakeCalendars) UserCalendars(context.Context) ([]model.UserCalendar, error) {
+	return f, nil
+}
+
+func TestHolidaysTool_UserCalendars(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/public":
+			_, _ = w.Write([]byte(testHolidayCalendar))
+		case "/work":
+			_, _ = w.Write([]byte(strings.ReplaceAll(testCalendar, "SUMMARY:Christmas Day", "SUMMARY:Offsite")))
+		default:
+			http.Error(w, "gone", http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+	t.Setenv("HOLIDAY_CALENDAR_LINK", srv.URL+"/public")
+	allowLocalUserCalendars(t)
+
+	tool := &holidaysTool{now: func() time.Time { return time.Date(2025, 10, 14, 12, 0, 0, 0, time.UTC) }}
+	ctx := WithUserCalendars(context.Background(), fakeCalendars{
+		{Name: "work", URL: srv.URL + "/work"},
+		{Name: "school", URL: srv.URL + "/school"},
+	})
+
+	got, err := tool.Call(ctx, `{"calendar":"My Work"}`)
+	if want := "2025-12-25 (Thursday, in 72 days): Offsite [work]"; err != nil || got != want {
+		t.Errorf("work calendar = %q, %v, want %q", got, err, want)
+	}
+
+	got, err = tool.Call(ctx, `{"calendar":"all","after_date":"2025-10-15"}`)
+	want := `The user's calendar "school" could not be loaded and is not included.` + "\n" +
+		"2025-10-15 (Wednesday, tomorrow): Test Day [public holiday]\n" +
+		"2025-11-01 (Saturday, in 18 days): All Saints' Day [public holiday]\n" +
+		"2025-12-25 (Thursday, in 72 days): Offsite [work]"
+	if err != nil || got != want {
+		t.Errorf("all calendars mismatch (-got +want):\n%s", cmp.Diff(got, want))
+	}
+
+	if _, err := tool.Call(ctx, `{"calendar":"school"}`); err == nil || !strings.Contains(err.Error(), "could not be loaded") {
+		t.Errorf("failing calendar = %v, want an error", err)
+	}
+	if _, err := tool.Call(ctx, `{"calendar":"gym"}`); err == nil || !strings.Contains(err.Error(), `Their calendars are "work", "school"`) {
+		t.Errorf("unknown calendar = %v, want the user's calendars listed", err)
+	}
+	if _, err := tool.Call(context.Background(), `{"calendar":"work"}`); err == nil || !strings.Contains(err.Error(), "they can link one") {
+		t.Errorf("no calendars = %v, want how to link one", err)
+	}
+
+	if _, _, ok := tool.CacheKey(ctx, `{"calendar":"work"}`); ok {
+		t.Error("the events of a user's calendar must not be shared between users")
+	}
+}
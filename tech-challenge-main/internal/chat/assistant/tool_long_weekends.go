@@ -0,0 +1,213 @@
+package assistant
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/acai-travel/tech-challenge/internal/features"
+	"github.com/openai/openai-go/v2"
+)
+
+// maxForecastDays is the longest forecast WeatherAPI provides.
+const maxForecastDays = 14
+
+type longWeekendsTool struct {
+	weather *WeatherService
+}
+
+func (t *longWeekendsTool) Name() string { return "find_long_weekends" }
+
+func (t *longWeekendsTool) Guidance() string {
+	return `Use **find_long_weekends** for long weekend / bridge day / "puente" planning; pass **city** when the user asks whether the weather will be nice.`
+}
+
+func (t *longWeekendsTool) Feature() features.Flag { return features.LongWeekends }
+
+func (t *longWeekendsTool) Definition() openai.FunctionDefinitionParam {
+	return openai.FunctionDefinitionParam{
+		Name:        t.Name(),
+		Description: openai.String("Finds upcoming long weekends: runs of 3+ days off where public holidays join a weekend, plus 'bridge' opportunities where taking a single working day off gives 4+ days. Optionally attaches the weather forecast for a city to long weekends within the next 14 days."),
+		Parameters:  paramsOf(longWeekendsArgs{}),
+	}
+}
+
+type longWeekendsArgs struct {
+	AfterDate dateArg `json:"after_date,omitempty" desc:"Optional date as YYYY-MM-DD, RFC3339 or an offset from today like 'next month' to start searching from. Defaults to today."`
+	Months    int     `json:"months,omitempty" validate:"min=1,max=12" desc:"How many months ahead to search (1-12). Defaults to 3."`
+	Country   string  `json:"country,omitempty" desc:"Optional country name in English, e.g. 'Spain'. Defaults to the configured local calendar."`
+	Region    string  `json:"region,omitempty" desc:"Optional region within the country, e.g. 'Catalonia'."`
+	City      string  `json:"city,omitempty" desc:"Optional city to attach the weather forecast for, e.g. 'Valencia'."`
+}
+
+func (t *longWeekendsTool) Call(ctx context.Context, args string) (string, error) {
+	var payload longWeekendsArgs
+	if err := decodeArgs(args, &payload); err != nil {
+		return "", err
+	}
+
+	from := payload.AfterDate.Time
+	if from.IsZero() {
+		from = time.Now()
+	}
+	if payload.Months < 1 || payload.Months > 12 {
+		payload.Months = 3
+	}
+	from = day(from)
+	to := from.AddDate(0, payload.Months, 0)
+
+	// Load a few extra days on each side so runs crossing the window edges are complete.
+	holidays, err := loadHolidays(ctx, payload.Country, payload.Region, from.AddDate(0, 0, -4), to.AddDate(0, 0, 4))
+	if err != nil {
+		return "", err
+	}
+
+	weekends := findLongWeekends(holidays, from, to)
+	if len(weekends) == 0 {
+		return fmt.Sprintf("No long weekends found between %s and %s.", from.Format(time.DateOnly), to.Format(time.DateOnly)), nil
+	}
+
+	forecast := forecastByDate(ctx, t.weather, t.Name(), payload.City)
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Long weekends between %s and %s:\n", from.Format(time.DateOnly), to.Format(time.DateOnly)))
+	for _, lw := range weekends {
+		sb.WriteString("- " + lw.String() + "\n")
+
+		for d := lw.Start; !d.After(lw.End); d = d.AddDate(0, 0, 1) {
+			if f, ok := forecast[d.Format(time.DateOnly)]; ok {
+				sb.WriteString(fmt.Sprintf("    %s in %s: %s\n", formatDay(d), payload.City, f))
+			}
+		}
+	}
+
+	return sb.String(), nil
+}
+
+// forecastByDate returns a one-line forecast per date for the city, or nil when no city
+// was requested or the forecast is unavailable; weather is an optional enrichment to the
+// date planning tools, named by tool in logs.
+func forecastByDate(ctx context.Context, service *WeatherService, tool, city string) map[string]string {
+	if city == "" || service == nil {
+		return nil
+	}
+
+	days := min(maxForecastDays, features.FromContext(ctx).Int(features.MaxForecastDays))
+	weather, err := service.Forecast(ctx, city, days)
+	if err != nil {
+		weatherErrors.Add(weatherErrorClass(err), 1)
+		slog.WarnContext(ctx, "Failed to fetch forecast for date planning", "tool", tool, "city", city, "error", err)
+		return nil
+	}
+	return dailyForecast(weather)
+}
+
+// dailyForecast summarizes each day of a forecast on one line, keyed by its local date.
+func dailyForecast(weather *WeatherResponse) map[string]string {
+	out := make(map[string]string, len(weather.Forecast.Forecastday))
+	for _, fd := range weather.Forecast.Forecastday {
+		out[fd.Date] = fmt.Sprintf("%s, %.0f–%.0f°C, %.1f mm precipitation",
+			fd.Day.Condition.Text, fd.Day.MintempC, fd.Day.MaxtempC, fd.Day.TotalprecipMm)
+	}
+	return out
+}
+
+type longWeekend struct {
+	Start, End time.Time
+	Holidays   []holiday
+	// Bridge is the working day to take off to get this long weekend, if any.
+	Bridge time.Time
+}
+
+func (lw longWeekend) Days() int {
+	return int(lw.End.Sub(lw.Start).Hours()/24) + 1
+}
+
+func (lw longWeekend) String() string {
+	names := make([]string, 0, len(lw.Holidays))
+	for _, h := range lw.Holidays {
+		names = append(names, h.Name)
+	}
+
+	s := fmt.Sprintf("%s to %s, %d days", formatDay(lw.Start), formatDay(lw.End), lw.Days())
+	if !lw.Bridge.IsZero() {
+		s += " if you take " + formatDay(lw.Bridge) + " off"
+	}
+	return s + ": " + strings.Join(names, ", ")
+}
+
+// findLongWeekends returns, in date order, the runs of 3+ consecutive days off (weekends
+// and holidays) overlapping [from, to], and bridge opportunities: a single working day
+// between two runs of days off which, taken off, gives 4+ consecutive days.
+func findLongWeekends(holidays []holiday, from, to time.Time) []longWeekend {
+	from, to = day(from), day(to)
+
+	byDate := make(map[time.Time][]holiday)
+	for _, h := range holidays {
+		byDate[h.Date] = append(byDate[h.Date], h)
+	}
+
+	free := func(d time.Time) bool {
+		return d.Weekday() == time.Saturday || d.Weekday() == time.Sunday || len(byDate[d]) > 0
+	}
+
+	// runAround returns the maximal run of free days containing d.
+	runAround := func(d time.Time) (time.Time, time.Time) {
+		start, end := d, d
+		for free(start.AddDate(0, 0, -1)) {
+			start = start.AddDate(0, 0, -1)
+		}
+		for free(end.AddDate(0, 0, 1)) {
+			end = end.AddDate(0, 0, 1)
+		}
+		return start, end
+	}
+
+	collect := func(start, end time.Time) []holiday {
+		var out []holiday
+		for d := start; !d.After(end); d = d.AddDate(0, 0, 1) {
+			out = append(out, byDate[d]...)
+		}
+		return out
+	}
+
+	var out []longWeekend
+	seen := make(map[time.Time]bool)
+
+	for d := from; !d.After(to); d = d.AddDate(0, 0, 1) {
+		if !free(d) {
+			// Bridge: a working day with days off on both sides, at least one being a holiday.
+			prev, next := d.AddDate(0, 0, -1), d.AddDate(0, 0, 1)
+			if !free(prev) || !free(next) {
+				continue
+			}
+
+			start, _ := runAround(prev)
+			_, end := runAround(next)
+			lw := longWeekend{Start: start, End: end, Holidays: collect(start, end), Bridge: d}
+			if lw.Days() >= 4 && len(lw.Holidays) > 0 {
+				out = append(out, lw)
+			}
+			continue
+		}
+
+		start, end := runAround(d)
+		if seen[start] {
+			continue
+		}
+		seen[start] = true
+
+		lw := longWeekend{Start: start, End: end, Holidays: collect(start, end)}
+		if lw.Days() >= 3 {
+			out = append(out, lw)
+		}
+	}
+
+	return out
+}
+
+func formatDay(d time.Time) string {
+	return d.Format(time.DateOnly) + " (" + d.Weekday().String() + ")"
+}
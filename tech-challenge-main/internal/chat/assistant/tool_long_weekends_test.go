@@ -0,0 +1,40 @@
+package assistant
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestFindLongWeekends(t *testing.T) {
+	date := func(s string) time.Time {
+		d, err := time.Parse(time.DateOnly, s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return d
+	}
+
+	holidays := []holiday{
+		{Date: date("2025-11-01"), Name: "All Saints' Day"},       // Saturday, no long weekend
+		{Date: date("2025-12-08"), Name: "Immaculate Conception"}, // Monday
+		{Date: date("2025-12-25"), Name: "Christmas Day"},         // Thursday, bridge on Friday
+		{Date: date("2025-12-26"), Name: "St. Stephen's Day"},     // Friday
+		{Date: date("2026-01-06"), Name: "Epiphany"},              // Tuesday, bridge on Monday
+	}
+
+	var got []string
+	for _, lw := range findLongWeekends(holidays, date("2025-10-14"), date("2026-01-14")) {
+		got = append(got, lw.String())
+	}
+
+	want := []string{
+		"2025-12-06 (Saturday) to 2025-12-08 (Monday), 3 days: Immaculate Conception",
+		"2025-12-25 (Thursday) to 2025-12-28 (Sunday), 4 days: Christmas Day, St. Stephen's Day",
+		"2026-01-03 (Saturday) to 2026-01-06 (Tuesday), 4 days if you take 2026-01-05 (Monday) off: Epiphany",
+	}
+	if !cmp.Equal(got, want) {
+		t.Errorf("findLongWeekends mismatch (-got +want):\n%s", cmp.Diff(got, want))
+	}
+}
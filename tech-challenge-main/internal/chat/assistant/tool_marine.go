@@ -0,0 +1,129 @@
+package assistant
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/acai-travel/tech-challenge/internal/features"
+	"github.com/openai/openai-go/v2"
+)
+
+// maxMarineDays is the longest marine forecast WeatherAPI provides.
+const maxMarineDays = 7
+
+type marineConditionsTool struct {
+	service *WeatherService
+}
+
+func (t *marineConditionsTool) Name() string { return "get_marine_conditions" }
+
+func (t *marineConditionsTool) Guidance() string {
+	return "Use **get_marine_conditions** for sea temperature, waves, swell or tides at a coastal place."
+}
+
+func (t *marineConditionsTool) Feature() features.Flag { return features.MarineConditions }
+
+func (t *marineConditionsTool) Definition() openai.FunctionDefinitionParam {
+	return openai.FunctionDefinitionParam{
+		Name:        t.Name(),
+		Description: openai.String("Use this function for sea and beach questions at a coastal place: sea temperature, wave height, swell and tide times for the coming days. Do NOT guess sea conditions or tides from training data."),
+		Parameters: openai.FunctionParameters{
+			"type": "object",
+			"properties": map[string]any{
+				"location": map[string]string{
+					"type":        "string",
+					"description": "Coastal city, beach or coordinates, e.g. 'Nazaré', 'Biarritz,France' or '36.72,-4.42'.",
+				},
+				"days": map[string]any{
+					"type":        "integer",
+					"description": "Number of forecast days (1-7). Defaults to 3.",
+					"minimum":     1,
+					"maximum":     maxMarineDays,
+				},
+			},
+			"required": []string{"location"},
+		},
+	}
+}
+
+type marineArgs struct {
+	Location string `json:"location"`
+	Days     int    `json:"days,omitempty"`
+}
+
+func (t *marineConditionsTool) Call(ctx context.Context, args string) (string, error) {
+	var payload marineArgs
+	if err := decodeArgs(args, &payload); err != nil {
+		return "", err
+	}
+	if strings.TrimSpace(payload.Location) == "" {
+		return "", errors.New(`missing argument "location": a coastal place or coordinates is required`)
+	}
+	if t.service == nil {
+		return "", errors.New("Weather service is not configured. Please set WEATHER_API_KEY environment variable.")
+	}
+
+	query, note, err := resolveLocation(ctx, payload.Location)
+	if err != nil {
+		return "", err
+	}
+	days := payload.Days
+	if days < 1 {
+		days = 3
+	}
+	days = min(days, maxMarineDays, features.FromContext(ctx).Int(features.MaxForecastDays))
+
+	weather, err := t.service.Marine(ctx, query, days)
+	if err != nil {
+		return "", weatherToolError(payload.Location, err)
+	}
+	return note + formatMarine(*weather), nil
+}
+
+// formatMarine summarizes each day of a marine forecast: the sea temperature range, the
+// highest waves, the swell at midday and the tides.
+func formatMarine(weather WeatherResponse) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("**%s, %s** sea conditions\n", weather.Location.Name, weather.Location.Country))
+
+	for _, fd := range weather.Forecast.Forecastday {
+		date, err := time.Parse(time.DateOnly, fd.Date)
+		if err != nil {
+			continue
+		}
+		sb.WriteString(fmt.Sprintf("- %s:", formatDay(date)))
+
+		if hours := fd.Hour; len(hours) > 0 {
+			minTemp, maxTemp, waves := hours[0].WaterTempC, hours[0].WaterTempC, 0.0
+			for _, h := range hours {
+				minTemp, maxTemp, waves = min(minTemp, h.WaterTempC), max(maxTemp, h.WaterTempC), max(waves, h.SigHtMt)
+			}
+			noon := hours[len(hours)/2]
+			sb.WriteString(fmt.Sprintf(" sea %.0f–%.0f°C, waves up to %.1f m, swell %.1f m from %s every %.0f s",
+				minTemp, maxTemp, waves, noon.SwellHtMt, noon.SwellDir, noon.SwellPeriodSecs))
+		} else {
+			sb.WriteString(" no sea forecast")
+		}
+
+		var tides []string
+		for _, group := range fd.Day.Tides {
+			for _, tide := range group.Tide {
+				at := tide.Time
+				if t, err := time.Parse("2006-01-02 15:04", tide.Time); err == nil {
+					at = t.Format("15:04")
+				}
+				tides = append(tides, fmt.Sprintf("%s %s (%.1f m)", strings.ToLower(tide.Type), at, float64(tide.HeightMt)))
+			}
+		}
+		if len(tides) > 0 {
+			sb.WriteString("; tides: " + strings.Join(tides, ", "))
+		}
+		sb.WriteString("\n")
+	}
+	writeStaleNote(&sb, weather)
+
+	return sb.String()
+}
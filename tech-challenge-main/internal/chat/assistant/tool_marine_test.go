@@ -0,0 +1,39 @@
+package assistant
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestMarineConditionsTool(t *testing.T) {
+	var gotQuery string
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotQuery = r.URL.Path + "?" + r.URL.RawQuery
+		// Tide heights come as strings.
+		fmt.Fprint(w, `{"location":{"name":"Nazaré","country":"Portugal"},"forecast":{"forecastday":[{"date":"2026-10-14",
+			"day":{"tides":[{"tide":[{"tide_time":"2026-10-14 05:12","tide_height_mt":"3.10","tide_type":"HIGH"},{"tide_time":"2026-10-14 11:30","tide_height_mt":"0.80","tide_type":"LOW"}]}]},
+			"hour":[{"water_temp_c":17.2,"sig_ht_mt":2.1,"swell_ht_mt":1.9,"swell_dir_16_point":"WNW","swell_period_secs":12.4},
+			        {"water_temp_c":18.4,"sig_ht_mt":3.4,"swell_ht_mt":2.6,"swell_dir_16_point":"NW","swell_period_secs":13.1}]}]}}`)
+	}))
+	defer api.Close()
+
+	service := NewWeatherService(t.Name())
+	service.baseURL = api.URL
+	tool := &marineConditionsTool{service: service}
+
+	got, err := tool.Call(context.Background(), `{"location":"Nazaré","days":10}`)
+	if err != nil {
+		t.Fatalf("Call error: %v", err)
+	}
+	if !strings.HasPrefix(gotQuery, "/marine.json?") || !strings.Contains(gotQuery, "days=7") || !strings.Contains(gotQuery, "tides=yes") {
+		t.Errorf("unexpected request %s", gotQuery)
+	}
+	want := "- 2026-10-14 (Wednesday): sea 17–18°C, waves up to 3.4 m, swell 2.6 m from NW every 13 s; tides: high 05:12 (3.1 m), low 11:30 (0.8 m)\n"
+	if !strings.Contains(got, want) {
+		t.Errorf("expected %q in:\n%s", want, got)
+	}
+}
@@ -0,0 +1,218 @@
+package assistant
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/acai-travel/tech-challenge/internal/features"
+	"github.com/openai/openai-go/v2"
+)
+
+const (
+	defaultPlacesRadiusM = 1500
+	maxPlacesRadiusM     = 10000
+)
+
+type placesTool struct {
+	provider PlacesProvider
+	// weather resolves the time zone of the location for open_now.
+	weather *WeatherService
+}
+
+func (t *placesTool) Name() string { return "find_places" }
+
+func (t *placesTool) Guidance() string {
+	return "Use **find_places** to recommend restaurants, cafes, bars, museums, attractions or parks near a place; pass **open_at** or **open_now** when the user asks what is open, and recommend only places it returns."
+}
+
+func (t *placesTool) Feature() features.Flag { return features.Places }
+
+func (t *placesTool) Definition() openai.FunctionDefinitionParam {
+	return openai.FunctionDefinitionParam{
+		Name:        t.Name(),
+		Description: openai.String("Use this function to recommend restaurants, cafes, bars, museums, attractions or parks near a place, e.g. 'good tapas near Sagrada Familia open Sunday'. It returns real places, closest first, with distance, opening hours and rating when known. Do NOT recommend specific places from memory when this tool can look them up."),
+		Parameters: openai.FunctionParameters{
+			"type": "object",
+			"properties": map[string]any{
+				"category": map[string]any{
+					"type":        "string",
+					"description": "Kind of place to find.",
+					"enum":        placeCategories,
+				},
+				"near": map[string]string{
+					"type":        "string",
+					"description": "Landmark, address, neighbourhood or city to search around, with the city, e.g. 'Sagrada Familia, Barcelona', or a place the user saved, e.g. 'hotel'.",
+				},
+				"keywords": map[string]string{
+					"type":        "string",
+					"description": "Optional words the place's name or cuisine must match, e.g. 'tapas', 'sushi' or 'vegan'.",
+				},
+				"open_now": map[string]string{
+					"type":        "boolean",
+					"description": "Only return places open right now.",
+				},
+				"open_at": map[string]string{
+					"type":        "string",
+					"format":      "date-time",
+					"description": "Only return places open at this local time of the place, in RFC3339 format, e.g. Sunday at 13:00 for 'open Sunday'. Ignored with open_now.",
+				},
+				"min_rating": map[string]any{
+					"type":        "number",
+					"description": "Optional minimum rating out of 5.",
+					"minimum":     0,
+					"maximum":     5,
+				},
+				"radius_m": map[string]any{
+					"type":        "integer",
+					"description": "Search radius in meters (100-10000). Defaults to 1500.",
+					"minimum":     100,
+					"maximum":     maxPlacesRadiusM,
+				},
+				"max_results": map[string]any{
+					"type":        "integer",
+					"description": "How many places to return (1-10). Defaults to 5.",
+					"minimum":     1,
+					"maximum":     10,
+				},
+			},
+			"required": []string{"category", "near"},
+		},
+	}
+}
+
+type placesArgs struct {
+	Category   string    `json:"category"`
+	Near       string    `json:"near"`
+	Keywords   string    `json:"keywords,omitempty"`
+	OpenNow    bool      `json:"open_now,omitempty"`
+	OpenAt     time.Time `json:"open_at,omitempty"`
+	MinRating  float64   `json:"min_rating,omitempty"`
+	RadiusM    int       `json:"radius_m,omitempty"`
+	MaxResults int       `json:"max_results,omitempty"`
+}
+
+func (t *placesTool) Call(ctx context.Context, args string) (string, error) {
+	var payload placesArgs
+	if err := decodeArgs(args, &payload); err != nil {
+		return "", err
+	}
+	payload.Near = strings.TrimSpace(payload.Near)
+	if payload.Near == "" {
+		return "", errors.New(`missing argument "near"`)
+	}
+	if !slices.Contains(placeCategories, payload.Category) {
+		return "", fmt.Errorf(`invalid argument "category": use one of %s`, strings.Join(placeCategories, ", "))
+	}
+	if t.provider == nil {
+		return "", errors.New("Place search is not configured.")
+	}
+
+	near, note, err := resolveLocation(ctx, payload.Near)
+	if err != nil {
+		return "", err
+	}
+	q := PlaceQuery{
+		Category:   payload.Category,
+		Keywords:   strings.TrimSpace(payload.Keywords),
+		Near:       near,
+		RadiusM:    min(max(payload.RadiusM, 100), maxPlacesRadiusM),
+		OpenAt:     payload.OpenAt,
+		MinRating:  payload.MinRating,
+		MaxResults: min(max(payload.MaxResults, 1), 10),
+	}
+	if payload.RadiusM == 0 {
+		q.RadiusM = defaultPlacesRadiusM
+	}
+	if payload.MaxResults == 0 {
+		q.MaxResults = defaultSearchResults
+	}
+	if payload.OpenNow {
+		// Opening hours are local, so "now" is the wall clock of the place.
+		if t.weather == nil {
+			return "", errors.New(`The local time at the place is unknown: pass "open_at" with the local time there instead of "open_now".`)
+		}
+		loc, _, err := t.weather.Timezone(ctx, near)
+		if err != nil {
+			return "", fmt.Errorf(`Could not find the local time at %s (%v): pass "open_at" with the local time there instead of "open_now".`, payload.Near, err)
+		}
+		q.OpenAt = time.Now().In(loc)
+	}
+
+	found, err := t.provider.SearchPlaces(ctx, q)
+	if errors.Is(err, ErrLocationNotFound) {
+		return "", fmt.Errorf("No location matches %q. Ask the user to be more specific, e.g. add the city.", payload.Near)
+	}
+	if err != nil {
+		return "", fmt.Errorf("Place search is temporarily unavailable (%v). Tell the user to try again later; do not make up places.", err)
+	}
+
+	rated := slices.ContainsFunc(found, func(p Place) bool { return p.Rating > 0 })
+	if rated && q.MinRating > 0 {
+		found = slices.DeleteFunc(found, func(p Place) bool { return p.Rating < q.MinRating })
+	}
+
+	var sb strings.Builder
+	sb.WriteString(note)
+	what := q.Category + "s" // every category has a regular plural
+	if q.Keywords != "" {
+		what += fmt.Sprintf(" matching %q", q.Keywords)
+	}
+	when := ""
+	if !q.OpenAt.IsZero() {
+		when = " open " + q.OpenAt.Format("Monday 15:04")
+	}
+	if len(found) == 0 {
+		sb.WriteString(fmt.Sprintf("No %s%s found within %d m of %s. Suggest a wider radius or fewer keywords.\n", what, when, q.RadiusM, payload.Near))
+		return sb.String(), nil
+	}
+
+	sb.WriteString(fmt.Sprintf("%s%s within %d m of %s, closest first:\n", capitalize(what), when, q.RadiusM, payload.Near))
+	for i, p := range found {
+		sb.WriteString(fmt.Sprintf("%d. %s\n", i+1, formatPlace(p)))
+	}
+	if q.MinRating > 0 && !rated {
+		sb.WriteString("This source has no ratings, so min_rating was not applied: don't claim these places are highly rated.\n")
+	}
+	if !q.OpenAt.IsZero() && slices.ContainsFunc(found, func(p Place) bool { return p.Open == nil }) {
+		sb.WriteString("Places with unknown hours may be closed then: tell the user to check before going.\n")
+	}
+	return sb.String(), nil
+}
+
+func formatPlace(p Place) string {
+	parts := []string{p.Name}
+	if p.Details != "" {
+		parts = append(parts, p.Details)
+	}
+	parts = append(parts, fmt.Sprintf("%d m away", p.DistanceM))
+	if p.Address != "" {
+		parts = append(parts, p.Address)
+	}
+	if p.Rating > 0 {
+		parts = append(parts, fmt.Sprintf("rated %.1f/5 (%d reviews)", p.Rating, p.Reviews))
+	}
+	switch {
+	case p.OpeningHours != "" && p.Open != nil:
+		parts = append(parts, "open at that time, hours "+p.OpeningHours)
+	case p.OpeningHours != "":
+		parts = append(parts, "hours "+p.OpeningHours)
+	default:
+		parts = append(parts, "hours unknown")
+	}
+	if p.Website != "" {
+		parts = append(parts, p.Website)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// capitalize upper-cases the first letter of s.
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
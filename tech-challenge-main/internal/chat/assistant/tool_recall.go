@@ -0,0 +1,120 @@
+package assistant
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/acai-travel/tech-challenge/internal/chat/model"
+	"github.com/openai/openai-go/v2"
+)
+
+// Memory is a message from a past conversation retrieved by recall_past_conversations.
+type Memory struct {
+	ConversationID string
+	Role           model.Role
+	Content        string
+	CreatedAt      time.Time
+	Score          float64
+}
+
+// Recaller searches the past conversations of the user being answered.
+type Recaller interface {
+	Recall(ctx context.Context, query string, limit int) ([]Memory, error)
+}
+
+type recallerKey struct{}
+
+// WithRecaller attaches the search used by recall_past_conversations to the context. The
+// tool is only offered when a recaller is attached.
+func WithRecaller(ctx context.Context, r Recaller) context.Context {
+	return context.WithValue(ctx, recallerKey{}, r)
+}
+
+func recallerFromContext(ctx context.Context) Recaller {
+	r, _ := ctx.Value(recallerKey{}).(Recaller)
+	return r
+}
+
+const (
+	defaultRecallLimit = 5
+	maxRecallLimit     = 10
+)
+
+type recallTool struct{}
+
+func (t *recallTool) Name() string { return "recall_past_conversations" }
+
+func (t *recallTool) Guidance() string {
+	return "Use **recall_past_conversations** when the user refers to something from an earlier conversation that is not in this one. Say so if nothing relevant is found; never guess."
+}
+
+func (t *recallTool) Definition() openai.FunctionDefinitionParam {
+	return openai.FunctionDefinitionParam{
+		Name:        t.Name(),
+		Description: openai.String("Searches the user's previous conversations by meaning and returns the most relevant past messages. Use it when the user refers to something they said or were told before that is not in this conversation, e.g. 'what hotel did I mention last month?'. Each line is 'YYYY-MM-DD <role>: <message>', most relevant first."),
+		Parameters: openai.FunctionParameters{
+			"type": "object",
+			"properties": map[string]any{
+				"query": map[string]string{
+					"type":        "string",
+					"description": "What to look for, phrased as a short description of the content, e.g. 'hotel booked in Lisbon'.",
+				},
+				"limit": map[string]any{
+					"type":        "integer",
+					"description": fmt.Sprintf("Optional number of messages to return, 1-%d. Defaults to %d.", maxRecallLimit, defaultRecallLimit),
+					"minimum":     1,
+					"maximum":     maxRecallLimit,
+				},
+			},
+			"required": []string{"query"},
+		},
+	}
+}
+
+// Available reports whether past conversations can be searched for this request.
+func (t *recallTool) Available(ctx context.Context) bool {
+	return recallerFromContext(ctx) != nil
+}
+
+type recallArgs struct {
+	Query string `json:"query"`
+	Limit int    `json:"limit,omitempty"`
+}
+
+func (t *recallTool) Call(ctx context.Context, args string) (string, error) {
+	var payload recallArgs
+	if err := decodeArgs(args, &payload); err != nil {
+		return "", err
+	}
+	if strings.TrimSpace(payload.Query) == "" {
+		return "", errors.New(`missing required argument "query"`)
+	}
+
+	limit := payload.Limit
+	if limit <= 0 {
+		limit = defaultRecallLimit
+	}
+	limit = min(limit, maxRecallLimit)
+
+	r := recallerFromContext(ctx)
+	if r == nil {
+		return "", errors.New("past conversations are not available")
+	}
+
+	memories, err := r.Recall(ctx, payload.Query, limit)
+	if err != nil {
+		return "", fmt.Errorf("failed to search past conversations: %w", err)
+	}
+	if len(memories) == 0 {
+		return "No relevant past messages found.", nil
+	}
+
+	var b strings.Builder
+	for _, m := range memories {
+		fmt.Fprintf(&b, "%s %s: %s\n", m.CreatedAt.Format("2006-01-02"), m.Role, strings.Join(strings.Fields(m.Content), " "))
+	}
+	return strings.TrimRight(b.String(), "\n"), nil
+}
@@ -0,0 +1,63 @@
+package assistant
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/acai-travel/tech-challenge/internal/chat/model"
+)
+
+type fakeRecaller func(ctx context.Context, query string, limit int) ([]Memory, error)
+
+func (f fakeRecaller) Recall(ctx context.Context, query string, limit int) ([]Memory, error) {
+	return f(ctx, query, limit)
+}
+
+func TestRecallTool(t *testing.T) {
+	tool := &recallTool{}
+	day := time.Date(2025, 9, 14, 10, 0, 0, 0, time.UTC)
+
+	t.Run("formats memories", func(t *testing.T) {
+		var gotLimit int
+		ctx := WithRecaller(context.Background(), fakeRecaller(func(_ context.Context, query string, limit int) ([]Memory, error) {
+			gotLimit = limit
+			return []Memory{
+				{Role: model.RoleUser, Content: "We booked the Hotel\n  Avenida in Lisbon", CreatedAt: day},
+				{Role: model.RoleAssistant, Content: "Enjoy Lisbon!", CreatedAt: day},
+			}, nil
+		}))
+
+		out, err := tool.Call(ctx, `{"query":"hotel","limit":50}`)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		want := "2025-09-14 user: We booked the Hotel Avenida in Lisbon\n2025-09-14 assistant: Enjoy Lisbon!"
+		if out != want {
+			t.Fatalf("got %q, want %q", out, want)
+		}
+		if gotLimit != maxRecallLimit {
+			t.Fatalf("expected limit to be capped at %d, got %d", maxRecallLimit, gotLimit)
+		}
+	})
+
+	t.Run("no matches", func(t *testing.T) {
+		ctx := WithRecaller(context.Background(), fakeRecaller(func(context.Context, string, int) ([]Memory, error) { return nil, nil }))
+
+		out, err := tool.Call(ctx, `{"query":"hotel"}`)
+		if err != nil || out != "No relevant past messages found." {
+			t.Fatalf("got %q, %v", out, err)
+		}
+	})
+
+	t.Run("requires a query", func(t *testing.T) {
+		ctx := WithRecaller(context.Background(), fakeRecaller(func(context.Context, string, int) ([]Memory, error) {
+			return nil, errors.New("should not be called")
+		}))
+
+		if _, err := tool.Call(ctx, `{"query":"  "}`); err == nil {
+			t.Fatal("expected an error for an empty query")
+		}
+	})
+}
@@ -0,0 +1,187 @@
+package assistant
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/acai-travel/tech-challenge/internal/features"
+	"github.com/openai/openai-go/v2"
+)
+
+const (
+	// routeSampleKm is the spacing of weather samples along a route, bounded by
+	// minRouteSamples and maxRouteSamples.
+	routeSampleKm   = 150.0
+	minRouteSamples = 2
+	maxRouteSamples = 8
+)
+
+type routeWeatherTool struct {
+	weather *WeatherService
+	routes  RouteProvider
+}
+
+func (t *routeWeatherTool) Name() string { return "get_route_weather" }
+
+func (t *routeWeatherTool) Guidance() string {
+	return `For a drive between two places ("the drive from Barcelona to Lyon tomorrow"), call **get_route_weather** with the origin, destination and departure time, and summarize the conditions along the way.`
+}
+
+func (t *routeWeatherTool) Feature() features.Flag { return features.Weather }
+
+func (t *routeWeatherTool) Definition() openai.FunctionDefinitionParam {
+	return openai.FunctionDefinitionParam{
+		Name:        t.Name(),
+		Description: openai.String("Use this function for the weather along a drive between two places: it returns the distance and driving time, and the forecast at points along the route at the time the driver is expected to pass them. Forecasts reach 14 days ahead."),
+		Parameters: openai.FunctionParameters{
+			"type": "object",
+			"properties": map[string]any{
+				"origin": map[string]string{
+					"type":        "string",
+					"description": "Where the drive starts: city name or coordinates, e.g. 'Barcelona', or a place the user saved, e.g. 'home'.",
+				},
+				"destination": map[string]string{
+					"type":        "string",
+					"description": "Where the drive ends, e.g. 'Lyon' or 'Lyon,France'.",
+				},
+				"departure_time": map[string]string{
+					"type":        "string",
+					"format":      "date-time",
+					"description": "Optional departure time in RFC3339 format. Defaults to now.",
+				},
+			},
+			"required": []string{"origin", "destination"},
+		},
+	}
+}
+
+type routeWeatherArgs struct {
+	Origin        string    `json:"origin"`
+	Destination   string    `json:"destination"`
+	DepartureTime time.Time `json:"departure_time,omitempty"`
+}
+
+func (t *routeWeatherTool) Call(ctx context.Context, args string) (string, error) {
+	var payload routeWeatherArgs
+	if err := decodeArgs(args, &payload); err != nil {
+		return "", err
+	}
+	if strings.TrimSpace(payload.Origin) == "" || strings.TrimSpace(payload.Destination) == "" {
+		return "", errors.New(`missing argument: "origin" and "destination" are required`)
+	}
+	if t.weather == nil || t.routes == nil {
+		return "", errors.New("Weather service is not configured. Please set WEATHER_API_KEY environment variable.")
+	}
+
+	now := time.Now()
+	departure := payload.DepartureTime
+	if departure.IsZero() || departure.Before(now) {
+		departure = now
+	}
+	maxDays := min(maxForecastDays, features.FromContext(ctx).Int(features.MaxForecastDays))
+	horizon := now.AddDate(0, 0, maxDays)
+	if departure.After(horizon) {
+		return "", fmt.Errorf("No forecast is available yet for a departure on %s: forecasts reach %s. Tell the user to ask again closer to the date.",
+			formatDay(day(departure)), formatDay(day(horizon)))
+	}
+
+	origin, originNote, err := resolveLocation(ctx, payload.Origin)
+	if err != nil {
+		return "", err
+	}
+	destination, destinationNote, err := resolveLocation(ctx, payload.Destination)
+	if err != nil {
+		return "", err
+	}
+
+	route, err := t.routes.Route(ctx, origin, destination)
+	if err != nil {
+		return "", weatherToolError(payload.Origin+" to "+payload.Destination, err)
+	}
+	if len(route.Path) == 0 {
+		return "", fmt.Errorf("No route was found from %s to %s.", payload.Origin, payload.Destination)
+	}
+
+	n := min(max(int(math.Ceil(route.DistanceKm/routeSampleKm))+1, minRouteSamples), maxRouteSamples)
+	waypoints := sampleRoute(route.Path, n)
+
+	// Each waypoint needs its own forecast, so they are fetched together.
+	days := min(int(departure.Add(route.Duration).Sub(day(now)).Hours()/24)+1, maxDays)
+	lines := make([]string, len(waypoints))
+	var wg sync.WaitGroup
+	for i, wp := range waypoints {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			at := departure.Add(time.Duration(wp.Fraction * float64(route.Duration)))
+			lines[i] = t.waypointWeather(ctx, wp, at, days, route.DistanceKm)
+		}()
+	}
+	wg.Wait()
+
+	var sb strings.Builder
+	sb.WriteString(originNote + destinationNote)
+	sb.WriteString(fmt.Sprintf("Drive from %s to %s: %.0f km, about %s, leaving %s.\n",
+		route.Origin, route.Destination, route.DistanceKm, formatDriveTime(route.Duration), departure.Format("2006-01-02 15:04 MST")))
+	if route.Estimated {
+		sb.WriteString("Distance, time and waypoints are estimated from a straight line, not planned on roads: say they are approximate.\n")
+	}
+	sb.WriteString("Weather along the route, at the local time the driver passes:\n")
+	for _, line := range lines {
+		sb.WriteString(line)
+	}
+	return sb.String(), nil
+}
+
+// waypointWeather returns the forecast line of a waypoint for the hour closest to at.
+func (t *routeWeatherTool) waypointWeather(ctx context.Context, wp routeWaypoint, at time.Time, days int, totalKm float64) string {
+	prefix := fmt.Sprintf("- %.0f km", wp.Fraction*totalKm)
+
+	weather, err := t.weather.Forecast(ctx, fmt.Sprintf("%.4f,%.4f", wp.Lat, wp.Lon), days)
+	if err != nil {
+		return prefix + ": no forecast: " + weatherToolError("this part of the route", err).Error() + "\n"
+	}
+	if weather.Location.Name != "" {
+		prefix += fmt.Sprintf(", near %s", weather.Location.Name)
+	}
+
+	var (
+		best  string
+		delta = time.Duration(math.MaxInt64)
+	)
+	for _, fd := range weather.Forecast.Forecastday {
+		for _, h := range fd.Hour {
+			d := at.Sub(time.Unix(h.TimeEpoch, 0))
+			if d < 0 {
+				d = -d
+			}
+			if d < delta {
+				delta = d
+				best = fmt.Sprintf("%s (%s): %s, %.0f°C, wind %.0f km/h, %d%% chance of rain",
+					prefix, h.Time, h.Condition.Text, h.TempC, h.WindKph, h.ChanceOfRain)
+			}
+		}
+	}
+	if best == "" || delta > time.Hour {
+		return prefix + ": no hourly forecast for that time\n"
+	}
+	if weather.Stale {
+		best += " (cached forecast, may be outdated)"
+	}
+	return best + "\n"
+}
+
+// formatDriveTime rounds a driving time to 5 minutes, e.g. "6h 10m".
+func formatDriveTime(d time.Duration) string {
+	d = d.Round(5 * time.Minute)
+	h, m := int(d.Hours()), int(d.Minutes())%60
+	if h == 0 {
+		return fmt.Sprintf("%dm", m)
+	}
+	return fmt.Sprintf("%dh %02dm", h, m)
+}
@@ -0,0 +1,109 @@
+package assistant
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/acai-travel/tech-challenge/internal/features"
+)
+
+type fakeRoutes struct{ route *Route }
+
+func (f fakeRoutes) Route(context.Context, string, string) (*Route, error) { return f.route, nil }
+
+func TestSampleRoute(t *testing.T) {
+	path := []RoutePoint{{0, 0}, {0, 1}, {0, 3}}
+	got := sampleRoute(path, 4)
+	if len(got) != 4 {
+		t.Fatalf("expected 4 waypoints, got %d", len(got))
+	}
+	for i, want := range []float64{0, 1, 2, 3} {
+		if math.Abs(got[i].Lon-want) > 1e-9 || got[i].Lat != 0 {
+			t.Errorf("waypoint %d = %+v, want lon %v", i, got[i], want)
+		}
+	}
+	if got[3].Fraction != 1 {
+		t.Errorf("expected the last waypoint at the end, got %v", got[3].Fraction)
+	}
+
+	if d := distanceKm(RoutePoint{41.39, 2.17}, RoutePoint{45.76, 4.84}); d < 500 || d > 540 {
+		t.Errorf("expected about 520 km from Barcelona to Lyon, got %.0f", d)
+	}
+}
+
+func TestRouteWeatherTool(t *testing.T) {
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		midnight := day(time.Now())
+		var hours []string
+		for h := range 48 {
+			at := midnight.Add(time.Duration(h) * time.Hour)
+			hours = append(hours, fmt.Sprintf(`{"time_epoch":%d,"time":%q,"temp_c":%d,"condition":{"text":"Cloudy"},"wind_kph":20,"chance_of_rain":40}`,
+				at.Unix(), at.Format("2006-01-02 15:04"), h%24))
+		}
+		fmt.Fprintf(w, `{"location":{"name":%q},"forecast":{"forecastday":[{"date":%q,"hour":[%s]}]}}`,
+			r.URL.Query().Get("q"), midnight.Format(time.DateOnly), strings.Join(hours, ","))
+	}))
+	defer api.Close()
+
+	service := NewWeatherService(t.Name())
+	service.baseURL = api.URL
+	tool := &routeWeatherTool{weather: service, routes: fakeRoutes{&Route{
+		Origin: "Barcelona, Spain", Destination: "Lyon, France", DistanceKm: 640, Duration: 6 * time.Hour,
+		Path: []RoutePoint{{41.39, 2.17}, {45.76, 4.84}},
+	}}}
+
+	departure := time.Now().Add(time.Hour).Truncate(time.Hour).UTC()
+	got, err := tool.Call(context.Background(), fmt.Sprintf(`{"origin":"Barcelona","destination":"Lyon","departure_time":%q}`, departure.Format(time.RFC3339)))
+	if err != nil {
+		t.Fatalf("Call error: %v", err)
+	}
+
+	if !strings.Contains(got, "Drive from Barcelona, Spain to Lyon, France: 640 km, about 6h 00m") {
+		t.Errorf("unexpected summary:\n%s", got)
+	}
+	if strings.Contains(got, "estimated from a straight line") {
+		t.Errorf("a planned route should not be flagged as estimated:\n%s", got)
+	}
+	// 640 km is sampled every 150 km or less: 6 waypoints, the first at departure.
+	if n := strings.Count(got, "\n- "); n != 6 {
+		t.Errorf("expected 6 waypoints, got %d:\n%s", n, got)
+	}
+	first := fmt.Sprintf("- 0 km, near 41.3900,2.1700 (%s): Cloudy, %d°C", departure.Format("2006-01-02 15:04"), departure.Hour())
+	last := fmt.Sprintf("- 640 km, near 45.7600,4.8400 (%s)", departure.Add(6*time.Hour).Format("2006-01-02 15:04"))
+	if !strings.Contains(got, first) || !strings.Contains(got, last) {
+		t.Errorf("expected %q and %q in:\n%s", first, last, got)
+	}
+}
+
+func TestRouteWeatherTool_MaxForecastDays(t *testing.T) {
+	var days atomic.Int64
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n, _ := strconv.Atoi(r.URL.Query().Get("days"))
+		days.Store(max(days.Load(), int64(n)))
+		fmt.Fprint(w, `{"location":{"name":"Somewhere"},"forecast":{"forecastday":[]}}`)
+	}))
+	defer api.Close()
+
+	service := NewWeatherService(t.Name())
+	service.baseURL = api.URL
+	tool := &routeWeatherTool{weather: service, routes: fakeRoutes{&Route{
+		Origin: "Barcelona, Spain", Destination: "Lyon, France", DistanceKm: 640, Duration: 30 * time.Hour,
+		Path: []RoutePoint{{41.39, 2.17}, {45.76, 4.84}},
+	}}}
+
+	ctx := features.WithSet(context.Background(), features.Set{features.MaxForecastDays: "1"})
+	if _, err := tool.Call(ctx, `{"origin":"Barcelona","destination":"Lyon"}`); err != nil {
+		t.Fatalf("Call error: %v", err)
+	}
+	if got := days.Load(); got != 1 {
+		t.Errorf("requested %d forecast days, want at most the flag's 1", got)
+	}
+}
@@ -0,0 +1,101 @@
+package assistant
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/acai-travel/tech-challenge/internal/features"
+	"github.com/openai/openai-go/v2"
+)
+
+type skiConditionsTool struct {
+	snow SnowProvider
+}
+
+func (t *skiConditionsTool) Name() string { return "get_ski_conditions" }
+
+func (t *skiConditionsTool) Guidance() string {
+	return "Use **get_ski_conditions** for snow and ski questions about a resort. If the snow depth is not reported, say so instead of guessing."
+}
+
+func (t *skiConditionsTool) Feature() features.Flag { return features.SkiConditions }
+
+func (t *skiConditionsTool) Definition() openai.FunctionDefinitionParam {
+	return openai.FunctionDefinitionParam{
+		Name:        t.Name(),
+		Description: openai.String("Use this function for ski and snow questions about a resort or mountain: the snow depth and open runs when known, and the daily snowfall, chance of snow and temperatures for the coming days. Do NOT guess snow conditions from training data."),
+		Parameters: openai.FunctionParameters{
+			"type": "object",
+			"properties": map[string]any{
+				"resort": map[string]string{
+					"type":        "string",
+					"description": "Ski resort, mountain or town, e.g. 'Baqueira', 'Zermatt' or 'Val Thorens,France', or a place the user saved, e.g. 'chalet'.",
+				},
+				"days": map[string]any{
+					"type":        "integer",
+					"description": "Number of forecast days (1-14). Defaults to 3.",
+					"minimum":     1,
+					"maximum":     14,
+				},
+			},
+			"required": []string{"resort"},
+		},
+	}
+}
+
+type skiArgs struct {
+	Resort string `json:"resort"`
+	Days   int    `json:"days,omitempty"`
+}
+
+func (t *skiConditionsTool) Call(ctx context.Context, args string) (string, error) {
+	var payload skiArgs
+	if err := decodeArgs(args, &payload); err != nil {
+		return "", err
+	}
+	if strings.TrimSpace(payload.Resort) == "" {
+		return "", errors.New(`missing argument "resort": a ski resort or mountain is required`)
+	}
+	if t.snow == nil {
+		return "", errors.New("Snow conditions are not configured. Please set WEATHER_API_KEY environment variable.")
+	}
+
+	query, note, err := resolveLocation(ctx, payload.Resort)
+	if err != nil {
+		return "", err
+	}
+	days := payload.Days
+	if days < 1 {
+		days = 3
+	}
+	days = min(days, features.FromContext(ctx).Int(features.MaxForecastDays))
+
+	report, err := t.snow.SnowReport(ctx, query, days)
+	if err != nil {
+		return "", weatherToolError(payload.Resort, err)
+	}
+
+	var sb strings.Builder
+	sb.WriteString(note)
+	sb.WriteString(fmt.Sprintf("**%s** snow conditions\n", report.Resort))
+	if report.DepthCm != nil {
+		sb.WriteString(fmt.Sprintf("Snow depth: %.0f cm\n", *report.DepthCm))
+	} else {
+		sb.WriteString("Snow depth: not reported; do not guess it, suggest the resort's own snow report.\n")
+	}
+	if report.OpenRuns != nil {
+		sb.WriteString(fmt.Sprintf("Runs open: %d%%\n", *report.OpenRuns))
+	}
+	for _, d := range report.Days {
+		sb.WriteString(fmt.Sprintf("- %s: %s, %.0f–%.0f°C, %.1f cm new snow (%d%% chance of snow)\n",
+			formatDay(d.Date), d.Condition, d.MinTempC, d.MaxTempC, d.SnowfallCm, d.ChanceOfSnow))
+	}
+	if report.Stale {
+		sb.WriteString(fmt.Sprintf("\nNote: live conditions are temporarily unavailable; this report is %s old and may be outdated. Tell the user.\n",
+			describeAge(time.Since(report.UpdatedAt))))
+	}
+	return sb.String(), nil
+}
@@ -0,0 +1,69 @@
+package assistant
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+type fakeSnowProvider struct {
+	report *SnowReport
+	resort string
+	days   int
+}
+
+func (f *fakeSnowProvider) SnowReport(_ context.Context, resort string, days int) (*SnowReport, error) {
+	f.resort, f.days = resort, days
+	return f.report, nil
+}
+
+func TestSkiConditionsTool(t *testing.T) {
+	depth, open := 85.0, 70
+	days := []SnowDay{{Date: time.Date(2026, 12, 19, 0, 0, 0, 0, time.UTC), SnowfallCm: 12, ChanceOfSnow: 89, MinTempC: -9, MaxTempC: -2, Condition: "Heavy snow"}}
+
+	t.Run("reports the depth from a snow provider", func(t *testing.T) {
+		snow := &fakeSnowProvider{report: &SnowReport{Resort: "Baqueira, Spain", DepthCm: &depth, OpenRuns: &open, Days: days}}
+		got, err := (&skiConditionsTool{snow: snow}).Call(context.Background(), `{"resort":"Baqueira"}`)
+		if err != nil {
+			t.Fatalf("Call error: %v", err)
+		}
+		for _, want := range []string{"**Baqueira, Spain**", "Snow depth: 85 cm", "Runs open: 70%", "- 2026-12-19 (Saturday): Heavy snow, -9–-2°C, 12.0 cm new snow (89% chance of snow)"} {
+			if !strings.Contains(got, want) {
+				t.Errorf("expected %q in:\n%s", want, got)
+			}
+		}
+		if snow.days != 3 {
+			t.Errorf("expected 3 forecast days by default, got %d", snow.days)
+		}
+	})
+
+	t.Run("says when the depth is unknown", func(t *testing.T) {
+		snow := &fakeSnowProvider{report: &SnowReport{Resort: "Baqueira, Spain", Days: days}}
+		got, err := (&skiConditionsTool{snow: snow}).Call(context.Background(), `{"resort":"Baqueira"}`)
+		if err != nil {
+			t.Fatalf("Call error: %v", err)
+		}
+		if !strings.Contains(got, "Snow depth: not reported") {
+			t.Errorf("expected the depth to be reported as unknown:\n%s", got)
+		}
+	})
+
+	t.Run("resolves saved places", func(t *testing.T) {
+		snow := &fakeSnowProvider{report: &SnowReport{Resort: "Baqueira, Spain", Days: days}}
+		ctx := WithAliasResolver(context.Background(), fakeAliases{"chalet": {Name: "chalet", Lat: 42.7, Lon: 0.93}})
+		got, err := (&skiConditionsTool{snow: snow}).Call(ctx, `{"resort":"chalet"}`)
+		if err != nil {
+			t.Fatalf("Call error: %v", err)
+		}
+		if snow.resort != "42.7000,0.9300" || !strings.Contains(got, `"chalet" is the user's saved place`) {
+			t.Errorf("expected the saved place's coordinates, got %q:\n%s", snow.resort, got)
+		}
+	})
+
+	t.Run("without a provider", func(t *testing.T) {
+		if _, err := (&skiConditionsTool{}).Call(context.Background(), `{"resort":"Baqueira"}`); err == nil {
+			t.Error("expected an error")
+		}
+	})
+}
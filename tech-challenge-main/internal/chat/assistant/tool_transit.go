@@ -0,0 +1,130 @@
+package assistant
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/acai-travel/tech-challenge/internal/features"
+	"github.com/openai/openai-go/v2"
+)
+
+// maxTransitAlerts bounds the alerts listed, so a major disruption doesn't flood the reply.
+const maxTransitAlerts = 15
+
+type transitTool struct {
+	provider TransitProvider
+}
+
+func (t *transitTool) Name() string { return "get_transit_status" }
+
+func (t *transitTool) Guidance() string {
+	return "Use **get_transit_status** for metro, train, tram or bus disruptions in a city; if the city is not supported, say so and point to the local operator."
+}
+
+func (t *transitTool) Feature() features.Flag { return features.Transit }
+
+// Available reports whether a transit provider is configured.
+func (t *transitTool) Available(context.Context) bool { return t.provider != nil }
+
+func (t *transitTool) Definition() openai.FunctionDefinitionParam {
+	return openai.FunctionDefinitionParam{
+		Name:        t.Name(),
+		Description: openai.String("Use this function when users ask about disruptions, delays, closures or strikes on public transport (metro, train, tram, bus) in a city. It returns the current alerts of the operators, or says the city is not supported. Do NOT guess transit disruptions."),
+		Parameters: openai.FunctionParameters{
+			"type": "object",
+			"properties": map[string]any{
+				"city": map[string]string{
+					"type":        "string",
+					"description": "City name, e.g. 'Madrid' or 'Boston'.",
+				},
+				"line": map[string]string{
+					"type":        "string",
+					"description": "Optional line or route to report on, e.g. 'L1' or 'Red'. Omit for the whole network.",
+				},
+			},
+			"required": []string{"city"},
+		},
+	}
+}
+
+type transitArgs struct {
+	City string `json:"city"`
+	Line string `json:"line,omitempty"`
+}
+
+func (t *transitTool) Call(ctx context.Context, args string) (string, error) {
+	var payload transitArgs
+	if err := decodeArgs(args, &payload); err != nil {
+		return "", err
+	}
+	payload.City, payload.Line = strings.TrimSpace(payload.City), strings.TrimSpace(payload.Line)
+	if payload.City == "" {
+		return "", errors.New(`missing argument "city"`)
+	}
+	if t.provider == nil {
+		return "", errors.New("Transit status is not configured.")
+	}
+
+	status, err := t.provider.TransitStatus(ctx, payload.City)
+	if errors.Is(err, ErrTransitUnsupported) {
+		// Not an error for the model: it should tell the user, not retry.
+		msg := fmt.Sprintf("Transit status is not available for %s.", payload.City)
+		if cities := t.provider.Cities(); len(cities) > 0 {
+			msg += " Supported cities: " + strings.Join(cities, ", ") + "."
+		}
+		return msg + " Tell the user and suggest checking the local operator's website or app.", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("The transit service is temporarily unavailable (%v). Tell the user to check the operator's website or try again later; do not guess.", err)
+	}
+
+	alerts := status.Alerts
+	if payload.Line != "" {
+		alerts = slices.DeleteFunc(slices.Clone(alerts), func(a TransitAlert) bool {
+			return len(a.Lines) > 0 && !slices.ContainsFunc(a.Lines, func(l string) bool { return strings.EqualFold(l, payload.Line) })
+		})
+	}
+
+	var sb strings.Builder
+	scope := status.City
+	if payload.Line != "" {
+		scope += ", line " + payload.Line
+	}
+	if len(alerts) == 0 {
+		sb.WriteString(fmt.Sprintf("No disruptions reported for %s as of %s.\n", scope, status.FetchedAt.UTC().Format("15:04 MST")))
+		return sb.String(), nil
+	}
+
+	sb.WriteString(fmt.Sprintf("%d disruption(s) reported for %s as of %s:\n", len(alerts), scope, status.FetchedAt.UTC().Format("15:04 MST")))
+	for _, a := range alerts[:min(len(alerts), maxTransitAlerts)] {
+		sb.WriteString("- " + formatTransitAlert(a) + "\n")
+	}
+	if n := len(alerts) - maxTransitAlerts; n > 0 {
+		sb.WriteString(fmt.Sprintf("... and %d more.\n", n))
+	}
+	return sb.String(), nil
+}
+
+func formatTransitAlert(a TransitAlert) string {
+	var parts []string
+	if len(a.Lines) > 0 {
+		parts = append(parts, "Lines "+strings.Join(a.Lines, ", "))
+	} else {
+		parts = append(parts, "Whole network")
+	}
+	if a.Effect != "" {
+		parts = append(parts, a.Effect)
+	}
+	s := strings.Join(parts, ", ") + ": " + cmp.Or(a.Header, a.Description, "disruption")
+	if a.Description != "" && a.Header != "" && a.Description != a.Header {
+		s += " " + a.Description
+	}
+	if !a.End.IsZero() {
+		s += " (until " + a.End.UTC().Format("2006-01-02 15:04 MST") + ")"
+	}
+	return strings.Join(strings.Fields(s), " ")
+}
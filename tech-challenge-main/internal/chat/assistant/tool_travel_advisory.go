@@ -0,0 +1,80 @@
+package assistant
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/acai-travel/tech-challenge/internal/features"
+	"github.com/openai/openai-go/v2"
+)
+
+type travelAdvisoryTool struct {
+	feed *advisoryFeed
+}
+
+func (t *travelAdvisoryTool) Name() string { return "get_travel_advisory" }
+
+func (t *travelAdvisoryTool) Guidance() string {
+	return "Use **get_travel_advisory** when the user asks whether a country is safe to visit; give the risk level and when it was updated, and recommend checking their government's advice."
+}
+
+func (t *travelAdvisoryTool) Feature() features.Flag { return features.TravelAdvisory }
+
+func (t *travelAdvisoryTool) Definition() openai.FunctionDefinitionParam {
+	return openai.FunctionDefinitionParam{
+		Name:        t.Name(),
+		Description: openai.String("Use this function when users ask whether it is safe to travel to a country. It returns the risk level, from 0 (safe) to 5 (avoid travel), aggregated from government travel advisories, and when it was last updated. Do NOT judge travel safety from training data."),
+		Parameters: openai.FunctionParameters{
+			"type": "object",
+			"properties": map[string]any{
+				"country": map[string]string{
+					"type":        "string",
+					"description": "Country name in English or ISO 3166-1 alpha-2 code, e.g. 'Peru' or 'PE'.",
+				},
+			},
+			"required": []string{"country"},
+		},
+	}
+}
+
+type travelAdvisoryArgs struct {
+	Country string `json:"country"`
+}
+
+func (t *travelAdvisoryTool) Call(ctx context.Context, args string) (string, error) {
+	var payload travelAdvisoryArgs
+	if err := decodeArgs(args, &payload); err != nil {
+		return "", err
+	}
+	if payload.Country = strings.TrimSpace(payload.Country); payload.Country == "" {
+		return "", errors.New(`missing argument "country"`)
+	}
+
+	a, ok, err := t.feed.Find(ctx, payload.Country)
+	if err != nil {
+		return "", fmt.Errorf("Travel advisories are temporarily unavailable (%v). Tell the user to check their government's travel advice; do not guess.", err)
+	}
+	if !ok {
+		return "", fmt.Errorf("No country matches %q. Ask the user which country they mean, or retry with its English name.", payload.Country)
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Travel advisory for %s (%s): %.1f out of 5, %s.\n", a.Name, a.Code, a.Score, advisoryLevel(a.Score)))
+	if a.Sources > 0 {
+		sb.WriteString(fmt.Sprintf("Based on %d government advisories", a.Sources))
+		if !a.Updated.IsZero() {
+			sb.WriteString(", updated " + a.Updated.Format(time.DateOnly))
+		}
+		sb.WriteString(".\n")
+	} else {
+		sb.WriteString("No government currently issues an advisory for this country.\n")
+	}
+	if a.Source != "" {
+		sb.WriteString("Details: " + a.Source + "\n")
+	}
+	sb.WriteString("Situations change quickly: tell the user to check their own government's travel advice before booking.\n")
+	return sb.String(), nil
+}
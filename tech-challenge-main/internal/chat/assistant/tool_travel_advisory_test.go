@@ -0,0 +1,48 @@
+package assistant
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestTravelAdvisoryTool(t *testing.T) {
+	var calls atomic.Int32
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		fmt.Fprint(w, `{"api_status":{"request":{"item":"all"}},"data":{
+			"GB":{"iso_alpha2":"GB","name":"United Kingdom","advisory":{"score":2.2,"sources_active":6,"updated":"2026-10-13 07:22:04","source":"https://www.travel-advisory.info/united-kingdom"}},
+			"SY":{"iso_alpha2":"SY","name":"Syria","advisory":{"score":5,"sources_active":9,"updated":"2026-10-13 07:22:04","source":"https://www.travel-advisory.info/syria"}}}}`)
+	}))
+	defer api.Close()
+
+	tool := &travelAdvisoryTool{feed: &advisoryFeed{url: api.URL, client: &http.Client{Timeout: time.Second}}}
+	ctx := context.Background()
+
+	cases := []struct {
+		country string
+		want    string
+	}{
+		{"the UK", "Travel advisory for United Kingdom (GB): 2.2 out of 5, low risk: travelling is relatively safe.\nBased on 6 government advisories, updated 2026-10-13."},
+		{"united kingdom", "United Kingdom (GB)"},
+		{"sy", "Syria (SY): 5.0 out of 5, extreme warning: avoid any trips."},
+	}
+	for _, tc := range cases {
+		got, err := tool.Call(ctx, fmt.Sprintf(`{"country":%q}`, tc.country))
+		if err != nil || !strings.Contains(got, tc.want) {
+			t.Errorf("Call(%q) = %q, %v; want it to contain %q", tc.country, got, err, tc.want)
+		}
+	}
+
+	if _, err := tool.Call(ctx, `{"country":"Atlantis"}`); err == nil || !strings.Contains(err.Error(), `No country matches "Atlantis"`) {
+		t.Errorf("expected an unknown country error, got %v", err)
+	}
+	if n := calls.Load(); n != 1 {
+		t.Errorf("expected the feed to be fetched once a day, got %d calls", n)
+	}
+}
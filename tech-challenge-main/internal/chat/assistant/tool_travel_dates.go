@@ -0,0 +1,256 @@
+package assistant
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/acai-travel/tech-challenge/internal/features"
+	"github.com/openai/openai-go/v2"
+)
+
+const (
+	defaultTravelOptions = 3
+	// maxTravelWindowDays bounds the period searched for travel dates.
+	maxTravelWindowDays = 366
+)
+
+type travelDatesTool struct {
+	weather *WeatherService
+}
+
+func (t *travelDatesTool) Name() string { return "suggest_travel_dates" }
+
+func (t *travelDatesTool) Guidance() string {
+	return `Use **suggest_travel_dates** when the user asks when to travel or take days off, e.g. "when should I take 3 days off next month?"; present each option with its dates, the days to take off and the holidays it uses, best first.`
+}
+
+func (t *travelDatesTool) Feature() features.Flag { return features.TravelDates }
+
+func (t *travelDatesTool) Definition() openai.FunctionDefinitionParam {
+	return openai.FunctionDefinitionParam{
+		Name:        t.Name(),
+		Description: openai.String("Suggests when to travel given how many working days the user can take off: the periods where those days, joined with weekends and public holidays, give the longest trips, best first. Returns JSON options with their dates, the working days to take off and the holidays they include, plus the weather forecast for a city for days within the next 14. Use it for questions like 'when should I take 3 days off next month?'."),
+		Parameters: openai.FunctionParameters{
+			"type": "object",
+			"properties": map[string]any{
+				"days_off": map[string]any{
+					"type":        "integer",
+					"description": "How many working days the user can take off (0-15). 0 finds trips using only weekends and holidays.",
+					"minimum":     0,
+					"maximum":     15,
+				},
+				"after_date": map[string]string{
+					"type":        "string",
+					"format":      "date-time",
+					"description": "Optional first day the trip may start, in RFC3339 format. Defaults to today.",
+				},
+				"before_date": map[string]string{
+					"type":        "string",
+					"format":      "date-time",
+					"description": "Optional last day the trip may end, in RFC3339 format. Defaults to 3 months after after_date.",
+				},
+				"max_options": map[string]any{
+					"type":        "integer",
+					"description": "How many options to return (1-5). Defaults to 3.",
+					"minimum":     1,
+					"maximum":     5,
+				},
+				"country": map[string]string{
+					"type":        "string",
+					"description": "Optional country name in English, e.g. 'Spain'. Defaults to the configured local calendar.",
+				},
+				"region": map[string]string{
+					"type":        "string",
+					"description": "Optional region within the country, e.g. 'Catalonia'.",
+				},
+				"city": map[string]string{
+					"type":        "string",
+					"description": "Optional destination to attach the weather forecast for, e.g. 'Lisbon'.",
+				},
+			},
+			"required": []string{"days_off"},
+		},
+	}
+}
+
+type travelDatesArgs struct {
+	DaysOff    int       `json:"days_off"`
+	AfterDate  time.Time `json:"after_date,omitempty"`
+	BeforeDate time.Time `json:"before_date,omitempty"`
+	MaxOptions int       `json:"max_options,omitempty"`
+	Country    string    `json:"country,omitempty"`
+	Region     string    `json:"region,omitempty"`
+	City       string    `json:"city,omitempty"`
+}
+
+type travelDates struct {
+	From    string         `json:"from"`
+	To      string         `json:"to"`
+	DaysOff int            `json:"days_off"`
+	Options []travelOption `json:"options"`
+}
+
+type travelOption struct {
+	Start     string `json:"start"`
+	End       string `json:"end"`
+	TotalDays int    `json:"total_days"`
+	// TakeOff are the working days to request off, in date order.
+	TakeOff  []string        `json:"take_off"`
+	Holidays []travelHoliday `json:"holidays,omitempty"`
+	Forecast []travelWeather `json:"forecast,omitempty"`
+}
+
+type travelHoliday struct {
+	Date string `json:"date"`
+	Name string `json:"name"`
+}
+
+type travelWeather struct {
+	Date    string `json:"date"`
+	Weather string `json:"weather"`
+}
+
+func (t *travelDatesTool) Call(ctx context.Context, args string) (string, error) {
+	var payload travelDatesArgs
+	if err := decodeArgs(args, &payload); err != nil {
+		return "", err
+	}
+
+	from := payload.AfterDate
+	if from.IsZero() {
+		from = time.Now()
+	}
+	from = day(from)
+	to := day(payload.BeforeDate)
+	if payload.BeforeDate.IsZero() {
+		to = from.AddDate(0, 3, 0)
+	}
+	to = minTime(to, from.AddDate(0, 0, maxTravelWindowDays))
+	if to.Before(from) {
+		return "", errors.New(`invalid arguments: "before_date" is earlier than "after_date"`)
+	}
+	if payload.MaxOptions < 1 || payload.MaxOptions > 5 {
+		payload.MaxOptions = defaultTravelOptions
+	}
+
+	holidays, err := loadHolidays(ctx, payload.Country, payload.Region, from, to)
+	if err != nil {
+		return "", err
+	}
+	windows := findTravelWindows(holidays, from, to, payload.DaysOff, payload.MaxOptions)
+	forecast := forecastByDate(ctx, t.weather, t.Name(), payload.City)
+
+	out := travelDates{From: from.Format(time.DateOnly), To: to.Format(time.DateOnly), DaysOff: payload.DaysOff, Options: []travelOption{}}
+	for _, w := range windows {
+		opt := travelOption{Start: formatDay(w.Start), End: formatDay(w.End), TotalDays: w.Days(), TakeOff: []string{}}
+		for _, d := range w.TakeOff {
+			opt.TakeOff = append(opt.TakeOff, formatDay(d))
+		}
+		for _, h := range w.Holidays {
+			opt.Holidays = append(opt.Holidays, travelHoliday{Date: h.Date.Format(time.DateOnly), Name: h.Name})
+		}
+		for d := w.Start; !d.After(w.End); d = d.AddDate(0, 0, 1) {
+			if f, ok := forecast[d.Format(time.DateOnly)]; ok {
+				opt.Forecast = append(opt.Forecast, travelWeather{Date: d.Format(time.DateOnly), Weather: f})
+			}
+		}
+		out.Options = append(out.Options, opt)
+	}
+
+	b, err := json.Marshal(out)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+type travelWindow struct {
+	Start, End time.Time
+	TakeOff    []time.Time
+	Holidays   []holiday
+}
+
+func (w travelWindow) Days() int {
+	return int(w.End.Sub(w.Start).Hours()/24) + 1
+}
+
+// findTravelWindows returns up to limit non-overlapping periods within [from, to] that take
+// at most daysOff working days off, longest first, then using fewer days off, then earliest.
+// Periods are maximal: they start and end next to working days or the bounds of the search.
+func findTravelWindows(holidays []holiday, from, to time.Time, daysOff, limit int) []travelWindow {
+	from, to = day(from), day(to)
+
+	byDate := make(map[time.Time][]holiday)
+	for _, h := range holidays {
+		byDate[h.Date] = append(byDate[h.Date], h)
+	}
+
+	var days []time.Time
+	for d := from; !d.After(to); d = d.AddDate(0, 0, 1) {
+		days = append(days, d)
+	}
+	working := func(i int) bool {
+		d := days[i]
+		return d.Weekday() != time.Saturday && d.Weekday() != time.Sunday && len(byDate[d]) == 0
+	}
+
+	// For each start next to a working day, extend the end as far as the days off allow;
+	// starting later in a run of free days would only give a shorter trip.
+	var candidates []travelWindow
+	end, used := 0, 0
+	for start := range days {
+		if end < start {
+			end, used = start, 0
+		}
+		for end < len(days) && (!working(end) || used < daysOff) {
+			if working(end) {
+				used++
+			}
+			end++
+		}
+
+		if end > start && (start == 0 || working(start-1)) {
+			w := travelWindow{Start: days[start], End: days[end-1]}
+			for i := start; i < end; i++ {
+				if working(i) {
+					w.TakeOff = append(w.TakeOff, days[i])
+				}
+				w.Holidays = append(w.Holidays, byDate[days[i]]...)
+			}
+			// Days off spent on a trip without a weekend or holiday are just leave.
+			if w.Days() > len(w.TakeOff) {
+				candidates = append(candidates, w)
+			}
+		}
+
+		if start < end && working(start) {
+			used--
+		}
+	}
+
+	slices.SortStableFunc(candidates, func(a, b travelWindow) int {
+		return cmp.Or(cmp.Compare(b.Days(), a.Days()), cmp.Compare(len(a.TakeOff), len(b.TakeOff)), a.Start.Compare(b.Start))
+	})
+
+	var out []travelWindow
+	for _, c := range candidates {
+		if len(out) == limit {
+			break
+		}
+		if !slices.ContainsFunc(out, func(w travelWindow) bool { return !c.End.Before(w.Start) && !w.End.Before(c.Start) }) {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+func minTime(a, b time.Time) time.Time {
+	if b.Before(a) {
+		return b
+	}
+	return a
+}
@@ -0,0 +1,102 @@
+package assistant
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestFindTravelWindows(t *testing.T) {
+	date := func(s string) time.Time {
+		d, err := time.Parse(time.DateOnly, s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return d
+	}
+
+	holidays := []holiday{
+		{Date: date("2025-12-08"), Name: "Immaculate Conception"}, // Monday
+		{Date: date("2025-12-25"), Name: "Christmas Day"},         // Thursday
+		{Date: date("2025-12-26"), Name: "St. Stephen's Day"},     // Friday
+	}
+
+	describe := func(ws []travelWindow) []string {
+		var out []string
+		for _, w := range ws {
+			s := formatDay(w.Start) + " to " + formatDay(w.End)
+			for _, d := range w.TakeOff {
+				s += ", off " + d.Format(time.DateOnly)
+			}
+			out = append(out, s)
+		}
+		return out
+	}
+
+	cases := []struct {
+		name     string
+		from, to string
+		daysOff  int
+		limit    int
+		want     []string
+	}{
+		{
+			name: "three days around Christmas",
+			from: "2025-12-01", to: "2025-12-31", daysOff: 3, limit: 2,
+			want: []string{
+				// Monday to Wednesday off joins two weekends and both holidays: 9 days.
+				"2025-12-20 (Saturday) to 2025-12-28 (Sunday), off 2025-12-22, off 2025-12-23, off 2025-12-24",
+				// Around the Monday holiday, 6 days; the earliest of the options that long.
+				"2025-12-03 (Wednesday) to 2025-12-08 (Monday), off 2025-12-03, off 2025-12-04, off 2025-12-05",
+			},
+		},
+		{
+			name: "no days off finds weekends joined with holidays",
+			from: "2025-12-01", to: "2025-12-15", daysOff: 0, limit: 1,
+			want: []string{"2025-12-06 (Saturday) to 2025-12-08 (Monday)"},
+		},
+		{
+			name: "search bounds cut periods",
+			from: "2025-12-27", to: "2025-12-28", daysOff: 2, limit: 3,
+			want: []string{"2025-12-27 (Saturday) to 2025-12-28 (Sunday)"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := describe(findTravelWindows(holidays, date(tc.from), date(tc.to), tc.daysOff, tc.limit))
+			if !cmp.Equal(got, tc.want) {
+				t.Errorf("findTravelWindows mismatch (-got +want):\n%s", cmp.Diff(got, tc.want))
+			}
+		})
+	}
+}
+
+func TestFindTravelWindows_NonOverlappingBestFirst(t *testing.T) {
+	from, _ := time.Parse(time.DateOnly, "2025-12-01") // Monday
+	windows := findTravelWindows(nil, from, from.AddDate(0, 0, 27), 1, 5)
+
+	if len(windows) == 0 {
+		t.Fatal("expected windows")
+	}
+	for i, w := range windows {
+		if w.Days() != 3 || len(w.TakeOff) != 1 {
+			t.Errorf("window %d: %d days taking %d off, want 3 days taking 1", i, w.Days(), len(w.TakeOff))
+		}
+		for _, prev := range windows[:i] {
+			if !w.Start.After(prev.End) && !prev.Start.After(w.End) {
+				t.Errorf("windows %s and %s overlap", formatDay(prev.Start), formatDay(w.Start))
+			}
+		}
+	}
+}
+
+func TestTravelDatesTool_RejectsInvertedWindow(t *testing.T) {
+	tool := &travelDatesTool{}
+	_, err := tool.Call(t.Context(), `{"days_off":2,"after_date":"2025-12-10T00:00:00Z","before_date":"2025-12-01T00:00:00Z"}`)
+	if err == nil || !strings.Contains(err.Error(), "before_date") {
+		t.Fatalf("expected an error about before_date, got %v", err)
+	}
+}
@@ -0,0 +1,314 @@
+package assistant
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/acai-travel/tech-challenge/internal/features"
+	"github.com/openai/openai-go/v2"
+)
+
+const defaultSearchResults = 5
+
+// stubNote tells the model that results of StubTravelSearch are not real offers.
+const stubNote = "These are SAMPLE results from a demo provider, not real offers: say so, and don't present them as bookable.\n"
+
+type flightSearchTool struct {
+	provider FlightSearchProvider
+}
+
+func (t *flightSearchTool) Name() string { return "search_flights" }
+
+func (t *flightSearchTool) Guidance() string {
+	return "Use **search_flights** when the user wants flight options or prices for specific dates; present the best few offers and never invent offers or prices."
+}
+
+func (t *flightSearchTool) Feature() features.Flag { return features.TravelSearch }
+
+// Available reports whether a flight search provider is configured.
+func (t *flightSearchTool) Available(context.Context) bool { return t.provider != nil }
+
+func (t *flightSearchTool) Definition() openai.FunctionDefinitionParam {
+	return openai.FunctionDefinitionParam{
+		Name:        t.Name(),
+		Description: openai.String("Searches bookable flights between two places on given dates, returning offers with times, stops and total price, cheapest first. Use it when the user wants flight options or prices instead of giving generic advice."),
+		Parameters: openai.FunctionParameters{
+			"type": "object",
+			"properties": map[string]any{
+				"origin": map[string]string{
+					"type":        "string",
+					"description": "Departure airport or city, as an IATA code when known, e.g. 'BCN' or 'Barcelona'.",
+				},
+				"destination": map[string]string{
+					"type":        "string",
+					"description": "Arrival airport or city, e.g. 'LIS' or 'Lisbon'.",
+				},
+				"departure_date": map[string]string{
+					"type":        "string",
+					"format":      "date-time",
+					"description": "Day of the outbound flight, in RFC3339 format.",
+				},
+				"return_date": map[string]string{
+					"type":        "string",
+					"format":      "date-time",
+					"description": "Optional day of the return flight, in RFC3339 format. Omit for one-way trips.",
+				},
+				"adults": map[string]any{
+					"type":        "integer",
+					"description": "Number of adult travellers (1-9). Defaults to 1.",
+					"minimum":     1,
+					"maximum":     9,
+				},
+				"cabin": map[string]any{
+					"type":        "string",
+					"description": "Cabin class. Defaults to economy.",
+					"enum":        []string{"economy", "premium_economy", "business", "first"},
+				},
+				"max_results": map[string]any{
+					"type":        "integer",
+					"description": "How many offers to return (1-10). Defaults to 5.",
+					"minimum":     1,
+					"maximum":     10,
+				},
+			},
+			"required": []string{"origin", "destination", "departure_date"},
+		},
+	}
+}
+
+type flightSearchArgs struct {
+	Origin        string    `json:"origin"`
+	Destination   string    `json:"destination"`
+	DepartureDate time.Time `json:"departure_date"`
+	ReturnDate    time.Time `json:"return_date,omitempty"`
+	Adults        int       `json:"adults,omitempty"`
+	Cabin         string    `json:"cabin,omitempty"`
+	MaxResults    int       `json:"max_results,omitempty"`
+}
+
+func (t *flightSearchTool) Call(ctx context.Context, args string) (string, error) {
+	var payload flightSearchArgs
+	if err := decodeArgs(args, &payload); err != nil {
+		return "", err
+	}
+	q := FlightQuery{
+		Origin:        strings.TrimSpace(payload.Origin),
+		Destination:   strings.TrimSpace(payload.Destination),
+		DepartureDate: day(payload.DepartureDate),
+		ReturnDate:    day(payload.ReturnDate),
+		Adults:        max(payload.Adults, 1),
+		Cabin:         payload.Cabin,
+		MaxResults:    payload.MaxResults,
+	}
+	if q.Origin == "" || q.Destination == "" {
+		return "", errors.New(`missing argument: "origin" and "destination" are required`)
+	}
+	if q.DepartureDate.Before(day(time.Now())) {
+		return "", errors.New(`invalid argument "departure_date": it is in the past`)
+	}
+	if !q.ReturnDate.IsZero() && q.ReturnDate.Before(q.DepartureDate) {
+		return "", errors.New(`invalid arguments: "return_date" is earlier than "departure_date"`)
+	}
+	if q.Cabin == "" {
+		q.Cabin = "economy"
+	}
+	if q.MaxResults < 1 {
+		q.MaxResults = defaultSearchResults
+	}
+	if t.provider == nil {
+		return "", errors.New("Flight search is not configured.")
+	}
+
+	offers, err := t.provider.SearchFlights(ctx, q)
+	if err != nil {
+		return "", fmt.Errorf("Flight search is temporarily unavailable (%v). Tell the user to try again later; do not make up flights or prices.", err)
+	}
+
+	var sb strings.Builder
+	if _, ok := t.provider.(StubTravelSearch); ok {
+		sb.WriteString(stubNote)
+	}
+	trip := "one way"
+	if !q.ReturnDate.IsZero() {
+		trip = "returning " + formatDay(q.ReturnDate)
+	}
+	if len(offers) == 0 {
+		sb.WriteString(fmt.Sprintf("No flights found from %s to %s on %s (%s).\n", q.Origin, q.Destination, formatDay(q.DepartureDate), trip))
+		return sb.String(), nil
+	}
+
+	sb.WriteString(fmt.Sprintf("Flights from %s to %s on %s (%s), %d adult(s), %s:\n",
+		q.Origin, q.Destination, formatDay(q.DepartureDate), trip, q.Adults, strings.ReplaceAll(q.Cabin, "_", " ")))
+	for i, o := range offers[:min(len(offers), q.MaxResults)] {
+		sb.WriteString(fmt.Sprintf("%d. %s %s: %s, %.2f %s total\n", i+1, o.Carrier, strings.Join(o.Flights, " + "),
+			formatLeg(o.Departure, o.Arrival, o.Stops), o.Price, o.Currency))
+		if !o.ReturnDeparture.IsZero() {
+			sb.WriteString("   Return: " + formatLeg(o.ReturnDeparture, o.ReturnArrival, o.ReturnStops) + "\n")
+		}
+		if o.URL != "" {
+			sb.WriteString("   Book: " + o.URL + "\n")
+		}
+	}
+	return sb.String(), nil
+}
+
+// formatLeg describes a flight's times, e.g. "2026-05-01 07:00 → 09:15 (2h 15m), direct".
+func formatLeg(departure, arrival time.Time, stops int) string {
+	s := fmt.Sprintf("%s → %s (%s)", departure.Format("2006-01-02 15:04"), arrival.Format("15:04"), formatDriveTime(arrival.Sub(departure)))
+	switch stops {
+	case 0:
+		return s + ", direct"
+	case 1:
+		return s + ", 1 stop"
+	default:
+		return fmt.Sprintf("%s, %d stops", s, stops)
+	}
+}
+
+type hotelSearchTool struct {
+	provider HotelSearchProvider
+}
+
+func (t *hotelSearchTool) Name() string { return "search_hotels" }
+
+func (t *hotelSearchTool) Guidance() string {
+	return "Use **search_hotels** when the user wants hotel options or prices for specific dates; present the best few offers and never invent offers or prices."
+}
+
+func (t *hotelSearchTool) Feature() features.Flag { return features.TravelSearch }
+
+// Available reports whether a hotel search provider is configured.
+func (t *hotelSearchTool) Available(context.Context) bool { return t.provider != nil }
+
+func (t *hotelSearchTool) Definition() openai.FunctionDefinitionParam {
+	return openai.FunctionDefinitionParam{
+		Name:        t.Name(),
+		Description: openai.String("Searches bookable hotels in a city for given dates, returning offers with class, guest rating and price. Use it when the user wants hotel options or prices instead of giving generic advice."),
+		Parameters: openai.FunctionParameters{
+			"type": "object",
+			"properties": map[string]any{
+				"city": map[string]string{
+					"type":        "string",
+					"description": "City or area to stay in, e.g. 'Lisbon' or 'Alfama, Lisbon'.",
+				},
+				"check_in": map[string]string{
+					"type":        "string",
+					"format":      "date-time",
+					"description": "Arrival day, in RFC3339 format.",
+				},
+				"check_out": map[string]string{
+					"type":        "string",
+					"format":      "date-time",
+					"description": "Departure day, in RFC3339 format.",
+				},
+				"guests": map[string]any{
+					"type":        "integer",
+					"description": "Number of guests (1-10). Defaults to 2.",
+					"minimum":     1,
+					"maximum":     10,
+				},
+				"rooms": map[string]any{
+					"type":        "integer",
+					"description": "Number of rooms (1-5). Defaults to 1.",
+					"minimum":     1,
+					"maximum":     5,
+				},
+				"max_price_per_night": map[string]any{
+					"type":        "number",
+					"description": "Optional budget per night for all rooms.",
+					"minimum":     0,
+				},
+				"max_results": map[string]any{
+					"type":        "integer",
+					"description": "How many offers to return (1-10). Defaults to 5.",
+					"minimum":     1,
+					"maximum":     10,
+				},
+			},
+			"required": []string{"city", "check_in", "check_out"},
+		},
+	}
+}
+
+type hotelSearchArgs struct {
+	City             string    `json:"city"`
+	CheckIn          time.Time `json:"check_in"`
+	CheckOut         time.Time `json:"check_out"`
+	Guests           int       `json:"guests,omitempty"`
+	Rooms            int       `json:"rooms,omitempty"`
+	MaxPricePerNight float64   `json:"max_price_per_night,omitempty"`
+	MaxResults       int       `json:"max_results,omitempty"`
+}
+
+func (t *hotelSearchTool) Call(ctx context.Context, args string) (string, error) {
+	var payload hotelSearchArgs
+	if err := decodeArgs(args, &payload); err != nil {
+		return "", err
+	}
+	q := HotelQuery{
+		City:             strings.TrimSpace(payload.City),
+		CheckIn:          day(payload.CheckIn),
+		CheckOut:         day(payload.CheckOut),
+		Guests:           payload.Guests,
+		Rooms:            max(payload.Rooms, 1),
+		MaxPricePerNight: payload.MaxPricePerNight,
+		MaxResults:       payload.MaxResults,
+	}
+	if q.City == "" {
+		return "", errors.New(`missing argument "city"`)
+	}
+	if q.CheckIn.Before(day(time.Now())) {
+		return "", errors.New(`invalid argument "check_in": it is in the past`)
+	}
+	if !q.CheckOut.After(q.CheckIn) {
+		return "", errors.New(`invalid arguments: "check_out" must be after "check_in"`)
+	}
+	if q.Guests < 1 {
+		q.Guests = 2
+	}
+	if q.MaxResults < 1 {
+		q.MaxResults = defaultSearchResults
+	}
+	if t.provider == nil {
+		return "", errors.New("Hotel search is not configured.")
+	}
+
+	offers, err := t.provider.SearchHotels(ctx, q)
+	if err != nil {
+		return "", fmt.Errorf("Hotel search is temporarily unavailable (%v). Tell the user to try again later; do not make up hotels or prices.", err)
+	}
+
+	var sb strings.Builder
+	if _, ok := t.provider.(StubTravelSearch); ok {
+		sb.WriteString(stubNote)
+	}
+	nights := int(q.CheckOut.Sub(q.CheckIn).Hours() / 24)
+	if len(offers) == 0 {
+		sb.WriteString(fmt.Sprintf("No hotels found in %s from %s to %s.\n", q.City, formatDay(q.CheckIn), formatDay(q.CheckOut)))
+		return sb.String(), nil
+	}
+
+	sb.WriteString(fmt.Sprintf("Hotels in %s from %s to %s (%d night(s)), %d guest(s), %d room(s):\n",
+		q.City, formatDay(q.CheckIn), formatDay(q.CheckOut), nights, q.Guests, q.Rooms))
+	for i, o := range offers[:min(len(offers), q.MaxResults)] {
+		sb.WriteString(fmt.Sprintf("%d. %s", i+1, o.Name))
+		if o.Stars > 0 {
+			sb.WriteString(fmt.Sprintf(" (%d stars)", o.Stars))
+		}
+		sb.WriteString(fmt.Sprintf(": %.2f %s per night, %.2f %s total", o.PricePerNight, o.Currency, o.TotalPrice, o.Currency))
+		if o.ReviewScore > 0 {
+			sb.WriteString(fmt.Sprintf(", rated %.1f/10", o.ReviewScore))
+		}
+		sb.WriteString("\n")
+		if o.Address != "" {
+			sb.WriteString("   " + o.Address + "\n")
+		}
+		if o.URL != "" {
+			sb.WriteString("   Book: " + o.URL + "\n")
+		}
+	}
+	return sb.String(), nil
+}
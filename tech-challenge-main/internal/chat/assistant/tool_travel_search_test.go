@@ -0,0 +1,65 @@
+package assistant
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFlightSearchTool(t *testing.T) {
+	tool := &flightSearchTool{provider: StubTravelSearch{}}
+	departure := time.Now().AddDate(0, 0, 10).Format(time.RFC3339)
+	back := time.Now().AddDate(0, 0, 14).Format(time.RFC3339)
+
+	got, err := tool.Call(context.Background(), fmt.Sprintf(`{"origin":"bcn","destination":"lis","departure_date":%q,"return_date":%q,"adults":2,"max_results":2}`, departure, back))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"SAMPLE results", "Flights from bcn to lis", "2 adult(s), economy", "1. Sample Air SA 100", "Return:", "direct"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Call() = %q; want it to contain %q", got, want)
+		}
+	}
+	if strings.Contains(got, "3. ") {
+		t.Errorf("Call() = %q; want at most 2 offers", got)
+	}
+
+	past := time.Now().AddDate(0, 0, -3).Format(time.RFC3339)
+	for _, args := range []string{
+		`{"origin":"BCN","destination":"","departure_date":"` + departure + `"}`,
+		`{"origin":"BCN","destination":"LIS","departure_date":"` + past + `"}`,
+		`{"origin":"BCN","destination":"LIS","departure_date":"` + back + `","return_date":"` + departure + `"}`,
+	} {
+		if _, err := tool.Call(context.Background(), args); err == nil {
+			t.Errorf("Call(%s) succeeded; want an error", args)
+		}
+	}
+}
+
+func TestHotelSearchTool(t *testing.T) {
+	tool := &hotelSearchTool{provider: StubTravelSearch{}}
+	checkIn := time.Now().AddDate(0, 0, 10).Format(time.RFC3339)
+	checkOut := time.Now().AddDate(0, 0, 13).Format(time.RFC3339)
+
+	got, err := tool.Call(context.Background(), fmt.Sprintf(`{"city":"Lisbon","check_in":%q,"check_out":%q,"max_price_per_night":150}`, checkIn, checkOut))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"SAMPLE results", "Hotels in Lisbon", "(3 night(s)), 2 guest(s), 1 room(s)", "Sample Central Hotel (4 stars): 140.00 EUR per night, 420.00 EUR total"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Call() = %q; want it to contain %q", got, want)
+		}
+	}
+	if strings.Contains(got, "Grand Palace") {
+		t.Errorf("Call() = %q; want offers over budget left out", got)
+	}
+
+	if _, err := tool.Call(context.Background(), fmt.Sprintf(`{"city":"Lisbon","check_in":%q,"check_out":%q}`, checkOut, checkIn)); err == nil {
+		t.Error("expected an error for a check-out before check-in")
+	}
+	if (&hotelSearchTool{}).Available(context.Background()) {
+		t.Error("expected search_hotels to be unavailable without a provider")
+	}
+}
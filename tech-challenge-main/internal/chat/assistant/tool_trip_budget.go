@@ -0,0 +1,164 @@
+package assistant
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"strings"
+
+	"github.com/acai-travel/tech-challenge/internal/features"
+	"github.com/openai/openai-go/v2"
+)
+
+const maxBudgetNights = 60
+
+type tripBudgetTool struct {
+	rates *exchangeRates
+}
+
+func (t *tripBudgetTool) Name() string { return "estimate_trip_budget" }
+
+func (t *tripBudgetTool) Guidance() string {
+	return "Use **estimate_trip_budget** when the user asks what a trip will cost; ask for the number of nights if unknown, present the breakdown and total, and add travel to the destination separately when known."
+}
+
+func (t *tripBudgetTool) Feature() features.Flag { return features.TripBudget }
+
+func (t *tripBudgetTool) Definition() openai.FunctionDefinitionParam {
+	return openai.FunctionDefinitionParam{
+		Name:        t.Name(),
+		Description: openai.String("Estimates the cost of a stay in a city from a cost index of popular destinations: accommodation, food, local transport and activities, in the user's currency. It excludes travel to the destination. Use it when the user asks how much a trip will cost or what budget to plan."),
+		Parameters: openai.FunctionParameters{
+			"type": "object",
+			"properties": map[string]any{
+				"city": map[string]string{
+					"type":        "string",
+					"description": "Destination city, e.g. 'Lisbon'.",
+				},
+				"nights": map[string]any{
+					"type":        "integer",
+					"description": "Number of nights (1-60).",
+					"minimum":     1,
+					"maximum":     maxBudgetNights,
+				},
+				"travelers": map[string]any{
+					"type":        "integer",
+					"description": "Number of travellers (1-20). Defaults to 1.",
+					"minimum":     1,
+					"maximum":     20,
+				},
+				"rooms": map[string]any{
+					"type":        "integer",
+					"description": "Number of rooms. Defaults to one per two travellers.",
+					"minimum":     1,
+					"maximum":     20,
+				},
+				"style": map[string]any{
+					"type":        "string",
+					"description": "Travel style: 'budget' (hostels, street food, public transport), 'mid' (3-star hotels, restaurants) or 'luxury'. Defaults to mid.",
+					"enum":        []string{"budget", "mid", "luxury"},
+				},
+				"currency": map[string]string{
+					"type":        "string",
+					"description": "ISO 4217 code of the currency to estimate in, e.g. 'USD'. Defaults to EUR.",
+				},
+			},
+			"required": []string{"city", "nights"},
+		},
+	}
+}
+
+type tripBudgetArgs struct {
+	City      string `json:"city"`
+	Nights    int    `json:"nights"`
+	Travelers int    `json:"travelers,omitempty"`
+	Rooms     int    `json:"rooms,omitempty"`
+	Style     string `json:"style,omitempty"`
+	Currency  string `json:"currency,omitempty"`
+}
+
+func (t *tripBudgetTool) Call(ctx context.Context, args string) (string, error) {
+	var payload tripBudgetArgs
+	if err := decodeArgs(args, &payload); err != nil {
+		return "", err
+	}
+	if strings.TrimSpace(payload.City) == "" {
+		return "", errors.New(`missing argument "city"`)
+	}
+	if payload.Nights < 1 || payload.Nights > maxBudgetNights {
+		return "", fmt.Errorf(`invalid argument "nights": must be between 1 and %d`, maxBudgetNights)
+	}
+	travelers := max(payload.Travelers, 1)
+	rooms := payload.Rooms
+	if rooms < 1 {
+		rooms = (travelers + 1) / 2
+	}
+	style := payload.Style
+	if style == "" {
+		style = "mid"
+	}
+	factors, ok := budgetStyles[style]
+	if !ok {
+		return "", errors.New(`invalid argument "style": use budget, mid or luxury`)
+	}
+
+	costs, ok := lookupCityCosts(payload.City)
+	if !ok {
+		return "", fmt.Errorf("No cost data for %s. Tell the user you can't estimate a budget for it; do not make up figures.", payload.City)
+	}
+
+	currency := strings.ToUpper(strings.TrimSpace(payload.Currency))
+	if currency == "" {
+		currency = "EUR"
+	}
+	var note string
+	rate, date, ok, err := t.rates.FromEUR(ctx, currency)
+	switch {
+	case err != nil:
+		slog.WarnContext(ctx, "Failed to get exchange rates", "error", err)
+		note = fmt.Sprintf("Exchange rates are unavailable, so amounts are in EUR instead of %s: say so.\n", currency)
+		currency, rate = "EUR", 1
+	case !ok:
+		note = fmt.Sprintf("There is no exchange rate for %q, so amounts are in EUR: say so.\n", currency)
+		currency, rate = "EUR", 1
+	}
+
+	days := payload.Nights + 1
+	lines := []struct {
+		label string
+		units string
+		n     int
+		each  float64
+	}{
+		{"Accommodation", fmt.Sprintf("%d room(s) × %d night(s)", rooms, payload.Nights), rooms * payload.Nights, costs.Lodging * factors[0]},
+		{"Food", fmt.Sprintf("%d traveller(s) × %d day(s)", travelers, days), travelers * days, costs.Food * factors[1]},
+		{"Local transport", fmt.Sprintf("%d traveller(s) × %d day(s)", travelers, days), travelers * days, costs.Transport * factors[2]},
+		{"Activities", fmt.Sprintf("%d traveller(s) × %d day(s)", travelers, days), travelers * days, costs.Activities * factors[3]},
+	}
+
+	var sb strings.Builder
+	sb.WriteString(note)
+	sb.WriteString(fmt.Sprintf("Estimated %s budget for %d night(s) in %s, %s for %d traveller(s), in %s:\n",
+		style, payload.Nights, costs.City, costs.Country, travelers, currency))
+	var total float64
+	for _, l := range lines {
+		each := roundMoney(l.each * rate)
+		total += each * float64(l.n)
+		sb.WriteString(fmt.Sprintf("- %s: %s × %s = %s\n", l.label, l.units, formatMoney(each, currency), formatMoney(each*float64(l.n), currency)))
+	}
+	sb.WriteString(fmt.Sprintf("Total: %s, about %s per person per day.\n", formatMoney(total, currency), formatMoney(total/float64(travelers*days), currency)))
+	if currency != "EUR" {
+		sb.WriteString(fmt.Sprintf("Converted from EUR at the ECB rate of %s: 1 EUR = %.4f %s.\n", date, rate, currency))
+	}
+	sb.WriteString("These are averages for the city and exclude travel to it: present them as an estimate.\n")
+	return sb.String(), nil
+}
+
+// roundMoney rounds an amount to a whole unit, as estimates don't need cents.
+func roundMoney(v float64) float64 { return math.Round(v) }
+
+func formatMoney(v float64, currency string) string {
+	return fmt.Sprintf("%.0f %s", v, currency)
+}
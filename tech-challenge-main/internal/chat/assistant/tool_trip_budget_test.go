@@ -0,0 +1,63 @@
+package assistant
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTripBudgetTool(t *testing.T) {
+	var calls int
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if r.URL.Query().Get("from") != "EUR" {
+			t.Errorf("unexpected query %q", r.URL.RawQuery)
+		}
+		fmt.Fprint(w, `{"amount":1.0,"base":"EUR","date":"2026-10-13","rates":{"USD":1.1,"GBP":0.85}}`)
+	}))
+	defer api.Close()
+
+	tool := &tripBudgetTool{rates: &exchangeRates{url: api.URL, client: &http.Client{Timeout: time.Second}}}
+
+	got, err := tool.Call(context.Background(), `{"city":"lisbon","nights":3,"travelers":2}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"Estimated mid budget for 3 night(s) in Lisbon, Portugal for 2 traveller(s), in EUR:",
+		"- Accommodation: 1 room(s) × 3 night(s) × 105 EUR = 315 EUR",
+		"- Food: 2 traveller(s) × 4 day(s) × 35 EUR = 280 EUR",
+		"Total: 763 EUR, about 95 EUR per person per day.",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Call() = %q; want it to contain %q", got, want)
+		}
+	}
+	if calls != 0 {
+		t.Errorf("expected no exchange rate requests for EUR, got %d", calls)
+	}
+
+	got, err = tool.Call(context.Background(), `{"city":"Krakow","nights":2,"style":"luxury","currency":"usd"}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"luxury budget for 2 night(s) in Kraków, Poland", "in USD:", "1 EUR = 1.1000 USD"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Call() = %q; want it to contain %q", got, want)
+		}
+	}
+
+	if got, _ := tool.Call(context.Background(), `{"city":"Paris","nights":1,"currency":"XYZ"}`); !strings.Contains(got, `no exchange rate for "XYZ"`) {
+		t.Errorf("Call() = %q; want a note about the unknown currency", got)
+	}
+	if _, err := tool.Call(context.Background(), `{"city":"Atlantis","nights":2}`); err == nil || !strings.Contains(err.Error(), "No cost data") {
+		t.Errorf("expected an unknown city error, got %v", err)
+	}
+	if _, err := tool.Call(context.Background(), `{"city":"Paris","nights":0}`); err == nil {
+		t.Error("expected an error for 0 nights")
+	}
+}
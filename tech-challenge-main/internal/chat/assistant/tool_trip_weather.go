@@ -0,0 +1,206 @@
+package assistant
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/acai-travel/tech-challenge/internal/features"
+	"github.com/openai/openai-go/v2"
+)
+
+// maxTripLegs bounds the forecasts fetched by one get_trip_weather call.
+const maxTripLegs = 10
+
+type tripWeatherTool struct {
+	service *WeatherService
+}
+
+func (t *tripWeatherTool) Name() string { return "get_trip_weather" }
+
+func (t *tripWeatherTool) Guidance() string {
+	return "For a trip with several stops, call **get_trip_weather** once with every leg (city and dates, in order) instead of get_weather per city, and summarize the weather leg by leg."
+}
+
+func (t *tripWeatherTool) Feature() features.Flag { return features.Weather }
+
+func (t *tripWeatherTool) Definition() openai.FunctionDefinitionParam {
+	return openai.FunctionDefinitionParam{
+		Name:        t.Name(),
+		Description: openai.String("Use this function for the weather of a trip with several stops: it returns the daily forecast of each leg, in order, in one call. Pass every leg of the itinerary at once instead of calling get_weather per city. Forecasts reach 14 days ahead; later days are reported as unavailable."),
+		Parameters: openai.FunctionParameters{
+			"type": "object",
+			"properties": map[string]any{
+				"legs": map[string]any{
+					"type":        "array",
+					"description": "The stops of the trip in itinerary order (1-10).",
+					"minItems":    1,
+					"maxItems":    maxTripLegs,
+					"items": map[string]any{
+						"type": "object",
+						"properties": map[string]any{
+							"city": map[string]string{
+								"type":        "string",
+								"description": "City name or location query, e.g. 'Porto' or 'Seville,Spain', or a place the user saved, e.g. 'home'.",
+							},
+							"start_date": map[string]string{
+								"type":        "string",
+								"format":      "date-time",
+								"description": "First day in the city, in RFC3339 format.",
+							},
+							"end_date": map[string]string{
+								"type":        "string",
+								"format":      "date-time",
+								"description": "Optional last day in the city, in RFC3339 format. Defaults to start_date.",
+							},
+						},
+						"required": []string{"city", "start_date"},
+					},
+				},
+			},
+			"required": []string{"legs"},
+		},
+	}
+}
+
+type tripLeg struct {
+	City      string    `json:"city"`
+	StartDate time.Time `json:"start_date"`
+	EndDate   time.Time `json:"end_date,omitempty"`
+}
+
+type tripWeatherArgs struct {
+	Legs []tripLeg `json:"legs"`
+}
+
+func parseTripWeatherArgs(args string) (tripWeatherArgs, error) {
+	var payload tripWeatherArgs
+	if err := decodeArgs(args, &payload); err != nil {
+		return payload, err
+	}
+	if len(payload.Legs) == 0 {
+		return payload, errors.New(`missing argument "legs": at least one leg is required`)
+	}
+	if len(payload.Legs) > maxTripLegs {
+		return payload, fmt.Errorf(`invalid argument "legs": at most %d legs are supported, split the trip`, maxTripLegs)
+	}
+	for i := range payload.Legs {
+		leg := &payload.Legs[i]
+		leg.City = strings.TrimSpace(leg.City)
+		if leg.City == "" {
+			return payload, fmt.Errorf(`missing argument "legs[%d].city": a city name or coordinates is required`, i)
+		}
+		if leg.StartDate.IsZero() {
+			return payload, fmt.Errorf(`missing argument "legs[%d].start_date"`, i)
+		}
+		leg.StartDate = day(leg.StartDate)
+		leg.EndDate = day(leg.EndDate)
+		if leg.EndDate.IsZero() {
+			leg.EndDate = leg.StartDate
+		}
+		if leg.EndDate.Before(leg.StartDate) {
+			return payload, fmt.Errorf(`invalid argument "legs[%d].end_date": it is earlier than start_date`, i)
+		}
+	}
+	return payload, nil
+}
+
+func (t *tripWeatherTool) DescribeCall(args string) string {
+	payload, err := parseTripWeatherArgs(args)
+	if err != nil {
+		return ""
+	}
+	cities := make([]string, 0, len(payload.Legs))
+	for _, leg := range payload.Legs {
+		cities = append(cities, leg.City)
+	}
+	return fmt.Sprintf("calling %s(%s)", t.Name(), strings.Join(cities, " → "))
+}
+
+func (t *tripWeatherTool) DescribeResult() string { return "trip weather received" }
+
+func (t *tripWeatherTool) Call(ctx context.Context, args string) (string, error) {
+	payload, err := parseTripWeatherArgs(args)
+	if err != nil {
+		return "", err
+	}
+
+	if t.service == nil {
+		return "", errors.New("Weather service is not configured. Please set WEATHER_API_KEY environment variable.")
+	}
+
+	// Legs are independent, so their forecasts are fetched together. A failed leg is
+	// reported in its place rather than failing the whole trip.
+	today := day(time.Now())
+	horizon := min(maxForecastDays, features.FromContext(ctx).Int(features.MaxForecastDays))
+	results := make([]string, len(payload.Legs))
+	var wg sync.WaitGroup
+	for i, leg := range payload.Legs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = t.legWeather(ctx, leg, today, horizon)
+		}()
+	}
+	wg.Wait()
+
+	var sb strings.Builder
+	sb.WriteString("Trip weather:\n")
+	for i, leg := range payload.Legs {
+		sb.WriteString(fmt.Sprintf("%d. %s, %s to %s\n", i+1, leg.City, formatDay(leg.StartDate), formatDay(leg.EndDate)))
+		sb.WriteString(results[i])
+	}
+	return sb.String(), nil
+}
+
+// legWeather returns the indented forecast lines of one leg. Days beyond the horizon, the
+// number of forecast days from today, are noted as unavailable.
+func (t *tripWeatherTool) legWeather(ctx context.Context, leg tripLeg, today time.Time, horizon int) string {
+	last := today.AddDate(0, 0, horizon-1)
+	if leg.StartDate.After(last) {
+		return fmt.Sprintf("    No forecast yet: forecasts reach %s.\n", formatDay(last))
+	}
+	if leg.EndDate.Before(today) {
+		return "    No forecast: these dates are in the past.\n"
+	}
+
+	query, note, err := resolveLocation(ctx, leg.City)
+	if err != nil {
+		return "    No forecast: " + err.Error() + "\n"
+	}
+	days := int(minTime(leg.EndDate, last).Sub(today).Hours()/24) + 1
+	weather, err := t.service.Forecast(ctx, query, days)
+	if err != nil {
+		return "    No forecast: " + weatherToolError(leg.City, err).Error() + "\n"
+	}
+
+	var sb strings.Builder
+	if note != "" {
+		sb.WriteString("    " + strings.TrimSpace(note) + "\n")
+	}
+	if weather.Location.Name != "" {
+		sb.WriteString(fmt.Sprintf("    Location: %s, %s\n", weather.Location.Name, weather.Location.Country))
+	}
+	if weather.Stale {
+		sb.WriteString(fmt.Sprintf("    Note: live weather is temporarily unavailable; this forecast is %s old and may be outdated. Tell the user.\n", describeAge(time.Since(weather.FetchedAt))))
+	}
+	forecast := dailyForecast(weather)
+	for d := leg.StartDate; !d.After(leg.EndDate); d = d.AddDate(0, 0, 1) {
+		if d.Before(today) {
+			continue
+		}
+		if d.After(last) {
+			sb.WriteString(fmt.Sprintf("    %s onwards: no forecast yet.\n", formatDay(d)))
+			break
+		}
+		f, ok := forecast[d.Format(time.DateOnly)]
+		if !ok {
+			f = "no forecast available"
+		}
+		sb.WriteString(fmt.Sprintf("    %s: %s\n", formatDay(d), f))
+	}
+	return sb.String()
+}
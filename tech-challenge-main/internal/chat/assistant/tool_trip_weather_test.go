@@ -0,0 +1,103 @@
+package assistant
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestTripWeatherTool(t *testing.T) {
+	// Each call waits for the other legs' calls, so the test only passes if they run together.
+	const legs = 3
+	var (
+		mu      sync.Mutex
+		arrived int
+		all     = make(chan struct{})
+	)
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		if arrived++; arrived == legs {
+			close(all)
+		}
+		mu.Unlock()
+		select {
+		case <-all:
+		case <-time.After(2 * time.Second):
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		q := r.URL.Query().Get("q")
+		if q == "Atlantis" {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"error":{"code":1006,"message":"No matching location found."}}`)
+			return
+		}
+		days, _ := strconv.Atoi(r.URL.Query().Get("days"))
+		var forecast []string
+		for i := range days {
+			date := day(time.Now()).AddDate(0, 0, i).Format(time.DateOnly)
+			forecast = append(forecast, fmt.Sprintf(`{"date":%q,"day":{"mintemp_c":%d,"maxtemp_c":%d,"condition":{"text":"Sunny in %s"}}}`, date, 10+i, 20+i, q))
+		}
+		fmt.Fprintf(w, `{"location":{"name":%q,"country":"Portugal"},"forecast":{"forecastday":[%s]}}`, q, strings.Join(forecast, ","))
+	}))
+	defer api.Close()
+
+	service := NewWeatherService(t.Name())
+	service.baseURL = api.URL
+	tool := &tripWeatherTool{service: service}
+
+	date := func(offset int) string { return day(time.Now()).AddDate(0, 0, offset).Format(time.RFC3339) }
+	args := fmt.Sprintf(`{"legs":[{"city":"Lisbon","start_date":%q,"end_date":%q},{"city":"Porto","start_date":%q},{"city":"Atlantis","start_date":%q},{"city":"Faro","start_date":%q}]}`,
+		date(0), date(1), date(2), date(3), date(30))
+
+	got, err := tool.Call(context.Background(), args)
+	if err != nil {
+		t.Fatalf("Call error: %v", err)
+	}
+
+	formatted := func(offset int) string { return formatDay(day(time.Now()).AddDate(0, 0, offset)) }
+	want := []string{
+		"1. Lisbon, " + formatted(0) + " to " + formatted(1),
+		"    " + formatted(0) + ": Sunny in Lisbon, 10–20°C",
+		"    " + formatted(1) + ": Sunny in Lisbon, 11–21°C",
+		"2. Porto, " + formatted(2) + " to " + formatted(2),
+		"    " + formatted(2) + ": Sunny in Porto, 12–22°C",
+		"3. Atlantis",
+		`    No forecast: No location matches "Atlantis"`,
+		"4. Faro",
+		"    No forecast yet: forecasts reach",
+	}
+	last := -1
+	for _, w := range want {
+		i := strings.Index(got, w)
+		if i <= last {
+			t.Fatalf("expected %q after the previous lines in:\n%s", w, got)
+		}
+		last = i
+	}
+}
+
+func TestParseTripWeatherArgs(t *testing.T) {
+	if _, err := parseTripWeatherArgs(`{"legs":[]}`); err == nil {
+		t.Error("expected an error without legs")
+	}
+	if _, err := parseTripWeatherArgs(`{"legs":[{"city":"Lisbon","start_date":"2026-10-20T00:00:00Z","end_date":"2026-10-18T00:00:00Z"}]}`); err == nil {
+		t.Error("expected an error for an end date before the start date")
+	}
+
+	got, err := parseTripWeatherArgs(`{"legs":[{"city":" Lisbon ","start_date":"2026-10-20T15:00:00+02:00"}]}`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	leg := got.Legs[0]
+	if want := time.Date(2026, 10, 20, 0, 0, 0, 0, time.UTC); leg.City != "Lisbon" || !leg.StartDate.Equal(want) || !leg.EndDate.Equal(want) {
+		t.Errorf("unexpected leg: %+v", leg)
+	}
+}
@@ -0,0 +1,116 @@
+package assistant
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/acai-travel/tech-challenge/internal/features"
+	"github.com/hashicorp/golang-lru/v2/expirable"
+	"github.com/openai/openai-go/v2"
+)
+
+type visaTool struct {
+	provider VisaProvider
+}
+
+func (t *visaTool) Name() string { return "check_visa_requirements" }
+
+func (t *visaTool) Guidance() string {
+	return "Use **check_visa_requirements** when the user asks whether they need a visa; ask which passport they hold if you don't know it."
+}
+
+func (t *visaTool) Feature() features.Flag { return features.VisaRequirements }
+
+func (t *visaTool) Definition() openai.FunctionDefinitionParam {
+	return openai.FunctionDefinitionParam{
+		Name:        t.Name(),
+		Description: openai.String("Use this function when users ask whether they need a visa to visit a country. Given the country that issued the passport and the destination, it returns whether the trip is visa-free, needs a visa on arrival, an eVisa or electronic authorization, or a visa, and the longest visa-free stay when known. Do NOT answer visa questions from training data."),
+		Parameters: openai.FunctionParameters{
+			"type": "object",
+			"properties": map[string]any{
+				"passport_country": map[string]string{
+					"type":        "string",
+					"description": "Country that issued the traveller's passport, in English, e.g. 'Spain'. Ask the user if unknown; don't assume it from their location.",
+				},
+				"destination_country": map[string]string{
+					"type":        "string",
+					"description": "Country to visit, in English, e.g. 'Japan'.",
+				},
+			},
+			"required": []string{"passport_country", "destination_country"},
+		},
+	}
+}
+
+type visaArgs struct {
+	Passport    string `json:"passport_country"`
+	Destination string `json:"destination_country"`
+}
+
+func (t *visaTool) Call(ctx context.Context, args string) (string, error) {
+	var payload visaArgs
+	if err := decodeArgs(args, &payload); err != nil {
+		return "", err
+	}
+	payload.Passport, payload.Destination = strings.TrimSpace(payload.Passport), strings.TrimSpace(payload.Destination)
+	if payload.Passport == "" || payload.Destination == "" {
+		return "", errors.New(`missing argument: "passport_country" and "destination_country" are required`)
+	}
+
+	req, err := t.provider.VisaRequirement(ctx, payload.Passport, payload.Destination)
+	if errors.Is(err, ErrUnknownCountry) {
+		return "", fmt.Errorf("No visa data for this trip (%v). Check the country names, or ask the user which country they mean.", err)
+	}
+	if err != nil {
+		return "", fmt.Errorf("Visa requirements are temporarily unavailable (%v). Tell the user to check the destination's embassy or official immigration website; do not guess.", err)
+	}
+
+	var s string
+	trip := fmt.Sprintf("With a passport from %s, visiting %s", req.Passport, req.Destination)
+	switch req.Kind {
+	case SameCountry:
+		s = fmt.Sprintf("A passport from %s is the traveller's own country: no visa is needed.", req.Destination)
+	case VisaFree:
+		s = trip + " is visa-free"
+		if req.MaxStayDays > 0 {
+			s += fmt.Sprintf(" for up to %d days", req.MaxStayDays)
+		}
+		s += "."
+	case NoAdmission:
+		s = trip + " is currently not possible: the destination does not admit these passport holders."
+	case VisaRequired:
+		s = trip + " requires a visa, applied for in advance at an embassy or consulate."
+	case VisaOnArrival:
+		s = trip + " requires a visa on arrival, obtained at the border."
+	case VisaElectronic:
+		s = trip + " requires an eVisa or electronic travel authorization, applied for online before departure."
+	default:
+		s = fmt.Sprintf("%s: %s.", trip, req.Kind)
+	}
+	return s + "\nRules change and depend on the purpose of the trip: tell the user to confirm with the destination's embassy or official immigration website before travelling.\n", nil
+}
+
+// cachedVisaProvider caches the requirements of a provider per passport and destination.
+type cachedVisaProvider struct {
+	provider VisaProvider
+	cache    *expirable.LRU[[2]string, *VisaRequirement]
+}
+
+func newCachedVisaProvider(p VisaProvider) *cachedVisaProvider {
+	return &cachedVisaProvider{provider: p, cache: expirable.NewLRU[[2]string, *VisaRequirement](1024, nil, visaDatasetTTL)}
+}
+
+func (c *cachedVisaProvider) VisaRequirement(ctx context.Context, passport, destination string) (*VisaRequirement, error) {
+	key := [2]string{countryKey(passport), countryKey(destination)}
+	if req, ok := c.cache.Get(key); ok {
+		return req, nil
+	}
+	req, err := c.provider.VisaRequirement(ctx, passport, destination)
+	if err != nil {
+		return nil, err
+	}
+	c.cache.Add(key, req)
+	return req, nil
+}
@@ -0,0 +1,77 @@
+package assistant
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestVisaTool(t *testing.T) {
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "Passport,Destination,Requirement\n"+
+			"Spain,Japan,90\n"+
+			"Spain,India,e-visa\n"+
+			"Spain,Spain,-1\n"+
+			"United Kingdom,Cambodia,visa on arrival\n"+
+			"United States,Russia,visa required\n")
+	}))
+	defer api.Close()
+
+	tool := &visaTool{provider: &visaDataset{url: api.URL, client: &http.Client{Timeout: time.Second}}}
+	cases := []struct {
+		passport, destination string
+		want                  string
+	}{
+		{"Spain", "Japan", "With a passport from Spain, visiting Japan is visa-free for up to 90 days."},
+		{"spain", "India", "requires an eVisa or electronic travel authorization"},
+		{"Spain", "spain", "no visa is needed"},
+		{"the UK", "Cambodia", "With a passport from United Kingdom, visiting Cambodia requires a visa on arrival"},
+		{"USA", "Russia", "requires a visa, applied for in advance"},
+	}
+	for _, tc := range cases {
+		got, err := tool.Call(context.Background(), fmt.Sprintf(`{"passport_country":%q,"destination_country":%q}`, tc.passport, tc.destination))
+		if err != nil || !strings.Contains(got, tc.want) {
+			t.Errorf("Call(%s, %s) = %q, %v; want it to contain %q", tc.passport, tc.destination, got, err, tc.want)
+		}
+	}
+
+	if _, err := tool.Call(context.Background(), `{"passport_country":"Spain","destination_country":"Atlantis"}`); err == nil || !strings.Contains(err.Error(), "No visa data") {
+		t.Errorf("expected an unknown country error, got %v", err)
+	}
+}
+
+type countingVisaProvider struct{ calls atomic.Int32 }
+
+func (p *countingVisaProvider) VisaRequirement(_ context.Context, passport, destination string) (*VisaRequirement, error) {
+	p.calls.Add(1)
+	if destination == "Atlantis" {
+		return nil, ErrUnknownCountry
+	}
+	return &VisaRequirement{Passport: passport, Destination: destination, Kind: VisaFree}, nil
+}
+
+func TestCachedVisaProvider(t *testing.T) {
+	p := &countingVisaProvider{}
+	cached := newCachedVisaProvider(p)
+	ctx := context.Background()
+
+	for _, passport := range []string{"Spain", "spain", " Spain "} {
+		if _, err := cached.VisaRequirement(ctx, passport, "Japan"); err != nil {
+			t.Fatalf("VisaRequirement error: %v", err)
+		}
+	}
+	for range 2 {
+		if _, err := cached.VisaRequirement(ctx, "Spain", "Atlantis"); !errors.Is(err, ErrUnknownCountry) {
+			t.Fatalf("expected ErrUnknownCountry, got %v", err)
+		}
+	}
+	if n := p.calls.Load(); n != 3 {
+		t.Errorf("expected 1 call for Japan and 2 uncached failures, got %d", n)
+	}
+}
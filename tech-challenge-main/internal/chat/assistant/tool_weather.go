@@ -0,0 +1,116 @@
+package assistant
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/acai-travel/tech-challenge/internal/features"
+	"github.com/openai/openai-go/v2"
+)
+
+type weatherTool struct {
+	service *WeatherService
+}
+
+func (t *weatherTool) Name() string { return "get_weather" }
+
+func (t *weatherTool) Guidance() string {
+	return `Always call **get_weather** for weather/temperature/forecast/climate questions. Never invent weather. Its args:
+   • **location**: extract from the user message (city, "City,Country", or "lat,lon").
+   • **forecast_days**:
+     – If the user asks for a specific **weekday or date** (e.g., "Friday", "Sep 5"), first call **compute_date** (e.g., offset "Friday", or base_date "2025-09-05" with offset "today") to get how many days it is from today, then set **forecast_days = days + 1** (clamp 1–10). After receiving data, answer **only for that target day** (not the whole range).
+     – Otherwise, default to a **short forecast** (1–3 days). Do NOT request 7+ days unless explicitly asked.
+   • If the location is missing or ambiguous, ask one brief clarifying question.`
+}
+
+func (t *weatherTool) Feature() features.Flag { return features.Weather }
+
+func (t *weatherTool) Definition() openai.FunctionDefinitionParam {
+	return openai.FunctionDefinitionParam{
+		Name:        t.Name(),
+		Description: openai.String("ALWAYS use this function when users ask about weather, temperature, forecast, or climate conditions. Do NOT generate weather information from training data. This function provides real-time weather data from WeatherAPI."),
+		Parameters:  paramsOf(weatherArgs{}),
+	}
+}
+
+type weatherArgs struct {
+	Location     string `json:"location" validate:"required" desc:"City name, coordinates, or location query (e.g., 'Barcelona', 'London,UK', '40.7128,-74.0060'), or a place the user saved, e.g. 'home' or 'the office'"`
+	ForecastDays *int   `json:"forecast_days,omitempty" validate:"min=1,max=14" desc:"Number of forecast days (1-14). If not provided, returns only current weather."`
+}
+
+func parseWeatherArgs(args string) (weatherArgs, error) {
+	var payload weatherArgs
+	if err := decodeArgs(args, &payload); err != nil {
+		return payload, err
+	}
+	if strings.TrimSpace(payload.Location) == "" {
+		return payload, errors.New(`missing argument "location": a city name or coordinates is required`)
+	}
+	return payload, nil
+}
+
+func (t *weatherTool) DescribeCall(args string) string {
+	payload, err := parseWeatherArgs(args)
+	if err != nil {
+		return ""
+	}
+	if payload.ForecastDays == nil {
+		return fmt.Sprintf("calling %s(%s)", t.Name(), payload.Location)
+	}
+	return fmt.Sprintf("calling %s(%s, %d days)", t.Name(), payload.Location, *payload.ForecastDays)
+}
+
+// CacheKey shares the weather of a place for 10 minutes, except that of the user's saved
+// places, which the result names.
+func (t *weatherTool) CacheKey(ctx context.Context, args string) (string, time.Duration, bool) {
+	payload, err := parseWeatherArgs(args)
+	if err != nil || t.service == nil {
+		return "", 0, false
+	}
+	if _, note, err := resolveLocation(ctx, payload.Location); err != nil || note != "" {
+		return "", 0, false
+	}
+
+	days := 0
+	if payload.ForecastDays != nil && *payload.ForecastDays > 0 {
+		days = min(*payload.ForecastDays, features.FromContext(ctx).Int(features.MaxForecastDays))
+	}
+	return strings.ToLower(strings.TrimSpace(payload.Location)) + "|" + strconv.Itoa(days), 10 * time.Minute, true
+}
+
+func (t *weatherTool) DescribeResult() string { return "weather received" }
+
+func (t *weatherTool) Call(ctx context.Context, args string) (string, error) {
+	payload, err := parseWeatherArgs(args)
+	if err != nil {
+		return "", err
+	}
+
+	if t.service == nil {
+		return "", errors.New("Weather service is not configured. Please set WEATHER_API_KEY environment variable.")
+	}
+
+	query, note, err := resolveLocation(ctx, payload.Location)
+	if err != nil {
+		return "", err
+	}
+
+	var weatherInfo string
+
+	if payload.ForecastDays != nil && *payload.ForecastDays > 0 {
+		days := min(*payload.ForecastDays, features.FromContext(ctx).Int(features.MaxForecastDays))
+		weatherInfo, err = t.service.GetForecast(ctx, query, days)
+	} else {
+		weatherInfo, err = t.service.GetCurrentWeather(ctx, query)
+	}
+
+	if err != nil {
+		return "", weatherToolError(payload.Location, err)
+	}
+
+	return note + weatherInfo, nil
+}
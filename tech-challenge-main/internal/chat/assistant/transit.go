@@ -0,0 +1,298 @@
+package assistant
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/acai-travel/tech-challenge/internal/httpx"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// ErrTransitUnsupported means no transit provider covers the city.
+var ErrTransitUnsupported = errors.New("transit status not supported for this city")
+
+// TransitProvider reports public transport disruptions for the get_transit_status tool.
+// The default reads the GTFS-Realtime service alerts feeds configured in TRANSIT_FEEDS;
+// providers for city APIs can be set with SetTransitProvider.
+type TransitProvider interface {
+	// TransitStatus returns the current disruptions in city, or an error wrapping
+	// ErrTransitUnsupported if the provider does not cover it.
+	TransitStatus(ctx context.Context, city string) (*TransitStatus, error)
+	// Cities lists the cities covered, for messages about unsupported ones.
+	Cities() []string
+}
+
+// TransitStatus lists the disruptions of the public transport of a city.
+type TransitStatus struct {
+	City      string
+	Alerts    []TransitAlert
+	FetchedAt time.Time
+}
+
+// TransitAlert is a disruption reported by an operator.
+type TransitAlert struct {
+	// Lines are the affected routes, e.g. "L1" or "Central"; empty for the whole network.
+	Lines []string
+	// Effect is what riders should expect, e.g. "reduced service" or "detour".
+	Effect      string
+	Header      string
+	Description string
+	// Start and End bound when the alert applies; zero when open-ended.
+	Start, End time.Time
+
+	// periods are all the periods an alert of a feed applies in.
+	periods [][2]time.Time
+}
+
+// transitFeeds serves the GTFS-Realtime alerts feed of each configured city.
+type transitFeeds map[string]*gtfsAlertsFeed
+
+// loadTransitFeeds reads TRANSIT_FEEDS, a comma-separated list of city=URL pairs, e.g.
+// "Boston=https://cdn.mbta.com/realtime/Alerts.pb". It returns nil when none are set.
+func loadTransitFeeds() transitFeeds {
+	var feeds transitFeeds
+	for _, entry := range splitList(os.Getenv("TRANSIT_FEEDS")) {
+		city, url, ok := strings.Cut(entry, "=")
+		city, url = strings.TrimSpace(city), strings.TrimSpace(url)
+		if !ok || city == "" || url == "" {
+			slog.Warn("Ignoring invalid TRANSIT_FEEDS entry, expected city=URL", "entry", entry)
+			continue
+		}
+		if feeds == nil {
+			feeds = transitFeeds{}
+		}
+		feeds[normalizeCity(city)] = &gtfsAlertsFeed{city: city, url: url, client: httpx.Client(10 * time.Second)}
+	}
+	return feeds
+}
+
+func (f transitFeeds) TransitStatus(ctx context.Context, city string) (*TransitStatus, error) {
+	feed, ok := f[normalizeCity(city)]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrTransitUnsupported, city)
+	}
+	return feed.status(ctx)
+}
+
+func (f transitFeeds) Cities() []string {
+	out := make([]string, 0, len(f))
+	for _, feed := range f {
+		out = append(out, feed.city)
+	}
+	slices.Sort(out)
+	return out
+}
+
+// normalizeCity matches city names ignoring case, spacing and a country after a comma, so
+// "madrid" and "Madrid, Spain" name the same city.
+func normalizeCity(city string) string {
+	city, _, _ = strings.Cut(city, ",")
+	return strings.Join(strings.Fields(strings.ToLower(city)), " ")
+}
+
+// gtfsFreshFor is how long a fetched feed is served before fetching it again; operators
+// typically update alerts feeds every 30 to 60 seconds.
+const gtfsFreshFor = time.Minute
+
+// gtfsAlertsFeed is a GTFS-Realtime feed of service alerts, fetched at most once per
+// gtfsFreshFor.
+type gtfsAlertsFeed struct {
+	city   string
+	url    string
+	client *http.Client
+
+	mu   sync.Mutex
+	last *TransitStatus
+}
+
+func (f *gtfsAlertsFeed) status(ctx context.Context) (*TransitStatus, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	now := time.Now()
+	if f.last != nil && now.Sub(f.last.FetchedAt) < gtfsFreshFor {
+		return f.last, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, f.url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch the transit feed of %s: %w", f.city, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("transit feed of %s returned status %d", f.city, resp.StatusCode)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 16<<20))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the transit feed of %s: %w", f.city, err)
+	}
+
+	alerts, err := parseGTFSAlerts(body)
+	if err != nil {
+		return nil, fmt.Errorf("invalid transit feed of %s: %w", f.city, err)
+	}
+	f.last = &TransitStatus{City: f.city, Alerts: activeAlerts(alerts, now), FetchedAt: now}
+	return f.last, nil
+}
+
+// activeAlerts keeps the alerts that apply at now, with Start and End set to the period
+// they apply in. Alerts without periods always apply.
+func activeAlerts(alerts []TransitAlert, now time.Time) []TransitAlert {
+	var out []TransitAlert
+	for _, a := range alerts {
+		if len(a.periods) == 0 {
+			out = append(out, a)
+			continue
+		}
+		for _, p := range a.periods {
+			if (p[0].IsZero() || !now.Before(p[0])) && (p[1].IsZero() || !now.After(p[1])) {
+				a.Start, a.End = p[0], p[1]
+				out = append(out, a)
+				break
+			}
+		}
+	}
+	return out
+}
+
+// gtfsEffects names the values of the GTFS-Realtime Alert.Effect enum.
+var gtfsEffects = map[uint64]string{
+	1: "no service", 2: "reduced service", 3: "significant delays", 4: "detour",
+	5: "additional service", 6: "modified service", 7: "other effect", 9: "stop moved",
+	10: "no effect", 11: "accessibility issue",
+}
+
+// parseGTFSAlerts decodes the service alerts of a GTFS-Realtime FeedMessage. Only the fields
+// the tool reports are read, directly from the wire format, so the feed's other entities
+// and extensions are skipped.
+func parseGTFSAlerts(b []byte) ([]TransitAlert, error) {
+	var alerts []TransitAlert
+	err := eachField(b, func(num protowire.Number, v []byte) error {
+		if num != 2 { // FeedMessage.entity
+			return nil
+		}
+		return eachField(v, func(num protowire.Number, v []byte) error {
+			if num != 5 { // FeedEntity.alert
+				return nil
+			}
+			a, err := parseGTFSAlert(v)
+			if err == nil {
+				alerts = append(alerts, a)
+			}
+			return err
+		})
+	})
+	return alerts, err
+}
+
+func parseGTFSAlert(b []byte) (TransitAlert, error) {
+	var a TransitAlert
+	err := eachField(b, func(num protowire.Number, v []byte) error {
+		switch num {
+		case 1: // active_period
+			var period [2]time.Time
+			err := eachField(v, func(num protowire.Number, v []byte) error {
+				if n, _ := protowire.ConsumeVarint(v); n > 0 && (num == 1 || num == 2) {
+					period[num-1] = time.Unix(int64(n), 0)
+				}
+				return nil
+			})
+			a.periods = append(a.periods, period)
+			return err
+		case 5: // informed_entity
+			return eachField(v, func(num protowire.Number, v []byte) error {
+				if num == 2 && !slices.Contains(a.Lines, string(v)) { // route_id
+					a.Lines = append(a.Lines, string(v))
+				}
+				return nil
+			})
+		case 7: // effect
+			n, _ := protowire.ConsumeVarint(v)
+			a.Effect = gtfsEffects[n]
+		case 10: // header_text
+			a.Header = translatedText(v)
+		case 11: // description_text
+			a.Description = translatedText(v)
+		}
+		return nil
+	})
+	return a, err
+}
+
+// translatedText returns the English translation of a TranslatedString, or its first.
+func translatedText(b []byte) string {
+	var first, english string
+	_ = eachField(b, func(num protowire.Number, v []byte) error {
+		if num != 1 {
+			return nil
+		}
+		var text, lang string
+		_ = eachField(v, func(num protowire.Number, v []byte) error {
+			switch num {
+			case 1:
+				text = string(v)
+			case 2:
+				lang = string(v)
+			}
+			return nil
+		})
+		if first == "" {
+			first = text
+		}
+		if english == "" && (lang == "" || strings.HasPrefix(strings.ToLower(lang), "en")) {
+			english = text
+		}
+		return nil
+	})
+	return strings.TrimSpace(cmp.Or(english, first))
+}
+
+// eachField calls fn with each field of a protobuf message: the bytes of length-delimited
+// fields, or the encoded varint of varint fields. Other wire types are skipped.
+func eachField(b []byte, fn func(num protowire.Number, v []byte) error) error {
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		b = b[n:]
+
+		var v []byte
+		switch typ {
+		case protowire.BytesType:
+			v, n = protowire.ConsumeBytes(b)
+		case protowire.VarintType:
+			_, n = protowire.ConsumeVarint(b)
+			if n >= 0 {
+				v = b[:n]
+			}
+		default:
+			n = protowire.ConsumeFieldValue(num, typ, b)
+		}
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		b = b[n:]
+
+		if v != nil {
+			if err := fn(num, v); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,143 @@
+package assistant
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// gtfsMessage encodes protobuf fields: []byte values as length-delimited, uint64 as varints.
+func gtfsMessage(fields ...any) []byte {
+	var b []byte
+	for i := 0; i < len(fields); i += 2 {
+		num := protowire.Number(fields[i].(int))
+		switch v := fields[i+1].(type) {
+		case []byte:
+			b = protowire.AppendTag(b, num, protowire.BytesType)
+			b = protowire.AppendBytes(b, v)
+		case string:
+			b = protowire.AppendTag(b, num, protowire.BytesType)
+			b = protowire.AppendString(b, v)
+		case uint64:
+			b = protowire.AppendTag(b, num, protowire.VarintType)
+			b = protowire.AppendVarint(b, v)
+		}
+	}
+	return b
+}
+
+func gtfsText(translations ...string) []byte {
+	var fields []any
+	for i := 0; i < len(translations); i += 2 {
+		fields = append(fields, 1, gtfsMessage(1, translations[i], 2, translations[i+1]))
+	}
+	return gtfsMessage(fields...)
+}
+
+func gtfsPeriod(start, end time.Time) []byte {
+	return gtfsMessage(1, uint64(start.Unix()), 2, uint64(end.Unix()))
+}
+
+func TestGTFSAlertsFeed(t *testing.T) {
+	now := time.Now()
+	feed := gtfsMessage(
+		1, gtfsMessage(1, "2.0"), // header
+		2, gtfsMessage(1, "a1", 5, gtfsMessage(
+			1, gtfsPeriod(now.Add(-time.Hour), now.Add(time.Hour)),
+			5, gtfsMessage(1, "metro", 2, "L1"),
+			5, gtfsMessage(1, "metro", 2, "L3"),
+			7, uint64(2),
+			10, gtfsText("Servicio reducido", "es", "Reduced service", "en"),
+			11, gtfsText("Trains every 10 minutes.", "en"),
+		)),
+		2, gtfsMessage(1, "expired", 5, gtfsMessage(
+			1, gtfsPeriod(now.Add(-3*time.Hour), now.Add(-2*time.Hour)),
+			10, gtfsText("Old works", "en"),
+		)),
+		2, gtfsMessage(1, "recurring", 5, gtfsMessage(
+			1, gtfsPeriod(now.Add(-26*time.Hour), now.Add(-22*time.Hour)),
+			1, gtfsPeriod(now.Add(-2*time.Hour), now.Add(2*time.Hour)),
+			7, uint64(1),
+			10, gtfsText("Night closure", ""),
+		)),
+		2, gtfsMessage(1, "vehicle", 4, gtfsMessage(1, "ignored")),
+	)
+
+	var calls atomic.Int32
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		_, _ = w.Write(feed)
+	}))
+	defer api.Close()
+
+	t.Setenv("TRANSIT_FEEDS", "Madrid="+api.URL+", broken")
+	feeds := loadTransitFeeds()
+	if got := feeds.Cities(); len(got) != 1 || got[0] != "Madrid" {
+		t.Fatalf("expected only Madrid to be configured, got %v", got)
+	}
+
+	status, err := feeds.TransitStatus(context.Background(), "madrid, Spain")
+	if err != nil {
+		t.Fatalf("TransitStatus error: %v", err)
+	}
+	if len(status.Alerts) != 2 {
+		t.Fatalf("expected 2 active alerts, got %+v", status.Alerts)
+	}
+	a := status.Alerts[0]
+	if strings.Join(a.Lines, ",") != "L1,L3" || a.Effect != "reduced service" || a.Header != "Reduced service" || a.Description != "Trains every 10 minutes." {
+		t.Errorf("unexpected alert: %+v", a)
+	}
+	if b := status.Alerts[1]; b.Header != "Night closure" || b.End.Unix() != now.Add(2*time.Hour).Unix() {
+		t.Errorf("expected the active period of the recurring alert, got %+v", b)
+	}
+
+	if _, err := feeds.TransitStatus(context.Background(), "Madrid"); err != nil || calls.Load() != 1 {
+		t.Errorf("expected the feed to be served from the cache, got %d calls, %v", calls.Load(), err)
+	}
+}
+
+type fakeTransit struct{ status *TransitStatus }
+
+func (f fakeTransit) TransitStatus(_ context.Context, city string) (*TransitStatus, error) {
+	if normalizeCity(city) != "madrid" {
+		return nil, ErrTransitUnsupported
+	}
+	return f.status, nil
+}
+
+func (f fakeTransit) Cities() []string { return []string{"Madrid"} }
+
+func TestTransitTool(t *testing.T) {
+	tool := &transitTool{provider: fakeTransit{&TransitStatus{City: "Madrid", FetchedAt: time.Date(2026, 10, 14, 8, 30, 0, 0, time.UTC), Alerts: []TransitAlert{
+		{Lines: []string{"L1"}, Effect: "reduced service", Header: "Reduced service", Description: "Trains every 10 minutes."},
+		{Lines: []string{"L5"}, Effect: "no service", Header: "Closed for works"},
+		{Header: "Strike from 10:00"},
+	}}}}
+	ctx := context.Background()
+
+	got, err := tool.Call(ctx, `{"city":"Madrid","line":"l1"}`)
+	if err != nil {
+		t.Fatalf("Call error: %v", err)
+	}
+	want := "2 disruption(s) reported for Madrid, line l1 as of 08:30 UTC:\n" +
+		"- Lines L1, reduced service: Reduced service Trains every 10 minutes.\n" +
+		"- Whole network: Strike from 10:00\n"
+	if got != want {
+		t.Errorf("Call = %q, want %q", got, want)
+	}
+
+	got, err = tool.Call(ctx, `{"city":"Lyon"}`)
+	if err != nil || !strings.Contains(got, "not available for Lyon. Supported cities: Madrid.") {
+		t.Errorf("expected an unsupported city message, got %q, %v", got, err)
+	}
+
+	if (&transitTool{}).Available(ctx) {
+		t.Error("expected the tool to be unavailable without a provider")
+	}
+}
@@ -0,0 +1,147 @@
+package assistant
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+	"time"
+)
+
+// FlightSearchProvider finds flight offers for search_flights, e.g. from Amadeus or
+// Skyscanner. Set one with SetFlightSearchProvider; without it the tool isn't offered.
+type FlightSearchProvider interface {
+	SearchFlights(ctx context.Context, q FlightQuery) ([]FlightOffer, error)
+}
+
+// HotelSearchProvider finds hotel offers for search_hotels. Set one with
+// SetHotelSearchProvider; without it the tool isn't offered.
+type HotelSearchProvider interface {
+	SearchHotels(ctx context.Context, q HotelQuery) ([]HotelOffer, error)
+}
+
+// FlightQuery is a flight search.
+type FlightQuery struct {
+	// Origin and Destination are IATA airport or city codes, or city names the provider
+	// resolves, e.g. "BCN" or "Barcelona".
+	Origin, Destination string
+	DepartureDate       time.Time
+	// ReturnDate is zero for one-way trips.
+	ReturnDate time.Time
+	Adults     int
+	// Cabin is "economy", "premium_economy", "business" or "first".
+	Cabin      string
+	MaxResults int
+}
+
+// FlightOffer is a bookable itinerary.
+type FlightOffer struct {
+	Carrier string
+	// Flights are the flight numbers, in order, e.g. "VY 1234".
+	Flights   []string
+	Departure time.Time
+	Arrival   time.Time
+	Stops     int
+	// ReturnDeparture and ReturnArrival are set for return trips.
+	ReturnDeparture, ReturnArrival time.Time
+	ReturnStops                    int
+	// Price is the total for all travellers.
+	Price    float64
+	Currency string
+	URL      string
+}
+
+// HotelQuery is a hotel search.
+type HotelQuery struct {
+	City              string
+	CheckIn, CheckOut time.Time
+	Guests, Rooms     int
+	// MaxPricePerNight is 0 for no limit, in the provider's currency.
+	MaxPricePerNight float64
+	MaxResults       int
+}
+
+// HotelOffer is a bookable stay.
+type HotelOffer struct {
+	Name    string
+	Address string
+	// Stars is the hotel class, and ReviewScore the guest rating out of 10; 0 when unknown.
+	Stars       int
+	ReviewScore float64
+	// PricePerNight and TotalPrice are for all rooms.
+	PricePerNight, TotalPrice float64
+	Currency                  string
+	URL                       string
+}
+
+// TravelSearchFromEnv returns the providers configured by TRAVEL_SEARCH_PROVIDER, or nil if
+// it isn't set. Only "stub" is built in, for demos and development; real providers are set
+// by the deployment.
+func TravelSearchFromEnv() (FlightSearchProvider, HotelSearchProvider) {
+	switch v := strings.ToLower(strings.TrimSpace(os.Getenv("TRAVEL_SEARCH_PROVIDER"))); v {
+	case "":
+		return nil, nil
+	case "stub":
+		return StubTravelSearch{}, StubTravelSearch{}
+	default:
+		slog.Warn("Unknown TRAVEL_SEARCH_PROVIDER, travel search disabled", "value", v)
+		return nil, nil
+	}
+}
+
+// StubTravelSearch returns made-up but plausible offers, so the search tools can be tried
+// without provider credentials. Its results are flagged to the model as samples.
+type StubTravelSearch struct{}
+
+func (StubTravelSearch) SearchFlights(_ context.Context, q FlightQuery) ([]FlightOffer, error) {
+	origin, destination := strings.ToUpper(q.Origin), strings.ToUpper(q.Destination)
+	var out []FlightOffer
+	for i, dep := range []int{7, 12, 18} {
+		departure := day(q.DepartureDate).Add(time.Duration(dep) * time.Hour)
+		offer := FlightOffer{
+			Carrier:   "Sample Air",
+			Flights:   []string{fmt.Sprintf("SA %d", 100+i)},
+			Departure: departure,
+			Arrival:   departure.Add(2*time.Hour + time.Duration(i)*time.Hour),
+			Stops:     i % 2,
+			Price:     float64(q.Adults) * (89 + 40*float64(i)),
+			Currency:  "EUR",
+			URL:       fmt.Sprintf("https://example.com/flights/%s-%s", origin, destination),
+		}
+		if !q.ReturnDate.IsZero() {
+			offer.ReturnDeparture = day(q.ReturnDate).Add(time.Duration(dep+1) * time.Hour)
+			offer.ReturnArrival = offer.ReturnDeparture.Add(offer.Arrival.Sub(offer.Departure))
+			offer.ReturnStops = offer.Stops
+			offer.Price *= 1.8
+		}
+		out = append(out, offer)
+	}
+	return out[:min(len(out), q.MaxResults)], nil
+}
+
+func (StubTravelSearch) SearchHotels(_ context.Context, q HotelQuery) ([]HotelOffer, error) {
+	nights := float64(max(1, int(q.CheckOut.Sub(q.CheckIn).Hours()/24)))
+	var out []HotelOffer
+	for i, h := range []struct {
+		name  string
+		stars int
+		price float64
+	}{{"Sample Central Hotel", 4, 140}, {"Sample Budget Inn", 2, 65}, {"Sample Grand Palace", 5, 320}} {
+		price := h.price * float64(q.Rooms)
+		if q.MaxPricePerNight > 0 && price > q.MaxPricePerNight {
+			continue
+		}
+		out = append(out, HotelOffer{
+			Name:          h.name,
+			Address:       fmt.Sprintf("%d Sample Street, %s", 10*(i+1), q.City),
+			Stars:         h.stars,
+			ReviewScore:   7.5 + float64(i)*0.6,
+			PricePerNight: price,
+			TotalPrice:    price * nights,
+			Currency:      "EUR",
+			URL:           "https://example.com/hotels/" + slug(q.City),
+		})
+	}
+	return out[:min(len(out), q.MaxResults)], nil
+}
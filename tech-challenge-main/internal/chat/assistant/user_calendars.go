@@ -0,0 +1,122 @@
+package assistant
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/acai-travel/tech-challenge/internal/chat/model"
+	ics "github.com/arran4/golang-ical"
+)
+
+// UserCalendarSource looks up the ICS feeds linked by the user being answered, e.g. their
+// work calendar.
+type UserCalendarSource interface {
+	UserCalendars(ctx context.Context) ([]model.UserCalendar, error)
+}
+
+type userCalendarSourceKey struct{}
+
+// WithUserCalendars attaches the user's linked calendars to the context, so get_holidays
+// can list their events and find_free_slots their free time.
+func WithUserCalendars(ctx context.Context, s UserCalendarSource) context.Context {
+	return context.WithValue(ctx, userCalendarSourceKey{}, s)
+}
+
+func userCalendarsFromContext(ctx context.Context) UserCalendarSource {
+	s, _ := ctx.Value(userCalendarSourceKey{}).(UserCalendarSource)
+	return s
+}
+
+// allCalendars is the calendar argument of get_holidays selecting public holidays and every
+// calendar of the user.
+const allCalendars = "all"
+
+// userCalendarTTL is how long a fetched user feed is reused. Unlike holiday calendars,
+// work calendars change during the day.
+const userCalendarTTL = 15 * time.Minute
+
+// userFeed is a loaded calendar of the user.
+type userFeed struct {
+	name   string
+	events []*ics.VEvent
+}
+
+// loadUserFeeds loads the user's calendar named name, or all of them for allCalendars.
+// Calendars that fail to load are skipped, and named in notes, unless the one asked for.
+func loadUserFeeds(ctx context.Context, name string) (feeds []userFeed, notes []string, err error) {
+	var calendars []model.UserCalendar
+	if s := userCalendarsFromContext(ctx); s != nil {
+		if calendars, err = s.UserCalendars(ctx); err != nil {
+			return nil, nil, fmt.Errorf("failed to look up the user's calendars: %w", err)
+		}
+	}
+
+	if name != allCalendars {
+		i := slices.IndexFunc(calendars, func(c model.UserCalendar) bool { return c.Name == name })
+		if i < 0 {
+			return nil, nil, unknownCalendarError(name, calendars)
+		}
+		calendars = calendars[i : i+1]
+	}
+
+	for _, c := range calendars {
+		events, err := loadUserCalendar(ctx, c.URL)
+		if err != nil {
+			// The feed URL may hold a secret token; it is not logged.
+			slog.WarnContext(ctx, "Failed to load a user calendar", "calendar", c.Name, "error", err)
+			if name != allCalendars {
+				return nil, nil, fmt.Errorf("The user's calendar %q could not be loaded. Tell the user to check that its link still works.", c.Name)
+			}
+			notes = append(notes, fmt.Sprintf("The user's calendar %q could not be loaded and is not included.", c.Name))
+			continue
+		}
+		feeds = append(feeds, userFeed{name: c.Name, events: events})
+	}
+	return feeds, notes, nil
+}
+
+// loadUserCalendarEvents returns the events within [from, to] of the user's calendar named
+// name, or of all of them with the public holidays of country and region for allCalendars,
+// sorted by date. Each event is named with its calendar in brackets, e.g. "Offsite [work]".
+func loadUserCalendarEvents(ctx context.Context, name, country, region string, from, to time.Time) (events []holiday, notes []string, err error) {
+	feeds, notes, err := loadUserFeeds(ctx, name)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if name == allCalendars {
+		public, err := loadHolidays(ctx, country, region, from, to)
+		if err != nil {
+			return nil, nil, err
+		}
+		events = tagged(public, "public holiday")
+	}
+	for _, f := range feeds {
+		events = append(events, tagged(expandHolidays(ctx, f.events, from, to), f.name)...)
+	}
+
+	slices.SortStableFunc(events, func(a, b holiday) int { return a.Date.Compare(b.Date) })
+	return events, notes, nil
+}
+
+func unknownCalendarError(name string, calendars []model.UserCalendar) error {
+	if len(calendars) == 0 {
+		return fmt.Errorf("The user has no calendar named %q, nor any other. Tell the user they can link one, e.g. their work calendar or school holidays, as an ICS feed.", name)
+	}
+	names := make([]string, len(calendars))
+	for i, c := range calendars {
+		names[i] = fmt.Sprintf("%q", c.Name)
+	}
+	return fmt.Errorf("The user has no calendar named %q. Their calendars are %s, or use %q for all of them.", name, strings.Join(names, ", "), allCalendars)
+}
+
+func tagged(events []holiday, calendar string) []holiday {
+	for i := range events {
+		events[i].Name += " [" + calendar + "]"
+	}
+	return events
+}
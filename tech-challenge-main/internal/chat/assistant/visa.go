@@ -0,0 +1,187 @@
+package assistant
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/acai-travel/tech-challenge/internal/httpx"
+)
+
+const (
+	// visaDatasetURL is the Passport Index dataset, one passport/destination pair per row.
+	visaDatasetURL = "https://raw.githubusercontent.com/ilyankou/passport-index-dataset/master/passport-index-tidy.csv"
+	// visaDatasetTTL is how long the dataset is reused; it is updated every few weeks.
+	visaDatasetTTL = 24 * time.Hour
+)
+
+// ErrUnknownCountry means a visa provider doesn't know the passport or destination country.
+var ErrUnknownCountry = errors.New("unknown country")
+
+// VisaKind is what a traveller needs to enter a country.
+type VisaKind string
+
+const (
+	VisaFree      VisaKind = "visa-free"
+	VisaOnArrival VisaKind = "visa on arrival"
+	// VisaElectronic covers visas and travel authorizations applied for online, e.g. an
+	// eVisa or an ETA.
+	VisaElectronic VisaKind = "eVisa or electronic travel authorization"
+	VisaRequired   VisaKind = "visa required"
+	NoAdmission    VisaKind = "no admission"
+	// SameCountry means the passport is of the destination itself.
+	SameCountry VisaKind = "own country"
+)
+
+// VisaRequirement is what holders of a passport need to visit a destination.
+type VisaRequirement struct {
+	Passport, Destination string
+	Kind                  VisaKind
+	// MaxStayDays is the longest stay allowed without a visa, when the provider knows it.
+	MaxStayDays int
+}
+
+// VisaProvider looks up visa requirements for check_visa_requirements. The default reads the
+// Passport Index dataset; an official or commercial source can be set with SetVisaProvider.
+type VisaProvider interface {
+	// VisaRequirement returns the requirement for holders of a passport of one country to visit
+	// another, both by English name, or an error wrapping ErrUnknownCountry.
+	VisaRequirement(ctx context.Context, passport, destination string) (*VisaRequirement, error)
+}
+
+// visaDataset serves requirements from a CSV of passport, destination and requirement rows,
+// in the format of the Passport Index dataset, cached for visaDatasetTTL. Failures are not
+// cached.
+type visaDataset struct {
+	url    string
+	client *http.Client
+
+	mu        sync.Mutex
+	rows      map[[2]string]string
+	names     map[string]string
+	fetchedAt time.Time
+}
+
+// visaRequirements is shared by all assistants, so tenants don't each fetch the dataset.
+var visaRequirements = newVisaDataset()
+
+func newVisaDataset() *visaDataset {
+	url := os.Getenv("VISA_DATASET_URL")
+	if url == "" {
+		url = visaDatasetURL
+	}
+	return &visaDataset{url: url, client: httpx.Client(20 * time.Second)}
+}
+
+func (d *visaDataset) VisaRequirement(ctx context.Context, passport, destination string) (*VisaRequirement, error) {
+	rows, names, err := d.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	from, to := countryKey(passport), countryKey(destination)
+	for _, c := range []struct{ key, name string }{{from, passport}, {to, destination}} {
+		if _, ok := names[c.key]; !ok {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownCountry, c.name)
+		}
+	}
+	if from == to {
+		return &VisaRequirement{Passport: names[from], Destination: names[to], Kind: SameCountry}, nil
+	}
+
+	value, ok := rows[[2]string{from, to}]
+	if !ok {
+		return nil, fmt.Errorf("%w: no data from %s to %s", ErrUnknownCountry, passport, destination)
+	}
+	req := &VisaRequirement{Passport: names[from], Destination: names[to]}
+	if days, err := strconv.Atoi(value); err == nil {
+		if days < 0 {
+			req.Kind = SameCountry
+		} else {
+			req.Kind, req.MaxStayDays = VisaFree, days
+		}
+		return req, nil
+	}
+	switch strings.ToLower(value) {
+	case "visa free":
+		req.Kind = VisaFree
+	case "visa on arrival":
+		req.Kind = VisaOnArrival
+	case "e-visa", "eta":
+		req.Kind = VisaElectronic
+	case "no admission", "covid ban":
+		req.Kind = NoAdmission
+	default:
+		req.Kind = VisaRequired
+	}
+	return req, nil
+}
+
+// countryKey normalizes a country name for lookups, resolving common aliases.
+func countryKey(name string) string {
+	key := strings.TrimPrefix(strings.Join(strings.Fields(strings.ToLower(name)), " "), "the ")
+	if alias, ok := visaCountryAliases[key]; ok {
+		return alias
+	}
+	return key
+}
+
+// visaCountryAliases maps common names to the names the dataset uses.
+var visaCountryAliases = map[string]string{
+	"uk": "united kingdom", "england": "united kingdom", "great britain": "united kingdom", "britain": "united kingdom",
+	"usa": "united states", "us": "united states", "united states of america": "united states", "america": "united states",
+	"uae": "united arab emirates", "holland": "netherlands", "czechia": "czech republic",
+	"türkiye": "turkey", "ivory coast": "cote d'ivoire", "korea": "south korea",
+}
+
+func (d *visaDataset) load(ctx context.Context) (map[[2]string]string, map[string]string, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.rows != nil && time.Since(d.fetchedAt) < visaDatasetTTL {
+		return d.rows, d.names, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, d.url, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch visa requirements: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, nil, fmt.Errorf("visa requirements returned status %d", resp.StatusCode)
+	}
+
+	r := csv.NewReader(io.LimitReader(resp.Body, 16<<20))
+	r.FieldsPerRecord = 3
+	if _, err := r.Read(); err != nil { // header
+		return nil, nil, fmt.Errorf("failed to parse visa requirements: %w", err)
+	}
+	rows, names := map[[2]string]string{}, map[string]string{}
+	for {
+		rec, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to parse visa requirements: %w", err)
+		}
+		from, to := countryKey(rec[0]), countryKey(rec[1])
+		names[from], names[to] = rec[0], rec[1]
+		rows[[2]string{from, to}] = strings.TrimSpace(rec[2])
+	}
+
+	d.rows, d.names, d.fetchedAt = rows, names, time.Now()
+	return rows, names, nil
+}
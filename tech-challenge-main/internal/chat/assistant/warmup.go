@@ -0,0 +1,74 @@
+package assistant
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/openai/openai-go/v2"
+)
+
+// Warmup establishes connections to OpenAI and WeatherAPI and primes the local holiday
+// calendar, so the first conversation after a deploy doesn't pay for DNS, TLS handshakes
+// and the calendar download. Failures are reported but leave the assistant usable.
+//
+// ASSISTANT_WARMUP_COMPLETION=true also issues a one-token completion, which additionally
+// warms the completion path at a negligible cost.
+func (a *Assistant) Warmup(ctx context.Context) error {
+	steps := map[string]func(context.Context) error{
+		"openai": func(ctx context.Context) error {
+			_, err := a.cli.Models.Get(ctx, a.model)
+			return err
+		},
+		"calendar": func(ctx context.Context) error {
+			_, err := loadCalendarCached(ctx, holidayCalendarLink("", ""))
+			return err
+		},
+	}
+	if a.weatherService != nil {
+		steps["weather"] = func(ctx context.Context) error {
+			_, err := a.weatherService.Current(ctx, "London")
+			return err
+		}
+	}
+	if os.Getenv("ASSISTANT_WARMUP_COMPLETION") == "true" {
+		steps["completion"] = func(ctx context.Context) error {
+			_, err := a.completions.New(ctx, openai.ChatCompletionNewParams{
+				Model:               openai.ChatModelGPT4oMini,
+				Messages:            []openai.ChatCompletionMessageParamUnion{openai.UserMessage("ping")},
+				MaxCompletionTokens: openai.Int(1),
+			})
+			return err
+		}
+	}
+
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs []error
+	)
+	for name, step := range steps {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			start := time.Now()
+			err := step(ctx)
+			if err != nil {
+				slog.WarnContext(ctx, "Warmup step failed", "step", name, "error", err)
+				mu.Lock()
+				errs = append(errs, fmt.Errorf("%s: %w", name, err))
+				mu.Unlock()
+				return
+			}
+			slog.InfoContext(ctx, "Warmup step done", "step", name, "duration", time.Since(start))
+		}()
+	}
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
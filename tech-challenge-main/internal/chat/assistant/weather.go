@@ -3,22 +3,42 @@ package assistant
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"maps"
 	"net/http"
 	"net/url"
 	"strconv"
 	"strings"
 	"time"
+
+	"github.com/acai-travel/tech-challenge/internal/httpx"
+	lru "github.com/hashicorp/golang-lru/v2"
+	"golang.org/x/sync/singleflight"
 )
 
 type WeatherService struct {
 	apiKey  string
 	client  *http.Client
 	baseURL string
+
+	quota *weatherQuota
+	// last keeps the latest response per query; see fetch.
+	last      *lru.Cache[string, *WeatherResponse]
+	refreshes singleflight.Group
+	// freshFor is how long a cached response is served without calling the provider, and
+	// staleWait how long callers with a cached response wait for a refresh.
+	freshFor  time.Duration
+	staleWait time.Duration
 }
 
 type WeatherResponse struct {
+	// FetchedAt is when the provider returned this data.
+	FetchedAt time.Time `json:"-"`
+	// Stale is set when cached data was served instead of calling the provider.
+	Stale bool `json:"-"`
+
 	Location struct {
 		Name      string  `json:"name"`
 		Country   string  `json:"country"`
@@ -26,6 +46,7 @@ type WeatherResponse struct {
 		Lat       float64 `json:"lat"`
 		Lon       float64 `json:"lon"`
 		Localtime string  `json:"localtime"`
+		TzID      string  `json:"tz_id"`
 	} `json:"location"`
 	Current struct {
 		TempC     float64 `json:"temp_c"`
@@ -43,25 +64,39 @@ type WeatherResponse struct {
 		FeelsLikeF   float64 `json:"feelslike_f"`
 		UV           float64 `json:"uv"`
 		VisibilityKm float64 `json:"vis_km"`
+		// Pollen counts in grains/m³ by plant, on plans that include them.
+		Pollen map[string]float64 `json:"pollen"`
 	} `json:"current"`
 	Forecast struct {
 		Forecastday []struct {
 			Date string `json:"date"`
 			Day  struct {
-				MaxtempC      float64 `json:"maxtemp_c"`
-				MaxtempF      float64 `json:"maxtemp_f"`
-				MintempC      float64 `json:"mintemp_c"`
-				MintempF      float64 `json:"mintemp_f"`
-				AvgtempC      float64 `json:"avgtemp_c"`
-				AvgtempF      float64 `json:"avgtemp_f"`
-				MaxwindKph    float64 `json:"maxwind_kph"`
-				MaxwindMph    float64 `json:"maxwind_mph"`
-				TotalprecipMm float64 `json:"totalprecip_mm"`
-				TotalprecipIn float64 `json:"totalprecip_in"`
+				MaxtempC      float64            `json:"maxtemp_c"`
+				MaxtempF      float64            `json:"maxtemp_f"`
+				MintempC      float64            `json:"mintemp_c"`
+				MintempF      float64            `json:"mintemp_f"`
+				AvgtempC      float64            `json:"avgtemp_c"`
+				AvgtempF      float64            `json:"avgtemp_f"`
+				MaxwindKph    float64            `json:"maxwind_kph"`
+				MaxwindMph    float64            `json:"maxwind_mph"`
+				TotalprecipMm float64            `json:"totalprecip_mm"`
+				TotalprecipIn float64            `json:"totalprecip_in"`
+				TotalsnowCm   float64            `json:"totalsnow_cm"`
+				ChanceOfSnow  int                `json:"daily_chance_of_snow"`
+				UV            float64            `json:"uv"`
+				Pollen        map[string]float64 `json:"pollen"`
 				Condition     struct {
 					Text string `json:"text"`
 					Icon string `json:"icon"`
 				} `json:"condition"`
+				// Tides are only returned by the marine endpoint.
+				Tides []struct {
+					Tide []struct {
+						Time     string    `json:"tide_time"`
+						HeightMt flexFloat `json:"tide_height_mt"`
+						Type     string    `json:"tide_type"`
+					} `json:"tide"`
+				} `json:"tides"`
 			} `json:"day"`
 			Hour []struct {
 				TimeEpoch int64   `json:"time_epoch"`
@@ -78,11 +113,30 @@ type WeatherResponse struct {
 				WindDir      string  `json:"wind_dir"`
 				Humidity     int     `json:"humidity"`
 				ChanceOfRain int     `json:"chance_of_rain"`
+				SnowCm       float64 `json:"snow_cm"`
+				// Sea conditions are only returned by the marine endpoint.
+				WaterTempC      float64 `json:"water_temp_c"`
+				SigHtMt         float64 `json:"sig_ht_mt"`
+				SwellHtMt       float64 `json:"swell_ht_mt"`
+				SwellDir        string  `json:"swell_dir_16_point"`
+				SwellPeriodSecs float64 `json:"swell_period_secs"`
 			} `json:"hour"`
 		} `json:"forecastday"`
 	} `json:"forecast"`
 }
 
+// flexFloat decodes numbers WeatherAPI sends either as JSON numbers or as strings.
+type flexFloat float64
+
+func (f *flexFloat) UnmarshalJSON(b []byte) error {
+	v, err := strconv.ParseFloat(strings.Trim(string(b), `"`), 64)
+	if err != nil {
+		return err
+	}
+	*f = flexFloat(v)
+	return nil
+}
+
 type WeatherError struct {
 	Error struct {
 		Code    int    `json:"code"`
@@ -91,97 +145,216 @@ type WeatherError struct {
 }
 
 func NewWeatherService(apiKey string) *WeatherService {
+	last, _ := lru.New[string, *WeatherResponse](512)
 	return &WeatherService{
 		apiKey:  apiKey,
-		client:  &http.Client{Timeout: 10 * time.Second},
-		baseURL: "http://api.weatherapi.com/v1",
+		client:  httpx.Client(10 * time.Second),
+		baseURL: "https://api.weatherapi.com/v1",
+		quota:   weatherQuotaFor(apiKey),
+		last:    last,
+		// WeatherAPI updates current conditions every 15 minutes.
+		freshFor:  5 * time.Minute,
+		staleWait: 2 * time.Second,
 	}
 }
 
 func (w *WeatherService) GetCurrentWeather(ctx context.Context, location string) (string, error) {
-	params := url.Values{}
-	params.Set("key", w.apiKey)
-	params.Set("q", location)
-	params.Set("aqi", "no")
-
-	req, err := http.NewRequestWithContext(ctx, "GET", w.baseURL+"/current.json?"+params.Encode(), nil)
+	weather, err := w.Current(ctx, location)
 	if err != nil {
-		return "", fmt.Errorf("failed to create request: %w", err)
+		return "", err
 	}
 
-	resp, err := w.client.Do(req)
-	if err != nil {
-		return "", fmt.Errorf("failed to make request: %w", err)
-	}
-	defer resp.Body.Close()
+	return w.formatCurrentWeather(*weather, healthAdvisories(ctx)), nil
+}
 
-	body, err := io.ReadAll(resp.Body)
+func (w *WeatherService) GetForecast(ctx context.Context, location string, days int) (string, error) {
+	weather, err := w.Forecast(ctx, location, days)
 	if err != nil {
-		return "", fmt.Errorf("failed to read response: %w", err)
+		return "", err
 	}
 
-	if resp.StatusCode != http.StatusOK {
-		var weatherErr WeatherError
-		if err := json.Unmarshal(body, &weatherErr); err == nil && weatherErr.Error.Message != "" {
-			return "", fmt.Errorf("weather API error: %s", weatherErr.Error.Message)
-		}
-		return "", fmt.Errorf("weather API returned status %d: %s", resp.StatusCode, string(body))
-	}
+	return w.formatForecast(*weather, healthAdvisories(ctx)), nil
+}
 
-	var weather WeatherResponse
-	if err := json.Unmarshal(body, &weather); err != nil {
-		return "", fmt.Errorf("failed to parse weather response: %w", err)
-	}
+// Current returns the raw current conditions for a location.
+func (w *WeatherService) Current(ctx context.Context, location string) (*WeatherResponse, error) {
+	params := url.Values{}
+	params.Set("q", location)
+	params.Set("aqi", "no")
+	params.Set("pollen", "yes")
 
-	return w.formatCurrentWeather(weather), nil
+	return w.fetch(ctx, "/current.json", params)
 }
 
-func (w *WeatherService) GetForecast(ctx context.Context, location string, days int) (string, error) {
+// Forecast returns the raw daily forecast for a location, including today.
+func (w *WeatherService) Forecast(ctx context.Context, location string, days int) (*WeatherResponse, error) {
 	if days < 1 || days > 14 {
 		days = 3 // Default to 3 days
 	}
 
 	params := url.Values{}
-	params.Set("key", w.apiKey)
 	params.Set("q", location)
 	params.Set("days", strconv.Itoa(days))
 	params.Set("aqi", "no")
 	params.Set("alerts", "no")
+	params.Set("pollen", "yes")
+
+	return w.fetch(ctx, "/forecast.json", params)
+}
+
+// Marine returns the raw marine forecast for a coastal location: hourly sea temperature,
+// waves and swell, and the daily tides. WeatherAPI forecasts up to 7 days of sea conditions.
+func (w *WeatherService) Marine(ctx context.Context, location string, days int) (*WeatherResponse, error) {
+	if days < 1 || days > maxMarineDays {
+		days = 3
+	}
+
+	params := url.Values{}
+	params.Set("q", location)
+	params.Set("days", strconv.Itoa(days))
+	params.Set("tides", "yes")
+
+	return w.fetch(ctx, "/marine.json", params)
+}
+
+// Timezone resolves the IANA time zone of a location, e.g. "Europe/Madrid" for "Barcelona".
+// It also returns the resolved place name, as "City, Country".
+func (w *WeatherService) Timezone(ctx context.Context, location string) (*time.Location, string, error) {
+	params := url.Values{}
+	params.Set("q", location)
+
+	weather, err := w.fetch(ctx, "/timezone.json", params)
+	if err != nil {
+		return nil, "", err
+	}
+
+	loc, err := time.LoadLocation(weather.Location.TzID)
+	if err != nil || weather.Location.TzID == "" {
+		return nil, "", fmt.Errorf("unknown time zone %q for %s", weather.Location.TzID, location)
+	}
+	return loc, weather.Location.Name + ", " + weather.Location.Country, nil
+}
+
+// fetch serves a query from the cache, refreshing it from the provider within the quota.
+// Entries younger than freshFor are served as they are. Older ones are refreshed, but if the
+// provider errors or takes longer than staleWait the cached entry is served instead, marked
+// stale, while the refresh carries on in the background. The cache is also preferred when
+// few calls remain in the quota. Concurrent refreshes of a query share one call.
+func (w *WeatherService) fetch(ctx context.Context, path string, params url.Values) (*WeatherResponse, error) {
+	key := path + "?" + params.Encode()
+	now := time.Now()
+
+	last, cached := w.last.Get(key)
+	if cached && now.Sub(last.FetchedAt) < w.freshFor {
+		weatherCache.Add("fresh", 1)
+		weather := *last
+		return &weather, nil
+	}
+	if cached && w.quota.nearLimit(now) {
+		return w.stale(last), nil
+	}
+
+	// The refresh outlives the caller when it gives up waiting, so it can update the cache.
+	refresh := w.refreshes.DoChan(key, func() (any, error) {
+		return w.refresh(context.WithoutCancel(ctx), key, path, params)
+	})
+
+	if !cached {
+		weatherCache.Add("miss", 1)
+		select {
+		case res := <-refresh:
+			if res.Err != nil {
+				return nil, res.Err
+			}
+			weather := *res.Val.(*WeatherResponse)
+			return &weather, nil
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	timer := time.NewTimer(w.staleWait)
+	defer timer.Stop()
+	select {
+	case res := <-refresh:
+		if res.Err == nil {
+			weatherCache.Add("refreshed", 1)
+			weather := *res.Val.(*WeatherResponse)
+			return &weather, nil
+		}
+	case <-timer.C:
+	case <-ctx.Done():
+	}
+	return w.stale(last), nil
+}
+
+// refresh calls the provider and caches the response.
+func (w *WeatherService) refresh(ctx context.Context, key, path string, params url.Values) (*WeatherResponse, error) {
+	now := time.Now()
+	if err := w.quota.take(now); err != nil {
+		return nil, err
+	}
+
+	weather, err := w.call(ctx, path, params)
+	if errors.Is(err, ErrQuotaExceeded) {
+		w.quota.exhaust(now)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	weather.FetchedAt = time.Now()
+	w.last.Add(key, weather)
+	return weather, nil
+}
+
+// stale returns a copy of a cached response, marked stale.
+func (w *WeatherService) stale(last *WeatherResponse) *WeatherResponse {
+	weatherCache.Add("stale", 1)
+	weather := *last
+	weather.Stale = true
+	return &weather
+}
+
+func (w *WeatherService) call(ctx context.Context, path string, params url.Values) (*WeatherResponse, error) {
+	params = maps.Clone(params)
+	params.Set("key", w.apiKey)
 
-	req, err := http.NewRequestWithContext(ctx, "GET", w.baseURL+"/forecast.json?"+params.Encode(), nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", w.baseURL+path+"?"+params.Encode(), nil)
 	if err != nil {
-		return "", fmt.Errorf("failed to create request: %w", err)
+		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	resp, err := w.client.Do(req)
 	if err != nil {
-		return "", fmt.Errorf("failed to make request: %w", err)
+		return nil, fmt.Errorf("%w: failed to make request: %w", ErrProviderUnavailable, err)
 	}
 	defer resp.Body.Close()
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return "", fmt.Errorf("failed to read response: %w", err)
+		return nil, fmt.Errorf("%w: failed to read response: %w", ErrProviderUnavailable, err)
 	}
 
 	if resp.StatusCode != http.StatusOK {
+		apiErr := &WeatherAPIError{Status: resp.StatusCode}
 		var weatherErr WeatherError
-		if err := json.Unmarshal(body, &weatherErr); err == nil && weatherErr.Error.Message != "" {
-			return "", fmt.Errorf("weather API error: %s", weatherErr.Error.Message)
+		if err := json.Unmarshal(body, &weatherErr); err == nil {
+			apiErr.Code, apiErr.Message = weatherErr.Error.Code, weatherErr.Error.Message
 		}
-		return "", fmt.Errorf("weather API returned status %d: %s", resp.StatusCode, string(body))
+		return nil, apiErr
 	}
 
 	var weather WeatherResponse
 	if err := json.Unmarshal(body, &weather); err != nil {
-		return "", fmt.Errorf("failed to parse weather response: %w", err)
+		return nil, fmt.Errorf("%w: failed to parse weather response: %w", ErrProviderUnavailable, err)
 	}
 
-	return w.formatForecast(weather), nil
+	return &weather, nil
 }
 
 // formatCurrentWeather formats current weather data into a beautiful, readable response
-func (w *WeatherService) formatCurrentWeather(weather WeatherResponse) string {
+func (w *WeatherService) formatCurrentWeather(weather WeatherResponse, advise bool) string {
 	loc := weather.Location
 	current := weather.Current
 
@@ -199,14 +372,21 @@ func (w *WeatherService) formatCurrentWeather(weather WeatherResponse) string {
 	sb.WriteString(fmt.Sprintf("**Wind:** %.1f km/h (%.1f mph) %s\n", current.WindKph, current.WindMph, current.WindDir))
 	sb.WriteString(fmt.Sprintf("**Humidity:** %d%%\n", current.Humidity))
 	sb.WriteString(fmt.Sprintf("**Feels Like:** %.1f°C (%.1f°F)\n", current.FeelsLikeC, current.FeelsLikeF))
-	sb.WriteString(fmt.Sprintf("**UV Index:** %.1f\n", current.UV))
+	sb.WriteString(fmt.Sprintf("**UV Index:** %.1f (%s)\n", current.UV, uvCategory(current.UV)))
+	if pollen := formatPollen(current.Pollen); pollen != "" {
+		sb.WriteString("**Pollen:** " + pollen + "\n")
+	}
 	sb.WriteString(fmt.Sprintf("**Visibility:** %.1f km\n", current.VisibilityKm))
+	if advisory := healthAdvisory(current.UV, current.Pollen); advise && advisory != "" {
+		sb.WriteString("**Advisory:** " + advisory + "\n")
+	}
+	writeStaleNote(&sb, weather)
 
 	return sb.String()
 }
 
 // formatForecast formats forecast data into a beautiful, readable response
-func (w *WeatherService) formatForecast(weather WeatherResponse) string {
+func (w *WeatherService) formatForecast(weather WeatherResponse, advise bool) string {
 	loc := weather.Location
 	forecast := weather.Forecast
 
@@ -235,8 +415,39 @@ func (w *WeatherService) formatForecast(weather WeatherResponse) string {
 			day.Day.MaxtempC, day.Day.MaxtempF, day.Day.MintempC, day.Day.MintempF))
 		sb.WriteString(fmt.Sprintf("   **Conditions:** %s\n", day.Day.Condition.Text))
 		sb.WriteString(fmt.Sprintf("   **Wind:** %.1f km/h (%.1f mph)\n", day.Day.MaxwindKph, day.Day.MaxwindMph))
-		sb.WriteString(fmt.Sprintf("   **Precipitation:** %.1f mm (%.1f in)\n\n", day.Day.TotalprecipMm, day.Day.TotalprecipIn))
+		sb.WriteString(fmt.Sprintf("   **Precipitation:** %.1f mm (%.1f in)\n", day.Day.TotalprecipMm, day.Day.TotalprecipIn))
+		sb.WriteString(fmt.Sprintf("   **UV Index:** %.0f (%s)\n", day.Day.UV, uvCategory(day.Day.UV)))
+		if pollen := formatPollen(day.Day.Pollen); pollen != "" {
+			sb.WriteString("   **Pollen:** " + pollen + "\n")
+		}
+		if advisory := healthAdvisory(day.Day.UV, day.Day.Pollen); advise && advisory != "" {
+			sb.WriteString("   **Advisory:** " + advisory + "\n")
+		}
+		sb.WriteString("\n")
 	}
+	writeStaleNote(&sb, weather)
 
 	return sb.String()
 }
+
+// writeStaleNote tells the model how old cached data is and that it may be outdated, so it
+// can say so.
+func writeStaleNote(sb *strings.Builder, weather WeatherResponse) {
+	if weather.Stale {
+		sb.WriteString(fmt.Sprintf("\nNote: live weather is temporarily unavailable; this data is %s old (fetched at %s) and may be outdated. Tell the user.\n",
+			describeAge(time.Since(weather.FetchedAt)), weather.FetchedAt.UTC().Format(time.RFC3339)))
+	}
+}
+
+func describeAge(d time.Duration) string {
+	switch {
+	case d < 2*time.Minute:
+		return "about a minute"
+	case d < 2*time.Hour:
+		return fmt.Sprintf("%d minutes", int(d.Minutes()))
+	case d < 48*time.Hour:
+		return fmt.Sprintf("%d hours", int(d.Hours()))
+	default:
+		return fmt.Sprintf("%d days", int(d.Hours()/24))
+	}
+}
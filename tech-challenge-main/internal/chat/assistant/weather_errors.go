@@ -0,0 +1,82 @@
+package assistant
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// Failure classes of WeatherService calls. Returned errors wrap one of these, so callers can
+// test them with errors.Is.
+var (
+	// ErrLocationNotFound means the provider could not match the location query.
+	ErrLocationNotFound = errors.New("location not found")
+	// ErrQuotaExceeded means the API key has used up its plan's calls.
+	ErrQuotaExceeded = errors.New("weather quota exceeded")
+	// ErrProviderUnavailable means the provider could not be reached or failed, e.g. a
+	// timeout, a 5xx or a rejected API key. Retrying later may succeed.
+	ErrProviderUnavailable = errors.New("weather provider unavailable")
+)
+
+// WeatherAPIError is an error response from WeatherAPI. See
+// https://www.weatherapi.com/docs/#intro-error-codes for the codes.
+type WeatherAPIError struct {
+	Status  int
+	Code    int
+	Message string
+}
+
+func (e *WeatherAPIError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("weather API returned status %d", e.Status)
+	}
+	return fmt.Sprintf("weather API error %d: %s", e.Code, e.Message)
+}
+
+// Unwrap classifies the error as one of the failure classes, or nil for request errors
+// such as a missing query.
+func (e *WeatherAPIError) Unwrap() error {
+	switch {
+	case e.Code == 1006:
+		return ErrLocationNotFound
+	case e.Code == 2007:
+		return ErrQuotaExceeded
+	case e.Code == 1002, e.Code == 2006, e.Code == 2008, e.Code == 2009:
+		// Missing, invalid or disabled key: nothing the model can fix.
+		return ErrProviderUnavailable
+	case e.Code == 9999, e.Status >= http.StatusInternalServerError, e.Status == http.StatusTooManyRequests:
+		return ErrProviderUnavailable
+	default:
+		return nil
+	}
+}
+
+// weatherErrorClass names the failure class of err for metrics.
+func weatherErrorClass(err error) string {
+	switch {
+	case errors.Is(err, ErrLocationNotFound):
+		return "location_not_found"
+	case errors.Is(err, ErrQuotaExceeded):
+		return "quota_exceeded"
+	case errors.Is(err, ErrProviderUnavailable):
+		return "provider_unavailable"
+	default:
+		return "other"
+	}
+}
+
+// weatherToolError rewrites a WeatherService error into a tool result the model can act on.
+func weatherToolError(location string, err error) error {
+	weatherErrors.Add(weatherErrorClass(err), 1)
+
+	switch {
+	case errors.Is(err, ErrLocationNotFound):
+		return fmt.Errorf("No location matches %q. Ask the user to be more specific, e.g. add the region or country.", location)
+	case errors.Is(err, ErrQuotaExceeded):
+		return errors.New("The weather service has reached its usage limit. Tell the user live weather is unavailable for now; do not guess the weather.")
+	case errors.Is(err, ErrProviderUnavailable):
+		return errors.New("The weather service is temporarily unavailable. Tell the user to try again in a few minutes; do not guess the weather.")
+	default:
+		return errors.New("Failed to get weather information: " + err.Error())
+	}
+}
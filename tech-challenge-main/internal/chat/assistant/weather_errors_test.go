@@ -0,0 +1,72 @@
+package assistant
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWeatherServiceErrors(t *testing.T) {
+	cases := []struct {
+		name   string
+		status int
+		body   string
+		want   error
+		tool   string // substring of the weather tool's message
+	}{
+		{"unknown location", http.StatusBadRequest, `{"error":{"code":1006,"message":"No matching location found."}}`, ErrLocationNotFound, "Ask the user to be more specific"},
+		{"quota", http.StatusForbidden, `{"error":{"code":2007,"message":"API key has exceeded calls per month quota."}}`, ErrQuotaExceeded, "usage limit"},
+		{"disabled key", http.StatusForbidden, `{"error":{"code":2008,"message":"API key has been disabled."}}`, ErrProviderUnavailable, "temporarily unavailable"},
+		{"server error", http.StatusBadGateway, `<html>bad gateway</html>`, ErrProviderUnavailable, "temporarily unavailable"},
+		{"invalid body", http.StatusOK, `{"location":`, ErrProviderUnavailable, "temporarily unavailable"},
+		{"bad request", http.StatusBadRequest, `{"error":{"code":1003,"message":"Parameter q is missing."}}`, nil, "Parameter q is missing"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tc.status)
+				fmt.Fprint(w, tc.body)
+			}))
+			defer api.Close()
+
+			service := NewWeatherService(t.Name())
+			service.baseURL = api.URL
+
+			_, err := service.Current(context.Background(), "Springfield")
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			for _, class := range []error{ErrLocationNotFound, ErrQuotaExceeded, ErrProviderUnavailable} {
+				if got := errors.Is(err, class); got != (class == tc.want) {
+					t.Errorf("errors.Is(%v, %v) = %v", err, class, got)
+				}
+			}
+
+			_, err = (&weatherTool{service: service}).Call(context.Background(), `{"location":"Springfield"}`)
+			if err == nil || !strings.Contains(err.Error(), tc.tool) {
+				t.Errorf("expected tool message containing %q, got %v", tc.tool, err)
+			}
+		})
+	}
+
+	t.Run("unreachable", func(t *testing.T) {
+		api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			time.Sleep(100 * time.Millisecond)
+		}))
+		defer api.Close()
+
+		service := NewWeatherService(t.Name())
+		service.baseURL = api.URL
+		service.client.Timeout = 10 * time.Millisecond
+
+		if _, err := service.Current(context.Background(), "Springfield"); !errors.Is(err, ErrProviderUnavailable) {
+			t.Fatalf("expected ErrProviderUnavailable, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,113 @@
+package assistant
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"expvar"
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// weatherQuota counts WeatherAPI calls against the monthly quota of the plan, per API key.
+// Counts live in memory: after a restart the provider's own limit still applies, and
+// running out is then detected from its quota errors.
+type weatherQuota struct {
+	mu sync.Mutex
+	// limit is the number of calls per calendar month (UTC); 0 means unlimited.
+	limit int
+	// reserve is the number of calls kept back: once fewer remain, cached data is
+	// preferred over new calls.
+	reserve int
+	month   string
+	used    int
+	// blockedUntil stops calls for a while after the provider reported the quota exceeded,
+	// whatever our count says.
+	blockedUntil time.Time
+}
+
+var (
+	weatherQuotasMu sync.Mutex
+	weatherQuotas   = map[string]*weatherQuota{}
+
+	// weatherQuotaMetrics exposes limit, used and remaining calls per API key, keyed by a
+	// fingerprint of the key so it doesn't leak on the admin port.
+	weatherQuotaMetrics = expvar.NewMap("assistant_weather_quota")
+)
+
+// weatherQuotaFor returns the quota shared by every WeatherService using apiKey, reading
+// WEATHER_API_MONTHLY_QUOTA (default unlimited) and WEATHER_API_QUOTA_RESERVE (default 5%
+// of the quota) when it is first used.
+func weatherQuotaFor(apiKey string) *weatherQuota {
+	weatherQuotasMu.Lock()
+	defer weatherQuotasMu.Unlock()
+
+	if q, ok := weatherQuotas[apiKey]; ok {
+		return q
+	}
+
+	limit, _ := strconv.Atoi(os.Getenv("WEATHER_API_MONTHLY_QUOTA"))
+	reserve, err := strconv.Atoi(os.Getenv("WEATHER_API_QUOTA_RESERVE"))
+	if err != nil {
+		reserve = limit / 20
+	}
+	q := &weatherQuota{limit: max(limit, 0), reserve: max(reserve, 0)}
+	weatherQuotas[apiKey] = q
+
+	sum := sha256.Sum256([]byte(apiKey))
+	weatherQuotaMetrics.Set(hex.EncodeToString(sum[:4]), expvar.Func(func() any { return q.stats(time.Now()) }))
+	return q
+}
+
+// roll resets the count when a new month starts. The caller must hold q.mu.
+func (q *weatherQuota) roll(now time.Time) {
+	if m := now.UTC().Format("2006-01"); m != q.month {
+		q.month, q.used = m, 0
+	}
+}
+
+// nearLimit reports whether few enough calls remain this month to prefer cached data.
+func (q *weatherQuota) nearLimit(now time.Time) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.roll(now)
+	return now.Before(q.blockedUntil) || (q.limit > 0 && q.limit-q.used <= q.reserve)
+}
+
+// take records a call, or returns an error wrapping ErrQuotaExceeded if none is left.
+func (q *weatherQuota) take(now time.Time) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.roll(now)
+	if now.Before(q.blockedUntil) {
+		return fmt.Errorf("%w: calls paused until %s", ErrQuotaExceeded, q.blockedUntil.UTC().Format(time.RFC3339))
+	}
+	if q.limit > 0 && q.used >= q.limit {
+		return fmt.Errorf("%w: monthly quota of %d calls used", ErrQuotaExceeded, q.limit)
+	}
+	q.used++
+	return nil
+}
+
+// exhaust stops calls for an hour, when the provider says the quota is exceeded before our
+// count does; the provider's period may not match ours.
+func (q *weatherQuota) exhaust(now time.Time) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.blockedUntil = now.Add(time.Hour)
+}
+
+func (q *weatherQuota) stats(now time.Time) map[string]any {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.roll(now)
+
+	out := map[string]any{"used": q.used, "month": q.month, "blocked": now.Before(q.blockedUntil)}
+	if q.limit > 0 {
+		out["limit"] = q.limit
+		out["remaining"] = max(q.limit-q.used, 0)
+	}
+	return out
+}
@@ -0,0 +1,111 @@
+package assistant
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWeatherQuota(t *testing.T) {
+	var calls atomic.Int32
+	quotaExceeded := atomic.Bool{}
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		if quotaExceeded.Load() {
+			w.WriteHeader(http.StatusForbidden)
+			fmt.Fprint(w, `{"error":{"code":2007,"message":"API key has exceeded calls per month quota."}}`)
+			return
+		}
+		fmt.Fprintf(w, `{"location":{"name":%q,"country":"Test"},"current":{"temp_c":20}}`, r.URL.Query().Get("q"))
+	}))
+	defer api.Close()
+
+	newService := func(limit, reserve int) *WeatherService {
+		s := NewWeatherService(t.Name())
+		s.baseURL = api.URL
+		s.quota = &weatherQuota{limit: limit, reserve: reserve}
+		s.freshFor = 0
+		return s
+	}
+	ctx := context.Background()
+
+	t.Run("serves cached data near the limit", func(t *testing.T) {
+		calls.Store(0)
+		s := newService(3, 1)
+
+		for _, city := range []string{"Oslo", "Paris"} {
+			if w, err := s.Current(ctx, city); err != nil || w.Stale {
+				t.Fatalf("%s: expected fresh data, got stale=%v err=%v", city, w != nil && w.Stale, err)
+			}
+		}
+
+		// One call left, which is the reserve: known queries come from the cache...
+		w, err := s.Current(ctx, "Oslo")
+		if err != nil || !w.Stale {
+			t.Fatalf("expected stale data for a cached query, got %v", err)
+		}
+		if out := s.formatCurrentWeather(*w, false); !strings.Contains(out, "may be outdated") {
+			t.Errorf("expected stale data to be annotated, got:\n%s", out)
+		}
+
+		// ...but new ones may still use the last calls.
+		if w, err := s.Current(ctx, "Rome"); err != nil || w.Stale {
+			t.Fatalf("expected a fresh call for a new query, got %v", err)
+		}
+		if _, err := s.Current(ctx, "Lima"); !errors.Is(err, ErrQuotaExceeded) {
+			t.Fatalf("expected ErrQuotaExceeded once the quota is used, got %v", err)
+		}
+		if got := calls.Load(); got != 3 {
+			t.Fatalf("expected 3 provider calls, got %d", got)
+		}
+		if got := s.quota.stats(time.Now())["remaining"]; got != 0 {
+			t.Errorf("expected 0 remaining, got %v", got)
+		}
+	})
+
+	t.Run("falls back to cached data when the provider is out of quota", func(t *testing.T) {
+		calls.Store(0)
+		s := newService(0, 0)
+
+		if _, err := s.Current(ctx, "Oslo"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		quotaExceeded.Store(true)
+		defer quotaExceeded.Store(false)
+
+		w, err := s.Current(ctx, "Oslo")
+		if err != nil || !w.Stale {
+			t.Fatalf("expected stale data, got %v", err)
+		}
+
+		// Calls are paused: no more requests hit the provider.
+		if _, err := s.Current(ctx, "Paris"); !errors.Is(err, ErrQuotaExceeded) {
+			t.Fatalf("expected ErrQuotaExceeded, got %v", err)
+		}
+		if got := calls.Load(); got != 2 {
+			t.Fatalf("expected 2 provider calls, got %d", got)
+		}
+	})
+
+	t.Run("resets monthly", func(t *testing.T) {
+		q := &weatherQuota{limit: 1}
+		sep := time.Date(2025, 9, 30, 23, 0, 0, 0, time.UTC)
+
+		if err := q.take(sep); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := q.take(sep); !errors.Is(err, ErrQuotaExceeded) {
+			t.Fatalf("expected ErrQuotaExceeded, got %v", err)
+		}
+		if err := q.take(sep.Add(2 * time.Hour)); err != nil {
+			t.Fatalf("expected a new month to reset the quota, got %v", err)
+		}
+	})
+}
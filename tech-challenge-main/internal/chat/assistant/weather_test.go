@@ -2,90 +2,297 @@ package assistant
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 )
 
-func TestWeatherService(t *testing.T) {
-	// Skip if no API key is available
-	apiKey := os.Getenv("WEATHER_API_KEY")
-	if apiKey == "" {
-		t.Skip("WEATHER_API_KEY not set, skipping weather service tests")
+// update rewrites the golden files with the current output: go test -run Weather -update
+var update = flag.Bool("update", false, "rewrite golden files")
+
+// fakeWeatherAPI serves the canned WeatherAPI responses in testdata/weatherapi: current
+// conditions in London and a forecast for Paris. Other locations are unknown, and the keys
+// "invalid" and "exhausted" fail as revoked and over-quota keys do.
+type fakeWeatherAPI struct {
+	*httptest.Server
+	mu       sync.Mutex
+	requests []*http.Request
+}
+
+func newFakeWeatherAPI(t *testing.T) *fakeWeatherAPI {
+	t.Helper()
+	api := &fakeWeatherAPI{}
+	serve := func(w http.ResponseWriter, status int, name string) {
+		body, err := os.ReadFile(filepath.Join("testdata", "weatherapi", name))
+		if err != nil {
+			t.Errorf("fake WeatherAPI: %v", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		w.Write(body)
+	}
+	api.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		api.mu.Lock()
+		api.requests = append(api.requests, r)
+		api.mu.Unlock()
+
+		q := r.URL.Query()
+		switch {
+		case q.Get("key") == "invalid":
+			serve(w, http.StatusUnauthorized, "error_2006.json")
+		case q.Get("key") == "exhausted":
+			serve(w, http.StatusForbidden, "error_2007.json")
+		case r.URL.Path == "/current.json" && q.Get("q") == "London":
+			serve(w, http.StatusOK, "current_london.json")
+		case r.URL.Path == "/forecast.json" && q.Get("q") == "Paris":
+			serve(w, http.StatusOK, "forecast_paris.json")
+		default:
+			serve(w, http.StatusBadRequest, "error_1006.json")
+		}
+	}))
+	t.Cleanup(api.Close)
+	return api
+}
+
+// service returns a WeatherService calling the fake with apiKey.
+func (api *fakeWeatherAPI) service(apiKey string) *WeatherService {
+	s := NewWeatherService(apiKey)
+	s.baseURL = api.URL
+	return s
+}
+
+// lastQuery returns the query of the latest request received.
+func (api *fakeWeatherAPI) lastQuery() url.Values {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+	if len(api.requests) == 0 {
+		return nil
 	}
+	return api.requests[len(api.requests)-1].URL.Query()
+}
 
-	service := NewWeatherService(apiKey)
+// checkGolden compares got with testdata/golden/name, or rewrites it with -update.
+func checkGolden(t *testing.T, name, got string) {
+	t.Helper()
+	path := filepath.Join("testdata", "golden", name)
+	if *update {
+		if err := os.WriteFile(path, []byte(got), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("%v; run with -update to create it", err)
+	}
+	if got != string(want) {
+		t.Errorf("output differs from %s (run with -update to accept it):\n--- got ---\n%s\n--- want ---\n%s", path, got, want)
+	}
+}
+
+func TestWeatherService(t *testing.T) {
+	api := newFakeWeatherAPI(t)
 	ctx := context.Background()
 
-	t.Run("GetCurrentWeather", func(t *testing.T) {
-		weather, err := service.GetCurrentWeather(ctx, "London")
+	t.Run("current", func(t *testing.T) {
+		s := api.service(t.Name())
+		out, err := s.GetCurrentWeather(ctx, "London")
 		if err != nil {
-			t.Fatalf("Failed to get current weather: %v", err)
+			t.Fatalf("GetCurrentWeather: %v", err)
 		}
+		checkGolden(t, "current_london.golden", out)
 
-		if weather == "" {
-			t.Error("Weather response is empty")
+		q := api.lastQuery()
+		if q.Get("key") != t.Name() || q.Get("aqi") != "no" || q.Get("pollen") != "yes" {
+			t.Errorf("query = %v, want the key, without air quality and with pollen", q)
 		}
+	})
 
-		// Check that the response contains expected information
-		if !contains(weather, "London") {
-			t.Error("Weather response should contain location name")
+	t.Run("forecast", func(t *testing.T) {
+		s := api.service(t.Name())
+		out, err := s.GetForecast(ctx, "Paris", 3)
+		if err != nil {
+			t.Fatalf("GetForecast: %v", err)
 		}
+		checkGolden(t, "forecast_paris.golden", out)
 
-		if !contains(weather, "°C") {
-			t.Error("Weather response should contain temperature in Celsius")
+		if q := api.lastQuery(); q.Get("days") != "3" || q.Get("alerts") != "no" {
+			t.Errorf("query = %v, want 3 days without alerts", q)
 		}
+	})
 
-		// Check for new formatting (no emojis, clean structure)
-		if !contains(weather, "**Current Weather Conditions:**") {
-			t.Error("Weather response should contain formatted section header")
+	t.Run("without advisories", func(t *testing.T) {
+		s := api.service(t.Name())
+		w, err := s.Forecast(ctx, "Paris", 0)
+		if err != nil {
+			t.Fatalf("Forecast: %v", err)
 		}
+		checkGolden(t, "forecast_paris_no_advisories.golden", s.formatForecast(*w, false))
 
-		if !contains(weather, "**Temperature:**") {
-			t.Error("Weather response should contain formatted temperature label")
+		if q := api.lastQuery(); q.Get("days") != "3" {
+			t.Errorf("days = %q, want the default of 3", q.Get("days"))
 		}
 	})
 
-	t.Run("GetForecast", func(t *testing.T) {
-		forecast, err := service.GetForecast(ctx, "Paris", 3)
+	t.Run("errors", func(t *testing.T) {
+		cases := []struct {
+			name, key, location string
+			want                error
+		}{
+			{"unknown location", "valid", "Atlantis", ErrLocationNotFound},
+			{"invalid key", "invalid", "London", ErrProviderUnavailable},
+			{"quota exceeded", "exhausted", "London", ErrQuotaExceeded},
+		}
+		for _, tc := range cases {
+			t.Run(tc.name, func(t *testing.T) {
+				out, err := api.service(tc.key).GetCurrentWeather(ctx, tc.location)
+				if !errors.Is(err, tc.want) || out != "" {
+					t.Errorf("GetCurrentWeather(%q) = %q, %v; want %v", tc.location, out, err, tc.want)
+				}
+			})
+		}
+	})
+}
+
+func BenchmarkFormatWeather(b *testing.B) {
+	load := func(name string) WeatherResponse {
+		data, err := os.ReadFile(filepath.Join("testdata", "weatherapi", name))
 		if err != nil {
-			t.Fatalf("Failed to get forecast: %v", err)
+			b.Fatal(err)
+		}
+		var w WeatherResponse
+		if err := json.Unmarshal(data, &w); err != nil {
+			b.Fatal(err)
 		}
+		return w
+	}
+	s := NewWeatherService(b.Name())
+	current, forecast := load("current_london.json"), load("forecast_paris.json")
 
-		if forecast == "" {
-			t.Error("Forecast response is empty")
+	b.Run("current", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			s.formatCurrentWeather(current, true)
+		}
+	})
+	b.Run("forecast", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			s.formatForecast(forecast, true)
 		}
+	})
+}
 
-		// Check that the response contains expected information
-		if !contains(forecast, "Paris") {
-			t.Error("Forecast response should contain location name")
+func TestWeatherService_StaleWhileRevalidate(t *testing.T) {
+	var (
+		calls   atomic.Int32
+		temp    atomic.Int32
+		failing atomic.Bool
+		// block, when set, holds provider calls until it is closed.
+		block atomic.Pointer[chan struct{}]
+	)
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		if ch := block.Load(); ch != nil {
+			<-*ch
+		}
+		if failing.Load() {
+			w.WriteHeader(http.StatusInternalServerError)
+			fmt.Fprint(w, `{"error":{"code":9999,"message":"Internal application error."}}`)
+			return
 		}
+		fmt.Fprintf(w, `{"location":{"name":"Oslo","country":"Norway"},"current":{"temp_c":%d}}`, temp.Load())
+	}))
+	defer api.Close()
+
+	newService := func(freshFor time.Duration) *WeatherService {
+		s := NewWeatherService(t.Name())
+		s.baseURL = api.URL
+		s.freshFor = freshFor
+		s.staleWait = 20 * time.Millisecond
+		return s
+	}
+	ctx := context.Background()
 
-		if !contains(forecast, "Forecast") {
-			t.Error("Forecast response should contain forecast information")
+	t.Run("serves fresh entries from the cache", func(t *testing.T) {
+		calls.Store(0)
+		s := newService(time.Hour)
+
+		for range 3 {
+			if w, err := s.Current(ctx, "Oslo"); err != nil || w.Stale {
+				t.Fatalf("expected fresh data, got %v", err)
+			}
+		}
+		if got := calls.Load(); got != 1 {
+			t.Fatalf("expected 1 provider call, got %d", got)
 		}
+	})
 
-		// Check for new formatting (no emojis, clean structure)
-		if !contains(forecast, "**3-Day Weather Forecast:**") {
-			t.Error("Forecast response should contain formatted section header")
+	t.Run("serves stale entries while a slow provider refreshes them", func(t *testing.T) {
+		s := newService(0)
+		temp.Store(10)
+		if _, err := s.Current(ctx, "Oslo"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
 		}
 
-		if !contains(forecast, "**Today**") {
-			t.Error("Forecast response should contain formatted day header")
+		release := make(chan struct{})
+		block.Store(&release)
+		temp.Store(20)
+
+		start := time.Now()
+		w, err := s.Current(ctx, "Oslo")
+		if err != nil || !w.Stale || w.Current.TempC != 10 {
+			t.Fatalf("expected the stale entry, got %+v, %v", w, err)
 		}
+		if elapsed := time.Since(start); elapsed > time.Second {
+			t.Errorf("expected stale data without waiting for the provider, took %s", elapsed)
+		}
+		if out := s.formatCurrentWeather(*w, false); !strings.Contains(out, "old (fetched at") {
+			t.Errorf("expected stale data to be annotated with its age, got:\n%s", out)
+		}
+
+		close(release)
+		block.Store(nil)
 
-		if !contains(forecast, "**High:**") {
-			t.Error("Forecast response should contain formatted high temperature label")
+		// The background refresh updates the cache.
+		deadline := time.Now().Add(5 * time.Second)
+		for {
+			if last, ok := s.last.Get("/current.json?aqi=no&pollen=yes&q=Oslo"); ok && last.Current.TempC == 20 {
+				break
+			}
+			if time.Now().After(deadline) {
+				t.Fatal("expected the background refresh to update the cache")
+			}
+			time.Sleep(5 * time.Millisecond)
 		}
 	})
 
-	t.Run("InvalidLocation", func(t *testing.T) {
-		_, err := service.GetCurrentWeather(ctx, "InvalidLocation12345")
-		if err == nil {
-			t.Error("Expected error for invalid location")
+	t.Run("serves stale entries when the provider fails", func(t *testing.T) {
+		s := newService(0)
+		if _, err := s.Current(ctx, "Oslo"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
 		}
-	})
-}
 
-func contains(s, substr string) bool {
-	return len(s) >= len(substr) && (s == substr || len(s) > len(substr))
+		failing.Store(true)
+		defer failing.Store(false)
+
+		if w, err := s.Current(ctx, "Oslo"); err != nil || !w.Stale {
+			t.Fatalf("expected stale data, got %v", err)
+		}
+		if _, err := s.Current(ctx, "Bergen"); !errors.Is(err, ErrProviderUnavailable) {
+			t.Fatalf("expected ErrProviderUnavailable without a cached entry, got %v", err)
+		}
+	})
 }
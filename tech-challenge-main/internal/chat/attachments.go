@@ -0,0 +1,247 @@
+package chat
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/acai-travel/tech-challenge/internal/audit"
+	"github.com/acai-travel/tech-challenge/internal/auth"
+	"github.com/acai-travel/tech-challenge/internal/blob"
+	"github.com/acai-travel/tech-challenge/internal/chat/model"
+	"github.com/acai-travel/tech-challenge/internal/pb"
+	"github.com/acai-travel/tech-challenge/internal/textx"
+	"github.com/twitchtv/twirp"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ErrInfected is returned by a Scanner that found malware in a file.
+var ErrInfected = errors.New("file is infected")
+
+// Scanner checks uploaded files for malware before they are stored. It returns ErrInfected
+// (possibly wrapped) to reject a file; any other error fails the upload, so files are never
+// stored unscanned.
+type Scanner interface {
+	Scan(ctx context.Context, filename string, data []byte) error
+}
+
+// maxAttachmentsPerMessage bounds how many attachments one message may reference.
+const maxAttachmentsPerMessage = 10
+
+// attachmentTypes maps the content types clients may upload to the types
+// http.DetectContentType reports for their contents. Text formats can't be told apart by
+// sniffing, and MP3 files without an ID3 tag aren't recognized at all.
+var attachmentTypes = map[string][]string{
+	"image/png":        {"image/png"},
+	"image/jpeg":       {"image/jpeg"},
+	"image/gif":        {"image/gif"},
+	"image/webp":       {"image/webp"},
+	"audio/mpeg":       {"audio/mpeg", "application/octet-stream"},
+	"audio/wav":        {"audio/wave"},
+	"audio/ogg":        {"application/ogg"},
+	"audio/webm":       {"video/webm"},
+	"application/pdf":  {"application/pdf"},
+	"application/json": {"text/plain"},
+	"text/plain":       {"text/plain"},
+	"text/csv":         {"text/plain"},
+	"text/markdown":    {"text/plain"},
+}
+
+// attachments stores uploaded files; nil blobs means attachments are disabled.
+type attachments struct {
+	blobs   blob.Store
+	scanner Scanner
+	maxSize int64
+}
+
+// EnableAttachments stores uploads in store, after checking them with scanner if not nil.
+// ATTACHMENT_MAX_BYTES bounds the size of a file (default 10 MiB).
+func (s *Server) EnableAttachments(store blob.Store, scanner Scanner) {
+	s.attachments = attachments{
+		blobs:   store,
+		scanner: scanner,
+		maxSize: int64(envInt("ATTACHMENT_MAX_BYTES", 10<<20)),
+	}
+}
+
+var errAttachmentsDisabled = twirp.NewError(twirp.Unimplemented, "attachments are not enabled")
+
+// validateAttachment checks an upload against the size limit and the allowed content types.
+// It returns the media type without parameters.
+func (a attachments) validateAttachment(contentType string, data []byte) (string, error) {
+	if len(data) == 0 {
+		return "", twirp.RequiredArgumentError("data")
+	}
+	if int64(len(data)) > a.maxSize {
+		return "", twirp.InvalidArgumentError("data", fmt.Sprintf("must be at most %d bytes", a.maxSize))
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return "", twirp.InvalidArgumentError("content_type", "must be a MIME type, e.g. image/png")
+	}
+
+	sniffable, ok := attachmentTypes[mediaType]
+	if !ok {
+		allowed := make([]string, 0, len(attachmentTypes))
+		for t := range attachmentTypes {
+			allowed = append(allowed, t)
+		}
+		slices.Sort(allowed)
+		return "", twirp.InvalidArgumentError("content_type", "must be one of: "+strings.Join(allowed, ", "))
+	}
+
+	detected, _, _ := mime.ParseMediaType(http.DetectContentType(data))
+	if !slices.Contains(sniffable, detected) {
+		return "", twirp.InvalidArgumentError("content_type", fmt.Sprintf("does not match the file contents (detected %s)", detected))
+	}
+
+	return mediaType, nil
+}
+
+// cleanFilename keeps the base name of an uploaded file, without directories or control characters.
+func cleanFilename(name string) string {
+	name = path.Base(strings.ReplaceAll(textx.Clean(name), `\`, "/"))
+	name = strings.Map(func(r rune) rune {
+		if r < 0x20 || r == 0x7f {
+			return -1
+		}
+		return r
+	}, name)
+	name = textx.Truncate(strings.TrimSpace(name), 255)
+	if name == "" || name == "." || name == "/" {
+		return "attachment"
+	}
+	return name
+}
+
+func (s *Server) UploadAttachment(ctx context.Context, req *pb.UploadAttachmentRequest) (*pb.UploadAttachmentResponse, error) {
+	if s.attachments.blobs == nil {
+		return nil, errAttachmentsDisabled
+	}
+
+	attachment, err := s.storeAttachment(ctx, req.GetFilename(), req.GetContentType(), req.GetData())
+	if err != nil {
+		return nil, err
+	}
+	return &pb.UploadAttachmentResponse{Attachment: attachment.Proto()}, nil
+}
+
+// storeAttachment validates, scans and stores a file of the caller, for them to send with a
+// message. Attachments must be enabled.
+func (s *Server) storeAttachment(ctx context.Context, filename, contentType string, data []byte) (*model.Attachment, error) {
+	contentType, err := s.attachments.validateAttachment(contentType, data)
+	if err != nil {
+		return nil, err
+	}
+
+	filename = cleanFilename(filename)
+	if s.attachments.scanner != nil {
+		if err := s.attachments.scanner.Scan(ctx, filename, data); errors.Is(err, ErrInfected) {
+			return nil, twirp.InvalidArgumentError("data", "was rejected by the malware scan")
+		} else if err != nil {
+			return nil, twirp.InternalErrorWith(fmt.Errorf("scanning attachment: %w", err))
+		}
+	}
+
+	sum := sha256.Sum256(data)
+	attachment := &model.Attachment{
+		ID:          primitive.NewObjectID(),
+		UserID:      auth.User(ctx),
+		Filename:    filename,
+		ContentType: contentType,
+		Size:        int64(len(data)),
+		SHA256:      hex.EncodeToString(sum[:]),
+		CreatedAt:   time.Now(),
+	}
+
+	audit.Detail(ctx, "attachment_id", attachment.ID.Hex())
+
+	// Store the contents first: metadata without contents would be a broken attachment.
+	if err := s.attachments.blobs.Put(ctx, attachment.ID.Hex(), bytes.NewReader(data)); err != nil {
+		return nil, twirp.InternalErrorWith(err)
+	}
+	if err := s.repo.CreateAttachment(ctx, attachment); err != nil {
+		if derr := s.attachments.blobs.Delete(context.WithoutCancel(ctx), attachment.ID.Hex()); derr != nil {
+			slog.ErrorContext(ctx, "Failed to delete orphaned attachment", "attachment_id", attachment.ID.Hex(), "error", derr)
+		}
+		return nil, twirp.InternalErrorWith(err)
+	}
+	return attachment, nil
+}
+
+func (s *Server) DownloadAttachment(ctx context.Context, req *pb.DownloadAttachmentRequest) (*pb.DownloadAttachmentResponse, error) {
+	if req.GetAttachmentId() == "" {
+		return nil, twirp.RequiredArgumentError("attachment_id")
+	}
+	if s.attachments.blobs == nil {
+		return nil, errAttachmentsDisabled
+	}
+
+	audit.Detail(ctx, "attachment_id", req.GetAttachmentId())
+	attachment, err := s.repo.FindAttachment(ctx, req.GetAttachmentId(), auth.User(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := s.attachments.blobs.Get(ctx, attachment.ID.Hex())
+	if errors.Is(err, blob.ErrNotFound) {
+		return nil, twirp.NotFoundError("attachment contents not found")
+	}
+	if err != nil {
+		return nil, twirp.InternalErrorWith(err)
+	}
+	defer r.Close()
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, twirp.InternalErrorWith(err)
+	}
+
+	return &pb.DownloadAttachmentResponse{Attachment: attachment.Proto(), Data: data}, nil
+}
+
+// resolveAttachments loads the attachments a new message references, which must have been
+// uploaded by the caller.
+func (s *Server) resolveAttachments(ctx context.Context, ids []string) ([]*model.Attachment, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	if s.attachments.blobs == nil {
+		return nil, errAttachmentsDisabled
+	}
+	if len(ids) > maxAttachmentsPerMessage {
+		return nil, twirp.InvalidArgumentError("attachment_ids", fmt.Sprintf("must have at most %d entries", maxAttachmentsPerMessage))
+	}
+
+	var out []*model.Attachment
+	seen := map[string]bool{}
+	for _, id := range ids {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		a, err := s.repo.FindAttachment(ctx, id, auth.User(ctx))
+		var te twirp.Error
+		if errors.As(err, &te) && te.Code() == twirp.NotFound {
+			return nil, twirp.InvalidArgumentError("attachment_ids", fmt.Sprintf("attachment %q not found", id))
+		}
+		if err != nil {
+			return nil, twirp.InternalErrorWith(err)
+		}
+		out = append(out, a)
+	}
+	return out, nil
+}
@@ -0,0 +1,162 @@
+package chat
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/acai-travel/tech-challenge/internal/auth"
+	"github.com/acai-travel/tech-challenge/internal/blob"
+	"github.com/acai-travel/tech-challenge/internal/chat/model"
+	. "github.com/acai-travel/tech-challenge/internal/chat/testing"
+	"github.com/acai-travel/tech-challenge/internal/pb"
+	"github.com/twitchtv/twirp"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var pngHeader = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+type scannerFunc func(ctx context.Context, filename string, data []byte) error
+
+func (f scannerFunc) Scan(ctx context.Context, filename string, data []byte) error {
+	return f(ctx, filename, data)
+}
+
+func TestValidateAttachment(t *testing.T) {
+	a := attachments{maxSize: 64}
+
+	cases := []struct {
+		name        string
+		contentType string
+		data        []byte
+		want        string
+		wantErr     bool
+	}{
+		{name: "png", contentType: "image/png", data: pngHeader, want: "image/png"},
+		{name: "parameters are dropped", contentType: "text/plain; charset=utf-8", data: []byte("hello"), want: "text/plain"},
+		{name: "json sniffs as text", contentType: "application/json", data: []byte(`{"a":1}`), want: "application/json"},
+		{name: "empty", contentType: "text/plain", wantErr: true},
+		{name: "too large", contentType: "text/plain", data: bytes.Repeat([]byte("a"), 65), wantErr: true},
+		{name: "not a MIME type", contentType: "png", data: pngHeader, wantErr: true},
+		{name: "type not allowed", contentType: "application/x-msdownload", data: []byte("MZ"), wantErr: true},
+		{name: "contents don't match", contentType: "image/jpeg", data: pngHeader, wantErr: true},
+		{name: "text posing as image", contentType: "image/png", data: []byte("hello"), wantErr: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := a.validateAttachment(tc.contentType, tc.data)
+			if tc.wantErr {
+				if te, ok := err.(twirp.Error); !ok || te.Code() != twirp.InvalidArgument {
+					t.Fatalf("expected InvalidArgument, got %v", err)
+				}
+				return
+			}
+			if err != nil || got != tc.want {
+				t.Fatalf("got %q, %v; want %q", got, err, tc.want)
+			}
+		})
+	}
+}
+
+func TestCleanFilename(t *testing.T) {
+	for in, want := range map[string]string{
+		"photo.png":             "photo.png",
+		"../../etc/passwd":      "passwd",
+		`C:\Users\me\notes.txt`: "notes.txt",
+		"  trip\x00plan.pdf ":   "tripplan.pdf",
+		"":                      "attachment",
+		"/":                     "attachment",
+	} {
+		if got := cleanFilename(in); got != want {
+			t.Errorf("cleanFilename(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestServer_UploadAttachment_Rejected(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("disabled by default", func(t *testing.T) {
+		srv := NewServer(model.New(ConnectMongo()), nil)
+		_, err := srv.UploadAttachment(ctx, &pb.UploadAttachmentRequest{ContentType: "image/png", Data: pngHeader})
+		if te, ok := err.(twirp.Error); !ok || te.Code() != twirp.Unimplemented {
+			t.Fatalf("expected Unimplemented, got %v", err)
+		}
+	})
+
+	t.Run("infected files are not stored", func(t *testing.T) {
+		store := blob.NewMemory()
+		srv := NewServer(model.New(ConnectMongo()), nil)
+		srv.EnableAttachments(store, scannerFunc(func(_ context.Context, filename string, _ []byte) error {
+			return fmt.Errorf("%s: %w", filename, ErrInfected)
+		}))
+
+		_, err := srv.UploadAttachment(ctx, &pb.UploadAttachmentRequest{Filename: "eicar.txt", ContentType: "text/plain", Data: []byte("X5O!P%@AP")})
+		if te, ok := err.(twirp.Error); !ok || te.Code() != twirp.InvalidArgument {
+			t.Fatalf("expected InvalidArgument, got %v", err)
+		}
+	})
+}
+
+func TestServer_Attachments(t *testing.T) {
+	alice := auth.WithUser(context.Background(), "alice-"+primitive.NewObjectID().Hex())
+	bob := auth.WithUser(context.Background(), "bob-"+primitive.NewObjectID().Hex())
+
+	srv := NewServer(model.New(ConnectMongo()), &fakeAssistant{
+		replyFn: func(_ context.Context, conv *model.Conversation) (string, error) {
+			last := conv.Messages[len(conv.Messages)-1]
+			if len(last.Attachments) != 1 || last.Attachments[0].Filename != "map.png" {
+				return "", fmt.Errorf("expected map.png to be attached, got %v", last.Attachments)
+			}
+			return "Nice map!", nil
+		},
+	})
+	srv.EnableAttachments(blob.NewMemory(), nil)
+
+	t.Run("upload, attach and download", WithFixture(func(t *testing.T, f *Fixture) {
+		up, err := srv.UploadAttachment(alice, &pb.UploadAttachmentRequest{Filename: "trips/map.png", ContentType: "image/png", Data: pngHeader})
+		if err != nil {
+			t.Fatalf("UploadAttachment error: %v", err)
+		}
+		id := up.GetAttachment().GetId()
+		if got := up.GetAttachment().GetFilename(); got != "map.png" {
+			t.Errorf("filename: got %q want map.png", got)
+		}
+		if got := up.GetAttachment().GetSize(); got != int64(len(pngHeader)) {
+			t.Errorf("size: got %d want %d", got, len(pngHeader))
+		}
+
+		down, err := srv.DownloadAttachment(alice, &pb.DownloadAttachmentRequest{AttachmentId: id})
+		if err != nil {
+			t.Fatalf("DownloadAttachment error: %v", err)
+		}
+		if !bytes.Equal(down.GetData(), pngHeader) {
+			t.Errorf("downloaded data differs from upload")
+		}
+
+		if _, err := srv.DownloadAttachment(bob, &pb.DownloadAttachmentRequest{AttachmentId: id}); err == nil {
+			t.Error("expected another user's attachment not to be found")
+		}
+
+		c := f.CreateConversation()
+		if _, err := srv.ContinueConversation(alice, &pb.ContinueConversationRequest{ConversationId: c.ID.Hex(), Message: "Here is the map", AttachmentIds: []string{id, id}}); err != nil {
+			t.Fatalf("ContinueConversation error: %v", err)
+		}
+
+		out, err := srv.DescribeConversation(alice, &pb.DescribeConversationRequest{ConversationId: c.ID.Hex()})
+		if err != nil {
+			t.Fatalf("DescribeConversation error: %v", err)
+		}
+		msgs := out.GetConversation().GetMessages()
+		if got := msgs[len(msgs)-2].GetAttachments(); len(got) != 1 || got[0].GetId() != id {
+			t.Errorf("expected the user message to reference the attachment, got %v", got)
+		}
+
+		_, err = srv.ContinueConversation(bob, &pb.ContinueConversationRequest{ConversationId: c.ID.Hex(), Message: "Mine now", AttachmentIds: []string{id}})
+		if te, ok := err.(twirp.Error); !ok || te.Code() != twirp.InvalidArgument {
+			t.Errorf("expected InvalidArgument when attaching another user's file, got %v", err)
+		}
+	}))
+}
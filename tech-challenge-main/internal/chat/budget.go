@@ -0,0 +1,145 @@
+package chat
+
+import (
+	"context"
+	"errors"
+	"expvar"
+	"log/slog"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/twitchtv/twirp"
+)
+
+// budgetExhausted counts, by "RPC/stage", the requests and stages cut short by their budget
+// rather than by the client, to tell which limits are too tight.
+var budgetExhausted = expvar.NewMap("chat_budget_exhausted")
+
+// budget bounds how long a request may spend generating, shared by every RPC that calls
+// the assistant. Stages get their own limit within the request's, and the request always
+// keeps a margin to persist what was generated.
+type budget struct {
+	// rpc names the RPC the budget applies to in metrics; empty for the defaults.
+	rpc string
+	// request caps generation for the whole request, including all stages.
+	request time.Duration
+	// title caps title generation; titles are optional, so they must not eat the reply's time.
+	// Other optional stages, e.g. language detection and quick replies, share it.
+	title time.Duration
+	// margin is kept back from every stage for the writes that follow it.
+	margin time.Duration
+	// rpcs overrides the budget of some RPCs, by name.
+	rpcs map[string]budget
+}
+
+// loadBudget reads CHAT_REQUEST_TIMEOUT, CHAT_TITLE_TIMEOUT and CHAT_BUDGET_MARGIN (Go
+// durations, e.g. "45s"), defaulting to 30s, 15s and 500ms, and CHAT_RPC_TIMEOUTS, which
+// overrides them for some RPCs as comma-separated RPC/stage=duration, e.g.
+// "StartConversation/title=5s,ContinueConversation=45s" (the stage defaults to request).
+func loadBudget() budget {
+	b := budget{
+		request: envDuration("CHAT_REQUEST_TIMEOUT", 30*time.Second),
+		title:   envDuration("CHAT_TITLE_TIMEOUT", 15*time.Second),
+		margin:  envDuration("CHAT_BUDGET_MARGIN", 500*time.Millisecond),
+	}
+	if v := os.Getenv("CHAT_RPC_TIMEOUTS"); v != "" {
+		rpcs, err := b.parseRPCs(v)
+		if err != nil {
+			slog.Warn("Ignoring CHAT_RPC_TIMEOUTS", "error", err)
+		}
+		b.rpcs = rpcs
+	}
+	return b
+}
+
+// parseRPCs parses CHAT_RPC_TIMEOUTS into overrides of b, keeping the valid entries.
+func (b budget) parseRPCs(v string) (map[string]budget, error) {
+	rpcs := map[string]budget{}
+	var errs []error
+	for _, entry := range strings.Split(v, ",") {
+		key, d, ok := strings.Cut(strings.TrimSpace(entry), "=")
+		limit, err := time.ParseDuration(d)
+		rpc, stage, _ := strings.Cut(key, "/")
+		if !ok || rpc == "" || err != nil || limit <= 0 {
+			errs = append(errs, errors.New("invalid entry "+entry+": want RPC/stage=duration"))
+			continue
+		}
+
+		o, ok := rpcs[rpc]
+		if !ok {
+			o = budget{rpc: rpc, request: b.request, title: b.title, margin: b.margin}
+		}
+		switch stage {
+		case "", "request":
+			o.request = limit
+		case "title":
+			o.title = limit
+		default:
+			errs = append(errs, errors.New("invalid entry "+entry+": the stage is request or title"))
+			continue
+		}
+		rpcs[rpc] = o
+	}
+	return rpcs, errors.Join(errs...)
+}
+
+func envDuration(key string, def time.Duration) time.Duration {
+	if d, err := time.ParseDuration(os.Getenv(key)); err == nil && d > 0 {
+		return d
+	}
+	return def
+}
+
+// of returns the budget of the RPC ctx serves.
+func (b budget) of(ctx context.Context) budget {
+	rpc, ok := twirp.MethodName(ctx)
+	if !ok {
+		rpc = "other"
+	}
+	if o, ok := b.rpcs[rpc]; ok {
+		return o
+	}
+	b.rpc, b.rpcs = rpc, nil
+	return b
+}
+
+// longest is the longest a request may take, whatever its RPC.
+func (b budget) longest() time.Duration {
+	longest := b.request
+	for _, o := range b.rpcs {
+		longest = max(longest, o.request)
+	}
+	return longest
+}
+
+// start bounds a request. A deadline already on ctx, e.g. from the client, is kept if earlier.
+func (b budget) start(ctx context.Context) (context.Context, context.CancelFunc) {
+	return b.bound(ctx, "request", b.request)
+}
+
+// stage bounds the stage of a request called name to limit, but never beyond the request's
+// deadline minus the margin. A stage always gets at least the margin, so it can fail fast
+// rather than not run at all.
+func (b budget) stage(ctx context.Context, name string, limit time.Duration) (context.Context, context.CancelFunc) {
+	if dl, ok := ctx.Deadline(); ok {
+		rem := time.Until(dl) - b.margin
+		if rem < limit {
+			limit = max(rem, b.margin)
+		}
+	}
+	return b.bound(ctx, name, limit)
+}
+
+// bound is context.WithTimeout, counting in budgetExhausted when the timeout, rather than
+// an earlier deadline of ctx, ends the stage.
+func (b budget) bound(ctx context.Context, stage string, limit time.Duration) (context.Context, context.CancelFunc) {
+	exhausted := errors.New("chat: " + stage + " budget exhausted")
+	ctx, cancel := context.WithTimeoutCause(ctx, limit, exhausted)
+	return ctx, func() {
+		if context.Cause(ctx) == exhausted {
+			budgetExhausted.Add(b.rpc+"/"+stage, 1)
+		}
+		cancel()
+	}
+}
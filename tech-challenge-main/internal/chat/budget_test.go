@@ -0,0 +1,114 @@
+package chat
+
+import (
+	"context"
+	"expvar"
+	"testing"
+	"time"
+
+	"github.com/twitchtv/twirp/ctxsetters"
+)
+
+func TestBudgetStage(t *testing.T) {
+	b := budget{request: 2 * time.Second, title: 10 * time.Second, margin: 100 * time.Millisecond}
+
+	remaining := func(ctx context.Context) time.Duration {
+		dl, ok := ctx.Deadline()
+		if !ok {
+			t.Fatal("expected a deadline")
+		}
+		return time.Until(dl)
+	}
+
+	ctx, cancel := b.start(context.Background())
+	defer cancel()
+
+	// A stage limit above the request's leaves the margin for persisting.
+	title, cancelTitle := b.stage(ctx, "title", b.title)
+	defer cancelTitle()
+	if got := remaining(title); got > 1900*time.Millisecond || got < 1800*time.Millisecond {
+		t.Errorf("title stage: %v remaining, want about 1.9s", got)
+	}
+
+	// A smaller stage limit applies as is.
+	short, cancelShort := b.stage(ctx, "short", 300*time.Millisecond)
+	defer cancelShort()
+	if got := remaining(short); got > 300*time.Millisecond || got < 200*time.Millisecond {
+		t.Errorf("short stage: %v remaining, want about 300ms", got)
+	}
+
+	// An almost expired request still gives the stage the margin.
+	late, cancelLate := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancelLate()
+	stage, cancelStage := b.stage(late, "title", b.title)
+	defer cancelStage()
+	if got := remaining(stage); got > 10*time.Millisecond {
+		t.Errorf("late stage: %v remaining, want the parent's deadline", got)
+	}
+
+	// A client deadline earlier than the budget is kept.
+	client, cancelClient := context.WithTimeout(context.Background(), time.Second)
+	defer cancelClient()
+	req, cancelReq := b.start(client)
+	defer cancelReq()
+	if got := remaining(req); got > time.Second {
+		t.Errorf("request: %v remaining, want the client's 1s", got)
+	}
+}
+
+func TestLoadBudget_RPCs(t *testing.T) {
+	t.Setenv("CHAT_REQUEST_TIMEOUT", "20s")
+	t.Setenv("CHAT_RPC_TIMEOUTS", "ContinueConversation=45s, StartConversation/title=5s, Broken/reply=1s")
+	b := loadBudget()
+
+	for rpc, want := range map[string]struct{ request, title time.Duration }{
+		"ContinueConversation": {45 * time.Second, 15 * time.Second},
+		"StartConversation":    {20 * time.Second, 5 * time.Second},
+		"DescribeConversation": {20 * time.Second, 15 * time.Second},
+	} {
+		got := b.of(ctxsetters.WithMethodName(context.Background(), rpc))
+		if got.rpc != rpc || got.request != want.request || got.title != want.title || got.margin != 500*time.Millisecond {
+			t.Errorf("%s: budget %+v, want request %v and title %v", rpc, got, want.request, want.title)
+		}
+	}
+	if _, ok := b.rpcs["Broken"]; ok {
+		t.Error("an entry with an unknown stage was kept")
+	}
+	if got := b.longest(); got != 45*time.Second {
+		t.Errorf("longest = %v, want 45s", got)
+	}
+}
+
+func TestBudgetExhausted(t *testing.T) {
+	b := budget{rpc: "TestRPC", request: 20 * time.Millisecond, title: time.Second, margin: time.Millisecond}
+	count := func(key string) int64 {
+		if v, ok := budgetExhausted.Get(key).(*expvar.Int); ok {
+			return v.Value()
+		}
+		return 0
+	}
+
+	ctx, cancel := b.start(context.Background())
+	<-ctx.Done()
+	cancel()
+	if got := count("TestRPC/request"); got != 1 {
+		t.Errorf("request exhausted %d times, want 1", got)
+	}
+
+	// Deadlines of the client aren't the budget's.
+	client, cancelClient := context.WithTimeout(context.Background(), 5*time.Millisecond)
+	defer cancelClient()
+	ctx, cancel = b.start(client)
+	<-ctx.Done()
+	cancel()
+	if got := count("TestRPC/request"); got != 1 {
+		t.Errorf("request exhausted %d times after a client timeout, want 1", got)
+	}
+
+	// Stages finishing in time aren't counted.
+	_, cancel = b.stage(context.Background(), "title", b.title)
+	cancel()
+	if got := count("TestRPC/title"); got != 0 {
+		t.Errorf("title exhausted %d times, want 0", got)
+	}
+}
@@ -0,0 +1,305 @@
+package chat
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/acai-travel/tech-challenge/internal/audit"
+	"github.com/acai-travel/tech-challenge/internal/auth"
+	"github.com/acai-travel/tech-challenge/internal/chat/model"
+	"github.com/acai-travel/tech-challenge/internal/pb"
+	"github.com/twitchtv/twirp"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	// bulkBatchSize bounds the conversations written per database operation.
+	bulkBatchSize = 500
+	// bulkJobThreshold is the largest set processed within the request; larger sets run
+	// as a background job.
+	bulkJobThreshold = 1_000
+	// maxBulkIDs bounds the IDs a request may list; filters select any number.
+	maxBulkIDs = 10_000
+	// bulkJobTimeout bounds a background job.
+	bulkJobTimeout = 30 * time.Minute
+	// bulkJobLease is how long a job is presumed running without its instance renewing it.
+	bulkJobLease = 2 * time.Minute
+
+	maxTags      = 20
+	maxTagLength = 50
+)
+
+// validateTags cleans the tags of a new conversation: trimmed, lowercased and without
+// duplicates, so filtering by tag doesn't depend on how it was typed.
+func validateTags(in []string) ([]string, error) {
+	if len(in) > maxTags {
+		return nil, twirp.InvalidArgumentError("tags", fmt.Sprintf("must have at most %d tags", maxTags))
+	}
+
+	var out []string
+	for _, t := range in {
+		t = strings.ToLower(strings.TrimSpace(t))
+		switch {
+		case t == "":
+			return nil, twirp.InvalidArgumentError("tags", "must not be empty")
+		case len([]rune(t)) > maxTagLength:
+			return nil, twirp.InvalidArgumentError("tags", fmt.Sprintf("must be at most %d characters", maxTagLength))
+		}
+		if !slices.Contains(out, t) {
+			out = append(out, t)
+		}
+	}
+	return out, nil
+}
+
+func (s *Server) BulkDeleteConversations(ctx context.Context, req *pb.BulkDeleteConversationsRequest) (*pb.BulkDeleteConversationsResponse, error) {
+	count, jobID, err := s.runBulk(ctx, model.BulkDelete, req.GetConversationIds(), req.GetFilter())
+	if err != nil {
+		return nil, err
+	}
+	return &pb.BulkDeleteConversationsResponse{Count: int32(count), JobId: jobID}, nil
+}
+
+func (s *Server) BulkArchiveConversations(ctx context.Context, req *pb.BulkArchiveConversationsRequest) (*pb.BulkArchiveConversationsResponse, error) {
+	count, jobID, err := s.runBulk(ctx, model.BulkArchive, req.GetConversationIds(), req.GetFilter())
+	if err != nil {
+		return nil, err
+	}
+	return &pb.BulkArchiveConversationsResponse{Count: int32(count), JobId: jobID}, nil
+}
+
+func (s *Server) GetBulkJob(ctx context.Context, req *pb.GetBulkJobRequest) (*pb.GetBulkJobResponse, error) {
+	if req.GetJobId() == "" {
+		return nil, twirp.RequiredArgumentError("job_id")
+	}
+
+	job, err := s.repo.FindBulkJob(ctx, req.GetJobId(), auth.User(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.GetBulkJobResponse{Job: job.Proto()}, nil
+}
+
+// runBulk applies action to the selected conversations, within the request for small sets
+// and otherwise in a background job whose ID it returns.
+func (s *Server) runBulk(ctx context.Context, action model.BulkAction, rawIDs []string, filter *pb.ConversationFilter) (int, string, error) {
+	if auth.User(ctx) == auth.Anonymous {
+		return 0, "", twirp.NewError(twirp.Unauthenticated, "bulk operations require an identified user")
+	}
+	ids, err := s.bulkTargets(ctx, rawIDs, filter)
+	if err != nil {
+		return 0, "", err
+	}
+	audit.Detail(ctx, "count", fmt.Sprint(len(ids)))
+
+	if len(ids) <= bulkJobThreshold {
+		count, err := s.applyBulk(ctx, action, ids, nil)
+		if err != nil {
+			return count, "", twirp.InternalErrorWith(err)
+		}
+		return count, "", nil
+	}
+
+	now := time.Now()
+	job := &model.BulkJob{
+		ID:        primitive.NewObjectID(),
+		UserID:    auth.User(ctx),
+		Action:    action,
+		State:     model.BulkJobRunning,
+		Total:     len(ids),
+		CreatedAt: now,
+		UpdatedAt: now,
+		// Renewed by the job for as long as it runs.
+		LeaseExpiresAt: now.Add(bulkJobLease),
+	}
+	if err := s.repo.CreateBulkJob(ctx, job); err != nil {
+		return 0, "", twirp.InternalErrorWith(err)
+	}
+	audit.Detail(ctx, "job_id", job.ID.Hex())
+
+	go s.runBulkJob(job, ids)
+	return 0, job.ID.Hex(), nil
+}
+
+// bulkTargets resolves the conversations a bulk request selects: exactly one of ids and
+// filter, among those the caller owns. Conversations visible to all are left alone: they
+// may be anyone's.
+func (s *Server) bulkTargets(ctx context.Context, rawIDs []string, filter *pb.ConversationFilter) ([]primitive.ObjectID, error) {
+	hasFilter := filter.GetOlderThan() != nil || filter.GetTag() != ""
+	switch {
+	case len(rawIDs) == 0 && !hasFilter:
+		return nil, twirp.RequiredArgumentError("conversation_ids or filter")
+	case len(rawIDs) > 0 && hasFilter:
+		return nil, twirp.InvalidArgumentError("filter", "must not be set with conversation_ids")
+	case len(rawIDs) > maxBulkIDs:
+		return nil, twirp.InvalidArgumentError("conversation_ids", fmt.Sprintf("must have at most %d IDs; use a filter for more", maxBulkIDs))
+	}
+
+	if hasFilter {
+		f := model.ConversationFilter{Tag: strings.ToLower(strings.TrimSpace(filter.GetTag())), Owner: auth.User(ctx)}
+		if filter.GetOlderThan() != nil {
+			f.OlderThan = filter.GetOlderThan().AsTime()
+		}
+		ids, err := s.repo.FindConversationIDs(ctx, f)
+		if err != nil {
+			return nil, twirp.InternalErrorWith(err)
+		}
+		return ids, nil
+	}
+
+	ids := make([]primitive.ObjectID, 0, len(rawIDs))
+	seen := make(map[primitive.ObjectID]bool, len(rawIDs))
+	for _, raw := range rawIDs {
+		id, err := primitive.ObjectIDFromHex(raw)
+		if err != nil {
+			return nil, twirp.InvalidArgumentError("conversation_ids", fmt.Sprintf("%q is not a conversation ID", raw))
+		}
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+
+	// Conversations the caller doesn't own are left alone, as if they didn't exist.
+	ids, err := s.repo.FindConversationIDs(ctx, model.ConversationFilter{IDs: ids, Owner: auth.User(ctx)})
+	if err != nil {
+		return nil, twirp.InternalErrorWith(err)
+	}
+	return ids, nil
+}
+
+// applyBulk applies action to ids in batches, calling progress after each, and reports how
+// many conversations it changed.
+func (s *Server) applyBulk(ctx context.Context, action model.BulkAction, ids []primitive.ObjectID, progress func(count int)) (int, error) {
+	count := 0
+	now := time.Now()
+	for batch := range slices.Chunk(ids, bulkBatchSize) {
+		var (
+			n   int
+			err error
+		)
+		switch action {
+		case model.BulkDelete:
+			n, err = s.repo.DeleteConversations(ctx, batch)
+		case model.BulkArchive:
+			n, err = s.repo.ArchiveConversations(ctx, batch, now)
+		default:
+			return count, fmt.Errorf("unknown bulk action %q", action)
+		}
+		count += n
+		if err != nil {
+			return count, err
+		}
+		if progress != nil {
+			progress(count)
+		}
+	}
+	return count, nil
+}
+
+func (s *Server) runBulkJob(job *model.BulkJob, ids []primitive.ObjectID) {
+	save := func(ctx context.Context) {
+		job.UpdatedAt = time.Now()
+		if err := s.repo.UpdateBulkJob(ctx, job); err != nil {
+			slog.ErrorContext(ctx, "Failed to save bulk job progress", "job_id", job.ID.Hex(), "error", err)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), bulkJobTimeout)
+	stopLease := s.holdBulkJob(ctx, job)
+	count, err := s.applyBulk(ctx, job.Action, ids, func(count int) {
+		job.Count = count
+		save(ctx)
+	})
+	stopLease()
+	cancel()
+
+	job.Count = count
+	job.State = model.BulkJobDone
+	if err != nil {
+		job.State, job.Error = model.BulkJobFailed, err.Error()
+		slog.Error("Bulk job failed", "job_id", job.ID.Hex(), "action", job.Action, "count", count, "error", err)
+	}
+
+	// The job's own context may have timed out.
+	ctx, cancel = context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	save(ctx)
+}
+
+// errJobLost is the failure recorded for jobs lost when the server running them stopped.
+var errJobLost = errors.New("the server stopped while running the job; start it again")
+
+// holdBulkJob renews the lease of a running job until the returned function is called, so
+// RecoverBulkJobs leaves it alone.
+func (s *Server) holdBulkJob(ctx context.Context, job *model.BulkJob) func() {
+	ctx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		t := time.NewTicker(bulkJobLease / 4)
+		defer t.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case now := <-t.C:
+				if err := s.repo.RenewBulkJobLease(ctx, job.ID, now.Add(bulkJobLease)); err != nil && ctx.Err() == nil {
+					slog.WarnContext(ctx, "Failed to renew bulk job lease", "job_id", job.ID.Hex(), "error", err)
+				}
+			}
+		}
+	}()
+	return func() {
+		cancel()
+		<-done
+	}
+}
+
+// RecoverBulkJobs marks jobs lost by a server stop as failed, so users polling GetBulkJob
+// learn to start them again instead of waiting forever. Like RecoverGenerations, it sweeps at
+// once and then periodically until ctx is done; jobs still running on another instance keep
+// their lease renewed.
+func (s *Server) RecoverBulkJobs(ctx context.Context) {
+	for {
+		n, err := s.sweepBulkJobs(ctx, time.Now())
+		if err != nil && ctx.Err() == nil {
+			slog.ErrorContext(ctx, "Failed to recover lost bulk jobs", "error", err)
+		}
+		if n > 0 {
+			slog.InfoContext(ctx, "Marked lost bulk jobs as failed", "count", n)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(bulkJobLease):
+		}
+	}
+}
+
+// sweepBulkJobs fails every running job whose lease expired before now, and reports how
+// many it failed.
+func (s *Server) sweepBulkJobs(ctx context.Context, now time.Time) (int, error) {
+	n := 0
+	for {
+		job, err := s.repo.ClaimLostBulkJob(ctx, now, errJobLost.Error())
+		if err != nil || job == nil {
+			return n, err
+		}
+		n++
+		slog.WarnContext(ctx, "Bulk job lost", "job_id", job.ID.Hex(), "action", job.Action, "count", job.Count)
+
+		if job.Action == model.BulkExport && s.exports.notify != nil {
+			if err := s.exports.notify.JobFinished(ctx, job); err != nil {
+				slog.ErrorContext(ctx, "Failed to notify export job end", "job_id", job.ID.Hex(), "error", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,159 @@
+package chat
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/acai-travel/tech-challenge/internal/auth"
+	"github.com/acai-travel/tech-challenge/internal/chat/model"
+	. "github.com/acai-travel/tech-challenge/internal/chat/testing"
+	"github.com/acai-travel/tech-challenge/internal/pb"
+	"github.com/google/uuid"
+	"github.com/twitchtv/twirp"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestValidateTags(t *testing.T) {
+	got, err := validateTags([]string{" Trip-2025 ", "trip-2025", "work"})
+	if err != nil || !slices.Equal(got, []string{"trip-2025", "work"}) {
+		t.Errorf("validateTags = %q, %v; want cleaned, unique tags", got, err)
+	}
+
+	for _, bad := range [][]string{{" "}, {string(make([]rune, maxTagLength+1))}, make([]string, maxTags+1)} {
+		if _, err := validateTags(bad); err == nil {
+			t.Errorf("validateTags(%q) should fail", bad)
+		}
+	}
+}
+
+func TestBulkConversations_InvalidRequests(t *testing.T) {
+	srv := NewServer(nil, &fakeAssistant{})
+	ctx := auth.WithUser(context.Background(), "alice")
+
+	tests := []struct {
+		name string
+		req  *pb.BulkDeleteConversationsRequest
+	}{
+		{"nothing selected", &pb.BulkDeleteConversationsRequest{}},
+		{"ids and filter", &pb.BulkDeleteConversationsRequest{ConversationIds: []string{"68a5aa7b14ba62ef8448c917"}, Filter: &pb.ConversationFilter{Tag: "x"}}},
+		{"invalid id", &pb.BulkDeleteConversationsRequest{ConversationIds: []string{"nope"}}},
+		{"too many ids", &pb.BulkDeleteConversationsRequest{ConversationIds: make([]string, maxBulkIDs+1)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := srv.BulkDeleteConversations(ctx, tt.req)
+			if te, ok := err.(twirp.Error); !ok || te.Code() != twirp.InvalidArgument {
+				t.Errorf("expected twirp.InvalidArgument, got %v", err)
+			}
+		})
+	}
+
+	_, err := srv.BulkDeleteConversations(context.Background(), &pb.BulkDeleteConversationsRequest{Filter: &pb.ConversationFilter{Tag: "x"}})
+	if te, ok := err.(twirp.Error); !ok || te.Code() != twirp.Unauthenticated {
+		t.Errorf("anonymous request: expected twirp.Unauthenticated, got %v", err)
+	}
+}
+
+func TestBulkConversations(t *testing.T) {
+	t.Run("archive by tag and delete by id", WithFixture(func(t *testing.T, f *Fixture) {
+		user := "alice-" + uuid.NewString()
+		ctx := auth.WithUser(context.Background(), user)
+		tag := uuid.NewString()
+		tagged := f.CreateConversation(func(c *model.Conversation) { c.Tags = []string{tag}; c.Owner = user })
+		other := f.CreateConversation(func(c *model.Conversation) { c.Owner = user })
+		srv := NewServer(f.Repository, &fakeAssistant{})
+
+		archived, err := srv.BulkArchiveConversations(ctx, &pb.BulkArchiveConversationsRequest{Filter: &pb.ConversationFilter{Tag: tag}})
+		if err != nil {
+			t.Fatalf("BulkArchiveConversations error: %v", err)
+		}
+		if archived.GetCount() != 1 || archived.GetJobId() != "" {
+			t.Fatalf("archived = %v, want 1 conversation within the request", archived)
+		}
+
+		list, err := srv.ListConversations(ctx, &pb.ListConversationsRequest{})
+		if err != nil {
+			t.Fatalf("ListConversations error: %v", err)
+		}
+		ids := make([]string, 0, len(list.GetConversations()))
+		for _, c := range list.GetConversations() {
+			ids = append(ids, c.GetId())
+		}
+		if slices.Contains(ids, tagged.ID.Hex()) || !slices.Contains(ids, other.ID.Hex()) {
+			t.Errorf("ListConversations should hide only the archived conversation")
+		}
+
+		deleted, err := srv.BulkDeleteConversations(ctx, &pb.BulkDeleteConversationsRequest{ConversationIds: []string{tagged.ID.Hex(), other.ID.Hex()}})
+		if err != nil {
+			t.Fatalf("BulkDeleteConversations error: %v", err)
+		}
+		if deleted.GetCount() != 2 {
+			t.Errorf("deleted %d conversations, want 2", deleted.GetCount())
+		}
+		if _, err := f.DescribeConversation(ctx, other.ID.Hex()); err == nil {
+			t.Error("deleted conversation still exists")
+		}
+	}))
+
+	t.Run("leaves other users' conversations alone", WithFixture(func(t *testing.T, f *Fixture) {
+		alice, bob := "alice-"+uuid.NewString(), "bob-"+uuid.NewString()
+		tag := uuid.NewString()
+		hers := f.CreateConversation(func(c *model.Conversation) { c.Tags = []string{tag}; c.Owner = alice })
+		shared := f.CreateConversation(func(c *model.Conversation) { c.Tags = []string{tag} })
+		his := f.CreateConversation(func(c *model.Conversation) { c.Tags = []string{tag}; c.Owner = bob })
+		srv := NewServer(f.Repository, &fakeAssistant{})
+		ctx := auth.WithUser(context.Background(), bob)
+
+		deleted, err := srv.BulkDeleteConversations(ctx, &pb.BulkDeleteConversationsRequest{Filter: &pb.ConversationFilter{OlderThan: timestamppb.New(time.Now().Add(time.Minute))}})
+		if err != nil {
+			t.Fatalf("BulkDeleteConversations error: %v", err)
+		}
+		if _, err := f.DescribeConversation(ctx, his.ID.Hex()); err == nil {
+			t.Error("the caller's conversation still exists")
+		}
+		if deleted.GetCount() < 1 {
+			t.Errorf("deleted %d conversations, want the caller's", deleted.GetCount())
+		}
+
+		deleted, err = srv.BulkDeleteConversations(ctx, &pb.BulkDeleteConversationsRequest{ConversationIds: []string{hers.ID.Hex(), shared.ID.Hex()}})
+		if err != nil || deleted.GetCount() != 0 {
+			t.Errorf("deleting others' conversations by ID = %v, %v; want none deleted", deleted, err)
+		}
+		for _, c := range []*model.Conversation{hers, shared} {
+			if _, err := f.DescribeConversation(ctx, c.ID.Hex()); err != nil {
+				t.Errorf("conversation %s of another user was deleted: %v", c.ID.Hex(), err)
+			}
+		}
+	}))
+}
+
+func TestRecoverBulkJobs(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("fails only jobs whose lease expired", WithFixture(func(t *testing.T, f *Fixture) {
+		srv := NewServer(f.Repository, nil)
+		now := time.Now()
+		user := uuid.NewString()
+		lost := &model.BulkJob{ID: primitive.NewObjectID(), UserID: user, Action: model.BulkDelete, State: model.BulkJobRunning, LeaseExpiresAt: now.Add(-time.Second)}
+		running := &model.BulkJob{ID: primitive.NewObjectID(), UserID: user, Action: model.BulkDelete, State: model.BulkJobRunning, LeaseExpiresAt: now.Add(bulkJobLease)}
+		for _, j := range []*model.BulkJob{lost, running} {
+			if err := f.CreateBulkJob(ctx, j); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		if _, err := srv.sweepBulkJobs(ctx, now); err != nil {
+			t.Fatalf("sweepBulkJobs error: %v", err)
+		}
+
+		if got, err := f.FindBulkJob(ctx, lost.ID.Hex(), user); err != nil || got.State != model.BulkJobFailed || got.Error == "" {
+			t.Errorf("lost job = %+v, %v; want failed", got, err)
+		}
+		if got, err := f.FindBulkJob(ctx, running.ID.Hex(), user); err != nil || got.State != model.BulkJobRunning {
+			t.Errorf("running job = %+v, %v; want still running", got, err)
+		}
+	}))
+}
@@ -0,0 +1,155 @@
+package chat
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/acai-travel/tech-challenge/internal/auth"
+	"github.com/acai-travel/tech-challenge/internal/chat/assistant"
+	"github.com/acai-travel/tech-challenge/internal/chat/model"
+	"github.com/acai-travel/tech-challenge/internal/httpx"
+	"github.com/acai-travel/tech-challenge/internal/pb"
+	"github.com/twitchtv/twirp"
+)
+
+// maxCalDAVFieldLength bounds the username and password of a CalDAV account, in bytes.
+const maxCalDAVFieldLength = 256
+
+// EnableCalDAV lets users connect a CalDAV calendar the assistant can add events to. Their
+// passwords are stored encrypted with key, which must be 32 bytes (AES-256).
+func (s *Server) EnableCalDAV(key []byte) {
+	if len(key) != 32 {
+		panic(fmt.Sprintf("CalDAV credentials key must be 32 bytes, got %d", len(key)))
+	}
+	block, _ := aes.NewCipher(key)
+	gcm, _ := cipher.NewGCM(block)
+	s.caldav = &credentialBox{aead: gcm}
+}
+
+var errCalDAVDisabled = twirp.NewError(twirp.Unimplemented, "CalDAV calendars are not enabled")
+
+// credentialBox encrypts the credentials users connect with AES-GCM. Each is bound to its
+// tenant and user, so a sealed password copied to another profile doesn't open.
+type credentialBox struct {
+	aead cipher.AEAD
+}
+
+// seal returns a random nonce followed by the encrypted plaintext.
+func (b *credentialBox) seal(tenantID, userID, plaintext string) ([]byte, error) {
+	nonce := make([]byte, b.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return b.aead.Seal(nonce, nonce, []byte(plaintext), credentialOwner(tenantID, userID)), nil
+}
+
+func (b *credentialBox) open(tenantID, userID string, sealed []byte) (string, error) {
+	n := b.aead.NonceSize()
+	if len(sealed) < n {
+		return "", errors.New("sealed credential is too short")
+	}
+	plaintext, err := b.aead.Open(nil, sealed[:n], sealed[n:], credentialOwner(tenantID, userID))
+	if err != nil {
+		return "", err
+	}
+	return string(plaintext), nil
+}
+
+// credentialOwner is the additional data binding a credential to its owner. The tenant is
+// length-prefixed, so no tenant and user pair reads as another.
+func credentialOwner(tenantID, userID string) []byte {
+	return fmt.Appendf(nil, "%d:%s%s", len(tenantID), tenantID, userID)
+}
+
+func (r *profileLoader) CalDAVAccount(ctx context.Context) (*assistant.CalDAVAccount, error) {
+	p, err := r.load(ctx)
+	if err != nil || p.CalDAV == nil {
+		return nil, err
+	}
+	password, err := r.caldav.open(auth.Tenant(ctx), r.userID, p.CalDAV.Password)
+	if err != nil {
+		// E.g. after the key was rotated, or for a password sealed before credentials were
+		// bound to their tenant: the user has to connect the calendar again.
+		return nil, fmt.Errorf("failed to decrypt the CalDAV password: %w", err)
+	}
+	return &assistant.CalDAVAccount{URL: p.CalDAV.URL, Username: p.CalDAV.Username, Password: password}, nil
+}
+
+// validateCalDAVAccount checks an account from a request, as validateUserCalendar does
+// calendar feeds: the server connects to it, so it must be a public https:// URL.
+func validateCalDAVAccount(in *pb.CalDAVAccount, password string) (model.CalDAVAccount, error) {
+	if in == nil {
+		return model.CalDAVAccount{}, twirp.RequiredArgumentError("account")
+	}
+
+	acc := model.CalDAVAccount{
+		URL:      strings.TrimSpace(in.GetUrl()),
+		Username: strings.TrimSpace(in.GetUsername()),
+	}
+	switch {
+	case acc.URL == "":
+		return acc, twirp.RequiredArgumentError("account.url")
+	case len(acc.URL) > maxCalendarURLLength:
+		return acc, twirp.InvalidArgumentError("account.url", fmt.Sprintf("must be at most %d characters", maxCalendarURLLength))
+	case acc.Username == "":
+		return acc, twirp.RequiredArgumentError("account.username")
+	case len(acc.Username) > maxCalDAVFieldLength:
+		return acc, twirp.InvalidArgumentError("account.username", fmt.Sprintf("must be at most %d characters", maxCalDAVFieldLength))
+	case password == "":
+		return acc, twirp.RequiredArgumentError("password")
+	case len(password) > maxCalDAVFieldLength:
+		return acc, twirp.InvalidArgumentError("password", fmt.Sprintf("must be at most %d characters", maxCalDAVFieldLength))
+	}
+
+	u, err := url.Parse(acc.URL)
+	if err != nil || u.Scheme != "https" || u.Hostname() == "" || u.User != nil {
+		return acc, twirp.InvalidArgumentError("account.url", "must be an https:// URL without credentials")
+	}
+	if !httpx.PublicHost(u.Hostname()) {
+		return acc, twirp.InvalidArgumentError("account.url", "must be a public host")
+	}
+	return acc, nil
+}
+
+func (s *Server) SetCalDAVAccount(ctx context.Context, req *pb.SetCalDAVAccountRequest) (*pb.SetCalDAVAccountResponse, error) {
+	if s.caldav == nil {
+		return nil, errCalDAVDisabled
+	}
+	acc, err := validateCalDAVAccount(req.GetAccount(), req.GetPassword())
+	if err != nil {
+		return nil, err
+	}
+	userID, err := requireUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if acc.Password, err = s.caldav.seal(auth.Tenant(ctx), userID, req.GetPassword()); err != nil {
+		return nil, twirp.InternalErrorWith(err)
+	}
+	if err := s.repo.SetCalDAVAccount(ctx, userID, acc); err != nil {
+		return nil, twirp.InternalErrorWith(err)
+	}
+
+	return &pb.SetCalDAVAccountResponse{Account: acc.Proto()}, nil
+}
+
+func (s *Server) DeleteCalDAVAccount(ctx context.Context, _ *pb.DeleteCalDAVAccountRequest) (*pb.DeleteCalDAVAccountResponse, error) {
+	userID, err := requireUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Allowed without EnableCalDAV, so users can still remove their credentials.
+	if err := s.repo.DeleteCalDAVAccount(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	return &pb.DeleteCalDAVAccountResponse{}, nil
+}
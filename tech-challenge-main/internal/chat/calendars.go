@@ -0,0 +1,126 @@
+package chat
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/acai-travel/tech-challenge/internal/auth"
+	"github.com/acai-travel/tech-challenge/internal/chat/model"
+	"github.com/acai-travel/tech-challenge/internal/httpx"
+	"github.com/acai-travel/tech-challenge/internal/pb"
+	"github.com/twitchtv/twirp"
+)
+
+const (
+	// maxUserCalendars bounds how many calendars a user may link.
+	maxUserCalendars = 10
+	// maxCalendarNameLength and maxCalendarURLLength bound the calendar fields, in characters.
+	maxCalendarNameLength = 64
+	maxCalendarURLLength  = 2048
+)
+
+func (r *profileLoader) UserCalendars(ctx context.Context) ([]model.UserCalendar, error) {
+	p, err := r.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return p.Calendars, nil
+}
+
+// validateUserCalendar checks a calendar from a request and returns it with its name
+// normalized and webcal:// URLs, as calendar apps share them, turned into https:// ones.
+func validateUserCalendar(in *pb.UserCalendar) (model.UserCalendar, error) {
+	if in == nil {
+		return model.UserCalendar{}, twirp.RequiredArgumentError("calendar")
+	}
+
+	cal := model.UserCalendar{
+		Name: model.NormalizeAliasName(in.GetName()),
+		URL:  strings.TrimSpace(in.GetUrl()),
+	}
+	if rest, ok := strings.CutPrefix(cal.URL, "webcal://"); ok {
+		cal.URL = "https://" + rest
+	}
+
+	switch {
+	case cal.Name == "":
+		return cal, twirp.RequiredArgumentError("calendar.name")
+	case cal.Name == "all":
+		// get_holidays takes "all" for every calendar of the user.
+		return cal, twirp.InvalidArgumentError("calendar.name", `"all" is reserved`)
+	case utf8.RuneCountInString(cal.Name) > maxCalendarNameLength:
+		return cal, twirp.InvalidArgumentError("calendar.name", fmt.Sprintf("must be at most %d characters", maxCalendarNameLength))
+	case cal.URL == "":
+		return cal, twirp.RequiredArgumentError("calendar.url")
+	case len(cal.URL) > maxCalendarURLLength:
+		return cal, twirp.InvalidArgumentError("calendar.url", fmt.Sprintf("must be at most %d characters", maxCalendarURLLength))
+	}
+
+	u, err := url.Parse(cal.URL)
+	if err != nil || u.Scheme != "https" || u.Hostname() == "" {
+		return cal, twirp.InvalidArgumentError("calendar.url", "must be an https:// or webcal:// URL")
+	}
+	if !httpx.PublicHost(u.Hostname()) {
+		// The server fetches the feed, it mustn't be pointed at itself or its network. Names
+		// resolving to such addresses are refused when the feed is fetched.
+		return cal, twirp.InvalidArgumentError("calendar.url", "must be a public host")
+	}
+	return cal, nil
+}
+
+func (s *Server) SetUserCalendar(ctx context.Context, req *pb.SetUserCalendarRequest) (*pb.SetUserCalendarResponse, error) {
+	cal, err := validateUserCalendar(req.GetCalendar())
+	if err != nil {
+		return nil, err
+	}
+	userID, err := requireUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ok, err := s.repo.SetUserCalendar(ctx, userID, cal, maxUserCalendars)
+	if err != nil {
+		return nil, twirp.InternalErrorWith(err)
+	}
+	if !ok {
+		return nil, twirp.NewError(twirp.ResourceExhausted, fmt.Sprintf("at most %d calendars can be linked", maxUserCalendars))
+	}
+
+	return &pb.SetUserCalendarResponse{Calendar: cal.Proto()}, nil
+}
+
+func (s *Server) DeleteUserCalendar(ctx context.Context, req *pb.DeleteUserCalendarRequest) (*pb.DeleteUserCalendarResponse, error) {
+	name := model.NormalizeAliasName(req.GetName())
+	if name == "" {
+		return nil, twirp.RequiredArgumentError("name")
+	}
+	userID, err := requireUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.DeleteUserCalendar(ctx, userID, name); err != nil {
+		return nil, err
+	}
+
+	return &pb.DeleteUserCalendarResponse{}, nil
+}
+
+func (s *Server) ListUserCalendars(ctx context.Context, _ *pb.ListUserCalendarsRequest) (*pb.ListUserCalendarsResponse, error) {
+	p, err := s.repo.FindUserProfile(ctx, auth.User(ctx))
+	if err != nil {
+		return nil, twirp.InternalErrorWith(err)
+	}
+
+	resp := &pb.ListUserCalendarsResponse{}
+	for i := range p.Calendars {
+		resp.Calendars = append(resp.Calendars, p.Calendars[i].Proto())
+	}
+	if p.CalDAV != nil {
+		resp.Caldav = p.CalDAV.Proto()
+	}
+	return resp, nil
+}
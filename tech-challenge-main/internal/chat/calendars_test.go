@@ -0,0 +1,97 @@
+package chat
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/acai-travel/tech-challenge/internal/auth"
+	"github.com/acai-travel/tech-challenge/internal/chat/model"
+	. "github.com/acai-travel/tech-challenge/internal/chat/testing"
+	"github.com/acai-travel/tech-challenge/internal/pb"
+	"github.com/twitchtv/twirp"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestValidateUserCalendar(t *testing.T) {
+	got, err := validateUserCalendar(&pb.UserCalendar{Name: " My  Work ", Url: " webcal://calendar.example.com/feed.ics "})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := (model.UserCalendar{Name: "work", URL: "https://calendar.example.com/feed.ics"}); got != want {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+
+	for name, in := range map[string]*pb.UserCalendar{
+		"missing":       nil,
+		"empty name":    {Name: "  ", Url: "https://example.com/a.ics"},
+		"reserved name": {Name: "All", Url: "https://example.com/a.ics"},
+		"long name":     {Name: strings.Repeat("a", maxCalendarNameLength+1), Url: "https://example.com/a.ics"},
+		"missing url":   {Name: "work"},
+		"long url":      {Name: "work", Url: "https://example.com/" + strings.Repeat("a", maxCalendarURLLength)},
+		"plain http":    {Name: "work", Url: "http://example.com/a.ics"},
+		"file":          {Name: "work", Url: "file:///etc/passwd"},
+		"localhost":     {Name: "work", Url: "https://localhost:8080/a.ics"},
+		"loopback":      {Name: "work", Url: "https://127.0.0.1/a.ics"},
+		"private":       {Name: "work", Url: "https://10.0.0.7/a.ics"},
+		"metadata":      {Name: "work", Url: "https://169.254.169.254/latest"},
+		"ipv6 loopback": {Name: "work", Url: "https://[::1]/a.ics"},
+	} {
+		if _, err := validateUserCalendar(in); err == nil {
+			t.Errorf("%s: expected an error", name)
+		} else if te, ok := err.(twirp.Error); !ok || te.Code() != twirp.InvalidArgument {
+			t.Errorf("%s: expected InvalidArgument, got %v", name, err)
+		}
+	}
+}
+
+func TestServer_UserCalendars(t *testing.T) {
+	srv := NewServer(model.New(ConnectMongo()), nil)
+	alice := auth.WithUser(context.Background(), "alice-"+primitive.NewObjectID().Hex())
+
+	t.Run("anonymous callers can't link calendars", func(t *testing.T) {
+		_, err := srv.SetUserCalendar(context.Background(), &pb.SetUserCalendarRequest{Calendar: &pb.UserCalendar{Name: "work", Url: "https://example.com/a.ics"}})
+		if te, ok := err.(twirp.Error); !ok || te.Code() != twirp.Unauthenticated {
+			t.Fatalf("expected Unauthenticated, got %v", err)
+		}
+	})
+
+	t.Run("set, replace, list and delete", WithFixture(func(t *testing.T, f *Fixture) {
+		for _, c := range []*pb.UserCalendar{
+			{Name: "Work", Url: "https://example.com/old.ics"},
+			{Name: "school", Url: "https://example.com/school.ics"},
+			{Name: "my work", Url: "https://example.com/work.ics"},
+		} {
+			if _, err := srv.SetUserCalendar(alice, &pb.SetUserCalendarRequest{Calendar: c}); err != nil {
+				t.Fatalf("SetUserCalendar(%v) error: %v", c, err)
+			}
+		}
+
+		out, err := srv.ListUserCalendars(alice, &pb.ListUserCalendarsRequest{})
+		if err != nil {
+			t.Fatalf("ListUserCalendars error: %v", err)
+		}
+		if len(out.GetCalendars()) != 2 {
+			t.Fatalf("expected 2 calendars, got %v", out.GetCalendars())
+		}
+
+		r := &profileLoader{repo: srv.repo, userID: auth.User(alice)}
+		cals, err := r.UserCalendars(alice)
+		if err != nil || len(cals) != 2 || cals[0].URL != "https://example.com/work.ics" {
+			t.Fatalf("expected the replaced work calendar first, got %+v, %v", cals, err)
+		}
+
+		if _, err := srv.DeleteUserCalendar(alice, &pb.DeleteUserCalendarRequest{Name: "School"}); err != nil {
+			t.Fatalf("DeleteUserCalendar error: %v", err)
+		}
+		_, err = srv.DeleteUserCalendar(alice, &pb.DeleteUserCalendarRequest{Name: "school"})
+		if te, ok := err.(twirp.Error); !ok || te.Code() != twirp.NotFound {
+			t.Fatalf("expected NotFound deleting twice, got %v", err)
+		}
+
+		bob := auth.WithUser(context.Background(), "bob-"+primitive.NewObjectID().Hex())
+		if out, err := srv.ListUserCalendars(bob, &pb.ListUserCalendarsRequest{}); err != nil || len(out.GetCalendars()) != 0 {
+			t.Errorf("expected no calendars for another user, got %v, %v", out.GetCalendars(), err)
+		}
+	}))
+}
@@ -0,0 +1,134 @@
+package chat
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/acai-travel/tech-challenge/internal/audit"
+	"github.com/acai-travel/tech-challenge/internal/chat/model"
+	"github.com/acai-travel/tech-challenge/internal/pb"
+	"github.com/twitchtv/twirp"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// errGenerationCancelled is the failure recorded for replies the user stopped.
+var errGenerationCancelled = errors.New("the user cancelled the reply")
+
+// cancelPollInterval is how long a generation waits before first checking whether the user
+// cancelled it through another instance. It waits twice as long before each next check, up
+// to maxCancelPollInterval, so long replies don't query the store every second.
+var cancelPollInterval = time.Second
+
+const maxCancelPollInterval = 8 * time.Second
+
+// runningGenerations cancels the replies this instance is generating, by conversation.
+type runningGenerations struct {
+	mu      sync.Mutex
+	cancels map[primitive.ObjectID]*runningGeneration
+}
+
+type runningGeneration struct {
+	cancel context.CancelCauseFunc
+}
+
+// add registers the generation of a reply in conversation id, cancelled by cancel, and
+// returns the function unregistering it.
+func (g *runningGenerations) add(id primitive.ObjectID, cancel context.CancelCauseFunc) func() {
+	r := &runningGeneration{cancel: cancel}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.cancels == nil {
+		g.cancels = map[primitive.ObjectID]*runningGeneration{}
+	}
+	g.cancels[id] = r
+
+	return func() {
+		g.mu.Lock()
+		defer g.mu.Unlock()
+		if g.cancels[id] == r {
+			delete(g.cancels, id)
+		}
+	}
+}
+
+// cancel cancels the generation of a reply in conversation id, and reports whether this
+// instance was generating one.
+func (g *runningGenerations) cancel(id primitive.ObjectID) bool {
+	g.mu.Lock()
+	r, ok := g.cancels[id]
+	g.mu.Unlock()
+	if ok {
+		r.cancel(errGenerationCancelled)
+	}
+	return ok
+}
+
+// watchCancel cancels the generation g with cancel once the user asks to, through whichever
+// instance, until ctx is done or the returned function is called.
+func (s *Server) watchCancel(ctx context.Context, g *model.Generation, cancel context.CancelCauseFunc) func() {
+	stop := make(chan struct{})
+	go func() {
+		interval := cancelPollInterval
+		timer := time.NewTimer(interval)
+		defer timer.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-stop:
+				return
+			case <-timer.C:
+			}
+
+			requested, err := s.repo.GenerationCancelRequested(ctx, g.ID)
+			if err != nil && ctx.Err() == nil {
+				slog.WarnContext(ctx, "Failed to check for a cancelled generation", "conversation_id", g.ConversationID.Hex(), "error", err)
+			}
+			if requested {
+				cancel(errGenerationCancelled)
+				return
+			}
+			interval = min(2*interval, maxCancelPollInterval)
+			timer.Reset(interval)
+		}
+	}()
+	return func() { close(stop) }
+}
+
+// CancelGeneration stops generating the reply to the last message of a conversation. No
+// reply is stored: the message gets a failed generation record instead, so it can be
+// retried with RetryFailedReply.
+func (s *Server) CancelGeneration(ctx context.Context, req *pb.CancelGenerationRequest) (*pb.CancelGenerationResponse, error) {
+	if req.GetConversationId() == "" {
+		return nil, twirp.RequiredArgumentError("conversation_id")
+	}
+
+	audit.Conversation(ctx, req.GetConversationId())
+	cancelled, err := s.cancelGeneration(ctx, req.GetConversationId())
+	if err != nil {
+		return nil, err
+	}
+	return &pb.CancelGenerationResponse{Cancelled: cancelled}, nil
+}
+
+// cancelGeneration cancels the reply being generated in the caller's conversation with id,
+// on this instance or through the generation record for the others, and reports whether
+// there was one.
+func (s *Server) cancelGeneration(ctx context.Context, id string) (bool, error) {
+	conv, err := s.describeConversation(ctx, id)
+	if err != nil {
+		return false, err
+	}
+	if s.running.cancel(conv.ID) {
+		return true, nil
+	}
+
+	cancelled, err := s.repo.RequestGenerationCancel(ctx, conv.ID)
+	if err != nil {
+		return false, twirp.InternalErrorWith(err)
+	}
+	return cancelled, nil
+}
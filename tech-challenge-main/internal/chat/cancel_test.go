@@ -0,0 +1,108 @@
+package chat
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/acai-travel/tech-challenge/internal/chat/model"
+	. "github.com/acai-travel/tech-challenge/internal/chat/testing"
+	"github.com/acai-travel/tech-challenge/internal/pb"
+	"github.com/twitchtv/twirp"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestRunningGenerations(t *testing.T) {
+	var running runningGenerations
+	id := primitive.NewObjectID()
+
+	if running.cancel(id) {
+		t.Fatal("cancelled a generation that isn't running")
+	}
+
+	ctx, cancel := context.WithCancelCause(context.Background())
+	unregister := running.add(id, cancel)
+	if !running.cancel(id) || context.Cause(ctx) != errGenerationCancelled {
+		t.Fatalf("cause = %v, want the generation cancelled", context.Cause(ctx))
+	}
+
+	// A later generation in the conversation isn't unregistered by the earlier one's end.
+	next, cancelNext := context.WithCancelCause(context.Background())
+	defer cancelNext(nil)
+	running.add(id, cancelNext)
+	unregister()
+	if !running.cancel(id) || next.Err() == nil {
+		t.Error("the later generation was unregistered")
+	}
+}
+
+func TestCancelGeneration(t *testing.T) {
+	interval := cancelPollInterval
+	cancelPollInterval = 10 * time.Millisecond
+	t.Cleanup(func() { cancelPollInterval = interval })
+
+	// blockingAssistant replies once its context is done, signalling when it starts.
+	blockingAssistant := func() (*fakeAssistant, <-chan struct{}) {
+		started := make(chan struct{})
+		var once sync.Once
+		return &fakeAssistant{replyFn: func(ctx context.Context, c *model.Conversation) (string, error) {
+			once.Do(func() { close(started) })
+			<-ctx.Done()
+			return "", ctx.Err()
+		}}, started
+	}
+
+	for name, otherInstance := range map[string]bool{"on this instance": false, "through another instance": true} {
+		t.Run(name, WithFixture(func(t *testing.T, f *Fixture) {
+			ctx := context.Background()
+			c := f.CreateConversation()
+			fa, started := blockingAssistant()
+			srv := NewServer(f.Repository, fa)
+			canceller := srv
+			if otherInstance {
+				canceller = NewServer(f.Repository, &fakeAssistant{})
+			}
+
+			errs := make(chan error, 1)
+			go func() {
+				_, err := srv.ContinueConversation(ctx, &pb.ContinueConversationRequest{ConversationId: c.ID.Hex(), Message: "Wrong question"})
+				errs <- err
+			}()
+			<-started
+
+			out, err := canceller.CancelGeneration(ctx, &pb.CancelGenerationRequest{ConversationId: c.ID.Hex()})
+			if err != nil || !out.GetCancelled() {
+				t.Fatalf("CancelGeneration = %v, %v; want cancelled", out, err)
+			}
+
+			select {
+			case err := <-errs:
+				if te, ok := err.(twirp.Error); !ok || te.Code() != twirp.Canceled {
+					t.Fatalf("ContinueConversation error = %v, want twirp.Canceled", err)
+				}
+			case <-time.After(5 * time.Second):
+				t.Fatal("the reply wasn't cancelled")
+			}
+
+			described, err := srv.DescribeConversation(ctx, &pb.DescribeConversationRequest{ConversationId: c.ID.Hex()})
+			if err != nil {
+				t.Fatalf("DescribeConversation error: %v", err)
+			}
+			if got := described.GetConversation().GetFailedReply(); !strings.Contains(got, "You stopped the reply") {
+				t.Errorf("failed_reply = %q, want the cancellation notice", got)
+			}
+		}))
+	}
+
+	t.Run("nothing to cancel", WithFixture(func(t *testing.T, f *Fixture) {
+		c := f.CreateConversation()
+		srv := NewServer(f.Repository, &fakeAssistant{})
+
+		out, err := srv.CancelGeneration(context.Background(), &pb.CancelGenerationRequest{ConversationId: c.ID.Hex()})
+		if err != nil || out.GetCancelled() {
+			t.Fatalf("CancelGeneration = %v, %v; want nothing cancelled", out, err)
+		}
+	}))
+}
@@ -0,0 +1,23 @@
+package chat
+
+import "github.com/acai-travel/tech-challenge/internal/doctor"
+
+// Checks verify what the server depends on, for doctor.Run: its database, the Atlas Vector
+// Search index if semantic search uses one, and the providers of the default assistant,
+// whose credentials the other assistants share.
+func (s *Server) Checks() []doctor.Check {
+	var index string
+	if s.semantic != nil {
+		if store, ok := s.semantic.store.(atlasVectorStore); ok {
+			index = store.index
+		}
+	}
+	checks := s.repo.Checks(index)
+
+	if a, ok := s.assistants.Get(DefaultAssistant); ok {
+		if c, ok := a.(interface{ Checks() []doctor.Check }); ok {
+			checks = append(checks, c.Checks()...)
+		}
+	}
+	return checks
+}
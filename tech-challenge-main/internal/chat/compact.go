@@ -0,0 +1,104 @@
+package chat
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+
+	"github.com/acai-travel/tech-challenge/internal/chat/model"
+	"github.com/acai-travel/tech-challenge/internal/pb"
+	"github.com/twitchtv/twirp"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// validateHistoryOptions checks the history options of a request before the conversation is
+// loaded.
+func validateHistoryOptions(o *pb.HistoryOptions) error {
+	switch {
+	case o.GetMaxMessages() < 0:
+		return twirp.InvalidArgumentError("history.max_messages", "must not be negative")
+	case o.GetCompressOverBytes() < 0:
+		return twirp.InvalidArgumentError("history.compress_over_bytes", "must not be negative")
+	}
+	return validateMask("history.message_mask", o.GetMessageMask(), &pb.Conversation_Message{})
+}
+
+// compactHistory shrinks the messages of out, the proto of conv, as o requests: older
+// messages are left out for the summary or beyond the maximum, and those returned lose the
+// fields outside the mask and have long contents compressed.
+func compactHistory(out *pb.Conversation, conv *model.Conversation, o *pb.HistoryOptions) error {
+	if o == nil {
+		return nil
+	}
+
+	// Both leave out the oldest messages, so those returned are always the latest.
+	keep := len(out.Messages)
+	if o.GetSummaryOnly() && conv.Summary != nil {
+		keep = len(conv.Unsummarized())
+		out.Summary = conv.Summary.Content
+	}
+	if n := int(o.GetMaxMessages()); n > 0 && keep > n {
+		keep = n
+	}
+	out.OmittedMessages = int32(len(out.Messages) - keep)
+	out.Messages = out.Messages[len(out.Messages)-keep:]
+
+	for _, m := range out.Messages {
+		applyMask(m, o.GetMessageMask())
+		if n := int(o.GetCompressOverBytes()); n > 0 && len(m.Content) > n {
+			gz, err := gzipString(m.Content)
+			if err != nil {
+				return twirp.InternalErrorWith(err)
+			}
+			if len(gz) < len(m.Content) {
+				m.Content, m.ContentGzip = "", gz
+			}
+		}
+	}
+	return nil
+}
+
+func gzipString(s string) ([]byte, error) {
+	var b bytes.Buffer
+	w := gzip.NewWriter(&b)
+	if _, err := w.Write([]byte(s)); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+// validateMask checks that the paths of mask, the request field named field, are fields of m.
+// Masks only select top-level fields.
+func validateMask(field string, mask *fieldmaskpb.FieldMask, m proto.Message) error {
+	fields := m.ProtoReflect().Descriptor().Fields()
+	for _, p := range mask.GetPaths() {
+		if fields.ByName(protoreflect.Name(p)) == nil {
+			return twirp.InvalidArgumentError(field, fmt.Sprintf("%q is not a field of %s", p, m.ProtoReflect().Descriptor().Name()))
+		}
+	}
+	return nil
+}
+
+// applyMask clears the fields of m that mask doesn't list; an empty mask keeps them all.
+func applyMask(m proto.Message, mask *fieldmaskpb.FieldMask) {
+	if len(mask.GetPaths()) == 0 {
+		return
+	}
+	keep := make(map[protoreflect.Name]bool, len(mask.GetPaths()))
+	for _, p := range mask.GetPaths() {
+		keep[protoreflect.Name(p)] = true
+	}
+
+	r := m.ProtoReflect()
+	fields := r.Descriptor().Fields()
+	for i := range fields.Len() {
+		if f := fields.Get(i); !keep[f.Name()] {
+			r.Clear(f)
+		}
+	}
+}
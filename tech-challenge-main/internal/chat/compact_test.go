@@ -0,0 +1,79 @@
+package chat
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/acai-travel/tech-challenge/internal/chat/model"
+	"github.com/acai-travel/tech-challenge/internal/pb"
+	"github.com/twitchtv/twirp"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+func TestCompactHistory(t *testing.T) {
+	conv := &model.Conversation{ID: primitive.NewObjectID()}
+	for i, content := range []string{"Plan a trip to Rome", "Day 1: the Colosseum.", "And day 2?", strings.Repeat("The Vatican museums, then a walk along the Tiber. ", 20)} {
+		role := model.RoleUser
+		if i%2 == 1 {
+			role = model.RoleAssistant
+		}
+		conv.Messages = append(conv.Messages, &model.Message{ID: primitive.NewObjectID(), Role: role, Content: content, CreatedAt: time.Now()})
+	}
+	conv.Summary = &model.Summary{Content: "The user is planning a trip to Rome.", UpToMessageID: conv.Messages[1].ID}
+
+	compact := func(o *pb.HistoryOptions) *pb.Conversation {
+		t.Helper()
+		out := conv.Proto()
+		if err := compactHistory(out, conv, o); err != nil {
+			t.Fatal(err)
+		}
+		return out
+	}
+
+	if out := compact(nil); len(out.GetMessages()) != 4 || out.GetSummary() != "" {
+		t.Errorf("without options got %d messages and summary %q, want the full history", len(out.GetMessages()), out.GetSummary())
+	}
+
+	out := compact(&pb.HistoryOptions{SummaryOnly: true})
+	if len(out.GetMessages()) != 2 || out.GetMessages()[0].GetContent() != "And day 2?" || out.GetOmittedMessages() != 2 || out.GetSummary() != conv.Summary.Content {
+		t.Errorf("summary only = %v, want the summary and the 2 messages after it", out)
+	}
+
+	out = compact(&pb.HistoryOptions{MaxMessages: 1, MessageMask: &fieldmaskpb.FieldMask{Paths: []string{"id", "content"}}})
+	if m := out.GetMessages(); len(m) != 1 || out.GetOmittedMessages() != 3 || m[0].GetId() != conv.Messages[3].ID.Hex() || m[0].GetTimestamp() != nil || m[0].GetContent() == "" {
+		t.Errorf("masked = %v, want the id and content of the latest message", out)
+	}
+
+	out = compact(&pb.HistoryOptions{CompressOverBytes: 100})
+	short, long := out.GetMessages()[0], out.GetMessages()[3]
+	if short.GetContent() != conv.Messages[0].Content || short.GetContentGzip() != nil {
+		t.Errorf("short message = %v, want it uncompressed", short)
+	}
+	r, err := gzip.NewReader(bytes.NewReader(long.GetContentGzip()))
+	if err != nil {
+		t.Fatalf("long message isn't gzipped: %v", err)
+	}
+	if b, _ := io.ReadAll(r); long.GetContent() != "" || string(b) != conv.Messages[3].Content || len(long.GetContentGzip()) >= len(b) {
+		t.Errorf("long message = %q and %d gzipped bytes, want only its smaller compressed content", long.GetContent(), len(long.GetContentGzip()))
+	}
+}
+
+func TestValidateHistoryOptions(t *testing.T) {
+	for name, o := range map[string]*pb.HistoryOptions{
+		"negative max":      {MaxMessages: -1},
+		"negative compress": {CompressOverBytes: -1},
+		"unknown field":     {MessageMask: &fieldmaskpb.FieldMask{Paths: []string{"id", "body"}}},
+	} {
+		if err := validateHistoryOptions(o); twirpCode(err) != twirp.InvalidArgument {
+			t.Errorf("%s: expected InvalidArgument, got %v", name, err)
+		}
+	}
+	if err := validateHistoryOptions(&pb.HistoryOptions{MessageMask: &fieldmaskpb.FieldMask{Paths: []string{"id", "role", "timestamp"}}}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,377 @@
+package chat
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/acai-travel/tech-challenge/internal/auth"
+	"github.com/acai-travel/tech-challenge/internal/chat/assistant"
+	"github.com/acai-travel/tech-challenge/internal/chat/model"
+	"github.com/acai-travel/tech-challenge/internal/pb"
+	"github.com/acai-travel/tech-challenge/internal/textx"
+	"github.com/twitchtv/twirp"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	// maxDigestConversations bounds the conversations a digest lists, most recent first.
+	maxDigestConversations = 10
+	// digestLease is how long a claimed digest waits for another attempt if sending fails.
+	digestLease = 15 * time.Minute
+	// digestInterval is how often RunDigests looks for due digests.
+	digestInterval = 5 * time.Minute
+	// digestTag marks the conversations digests are delivered in.
+	digestTag = "digest"
+)
+
+// HolidayCalendar lists public holidays, for digests to mention the upcoming ones.
+type HolidayCalendar interface {
+	Holidays(ctx context.Context, country, region string, from, to time.Time) ([]assistant.Holiday, error)
+}
+
+// DigestNotifier receives the digests of users who chose webhook delivery.
+type DigestNotifier interface {
+	DigestReady(ctx context.Context, userID string, d *Digest) error
+}
+
+type digests struct {
+	holidays HolidayCalendar
+	notify   DigestNotifier
+}
+
+// EnableDigests lets users subscribe to digests of their conversations, listing upcoming
+// holidays from holidays if not nil. Digests are delivered as messages, or to notify (if not
+// nil) for users who choose so. RunDigests sends them.
+func (s *Server) EnableDigests(holidays HolidayCalendar, notify DigestNotifier) {
+	s.digests = &digests{holidays: holidays, notify: notify}
+}
+
+var errDigestsDisabled = twirp.NewError(twirp.Unimplemented, "digests are not enabled")
+
+// Digest summarizes the activity of a user's conversations over a period.
+type Digest struct {
+	Frequency     model.DigestFrequency `json:"frequency"`
+	Since         time.Time             `json:"since"`
+	Until         time.Time             `json:"until"`
+	Conversations []DigestConversation  `json:"conversations"`
+	Holidays      []assistant.Holiday   `json:"holidays"`
+}
+
+// DigestConversation is the activity of one conversation in a digest.
+type DigestConversation struct {
+	ID          string `json:"id"`
+	Title       string `json:"title"`
+	NewMessages int    `json:"new_messages"`
+	// UnreadReplies are the new replies the user hasn't seen, e.g. answered after a retry.
+	UnreadReplies int    `json:"unread_replies"`
+	LatestReply   string `json:"latest_reply,omitempty"`
+}
+
+// Empty reports whether d has nothing worth sending.
+func (d *Digest) Empty() bool {
+	return len(d.Conversations) == 0 && len(d.Holidays) == 0
+}
+
+// Summary is one line about d, e.g. for a push notification.
+func (d *Digest) Summary() string {
+	var parts []string
+	if n := len(d.Conversations); n > 0 {
+		parts = append(parts, plural(n, "active conversation"))
+	}
+	if n := len(d.Holidays); n > 0 {
+		parts = append(parts, plural(n, "upcoming holiday"))
+	}
+	return strings.Join(parts, " and ") + "."
+}
+
+// Text renders d as the content of a message.
+func (d *Digest) Text() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Your %s digest, %s to %s\n", d.Frequency, d.Since.Format("Jan 2"), d.Until.Format("Jan 2"))
+
+	if len(d.Conversations) > 0 {
+		b.WriteString("\nConversations\n")
+		for _, c := range d.Conversations {
+			fmt.Fprintf(&b, "- %s: %s", c.Title, plural(c.NewMessages, "new message"))
+			if c.UnreadReplies > 0 {
+				fmt.Fprintf(&b, ", %s", plural(c.UnreadReplies, "unread reply"))
+			}
+			if c.LatestReply != "" {
+				fmt.Fprintf(&b, ". Latest reply: %q", c.LatestReply)
+			}
+			b.WriteString("\n")
+		}
+	}
+
+	if len(d.Holidays) > 0 {
+		b.WriteString("\nUpcoming holidays\n")
+		for _, h := range d.Holidays {
+			fmt.Fprintf(&b, "- %s (%s): %s\n", h.Date.Format(time.DateOnly), h.Date.Weekday(), h.Name)
+		}
+	}
+	return b.String()
+}
+
+func plural(n int, noun string) string {
+	if n == 1 {
+		return "1 " + noun
+	}
+	if strings.HasSuffix(noun, "y") {
+		return fmt.Sprintf("%d %sies", n, strings.TrimSuffix(noun, "y"))
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
+
+func (s *Server) SetDigestSubscription(ctx context.Context, req *pb.SetDigestSubscriptionRequest) (*pb.SetDigestSubscriptionResponse, error) {
+	if s.digests == nil {
+		return nil, errDigestsDisabled
+	}
+	userID := auth.User(ctx)
+	if userID == auth.Anonymous {
+		return nil, twirp.NewError(twirp.Unauthenticated, "digests require an identified user")
+	}
+
+	var frequency model.DigestFrequency
+	switch req.GetFrequency() {
+	case pb.DigestFrequency_DIGEST_FREQUENCY_OFF:
+		if err := s.repo.SetDigestSubscription(ctx, userID, nil); err != nil {
+			return nil, twirp.InternalErrorWith(err)
+		}
+		return &pb.SetDigestSubscriptionResponse{}, nil
+	case pb.DigestFrequency_DIGEST_FREQUENCY_DAILY:
+		frequency = model.DigestDaily
+	case pb.DigestFrequency_DIGEST_FREQUENCY_WEEKLY:
+		frequency = model.DigestWeekly
+	default:
+		return nil, twirp.InvalidArgumentError("frequency", "must be off, daily or weekly")
+	}
+
+	delivery := model.DigestAsMessage
+	switch req.GetDelivery() {
+	case pb.DigestDelivery_DIGEST_DELIVERY_MESSAGE:
+	case pb.DigestDelivery_DIGEST_DELIVERY_WEBHOOK:
+		if s.digests.notify == nil {
+			return nil, twirp.InvalidArgumentError("delivery", "no digest webhook is configured")
+		}
+		delivery = model.DigestByWebhook
+	default:
+		return nil, twirp.InvalidArgumentError("delivery", "must be message or webhook")
+	}
+
+	country, region := strings.TrimSpace(req.GetCountry()), strings.TrimSpace(req.GetRegion())
+	if region != "" && country == "" {
+		return nil, twirp.InvalidArgumentError("region", "requires a country")
+	}
+
+	profile, err := s.repo.FindUserProfile(ctx, userID)
+	if err != nil {
+		return nil, twirp.InternalErrorWith(err)
+	}
+
+	sub := &model.DigestSubscription{
+		Frequency: frequency,
+		Delivery:  delivery,
+		Country:   country,
+		Region:    region,
+		NextAt:    time.Now().Add(frequency.Period()),
+	}
+	// Changing how digests look keeps their schedule; changing how often restarts it.
+	if prev := profile.Digest; prev != nil {
+		sub.LastSentAt = prev.LastSentAt
+		if prev.Frequency == frequency {
+			sub.NextAt = prev.NextAt
+		}
+	}
+
+	if err := s.repo.SetDigestSubscription(ctx, userID, sub); err != nil {
+		return nil, twirp.InternalErrorWith(err)
+	}
+	return &pb.SetDigestSubscriptionResponse{Subscription: sub.Proto()}, nil
+}
+
+func (s *Server) GetDigestSubscription(ctx context.Context, _ *pb.GetDigestSubscriptionRequest) (*pb.GetDigestSubscriptionResponse, error) {
+	if s.digests == nil {
+		return nil, errDigestsDisabled
+	}
+
+	profile, err := s.repo.FindUserProfile(ctx, auth.User(ctx))
+	if err != nil {
+		return nil, twirp.InternalErrorWith(err)
+	}
+	if profile.Digest == nil {
+		return &pb.GetDigestSubscriptionResponse{}, nil
+	}
+	return &pb.GetDigestSubscriptionResponse{Subscription: profile.Digest.Proto()}, nil
+}
+
+// RunDigests sends the digests that are due, then checks again every few minutes until ctx
+// is done. It does nothing unless digests are enabled. Instances claim digests before sending
+// them, so it may run on all of them.
+func (s *Server) RunDigests(ctx context.Context) {
+	if s.digests == nil {
+		return
+	}
+	for {
+		n, err := s.sendDueDigests(ctx, time.Now())
+		if err != nil && ctx.Err() == nil {
+			slog.ErrorContext(ctx, "Failed to send digests", "error", err)
+		}
+		if n > 0 {
+			slog.InfoContext(ctx, "Sent digests", "count", n)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(digestInterval):
+		}
+	}
+}
+
+// sendDueDigests sends every digest due at now and reports how many it sent. Digests that
+// fail are retried after digestLease.
+func (s *Server) sendDueDigests(ctx context.Context, now time.Time) (int, error) {
+	n := 0
+	for {
+		p, err := s.repo.ClaimDueDigest(ctx, now, digestLease)
+		if err != nil || p == nil {
+			return n, err
+		}
+
+		if err := s.sendDigest(ctx, p, now); err != nil {
+			slog.WarnContext(ctx, "Failed to send digest, will retry", "user_id", p.UserID, "error", err)
+			continue
+		}
+		n++
+	}
+}
+
+func (s *Server) sendDigest(ctx context.Context, p *model.UserProfile, now time.Time) error {
+	d, err := s.buildDigest(ctx, p.UserID, p.Digest, now)
+	if err != nil {
+		return err
+	}
+
+	// Quiet periods are skipped rather than sending an empty digest.
+	if !d.Empty() {
+		if err := s.deliverDigest(ctx, p.UserID, p.Digest.Delivery, d); err != nil {
+			return err
+		}
+	}
+	return s.repo.FinishDigest(ctx, p.UserID, now, now.Add(p.Digest.Frequency.Period()))
+}
+
+// digestHolidayWindow is how far ahead digests look for holidays: far enough to plan for,
+// without listing the same holidays in many digests in a row.
+func digestHolidayWindow(f model.DigestFrequency) time.Duration {
+	if f == model.DigestWeekly {
+		return 28 * 24 * time.Hour
+	}
+	return 7 * 24 * time.Hour
+}
+
+// buildDigest collects the activity since the last digest, or over one period for the first.
+func (s *Server) buildDigest(ctx context.Context, userID string, sub *model.DigestSubscription, now time.Time) (*Digest, error) {
+	since := now.Add(-sub.Frequency.Period())
+	if sub.LastSentAt != nil {
+		since = *sub.LastSentAt
+	}
+	d := &Digest{Frequency: sub.Frequency, Since: since, Until: now}
+
+	convs, err := s.repo.ListActiveConversations(ctx, userID, since, maxDigestConversations)
+	if err != nil {
+		return nil, err
+	}
+	for _, conv := range convs {
+		if c, ok := digestConversation(conv, userID, since); ok {
+			d.Conversations = append(d.Conversations, c)
+		}
+	}
+
+	if s.digests.holidays != nil {
+		holidays, err := s.digests.holidays.Holidays(ctx, sub.Country, sub.Region, now, now.Add(digestHolidayWindow(sub.Frequency)))
+		if err != nil {
+			// The conversations are still worth sending.
+			slog.WarnContext(ctx, "Failed to load holidays for digest", "user_id", userID, "error", err)
+		}
+		d.Holidays = holidays
+	}
+	return d, nil
+}
+
+// digestConversation sums up the messages of conv created since a time, and reports false if
+// there are none.
+func digestConversation(conv *model.Conversation, userID string, since time.Time) (DigestConversation, bool) {
+	// Messages up to the user's read marker have been seen.
+	seen := -1
+	for _, r := range conv.Reads {
+		if r.UserID == userID {
+			for i, m := range conv.Messages {
+				if m.ID == r.MessageID {
+					seen = i
+				}
+			}
+		}
+	}
+
+	c := DigestConversation{ID: conv.ID.Hex(), Title: conv.Title}
+	for i, m := range conv.Messages {
+		if m.CreatedAt.Before(since) {
+			continue
+		}
+		c.NewMessages++
+		if m.Role == model.RoleAssistant {
+			c.LatestReply = textx.Truncate(strings.Join(strings.Fields(m.Content), " "), 160)
+			if i > seen {
+				c.UnreadReplies++
+			}
+		}
+	}
+	return c, c.NewMessages > 0
+}
+
+func (s *Server) deliverDigest(ctx context.Context, userID string, delivery model.DigestDelivery, d *Digest) error {
+	if delivery == model.DigestByWebhook {
+		if s.digests.notify == nil {
+			return errors.New("digest webhook is no longer configured")
+		}
+		return s.digests.notify.DigestReady(ctx, userID, d)
+	}
+
+	now := time.Now()
+	title := fmt.Sprintf("Your %s digest, %s", d.Frequency, d.Until.Format("Jan 2"))
+	conv := &model.Conversation{
+		ID:        primitive.NewObjectID(),
+		Title:     title,
+		CreatedAt: now,
+		UpdatedAt: now,
+		Messages: []*model.Message{{
+			ID:        primitive.NewObjectID(),
+			Role:      model.RoleAssistant,
+			Content:   d.Text(),
+			CreatedAt: now,
+			UpdatedAt: now,
+		}},
+		Tags: []string{digestTag},
+		// Digests list the titles and replies of the user's conversations.
+		Owner: userID,
+	}
+	if err := s.repo.CreateConversation(ctx, conv); err != nil {
+		return err
+	}
+
+	// The digest is delivered, a failed notification mustn't send it again.
+	if err := s.Notify(ctx, userID, Notification{Kind: NotifyDigest, Title: title, Body: d.Summary(), ConversationID: conv.ID.Hex()}); err != nil {
+		slog.WarnContext(ctx, "Failed to notify of digest", "user_id", userID, "error", err)
+	}
+	return nil
+}
+
+// DigestReady posts the digest with the ID of the user it is for.
+func (n *WebhookNotifier) DigestReady(ctx context.Context, userID string, d *Digest) error {
+	return n.post(ctx, map[string]any{"user_id": userID, "digest": d})
+}
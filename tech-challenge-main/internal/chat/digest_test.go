@@ -0,0 +1,222 @@
+package chat
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/acai-travel/tech-challenge/internal/auth"
+	"github.com/acai-travel/tech-challenge/internal/chat/assistant"
+	"github.com/acai-travel/tech-challenge/internal/chat/model"
+	. "github.com/acai-travel/tech-challenge/internal/chat/testing"
+	"github.com/acai-travel/tech-challenge/internal/pb"
+	"github.com/twitchtv/twirp"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestDigestConversation(t *testing.T) {
+	since := time.Date(2026, 10, 13, 9, 0, 0, 0, time.UTC)
+	msg := func(role model.Role, content string, at time.Time) *model.Message {
+		return &model.Message{ID: primitive.NewObjectID(), Role: role, Content: content, CreatedAt: at}
+	}
+	old := msg(model.RoleUser, "Plan a trip to Lisbon", since.Add(-time.Hour))
+	asked := msg(model.RoleUser, "And the hotels?", since.Add(time.Hour))
+	seen := msg(model.RoleAssistant, "Try Alfama.", since.Add(2*time.Hour))
+	unread := msg(model.RoleAssistant, "Hotel   Avenida has rooms.", since.Add(3*time.Hour))
+
+	conv := &model.Conversation{
+		ID:       primitive.NewObjectID(),
+		Title:    "Lisbon",
+		Messages: []*model.Message{old, asked, seen, unread},
+		Reads:    []*model.ReadMarker{{UserID: "bob", MessageID: unread.ID}, {UserID: "alice", MessageID: seen.ID}},
+	}
+
+	got, ok := digestConversation(conv, "alice", since)
+	want := DigestConversation{ID: conv.ID.Hex(), Title: "Lisbon", NewMessages: 3, UnreadReplies: 1, LatestReply: "Hotel Avenida has rooms."}
+	if !ok || got != want {
+		t.Errorf("digestConversation = %+v, %v; want %+v", got, ok, want)
+	}
+
+	if _, ok := digestConversation(conv, "alice", since.Add(4*time.Hour)); ok {
+		t.Error("expected no activity after the last message")
+	}
+}
+
+func TestDigest_Text(t *testing.T) {
+	d := &Digest{
+		Frequency: model.DigestWeekly,
+		Since:     time.Date(2026, 10, 7, 0, 0, 0, 0, time.UTC),
+		Until:     time.Date(2026, 10, 14, 0, 0, 0, 0, time.UTC),
+		Conversations: []DigestConversation{
+			{Title: "Lisbon", NewMessages: 3, UnreadReplies: 2, LatestReply: "Hotel Avenida has rooms."},
+			{Title: "Packing", NewMessages: 1},
+		},
+		Holidays: []assistant.Holiday{{Date: time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC), Name: "All Saints' Day"}},
+	}
+
+	got := d.Text()
+	for _, want := range []string{
+		"Your weekly digest, Oct 7 to Oct 14",
+		`- Lisbon: 3 new messages, 2 unread replies. Latest reply: "Hotel Avenida has rooms."`,
+		"- Packing: 1 new message\n",
+		"- 2026-11-01 (Sunday): All Saints' Day",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("digest text lacks %q:\n%s", want, got)
+		}
+	}
+}
+
+func TestSetDigestSubscription_Validation(t *testing.T) {
+	alice := auth.WithUser(context.Background(), "alice")
+
+	srv := NewServer(nil, &fakeAssistant{})
+	if _, err := srv.SetDigestSubscription(alice, &pb.SetDigestSubscriptionRequest{}); twirpCode(err) != twirp.Unimplemented {
+		t.Fatalf("expected Unimplemented while disabled, got %v", err)
+	}
+
+	srv.EnableDigests(nil, nil)
+	cases := []struct {
+		name string
+		ctx  context.Context
+		req  *pb.SetDigestSubscriptionRequest
+		want twirp.ErrorCode
+	}{
+		{"anonymous", context.Background(), &pb.SetDigestSubscriptionRequest{Frequency: pb.DigestFrequency_DIGEST_FREQUENCY_DAILY}, twirp.Unauthenticated},
+		{"unknown frequency", alice, &pb.SetDigestSubscriptionRequest{Frequency: 7}, twirp.InvalidArgument},
+		{"unknown delivery", alice, &pb.SetDigestSubscriptionRequest{Frequency: pb.DigestFrequency_DIGEST_FREQUENCY_DAILY, Delivery: 7}, twirp.InvalidArgument},
+		{"webhook not configured", alice, &pb.SetDigestSubscriptionRequest{Frequency: pb.DigestFrequency_DIGEST_FREQUENCY_DAILY, Delivery: pb.DigestDelivery_DIGEST_DELIVERY_WEBHOOK}, twirp.InvalidArgument},
+		{"region without country", alice, &pb.SetDigestSubscriptionRequest{Frequency: pb.DigestFrequency_DIGEST_FREQUENCY_DAILY, Region: "Catalonia"}, twirp.InvalidArgument},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := srv.SetDigestSubscription(tc.ctx, tc.req); twirpCode(err) != tc.want {
+				t.Errorf("expected %s, got %v", tc.want, err)
+			}
+		})
+	}
+}
+
+func twirpCode(err error) twirp.ErrorCode {
+	if te, ok := err.(twirp.Error); ok {
+		return te.Code()
+	}
+	return twirp.NoError
+}
+
+type fakeDigestNotifier struct {
+	sent map[string]*Digest
+}
+
+func (f *fakeDigestNotifier) DigestReady(_ context.Context, userID string, d *Digest) error {
+	f.sent[userID] = d
+	return nil
+}
+
+type fakeHolidays []assistant.Holiday
+
+func (f fakeHolidays) Holidays(context.Context, string, string, time.Time, time.Time) ([]assistant.Holiday, error) {
+	return f, nil
+}
+
+func TestSendDueDigests(t *testing.T) {
+	t.Run("sends due digests once per period", WithFixture(func(t *testing.T, f *Fixture) {
+		user := "alice-" + primitive.NewObjectID().Hex()
+		ctx := auth.WithUser(context.Background(), user)
+		notify := &fakeDigestNotifier{sent: map[string]*Digest{}}
+		srv := NewServer(f.Repository, &fakeAssistant{})
+		srv.EnableDigests(fakeHolidays{{Date: time.Now().AddDate(0, 0, 3), Name: "Fiesta"}}, notify)
+
+		// The first digest covers the day before it is due, so activity comes after subscribing.
+		now := time.Now()
+		active := now.Add(2 * time.Hour)
+		conv := f.CreateConversation(func(c *model.Conversation) {
+			c.UpdatedAt = active
+			c.Messages = append(c.Messages, &model.Message{ID: primitive.NewObjectID(), Role: model.RoleAssistant, Content: "Sunny", CreatedAt: active})
+			c.Messages[0].CreatedAt = active
+			c.Reads = []*model.ReadMarker{{UserID: user, MessageID: c.Messages[0].ID}}
+		})
+
+		if _, err := srv.SetDigestSubscription(ctx, &pb.SetDigestSubscriptionRequest{
+			Frequency: pb.DigestFrequency_DIGEST_FREQUENCY_DAILY,
+			Delivery:  pb.DigestDelivery_DIGEST_DELIVERY_WEBHOOK,
+		}); err != nil {
+			t.Fatalf("SetDigestSubscription error: %v", err)
+		}
+		t.Cleanup(func() { _ = f.SetDigestSubscription(context.Background(), user, nil) })
+
+		// Other users' digests may be due in the shared database, so only alice's is checked.
+		if _, err := srv.sendDueDigests(ctx, now.Add(25*time.Hour)); err != nil {
+			t.Fatalf("sendDueDigests error: %v", err)
+		}
+		d := notify.sent[user]
+		if d == nil {
+			t.Fatal("expected a digest for alice")
+		}
+		if len(d.Conversations) != 1 || d.Conversations[0].ID != conv.ID.Hex() || d.Conversations[0].UnreadReplies != 1 {
+			t.Errorf("unexpected conversations: %+v", d.Conversations)
+		}
+		if len(d.Holidays) != 1 {
+			t.Errorf("expected the upcoming holiday, got %+v", d.Holidays)
+		}
+
+		delete(notify.sent, user)
+		if _, err := srv.sendDueDigests(ctx, now.Add(26*time.Hour)); err != nil {
+			t.Fatalf("sendDueDigests error: %v", err)
+		}
+		if notify.sent[user] != nil {
+			t.Error("expected the next digest to wait for the next period")
+		}
+
+		out, err := srv.GetDigestSubscription(ctx, &pb.GetDigestSubscriptionRequest{})
+		if err != nil {
+			t.Fatalf("GetDigestSubscription error: %v", err)
+		}
+		if got := out.GetSubscription().GetLastSentAt(); got == nil || !got.AsTime().After(now) {
+			t.Errorf("expected last_sent_at to be recorded, got %v", got)
+		}
+	}))
+}
+
+func TestDeliverDigest_OnlyVisibleToTheUser(t *testing.T) {
+	t.Run("as message", WithFixture(func(t *testing.T, f *Fixture) {
+		srv := NewServer(f.Repository, &fakeAssistant{})
+		user := "digest-" + primitive.NewObjectID().Hex()
+		alice, bob := auth.WithUser(context.Background(), user), auth.WithUser(context.Background(), "bob")
+
+		d := &Digest{Frequency: model.DigestDaily, Until: time.Now()}
+		if err := srv.deliverDigest(alice, user, model.DigestAsMessage, d); err != nil {
+			t.Fatalf("deliverDigest error: %v", err)
+		}
+
+		list, err := srv.ListConversations(alice, &pb.ListConversationsRequest{})
+		if err != nil {
+			t.Fatalf("ListConversations error: %v", err)
+		}
+		var id string
+		for _, c := range list.GetConversations() {
+			if slices.Contains(c.GetTags(), digestTag) {
+				id = c.GetId()
+			}
+		}
+		if id == "" {
+			t.Fatal("digest not listed for its user")
+		}
+		t.Cleanup(func() { _ = f.DeleteConversation(context.Background(), id) })
+
+		if _, err := srv.DescribeConversation(bob, &pb.DescribeConversationRequest{ConversationId: id}); twirpCode(err) != twirp.NotFound {
+			t.Errorf("expected NotFound describing another user's digest, got %v", err)
+		}
+		list, err = srv.ListConversations(bob, &pb.ListConversationsRequest{IncludePreview: true})
+		if err != nil {
+			t.Fatalf("ListConversations error: %v", err)
+		}
+		for _, c := range list.GetConversations() {
+			if c.GetId() == id {
+				t.Error("another user's digest is listed")
+			}
+		}
+	}))
+}
@@ -0,0 +1,556 @@
+package chat
+
+import (
+	"bufio"
+	"bytes"
+	"cmp"
+	"context"
+	"crypto/subtle"
+	"crypto/tls"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/http"
+	"net/mail"
+	"net/smtp"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/acai-travel/tech-challenge/internal/auth"
+	"github.com/acai-travel/tech-challenge/internal/chat/model"
+	"github.com/acai-travel/tech-challenge/internal/pii"
+	"github.com/acai-travel/tech-challenge/internal/textx"
+	"github.com/twitchtv/twirp"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	// maxInboundEmail bounds the size of a received email, attachments included.
+	maxInboundEmail = 10 << 20
+	// emailTag marks the conversations held over email.
+	emailTag = "email"
+	// emailReplyTimeout bounds answering an email, from receiving it to sending the reply.
+	emailReplyTimeout = 3 * time.Minute
+	// smtpTimeout bounds sending an email when the context has no earlier deadline.
+	smtpTimeout = 30 * time.Second
+)
+
+// MailSender sends the assistant's replies to email threads.
+type MailSender interface {
+	SendMail(ctx context.Context, m *OutgoingEmail) error
+}
+
+// OutgoingEmail is a plain text email replying in a thread.
+type OutgoingEmail struct {
+	From, To  string
+	Subject   string
+	MessageID string
+	// InReplyTo and References thread the email, as in RFC 5322.
+	InReplyTo  string
+	References []string
+	Body       string
+}
+
+// emailChannel answers emails; nil sender means the channel is disabled.
+type emailChannel struct {
+	address string
+	sender  MailSender
+	secret  string
+}
+
+// EnableEmail lets users talk to the assistant by email: EmailHandler receives the emails
+// sent to address from the user's email provider, and replies are sent from address with
+// sender. Emails continuing a thread continue its conversation. Webhook calls must carry
+// secret.
+func (s *Server) EnableEmail(address string, sender MailSender, secret string) {
+	s.email = emailChannel{address: address, sender: sender, secret: secret}
+}
+
+var errEmailDisabled = twirp.NewError(twirp.Unimplemented, "the email channel is not enabled")
+
+// inboundEmail is the part of a received email the channel uses.
+type inboundEmail struct {
+	From      string
+	Subject   string
+	MessageID string
+	// Thread are the Message-IDs of the emails it replies to, from In-Reply-To and References.
+	Thread []string
+	Body   string
+	// Automatic is set for auto-replies, bounces and mailing list traffic, which are not
+	// answered so two robots don't write to each other forever.
+	Automatic bool
+	// Authenticated is set when the receiving server verified the sender, see authenticated.
+	Authenticated bool
+}
+
+// EmailHandler receives emails from an email provider's inbound webhook, and answers them
+// in the background. It accepts the raw RFC 5322 message as the request body, or as the
+// "email" (SendGrid) or "body-mime" (Mailgun) field of a form. The secret of EnableEmail
+// must be in the X-Webhook-Secret header or the token query parameter. Emails whose sender
+// the provider didn't authenticate are ignored, as anyone can write any From header.
+func (s *Server) EmailHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if s.email.sender == nil {
+			http.Error(w, errEmailDisabled.Msg(), http.StatusNotImplemented)
+			return
+		}
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		token := r.Header.Get("X-Webhook-Secret")
+		if token == "" {
+			token = r.URL.Query().Get("token")
+		}
+		if subtle.ConstantTimeCompare([]byte(token), []byte(s.email.secret)) != 1 {
+			http.Error(w, "invalid webhook secret", http.StatusUnauthorized)
+			return
+		}
+
+		raw, err := rawEmail(w, r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		in, err := parseEmail(raw)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if in.Automatic || strings.EqualFold(in.From, s.email.address) || strings.TrimSpace(in.Body) == "" {
+			slog.InfoContext(r.Context(), "Ignoring email", "message_id", in.MessageID, "automatic", in.Automatic)
+			w.WriteHeader(http.StatusAccepted)
+			return
+		}
+		if !in.Authenticated {
+			slog.WarnContext(r.Context(), "Ignoring unauthenticated email", "message_id", in.MessageID)
+			w.WriteHeader(http.StatusAccepted)
+			return
+		}
+
+		// Providers time out webhooks long before a reply is ready, and retry them.
+		go func() {
+			ctx, cancel := context.WithTimeout(context.WithoutCancel(r.Context()), emailReplyTimeout)
+			defer cancel()
+			if err := s.answerEmail(ctx, in); err != nil {
+				slog.ErrorContext(ctx, "Failed to answer email", "message_id", in.MessageID, "error", err)
+			}
+		}()
+		w.WriteHeader(http.StatusAccepted)
+	})
+}
+
+// rawEmail returns the RFC 5322 message of a webhook request.
+func rawEmail(w http.ResponseWriter, r *http.Request) ([]byte, error) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxInboundEmail)
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	switch mediaType {
+	case "multipart/form-data", "application/x-www-form-urlencoded":
+		if err := r.ParseMultipartForm(maxInboundEmail); err != nil && !errors.Is(err, http.ErrNotMultipart) {
+			return nil, fmt.Errorf("invalid form: %w", err)
+		}
+		for _, field := range []string{"email", "body-mime"} {
+			if v := r.FormValue(field); v != "" {
+				return []byte(v), nil
+			}
+		}
+		return nil, errors.New(`the form has no "email" or "body-mime" field`)
+	default:
+		raw, err := io.ReadAll(r.Body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the email: %w", err)
+		}
+		return raw, nil
+	}
+}
+
+// parseEmail reads the sender, threading headers and plain text of an email.
+func parseEmail(raw []byte) (*inboundEmail, error) {
+	msg, err := mail.ReadMessage(bytes.NewReader(raw))
+	if err != nil {
+		return nil, fmt.Errorf("invalid email: %w", err)
+	}
+	from, err := mail.ParseAddress(msg.Header.Get("From"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid From header: %w", err)
+	}
+
+	var dec mime.WordDecoder
+	subject, err := dec.DecodeHeader(msg.Header.Get("Subject"))
+	if err != nil {
+		subject = msg.Header.Get("Subject")
+	}
+	in := &inboundEmail{
+		From:      strings.ToLower(from.Address),
+		Subject:   strings.TrimSpace(subject),
+		MessageID: strings.TrimSpace(msg.Header.Get("Message-ID")),
+		Thread:    messageIDs(msg.Header.Get("In-Reply-To") + " " + msg.Header.Get("References")),
+	}
+	auto := strings.ToLower(msg.Header.Get("Auto-Submitted"))
+	in.Automatic = (auto != "" && auto != "no") || msg.Header.Get("List-Id") != "" ||
+		strings.EqualFold(msg.Header.Get("Precedence"), "bulk") || strings.EqualFold(msg.Header.Get("Precedence"), "auto_reply")
+	in.Authenticated = authenticated(msg.Header.Get("Authentication-Results"), in.From)
+
+	text, err := plainText(msg.Header, msg.Body)
+	if err != nil {
+		return nil, err
+	}
+	in.Body = stripQuotedReply(text)
+	return in, nil
+}
+
+// resultComment matches the comments of an Authentication-Results header.
+var resultComment = regexp.MustCompile(`\([^)]*\)`)
+
+// authenticated reports whether an Authentication-Results header (RFC 8601) shows that the
+// domain of from passed DMARC, or SPF or DKIM for a domain aligned with it. Only the topmost
+// header counts, as it is added by the provider's receiving server; those below it may have
+// been written by the sender.
+func authenticated(results, from string) bool {
+	_, domain, ok := strings.Cut(from, "@")
+	if !ok {
+		return false
+	}
+	methods := strings.Split(resultComment.ReplaceAllString(results, ""), ";")
+	for _, m := range methods[1:] { // the first is the ID of the server
+		fields := strings.Fields(strings.ToLower(m))
+		if len(fields) == 0 {
+			continue
+		}
+		method, result, _ := strings.Cut(fields[0], "=")
+		if result != "pass" {
+			continue
+		}
+		props := map[string]string{}
+		for _, f := range fields[1:] {
+			if k, v, ok := strings.Cut(f, "="); ok {
+				props[k] = strings.TrimPrefix(v[strings.LastIndex(v, "@")+1:], "@")
+			}
+		}
+		var checked string
+		switch method {
+		case "dmarc":
+			checked = cmp.Or(props["header.from"], domain)
+		case "dkim":
+			checked = cmp.Or(props["header.d"], props["header.i"])
+		case "spf":
+			checked = cmp.Or(props["smtp.mailfrom"], props["smtp.helo"])
+		}
+		if checked != "" && alignedDomains(checked, domain) {
+			return true
+		}
+	}
+	return false
+}
+
+// alignedDomains reports whether two domains are equal or one is a subdomain of the other,
+// as for relaxed DMARC alignment.
+func alignedDomains(a, b string) bool {
+	return a == b || strings.HasSuffix(a, "."+b) || strings.HasSuffix(b, "."+a)
+}
+
+// plainText returns the text/plain body of a message or part, looking into multipart ones.
+func plainText(header map[string][]string, body io.Reader) (string, error) {
+	get := func(k string) string {
+		if v := header[k]; len(v) > 0 {
+			return v[0]
+		}
+		return ""
+	}
+	mediaType, params, err := mime.ParseMediaType(get("Content-Type"))
+	if err != nil {
+		mediaType = "text/plain" // the default of RFC 2045
+	}
+
+	if strings.HasPrefix(mediaType, "multipart/") {
+		mr := multipart.NewReader(body, params["boundary"])
+		for {
+			part, err := mr.NextRawPart()
+			if errors.Is(err, io.EOF) {
+				return "", nil
+			}
+			if err != nil {
+				return "", fmt.Errorf("invalid multipart email: %w", err)
+			}
+			if text, err := plainText(part.Header, part); err != nil || text != "" {
+				return text, err
+			}
+		}
+	}
+	if mediaType != "text/plain" || strings.HasPrefix(get("Content-Disposition"), "attachment") {
+		return "", nil
+	}
+
+	switch strings.ToLower(get("Content-Transfer-Encoding")) {
+	case "quoted-printable":
+		body = quotedprintable.NewReader(body)
+	case "base64":
+		body = base64.NewDecoder(base64.StdEncoding, body) // it skips line breaks
+	}
+	b, err := io.ReadAll(io.LimitReader(body, maxInboundEmail))
+	if err != nil {
+		return "", fmt.Errorf("failed to decode the email: %w", err)
+	}
+	return textx.RepairMojibake(string(b)), nil
+}
+
+// replyHeader matches the line mail clients put above the quoted email, e.g.
+// "On Mon, 5 May 2026 at 10:00, Ana <ana@example.com> wrote:".
+var replyHeader = regexp.MustCompile(`(?i)^(on .+ wrote:|el .+ escribió:|le .+ a écrit :|am .+ schrieb .+:|-+ ?original message ?-+)$`)
+
+// stripQuotedReply removes the quoted email and the signature below a reply, so only what
+// the user wrote becomes the message.
+func stripQuotedReply(text string) string {
+	var lines []string
+	sc := bufio.NewScanner(strings.NewReader(strings.ReplaceAll(text, "\r\n", "\n")))
+	sc.Buffer(nil, maxInboundEmail)
+	for sc.Scan() {
+		line := strings.TrimRight(sc.Text(), " \t")
+		if replyHeader.MatchString(strings.TrimSpace(line)) || line == "-- " {
+			break
+		}
+		if strings.HasPrefix(line, ">") {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+// messageIDs splits a list of Message-IDs, e.g. of a References header.
+func messageIDs(s string) []string {
+	var out []string
+	for _, f := range strings.Fields(s) {
+		if strings.HasPrefix(f, "<") && strings.HasSuffix(f, ">") {
+			out = append(out, f)
+		}
+	}
+	return out
+}
+
+// answerEmail adds an email to the conversation of its thread, or starts one, and emails the
+// reply back.
+func (s *Server) answerEmail(ctx context.Context, in *inboundEmail) error {
+	ctx = auth.WithUser(ctx, in.From)
+
+	if in.MessageID != "" {
+		// A redelivered email was already answered.
+		if conv, err := s.repo.FindEmailConversation(ctx, []string{in.MessageID}); err != nil || conv != nil {
+			return err
+		}
+	}
+
+	conv, err := s.repo.FindEmailConversation(ctx, in.Thread)
+	if err != nil {
+		return err
+	}
+	if conv != nil && conv.Email.Address != in.From {
+		// Only the user who started a thread may continue its conversation.
+		conv = nil
+	}
+
+	now := time.Now()
+	msg := &model.Message{ID: primitive.NewObjectID(), Role: model.RoleUser, Content: in.Body, CreatedAt: now, UpdatedAt: now}
+	msg.PII = pii.Kinds(s.pii.Detect(in.Body))
+	received := []string{}
+	if in.MessageID != "" {
+		received = append(received, in.MessageID)
+	}
+
+	if conv == nil {
+		conv = &model.Conversation{
+			ID:        primitive.NewObjectID(),
+			Title:     textx.Title(threadSubject(in.Subject), 80),
+			CreatedAt: now,
+			UpdatedAt: now,
+			Messages:  []*model.Message{msg},
+			Tags:      []string{emailTag},
+			Email:     &model.EmailThread{Address: in.From, Subject: in.Subject, MessageIDs: received},
+			Owner:     in.From,
+		}
+		if err := s.repo.CreateConversation(ctx, conv); err != nil {
+			return err
+		}
+	} else {
+		unlock, err := s.lockConversation(ctx, conv.ID.Hex())
+		if err != nil {
+			return err
+		}
+		defer unlock()
+
+		if err := s.repo.AppendMessages(ctx, conv.ID, msg); err != nil {
+			return err
+		}
+		if len(received) > 0 {
+			if err := s.repo.AddEmailMessageIDs(ctx, conv.ID, received...); err != nil {
+				return err
+			}
+		}
+		conv.Messages = append(conv.Messages, msg)
+	}
+	s.index(ctx, conv, msg)
+
+	body := "Sorry, I couldn't answer your last email. Please send it again in a few minutes."
+	if err := s.checkAbuse(ctx, msg.Content); err != nil {
+		notice, ok := abuseNotice(err)
+		if !ok {
+			return nil
+		}
+		body = notice
+	} else if err := s.checkQuota(ctx); err != nil {
+		body = quotaNotice(err)
+	} else if answer, err := s.sendReply(ctx, conv, msg); err != nil {
+		slog.ErrorContext(ctx, "Failed to reply to email", "conversation_id", conv.ID.Hex(), "error", err)
+	} else {
+		body = answer.Content
+	}
+
+	reply := &OutgoingEmail{
+		From:       s.email.address,
+		To:         in.From,
+		Subject:    replySubject(conv.Email.Subject),
+		MessageID:  newMessageID(s.email.address),
+		InReplyTo:  in.MessageID,
+		References: slices.Concat(in.Thread, received),
+		Body:       body,
+	}
+	if err := s.email.sender.SendMail(ctx, reply); err != nil {
+		return fmt.Errorf("failed to send the reply: %w", err)
+	}
+	return s.repo.AddEmailMessageIDs(ctx, conv.ID, reply.MessageID)
+}
+
+// threadSubject returns the subject of a thread without reply prefixes, or a default title.
+func threadSubject(subject string) string {
+	for {
+		trimmed := strings.TrimSpace(subject)
+		lower := strings.ToLower(trimmed)
+		cut := false
+		for _, prefix := range []string{"re:", "fwd:", "fw:", "aw:", "sv:"} {
+			if strings.HasPrefix(lower, prefix) {
+				trimmed, cut = trimmed[len(prefix):], true
+				break
+			}
+		}
+		if !cut {
+			if trimmed == "" {
+				return "Email conversation"
+			}
+			return trimmed
+		}
+		subject = trimmed
+	}
+}
+
+func replySubject(subject string) string {
+	return "Re: " + threadSubject(subject)
+}
+
+// newMessageID returns a unique Message-ID in the domain of address.
+func newMessageID(address string) string {
+	domain := "localhost"
+	if _, d, ok := strings.Cut(address, "@"); ok {
+		domain = d
+	}
+	return "<" + primitive.NewObjectID().Hex() + "@" + domain + ">"
+}
+
+// SMTPSender sends emails through an SMTP server.
+type SMTPSender struct {
+	addr string
+	auth smtp.Auth
+}
+
+// NewSMTPSender sends through the SMTP server at addr (host:port), authenticating with user
+// and password unless user is empty.
+func NewSMTPSender(addr, user, password string) *SMTPSender {
+	s := &SMTPSender{addr: addr}
+	if user != "" {
+		host, _, _ := net.SplitHostPort(addr)
+		s.auth = smtp.PlainAuth("", user, password, host)
+	}
+	return s
+}
+
+// SendMail sends m, giving up when ctx is done or after smtpTimeout, whichever is first.
+// Like smtp.SendMail, it upgrades to TLS when the server supports it.
+func (s *SMTPSender) SendMail(ctx context.Context, m *OutgoingEmail) error {
+	ctx, cancel := context.WithTimeout(ctx, smtpTimeout)
+	defer cancel()
+
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", s.addr)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	deadline, _ := ctx.Deadline()
+	if err := conn.SetDeadline(deadline); err != nil {
+		return err
+	}
+	// Closing the connection interrupts the exchange when ctx is canceled.
+	stop := context.AfterFunc(ctx, func() { _ = conn.Close() })
+	defer stop()
+
+	host, _, _ := net.SplitHostPort(s.addr)
+	c, err := smtp.NewClient(conn, host)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+	if ok, _ := c.Extension("STARTTLS"); ok {
+		if err := c.StartTLS(&tls.Config{ServerName: host}); err != nil {
+			return err
+		}
+	}
+	if s.auth != nil {
+		if err := c.Auth(s.auth); err != nil {
+			return err
+		}
+	}
+	if err := c.Mail(m.From); err != nil {
+		return err
+	}
+	if err := c.Rcpt(m.To); err != nil {
+		return err
+	}
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(m.Bytes()); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return c.Quit()
+}
+
+// Bytes renders the email in RFC 5322 format, its body quoted-printable encoded.
+func (m *OutgoingEmail) Bytes() []byte {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "From: %s\r\nTo: %s\r\n", m.From, m.To)
+	fmt.Fprintf(&b, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", m.Subject))
+	fmt.Fprintf(&b, "Date: %s\r\nMessage-ID: %s\r\n", time.Now().Format(time.RFC1123Z), m.MessageID)
+	if m.InReplyTo != "" {
+		fmt.Fprintf(&b, "In-Reply-To: %s\r\n", m.InReplyTo)
+	}
+	if len(m.References) > 0 {
+		fmt.Fprintf(&b, "References: %s\r\n", strings.Join(m.References, " "))
+	}
+	b.WriteString("Auto-Submitted: auto-replied\r\nMIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\nContent-Transfer-Encoding: quoted-printable\r\n\r\n")
+	qp := quotedprintable.NewWriter(&b)
+	_, _ = qp.Write([]byte(strings.ReplaceAll(m.Body, "\n", "\r\n")))
+	_ = qp.Close()
+	return b.Bytes()
+}
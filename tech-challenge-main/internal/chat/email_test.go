@@ -0,0 +1,228 @@
+package chat
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/acai-travel/tech-challenge/internal/chat/model"
+	. "github.com/acai-travel/tech-challenge/internal/chat/testing"
+)
+
+const testEmail = "Authentication-Results: mx.example.org; dkim=pass header.d=example.com header.s=s1;\r\n" +
+	" spf=softfail (example.org: domain of ana@example.com doesn't designate it) smtp.mailfrom=ana@example.com\r\n" +
+	"From: Ana Pérez <Ana@Example.com>\r\n" +
+	"To: assistant@example.org\r\n" +
+	"Subject: =?utf-8?q?Re:_Caf=C3=A9s_in_Lisbon?=\r\n" +
+	"Message-ID: <2@example.com>\r\n" +
+	"In-Reply-To: <1@example.org>\r\n" +
+	"References: <0@example.com> <1@example.org>\r\n" +
+	"MIME-Version: 1.0\r\n" +
+	"Content-Type: multipart/alternative; boundary=b1\r\n" +
+	"\r\n" +
+	"--b1\r\n" +
+	"Content-Type: text/plain; charset=utf-8\r\n" +
+	"Content-Transfer-Encoding: quoted-printable\r\n" +
+	"\r\n" +
+	"And which one is open on Sund=\r\n" +
+	"ay?\r\n" +
+	"\r\n" +
+	"On Mon, 4 May 2026 at 10:00, Assistant <assistant@example.org> wrote:\r\n" +
+	"> Try Caf=C3=A9 A Brasileira.\r\n" +
+	"--b1\r\n" +
+	"Content-Type: text/html; charset=utf-8\r\n" +
+	"\r\n" +
+	"<p>And which one is open on Sunday?</p>\r\n" +
+	"--b1--\r\n"
+
+func TestParseEmail(t *testing.T) {
+	in, err := parseEmail([]byte(testEmail))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if in.From != "ana@example.com" || in.Subject != "Re: Cafés in Lisbon" || in.MessageID != "<2@example.com>" {
+		t.Errorf("headers = %q, %q, %q", in.From, in.Subject, in.MessageID)
+	}
+	if got := strings.Join(in.Thread, " "); got != "<1@example.org> <0@example.com> <1@example.org>" {
+		t.Errorf("thread = %q", got)
+	}
+	if in.Body != "And which one is open on Sunday?" {
+		t.Errorf("body = %q; want the reply without the quoted email", in.Body)
+	}
+	if in.Automatic {
+		t.Error("expected a personal email not to be automatic")
+	}
+	if !in.Authenticated {
+		t.Error("expected an email with an aligned DKIM signature to be authenticated")
+	}
+
+	auto, err := parseEmail([]byte("From: mailer@example.com\r\nAuto-Submitted: auto-replied\r\nSubject: Out of office\r\n\r\nI'm away.\r\n"))
+	if err != nil || !auto.Automatic {
+		t.Errorf("expected an auto-reply to be automatic, got %+v, %v", auto, err)
+	}
+}
+
+func TestAuthenticated(t *testing.T) {
+	for _, tt := range []struct {
+		results string
+		want    bool
+	}{
+		{"mx.example.org; dmarc=pass (p=reject) header.from=example.com", true},
+		{"mx.example.org; dkim=pass header.i=@mail.example.com", true},
+		{"mx.example.org; spf=pass smtp.mailfrom=bounces@example.com", true},
+		{"mx.example.org; dkim=pass header.d=attacker.net; spf=pass smtp.mailfrom=attacker.net", false},
+		{"mx.example.org; dmarc=fail header.from=example.com; spf=none", false},
+		{"mx.example.org; dkim=pass header.d=notexample.com", false},
+		// Only the server's own verdict counts, not one pretending to be a method.
+		{"dmarc=pass", false},
+		{"", false},
+	} {
+		if got := authenticated(tt.results, "ana@example.com"); got != tt.want {
+			t.Errorf("authenticated(%q) = %v; want %v", tt.results, got, tt.want)
+		}
+	}
+}
+
+func TestThreadSubject(t *testing.T) {
+	for subject, want := range map[string]string{
+		"Re: RE: Fwd: Trip to Rome": "Trip to Rome",
+		"Trip to Rome":              "Trip to Rome",
+		"Re: ":                      "Email conversation",
+	} {
+		if got := threadSubject(subject); got != want {
+			t.Errorf("threadSubject(%q) = %q; want %q", subject, got, want)
+		}
+	}
+}
+
+func TestEmailHandler_InvalidRequests(t *testing.T) {
+	disabled := NewServer(nil, &fakeAssistant{})
+	rec := httptest.NewRecorder()
+	disabled.EmailHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/email/inbound", strings.NewReader(testEmail)))
+	if rec.Code != http.StatusNotImplemented {
+		t.Errorf("disabled channel: got status %d", rec.Code)
+	}
+
+	srv := NewServer(nil, &fakeAssistant{})
+	srv.EnableEmail("assistant@example.org", &fakeMailSender{}, "s3cret")
+	tests := []struct {
+		name, target, body string
+		status             int
+	}{
+		{"missing secret", "/email/inbound", testEmail, http.StatusUnauthorized},
+		{"wrong secret", "/email/inbound?token=guess", testEmail, http.StatusUnauthorized},
+		{"not an email", "/email/inbound?token=s3cret", "hello", http.StatusBadRequest},
+		// Ignored without being answered, which would fail without a repository.
+		{"unauthenticated", "/email/inbound?token=s3cret", testEmail[strings.Index(testEmail, "From:"):], http.StatusAccepted},
+		{"own email", "/email/inbound?token=s3cret", "From: assistant@example.org\r\n\r\nLoop?\r\n", http.StatusAccepted},
+	}
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		srv.EmailHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodPost, tt.target, strings.NewReader(tt.body)))
+		if rec.Code != tt.status {
+			t.Errorf("%s: got status %d, want %d", tt.name, rec.Code, tt.status)
+		}
+	}
+}
+
+func TestSMTPSender_ContextTimeout(t *testing.T) {
+	// A server that accepts connections but never greets.
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			defer conn.Close()
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	err = NewSMTPSender(l.Addr().String(), "", "").SendMail(ctx, &OutgoingEmail{From: "a@example.org", To: "b@example.com"})
+	if err == nil {
+		t.Fatal("expected an error from a server that never answers")
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("SendMail took %v; want it to stop with the context", elapsed)
+	}
+}
+
+type fakeMailSender struct {
+	mu   sync.Mutex
+	sent []*OutgoingEmail
+}
+
+func (f *fakeMailSender) SendMail(_ context.Context, m *OutgoingEmail) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.sent = append(f.sent, m)
+	return nil
+}
+
+func TestAnswerEmail_Thread(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	repo := model.New(ConnectMongo())
+	fa := &fakeAssistant{
+		replyFn: func(_ context.Context, c *model.Conversation) (string, error) {
+			return "Reply to: " + c.Messages[len(c.Messages)-1].Content, nil
+		},
+	}
+	sender := &fakeMailSender{}
+	srv := NewServer(repo, fa)
+	srv.EnableEmail("assistant@example.org", sender, "s3cret")
+
+	first := &inboundEmail{From: "ana@example.com", Subject: "Cafés in Lisbon", MessageID: "<" + t.Name() + ".1@example.com>", Body: "Good cafés in Lisbon?"}
+	if err := srv.answerEmail(ctx, first); err != nil {
+		t.Fatal(err)
+	}
+	if len(sender.sent) != 1 || sender.sent[0].To != "ana@example.com" || sender.sent[0].Subject != "Re: Cafés in Lisbon" {
+		t.Fatalf("sent = %+v", sender.sent)
+	}
+	reply := sender.sent[0]
+
+	second := &inboundEmail{From: "ana@example.com", Subject: "Re: Cafés in Lisbon", MessageID: "<" + t.Name() + ".2@example.com>",
+		Thread: []string{reply.MessageID, first.MessageID}, Body: "Open on Sunday?"}
+	if err := srv.answerEmail(ctx, second); err != nil {
+		t.Fatal(err)
+	}
+	// A redelivery of the same email is not answered twice.
+	if err := srv.answerEmail(ctx, second); err != nil {
+		t.Fatal(err)
+	}
+	if len(sender.sent) != 2 || sender.sent[1].InReplyTo != second.MessageID {
+		t.Fatalf("sent = %+v", sender.sent)
+	}
+
+	conv, err := repo.FindEmailConversation(ctx, []string{second.MessageID})
+	if err != nil || conv == nil {
+		t.Fatalf("FindEmailConversation = %v, %v", conv, err)
+	}
+	if got := len(conv.Messages); got != 4 {
+		t.Errorf("expected both emails and replies in one conversation, got %d messages", got)
+	}
+	if conv.Owner != "ana@example.com" {
+		t.Errorf("owner = %q; want the sender, so the API shows the conversation to them only", conv.Owner)
+	}
+
+	// Someone else referencing the thread starts their own conversation.
+	other := &inboundEmail{From: "eve@example.com", MessageID: "<" + t.Name() + ".3@example.com>", Thread: []string{reply.MessageID}, Body: "Hi"}
+	if err := srv.answerEmail(ctx, other); err != nil {
+		t.Fatal(err)
+	}
+	if c, _ := repo.FindEmailConversation(ctx, []string{other.MessageID}); c == nil || c.ID == conv.ID {
+		t.Error("expected a separate conversation for another sender")
+	}
+}
@@ -0,0 +1,409 @@
+package chat
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/acai-travel/tech-challenge/internal/audit"
+	"github.com/acai-travel/tech-challenge/internal/auth"
+	"github.com/acai-travel/tech-challenge/internal/blob"
+	"github.com/acai-travel/tech-challenge/internal/chat/model"
+	"github.com/acai-travel/tech-challenge/internal/httpx"
+	"github.com/acai-travel/tech-challenge/internal/pb"
+	"github.com/acai-travel/tech-challenge/internal/pdf"
+	"github.com/twitchtv/twirp"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// exportPathPrefix is where ExportHandler serves finished archives.
+const exportPathPrefix = "/exports/"
+
+// JobNotifier is told when a background job finished, so users needn't poll GetBulkJob.
+type JobNotifier interface {
+	JobFinished(ctx context.Context, job *model.BulkJob) error
+}
+
+// exports builds conversation archives; nil blobs means exports are disabled.
+type exports struct {
+	blobs  blob.Store
+	notify JobNotifier
+}
+
+// EnableExportArchives stores the archives built for RequestExportArchive in store, telling
+// notify (if not nil) once they are ready.
+func (s *Server) EnableExportArchives(store blob.Store, notify JobNotifier) {
+	s.exports = exports{blobs: store, notify: notify}
+}
+
+var errExportsDisabled = twirp.NewError(twirp.Unimplemented, "export archives are not enabled")
+
+func (s *Server) RequestExportArchive(ctx context.Context, req *pb.RequestExportArchiveRequest) (*pb.RequestExportArchiveResponse, error) {
+	if s.exports.blobs == nil {
+		return nil, errExportsDisabled
+	}
+
+	if auth.User(ctx) == auth.Anonymous {
+		return nil, twirp.NewError(twirp.Unauthenticated, "export archives require an identified user")
+	}
+
+	// Archived conversations are exported too: an export is everything the caller owns.
+	ids, err := s.repo.FindConversationIDs(ctx, model.ConversationFilter{Owner: auth.User(ctx)})
+	if err != nil {
+		return nil, twirp.InternalErrorWith(err)
+	}
+
+	now := time.Now()
+	job := &model.BulkJob{
+		ID:        primitive.NewObjectID(),
+		UserID:    auth.User(ctx),
+		Action:    model.BulkExport,
+		State:     model.BulkJobRunning,
+		Total:     len(ids),
+		CreatedAt: now,
+		UpdatedAt: now,
+		// Renewed by the job for as long as it runs.
+		LeaseExpiresAt: now.Add(bulkJobLease),
+	}
+	if err := s.repo.CreateBulkJob(ctx, job); err != nil {
+		return nil, twirp.InternalErrorWith(err)
+	}
+	audit.Detail(ctx, "job_id", job.ID.Hex())
+
+	go s.runExportJob(job, ids)
+	return &pb.RequestExportArchiveResponse{JobId: job.ID.Hex()}, nil
+}
+
+func (s *Server) runExportJob(job *model.BulkJob, ids []primitive.ObjectID) {
+	save := func(ctx context.Context) {
+		job.UpdatedAt = time.Now()
+		if err := s.repo.UpdateBulkJob(ctx, job); err != nil {
+			slog.ErrorContext(ctx, "Failed to save export job progress", "job_id", job.ID.Hex(), "error", err)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), bulkJobTimeout)
+	stopLease := s.holdBulkJob(ctx, job)
+	err := s.storeArchive(ctx, job.ID.Hex(), ids, func(count int) {
+		job.Count = count
+		if count%bulkBatchSize == 0 {
+			save(ctx)
+		}
+	})
+	stopLease()
+	cancel()
+
+	job.State, job.DownloadURL = model.BulkJobDone, exportPathPrefix+job.ID.Hex()
+	if err != nil {
+		job.State, job.DownloadURL, job.Error = model.BulkJobFailed, "", err.Error()
+		slog.Error("Export job failed", "job_id", job.ID.Hex(), "count", job.Count, "error", err)
+	}
+
+	// The job's own context may have timed out.
+	ctx, cancel = context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	save(ctx)
+
+	if s.exports.notify != nil {
+		if err := s.exports.notify.JobFinished(ctx, job); err != nil {
+			slog.ErrorContext(ctx, "Failed to notify export job end", "job_id", job.ID.Hex(), "error", err)
+		}
+	}
+}
+
+// storeArchive writes the archive of ids to the blob store under key, calling progress
+// after each conversation. The archive is streamed, never held in memory.
+func (s *Server) storeArchive(ctx context.Context, key string, ids []primitive.ObjectID, progress func(count int)) error {
+	pr, pw := io.Pipe()
+	written := make(chan error, 1)
+	go func() {
+		err := s.writeArchive(ctx, pw, ids, progress)
+		_ = pw.CloseWithError(err)
+		written <- err
+	}()
+
+	err := s.exports.blobs.Put(ctx, key, pr)
+	_ = pr.Close() // unblocks the writer if the store gave up
+	if werr := <-written; werr != nil && !errors.Is(werr, io.ErrClosedPipe) {
+		return werr
+	}
+	return err
+}
+
+// writeArchive writes a zip with a Markdown and a JSON file per conversation. Conversations
+// deleted while exporting are left out.
+func (s *Server) writeArchive(ctx context.Context, w io.Writer, ids []primitive.ObjectID, progress func(count int)) error {
+	zw := zip.NewWriter(w)
+	for i, id := range ids {
+		conv, err := s.repo.DescribeConversation(ctx, id.Hex())
+		var te twirp.Error
+		if errors.As(err, &te) && te.Code() == twirp.NotFound {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		name := archiveName(conv)
+		data, err := protojson.MarshalOptions{Multiline: true}.Marshal(conv.Proto())
+		if err != nil {
+			return err
+		}
+		if err := writeArchiveFile(zw, name+".json", conv.UpdatedAt, data); err != nil {
+			return err
+		}
+		if err := writeArchiveFile(zw, name+".md", conv.UpdatedAt, conversationMarkdown(conv, true)); err != nil {
+			return err
+		}
+		progress(i + 1)
+	}
+	return zw.Close()
+}
+
+func writeArchiveFile(zw *zip.Writer, name string, modified time.Time, data []byte) error {
+	f, err := zw.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate, Modified: modified})
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(data)
+	return err
+}
+
+// archiveName names the files of a conversation by date and title, so they sort and read
+// well in a file browser; the ID keeps names unique.
+func archiveName(conv *model.Conversation) string {
+	slug := strings.Map(func(r rune) rune {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			return unicode.ToLower(r)
+		case unicode.IsSpace(r) || r == '-':
+			return '-'
+		default:
+			return -1
+		}
+	}, conv.Title)
+	slug = strings.Join(strings.FieldsFunc(slug, func(r rune) bool { return r == '-' }), "-")
+	if r := []rune(slug); len(r) > 50 {
+		slug = strings.TrimRight(string(r[:50]), "-")
+	}
+
+	name := conv.CreatedAt.UTC().Format("2006-01-02")
+	if slug != "" {
+		name += "-" + slug
+	}
+	return "conversations/" + name + "-" + conv.ID.Hex()
+}
+
+func transcriptTitle(conv *model.Conversation) string {
+	if conv.Title == "" {
+		return "Untitled conversation"
+	}
+	return conv.Title
+}
+
+// transcriptDetails is the line under the title of a transcript.
+func transcriptDetails(conv *model.Conversation) string {
+	details := "Started " + conv.CreatedAt.UTC().Format(time.RFC1123)
+	if len(conv.Tags) > 0 {
+		details += " · tags: " + strings.Join(conv.Tags, ", ")
+	}
+	if conv.ArchivedAt != nil {
+		details += " · archived"
+	}
+	return details
+}
+
+func messageHeading(m *model.Message) string {
+	author := "You"
+	if m.Role == model.RoleAssistant {
+		author = "Assistant"
+	}
+	return author + " · " + m.CreatedAt.UTC().Format("2006-01-02 15:04") + " UTC"
+}
+
+func toolCallLine(t *model.ToolCall) string {
+	if t.Result == "" {
+		return t.Call
+	}
+	return t.Call + " — " + t.Result
+}
+
+// conversationMarkdown renders a conversation for reading, with tool traces if tools.
+func conversationMarkdown(conv *model.Conversation, tools bool) []byte {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "# %s\n\n%s\n", transcriptTitle(conv), transcriptDetails(conv))
+	for _, m := range conv.Messages {
+		fmt.Fprintf(&b, "\n## %s\n\n", messageHeading(m))
+		if tools {
+			for _, t := range m.Tools {
+				fmt.Fprintf(&b, "> %s\n", toolCallLine(t))
+			}
+			if len(m.Tools) > 0 {
+				b.WriteString("\n")
+			}
+		}
+		fmt.Fprintf(&b, "%s\n", strings.TrimSpace(m.Content))
+		for _, a := range m.Attachments {
+			fmt.Fprintf(&b, "\n- Attachment: %s (%s)\n", a.Filename, a.ContentType)
+		}
+	}
+	return b.Bytes()
+}
+
+// conversationPDF renders a conversation for printing, with tool traces if tools.
+func conversationPDF(conv *model.Conversation, tools bool) []byte {
+	doc := pdf.New(transcriptTitle(conv))
+	doc.Paragraph(pdf.Title, transcriptTitle(conv))
+	doc.Paragraph(pdf.Note, transcriptDetails(conv))
+	for _, m := range conv.Messages {
+		doc.Space(8)
+		doc.Paragraph(pdf.Heading, messageHeading(m))
+		if tools {
+			for _, t := range m.Tools {
+				doc.Paragraph(pdf.Note, toolCallLine(t))
+			}
+		}
+		doc.Paragraph(pdf.Body, strings.TrimSpace(m.Content))
+		for _, a := range m.Attachments {
+			doc.Paragraph(pdf.Note, fmt.Sprintf("Attachment: %s (%s)", a.Filename, a.ContentType))
+		}
+	}
+	return doc.Bytes()
+}
+
+func (s *Server) ExportConversation(ctx context.Context, req *pb.ExportConversationRequest) (*pb.ExportConversationResponse, error) {
+	if req.GetConversationId() == "" {
+		return nil, twirp.RequiredArgumentError("conversation_id")
+	}
+
+	audit.Conversation(ctx, req.GetConversationId())
+	conv, err := s.describeConversation(ctx, req.GetConversationId())
+	if err != nil {
+		return nil, err
+	}
+
+	name := strings.TrimPrefix(archiveName(conv), "conversations/")
+	tools := req.GetIncludeToolTraces()
+	resp := &pb.ExportConversationResponse{}
+	switch req.GetFormat() {
+	case pb.ExportConversationRequest_MARKDOWN:
+		resp.Filename, resp.ContentType, resp.Data = name+".md", "text/markdown; charset=utf-8", conversationMarkdown(conv, tools)
+	case pb.ExportConversationRequest_JSON:
+		proto := conv.Proto()
+		if !tools {
+			for _, m := range proto.GetMessages() {
+				m.ToolCalls = nil
+			}
+		}
+		data, err := protojson.MarshalOptions{Multiline: true}.Marshal(proto)
+		if err != nil {
+			return nil, twirp.InternalErrorWith(err)
+		}
+		resp.Filename, resp.ContentType, resp.Data = name+".json", "application/json", data
+	case pb.ExportConversationRequest_PDF:
+		resp.Filename, resp.ContentType, resp.Data = name+".pdf", "application/pdf", conversationPDF(conv, tools)
+	default:
+		return nil, twirp.InvalidArgumentError("format", "must be MARKDOWN, JSON or PDF")
+	}
+
+	return resp, nil
+}
+
+// ExportHandler serves the archives of finished exports at their download URL, to the user
+// who requested them.
+func (s *Server) ExportHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if s.exports.blobs == nil {
+			http.Error(w, errExportsDisabled.Msg(), http.StatusNotImplemented)
+			return
+		}
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		ctx := r.Context()
+		job, err := s.repo.FindBulkJob(ctx, strings.TrimPrefix(r.URL.Path, exportPathPrefix), auth.User(ctx))
+		var te twirp.Error
+		if errors.As(err, &te) || (err == nil && (job.Action != model.BulkExport || job.State != model.BulkJobDone)) {
+			http.Error(w, "export not found", http.StatusNotFound)
+			return
+		}
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to find export job", "error", err)
+			http.Error(w, "internal error", http.StatusInternalServerError)
+			return
+		}
+
+		archive, err := s.exports.blobs.Get(ctx, job.ID.Hex())
+		if errors.Is(err, blob.ErrNotFound) {
+			http.Error(w, "export not found", http.StatusNotFound)
+			return
+		}
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to open export archive", "job_id", job.ID.Hex(), "error", err)
+			http.Error(w, "internal error", http.StatusInternalServerError)
+			return
+		}
+		defer archive.Close()
+
+		w.Header().Set("Content-Type", "application/zip")
+		w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="conversations-%s.zip"`, job.CreatedAt.UTC().Format("2006-01-02")))
+		if _, err := io.Copy(w, archive); err != nil {
+			slog.WarnContext(ctx, "Failed to send export archive", "job_id", job.ID.Hex(), "error", err)
+		}
+	})
+}
+
+// WebhookNotifier posts finished jobs, digests and notifications as JSON to a URL.
+type WebhookNotifier struct {
+	url    string
+	client *http.Client
+}
+
+func NewWebhookNotifier(url string) *WebhookNotifier {
+	return &WebhookNotifier{url: url, client: httpx.Client(10 * time.Second)}
+}
+
+// JobFinished posts the job with the ID of the user who started it.
+func (n *WebhookNotifier) JobFinished(ctx context.Context, job *model.BulkJob) error {
+	payload, err := protojson.Marshal(job.Proto())
+	if err != nil {
+		return err
+	}
+	return n.post(ctx, map[string]any{"user_id": job.UserID, "job": json.RawMessage(payload)})
+}
+
+// post sends v as JSON, failing unless the webhook responds with a 2xx status.
+func (n *WebhookNotifier) post(ctx context.Context, v any) error {
+	body, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("webhook responded %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,202 @@
+package chat
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/acai-travel/tech-challenge/internal/auth"
+	"github.com/acai-travel/tech-challenge/internal/blob"
+	"github.com/acai-travel/tech-challenge/internal/chat/model"
+	. "github.com/acai-travel/tech-challenge/internal/chat/testing"
+	"github.com/acai-travel/tech-challenge/internal/pb"
+	"github.com/twitchtv/twirp"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestArchiveName(t *testing.T) {
+	conv := &model.Conversation{
+		ID:        primitive.NewObjectID(),
+		Title:     "Weather in Barcelona, next week?",
+		CreatedAt: time.Date(2025, 8, 20, 10, 0, 0, 0, time.UTC),
+	}
+	want := "conversations/2025-08-20-weather-in-barcelona-next-week-" + conv.ID.Hex()
+	if got := archiveName(conv); got != want {
+		t.Errorf("archiveName = %q, want %q", got, want)
+	}
+
+	conv.Title = "🌦️"
+	if got := archiveName(conv); got != "conversations/2025-08-20-"+conv.ID.Hex() {
+		t.Errorf("archiveName without a usable title = %q", got)
+	}
+}
+
+func TestConversationMarkdown(t *testing.T) {
+	conv := &model.Conversation{
+		Title:     "Packing",
+		CreatedAt: time.Date(2025, 8, 20, 10, 0, 0, 0, time.UTC),
+		Tags:      []string{"trip-2025"},
+		Messages: []*model.Message{
+			{Role: model.RoleUser, Content: "What should I pack?", CreatedAt: time.Date(2025, 8, 20, 10, 0, 0, 0, time.UTC)},
+			{Role: model.RoleAssistant, Content: "An umbrella.\n", CreatedAt: time.Date(2025, 8, 20, 10, 1, 0, 0, time.UTC), Tools: []*model.ToolCall{
+				{Tool: "get_weather", Call: "calling get_weather(Barcelona, 3 days)", Result: "weather received"},
+			}},
+		},
+	}
+	trace := "calling get_weather(Barcelona, 3 days) — weather received"
+
+	md := string(conversationMarkdown(conv, true))
+	for _, want := range []string{"# Packing\n", "tags: trip-2025", "## You · 2025-08-20 10:00 UTC\n\nWhat should I pack?\n", "## Assistant · 2025-08-20 10:01 UTC\n\n> " + trace + "\n\nAn umbrella.\n"} {
+		if !strings.Contains(md, want) {
+			t.Errorf("markdown is missing %q:\n%s", want, md)
+		}
+	}
+	if md := string(conversationMarkdown(conv, false)); strings.Contains(md, trace) {
+		t.Errorf("markdown without tool traces has them:\n%s", md)
+	}
+
+	doc := conversationPDF(conv, true)
+	for _, want := range []string{"%PDF-", "(Packing) Tj", "(What should I pack?) Tj", "(An umbrella.) Tj", "(calling get_weather\\(Barcelona, 3 days\\) \x97 weather received) Tj"} {
+		if !bytes.Contains(doc, []byte(want)) {
+			t.Errorf("PDF is missing %q", want)
+		}
+	}
+	if bytes.Contains(conversationPDF(conv, false), []byte("get_weather")) {
+		t.Error("PDF without tool traces has them")
+	}
+}
+
+func TestExportConversation(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("requires a conversation", func(t *testing.T) {
+		_, err := NewServer(nil, &fakeAssistant{}).ExportConversation(ctx, &pb.ExportConversationRequest{})
+		if te, ok := err.(twirp.Error); !ok || te.Code() != twirp.InvalidArgument {
+			t.Errorf("expected twirp.InvalidArgument, got %v", err)
+		}
+	})
+
+	t.Run("renders the transcript as PDF", WithFixture(func(t *testing.T, f *Fixture) {
+		conv := f.CreateConversation()
+		srv := NewServer(f.Repository, &fakeAssistant{})
+
+		resp, err := srv.ExportConversation(ctx, &pb.ExportConversationRequest{ConversationId: conv.ID.Hex(), Format: pb.ExportConversationRequest_PDF})
+		if err != nil {
+			t.Fatalf("ExportConversation error: %v", err)
+		}
+		if resp.GetContentType() != "application/pdf" || !strings.HasSuffix(resp.GetFilename(), ".pdf") || !bytes.HasPrefix(resp.GetData(), []byte("%PDF-")) {
+			t.Errorf("unexpected export %q (%s)", resp.GetFilename(), resp.GetContentType())
+		}
+	}))
+}
+
+func TestWebhookNotifier(t *testing.T) {
+	var got struct {
+		UserID string `json:"user_id"`
+		Job    struct {
+			DownloadURL string `json:"downloadUrl"`
+		} `json:"job"`
+	}
+	hook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&got)
+	}))
+	defer hook.Close()
+
+	job := &model.BulkJob{ID: primitive.NewObjectID(), UserID: "alice", Action: model.BulkExport, State: model.BulkJobDone, DownloadURL: "/exports/x"}
+	if err := NewWebhookNotifier(hook.URL).JobFinished(context.Background(), job); err != nil {
+		t.Fatalf("JobFinished error: %v", err)
+	}
+	if got.UserID != "alice" || got.Job.DownloadURL != "/exports/x" {
+		t.Errorf("webhook received %+v", got)
+	}
+}
+
+func TestRequestExportArchive(t *testing.T) {
+	t.Run("disabled", func(t *testing.T) {
+		_, err := NewServer(nil, &fakeAssistant{}).RequestExportArchive(context.Background(), &pb.RequestExportArchiveRequest{})
+		if te, ok := err.(twirp.Error); !ok || te.Code() != twirp.Unimplemented {
+			t.Errorf("expected twirp.Unimplemented, got %v", err)
+		}
+	})
+
+	t.Run("requires an identified user", func(t *testing.T) {
+		srv := NewServer(nil, &fakeAssistant{})
+		srv.EnableExportArchives(blob.NewMemory(), nil)
+		_, err := srv.RequestExportArchive(context.Background(), &pb.RequestExportArchiveRequest{})
+		if te, ok := err.(twirp.Error); !ok || te.Code() != twirp.Unauthenticated {
+			t.Errorf("expected twirp.Unauthenticated, got %v", err)
+		}
+	})
+
+	t.Run("builds a downloadable archive", WithFixture(func(t *testing.T, f *Fixture) {
+		user := "alice-" + primitive.NewObjectID().Hex()
+		alice := auth.WithUser(context.Background(), user)
+		conv := f.CreateConversation(func(c *model.Conversation) { c.Owner = user })
+		bobs := f.CreateConversation(func(c *model.Conversation) { c.Owner = "bob-" + primitive.NewObjectID().Hex() })
+		srv := NewServer(f.Repository, &fakeAssistant{})
+		srv.EnableExportArchives(blob.NewMemory(), nil)
+
+		resp, err := srv.RequestExportArchive(alice, &pb.RequestExportArchiveRequest{})
+		if err != nil {
+			t.Fatalf("RequestExportArchive error: %v", err)
+		}
+
+		var job *pb.BulkJob
+		for deadline := time.Now().Add(10 * time.Second); time.Now().Before(deadline); time.Sleep(50 * time.Millisecond) {
+			got, err := srv.GetBulkJob(alice, &pb.GetBulkJobRequest{JobId: resp.GetJobId()})
+			if err != nil {
+				t.Fatalf("GetBulkJob error: %v", err)
+			}
+			if job = got.GetJob(); job.GetState() != pb.BulkJob_RUNNING {
+				break
+			}
+		}
+		if job.GetState() != pb.BulkJob_DONE || job.GetDownloadUrl() == "" {
+			t.Fatalf("export job = %v, want done with a download URL", job)
+		}
+
+		req := httptest.NewRequest(http.MethodGet, job.GetDownloadUrl(), nil).WithContext(alice)
+		rec := httptest.NewRecorder()
+		srv.ExportHandler().ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("download status = %d: %s", rec.Code, rec.Body)
+		}
+
+		zr, err := zip.NewReader(bytes.NewReader(rec.Body.Bytes()), int64(rec.Body.Len()))
+		if err != nil {
+			t.Fatalf("archive is not a zip: %v", err)
+		}
+		found := 0
+		for _, file := range zr.File {
+			if strings.Contains(file.Name, bobs.ID.Hex()) {
+				t.Errorf("archive has another user's conversation: %s", file.Name)
+			}
+			if strings.Contains(file.Name, conv.ID.Hex()) {
+				r, _ := file.Open()
+				data, _ := io.ReadAll(r)
+				_ = r.Close()
+				if len(data) == 0 {
+					t.Errorf("%s is empty", file.Name)
+				}
+				found++
+			}
+		}
+		if found != 2 {
+			t.Errorf("archive has %d files for the conversation, want Markdown and JSON", found)
+		}
+
+		// Exports are only served to the user who requested them.
+		rec = httptest.NewRecorder()
+		srv.ExportHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, job.GetDownloadUrl(), nil))
+		if rec.Code != http.StatusNotFound {
+			t.Errorf("download by another user: status = %d, want 404", rec.Code)
+		}
+	}))
+}
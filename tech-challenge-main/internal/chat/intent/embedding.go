@@ -0,0 +1,112 @@
+package intent
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+)
+
+// Embedder turns texts into vectors whose cosine similarity reflects how related they are.
+type Embedder interface {
+	Embed(ctx context.Context, texts []string) ([][]float32, error)
+}
+
+// examples are typical messages of each label; messages get the label of the closest one.
+var examples = []struct {
+	label Label
+	text  string
+}{
+	{Weather, "What's the weather like in Barcelona today?"},
+	{Weather, "Will it rain this weekend?"},
+	{Weather, "Do I need a jacket tomorrow in London?"},
+	{Holidays, "When is the next public holiday?"},
+	{Holidays, "Are there any long weekends coming up?"},
+	{Holidays, "Is next Monday a bank holiday in Germany?"},
+	{TravelPlanning, "Plan a 5-day trip to Japan"},
+	{TravelPlanning, "What should I pack for a week in Iceland?"},
+	{TravelPlanning, "Which neighbourhood should I stay in when visiting Lisbon?"},
+	{Coding, "Why does my Python function return None?"},
+	{Coding, "Write a SQL query that counts orders per customer"},
+	{Coding, "How do I undo my last git commit?"},
+	{General, "How do I make a good espresso?"},
+	{General, "What is the capital of Australia?"},
+	{General, "Explain how compound interest works"},
+	{Other, "Hi!"},
+	{Other, "Thanks, that's all"},
+	{Other, "ok"},
+}
+
+// Embeddings classifies messages by their similarity to examples of each label. It
+// understands any language the embedding model does, for one embedding per message.
+type Embeddings struct {
+	embedder Embedder
+
+	mu      sync.Mutex
+	vectors [][]float32
+}
+
+func NewEmbeddings(e Embedder) *Embeddings {
+	return &Embeddings{embedder: e}
+}
+
+// exampleVectors embeds the examples on first use; failures are retried on the next call.
+func (c *Embeddings) exampleVectors(ctx context.Context) ([][]float32, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.vectors != nil {
+		return c.vectors, nil
+	}
+
+	texts := make([]string, len(examples))
+	for i, ex := range examples {
+		texts[i] = ex.text
+	}
+	vectors, err := c.embedder.Embed(ctx, texts)
+	if err != nil {
+		return nil, err
+	}
+	if len(vectors) != len(texts) {
+		return nil, fmt.Errorf("expected %d embeddings, got %d", len(texts), len(vectors))
+	}
+	c.vectors = vectors
+	return vectors, nil
+}
+
+func (c *Embeddings) Classify(ctx context.Context, content string) (Label, error) {
+	refs, err := c.exampleVectors(ctx)
+	if err != nil {
+		return Other, err
+	}
+	vectors, err := c.embedder.Embed(ctx, []string{content})
+	if err != nil {
+		return Other, err
+	}
+	if len(vectors) != 1 {
+		return Other, fmt.Errorf("expected 1 embedding, got %d", len(vectors))
+	}
+
+	best, label := math.Inf(-1), Other
+	for i, ref := range refs {
+		if score := cosine(vectors[0], ref); score > best {
+			best, label = score, examples[i].label
+		}
+	}
+	return label, nil
+}
+
+func cosine(a, b []float32) float64 {
+	if len(a) != len(b) {
+		return 0
+	}
+	var dot, na, nb float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		na += float64(a[i]) * float64(a[i])
+		nb += float64(b[i]) * float64(b[i])
+	}
+	if na == 0 || nb == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(na) * math.Sqrt(nb))
+}
@@ -0,0 +1,97 @@
+// Package intent labels user messages with what they ask for, for analytics: which
+// requests are common, and which would need new tools. Classifiers are pluggable, from
+// free keyword matching to a model call.
+package intent
+
+import (
+	"context"
+	"strings"
+	"unicode"
+)
+
+// Label is the intent of a user message.
+type Label string
+
+const (
+	Weather        Label = "weather"
+	Holidays       Label = "holidays"
+	TravelPlanning Label = "travel-planning"
+	Coding         Label = "coding"
+	// General are answerable questions without a dedicated tool, e.g. facts or advice.
+	General Label = "general"
+	// Other are greetings, thanks and messages that ask for nothing.
+	Other Label = "other"
+)
+
+// Labels are all labels, in the order analytics list them.
+var Labels = []Label{Weather, Holidays, TravelPlanning, Coding, General, Other}
+
+// Parse returns the label named s, ignoring case and surrounding punctuation, or Other.
+func Parse(s string) Label {
+	s = strings.ToLower(strings.TrimFunc(s, func(r rune) bool { return !unicode.IsLetter(r) }))
+	s = strings.ReplaceAll(s, " ", "-")
+	for _, l := range Labels {
+		if s == string(l) {
+			return l
+		}
+	}
+	return Other
+}
+
+// Classifier labels messages.
+type Classifier interface {
+	Classify(ctx context.Context, content string) (Label, error)
+}
+
+// ClassifierFunc adapts a function to Classifier.
+type ClassifierFunc func(ctx context.Context, content string) (Label, error)
+
+func (f ClassifierFunc) Classify(ctx context.Context, content string) (Label, error) {
+	return f(ctx, content)
+}
+
+// Keywords classifies messages by the words they contain. It is free and fast but only
+// knows English; the zero value is ready to use.
+type Keywords struct{}
+
+// keywords are checked in order, so more specific intents come first: "weather for my
+// trip" is about the weather.
+var keywords = []struct {
+	label Label
+	words []string
+}{
+	{Weather, []string{"weather", "forecast", "rain", "raining", "sunny", "snow", "temperature", "wind", "humid", "umbrella", "degrees"}},
+	{Holidays, []string{"holiday", "holidays", "bank holiday", "long weekend", "public holiday", "day off", "days off"}},
+	{TravelPlanning, []string{"trip", "travel", "itinerary", "flight", "flights", "hotel", "visit", "vacation", "packing", "tour", "sightseeing", "booking", "destination"}},
+	// Only words and phrases about software: "postal code", "bug spray" or "Java" are about
+	// travel as often.
+	{Coding, []string{"coding", "programming", "source code", "my code", "this code", "debug", "compile", "stack trace", "syntax error", "unit test", "regex", "sql", "python", "javascript", "typescript", "golang", "git commit", "pull request", "rest api"}},
+}
+
+var greetings = []string{"hi", "hello", "hey", "thanks", "thank you", "ok", "okay", "bye", "good morning", "good night"}
+
+func (Keywords) Classify(_ context.Context, content string) (Label, error) {
+	words := strings.FieldsFunc(strings.ToLower(content), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	text := " " + strings.Join(words, " ") + " "
+
+	for _, k := range keywords {
+		for _, w := range k.words {
+			if strings.Contains(text, " "+w+" ") {
+				return k.label, nil
+			}
+		}
+	}
+
+	trimmed := strings.TrimSpace(text)
+	for _, g := range greetings {
+		if trimmed == g {
+			return Other, nil
+		}
+	}
+	if trimmed == "" {
+		return Other, nil
+	}
+	return General, nil
+}
@@ -0,0 +1,76 @@
+package intent
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	for in, want := range map[string]Label{
+		"weather":           Weather,
+		" Travel planning.": TravelPlanning,
+		"travel-planning":   TravelPlanning,
+		"HOLIDAYS":          Holidays,
+		"Coding":            Coding,
+		"shopping":          Other,
+	} {
+		if got := Parse(in); got != want {
+			t.Errorf("Parse(%q) = %s, want %s", in, got, want)
+		}
+	}
+}
+
+func TestKeywords(t *testing.T) {
+	tests := map[string]Label{
+		"Will it rain in Oslo tomorrow?":       Weather,
+		"What's the weather for my trip?":      Weather,
+		"Is Monday a public holiday in Spain?": Holidays,
+		"Plan a 3-day itinerary for Rome":      TravelPlanning,
+		"Fix this bug in my Python script":     Coding,
+		"How do I undo my last git commit?":    Coding,
+		"What's the postal code of Lisbon?":    General,
+		"Which bug spray works against ticks?": General,
+		"What is the capital of Australia?":    General,
+		"My laptop is running hot":             General,
+		"Thanks!":                              Other,
+		"":                                     Other,
+	}
+	for msg, want := range tests {
+		if got, err := (Keywords{}).Classify(context.Background(), msg); err != nil || got != want {
+			t.Errorf("Classify(%q) = %s, %v, want %s", msg, got, err, want)
+		}
+	}
+}
+
+// wordEmbedder embeds texts as counts of a few words, so similar texts share them.
+type wordEmbedder struct{ calls int }
+
+func (e *wordEmbedder) Embed(_ context.Context, texts []string) ([][]float32, error) {
+	e.calls++
+	out := make([][]float32, len(texts))
+	for i, text := range texts {
+		text = strings.ToLower(text)
+		for _, w := range []string{"rain", "weather", "holiday", "trip", "pack", "capital", "thanks"} {
+			out[i] = append(out[i], float32(strings.Count(text, w)))
+		}
+	}
+	return out, nil
+}
+
+func TestEmbeddings(t *testing.T) {
+	e := &wordEmbedder{}
+	c := NewEmbeddings(e)
+	for msg, want := range map[string]Label{
+		"Any rain expected in Porto?":       Weather,
+		"Help me plan a trip to Peru":       TravelPlanning,
+		"Which holiday is next in Ireland?": Holidays,
+	} {
+		if got, err := c.Classify(context.Background(), msg); err != nil || got != want {
+			t.Errorf("Classify(%q) = %s, %v, want %s", msg, got, err, want)
+		}
+	}
+	if e.calls != 4 {
+		t.Errorf("examples should be embedded once: %d embedding calls, want 4", e.calls)
+	}
+}
@@ -0,0 +1,126 @@
+package chat
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/acai-travel/tech-challenge/internal/chat/intent"
+	"github.com/acai-travel/tech-challenge/internal/chat/model"
+	"github.com/twitchtv/twirp"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// intentLabeler labels user messages with their intent in the background, off the reply
+// path. Like the semantic index, its queue lives in memory: messages whose job is dropped
+// or lost on restart stay unlabeled.
+type intentLabeler struct {
+	repo       *model.Repository
+	classifier intent.Classifier
+	queue      chan labelJob
+	once       sync.Once
+}
+
+type labelJob struct {
+	conversationID primitive.ObjectID
+	message        *model.Message
+	// icon also gives the conversation the icon of the message's category.
+	icon bool
+}
+
+func newIntentLabeler(repo *model.Repository, c intent.Classifier, size int) *intentLabeler {
+	return &intentLabeler{repo: repo, classifier: c, queue: make(chan labelJob, size)}
+}
+
+// Enqueue schedules a message for labeling. It never blocks and reports false if the
+// queue is full.
+func (l *intentLabeler) Enqueue(job labelJob) bool {
+	l.once.Do(func() { go l.run() })
+
+	select {
+	case l.queue <- job:
+		return true
+	default:
+		return false
+	}
+}
+
+func (l *intentLabeler) run() {
+	for job := range l.queue {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		_, err := l.label(ctx, job)
+		cancel()
+
+		if err != nil {
+			slog.Warn("Failed to label message intent", "conversation_id", job.conversationID.Hex(), "error", err)
+		}
+	}
+}
+
+// label classifies and stores the intent of a message, and returns it even if storing it
+// failed.
+func (l *intentLabeler) label(ctx context.Context, job labelJob) (intent.Label, error) {
+	label, err := l.classifier.Classify(ctx, job.message.Content)
+	if err != nil {
+		return "", err
+	}
+	return label, writeWithRetry(ctx, 3, 100*time.Millisecond, func(ctx context.Context) error {
+		if err := l.repo.SetMessageIntent(ctx, job.conversationID, job.message.ID, string(label)); err != nil {
+			return err
+		}
+		if icon := categoryIcons[label]; job.icon && icon != "" {
+			return l.repo.UpdateIcon(ctx, job.conversationID, icon)
+		}
+		return nil
+	})
+}
+
+// EnableIntentAnalytics labels every new user message with its intent, as classified by c,
+// for AdminService.GetIntentStats.
+func (s *Server) EnableIntentAnalytics(c intent.Classifier) {
+	s.intents = newIntentLabeler(s.repo, c, 1_000)
+}
+
+// labelIntents schedules the user messages among msgs for intent labeling, if enabled. The
+// first user message of a conversation also gives it its icon, with EnableTitleIcons.
+func (s *Server) labelIntents(ctx context.Context, conv *model.Conversation, msgs ...*model.Message) {
+	if s.intents == nil {
+		return
+	}
+	var first primitive.ObjectID
+	if i := slices.IndexFunc(conv.Messages, func(m *model.Message) bool { return m.Role == model.RoleUser }); i >= 0 {
+		first = conv.Messages[i].ID
+	}
+	for _, m := range msgs {
+		if m.Role == model.RoleUser && !s.intents.Enqueue(labelJob{conversationID: conv.ID, message: m, icon: s.titleIcons && m.ID == first}) {
+			slog.WarnContext(ctx, "Intent labeling queue is full, message stays unlabeled", "conversation_id", conv.ID.Hex())
+		}
+	}
+}
+
+// IntentStats counts the user messages sent in [since, until) by intent, for the admin
+// server; "" counts the unlabeled ones. Zero times leave the range open, and an empty
+// assistant counts the messages of all of them.
+func (s *Server) IntentStats(ctx context.Context, since, until time.Time, assistant string) (map[string]int64, error) {
+	if !since.IsZero() && !until.IsZero() && !since.Before(until) {
+		return nil, twirp.InvalidArgumentError("until", "must be after since")
+	}
+
+	var assistants []string
+	switch assistant {
+	case "":
+	case DefaultAssistant:
+		// Conversations of the default assistant usually don't name it.
+		assistants = []string{"", DefaultAssistant}
+	default:
+		assistants = []string{assistant}
+	}
+
+	counts, err := s.repo.CountIntents(ctx, since, until, assistants...)
+	if err != nil {
+		return nil, twirp.InternalErrorWith(err)
+	}
+	return counts, nil
+}
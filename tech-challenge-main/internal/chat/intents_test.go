@@ -0,0 +1,57 @@
+package chat
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/acai-travel/tech-challenge/internal/chat/intent"
+	"github.com/acai-travel/tech-challenge/internal/chat/model"
+	. "github.com/acai-travel/tech-challenge/internal/chat/testing"
+	"github.com/twitchtv/twirp"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestIntentStats(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("rejects an empty range", func(t *testing.T) {
+		now := time.Now()
+		_, err := NewServer(nil, &fakeAssistant{}).IntentStats(ctx, now, now, "")
+		if te, ok := err.(twirp.Error); !ok || te.Code() != twirp.InvalidArgument {
+			t.Errorf("expected twirp.InvalidArgument, got %v", err)
+		}
+	})
+
+	t.Run("counts labeled messages", WithFixture(func(t *testing.T, f *Fixture) {
+		since := time.Now().Add(-time.Minute)
+		conv := f.CreateConversation(func(c *model.Conversation) {
+			for _, content := range []string{"Will it rain in Oslo?", "Plan a trip to Rome", "Thanks!"} {
+				c.Messages = append(c.Messages, &model.Message{ID: primitive.NewObjectID(), Role: model.RoleUser, Content: content, CreatedAt: time.Now()})
+			}
+		})
+
+		srv := NewServer(f.Repository, &fakeAssistant{})
+		srv.EnableIntentAnalytics(intent.Keywords{})
+		for _, m := range conv.Messages[1:3] {
+			if _, err := srv.intents.label(ctx, labelJob{conversationID: conv.ID, message: m}); err != nil {
+				t.Fatalf("label error: %v", err)
+			}
+		}
+
+		got, err := srv.IntentStats(ctx, since, time.Time{}, "")
+		if err != nil {
+			t.Fatalf("IntentStats error: %v", err)
+		}
+		if got["weather"] < 1 || got["travel-planning"] < 1 || got[""] < 1 {
+			t.Errorf("IntentStats = %v", got)
+		}
+
+		if got, err := srv.IntentStats(ctx, since, time.Time{}, "assistant-"+conv.ID.Hex()); err != nil || len(got) != 0 {
+			t.Errorf("IntentStats = %v, %v; want no messages for an assistant without conversations", got, err)
+		}
+		if got, err := srv.IntentStats(ctx, since, time.Time{}, DefaultAssistant); err != nil || got["weather"] < 1 {
+			t.Errorf("IntentStats(%s) = %v, %v; want the messages of conversations not naming an assistant", DefaultAssistant, got, err)
+		}
+	}))
+}
@@ -0,0 +1,57 @@
+package chat
+
+import (
+	"context"
+
+	"github.com/acai-travel/tech-challenge/internal/audit"
+	"github.com/acai-travel/tech-challenge/internal/chat/assistant"
+	"github.com/acai-travel/tech-challenge/internal/pb"
+	"github.com/twitchtv/twirp"
+)
+
+// LanguageDetector tells which language a message is written in, as a BCP 47 tag, or ""
+// if it can't tell.
+type LanguageDetector interface {
+	DetectLanguage(ctx context.Context, content string) (string, error)
+}
+
+// EnableLanguageDetection locks new conversations to the language of their first message, as
+// detected by d, unless the request sets one.
+func (s *Server) EnableLanguageDetection(d LanguageDetector) {
+	s.languages = d
+}
+
+// requestLanguage validates the language requested for a conversation, returning its
+// canonical tag; empty stays empty.
+func requestLanguage(field, language string) (string, error) {
+	if language == "" {
+		return "", nil
+	}
+	tag, ok := assistant.CanonicalLanguage(language)
+	if !ok {
+		return "", twirp.InvalidArgumentError(field, "must be a BCP 47 language tag, e.g. es or pt-BR")
+	}
+	return tag, nil
+}
+
+func (s *Server) SetConversationLanguage(ctx context.Context, req *pb.SetConversationLanguageRequest) (*pb.SetConversationLanguageResponse, error) {
+	if req.GetConversationId() == "" {
+		return nil, twirp.RequiredArgumentError("conversation_id")
+	}
+	language, err := requestLanguage("language", req.GetLanguage())
+	if err != nil {
+		return nil, err
+	}
+
+	audit.Conversation(ctx, req.GetConversationId())
+	conversation, err := s.describeConversation(ctx, req.GetConversationId())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.UpdateLanguage(ctx, conversation.ID, language); err != nil {
+		return nil, err
+	}
+
+	return &pb.SetConversationLanguageResponse{Language: language}, nil
+}
@@ -0,0 +1,98 @@
+package chat
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/acai-travel/tech-challenge/internal/chat/model"
+	. "github.com/acai-travel/tech-challenge/internal/chat/testing"
+	"github.com/acai-travel/tech-challenge/internal/pb"
+	"github.com/twitchtv/twirp"
+)
+
+type languageFunc func(ctx context.Context, content string) (string, error)
+
+func (f languageFunc) DetectLanguage(ctx context.Context, content string) (string, error) {
+	return f(ctx, content)
+}
+
+func TestServer_ConversationLanguage(t *testing.T) {
+	ctx := context.Background()
+	repo := model.New(ConnectMongo())
+
+	var replyLanguages []string
+	fa := &fakeAssistant{
+		titleFn: func(context.Context, *model.Conversation) (string, error) { return "Tiempo", nil },
+		replyFn: func(_ context.Context, c *model.Conversation) (string, error) {
+			replyLanguages = append(replyLanguages, c.Language)
+			return "¡Hace sol!", nil
+		},
+	}
+	srv := NewServer(repo, fa)
+	srv.EnableLanguageDetection(languageFunc(func(_ context.Context, content string) (string, error) {
+		if content == "ok" {
+			return "", errors.New("can't tell")
+		}
+		return "ES", nil
+	}))
+
+	t.Run("detects the language of the first message", func(t *testing.T) {
+		out, err := srv.StartConversation(ctx, &pb.StartConversationRequest{Message: "¿Qué tiempo hace en Madrid?"})
+		if err != nil {
+			t.Fatalf("StartConversation error: %v", err)
+		}
+
+		conv, err := repo.DescribeConversation(ctx, out.GetConversationId())
+		if err != nil {
+			t.Fatalf("DescribeConversation error: %v", err)
+		}
+		if conv.Language != "es" {
+			t.Fatalf("expected language es, got %q", conv.Language)
+		}
+
+		// Later replies are locked to it, and the lock can be changed.
+		if _, err := srv.ContinueConversation(ctx, &pb.ContinueConversationRequest{ConversationId: out.GetConversationId(), Message: "ok"}); err != nil {
+			t.Fatalf("ContinueConversation error: %v", err)
+		}
+		set, err := srv.SetConversationLanguage(ctx, &pb.SetConversationLanguageRequest{ConversationId: out.GetConversationId(), Language: "pt_br"})
+		if err != nil {
+			t.Fatalf("SetConversationLanguage error: %v", err)
+		}
+		if set.GetLanguage() != "pt-BR" {
+			t.Errorf("expected canonical tag pt-BR, got %q", set.GetLanguage())
+		}
+		if _, err := srv.ContinueConversation(ctx, &pb.ContinueConversationRequest{ConversationId: out.GetConversationId(), Message: "ok"}); err != nil {
+			t.Fatalf("ContinueConversation error: %v", err)
+		}
+
+		if got := replyLanguages[len(replyLanguages)-2:]; got[0] != "es" || got[1] != "pt-BR" {
+			t.Errorf("reply languages: got %q, want [es pt-BR]", got)
+		}
+	})
+
+	t.Run("a requested language takes precedence", func(t *testing.T) {
+		out, err := srv.StartConversation(ctx, &pb.StartConversationRequest{Message: "Hola", Language: "en"})
+		if err != nil {
+			t.Fatalf("StartConversation error: %v", err)
+		}
+		if got := replyLanguages[len(replyLanguages)-1]; got != "en" {
+			t.Errorf("expected the first reply to be locked to en, got %q", got)
+		}
+
+		conv, err := repo.DescribeConversation(ctx, out.GetConversationId())
+		if err != nil {
+			t.Fatalf("DescribeConversation error: %v", err)
+		}
+		if conv.Language != "en" {
+			t.Fatalf("expected language en, got %q", conv.Language)
+		}
+	})
+
+	t.Run("rejects invalid languages", func(t *testing.T) {
+		_, err := srv.StartConversation(ctx, &pb.StartConversationRequest{Message: "Hola", Language: "klingon!"})
+		if te, ok := err.(twirp.Error); !ok || te.Code() != twirp.InvalidArgument {
+			t.Fatalf("expected InvalidArgument, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,52 @@
+package chat
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/twitchtv/twirp"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// leaseMargin keeps a conversation leased past the request budget, for the writes that
+// follow the reply. Leases expire, so a server stopping mid-reply doesn't lock it forever.
+const leaseMargin = 15 * time.Second
+
+// errConversationBusy is returned to a request replying in a conversation another request
+// is already replying in: both replies would be generated from a history missing the
+// other's messages.
+var errConversationBusy = twirp.NewError(twirp.Aborted, "a reply is already being generated in this conversation; retry once it is done")
+
+// lockConversation leases a conversation to the request for as long as a reply may take.
+// The returned function releases it.
+func (s *Server) lockConversation(ctx context.Context, id string) (func(), error) {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, twirp.NotFoundError("invalid conversation ID")
+	}
+
+	holder := primitive.NewObjectID().Hex()
+	ok, err := s.repo.AcquireLease(ctx, oid, holder, s.budget.of(ctx).request+leaseMargin)
+	var te twirp.Error
+	if errors.As(err, &te) {
+		return nil, err // not found
+	}
+	if err != nil {
+		return nil, twirp.InternalErrorWith(err)
+	}
+	if !ok {
+		return nil, errConversationBusy
+	}
+
+	return func() {
+		// Detached: unlocking matters most when the request was cancelled.
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+		defer cancel()
+
+		if err := s.repo.ReleaseLease(ctx, oid, holder); err != nil {
+			slog.ErrorContext(ctx, "Failed to release conversation lease; it will expire", "conversation_id", id, "error", err)
+		}
+	}, nil
+}
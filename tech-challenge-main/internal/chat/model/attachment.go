@@ -0,0 +1,31 @@
+package model
+
+import (
+	"time"
+
+	"github.com/acai-travel/tech-challenge/internal/pb"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Attachment is an uploaded file. Its contents live in a blob store under the hex ID; this
+// is the metadata, also copied into the messages that reference it.
+type Attachment struct {
+	ID          primitive.ObjectID `bson:"_id"`
+	UserID      string             `bson:"user_id"`
+	Filename    string             `bson:"filename"`
+	ContentType string             `bson:"content_type"`
+	Size        int64              `bson:"size"`
+	SHA256      string             `bson:"sha256"`
+	CreatedAt   time.Time          `bson:"created_at"`
+}
+
+func (a *Attachment) Proto() *pb.Attachment {
+	return &pb.Attachment{
+		Id:          a.ID.Hex(),
+		Filename:    a.Filename,
+		ContentType: a.ContentType,
+		Size:        a.Size,
+		CreatedAt:   timestamppb.New(a.CreatedAt),
+	}
+}
@@ -0,0 +1,97 @@
+package model
+
+import (
+	"time"
+
+	"github.com/acai-travel/tech-challenge/internal/pb"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ConversationFilter selects conversations for bulk operations. Set fields must all match;
+// the zero filter matches every conversation.
+type ConversationFilter struct {
+	// OlderThan matches conversations last updated before it.
+	OlderThan time.Time
+	Tag       string
+	// IDs, if set, only matches these conversations.
+	IDs []primitive.ObjectID
+	// Owner, if set, only matches the conversations this user owns, not those visible to all.
+	Owner string
+}
+
+func (f ConversationFilter) query() map[string]any {
+	q := map[string]any{}
+	if f.IDs != nil {
+		q["_id"] = map[string]any{"$in": f.IDs}
+	}
+	if f.Owner != "" {
+		q["owner"] = f.Owner
+	}
+	if !f.OlderThan.IsZero() {
+		q["updated_at"] = map[string]any{"$lt": f.OlderThan}
+	}
+	if f.Tag != "" {
+		q["tags"] = f.Tag
+	}
+	return q
+}
+
+type BulkAction string
+
+const (
+	BulkDelete  BulkAction = "delete"
+	BulkArchive BulkAction = "archive"
+	// BulkExport packages conversations into an archive, changing none.
+	BulkExport BulkAction = "export"
+)
+
+type BulkJobState string
+
+const (
+	BulkJobRunning BulkJobState = "running"
+	BulkJobDone    BulkJobState = "done"
+	BulkJobFailed  BulkJobState = "failed"
+)
+
+// BulkJob tracks a bulk operation too large to run within a request, or an export.
+type BulkJob struct {
+	ID     primitive.ObjectID `bson:"_id"`
+	UserID string             `bson:"user_id"`
+	Action BulkAction         `bson:"action"`
+	State  BulkJobState       `bson:"state"`
+	Total  int                `bson:"total"`
+	Count  int                `bson:"count"`
+	Error  string             `bson:"error,omitempty"`
+	// DownloadURL is where a finished export can be downloaded.
+	DownloadURL string    `bson:"download_url,omitempty"`
+	CreatedAt   time.Time `bson:"created_at"`
+	UpdatedAt   time.Time `bson:"updated_at"`
+	// LeaseExpiresAt is when a running job is presumed lost, e.g. to a restart, unless the
+	// instance running it renews the lease.
+	LeaseExpiresAt time.Time `bson:"lease_expires_at"`
+}
+
+func (j *BulkJob) Proto() *pb.BulkJob {
+	state := pb.BulkJob_UNKNOWN
+	switch j.State {
+	case BulkJobRunning:
+		state = pb.BulkJob_RUNNING
+	case BulkJobDone:
+		state = pb.BulkJob_DONE
+	case BulkJobFailed:
+		state = pb.BulkJob_FAILED
+	}
+
+	return &pb.BulkJob{
+		Id:          j.ID.Hex(),
+		Action:      string(j.Action),
+		State:       state,
+		Total:       int32(j.Total),
+		Count:       int32(j.Count),
+		Error:       j.Error,
+		DownloadUrl: j.DownloadURL,
+		CreatedAt:   timestamppb.New(j.CreatedAt),
+		UpdatedAt:   timestamppb.New(j.UpdatedAt),
+	}
+}
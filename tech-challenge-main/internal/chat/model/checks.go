@@ -0,0 +1,73 @@
+package model
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/acai-travel/tech-challenge/internal/doctor"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Checks verify that MongoDB is reachable and supports transactions and, unless
+// vectorIndex is empty, that the Atlas Vector Search index of message embeddings named
+// vectorIndex can be queried, for doctor.Run.
+func (r *Repository) Checks(vectorIndex string) []doctor.Check {
+	checks := []doctor.Check{
+		{
+			Name: "mongodb",
+			Run: func(ctx context.Context) error {
+				return r.conn.Client().Ping(ctx, nil)
+			},
+			Fix: "set MONGODB_URI to a reachable MongoDB, including credentials, and check the network allows it",
+		},
+		{
+			Name: "mongodb transactions",
+			Run: func(ctx context.Context) error {
+				var hello struct {
+					SetName string `bson:"setName"`
+					Msg     string `bson:"msg"`
+				}
+				if err := r.conn.RunCommand(ctx, bson.D{{Key: "hello", Value: 1}}).Decode(&hello); err != nil {
+					return err
+				}
+				if hello.SetName == "" && hello.Msg != "isdbgrid" {
+					return errors.New("standalone server: writes spanning several documents aren't atomic")
+				}
+				return nil
+			},
+			Fix:      "run MongoDB as a replica set; a single member is enough",
+			Optional: true,
+		},
+	}
+
+	if vectorIndex != "" {
+		checks = append(checks, doctor.Check{
+			Name: "mongodb vector search index",
+			Run: func(ctx context.Context) error {
+				coll := r.collection(embeddingCollection)
+				cursor, err := coll.SearchIndexes().List(ctx, options.SearchIndexes().SetName(vectorIndex))
+				if err != nil {
+					return err
+				}
+				var indexes []struct {
+					Status    string `bson:"status"`
+					Queryable bool   `bson:"queryable"`
+				}
+				if err := cursor.All(ctx, &indexes); err != nil {
+					return err
+				}
+				switch {
+				case len(indexes) == 0:
+					return fmt.Errorf("no search index %q on %s", vectorIndex, coll.Name())
+				case !indexes[0].Queryable:
+					return fmt.Errorf("search index %q on %s is %s, not queryable yet", vectorIndex, coll.Name(), indexes[0].Status)
+				}
+				return nil
+			},
+			Fix: "create the index described under Semantic search in the README, set VECTOR_SEARCH_INDEX to its name, or use another VECTOR_STORE",
+		})
+	}
+	return checks
+}
@@ -9,11 +9,119 @@ import (
 )
 
 type Conversation struct {
-	ID        primitive.ObjectID `bson:"_id"`
-	Title     string             `bson:"subject"`
-	CreatedAt time.Time          `bson:"created_at"`
+	ID    primitive.ObjectID `bson:"_id"`
+	Title string             `bson:"subject"`
+	// Icon is the emoji of the conversation's category, shown before the title.
+	Icon      string              `bson:"icon,omitempty"`
+	CreatedAt time.Time           `bson:"created_at"`
+	UpdatedAt time.Time           `bson:"updated_at"`
+	Messages  []*Message          `bson:"messages"`
+	Settings  *GenerationSettings `bson:"settings,omitempty"`
+	Assistant string              `bson:"assistant,omitempty"`
+	// Reads has one marker per user who has read the conversation.
+	Reads []*ReadMarker `bson:"reads,omitempty"`
+	// Summary condenses the earlier messages of a long conversation.
+	Summary *Summary `bson:"summary,omitempty"`
+	// Language is the BCP 47 tag of the language replies are written in, so they don't
+	// switch languages on a short or ambiguous message.
+	Language string `bson:"language,omitempty"`
+	// Tags are labels set when the conversation was started, e.g. "trip-2025".
+	Tags []string `bson:"tags,omitempty"`
+	// ArchivedAt is set once the conversation is archived, hiding it from lists.
+	ArchivedAt *time.Time `bson:"archived_at,omitempty"`
+	// Usage adds up what generating the replies consumed, against the budget in Settings.
+	Usage Usage `bson:"usage"`
+	// Email is set for conversations held in an email thread.
+	Email *EmailThread `bson:"email,omitempty"`
+	// WhatsApp is set for conversations held in a WhatsApp chat.
+	WhatsApp *WhatsAppChat `bson:"whatsapp,omitempty"`
+	// Widget is set for conversations held in the chat widget of a website.
+	Widget *WidgetChat `bson:"widget,omitempty"`
+	// Owner is the only user the API shows the conversation to: the identified user who
+	// started it or got it, e.g. as a digest, or its sender over email, WhatsApp and the
+	// widget. Conversations without one, started anonymously or before owners were recorded,
+	// are visible to all.
+	Owner string `bson:"owner,omitempty"`
+}
+
+// VisibleTo reports whether the API may show the conversation to a user.
+func (c *Conversation) VisibleTo(userID string) bool {
+	return c.Owner == "" || c.Owner == userID
+}
+
+// EmailThread links a conversation to the email thread it is held in.
+type EmailThread struct {
+	// Address is the user's email address, the only one allowed to continue the thread.
+	Address string `bson:"address"`
+	Subject string `bson:"subject"`
+	// MessageIDs are the Message-IDs of the thread's emails, received and sent, so replies
+	// are matched to the conversation by their In-Reply-To and References headers.
+	MessageIDs []string `bson:"message_ids"`
+}
+
+// WhatsAppChat links a conversation to the WhatsApp chat it is held in.
+type WhatsAppChat struct {
+	// Phone is the user's number in international format, without the "+".
+	Phone string `bson:"phone"`
+	// MessageIDs are the IDs of the latest messages received, so redelivered webhooks
+	// aren't answered twice.
+	MessageIDs []string `bson:"message_ids"`
+}
+
+// WidgetChat links a conversation to the anonymous visitor of a website who holds it.
+type WidgetChat struct {
+	// VisitorID is the user ID of the visitor's session, the only one allowed to read and
+	// continue the conversation.
+	VisitorID string `bson:"visitor_id"`
+	// Origin is the website the widget is embedded in, e.g. "https://example.com".
+	Origin string `bson:"origin"`
+}
+
+// ReadMarker is the latest message a user has seen in a conversation.
+type ReadMarker struct {
+	UserID    string             `bson:"user_id"`
+	MessageID primitive.ObjectID `bson:"message_id"`
 	UpdatedAt time.Time          `bson:"updated_at"`
-	Messages  []*Message         `bson:"messages"`
+}
+
+// Summary is a rolling summary of a conversation's messages up to and including UpToMessageID.
+type Summary struct {
+	Content       string             `bson:"content"`
+	UpToMessageID primitive.ObjectID `bson:"up_to_message_id"`
+	UpdatedAt     time.Time          `bson:"updated_at"`
+}
+
+// Unsummarized returns the messages not covered by the summary, i.e. all messages if there
+// is none.
+func (c *Conversation) Unsummarized() []*Message {
+	if c.Summary == nil {
+		return c.Messages
+	}
+	for i, m := range c.Messages {
+		if m.ID == c.Summary.UpToMessageID {
+			return c.Messages[i+1:]
+		}
+	}
+	return c.Messages
+}
+
+// PinnedSummarized returns the pinned messages covered by the summary, which replies send
+// verbatim alongside it.
+func (c *Conversation) PinnedSummarized() []*Message {
+	if c.Summary == nil {
+		return nil
+	}
+	var pinned []*Message
+	for _, m := range c.Messages {
+		if m.PinnedAt != nil {
+			pinned = append(pinned, m)
+		}
+		if m.ID == c.Summary.UpToMessageID {
+			return pinned
+		}
+	}
+	// The summary covers messages no longer in the conversation: all are unsummarized.
+	return nil
 }
 
 func (c *Conversation) Proto() *pb.Conversation {
@@ -21,6 +129,15 @@ func (c *Conversation) Proto() *pb.Conversation {
 		Id:        c.ID.Hex(),
 		Title:     c.Title,
 		Timestamp: timestamppb.New(c.UpdatedAt),
+		Settings:  c.Settings.Proto(),
+		Assistant: c.Assistant,
+		Language:  c.Language,
+		Tags:      c.Tags,
+		Usage:     c.usageProto(),
+		Icon:      c.Icon,
+	}
+	if c.ArchivedAt != nil {
+		proto.ArchivedAt = timestamppb.New(*c.ArchivedAt)
 	}
 
 	for _, m := range c.Messages {
@@ -0,0 +1,74 @@
+package model
+
+import (
+	"time"
+
+	"github.com/acai-travel/tech-challenge/internal/pb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type DigestFrequency string
+
+const (
+	DigestDaily  DigestFrequency = "daily"
+	DigestWeekly DigestFrequency = "weekly"
+)
+
+// Period returns the time between two digests.
+func (f DigestFrequency) Period() time.Duration {
+	if f == DigestWeekly {
+		return 7 * 24 * time.Hour
+	}
+	return 24 * time.Hour
+}
+
+func (f DigestFrequency) Proto() pb.DigestFrequency {
+	switch f {
+	case DigestDaily:
+		return pb.DigestFrequency_DIGEST_FREQUENCY_DAILY
+	case DigestWeekly:
+		return pb.DigestFrequency_DIGEST_FREQUENCY_WEEKLY
+	default:
+		return pb.DigestFrequency_DIGEST_FREQUENCY_OFF
+	}
+}
+
+type DigestDelivery string
+
+const (
+	DigestAsMessage DigestDelivery = "message"
+	DigestByWebhook DigestDelivery = "webhook"
+)
+
+func (d DigestDelivery) Proto() pb.DigestDelivery {
+	if d == DigestByWebhook {
+		return pb.DigestDelivery_DIGEST_DELIVERY_WEBHOOK
+	}
+	return pb.DigestDelivery_DIGEST_DELIVERY_MESSAGE
+}
+
+// DigestSubscription is a user's choice to get periodic digests of their conversations.
+type DigestSubscription struct {
+	Frequency DigestFrequency `bson:"frequency"`
+	Delivery  DigestDelivery  `bson:"delivery"`
+	// Country and Region select the holiday calendar; empty uses the server's.
+	Country string `bson:"country,omitempty"`
+	Region  string `bson:"region,omitempty"`
+	// NextAt is when the next digest is due.
+	NextAt     time.Time  `bson:"next_at"`
+	LastSentAt *time.Time `bson:"last_sent_at,omitempty"`
+}
+
+func (s *DigestSubscription) Proto() *pb.DigestSubscription {
+	proto := &pb.DigestSubscription{
+		Frequency: s.Frequency.Proto(),
+		Delivery:  s.Delivery.Proto(),
+		Country:   s.Country,
+		Region:    s.Region,
+		NextAt:    timestamppb.New(s.NextAt),
+	}
+	if s.LastSentAt != nil {
+		proto.LastSentAt = timestamppb.New(*s.LastSentAt)
+	}
+	return proto
+}
@@ -0,0 +1,38 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// MessageEmbedding is the vector representation of a message, used to search past
+// conversations by meaning. It is keyed by the message ID, so re-indexing a message
+// replaces its embedding.
+type MessageEmbedding struct {
+	MessageID      primitive.ObjectID `bson:"_id"`
+	ConversationID primitive.ObjectID `bson:"conversation_id"`
+	UserID         string             `bson:"user_id"`
+	Role           Role               `bson:"role"`
+	Content        string             `bson:"content"`
+	Model          string             `bson:"model"`
+	Vector         []float32          `bson:"vector"`
+	CreatedAt      time.Time          `bson:"created_at"`
+}
+
+// EmbeddingQuery selects the embeddings of a user's messages nearest to a vector.
+type EmbeddingQuery struct {
+	UserID string
+	// Model is the embedding model of Vector; embeddings of other models can't be compared.
+	Model  string
+	Vector []float32
+	Limit  int
+	// ExcludeConversation, if not zero, skips the messages of one conversation.
+	ExcludeConversation primitive.ObjectID
+}
+
+// ScoredEmbedding is a search result with its cosine similarity to the query.
+type ScoredEmbedding struct {
+	Embedding *MessageEmbedding
+	Score     float64
+}
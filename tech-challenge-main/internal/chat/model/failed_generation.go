@@ -0,0 +1,33 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// FailedGeneration records a reply that could not be generated for a persisted user
+// message, so it can be retried instead of leaving the message unanswered.
+type FailedGeneration struct {
+	ID             primitive.ObjectID `bson:"_id"`
+	ConversationID primitive.ObjectID `bson:"conversation_id"`
+	MessageID      primitive.ObjectID `bson:"message_id"`
+	Error          string             `bson:"error"`
+	Attempts       int                `bson:"attempts"`
+	CreatedAt      time.Time          `bson:"created_at"`
+	UpdatedAt      time.Time          `bson:"updated_at"`
+	ResolvedAt     *time.Time         `bson:"resolved_at,omitempty"`
+}
+
+// Generation records a reply being generated for a persisted user message. It is deleted
+// once the reply is stored or its failure recorded, so a record left behind after the time
+// a reply may take means the server stopped mid-generation and nobody will answer.
+type Generation struct {
+	ID             primitive.ObjectID `bson:"_id"`
+	ConversationID primitive.ObjectID `bson:"conversation_id"`
+	MessageID      primitive.ObjectID `bson:"message_id"`
+	StartedAt      time.Time          `bson:"started_at"`
+	// CancelRequestedAt is set when the user stops the generation, for the instance
+	// generating it to cancel it.
+	CancelRequestedAt *time.Time `bson:"cancel_requested_at,omitempty"`
+}
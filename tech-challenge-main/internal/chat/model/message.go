@@ -14,13 +14,73 @@ type Message struct {
 	Content   string             `bson:"content"`
 	CreatedAt time.Time          `bson:"created_at"`
 	UpdatedAt time.Time          `bson:"updated_at"`
+	// Attachments sent with the message
+	Attachments []*Attachment `bson:"attachments,omitempty"`
+	// PII lists the kinds of personal data detected in the content, e.g. "email".
+	PII []string `bson:"pii,omitempty"`
+	// Tools traces the tools the assistant called while writing a reply.
+	Tools []*ToolCall `bson:"tools,omitempty"`
+	// PinnedAt is set while the message is pinned. Pinned messages are sent verbatim even
+	// once the conversation's summary covers them.
+	PinnedAt *time.Time `bson:"pinned_at,omitempty"`
+	// Intent labels what a user message asks for, e.g. "weather", for analytics.
+	Intent string `bson:"intent,omitempty"`
+	// DeviceID is the device a user message was sent from, if the client identified it.
+	DeviceID string `bson:"device_id,omitempty"`
+	// Rating is how the user rated a reply of the assistant, if they did.
+	Rating Rating `bson:"rating,omitempty"`
+}
+
+// Rating is a user's opinion of a reply: RatingPositive or RatingNegative.
+type Rating string
+
+const (
+	RatingPositive Rating = "positive"
+	RatingNegative Rating = "negative"
+)
+
+func (r Rating) Proto() pb.MessageRating {
+	switch r {
+	case RatingPositive:
+		return pb.MessageRating_MESSAGE_RATING_POSITIVE
+	case RatingNegative:
+		return pb.MessageRating_MESSAGE_RATING_NEGATIVE
+	default:
+		return pb.MessageRating_MESSAGE_RATING_UNSPECIFIED
+	}
+}
+
+// ToolCall is a tool call made for a reply, as described to users.
+type ToolCall struct {
+	Tool string `bson:"tool"`
+	// Call describes the call, e.g. "calling get_weather(Barcelona, 3 days)".
+	Call string `bson:"call"`
+	// Result describes the outcome, e.g. "weather received".
+	Result string `bson:"result,omitempty"`
+	Failed bool   `bson:"failed,omitempty"`
 }
 
 func (m *Message) Proto() *pb.Conversation_Message {
-	return &pb.Conversation_Message{
-		Id:        m.ID.Hex(),
-		Role:      m.Role.Proto(),
-		Content:   m.Content,
-		Timestamp: timestamppb.New(m.CreatedAt),
+	proto := &pb.Conversation_Message{
+		Id:           m.ID.Hex(),
+		Role:         m.Role.Proto(),
+		Content:      m.Content,
+		Timestamp:    timestamppb.New(m.CreatedAt),
+		PersonalData: m.PII,
+		Intent:       m.Intent,
+		DeviceId:     m.DeviceID,
+		Rating:       m.Rating.Proto(),
+	}
+	if m.PinnedAt != nil {
+		proto.PinnedAt = timestamppb.New(*m.PinnedAt)
+	}
+
+	for _, a := range m.Attachments {
+		proto.Attachments = append(proto.Attachments, a.Proto())
+	}
+	for _, t := range m.Tools {
+		proto.ToolCalls = append(proto.ToolCalls, &pb.Conversation_ToolCall{Tool: t.Tool, Call: t.Call, Result: t.Result, Failed: t.Failed})
 	}
+
+	return proto
 }
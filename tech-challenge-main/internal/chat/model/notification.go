@@ -0,0 +1,107 @@
+package model
+
+import (
+	"time"
+
+	"github.com/acai-travel/tech-challenge/internal/pb"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// NotificationSettings are how a user wants to be notified. A kind of notification without a
+// route goes to Channels.
+type NotificationSettings struct {
+	Channels   []string            `bson:"channels"`
+	Routes     []NotificationRoute `bson:"routes,omitempty"`
+	Email      string              `bson:"email,omitempty"`
+	QuietHours *QuietHours         `bson:"quiet_hours,omitempty"`
+}
+
+// NotificationRoute lists the channels of one kind of notification; none mutes it.
+type NotificationRoute struct {
+	Kind     string   `bson:"kind"`
+	Channels []string `bson:"channels"`
+}
+
+// QuietHours is a daily period, from Start to End in Timezone, when only urgent notifications
+// are sent. Times are HH:MM; a Start after End spans midnight.
+type QuietHours struct {
+	Start    string `bson:"start"`
+	End      string `bson:"end"`
+	Timezone string `bson:"timezone,omitempty"`
+}
+
+// ChannelsOf returns the channels notifications of kind go to.
+func (s *NotificationSettings) ChannelsOf(kind string) []string {
+	for _, r := range s.Routes {
+		if r.Kind == kind {
+			return r.Channels
+		}
+	}
+	return s.Channels
+}
+
+func (s *NotificationSettings) Proto() *pb.NotificationSettings {
+	proto := &pb.NotificationSettings{
+		Channels: s.Channels,
+		Email:    s.Email,
+	}
+	for _, r := range s.Routes {
+		proto.Routes = append(proto.Routes, &pb.NotificationRoute{Kind: r.Kind, Channels: r.Channels})
+	}
+	if q := s.QuietHours; q != nil {
+		proto.QuietHours = &pb.QuietHours{Start: q.Start, End: q.End, Timezone: q.Timezone}
+	}
+	return proto
+}
+
+// QueuedNotification is a notification held back by quiet hours, waiting to be sent to the
+// channels it was routed to.
+type QueuedNotification struct {
+	ID             primitive.ObjectID `bson:"_id"`
+	UserID         string             `bson:"user_id"`
+	Channels       []string           `bson:"channels"`
+	Kind           string             `bson:"kind"`
+	Title          string             `bson:"title"`
+	Body           string             `bson:"body"`
+	ConversationID string             `bson:"conversation_id,omitempty"`
+	CreatedAt      time.Time          `bson:"created_at"`
+	// DeliverAt is when it is next due: the end of the quiet hours, then a retry.
+	DeliverAt time.Time `bson:"deliver_at"`
+	Attempts  int       `bson:"attempts"`
+}
+
+type PushPlatform string
+
+const (
+	PushFCM  PushPlatform = "fcm"
+	PushAPNs PushPlatform = "apns"
+)
+
+func (p PushPlatform) Proto() pb.PushPlatform {
+	switch p {
+	case PushFCM:
+		return pb.PushPlatform_PUSH_PLATFORM_FCM
+	case PushAPNs:
+		return pb.PushPlatform_PUSH_PLATFORM_APNS
+	default:
+		return pb.PushPlatform_PUSH_PLATFORM_UNSPECIFIED
+	}
+}
+
+// PushDevice is a device of a user that push notifications are sent to, by its token.
+type PushDevice struct {
+	Token        string       `bson:"token"`
+	Platform     PushPlatform `bson:"platform"`
+	DeviceID     string       `bson:"device_id,omitempty"`
+	RegisteredAt time.Time    `bson:"registered_at"`
+}
+
+func (d *PushDevice) Proto() *pb.PushDevice {
+	return &pb.PushDevice{
+		Token:        d.Token,
+		Platform:     d.Platform.Proto(),
+		DeviceId:     d.DeviceID,
+		RegisteredAt: timestamppb.New(d.RegisteredAt),
+	}
+}
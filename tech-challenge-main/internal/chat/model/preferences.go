@@ -0,0 +1,22 @@
+package model
+
+import "github.com/acai-travel/tech-challenge/internal/pb"
+
+// Preferences are how a user wants to be answered. Unset fields take their default.
+type Preferences struct {
+	HealthAdvisories *bool `bson:"health_advisories,omitempty"`
+	// Timezone is the IANA name of the user's time zone, e.g. "Europe/Madrid"; empty if unknown.
+	Timezone *string `bson:"timezone,omitempty"`
+}
+
+// HealthAdvisoriesEnabled reports whether weather answers should add UV and pollen
+// advisories, which they do unless the user turned them off.
+func (p Preferences) HealthAdvisoriesEnabled() bool {
+	return p.HealthAdvisories == nil || *p.HealthAdvisories
+}
+
+// Proto returns the preferences with defaults for those never set.
+func (p Preferences) Proto() *pb.Preferences {
+	enabled := p.HealthAdvisoriesEnabled()
+	return &pb.Preferences{HealthAdvisories: &enabled, Timezone: p.Timezone}
+}
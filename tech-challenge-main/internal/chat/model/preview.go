@@ -0,0 +1,47 @@
+package model
+
+import (
+	"strings"
+
+	"github.com/acai-travel/tech-challenge/internal/pb"
+	"github.com/acai-travel/tech-challenge/internal/textx"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// previewLength is the number of characters of the latest message shown in a preview.
+const previewLength = 120
+
+// ConversationPreview is a conversation without its messages, plus a summary of them
+// computed by the database.
+type ConversationPreview struct {
+	Conversation `bson:",inline"`
+	MessageCount int      `bson:"message_count"`
+	LastMessage  *Message `bson:"last_message,omitempty"`
+	// UnreadCount is the number of assistant replies after the user's last read message.
+	UnreadCount int `bson:"unread_count"`
+}
+
+func (p *ConversationPreview) Proto() *pb.Conversation {
+	proto := p.Conversation.Proto()
+	proto.Preview = &pb.Conversation_Preview{
+		MessageCount: int32(p.MessageCount),
+		Unread:       p.UnreadCount > 0,
+		UnreadCount:  int32(p.UnreadCount),
+	}
+
+	if m := p.LastMessage; m != nil {
+		proto.Preview.LastMessage = snippet(m.Content)
+		proto.Preview.LastMessageRole = m.Role.Proto()
+		proto.Preview.LastMessageTimestamp = timestamppb.New(m.CreatedAt)
+	}
+
+	return proto
+}
+
+func snippet(s string) string {
+	s = strings.Join(strings.Fields(textx.Clean(s)), " ")
+	if cut := textx.Truncate(s, previewLength); cut != s {
+		return cut + "…"
+	}
+	return s
+}
@@ -0,0 +1,103 @@
+package model
+
+import (
+	"strings"
+	"time"
+
+	"github.com/acai-travel/tech-challenge/internal/pb"
+)
+
+// UserProfile holds per-user data that outlives conversations.
+type UserProfile struct {
+	UserID    string          `bson:"_id"`
+	Aliases   []LocationAlias `bson:"aliases"`
+	Calendars []UserCalendar  `bson:"calendars,omitempty"`
+	UpdatedAt time.Time       `bson:"updated_at"`
+	// Digest is set while the user is subscribed to digests.
+	Digest *DigestSubscription `bson:"digest,omitempty"`
+	// CalDAV is set while the user has a CalDAV calendar connected.
+	CalDAV      *CalDAVAccount `bson:"caldav,omitempty"`
+	Preferences Preferences    `bson:"preferences"`
+	// Notifications is set once the user chose how to be notified.
+	Notifications *NotificationSettings `bson:"notifications,omitempty"`
+	// PushDevices are the devices registered for push notifications, oldest first.
+	PushDevices []PushDevice `bson:"push_devices,omitempty"`
+}
+
+// LocationAlias is a named place of a user, e.g. "home", stored under its normalized name.
+type LocationAlias struct {
+	Name  string  `bson:"name"`
+	Lat   float64 `bson:"lat"`
+	Lon   float64 `bson:"lon"`
+	Label string  `bson:"label,omitempty"`
+}
+
+func (a *LocationAlias) Proto() *pb.LocationAlias {
+	return &pb.LocationAlias{
+		Name:      a.Name,
+		Latitude:  a.Lat,
+		Longitude: a.Lon,
+		Label:     a.Label,
+	}
+}
+
+// Alias returns the alias saved under name, or nil.
+func (p *UserProfile) Alias(name string) *LocationAlias {
+	name = NormalizeAliasName(name)
+	for i := range p.Aliases {
+		if p.Aliases[i].Name == name {
+			return &p.Aliases[i]
+		}
+	}
+	return nil
+}
+
+// UserCalendar is an ICS feed of a user, e.g. "work", stored under its normalized name,
+// which is normalized like alias names.
+type UserCalendar struct {
+	Name string `bson:"name"`
+	URL  string `bson:"url"`
+}
+
+func (c *UserCalendar) Proto() *pb.UserCalendar {
+	return &pb.UserCalendar{
+		Name: c.Name,
+		Url:  c.URL,
+	}
+}
+
+// Calendar returns the calendar linked under name, or nil.
+func (p *UserProfile) Calendar(name string) *UserCalendar {
+	name = NormalizeAliasName(name)
+	for i := range p.Calendars {
+		if p.Calendars[i].Name == name {
+			return &p.Calendars[i]
+		}
+	}
+	return nil
+}
+
+// CalDAVAccount is the CalDAV calendar a user lets the assistant add events to. Password is
+// sealed by the chat server, which alone can open it.
+type CalDAVAccount struct {
+	URL      string `bson:"url"`
+	Username string `bson:"username"`
+	Password []byte `bson:"password"`
+}
+
+func (a *CalDAVAccount) Proto() *pb.CalDAVAccount {
+	return &pb.CalDAVAccount{
+		Url:      a.URL,
+		Username: a.Username,
+	}
+}
+
+// NormalizeAliasName lowercases an alias name and drops a leading "my" or "the", so "My Home"
+// and "home" name the same place, as do "the office" and "office".
+func NormalizeAliasName(name string) string {
+	name = strings.Join(strings.Fields(strings.ToLower(name)), " ")
+	for _, prefix := range []string{"my ", "the "} {
+		name = strings.TrimPrefix(name, prefix)
+	}
+	return name
+}
@@ -0,0 +1,16 @@
+package model
+
+// DailyUsage is what a user consumed in a UTC day, counted against their plan's quota.
+type DailyUsage struct {
+	ID     string `bson:"_id"`
+	UserID string `bson:"user_id"`
+	// Day is the UTC day, e.g. "2025-08-20".
+	Day       string `bson:"day"`
+	Messages  int64  `bson:"messages"`
+	Tokens    int64  `bson:"tokens"`
+	ToolCalls int64  `bson:"tool_calls"`
+}
+
+func dailyUsageID(userID, day string) string {
+	return userID + "/" + day
+}
@@ -3,6 +3,10 @@ package model
 import (
 	"context"
 	"errors"
+	"fmt"
+	"slices"
+	"sync/atomic"
+	"time"
 
 	"github.com/twitchtv/twirp"
 	"go.mongodb.org/mongo-driver/bson"
@@ -12,11 +16,27 @@ import (
 )
 
 const (
-	conversationCollection = "conversations"
+	conversationCollection     = "conversations"
+	failedGenerationCollection = "failed_generations"
+	generationCollection       = "generations"
+	embeddingCollection        = "message_embeddings"
+	attachmentCollection       = "attachments"
+	userProfileCollection      = "user_profiles"
+	bulkJobCollection          = "bulk_jobs"
+	sessionCollection          = "sessions"
+	dailyUsageCollection       = "daily_usage"
+	shadowReplyCollection      = "shadow_replies"
+	notificationCollection     = "notifications"
+	promptRolloutCollection    = "prompt_rollouts"
+	whatsAppChatCollection     = "whatsapp_chats"
 )
 
 type Repository struct {
-	conn *mongo.Database
+	conn   *mongo.Database
+	prefix string
+
+	// Set once the server rejected a transaction, e.g. a standalone (non replica set) deployment
+	noTransactions atomic.Bool
 }
 
 func New(conn *mongo.Database) *Repository {
@@ -25,20 +45,48 @@ func New(conn *mongo.Database) *Repository {
 	}
 }
 
+// NewWithPrefix returns a repository whose collection names start with prefix, so several
+// tenants can share a database without sharing data.
+func NewWithPrefix(conn *mongo.Database, prefix string) *Repository {
+	return &Repository{
+		conn:   conn,
+		prefix: prefix,
+	}
+}
+
+func (r *Repository) collection(name string) *mongo.Collection {
+	return r.conn.Collection(r.prefix + name)
+}
+
 func (r *Repository) CreateConversation(ctx context.Context, c *Conversation) error {
-	_, err := r.conn.Collection(conversationCollection).InsertOne(ctx, c)
+	_, err := r.collection(conversationCollection).InsertOne(ctx, c)
 	return err
 }
 
 func (r *Repository) DescribeConversation(ctx context.Context, id string) (*Conversation, error) {
+	return r.findConversation(ctx, id, map[string]any{}, nil)
+}
+
+// DescribeVisibleConversation is DescribeConversation for a user: conversations they may not
+// see aren't found. Only the stored fields listed, and the ID, are read; nil reads them all.
+func (r *Repository) DescribeVisibleConversation(ctx context.Context, id, userID string, fields []string) (*Conversation, error) {
+	return r.findConversation(ctx, id, map[string]any{"owner": visibleTo(userID)}, fields)
+}
+
+func (r *Repository) findConversation(ctx context.Context, id string, filter map[string]any, fields []string) (*Conversation, error) {
 	var c Conversation
 
 	oid, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
 		return nil, twirp.NotFoundError("invalid conversation ID")
 	}
+	filter["_id"] = oid
 
-	err = r.conn.Collection(conversationCollection).FindOne(ctx, map[string]any{"_id": oid}).Decode(&c)
+	opts := options.FindOne()
+	if fields != nil {
+		opts.SetProjection(projection(fields))
+	}
+	err = r.collection(conversationCollection).FindOne(ctx, filter, opts).Decode(&c)
 	if errors.Is(err, mongo.ErrNoDocuments) {
 		return nil, twirp.NotFoundError("conversation not found")
 	}
@@ -50,12 +98,27 @@ func (r *Repository) DescribeConversation(ctx context.Context, id string) (*Conv
 	return &c, nil
 }
 
-func (r *Repository) ListConversations(ctx context.Context) ([]*Conversation, error) {
+// visibleTo matches the owner of the conversations visible to a user: none, or the user.
+func visibleTo(userID string) map[string]any {
+	return map[string]any{"$in": []any{nil, userID}}
+}
+
+// ListConversations returns the conversations visible to a user, newest first, without their
+// messages. Archived conversations are only included with includeArchived. Only the stored
+// fields listed, and the IDs, are read; nil reads them all.
+func (r *Repository) ListConversations(ctx context.Context, userID string, includeArchived bool, fields []string) ([]*Conversation, error) {
 	opts := options.Find().
-		SetSort(bson.D{{Key: "created_at", Value: -1}})
+		SetSort(bson.D{{Key: "created_at", Value: -1}}).
+		SetProjection(map[string]any{"messages": 0})
+	if fields != nil {
+		p := projection(fields)
+		delete(p, "messages")
+		opts.SetProjection(p)
+	}
 
-	cursor, err := r.conn.Collection(conversationCollection).
-		Find(ctx, map[string]any{}, opts)
+	filter := archivedFilter(includeArchived)
+	filter["owner"] = visibleTo(userID)
+	cursor, err := r.collection(conversationCollection).Find(ctx, filter, opts)
 
 	if err != nil {
 		return nil, err
@@ -84,8 +147,38 @@ func (r *Repository) ListConversations(ctx context.Context) ([]*Conversation, er
 	return items, nil
 }
 
+// FindConversationTitles returns the titles of the conversations among ids that exist and
+// aren't archived.
+func (r *Repository) FindConversationTitles(ctx context.Context, ids []primitive.ObjectID) (map[primitive.ObjectID]string, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	filter := archivedFilter(false)
+	filter["_id"] = map[string]any{"$in": ids}
+	cursor, err := r.collection(conversationCollection).
+		Find(ctx, filter, options.Find().SetProjection(map[string]any{"title": 1}))
+	if err != nil {
+		return nil, err
+	}
+
+	var items []struct {
+		ID    primitive.ObjectID `bson:"_id"`
+		Title string             `bson:"title"`
+	}
+	if err := cursor.All(ctx, &items); err != nil {
+		return nil, err
+	}
+
+	titles := make(map[primitive.ObjectID]string, len(items))
+	for _, c := range items {
+		titles[c.ID] = c.Title
+	}
+	return titles, nil
+}
+
 func (r *Repository) UpdateConversation(ctx context.Context, c *Conversation) error {
-	_, err := r.conn.Collection(conversationCollection).UpdateOne(ctx,
+	_, err := r.collection(conversationCollection).UpdateOne(ctx,
 		map[string]any{"_id": c.ID},
 		map[string]any{"$set": c})
 
@@ -96,11 +189,1443 @@ func (r *Repository) UpdateConversation(ctx context.Context, c *Conversation) er
 	return err
 }
 
+// AppendMessages appends messages to a conversation and bumps its updated_at, without
+// rewriting the rest of the document. It is idempotent: if the messages were already
+// appended (e.g. by a retried write), it does nothing.
+func (r *Repository) AppendMessages(ctx context.Context, id primitive.ObjectID, msgs ...*Message) error {
+	if len(msgs) == 0 {
+		return nil
+	}
+
+	ids := make([]primitive.ObjectID, 0, len(msgs))
+	for _, m := range msgs {
+		ids = append(ids, m.ID)
+	}
+
+	res, err := r.collection(conversationCollection).UpdateOne(ctx,
+		map[string]any{"_id": id, "messages._id": map[string]any{"$nin": ids}},
+		map[string]any{
+			"$push": map[string]any{"messages": map[string]any{"$each": msgs}},
+			"$set":  map[string]any{"updated_at": msgs[len(msgs)-1].CreatedAt},
+		})
+
+	if err != nil {
+		return err
+	}
+
+	if res.MatchedCount == 0 {
+		return r.requireConversation(ctx, id)
+	}
+
+	return nil
+}
+
+// AcquireLease takes the lease of a conversation for ttl, so only one request replies in it
+// at a time. It reports false while another holder's lease hasn't expired.
+func (r *Repository) AcquireLease(ctx context.Context, id primitive.ObjectID, holder string, ttl time.Duration) (bool, error) {
+	now := time.Now()
+	res, err := r.collection(conversationCollection).UpdateOne(ctx,
+		map[string]any{"_id": id, "$or": []map[string]any{
+			{"lease": map[string]any{"$exists": false}},
+			{"lease.expires_at": map[string]any{"$lte": now}},
+			{"lease.holder": holder},
+		}},
+		map[string]any{"$set": map[string]any{"lease": map[string]any{"holder": holder, "expires_at": now.Add(ttl)}}})
+
+	if err != nil {
+		return false, err
+	}
+
+	if res.MatchedCount == 0 {
+		return false, r.requireConversation(ctx, id)
+	}
+
+	return true, nil
+}
+
+// ReleaseLease gives up a lease taken with AcquireLease, unless it expired and was taken by
+// another holder since.
+func (r *Repository) ReleaseLease(ctx context.Context, id primitive.ObjectID, holder string) error {
+	_, err := r.collection(conversationCollection).UpdateOne(ctx,
+		map[string]any{"_id": id, "lease.holder": holder},
+		map[string]any{"$unset": map[string]any{"lease": ""}})
+
+	return err
+}
+
+// ListConversationPreviews returns the conversations visible to a user, newest first, each
+// with its message count, latest message and the number of replies the user hasn't read.
+// These are computed by the database, so message histories are never transferred. Archived
+// conversations are only included with includeArchived. Only the stored fields listed, and
+// the IDs, are read; nil reads them all.
+func (r *Repository) ListConversationPreviews(ctx context.Context, userID string, includeArchived bool, fields []string) ([]*ConversationPreview, error) {
+	messages := map[string]any{"$ifNull": []any{"$messages", []any{}}}
+	lastRead := map[string]any{"$arrayElemAt": []any{
+		map[string]any{"$map": map[string]any{
+			"input": map[string]any{"$filter": map[string]any{
+				"input": map[string]any{"$ifNull": []any{"$reads", []any{}}},
+				"cond":  map[string]any{"$eq": []any{"$$this.user_id", userID}},
+			}},
+			"in": "$$this.message_id",
+		}},
+		0,
+	}}
+
+	match := archivedFilter(includeArchived)
+	match["owner"] = visibleTo(userID)
+	pipeline := []map[string]any{
+		{"$match": match},
+		{"$sort": bson.D{{Key: "created_at", Value: -1}}},
+		{"$addFields": map[string]any{
+			// -1 when the user hasn't read anything, so every reply counts as unread.
+			"last_read_index": map[string]any{"$indexOfArray": []any{
+				map[string]any{"$map": map[string]any{"input": messages, "in": "$$this._id"}},
+				map[string]any{"$ifNull": []any{lastRead, nil}},
+			}},
+		}},
+		{"$project": previewProjection(fields, map[string]any{
+			"message_count": map[string]any{"$size": messages},
+			"last_message":  map[string]any{"$arrayElemAt": []any{"$messages", -1}},
+			"unread_count": map[string]any{"$size": map[string]any{"$filter": map[string]any{
+				"input": map[string]any{"$slice": []any{
+					messages,
+					map[string]any{"$add": []any{"$last_read_index", 1}},
+					map[string]any{"$add": []any{map[string]any{"$size": messages}, 1}},
+				}},
+				"cond": map[string]any{"$eq": []any{"$$this.role", RoleAssistant}},
+			}}},
+		})},
+	}
+
+	cursor, err := r.collection(conversationCollection).Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+
+	var items []*ConversationPreview
+	if err := cursor.All(ctx, &items); err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+// previewFields are the stored fields of the conversations ListConversationPreviews returns.
+var previewFields = []string{"subject", "icon", "created_at", "updated_at", "settings", "assistant", "tags", "archived_at"}
+
+// previewProjection projects the preview fields among fields, nil for all, next to computed.
+func previewProjection(fields []string, computed map[string]any) map[string]any {
+	for _, f := range previewFields {
+		if fields == nil || slices.Contains(fields, f) {
+			computed[f] = 1
+		}
+	}
+	return computed
+}
+
+// projection includes fields in the documents read.
+func projection(fields []string) map[string]any {
+	p := map[string]any{"_id": 1}
+	for _, f := range fields {
+		p[f] = 1
+	}
+	return p
+}
+
+func archivedFilter(includeArchived bool) map[string]any {
+	if includeArchived {
+		return map[string]any{}
+	}
+	return map[string]any{"archived_at": map[string]any{"$exists": false}}
+}
+
+// MarkRead records the latest message a user has seen in a conversation, replacing their
+// previous marker.
+func (r *Repository) MarkRead(ctx context.Context, id primitive.ObjectID, userID string, messageID primitive.ObjectID) error {
+	coll := r.collection(conversationCollection)
+	now := time.Now()
+
+	// Two attempts: a concurrent first read may add the user's marker between our updates.
+	for attempt := 0; attempt < 2; attempt++ {
+		res, err := coll.UpdateOne(ctx,
+			map[string]any{"_id": id, "reads.user_id": userID},
+			map[string]any{"$set": map[string]any{"reads.$.message_id": messageID, "reads.$.updated_at": now}})
+		if err != nil {
+			return err
+		}
+		if res.MatchedCount > 0 {
+			return nil
+		}
+
+		res, err = coll.UpdateOne(ctx,
+			map[string]any{"_id": id, "reads.user_id": map[string]any{"$ne": userID}},
+			map[string]any{"$push": map[string]any{"reads": &ReadMarker{UserID: userID, MessageID: messageID, UpdatedAt: now}}})
+		if err != nil {
+			return err
+		}
+		if res.MatchedCount > 0 {
+			return nil
+		}
+
+		if err := r.requireConversation(ctx, id); err != nil {
+			return err
+		}
+	}
+
+	return errors.New("failed to update read marker: concurrent modification")
+}
+
+// UpdateTitle sets the title of a conversation.
+func (r *Repository) UpdateTitle(ctx context.Context, id primitive.ObjectID, title string) error {
+	res, err := r.collection(conversationCollection).UpdateOne(ctx,
+		map[string]any{"_id": id},
+		map[string]any{"$set": map[string]any{"subject": title}})
+
+	if err != nil {
+		return err
+	}
+
+	if res.MatchedCount == 0 {
+		return twirp.NotFoundError("conversation not found")
+	}
+
+	return nil
+}
+
+// UpdateIcon sets the icon shown before the title of a conversation.
+func (r *Repository) UpdateIcon(ctx context.Context, id primitive.ObjectID, icon string) error {
+	res, err := r.collection(conversationCollection).UpdateOne(ctx,
+		map[string]any{"_id": id},
+		map[string]any{"$set": map[string]any{"icon": icon}})
+
+	if err != nil {
+		return err
+	}
+
+	if res.MatchedCount == 0 {
+		return twirp.NotFoundError("conversation not found")
+	}
+
+	return nil
+}
+
+// UpdateLanguage sets the reply language of a conversation; empty removes it.
+func (r *Repository) UpdateLanguage(ctx context.Context, id primitive.ObjectID, language string) error {
+	update := map[string]any{"$set": map[string]any{"language": language}}
+	if language == "" {
+		update = map[string]any{"$unset": map[string]any{"language": ""}}
+	}
+
+	res, err := r.collection(conversationCollection).UpdateOne(ctx, map[string]any{"_id": id}, update)
+	if err != nil {
+		return err
+	}
+
+	if res.MatchedCount == 0 {
+		return twirp.NotFoundError("conversation not found")
+	}
+
+	return nil
+}
+
+// AddUsage adds what generating a reply consumed to the usage of a conversation.
+func (r *Repository) AddUsage(ctx context.Context, id primitive.ObjectID, u Usage) error {
+	res, err := r.collection(conversationCollection).UpdateOne(ctx,
+		map[string]any{"_id": id},
+		map[string]any{"$inc": map[string]any{
+			"usage.prompt_tokens":     u.PromptTokens,
+			"usage.completion_tokens": u.CompletionTokens,
+			"usage.cost_usd":          u.CostUSD,
+			"usage.limited_replies":   u.LimitedReplies,
+		}})
+	if err != nil {
+		return err
+	}
+
+	if res.MatchedCount == 0 {
+		return twirp.NotFoundError("conversation not found")
+	}
+
+	return nil
+}
+
+// PinMessage pins a message of a conversation at the given time, or unpins it if at is nil.
+func (r *Repository) PinMessage(ctx context.Context, id, messageID primitive.ObjectID, at *time.Time) error {
+	update := map[string]any{"$set": map[string]any{"messages.$.pinned_at": at}}
+	if at == nil {
+		update = map[string]any{"$unset": map[string]any{"messages.$.pinned_at": ""}}
+	}
+
+	res, err := r.collection(conversationCollection).UpdateOne(ctx,
+		map[string]any{"_id": id, "messages._id": messageID}, update)
+	if err != nil {
+		return err
+	}
+
+	if res.MatchedCount == 0 {
+		return twirp.NotFoundError("message not found")
+	}
+
+	return nil
+}
+
+// RateMessage sets the rating of a message of a conversation; an empty rating clears it.
+func (r *Repository) RateMessage(ctx context.Context, id, messageID primitive.ObjectID, rating Rating) error {
+	update := map[string]any{"$set": map[string]any{"messages.$.rating": rating}}
+	if rating == "" {
+		update = map[string]any{"$unset": map[string]any{"messages.$.rating": ""}}
+	}
+
+	res, err := r.collection(conversationCollection).UpdateOne(ctx,
+		map[string]any{"_id": id, "messages._id": messageID}, update)
+	if err != nil {
+		return err
+	}
+
+	if res.MatchedCount == 0 {
+		return twirp.NotFoundError("message not found")
+	}
+
+	return nil
+}
+
+// SetMessageIntent labels a message of a conversation with its intent.
+func (r *Repository) SetMessageIntent(ctx context.Context, id, messageID primitive.ObjectID, intent string) error {
+	res, err := r.collection(conversationCollection).UpdateOne(ctx,
+		map[string]any{"_id": id, "messages._id": messageID},
+		map[string]any{"$set": map[string]any{"messages.$.intent": intent}})
+	if err != nil {
+		return err
+	}
+
+	if res.MatchedCount == 0 {
+		return twirp.NotFoundError("message not found")
+	}
+
+	return nil
+}
+
+// CountIntents counts the user messages sent in [since, until) by intent, "" counting
+// the unlabeled ones. Zero times leave the range open, and assistants, if any, only counts
+// the conversations of those, "" being the default one.
+func (r *Repository) CountIntents(ctx context.Context, since, until time.Time, assistants ...string) (map[string]int64, error) {
+	message := map[string]any{"role": RoleUser}
+	created := map[string]any{}
+	if !since.IsZero() {
+		created["$gte"] = since
+	}
+	if !until.IsZero() {
+		created["$lt"] = until
+	}
+	if len(created) > 0 {
+		message["created_at"] = created
+	}
+
+	// Conversations without a message in range are skipped before unwinding, so only the
+	// matching ones are; the match after it drops their other messages.
+	conversations := map[string]any{"messages": map[string]any{"$elemMatch": message}}
+	if len(assistants) > 0 {
+		in := make([]any, 0, len(assistants))
+		for _, a := range assistants {
+			if a == "" {
+				in = append(in, nil, "")
+			} else {
+				in = append(in, a)
+			}
+		}
+		conversations["assistant"] = map[string]any{"$in": in}
+	}
+	unwound := make(map[string]any, len(message))
+	for k, v := range message {
+		unwound["messages."+k] = v
+	}
+
+	cursor, err := r.collection(conversationCollection).Aggregate(ctx, []map[string]any{
+		{"$match": conversations},
+		{"$unwind": "$messages"},
+		{"$match": unwound},
+		{"$group": map[string]any{
+			"_id":   map[string]any{"$ifNull": []any{"$messages.intent", ""}},
+			"count": map[string]any{"$sum": 1},
+		}},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []struct {
+		Intent string `bson:"_id"`
+		Count  int64  `bson:"count"`
+	}
+	if err := cursor.All(ctx, &rows); err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		counts[row.Intent] = row.Count
+	}
+	return counts, nil
+}
+
+// UpdateSummary replaces the summary of a conversation, provided it still covers the
+// messages up to prev (the zero ID meaning no summary yet). It reports whether the summary
+// was replaced; it isn't if a concurrent update got there first.
+func (r *Repository) UpdateSummary(ctx context.Context, id, prev primitive.ObjectID, s *Summary) (bool, error) {
+	filter := map[string]any{"_id": id, "summary.up_to_message_id": prev}
+	if prev.IsZero() {
+		filter = map[string]any{"_id": id, "summary": map[string]any{"$exists": false}}
+	}
+
+	res, err := r.collection(conversationCollection).UpdateOne(ctx, filter, map[string]any{"$set": map[string]any{"summary": s}})
+	if err != nil {
+		return false, err
+	}
+	return res.ModifiedCount > 0, nil
+}
+
+// FindEmailConversation returns the conversation of the email thread with one of
+// messageIDs, or nil if there is none.
+func (r *Repository) FindEmailConversation(ctx context.Context, messageIDs []string) (*Conversation, error) {
+	if len(messageIDs) == 0 {
+		return nil, nil
+	}
+
+	var c Conversation
+	err := r.collection(conversationCollection).FindOne(ctx,
+		map[string]any{"email.message_ids": map[string]any{"$in": messageIDs}},
+		options.FindOne().SetSort(bson.D{{Key: "updated_at", Value: -1}})).Decode(&c)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// AddEmailMessageIDs records emails of the thread a conversation is held in.
+func (r *Repository) AddEmailMessageIDs(ctx context.Context, id primitive.ObjectID, messageIDs ...string) error {
+	res, err := r.collection(conversationCollection).UpdateOne(ctx,
+		map[string]any{"_id": id},
+		map[string]any{"$addToSet": map[string]any{"email.message_ids": map[string]any{"$each": messageIDs}}})
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return twirp.NotFoundError("conversation not found")
+	}
+	return nil
+}
+
+// maxWhatsAppMessageIDs bounds how many received message IDs a WhatsApp conversation
+// keeps; webhooks are only redelivered for a few days.
+const maxWhatsAppMessageIDs = 200
+
+// FindWhatsAppConversation returns the latest conversation held in the WhatsApp chat with
+// phone that is not archived, or nil if there is none.
+func (r *Repository) FindWhatsAppConversation(ctx context.Context, phone string) (*Conversation, error) {
+	var c Conversation
+	err := r.collection(conversationCollection).FindOne(ctx,
+		map[string]any{"whatsapp.phone": phone, "archived_at": map[string]any{"$exists": false}},
+		options.FindOne().SetSort(bson.D{{Key: "updated_at", Value: -1}})).Decode(&c)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// HasWhatsAppMessage reports whether a WhatsApp message was already received in a
+// conversation with phone.
+func (r *Repository) HasWhatsAppMessage(ctx context.Context, phone, messageID string) (bool, error) {
+	n, err := r.collection(conversationCollection).CountDocuments(ctx,
+		map[string]any{"whatsapp.phone": phone, "whatsapp.message_ids": messageID}, options.Count().SetLimit(1))
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
+
+// AddWhatsAppMessageID records a message received in the WhatsApp chat of a conversation.
+func (r *Repository) AddWhatsAppMessageID(ctx context.Context, id primitive.ObjectID, messageID string) error {
+	res, err := r.collection(conversationCollection).UpdateOne(ctx,
+		map[string]any{"_id": id},
+		map[string]any{"$push": map[string]any{"whatsapp.message_ids": map[string]any{
+			"$each":  []string{messageID},
+			"$slice": -maxWhatsAppMessageIDs,
+		}}})
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return twirp.NotFoundError("conversation not found")
+	}
+	return nil
+}
+
+// OpenWhatsAppConversation returns the conversation held in the WhatsApp chat of c, or creates
+// c for it if it has none that is not archived. It reports whether c was created. Each chat
+// is upserted by phone with its open conversation, so concurrent messages of a new chat
+// start a single one.
+func (r *Repository) OpenWhatsAppConversation(ctx context.Context, c *Conversation) (*Conversation, bool, error) {
+	// c is stored first, so the conversation a chat links to always exists.
+	if err := r.CreateConversation(ctx, c); err != nil {
+		return nil, false, err
+	}
+	chats := r.collection(whatsAppChatCollection)
+	for {
+		var chat struct {
+			ConversationID primitive.ObjectID `bson:"conversation_id"`
+		}
+		err := chats.FindOneAndUpdate(ctx, map[string]any{"_id": c.WhatsApp.Phone},
+			map[string]any{"$setOnInsert": map[string]any{"conversation_id": c.ID}},
+			options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)).Decode(&chat)
+		if err != nil {
+			return nil, false, err
+		}
+		if chat.ConversationID == c.ID {
+			return c, true, nil
+		}
+
+		var open Conversation
+		err = r.collection(conversationCollection).FindOne(ctx,
+			map[string]any{"_id": chat.ConversationID, "archived_at": map[string]any{"$exists": false}}).Decode(&open)
+		if err == nil {
+			_, err := r.collection(conversationCollection).DeleteOne(ctx, map[string]any{"_id": c.ID})
+			return &open, false, err
+		}
+		if !errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, false, err
+		}
+
+		// The linked conversation was archived or deleted; c replaces it unless another
+		// message did first.
+		res, err := chats.UpdateOne(ctx,
+			map[string]any{"_id": c.WhatsApp.Phone, "conversation_id": chat.ConversationID},
+			map[string]any{"$set": map[string]any{"conversation_id": c.ID}})
+		if err != nil {
+			return nil, false, err
+		}
+		if res.ModifiedCount == 1 {
+			return c, true, nil
+		}
+	}
+}
+
+// requireConversation returns a not found error unless the conversation exists.
+func (r *Repository) requireConversation(ctx context.Context, id primitive.ObjectID) error {
+	n, err := r.collection(conversationCollection).CountDocuments(ctx, map[string]any{"_id": id}, options.Count().SetLimit(1))
+	if err != nil {
+		return err
+	}
+
+	if n == 0 {
+		return twirp.NotFoundError("conversation not found")
+	}
+
+	return nil
+}
+
+// WithTransaction runs fn in a transaction. Deployments without transaction support
+// (standalone servers) run fn directly, so callers get atomicity where available.
+func (r *Repository) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if r.noTransactions.Load() {
+		return fn(ctx)
+	}
+
+	sess, err := r.conn.Client().StartSession()
+	if err != nil {
+		return err
+	}
+	defer sess.EndSession(ctx)
+
+	_, err = sess.WithTransaction(ctx, func(sc mongo.SessionContext) (any, error) {
+		return nil, fn(sc)
+	})
+
+	// IllegalOperation: "Transaction numbers are only allowed on a replica set member or mongos"
+	var ce mongo.CommandError
+	if errors.As(err, &ce) && ce.Code == 20 {
+		r.noTransactions.Store(true)
+		return fn(ctx)
+	}
+
+	return err
+}
+
 func (r *Repository) DeleteConversation(ctx context.Context, id string) error {
-	_, err := r.conn.Collection(conversationCollection).DeleteOne(ctx, map[string]any{"_id": id})
+	_, err := r.collection(conversationCollection).DeleteOne(ctx, map[string]any{"_id": id})
 	if errors.Is(err, mongo.ErrNoDocuments) {
 		return twirp.NotFoundError("conversation not found")
 	}
 
 	return err
 }
+
+// RecordFailedGeneration stores a failed reply attempt. If the message already has an
+// unresolved record, its error and attempt count are updated instead.
+func (r *Repository) RecordFailedGeneration(ctx context.Context, convID, msgID primitive.ObjectID, cause error) error {
+	now := time.Now()
+	_, err := r.collection(failedGenerationCollection).UpdateOne(ctx,
+		map[string]any{"conversation_id": convID, "message_id": msgID, "resolved_at": map[string]any{"$exists": false}},
+		map[string]any{
+			"$set":         map[string]any{"error": cause.Error(), "updated_at": now},
+			"$inc":         map[string]any{"attempts": 1},
+			"$setOnInsert": map[string]any{"_id": primitive.NewObjectID(), "created_at": now},
+		},
+		options.Update().SetUpsert(true))
+
+	return err
+}
+
+// FindFailedGeneration returns the unresolved failed generation of a conversation, or nil if there is none.
+func (r *Repository) FindFailedGeneration(ctx context.Context, convID primitive.ObjectID) (*FailedGeneration, error) {
+	var fg FailedGeneration
+
+	err := r.collection(failedGenerationCollection).FindOne(ctx,
+		map[string]any{"conversation_id": convID, "resolved_at": map[string]any{"$exists": false}},
+		options.FindOne().SetSort(bson.D{{Key: "created_at", Value: -1}})).Decode(&fg)
+
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &fg, nil
+}
+
+// ResolveFailedGeneration marks a failed generation as answered.
+func (r *Repository) ResolveFailedGeneration(ctx context.Context, id primitive.ObjectID) error {
+	_, err := r.collection(failedGenerationCollection).UpdateOne(ctx,
+		map[string]any{"_id": id},
+		map[string]any{"$set": map[string]any{"resolved_at": time.Now()}})
+
+	return err
+}
+
+// StartGeneration records that a reply to a message is being generated.
+func (r *Repository) StartGeneration(ctx context.Context, convID, msgID primitive.ObjectID) (*Generation, error) {
+	g := &Generation{
+		ID:             primitive.NewObjectID(),
+		ConversationID: convID,
+		MessageID:      msgID,
+		StartedAt:      time.Now(),
+	}
+	if _, err := r.collection(generationCollection).InsertOne(ctx, g); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+// FinishGeneration deletes the record of a generation that ended, successfully or not.
+func (r *Repository) FinishGeneration(ctx context.Context, id primitive.ObjectID) error {
+	_, err := r.collection(generationCollection).DeleteOne(ctx, map[string]any{"_id": id})
+	return err
+}
+
+// RequestGenerationCancel asks the instances generating replies in a conversation to cancel
+// them, and reports whether any reply was being generated.
+func (r *Repository) RequestGenerationCancel(ctx context.Context, convID primitive.ObjectID) (bool, error) {
+	res, err := r.collection(generationCollection).UpdateMany(ctx,
+		map[string]any{"conversation_id": convID},
+		map[string]any{"$set": map[string]any{"cancel_requested_at": time.Now()}})
+	if err != nil {
+		return false, err
+	}
+	return res.MatchedCount > 0, nil
+}
+
+// GenerationCancelRequested reports whether the user asked to cancel a generation.
+func (r *Repository) GenerationCancelRequested(ctx context.Context, id primitive.ObjectID) (bool, error) {
+	n, err := r.collection(generationCollection).CountDocuments(ctx,
+		map[string]any{"_id": id, "cancel_requested_at": map[string]any{"$exists": true}})
+	return n > 0, err
+}
+
+// ClaimStaleGeneration deletes and returns a generation started before startedBefore, or
+// nil if there is none. Deleting claims it, so concurrent sweeps handle each one once.
+func (r *Repository) ClaimStaleGeneration(ctx context.Context, startedBefore time.Time) (*Generation, error) {
+	var g Generation
+
+	err := r.collection(generationCollection).FindOneAndDelete(ctx,
+		map[string]any{"started_at": map[string]any{"$lt": startedBefore}},
+		options.FindOneAndDelete().SetSort(bson.D{{Key: "started_at", Value: 1}})).Decode(&g)
+
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &g, nil
+}
+
+// SaveEmbeddings stores message embeddings, replacing any previous embedding of the same message.
+func (r *Repository) SaveEmbeddings(ctx context.Context, embeddings ...*MessageEmbedding) error {
+	if len(embeddings) == 0 {
+		return nil
+	}
+
+	writes := make([]mongo.WriteModel, 0, len(embeddings))
+	for _, e := range embeddings {
+		writes = append(writes, mongo.NewReplaceOneModel().
+			SetFilter(map[string]any{"_id": e.MessageID}).
+			SetReplacement(e).
+			SetUpsert(true))
+	}
+
+	_, err := r.collection(embeddingCollection).BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false))
+	return err
+}
+
+// ListEmbeddings returns up to limit embeddings of a user's messages produced by an
+// embedding model, newest first.
+func (r *Repository) ListEmbeddings(ctx context.Context, userID, embeddingModel string, limit int) ([]*MessageEmbedding, error) {
+	opts := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: -1}}).
+		SetLimit(int64(limit))
+
+	cursor, err := r.collection(embeddingCollection).
+		Find(ctx, map[string]any{"user_id": userID, "model": embeddingModel}, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var embeddings []*MessageEmbedding
+	if err := cursor.All(ctx, &embeddings); err != nil {
+		return nil, err
+	}
+
+	return embeddings, nil
+}
+
+// SearchEmbeddings finds the embeddings nearest to q with the Atlas Vector Search index
+// named index, which must index vector and filter on user_id, model and conversation_id.
+func (r *Repository) SearchEmbeddings(ctx context.Context, index string, q EmbeddingQuery) ([]ScoredEmbedding, error) {
+	filter := map[string]any{"user_id": q.UserID, "model": q.Model}
+	if !q.ExcludeConversation.IsZero() {
+		filter["conversation_id"] = map[string]any{"$ne": q.ExcludeConversation}
+	}
+
+	pipeline := []map[string]any{
+		{"$vectorSearch": map[string]any{
+			"index":       index,
+			"path":        "vector",
+			"queryVector": q.Vector,
+			// More candidates than results keep the approximate search accurate.
+			"numCandidates": min(max(20*q.Limit, 100), 10_000),
+			"limit":         q.Limit,
+			"filter":        filter,
+		}},
+		{"$addFields": map[string]any{"score": map[string]any{"$meta": "vectorSearchScore"}}},
+	}
+
+	cursor, err := r.collection(embeddingCollection).Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []struct {
+		MessageEmbedding `bson:",inline"`
+		Score            float64 `bson:"score"`
+	}
+	if err := cursor.All(ctx, &rows); err != nil {
+		return nil, err
+	}
+
+	results := make([]ScoredEmbedding, len(rows))
+	for i := range rows {
+		// Atlas scores cosine similarity as (1 + cos) / 2; callers compare raw similarities.
+		results[i] = ScoredEmbedding{Embedding: &rows[i].MessageEmbedding, Score: 2*rows[i].Score - 1}
+	}
+	return results, nil
+}
+
+func (r *Repository) CreateAttachment(ctx context.Context, a *Attachment) error {
+	_, err := r.collection(attachmentCollection).InsertOne(ctx, a)
+	return err
+}
+
+// FindAttachment returns an attachment uploaded by a user. Other users' attachments are
+// reported as not found, so their IDs can't be probed.
+func (r *Repository) FindAttachment(ctx context.Context, id, userID string) (*Attachment, error) {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, twirp.NotFoundError("attachment not found")
+	}
+
+	var a Attachment
+	err = r.collection(attachmentCollection).FindOne(ctx, map[string]any{"_id": oid, "user_id": userID}).Decode(&a)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, twirp.NotFoundError("attachment not found")
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &a, nil
+}
+
+// FindUserProfile returns a user's profile, empty if they never saved anything.
+func (r *Repository) FindUserProfile(ctx context.Context, userID string) (*UserProfile, error) {
+	var p UserProfile
+	err := r.collection(userProfileCollection).FindOne(ctx, map[string]any{"_id": userID}).Decode(&p)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return &UserProfile{UserID: userID}, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &p, nil
+}
+
+// SetPreferences saves the preferences set in p, keeping the others, and returns all of them.
+func (r *Repository) SetPreferences(ctx context.Context, userID string, p Preferences) (*Preferences, error) {
+	set := map[string]any{"updated_at": time.Now()}
+	if p.HealthAdvisories != nil {
+		set["preferences.health_advisories"] = *p.HealthAdvisories
+	}
+	if p.Timezone != nil {
+		set["preferences.timezone"] = *p.Timezone
+	}
+
+	var out UserProfile
+	err := r.collection(userProfileCollection).FindOneAndUpdate(ctx, map[string]any{"_id": userID},
+		map[string]any{"$set": set},
+		options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)).Decode(&out)
+	if err != nil {
+		return nil, err
+	}
+
+	return &out.Preferences, nil
+}
+
+// SetDigestSubscription saves a user's digest subscription, or removes it if sub is nil.
+func (r *Repository) SetDigestSubscription(ctx context.Context, userID string, sub *DigestSubscription) error {
+	update := map[string]any{"$set": map[string]any{"digest": sub, "updated_at": time.Now()}}
+	if sub == nil {
+		update = map[string]any{"$unset": map[string]any{"digest": ""}}
+	}
+	_, err := r.collection(userProfileCollection).UpdateOne(ctx, map[string]any{"_id": userID}, update,
+		options.Update().SetUpsert(sub != nil))
+	return err
+}
+
+// ClaimDueDigest returns the profile of a user whose digest is due at now, postponing it by
+// lease so other instances don't send it too, or nil if none is due. The digest is due again
+// after the lease unless FinishDigest reschedules it.
+func (r *Repository) ClaimDueDigest(ctx context.Context, now time.Time, lease time.Duration) (*UserProfile, error) {
+	var p UserProfile
+	err := r.collection(userProfileCollection).FindOneAndUpdate(ctx,
+		map[string]any{"digest.next_at": map[string]any{"$lte": now}},
+		map[string]any{"$set": map[string]any{"digest.next_at": now.Add(lease)}},
+		options.FindOneAndUpdate().SetSort(bson.D{{Key: "digest.next_at", Value: 1}})).Decode(&p)
+
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &p, nil
+}
+
+// FinishDigest records that a user's digest was sent at sentAt and schedules the next one,
+// unless they unsubscribed in the meantime.
+func (r *Repository) FinishDigest(ctx context.Context, userID string, sentAt, next time.Time) error {
+	_, err := r.collection(userProfileCollection).UpdateOne(ctx,
+		map[string]any{"_id": userID, "digest": map[string]any{"$exists": true}},
+		map[string]any{"$set": map[string]any{"digest.last_sent_at": sentAt, "digest.next_at": next}})
+	return err
+}
+
+// ListActiveConversations returns the conversations a user has read that were updated since
+// a time, most recent first, up to limit. Archived conversations are left out.
+func (r *Repository) ListActiveConversations(ctx context.Context, userID string, since time.Time, limit int) ([]*Conversation, error) {
+	filter := archivedFilter(false)
+	filter["reads.user_id"] = userID
+	filter["updated_at"] = map[string]any{"$gte": since}
+
+	cursor, err := r.collection(conversationCollection).Find(ctx, filter,
+		options.Find().SetSort(bson.D{{Key: "updated_at", Value: -1}}).SetLimit(int64(limit)))
+	if err != nil {
+		return nil, err
+	}
+
+	var items []*Conversation
+	if err := cursor.All(ctx, &items); err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+// SetLocationAlias saves a user's alias, replacing the one with the same name. It returns
+// false, without saving, if the user already has limit other aliases.
+func (r *Repository) SetLocationAlias(ctx context.Context, userID string, alias LocationAlias, limit int) (bool, error) {
+	coll := r.collection(userProfileCollection)
+	now := time.Now()
+
+	res, err := coll.UpdateOne(ctx,
+		map[string]any{"_id": userID, "aliases.name": alias.Name},
+		map[string]any{"$set": map[string]any{"aliases.$": alias, "updated_at": now}})
+	if err != nil {
+		return false, err
+	}
+	if res.MatchedCount > 0 {
+		return true, nil
+	}
+
+	// Not saved yet: append it, unless the limit is reached or a concurrent call added it.
+	res, err = coll.UpdateOne(ctx,
+		map[string]any{
+			"_id":                              userID,
+			"aliases.name":                     map[string]any{"$ne": alias.Name},
+			fmt.Sprintf("aliases.%d", limit-1): map[string]any{"$exists": false},
+		},
+		map[string]any{
+			"$push": map[string]any{"aliases": alias},
+			"$set":  map[string]any{"updated_at": now},
+		},
+		options.Update().SetUpsert(true))
+	if mongo.IsDuplicateKeyError(err) {
+		// The profile exists, but either has the alias now or is full.
+		p, err := r.FindUserProfile(ctx, userID)
+		if err != nil {
+			return false, err
+		}
+		if p.Alias(alias.Name) == nil {
+			return false, nil
+		}
+		return r.SetLocationAlias(ctx, userID, alias, limit)
+	}
+	return err == nil, err
+}
+
+// DeleteLocationAlias removes a user's alias.
+func (r *Repository) DeleteLocationAlias(ctx context.Context, userID, name string) error {
+	res, err := r.collection(userProfileCollection).UpdateOne(ctx,
+		map[string]any{"_id": userID, "aliases.name": name},
+		map[string]any{
+			"$pull": map[string]any{"aliases": map[string]any{"name": name}},
+			"$set":  map[string]any{"updated_at": time.Now()},
+		})
+	if err != nil {
+		return err
+	}
+
+	if res.MatchedCount == 0 {
+		return twirp.NotFoundError("location alias not found")
+	}
+
+	return nil
+}
+
+// SetUserCalendar saves a user's calendar, replacing the one with the same name. It returns
+// false, without saving, if the user already has limit other calendars.
+func (r *Repository) SetUserCalendar(ctx context.Context, userID string, cal UserCalendar, limit int) (bool, error) {
+	coll := r.collection(userProfileCollection)
+	now := time.Now()
+
+	res, err := coll.UpdateOne(ctx,
+		map[string]any{"_id": userID, "calendars.name": cal.Name},
+		map[string]any{"$set": map[string]any{"calendars.$": cal, "updated_at": now}})
+	if err != nil {
+		return false, err
+	}
+	if res.MatchedCount > 0 {
+		return true, nil
+	}
+
+	// As in SetLocationAlias: append it, unless the limit is reached or a concurrent call added it.
+	res, err = coll.UpdateOne(ctx,
+		map[string]any{
+			"_id":                                userID,
+			"calendars.name":                     map[string]any{"$ne": cal.Name},
+			fmt.Sprintf("calendars.%d", limit-1): map[string]any{"$exists": false},
+		},
+		map[string]any{
+			"$push": map[string]any{"calendars": cal},
+			"$set":  map[string]any{"updated_at": now},
+		},
+		options.Update().SetUpsert(true))
+	if mongo.IsDuplicateKeyError(err) {
+		p, err := r.FindUserProfile(ctx, userID)
+		if err != nil {
+			return false, err
+		}
+		if p.Calendar(cal.Name) == nil {
+			return false, nil
+		}
+		return r.SetUserCalendar(ctx, userID, cal, limit)
+	}
+	return err == nil, err
+}
+
+// DeleteUserCalendar removes a user's calendar.
+func (r *Repository) DeleteUserCalendar(ctx context.Context, userID, name string) error {
+	res, err := r.collection(userProfileCollection).UpdateOne(ctx,
+		map[string]any{"_id": userID, "calendars.name": name},
+		map[string]any{
+			"$pull": map[string]any{"calendars": map[string]any{"name": name}},
+			"$set":  map[string]any{"updated_at": time.Now()},
+		})
+	if err != nil {
+		return err
+	}
+
+	if res.MatchedCount == 0 {
+		return twirp.NotFoundError("calendar not found")
+	}
+
+	return nil
+}
+
+// SetCalDAVAccount saves a user's CalDAV account, replacing any other.
+func (r *Repository) SetCalDAVAccount(ctx context.Context, userID string, acc CalDAVAccount) error {
+	_, err := r.collection(userProfileCollection).UpdateOne(ctx,
+		map[string]any{"_id": userID},
+		map[string]any{"$set": map[string]any{"caldav": acc, "updated_at": time.Now()}},
+		options.Update().SetUpsert(true))
+	return err
+}
+
+// DeleteCalDAVAccount removes a user's CalDAV account.
+func (r *Repository) DeleteCalDAVAccount(ctx context.Context, userID string) error {
+	res, err := r.collection(userProfileCollection).UpdateOne(ctx,
+		map[string]any{"_id": userID, "caldav": map[string]any{"$exists": true}},
+		map[string]any{
+			"$unset": map[string]any{"caldav": ""},
+			"$set":   map[string]any{"updated_at": time.Now()},
+		})
+	if err != nil {
+		return err
+	}
+
+	if res.MatchedCount == 0 {
+		return twirp.NotFoundError("CalDAV account not found")
+	}
+
+	return nil
+}
+
+// FindConversationIDs returns the IDs of the conversations matching f, oldest first.
+func (r *Repository) FindConversationIDs(ctx context.Context, f ConversationFilter) ([]primitive.ObjectID, error) {
+	cursor, err := r.collection(conversationCollection).Find(ctx, f.query(),
+		options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}).SetProjection(map[string]any{"_id": 1}))
+	if err != nil {
+		return nil, err
+	}
+
+	var docs []struct {
+		ID primitive.ObjectID `bson:"_id"`
+	}
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+
+	ids := make([]primitive.ObjectID, len(docs))
+	for i, d := range docs {
+		ids[i] = d.ID
+	}
+	return ids, nil
+}
+
+// DeleteConversations deletes conversations with the data kept about them elsewhere, and
+// reports how many conversations existed. Their attachments are owned by users, not
+// conversations, and are kept.
+func (r *Repository) DeleteConversations(ctx context.Context, ids []primitive.ObjectID) (int, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	res, err := r.collection(conversationCollection).DeleteMany(ctx, map[string]any{"_id": map[string]any{"$in": ids}})
+	if err != nil {
+		return 0, err
+	}
+
+	// Orphans are harmless but take space; a failure here leaves them for the next delete.
+	related := map[string]any{"conversation_id": map[string]any{"$in": ids}}
+	for _, name := range []string{embeddingCollection, failedGenerationCollection, generationCollection, shadowReplyCollection} {
+		if _, err := r.collection(name).DeleteMany(ctx, related); err != nil {
+			return int(res.DeletedCount), err
+		}
+	}
+
+	return int(res.DeletedCount), nil
+}
+
+// ArchiveConversations archives conversations not archived yet, and reports how many.
+func (r *Repository) ArchiveConversations(ctx context.Context, ids []primitive.ObjectID, at time.Time) (int, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	res, err := r.collection(conversationCollection).UpdateMany(ctx,
+		map[string]any{"_id": map[string]any{"$in": ids}, "archived_at": map[string]any{"$exists": false}},
+		map[string]any{"$set": map[string]any{"archived_at": at}})
+	if err != nil {
+		return 0, err
+	}
+
+	return int(res.ModifiedCount), nil
+}
+
+func (r *Repository) CreateBulkJob(ctx context.Context, j *BulkJob) error {
+	_, err := r.collection(bulkJobCollection).InsertOne(ctx, j)
+	return err
+}
+
+// UpdateBulkJob saves the progress of a job. Its lease is only changed by RenewBulkJobLease.
+func (r *Repository) UpdateBulkJob(ctx context.Context, j *BulkJob) error {
+	_, err := r.collection(bulkJobCollection).UpdateOne(ctx,
+		map[string]any{"_id": j.ID},
+		map[string]any{"$set": map[string]any{
+			"state":        j.State,
+			"count":        j.Count,
+			"error":        j.Error,
+			"download_url": j.DownloadURL,
+			"updated_at":   j.UpdatedAt,
+		}})
+	return err
+}
+
+// RenewBulkJobLease extends the lease of a running job until expiresAt.
+func (r *Repository) RenewBulkJobLease(ctx context.Context, id primitive.ObjectID, expiresAt time.Time) error {
+	_, err := r.collection(bulkJobCollection).UpdateOne(ctx,
+		map[string]any{"_id": id, "state": BulkJobRunning},
+		map[string]any{"$set": map[string]any{"lease_expires_at": expiresAt}})
+	return err
+}
+
+// ClaimLostBulkJob marks one running job whose lease expired before now as failed with
+// cause, and returns it; nil if there is none. Jobs without a lease are lost too.
+func (r *Repository) ClaimLostBulkJob(ctx context.Context, now time.Time, cause string) (*BulkJob, error) {
+	var j BulkJob
+	err := r.collection(bulkJobCollection).FindOneAndUpdate(ctx,
+		map[string]any{"state": BulkJobRunning, "lease_expires_at": map[string]any{"$not": map[string]any{"$gte": now}}},
+		map[string]any{"$set": map[string]any{"state": BulkJobFailed, "error": cause, "updated_at": now}},
+		options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(&j)
+
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &j, nil
+}
+
+// FindBulkJob returns a job started by a user.
+func (r *Repository) FindBulkJob(ctx context.Context, id, userID string) (*BulkJob, error) {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, twirp.NotFoundError("invalid job ID")
+	}
+
+	var j BulkJob
+	err = r.collection(bulkJobCollection).FindOne(ctx, map[string]any{"_id": oid, "user_id": userID}).Decode(&j)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, twirp.NotFoundError("job not found")
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &j, nil
+}
+
+// CreateSession saves a new session.
+func (r *Repository) CreateSession(ctx context.Context, s *Session) error {
+	_, err := r.collection(sessionCollection).InsertOne(ctx, s)
+	return err
+}
+
+// TouchSession records a request of a user with the session whose token has the hash, and
+// returns the session, or nil if the user has no such session.
+func (r *Repository) TouchSession(ctx context.Context, userID, tokenHash, userAgent string, now time.Time) (*Session, error) {
+	set := map[string]any{"last_seen_at": now}
+	if userAgent != "" {
+		set["user_agent"] = userAgent
+	}
+
+	var s Session
+	err := r.collection(sessionCollection).FindOneAndUpdate(ctx,
+		map[string]any{"user_id": userID, "token_hash": tokenHash},
+		map[string]any{"$set": set},
+		options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(&s)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &s, nil
+}
+
+// ListSessions returns the sessions of a user, most recently seen first. Revoked sessions
+// are only included with includeRevoked.
+func (r *Repository) ListSessions(ctx context.Context, userID string, includeRevoked bool) ([]*Session, error) {
+	filter := map[string]any{"user_id": userID}
+	if !includeRevoked {
+		filter["revoked_at"] = map[string]any{"$exists": false}
+	}
+
+	cursor, err := r.collection(sessionCollection).Find(ctx, filter,
+		options.Find().SetSort(bson.D{{Key: "last_seen_at", Value: -1}}))
+	if err != nil {
+		return nil, err
+	}
+
+	var sessions []*Session
+	if err := cursor.All(ctx, &sessions); err != nil {
+		return nil, err
+	}
+	return sessions, nil
+}
+
+// RevokeSession revokes a session of a user and returns it. Revoking a revoked session
+// keeps the time it was first revoked.
+func (r *Repository) RevokeSession(ctx context.Context, id, userID string, at time.Time) (*Session, error) {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, twirp.NotFoundError("invalid session ID")
+	}
+
+	filter := map[string]any{"_id": oid, "user_id": userID}
+	if _, err := r.collection(sessionCollection).UpdateOne(ctx,
+		map[string]any{"_id": oid, "user_id": userID, "revoked_at": map[string]any{"$exists": false}},
+		map[string]any{"$set": map[string]any{"revoked_at": at}}); err != nil {
+		return nil, err
+	}
+
+	var s Session
+	err = r.collection(sessionCollection).FindOne(ctx, filter).Decode(&s)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, twirp.NotFoundError("session not found")
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &s, nil
+}
+
+// FindDailyUsage returns what a user consumed on a UTC day, zero if nothing.
+func (r *Repository) FindDailyUsage(ctx context.Context, userID, day string) (*DailyUsage, error) {
+	u := DailyUsage{ID: dailyUsageID(userID, day), UserID: userID, Day: day}
+	err := r.collection(dailyUsageCollection).FindOne(ctx, map[string]any{"_id": u.ID}).Decode(&u)
+	if err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, err
+	}
+
+	return &u, nil
+}
+
+// ReserveDailyMessage counts a message against what a user consumed on a day, unless they
+// used up one of the limits, zero meaning none; it then counts nothing and returns false.
+// Checking and counting is a single update, so concurrent messages can't exceed them.
+func (r *Repository) ReserveDailyMessage(ctx context.Context, userID, day string, limits DailyUsage) (bool, error) {
+	filter := map[string]any{"_id": dailyUsageID(userID, day)}
+	for field, limit := range map[string]int64{"messages": limits.Messages, "tokens": limits.Tokens, "tool_calls": limits.ToolCalls} {
+		if limit > 0 {
+			filter[field] = map[string]any{"$lt": limit}
+		}
+	}
+	update := map[string]any{
+		"$inc":         map[string]any{"messages": 1},
+		"$setOnInsert": map[string]any{"user_id": userID, "day": day},
+	}
+
+	// Without a match the upsert inserts the day's usage, which fails as a duplicate once
+	// it exists, i.e. when a limit is used up, or when a concurrent message inserted it first.
+	for range 2 {
+		_, err := r.collection(dailyUsageCollection).UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+		if !mongo.IsDuplicateKeyError(err) {
+			return err == nil, err
+		}
+	}
+	return false, nil
+}
+
+// AddDailyUsage adds the messages, tokens and tool calls of u to what its user consumed on
+// its day.
+func (r *Repository) AddDailyUsage(ctx context.Context, u DailyUsage) error {
+	_, err := r.collection(dailyUsageCollection).UpdateOne(ctx,
+		map[string]any{"_id": dailyUsageID(u.UserID, u.Day)},
+		map[string]any{
+			"$inc":         map[string]any{"messages": u.Messages, "tokens": u.Tokens, "tool_calls": u.ToolCalls},
+			"$setOnInsert": map[string]any{"user_id": u.UserID, "day": u.Day},
+		},
+		options.Update().SetUpsert(true))
+	return err
+}
+
+// SaveShadowReply stores a reply of a shadow candidate.
+func (r *Repository) SaveShadowReply(ctx context.Context, reply *ShadowReply) error {
+	_, err := r.collection(shadowReplyCollection).InsertOne(ctx, reply)
+	return err
+}
+
+// SetNotificationSettings saves how a user wants to be notified, replacing their settings.
+func (r *Repository) SetNotificationSettings(ctx context.Context, userID string, settings NotificationSettings) error {
+	_, err := r.collection(userProfileCollection).UpdateOne(ctx,
+		map[string]any{"_id": userID},
+		map[string]any{"$set": map[string]any{"notifications": settings, "updated_at": time.Now()}},
+		options.Update().SetUpsert(true))
+	return err
+}
+
+func (r *Repository) QueueNotification(ctx context.Context, n *QueuedNotification) error {
+	_, err := r.collection(notificationCollection).InsertOne(ctx, n)
+	return err
+}
+
+// ClaimDueNotification returns a queued notification due at now, postponing it by lease and
+// counting the attempt so other instances don't send it too, or nil if none is due. It is due
+// again after the lease unless DeleteNotification removes it.
+func (r *Repository) ClaimDueNotification(ctx context.Context, now time.Time, lease time.Duration) (*QueuedNotification, error) {
+	var n QueuedNotification
+	err := r.collection(notificationCollection).FindOneAndUpdate(ctx,
+		map[string]any{"deliver_at": map[string]any{"$lte": now}},
+		map[string]any{
+			"$set": map[string]any{"deliver_at": now.Add(lease)},
+			"$inc": map[string]any{"attempts": 1},
+		},
+		options.FindOneAndUpdate().SetSort(bson.D{{Key: "deliver_at", Value: 1}}).SetReturnDocument(options.After)).Decode(&n)
+
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &n, nil
+}
+
+func (r *Repository) DeleteNotification(ctx context.Context, id primitive.ObjectID) error {
+	_, err := r.collection(notificationCollection).DeleteOne(ctx, map[string]any{"_id": id})
+	return err
+}
+
+// SetPushDevice registers a device of a user for push notifications, replacing the one with
+// the same token. A token is unregistered from other users, as the device is now the user's,
+// and only the latest limit devices of a user are kept.
+func (r *Repository) SetPushDevice(ctx context.Context, userID string, d PushDevice, limit int) error {
+	coll := r.collection(userProfileCollection)
+	now := time.Now()
+
+	if _, err := coll.UpdateMany(ctx,
+		map[string]any{"push_devices.token": d.Token},
+		map[string]any{
+			"$pull": map[string]any{"push_devices": map[string]any{"token": d.Token}},
+			"$set":  map[string]any{"updated_at": now},
+		}); err != nil {
+		return err
+	}
+
+	_, err := coll.UpdateOne(ctx,
+		map[string]any{"_id": userID},
+		map[string]any{
+			"$push": map[string]any{"push_devices": map[string]any{
+				"$each":  []PushDevice{d},
+				"$sort":  map[string]any{"registered_at": 1},
+				"$slice": -limit,
+			}},
+			"$set": map[string]any{"updated_at": now},
+		},
+		options.Update().SetUpsert(true))
+	return err
+}
+
+// DeletePushDevice unregisters a device of a user by its token.
+func (r *Repository) DeletePushDevice(ctx context.Context, userID, token string) error {
+	res, err := r.collection(userProfileCollection).UpdateOne(ctx,
+		map[string]any{"_id": userID, "push_devices.token": token},
+		map[string]any{
+			"$pull": map[string]any{"push_devices": map[string]any{"token": token}},
+			"$set":  map[string]any{"updated_at": time.Now()},
+		})
+	if err != nil {
+		return err
+	}
+
+	if res.MatchedCount == 0 {
+		return twirp.NotFoundError("push device not found")
+	}
+
+	return nil
+}
+
+// StartPromptRollout returns the rollout with the given name, starting it at now if it is new.
+func (r *Repository) StartPromptRollout(ctx context.Context, name string, now time.Time) (*PromptRollout, error) {
+	var p PromptRollout
+	err := r.collection(promptRolloutCollection).FindOneAndUpdate(ctx,
+		map[string]any{"_id": name},
+		map[string]any{"$setOnInsert": map[string]any{"state": RolloutActive, "started_at": now}},
+		options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)).Decode(&p)
+	if err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// FindPromptRollout returns the rollout with the given name.
+func (r *Repository) FindPromptRollout(ctx context.Context, name string) (*PromptRollout, error) {
+	var p PromptRollout
+	err := r.collection(promptRolloutCollection).FindOne(ctx, map[string]any{"_id": name}).Decode(&p)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, twirp.NotFoundError("prompt rollout not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// AddRolloutSignals adds to the signals of the new version of an active rollout; they are
+// no longer counted once it is decided.
+func (r *Repository) AddRolloutSignals(ctx context.Context, name string, s RolloutSignals) error {
+	_, err := r.collection(promptRolloutCollection).UpdateOne(ctx,
+		map[string]any{"_id": name, "state": RolloutActive},
+		map[string]any{"$inc": map[string]any{
+			"signals.replies":  s.Replies,
+			"signals.errors":   s.Errors,
+			"signals.refusals": s.Refusals,
+			"signals.positive": s.Positive,
+			"signals.negative": s.Negative,
+		}})
+	return err
+}
+
+// DecidePromptRollout ends an active rollout in state, promoted or rolled back, for reason. It
+// reports whether the rollout was still active, as another server may have decided first.
+func (r *Repository) DecidePromptRollout(ctx context.Context, name string, state RolloutState, reason string, at time.Time) (bool, error) {
+	res, err := r.collection(promptRolloutCollection).UpdateOne(ctx,
+		map[string]any{"_id": name, "state": RolloutActive},
+		map[string]any{"$set": map[string]any{"state": state, "reason": reason, "decided_at": at}})
+	if err != nil {
+		return false, err
+	}
+	return res.ModifiedCount > 0, nil
+}
@@ -0,0 +1,57 @@
+package model_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/acai-travel/tech-challenge/internal/chat/model"
+	. "github.com/acai-travel/tech-challenge/internal/chat/testing"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// BenchmarkAppendMessages measures appending a question and its reply, as every turn does.
+// It needs MongoDB, like the fixture tests.
+func BenchmarkAppendMessages(b *testing.B) {
+	for _, n := range []int{1, 100} {
+		b.Run(fmt.Sprintf("history=%d", n), WithBenchFixture(func(b *testing.B, f *Fixture) {
+			ctx := context.Background()
+			history := make([]*model.Message, n)
+			for i := range history {
+				history[i] = turnMessage(model.RoleUser)
+			}
+			conv := f.CreateConversation(func(c *model.Conversation) { c.Messages = history })
+
+			// The conversation is reset to its history every 100 appends, so the benchmark
+			// measures conversations of about n messages rather than ever-growing ones.
+			appended := 0
+			for b.Loop() {
+				if appended == 100 {
+					b.StopTimer()
+					conv.Messages = history
+					if err := f.UpdateConversation(ctx, conv); err != nil {
+						b.Fatal(err)
+					}
+					appended = 0
+					b.StartTimer()
+				}
+				if err := f.AppendMessages(ctx, conv.ID, turnMessage(model.RoleUser), turnMessage(model.RoleAssistant)); err != nil {
+					b.Fatal(err)
+				}
+				appended += 2
+			}
+		}))
+	}
+}
+
+func turnMessage(role model.Role) *model.Message {
+	now := time.Now()
+	return &model.Message{
+		ID:        primitive.NewObjectID(),
+		Role:      role,
+		Content:   "Lisbon will be sunny and around 24°C all week, with a light breeze in the afternoons.",
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
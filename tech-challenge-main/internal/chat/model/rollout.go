@@ -0,0 +1,39 @@
+package model
+
+import "time"
+
+// RolloutState is the stage of a prompt rollout.
+type RolloutState string
+
+const (
+	// RolloutActive rollouts answer with the new version while its signals are watched.
+	RolloutActive RolloutState = "active"
+	// RolloutPromoted rollouts kept the new version through their window.
+	RolloutPromoted RolloutState = "promoted"
+	// RolloutRolledBack rollouts went back to the previous version, as the new one degraded.
+	RolloutRolledBack RolloutState = "rolled_back"
+)
+
+// PromptRollout is the state of a prompt version replacing the previous one, shared by the
+// servers answering with it.
+type PromptRollout struct {
+	// Name identifies the new version, e.g. "travel-prompt-v2".
+	Name      string       `bson:"_id"`
+	State     RolloutState `bson:"state"`
+	StartedAt time.Time    `bson:"started_at"`
+	// Signals are those of the replies of the new version while the rollout was active.
+	Signals   RolloutSignals `bson:"signals"`
+	DecidedAt *time.Time     `bson:"decided_at,omitempty"`
+	// Reason explains the decision, e.g. which signal degraded.
+	Reason string `bson:"reason,omitempty"`
+}
+
+// RolloutSignals count the outcomes of replies: how many there were, failed, were refused by
+// the safety policy, and were rated positively or negatively by their users.
+type RolloutSignals struct {
+	Replies  int64 `bson:"replies"`
+	Errors   int64 `bson:"errors"`
+	Refusals int64 `bson:"refusals"`
+	Positive int64 `bson:"positive"`
+	Negative int64 `bson:"negative"`
+}
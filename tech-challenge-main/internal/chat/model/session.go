@@ -0,0 +1,39 @@
+package model
+
+import (
+	"time"
+
+	"github.com/acai-travel/tech-challenge/internal/pb"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Session is a session a user started on a device, as the client identified it. Its requests
+// carry the token the server issued, which is kept hashed.
+type Session struct {
+	ID        primitive.ObjectID `bson:"_id"`
+	UserID    string             `bson:"user_id"`
+	DeviceID  string             `bson:"device_id"`
+	TokenHash string             `bson:"token_hash"`
+	// UserAgent is the User-Agent header of the latest request.
+	UserAgent  string    `bson:"user_agent,omitempty"`
+	CreatedAt  time.Time `bson:"created_at"`
+	LastSeenAt time.Time `bson:"last_seen_at"`
+	// RevokedAt is set once the session is revoked; requests with its token are then
+	// rejected.
+	RevokedAt *time.Time `bson:"revoked_at,omitempty"`
+}
+
+func (s *Session) Proto() *pb.Session {
+	proto := &pb.Session{
+		Id:         s.ID.Hex(),
+		DeviceId:   s.DeviceID,
+		UserAgent:  s.UserAgent,
+		CreatedAt:  timestamppb.New(s.CreatedAt),
+		LastSeenAt: timestamppb.New(s.LastSeenAt),
+	}
+	if s.RevokedAt != nil {
+		proto.RevokedAt = timestamppb.New(*s.RevokedAt)
+	}
+	return proto
+}
@@ -0,0 +1,27 @@
+package model
+
+import "github.com/acai-travel/tech-challenge/internal/pb"
+
+// GenerationSettings overrides how the assistant generates replies in a conversation.
+type GenerationSettings struct {
+	Model           string   `bson:"model,omitempty"`
+	Temperature     *float64 `bson:"temperature,omitempty"`
+	MaxOutputTokens int64    `bson:"max_output_tokens,omitempty"`
+	// MaxCostUSD and MaxTotalTokens budget the whole conversation; 0 means no limit.
+	MaxCostUSD     float64 `bson:"max_cost_usd,omitempty"`
+	MaxTotalTokens int64   `bson:"max_total_tokens,omitempty"`
+}
+
+func (s *GenerationSettings) Proto() *pb.GenerationSettings {
+	if s == nil {
+		return nil
+	}
+
+	return &pb.GenerationSettings{
+		Model:           s.Model,
+		Temperature:     s.Temperature,
+		MaxOutputTokens: s.MaxOutputTokens,
+		MaxCostUsd:      s.MaxCostUSD,
+		MaxTotalTokens:  s.MaxTotalTokens,
+	}
+}
@@ -0,0 +1,39 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ShadowReply is a reply a shadow candidate generated to a user message alongside the
+// reply the user got, so a prompt or model change can be evaluated on real traffic. Users
+// never see it.
+type ShadowReply struct {
+	ID primitive.ObjectID `bson:"_id"`
+	// Candidate names the prompt or model change, e.g. "travel-prompt-v2".
+	Candidate      string             `bson:"candidate"`
+	ConversationID primitive.ObjectID `bson:"conversation_id"`
+	// MessageID is the user message replied to.
+	MessageID primitive.ObjectID `bson:"message_id"`
+	UserID    string             `bson:"user_id"`
+	CreatedAt time.Time          `bson:"created_at"`
+
+	Reply     string      `bson:"reply,omitempty"`
+	Error     string      `bson:"error,omitempty"`
+	ToolCalls []*ToolCall `bson:"tool_calls,omitempty"`
+	Usage     Usage       `bson:"usage"`
+	LatencyMs int64       `bson:"latency_ms"`
+
+	// Primary is the reply the user got, to compare with.
+	Primary ShadowBaseline `bson:"primary"`
+}
+
+// ShadowBaseline is the reply a ShadowReply is compared with.
+type ShadowBaseline struct {
+	Reply     string      `bson:"reply,omitempty"`
+	Error     string      `bson:"error,omitempty"`
+	ToolCalls []*ToolCall `bson:"tool_calls,omitempty"`
+	Usage     Usage       `bson:"usage"`
+	LatencyMs int64       `bson:"latency_ms"`
+}
@@ -0,0 +1,45 @@
+package model
+
+import "github.com/acai-travel/tech-challenge/internal/pb"
+
+// Usage is what generating replies consumed, for a whole conversation or a single reply.
+type Usage struct {
+	PromptTokens     int64 `bson:"prompt_tokens"`
+	CompletionTokens int64 `bson:"completion_tokens"`
+	// CostUSD is estimated from list prices; models without a known price add nothing.
+	CostUSD float64 `bson:"cost_usd"`
+	// LimitedReplies counts replies generated after the budget was spent.
+	LimitedReplies int64 `bson:"limited_replies"`
+}
+
+// Tokens is the number of prompt and completion tokens used.
+func (u Usage) Tokens() int64 {
+	return u.PromptTokens + u.CompletionTokens
+}
+
+// Add adds the usage of o to u.
+func (u *Usage) Add(o Usage) {
+	u.PromptTokens += o.PromptTokens
+	u.CompletionTokens += o.CompletionTokens
+	u.CostUSD += o.CostUSD
+	u.LimitedReplies += o.LimitedReplies
+}
+
+// OverBudget reports whether the conversation has spent the budget in its settings.
+func (c *Conversation) OverBudget() bool {
+	s := c.Settings
+	if s == nil {
+		return false
+	}
+	return (s.MaxCostUSD > 0 && c.Usage.CostUSD >= s.MaxCostUSD) ||
+		(s.MaxTotalTokens > 0 && c.Usage.Tokens() >= s.MaxTotalTokens)
+}
+
+func (c *Conversation) usageProto() *pb.Conversation_Usage {
+	return &pb.Conversation_Usage{
+		PromptTokens:     c.Usage.PromptTokens,
+		CompletionTokens: c.Usage.CompletionTokens,
+		CostUsd:          c.Usage.CostUSD,
+		OverBudget:       c.OverBudget(),
+	}
+}
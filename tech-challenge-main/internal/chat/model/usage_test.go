@@ -0,0 +1,27 @@
+package model_test
+
+import (
+	"testing"
+
+	"github.com/acai-travel/tech-challenge/internal/chat/model"
+)
+
+func TestConversationOverBudget(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings *model.GenerationSettings
+		usage    model.Usage
+		want     bool
+	}{
+		{name: "no budget", usage: model.Usage{CostUSD: 100}},
+		{name: "under cost budget", settings: &model.GenerationSettings{MaxCostUSD: 1}, usage: model.Usage{CostUSD: 0.4}},
+		{name: "cost budget spent", settings: &model.GenerationSettings{MaxCostUSD: 1}, usage: model.Usage{CostUSD: 1.2}, want: true},
+		{name: "token budget spent", settings: &model.GenerationSettings{MaxTotalTokens: 1000}, usage: model.Usage{PromptTokens: 800, CompletionTokens: 200}, want: true},
+	}
+	for _, tt := range tests {
+		conv := &model.Conversation{Settings: tt.settings, Usage: tt.usage}
+		if got := conv.OverBudget(); got != tt.want {
+			t.Errorf("%s: OverBudget() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,483 @@
+package chat
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/mail"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/acai-travel/tech-challenge/internal/auth"
+	"github.com/acai-travel/tech-challenge/internal/chat/model"
+	"github.com/acai-travel/tech-challenge/internal/pb"
+	"github.com/acai-travel/tech-challenge/internal/textx"
+	"github.com/twitchtv/twirp"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	// notificationLease is how long a claimed notification waits for another attempt if
+	// sending fails.
+	notificationLease = 10 * time.Minute
+	// notificationInterval is how often RunNotifications looks for due notifications.
+	notificationInterval = time.Minute
+	// maxNotificationAttempts bounds the attempts at sending a queued notification.
+	maxNotificationAttempts = 5
+	// notificationTag marks the conversations notifications are delivered in.
+	notificationTag = "notification"
+	// conversationChannel is the name of the built-in channel posting notifications as messages.
+	conversationChannel = "conversation"
+)
+
+// Kinds of notifications, which users route to channels.
+const (
+	// NotifyReplyReady is sent when a reply finishes after the user left.
+	NotifyReplyReady = "reply_ready"
+	// NotifyDigest is sent when a digest is delivered as a conversation.
+	NotifyDigest = "digest"
+)
+
+var notificationKinds = []string{NotifyReplyReady, NotifyDigest}
+
+// defaultNotificationSettings apply to users who never chose: everything is posted as a
+// message, but finished replies and digests, which are already in their conversation.
+var defaultNotificationSettings = model.NotificationSettings{
+	Channels: []string{conversationChannel},
+	Routes: []model.NotificationRoute{
+		{Kind: NotifyReplyReady, Channels: []string{}},
+		{Kind: NotifyDigest, Channels: []string{}},
+	},
+}
+
+// Notification is something to tell a user outside of a request of theirs.
+type Notification struct {
+	Kind  string `json:"kind"`
+	Title string `json:"title"`
+	Body  string `json:"body"`
+	// ConversationID is the conversation it is about, if any.
+	ConversationID string    `json:"conversation_id,omitempty"`
+	CreatedAt      time.Time `json:"created_at"`
+	// Urgent notifications are sent during quiet hours too.
+	Urgent bool `json:"urgent,omitempty"`
+}
+
+// Recipient is the user a channel notifies.
+type Recipient struct {
+	UserID string
+	// Email is the user's address, empty if unknown.
+	Email string
+}
+
+// NotificationChannel sends notifications to users, e.g. by email.
+type NotificationChannel interface {
+	// Name identifies the channel in users' settings, e.g. "email".
+	Name() string
+	Notify(ctx context.Context, to Recipient, n *Notification) error
+}
+
+type notifications struct {
+	channels map[string]NotificationChannel
+	// names are the channel names in the order they were enabled, the conversation one first.
+	names []string
+}
+
+// EnableNotifications lets users be notified of digests and replies that finish after they
+// left, through the in-conversation channel, the push one with EnablePush, and channels, as
+// their settings route each kind. RunNotifications sends those held back by quiet hours.
+func (s *Server) EnableNotifications(channels ...NotificationChannel) {
+	n := &notifications{channels: map[string]NotificationChannel{}}
+	n.add(&conversationNotifier{repo: s.repo})
+	if s.push != nil {
+		n.add(s.push)
+	}
+	for _, c := range channels {
+		n.add(c)
+	}
+	s.notifications = n
+}
+
+func (n *notifications) add(c NotificationChannel) {
+	if _, ok := n.channels[c.Name()]; ok {
+		panic(fmt.Sprintf("notification channel %q enabled twice", c.Name()))
+	}
+	n.channels[c.Name()] = c
+	n.names = append(n.names, c.Name())
+}
+
+var errNotificationsDisabled = twirp.NewError(twirp.Unimplemented, "notifications are not enabled")
+
+// Notify sends n to a user through the channels their settings route its kind to. Unless n
+// is urgent, it waits for the end of the user's quiet hours on every channel but the
+// conversation one. Channels that fail are retried by RunNotifications. It does nothing
+// unless notifications are enabled.
+func (s *Server) Notify(ctx context.Context, userID string, n Notification) error {
+	if s.notifications == nil {
+		return nil
+	}
+	now := time.Now()
+	if n.CreatedAt.IsZero() {
+		n.CreatedAt = now
+	}
+
+	p, err := s.repo.FindUserProfile(ctx, userID)
+	if err != nil {
+		return err
+	}
+	settings := notificationSettingsOf(p)
+
+	var send, held []string
+	until, quiet := quietUntil(settings.QuietHours, now)
+	for _, name := range settings.ChannelsOf(n.Kind) {
+		switch {
+		case s.notifications.channels[name] == nil:
+			// E.g. a channel the server no longer enables.
+			slog.DebugContext(ctx, "Skipping disabled notification channel", "channel", name)
+		case quiet && !n.Urgent && name != conversationChannel:
+			held = append(held, name)
+		default:
+			send = append(send, name)
+		}
+	}
+
+	if len(held) > 0 {
+		if err := s.queueNotification(ctx, userID, held, &n, until, 0); err != nil {
+			return err
+		}
+	}
+	if failed := s.sendNotification(ctx, recipientOf(p, settings), send, &n); len(failed) > 0 {
+		return s.queueNotification(ctx, userID, failed, &n, now.Add(notificationLease), 1)
+	}
+	return nil
+}
+
+// sendNotification sends n to the channels named and returns those that failed.
+func (s *Server) sendNotification(ctx context.Context, to Recipient, channels []string, n *Notification) []string {
+	var failed []string
+	for _, name := range channels {
+		if err := s.notifications.channels[name].Notify(ctx, to, n); err != nil {
+			slog.WarnContext(ctx, "Failed to send notification", "channel", name, "user_id", to.UserID, "kind", n.Kind, "error", err)
+			failed = append(failed, name)
+		}
+	}
+	return failed
+}
+
+func (s *Server) queueNotification(ctx context.Context, userID string, channels []string, n *Notification, at time.Time, attempts int) error {
+	return s.repo.QueueNotification(ctx, &model.QueuedNotification{
+		ID:             primitive.NewObjectID(),
+		UserID:         userID,
+		Channels:       channels,
+		Kind:           n.Kind,
+		Title:          n.Title,
+		Body:           n.Body,
+		ConversationID: n.ConversationID,
+		CreatedAt:      n.CreatedAt,
+		DeliverAt:      at,
+		Attempts:       attempts,
+	})
+}
+
+// RunNotifications sends the notifications held back by quiet hours or failed channels once
+// they are due, then checks again every minute until ctx is done. It does nothing unless
+// notifications are enabled. Instances claim notifications before sending them, so it may
+// run on all of them.
+func (s *Server) RunNotifications(ctx context.Context) {
+	if s.notifications == nil {
+		return
+	}
+	for {
+		n, err := s.sendDueNotifications(ctx, time.Now())
+		if err != nil && ctx.Err() == nil {
+			slog.ErrorContext(ctx, "Failed to send notifications", "error", err)
+		}
+		if n > 0 {
+			slog.InfoContext(ctx, "Sent queued notifications", "count", n)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(notificationInterval):
+		}
+	}
+}
+
+// sendDueNotifications sends every queued notification due at now and reports how many it
+// sent. The channels that fail are retried after notificationLease, up to
+// maxNotificationAttempts times.
+func (s *Server) sendDueNotifications(ctx context.Context, now time.Time) (int, error) {
+	sent := 0
+	for {
+		q, err := s.repo.ClaimDueNotification(ctx, now, notificationLease)
+		if err != nil || q == nil {
+			return sent, err
+		}
+
+		p, err := s.repo.FindUserProfile(ctx, q.UserID)
+		if err != nil {
+			return sent, err
+		}
+		n := &Notification{Kind: q.Kind, Title: q.Title, Body: q.Body, ConversationID: q.ConversationID, CreatedAt: q.CreatedAt}
+
+		var enabled []string
+		for _, name := range q.Channels {
+			if s.notifications.channels[name] != nil {
+				enabled = append(enabled, name)
+			}
+		}
+		failed := s.sendNotification(ctx, recipientOf(p, notificationSettingsOf(p)), enabled, n)
+		if len(failed) > 0 && q.Attempts < maxNotificationAttempts {
+			// Requeued before deleting: a crash in between sends it twice rather than never.
+			if err := s.queueNotification(ctx, q.UserID, failed, n, now.Add(notificationLease), q.Attempts); err != nil {
+				return sent, err
+			}
+		} else if len(failed) > 0 {
+			slog.WarnContext(ctx, "Giving up on notification", "user_id", q.UserID, "kind", q.Kind, "channels", failed)
+		}
+		if err := s.repo.DeleteNotification(ctx, q.ID); err != nil {
+			return sent, err
+		}
+		if len(failed) < len(enabled) {
+			sent++
+		}
+	}
+}
+
+func notificationSettingsOf(p *model.UserProfile) *model.NotificationSettings {
+	if p.Notifications != nil {
+		return p.Notifications
+	}
+	return &defaultNotificationSettings
+}
+
+func recipientOf(p *model.UserProfile, settings *model.NotificationSettings) Recipient {
+	to := Recipient{UserID: p.UserID, Email: settings.Email}
+	if to.Email == "" && isEmailAddress(p.UserID) {
+		// As for users of the email channel.
+		to.Email = p.UserID
+	}
+	return to
+}
+
+func isEmailAddress(s string) bool {
+	a, err := mail.ParseAddress(s)
+	return err == nil && a.Address == s
+}
+
+// quietUntil reports whether t falls within the quiet hours q, and when they end.
+func quietUntil(q *model.QuietHours, t time.Time) (time.Time, bool) {
+	if q == nil {
+		return time.Time{}, false
+	}
+	loc, err := time.LoadLocation(q.Timezone)
+	start, serr := time.Parse("15:04", q.Start)
+	end, eerr := time.Parse("15:04", q.End)
+	if err != nil || serr != nil || eerr != nil {
+		return time.Time{}, false
+	}
+
+	local := t.In(loc)
+	y, m, d := local.Date()
+	at := func(day int, clock time.Time) time.Time {
+		return time.Date(y, m, day, clock.Hour(), clock.Minute(), 0, 0, loc)
+	}
+	// Today's quiet hours, or yesterday's when they span midnight.
+	for _, day := range []int{d, d - 1} {
+		from, to := at(day, start), at(day, end)
+		if !to.After(from) {
+			to = at(day+1, end)
+		}
+		if !local.Before(from) && local.Before(to) {
+			return to, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// validateNotificationSettings checks settings from a request against the channels enabled.
+func (n *notifications) validateNotificationSettings(in *pb.NotificationSettings, userID string) (model.NotificationSettings, error) {
+	if in == nil {
+		return model.NotificationSettings{}, twirp.RequiredArgumentError("settings")
+	}
+
+	var usesEmail bool
+	channels := func(field string, names []string) ([]string, error) {
+		out := []string{}
+		for _, name := range names {
+			name = strings.ToLower(strings.TrimSpace(name))
+			if n.channels[name] == nil {
+				return nil, twirp.InvalidArgumentError(field, "must be among: "+strings.Join(n.names, ", "))
+			}
+			if !slices.Contains(out, name) {
+				out = append(out, name)
+			}
+			usesEmail = usesEmail || name == "email"
+		}
+		return out, nil
+	}
+
+	var settings model.NotificationSettings
+	var err error
+	if settings.Channels, err = channels("settings.channels", in.GetChannels()); err != nil {
+		return settings, err
+	}
+	for _, r := range in.GetRoutes() {
+		kind := strings.ToLower(strings.TrimSpace(r.GetKind()))
+		if !slices.Contains(notificationKinds, kind) {
+			return settings, twirp.InvalidArgumentError("settings.routes.kind", "must be one of: "+strings.Join(notificationKinds, ", "))
+		}
+		if slices.ContainsFunc(settings.Routes, func(r model.NotificationRoute) bool { return r.Kind == kind }) {
+			return settings, twirp.InvalidArgumentError("settings.routes.kind", fmt.Sprintf("%q is routed twice", kind))
+		}
+		route := model.NotificationRoute{Kind: kind}
+		if route.Channels, err = channels("settings.routes.channels", r.GetChannels()); err != nil {
+			return settings, err
+		}
+		settings.Routes = append(settings.Routes, route)
+	}
+
+	settings.Email = strings.TrimSpace(in.GetEmail())
+	switch {
+	case settings.Email != "" && (len(settings.Email) > 254 || !isEmailAddress(settings.Email)):
+		return settings, twirp.InvalidArgumentError("settings.email", "must be an email address")
+	case usesEmail && settings.Email == "" && !isEmailAddress(userID):
+		return settings, twirp.RequiredArgumentError("settings.email")
+	}
+
+	if q := in.GetQuietHours(); q != nil {
+		quiet := &model.QuietHours{Start: strings.TrimSpace(q.GetStart()), End: strings.TrimSpace(q.GetEnd()), Timezone: strings.TrimSpace(q.GetTimezone())}
+		if _, err := time.Parse("15:04", quiet.Start); err != nil {
+			return settings, twirp.InvalidArgumentError("settings.quiet_hours.start", "must be a time as HH:MM")
+		}
+		if _, err := time.Parse("15:04", quiet.End); err != nil {
+			return settings, twirp.InvalidArgumentError("settings.quiet_hours.end", "must be a time as HH:MM")
+		}
+		if quiet.Start == quiet.End {
+			return settings, twirp.InvalidArgumentError("settings.quiet_hours.end", "must differ from the start")
+		}
+		if _, err := time.LoadLocation(quiet.Timezone); err != nil {
+			return settings, twirp.InvalidArgumentError("settings.quiet_hours.timezone", "must be an IANA time zone, e.g. Europe/Madrid")
+		}
+		settings.QuietHours = quiet
+	}
+	return settings, nil
+}
+
+func (s *Server) SetNotificationSettings(ctx context.Context, req *pb.SetNotificationSettingsRequest) (*pb.SetNotificationSettingsResponse, error) {
+	if s.notifications == nil {
+		return nil, errNotificationsDisabled
+	}
+	userID := auth.User(ctx)
+	if userID == auth.Anonymous {
+		return nil, twirp.NewError(twirp.Unauthenticated, "notifications require an identified user")
+	}
+
+	settings, err := s.notifications.validateNotificationSettings(req.GetSettings(), userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.SetNotificationSettings(ctx, userID, settings); err != nil {
+		return nil, twirp.InternalErrorWith(err)
+	}
+
+	return &pb.SetNotificationSettingsResponse{Settings: settings.Proto()}, nil
+}
+
+func (s *Server) GetNotificationSettings(ctx context.Context, _ *pb.GetNotificationSettingsRequest) (*pb.GetNotificationSettingsResponse, error) {
+	if s.notifications == nil {
+		return nil, errNotificationsDisabled
+	}
+
+	p, err := s.repo.FindUserProfile(ctx, auth.User(ctx))
+	if err != nil {
+		return nil, twirp.InternalErrorWith(err)
+	}
+
+	return &pb.GetNotificationSettingsResponse{
+		Settings:          notificationSettingsOf(p).Proto(),
+		AvailableChannels: s.notifications.names,
+	}, nil
+}
+
+// notifyReplyReady tells the user a reply in conv finished after they left.
+func (s *Server) notifyReplyReady(ctx context.Context, conv *model.Conversation, answer *model.Message) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 30*time.Second)
+	defer cancel()
+
+	err := s.Notify(ctx, auth.User(ctx), Notification{
+		Kind:           NotifyReplyReady,
+		Title:          conv.Title,
+		Body:           textx.Truncate(strings.Join(strings.Fields(answer.Content), " "), 160),
+		ConversationID: conv.ID.Hex(),
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to notify of a finished reply", "conversation_id", conv.ID.Hex(), "error", err)
+	}
+}
+
+// conversationNotifier posts notifications as assistant messages, in a new conversation
+// tagged notificationTag that only the recipient sees.
+type conversationNotifier struct {
+	repo *model.Repository
+}
+
+func (c *conversationNotifier) Name() string { return conversationChannel }
+
+func (c *conversationNotifier) Notify(ctx context.Context, to Recipient, n *Notification) error {
+	if n.ConversationID != "" {
+		// What it tells of is already in that conversation, e.g. the reply or the digest.
+		return nil
+	}
+
+	now := time.Now()
+	return c.repo.CreateConversation(ctx, &model.Conversation{
+		ID:        primitive.NewObjectID(),
+		Title:     n.Title,
+		CreatedAt: now,
+		UpdatedAt: now,
+		Messages: []*model.Message{{
+			ID:        primitive.NewObjectID(),
+			Role:      model.RoleAssistant,
+			Content:   n.Body,
+			CreatedAt: now,
+			UpdatedAt: now,
+		}},
+		Tags:  []string{notificationTag},
+		Owner: to.UserID,
+	})
+}
+
+// Notify posts the notification with the ID of the user it is for.
+func (n *WebhookNotifier) Notify(ctx context.Context, to Recipient, notification *Notification) error {
+	return n.post(ctx, map[string]any{"user_id": to.UserID, "notification": notification})
+}
+
+func (n *WebhookNotifier) Name() string { return "webhook" }
+
+// EmailNotifier sends notifications by email from an address.
+type EmailNotifier struct {
+	from   string
+	sender MailSender
+}
+
+func NewEmailNotifier(from string, sender MailSender) *EmailNotifier {
+	return &EmailNotifier{from: from, sender: sender}
+}
+
+func (e *EmailNotifier) Name() string { return "email" }
+
+func (e *EmailNotifier) Notify(ctx context.Context, to Recipient, n *Notification) error {
+	if to.Email == "" {
+		return errors.New("the user has no email address")
+	}
+	return e.sender.SendMail(ctx, &OutgoingEmail{
+		From:      e.from,
+		To:        to.Email,
+		Subject:   n.Title,
+		MessageID: newMessageID(e.from),
+		Body:      n.Body,
+	})
+}
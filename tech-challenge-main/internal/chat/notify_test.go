@@ -0,0 +1,260 @@
+package chat
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/acai-travel/tech-challenge/internal/auth"
+	"github.com/acai-travel/tech-challenge/internal/chat/model"
+	. "github.com/acai-travel/tech-challenge/internal/chat/testing"
+	"github.com/acai-travel/tech-challenge/internal/pb"
+	"github.com/twitchtv/twirp"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type fakeNotificationChannel struct {
+	name string
+	sent map[string][]Notification
+	err  error
+}
+
+func (f *fakeNotificationChannel) Name() string { return f.name }
+
+func (f *fakeNotificationChannel) Notify(_ context.Context, to Recipient, n *Notification) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.sent[to.UserID] = append(f.sent[to.UserID], *n)
+	return nil
+}
+
+func TestQuietUntil(t *testing.T) {
+	madrid, _ := time.LoadLocation("Europe/Madrid")
+	night := &model.QuietHours{Start: "22:00", End: "07:00", Timezone: "Europe/Madrid"}
+	lunch := &model.QuietHours{Start: "13:00", End: "14:30"}
+
+	for _, tt := range []struct {
+		name  string
+		q     *model.QuietHours
+		at    time.Time
+		until time.Time
+	}{
+		{"before midnight", night, time.Date(2025, 10, 14, 23, 0, 0, 0, madrid), time.Date(2025, 10, 15, 7, 0, 0, 0, madrid)},
+		{"after midnight", night, time.Date(2025, 10, 15, 3, 0, 0, 0, madrid), time.Date(2025, 10, 15, 7, 0, 0, 0, madrid)},
+		{"at the start", night, time.Date(2025, 10, 14, 22, 0, 0, 0, madrid), time.Date(2025, 10, 15, 7, 0, 0, 0, madrid)},
+		{"in another zone", night, time.Date(2025, 10, 14, 21, 30, 0, 0, time.UTC), time.Date(2025, 10, 15, 7, 0, 0, 0, madrid)},
+		{"at the end", night, time.Date(2025, 10, 15, 7, 0, 0, 0, madrid), time.Time{}},
+		{"daytime", night, time.Date(2025, 10, 15, 12, 0, 0, 0, madrid), time.Time{}},
+		{"within the day, in UTC", lunch, time.Date(2025, 10, 15, 13, 15, 0, 0, time.UTC), time.Date(2025, 10, 15, 14, 30, 0, 0, time.UTC)},
+		{"outside the day", lunch, time.Date(2025, 10, 15, 15, 0, 0, 0, time.UTC), time.Time{}},
+		{"none", nil, time.Date(2025, 10, 15, 3, 0, 0, 0, madrid), time.Time{}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			until, quiet := quietUntil(tt.q, tt.at)
+			if quiet != !tt.until.IsZero() || !until.Equal(tt.until) {
+				t.Errorf("quietUntil(%v) = %v, %v, want %v", tt.at, until, quiet, tt.until)
+			}
+		})
+	}
+}
+
+func TestValidateNotificationSettings(t *testing.T) {
+	srv := &Server{}
+	srv.EnableNotifications(&fakeNotificationChannel{name: "email"}, &fakeNotificationChannel{name: "push"})
+
+	got, err := srv.notifications.validateNotificationSettings(&pb.NotificationSettings{
+		Channels:   []string{"Conversation", " push ", "push"},
+		Routes:     []*pb.NotificationRoute{{Kind: "digest", Channels: []string{"email"}}},
+		QuietHours: &pb.QuietHours{Start: "22:00", End: "07:00", Timezone: "Europe/Madrid"},
+	}, "alice@example.com")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got.Channels) != 2 || got.Channels[0] != "conversation" || got.Channels[1] != "push" {
+		t.Errorf("channels = %v, want them normalized once each", got.Channels)
+	}
+	if c := got.ChannelsOf(NotifyDigest); len(c) != 1 || c[0] != "email" {
+		t.Errorf("digests go to %v, want email", c)
+	}
+	if c := got.ChannelsOf(NotifyReplyReady); len(c) != 2 {
+		t.Errorf("finished replies go to %v, want the default channels", c)
+	}
+
+	for name, tt := range map[string]struct {
+		in     *pb.NotificationSettings
+		userID string
+	}{
+		"missing":           {nil, "alice@example.com"},
+		"unknown channel":   {&pb.NotificationSettings{Channels: []string{"sms"}}, "alice@example.com"},
+		"unknown kind":      {&pb.NotificationSettings{Routes: []*pb.NotificationRoute{{Kind: "reminder"}}}, "alice@example.com"},
+		"routed twice":      {&pb.NotificationSettings{Routes: []*pb.NotificationRoute{{Kind: "digest"}, {Kind: "digest"}}}, "alice@example.com"},
+		"invalid email":     {&pb.NotificationSettings{Email: "alice"}, "alice@example.com"},
+		"email without one": {&pb.NotificationSettings{Channels: []string{"email"}}, "alice"},
+		"invalid start":     {&pb.NotificationSettings{QuietHours: &pb.QuietHours{Start: "10pm", End: "07:00"}}, "alice"},
+		"empty period":      {&pb.NotificationSettings{QuietHours: &pb.QuietHours{Start: "07:00", End: "07:00"}}, "alice"},
+		"unknown timezone":  {&pb.NotificationSettings{QuietHours: &pb.QuietHours{Start: "22:00", End: "07:00", Timezone: "Mars/Olympus"}}, "alice"},
+	} {
+		if _, err := srv.notifications.validateNotificationSettings(tt.in, tt.userID); err == nil {
+			t.Errorf("%s: expected an error", name)
+		} else if te, ok := err.(twirp.Error); !ok || te.Code() != twirp.InvalidArgument {
+			t.Errorf("%s: expected InvalidArgument, got %v", name, err)
+		}
+	}
+}
+
+func TestNotificationChannels(t *testing.T) {
+	n := &Notification{Kind: NotifyReplyReady, Title: "Trip to Lisbon", Body: "Here's your plan for Lisbon.", CreatedAt: time.Now()}
+	to := Recipient{UserID: "alice", Email: "alice@example.com"}
+
+	t.Run("webhook", func(t *testing.T) {
+		var got struct {
+			UserID       string       `json:"user_id"`
+			Notification Notification `json:"notification"`
+		}
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewDecoder(r.Body).Decode(&got)
+		}))
+		defer srv.Close()
+
+		if err := NewWebhookNotifier(srv.URL).Notify(context.Background(), to, n); err != nil {
+			t.Fatal(err)
+		}
+		if got.UserID != "alice" || got.Notification.Kind != NotifyReplyReady || got.Notification.Body != n.Body {
+			t.Errorf("webhook received %+v", got)
+		}
+	})
+
+	t.Run("email", func(t *testing.T) {
+		sender := &fakeMailSender{}
+		email := NewEmailNotifier("assistant@example.com", sender)
+		if err := email.Notify(context.Background(), to, n); err != nil {
+			t.Fatal(err)
+		}
+		if len(sender.sent) != 1 || sender.sent[0].To != "alice@example.com" || sender.sent[0].Subject != "Trip to Lisbon" || sender.sent[0].Body != n.Body {
+			t.Errorf("sent %+v", sender.sent)
+		}
+		if err := email.Notify(context.Background(), Recipient{UserID: "bob"}, n); err == nil {
+			t.Error("expected an error without an address")
+		}
+	})
+}
+
+func TestServer_Notify(t *testing.T) {
+	t.Run("routes and holds back during quiet hours", WithFixture(func(t *testing.T, f *Fixture) {
+		user := "alice-" + primitive.NewObjectID().Hex()
+		ctx := auth.WithUser(context.Background(), user)
+		webhook := &fakeNotificationChannel{name: "webhook", sent: map[string][]Notification{}}
+		srv := NewServer(f.Repository, &fakeAssistant{})
+		srv.EnableNotifications(webhook)
+
+		out, err := srv.GetNotificationSettings(ctx, &pb.GetNotificationSettingsRequest{})
+		if err != nil || len(out.GetSettings().GetChannels()) != 1 || len(out.GetAvailableChannels()) != 2 {
+			t.Fatalf("GetNotificationSettings = %v, %v, want the defaults", out, err)
+		}
+
+		if _, err := srv.SetNotificationSettings(ctx, &pb.SetNotificationSettingsRequest{Settings: &pb.NotificationSettings{
+			Channels: []string{"webhook"},
+			Routes:   []*pb.NotificationRoute{{Kind: NotifyDigest}},
+		}}); err != nil {
+			t.Fatalf("SetNotificationSettings error: %v", err)
+		}
+		if err := srv.Notify(ctx, user, Notification{Kind: NotifyReplyReady, Title: "Trip", Body: "Here's your plan."}); err != nil {
+			t.Fatal(err)
+		}
+		if err := srv.Notify(ctx, user, Notification{Kind: NotifyDigest, Title: "Digest", Body: "Your week in travel."}); err != nil {
+			t.Fatal(err)
+		}
+		if got := webhook.sent[user]; len(got) != 1 || got[0].Kind != NotifyReplyReady {
+			t.Fatalf("webhook got %+v, want only the finished reply", got)
+		}
+
+		// Quiet from an hour ago to an hour from now, whatever the time of the run.
+		now := time.Now().UTC()
+		if _, err := srv.SetNotificationSettings(ctx, &pb.SetNotificationSettingsRequest{Settings: &pb.NotificationSettings{
+			Channels:   []string{"webhook"},
+			QuietHours: &pb.QuietHours{Start: now.Add(-time.Hour).Format("15:04"), End: now.Add(time.Hour).Format("15:04")},
+		}}); err != nil {
+			t.Fatalf("SetNotificationSettings error: %v", err)
+		}
+		delete(webhook.sent, user)
+		if err := srv.Notify(ctx, user, Notification{Kind: NotifyReplyReady, Title: "Pack", Body: "Pack for Lisbon."}); err != nil {
+			t.Fatal(err)
+		}
+		if err := srv.Notify(ctx, user, Notification{Kind: NotifyDigest, Title: "Digest", Body: "Your week in travel.", Urgent: true}); err != nil {
+			t.Fatal(err)
+		}
+		if got := webhook.sent[user]; len(got) != 1 || got[0].Kind != NotifyDigest {
+			t.Fatalf("webhook got %+v, want only the urgent digest during quiet hours", got)
+		}
+
+		// Other tests' notifications may be due in the shared database, so only alice's are checked.
+		if _, err := srv.sendDueNotifications(ctx, now.Add(2*time.Hour)); err != nil {
+			t.Fatalf("sendDueNotifications error: %v", err)
+		}
+		if got := webhook.sent[user]; len(got) != 2 || got[1].Title != "Pack" {
+			t.Fatalf("webhook got %+v, want the held reply after the quiet hours", got)
+		}
+	}))
+
+	t.Run("posts to a conversation of the user's", WithFixture(func(t *testing.T, f *Fixture) {
+		user := "bob-" + primitive.NewObjectID().Hex()
+		ctx := auth.WithUser(context.Background(), user)
+		srv := NewServer(f.Repository, &fakeAssistant{})
+		srv.EnableNotifications()
+		if _, err := srv.SetNotificationSettings(ctx, &pb.SetNotificationSettingsRequest{Settings: &pb.NotificationSettings{
+			Channels: []string{"conversation"},
+		}}); err != nil {
+			t.Fatalf("SetNotificationSettings error: %v", err)
+		}
+		conv := f.CreateConversation()
+
+		if err := srv.Notify(ctx, user, Notification{Kind: NotifyReplyReady, Title: "Trip", Body: "Here's your plan.", ConversationID: conv.ID.Hex()}); err != nil {
+			t.Fatal(err)
+		}
+		got, err := f.DescribeConversation(context.Background(), conv.ID.Hex())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(got.Messages) != len(conv.Messages) {
+			t.Errorf("got %d messages, want the reply not posted again", len(got.Messages))
+		}
+
+		if err := srv.Notify(ctx, user, Notification{Kind: NotifyDigest, Title: "Digest", Body: "Your week in travel."}); err != nil {
+			t.Fatal(err)
+		}
+		seen := func(userID string) *model.Conversation {
+			convs, err := f.ListConversations(context.Background(), userID, false, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, c := range convs {
+				if slices.Contains(c.Tags, notificationTag) && c.Owner == user {
+					return c
+				}
+			}
+			return nil
+		}
+		if c := seen(user); c == nil || c.Title != "Digest" {
+			t.Errorf("the user doesn't see the notification's conversation, got %+v", c)
+		}
+		if c := seen("eve-" + primitive.NewObjectID().Hex()); c != nil {
+			t.Errorf("another user sees the notification's conversation %s", c.ID.Hex())
+		}
+	}))
+
+	t.Run("disabled", func(t *testing.T) {
+		srv := &Server{}
+		if err := srv.Notify(context.Background(), "alice", Notification{Kind: NotifyDigest}); err != nil {
+			t.Errorf("Notify = %v, want nothing to do", err)
+		}
+		_, err := srv.GetNotificationSettings(context.Background(), &pb.GetNotificationSettingsRequest{})
+		if te, ok := err.(twirp.Error); !ok || te.Code() != twirp.Unimplemented {
+			t.Errorf("expected Unimplemented, got %v", err)
+		}
+	})
+}
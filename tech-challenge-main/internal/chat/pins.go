@@ -0,0 +1,69 @@
+package chat
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/acai-travel/tech-challenge/internal/audit"
+	"github.com/acai-travel/tech-challenge/internal/chat/model"
+	"github.com/acai-travel/tech-challenge/internal/pb"
+	"github.com/twitchtv/twirp"
+)
+
+func (s *Server) PinMessage(ctx context.Context, req *pb.PinMessageRequest) (*pb.PinMessageResponse, error) {
+	if req.GetConversationId() == "" {
+		return nil, twirp.RequiredArgumentError("conversation_id")
+	}
+	if req.GetMessageId() == "" {
+		return nil, twirp.RequiredArgumentError("message_id")
+	}
+
+	audit.Conversation(ctx, req.GetConversationId())
+	conversation, err := s.describeConversation(ctx, req.GetConversationId())
+	if err != nil {
+		return nil, err
+	}
+
+	id := req.GetMessageId()
+	i := slices.IndexFunc(conversation.Messages, func(m *model.Message) bool { return m.ID.Hex() == id })
+	if i < 0 {
+		return nil, twirp.InvalidArgumentError("message_id", "is not a message of this conversation")
+	}
+	msg := conversation.Messages[i]
+
+	// Pinning again keeps the original time, so highlights don't reorder.
+	if req.GetUnpin() {
+		msg.PinnedAt = nil
+	} else if msg.PinnedAt == nil {
+		now := time.Now()
+		msg.PinnedAt = &now
+	}
+
+	if err := s.repo.PinMessage(ctx, conversation.ID, msg.ID, msg.PinnedAt); err != nil {
+		return nil, err
+	}
+
+	return &pb.PinMessageResponse{Message: msg.Proto()}, nil
+}
+
+func (s *Server) ListPinnedMessages(ctx context.Context, req *pb.ListPinnedMessagesRequest) (*pb.ListPinnedMessagesResponse, error) {
+	if req.GetConversationId() == "" {
+		return nil, twirp.RequiredArgumentError("conversation_id")
+	}
+
+	audit.Conversation(ctx, req.GetConversationId())
+	conversation, err := s.describeConversation(ctx, req.GetConversationId())
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &pb.ListPinnedMessagesResponse{}
+	for _, m := range conversation.Messages {
+		if m.PinnedAt != nil {
+			resp.Messages = append(resp.Messages, m.Proto())
+		}
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,73 @@
+package chat
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/acai-travel/tech-challenge/internal/chat/model"
+	. "github.com/acai-travel/tech-challenge/internal/chat/testing"
+	"github.com/acai-travel/tech-challenge/internal/pb"
+	"github.com/twitchtv/twirp"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestPinnedSummarized(t *testing.T) {
+	now := time.Now()
+	msgs := []*model.Message{
+		{ID: primitive.NewObjectID(), Content: "Plan my trip"},
+		{ID: primitive.NewObjectID(), Content: "Day 1: Lisbon", PinnedAt: &now},
+		{ID: primitive.NewObjectID(), Content: "Thanks"},
+		{ID: primitive.NewObjectID(), Content: "Day 2: Porto", PinnedAt: &now},
+	}
+
+	conv := &model.Conversation{Messages: msgs}
+	if got := conv.PinnedSummarized(); len(got) != 0 {
+		t.Errorf("without a summary, got %d pinned summarized messages", len(got))
+	}
+
+	conv.Summary = &model.Summary{UpToMessageID: msgs[2].ID}
+	if got := conv.PinnedSummarized(); len(got) != 1 || got[0] != msgs[1] {
+		t.Errorf("expected only the pinned message covered by the summary, got %d", len(got))
+	}
+}
+
+func TestPinMessage(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("requires a message", func(t *testing.T) {
+		_, err := NewServer(nil, &fakeAssistant{}).PinMessage(ctx, &pb.PinMessageRequest{ConversationId: primitive.NewObjectID().Hex()})
+		if te, ok := err.(twirp.Error); !ok || te.Code() != twirp.InvalidArgument || te.Meta("argument") != "message_id" {
+			t.Errorf("expected twirp.InvalidArgument for message_id, got %v", err)
+		}
+	})
+
+	t.Run("pins, lists and unpins", WithFixture(func(t *testing.T, f *Fixture) {
+		conv := f.CreateConversation()
+		srv := NewServer(f.Repository, &fakeAssistant{})
+		msgID := conv.Messages[0].ID.Hex()
+
+		pinned, err := srv.PinMessage(ctx, &pb.PinMessageRequest{ConversationId: conv.ID.Hex(), MessageId: msgID})
+		if err != nil || pinned.GetMessage().GetPinnedAt() == nil {
+			t.Fatalf("PinMessage = %v, %v", pinned, err)
+		}
+
+		list, err := srv.ListPinnedMessages(ctx, &pb.ListPinnedMessagesRequest{ConversationId: conv.ID.Hex()})
+		if err != nil || len(list.GetMessages()) != 1 || list.GetMessages()[0].GetId() != msgID {
+			t.Fatalf("ListPinnedMessages = %v, %v", list, err)
+		}
+
+		if _, err := srv.PinMessage(ctx, &pb.PinMessageRequest{ConversationId: conv.ID.Hex(), MessageId: msgID, Unpin: true}); err != nil {
+			t.Fatalf("unpin error: %v", err)
+		}
+		list, _ = srv.ListPinnedMessages(ctx, &pb.ListPinnedMessagesRequest{ConversationId: conv.ID.Hex()})
+		if len(list.GetMessages()) != 0 {
+			t.Errorf("expected no pinned messages after unpinning, got %d", len(list.GetMessages()))
+		}
+
+		_, err = srv.PinMessage(ctx, &pb.PinMessageRequest{ConversationId: conv.ID.Hex(), MessageId: primitive.NewObjectID().Hex()})
+		if te, ok := err.(twirp.Error); !ok || te.Code() != twirp.InvalidArgument {
+			t.Errorf("expected twirp.InvalidArgument for an unknown message, got %v", err)
+		}
+	}))
+}
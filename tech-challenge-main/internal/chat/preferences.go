@@ -0,0 +1,44 @@
+package chat
+
+import (
+	"context"
+	"time"
+
+	"github.com/acai-travel/tech-challenge/internal/auth"
+	"github.com/acai-travel/tech-challenge/internal/chat/model"
+	"github.com/acai-travel/tech-challenge/internal/pb"
+	"github.com/twitchtv/twirp"
+)
+
+func (s *Server) SetPreferences(ctx context.Context, req *pb.SetPreferencesRequest) (*pb.SetPreferencesResponse, error) {
+	if req.GetPreferences() == nil {
+		return nil, twirp.RequiredArgumentError("preferences")
+	}
+	userID := auth.User(ctx)
+	if userID == auth.Anonymous {
+		return nil, twirp.NewError(twirp.Unauthenticated, "preferences require an identified user")
+	}
+
+	prefs := req.GetPreferences()
+	if tz := prefs.Timezone; tz != nil && *tz != "" {
+		if _, err := time.LoadLocation(*tz); err != nil {
+			return nil, twirp.InvalidArgumentError("preferences.timezone", "must be an IANA time zone, e.g. Europe/Madrid")
+		}
+	}
+
+	p, err := s.repo.SetPreferences(ctx, userID, model.Preferences{HealthAdvisories: prefs.HealthAdvisories, Timezone: prefs.Timezone})
+	if err != nil {
+		return nil, twirp.InternalErrorWith(err)
+	}
+
+	return &pb.SetPreferencesResponse{Preferences: p.Proto()}, nil
+}
+
+func (s *Server) GetPreferences(ctx context.Context, _ *pb.GetPreferencesRequest) (*pb.GetPreferencesResponse, error) {
+	p, err := s.repo.FindUserProfile(ctx, auth.User(ctx))
+	if err != nil {
+		return nil, twirp.InternalErrorWith(err)
+	}
+
+	return &pb.GetPreferencesResponse{Preferences: p.Preferences.Proto()}, nil
+}
@@ -0,0 +1,69 @@
+package chat
+
+import (
+	"context"
+	"testing"
+
+	"github.com/acai-travel/tech-challenge/internal/auth"
+	. "github.com/acai-travel/tech-challenge/internal/chat/testing"
+	"github.com/acai-travel/tech-challenge/internal/pb"
+	"github.com/twitchtv/twirp"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestPreferences(t *testing.T) {
+	t.Run("saves and loads the health advisory preference", WithFixture(func(t *testing.T, f *Fixture) {
+		srv := NewServer(f.Repository, &fakeAssistant{})
+		user := "alice-" + primitive.NewObjectID().Hex()
+		ctx := auth.WithUser(context.Background(), user)
+
+		got, err := srv.GetPreferences(ctx, &pb.GetPreferencesRequest{})
+		if err != nil || !got.GetPreferences().GetHealthAdvisories() {
+			t.Fatalf("expected advisories on by default, got %v, %v", got, err)
+		}
+
+		off := false
+		if _, err := srv.SetPreferences(ctx, &pb.SetPreferencesRequest{Preferences: &pb.Preferences{HealthAdvisories: &off}}); err != nil {
+			t.Fatalf("SetPreferences error: %v", err)
+		}
+		// Unset fields keep their value.
+		set, err := srv.SetPreferences(ctx, &pb.SetPreferencesRequest{Preferences: &pb.Preferences{}})
+		if err != nil || set.GetPreferences().GetHealthAdvisories() {
+			t.Fatalf("expected advisories to stay off, got %v, %v", set, err)
+		}
+
+		loader := &profileLoader{repo: srv.repo, userID: user}
+		if on, err := loader.HealthAdvisories(ctx); err != nil || on {
+			t.Errorf("expected the assistant to see advisories off, got %v, %v", on, err)
+		}
+	}))
+
+	t.Run("saves the time zone", WithFixture(func(t *testing.T, f *Fixture) {
+		srv := NewServer(f.Repository, &fakeAssistant{})
+		user := "alice-" + primitive.NewObjectID().Hex()
+		ctx := auth.WithUser(context.Background(), user)
+
+		unknown := "Europe/Atlantis"
+		if _, err := srv.SetPreferences(ctx, &pb.SetPreferencesRequest{Preferences: &pb.Preferences{Timezone: &unknown}}); twirpCode(err) != twirp.InvalidArgument {
+			t.Errorf("unknown time zone: expected InvalidArgument, got %v", err)
+		}
+
+		madrid := "Europe/Madrid"
+		set, err := srv.SetPreferences(ctx, &pb.SetPreferencesRequest{Preferences: &pb.Preferences{Timezone: &madrid}})
+		if err != nil || set.GetPreferences().GetTimezone() != madrid {
+			t.Fatalf("SetPreferences = %v, %v; want the time zone", set, err)
+		}
+		loader := &profileLoader{repo: srv.repo, userID: user}
+		if tz, err := loader.Timezone(ctx); err != nil || tz != madrid {
+			t.Errorf("expected the assistant to see %s, got %q, %v", madrid, tz, err)
+		}
+	}))
+
+	t.Run("requires an identified user", func(t *testing.T) {
+		srv := NewServer(nil, &fakeAssistant{})
+		_, err := srv.SetPreferences(context.Background(), &pb.SetPreferencesRequest{Preferences: &pb.Preferences{}})
+		if twirpCode(err) != twirp.Unauthenticated {
+			t.Errorf("expected Unauthenticated, got %v", err)
+		}
+	})
+}
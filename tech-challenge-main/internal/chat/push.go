@@ -0,0 +1,185 @@
+package chat
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/acai-travel/tech-challenge/internal/auth"
+	"github.com/acai-travel/tech-challenge/internal/chat/model"
+	"github.com/acai-travel/tech-challenge/internal/pb"
+	"github.com/acai-travel/tech-challenge/internal/push"
+	"github.com/twitchtv/twirp"
+)
+
+const (
+	// maxPushDevices bounds the devices of a user; registering another forgets the oldest.
+	maxPushDevices = 10
+	// maxPushTokenLength bounds device tokens, in bytes; FCM's are about 160, APNs' 64.
+	maxPushTokenLength = 1024
+	// pushChannelName is the name of the push channel in notification settings.
+	pushChannelName = "push"
+)
+
+// PushProvider sends push notifications to the app on the devices of one platform, e.g.
+// push.FCM or push.APNs. Send fails with push.ErrUnregistered for tokens to forget.
+type PushProvider interface {
+	Send(ctx context.Context, token string, m *push.Message) error
+}
+
+// pushChannel sends notifications to every registered device of a user.
+type pushChannel struct {
+	repo      *model.Repository
+	providers map[model.PushPlatform]PushProvider
+}
+
+// EnablePush lets users register devices for push notifications, sent through fcm to
+// Android and web apps and apns to iOS apps (either may be nil), and adds the "push"
+// notification channel.
+func (s *Server) EnablePush(fcm, apns PushProvider) {
+	p := &pushChannel{repo: s.repo, providers: map[model.PushPlatform]PushProvider{}}
+	if fcm != nil {
+		p.providers[model.PushFCM] = fcm
+	}
+	if apns != nil {
+		p.providers[model.PushAPNs] = apns
+	}
+	s.push = p
+	if s.notifications != nil {
+		s.notifications.add(p)
+	}
+}
+
+var errPushDisabled = twirp.NewError(twirp.Unimplemented, "push notifications are not enabled")
+
+func (p *pushChannel) Name() string { return pushChannelName }
+
+// Notify sends n to each device of the user, forgetting those the provider no longer knows. It
+// only fails if no device got n, so a retry doesn't notify the others twice.
+func (p *pushChannel) Notify(ctx context.Context, to Recipient, n *Notification) error {
+	profile, err := p.repo.FindUserProfile(ctx, to.UserID)
+	if err != nil {
+		return err
+	}
+
+	m := &push.Message{Title: n.Title, Body: n.Body, Data: map[string]string{"kind": n.Kind}}
+	if n.ConversationID != "" {
+		m.Data["conversation_id"] = n.ConversationID
+	}
+
+	var errs []error
+	delivered := false
+	for _, d := range profile.PushDevices {
+		provider := p.providers[d.Platform]
+		if provider == nil {
+			continue
+		}
+		err := provider.Send(ctx, d.Token, m)
+		switch {
+		case err == nil:
+			delivered = true
+		case errors.Is(err, push.ErrUnregistered):
+			slog.InfoContext(ctx, "Forgetting unregistered push device", "user_id", to.UserID, "platform", d.Platform)
+			if err := p.repo.DeletePushDevice(ctx, to.UserID, d.Token); err != nil && twirpCodeOf(err) != twirp.NotFound {
+				slog.WarnContext(ctx, "Failed to forget push device", "user_id", to.UserID, "error", err)
+			}
+		default:
+			errs = append(errs, fmt.Errorf("%s: %w", d.Platform, err))
+		}
+	}
+	if delivered {
+		return nil
+	}
+	return errors.Join(errs...)
+}
+
+func twirpCodeOf(err error) twirp.ErrorCode {
+	var te twirp.Error
+	if errors.As(err, &te) {
+		return te.Code()
+	}
+	return twirp.NoError
+}
+
+func pushPlatform(p pb.PushPlatform) model.PushPlatform {
+	switch p {
+	case pb.PushPlatform_PUSH_PLATFORM_FCM:
+		return model.PushFCM
+	case pb.PushPlatform_PUSH_PLATFORM_APNS:
+		return model.PushAPNs
+	default:
+		return ""
+	}
+}
+
+func (s *Server) RegisterPushDevice(ctx context.Context, req *pb.RegisterPushDeviceRequest) (*pb.RegisterPushDeviceResponse, error) {
+	if s.push == nil {
+		return nil, errPushDisabled
+	}
+	userID := auth.User(ctx)
+	if userID == auth.Anonymous {
+		return nil, twirp.NewError(twirp.Unauthenticated, "push notifications require an identified user")
+	}
+
+	token := strings.TrimSpace(req.GetToken())
+	switch {
+	case token == "":
+		return nil, twirp.RequiredArgumentError("token")
+	case len(token) > maxPushTokenLength || strings.ContainsFunc(token, func(r rune) bool { return r <= ' ' || r > '~' }):
+		return nil, twirp.InvalidArgumentError("token", "must be the device token the platform gave the app")
+	}
+	platform := pushPlatform(req.GetPlatform())
+	if platform == "" {
+		return nil, twirp.InvalidArgumentError("platform", "must be FCM or APNs")
+	}
+	if s.push.providers[platform] == nil {
+		return nil, twirp.InvalidArgumentError("platform", fmt.Sprintf("%s is not configured on the server", platform))
+	}
+
+	d := model.PushDevice{
+		Token:        token,
+		Platform:     platform,
+		DeviceID:     auth.Device(ctx),
+		RegisteredAt: time.Now(),
+	}
+	if err := s.repo.SetPushDevice(ctx, userID, d, maxPushDevices); err != nil {
+		return nil, twirp.InternalErrorWith(err)
+	}
+
+	return &pb.RegisterPushDeviceResponse{Device: d.Proto()}, nil
+}
+
+func (s *Server) UnregisterPushDevice(ctx context.Context, req *pb.UnregisterPushDeviceRequest) (*pb.UnregisterPushDeviceResponse, error) {
+	token := strings.TrimSpace(req.GetToken())
+	if token == "" {
+		return nil, twirp.RequiredArgumentError("token")
+	}
+	userID := auth.User(ctx)
+	if userID == auth.Anonymous {
+		return nil, twirp.NewError(twirp.Unauthenticated, "push notifications require an identified user")
+	}
+
+	// Allowed without EnablePush, so apps can still unregister on sign out.
+	if err := s.repo.DeletePushDevice(ctx, userID, token); err != nil {
+		return nil, err
+	}
+
+	return &pb.UnregisterPushDeviceResponse{}, nil
+}
+
+func (s *Server) ListPushDevices(ctx context.Context, _ *pb.ListPushDevicesRequest) (*pb.ListPushDevicesResponse, error) {
+	p, err := s.repo.FindUserProfile(ctx, auth.User(ctx))
+	if err != nil {
+		return nil, twirp.InternalErrorWith(err)
+	}
+
+	resp := &pb.ListPushDevicesResponse{}
+	for _, d := range slices.Backward(p.PushDevices) {
+		resp.Devices = append(resp.Devices, d.Proto())
+	}
+	return resp, nil
+}
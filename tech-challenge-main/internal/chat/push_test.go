@@ -0,0 +1,142 @@
+package chat
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/acai-travel/tech-challenge/internal/auth"
+	"github.com/acai-travel/tech-challenge/internal/chat/model"
+	. "github.com/acai-travel/tech-challenge/internal/chat/testing"
+	"github.com/acai-travel/tech-challenge/internal/pb"
+	"github.com/acai-travel/tech-challenge/internal/push"
+	"github.com/twitchtv/twirp"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// fakePushProvider records what it sends, by token, and rejects the tokens in gone.
+type fakePushProvider struct {
+	sent map[string][]*push.Message
+	gone map[string]bool
+}
+
+func (f *fakePushProvider) Send(_ context.Context, token string, m *push.Message) error {
+	if f.gone[token] {
+		return push.ErrUnregistered
+	}
+	f.sent[token] = append(f.sent[token], m)
+	return nil
+}
+
+func TestEnablePush(t *testing.T) {
+	for name, enable := range map[string]func(*Server){
+		"before notifications": func(s *Server) { s.EnablePush(&fakePushProvider{}, nil); s.EnableNotifications() },
+		"after notifications":  func(s *Server) { s.EnableNotifications(); s.EnablePush(&fakePushProvider{}, nil) },
+	} {
+		srv := &Server{}
+		enable(srv)
+		if srv.notifications.channels[pushChannelName] == nil {
+			t.Errorf("%s: expected the push channel, got %v", name, srv.notifications.names)
+		}
+	}
+}
+
+func TestServer_RegisterPushDevice(t *testing.T) {
+	alice := auth.WithUser(context.Background(), "alice")
+
+	_, err := (&Server{}).RegisterPushDevice(alice, &pb.RegisterPushDeviceRequest{Token: "t", Platform: pb.PushPlatform_PUSH_PLATFORM_FCM})
+	if twirpCode(err) != twirp.Unimplemented {
+		t.Fatalf("expected Unimplemented without push, got %v", err)
+	}
+
+	srv := &Server{}
+	srv.EnablePush(&fakePushProvider{}, nil)
+	for name, req := range map[string]*pb.RegisterPushDeviceRequest{
+		"missing token":     {Platform: pb.PushPlatform_PUSH_PLATFORM_FCM},
+		"token with spaces": {Token: "a b", Platform: pb.PushPlatform_PUSH_PLATFORM_FCM},
+		"missing platform":  {Token: "t"},
+		"unconfigured APNs": {Token: "t", Platform: pb.PushPlatform_PUSH_PLATFORM_APNS},
+	} {
+		if _, err := srv.RegisterPushDevice(alice, req); twirpCode(err) != twirp.InvalidArgument {
+			t.Errorf("%s: expected InvalidArgument, got %v", name, err)
+		}
+	}
+	if _, err := srv.RegisterPushDevice(context.Background(), &pb.RegisterPushDeviceRequest{Token: "t", Platform: pb.PushPlatform_PUSH_PLATFORM_FCM}); twirpCode(err) != twirp.Unauthenticated {
+		t.Errorf("expected Unauthenticated for anonymous users, got %v", err)
+	}
+}
+
+func TestServer_PushDevices(t *testing.T) {
+	t.Run("register, notify and forget", WithFixture(func(t *testing.T, f *Fixture) {
+		user, other := "alice-"+primitive.NewObjectID().Hex(), "bob-"+primitive.NewObjectID().Hex()
+		ctx := auth.WithUser(context.Background(), user)
+		fcm := &fakePushProvider{sent: map[string][]*push.Message{}, gone: map[string]bool{}}
+		apns := &fakePushProvider{sent: map[string][]*push.Message{}, gone: map[string]bool{}}
+		srv := NewServer(f.Repository, &fakeAssistant{})
+		srv.EnablePush(fcm, apns)
+		srv.EnableNotifications()
+
+		phone, tablet := "phone-"+user, "tablet-"+user
+		for _, req := range []*pb.RegisterPushDeviceRequest{
+			{Token: phone, Platform: pb.PushPlatform_PUSH_PLATFORM_APNS},
+			{Token: tablet, Platform: pb.PushPlatform_PUSH_PLATFORM_FCM},
+		} {
+			if _, err := srv.RegisterPushDevice(ctx, req); err != nil {
+				t.Fatalf("RegisterPushDevice error: %v", err)
+			}
+		}
+		out, err := srv.ListPushDevices(ctx, &pb.ListPushDevicesRequest{})
+		if err != nil || len(out.GetDevices()) != 2 || out.GetDevices()[0].GetToken() != tablet {
+			t.Fatalf("ListPushDevices = %v, %v, want both, the latest first", out, err)
+		}
+
+		if _, err := srv.SetNotificationSettings(ctx, &pb.SetNotificationSettingsRequest{Settings: &pb.NotificationSettings{Channels: []string{"push"}}}); err != nil {
+			t.Fatalf("SetNotificationSettings error: %v", err)
+		}
+		fcm.gone[tablet] = true
+		if err := srv.Notify(ctx, user, Notification{Kind: NotifyReplyReady, Title: "Trip", Body: "Here's your plan.", ConversationID: "c1"}); err != nil {
+			t.Fatal(err)
+		}
+		if got := apns.sent[phone]; len(got) != 1 || got[0].Data["conversation_id"] != "c1" || got[0].Data["kind"] != NotifyReplyReady {
+			t.Errorf("phone got %+v, want the finished reply", got)
+		}
+		p, err := f.FindUserProfile(ctx, user)
+		if err != nil || len(p.PushDevices) != 1 || p.PushDevices[0].Token != phone {
+			t.Fatalf("devices = %+v, %v, want the unregistered tablet forgotten", p.PushDevices, err)
+		}
+
+		// Signing in as bob on the phone moves it to him.
+		if _, err := srv.RegisterPushDevice(auth.WithUser(context.Background(), other), &pb.RegisterPushDeviceRequest{Token: phone, Platform: pb.PushPlatform_PUSH_PLATFORM_APNS}); err != nil {
+			t.Fatalf("RegisterPushDevice error: %v", err)
+		}
+		if p, err := f.FindUserProfile(ctx, user); err != nil || len(p.PushDevices) != 0 {
+			t.Fatalf("alice's devices = %+v, %v, want none", p.PushDevices, err)
+		}
+
+		_, err = srv.UnregisterPushDevice(ctx, &pb.UnregisterPushDeviceRequest{Token: phone})
+		if twirpCode(err) != twirp.NotFound {
+			t.Errorf("expected NotFound unregistering bob's device as alice, got %v", err)
+		}
+		if _, err := srv.UnregisterPushDevice(auth.WithUser(context.Background(), other), &pb.UnregisterPushDeviceRequest{Token: phone}); err != nil {
+			t.Errorf("UnregisterPushDevice error: %v", err)
+		}
+	}))
+
+	t.Run("keeps the latest devices", WithFixture(func(t *testing.T, f *Fixture) {
+		user := "carol-" + primitive.NewObjectID().Hex()
+		ctx, start := context.Background(), time.Now()
+		for i := range maxPushDevices + 2 {
+			d := model.PushDevice{Token: primitive.NewObjectID().Hex(), Platform: model.PushFCM, RegisteredAt: start.Add(time.Duration(i) * time.Minute)}
+			if err := f.SetPushDevice(ctx, user, d, maxPushDevices); err != nil {
+				t.Fatal(err)
+			}
+		}
+		p, err := f.FindUserProfile(ctx, user)
+		if err != nil || len(p.PushDevices) != maxPushDevices {
+			t.Fatalf("got %d devices, %v, want %d", len(p.PushDevices), err, maxPushDevices)
+		}
+		if got := p.PushDevices[0].RegisteredAt; !got.Equal(start.Add(2 * time.Minute).Truncate(time.Millisecond)) {
+			t.Errorf("oldest device registered at %v, want the first two forgotten", got)
+		}
+	}))
+}
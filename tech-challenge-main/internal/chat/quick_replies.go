@@ -0,0 +1,45 @@
+package chat
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/acai-travel/tech-challenge/internal/chat/assistant"
+	"github.com/acai-travel/tech-challenge/internal/chat/model"
+	"github.com/acai-travel/tech-challenge/internal/pb"
+)
+
+// QuickReplySuggester suggests follow-ups to a reply, for chat UIs to render as tappable
+// chips.
+type QuickReplySuggester interface {
+	SuggestQuickReplies(ctx context.Context, conv *model.Conversation, reply string) ([]assistant.QuickReply, error)
+}
+
+// EnableQuickReplies suggests follow-ups with q to requests asking for quick replies.
+func (s *Server) EnableQuickReplies(q QuickReplySuggester) {
+	s.quickReplies = q
+}
+
+// suggestQuickReplies returns the follow-ups to reply, if requested. Suggestions are
+// optional, so failures are only logged.
+func (s *Server) suggestQuickReplies(ctx context.Context, conv *model.Conversation, reply string, requested bool) []*pb.QuickReply {
+	if !requested || s.quickReplies == nil {
+		return nil
+	}
+
+	b := s.budget.of(ctx)
+	ctx, cancel := b.stage(ctx, "quick_replies", b.title)
+	defer cancel()
+
+	suggested, err := s.quickReplies.SuggestQuickReplies(ctx, conv, reply)
+	if err != nil {
+		slog.WarnContext(ctx, "Failed to suggest quick replies", "conversation_id", conv.ID.Hex(), "error", err)
+		return nil
+	}
+
+	var out []*pb.QuickReply
+	for _, q := range suggested {
+		out = append(out, &pb.QuickReply{Label: q.Label, Message: q.Message})
+	}
+	return out
+}
@@ -0,0 +1,43 @@
+package chat
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/acai-travel/tech-challenge/internal/chat/assistant"
+	"github.com/acai-travel/tech-challenge/internal/chat/model"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type fakeSuggester struct {
+	replies []assistant.QuickReply
+	err     error
+}
+
+func (f *fakeSuggester) SuggestQuickReplies(context.Context, *model.Conversation, string) ([]assistant.QuickReply, error) {
+	return f.replies, f.err
+}
+
+func TestSuggestQuickReplies(t *testing.T) {
+	ctx := context.Background()
+	conv := &model.Conversation{ID: primitive.NewObjectID()}
+	srv := NewServer(nil, &fakeAssistant{})
+
+	if got := srv.suggestQuickReplies(ctx, conv, "Sunny", true); got != nil {
+		t.Errorf("quick replies without a suggester: %v", got)
+	}
+
+	srv.EnableQuickReplies(&fakeSuggester{replies: []assistant.QuickReply{{Label: "3-day forecast", Message: "Show me the 3 day forecast"}}})
+	if got := srv.suggestQuickReplies(ctx, conv, "Sunny", false); got != nil {
+		t.Errorf("quick replies not requested, but got %v", got)
+	}
+	if got := srv.suggestQuickReplies(ctx, conv, "Sunny", true); len(got) != 1 || got[0].GetLabel() != "3-day forecast" || got[0].GetMessage() != "Show me the 3 day forecast" {
+		t.Errorf("suggestQuickReplies = %v", got)
+	}
+
+	srv.EnableQuickReplies(&fakeSuggester{err: errors.New("rate limited")})
+	if got := srv.suggestQuickReplies(ctx, conv, "Sunny", true); got != nil {
+		t.Errorf("failed suggestions should be dropped, got %v", got)
+	}
+}
@@ -0,0 +1,134 @@
+package chat
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/acai-travel/tech-challenge/internal/auth"
+	"github.com/acai-travel/tech-challenge/internal/chat/model"
+	"github.com/acai-travel/tech-challenge/internal/pb"
+	"github.com/acai-travel/tech-challenge/internal/quota"
+	"github.com/twitchtv/twirp"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// quotaLabels name the limits of a plan in quota errors.
+var quotaLabels = map[string]string{
+	quota.Messages:  "message",
+	quota.Tokens:    "token",
+	quota.ToolCalls: "tool call",
+}
+
+// EnableQuotas limits what identified users consume per day to the allowances of their plan,
+// which the gateway sets in auth.PlanHeader; users without a known plan get the free plan.
+// Anonymous requests aren't limited.
+func (s *Server) EnableQuotas(plans quota.Plans) {
+	s.quotas = plans
+}
+
+// checkQuota counts a message against the caller's quota, or returns a ResourceExhausted
+// error once they used up an allowance of their plan today. The APIs check it before storing
+// a message, so refused messages leave no trace; channels keep the message, as part of the
+// thread, and reply with quotaNotice.
+func (s *Server) checkQuota(ctx context.Context) error {
+	userID := auth.User(ctx)
+	if s.quotas == nil || userID == auth.Anonymous {
+		return nil
+	}
+
+	now := time.Now()
+	name, plan := s.quotas.Plan(auth.Plan(ctx))
+	ok, err := s.repo.ReserveDailyMessage(ctx, userID, quota.Day(now), model.DailyUsage{
+		Messages:  plan.MessagesPerDay,
+		Tokens:    plan.TokensPerDay,
+		ToolCalls: plan.ToolCallsPerDay,
+	})
+	if err != nil {
+		// Refused, so users can't get past their quota while the database is unavailable.
+		slog.ErrorContext(ctx, "Failed to check quota", "error", err)
+		return twirp.NewError(twirp.Unavailable, "the quota can't be checked right now, please try again later")
+	}
+	if ok {
+		return nil
+	}
+
+	limit := quota.Messages
+	if used, err := s.repo.FindDailyUsage(ctx, userID, quota.Day(now)); err == nil {
+		limit = cmp.Or(plan.Exceeded(dailyUsage(used)), limit)
+	}
+	resets := quota.ResetsAt(now)
+	return twirp.NewError(twirp.ResourceExhausted,
+		fmt.Sprintf("daily %s quota of the %s plan used up, it resets at %s", quotaLabels[limit], name, resets.Format(time.RFC3339))).
+		WithMeta("quota", limit).
+		WithMeta("resets_at", resets.Format(time.RFC3339))
+}
+
+// quotaNotice returns the reply to users of channels refused by checkQuota.
+func quotaNotice(err error) string {
+	var te twirp.Error
+	if !errors.As(err, &te) {
+		return "Sorry, you've reached today's limit. It resets at midnight UTC."
+	}
+	return fmt.Sprintf("Sorry, you've reached today's %s limit. It resets at midnight UTC.", quotaLabels[te.Meta("quota")])
+}
+
+// addQuotaUsage counts the tokens and tool calls of a reply, whether or not it succeeded,
+// against the caller's quota; checkQuota counted the message. Failing to is logged but
+// non-fatal.
+func (s *Server) addQuotaUsage(ctx context.Context, used model.Usage, toolCalls int) {
+	userID := auth.User(ctx)
+	if s.quotas == nil || userID == auth.Anonymous {
+		return
+	}
+
+	wctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+	defer cancel()
+	if err := s.repo.AddDailyUsage(wctx, model.DailyUsage{
+		UserID:    userID,
+		Day:       quota.Day(time.Now()),
+		Tokens:    used.Tokens(),
+		ToolCalls: int64(toolCalls),
+	}); err != nil {
+		slog.ErrorContext(ctx, "Failed to record quota usage", "error", err)
+	}
+}
+
+func dailyUsage(u *model.DailyUsage) quota.Usage {
+	return quota.Usage{Messages: u.Messages, Tokens: u.Tokens, ToolCalls: u.ToolCalls}
+}
+
+func (s *Server) GetQuota(ctx context.Context, _ *pb.GetQuotaRequest) (*pb.GetQuotaResponse, error) {
+	if s.quotas == nil {
+		return nil, twirp.NewError(twirp.Unimplemented, "quotas are not enabled")
+	}
+	userID := auth.User(ctx)
+	if userID == auth.Anonymous {
+		return nil, twirp.NewError(twirp.Unauthenticated, "quotas require an identified user")
+	}
+
+	now := time.Now()
+	used, err := s.repo.FindDailyUsage(ctx, userID, quota.Day(now))
+	if err != nil {
+		return nil, twirp.InternalErrorWith(err)
+	}
+
+	name, plan := s.quotas.Plan(auth.Plan(ctx))
+	return &pb.GetQuotaResponse{Quota: &pb.Quota{
+		Plan:      name,
+		Messages:  allowance(plan.MessagesPerDay, used.Messages),
+		Tokens:    allowance(plan.TokensPerDay, used.Tokens),
+		ToolCalls: allowance(plan.ToolCallsPerDay, used.ToolCalls),
+		ResetsAt:  timestamppb.New(quota.ResetsAt(now)),
+	}}, nil
+}
+
+func allowance(limit, used int64) *pb.Quota_Allowance {
+	if limit == 0 {
+		return &pb.Quota_Allowance{Used: used, Unlimited: true}
+	}
+	return &pb.Quota_Allowance{Limit: limit, Used: used, Remaining: max(limit-used, 0)}
+}
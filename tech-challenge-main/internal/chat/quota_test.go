@@ -0,0 +1,128 @@
+package chat
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/acai-travel/tech-challenge/internal/auth"
+	. "github.com/acai-travel/tech-challenge/internal/chat/testing"
+	"github.com/acai-travel/tech-challenge/internal/pb"
+	"github.com/acai-travel/tech-challenge/internal/quota"
+	"github.com/twitchtv/twirp"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestQuotas(t *testing.T) {
+	plans := quota.Plans{
+		quota.Free: {MessagesPerDay: 2},
+		quota.Pro:  {MessagesPerDay: 10, TokensPerDay: 1000},
+	}
+
+	t.Run("refuses messages once the allowance is used up", WithFixture(func(t *testing.T, f *Fixture) {
+		srv := NewServer(f.Repository, &fakeAssistant{})
+		srv.EnableQuotas(plans)
+		ctx := auth.WithUser(context.Background(), "alice-"+primitive.NewObjectID().Hex())
+
+		out, err := srv.StartConversation(ctx, &pb.StartConversationRequest{Message: "Weather in Rome?"})
+		if err != nil {
+			t.Fatalf("StartConversation error: %v", err)
+		}
+		if _, err := srv.ContinueConversation(ctx, &pb.ContinueConversationRequest{ConversationId: out.GetConversationId(), Message: "And tomorrow?"}); err != nil {
+			t.Fatalf("ContinueConversation error: %v", err)
+		}
+
+		_, err = srv.ContinueConversation(ctx, &pb.ContinueConversationRequest{ConversationId: out.GetConversationId(), Message: "And Sunday?"})
+		var te twirp.Error
+		if !errors.As(err, &te) || te.Code() != twirp.ResourceExhausted || te.Meta("quota") != quota.Messages || te.Meta("resets_at") == "" {
+			t.Fatalf("expected the message quota to be used up, got %v", err)
+		}
+		if !strings.Contains(te.Msg(), "free plan") {
+			t.Errorf("error %q should name the plan", te.Msg())
+		}
+		conv, err := f.Repository.DescribeConversation(ctx, out.GetConversationId())
+		if err != nil || len(conv.Messages) != 4 {
+			t.Errorf("conversation = %v, %v; want the refused message not stored", conv, err)
+		}
+
+		got, err := srv.GetQuota(ctx, &pb.GetQuotaRequest{})
+		if err != nil {
+			t.Fatalf("GetQuota error: %v", err)
+		}
+		q := got.GetQuota()
+		if q.GetPlan() != quota.Free || q.GetMessages().GetUsed() != 2 || q.GetMessages().GetRemaining() != 0 ||
+			!q.GetTokens().GetUnlimited() || q.GetResetsAt() == nil {
+			t.Errorf("quota = %v", q)
+		}
+
+		// Another plan has its own allowances.
+		pro := auth.WithPlan(ctx, quota.Pro)
+		if _, err := srv.StartConversation(pro, &pb.StartConversationRequest{Message: "Weather in Milan?"}); err != nil {
+			t.Fatalf("StartConversation on the pro plan: %v", err)
+		}
+		got, err = srv.GetQuota(pro, &pb.GetQuotaRequest{})
+		if err != nil || got.GetQuota().GetPlan() != quota.Pro || got.GetQuota().GetMessages().GetRemaining() != 7 {
+			t.Errorf("pro quota = %v, %v", got, err)
+		}
+	}))
+
+	t.Run("counts concurrent messages once each", WithFixture(func(t *testing.T, f *Fixture) {
+		srv := NewServer(f.Repository, &fakeAssistant{})
+		srv.EnableQuotas(plans)
+		ctx := auth.WithUser(context.Background(), "alice-"+primitive.NewObjectID().Hex())
+
+		var wg sync.WaitGroup
+		errs := make(chan error, 10)
+		for range 10 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				errs <- srv.checkQuota(ctx)
+			}()
+		}
+		wg.Wait()
+		close(errs)
+
+		allowed := 0
+		for err := range errs {
+			switch twirpCode(err) {
+			case twirp.NoError:
+				allowed++
+			case twirp.ResourceExhausted:
+			default:
+				t.Errorf("checkQuota error: %v", err)
+			}
+		}
+		if allowed != 2 {
+			t.Errorf("allowed %d concurrent messages, want the free plan's 2", allowed)
+		}
+	}))
+
+	t.Run("GetQuota needs quotas and an identified user", func(t *testing.T) {
+		srv := NewServer(nil, &fakeAssistant{})
+		ctx := auth.WithUser(context.Background(), "alice")
+		if _, err := srv.GetQuota(ctx, &pb.GetQuotaRequest{}); twirpCode(err) != twirp.Unimplemented {
+			t.Errorf("expected Unimplemented, got %v", err)
+		}
+		srv.EnableQuotas(plans)
+		if _, err := srv.GetQuota(context.Background(), &pb.GetQuotaRequest{}); twirpCode(err) != twirp.Unauthenticated {
+			t.Errorf("expected Unauthenticated, got %v", err)
+		}
+		// Anonymous requests aren't limited, so they don't need the repository.
+		if err := srv.checkQuota(context.Background()); err != nil {
+			t.Errorf("checkQuota for an anonymous user: %v", err)
+		}
+	})
+}
+
+func TestQuotaNotice(t *testing.T) {
+	err := twirp.NewError(twirp.ResourceExhausted, "used up").WithMeta("quota", quota.ToolCalls)
+	if got := quotaNotice(err); !strings.Contains(got, "tool call limit") {
+		t.Errorf("quotaNotice = %q", got)
+	}
+	if got := allowance(10, 12); got.GetRemaining() != 0 || got.GetUnlimited() {
+		t.Errorf("allowance over the limit = %v", got)
+	}
+}
@@ -0,0 +1,51 @@
+package chat
+
+import (
+	"context"
+	"slices"
+
+	"github.com/acai-travel/tech-challenge/internal/audit"
+	"github.com/acai-travel/tech-challenge/internal/chat/model"
+	"github.com/acai-travel/tech-challenge/internal/pb"
+	"github.com/twitchtv/twirp"
+)
+
+func (s *Server) RateMessage(ctx context.Context, req *pb.RateMessageRequest) (*pb.RateMessageResponse, error) {
+	if req.GetConversationId() == "" {
+		return nil, twirp.RequiredArgumentError("conversation_id")
+	}
+	if req.GetMessageId() == "" {
+		return nil, twirp.RequiredArgumentError("message_id")
+	}
+	var rating model.Rating
+	switch req.GetRating() {
+	case pb.MessageRating_MESSAGE_RATING_POSITIVE:
+		rating = model.RatingPositive
+	case pb.MessageRating_MESSAGE_RATING_NEGATIVE:
+		rating = model.RatingNegative
+	}
+
+	audit.Conversation(ctx, req.GetConversationId())
+	conversation, err := s.describeConversation(ctx, req.GetConversationId())
+	if err != nil {
+		return nil, err
+	}
+
+	id := req.GetMessageId()
+	i := slices.IndexFunc(conversation.Messages, func(m *model.Message) bool { return m.ID.Hex() == id })
+	if i < 0 {
+		return nil, twirp.InvalidArgumentError("message_id", "is not a message of this conversation")
+	}
+	msg := conversation.Messages[i]
+	if msg.Role != model.RoleAssistant {
+		return nil, twirp.InvalidArgumentError("message_id", "is not a reply of the assistant")
+	}
+
+	if err := s.repo.RateMessage(ctx, conversation.ID, msg.ID, rating); err != nil {
+		return nil, err
+	}
+	s.rollout.rated(ctx, conversation, msg.CreatedAt, msg.Rating, rating)
+	msg.Rating = rating
+
+	return &pb.RateMessageResponse{Message: msg.Proto()}, nil
+}
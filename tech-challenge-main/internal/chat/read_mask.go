@@ -0,0 +1,47 @@
+package chat
+
+import (
+	"slices"
+
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// storedConversationFields are the stored fields each field of pb.Conversation is built from.
+// The ID is always read, and the preview is computed by the database.
+var storedConversationFields = map[string][]string{
+	"title":            {"subject"},
+	"timestamp":        {"updated_at"},
+	"messages":         {"messages"},
+	"settings":         {"settings"},
+	"assistant":        {"assistant"},
+	"language":         {"language"},
+	"failed_reply":     {"messages"},
+	"tags":             {"tags"},
+	"archived_at":      {"archived_at"},
+	"usage":            {"usage", "settings"},
+	"icon":             {"icon"},
+	"summary":          {"messages", "summary"},
+	"omitted_messages": {"messages", "summary"},
+}
+
+// storedFields returns the stored fields to read for the conversation fields in mask, or nil
+// to read them all when it is empty.
+func storedFields(mask *fieldmaskpb.FieldMask) []string {
+	if len(mask.GetPaths()) == 0 {
+		return nil
+	}
+	fields := []string{}
+	for _, p := range mask.GetPaths() {
+		for _, f := range storedConversationFields[p] {
+			if !slices.Contains(fields, f) {
+				fields = append(fields, f)
+			}
+		}
+	}
+	return fields
+}
+
+// masked reports whether the field named path of pb.Conversation is returned with mask.
+func masked(mask *fieldmaskpb.FieldMask, path string) bool {
+	return len(mask.GetPaths()) == 0 || slices.Contains(mask.GetPaths(), path)
+}
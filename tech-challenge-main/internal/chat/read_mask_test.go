@@ -0,0 +1,73 @@
+package chat
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/acai-travel/tech-challenge/internal/chat/model"
+	. "github.com/acai-travel/tech-challenge/internal/chat/testing"
+	"github.com/acai-travel/tech-challenge/internal/pb"
+	"github.com/twitchtv/twirp"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+func TestStoredFields(t *testing.T) {
+	if got := storedFields(nil); got != nil {
+		t.Errorf("storedFields(nil) = %v, want every field", got)
+	}
+	if got := storedFields(&fieldmaskpb.FieldMask{Paths: []string{"id"}}); got == nil || len(got) != 0 {
+		t.Errorf("storedFields(id) = %#v, want only the ID", got)
+	}
+	got := storedFields(&fieldmaskpb.FieldMask{Paths: []string{"title", "usage", "settings", "preview"}})
+	if want := []string{"subject", "usage", "settings"}; !slices.Equal(got, want) {
+		t.Errorf("storedFields = %v, want %v", got, want)
+	}
+}
+
+func TestServer_ReadMask(t *testing.T) {
+	ctx := context.Background()
+	mask := func(paths ...string) *fieldmaskpb.FieldMask { return &fieldmaskpb.FieldMask{Paths: paths} }
+
+	t.Run("describe", WithFixture(func(t *testing.T, f *Fixture) {
+		srv := NewServer(f.Repository, nil)
+		c := f.CreateConversation(func(c *model.Conversation) { c.Tags = []string{"trip-2025"} })
+
+		out, err := srv.DescribeConversation(ctx, &pb.DescribeConversationRequest{ConversationId: c.ID.Hex(), ReadMask: mask("id", "title")})
+		if err != nil {
+			t.Fatalf("DescribeConversation error: %v", err)
+		}
+		got := out.GetConversation()
+		if got.GetId() != c.ID.Hex() || got.GetTitle() != c.Title || got.GetTimestamp() != nil || got.GetMessages() != nil || got.GetTags() != nil || got.GetUsage() != nil {
+			t.Errorf("DescribeConversation = %v, want only the id and title", got)
+		}
+
+		_, err = srv.DescribeConversation(ctx, &pb.DescribeConversationRequest{ConversationId: c.ID.Hex(), ReadMask: mask("subject")})
+		if twirpCode(err) != twirp.InvalidArgument {
+			t.Errorf("expected InvalidArgument for an unknown field, got %v", err)
+		}
+	}))
+
+	t.Run("list", WithFixture(func(t *testing.T, f *Fixture) {
+		srv := NewServer(f.Repository, nil)
+		c := f.CreateConversation()
+
+		for _, preview := range []bool{false, true} {
+			out, err := srv.ListConversations(ctx, &pb.ListConversationsRequest{IncludePreview: preview, ReadMask: mask("id", "title", "timestamp", "preview")})
+			if err != nil {
+				t.Fatalf("ListConversations error: %v", err)
+			}
+			i := slices.IndexFunc(out.GetConversations(), func(p *pb.Conversation) bool { return p.GetId() == c.ID.Hex() })
+			if i < 0 {
+				t.Fatal("conversation not listed")
+			}
+			got := out.GetConversations()[i]
+			if got.GetTitle() != c.Title || !got.GetTimestamp().AsTime().Equal(c.UpdatedAt) || got.GetSettings() != nil || got.GetUsage() != nil {
+				t.Errorf("ListConversations = %v, want only the id, title, timestamp and preview", got)
+			}
+			if preview && got.GetPreview().GetMessageCount() != 1 {
+				t.Errorf("preview = %v, want the message count", got.GetPreview())
+			}
+		}
+	}))
+}
@@ -0,0 +1,97 @@
+package chat
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/twitchtv/twirp"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// writeWithRetry runs a repository write, retrying transient failures with exponential
+// backoff. Twirp errors (e.g. not found) are considered permanent and returned immediately.
+func writeWithRetry(ctx context.Context, attempts int, backoff time.Duration, write func(context.Context) error) error {
+	var err error
+	for i := 0; i < attempts; i++ {
+		if err = write(ctx); err == nil {
+			return nil
+		}
+
+		var te twirp.Error
+		if errors.As(err, &te) || i == attempts-1 {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return errors.Join(err, ctx.Err())
+		case <-time.After(backoff << i):
+		}
+	}
+	return err
+}
+
+// reconciler re-applies conversation writes that still failed after the reply was returned
+// to the user, so the stored conversation converges with what the user saw. Writes must be
+// idempotent. Jobs live in memory only: they cover transient database outages, not restarts.
+type reconciler struct {
+	queue   chan reconcileJob
+	once    sync.Once
+	backoff time.Duration
+	maxWait time.Duration
+}
+
+type reconcileJob struct {
+	conversationID primitive.ObjectID
+	write          func(context.Context) error
+}
+
+func newReconciler(size int) *reconciler {
+	return &reconciler{
+		queue:   make(chan reconcileJob, size),
+		backoff: time.Second,
+		maxWait: time.Minute,
+	}
+}
+
+// Enqueue schedules a write for a conversation in the background. It never blocks and
+// reports false if the queue is full.
+func (r *reconciler) Enqueue(id primitive.ObjectID, write func(context.Context) error) bool {
+	r.once.Do(func() { go r.run() })
+
+	select {
+	case r.queue <- reconcileJob{conversationID: id, write: write}:
+		return true
+	default:
+		return false
+	}
+}
+
+func (r *reconciler) run() {
+	for job := range r.queue {
+		wait := r.backoff
+		for {
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			err := job.write(ctx)
+			cancel()
+
+			if err == nil {
+				slog.Info("Reconciled conversation write", "conversation_id", job.conversationID.Hex())
+				break
+			}
+
+			var te twirp.Error
+			if errors.As(err, &te) {
+				slog.Error("Dropping conversation reconciliation", "conversation_id", job.conversationID.Hex(), "error", err)
+				break
+			}
+
+			slog.Warn("Conversation reconciliation failed, will retry", "conversation_id", job.conversationID.Hex(), "error", err, "retry_in", wait)
+			time.Sleep(wait)
+			wait = min(2*wait, r.maxWait)
+		}
+	}
+}
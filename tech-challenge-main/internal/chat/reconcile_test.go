@@ -0,0 +1,50 @@
+package chat
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/twitchtv/twirp"
+)
+
+func TestWriteWithRetry(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("succeeds after transient failures", func(t *testing.T) {
+		calls := 0
+		err := writeWithRetry(ctx, 3, time.Millisecond, func(context.Context) error {
+			calls++
+			if calls < 3 {
+				return errors.New("connection reset")
+			}
+			return nil
+		})
+		if err != nil || calls != 3 {
+			t.Fatalf("expected success on 3rd attempt, got err=%v calls=%d", err, calls)
+		}
+	})
+
+	t.Run("gives up after all attempts", func(t *testing.T) {
+		calls := 0
+		err := writeWithRetry(ctx, 3, time.Millisecond, func(context.Context) error {
+			calls++
+			return errors.New("connection reset")
+		})
+		if err == nil || calls != 3 {
+			t.Fatalf("expected failure after 3 attempts, got err=%v calls=%d", err, calls)
+		}
+	})
+
+	t.Run("does not retry permanent errors", func(t *testing.T) {
+		calls := 0
+		err := writeWithRetry(ctx, 3, time.Millisecond, func(context.Context) error {
+			calls++
+			return twirp.NotFoundError("conversation not found")
+		})
+		if err == nil || calls != 1 {
+			t.Fatalf("expected a single attempt, got err=%v calls=%d", err, calls)
+		}
+	})
+}
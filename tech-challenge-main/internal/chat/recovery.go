@@ -0,0 +1,114 @@
+package chat
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/acai-travel/tech-challenge/internal/chat/model"
+	"github.com/twitchtv/twirp"
+)
+
+// errInterrupted is the failure recorded for replies lost when the server stopped while
+// generating them.
+var errInterrupted = errors.New("the server stopped while generating the reply")
+
+// trackGeneration records that a reply to msg is being generated and returns the context
+// to generate it with, cancelled when the user cancels the generation, and the function
+// deleting the record, to call once the reply is stored or its failure recorded. Tracking
+// is best effort: the reply is generated even if the record can't be written, though it
+// can then only be cancelled through this instance.
+func (s *Server) trackGeneration(ctx context.Context, conv *model.Conversation, msg *model.Message) (context.Context, func()) {
+	ctx, cancel := context.WithCancelCause(ctx)
+	unregister := s.running.add(conv.ID, cancel)
+
+	g, err := s.repo.StartGeneration(ctx, conv.ID, msg.ID)
+	if err != nil {
+		slog.WarnContext(ctx, "Failed to record generation start", "conversation_id", conv.ID.Hex(), "error", err)
+		return ctx, func() {
+			unregister()
+			cancel(nil)
+		}
+	}
+	stopWatching := s.watchCancel(ctx, g, cancel)
+
+	return ctx, func() {
+		stopWatching()
+		unregister()
+		defer cancel(nil)
+
+		// Detached: the request is often over, or timed out, by the time the reply is handled.
+		wctx, cancelWrite := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+		defer cancelWrite()
+
+		if err := s.repo.FinishGeneration(wctx, g.ID); err != nil {
+			slog.ErrorContext(wctx, "Failed to record generation end", "conversation_id", conv.ID.Hex(), "error", err)
+		}
+	}
+}
+
+// RecoverGenerations marks replies interrupted by a server stop as failed, so users get a
+// notice and can retry them instead of waiting for a reply that never comes. It sweeps at
+// once and then periodically until ctx is done: generations are only stale once they are
+// older than any request may take, as younger ones may still be running on another instance.
+func (s *Server) RecoverGenerations(ctx context.Context) {
+	staleAfter := 2 * s.budget.longest()
+	for {
+		n, err := s.sweepGenerations(ctx, time.Now().Add(-staleAfter))
+		if err != nil && ctx.Err() == nil {
+			slog.ErrorContext(ctx, "Failed to recover interrupted replies", "error", err)
+		}
+		if n > 0 {
+			slog.InfoContext(ctx, "Marked interrupted replies as failed", "count", n)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(staleAfter):
+		}
+	}
+}
+
+// sweepGenerations records a failure for every generation started before startedBefore
+// whose message is still unanswered, and reports how many it recorded.
+func (s *Server) sweepGenerations(ctx context.Context, startedBefore time.Time) (int, error) {
+	n := 0
+	for {
+		g, err := s.repo.ClaimStaleGeneration(ctx, startedBefore)
+		if err != nil || g == nil {
+			return n, err
+		}
+
+		conv, err := s.repo.DescribeConversation(ctx, g.ConversationID.Hex())
+		var te twirp.Error
+		if errors.As(err, &te) && te.Code() == twirp.NotFound {
+			continue // deleted since
+		}
+		if err != nil {
+			return n, err
+		}
+
+		// The reply may have been stored just before the server stopped.
+		if len(conv.Messages) == 0 || conv.Messages[len(conv.Messages)-1].ID != g.MessageID {
+			continue
+		}
+		if err := s.repo.RecordFailedGeneration(ctx, g.ConversationID, g.MessageID, errInterrupted); err != nil {
+			return n, err
+		}
+		n++
+	}
+}
+
+// failedReplyNotice explains a failed reply to the user. Failure causes are internal, so
+// they are only told apart from server restarts and cancellations.
+func failedReplyNotice(f *model.FailedGeneration) string {
+	switch f.Error {
+	case errInterrupted.Error():
+		return "The reply to your last message was interrupted by a server restart. Retry to get an answer."
+	case errGenerationCancelled.Error():
+		return "You stopped the reply to your last message. Retry to get an answer."
+	}
+	return "The reply to your last message could not be generated. Retry to get an answer."
+}
@@ -0,0 +1,55 @@
+package chat
+
+import (
+	"sort"
+	"sync"
+)
+
+// DefaultAssistant is the name the server registers its default assistant under.
+const DefaultAssistant = "general"
+
+// AssistantRegistry holds the named assistants conversations can select. The empty name
+// resolves to the default assistant.
+type AssistantRegistry struct {
+	mu         sync.RWMutex
+	assistants map[string]Assistant
+	fallback   string
+}
+
+// NewAssistantRegistry returns a registry with def registered as the default assistant.
+func NewAssistantRegistry(name string, def Assistant) *AssistantRegistry {
+	return &AssistantRegistry{
+		assistants: map[string]Assistant{name: def},
+		fallback:   name,
+	}
+}
+
+// Register adds or replaces a named assistant.
+func (r *AssistantRegistry) Register(name string, a Assistant) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.assistants[name] = a
+}
+
+// Get returns the named assistant, or the default for an empty name.
+func (r *AssistantRegistry) Get(name string) (Assistant, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if name == "" {
+		name = r.fallback
+	}
+	a, ok := r.assistants[name]
+	return a, ok
+}
+
+// Names returns the registered assistant names in alphabetical order.
+func (r *AssistantRegistry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	names := make([]string, 0, len(r.assistants))
+	for n := range r.assistants {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,42 @@
+package chat
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/acai-travel/tech-challenge/internal/chat/model"
+)
+
+type namedAssistant string
+
+func (n namedAssistant) Title(context.Context, *model.Conversation) (string, error) {
+	return string(n), nil
+}
+
+func (n namedAssistant) Reply(context.Context, *model.Conversation) (string, error) {
+	return string(n), nil
+}
+
+func TestAssistantRegistry(t *testing.T) {
+	r := NewAssistantRegistry(DefaultAssistant, namedAssistant("general"))
+	r.Register("travel", namedAssistant("travel"))
+
+	for name, want := range map[string]string{"": "general", "general": "general", "travel": "travel"} {
+		a, ok := r.Get(name)
+		if !ok {
+			t.Fatalf("Get(%q) not found", name)
+		}
+		if got := string(a.(namedAssistant)); got != want {
+			t.Errorf("Get(%q) = %q, want %q", name, got, want)
+		}
+	}
+
+	if _, ok := r.Get("support"); ok {
+		t.Error("Get(\"support\") found an unregistered assistant")
+	}
+
+	if got, want := r.Names(), []string{"general", "travel"}; !slices.Equal(got, want) {
+		t.Errorf("Names() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,188 @@
+package chat
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/acai-travel/tech-challenge/internal/audit"
+	"github.com/acai-travel/tech-challenge/internal/chat/assistant"
+	"github.com/acai-travel/tech-challenge/internal/chat/model"
+	"github.com/twitchtv/twirp"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ReplayOptions select the message of a stored conversation to answer again, and what to
+// change about the assistant answering it.
+type ReplayOptions struct {
+	ConversationID string
+	// MessageID is the user message to answer; empty is the last one.
+	MessageID string
+	// Assistant is the registered assistant answering; empty keeps the conversation's.
+	Assistant string
+	// Prompt replaces the assistant's system prompt, if set; see EnablePromptReplays.
+	Prompt string
+	// Model replaces the conversation's model, if set. It needn't be allowed to users.
+	Model string
+}
+
+// Replay is a reply generated again, next to the one the user got.
+type Replay struct {
+	MessageID primitive.ObjectID
+	// Original is the reply stored after the message; empty if it failed.
+	Original string
+	Reply    string
+	// Diff compares Original and Reply line by line: unchanged lines start with "  ",
+	// removed ones with "- " and added ones with "+ ".
+	Diff      string
+	ToolCalls []*model.ToolCall
+	Usage     model.Usage
+	LatencyMs int64
+	// Error is why no reply was generated.
+	Error string
+}
+
+// EnablePromptReplays lets Replay change the system prompt, with newAssistant returning the
+// assistant registered as name with prompt instead of its own.
+func (s *Server) EnablePromptReplays(newAssistant func(name, prompt string) (Assistant, error)) {
+	s.replayAssistant = newAssistant
+}
+
+// Replay answers a message of a stored conversation again, with the current assistant or
+// the changes in o, to see how a prompt or model change affects it. Nothing is stored: the
+// conversation, its usage and the user's quota are left as they are. Tools still run, with
+// the caller's identity and default feature flags.
+func (s *Server) Replay(ctx context.Context, o ReplayOptions) (*Replay, error) {
+	if o.ConversationID == "" {
+		return nil, twirp.RequiredArgumentError("conversation_id")
+	}
+	conv, err := s.repo.DescribeConversation(ctx, o.ConversationID)
+	if err != nil {
+		return nil, err
+	}
+	audit.Conversation(ctx, conv.ID.Hex())
+
+	at := -1
+	for i := len(conv.Messages) - 1; i >= 0; i-- {
+		m := conv.Messages[i]
+		if m.Role == model.RoleUser && (o.MessageID == "" || m.ID.Hex() == o.MessageID) {
+			at = i
+			break
+		}
+	}
+	if at < 0 {
+		if o.MessageID != "" {
+			return nil, twirp.NotFoundError("message not found")
+		}
+		return nil, twirp.NewError(twirp.FailedPrecondition, "the conversation has no user message")
+	}
+
+	name := cmp.Or(o.Assistant, conv.Assistant)
+	a, ok := s.assistants.Get(name)
+	if !ok {
+		return nil, twirp.InvalidArgumentError("assistant", "unknown assistant "+name)
+	}
+	if o.Prompt != "" {
+		if s.replayAssistant == nil {
+			return nil, twirp.NewError(twirp.Unimplemented, "prompt replays are disabled")
+		}
+		if a, err = s.replayAssistant(cmp.Or(name, DefaultAssistant), o.Prompt); err != nil {
+			return nil, twirp.InvalidArgumentError("prompt", err.Error())
+		}
+	}
+
+	out := &Replay{MessageID: conv.Messages[at].ID}
+	if next := at + 1; next < len(conv.Messages) && conv.Messages[next].Role == model.RoleAssistant {
+		out.Original = conv.Messages[next].Content
+	}
+
+	// The assistant sees the conversation as it was when the message was sent.
+	snapshot := *conv
+	snapshot.Messages = slices.Clone(conv.Messages[:at+1])
+	if conv.Summary != nil && !summaryCovers(snapshot.Messages, conv.Summary.UpToMessageID) {
+		snapshot.Summary = nil
+	}
+	if o.Model != "" {
+		settings := model.GenerationSettings{}
+		if conv.Settings != nil {
+			settings = *conv.Settings
+		}
+		settings.Model = o.Model
+		snapshot.Settings = &settings
+	}
+
+	ctx = assistant.WithProgress(ctx, func(p assistant.Progress) {
+		out.ToolCalls = recordToolCall(out.ToolCalls, p)
+	})
+	ctx = assistant.WithUsage(ctx, func(u model.Usage) { out.Usage = u })
+
+	b := s.budget.of(ctx)
+	ctx, cancel := b.start(ctx)
+	defer cancel()
+
+	start := time.Now()
+	out.Reply, err = a.Reply(ctx, &snapshot)
+	out.LatencyMs = time.Since(start).Milliseconds()
+	if err != nil {
+		out.Error = err.Error()
+		return out, nil
+	}
+	out.Diff = lineDiff(out.Original, out.Reply)
+	return out, nil
+}
+
+// summaryCovers reports whether the summary up to message id summarizes msgs only.
+func summaryCovers(msgs []*model.Message, id primitive.ObjectID) bool {
+	for _, m := range msgs {
+		if m.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// lineDiff compares a and b line by line, through their longest common subsequence.
+func lineDiff(a, b string) string {
+	x, y := lines(a), lines(b)
+
+	// common[i][j] is the length of the longest common subsequence of x[i:] and y[j:].
+	common := make([][]int, len(x)+1)
+	for i := range common {
+		common[i] = make([]int, len(y)+1)
+	}
+	for i := len(x) - 1; i >= 0; i-- {
+		for j := len(y) - 1; j >= 0; j-- {
+			if x[i] == y[j] {
+				common[i][j] = common[i+1][j+1] + 1
+			} else {
+				common[i][j] = max(common[i+1][j], common[i][j+1])
+			}
+		}
+	}
+
+	var sb strings.Builder
+	i, j := 0, 0
+	for i < len(x) || j < len(y) {
+		switch {
+		case i < len(x) && j < len(y) && x[i] == y[j]:
+			sb.WriteString("  " + x[i] + "\n")
+			i, j = i+1, j+1
+		case j == len(y) || (i < len(x) && common[i+1][j] >= common[i][j+1]):
+			sb.WriteString("- " + x[i] + "\n")
+			i++
+		default:
+			sb.WriteString("+ " + y[j] + "\n")
+			j++
+		}
+	}
+	return sb.String()
+}
+
+func lines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, "\n")
+}
@@ -0,0 +1,93 @@
+package chat
+
+import (
+	"context"
+	"testing"
+
+	"github.com/acai-travel/tech-challenge/internal/chat/model"
+	. "github.com/acai-travel/tech-challenge/internal/chat/testing"
+	"github.com/twitchtv/twirp"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestLineDiff(t *testing.T) {
+	for name, tc := range map[string]struct {
+		a, b, want string
+	}{
+		"same":     {a: "Sunny.\nTake a hat.", b: "Sunny.\nTake a hat.", want: "  Sunny.\n  Take a hat.\n"},
+		"changed":  {a: "Sunny.\nTake a hat.", b: "Sunny, 24°C.\nTake a hat.", want: "- Sunny.\n+ Sunny, 24°C.\n  Take a hat.\n"},
+		"added":    {a: "Sunny.", b: "Sunny.\nTake a hat.", want: "  Sunny.\n+ Take a hat.\n"},
+		"no reply": {a: "", b: "Sunny.", want: "+ Sunny.\n"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if got := lineDiff(tc.a, tc.b); got != tc.want {
+				t.Errorf("lineDiff = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestReplay(t *testing.T) {
+	ctx := context.Background()
+	question := primitive.NewObjectID()
+	withReplies := func(c *model.Conversation) {
+		c.Messages = append(c.Messages,
+			&model.Message{ID: primitive.NewObjectID(), Role: model.RoleAssistant, Content: "Sunny."},
+			&model.Message{ID: question, Role: model.RoleUser, Content: "And tomorrow?"},
+			&model.Message{ID: primitive.NewObjectID(), Role: model.RoleAssistant, Content: "Rainy."},
+		)
+	}
+
+	t.Run("answers the message again without storing", WithFixture(func(t *testing.T, f *Fixture) {
+		c := f.CreateConversation(withReplies)
+		var seen *model.Conversation
+		srv := NewServer(f.Repository, &fakeAssistant{replyFn: func(_ context.Context, conv *model.Conversation) (string, error) {
+			seen = conv
+			return "Rainy, take an umbrella.", nil
+		}})
+
+		r, err := srv.Replay(ctx, ReplayOptions{ConversationID: c.ID.Hex(), Model: "gpt-4o"})
+		if err != nil {
+			t.Fatalf("Replay error: %v", err)
+		}
+		if r.MessageID != question || r.Original != "Rainy." || r.Diff != "- Rainy.\n+ Rainy, take an umbrella.\n" {
+			t.Errorf("replay = %+v, want the last question's reply compared", r)
+		}
+		if len(seen.Messages) != 3 || seen.Settings == nil || seen.Settings.Model != "gpt-4o" {
+			t.Errorf("assistant saw %d messages with settings %+v, want 3 and the model", len(seen.Messages), seen.Settings)
+		}
+
+		stored, err := f.Repository.DescribeConversation(ctx, c.ID.Hex())
+		if err != nil || len(stored.Messages) != 4 || stored.Settings != nil {
+			t.Errorf("stored conversation changed: %+v, %v", stored, err)
+		}
+	}))
+
+	t.Run("earlier message and prompt", WithFixture(func(t *testing.T, f *Fixture) {
+		c := f.CreateConversation(withReplies)
+		srv := NewServer(f.Repository, &fakeAssistant{})
+		var prompt string
+		srv.EnablePromptReplays(func(name, p string) (Assistant, error) {
+			prompt = p
+			return &fakeAssistant{replyFn: func(_ context.Context, conv *model.Conversation) (string, error) {
+				if len(conv.Messages) != 1 {
+					t.Errorf("assistant saw %d messages, want the first question only", len(conv.Messages))
+				}
+				return "Sunny.", nil
+			}}, nil
+		})
+
+		r, err := srv.Replay(ctx, ReplayOptions{ConversationID: c.ID.Hex(), MessageID: c.Messages[0].ID.Hex(), Prompt: "Be brief."})
+		if err != nil || r.Diff != "  Sunny.\n" || prompt != "Be brief." {
+			t.Errorf("Replay = %+v, %v with prompt %q", r, err, prompt)
+		}
+	}))
+
+	t.Run("prompt replays disabled", WithFixture(func(t *testing.T, f *Fixture) {
+		c := f.CreateConversation()
+		_, err := NewServer(f.Repository, &fakeAssistant{}).Replay(ctx, ReplayOptions{ConversationID: c.ID.Hex(), Prompt: "Be brief."})
+		if te, ok := err.(twirp.Error); !ok || te.Code() != twirp.Unimplemented {
+			t.Errorf("Replay error = %v, want twirp.Unimplemented", err)
+		}
+	}))
+}
@@ -0,0 +1,242 @@
+package chat
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"hash/fnv"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/acai-travel/tech-challenge/internal/chat/model"
+)
+
+// rolloutInterval is how often the signals of an active rollout are checked, and servers
+// pick up the decisions of the others.
+const rolloutInterval = time.Minute
+
+// PromptRollout is a prompt version replacing the current one of an assistant: the new
+// (green) version answers a share of its conversations while the quality of its replies is
+// watched, and the previous (blue) one takes them back if the signals degrade beyond the
+// thresholds within the window. Otherwise the new version answers every conversation once
+// the window ends.
+type PromptRollout struct {
+	// Name identifies the version across restarts and servers, e.g. "travel-prompt-v2"; a
+	// rolled back version is only tried again under another name.
+	Name string
+	// Assistant answers with the new version.
+	Assistant Assistant
+	// Of is the name of the assistant replaced; empty is DefaultAssistant.
+	Of string
+	// Percent of the conversations of Of answered by the new version while it is watched;
+	// zero is 100. Conversations stick to the version they got.
+	Percent float64
+	// Window is how long the new version is watched; zero is a day.
+	Window     time.Duration
+	Thresholds RolloutThresholds
+}
+
+// RolloutThresholds are the signals a new prompt version may reach before it is rolled back.
+// Zero values use those of DefaultRolloutThresholds.
+type RolloutThresholds struct {
+	// MaxErrorRate is the share of replies that may fail, e.g. 0.05.
+	MaxErrorRate float64
+	// MaxRefusalRate is the share of replies the safety policy may refuse.
+	MaxRefusalRate float64
+	// MinReplies are needed before error and refusal rates are judged.
+	MinReplies int64
+	// MinPositiveRatio is the share of rated replies that must be rated positively.
+	MinPositiveRatio float64
+	// MinRatings are needed before the ratio of positive ratings is judged.
+	MinRatings int64
+}
+
+// DefaultRolloutThresholds roll back new versions failing or refusing more than one reply in
+// twenty, or rated positively less than three times in five.
+var DefaultRolloutThresholds = RolloutThresholds{
+	MaxErrorRate:     0.05,
+	MaxRefusalRate:   0.05,
+	MinReplies:       50,
+	MinPositiveRatio: 0.6,
+	MinRatings:       20,
+}
+
+// degraded returns why signals s are beyond the thresholds, or "" if they aren't.
+func (t RolloutThresholds) degraded(s model.RolloutSignals) string {
+	d := DefaultRolloutThresholds
+	t.MaxErrorRate = cmp.Or(t.MaxErrorRate, d.MaxErrorRate)
+	t.MaxRefusalRate = cmp.Or(t.MaxRefusalRate, d.MaxRefusalRate)
+	t.MinReplies = cmp.Or(t.MinReplies, d.MinReplies)
+	t.MinPositiveRatio = cmp.Or(t.MinPositiveRatio, d.MinPositiveRatio)
+	t.MinRatings = cmp.Or(t.MinRatings, d.MinRatings)
+
+	if s.Replies >= t.MinReplies {
+		if r := float64(s.Errors) / float64(s.Replies); r > t.MaxErrorRate {
+			return fmt.Sprintf("error rate %.1f%% is above %.1f%%", r*100, t.MaxErrorRate*100)
+		}
+		if r := float64(s.Refusals) / float64(s.Replies); r > t.MaxRefusalRate {
+			return fmt.Sprintf("refusal rate %.1f%% is above %.1f%%", r*100, t.MaxRefusalRate*100)
+		}
+	}
+	if n := s.Positive + s.Negative; n >= t.MinRatings {
+		if r := float64(s.Positive) / float64(n); r < t.MinPositiveRatio {
+			return fmt.Sprintf("positive ratings %.1f%% are below %.1f%%", r*100, t.MinPositiveRatio*100)
+		}
+	}
+	return ""
+}
+
+// rollout runs a PromptRollout. Until RunPromptRollout loaded its state, the previous
+// version answers.
+type rollout struct {
+	PromptRollout
+	repo *model.Repository
+
+	mu    sync.RWMutex
+	state *model.PromptRollout
+}
+
+// EnablePromptRollout answers the conversations of the assistant r.Of with r.Assistant,
+// rolling back to the registered one if r's replies fail, are refused or are rated poorly.
+// Its state is shared through the repository by RunPromptRollout, which must run for the new
+// version to answer. It should be called after registering r.Of.
+func (s *Server) EnablePromptRollout(r PromptRollout) {
+	r.Of = cmp.Or(r.Of, DefaultAssistant)
+	r.Percent = cmp.Or(r.Percent, 100)
+	r.Window = cmp.Or(r.Window, 24*time.Hour)
+	s.rollout = &rollout{PromptRollout: r, repo: s.repo}
+}
+
+// RunPromptRollout starts the rollout enabled by EnablePromptRollout, or resumes it, and
+// decides it when its signals degrade or its window ends, until ctx is done. Every server
+// answering with the rollout should run it: they count signals together, and follow the
+// decision of the first one to take it.
+func (s *Server) RunPromptRollout(ctx context.Context) {
+	r := s.rollout
+	if r == nil {
+		return
+	}
+
+	ticker := time.NewTicker(rolloutInterval)
+	defer ticker.Stop()
+	for {
+		if err := r.check(ctx, time.Now()); err != nil {
+			slog.ErrorContext(ctx, "Failed to check the prompt rollout", "rollout", r.Name, "error", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// check loads the state of the rollout, deciding it if it is still active and its signals
+// degraded or its window ended at now.
+func (r *rollout) check(ctx context.Context, now time.Time) error {
+	state, err := r.repo.StartPromptRollout(ctx, r.Name, now)
+	if err != nil {
+		return err
+	}
+	if state.State == model.RolloutActive {
+		decision, reason := model.RolloutActive, r.Thresholds.degraded(state.Signals)
+		switch {
+		case reason != "":
+			decision = model.RolloutRolledBack
+		case !now.Before(state.StartedAt.Add(r.Window)):
+			decision, reason = model.RolloutPromoted, "no signal degraded within the window"
+		}
+		if decision != model.RolloutActive {
+			if _, err := r.repo.DecidePromptRollout(ctx, r.Name, decision, reason, now); err != nil {
+				return err
+			}
+			// Another server may have decided first; theirs stands.
+			if state, err = r.repo.FindPromptRollout(ctx, r.Name); err != nil {
+				return err
+			}
+		}
+	}
+
+	r.mu.Lock()
+	prev := r.state
+	r.state = state
+	r.mu.Unlock()
+	if prev == nil || prev.State != state.State {
+		log := slog.InfoContext
+		if state.State == model.RolloutRolledBack {
+			log = slog.WarnContext
+		}
+		log(ctx, "Prompt rollout "+string(state.State), "rollout", r.Name, "assistant", r.Of, "reason", state.Reason,
+			"replies", state.Signals.Replies, "errors", state.Signals.Errors, "refusals", state.Signals.Refusals,
+			"positive", state.Signals.Positive, "negative", state.Signals.Negative)
+	}
+	return nil
+}
+
+// answers reports whether the new version answers conv, or answered it at t.
+func (r *rollout) answers(conv *model.Conversation, t time.Time) bool {
+	if r == nil || cmp.Or(conv.Assistant, DefaultAssistant) != r.Of {
+		return false
+	}
+	r.mu.RLock()
+	state := r.state
+	r.mu.RUnlock()
+	switch {
+	case state == nil || t.Before(state.StartedAt):
+		return false
+	case state.State == model.RolloutPromoted:
+		return t.After(*state.DecidedAt) || r.sampled(conv)
+	case state.State == model.RolloutRolledBack:
+		return t.Before(*state.DecidedAt) && r.sampled(conv)
+	default:
+		return r.sampled(conv)
+	}
+}
+
+// sampled reports whether conv is among the Percent of conversations the new version answers.
+func (r *rollout) sampled(conv *model.Conversation) bool {
+	h := fnv.New32a()
+	h.Write(conv.ID[:])
+	return float64(h.Sum32()%10_000) < r.Percent*100
+}
+
+// active reports whether the rollout is still watching the signals of the new version.
+func (r *rollout) active() bool {
+	if r == nil {
+		return false
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.state != nil && r.state.State == model.RolloutActive
+}
+
+// record adds signals of the new version to the rollout, if it is still active. Failing to is
+// logged but non-fatal: the rollout is merely judged on fewer replies.
+func (r *rollout) record(ctx context.Context, s model.RolloutSignals) {
+	if !r.active() {
+		return
+	}
+	wctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+	defer cancel()
+	if err := r.repo.AddRolloutSignals(wctx, r.Name, s); err != nil {
+		slog.ErrorContext(ctx, "Failed to record prompt rollout signals", "rollout", r.Name, "error", err)
+	}
+}
+
+// rated records that a reply of conv written at t was rated, replacing the previous rating.
+func (r *rollout) rated(ctx context.Context, conv *model.Conversation, t time.Time, prev, rating model.Rating) {
+	if prev == rating || !r.answers(conv, t) {
+		return
+	}
+	var s model.RolloutSignals
+	for rating, n := range map[model.Rating]int64{prev: -1, rating: 1} {
+		switch rating {
+		case model.RatingPositive:
+			s.Positive += n
+		case model.RatingNegative:
+			s.Negative += n
+		}
+	}
+	r.record(ctx, s)
+}
@@ -0,0 +1,165 @@
+package chat
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/acai-travel/tech-challenge/internal/chat/model"
+	. "github.com/acai-travel/tech-challenge/internal/chat/testing"
+	"github.com/acai-travel/tech-challenge/internal/pb"
+	"github.com/twitchtv/twirp"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestRolloutThresholds(t *testing.T) {
+	for name, c := range map[string]struct {
+		signals  model.RolloutSignals
+		degraded bool
+	}{
+		"healthy":                  {model.RolloutSignals{Replies: 100, Errors: 2, Refusals: 1, Positive: 30, Negative: 5}, false},
+		"too few replies to judge": {model.RolloutSignals{Replies: 10, Errors: 5}, false},
+		"errors":                   {model.RolloutSignals{Replies: 100, Errors: 6}, true},
+		"refusals":                 {model.RolloutSignals{Replies: 100, Refusals: 10}, true},
+		"poorly rated":             {model.RolloutSignals{Replies: 100, Positive: 10, Negative: 15}, true},
+		"too few ratings to judge": {model.RolloutSignals{Replies: 100, Negative: 15}, false},
+	} {
+		if got := (RolloutThresholds{}).degraded(c.signals); (got != "") != c.degraded {
+			t.Errorf("%s: degraded = %q, want degraded %v", name, got, c.degraded)
+		}
+	}
+
+	strict := RolloutThresholds{MaxErrorRate: 0.01, MinReplies: 10}
+	if got := strict.degraded(model.RolloutSignals{Replies: 10, Errors: 1}); got != "error rate 10.0% is above 1.0%" {
+		t.Errorf("degraded = %q, want the error rate", got)
+	}
+}
+
+func TestRollout_Answers(t *testing.T) {
+	start := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	decided := start.Add(time.Hour)
+	r := &rollout{PromptRollout: PromptRollout{Of: DefaultAssistant, Percent: 100}}
+	conv := &model.Conversation{ID: primitive.NewObjectID()}
+
+	if r.answers(conv, start) {
+		t.Error("answered before the state was loaded")
+	}
+
+	r.state = &model.PromptRollout{State: model.RolloutActive, StartedAt: start}
+	if !r.answers(conv, start.Add(time.Minute)) || r.answers(conv, start.Add(-time.Minute)) {
+		t.Error("should answer the conversations of its assistant once started")
+	}
+	if r.answers(&model.Conversation{ID: conv.ID, Assistant: "travel"}, start.Add(time.Minute)) {
+		t.Error("answered the conversation of another assistant")
+	}
+
+	r.state = &model.PromptRollout{State: model.RolloutRolledBack, StartedAt: start, DecidedAt: &decided}
+	if !r.answers(conv, start.Add(time.Minute)) || r.answers(conv, decided.Add(time.Minute)) {
+		t.Error("should only have answered until rolled back")
+	}
+
+	t.Run("percent", func(t *testing.T) {
+		r := &rollout{PromptRollout: PromptRollout{Of: DefaultAssistant, Percent: 20}}
+		r.state = &model.PromptRollout{State: model.RolloutActive, StartedAt: start}
+		n := 0
+		for range 1_000 {
+			if r.answers(&model.Conversation{ID: primitive.NewObjectID()}, decided) {
+				n++
+			}
+		}
+		if n < 150 || n > 250 {
+			t.Errorf("answered %d of 1000 conversations, want about 200", n)
+		}
+
+		r.state = &model.PromptRollout{State: model.RolloutPromoted, StartedAt: start, DecidedAt: &decided}
+		if !r.answers(&model.Conversation{ID: primitive.NewObjectID()}, decided.Add(time.Minute)) {
+			t.Error("should answer every conversation once promoted")
+		}
+	})
+}
+
+func TestServer_PromptRollout(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("rolls back failing replies", WithFixture(func(t *testing.T, f *Fixture) {
+		blue := &fakeAssistant{replyFn: func(context.Context, *model.Conversation) (string, error) { return "blue", nil }}
+		green := &fakeAssistant{replyFn: func(context.Context, *model.Conversation) (string, error) { return "", errors.New("boom") }}
+		srv := NewServer(f.Repository, blue)
+		name := "rollout-" + primitive.NewObjectID().Hex()
+		srv.EnablePromptRollout(PromptRollout{Name: name, Assistant: green, Thresholds: RolloutThresholds{MinReplies: 2}})
+
+		conv := f.CreateConversation()
+		if reply, _, _ := srv.generateReply(ctx, conv); reply != "blue" {
+			t.Fatalf("reply = %q before the rollout started, want the previous version's", reply)
+		}
+		if err := srv.rollout.check(ctx, time.Now()); err != nil {
+			t.Fatal(err)
+		}
+		for range 2 {
+			if _, _, err := srv.generateReply(ctx, conv); err == nil {
+				t.Fatal("expected the new version to answer, and fail")
+			}
+		}
+
+		if err := srv.rollout.check(ctx, time.Now()); err != nil {
+			t.Fatal(err)
+		}
+		state, err := f.FindPromptRollout(ctx, name)
+		if err != nil || state.State != model.RolloutRolledBack || state.Signals.Errors != 2 {
+			t.Fatalf("rollout = %+v, %v, want rolled back after 2 errors", state, err)
+		}
+		if reply, _, _ := srv.generateReply(ctx, conv); reply != "blue" {
+			t.Errorf("reply = %q after the rollback, want the previous version's", reply)
+		}
+	}))
+
+	t.Run("promotes after the window", WithFixture(func(t *testing.T, f *Fixture) {
+		srv := NewServer(f.Repository, &fakeAssistant{})
+		name := "rollout-" + primitive.NewObjectID().Hex()
+		srv.EnablePromptRollout(PromptRollout{Name: name, Assistant: &fakeAssistant{}, Window: time.Hour})
+
+		now := time.Now()
+		if err := srv.rollout.check(ctx, now); err != nil {
+			t.Fatal(err)
+		}
+		if err := srv.rollout.check(ctx, now.Add(time.Hour)); err != nil {
+			t.Fatal(err)
+		}
+		if state, err := f.FindPromptRollout(ctx, name); err != nil || state.State != model.RolloutPromoted {
+			t.Fatalf("rollout = %+v, %v, want promoted", state, err)
+		}
+	}))
+
+	t.Run("counts ratings", WithFixture(func(t *testing.T, f *Fixture) {
+		srv := NewServer(f.Repository, &fakeAssistant{})
+		name := "rollout-" + primitive.NewObjectID().Hex()
+		srv.EnablePromptRollout(PromptRollout{Name: name, Assistant: &fakeAssistant{}})
+		if err := srv.rollout.check(ctx, time.Now().Add(-time.Minute)); err != nil {
+			t.Fatal(err)
+		}
+
+		reply := &model.Message{ID: primitive.NewObjectID(), Role: model.RoleAssistant, Content: "Sunny.", CreatedAt: time.Now()}
+		conv := f.CreateConversation(func(c *model.Conversation) { c.Messages = append(c.Messages, reply) })
+
+		rate := func(r pb.MessageRating) {
+			t.Helper()
+			out, err := srv.RateMessage(ctx, &pb.RateMessageRequest{ConversationId: conv.ID.Hex(), MessageId: reply.ID.Hex(), Rating: r})
+			if err != nil || out.GetMessage().GetRating() != r {
+				t.Fatalf("RateMessage = %v, %v, want rated %v", out, err, r)
+			}
+		}
+		rate(pb.MessageRating_MESSAGE_RATING_POSITIVE)
+		rate(pb.MessageRating_MESSAGE_RATING_NEGATIVE)
+
+		state, err := f.FindPromptRollout(ctx, name)
+		if err != nil || state.Signals.Positive != 0 || state.Signals.Negative != 1 {
+			t.Fatalf("signals = %+v, %v, want only the latest rating", state.Signals, err)
+		}
+
+		_, err = srv.RateMessage(ctx, &pb.RateMessageRequest{ConversationId: conv.ID.Hex(), MessageId: conv.Messages[0].ID.Hex(), Rating: pb.MessageRating_MESSAGE_RATING_POSITIVE})
+		if twirpCode(err) != twirp.InvalidArgument {
+			t.Errorf("expected InvalidArgument rating a user message, got %v", err)
+		}
+	}))
+}
@@ -0,0 +1,194 @@
+package chat
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/acai-travel/tech-challenge/internal/chat/assistant"
+	"github.com/acai-travel/tech-challenge/internal/chat/model"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Embedder turns texts into vectors whose cosine similarity reflects how related they are.
+type Embedder interface {
+	EmbeddingModel() string
+	Embed(ctx context.Context, texts []string) ([][]float32, error)
+}
+
+// maxSemanticCandidates bounds how many of a user's most recent messages the scan and
+// memory vector stores search. Personal histories rarely have more.
+const maxSemanticCandidates = 5_000
+
+// semanticIndex embeds messages in the background and searches them by similarity. Like
+// the reconciler, its queue lives in memory: messages whose job is dropped or lost on
+// restart are simply not searchable.
+type semanticIndex struct {
+	store    VectorStore
+	embedder Embedder
+	queue    chan indexJob
+	once     sync.Once
+}
+
+type indexJob struct {
+	userID         string
+	conversationID primitive.ObjectID
+	messages       []*model.Message
+}
+
+func newSemanticIndex(store VectorStore, e Embedder, size int) *semanticIndex {
+	return &semanticIndex{
+		store:    store,
+		embedder: e,
+		queue:    make(chan indexJob, size),
+	}
+}
+
+// Enqueue schedules messages of a user's conversation for embedding. It never blocks and
+// reports false if the queue is full.
+func (x *semanticIndex) Enqueue(userID string, conversationID primitive.ObjectID, msgs ...*model.Message) bool {
+	x.once.Do(func() { go x.run() })
+
+	select {
+	case x.queue <- indexJob{userID: userID, conversationID: conversationID, messages: msgs}:
+		return true
+	default:
+		return false
+	}
+}
+
+func (x *semanticIndex) run() {
+	for job := range x.queue {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		err := x.index(ctx, job)
+		cancel()
+
+		if err != nil {
+			slog.Warn("Failed to index messages for semantic search", "conversation_id", job.conversationID.Hex(), "error", err)
+		}
+	}
+}
+
+func (x *semanticIndex) index(ctx context.Context, job indexJob) error {
+	texts := make([]string, len(job.messages))
+	for i, m := range job.messages {
+		texts[i] = m.Content
+	}
+
+	vectors, err := x.embedder.Embed(ctx, texts)
+	if err != nil {
+		return err
+	}
+	if len(vectors) != len(texts) {
+		return fmt.Errorf("expected %d embeddings, got %d", len(texts), len(vectors))
+	}
+
+	embeddings := make([]*model.MessageEmbedding, len(job.messages))
+	for i, m := range job.messages {
+		embeddings[i] = &model.MessageEmbedding{
+			MessageID:      m.ID,
+			ConversationID: job.conversationID,
+			UserID:         job.userID,
+			Role:           m.Role,
+			Content:        m.Content,
+			Model:          x.embedder.EmbeddingModel(),
+			Vector:         vectors[i],
+			CreatedAt:      m.CreatedAt,
+		}
+	}
+
+	return writeWithRetry(ctx, 3, 100*time.Millisecond, func(ctx context.Context) error {
+		return x.store.Save(ctx, embeddings...)
+	})
+}
+
+// Search returns up to limit of the user's messages most similar to query, optionally
+// excluding one conversation.
+func (x *semanticIndex) Search(ctx context.Context, userID, query string, limit int, exclude primitive.ObjectID) ([]model.ScoredEmbedding, error) {
+	vectors, err := x.embedder.Embed(ctx, []string{query})
+	if err != nil {
+		return nil, err
+	}
+	if len(vectors) != 1 {
+		return nil, fmt.Errorf("expected 1 embedding, got %d", len(vectors))
+	}
+
+	return x.store.Search(ctx, model.EmbeddingQuery{
+		UserID:              userID,
+		Model:               x.embedder.EmbeddingModel(),
+		Vector:              vectors[0],
+		Limit:               limit,
+		ExcludeConversation: exclude,
+	})
+}
+
+// rankBySimilarity returns the limit candidates closest to query, most similar first.
+func rankBySimilarity(query []float32, candidates []*model.MessageEmbedding, limit int) []model.ScoredEmbedding {
+	hits := make([]model.ScoredEmbedding, 0, len(candidates))
+	for _, e := range candidates {
+		hits = append(hits, model.ScoredEmbedding{Embedding: e, Score: cosine(query, e.Vector)})
+	}
+
+	slices.SortStableFunc(hits, func(a, b model.ScoredEmbedding) int { return cmp.Compare(b.Score, a.Score) })
+	if len(hits) > limit {
+		hits = hits[:limit]
+	}
+	return hits
+}
+
+// cosine returns the cosine similarity of two vectors, or 0 if they can't be compared.
+func cosine(a, b []float32) float64 {
+	if len(a) != len(b) || len(a) == 0 {
+		return 0
+	}
+
+	var dot, na, nb float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		na += float64(a[i]) * float64(a[i])
+		nb += float64(b[i]) * float64(b[i])
+	}
+	if na == 0 || nb == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(na) * math.Sqrt(nb))
+}
+
+// minRecallScore drops weak matches from recall results, so the model says it found nothing
+// rather than answering from an unrelated message.
+const minRecallScore = 0.25
+
+// recaller searches a user's other conversations for the recall_past_conversations tool.
+type recaller struct {
+	index          *semanticIndex
+	userID         string
+	conversationID primitive.ObjectID
+}
+
+func (r recaller) Recall(ctx context.Context, query string, limit int) ([]assistant.Memory, error) {
+	// The current conversation is already in the model's context.
+	hits, err := r.index.Search(ctx, r.userID, query, limit, r.conversationID)
+	if err != nil {
+		return nil, err
+	}
+
+	memories := make([]assistant.Memory, 0, len(hits))
+	for _, h := range hits {
+		if h.Score < minRecallScore {
+			continue
+		}
+		memories = append(memories, assistant.Memory{
+			ConversationID: h.Embedding.ConversationID.Hex(),
+			Role:           h.Embedding.Role,
+			Content:        h.Embedding.Content,
+			CreatedAt:      h.Embedding.CreatedAt,
+			Score:          h.Score,
+		})
+	}
+	return memories, nil
+}
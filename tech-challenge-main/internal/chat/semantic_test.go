@@ -0,0 +1,49 @@
+package chat
+
+import (
+	"math"
+	"testing"
+
+	"github.com/acai-travel/tech-challenge/internal/chat/model"
+)
+
+func TestCosine(t *testing.T) {
+	cases := []struct {
+		name string
+		a, b []float32
+		want float64
+	}{
+		{"identical", []float32{1, 2, 3}, []float32{1, 2, 3}, 1},
+		{"scaled", []float32{1, 0}, []float32{5, 0}, 1},
+		{"orthogonal", []float32{1, 0}, []float32{0, 1}, 0},
+		{"opposite", []float32{1, 1}, []float32{-1, -1}, -1},
+		{"length mismatch", []float32{1, 0}, []float32{1, 0, 0}, 0},
+		{"zero vector", []float32{0, 0}, []float32{1, 0}, 0},
+		{"empty", nil, nil, 0},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := cosine(tc.a, tc.b); math.Abs(got-tc.want) > 1e-9 {
+				t.Fatalf("cosine(%v, %v) = %v, want %v", tc.a, tc.b, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestRankBySimilarity(t *testing.T) {
+	hotel := &model.MessageEmbedding{Content: "hotel", Vector: []float32{1, 0.1}}
+	flight := &model.MessageEmbedding{Content: "flight", Vector: []float32{0.5, 0.5}}
+	weather := &model.MessageEmbedding{Content: "weather", Vector: []float32{0, 1}}
+
+	hits := rankBySimilarity([]float32{1, 0}, []*model.MessageEmbedding{weather, flight, hotel}, 2)
+	if len(hits) != 2 {
+		t.Fatalf("expected 2 hits, got %d", len(hits))
+	}
+	if hits[0].Embedding != hotel || hits[1].Embedding != flight {
+		t.Fatalf("unexpected order: %s, %s", hits[0].Embedding.Content, hits[1].Embedding.Content)
+	}
+	if hits[0].Score < hits[1].Score {
+		t.Fatalf("expected scores in descending order, got %v then %v", hits[0].Score, hits[1].Score)
+	}
+}
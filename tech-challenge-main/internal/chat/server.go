@@ -4,16 +4,29 @@ import (
 	"context"
 	"crypto/sha256"
 	"encoding/hex"
+	"errors"
+	"fmt"
 	"log/slog"
+	"math"
+	"os"
+	"slices"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	lru "github.com/hashicorp/golang-lru/v2"
 	"golang.org/x/sync/errgroup"
 	"golang.org/x/sync/singleflight"
 
+	"github.com/acai-travel/tech-challenge/internal/audit"
+	"github.com/acai-travel/tech-challenge/internal/auth"
+	"github.com/acai-travel/tech-challenge/internal/chat/assistant"
 	"github.com/acai-travel/tech-challenge/internal/chat/model"
+	"github.com/acai-travel/tech-challenge/internal/logx"
 	"github.com/acai-travel/tech-challenge/internal/pb"
+	"github.com/acai-travel/tech-challenge/internal/pii"
+	"github.com/acai-travel/tech-challenge/internal/quota"
+	"github.com/acai-travel/tech-challenge/internal/textx"
 	"github.com/twitchtv/twirp"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
@@ -26,29 +39,253 @@ type Assistant interface {
 }
 
 type Server struct {
-	repo   *model.Repository
-	assist Assistant
+	repo       *model.Repository
+	assistants *AssistantRegistry
 
 	// Caching for titles
-	titleLRU *lru.Cache[string, string]
-	titleSF  singleflight.Group
+	titleLRU      *lru.Cache[string, string]
+	titleSF       singleflight.Group
+	titleInflight atomic.Int64
+
+	// Models conversations may select via GenerationSettings
+	allowedModels []string
+
+	// Background writes for conversations whose final update failed
+	reconciler *reconciler
+
+	// Time limits for generating titles and replies
+	budget budget
+
+	// Replies being generated, for CancelGeneration
+	running runningGenerations
+
+	// Embeds messages for SearchSemantic and recall; nil until EnableSemanticSearch
+	semantic *semanticIndex
+
+	// Summarizes long conversations; nil until EnableSummaries
+	compactor *compactor
+
+	// Uploaded files; disabled until EnableAttachments
+	attachments attachments
+
+	// Conversation archives; disabled until EnableExportArchives
+	exports exports
+
+	// Detects the language of new conversations; nil until EnableLanguageDetection
+	languages LanguageDetector
+
+	// Tags personal data in user messages; nil until EnablePIIDetection
+	pii *pii.Policy
+
+	// Suggests follow-ups to replies; nil until EnableQuickReplies
+	quickReplies QuickReplySuggester
+
+	// Labels user messages with their intent; nil until EnableIntentAnalytics
+	intents *intentLabeler
+
+	// Gives new conversations the icon of their first message's intent, with EnableTitleIcons
+	titleIcons bool
+
+	// Sends digests to subscribed users; nil until EnableDigests
+	digests *digests
+
+	// Routes notifications to the channels users choose; nil until EnableNotifications
+	notifications *notifications
+
+	// Sends push notifications to users' devices; nil until EnablePush
+	push *pushChannel
+
+	// Answers emails; disabled until EnableEmail
+	email emailChannel
+
+	// Answers WhatsApp messages; disabled until EnableWhatsApp
+	whatsApp whatsAppChannel
+
+	// Serves the embeddable chat widget; disabled until EnableWidget
+	widget widget
+
+	// Daily allowances of users by plan; nil until EnableQuotas
+	quotas quota.Plans
+
+	// Throttles abusive users; nil until EnableAbuseDetection
+	abuse *abuseGuard
+
+	// Encrypts the CalDAV passwords of users; nil until EnableCalDAV
+	caldav *credentialBox
+
+	// Also answers a share of messages with a candidate; nil until EnableShadowing
+	shadow *shadow
+
+	// Answers with a new prompt version while its replies are good; nil until EnablePromptRollout
+	rollout *rollout
+
+	// Builds assistants with another prompt for Replay; nil until EnablePromptReplays
+	replayAssistant func(name, prompt string) (Assistant, error)
+
+	// Sessions this server saved recently, by user and token hash, see TrackSessions
+	sessions *lru.Cache[string, *model.Session]
 }
 
 // NewServer initializes the server with an in-memory LRU for titles.
 // Size is tunable; 10k entries is plenty for most deployments.
+// CHAT_ALLOWED_MODELS optionally overrides the comma-separated model allowlist.
+// CHAT_REQUEST_TIMEOUT, CHAT_TITLE_TIMEOUT, CHAT_BUDGET_MARGIN and CHAT_RPC_TIMEOUTS tune how
+// long generation may take.
+// assist becomes the default assistant; others can be added with RegisterAssistant.
 func NewServer(repo *model.Repository, assist Assistant) *Server {
 	cache, _ := lru.New[string, string](10_000)
+	sessions, _ := lru.New[string, *model.Session](10_000)
 	return &Server{
-		repo:     repo,
-		assist:   assist,
-		titleLRU: cache,
+		repo:          repo,
+		assistants:    NewAssistantRegistry(DefaultAssistant, assist),
+		titleLRU:      cache,
+		allowedModels: loadAllowedModels(),
+		reconciler:    newReconciler(1_000),
+		budget:        loadBudget(),
+		sessions:      sessions,
+	}
+}
+
+// RegisterAssistant makes a named assistant selectable per conversation. It should be
+// called at startup, before the server handles requests, and so should AllowModels and the
+// Enable methods.
+func (s *Server) RegisterAssistant(name string, a Assistant) {
+	s.assistants.Register(name, a)
+}
+
+// AllowModels replaces the models conversations may select, e.g. with a tenant's allowlist.
+func (s *Server) AllowModels(models []string) {
+	if len(models) > 0 {
+		s.allowedModels = models
+	}
+}
+
+// EnableSemanticSearch embeds new messages in the background with e into store, enabling
+// the SearchSemantic RPC and letting assistants recall past conversations.
+func (s *Server) EnableSemanticSearch(e Embedder, store VectorStore) {
+	s.semantic = newSemanticIndex(store, e, 1_000)
+}
+
+// EnableSummaries compacts long conversations in the background with sum, so replies send a
+// rolling summary plus the recent messages instead of the whole thread.
+func (s *Server) EnableSummaries(sum Summarizer) {
+	s.compactor = newCompactor(s.repo, sum, 100)
+}
+
+// EnablePIIDetection records the kinds of personal data found by p in each user message, so
+// clients and operators know which messages need restricted handling. Masking it before the
+// model sees it is up to the assistants.
+func (s *Server) EnablePIIDetection(p *pii.Policy) {
+	s.pii = p
+}
+
+// index schedules persisted messages for embedding, if semantic search is enabled, and
+// intent labeling, if intent analytics are.
+func (s *Server) index(ctx context.Context, conv *model.Conversation, msgs ...*model.Message) {
+	s.labelIntents(ctx, conv, msgs...)
+	s.embed(ctx, conv, msgs...)
+}
+
+// embed schedules persisted messages for embedding, if semantic search is enabled.
+func (s *Server) embed(ctx context.Context, conv *model.Conversation, msgs ...*model.Message) {
+	if s.semantic == nil {
+		return
+	}
+	if !s.semantic.Enqueue(auth.User(ctx), conv.ID, msgs...) {
+		slog.WarnContext(ctx, "Semantic index queue is full, messages will not be searchable", "conversation_id", conv.ID.Hex())
 	}
 }
 
+// ownerOf is the owner of the conversations the caller starts: identified users own theirs,
+// while anonymous ones are visible to all.
+func ownerOf(ctx context.Context) string {
+	if userID := auth.User(ctx); userID != auth.Anonymous {
+		return userID
+	}
+	return ""
+}
+
+// assistantFor returns the assistant answering in a conversation. Conversations naming an
+// assistant that is no longer registered fall back to the default one.
+func (s *Server) assistantFor(conv *model.Conversation) Assistant {
+	if a, ok := s.assistants.Get(conv.Assistant); ok {
+		return a
+	}
+	slog.Warn("Unknown conversation assistant, using default", "conversation_id", conv.ID.Hex(), "assistant", conv.Assistant)
+	a, _ := s.assistants.Get("")
+	return a
+}
+
+// describeConversation returns a conversation the caller may see.
+func (s *Server) describeConversation(ctx context.Context, id string) (*model.Conversation, error) {
+	return s.repo.DescribeVisibleConversation(ctx, id, auth.User(ctx), nil)
+}
+
+// Stats reports in-process diagnostics for the admin endpoint.
+func (s *Server) Stats() map[string]any {
+	stats := map[string]any{
+		"title_cache_size": s.titleLRU.Len(),
+		"title_inflight":   s.titleInflight.Load(),
+	}
+	if sh := s.shadow; sh != nil {
+		stats["shadow_runs"] = sh.runs.Load()
+		stats["shadow_failures"] = sh.failures.Load()
+		stats["shadow_skipped"] = sh.skipped.Load()
+	}
+	return stats
+}
+
+var defaultAllowedModels = []string{assistant.DefaultModel, "gpt-4o", "gpt-4o-mini", "gpt-4.1", "gpt-4.1-mini"}
+
+func loadAllowedModels() []string {
+	var models []string
+	for _, m := range strings.Split(os.Getenv("CHAT_ALLOWED_MODELS"), ",") {
+		if m = strings.TrimSpace(m); m != "" {
+			models = append(models, m)
+		}
+	}
+	if len(models) == 0 {
+		return defaultAllowedModels
+	}
+	return models
+}
+
 func (s *Server) StartConversation(ctx context.Context, req *pb.StartConversationRequest) (*pb.StartConversationResponse, error) {
 	if strings.TrimSpace(req.GetMessage()) == "" {
 		return nil, twirp.RequiredArgumentError("message")
 	}
+	if err := validateToolOptions(req.GetToolOptions()); err != nil {
+		return nil, err
+	}
+	a, ok := s.assistants.Get(req.GetAssistant())
+	if !ok {
+		return nil, twirp.InvalidArgumentError("assistant", "must be one of: "+strings.Join(s.assistants.Names(), ", "))
+	}
+	settings, err := s.generationSettings(req.GetSettings(), a)
+	if err != nil {
+		return nil, err
+	}
+	files, err := s.resolveAttachments(ctx, req.GetAttachmentIds())
+	if err != nil {
+		return nil, err
+	}
+	language, err := requestLanguage("language", req.GetLanguage())
+	if err != nil {
+		return nil, err
+	}
+	tags, err := validateTags(req.GetTags())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkAbuse(ctx, req.GetMessage()); err != nil {
+		return nil, err
+	}
+	if err := s.checkQuota(ctx); err != nil {
+		return nil, err
+	}
+
+	ctx = withToolOptions(ctx, req.GetToolOptions())
+	ctx = withVerbosity(ctx, req.GetVerbosity())
 
 	now := time.Now()
 	conversation := &model.Conversation{
@@ -57,45 +294,82 @@ func (s *Server) StartConversation(ctx context.Context, req *pb.StartConversatio
 		CreatedAt: now,
 		UpdatedAt: now,
 		Messages: []*model.Message{{
-			ID:        primitive.NewObjectID(),
-			Role:      model.RoleUser,
-			Content:   req.GetMessage(),
-			CreatedAt: now,
-			UpdatedAt: now,
+			ID:          primitive.NewObjectID(),
+			Role:        model.RoleUser,
+			Content:     req.GetMessage(),
+			CreatedAt:   now,
+			UpdatedAt:   now,
+			Attachments: files,
+			PII:         pii.Kinds(s.pii.Detect(req.GetMessage())),
+			DeviceID:    auth.Device(ctx),
 		}},
+		Settings:  settings,
+		Assistant: req.GetAssistant(),
+		Language:  language,
+		Tags:      tags,
+		Owner:     ownerOf(ctx),
 	}
 
+	audit.Conversation(ctx, conversation.ID.Hex())
+
 	// Persist early so we never lose the user's first message.
 	if err := s.repo.CreateConversation(ctx, conversation); err != nil {
 		return nil, err
 	}
+	if s.iconsEnabled() {
+		// Labeled for the icon below rather than in the background.
+		s.embed(ctx, conversation, conversation.Messages[0])
+	} else {
+		s.index(ctx, conversation, conversation.Messages[0])
+	}
+	ctx, done := s.trackGeneration(ctx, conversation, conversation.Messages[0])
+	defer done()
 
 	// Request-scoped timeout & cancellation for both calls.
-	ctxReq, cancelReq := context.WithTimeout(ctx, 30*time.Second)
+	b := s.budget.of(ctx)
+	ctxReq, cancelReq := b.start(ctx)
 	defer cancelReq()
 
-	// Adaptive title budget: up to 15s but never beyond req deadline - 500ms.
-	titleBudget := 15 * time.Second
-	if dl, ok := ctxReq.Deadline(); ok {
-		rem := time.Until(dl) - 500*time.Millisecond
-		if rem < titleBudget {
-			if rem <= 0 {
-				rem = 500 * time.Millisecond
-			}
-			titleBudget = rem
-		}
-	}
-
 	var (
-		title string
-		reply string
+		title    string
+		icon     string
+		reply    string
+		tools    []*model.ToolCall
+		detected string
+		similar  []*pb.SimilarConversation
 	)
 
 	g, gctx := errgroup.WithContext(ctxReq)
 
+	// Language of the first message, unless requested; the first reply follows the user
+	// anyway, the lock keeps later ones from switching.
+	if language == "" && s.languages != nil {
+		g.Go(func() error {
+			lctx, cancel := b.stage(gctx, "language", b.title)
+			defer cancel()
+
+			l, err := s.languages.DetectLanguage(lctx, req.GetMessage())
+			if err != nil {
+				slog.WarnContext(gctx, "Language detection failed; conversation is not locked to a language", "error", err)
+				return nil // non-fatal
+			}
+			detected, _ = assistant.CanonicalLanguage(l)
+			return nil
+		})
+	}
+
+	// Past conversations on the same topic, searched while the reply is generated.
+	g.Go(func() error {
+		sctx, cancel := b.stage(gctx, "similar", b.title)
+		defer cancel()
+
+		similar = s.similarConversations(sctx, conversation, req.GetSimilarConversations())
+		return nil // non-fatal
+	})
+
 	// Title (cached + singleflight), with its own sub-timeout
 	g.Go(func() error {
-		tctx, cancel := context.WithTimeout(gctx, titleBudget)
+		tctx, cancel := b.stage(gctx, "title", b.title)
 		defer cancel()
 
 		t, err := s.generateTitle(tctx, conversation)
@@ -107,44 +381,104 @@ func (s *Server) StartConversation(ctx context.Context, req *pb.StartConversatio
 		return nil
 	})
 
+	// Icon of the conversation's category, next to the title
+	if s.iconsEnabled() {
+		g.Go(func() error {
+			ictx, cancel := b.stage(gctx, "icon", b.title)
+			defer cancel()
+
+			icon = s.titleIcon(ictx, conversation)
+			return nil // non-fatal
+		})
+	}
+
 	// Reply (required)
 	g.Go(func() error {
-		r, err := s.generateReply(gctx, conversation)
+		rctx, cancel := b.stage(gctx, "reply", b.request)
+		defer cancel()
+
+		r, calls, err := s.generateReply(rctx, conversation)
 		if err != nil {
 			return err
 		}
-		reply = r
+		reply, tools = r, calls
 		return nil
 	})
 
 	// If reply errors or context cancels, this returns early and cancels the sibling.
 	if err := g.Wait(); err != nil {
-		return nil, twirp.InternalErrorWith(err)
+		s.recordFailedGeneration(ctx, conversation, conversation.Messages[0], err)
+		return nil, replyError(err)
 	}
 
 	// Update conversation with results
 	if title != "" {
 		conversation.Title = title
 	}
+	conversation.Icon = icon
+	if detected != "" {
+		conversation.Language = detected
+	}
 	now = time.Now()
 	conversation.UpdatedAt = now
-	conversation.Messages = append(conversation.Messages, &model.Message{
+	answer := &model.Message{
 		ID:        primitive.NewObjectID(),
 		Role:      model.RoleAssistant,
 		Content:   reply,
 		CreatedAt: now,
 		UpdatedAt: now,
-	})
+		Tools:     tools,
+	}
+	conversation.Messages = append(conversation.Messages, answer)
 
-	if err := s.repo.UpdateConversation(ctxReq, conversation); err != nil {
-		// Non-fatal: we already have the reply to return
-		slog.ErrorContext(ctxReq, "Failed to update conversation", "error", err)
+	// Non-fatal: we already have the reply to return, but the stored conversation must
+	// catch up with it. Write detached from the request so a disconnect doesn't abort it.
+	wctx, cancelWrite := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+	defer cancelWrite()
+
+	// The write may be reconciled later with a context that no longer carries the caller.
+	user := auth.User(ctx)
+	write := func(ctx context.Context) error {
+		return s.repo.WithTransaction(ctx, func(ctx context.Context) error {
+			if title != "" {
+				if err := s.repo.UpdateTitle(ctx, conversation.ID, title); err != nil {
+					return err
+				}
+			}
+			if icon != "" {
+				if err := s.repo.UpdateIcon(ctx, conversation.ID, icon); err != nil {
+					return err
+				}
+			}
+			if detected != "" {
+				if err := s.repo.UpdateLanguage(ctx, conversation.ID, detected); err != nil {
+					return err
+				}
+			}
+			if err := s.repo.AppendMessages(ctx, conversation.ID, answer); err != nil {
+				return err
+			}
+			// The reply is in the response, so the user has seen it.
+			return s.repo.MarkRead(ctx, conversation.ID, user, answer.ID)
+		})
 	}
 
+	if err := writeWithRetry(wctx, 3, 100*time.Millisecond, write); err != nil {
+		if s.reconciler.Enqueue(conversation.ID, write) {
+			slog.WarnContext(ctx, "Failed to update conversation, scheduled reconciliation", "conversation_id", conversation.ID.Hex(), "error", err)
+		} else {
+			slog.ErrorContext(ctx, "Failed to update conversation and reconciliation queue is full", "conversation_id", conversation.ID.Hex(), "error", err)
+		}
+	}
+	s.index(ctx, conversation, answer)
+
 	return &pb.StartConversationResponse{
-		ConversationId: conversation.ID.Hex(),
-		Title:          conversation.Title,
-		Reply:          reply,
+		ConversationId:       conversation.ID.Hex(),
+		Title:                conversation.Title,
+		Icon:                 icon,
+		Reply:                reply,
+		QuickReplies:         s.suggestQuickReplies(ctxReq, conversation, reply, req.GetQuickReplies()),
+		SimilarConversations: similar,
 	}, nil
 }
 
@@ -162,7 +496,10 @@ func (s *Server) generateTitle(ctx context.Context, conv *model.Conversation) (s
 
 	// Collapse duplicate inflight requests
 	v, err, _ := s.titleSF.Do(key, func() (any, error) {
-		t, err := s.assist.Title(ctx, conv)
+		s.titleInflight.Add(1)
+		defer s.titleInflight.Add(-1)
+
+		t, err := s.assistantFor(conv).Title(ctx, conv)
 		if err == nil {
 			nt := normalizeTitle(t)
 			if nt != "" {
@@ -178,10 +515,277 @@ func (s *Server) generateTitle(ctx context.Context, conv *model.Conversation) (s
 	return v.(string), nil
 }
 
-func (s *Server) generateReply(ctx context.Context, conv *model.Conversation) (string, error) {
+// appendMessage locks the conversation with id, loads it with load and appends msg. The
+// conversation stays locked until unlock is called, once the reply is stored, so the history
+// read next includes every earlier reply.
+func (s *Server) appendMessage(ctx context.Context, id string, load func(context.Context, string) (*model.Conversation, error), msg *model.Message) (*model.Conversation, func(), error) {
+	unlock, err := s.lockConversation(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	conv, err := load(ctx, id)
+	if err != nil {
+		unlock()
+		return nil, nil, err
+	}
+	if err := s.repo.AppendMessages(ctx, conv.ID, msg); err != nil {
+		unlock()
+		return nil, nil, twirp.InternalErrorWith(err)
+	}
+	conv.Messages = append(conv.Messages, msg)
+	return conv, unlock, nil
+}
+
+// sendReply generates and stores the reply to msg, the last message of conv, recording the
+// failure if there is none. Every way of sending a message but StartConversation, which
+// titles the conversation meanwhile, ends with it.
+func (s *Server) sendReply(ctx context.Context, conv *model.Conversation, msg *model.Message) (*model.Message, error) {
+	ctx, done := s.trackGeneration(ctx, conv, msg)
+	defer done()
+
+	b := s.budget.of(ctx)
+	ctx, cancel := b.start(ctx)
+	defer cancel()
+
+	rctx, cancelReply := b.stage(ctx, "reply", b.request)
+	reply, tools, err := s.generateReply(rctx, conv)
+	cancelReply()
+	if err != nil {
+		s.recordFailedGeneration(ctx, conv, msg, err)
+		return nil, replyError(err)
+	}
+
+	now := time.Now()
+	answer := &model.Message{
+		ID:        primitive.NewObjectID(),
+		Role:      model.RoleAssistant,
+		Content:   reply,
+		CreatedAt: now,
+		UpdatedAt: now,
+		Tools:     tools,
+	}
+
+	if err := s.repo.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.AppendMessages(ctx, conv.ID, answer); err != nil {
+			return err
+		}
+		if err := s.repo.MarkRead(ctx, conv.ID, auth.User(ctx), answer.ID); err != nil {
+			return err
+		}
+		// A failed earlier run of the same message is answered now.
+		failed, err := s.repo.FindFailedGeneration(ctx, conv.ID)
+		if err != nil || failed == nil || failed.MessageID != msg.ID {
+			return err
+		}
+		return s.repo.ResolveFailedGeneration(ctx, failed.ID)
+	}); err != nil {
+		return nil, twirp.InternalErrorWith(err)
+	}
+	conv.Messages = append(conv.Messages, answer)
+	s.index(ctx, conv, answer)
+	if s.compactor != nil {
+		s.compactor.Maybe(conv)
+	}
+	return answer, nil
+}
+
+// generateReply returns the reply to conv with the tool calls made for it.
+func (s *Server) generateReply(ctx context.Context, conv *model.Conversation) (string, []*model.ToolCall, error) {
 	// If you later add reply caching, be careful: replies are time- and context-sensitive.
 	// For now, call through.
-	return s.assist.Reply(ctx, conv)
+	ctx = logx.WithConversation(ctx, conv.ID.Hex())
+	if s.semantic != nil {
+		ctx = assistant.WithRecaller(ctx, recaller{index: s.semantic, userID: auth.User(ctx), conversationID: conv.ID})
+	}
+	if userID := auth.User(ctx); userID != auth.Anonymous {
+		profile := &profileLoader{repo: s.repo, userID: userID, caldav: s.caldav}
+		ctx = assistant.WithAliasResolver(ctx, profile)
+		ctx = assistant.WithUserCalendars(ctx, profile)
+		ctx = assistant.WithPreferences(ctx, profile)
+		if s.caldav != nil {
+			ctx = assistant.WithCalDAV(ctx, profile)
+		}
+	}
+
+	var calls []*model.ToolCall
+	ctx = assistant.WithProgress(ctx, func(p assistant.Progress) { calls = recordToolCall(calls, p) })
+
+	var used model.Usage
+	ctx = assistant.WithUsage(ctx, func(u model.Usage) { used = u })
+
+	a, rolledOut := s.assistantFor(conv), s.rollout.answers(conv, time.Now())
+	var refused bool
+	if rolledOut {
+		a = s.rollout.Assistant
+		ctx = assistant.WithRefusals(ctx, func(string) { refused = true })
+	}
+
+	start := time.Now()
+	reply, err := a.Reply(ctx, conv)
+	if err != nil && context.Cause(ctx) == errGenerationCancelled {
+		err = errGenerationCancelled
+	}
+	if rolledOut && err != errGenerationCancelled {
+		signals := model.RolloutSignals{Replies: 1}
+		if err != nil {
+			signals.Errors = 1
+		} else if refused {
+			signals.Refusals = 1
+		}
+		s.rollout.record(ctx, signals)
+	}
+	primary := model.ShadowBaseline{Reply: reply, ToolCalls: calls, Usage: used, LatencyMs: time.Since(start).Milliseconds()}
+	if err != nil {
+		primary.Error = err.Error()
+	}
+	s.shadowReply(ctx, conv, primary)
+
+	s.addUsage(ctx, conv, used)
+	s.addQuotaUsage(ctx, used, len(calls))
+	return reply, calls, err
+}
+
+// recordToolCall adds the tool call progress p reports to calls.
+func recordToolCall(calls []*model.ToolCall, p assistant.Progress) []*model.ToolCall {
+	if p.Kind == assistant.ProgressToolCalled {
+		return append(calls, &model.ToolCall{Tool: p.Tool, Call: p.Message})
+	}
+	// Tools run one at a time, so the outcome is that of the last call.
+	if n := len(calls); n > 0 && calls[n-1].Tool == p.Tool {
+		calls[n-1].Result, calls[n-1].Failed = p.Message, p.Kind == assistant.ProgressToolFailed
+	}
+	return calls
+}
+
+// addUsage adds what a reply consumed to the budget of conv, whether or not it succeeded.
+// Failing to is logged but non-fatal: the conversation is merely over budget later.
+func (s *Server) addUsage(ctx context.Context, conv *model.Conversation, u model.Usage) {
+	if u == (model.Usage{}) {
+		return
+	}
+	conv.Usage.Add(u)
+
+	wctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+	defer cancel()
+	if err := s.repo.AddUsage(wctx, conv.ID, u); err != nil {
+		slog.ErrorContext(ctx, "Failed to record conversation usage", "conversation_id", conv.ID.Hex(), "error", err)
+	}
+}
+
+// generationSettings validates requested generation overrides against the server allowlist
+// and the model a answers with.
+func (s *Server) generationSettings(in *pb.GenerationSettings, a Assistant) (*model.GenerationSettings, error) {
+	if in == nil {
+		return nil, nil
+	}
+
+	out := &model.GenerationSettings{
+		Model:           strings.TrimSpace(in.GetModel()),
+		Temperature:     in.Temperature,
+		MaxOutputTokens: in.GetMaxOutputTokens(),
+		MaxCostUSD:      in.GetMaxCostUsd(),
+		MaxTotalTokens:  in.GetMaxTotalTokens(),
+	}
+
+	name := out.Model
+	if name == "" {
+		name = assistantModel(a)
+	} else if !slices.Contains(s.allowedModels, name) {
+		return nil, twirp.InvalidArgumentError("settings.model", fmt.Sprintf("model %q is not allowed", name))
+	}
+
+	if t := out.Temperature; t != nil {
+		if *t < 0 || *t > 2 {
+			return nil, twirp.InvalidArgumentError("settings.temperature", "must be between 0 and 2")
+		}
+		if !assistant.SupportsTemperature(name) {
+			return nil, twirp.InvalidArgumentError("settings.temperature", fmt.Sprintf("model %q does not support temperature", name))
+		}
+	}
+
+	if out.MaxOutputTokens < 0 {
+		return nil, twirp.InvalidArgumentError("settings.max_output_tokens", "must not be negative")
+	}
+	if out.MaxCostUSD < 0 || math.IsNaN(out.MaxCostUSD) || math.IsInf(out.MaxCostUSD, 0) {
+		return nil, twirp.InvalidArgumentError("settings.max_cost_usd", "must be a non-negative amount")
+	}
+	if out.MaxTotalTokens < 0 {
+		return nil, twirp.InvalidArgumentError("settings.max_total_tokens", "must not be negative")
+	}
+
+	return out, nil
+}
+
+// assistantModel returns the model a replies with when the conversation doesn't choose one.
+func assistantModel(a Assistant) string {
+	if m, ok := a.(interface{ Model() string }); ok {
+		return m.Model()
+	}
+	return assistant.DefaultModel
+}
+
+// validateToolOptions rejects tool options that contradict themselves. Whether a tool
+// exists is only known to the assistant, which reports it via ErrInvalidToolPolicy.
+func validateToolOptions(opts *pb.ToolOptions) error {
+	force := opts.GetForceTool()
+	if force == "" {
+		return nil
+	}
+	if opts.GetDisableTools() {
+		return twirp.InvalidArgumentError("tool_options.force_tool", "cannot force a tool when tools are disabled")
+	}
+	if slices.Contains(opts.GetDenyTools(), force) {
+		return twirp.InvalidArgumentError("tool_options.force_tool", "cannot force a denied tool")
+	}
+	return nil
+}
+
+func withToolOptions(ctx context.Context, opts *pb.ToolOptions) context.Context {
+	if opts == nil {
+		return ctx
+	}
+	return assistant.WithToolPolicy(ctx, assistant.ToolPolicy{
+		Force:   opts.GetForceTool(),
+		Deny:    opts.GetDenyTools(),
+		Disable: opts.GetDisableTools(),
+	})
+}
+
+var verbosities = map[pb.Verbosity]assistant.Verbosity{
+	pb.Verbosity_VERBOSITY_CONCISE:  assistant.VerbosityConcise,
+	pb.Verbosity_VERBOSITY_DETAILED: assistant.VerbosityDetailed,
+	pb.Verbosity_VERBOSITY_BULLET:   assistant.VerbosityBullet,
+}
+
+func withVerbosity(ctx context.Context, v pb.Verbosity) context.Context {
+	if av, ok := verbosities[v]; ok {
+		return assistant.WithVerbosity(ctx, av)
+	}
+	return ctx
+}
+
+// withMaxOutputTokens caps the output tokens of the reply to a request asking for at most n;
+// 0 keeps the conversation's cap.
+func withMaxOutputTokens(ctx context.Context, n int64) context.Context {
+	if n > 0 {
+		return assistant.WithMaxOutputTokens(ctx, n)
+	}
+	return ctx
+}
+
+// replyError maps a reply generation failure to a twirp error.
+func replyError(err error) error {
+	if errors.Is(err, assistant.ErrInvalidToolPolicy) {
+		return twirp.InvalidArgumentError("tool_options", err.Error())
+	}
+	if errors.Is(err, errGenerationCancelled) {
+		return twirp.NewError(twirp.Canceled, "the reply was cancelled, use RetryFailedReply to generate it")
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		// The user's message is stored and the failure recorded, so the reply can be retried.
+		return twirp.NewError(twirp.DeadlineExceeded, "the assistant took too long to reply, use RetryFailedReply to try again")
+	}
+	return twirp.InternalErrorWith(err)
 }
 
 // ---- Cache key helpers ----
@@ -205,11 +809,7 @@ func normalizeForKey(s string) string {
 }
 
 func normalizeTitle(s string) string {
-	s = strings.TrimSpace(strings.ReplaceAll(s, "\n", " "))
-	if len(s) > 80 {
-		s = s[:80]
-	}
-	return s
+	return textx.Title(s, 80)
 }
 
 func (s *Server) ContinueConversation(ctx context.Context, req *pb.ContinueConversationRequest) (*pb.ContinueConversationResponse, error) {
@@ -219,43 +819,83 @@ func (s *Server) ContinueConversation(ctx context.Context, req *pb.ContinueConve
 	if strings.TrimSpace(req.GetMessage()) == "" {
 		return nil, twirp.RequiredArgumentError("message")
 	}
+	if err := validateToolOptions(req.GetToolOptions()); err != nil {
+		return nil, err
+	}
+	if req.GetMaxOutputTokens() < 0 {
+		return nil, twirp.InvalidArgumentError("max_output_tokens", "must not be negative")
+	}
+	if err := s.checkAbuse(ctx, req.GetMessage()); err != nil {
+		return nil, err
+	}
+	if err := s.checkQuota(ctx); err != nil {
+		return nil, err
+	}
 
-	conversation, err := s.repo.DescribeConversation(ctx, req.GetConversationId())
+	files, err := s.resolveAttachments(ctx, req.GetAttachmentIds())
 	if err != nil {
 		return nil, err
 	}
 
-	conversation.UpdatedAt = time.Now()
-	conversation.Messages = append(conversation.Messages, &model.Message{
-		ID:        primitive.NewObjectID(),
-		Role:      model.RoleUser,
-		Content:   req.GetMessage(),
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
-	})
+	ctx = withToolOptions(ctx, req.GetToolOptions())
+	ctx = withVerbosity(ctx, req.GetVerbosity())
+	ctx = withMaxOutputTokens(ctx, req.GetMaxOutputTokens())
 
-	reply, err := s.assist.Reply(ctx, conversation)
+	audit.Conversation(ctx, req.GetConversationId())
+	now := time.Now()
+	message := &model.Message{
+		ID:          primitive.NewObjectID(),
+		Role:        model.RoleUser,
+		Content:     req.GetMessage(),
+		CreatedAt:   now,
+		UpdatedAt:   now,
+		Attachments: files,
+		PII:         pii.Kinds(s.pii.Detect(req.GetMessage())),
+		DeviceID:    auth.Device(ctx),
+	}
+	// Persist the user's message before generating, so a failed reply can be retried.
+	conversation, unlock, err := s.appendMessage(ctx, req.GetConversationId(), s.describeConversation, message)
 	if err != nil {
-		return nil, twirp.InternalErrorWith(err)
+		return nil, err
 	}
+	defer unlock()
+	s.index(ctx, conversation, message)
 
-	conversation.Messages = append(conversation.Messages, &model.Message{
-		ID:        primitive.NewObjectID(),
-		Role:      model.RoleAssistant,
-		Content:   reply,
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
-	})
-
-	if err := s.repo.UpdateConversation(ctx, conversation); err != nil {
-		return nil, twirp.InternalErrorWith(err)
+	answer, err := s.sendReply(ctx, conversation, message)
+	if err != nil {
+		return nil, err
 	}
 
-	return &pb.ContinueConversationResponse{Reply: reply}, nil
+	return &pb.ContinueConversationResponse{
+		Reply:        answer.Content,
+		QuickReplies: s.suggestQuickReplies(ctx, conversation, answer.Content, req.GetQuickReplies()),
+	}, nil
 }
 
 func (s *Server) ListConversations(ctx context.Context, req *pb.ListConversationsRequest) (*pb.ListConversationsResponse, error) {
-	conversations, err := s.repo.ListConversations(ctx)
+	mask := req.GetReadMask()
+	if err := validateMask("read_mask", mask, &pb.Conversation{}); err != nil {
+		return nil, err
+	}
+	fields := storedFields(mask)
+
+	// Previews are only computed when asked for and in the mask.
+	if req.GetIncludePreview() && masked(mask, "preview") {
+		previews, err := s.repo.ListConversationPreviews(ctx, auth.User(ctx), req.GetIncludeArchived(), fields)
+		if err != nil {
+			return nil, twirp.InternalErrorWith(err)
+		}
+
+		resp := &pb.ListConversationsResponse{}
+		for _, p := range previews {
+			proto := p.Proto()
+			applyMask(proto, mask)
+			resp.Conversations = append(resp.Conversations, proto)
+		}
+		return resp, nil
+	}
+
+	conversations, err := s.repo.ListConversations(ctx, auth.User(ctx), req.GetIncludeArchived(), fields)
 	if err != nil {
 		return nil, twirp.InternalErrorWith(err)
 	}
@@ -263,7 +903,9 @@ func (s *Server) ListConversations(ctx context.Context, req *pb.ListConversation
 	resp := &pb.ListConversationsResponse{}
 	for _, conv := range conversations {
 		conv.Messages = nil // Clear messages to avoid sending large data
-		resp.Conversations = append(resp.Conversations, conv.Proto())
+		proto := conv.Proto()
+		applyMask(proto, mask)
+		resp.Conversations = append(resp.Conversations, proto)
 	}
 	return resp, nil
 }
@@ -272,13 +914,205 @@ func (s *Server) DescribeConversation(ctx context.Context, req *pb.DescribeConve
 	if req.GetConversationId() == "" {
 		return nil, twirp.RequiredArgumentError("conversation_id")
 	}
+	if err := validateHistoryOptions(req.GetHistory()); err != nil {
+		return nil, err
+	}
+	mask := req.GetReadMask()
+	if err := validateMask("read_mask", mask, &pb.Conversation{}); err != nil {
+		return nil, err
+	}
+	fields := storedFields(mask)
+	if fields != nil && req.GetHistory().GetSummaryOnly() {
+		fields = append(fields, "summary")
+	}
 
-	conversation, err := s.repo.DescribeConversation(ctx, req.GetConversationId())
+	audit.Conversation(ctx, req.GetConversationId())
+	conversation, err := s.repo.DescribeVisibleConversation(ctx, req.GetConversationId(), auth.User(ctx), fields)
 	if err != nil {
 		return nil, err
 	}
 	if conversation == nil {
 		return nil, twirp.NotFoundError("conversation not found")
 	}
-	return &pb.DescribeConversationResponse{Conversation: conversation.Proto()}, nil
+
+	proto := conversation.Proto()
+	if n := len(conversation.Messages); n > 0 && masked(mask, "failed_reply") {
+		// Non-fatal: the conversation is still worth showing without the notice.
+		failed, err := s.repo.FindFailedGeneration(ctx, conversation.ID)
+		if err != nil {
+			slog.WarnContext(ctx, "Failed to look up failed reply", "conversation_id", conversation.ID.Hex(), "error", err)
+		} else if failed != nil && failed.MessageID == conversation.Messages[n-1].ID {
+			proto.FailedReply = failedReplyNotice(failed)
+		}
+	}
+	if err := compactHistory(proto, conversation, req.GetHistory()); err != nil {
+		return nil, err
+	}
+	applyMask(proto, mask)
+
+	return &pb.DescribeConversationResponse{Conversation: proto}, nil
+}
+
+func (s *Server) RetryFailedReply(ctx context.Context, req *pb.RetryFailedReplyRequest) (*pb.RetryFailedReplyResponse, error) {
+	if req.GetConversationId() == "" {
+		return nil, twirp.RequiredArgumentError("conversation_id")
+	}
+	if err := s.checkQuota(ctx); err != nil {
+		return nil, err
+	}
+
+	audit.Conversation(ctx, req.GetConversationId())
+	unlock, err := s.lockConversation(ctx, req.GetConversationId())
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	conversation, err := s.describeConversation(ctx, req.GetConversationId())
+	if err != nil {
+		return nil, err
+	}
+
+	failed, err := s.repo.FindFailedGeneration(ctx, conversation.ID)
+	if err != nil {
+		return nil, twirp.InternalErrorWith(err)
+	}
+	if failed == nil {
+		return nil, twirp.NotFoundError("no failed reply to retry")
+	}
+
+	if len(conversation.Messages) == 0 {
+		return nil, twirp.NewError(twirp.FailedPrecondition, "conversation has no messages")
+	}
+	// Only the last message can be retried; if the user has moved on, the failure is moot.
+	last := conversation.Messages[len(conversation.Messages)-1]
+	if last.ID != failed.MessageID {
+		if err := s.repo.ResolveFailedGeneration(ctx, failed.ID); err != nil {
+			slog.ErrorContext(ctx, "Failed to resolve failed generation", "error", err)
+		}
+		return nil, twirp.NewError(twirp.FailedPrecondition, "the failed message is no longer the last message of the conversation")
+	}
+	ctx, done := s.trackGeneration(ctx, conversation, last)
+	defer done()
+
+	b := s.budget.of(ctx)
+	ctx, cancel := b.start(ctx)
+	defer cancel()
+
+	rctx, cancelReply := b.stage(ctx, "reply", b.request)
+	reply, tools, err := s.generateReply(rctx, conversation)
+	cancelReply()
+	if err != nil {
+		s.recordFailedGeneration(ctx, conversation, last, err)
+		return nil, replyError(err)
+	}
+
+	now := time.Now()
+	answer := &model.Message{
+		ID:        primitive.NewObjectID(),
+		Role:      model.RoleAssistant,
+		Content:   reply,
+		CreatedAt: now,
+		UpdatedAt: now,
+		Tools:     tools,
+	}
+
+	// Answer and resolution must land together, or a retry could answer the message twice.
+	if err := s.repo.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.AppendMessages(ctx, conversation.ID, answer); err != nil {
+			return err
+		}
+		if err := s.repo.MarkRead(ctx, conversation.ID, auth.User(ctx), answer.ID); err != nil {
+			return err
+		}
+		return s.repo.ResolveFailedGeneration(ctx, failed.ID)
+	}); err != nil {
+		return nil, twirp.InternalErrorWith(err)
+	}
+	s.index(ctx, conversation, answer)
+	if s.compactor != nil {
+		conversation.Messages = append(conversation.Messages, answer)
+		s.compactor.Maybe(conversation)
+	}
+
+	return &pb.RetryFailedReplyResponse{Reply: reply}, nil
+}
+
+func (s *Server) MarkRead(ctx context.Context, req *pb.MarkReadRequest) (*pb.MarkReadResponse, error) {
+	if req.GetConversationId() == "" {
+		return nil, twirp.RequiredArgumentError("conversation_id")
+	}
+
+	audit.Conversation(ctx, req.GetConversationId())
+	conversation, err := s.describeConversation(ctx, req.GetConversationId())
+	if err != nil {
+		return nil, err
+	}
+	if len(conversation.Messages) == 0 {
+		return &pb.MarkReadResponse{}, nil
+	}
+
+	last := conversation.Messages[len(conversation.Messages)-1]
+	if id := req.GetMessageId(); id != "" {
+		i := slices.IndexFunc(conversation.Messages, func(m *model.Message) bool { return m.ID.Hex() == id })
+		if i < 0 {
+			return nil, twirp.InvalidArgumentError("message_id", "is not a message of this conversation")
+		}
+		last = conversation.Messages[i]
+	}
+
+	if err := s.repo.MarkRead(ctx, conversation.ID, auth.User(ctx), last.ID); err != nil {
+		return nil, err
+	}
+
+	return &pb.MarkReadResponse{}, nil
+}
+
+const (
+	defaultSemanticLimit = 10
+	maxSemanticLimit     = 50
+)
+
+func (s *Server) SearchSemantic(ctx context.Context, req *pb.SearchSemanticRequest) (*pb.SearchSemanticResponse, error) {
+	if strings.TrimSpace(req.GetQuery()) == "" {
+		return nil, twirp.RequiredArgumentError("query")
+	}
+	if req.GetLimit() < 0 || req.GetLimit() > maxSemanticLimit {
+		return nil, twirp.InvalidArgumentError("limit", fmt.Sprintf("must be between 0 and %d", maxSemanticLimit))
+	}
+	if s.semantic == nil {
+		return nil, twirp.NewError(twirp.Unimplemented, "semantic search is not enabled")
+	}
+
+	limit := int(req.GetLimit())
+	if limit == 0 {
+		limit = defaultSemanticLimit
+	}
+
+	hits, err := s.semantic.Search(ctx, auth.User(ctx), req.GetQuery(), limit, primitive.NilObjectID)
+	if err != nil {
+		return nil, twirp.InternalErrorWith(err)
+	}
+
+	resp := &pb.SearchSemanticResponse{}
+	for _, h := range hits {
+		msg := &model.Message{ID: h.Embedding.MessageID, Role: h.Embedding.Role, Content: h.Embedding.Content, CreatedAt: h.Embedding.CreatedAt}
+		resp.Results = append(resp.Results, &pb.SearchSemanticResponse_Result{
+			ConversationId: h.Embedding.ConversationID.Hex(),
+			Message:        msg.Proto(),
+			Score:          h.Score,
+		})
+	}
+	return resp, nil
+}
+
+// recordFailedGeneration dead-letters a failed reply so it can be retried later. It runs
+// detached from ctx, which is often already cancelled when the reply timed out.
+func (s *Server) recordFailedGeneration(ctx context.Context, conv *model.Conversation, msg *model.Message, cause error) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+	defer cancel()
+
+	if err := s.repo.RecordFailedGeneration(ctx, conv.ID, msg.ID, cause); err != nil {
+		slog.ErrorContext(ctx, "Failed to record failed generation", "conversation_id", conv.ID.Hex(), "error", err)
+	}
 }
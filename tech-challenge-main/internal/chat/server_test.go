@@ -3,15 +3,20 @@ package chat
 import (
 	"context"
 	"errors"
+	"strings"
 	"sync"
 	"testing"
 	"time"
+	"unicode/utf8"
 
+	"github.com/acai-travel/tech-challenge/internal/auth"
 	"github.com/acai-travel/tech-challenge/internal/chat/model"
 	. "github.com/acai-travel/tech-challenge/internal/chat/testing"
 	"github.com/acai-travel/tech-challenge/internal/pb"
+	"github.com/acai-travel/tech-challenge/internal/pii"
 	"github.com/google/go-cmp/cmp"
 	"github.com/twitchtv/twirp"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"google.golang.org/protobuf/testing/protocmp"
 )
 
@@ -78,6 +83,171 @@ func TestServer_DescribeConversation(t *testing.T) {
 	}))
 }
 
+// keywordEmbedder embeds a text as one dimension per keyword it mentions.
+type keywordEmbedder []string
+
+func (k keywordEmbedder) EmbeddingModel() string { return "keywords" }
+
+func (k keywordEmbedder) Embed(_ context.Context, texts []string) ([][]float32, error) {
+	out := make([][]float32, len(texts))
+	for i, text := range texts {
+		out[i] = make([]float32, len(k))
+		for j, word := range k {
+			if strings.Contains(strings.ToLower(text), word) {
+				out[i][j] = 1
+			}
+		}
+	}
+	return out, nil
+}
+
+func TestServer_SearchSemantic(t *testing.T) {
+	alice := auth.WithUser(context.Background(), "alice-"+primitive.NewObjectID().Hex())
+
+	t.Run("disabled by default", func(t *testing.T) {
+		srv := NewServer(model.New(ConnectMongo()), nil)
+		_, err := srv.SearchSemantic(alice, &pb.SearchSemanticRequest{Query: "hotel"})
+		if te, ok := err.(twirp.Error); !ok || te.Code() != twirp.Unimplemented {
+			t.Fatalf("expected Unimplemented, got %v", err)
+		}
+	})
+
+	t.Run("finds indexed messages of the caller only", WithFixture(func(t *testing.T, f *Fixture) {
+		srv := NewServer(model.New(ConnectMongo()), &fakeAssistant{
+			replyFn: func(context.Context, *model.Conversation) (string, error) { return "Enjoy the hotel!", nil },
+		})
+		srv.EnableSemanticSearch(keywordEmbedder{"hotel", "weather"}, NewMemoryVectorStore(srv.repo))
+
+		c := f.CreateConversation()
+		if _, err := srv.ContinueConversation(alice, &pb.ContinueConversationRequest{ConversationId: c.ID.Hex(), Message: "I booked the Hotel Avenida"}); err != nil {
+			t.Fatalf("ContinueConversation error: %v", err)
+		}
+
+		var results []*pb.SearchSemanticResponse_Result
+		for deadline := time.Now().Add(5 * time.Second); time.Now().Before(deadline); time.Sleep(50 * time.Millisecond) {
+			out, err := srv.SearchSemantic(alice, &pb.SearchSemanticRequest{Query: "which hotel?", Limit: 1})
+			if err != nil {
+				t.Fatalf("SearchSemantic error: %v", err)
+			}
+			if results = out.GetResults(); len(results) > 0 && results[0].GetScore() > 0 {
+				break
+			}
+		}
+
+		if len(results) != 1 {
+			t.Fatalf("expected 1 result, got %d", len(results))
+		}
+		if got := results[0].GetConversationId(); got != c.ID.Hex() {
+			t.Errorf("conversation id: got %s want %s", got, c.ID.Hex())
+		}
+		if got := results[0].GetMessage().GetContent(); !strings.Contains(strings.ToLower(got), "hotel") {
+			t.Errorf("unexpected result content: %q", got)
+		}
+
+		bob := auth.WithUser(context.Background(), "bob-"+primitive.NewObjectID().Hex())
+		out, err := srv.SearchSemantic(bob, &pb.SearchSemanticRequest{Query: "which hotel?"})
+		if err != nil {
+			t.Fatalf("SearchSemantic error: %v", err)
+		}
+		if len(out.GetResults()) != 0 {
+			t.Errorf("expected no results for another user, got %d", len(out.GetResults()))
+		}
+	}))
+
+	t.Run("validates arguments", func(t *testing.T) {
+		srv := NewServer(model.New(ConnectMongo()), nil)
+		srv.EnableSemanticSearch(keywordEmbedder{"hotel"}, NewMemoryVectorStore(srv.repo))
+
+		for _, req := range []*pb.SearchSemanticRequest{{Query: " "}, {Query: "hotel", Limit: 51}} {
+			_, err := srv.SearchSemantic(alice, req)
+			if te, ok := err.(twirp.Error); !ok || te.Code() != twirp.InvalidArgument {
+				t.Errorf("request %v: expected InvalidArgument, got %v", req, err)
+			}
+		}
+	})
+}
+
+func TestServer_ListConversations_IncludePreview(t *testing.T) {
+	ctx := context.Background()
+	srv := NewServer(model.New(ConnectMongo()), nil)
+
+	t.Run("preview summarizes messages and tracks unread replies", WithFixture(func(t *testing.T, f *Fixture) {
+		c := f.CreateConversation(func(c *model.Conversation) {
+			c.Messages = append(c.Messages, &model.Message{
+				ID:        primitive.NewObjectID(),
+				Role:      model.RoleAssistant,
+				Content:   "It is  sunny\nin Barcelona " + strings.Repeat("and warm ", 20),
+				CreatedAt: time.Date(2023, 10, 1, 0, 1, 0, 0, time.UTC),
+				UpdatedAt: time.Date(2023, 10, 1, 0, 1, 0, 0, time.UTC),
+			})
+		})
+
+		preview := func() *pb.Conversation_Preview {
+			t.Helper()
+			out, err := srv.ListConversations(ctx, &pb.ListConversationsRequest{IncludePreview: true})
+			if err != nil {
+				t.Fatalf("ListConversations error: %v", err)
+			}
+			for _, conv := range out.GetConversations() {
+				if conv.GetId() == c.ID.Hex() {
+					if len(conv.GetMessages()) != 0 {
+						t.Errorf("expected no messages in list, got %d", len(conv.GetMessages()))
+					}
+					return conv.GetPreview()
+				}
+			}
+			t.Fatalf("conversation %s not listed", c.ID.Hex())
+			return nil
+		}
+
+		p := preview()
+		if got, want := p.GetMessageCount(), int32(2); got != want {
+			t.Errorf("message count: got %d want %d", got, want)
+		}
+		if got := p.GetLastMessageRole(); got != pb.Conversation_ASSISTANT {
+			t.Errorf("last message role: got %v want ASSISTANT", got)
+		}
+		if got := p.GetLastMessage(); !strings.HasPrefix(got, "It is sunny in Barcelona and warm") || !strings.HasSuffix(got, "…") {
+			t.Errorf("last message: got %q, want a shortened single-line snippet", got)
+		}
+		if !p.GetUnread() || p.GetUnreadCount() != 1 {
+			t.Errorf("expected one unread reply, got unread=%v count=%d", p.GetUnread(), p.GetUnreadCount())
+		}
+
+		if _, err := srv.MarkRead(ctx, &pb.MarkReadRequest{ConversationId: c.ID.Hex()}); err != nil {
+			t.Fatalf("MarkRead error: %v", err)
+		}
+		if p := preview(); p.GetUnread() || p.GetUnreadCount() != 0 {
+			t.Errorf("expected no unread replies after MarkRead, got unread=%v count=%d", p.GetUnread(), p.GetUnreadCount())
+		}
+
+		// Read markers are per user.
+		other := auth.WithUser(ctx, "someone-else")
+		out, err := srv.ListConversations(other, &pb.ListConversationsRequest{IncludePreview: true})
+		if err != nil {
+			t.Fatalf("ListConversations error: %v", err)
+		}
+		for _, conv := range out.GetConversations() {
+			if conv.GetId() == c.ID.Hex() && conv.GetPreview().GetUnreadCount() != 1 {
+				t.Errorf("expected the reply to be unread for another user, got %d", conv.GetPreview().GetUnreadCount())
+			}
+		}
+
+		// Marking an earlier message leaves the replies after it unread.
+		if _, err := srv.MarkRead(ctx, &pb.MarkReadRequest{ConversationId: c.ID.Hex(), MessageId: c.Messages[0].ID.Hex()}); err != nil {
+			t.Fatalf("MarkRead error: %v", err)
+		}
+		if got := preview().GetUnreadCount(); got != 1 {
+			t.Errorf("expected one unread reply after marking the first message, got %d", got)
+		}
+
+		_, err = srv.MarkRead(ctx, &pb.MarkReadRequest{ConversationId: c.ID.Hex(), MessageId: primitive.NewObjectID().Hex()})
+		if te, ok := err.(twirp.Error); !ok || te.Code() != twirp.InvalidArgument {
+			t.Errorf("expected twirp.InvalidArgument for a foreign message, got %v", err)
+		}
+	}))
+}
+
 // -----------------------------------------------------------------------------
 // new tests for StartConversation (title + reply logic, perf, caching)
 // -----------------------------------------------------------------------------
@@ -118,6 +288,36 @@ func TestStartConversation_Happy(t *testing.T) {
 	}
 }
 
+func TestStartConversation_TagsPersonalData(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	repo := model.New(ConnectMongo())
+	fa := &fakeAssistant{
+		titleFn: func(context.Context, *model.Conversation) (string, error) { return "Booking", nil },
+		replyFn: func(context.Context, *model.Conversation) (string, error) { return "Noted.", nil },
+	}
+	srv := NewServer(repo, fa)
+	srv.EnablePIIDetection(&pii.Policy{Mode: pii.ModeTag, Kinds: pii.AllKinds})
+
+	out, err := srv.StartConversation(ctx, &pb.StartConversationRequest{
+		Message: "Send the booking to jane@example.com or call +34 612 34 56 78",
+	})
+	if err != nil {
+		t.Fatalf("StartConversation error: %v", err)
+	}
+
+	conv, err := repo.DescribeConversation(ctx, out.GetConversationId())
+	if err != nil {
+		t.Fatalf("DescribeConversation error: %v", err)
+	}
+	if got, want := conv.Messages[0].PII, []string{"email", "phone"}; !cmp.Equal(got, want) {
+		t.Errorf("user message PII: got %v, want %v", got, want)
+	}
+	if got := conv.Messages[1].PII; len(got) != 0 {
+		t.Errorf("expected no PII tags on the reply, got %v", got)
+	}
+}
+
 func TestStartConversation_TitleFailureIsNonFatal(t *testing.T) {
 	t.Parallel()
 	ctx := context.Background()
@@ -216,3 +416,251 @@ func TestStartConversation_TitleCachingAndSingleflight(t *testing.T) {
 		t.Fatalf("expected title to be computed once, got %d calls", tc)
 	}
 }
+
+func TestStartConversation_InvalidSettings(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	fa := &fakeAssistant{}
+	srv := NewServer(model.New(ConnectMongo()), fa)
+
+	temp := func(v float64) *float64 { return &v }
+
+	tests := []struct {
+		name     string
+		settings *pb.GenerationSettings
+	}{
+		{"model not allowed", &pb.GenerationSettings{Model: "gpt-unknown"}},
+		{"temperature out of range", &pb.GenerationSettings{Model: "gpt-4o", Temperature: temp(3)}},
+		{"temperature on reasoning model", &pb.GenerationSettings{Temperature: temp(0.5)}},
+		{"negative max tokens", &pb.GenerationSettings{MaxOutputTokens: -1}},
+		{"negative cost budget", &pb.GenerationSettings{MaxCostUsd: -0.5}},
+		{"negative token budget", &pb.GenerationSettings{MaxTotalTokens: -1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := srv.StartConversation(ctx, &pb.StartConversationRequest{Message: "hi", Settings: tt.settings})
+			if te, ok := err.(twirp.Error); !ok || te.Code() != twirp.InvalidArgument {
+				t.Fatalf("expected twirp.InvalidArgument error, got %v", err)
+			}
+		})
+	}
+
+	if fa.replyCalls != 0 || fa.titleCalls != 0 {
+		t.Fatalf("assistant should not be called for invalid settings")
+	}
+}
+
+type modelAssistant struct {
+	fakeAssistant
+	model string
+}
+
+func (a *modelAssistant) Model() string { return a.model }
+
+func TestGenerationSettings_AssistantModel(t *testing.T) {
+	srv := NewServer(nil, &fakeAssistant{})
+	temp := 0.5
+	in := &pb.GenerationSettings{Temperature: &temp}
+
+	if _, err := srv.generationSettings(in, &modelAssistant{model: "gpt-4o-mini"}); err != nil {
+		t.Errorf("temperature rejected for an assistant replying with gpt-4o-mini: %v", err)
+	}
+	if _, err := srv.generationSettings(in, &fakeAssistant{}); twirpCode(err) != twirp.InvalidArgument {
+		t.Errorf("expected InvalidArgument for the default reasoning model, got %v", err)
+	}
+}
+
+func TestContinueConversation_NegativeMaxOutputTokens(t *testing.T) {
+	t.Parallel()
+	fa := &fakeAssistant{}
+	srv := NewServer(model.New(ConnectMongo()), fa)
+
+	_, err := srv.ContinueConversation(context.Background(), &pb.ContinueConversationRequest{
+		ConversationId:  primitive.NewObjectID().Hex(),
+		Message:         "shorter please",
+		MaxOutputTokens: -1,
+	})
+	if te, ok := err.(twirp.Error); !ok || te.Code() != twirp.InvalidArgument || te.Meta("argument") != "max_output_tokens" {
+		t.Fatalf("expected twirp.InvalidArgument for max_output_tokens, got %v", err)
+	}
+	if fa.replyCalls != 0 {
+		t.Fatalf("assistant should not be called for an invalid request")
+	}
+}
+
+func TestRetryFailedReply(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("failed reply is kept and can be retried", WithFixture(func(t *testing.T, f *Fixture) {
+		c := f.CreateConversation()
+
+		fail := true
+		fa := &fakeAssistant{
+			replyFn: func(ctx context.Context, c *model.Conversation) (string, error) {
+				if fail {
+					return "", errors.New("model timed out")
+				}
+				return "Sunny", nil
+			},
+		}
+		srv := NewServer(f.Repository, fa)
+
+		if _, err := srv.ContinueConversation(ctx, &pb.ContinueConversationRequest{ConversationId: c.ID.Hex(), Message: "And tomorrow?"}); err == nil {
+			t.Fatal("expected ContinueConversation to fail")
+		}
+
+		stored, err := f.DescribeConversation(ctx, c.ID.Hex())
+		if err != nil {
+			t.Fatalf("DescribeConversation error: %v", err)
+		}
+		if got := len(stored.Messages); got != 2 {
+			t.Fatalf("expected the user message to be persisted, got %d messages", got)
+		}
+
+		fail = false
+		out, err := srv.RetryFailedReply(ctx, &pb.RetryFailedReplyRequest{ConversationId: c.ID.Hex()})
+		if err != nil {
+			t.Fatalf("RetryFailedReply error: %v", err)
+		}
+		if got, want := out.GetReply(), "Sunny"; got != want {
+			t.Fatalf("reply: got %q want %q", got, want)
+		}
+
+		_, err = srv.RetryFailedReply(ctx, &pb.RetryFailedReplyRequest{ConversationId: c.ID.Hex()})
+		if te, ok := err.(twirp.Error); !ok || te.Code() != twirp.NotFound {
+			t.Fatalf("expected twirp.NotFound once resolved, got %v", err)
+		}
+	}))
+
+	t.Run("conversation without messages", WithFixture(func(t *testing.T, f *Fixture) {
+		c := f.CreateConversation(func(c *model.Conversation) { c.Messages = nil })
+		if err := f.RecordFailedGeneration(ctx, c.ID, primitive.NewObjectID(), errors.New("model timed out")); err != nil {
+			t.Fatalf("RecordFailedGeneration error: %v", err)
+		}
+
+		_, err := NewServer(f.Repository, &fakeAssistant{}).RetryFailedReply(ctx, &pb.RetryFailedReplyRequest{ConversationId: c.ID.Hex()})
+		if te, ok := err.(twirp.Error); !ok || te.Code() != twirp.FailedPrecondition {
+			t.Fatalf("expected twirp.FailedPrecondition, got %v", err)
+		}
+	}))
+}
+
+// -----------------------------------------------------------------------------
+// fuzz
+// -----------------------------------------------------------------------------
+
+func FuzzNormalizeTitle(f *testing.F) {
+	for _, seed := range []string{"", "Weather in Barcelona", "  Trip\nplanning  ", strings.Repeat("long title ", 10), "Plans for Día de Reyes ☀️"} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, s string) {
+		got := normalizeTitle(s)
+		if n := utf8.RuneCountInString(got); n > 80 {
+			t.Errorf("normalizeTitle(%q) is %d characters, want at most 80", s, n)
+		}
+		if !utf8.ValidString(got) {
+			t.Errorf("normalizeTitle(%q) = %q is not valid UTF-8", s, got)
+		}
+		if strings.Contains(got, "\n") {
+			t.Errorf("normalizeTitle(%q) = %q contains a newline", s, got)
+		}
+		if got != strings.TrimSpace(got) {
+			t.Errorf("normalizeTitle(%q) = %q has surrounding whitespace", s, got)
+		}
+	})
+}
+
+func FuzzMakeTitleKey(f *testing.F) {
+	for _, seed := range []string{"", "What's the weather?", "  WHAT'S   the weather?\n", "\x00\xff"} {
+		f.Add(seed)
+	}
+
+	srv := &Server{}
+	f.Fuzz(func(t *testing.T, msg string) {
+		conv := &model.Conversation{Messages: []*model.Message{{Content: msg}}}
+		key := srv.makeTitleKey(conv, "o1", "v1")
+		if len(key) != 64 {
+			t.Fatalf("makeTitleKey(%q) = %q, want a 64-char hex digest", msg, key)
+		}
+
+		padded := &model.Conversation{Messages: []*model.Message{{Content: "  " + msg + "\n"}}}
+		if other := srv.makeTitleKey(padded, "o1", "v1"); other != key {
+			t.Errorf("makeTitleKey differs for %q with surrounding whitespace", msg)
+		}
+		if other := srv.makeTitleKey(conv, "gpt-4o", "v1"); other == key {
+			t.Errorf("makeTitleKey(%q) ignores the model", msg)
+		}
+	})
+}
+
+func TestRecoverGenerations(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("interrupted reply is marked failed and shown", WithFixture(func(t *testing.T, f *Fixture) {
+		c := f.CreateConversation()
+		answered := f.CreateConversation()
+		srv := NewServer(f.Repository, &fakeAssistant{})
+
+		if _, err := f.StartGeneration(ctx, c.ID, c.Messages[0].ID); err != nil {
+			t.Fatalf("StartGeneration error: %v", err)
+		}
+		// Answered before the server stopped: nothing to recover.
+		if _, err := f.StartGeneration(ctx, answered.ID, primitive.NewObjectID()); err != nil {
+			t.Fatalf("StartGeneration error: %v", err)
+		}
+
+		n, err := srv.sweepGenerations(ctx, time.Now().Add(time.Second))
+		if err != nil {
+			t.Fatalf("sweepGenerations error: %v", err)
+		}
+		if n != 1 {
+			t.Fatalf("recovered %d replies, want 1", n)
+		}
+
+		out, err := srv.DescribeConversation(ctx, &pb.DescribeConversationRequest{ConversationId: c.ID.Hex()})
+		if err != nil {
+			t.Fatalf("DescribeConversation error: %v", err)
+		}
+		if !strings.Contains(out.GetConversation().GetFailedReply(), "server restart") {
+			t.Errorf("failed_reply = %q, want the restart notice", out.GetConversation().GetFailedReply())
+		}
+
+		if n, _ := srv.sweepGenerations(ctx, time.Now().Add(time.Second)); n != 0 {
+			t.Errorf("second sweep recovered %d replies, want 0", n)
+		}
+	}))
+}
+
+func TestContinueConversation_Busy(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("second reply in a conversation is aborted", WithFixture(func(t *testing.T, f *Fixture) {
+		c := f.CreateConversation()
+		srv := NewServer(f.Repository, &fakeAssistant{replyFn: func(ctx context.Context, c *model.Conversation) (string, error) {
+			return "Sunny", nil
+		}})
+
+		// Another request is replying.
+		if ok, err := f.AcquireLease(ctx, c.ID, "other-request", time.Minute); err != nil || !ok {
+			t.Fatalf("AcquireLease = %v, %v", ok, err)
+		}
+
+		_, err := srv.ContinueConversation(ctx, &pb.ContinueConversationRequest{ConversationId: c.ID.Hex(), Message: "And tomorrow?"})
+		if te, ok := err.(twirp.Error); !ok || te.Code() != twirp.Aborted {
+			t.Fatalf("expected twirp.Aborted while the conversation is leased, got %v", err)
+		}
+
+		if err := f.ReleaseLease(ctx, c.ID, "other-request"); err != nil {
+			t.Fatalf("ReleaseLease error: %v", err)
+		}
+		if _, err := srv.ContinueConversation(ctx, &pb.ContinueConversationRequest{ConversationId: c.ID.Hex(), Message: "And tomorrow?"}); err != nil {
+			t.Fatalf("ContinueConversation error: %v", err)
+		}
+		// The lease is released with the reply.
+		if _, err := srv.ContinueConversation(ctx, &pb.ContinueConversationRequest{ConversationId: c.ID.Hex(), Message: "Thanks"}); err != nil {
+			t.Fatalf("ContinueConversation error: %v", err)
+		}
+	}))
+}
@@ -0,0 +1,175 @@
+package chat
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/acai-travel/tech-challenge/internal/audit"
+	"github.com/acai-travel/tech-challenge/internal/auth"
+	"github.com/acai-travel/tech-challenge/internal/chat/model"
+	"github.com/acai-travel/tech-challenge/internal/pb"
+	"github.com/twitchtv/twirp"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	// sessionTouchInterval is how often the last request of a session is saved. Revoking a
+	// session takes as long to reach the other servers.
+	sessionTouchInterval = time.Minute
+	// maxDeviceID bounds the length of auth.DeviceHeader.
+	maxDeviceID = 128
+)
+
+// startSessionPath is the RPC starting sessions, which identified users call without one.
+var startSessionPath = pb.ChatServicePathPrefix + "StartSession"
+
+// TrackSessions rejects the requests of identified users that don't carry the token of one
+// of their sessions in auth.SessionHeader, or carry a revoked one, and records the last
+// request of each session. Anonymous requests pass through untracked, and StartSession
+// calls without a session.
+func (s *Server) TrackSessions(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		userID, token := auth.User(ctx), auth.Session(ctx)
+		if userID == auth.Anonymous {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if len(auth.Device(ctx)) > maxDeviceID {
+			_ = twirp.WriteError(w, twirp.InvalidArgumentError(auth.DeviceHeader, fmt.Sprintf("must be at most %d characters", maxDeviceID)))
+			return
+		}
+		if token == "" {
+			if r.URL.Path == startSessionPath {
+				next.ServeHTTP(w, r)
+				return
+			}
+			_ = twirp.WriteError(w, twirp.NewError(twirp.Unauthenticated, "missing session token, start a session with StartSession"))
+			return
+		}
+
+		sess, err := s.touchSession(ctx, userID, hashSessionToken(token), r.UserAgent())
+		if err != nil {
+			// Rejected rather than let through, so a revoked session can't get in while the
+			// database is unavailable.
+			_ = twirp.WriteError(w, twirp.InternalErrorWith(err))
+			return
+		}
+		if sess == nil {
+			_ = twirp.WriteError(w, twirp.NewError(twirp.Unauthenticated, "unknown session token"))
+			return
+		}
+		if sess.RevokedAt != nil {
+			_ = twirp.WriteError(w, twirp.NewError(twirp.Unauthenticated, "session revoked"))
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// hashSessionToken returns the hash sessions are stored and cached by, so the tokens
+// themselves aren't kept.
+func hashSessionToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func sessionKey(userID, tokenHash string) string {
+	return userID + "\x00" + tokenHash
+}
+
+// touchSession saves the request of a session, unless this server did within the last
+// sessionTouchInterval. It returns nil if the user has no session with the token.
+func (s *Server) touchSession(ctx context.Context, userID, tokenHash, userAgent string) (*model.Session, error) {
+	now := time.Now()
+	key := sessionKey(userID, tokenHash)
+	if sess, ok := s.sessions.Get(key); ok && now.Sub(sess.LastSeenAt) < sessionTouchInterval && sess.UserAgent == userAgent {
+		return sess, nil
+	}
+
+	sess, err := s.repo.TouchSession(ctx, userID, tokenHash, userAgent, now)
+	if err != nil || sess == nil {
+		return nil, err
+	}
+	s.sessions.Add(key, sess)
+	return sess, nil
+}
+
+func (s *Server) StartSession(ctx context.Context, _ *pb.StartSessionRequest) (*pb.StartSessionResponse, error) {
+	userID := auth.User(ctx)
+	if userID == auth.Anonymous {
+		return nil, twirp.NewError(twirp.Unauthenticated, "sessions require an identified user")
+	}
+
+	token := rand.Text()
+	now := time.Now()
+	sess := &model.Session{
+		ID:         primitive.NewObjectID(),
+		UserID:     userID,
+		DeviceID:   auth.Device(ctx),
+		TokenHash:  hashSessionToken(token),
+		CreatedAt:  now,
+		LastSeenAt: now,
+	}
+	if err := s.repo.CreateSession(ctx, sess); err != nil {
+		return nil, twirp.InternalErrorWith(err)
+	}
+	audit.Detail(ctx, "session_id", sess.ID.Hex())
+
+	p := sess.Proto()
+	p.Current = true
+	return &pb.StartSessionResponse{Session: p, Token: token}, nil
+}
+
+// currentSession reports whether sess is the session of the request in ctx.
+func currentSession(ctx context.Context, sess *model.Session) bool {
+	token := auth.Session(ctx)
+	return token != "" && sess.TokenHash == hashSessionToken(token)
+}
+
+func (s *Server) ListSessions(ctx context.Context, req *pb.ListSessionsRequest) (*pb.ListSessionsResponse, error) {
+	userID := auth.User(ctx)
+	if userID == auth.Anonymous {
+		return nil, twirp.NewError(twirp.Unauthenticated, "sessions require an identified user")
+	}
+
+	sessions, err := s.repo.ListSessions(ctx, userID, req.GetIncludeRevoked())
+	if err != nil {
+		return nil, twirp.InternalErrorWith(err)
+	}
+
+	resp := &pb.ListSessionsResponse{}
+	for _, sess := range sessions {
+		p := sess.Proto()
+		p.Current = currentSession(ctx, sess)
+		resp.Sessions = append(resp.Sessions, p)
+	}
+	return resp, nil
+}
+
+func (s *Server) RevokeSession(ctx context.Context, req *pb.RevokeSessionRequest) (*pb.RevokeSessionResponse, error) {
+	if req.GetSessionId() == "" {
+		return nil, twirp.RequiredArgumentError("session_id")
+	}
+	userID := auth.User(ctx)
+	if userID == auth.Anonymous {
+		return nil, twirp.NewError(twirp.Unauthenticated, "sessions require an identified user")
+	}
+
+	audit.Detail(ctx, "session_id", req.GetSessionId())
+	sess, err := s.repo.RevokeSession(ctx, req.GetSessionId(), userID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	// Rejected on this server at once, and on the others once they save its next request.
+	s.sessions.Add(sessionKey(userID, sess.TokenHash), sess)
+
+	p := sess.Proto()
+	p.Current = currentSession(ctx, sess)
+	return &pb.RevokeSessionResponse{Session: p}, nil
+}
@@ -0,0 +1,177 @@
+package chat
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/acai-travel/tech-challenge/internal/auth"
+	. "github.com/acai-travel/tech-challenge/internal/chat/testing"
+	"github.com/acai-travel/tech-challenge/internal/pb"
+	"github.com/twitchtv/twirp"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// sessionRequest sends a request for the RPC method as user with the session token through
+// TrackSessions and DetectAbuse, and returns the response status.
+func sessionRequest(srv *Server, method, user, token string) int {
+	h := auth.Middleware()(srv.TrackSessions(srv.DetectAbuse(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))))
+	req := httptest.NewRequest(http.MethodPost, "/twirp/acai.chat.ChatService/"+method, nil)
+	req.Header.Set("User-Agent", "test-agent/1.0")
+	if user != "" {
+		req.Header.Set(auth.UserHeader, user)
+	}
+	if token != "" {
+		req.Header.Set(auth.SessionHeader, token)
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec.Code
+}
+
+// startSession starts a session of user on device and returns its token.
+func startSession(t *testing.T, srv *Server, user, device string) string {
+	t.Helper()
+	resp, err := srv.StartSession(auth.WithDevice(auth.WithUser(context.Background(), user), device), &pb.StartSessionRequest{})
+	if err != nil {
+		t.Fatalf("StartSession: %v", err)
+	}
+	if resp.GetToken() == "" || resp.GetSession().GetDeviceId() != device || !resp.GetSession().GetCurrent() {
+		t.Fatalf("StartSession = %v; want a token and the current session of the device", resp)
+	}
+	return resp.GetToken()
+}
+
+func TestTrackSessions_Untracked(t *testing.T) {
+	// Without a repository, only requests that aren't tracked can pass.
+	srv := NewServer(nil, &fakeAssistant{})
+	if code := sessionRequest(srv, "ListConversations", "", ""); code != http.StatusNoContent {
+		t.Errorf("anonymous request: got status %d", code)
+	}
+	if code := sessionRequest(srv, "StartSession", "alice", ""); code != http.StatusNoContent {
+		t.Errorf("starting a session: got status %d", code)
+	}
+	if code := sessionRequest(srv, "ListConversations", "alice", ""); code != http.StatusUnauthorized {
+		t.Errorf("request without a session: got status %d", code)
+	}
+
+	h := auth.Middleware()(srv.TrackSessions(http.NotFoundHandler()))
+	req := httptest.NewRequest(http.MethodPost, "/twirp/acai.chat.ChatService/StartSession", nil)
+	req.Header.Set(auth.UserHeader, "alice")
+	req.Header.Set(auth.DeviceHeader, strings.Repeat("x", maxDeviceID+1))
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("device ID too long: got status %d", rec.Code)
+	}
+
+	for name, call := range map[string]func(context.Context) error{
+		"StartSession": func(ctx context.Context) error {
+			_, err := srv.StartSession(ctx, &pb.StartSessionRequest{})
+			return err
+		},
+		"ListSessions": func(ctx context.Context) error {
+			_, err := srv.ListSessions(ctx, &pb.ListSessionsRequest{})
+			return err
+		},
+	} {
+		if err := call(context.Background()); twirpCode(err) != twirp.Unauthenticated {
+			t.Errorf("%s: expected Unauthenticated, got %v", name, err)
+		}
+	}
+	_, err := srv.RevokeSession(auth.WithUser(context.Background(), "alice"), &pb.RevokeSessionRequest{})
+	if twirpCode(err) != twirp.InvalidArgument {
+		t.Errorf("RevokeSession: expected InvalidArgument, got %v", err)
+	}
+}
+
+func TestSessions(t *testing.T) {
+	t.Run("lists and revokes the sessions of the caller", WithFixture(func(t *testing.T, f *Fixture) {
+		srv := NewServer(f.Repository, &fakeAssistant{})
+		user := "alice-" + primitive.NewObjectID().Hex()
+
+		laptop, phone := startSession(t, srv, user, "laptop"), startSession(t, srv, user, "phone")
+		for _, token := range []string{laptop, phone, laptop} {
+			if code := sessionRequest(srv, "ListConversations", user, token); code != http.StatusNoContent {
+				t.Fatalf("request with a session: got status %d", code)
+			}
+		}
+		tablet := startSession(t, srv, "bob-"+primitive.NewObjectID().Hex(), "tablet")
+		if code := sessionRequest(srv, "ListConversations", user, tablet); code != http.StatusUnauthorized {
+			t.Errorf("request with another user's session: got status %d", code)
+		}
+		if code := sessionRequest(srv, "ListConversations", user, "guess"); code != http.StatusUnauthorized {
+			t.Errorf("request with an unknown session: got status %d", code)
+		}
+
+		ctx := auth.WithSession(auth.WithUser(context.Background(), user), laptop)
+		list, err := srv.ListSessions(ctx, &pb.ListSessionsRequest{})
+		if err != nil || len(list.GetSessions()) != 2 {
+			t.Fatalf("ListSessions = %v, %v; want the laptop and the phone", list, err)
+		}
+		var phoneSession *pb.Session
+		for _, s := range list.GetSessions() {
+			if s.GetDeviceId() == "phone" {
+				phoneSession = s
+			}
+			if s.GetCurrent() != (s.GetDeviceId() == "laptop") || s.GetUserAgent() != "test-agent/1.0" {
+				t.Errorf("session %+v: want the laptop current, with the user agent", s)
+			}
+		}
+		if phoneSession == nil {
+			t.Fatalf("sessions %v: missing the phone", list.GetSessions())
+		}
+
+		revoked, err := srv.RevokeSession(ctx, &pb.RevokeSessionRequest{SessionId: phoneSession.GetId()})
+		if err != nil || revoked.GetSession().GetRevokedAt() == nil {
+			t.Fatalf("RevokeSession = %v, %v", revoked, err)
+		}
+		if code := sessionRequest(srv, "ListConversations", user, phone); code != http.StatusUnauthorized {
+			t.Errorf("request with the revoked session: got status %d", code)
+		}
+		// Nor does leaving the token out get around the revocation.
+		if code := sessionRequest(srv, "ListConversations", user, ""); code != http.StatusUnauthorized {
+			t.Errorf("request without a session: got status %d", code)
+		}
+		if code := sessionRequest(srv, "ListConversations", user, laptop); code != http.StatusNoContent {
+			t.Errorf("request from the laptop: got status %d", code)
+		}
+
+		list, err = srv.ListSessions(ctx, &pb.ListSessionsRequest{})
+		if err != nil || len(list.GetSessions()) != 1 {
+			t.Errorf("ListSessions = %v, %v; want only the laptop", list, err)
+		}
+		list, err = srv.ListSessions(ctx, &pb.ListSessionsRequest{IncludeRevoked: true})
+		if err != nil || len(list.GetSessions()) != 2 {
+			t.Errorf("ListSessions with revoked = %v, %v; want both", list, err)
+		}
+
+		// Other users' sessions can't be revoked.
+		other := auth.WithUser(context.Background(), "mallory")
+		if _, err := srv.RevokeSession(other, &pb.RevokeSessionRequest{SessionId: list.GetSessions()[0].GetId()}); twirpCode(err) != twirp.NotFound {
+			t.Errorf("revoking another user's session: expected NotFound, got %v", err)
+		}
+	}))
+
+	t.Run("records the device on user messages", WithFixture(func(t *testing.T, f *Fixture) {
+		srv := NewServer(f.Repository, &fakeAssistant{})
+		ctx := auth.WithDevice(auth.WithUser(context.Background(), "alice-"+primitive.NewObjectID().Hex()), "phone")
+
+		out, err := srv.StartConversation(ctx, &pb.StartConversationRequest{Message: "Weather in Oslo?"})
+		if err != nil {
+			t.Fatalf("StartConversation error: %v", err)
+		}
+		conv, err := srv.DescribeConversation(ctx, &pb.DescribeConversationRequest{ConversationId: out.GetConversationId()})
+		if err != nil {
+			t.Fatalf("DescribeConversation error: %v", err)
+		}
+		msgs := conv.GetConversation().GetMessages()
+		if len(msgs) != 2 || msgs[0].GetDeviceId() != "phone" || msgs[1].GetDeviceId() != "" {
+			t.Errorf("messages %v: want the device on the user message only", msgs)
+		}
+	}))
+}
@@ -0,0 +1,134 @@
+package chat
+
+import (
+	"cmp"
+	"context"
+	"log/slog"
+	"math/rand/v2"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/acai-travel/tech-challenge/internal/audit"
+	"github.com/acai-travel/tech-challenge/internal/auth"
+	"github.com/acai-travel/tech-challenge/internal/chat/assistant"
+	"github.com/acai-travel/tech-challenge/internal/chat/model"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// maxShadowInflight bounds the shadow replies generated at once; replies sampled while as
+// many are in flight aren't shadowed, so candidates can't slow down the server.
+const maxShadowInflight = 8
+
+// ShadowCandidate is a prompt or model change evaluated on real traffic: it also answers a
+// share of the conversations of the assistant it may replace, in the background.
+type ShadowCandidate struct {
+	// Name labels the stored replies, e.g. "travel-prompt-v2".
+	Name string
+	// Assistant generates the candidate replies, e.g. with the new prompt or model.
+	Assistant Assistant
+	// Of is the name of the assistant whose conversations are shadowed; empty is
+	// DefaultAssistant.
+	Of string
+	// Percent of the replies of Of that are shadowed, from 0 to 100.
+	Percent float64
+}
+
+// shadow runs a ShadowCandidate.
+type shadow struct {
+	candidate ShadowCandidate
+	// save stores the replies, the repository unless a test replaces it.
+	save  func(context.Context, *model.ShadowReply) error
+	slots chan struct{}
+	wg    sync.WaitGroup
+
+	runs, failures, skipped atomic.Int64
+}
+
+// EnableShadowing also generates a reply with c.Assistant to a share of the messages sent
+// to the assistant c.Of, after the user's reply and without delaying it. Shadow replies are
+// stored with the reply the user got, for evaluation, and never returned to users.
+func (s *Server) EnableShadowing(c ShadowCandidate) {
+	c.Of = cmp.Or(c.Of, DefaultAssistant)
+	s.shadow = &shadow{
+		candidate: c,
+		save:      s.repo.SaveShadowReply,
+		slots:     make(chan struct{}, maxShadowInflight),
+	}
+}
+
+// shadowReply has the candidate answer conv too, if sampled; primary is the reply the user
+// got.
+func (s *Server) shadowReply(ctx context.Context, conv *model.Conversation, primary model.ShadowBaseline) {
+	sh := s.shadow
+	if sh == nil || cmp.Or(conv.Assistant, DefaultAssistant) != sh.candidate.Of || rand.Float64()*100 >= sh.candidate.Percent {
+		return
+	}
+	select {
+	case sh.slots <- struct{}{}:
+	default:
+		sh.skipped.Add(1)
+		return
+	}
+
+	// The caller keeps using conv, e.g. to append the reply, so the candidate gets a copy.
+	snapshot := *conv
+	snapshot.Messages = make([]*model.Message, len(conv.Messages))
+	for i, m := range conv.Messages {
+		c := *m
+		snapshot.Messages[i] = &c
+	}
+
+	// Shadow replies aren't part of the call: not audited, reported to the callbacks of the
+	// reply, e.g. its progress and usage, nor cancelled with the request. Nor do they act for
+	// the user: the candidate can't write to their calendar, which the reply they got may
+	// already have.
+	ctx = assistant.WithoutCalDAV(assistant.WithoutCallbacks(audit.Detach(context.WithoutCancel(ctx))))
+	sh.wg.Add(1)
+	go func() {
+		defer sh.wg.Done()
+		defer func() { <-sh.slots }()
+		sh.run(ctx, &snapshot, primary, s.budget.of(ctx))
+	}()
+}
+
+func (sh *shadow) run(ctx context.Context, conv *model.Conversation, primary model.ShadowBaseline, b budget) {
+	ctx, cancel := b.stage(ctx, "shadow", b.request)
+	defer cancel()
+
+	out := &model.ShadowReply{
+		ID:             primitive.NewObjectID(),
+		Candidate:      sh.candidate.Name,
+		ConversationID: conv.ID,
+		UserID:         auth.User(ctx),
+		CreatedAt:      time.Now(),
+		Primary:        primary,
+	}
+	for i := len(conv.Messages) - 1; i >= 0; i-- {
+		if conv.Messages[i].Role == model.RoleUser {
+			out.MessageID = conv.Messages[i].ID
+			break
+		}
+	}
+
+	ctx = assistant.WithProgress(ctx, func(p assistant.Progress) {
+		out.ToolCalls = recordToolCall(out.ToolCalls, p)
+	})
+	ctx = assistant.WithUsage(ctx, func(u model.Usage) { out.Usage = u })
+
+	start := time.Now()
+	reply, err := sh.candidate.Assistant.Reply(ctx, conv)
+	out.LatencyMs = time.Since(start).Milliseconds()
+	out.Reply = reply
+	sh.runs.Add(1)
+	if err != nil {
+		out.Error = err.Error()
+		sh.failures.Add(1)
+	}
+
+	wctx, wcancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+	defer wcancel()
+	if err := sh.save(wctx, out); err != nil {
+		slog.ErrorContext(ctx, "Failed to store a shadow reply", "candidate", sh.candidate.Name, "conversation_id", conv.ID.Hex(), "error", err)
+	}
+}
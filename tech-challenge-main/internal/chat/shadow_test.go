@@ -0,0 +1,185 @@
+package chat
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/acai-travel/tech-challenge/internal/audit"
+	"github.com/acai-travel/tech-challenge/internal/auth"
+	"github.com/acai-travel/tech-challenge/internal/chat/assistant"
+	"github.com/acai-travel/tech-challenge/internal/chat/assistant/assistanttest"
+	"github.com/acai-travel/tech-challenge/internal/chat/model"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestShadowing(t *testing.T) {
+	primary := &fakeAssistant{replyFn: func(context.Context, *model.Conversation) (string, error) {
+		return "It's sunny in Oslo.", nil
+	}}
+	release := make(chan struct{})
+	var seen []int
+	var cancelled bool
+	candidate := &fakeAssistant{replyFn: func(ctx context.Context, conv *model.Conversation) (string, error) {
+		<-release
+		audit.Model(ctx, "o1")
+		seen = append(seen, len(conv.Messages))
+		cancelled = ctx.Err() != nil
+		return "", errors.New("candidate failed")
+	}}
+
+	srv := NewServer(nil, primary)
+	srv.EnableShadowing(ShadowCandidate{Name: "prompt-v2", Assistant: candidate, Percent: 100})
+	var mu sync.Mutex
+	var saved []*model.ShadowReply
+	srv.shadow.save = func(_ context.Context, r *model.ShadowReply) error {
+		mu.Lock()
+		defer mu.Unlock()
+		saved = append(saved, r)
+		return nil
+	}
+
+	events := &eventRecorder{}
+	hooks := audit.Hooks(events)
+	ctx, err := hooks.RequestRouted(auth.WithUser(context.Background(), "alice"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(ctx)
+
+	question := &model.Message{ID: primitive.NewObjectID(), Role: model.RoleUser, Content: "Weather in Oslo?"}
+	conv := &model.Conversation{ID: primitive.NewObjectID(), Messages: []*model.Message{question}}
+	reply, _, err := srv.generateReply(ctx, conv)
+	if err != nil || reply != "It's sunny in Oslo." {
+		t.Fatalf("generateReply = %q, %v; want the primary reply", reply, err)
+	}
+
+	// The request ends and the conversation moves on before the candidate replies.
+	conv.Messages = append(conv.Messages, &model.Message{Role: model.RoleAssistant, Content: reply})
+	cancel()
+	hooks.ResponseSent(ctx)
+	close(release)
+	srv.shadow.wg.Wait()
+
+	if len(saved) != 1 {
+		t.Fatalf("saved %d shadow replies, want 1", len(saved))
+	}
+	got := saved[0]
+	if got.Candidate != "prompt-v2" || got.ConversationID != conv.ID || got.MessageID != question.ID || got.UserID != "alice" {
+		t.Errorf("shadow reply %+v: want the candidate, conversation, question and user", got)
+	}
+	if got.Error != "candidate failed" || got.Primary.Reply != "It's sunny in Oslo." || got.Primary.Error != "" {
+		t.Errorf("shadow reply %+v: want the candidate's error and the primary reply", got)
+	}
+	if len(seen) != 1 || seen[0] != 1 || cancelled {
+		t.Errorf("candidate saw %v messages, cancelled %v; want the question only, not cancelled", seen, cancelled)
+	}
+	if len(events.events) != 1 || len(events.events[0].Models) != 0 {
+		t.Errorf("audited %+v; want the call without the candidate's model", events.events)
+	}
+	if stats := srv.Stats(); stats["shadow_runs"] != int64(1) || stats["shadow_failures"] != int64(1) {
+		t.Errorf("stats = %v", stats)
+	}
+
+	// Other assistants' conversations aren't shadowed, nor any with a zero percent.
+	srv.RegisterAssistant("travel", primary)
+	if _, _, err := srv.generateReply(context.Background(), &model.Conversation{Assistant: "travel", Messages: []*model.Message{question}}); err != nil {
+		t.Fatal(err)
+	}
+	srv.EnableShadowing(ShadowCandidate{Name: "off", Assistant: candidate, Of: DefaultAssistant})
+	if _, _, err := srv.generateReply(context.Background(), &model.Conversation{Messages: []*model.Message{question}}); err != nil {
+		t.Fatal(err)
+	}
+	srv.shadow.wg.Wait()
+	if candidate.replyCalls != 1 {
+		t.Errorf("candidate replied %d times, want once", candidate.replyCalls)
+	}
+}
+
+func TestShadowing_ToolCallingCandidate(t *testing.T) {
+	primary := &fakeAssistant{replyFn: func(context.Context, *model.Conversation) (string, error) {
+		return "It's Saturday.", nil
+	}}
+	candidate := assistant.NewWithProfile(assistant.Profile{Name: "candidate", Tools: []string{"compute_date"}})
+	candidate.SetCompletionProvider(assistanttest.New(
+		assistanttest.Call("compute_date", `{"offset":"+3 days","base_date":"2025-01-01"}`),
+		assistanttest.Answer("That's Saturday, January 4."),
+	))
+
+	srv := NewServer(nil, primary)
+	srv.EnableShadowing(ShadowCandidate{Name: "tools", Assistant: candidate, Percent: 100})
+	var saved []*model.ShadowReply
+	srv.shadow.save = func(_ context.Context, r *model.ShadowReply) error {
+		saved = append(saved, r)
+		return nil
+	}
+
+	// The caller's callbacks only hear of the primary reply.
+	var progress, usage int
+	ctx := assistant.WithProgress(context.Background(), func(assistant.Progress) { progress++ })
+	ctx = assistant.WithUsage(ctx, func(model.Usage) { usage++ })
+	conv := &model.Conversation{ID: primitive.NewObjectID(), Messages: []*model.Message{
+		{ID: primitive.NewObjectID(), Role: model.RoleUser, Content: "What's the date 3 days after New Year?"},
+	}}
+	_, calls, err := srv.generateReply(ctx, conv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv.shadow.wg.Wait()
+
+	if len(calls) != 0 || progress != 0 || usage != 0 {
+		t.Errorf("primary got %d tool calls, %d progress and %d usage reports; want none of the candidate's", len(calls), progress, usage)
+	}
+	if len(saved) != 1 || len(saved[0].ToolCalls) != 1 || saved[0].ToolCalls[0].Tool != "compute_date" {
+		t.Fatalf("saved %+v; want the candidate's tool call", saved)
+	}
+}
+
+// countingCalDAV counts the lookups of the user's CalDAV account.
+type countingCalDAV struct{ lookups atomic.Int32 }
+
+func (c *countingCalDAV) CalDAVAccount(context.Context) (*assistant.CalDAVAccount, error) {
+	c.lookups.Add(1)
+	return &assistant.CalDAVAccount{URL: "https://caldav.example.com/alice/", Username: "alice", Password: "secret"}, nil
+}
+
+func TestShadowing_CandidateDoesNotWriteCalendars(t *testing.T) {
+	primary := &fakeAssistant{replyFn: func(context.Context, *model.Conversation) (string, error) {
+		return "Added it to your calendar.", nil
+	}}
+	llm := assistanttest.New(
+		assistanttest.Call("add_calendar_event", `{"title":"Flight to Lisbon","start":"2025-10-15T14:00"}`),
+		assistanttest.Answer("Added it to your calendar."),
+	)
+	candidate := assistant.NewWithProfile(assistant.Profile{Name: "candidate", Tools: []string{"add_calendar_event", "update_calendar_event"}})
+	candidate.SetCompletionProvider(llm)
+
+	srv := NewServer(nil, primary)
+	srv.EnableShadowing(ShadowCandidate{Name: "calendar", Assistant: candidate, Percent: 100})
+	var saved []*model.ShadowReply
+	srv.shadow.save = func(_ context.Context, r *model.ShadowReply) error {
+		saved = append(saved, r)
+		return nil
+	}
+
+	caldav := &countingCalDAV{}
+	conv := &model.Conversation{ID: primitive.NewObjectID(), Messages: []*model.Message{
+		{ID: primitive.NewObjectID(), Role: model.RoleUser, Content: "Put my flight on my calendar."},
+	}}
+	srv.shadowReply(assistant.WithCalDAV(context.Background(), caldav), conv, model.ShadowBaseline{Reply: "Added it to your calendar."})
+	srv.shadow.wg.Wait()
+
+	if len(saved) != 1 {
+		t.Fatalf("saved %d shadow replies, want 1", len(saved))
+	}
+	for _, tool := range llm.Requests()[0].Tools {
+		if name := tool.OfFunction.Function.Name; name == "add_calendar_event" || name == "update_calendar_event" {
+			t.Errorf("the candidate was offered %s", name)
+		}
+	}
+	if n := caldav.lookups.Load(); n != 0 {
+		t.Errorf("the candidate looked up the user's CalDAV account %d times, want never", n)
+	}
+}
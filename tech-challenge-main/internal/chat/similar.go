@@ -0,0 +1,69 @@
+package chat
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/acai-travel/tech-challenge/internal/auth"
+	"github.com/acai-travel/tech-challenge/internal/chat/model"
+	"github.com/acai-travel/tech-challenge/internal/pb"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	// maxSimilarConversations bounds the links returned with a new conversation.
+	maxSimilarConversations = 3
+	// minSimilarScore is stricter than minRecallScore: a link suggests continuing the
+	// same thread, not just that something related was said once.
+	minSimilarScore = 0.5
+	// similarCandidates are the messages searched to find the conversations, since one
+	// conversation often has several close messages.
+	similarCandidates = 20
+)
+
+// similarConversations returns the user's past conversations closest to the first message
+// of conv, if requested and semantic search is enabled. Suggestions are optional, so
+// failures are only logged.
+func (s *Server) similarConversations(ctx context.Context, conv *model.Conversation, requested bool) []*pb.SimilarConversation {
+	if !requested || s.semantic == nil {
+		return nil
+	}
+
+	hits, err := s.semantic.Search(ctx, auth.User(ctx), conv.Messages[0].Content, similarCandidates, conv.ID)
+	if err != nil {
+		slog.WarnContext(ctx, "Failed to find similar conversations", "conversation_id", conv.ID.Hex(), "error", err)
+		return nil
+	}
+
+	// Hits are sorted, so the first of each conversation is its best.
+	var ids []primitive.ObjectID
+	scores := map[primitive.ObjectID]float64{}
+	for _, h := range hits {
+		id := h.Embedding.ConversationID
+		if _, seen := scores[id]; seen || h.Score < minSimilarScore {
+			continue
+		}
+		ids = append(ids, id)
+		scores[id] = h.Score
+	}
+
+	// Embeddings may outlive their conversation, and archived ones aren't worth continuing.
+	titles, err := s.repo.FindConversationTitles(ctx, ids)
+	if err != nil {
+		slog.WarnContext(ctx, "Failed to find similar conversations", "conversation_id", conv.ID.Hex(), "error", err)
+		return nil
+	}
+
+	var out []*pb.SimilarConversation
+	for _, id := range ids {
+		title, ok := titles[id]
+		if !ok {
+			continue
+		}
+		out = append(out, &pb.SimilarConversation{ConversationId: id.Hex(), Title: title, Score: scores[id]})
+		if len(out) == maxSimilarConversations {
+			break
+		}
+	}
+	return out
+}
@@ -0,0 +1,73 @@
+package chat
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/acai-travel/tech-challenge/internal/auth"
+	"github.com/acai-travel/tech-challenge/internal/chat/model"
+	. "github.com/acai-travel/tech-challenge/internal/chat/testing"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestSimilarConversations(t *testing.T) {
+	user := "alice-" + primitive.NewObjectID().Hex()
+	ctx := auth.WithUser(context.Background(), user)
+
+	t.Run("links the user's live conversations on the same topic", WithFixture(func(t *testing.T, f *Fixture) {
+		embedder := keywordEmbedder{"hotel", "lisbon", "weather"}
+		store := NewScanVectorStore(f.Repository)
+		srv := NewServer(f.Repository, &fakeAssistant{})
+		srv.EnableSemanticSearch(embedder, store)
+
+		hotel := f.CreateConversation(func(c *model.Conversation) { c.Title = "Hotels in Lisbon" })
+		archived := f.CreateConversation(func(c *model.Conversation) {
+			at := time.Now()
+			c.ArchivedAt = &at
+		})
+		weather := f.CreateConversation()
+		deleted := primitive.NewObjectID()
+
+		for _, c := range []struct {
+			id      primitive.ObjectID
+			content string
+		}{
+			{hotel.ID, "Find me a hotel in Lisbon"},
+			{hotel.ID, "A cheaper hotel in Lisbon please"},
+			{archived.ID, "Which hotel in Lisbon did I book?"},
+			{deleted, "Hotel in Lisbon with a pool"},
+			{weather.ID, "What is the weather like today?"},
+		} {
+			vectors, _ := embedder.Embed(ctx, []string{c.content})
+			err := store.Save(ctx, &model.MessageEmbedding{
+				MessageID: primitive.NewObjectID(), ConversationID: c.id, UserID: user, Role: model.RoleUser,
+				Content: c.content, Model: embedder.EmbeddingModel(), Vector: vectors[0], CreatedAt: time.Now(),
+			})
+			if err != nil {
+				t.Fatalf("Save error: %v", err)
+			}
+		}
+
+		conv := &model.Conversation{ID: primitive.NewObjectID(), Messages: []*model.Message{{Content: "Any hotel deals in Lisbon?"}}}
+		got := srv.similarConversations(ctx, conv, true)
+		if len(got) != 1 || got[0].GetConversationId() != hotel.ID.Hex() || got[0].GetTitle() != "Hotels in Lisbon" {
+			t.Fatalf("similarConversations = %v, want only %s", got, hotel.ID.Hex())
+		}
+		if got[0].GetScore() < minSimilarScore {
+			t.Errorf("score %v is below the minimum", got[0].GetScore())
+		}
+
+		if got := srv.similarConversations(ctx, conv, false); got != nil {
+			t.Errorf("similar conversations not requested, but got %v", got)
+		}
+	}))
+
+	t.Run("disabled without semantic search", func(t *testing.T) {
+		srv := NewServer(nil, &fakeAssistant{})
+		conv := &model.Conversation{ID: primitive.NewObjectID(), Messages: []*model.Message{{Content: "Hotels in Lisbon"}}}
+		if got := srv.similarConversations(ctx, conv, true); got != nil {
+			t.Errorf("expected no suggestions, got %v", got)
+		}
+	})
+}
@@ -0,0 +1,295 @@
+package chat
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"time"
+	"unicode/utf8"
+
+	"github.com/acai-travel/tech-challenge/internal/audit"
+	"github.com/acai-travel/tech-challenge/internal/auth"
+	"github.com/acai-travel/tech-challenge/internal/chat/assistant"
+	"github.com/acai-travel/tech-challenge/internal/chat/model"
+	"github.com/acai-travel/tech-challenge/internal/pii"
+	"github.com/acai-travel/tech-challenge/internal/textx"
+	"github.com/gorilla/mux"
+	"github.com/twitchtv/twirp"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// maxStreamedMessage bounds the length of a message sent to a streaming endpoint, in
+// characters.
+const maxStreamedMessage = 4000
+
+// streamedMessage is a message as the streaming endpoints send it.
+type streamedMessage struct {
+	ID        string `json:"id"`
+	Role      string `json:"role"`
+	Content   string `json:"content"`
+	CreatedAt int64  `json:"created_at"`
+}
+
+func streamedMessageObject(m *model.Message) streamedMessage {
+	return streamedMessage{ID: m.ID.Hex(), Role: string(m.Role), Content: m.Content, CreatedAt: m.CreatedAt.Unix()}
+}
+
+// StreamHandler serves the streaming API for interactive clients, e.g. terminal UIs:
+//
+//	POST /stream/messages    sends a message and streams the reply
+//	POST /stream/cancel      stops generating the reply streamed in a conversation
+//
+// The body has the "message" and, to continue a conversation, its "conversation_id". The
+// reply is streamed as server-sent events: "conversation" with the conversation's ID and
+// the stored message, "progress" while tools run, "delta" with each piece of text as the
+// model writes it, then "reply", "error" or, once cancelled like with CancelGeneration,
+// "cancelled". The text of "reply" replaces that of the deltas: it has passed the reply
+// policies, which may change it, e.g. adding a disclaimer.
+func (s *Server) StreamHandler() http.Handler {
+	r := mux.NewRouter()
+	r.Use(audit.Route)
+	r.HandleFunc("/stream/messages", s.sendStreamMessage).Methods(http.MethodPost)
+	r.HandleFunc("/stream/cancel", s.cancelStream).Methods(http.MethodPost)
+	r.NotFoundHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeStreamError(r.Context(), w, twirp.NewError(twirp.BadRoute, "unknown endpoint "+r.Method+" "+r.URL.Path))
+	})
+	r.MethodNotAllowedHandler = r.NotFoundHandler
+	return r
+}
+
+type streamRequest struct {
+	ConversationID string `json:"conversation_id"`
+	Message        string `json:"message"`
+	// MaxOutputTokens caps the reply as ContinueConversation's max_output_tokens does.
+	MaxOutputTokens int64 `json:"max_output_tokens"`
+}
+
+// decodeStreamRequest reads and validates the message of a request to a streaming endpoint.
+func decodeStreamRequest(w http.ResponseWriter, r *http.Request) (*streamRequest, error) {
+	var req streamRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 64<<10)).Decode(&req); err != nil {
+		return nil, twirp.NewError(twirp.Malformed, "invalid JSON body: "+err.Error())
+	}
+	req.Message = strings.TrimSpace(req.Message)
+	if req.Message == "" {
+		return nil, twirp.RequiredArgumentError("message")
+	}
+	if utf8.RuneCountInString(req.Message) > maxStreamedMessage {
+		return nil, twirp.InvalidArgumentError("message", fmt.Sprintf("must be at most %d characters", maxStreamedMessage))
+	}
+	if req.MaxOutputTokens < 0 {
+		return nil, twirp.InvalidArgumentError("max_output_tokens", "must not be negative")
+	}
+	return &req, nil
+}
+
+// newStreamedMessage returns the user message with content, tagged with its personal data.
+func (s *Server) newStreamedMessage(ctx context.Context, content string) *model.Message {
+	now := time.Now()
+	return &model.Message{
+		ID:        primitive.NewObjectID(),
+		Role:      model.RoleUser,
+		Content:   content,
+		CreatedAt: now,
+		UpdatedAt: now,
+		PII:       pii.Kinds(s.pii.Detect(content)),
+		DeviceID:  auth.Device(ctx),
+	}
+}
+
+func (s *Server) sendStreamMessage(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	req, err := decodeStreamRequest(w, r)
+	if err != nil {
+		writeStreamError(ctx, w, err)
+		return
+	}
+	if err := s.checkAbuse(ctx, req.Message); err != nil {
+		writeStreamError(ctx, w, err)
+		return
+	}
+	if err := s.checkQuota(ctx); err != nil {
+		writeStreamError(ctx, w, err)
+		return
+	}
+	ctx = withMaxOutputTokens(ctx, req.MaxOutputTokens)
+
+	msg := s.newStreamedMessage(ctx, req.Message)
+	var conv *model.Conversation
+	if req.ConversationID == "" {
+		conv = &model.Conversation{
+			ID:        primitive.NewObjectID(),
+			Title:     textx.Title(msg.Content, 80),
+			CreatedAt: msg.CreatedAt,
+			UpdatedAt: msg.CreatedAt,
+			Messages:  []*model.Message{msg},
+			Owner:     ownerOf(ctx),
+		}
+		if err := s.repo.CreateConversation(ctx, conv); err != nil {
+			writeStreamError(ctx, w, twirp.InternalErrorWith(err))
+			return
+		}
+	} else {
+		var unlock func()
+		conv, unlock, err = s.appendMessage(ctx, req.ConversationID, s.describeConversation, msg)
+		if err != nil {
+			writeStreamError(ctx, w, err)
+			return
+		}
+		defer unlock()
+	}
+	audit.Conversation(ctx, conv.ID.Hex())
+	s.streamReply(ctx, w, conv, msg)
+}
+
+// streamReply answers msg, the last message of conv, streaming the events of StreamHandler.
+func (s *Server) streamReply(ctx context.Context, w http.ResponseWriter, conv *model.Conversation, msg *model.Message) {
+	s.index(ctx, conv, msg)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no") // proxies must not hold events back
+	events := &eventWriter{w: w, rc: http.NewResponseController(w)}
+	events.send("conversation", map[string]any{"id": conv.ID.Hex(), "message": streamedMessageObject(msg)})
+
+	// Progress and text are reported from the reply loop, which must not wait for a slow
+	// connection, so events are written from here and dropped when the client falls behind.
+	// Deltas stop at the first dropped one; the reply has the whole text anyway.
+	progress := make(chan assistant.Progress, 16)
+	deltas := make(chan string, 256)
+	var lagging atomic.Bool
+	type result struct {
+		answer *model.Message
+		err    error
+	}
+	done := make(chan result, 1)
+	// With notifications, a reply outlives a client that disconnects, and the user is told
+	// once it is ready; cancelStream still stops it.
+	gctx := ctx
+	if s.notifications != nil {
+		gctx = context.WithoutCancel(ctx)
+	}
+	go func() {
+		pctx := assistant.WithProgress(gctx, func(p assistant.Progress) {
+			select {
+			case progress <- p:
+			default:
+			}
+		})
+		pctx = assistant.WithDeltas(pctx, func(text string) {
+			if lagging.Load() {
+				return
+			}
+			select {
+			case deltas <- text:
+			default:
+				lagging.Store(true)
+			}
+		})
+		answer, err := s.sendReply(pctx, conv, msg)
+		done <- result{answer, err}
+	}()
+
+	sendProgress := func(p assistant.Progress) {
+		events.send("progress", map[string]any{"kind": p.Kind, "tool": p.Tool, "message": p.Message})
+	}
+	for {
+		select {
+		case p := <-progress:
+			sendProgress(p)
+		case text := <-deltas:
+			events.send("delta", map[string]any{"text": text})
+		case res := <-done:
+			for len(progress) > 0 {
+				sendProgress(<-progress)
+			}
+			if errors.Is(res.err, errGenerationCancelled) {
+				events.send("cancelled", map[string]any{"message": "You stopped the reply. Retry to get an answer."})
+				return
+			}
+			if res.err != nil {
+				slog.ErrorContext(ctx, "Failed to stream reply", "conversation_id", conv.ID.Hex(), "error", res.err)
+				audit.Failed(ctx, twirp.Internal)
+				events.send("error", map[string]any{"message": "Sorry, I couldn't answer. Please try again in a few minutes."})
+				return
+			}
+			if ctx.Err() != nil {
+				s.notifyReplyReady(gctx, conv, res.answer)
+				return
+			}
+			events.send("reply", streamedMessageObject(res.answer))
+			return
+		}
+	}
+}
+
+// cancelStream stops generating the reply to the conversation in the body.
+func (s *Server) cancelStream(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var req struct {
+		ConversationID string `json:"conversation_id"`
+	}
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 4<<10)).Decode(&req); err != nil {
+		writeStreamError(ctx, w, twirp.NewError(twirp.Malformed, "invalid JSON body: "+err.Error()))
+		return
+	}
+	if req.ConversationID == "" {
+		writeStreamError(ctx, w, twirp.RequiredArgumentError("conversation_id"))
+		return
+	}
+
+	audit.Conversation(ctx, req.ConversationID)
+	cancelled, err := s.cancelGeneration(ctx, req.ConversationID)
+	if err != nil {
+		writeStreamError(ctx, w, err)
+		return
+	}
+	writeStreamJSON(w, map[string]bool{"cancelled": cancelled})
+}
+
+// eventWriter writes server-sent events, flushing each so it arrives at once.
+type eventWriter struct {
+	w  http.ResponseWriter
+	rc *http.ResponseController
+}
+
+func (e *eventWriter) send(event string, data any) {
+	b, _ := json.Marshal(data)
+	fmt.Fprintf(e.w, "event: %s\ndata: %s\n\n", event, b)
+	_ = e.rc.Flush()
+}
+
+func writeStreamJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// writeStreamError writes err in Twirp's JSON error format, so clients parse the errors of
+// the streaming endpoints and the Twirp API alike.
+func writeStreamError(ctx context.Context, w http.ResponseWriter, err error) {
+	var te twirp.Error
+	if !errors.As(err, &te) {
+		te = twirp.InternalErrorWith(err)
+	}
+	audit.Failed(ctx, te.Code())
+	status := twirp.ServerHTTPStatusFromErrorCode(te.Code())
+	if status >= 500 {
+		slog.ErrorContext(ctx, "Streaming request failed", "error", err)
+	}
+
+	body := map[string]any{"code": te.Code(), "msg": te.Msg()}
+	if arg := te.Meta("argument"); arg != "" {
+		body["meta"] = map[string]string{"argument": arg}
+	}
+	if after := te.Meta("retry_after"); after != "" {
+		w.Header().Set("Retry-After", after)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
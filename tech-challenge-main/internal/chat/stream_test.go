@@ -0,0 +1,87 @@
+package chat
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/acai-travel/tech-challenge/internal/chat/model"
+	. "github.com/acai-travel/tech-challenge/internal/chat/testing"
+)
+
+func TestStreamHandler_Validation(t *testing.T) {
+	h := NewServer(nil, &fakeAssistant{}).StreamHandler()
+
+	tests := []struct {
+		name         string
+		method, path string
+		body         string
+		status       int
+		code         string
+	}{
+		{"not JSON", http.MethodPost, "/stream/messages", `hi`, http.StatusBadRequest, "malformed"},
+		{"empty message", http.MethodPost, "/stream/messages", `{"message":" "}`, http.StatusBadRequest, "invalid_argument"},
+		{"message too long", http.MethodPost, "/stream/messages", `{"message":"` + strings.Repeat("a", maxStreamedMessage+1) + `"}`, http.StatusBadRequest, "invalid_argument"},
+		{"negative max tokens", http.MethodPost, "/stream/messages", `{"message":"hi","max_output_tokens":-1}`, http.StatusBadRequest, "invalid_argument"},
+		{"unknown endpoint", http.MethodPost, "/stream/replies", `{}`, http.StatusNotFound, "bad_route"},
+		{"wrong method", http.MethodGet, "/stream/messages", ``, http.StatusNotFound, "bad_route"},
+		{"cancel without conversation", http.MethodPost, "/stream/cancel", `{}`, http.StatusBadRequest, "invalid_argument"},
+		{"cancel not JSON", http.MethodPost, "/stream/cancel", `stop`, http.StatusBadRequest, "malformed"},
+	}
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body)))
+		var body struct {
+			Code string `json:"code"`
+		}
+		if rec.Code != tt.status || json.NewDecoder(rec.Body).Decode(&body) != nil || body.Code != tt.code {
+			t.Errorf("%s: got status %d, code %q; want %d, %q", tt.name, rec.Code, body.Code, tt.status, tt.code)
+		}
+	}
+}
+
+func TestStreamHandler(t *testing.T) {
+	t.Parallel()
+	repo := model.New(ConnectMongo())
+	fa := &fakeAssistant{
+		replyFn: func(_ context.Context, c *model.Conversation) (string, error) {
+			return "Reply to: " + c.Messages[len(c.Messages)-1].Content, nil
+		},
+	}
+	h := NewServer(repo, fa).StreamHandler()
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/stream/messages", strings.NewReader(`{"message":"Museums in Vienna?"}`)))
+	events := rec.Body.String()
+	if rec.Code != http.StatusOK || !strings.HasPrefix(events, "event: conversation\n") ||
+		!strings.Contains(events, `"content":"Reply to: Museums in Vienna?"`) {
+		t.Fatalf("got status %d, events %q", rec.Code, events)
+	}
+	var started struct {
+		ID string `json:"id"`
+	}
+	data, _, _ := strings.Cut(strings.TrimPrefix(events, "event: conversation\ndata: "), "\n")
+	if err := json.Unmarshal([]byte(data), &started); err != nil || started.ID == "" {
+		t.Fatalf("conversation event %q: %v", data, err)
+	}
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/stream/messages", strings.NewReader(`{"conversation_id":"`+started.ID+`","message":"And in Graz?"}`)))
+	if !strings.Contains(rec.Body.String(), "event: reply\n") || !strings.Contains(rec.Body.String(), `"content":"Reply to: And in Graz?"`) {
+		t.Fatalf("events = %q", rec.Body.String())
+	}
+
+	conv, err := repo.DescribeConversation(context.Background(), started.ID)
+	if err != nil || len(conv.Messages) != 4 || conv.Title != "Museums in Vienna?" {
+		t.Errorf("conversation = %+v, %v; want 4 messages titled after the first", conv, err)
+	}
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/stream/messages", strings.NewReader(`{"conversation_id":"000000000000000000000000","message":"hi"}`)))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("unknown conversation: got status %d", rec.Code)
+	}
+}
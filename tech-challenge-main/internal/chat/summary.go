@@ -0,0 +1,145 @@
+package chat
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/acai-travel/tech-challenge/internal/chat/model"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Summarizer folds messages into a conversation's rolling summary.
+type Summarizer interface {
+	Summarize(ctx context.Context, previous string, msgs []*model.Message) (string, error)
+}
+
+// compactor keeps the context sent to the model flat as conversations grow: once the
+// messages not yet summarized exceed a token threshold, all but the most recent ones are
+// folded into the conversation's summary in the background. Like the reconciler, jobs
+// live in memory; a lost job is redone after the next reply.
+type compactor struct {
+	repo       *model.Repository
+	summarizer Summarizer
+
+	// threshold is the estimated number of unsummarized tokens that triggers a summary.
+	threshold int
+	// keep is the number of recent messages always sent verbatim.
+	keep int
+
+	queue chan *model.Conversation
+	once  sync.Once
+
+	mu      sync.Mutex
+	pending map[primitive.ObjectID]bool
+}
+
+// newCompactor reads CHAT_SUMMARY_TOKENS (default 6000) and CHAT_SUMMARY_KEEP_MESSAGES
+// (default 10) from the environment.
+func newCompactor(repo *model.Repository, s Summarizer, size int) *compactor {
+	return &compactor{
+		repo:       repo,
+		summarizer: s,
+		threshold:  envInt("CHAT_SUMMARY_TOKENS", 6_000),
+		keep:       envInt("CHAT_SUMMARY_KEEP_MESSAGES", 10),
+		queue:      make(chan *model.Conversation, size),
+		pending:    make(map[primitive.ObjectID]bool),
+	}
+}
+
+func envInt(key string, def int) int {
+	if n, err := strconv.Atoi(os.Getenv(key)); err == nil && n > 0 {
+		return n
+	}
+	return def
+}
+
+// estimateTokens approximates the tokens msgs take in a prompt, at about four characters
+// per token plus a small per-message overhead.
+func estimateTokens(msgs []*model.Message) int {
+	n := 0
+	for _, m := range msgs {
+		n += utf8.RuneCountInString(m.Content)/4 + 4
+	}
+	return n
+}
+
+// due reports whether conv has enough unsummarized messages to compact.
+func (c *compactor) due(conv *model.Conversation) bool {
+	msgs := conv.Unsummarized()
+	return len(msgs) > c.keep && estimateTokens(msgs) > c.threshold
+}
+
+// Maybe schedules a summary of conv if it is due and none is pending. conv must not be
+// modified afterwards. It never blocks; a full queue skips the summary until the next reply.
+func (c *compactor) Maybe(conv *model.Conversation) {
+	if !c.due(conv) {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.pending[conv.ID] {
+		return
+	}
+
+	c.once.Do(func() { go c.run() })
+
+	select {
+	case c.queue <- conv:
+		c.pending[conv.ID] = true
+	default:
+		slog.Warn("Summary queue is full, skipping conversation", "conversation_id", conv.ID.Hex())
+	}
+}
+
+func (c *compactor) run() {
+	for conv := range c.queue {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		err := c.compact(ctx, conv)
+		cancel()
+
+		if err != nil {
+			slog.Warn("Failed to summarize conversation", "conversation_id", conv.ID.Hex(), "error", err)
+		}
+
+		c.mu.Lock()
+		delete(c.pending, conv.ID)
+		c.mu.Unlock()
+	}
+}
+
+func (c *compactor) compact(ctx context.Context, conv *model.Conversation) error {
+	msgs := conv.Unsummarized()
+	covered := msgs[:len(msgs)-c.keep]
+
+	var previous string
+	var prev primitive.ObjectID
+	if conv.Summary != nil {
+		previous, prev = conv.Summary.Content, conv.Summary.UpToMessageID
+	}
+
+	content, err := c.summarizer.Summarize(ctx, previous, covered)
+	if err != nil {
+		return err
+	}
+
+	summary := &model.Summary{
+		Content:       content,
+		UpToMessageID: covered[len(covered)-1].ID,
+		UpdatedAt:     time.Now(),
+	}
+
+	ok, err := c.repo.UpdateSummary(ctx, conv.ID, prev, summary)
+	if err != nil {
+		return err
+	}
+	if ok {
+		slog.Info("Summarized conversation", "conversation_id", conv.ID.Hex(), "messages", len(covered))
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package chat
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/acai-travel/tech-challenge/internal/chat/model"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestCompactorDue(t *testing.T) {
+	c := &compactor{threshold: 100, keep: 2}
+
+	messages := func(n, size int) []*model.Message {
+		out := make([]*model.Message, n)
+		for i := range out {
+			out[i] = &model.Message{ID: primitive.NewObjectID(), Role: model.RoleUser, Content: strings.Repeat("a", size)}
+		}
+		return out
+	}
+
+	t.Run("short conversation", func(t *testing.T) {
+		if c.due(&model.Conversation{Messages: messages(5, 10)}) {
+			t.Fatal("expected a short conversation not to be due")
+		}
+	})
+
+	t.Run("few long messages are kept verbatim", func(t *testing.T) {
+		if c.due(&model.Conversation{Messages: messages(2, 1_000)}) {
+			t.Fatal("expected no summary when only the kept messages exceed the threshold")
+		}
+	})
+
+	t.Run("long conversation", func(t *testing.T) {
+		if !c.due(&model.Conversation{Messages: messages(5, 1_000)}) {
+			t.Fatal("expected a long conversation to be due")
+		}
+	})
+
+	t.Run("summarized messages don't count", func(t *testing.T) {
+		msgs := append(messages(4, 1_000), messages(3, 10)...)
+		conv := &model.Conversation{Messages: msgs, Summary: &model.Summary{UpToMessageID: msgs[3].ID}}
+
+		if got := conv.Unsummarized(); len(got) != 3 || got[0] != msgs[4] {
+			t.Fatalf("expected the 3 messages after the summary, got %d", len(got))
+		}
+		if c.due(conv) {
+			t.Fatal("expected a summarized conversation not to be due")
+		}
+	})
+
+	t.Run("unknown summary boundary sends everything", func(t *testing.T) {
+		msgs := messages(3, 10)
+		conv := &model.Conversation{Messages: msgs, Summary: &model.Summary{UpToMessageID: primitive.NewObjectID()}}
+		if got := conv.Unsummarized(); len(got) != 3 {
+			t.Fatalf("expected all messages, got %d", len(got))
+		}
+	})
+}
@@ -12,7 +12,7 @@ import (
 
 type Fixture struct {
 	*model.Repository
-	test   *testing.T
+	test   testing.TB
 	defers []func()
 }
 
@@ -24,6 +24,15 @@ func WithFixture(runner func(t *testing.T, f *Fixture)) func(t *testing.T) {
 	}
 }
 
+// WithBenchFixture is WithFixture for benchmarks.
+func WithBenchFixture(runner func(b *testing.B, f *Fixture)) func(b *testing.B) {
+	return func(b *testing.B) {
+		f := &Fixture{Repository: model.New(ConnectMongo()), test: b}
+		defer f.Teardown()
+		runner(b, f)
+	}
+}
+
 func (f *Fixture) CreateConversation(mods ...func(*model.Conversation)) *model.Conversation {
 	c := &model.Conversation{
 		ID:        primitive.NewObjectID(),
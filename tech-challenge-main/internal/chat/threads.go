@@ -0,0 +1,538 @@
+package chat
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/acai-travel/tech-challenge/internal/audit"
+	"github.com/acai-travel/tech-challenge/internal/auth"
+	"github.com/acai-travel/tech-challenge/internal/chat/assistant"
+	"github.com/acai-travel/tech-challenge/internal/chat/model"
+	"github.com/acai-travel/tech-challenge/internal/pii"
+	"github.com/gorilla/mux"
+	"github.com/twitchtv/twirp"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// This file maps conversations to the threads of OpenAI's Assistants API, so tooling built
+// against it can use this service's persistence and tools. Threads are conversations and
+// keep their IDs; a run answers the last message of a thread. Runs complete before the
+// response is sent, so clients polling them find them done at once. Streaming runs, vector
+// stores and client-defined assistants or tools are not supported.
+
+const (
+	// threadMessagesLimit is the default page size of the messages of a thread, as in OpenAI's API.
+	threadMessagesLimit = 20
+	maxThreadMessages   = 100
+	// maxInitialMessages bounds the messages a thread may be created with.
+	maxInitialMessages = 32
+)
+
+type threadMessageRequest struct {
+	Role    string          `json:"role"`
+	Content json.RawMessage `json:"content"`
+}
+
+// text returns the content of a message: a string, or a list of parts of which only text
+// parts are supported.
+func (r threadMessageRequest) text() (string, error) {
+	var s string
+	if err := json.Unmarshal(r.Content, &s); err == nil {
+		return s, nil
+	}
+
+	var parts []struct {
+		Type string `json:"type"`
+		Text string `json:"text"`
+	}
+	if err := json.Unmarshal(r.Content, &parts); err != nil {
+		return "", twirp.InvalidArgumentError("content", "must be a string or a list of content parts")
+	}
+	texts := make([]string, 0, len(parts))
+	for _, p := range parts {
+		if p.Type != "text" {
+			return "", twirp.InvalidArgumentError("content", fmt.Sprintf("%q content parts are not supported", p.Type))
+		}
+		texts = append(texts, p.Text)
+	}
+	return strings.Join(texts, "\n\n"), nil
+}
+
+type thread struct {
+	ID        string            `json:"id"`
+	Object    string            `json:"object"`
+	CreatedAt int64             `json:"created_at"`
+	Metadata  map[string]string `json:"metadata"`
+}
+
+func threadObject(conv *model.Conversation) thread {
+	return thread{ID: conv.ID.Hex(), Object: "thread", CreatedAt: conv.CreatedAt.Unix(), Metadata: map[string]string{}}
+}
+
+type threadText struct {
+	Value       string `json:"value"`
+	Annotations []any  `json:"annotations"`
+}
+
+type threadContent struct {
+	Type string     `json:"type"`
+	Text threadText `json:"text"`
+}
+
+type threadMessage struct {
+	ID          string            `json:"id"`
+	Object      string            `json:"object"`
+	CreatedAt   int64             `json:"created_at"`
+	ThreadID    string            `json:"thread_id"`
+	Role        string            `json:"role"`
+	Content     []threadContent   `json:"content"`
+	AssistantID *string           `json:"assistant_id"`
+	RunID       *string           `json:"run_id"`
+	Attachments []any             `json:"attachments"`
+	Metadata    map[string]string `json:"metadata"`
+}
+
+func threadMessageObject(conv *model.Conversation, m *model.Message, runID string) threadMessage {
+	out := threadMessage{
+		ID:          m.ID.Hex(),
+		Object:      "thread.message",
+		CreatedAt:   m.CreatedAt.Unix(),
+		ThreadID:    conv.ID.Hex(),
+		Role:        string(m.Role),
+		Content:     []threadContent{{Type: "text", Text: threadText{Value: m.Content, Annotations: []any{}}}},
+		Attachments: []any{},
+		Metadata:    map[string]string{},
+	}
+	if m.Role == model.RoleAssistant {
+		name := assistantID(conv)
+		out.AssistantID = &name
+		if runID != "" {
+			out.RunID = &runID
+		}
+	}
+	return out
+}
+
+type threadRunError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+type threadRun struct {
+	ID           string            `json:"id"`
+	Object       string            `json:"object"`
+	CreatedAt    int64             `json:"created_at"`
+	ThreadID     string            `json:"thread_id"`
+	AssistantID  string            `json:"assistant_id"`
+	Status       string            `json:"status"`
+	StartedAt    *int64            `json:"started_at"`
+	CompletedAt  *int64            `json:"completed_at"`
+	FailedAt     *int64            `json:"failed_at"`
+	LastError    *threadRunError   `json:"last_error"`
+	Model        string            `json:"model"`
+	Instructions string            `json:"instructions"`
+	Tools        []any             `json:"tools"`
+	Metadata     map[string]string `json:"metadata"`
+}
+
+func assistantID(conv *model.Conversation) string {
+	if conv.Assistant == "" {
+		return DefaultAssistant
+	}
+	return conv.Assistant
+}
+
+// describeRun reports the run of a thread answering a message: runs are named after the
+// message they answer, so their state can be told from the thread alone.
+func (s *Server) describeRun(ctx context.Context, conv *model.Conversation, runID string) (*threadRun, error) {
+	i := slices.IndexFunc(conv.Messages, func(m *model.Message) bool { return m.ID.Hex() == runID })
+	if i < 0 || conv.Messages[i].Role != model.RoleUser {
+		return nil, twirp.NotFoundError("run not found")
+	}
+
+	msg := conv.Messages[i]
+	started := msg.CreatedAt.Unix()
+	run := &threadRun{
+		ID:          runID,
+		Object:      "thread.run",
+		CreatedAt:   started,
+		ThreadID:    conv.ID.Hex(),
+		AssistantID: assistantID(conv),
+		Status:      "in_progress",
+		StartedAt:   &started,
+		Model:       assistant.DefaultModel,
+		Tools:       []any{},
+		Metadata:    map[string]string{},
+	}
+	if m := conv.Settings.Proto().GetModel(); m != "" {
+		run.Model = m
+	}
+
+	if i+1 < len(conv.Messages) && conv.Messages[i+1].Role == model.RoleAssistant {
+		done := conv.Messages[i+1].CreatedAt.Unix()
+		run.Status, run.CompletedAt = "completed", &done
+		return run, nil
+	}
+
+	failed, err := s.repo.FindFailedGeneration(ctx, conv.ID)
+	if err != nil {
+		return nil, err
+	}
+	if failed != nil && failed.MessageID == msg.ID {
+		at := failed.UpdatedAt.Unix()
+		run.Status, run.FailedAt = "failed", &at
+		run.LastError = &threadRunError{Code: "server_error", Message: failedReplyNotice(failed)}
+	} else if i+1 < len(conv.Messages) {
+		// The user moved on without an answer.
+		run.Status = "cancelled"
+	}
+	return run, nil
+}
+
+// ThreadsHandler serves the OpenAI-compatible threads API under /v1/threads.
+func (s *Server) ThreadsHandler() http.Handler {
+	r := mux.NewRouter()
+	r.Use(audit.Route)
+	r.HandleFunc("/v1/threads", s.createThread).Methods(http.MethodPost)
+	r.HandleFunc("/v1/threads/{thread}", s.getThread).Methods(http.MethodGet)
+	r.HandleFunc("/v1/threads/{thread}", s.deleteThread).Methods(http.MethodDelete)
+	r.HandleFunc("/v1/threads/{thread}/messages", s.listThreadMessages).Methods(http.MethodGet)
+	r.HandleFunc("/v1/threads/{thread}/messages", s.createThreadMessage).Methods(http.MethodPost)
+	r.HandleFunc("/v1/threads/{thread}/runs", s.createThreadRun).Methods(http.MethodPost)
+	r.HandleFunc("/v1/threads/{thread}/runs/{run}", s.getThreadRun).Methods(http.MethodGet)
+	r.NotFoundHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeThreadError(r.Context(), w, twirp.NewError(twirp.BadRoute, "unknown endpoint "+r.Method+" "+r.URL.Path))
+	})
+	r.MethodNotAllowedHandler = r.NotFoundHandler
+	return r
+}
+
+func (s *Server) createThread(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Messages []threadMessageRequest `json:"messages"`
+	}
+	if !decodeThreadRequest(w, r, &req) {
+		return
+	}
+	if len(req.Messages) > maxInitialMessages {
+		writeThreadError(r.Context(), w, twirp.InvalidArgumentError("messages", fmt.Sprintf("must have at most %d entries", maxInitialMessages)))
+		return
+	}
+
+	now := time.Now()
+	conv := &model.Conversation{
+		ID:        primitive.NewObjectID(),
+		Title:     "Untitled conversation",
+		CreatedAt: now,
+		UpdatedAt: now,
+		Messages:  []*model.Message{},
+		Owner:     ownerOf(r.Context()),
+	}
+	for _, m := range req.Messages {
+		msg, err := s.threadMessage(r.Context(), m, now)
+		if err != nil {
+			writeThreadError(r.Context(), w, err)
+			return
+		}
+		conv.Messages = append(conv.Messages, msg)
+	}
+
+	if err := s.repo.CreateConversation(r.Context(), conv); err != nil {
+		writeThreadError(r.Context(), w, err)
+		return
+	}
+	audit.Conversation(r.Context(), conv.ID.Hex())
+	s.index(r.Context(), conv, conv.Messages...)
+	writeThreadJSON(w, threadObject(conv))
+}
+
+func (s *Server) getThread(w http.ResponseWriter, r *http.Request) {
+	audit.Conversation(r.Context(), mux.Vars(r)["thread"])
+	conv, err := s.describeConversation(r.Context(), mux.Vars(r)["thread"])
+	if err != nil {
+		writeThreadError(r.Context(), w, err)
+		return
+	}
+	writeThreadJSON(w, threadObject(conv))
+}
+
+func (s *Server) deleteThread(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["thread"]
+	audit.Conversation(r.Context(), id)
+	conv, err := s.repo.DescribeVisibleConversation(r.Context(), id, auth.User(r.Context()), []string{})
+	if err != nil {
+		writeThreadError(r.Context(), w, err)
+		return
+	}
+	n, err := s.repo.DeleteConversations(r.Context(), []primitive.ObjectID{conv.ID})
+	if err != nil {
+		writeThreadError(r.Context(), w, err)
+		return
+	}
+	if n == 0 {
+		writeThreadError(r.Context(), w, twirp.NotFoundError("conversation not found"))
+		return
+	}
+	writeThreadJSON(w, map[string]any{"id": id, "object": "thread.deleted", "deleted": true})
+}
+
+func (s *Server) listThreadMessages(w http.ResponseWriter, r *http.Request) {
+	audit.Conversation(r.Context(), mux.Vars(r)["thread"])
+	conv, err := s.describeConversation(r.Context(), mux.Vars(r)["thread"])
+	if err != nil {
+		writeThreadError(r.Context(), w, err)
+		return
+	}
+
+	q := r.URL.Query()
+	limit := threadMessagesLimit
+	if v := q.Get("limit"); v != "" {
+		if limit, err = strconv.Atoi(v); err != nil || limit < 1 || limit > maxThreadMessages {
+			writeThreadError(r.Context(), w, twirp.InvalidArgumentError("limit", fmt.Sprintf("must be between 1 and %d", maxThreadMessages)))
+			return
+		}
+	}
+
+	msgs := slices.Clone(conv.Messages)
+	switch q.Get("order") {
+	case "", "desc":
+		slices.Reverse(msgs)
+	case "asc":
+	default:
+		writeThreadError(r.Context(), w, twirp.InvalidArgumentError("order", "must be asc or desc"))
+		return
+	}
+	if after := q.Get("after"); after != "" {
+		i := slices.IndexFunc(msgs, func(m *model.Message) bool { return m.ID.Hex() == after })
+		msgs = msgs[i+1:] // all of them if not found
+	}
+	if before := q.Get("before"); before != "" {
+		if i := slices.IndexFunc(msgs, func(m *model.Message) bool { return m.ID.Hex() == before }); i >= 0 {
+			msgs = msgs[:i]
+		}
+	}
+
+	hasMore := len(msgs) > limit
+	msgs = msgs[:min(limit, len(msgs))]
+	data := make([]threadMessage, len(msgs))
+	for i, m := range msgs {
+		data[i] = threadMessageObject(conv, m, answeredMessageID(conv, m))
+	}
+
+	page := map[string]any{"object": "list", "data": data, "has_more": hasMore, "first_id": nil, "last_id": nil}
+	if len(data) > 0 {
+		page["first_id"], page["last_id"] = data[0].ID, data[len(data)-1].ID
+	}
+	writeThreadJSON(w, page)
+}
+
+// answeredMessageID returns the ID of the message an assistant message answers, i.e. the ID
+// of the run that created it, or "" if there is none.
+func answeredMessageID(conv *model.Conversation, m *model.Message) string {
+	i := slices.Index(conv.Messages, m)
+	if m.Role != model.RoleAssistant || i < 1 || conv.Messages[i-1].Role != model.RoleUser {
+		return ""
+	}
+	return conv.Messages[i-1].ID.Hex()
+}
+
+func (s *Server) createThreadMessage(w http.ResponseWriter, r *http.Request) {
+	var req threadMessageRequest
+	if !decodeThreadRequest(w, r, &req) {
+		return
+	}
+
+	audit.Conversation(r.Context(), mux.Vars(r)["thread"])
+	conv, err := s.describeConversation(r.Context(), mux.Vars(r)["thread"])
+	if err != nil {
+		writeThreadError(r.Context(), w, err)
+		return
+	}
+	msg, err := s.threadMessage(r.Context(), req, time.Now())
+	if err != nil {
+		writeThreadError(r.Context(), w, err)
+		return
+	}
+
+	if err := s.repo.AppendMessages(r.Context(), conv.ID, msg); err != nil {
+		writeThreadError(r.Context(), w, err)
+		return
+	}
+	s.index(r.Context(), conv, msg)
+	writeThreadJSON(w, threadMessageObject(conv, msg, ""))
+}
+
+// threadMessage validates a message added to a thread. Clients may add assistant messages,
+// e.g. to seed a thread, as in OpenAI's API.
+func (s *Server) threadMessage(ctx context.Context, req threadMessageRequest, now time.Time) (*model.Message, error) {
+	role := model.Role(req.Role)
+	if role != model.RoleUser && role != model.RoleAssistant {
+		return nil, twirp.InvalidArgumentError("role", "must be user or assistant")
+	}
+	content, err := req.text()
+	if err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(content) == "" {
+		return nil, twirp.RequiredArgumentError("content")
+	}
+	if role == model.RoleUser {
+		if err := s.checkAbuse(ctx, content); err != nil {
+			return nil, err
+		}
+	}
+
+	msg := &model.Message{ID: primitive.NewObjectID(), Role: role, Content: content, CreatedAt: now, UpdatedAt: now}
+	if role == model.RoleUser {
+		msg.PII = pii.Kinds(s.pii.Detect(content))
+		msg.DeviceID = auth.Device(ctx)
+	}
+	return msg, nil
+}
+
+func (s *Server) createThreadRun(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		AssistantID         string `json:"assistant_id"`
+		Stream              bool   `json:"stream"`
+		MaxCompletionTokens int64  `json:"max_completion_tokens"`
+	}
+	if !decodeThreadRequest(w, r, &req) {
+		return
+	}
+	ctx := r.Context()
+	if req.Stream {
+		writeThreadError(ctx, w, twirp.InvalidArgumentError("stream", "streaming runs are not supported"))
+		return
+	}
+	if req.AssistantID == "" {
+		writeThreadError(ctx, w, twirp.RequiredArgumentError("assistant_id"))
+		return
+	}
+	if _, ok := s.assistants.Get(req.AssistantID); !ok {
+		writeThreadError(ctx, w, twirp.InvalidArgumentError("assistant_id", "must be one of: "+strings.Join(s.assistants.Names(), ", ")))
+		return
+	}
+	if req.MaxCompletionTokens < 0 {
+		writeThreadError(ctx, w, twirp.InvalidArgumentError("max_completion_tokens", "must not be negative"))
+		return
+	}
+	ctx = withMaxOutputTokens(ctx, req.MaxCompletionTokens)
+
+	if err := s.checkQuota(ctx); err != nil {
+		writeThreadError(ctx, w, err)
+		return
+	}
+
+	id := mux.Vars(r)["thread"]
+	audit.Conversation(ctx, id)
+	unlock, err := s.lockConversation(ctx, id)
+	if err != nil {
+		writeThreadError(ctx, w, err)
+		return
+	}
+	defer unlock()
+
+	conv, err := s.describeConversation(ctx, id)
+	if err != nil {
+		writeThreadError(ctx, w, err)
+		return
+	}
+	if len(conv.Messages) == 0 || conv.Messages[len(conv.Messages)-1].Role != model.RoleUser {
+		writeThreadError(ctx, w, twirp.NewError(twirp.FailedPrecondition, "the thread has no user message to answer"))
+		return
+	}
+	conv.Assistant = req.AssistantID // for this run only
+
+	last := conv.Messages[len(conv.Messages)-1]
+	_, err = s.sendReply(ctx, conv, last)
+	var te twirp.Error
+	if errors.As(err, &te) && te.Code() == twirp.InvalidArgument {
+		writeThreadError(ctx, w, err)
+		return
+	}
+	// Other failures are recorded, and reported as a failed run.
+
+	conv, err = s.describeConversation(ctx, id)
+	if err != nil {
+		writeThreadError(ctx, w, err)
+		return
+	}
+	run, err := s.describeRun(ctx, conv, last.ID.Hex())
+	if err != nil {
+		writeThreadError(ctx, w, err)
+		return
+	}
+	run.AssistantID = req.AssistantID
+	writeThreadJSON(w, run)
+}
+
+func (s *Server) getThreadRun(w http.ResponseWriter, r *http.Request) {
+	audit.Conversation(r.Context(), mux.Vars(r)["thread"])
+	conv, err := s.describeConversation(r.Context(), mux.Vars(r)["thread"])
+	if err != nil {
+		writeThreadError(r.Context(), w, err)
+		return
+	}
+	run, err := s.describeRun(r.Context(), conv, mux.Vars(r)["run"])
+	if err != nil {
+		writeThreadError(r.Context(), w, err)
+		return
+	}
+	writeThreadJSON(w, run)
+}
+
+func decodeThreadRequest(w http.ResponseWriter, r *http.Request, v any) bool {
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1<<20)).Decode(v); err != nil {
+		writeThreadError(r.Context(), w, twirp.NewError(twirp.Malformed, "invalid JSON body: "+err.Error()))
+		return false
+	}
+	return true
+}
+
+func writeThreadJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// writeThreadError writes err in the error format of OpenAI's API.
+func writeThreadError(ctx context.Context, w http.ResponseWriter, err error) {
+	var te twirp.Error
+	if !errors.As(err, &te) {
+		te = twirp.InternalErrorWith(err)
+	}
+	audit.Failed(ctx, te.Code())
+
+	status := twirp.ServerHTTPStatusFromErrorCode(te.Code())
+	kind := "invalid_request_error"
+	if status >= 500 {
+		kind = "server_error"
+		slog.ErrorContext(ctx, "Threads API request failed", "error", err)
+	}
+
+	if after := te.Meta("retry_after"); after != "" {
+		w.Header().Set("Retry-After", after)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]any{"error": map[string]any{
+		"message": te.Msg(),
+		"type":    kind,
+		"param":   nilIfEmpty(te.Meta("argument")),
+		"code":    string(te.Code()),
+	}})
+}
+
+func nilIfEmpty(s string) any {
+	if s == "" {
+		return nil
+	}
+	return s
+}
@@ -0,0 +1,140 @@
+package chat
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/acai-travel/tech-challenge/internal/chat/model"
+	. "github.com/acai-travel/tech-challenge/internal/chat/testing"
+)
+
+// callThreads sends a request to the threads API of srv and decodes the JSON response into out.
+func callThreads(t *testing.T, srv *Server, method, path, body string, out any) int {
+	t.Helper()
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	rec := httptest.NewRecorder()
+	srv.ThreadsHandler().ServeHTTP(rec, req)
+	if out != nil {
+		if err := json.Unmarshal(rec.Body.Bytes(), out); err != nil {
+			t.Fatalf("%s %s: invalid JSON response %q: %v", method, path, rec.Body, err)
+		}
+	}
+	return rec.Code
+}
+
+func TestThreadMessageRequest_Text(t *testing.T) {
+	tests := []struct {
+		content string
+		want    string
+		wantErr bool
+	}{
+		{content: `"Weather in Paris?"`, want: "Weather in Paris?"},
+		{content: `[{"type":"text","text":"Weather"},{"type":"text","text":"in Paris?"}]`, want: "Weather\n\nin Paris?"},
+		{content: `[{"type":"image_url","image_url":{"url":"https://example.com/a.png"}}]`, wantErr: true},
+		{content: `42`, wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := threadMessageRequest{Role: "user", Content: json.RawMessage(tt.content)}.text()
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("text(%s) = %q, %v", tt.content, got, err)
+		}
+	}
+}
+
+func TestThreads_InvalidRequests(t *testing.T) {
+	srv := NewServer(nil, &fakeAssistant{})
+
+	tests := []struct {
+		name, method, path, body string
+		status                   int
+		param                    string
+	}{
+		{"unknown endpoint", http.MethodPost, "/v1/threads/x/steps", `{}`, http.StatusNotFound, ""},
+		{"malformed body", http.MethodPost, "/v1/threads", `{`, http.StatusBadRequest, ""},
+		{"invalid role", http.MethodPost, "/v1/threads", `{"messages":[{"role":"system","content":"hi"}]}`, http.StatusBadRequest, "role"},
+		{"streaming run", http.MethodPost, "/v1/threads/68a5aa7b14ba62ef8448c917/runs", `{"assistant_id":"general","stream":true}`, http.StatusBadRequest, "stream"},
+		{"negative max tokens", http.MethodPost, "/v1/threads/68a5aa7b14ba62ef8448c917/runs", `{"assistant_id":"general","max_completion_tokens":-1}`, http.StatusBadRequest, "max_completion_tokens"},
+		{"unknown assistant", http.MethodPost, "/v1/threads/68a5aa7b14ba62ef8448c917/runs", `{"assistant_id":"asst_abc"}`, http.StatusBadRequest, "assistant_id"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var resp struct {
+				Error struct {
+					Message string `json:"message"`
+					Type    string `json:"type"`
+					Param   string `json:"param"`
+				} `json:"error"`
+			}
+			if status := callThreads(t, srv, tt.method, tt.path, tt.body, &resp); status != tt.status {
+				t.Errorf("status = %d, want %d", status, tt.status)
+			}
+			if resp.Error.Type != "invalid_request_error" || resp.Error.Message == "" || resp.Error.Param != tt.param {
+				t.Errorf("error = %+v, want an invalid_request_error for %q", resp.Error, tt.param)
+			}
+		})
+	}
+}
+
+func TestThreads(t *testing.T) {
+	t.Run("create, run and list", WithFixture(func(t *testing.T, f *Fixture) {
+		srv := NewServer(f.Repository, &fakeAssistant{
+			replyFn: func(ctx context.Context, conv *model.Conversation) (string, error) {
+				return "Sunny in " + conv.Messages[len(conv.Messages)-1].Content, nil
+			},
+		})
+
+		var thread struct {
+			ID     string `json:"id"`
+			Object string `json:"object"`
+		}
+		if status := callThreads(t, srv, http.MethodPost, "/v1/threads", `{"messages":[{"role":"user","content":"Paris"}]}`, &thread); status != http.StatusOK || thread.Object != "thread" {
+			t.Fatalf("create thread: status %d, %+v", status, thread)
+		}
+		t.Cleanup(func() { _ = f.DeleteConversation(context.Background(), thread.ID) })
+
+		var run struct {
+			ID       string `json:"id"`
+			ThreadID string `json:"thread_id"`
+			Status   string `json:"status"`
+		}
+		if status := callThreads(t, srv, http.MethodPost, "/v1/threads/"+thread.ID+"/runs", `{"assistant_id":"general"}`, &run); status != http.StatusOK || run.Status != "completed" || run.ThreadID != thread.ID {
+			t.Fatalf("create run: status %d, %+v", status, run)
+		}
+
+		var polled struct {
+			Status string `json:"status"`
+		}
+		callThreads(t, srv, http.MethodGet, "/v1/threads/"+thread.ID+"/runs/"+run.ID, "", &polled)
+		if polled.Status != "completed" {
+			t.Errorf("polled run status = %q, want completed", polled.Status)
+		}
+
+		var page struct {
+			Data []struct {
+				Role    string `json:"role"`
+				RunID   string `json:"run_id"`
+				Content []struct {
+					Text struct {
+						Value string `json:"value"`
+					} `json:"text"`
+				} `json:"content"`
+			} `json:"data"`
+		}
+		callThreads(t, srv, http.MethodGet, "/v1/threads/"+thread.ID+"/messages", "", &page)
+		if len(page.Data) != 2 {
+			t.Fatalf("thread has %d messages, want 2", len(page.Data))
+		}
+		if latest := page.Data[0]; latest.Role != "assistant" || latest.RunID != run.ID || latest.Content[0].Text.Value != "Sunny in Paris" {
+			t.Errorf("latest message = %+v, want the run's reply", latest)
+		}
+
+		// Nothing is left to answer.
+		if status := callThreads(t, srv, http.MethodPost, "/v1/threads/"+thread.ID+"/runs", `{"assistant_id":"general"}`, nil); status != http.StatusPreconditionFailed {
+			t.Errorf("run without a user message: status = %d, want 412", status)
+		}
+	}))
+}
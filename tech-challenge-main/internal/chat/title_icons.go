@@ -0,0 +1,45 @@
+package chat
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/acai-travel/tech-challenge/internal/chat/intent"
+	"github.com/acai-travel/tech-challenge/internal/chat/model"
+)
+
+// categoryIcons are the emoji shown before the titles of conversations by category; the
+// others get none.
+var categoryIcons = map[intent.Label]string{
+	intent.Weather:        "🌤️",
+	intent.Holidays:       "📅",
+	intent.TravelPlanning: "✈️",
+	intent.Coding:         "💻",
+}
+
+// EnableTitleIcons gives new conversations the emoji of their category, the intent intent
+// analytics label their first message with, so it needs EnableIntentAnalytics. It is
+// returned in its own field, not in the title, so clients that don't want emoji can ignore
+// it.
+func (s *Server) EnableTitleIcons() {
+	s.titleIcons = true
+}
+
+func (s *Server) iconsEnabled() bool {
+	return s.titleIcons && s.intents != nil
+}
+
+// titleIcon labels the first message of conv, which StartConversation doesn't leave to the
+// background, and returns the icon of its category, or "" if icons aren't enabled or the
+// category has none. Failing to label conv is logged but non-fatal.
+func (s *Server) titleIcon(ctx context.Context, conv *model.Conversation) string {
+	if !s.iconsEnabled() {
+		return ""
+	}
+	label, err := s.intents.label(ctx, labelJob{conversationID: conv.ID, message: conv.Messages[0]})
+	if err != nil {
+		// Without a label, the conversation has no icon.
+		slog.WarnContext(ctx, "Failed to label message intent", "conversation_id", conv.ID.Hex(), "error", err)
+	}
+	return categoryIcons[label]
+}
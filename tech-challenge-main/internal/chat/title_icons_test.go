@@ -0,0 +1,95 @@
+package chat
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+
+	"github.com/acai-travel/tech-challenge/internal/chat/intent"
+	"github.com/acai-travel/tech-challenge/internal/chat/model"
+	. "github.com/acai-travel/tech-challenge/internal/chat/testing"
+	"github.com/acai-travel/tech-challenge/internal/pb"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestTitleIcon(t *testing.T) {
+	ctx := context.Background()
+	conv := &model.Conversation{ID: primitive.NewObjectID(), Messages: []*model.Message{
+		{ID: primitive.NewObjectID(), Role: model.RoleAssistant, Content: "Hi! Where to?"},
+		{ID: primitive.NewObjectID(), Role: model.RoleUser, Content: "Will it rain in Oslo?"},
+		{ID: primitive.NewObjectID(), Role: model.RoleUser, Content: "Plan a 3-day itinerary for Rome"},
+	}}
+
+	if got := (&Server{}).titleIcon(ctx, conv); got != "" {
+		t.Errorf("icon = %q without EnableTitleIcons, want none", got)
+	}
+	srv := NewServer(nil, &fakeAssistant{})
+	srv.EnableTitleIcons()
+	if got := srv.titleIcon(ctx, conv); got != "" {
+		t.Errorf("icon = %q without intent analytics, whose labels icons are, want none", got)
+	}
+
+	// Only the first user message gives the conversation its icon.
+	srv.EnableIntentAnalytics(intent.Keywords{})
+	srv.intents.once.Do(func() {}) // keeps the jobs queued
+	srv.labelIntents(ctx, conv, conv.Messages...)
+	close(srv.intents.queue)
+	var icons []bool
+	for job := range srv.intents.queue {
+		icons = append(icons, job.icon)
+	}
+	if len(icons) != 2 || !icons[0] || icons[1] {
+		t.Errorf("jobs give an icon %v, want for the first user message only", icons)
+	}
+}
+
+func TestStartConversation_TitleIcon(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	repo := model.New(ConnectMongo())
+	fa := &fakeAssistant{
+		titleFn: func(context.Context, *model.Conversation) (string, error) { return "Weekend in Lisbon", nil },
+		replyFn: func(context.Context, *model.Conversation) (string, error) { return "Here's a plan.", nil },
+	}
+	var classified atomic.Int32
+	srv := NewServer(repo, fa)
+	srv.EnableIntentAnalytics(intent.ClassifierFunc(func(ctx context.Context, content string) (intent.Label, error) {
+		classified.Add(1)
+		return intent.Keywords{}.Classify(ctx, content)
+	}))
+	srv.EnableTitleIcons()
+
+	out, err := srv.StartConversation(ctx, &pb.StartConversationRequest{Message: "Plan a weekend trip to Lisbon"})
+	if err != nil {
+		t.Fatalf("StartConversation error: %v", err)
+	}
+	if out.GetIcon() != "✈️" || out.GetTitle() != "Weekend in Lisbon" {
+		t.Errorf("got icon %q and title %q, want the travel icon apart from the title", out.GetIcon(), out.GetTitle())
+	}
+	if n := classified.Load(); n != 1 {
+		t.Errorf("classified %d messages, want the first one once, for both the icon and analytics", n)
+	}
+
+	conv, err := repo.DescribeConversation(ctx, out.GetConversationId())
+	if err != nil {
+		t.Fatalf("DescribeConversation error: %v", err)
+	}
+	if got := conv.Proto().GetIcon(); got != "✈️" {
+		t.Errorf("stored icon = %q, want the travel one", got)
+	}
+	if got := conv.Messages[0].Intent; got != string(intent.TravelPlanning) {
+		t.Errorf("first message intent = %q, want the label of the icon", got)
+	}
+
+	// Conversations started otherwise get their icon when the first message is labeled.
+	other := &model.Conversation{ID: primitive.NewObjectID(), Messages: []*model.Message{{ID: primitive.NewObjectID(), Role: model.RoleUser, Content: "Will it rain in Oslo?"}}}
+	if err := repo.CreateConversation(ctx, other); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := srv.intents.label(ctx, labelJob{conversationID: other.ID, message: other.Messages[0], icon: true}); err != nil {
+		t.Fatalf("label error: %v", err)
+	}
+	if conv, err := repo.DescribeConversation(ctx, other.ID.Hex()); err != nil || conv.Icon != "🌤️" {
+		t.Errorf("stored icon = %v, %v; want the weather one", conv, err)
+	}
+}
@@ -0,0 +1,177 @@
+package chat
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/acai-travel/tech-challenge/internal/chat/model"
+	"github.com/acai-travel/tech-challenge/internal/vector"
+	"github.com/hashicorp/golang-lru/v2/expirable"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// VectorStore keeps message embeddings and finds the ones nearest to a query. Embeddings
+// are always stored in MongoDB; stores differ in how they search them.
+type VectorStore interface {
+	Save(ctx context.Context, embeddings ...*model.MessageEmbedding) error
+	// Search returns up to q.Limit embeddings, most similar first.
+	Search(ctx context.Context, q model.EmbeddingQuery) ([]model.ScoredEmbedding, error)
+}
+
+// NewScanVectorStore returns a store that scores a user's most recent embeddings one by one
+// on every search. It is exact and always up to date, but only searches the last
+// maxSemanticCandidates messages.
+func NewScanVectorStore(repo *model.Repository) VectorStore {
+	return scanVectorStore{repo: repo}
+}
+
+type scanVectorStore struct {
+	repo *model.Repository
+}
+
+func (s scanVectorStore) Save(ctx context.Context, embeddings ...*model.MessageEmbedding) error {
+	return s.repo.SaveEmbeddings(ctx, embeddings...)
+}
+
+func (s scanVectorStore) Search(ctx context.Context, q model.EmbeddingQuery) ([]model.ScoredEmbedding, error) {
+	candidates, err := s.repo.ListEmbeddings(ctx, q.UserID, q.Model, maxSemanticCandidates)
+	if err != nil {
+		return nil, err
+	}
+
+	kept := candidates[:0]
+	for _, e := range candidates {
+		if q.ExcludeConversation.IsZero() || e.ConversationID != q.ExcludeConversation {
+			kept = append(kept, e)
+		}
+	}
+	return rankBySimilarity(q.Vector, kept, q.Limit), nil
+}
+
+// NewAtlasVectorStore returns a store searching with the MongoDB Atlas Vector Search index
+// named index. Searches scale to any history but need an Atlas cluster.
+func NewAtlasVectorStore(repo *model.Repository, index string) VectorStore {
+	return atlasVectorStore{repo: repo, index: index}
+}
+
+type atlasVectorStore struct {
+	repo  *model.Repository
+	index string
+}
+
+func (s atlasVectorStore) Save(ctx context.Context, embeddings ...*model.MessageEmbedding) error {
+	return s.repo.SaveEmbeddings(ctx, embeddings...)
+}
+
+func (s atlasVectorStore) Search(ctx context.Context, q model.EmbeddingQuery) ([]model.ScoredEmbedding, error) {
+	return s.repo.SearchEmbeddings(ctx, s.index, q)
+}
+
+const (
+	// memoryIndexes bounds how many users' indexes a memory store keeps, at up to
+	// maxSemanticCandidates embeddings each.
+	memoryIndexes = 64
+	// memoryIndexTTL bounds how long an index misses embeddings saved or deleted by other
+	// instances.
+	memoryIndexTTL = time.Minute
+)
+
+// NewMemoryVectorStore returns a store that loads each searched user's recent embeddings
+// into an in-process HNSW graph, so searches are fast on any MongoDB. Indexes are updated
+// with the embeddings this instance saves and reloaded every memoryIndexTTL.
+func NewMemoryVectorStore(repo *model.Repository) VectorStore {
+	return &memoryVectorStore{
+		repo:    repo,
+		indexes: expirable.NewLRU[string, *userVectors](memoryIndexes, nil, memoryIndexTTL),
+	}
+}
+
+type memoryVectorStore struct {
+	repo *model.Repository
+
+	// mu makes creating a missing index atomic; the LRU is safe for concurrent use.
+	mu      sync.Mutex
+	indexes *expirable.LRU[string, *userVectors]
+}
+
+// userVectors is the index of one user's embeddings of one model.
+type userVectors struct {
+	mu     sync.Mutex
+	loaded bool
+	graph  *vector.HNSW
+	// embeddings are the embeddings of graph nodes, nil once replaced by a newer one.
+	embeddings []*model.MessageEmbedding
+	nodes      map[primitive.ObjectID]int
+}
+
+func vectorsKey(userID, embeddingModel string) string {
+	return userID + "\x00" + embeddingModel
+}
+
+func (s *memoryVectorStore) Save(ctx context.Context, embeddings ...*model.MessageEmbedding) error {
+	// Saving first means an index loading concurrently either reads the embeddings or
+	// is loaded before they are added below.
+	if err := s.repo.SaveEmbeddings(ctx, embeddings...); err != nil {
+		return err
+	}
+
+	for _, e := range embeddings {
+		ix, ok := s.indexes.Peek(vectorsKey(e.UserID, e.Model))
+		if !ok {
+			continue
+		}
+
+		ix.mu.Lock()
+		if ix.loaded {
+			ix.add(e)
+		}
+		ix.mu.Unlock()
+	}
+	return nil
+}
+
+func (s *memoryVectorStore) Search(ctx context.Context, q model.EmbeddingQuery) ([]model.ScoredEmbedding, error) {
+	key := vectorsKey(q.UserID, q.Model)
+	s.mu.Lock()
+	ix, ok := s.indexes.Get(key)
+	if !ok {
+		ix = &userVectors{graph: vector.NewHNSW(), nodes: map[primitive.ObjectID]int{}}
+		s.indexes.Add(key, ix)
+	}
+	s.mu.Unlock()
+
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+
+	if !ix.loaded {
+		candidates, err := s.repo.ListEmbeddings(ctx, q.UserID, q.Model, maxSemanticCandidates)
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range candidates {
+			ix.add(e)
+		}
+		ix.loaded = true
+	}
+
+	results := ix.graph.Search(q.Vector, q.Limit, func(n int) bool {
+		e := ix.embeddings[n]
+		return e != nil && (q.ExcludeConversation.IsZero() || e.ConversationID != q.ExcludeConversation)
+	})
+
+	hits := make([]model.ScoredEmbedding, len(results))
+	for i, r := range results {
+		hits[i] = model.ScoredEmbedding{Embedding: ix.embeddings[r.Node], Score: r.Score}
+	}
+	return hits, nil
+}
+
+func (ix *userVectors) add(e *model.MessageEmbedding) {
+	if n, ok := ix.nodes[e.MessageID]; ok {
+		ix.embeddings[n] = nil
+	}
+	n := ix.graph.Add(e.Vector)
+	ix.embeddings = append(ix.embeddings, e)
+	ix.nodes[e.MessageID] = n
+}
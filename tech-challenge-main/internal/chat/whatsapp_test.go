@@ -0,0 +1,314 @@
+package chat
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/acai-travel/tech-challenge/internal/abuse"
+	"github.com/acai-travel/tech-challenge/internal/blob"
+	"github.com/acai-travel/tech-challenge/internal/chat/model"
+	. "github.com/acai-travel/tech-challenge/internal/chat/testing"
+	"github.com/acai-travel/tech-challenge/internal/quota"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const testWhatsAppWebhook = `{"object":"whatsapp_business_account","entry":[{"changes":[
+	{"field":"messages","value":{"statuses":[{"id":"wamid.S","status":"read"}]}},
+	{"field":"messages","value":{"messages":[
+		{"id":"wamid.1","from":"34600111222","type":"text","text":{"body":" Tapas near me? "}},
+		{"id":"wamid.2","from":"34600111222","type":"image","image":{"id":"m1","mime_type":"image/jpeg","caption":"Where is this?"}},
+		{"id":"wamid.3","from":"34600111222","type":"location","location":{"latitude":41.38879,"longitude":2.15899,"name":"Hotel Arts"}}
+	]}}]}]}`
+
+func signWhatsApp(body, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(body))
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestParseWhatsAppWebhook(t *testing.T) {
+	msgs, err := parseWhatsAppWebhook([]byte(testWhatsAppWebhook))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(msgs) != 3 {
+		t.Fatalf("got %d messages; want 3 without the status update", len(msgs))
+	}
+	for i, want := range []string{"Tapas near me?", "Where is this?", "My location: Hotel Arts (41.38879,2.15899)"} {
+		if got := msgs[i].content(); got != want {
+			t.Errorf("message %d content = %q; want %q", i, got, want)
+		}
+	}
+	if m := msgs[1].media(); m == nil || m.ID != "m1" || m.MimeType != "image/jpeg" {
+		t.Errorf("media = %+v", m)
+	}
+	if _, err := parseWhatsAppWebhook([]byte(`{"object":"page"}`)); err == nil {
+		t.Error("expected an error for another webhook object")
+	}
+}
+
+func TestSplitWhatsAppText(t *testing.T) {
+	if got := splitWhatsAppText("short"); len(got) != 1 || got[0] != "short" {
+		t.Errorf("splitWhatsAppText(short) = %q", got)
+	}
+
+	long := strings.Repeat("é", 3000) + "\n\n" + strings.Repeat("b", 3000)
+	got := splitWhatsAppText(long)
+	if len(got) != 2 || got[0] != strings.Repeat("é", 3000) || got[1] != strings.Repeat("b", 3000) {
+		t.Errorf("expected the text split between paragraphs, got %d parts", len(got))
+	}
+	for _, part := range splitWhatsAppText(strings.Repeat("x", 10000)) {
+		if n := utf8.RuneCountInString(part); n > maxWhatsAppText {
+			t.Errorf("part of %d characters", n)
+		}
+	}
+}
+
+func TestWhatsAppHandler(t *testing.T) {
+	disabled := NewServer(nil, &fakeAssistant{})
+	rec := httptest.NewRecorder()
+	disabled.WhatsAppHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/whatsapp/webhook", strings.NewReader(testWhatsAppWebhook)))
+	if rec.Code != http.StatusNotImplemented {
+		t.Errorf("disabled channel: got status %d", rec.Code)
+	}
+
+	srv := NewServer(nil, &fakeAssistant{})
+	srv.EnableWhatsApp(&fakeWhatsApp{}, "verify-me", "s3cret")
+
+	rec = httptest.NewRecorder()
+	srv.WhatsAppHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/whatsapp/webhook?hub.mode=subscribe&hub.verify_token=verify-me&hub.challenge=42", nil))
+	if rec.Code != http.StatusOK || rec.Body.String() != "42" {
+		t.Errorf("verification: got %d %q; want the challenge echoed", rec.Code, rec.Body.String())
+	}
+	rec = httptest.NewRecorder()
+	srv.WhatsAppHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/whatsapp/webhook?hub.mode=subscribe&hub.verify_token=guess&hub.challenge=42", nil))
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("wrong verify token: got status %d", rec.Code)
+	}
+
+	statuses := `{"object":"whatsapp_business_account","entry":[]}`
+	tests := []struct {
+		name, body, signature string
+		status                int
+	}{
+		{"missing signature", testWhatsAppWebhook, "", http.StatusUnauthorized},
+		{"wrong signature", testWhatsAppWebhook, signWhatsApp(testWhatsAppWebhook, "guess"), http.StatusUnauthorized},
+		{"not a webhook", "hello", signWhatsApp("hello", "s3cret"), http.StatusBadRequest},
+		{"no messages", statuses, signWhatsApp(statuses, "s3cret"), http.StatusOK},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodPost, "/whatsapp/webhook", strings.NewReader(tt.body))
+		req.Header.Set("X-Hub-Signature-256", tt.signature)
+		rec := httptest.NewRecorder()
+		srv.WhatsAppHandler().ServeHTTP(rec, req)
+		if rec.Code != tt.status {
+			t.Errorf("%s: got status %d, want %d", tt.name, rec.Code, tt.status)
+		}
+	}
+}
+
+func TestWhatsAppCloudClient(t *testing.T) {
+	var sent string
+	var api *httptest.Server
+	api = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer tok" {
+			t.Errorf("missing token on %s", r.URL.Path)
+		}
+		switch r.URL.Path {
+		case "/123/messages":
+			b, _ := io.ReadAll(r.Body)
+			sent = string(b)
+			fmt.Fprint(w, `{"messages":[{"id":"wamid.R"}]}`)
+		case "/m1":
+			fmt.Fprintf(w, `{"url":%q,"mime_type":"image/jpeg"}`, api.URL+"/download/m1")
+		case "/download/m1":
+			w.Header().Set("Content-Type", "image/jpeg")
+			fmt.Fprint(w, "\xff\xd8\xff\xe0jpeg")
+		default:
+			http.Error(w, `{"error":{"message":"unknown"}}`, http.StatusBadRequest)
+		}
+	}))
+	defer api.Close()
+
+	c := NewWhatsAppCloudClient("123", "tok")
+	c.baseURL = api.URL
+	if err := c.SendText(context.Background(), "34600111222", "Hi"); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(sent, `"to":"34600111222"`) || !strings.Contains(sent, `"body":"Hi"`) {
+		t.Errorf("sent %s", sent)
+	}
+	data, contentType, err := c.Media(context.Background(), "m1")
+	if err != nil || contentType != "image/jpeg" || string(data) != "\xff\xd8\xff\xe0jpeg" {
+		t.Errorf("Media() = %q, %q, %v", data, contentType, err)
+	}
+	if _, _, err := c.Media(context.Background(), "missing"); err == nil || !strings.Contains(err.Error(), "status 400") {
+		t.Errorf("expected the API error, got %v", err)
+	}
+}
+
+type fakeWhatsApp struct {
+	mu    sync.Mutex
+	sent  []string
+	media map[string][]byte
+}
+
+func (f *fakeWhatsApp) SendText(_ context.Context, to, body string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.sent = append(f.sent, to+": "+body)
+	return nil
+}
+
+func (f *fakeWhatsApp) Media(_ context.Context, id string) ([]byte, string, error) {
+	data, ok := f.media[id]
+	if !ok {
+		return nil, "", fmt.Errorf("no media %q", id)
+	}
+	return data, "image/jpeg", nil
+}
+
+func TestAnswerWhatsApp_Chat(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	repo := model.New(ConnectMongo())
+	fa := &fakeAssistant{
+		replyFn: func(_ context.Context, c *model.Conversation) (string, error) {
+			return "Reply to: " + c.Messages[len(c.Messages)-1].Content, nil
+		},
+	}
+	api := &fakeWhatsApp{media: map[string][]byte{"m1": []byte("\xff\xd8\xff\xe0jpeg")}}
+	srv := NewServer(repo, fa)
+	srv.EnableWhatsApp(api, "verify-me", "s3cret")
+	srv.EnableAttachments(blob.NewMemory(), nil)
+
+	phone := primitive.NewObjectID().Hex()
+	msgs, err := parseWhatsAppWebhook([]byte(strings.ReplaceAll(strings.ReplaceAll(testWhatsAppWebhook, "34600111222", phone), "wamid.", t.Name()+".")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, m := range msgs {
+		if err := srv.answerWhatsApp(ctx, m); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// A redelivered message is not answered twice.
+	if err := srv.answerWhatsApp(ctx, msgs[0]); err != nil {
+		t.Fatal(err)
+	}
+	if len(api.sent) != 3 || api.sent[0] != phone+": Reply to: Tapas near me?" {
+		t.Fatalf("sent = %q", api.sent)
+	}
+
+	conv, err := repo.FindWhatsAppConversation(ctx, phone)
+	if err != nil || conv == nil {
+		t.Fatalf("FindWhatsAppConversation = %v, %v", conv, err)
+	}
+	if got := len(conv.Messages); got != 6 {
+		t.Errorf("expected the messages and replies in one conversation, got %d messages", got)
+	}
+	if conv.Owner != whatsAppUser(phone) {
+		t.Errorf("owner = %q; want the sender, so the API shows the conversation to them only", conv.Owner)
+	}
+	if a := conv.Messages[2].Attachments; len(a) != 1 || a[0].ContentType != "image/jpeg" || a[0].UserID != whatsAppUser(phone) {
+		t.Errorf("expected the image as an attachment of the user, got %+v", a)
+	}
+
+	// /new starts another conversation.
+	if err := srv.answerWhatsApp(ctx, &whatsAppMessage{ID: t.Name() + ".4", From: phone, Type: "text", Text: struct {
+		Body string `json:"body"`
+	}{"/new"}}); err != nil {
+		t.Fatal(err)
+	}
+	if c, _ := repo.FindWhatsAppConversation(ctx, phone); c != nil {
+		t.Errorf("expected no current conversation after /new, got %s", c.ID.Hex())
+	}
+}
+
+func TestAnswerWhatsApp_Abuse(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	repo := model.New(ConnectMongo())
+	api := &fakeWhatsApp{}
+	srv := NewServer(repo, &fakeAssistant{})
+	srv.EnableWhatsApp(api, "verify-me", "s3cret")
+	srv.EnableAbuseDetection(abuse.Policy{RepeatLimit: 1, RepeatWindow: time.Minute, Throttle: time.Minute}, nil)
+	srv.EnableQuotas(quota.Defaults())
+
+	phone := primitive.NewObjectID().Hex()
+	for i := range 3 {
+		if err := srv.answerWhatsApp(ctx, &whatsAppMessage{ID: fmt.Sprintf("%s.%d", t.Name(), i), From: phone, Type: "text", Text: struct {
+			Body string `json:"body"`
+		}{"Hi"}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(api.sent) != 1 {
+		t.Errorf("sent = %q; want the throttled sender answered once", api.sent)
+	}
+	used, err := repo.FindDailyUsage(ctx, whatsAppUser(phone), quota.Day(time.Now()))
+	if err != nil || used.Messages != 1 {
+		t.Errorf("daily usage = %+v, %v; want only the answered message counted", used, err)
+	}
+}
+
+func TestOpenWhatsAppConversation_Concurrent(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	repo := model.New(ConnectMongo())
+	phone := primitive.NewObjectID().Hex()
+
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		ids     = map[primitive.ObjectID]bool{}
+		created int
+	)
+	for range 5 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			c, ok, err := repo.OpenWhatsAppConversation(ctx, &model.Conversation{
+				ID:       primitive.NewObjectID(),
+				Title:    "Tapas",
+				WhatsApp: &model.WhatsAppChat{Phone: phone},
+			})
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			ids[c.ID] = true
+			if ok {
+				created++
+			}
+		}()
+	}
+	wg.Wait()
+	if len(ids) != 1 || created != 1 {
+		t.Fatalf("got %d conversations, %d created; want one for the chat", len(ids), created)
+	}
+
+	// Once archived, the next message starts another one.
+	for id := range ids {
+		if _, err := repo.ArchiveConversations(ctx, []primitive.ObjectID{id}, time.Now()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	c, ok, err := repo.OpenWhatsAppConversation(ctx, &model.Conversation{ID: primitive.NewObjectID(), WhatsApp: &model.WhatsAppChat{Phone: phone}})
+	if err != nil || !ok || ids[c.ID] {
+		t.Errorf("after archiving, got %v, %v, %v; want a new conversation", c, ok, err)
+	}
+}
@@ -0,0 +1,300 @@
+package chat
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/acai-travel/tech-challenge/internal/auth"
+	"github.com/acai-travel/tech-challenge/internal/chat/model"
+	"github.com/acai-travel/tech-challenge/internal/textx"
+	"github.com/gorilla/mux"
+	"github.com/twitchtv/twirp"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// This file serves the chat widget third-party websites embed. Visitors are anonymous: a
+// session token signed by the server identifies them, for the website it was issued to.
+// Browsers only call the widget endpoints from the allowed origins, and each origin shares
+// a rate limit, so one busy or abusive website can't exhaust the assistant for the others.
+
+const (
+	// widgetSessionTTL is how long a visitor keeps their session, and so their conversations.
+	widgetSessionTTL = 24 * time.Hour
+	// widgetTag marks the conversations held in the widget.
+	widgetTag = "widget"
+	// widgetVisitorPrefix starts the user IDs of widget visitors.
+	widgetVisitorPrefix = "widget:"
+)
+
+// widget serves the embeddable chat widget; nil secret means it is disabled.
+type widget struct {
+	secret  []byte
+	origins map[string]bool
+	limiter *originLimiter
+}
+
+// EnableWidget serves the chat widget to the websites of origins, e.g.
+// "https://example.com", with WidgetHandler. Session tokens are signed with secret. Each
+// origin may make perMinute requests a minute, with bursts as large.
+func (s *Server) EnableWidget(secret []byte, origins []string, perMinute int) {
+	allowed := make(map[string]bool, len(origins))
+	for _, o := range origins {
+		allowed[strings.TrimSuffix(strings.TrimSpace(o), "/")] = true
+	}
+	s.widget = widget{secret: secret, origins: allowed, limiter: newOriginLimiter(perMinute)}
+}
+
+var (
+	errWidgetDisabled       = twirp.NewError(twirp.Unimplemented, "the chat widget is not enabled")
+	errInvalidWidgetSession = twirp.NewError(twirp.Unauthenticated, "invalid session token")
+)
+
+// widgetSession is the payload of a session token.
+type widgetSession struct {
+	VisitorID string `json:"sub"`
+	Origin    string `json:"origin"`
+	ExpiresAt int64  `json:"exp"`
+}
+
+// signWidgetSession returns the token of a session: its JSON payload and the HMAC-SHA256
+// of the payload, both base64url encoded and joined by a dot.
+func signWidgetSession(secret []byte, sess widgetSession) string {
+	payload, _ := json.Marshal(sess)
+	enc := base64.RawURLEncoding.EncodeToString(payload)
+	return enc + "." + base64.RawURLEncoding.EncodeToString(widgetSignature(secret, enc))
+}
+
+func widgetSignature(secret []byte, payload string) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(payload))
+	return mac.Sum(nil)
+}
+
+// parseWidgetSession returns the session of a token signed with secret, unless it expired.
+func parseWidgetSession(secret []byte, token string, now time.Time) (widgetSession, error) {
+	var sess widgetSession
+	payload, sig, ok := strings.Cut(token, ".")
+	if !ok {
+		return sess, errInvalidWidgetSession
+	}
+	got, err := base64.RawURLEncoding.DecodeString(sig)
+	if err != nil || !hmac.Equal(got, widgetSignature(secret, payload)) {
+		return sess, errInvalidWidgetSession
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil || json.Unmarshal(raw, &sess) != nil {
+		return sess, errInvalidWidgetSession
+	}
+	if now.Unix() >= sess.ExpiresAt {
+		return sess, twirp.NewError(twirp.Unauthenticated, "the session expired, start a new one")
+	}
+	return sess, nil
+}
+
+// originLimiter is a token bucket per origin. Origins come from the allow list, so there
+// are few buckets and they are never evicted.
+type originLimiter struct {
+	perMinute int
+
+	mu      sync.Mutex
+	buckets map[string]*originBucket
+}
+
+type originBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+func newOriginLimiter(perMinute int) *originLimiter {
+	return &originLimiter{perMinute: perMinute, buckets: map[string]*originBucket{}}
+}
+
+// allow takes a token from the bucket of origin, or reports how long until one is free.
+func (l *originLimiter) allow(origin string, now time.Time) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	b, ok := l.buckets[origin]
+	if !ok {
+		b = &originBucket{tokens: float64(l.perMinute), last: now}
+		l.buckets[origin] = b
+	}
+	perSecond := float64(l.perMinute) / 60
+	b.tokens = min(float64(l.perMinute), b.tokens+now.Sub(b.last).Seconds()*perSecond)
+	b.last = now
+	if b.tokens < 1 {
+		return false, time.Duration((1 - b.tokens) / perSecond * float64(time.Second))
+	}
+	b.tokens--
+	return true, 0
+}
+
+// WidgetHandler serves the endpoints of the chat widget:
+//
+//	POST /widget/sessions                starts an anonymous session
+//	POST /widget/messages                sends a message and streams the reply
+//	GET  /widget/conversations/{id}      returns a conversation of the session
+//
+// Sessions are passed as bearer tokens. Requests must come from an origin of EnableWidget.
+func (s *Server) WidgetHandler() http.Handler {
+	r := mux.NewRouter()
+	r.HandleFunc("/widget/sessions", s.createWidgetSession).Methods(http.MethodPost)
+	r.HandleFunc("/widget/messages", s.sendWidgetMessage).Methods(http.MethodPost)
+	r.HandleFunc("/widget/conversations/{id}", s.getWidgetConversation).Methods(http.MethodGet)
+	r.NotFoundHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeStreamError(r.Context(), w, twirp.NewError(twirp.BadRoute, "unknown endpoint "+r.Method+" "+r.URL.Path))
+	})
+	r.MethodNotAllowedHandler = r.NotFoundHandler
+
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if s.widget.secret == nil {
+			writeStreamError(req.Context(), w, errWidgetDisabled)
+			return
+		}
+		origin := strings.TrimSuffix(req.Header.Get("Origin"), "/")
+		if !s.widget.origins[origin] {
+			writeStreamError(req.Context(), w, twirp.NewError(twirp.PermissionDenied, "origin not allowed"))
+			return
+		}
+		w.Header().Set("Access-Control-Allow-Origin", origin)
+		w.Header().Add("Vary", "Origin")
+
+		if req.Method == http.MethodOptions {
+			w.Header().Set("Access-Control-Allow-Methods", "GET, POST")
+			w.Header().Set("Access-Control-Allow-Headers", "Authorization, Content-Type")
+			w.Header().Set("Access-Control-Max-Age", "600")
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		if ok, wait := s.widget.limiter.allow(origin, time.Now()); !ok {
+			w.Header().Set("Retry-After", strconv.Itoa(int(wait.Seconds())+1))
+			writeStreamError(req.Context(), w, twirp.NewError(twirp.ResourceExhausted, "too many requests from this website, try again later"))
+			return
+		}
+		r.ServeHTTP(w, req)
+	})
+}
+
+func (s *Server) createWidgetSession(w http.ResponseWriter, r *http.Request) {
+	sess := widgetSession{
+		VisitorID: widgetVisitorPrefix + primitive.NewObjectID().Hex(),
+		Origin:    strings.TrimSuffix(r.Header.Get("Origin"), "/"),
+		ExpiresAt: time.Now().Add(widgetSessionTTL).Unix(),
+	}
+	writeStreamJSON(w, map[string]any{
+		"token":      signWidgetSession(s.widget.secret, sess),
+		"expires_at": sess.ExpiresAt,
+	})
+}
+
+// widgetVisitor authenticates the session of a request, which must come from the origin it
+// was issued to, and returns the context of its visitor.
+func (s *Server) widgetVisitor(r *http.Request) (context.Context, widgetSession, error) {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok {
+		return nil, widgetSession{}, twirp.NewError(twirp.Unauthenticated, "missing session token")
+	}
+	sess, err := parseWidgetSession(s.widget.secret, token, time.Now())
+	if err != nil {
+		return nil, sess, err
+	}
+	if sess.Origin != strings.TrimSuffix(r.Header.Get("Origin"), "/") {
+		return nil, sess, twirp.NewError(twirp.PermissionDenied, "the session belongs to another website")
+	}
+	// The visitor's identity comes from the token alone, never from the user header.
+	return auth.WithUser(r.Context(), sess.VisitorID), sess, nil
+}
+
+// widgetConversation loads a conversation of the visitor; those of others aren't found.
+func (s *Server) widgetConversation(ctx context.Context, id string) (*model.Conversation, error) {
+	conv, err := s.repo.DescribeVisibleConversation(ctx, id, auth.User(ctx), nil)
+	if err != nil {
+		return nil, err
+	}
+	if conv.Widget == nil || conv.Widget.VisitorID != auth.User(ctx) {
+		return nil, twirp.NotFoundError("conversation not found")
+	}
+	return conv, nil
+}
+
+func (s *Server) getWidgetConversation(w http.ResponseWriter, r *http.Request) {
+	ctx, _, err := s.widgetVisitor(r)
+	if err != nil {
+		writeStreamError(r.Context(), w, err)
+		return
+	}
+	conv, err := s.widgetConversation(ctx, mux.Vars(r)["id"])
+	if err != nil {
+		writeStreamError(ctx, w, err)
+		return
+	}
+
+	msgs := []streamedMessage{}
+	for _, m := range conv.Messages {
+		if m.Role == model.RoleUser || m.Role == model.RoleAssistant {
+			msgs = append(msgs, streamedMessageObject(m))
+		}
+	}
+	writeStreamJSON(w, map[string]any{"id": conv.ID.Hex(), "title": conv.Title, "messages": msgs})
+}
+
+// sendWidgetMessage adds a message to a conversation of the visitor, or starts one, and
+// streams the reply as StreamHandler does.
+func (s *Server) sendWidgetMessage(w http.ResponseWriter, r *http.Request) {
+	ctx, sess, err := s.widgetVisitor(r)
+	if err != nil {
+		writeStreamError(r.Context(), w, err)
+		return
+	}
+	req, err := decodeStreamRequest(w, r)
+	if err != nil {
+		writeStreamError(ctx, w, err)
+		return
+	}
+	if err := s.checkAbuse(ctx, req.Message); err != nil {
+		writeStreamError(ctx, w, err)
+		return
+	}
+	if err := s.checkQuota(ctx); err != nil {
+		writeStreamError(ctx, w, err)
+		return
+	}
+	ctx = withMaxOutputTokens(ctx, req.MaxOutputTokens)
+
+	msg := s.newStreamedMessage(ctx, req.Message)
+	var conv *model.Conversation
+	if req.ConversationID == "" {
+		conv = &model.Conversation{
+			ID:        primitive.NewObjectID(),
+			Title:     textx.Title(msg.Content, 80),
+			CreatedAt: msg.CreatedAt,
+			UpdatedAt: msg.CreatedAt,
+			Messages:  []*model.Message{msg},
+			Tags:      []string{widgetTag},
+			Widget:    &model.WidgetChat{VisitorID: sess.VisitorID, Origin: sess.Origin},
+			Owner:     sess.VisitorID,
+		}
+		if err := s.repo.CreateConversation(ctx, conv); err != nil {
+			writeStreamError(ctx, w, twirp.InternalErrorWith(err))
+			return
+		}
+	} else {
+		var unlock func()
+		conv, unlock, err = s.appendMessage(ctx, req.ConversationID, s.widgetConversation, msg)
+		if err != nil {
+			writeStreamError(ctx, w, err)
+			return
+		}
+		defer unlock()
+	}
+	s.streamReply(ctx, w, conv, msg)
+}
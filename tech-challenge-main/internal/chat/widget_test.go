@@ -0,0 +1,220 @@
+package chat
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/acai-travel/tech-challenge/internal/auth"
+	"github.com/acai-travel/tech-challenge/internal/chat/model"
+	. "github.com/acai-travel/tech-challenge/internal/chat/testing"
+	"github.com/acai-travel/tech-challenge/internal/pb"
+	"github.com/twitchtv/twirp"
+)
+
+const testWidgetOrigin = "https://shop.example.com"
+
+func TestWidgetSessionToken(t *testing.T) {
+	secret := []byte("0123456789abcdef0123456789abcdef")
+	now := time.Now()
+	sess := widgetSession{VisitorID: "widget:1", Origin: testWidgetOrigin, ExpiresAt: now.Add(time.Hour).Unix()}
+	token := signWidgetSession(secret, sess)
+
+	if got, err := parseWidgetSession(secret, token, now); err != nil || got != sess {
+		t.Errorf("parseWidgetSession = %+v, %v; want %+v", got, err, sess)
+	}
+	if _, err := parseWidgetSession(secret, token, now.Add(2*time.Hour)); err == nil || !strings.Contains(err.Error(), "expired") {
+		t.Errorf("expected an expired session, got %v", err)
+	}
+	if _, err := parseWidgetSession([]byte("another secret"), token, now); err == nil {
+		t.Error("expected a token signed with another secret to be rejected")
+	}
+	payload, sig, _ := strings.Cut(token, ".")
+	forged := signWidgetSession(secret, widgetSession{VisitorID: "widget:2", Origin: testWidgetOrigin, ExpiresAt: sess.ExpiresAt})
+	forgedPayload, _, _ := strings.Cut(forged, ".")
+	if _, err := parseWidgetSession(secret, forgedPayload+"."+sig, now); err == nil {
+		t.Error("expected a payload with another signature to be rejected")
+	}
+	if _, err := parseWidgetSession(secret, payload, now); err == nil {
+		t.Error("expected an unsigned token to be rejected")
+	}
+}
+
+func TestOriginLimiter(t *testing.T) {
+	l := newOriginLimiter(2)
+	now := time.Now()
+	for i := 0; i < 2; i++ {
+		if ok, _ := l.allow("a", now); !ok {
+			t.Fatalf("request %d denied within the burst", i)
+		}
+	}
+	ok, wait := l.allow("a", now)
+	if ok || wait <= 0 || wait > 30*time.Second {
+		t.Errorf("allow = %v, %v; want denied for up to 30s", ok, wait)
+	}
+	if ok, _ := l.allow("b", now); !ok {
+		t.Error("expected other origins to have their own limit")
+	}
+	if ok, _ := l.allow("a", now.Add(30*time.Second)); !ok {
+		t.Error("expected a token back after 30s")
+	}
+}
+
+func widgetRequest(method, target, origin, token, body string) *http.Request {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	if origin != "" {
+		req.Header.Set("Origin", origin)
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	return req
+}
+
+func newWidgetSession(t *testing.T, h http.Handler, origin string) string {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, widgetRequest(http.MethodPost, "/widget/sessions", origin, "", ""))
+	var resp struct {
+		Token string `json:"token"`
+	}
+	if rec.Code != http.StatusOK || json.NewDecoder(rec.Body).Decode(&resp) != nil || resp.Token == "" {
+		t.Fatalf("creating a session: status %d, %q", rec.Code, rec.Body.String())
+	}
+	return resp.Token
+}
+
+func TestWidgetHandler(t *testing.T) {
+	disabled := NewServer(nil, &fakeAssistant{})
+	rec := httptest.NewRecorder()
+	disabled.WidgetHandler().ServeHTTP(rec, widgetRequest(http.MethodPost, "/widget/sessions", testWidgetOrigin, "", ""))
+	if rec.Code != http.StatusNotImplemented {
+		t.Errorf("disabled widget: got status %d", rec.Code)
+	}
+
+	srv := NewServer(nil, &fakeAssistant{})
+	srv.EnableWidget([]byte("0123456789abcdef0123456789abcdef"), []string{testWidgetOrigin + "/", "https://blog.example.com"}, 100)
+	h := srv.WidgetHandler()
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, widgetRequest(http.MethodOptions, "/widget/messages", testWidgetOrigin, "", ""))
+	if rec.Code != http.StatusNoContent || rec.Header().Get("Access-Control-Allow-Origin") != testWidgetOrigin ||
+		!strings.Contains(rec.Header().Get("Access-Control-Allow-Headers"), "Authorization") {
+		t.Errorf("preflight: got %d %v", rec.Code, rec.Header())
+	}
+
+	token := newWidgetSession(t, h, testWidgetOrigin)
+	blogToken := newWidgetSession(t, h, "https://blog.example.com")
+
+	tests := []struct {
+		name          string
+		origin, token string
+		body          string
+		status        int
+	}{
+		{"unknown origin", "https://evil.example.com", token, `{"message":"hi"}`, http.StatusForbidden},
+		{"no origin", "", token, `{"message":"hi"}`, http.StatusForbidden},
+		{"missing token", testWidgetOrigin, "", `{"message":"hi"}`, http.StatusUnauthorized},
+		{"invalid token", testWidgetOrigin, "guess", `{"message":"hi"}`, http.StatusUnauthorized},
+		{"token of another website", testWidgetOrigin, blogToken, `{"message":"hi"}`, http.StatusForbidden},
+		{"not JSON", testWidgetOrigin, token, `hi`, http.StatusBadRequest},
+		{"empty message", testWidgetOrigin, token, `{"message":"  "}`, http.StatusBadRequest},
+		{"message too long", testWidgetOrigin, token, `{"message":"` + strings.Repeat("a", maxStreamedMessage+1) + `"}`, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, widgetRequest(http.MethodPost, "/widget/messages", tt.origin, tt.token, tt.body))
+		if rec.Code != tt.status {
+			t.Errorf("%s: got status %d, want %d (%s)", tt.name, rec.Code, tt.status, rec.Body.String())
+		}
+	}
+}
+
+func TestWidgetHandler_RateLimit(t *testing.T) {
+	srv := NewServer(nil, &fakeAssistant{})
+	srv.EnableWidget([]byte("0123456789abcdef0123456789abcdef"), []string{testWidgetOrigin}, 2)
+	h := srv.WidgetHandler()
+
+	newWidgetSession(t, h, testWidgetOrigin)
+	newWidgetSession(t, h, testWidgetOrigin)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, widgetRequest(http.MethodPost, "/widget/sessions", testWidgetOrigin, "", ""))
+	if rec.Code != http.StatusTooManyRequests || rec.Header().Get("Retry-After") == "" {
+		t.Errorf("got status %d, Retry-After %q; want 429 with a delay", rec.Code, rec.Header().Get("Retry-After"))
+	}
+	// Preflight requests don't count.
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, widgetRequest(http.MethodOptions, "/widget/sessions", testWidgetOrigin, "", ""))
+	if rec.Code != http.StatusNoContent {
+		t.Errorf("preflight: got status %d", rec.Code)
+	}
+}
+
+func TestWidgetMessages(t *testing.T) {
+	t.Parallel()
+	repo := model.New(ConnectMongo())
+	fa := &fakeAssistant{
+		replyFn: func(_ context.Context, c *model.Conversation) (string, error) {
+			return "Reply to: " + c.Messages[len(c.Messages)-1].Content, nil
+		},
+	}
+	srv := NewServer(repo, fa)
+	srv.EnableWidget([]byte("0123456789abcdef0123456789abcdef"), []string{testWidgetOrigin}, 100)
+	h := srv.WidgetHandler()
+	token := newWidgetSession(t, h, testWidgetOrigin)
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, widgetRequest(http.MethodPost, "/widget/messages", testWidgetOrigin, token, `{"message":"Beaches near Lisbon?"}`))
+	if rec.Code != http.StatusOK || rec.Header().Get("Content-Type") != "text/event-stream" {
+		t.Fatalf("got status %d, %q", rec.Code, rec.Body.String())
+	}
+	events := rec.Body.String()
+	if !strings.HasPrefix(events, "event: conversation\n") || !strings.Contains(events, "event: reply\ndata: ") ||
+		!strings.Contains(events, `"content":"Reply to: Beaches near Lisbon?"`) {
+		t.Fatalf("events = %q", events)
+	}
+	var started struct {
+		ID string `json:"id"`
+	}
+	data, _, _ := strings.Cut(strings.TrimPrefix(events, "event: conversation\ndata: "), "\n")
+	if err := json.Unmarshal([]byte(data), &started); err != nil || started.ID == "" {
+		t.Fatalf("conversation event %q: %v", data, err)
+	}
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, widgetRequest(http.MethodPost, "/widget/messages", testWidgetOrigin, token, `{"conversation_id":"`+started.ID+`","message":"And in Porto?"}`))
+	if !strings.Contains(rec.Body.String(), `"content":"Reply to: And in Porto?"`) {
+		t.Fatalf("events = %q", rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, widgetRequest(http.MethodGet, "/widget/conversations/"+started.ID, testWidgetOrigin, token, ""))
+	var conv struct {
+		Messages []streamedMessage `json:"messages"`
+	}
+	if err := json.NewDecoder(rec.Body).Decode(&conv); err != nil || len(conv.Messages) != 4 {
+		t.Errorf("conversation = %+v, %v; want 4 messages", conv, err)
+	}
+
+	// Another visitor can neither read nor continue the conversation.
+	other := newWidgetSession(t, h, testWidgetOrigin)
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, widgetRequest(http.MethodGet, "/widget/conversations/"+started.ID, testWidgetOrigin, other, ""))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("reading another visitor's conversation: got status %d", rec.Code)
+	}
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, widgetRequest(http.MethodPost, "/widget/messages", testWidgetOrigin, other, `{"conversation_id":"`+started.ID+`","message":"hi"}`))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("continuing another visitor's conversation: got status %d", rec.Code)
+	}
+	// Nor can users of the API.
+	_, err := srv.DescribeConversation(auth.WithUser(context.Background(), "alice"), &pb.DescribeConversationRequest{ConversationId: started.ID})
+	if twirpCode(err) != twirp.NotFound {
+		t.Errorf("describing a widget conversation through the API: got %v", err)
+	}
+}
@@ -0,0 +1,103 @@
+// Package doctor checks a deployment's configuration and the services it depends on, so
+// misconfigurations are reported with what to do about them at startup, or by cmd/doctor,
+// rather than by the first user request that needs them.
+package doctor
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// Status is the outcome of a check.
+type Status string
+
+const (
+	StatusOK   Status = "ok"
+	StatusWarn Status = "warn"
+	StatusFail Status = "fail"
+)
+
+// Check verifies one setting or dependency.
+type Check struct {
+	// Name identifies what is checked, e.g. "openai" or "mongodb".
+	Name string
+	// Run returns nil if it works, or an error saying what doesn't.
+	Run func(ctx context.Context) error
+	// Fix tells operators what to do when Run fails.
+	Fix string
+	// Optional checks only warn when they fail: the server works without them, with
+	// features disabled or degraded.
+	Optional bool
+}
+
+// Result is the outcome of a Check.
+type Result struct {
+	Name     string
+	Status   Status
+	Err      error
+	Fix      string
+	Duration time.Duration
+}
+
+// Run runs checks concurrently, each for at most timeout, and returns their results in the
+// order of checks.
+func Run(ctx context.Context, timeout time.Duration, checks ...Check) []Result {
+	results := make([]Result, len(checks))
+	var wg sync.WaitGroup
+	for i, c := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+
+			start := time.Now()
+			err := c.Run(ctx)
+			r := Result{Name: c.Name, Status: StatusOK, Err: err, Duration: time.Since(start)}
+			if err != nil {
+				r.Status, r.Fix = StatusFail, c.Fix
+				if c.Optional {
+					r.Status = StatusWarn
+				}
+			}
+			results[i] = r
+		}()
+	}
+	wg.Wait()
+	return results
+}
+
+// Failed reports whether a required check failed.
+func Failed(results []Result) bool {
+	for _, r := range results {
+		if r.Status == StatusFail {
+			return true
+		}
+	}
+	return false
+}
+
+// Write prints results for operators, a line per check followed by the error and fix of
+// those that didn't pass.
+func Write(w io.Writer, results []Result) error {
+	for _, r := range results {
+		if _, err := fmt.Fprintf(w, "[%-4s] %s (%s)\n", r.Status, r.Name, r.Duration.Round(time.Millisecond)); err != nil {
+			return err
+		}
+		if r.Err == nil {
+			continue
+		}
+		if _, err := fmt.Fprintf(w, "       %v\n", r.Err); err != nil {
+			return err
+		}
+		if r.Fix != "" {
+			if _, err := fmt.Fprintf(w, "       fix: %s\n", r.Fix); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package doctor
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRun(t *testing.T) {
+	checks := []Check{
+		{Name: "ok", Run: func(context.Context) error { return nil }},
+		{Name: "slow", Run: func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		}, Fix: "speed it up"},
+		{Name: "optional", Run: func(context.Context) error { return errors.New("not set") }, Fix: "set it", Optional: true},
+	}
+
+	results := Run(context.Background(), 20*time.Millisecond, checks...)
+	want := []Status{StatusOK, StatusFail, StatusWarn}
+	for i, r := range results {
+		if r.Name != checks[i].Name || r.Status != want[i] {
+			t.Errorf("result %d = %s %s, want %s %s", i, r.Name, r.Status, checks[i].Name, want[i])
+		}
+	}
+	if !errors.Is(results[1].Err, context.DeadlineExceeded) || results[1].Fix != "speed it up" {
+		t.Errorf("slow check = %+v, want a timeout and its fix", results[1])
+	}
+	if !Failed(results) || Failed(results[2:]) {
+		t.Error("Failed should only report failed required checks")
+	}
+
+	var out strings.Builder
+	if err := Write(&out, results[2:]); err != nil {
+		t.Fatal(err)
+	}
+	if got := out.String(); !strings.HasPrefix(got, "[warn] optional (") || !strings.Contains(got, "       not set\n       fix: set it\n") {
+		t.Errorf("Write printed:\n%s", got)
+	}
+}
@@ -0,0 +1,152 @@
+// Package features gates assistant capabilities per tenant and user.
+package features
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+)
+
+// Flag names a capability that can be switched per tenant or user.
+type Flag string
+
+const (
+	// Weather offers the get_weather tool.
+	Weather Flag = "weather"
+	// LongWeekends offers the find_long_weekends tool.
+	LongWeekends Flag = "long_weekends"
+	// TravelDates offers the suggest_travel_dates tool.
+	TravelDates Flag = "travel_dates"
+	// SkiConditions offers the get_ski_conditions tool.
+	SkiConditions Flag = "ski_conditions"
+	// MarineConditions offers the get_marine_conditions tool.
+	MarineConditions Flag = "marine_conditions"
+	// Transit offers the get_transit_status tool, where a transit provider is configured.
+	Transit Flag = "transit"
+	// TravelAdvisory offers the get_travel_advisory tool.
+	TravelAdvisory Flag = "travel_advisory"
+	// VisaRequirements offers the check_visa_requirements tool.
+	VisaRequirements Flag = "visa_requirements"
+	// TravelSearch offers the search_flights and search_hotels tools, where providers are
+	// configured.
+	TravelSearch Flag = "travel_search"
+	// Places offers the find_places tool.
+	Places Flag = "places"
+	// TripBudget offers the estimate_trip_budget tool.
+	TripBudget Flag = "trip_budget"
+	// WebSearch offers web search tools.
+	WebSearch Flag = "web_search"
+	// Vision allows image inputs.
+	Vision Flag = "vision"
+	// DebugCapture stores the completion requests and responses of replies, where the debug
+	// store is enabled.
+	DebugCapture Flag = "debug_capture"
+	// MaxForecastDays caps how many days ahead weather forecasts may go.
+	MaxForecastDays Flag = "max_forecast_days"
+)
+
+type kind int
+
+const (
+	kindBool kind = iota
+	kindInt
+)
+
+type definition struct {
+	kind     kind
+	fallback string
+	min, max int
+}
+
+var definitions = map[Flag]definition{
+	Weather:          {kind: kindBool, fallback: "true"},
+	LongWeekends:     {kind: kindBool, fallback: "true"},
+	TravelDates:      {kind: kindBool, fallback: "true"},
+	SkiConditions:    {kind: kindBool, fallback: "true"},
+	MarineConditions: {kind: kindBool, fallback: "true"},
+	Transit:          {kind: kindBool, fallback: "true"},
+	TravelAdvisory:   {kind: kindBool, fallback: "true"},
+	VisaRequirements: {kind: kindBool, fallback: "true"},
+	TravelSearch:     {kind: kindBool, fallback: "true"},
+	Places:           {kind: kindBool, fallback: "true"},
+	TripBudget:       {kind: kindBool, fallback: "true"},
+	WebSearch:        {kind: kindBool, fallback: "false"},
+	Vision:           {kind: kindBool, fallback: "false"},
+	DebugCapture:     {kind: kindBool, fallback: "false"},
+	MaxForecastDays:  {kind: kindInt, fallback: "14", min: 1, max: 14},
+}
+
+// Known returns the names of all flags, sorted.
+func Known() []Flag {
+	out := make([]Flag, 0, len(definitions))
+	for f := range definitions {
+		out = append(out, f)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i] < out[j] })
+	return out
+}
+
+// Default returns the value a flag has unless overridden.
+func Default(f Flag) string {
+	return definitions[f].fallback
+}
+
+// Validate reports whether value is acceptable for the flag.
+func Validate(f Flag, value string) error {
+	def, ok := definitions[f]
+	if !ok {
+		return fmt.Errorf("unknown feature flag %q", f)
+	}
+
+	switch def.kind {
+	case kindBool:
+		if _, err := strconv.ParseBool(value); err != nil {
+			return fmt.Errorf("feature flag %q must be true or false", f)
+		}
+	case kindInt:
+		n, err := strconv.Atoi(value)
+		if err != nil || n < def.min || n > def.max {
+			return fmt.Errorf("feature flag %q must be an integer between %d and %d", f, def.min, def.max)
+		}
+	}
+	return nil
+}
+
+// Set holds flag overrides. Flags without an override, or with an invalid one, have their
+// default value, so the zero Set is usable.
+type Set map[Flag]string
+
+// Enabled returns the value of a boolean flag.
+func (s Set) Enabled(f Flag) bool {
+	if v, ok := s[f]; ok {
+		if b, err := strconv.ParseBool(v); err == nil {
+			return b
+		}
+	}
+	b, _ := strconv.ParseBool(Default(f))
+	return b
+}
+
+// Int returns the value of an integer flag.
+func (s Set) Int(f Flag) int {
+	if v, ok := s[f]; ok && Validate(f, v) == nil {
+		n, _ := strconv.Atoi(v)
+		return n
+	}
+	n, _ := strconv.Atoi(Default(f))
+	return n
+}
+
+type setKey struct{}
+
+// WithSet returns a context carrying the flags of the caller.
+func WithSet(ctx context.Context, s Set) context.Context {
+	return context.WithValue(ctx, setKey{}, s)
+}
+
+// FromContext returns the flags carried by ctx, or defaults for all flags.
+func FromContext(ctx context.Context) Set {
+	s, _ := ctx.Value(setKey{}).(Set)
+	return s
+}
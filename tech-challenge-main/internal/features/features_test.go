@@ -0,0 +1,46 @@
+package features
+
+import "testing"
+
+func TestSet(t *testing.T) {
+	var empty Set
+	if !empty.Enabled(Weather) || empty.Enabled(WebSearch) {
+		t.Error("zero Set should have default flag values")
+	}
+	if got := empty.Int(MaxForecastDays); got != 14 {
+		t.Errorf("default max_forecast_days = %d, want 14", got)
+	}
+
+	s := Set{Weather: "false", WebSearch: "true", MaxForecastDays: "5"}
+	if s.Enabled(Weather) || !s.Enabled(WebSearch) {
+		t.Error("overrides should take precedence over defaults")
+	}
+	if got := s.Int(MaxForecastDays); got != 5 {
+		t.Errorf("max_forecast_days = %d, want 5", got)
+	}
+
+	invalid := Set{Weather: "maybe", MaxForecastDays: "99"}
+	if !invalid.Enabled(Weather) || invalid.Int(MaxForecastDays) != 14 {
+		t.Error("invalid overrides should fall back to defaults")
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		flag  Flag
+		value string
+		ok    bool
+	}{
+		{Weather, "true", true},
+		{Weather, "yes", false},
+		{MaxForecastDays, "7", true},
+		{MaxForecastDays, "0", false},
+		{MaxForecastDays, "15", false},
+		{"teleport", "true", false},
+	}
+	for _, tt := range tests {
+		if err := Validate(tt.flag, tt.value); (err == nil) != tt.ok {
+			t.Errorf("Validate(%q, %q) = %v, want ok=%v", tt.flag, tt.value, err, tt.ok)
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package features
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/acai-travel/tech-challenge/internal/auth"
+	"github.com/hashicorp/golang-lru/v2/expirable"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const flagCollection = "feature_flags"
+
+// cacheTTL bounds how long a flag change takes to reach other server instances.
+const cacheTTL = 30 * time.Second
+
+// cacheSize bounds the resolved sets kept, one per tenant and user.
+const cacheSize = 10_000
+
+// Override is the flags set for a tenant, or for one user of a tenant when UserID is set.
+type Override struct {
+	TenantID  string          `bson:"tenant_id"`
+	UserID    string          `bson:"user_id"`
+	Flags     map[Flag]string `bson:"flags"`
+	UpdatedAt time.Time       `bson:"updated_at"`
+}
+
+// Store keeps flag overrides in MongoDB, with a short-lived in-memory cache so resolving
+// flags doesn't cost a query per request.
+type Store struct {
+	conn  *mongo.Database
+	cache *expirable.LRU[[2]string, Set]
+}
+
+func NewStore(conn *mongo.Database) *Store {
+	return &Store{conn: conn, cache: expirable.NewLRU[[2]string, Set](cacheSize, nil, cacheTTL)}
+}
+
+// Resolve returns the flags of a user: tenant overrides, then user overrides on top.
+func (s *Store) Resolve(ctx context.Context, tenantID, userID string) (Set, error) {
+	key := [2]string{tenantID, userID}
+
+	if set, ok := s.cache.Get(key); ok {
+		return set, nil
+	}
+
+	cursor, err := s.conn.Collection(flagCollection).Find(ctx,
+		map[string]any{"tenant_id": tenantID, "user_id": map[string]any{"$in": []string{"", userID}}},
+		options.Find().SetSort(bson.D{{Key: "user_id", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+
+	var overrides []*Override
+	if err := cursor.All(ctx, &overrides); err != nil {
+		return nil, err
+	}
+
+	set := make(Set)
+	for _, o := range overrides { // tenant-wide ("" user) sorts first
+		for f, v := range o.Flags {
+			set[f] = v
+		}
+	}
+
+	s.cache.Add(key, set)
+
+	return set, nil
+}
+
+// SetFlag overrides a flag for a tenant, or for one of its users when userID is not empty.
+// An empty value removes the override. Values must pass Validate.
+func (s *Store) SetFlag(ctx context.Context, tenantID, userID string, f Flag, value string) error {
+	update := map[string]any{
+		"$set": map[string]any{"updated_at": time.Now()},
+	}
+	if value == "" {
+		update["$unset"] = map[string]any{"flags." + string(f): ""}
+	} else {
+		update["$set"].(map[string]any)["flags."+string(f)] = value
+	}
+
+	_, err := s.conn.Collection(flagCollection).UpdateOne(ctx,
+		map[string]any{"tenant_id": tenantID, "user_id": userID},
+		update,
+		options.Update().SetUpsert(true))
+	if err != nil {
+		return err
+	}
+
+	// Changes apply immediately on this instance; others pick them up within cacheTTL.
+	for _, key := range s.cache.Keys() {
+		if key[0] == tenantID {
+			s.cache.Remove(key)
+		}
+	}
+
+	return nil
+}
+
+// List returns the overrides of a tenant, tenant-wide first.
+func (s *Store) List(ctx context.Context, tenantID string) ([]*Override, error) {
+	cursor, err := s.conn.Collection(flagCollection).Find(ctx,
+		map[string]any{"tenant_id": tenantID},
+		options.Find().SetSort(bson.D{{Key: "user_id", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+
+	var overrides []*Override
+	if err := cursor.All(ctx, &overrides); err != nil {
+		return nil, err
+	}
+	return overrides, nil
+}
+
+// Middleware resolves the flags of the calling tenant and user into the request context.
+// Requests proceed with default flags if they can't be resolved.
+func Middleware(store *Store) func(handler http.Handler) http.Handler {
+	return func(handler http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+			set, err := store.Resolve(ctx, auth.Tenant(ctx), auth.User(ctx))
+			if err != nil {
+				slog.WarnContext(ctx, "Failed to resolve feature flags, using defaults", "error", err)
+			}
+			handler.ServeHTTP(w, r.WithContext(WithSet(ctx, set)))
+		})
+	}
+}
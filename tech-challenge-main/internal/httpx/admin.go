@@ -0,0 +1,46 @@
+package httpx
+
+import (
+	"encoding/json"
+	"expvar"
+	"net/http"
+	"net/http/pprof"
+	"runtime"
+)
+
+// Admin returns a handler for internal diagnostics: net/http/pprof under /debug/pprof/,
+// expvar under /debug/vars and a runtime summary under /debug/stats, merged with stats.
+// It must only be served on an internal port.
+func Admin(stats func() map[string]any) http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	mux.Handle("/debug/vars", expvar.Handler())
+
+	mux.HandleFunc("/debug/stats", func(w http.ResponseWriter, r *http.Request) {
+		var mem runtime.MemStats
+		runtime.ReadMemStats(&mem)
+
+		out := map[string]any{
+			"goroutines":     runtime.NumGoroutine(),
+			"heap_alloc":     mem.HeapAlloc,
+			"heap_objects":   mem.HeapObjects,
+			"gc_cycles":      mem.NumGC,
+			"gc_pause_total": mem.PauseTotalNs,
+		}
+		if stats != nil {
+			for k, v := range stats() {
+				out[k] = v
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(out)
+	})
+
+	return mux
+}
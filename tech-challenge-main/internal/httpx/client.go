@@ -0,0 +1,253 @@
+package httpx
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// Outbound configures the requests the server makes to OpenAI, WeatherAPI, holiday
+// calendars and other providers, e.g. for deployments behind an egress proxy.
+type Outbound struct {
+	// Proxy all requests go through; nil uses HTTPS_PROXY, HTTP_PROXY and NO_PROXY.
+	Proxy *url.URL
+	// RootCAs verify the servers; nil trusts the system's.
+	RootCAs *x509.CertPool
+	// MaxIdleConnsPerHost and MaxConnsPerHost size the connection pool of each host; zero
+	// keeps net/http's defaults.
+	MaxIdleConnsPerHost, MaxConnsPerHost int
+	// Timeouts replaces the timeout of the requests to a host, e.g. "api.openai.com".
+	Timeouts map[string]time.Duration
+	// AllowedHosts, if set, are the only hosts requests may be sent to, e.g.
+	// "api.openai.com" or "*.weatherapi.com"; others fail with ErrHostNotAllowed.
+	AllowedHosts []string
+	// Pins are, by host name, the base64 SHA-256 hashes of the public keys (SPKI) one of which the
+	// host's certificate chain must include, besides being trusted.
+	Pins map[string][]string
+}
+
+// ErrHostNotAllowed is returned for requests to hosts Outbound.AllowedHosts doesn't list.
+var ErrHostNotAllowed = errors.New("outbound host not allowed")
+
+// OutboundFromEnv reads OUTBOUND_PROXY (a URL), OUTBOUND_CA_FILE (PEM certificates trusted
+// besides the system's), OUTBOUND_MAX_IDLE_CONNS_PER_HOST, OUTBOUND_MAX_CONNS_PER_HOST,
+// OUTBOUND_TIMEOUTS (comma-separated host=duration, e.g. "api.openai.com=2m"),
+// OUTBOUND_ALLOWED_HOSTS (comma-separated) and OUTBOUND_PINS (comma-separated host=pins, the
+// pins separated by "|").
+func OutboundFromEnv() (*Outbound, error) {
+	o := &Outbound{}
+	if v := os.Getenv("OUTBOUND_PROXY"); v != "" {
+		u, err := url.Parse(v)
+		if err != nil || u.Host == "" {
+			return nil, fmt.Errorf("invalid OUTBOUND_PROXY %q", v)
+		}
+		o.Proxy = u
+	}
+
+	if path := os.Getenv("OUTBOUND_CA_FILE"); path != "" {
+		pem, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read OUTBOUND_CA_FILE: %w", err)
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates in OUTBOUND_CA_FILE %s", path)
+		}
+		o.RootCAs = pool
+	}
+
+	for name, n := range map[string]*int{
+		"OUTBOUND_MAX_IDLE_CONNS_PER_HOST": &o.MaxIdleConnsPerHost,
+		"OUTBOUND_MAX_CONNS_PER_HOST":      &o.MaxConnsPerHost,
+	} {
+		if v := os.Getenv(name); v != "" {
+			var err error
+			if *n, err = strconv.Atoi(v); err != nil || *n < 0 {
+				return nil, fmt.Errorf("invalid %s %q", name, v)
+			}
+		}
+	}
+
+	if v := os.Getenv("OUTBOUND_TIMEOUTS"); v != "" {
+		o.Timeouts = map[string]time.Duration{}
+		for _, entry := range strings.Split(v, ",") {
+			host, d, ok := strings.Cut(strings.TrimSpace(entry), "=")
+			timeout, err := time.ParseDuration(d)
+			if !ok || host == "" || err != nil || timeout <= 0 {
+				return nil, fmt.Errorf("invalid OUTBOUND_TIMEOUTS entry %q: want host=duration", entry)
+			}
+			o.Timeouts[strings.ToLower(host)] = timeout
+		}
+	}
+
+	if v := os.Getenv("OUTBOUND_ALLOWED_HOSTS"); v != "" {
+		for _, host := range strings.Split(v, ",") {
+			if host = strings.ToLower(strings.TrimSpace(host)); host != "" {
+				o.AllowedHosts = append(o.AllowedHosts, host)
+			}
+		}
+	}
+
+	if v := os.Getenv("OUTBOUND_PINS"); v != "" {
+		o.Pins = map[string][]string{}
+		for _, entry := range strings.Split(v, ",") {
+			host, pins, ok := strings.Cut(strings.TrimSpace(entry), "=")
+			if !ok || host == "" {
+				return nil, fmt.Errorf("invalid OUTBOUND_PINS entry %q: want host=pin|pin", entry)
+			}
+			for _, pin := range strings.Split(pins, "|") {
+				if b, err := base64.StdEncoding.DecodeString(pin); err != nil || len(b) != sha256.Size {
+					return nil, fmt.Errorf("invalid OUTBOUND_PINS pin %q of %s: want a base64 SHA-256 hash", pin, host)
+				}
+				o.Pins[strings.ToLower(host)] = append(o.Pins[strings.ToLower(host)], pin)
+			}
+		}
+	}
+	return o, nil
+}
+
+// outboundConfig is what clients of Client send their requests with.
+type outboundConfig struct {
+	transport http.RoundTripper
+	// public is transport connecting only to public addresses, for PublicClient.
+	public   http.RoundTripper
+	timeouts map[string]time.Duration
+	allowed  []string
+}
+
+// allows reports whether requests may be sent to host.
+func (c *outboundConfig) allows(host string) bool {
+	if len(c.allowed) == 0 {
+		return true
+	}
+	for _, a := range c.allowed {
+		if a == host || (strings.HasPrefix(a, "*.") && strings.HasSuffix(host, a[1:])) {
+			return true
+		}
+	}
+	return false
+}
+
+var outbound atomic.Pointer[outboundConfig]
+
+// SetOutbound makes the clients of Client send requests as o configures, including those
+// created before. It should be called at startup.
+func SetOutbound(o *Outbound) {
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	if o.Proxy != nil {
+		t.Proxy = http.ProxyURL(o.Proxy)
+	}
+	if o.RootCAs != nil || len(o.Pins) > 0 {
+		t.TLSClientConfig = &tls.Config{RootCAs: o.RootCAs, VerifyConnection: verifyPins(o.Pins)}
+	}
+	if o.MaxIdleConnsPerHost > 0 {
+		t.MaxIdleConnsPerHost = o.MaxIdleConnsPerHost
+		t.MaxIdleConns = max(t.MaxIdleConns, o.MaxIdleConnsPerHost)
+	}
+	if o.MaxConnsPerHost > 0 {
+		t.MaxConnsPerHost = o.MaxConnsPerHost
+	}
+	outbound.Store(&outboundConfig{transport: t, public: publicTransport(t), timeouts: maps.Clone(o.Timeouts), allowed: slices.Clone(o.AllowedHosts)})
+}
+
+// verifyPins checks that the verified certificate chains of pinned hosts include a pinned
+// public key.
+func verifyPins(pins map[string][]string) func(tls.ConnectionState) error {
+	if len(pins) == 0 {
+		return nil
+	}
+	pins = maps.Clone(pins)
+	return func(cs tls.ConnectionState) error {
+		want, ok := pins[strings.ToLower(cs.ServerName)]
+		if !ok {
+			return nil
+		}
+		// Only the verified chains count: the server may send any certificate along.
+		for _, chain := range cs.VerifiedChains {
+			for _, cert := range chain {
+				sum := sha256.Sum256(cert.RawSubjectPublicKeyInfo)
+				if slices.Contains(want, base64.StdEncoding.EncodeToString(sum[:])) {
+					return nil
+				}
+			}
+		}
+		return fmt.Errorf("certificate of %s matches none of its pins", cs.ServerName)
+	}
+}
+
+// Client returns a client for requests to providers. They time out after timeout, or after
+// the Outbound timeout of their host if set; zero is no timeout.
+func Client(timeout time.Duration) *http.Client {
+	return &http.Client{Transport: outboundTransport{timeout: timeout}}
+}
+
+type outboundTransport struct {
+	timeout time.Duration
+	// public sends requests only to public addresses.
+	public bool
+}
+
+func (t outboundTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	cfg := outbound.Load()
+	if cfg == nil {
+		cfg = &outboundConfig{transport: http.DefaultTransport, public: defaultPublicTransport()}
+	}
+
+	host := strings.ToLower(req.URL.Hostname())
+	if !cfg.allows(host) {
+		return nil, fmt.Errorf("%w: %s", ErrHostNotAllowed, host)
+	}
+	transport := cfg.transport
+	if t.public {
+		if !PublicHost(host) {
+			return nil, fmt.Errorf("%w: %s", ErrNotPublic, host)
+		}
+		transport = cfg.public
+	}
+
+	timeout := t.timeout
+	if d, ok := cfg.timeouts[host]; ok {
+		timeout = d
+	}
+	if timeout <= 0 {
+		return transport.RoundTrip(req)
+	}
+
+	// Like http.Client.Timeout, the timeout covers reading the body.
+	ctx, cancel := context.WithTimeout(req.Context(), timeout)
+	resp, err := transport.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
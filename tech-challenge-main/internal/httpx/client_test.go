@@ -0,0 +1,206 @@
+package httpx
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"errors"
+	"io"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestOutboundFromEnv(t *testing.T) {
+	t.Setenv("OUTBOUND_PROXY", "http://proxy.internal:3128")
+	t.Setenv("OUTBOUND_MAX_CONNS_PER_HOST", "16")
+	t.Setenv("OUTBOUND_TIMEOUTS", "api.openai.com=2m, API.weatherapi.com=5s")
+	t.Setenv("OUTBOUND_ALLOWED_HOSTS", "api.openai.com, *.weatherapi.com")
+	t.Setenv("OUTBOUND_PINS", "api.openai.com=47DEQpj8HBSa+/TImW+5JCeuQeRkm5NMpJWZG3hSuFU=|C5+lpZ7tcVwmwQIMcRtPbsQtWLABXhQzejna0wHFr8M=")
+
+	o, err := OutboundFromEnv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if o.Proxy.String() != "http://proxy.internal:3128" || o.MaxConnsPerHost != 16 || o.RootCAs != nil {
+		t.Errorf("OutboundFromEnv = %+v", o)
+	}
+	if o.Timeouts["api.openai.com"] != 2*time.Minute || o.Timeouts["api.weatherapi.com"] != 5*time.Second {
+		t.Errorf("timeouts = %v", o.Timeouts)
+	}
+	if len(o.AllowedHosts) != 2 || o.AllowedHosts[1] != "*.weatherapi.com" || len(o.Pins["api.openai.com"]) != 2 {
+		t.Errorf("allowed hosts = %v, pins = %v", o.AllowedHosts, o.Pins)
+	}
+
+	for name, v := range map[string]string{
+		"OUTBOUND_PROXY":              "proxy.internal",
+		"OUTBOUND_CA_FILE":            "/nonexistent.pem",
+		"OUTBOUND_MAX_CONNS_PER_HOST": "-1",
+		"OUTBOUND_TIMEOUTS":           "api.openai.com",
+		"OUTBOUND_PINS":               "api.openai.com=not-a-hash",
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv(name, v)
+			if _, err := OutboundFromEnv(); err == nil || !strings.Contains(err.Error(), name) {
+				t.Errorf("OutboundFromEnv with %s=%q: got %v, want an error", name, v, err)
+			}
+		})
+	}
+}
+
+func TestClient(t *testing.T) {
+	t.Cleanup(func() { outbound.Store(nil) })
+
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(100 * time.Millisecond)
+		io.WriteString(w, "ok")
+	}))
+	defer slow.Close()
+	u, _ := url.Parse(slow.URL)
+	host := u.Hostname()
+
+	get := func(c *http.Client, url string) (string, error) {
+		resp, err := c.Get(url)
+		if err != nil {
+			return "", err
+		}
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		return string(body), err
+	}
+
+	t.Run("times out", func(t *testing.T) {
+		outbound.Store(nil)
+		if _, err := get(Client(10*time.Millisecond), slow.URL); !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("got %v, want a timeout", err)
+		}
+		if body, err := get(Client(0), slow.URL); err != nil || body != "ok" {
+			t.Errorf("without a timeout: got %q, %v", body, err)
+		}
+	})
+
+	t.Run("per-host timeouts", func(t *testing.T) {
+		SetOutbound(&Outbound{Timeouts: map[string]time.Duration{host: time.Second}})
+		if body, err := get(Client(10*time.Millisecond), slow.URL); err != nil || body != "ok" {
+			t.Errorf("with a longer timeout for %s: got %q, %v", host, body, err)
+		}
+
+		SetOutbound(&Outbound{Timeouts: map[string]time.Duration{host: 10 * time.Millisecond}})
+		if _, err := get(Client(0), slow.URL); !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("with a shorter timeout for %s: got %v, want a timeout", host, err)
+		}
+	})
+
+	t.Run("proxy", func(t *testing.T) {
+		var proxied string
+		proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			proxied = r.URL.String()
+			io.WriteString(w, "proxied")
+		}))
+		defer proxy.Close()
+		proxyURL, _ := url.Parse(proxy.URL)
+
+		// Clients created before SetOutbound use it too.
+		c := Client(time.Second)
+		SetOutbound(&Outbound{Proxy: proxyURL})
+		if body, err := get(c, "http://api.weatherapi.com/v1/current.json"); err != nil || body != "proxied" {
+			t.Fatalf("got %q, %v", body, err)
+		}
+		if proxied != "http://api.weatherapi.com/v1/current.json" {
+			t.Errorf("proxy got %q", proxied)
+		}
+	})
+}
+
+func TestClient_AllowedHosts(t *testing.T) {
+	t.Cleanup(func() { outbound.Store(nil) })
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer api.Close()
+
+	SetOutbound(&Outbound{AllowedHosts: []string{"api.openai.com", "*.weatherapi.com"}})
+	cfg := outbound.Load()
+	for host, want := range map[string]bool{
+		"api.openai.com":     true,
+		"api.weatherapi.com": true,
+		"weatherapi.com":     false,
+		"evilweatherapi.com": false,
+		"example.com":        false,
+	} {
+		if got := cfg.allows(host); got != want {
+			t.Errorf("allows(%q) = %v, want %v", host, got, want)
+		}
+	}
+	if _, err := Client(time.Second).Get(api.URL); !errors.Is(err, ErrHostNotAllowed) {
+		t.Errorf("got %v, want ErrHostNotAllowed", err)
+	}
+}
+
+func TestClient_Pins(t *testing.T) {
+	t.Cleanup(func() { outbound.Store(nil) })
+	api := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, "ok")
+	}))
+	defer api.Close()
+
+	roots := x509.NewCertPool()
+	roots.AddCert(api.Certificate())
+	sum := sha256.Sum256(api.Certificate().RawSubjectPublicKeyInfo)
+	pin := base64.StdEncoding.EncodeToString(sum[:])
+	other := base64.StdEncoding.EncodeToString(make([]byte, sha256.Size))
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{SerialNumber: big.NewInt(1), Subject: pkix.Name{CommonName: "pinned"}, NotAfter: time.Now().Add(time.Hour)}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sum = sha256.Sum256(cert.RawSubjectPublicKeyInfo)
+	appended := base64.StdEncoding.EncodeToString(sum[:])
+	api.TLS.Certificates[0].Certificate = append(api.TLS.Certificates[0].Certificate, der)
+
+	for _, tc := range []struct {
+		name string
+		pins map[string][]string
+		ok   bool
+	}{
+		{"pinned key", map[string][]string{"example.com": {other, pin}}, true},
+		{"other key", map[string][]string{"example.com": {other}}, false},
+		{"unpinned host", map[string][]string{"api.openai.com": {other}}, true},
+		// The server sends the pinned certificate along, outside the chain verified.
+		{"unverified pinned certificate", map[string][]string{"example.com": {appended}}, false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			SetOutbound(&Outbound{RootCAs: roots, Pins: tc.pins})
+			// The test server's certificate is for example.com.
+			transport := outbound.Load().transport.(*http.Transport)
+			transport.DialContext = func(ctx context.Context, network, _ string) (net.Conn, error) {
+				return (&net.Dialer{}).DialContext(ctx, network, api.Listener.Addr().String())
+			}
+
+			resp, err := Client(time.Second).Get("https://example.com/")
+			if err == nil {
+				resp.Body.Close()
+			}
+			if (err == nil) != tc.ok {
+				t.Errorf("got %v, want success %v", err, tc.ok)
+			}
+		})
+	}
+}
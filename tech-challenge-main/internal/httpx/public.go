@@ -0,0 +1,126 @@
+package httpx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// ErrNotPublic is returned for requests of PublicClient to hosts or addresses that aren't
+// public.
+var ErrNotPublic = errors.New("outbound address not public")
+
+// PublicClient is Client for URLs users give, e.g. their calendar feeds. Its requests,
+// redirects included, only connect to public addresses, whatever their host names resolve to,
+// so users can't point the server at itself or its network. Through a proxy, the proxy
+// connects: only the host names are checked.
+func PublicClient(timeout time.Duration) *http.Client {
+	return &http.Client{Transport: outboundTransport{timeout: timeout, public: true}, CheckRedirect: checkPublicRedirect}
+}
+
+// PublicHost reports whether host may be a public server: not localhost nor a loopback,
+// private, link-local, multicast or unspecified address. Host names may still resolve to
+// any address.
+func PublicHost(host string) bool {
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return false
+	}
+	ip, err := netip.ParseAddr(host)
+	return err != nil || publicAddr(ip)
+}
+
+// sharedAddrSpace is the carrier-grade NAT range, private though IsPrivate doesn't say so.
+var sharedAddrSpace = netip.MustParsePrefix("100.64.0.0/10")
+
+func publicAddr(ip netip.Addr) bool {
+	ip = ip.Unmap()
+	return !ip.IsLoopback() && !ip.IsPrivate() && !ip.IsLinkLocalUnicast() && !ip.IsLinkLocalMulticast() &&
+		!ip.IsInterfaceLocalMulticast() && !ip.IsMulticast() && !ip.IsUnspecified() && !sharedAddrSpace.Contains(ip)
+}
+
+// checkPublicRedirect follows at most 10 redirects, as http.Client does, and only to public
+// hosts.
+func checkPublicRedirect(req *http.Request, via []*http.Request) error {
+	if len(via) >= 10 {
+		return errors.New("stopped after 10 redirects")
+	}
+	if host := req.URL.Hostname(); !PublicHost(host) {
+		return fmt.Errorf("%w: redirected to %s", ErrNotPublic, host)
+	}
+	return nil
+}
+
+// publicTransport is t connecting only to public addresses, but for its proxies.
+func publicTransport(t *http.Transport) *http.Transport {
+	p := t.Clone()
+	p.DialContext = dialPublic(proxyAddrs(p.Proxy))
+	return p
+}
+
+// defaultPublicTransport is publicTransport of http.DefaultTransport, for when SetOutbound
+// wasn't called.
+var defaultPublicTransport = sync.OnceValue(func() http.RoundTripper {
+	return publicTransport(http.DefaultTransport.(*http.Transport))
+})
+
+// dialPublic dials as net/http's default transport does, refusing addresses that aren't
+// public once resolved, except proxies, the host:port addresses of which are dialed as is.
+func dialPublic(proxies []string) func(ctx context.Context, network, addr string) (net.Conn, error) {
+	direct := &net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second}
+	public := &net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second, Control: controlPublic}
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		if slices.Contains(proxies, addr) {
+			return direct.DialContext(ctx, network, addr)
+		}
+		return public.DialContext(ctx, network, addr)
+	}
+}
+
+// controlPublic refuses connections to addresses that aren't public. It runs for each
+// address a host name resolves to, right before connecting, so DNS rebinding can't get
+// around it.
+func controlPublic(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	if ip, err := netip.ParseAddr(host); err != nil || !publicAddr(ip) {
+		return fmt.Errorf("%w: %s", ErrNotPublic, host)
+	}
+	return nil
+}
+
+// proxyAddrs returns the host:port addresses of the proxies proxy picks for HTTP and HTTPS
+// requests.
+func proxyAddrs(proxy func(*http.Request) (*url.URL, error)) []string {
+	if proxy == nil {
+		return nil
+	}
+	var addrs []string
+	for _, target := range []string{"http://example.com", "https://example.com"} {
+		req, _ := http.NewRequest(http.MethodGet, target, nil)
+		u, err := proxy(req)
+		if err != nil || u == nil {
+			continue
+		}
+		port := u.Port()
+		if port == "" {
+			port = map[string]string{"https": "443", "socks5": "1080"}[u.Scheme]
+		}
+		if port == "" {
+			port = "80"
+		}
+		addrs = append(addrs, net.JoinHostPort(u.Hostname(), port))
+	}
+	return addrs
+}
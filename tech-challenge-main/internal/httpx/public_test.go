@@ -0,0 +1,83 @@
+package httpx
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestPublicHost(t *testing.T) {
+	for host, want := range map[string]bool{
+		"calendar.google.com": true,
+		"93.184.216.34":       true,
+		"2606:4700::1111":     true,
+		"localhost":           false,
+		"api.localhost.":      false,
+		"127.0.0.1":           false,
+		"10.1.2.3":            false,
+		"169.254.169.254":     false,
+		"100.64.0.1":          false,
+		"::ffff:192.168.0.1":  false,
+		"fd00::1":             false,
+		"0.0.0.0":             false,
+	} {
+		if got := PublicHost(host); got != want {
+			t.Errorf("PublicHost(%q) = %v, want %v", host, got, want)
+		}
+	}
+}
+
+func TestPublicClient(t *testing.T) {
+	t.Cleanup(func() { outbound.Store(nil) })
+
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, "ok")
+	}))
+	defer api.Close()
+	u, _ := url.Parse(api.URL)
+
+	for _, o := range []*Outbound{nil, {}} {
+		if o == nil {
+			outbound.Store(nil)
+		} else {
+			SetOutbound(o)
+		}
+		if _, err := PublicClient(0).Get(api.URL); !errors.Is(err, ErrNotPublic) {
+			t.Errorf("request to a loopback address: got %v, want ErrNotPublic", err)
+		}
+	}
+
+	// Host names are only resolved when dialing, so that's where the address is checked.
+	dial := dialPublic(nil)
+	if _, err := dial(context.Background(), "tcp", net.JoinHostPort("localhost", u.Port())); !errors.Is(err, ErrNotPublic) {
+		t.Errorf("dialing a host name resolving to a loopback address: got %v, want ErrNotPublic", err)
+	}
+	conn, err := dialPublic([]string{u.Host})(context.Background(), "tcp", u.Host)
+	if err != nil {
+		t.Fatalf("dialing a proxy: %v", err)
+	}
+	conn.Close()
+
+	req := httptest.NewRequest(http.MethodGet, "https://169.254.169.254/latest/meta-data", nil)
+	if err := checkPublicRedirect(req, []*http.Request{httptest.NewRequest(http.MethodGet, "https://example.com", nil)}); !errors.Is(err, ErrNotPublic) {
+		t.Errorf("redirect to a link-local address: got %v, want ErrNotPublic", err)
+	}
+	if err := checkPublicRedirect(httptest.NewRequest(http.MethodGet, "https://example.org", nil), nil); err != nil {
+		t.Errorf("redirect to a public host: %v", err)
+	}
+}
+
+func TestProxyAddrs(t *testing.T) {
+	proxy, _ := url.Parse("http://proxy.internal")
+	if got := proxyAddrs(http.ProxyURL(proxy)); len(got) != 2 || got[0] != "proxy.internal:80" {
+		t.Errorf("proxyAddrs = %v, want the proxy's address on port 80", got)
+	}
+	if got := proxyAddrs(nil); got != nil {
+		t.Errorf("proxyAddrs without a proxy = %v", got)
+	}
+}
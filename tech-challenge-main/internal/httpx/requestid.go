@@ -0,0 +1,40 @@
+package httpx
+
+import (
+	"net/http"
+
+	"github.com/acai-travel/tech-challenge/internal/logx"
+	"github.com/google/uuid"
+)
+
+// RequestIDHeader carries the ID of a request, so its logs can be found from the gateway's.
+const RequestIDHeader = "X-Request-ID"
+
+// RequestID puts the request ID from RequestIDHeader, or a new one if the header is missing
+// or unusable, into the request context for logx, and echoes it in the response.
+func RequestID() func(handler http.Handler) http.Handler {
+	return func(handler http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			id := r.Header.Get(RequestIDHeader)
+			if !validRequestID(id) {
+				id = uuid.NewString()
+			}
+			w.Header().Set(RequestIDHeader, id)
+			handler.ServeHTTP(w, r.WithContext(logx.WithRequestID(r.Context(), id)))
+		})
+	}
+}
+
+// validRequestID reports whether id is short and printable, so clients can't forge log
+// lines with it.
+func validRequestID(id string) bool {
+	if id == "" || len(id) > 128 {
+		return false
+	}
+	for _, c := range id {
+		if c <= ' ' || c > '~' {
+			return false
+		}
+	}
+	return true
+}
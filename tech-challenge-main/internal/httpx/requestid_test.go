@@ -0,0 +1,41 @@
+package httpx
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/acai-travel/tech-challenge/internal/logx"
+)
+
+func TestRequestID(t *testing.T) {
+	var seen string
+	handler := RequestID()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = logx.RequestID(r.Context())
+	}))
+
+	for name, tc := range map[string]struct {
+		header string
+		kept   bool
+	}{
+		"forwarded": {header: "gw-123", kept: true},
+		"missing":   {header: ""},
+		"forged":    {header: "x\nlevel=ERROR"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tc.header != "" {
+				req.Header.Set(RequestIDHeader, tc.header)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if seen == "" || rec.Header().Get(RequestIDHeader) != seen {
+				t.Fatalf("context ID %q, response header %q: want the same non-empty ID", seen, rec.Header().Get(RequestIDHeader))
+			}
+			if (seen == tc.header) != tc.kept {
+				t.Errorf("ID = %q for header %q, kept = %v", seen, tc.header, tc.kept)
+			}
+		})
+	}
+}
@@ -0,0 +1,197 @@
+// Package llmdebug keeps the exact completion requests sent to the model and its raw
+// responses, for operators to find out why the assistant answered what it did. Traces hold
+// what users wrote, so capturing is opt-in, per tenant or user, and traces expire.
+package llmdebug
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/acai-travel/tech-challenge/internal/pb"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const traceCollection = "completion_traces"
+
+// Trace is a completion request of a reply and what the model returned.
+type Trace struct {
+	ID             primitive.ObjectID `bson:"_id"`
+	Time           time.Time          `bson:"time"`
+	TenantID       string             `bson:"tenant_id"`
+	UserID         string             `bson:"user_id"`
+	ConversationID string             `bson:"conversation_id"`
+	Model          string             `bson:"model"`
+	// Turn numbers the completions of a reply from 0; later turns follow tool calls.
+	Turn int `bson:"turn"`
+	// Request is the JSON body sent, with the messages and tool definitions.
+	Request string `bson:"request"`
+	// Response is the raw JSON response, empty if the request failed.
+	Response   string `bson:"response,omitempty"`
+	Error      string `bson:"error,omitempty"`
+	DurationMs int64  `bson:"duration_ms"`
+	// ToolCalls is how many tools the response called, and ToolMs how long they took.
+	ToolCalls int   `bson:"tool_calls,omitempty"`
+	ToolMs    int64 `bson:"tool_ms,omitempty"`
+}
+
+func (t *Trace) Proto() *pb.CompletionTrace {
+	return &pb.CompletionTrace{
+		Id:             t.ID.Hex(),
+		Time:           timestamppb.New(t.Time),
+		TenantId:       t.TenantID,
+		UserId:         t.UserID,
+		ConversationId: t.ConversationID,
+		Model:          t.Model,
+		Turn:           int32(t.Turn),
+		Request:        t.Request,
+		Response:       t.Response,
+		Error:          t.Error,
+		DurationMs:     t.DurationMs,
+		ToolCalls:      int32(t.ToolCalls),
+		ToolMs:         t.ToolMs,
+	}
+}
+
+// Store keeps traces in MongoDB, for at most retention and, of each conversation, the
+// latest perConversation. Traces are written in the background, and dropped when writes
+// fall behind: they only serve debugging.
+type Store struct {
+	conn            *mongo.Database
+	retention       time.Duration
+	perConversation int
+	queue           chan *Trace
+	once            sync.Once
+}
+
+func NewStore(conn *mongo.Database, retention time.Duration, perConversation int) *Store {
+	return &Store{conn: conn, retention: retention, perConversation: perConversation, queue: make(chan *Trace, 256)}
+}
+
+// FromEnv returns the store if LLM_DEBUG_STORE=true, or nil. LLM_DEBUG_RETENTION (a Go
+// duration, 72h by default) and LLM_DEBUG_MAX_PER_CONVERSATION (200 by default) limit
+// what it keeps.
+func FromEnv(conn *mongo.Database) (*Store, error) {
+	if os.Getenv("LLM_DEBUG_STORE") != "true" {
+		return nil, nil
+	}
+
+	retention := 72 * time.Hour
+	if v := os.Getenv("LLM_DEBUG_RETENTION"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil || d <= 0 {
+			return nil, fmt.Errorf("invalid LLM_DEBUG_RETENTION %q", v)
+		}
+		retention = d
+	}
+
+	perConversation := 200
+	if v := os.Getenv("LLM_DEBUG_MAX_PER_CONVERSATION"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			return nil, fmt.Errorf("invalid LLM_DEBUG_MAX_PER_CONVERSATION %q", v)
+		}
+		perConversation = n
+	}
+	return NewStore(conn, retention, perConversation), nil
+}
+
+// Record stores t in the background.
+func (s *Store) Record(t *Trace) {
+	s.once.Do(func() { go s.run() })
+
+	if t.ID.IsZero() {
+		t.ID = primitive.NewObjectID()
+	}
+	select {
+	case s.queue <- t:
+	default:
+		slog.Warn("Completion trace queue is full, dropping a trace", "conversation_id", t.ConversationID)
+	}
+}
+
+func (s *Store) run() {
+	for t := range s.queue {
+		s.insert(t)
+	}
+}
+
+// insert stores t and deletes the traces of its conversation in its tenant beyond the latest
+// perConversation.
+func (s *Store) insert(t *Trace) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	coll := s.conn.Collection(traceCollection)
+	if _, err := coll.InsertOne(ctx, t); err != nil {
+		slog.Error("Failed to store completion trace", "conversation_id", t.ConversationID, "error", err)
+		return
+	}
+
+	var oldest struct {
+		ID primitive.ObjectID `bson:"_id"`
+	}
+	conversation := map[string]any{"tenant_id": t.TenantID, "conversation_id": t.ConversationID}
+	err := coll.FindOne(ctx, conversation,
+		options.FindOne().
+			SetSort(bson.D{{Key: "_id", Value: -1}}).
+			SetSkip(int64(s.perConversation)).
+			SetProjection(map[string]any{"_id": 1})).Decode(&oldest)
+	if err == mongo.ErrNoDocuments {
+		return
+	}
+	if err == nil {
+		conversation["_id"] = map[string]any{"$lte": oldest.ID}
+		_, err = coll.DeleteMany(ctx, conversation)
+	}
+	if err != nil {
+		slog.Warn("Failed to trim completion traces", "conversation_id", t.ConversationID, "error", err)
+	}
+}
+
+// List returns the latest limit traces of a conversation of a tenant, oldest first.
+func (s *Store) List(ctx context.Context, tenantID, conversationID string, limit int) ([]*Trace, error) {
+	cursor, err := s.conn.Collection(traceCollection).Find(ctx,
+		map[string]any{"tenant_id": tenantID, "conversation_id": conversationID},
+		options.Find().SetSort(bson.D{{Key: "_id", Value: -1}}).SetLimit(int64(limit)))
+	if err != nil {
+		return nil, err
+	}
+
+	var traces []*Trace
+	if err := cursor.All(ctx, &traces); err != nil {
+		return nil, err
+	}
+	slices.Reverse(traces)
+	return traces, nil
+}
+
+// Run deletes the traces older than the retention at once and then every hour, until ctx
+// is done.
+func (s *Store) Run(ctx context.Context) {
+	for {
+		res, err := s.conn.Collection(traceCollection).DeleteMany(ctx,
+			map[string]any{"time": map[string]any{"$lt": time.Now().Add(-s.retention)}})
+		if err != nil && ctx.Err() == nil {
+			slog.ErrorContext(ctx, "Failed to delete expired completion traces", "error", err)
+		}
+		if err == nil && res.DeletedCount > 0 {
+			slog.InfoContext(ctx, "Deleted expired completion traces", "count", res.DeletedCount)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(time.Hour):
+		}
+	}
+}
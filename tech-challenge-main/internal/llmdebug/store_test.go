@@ -0,0 +1,93 @@
+package llmdebug
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	chattest "github.com/acai-travel/tech-challenge/internal/chat/testing"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestFromEnv(t *testing.T) {
+	t.Setenv("LLM_DEBUG_STORE", "")
+	if s, err := FromEnv(nil); s != nil || err != nil {
+		t.Errorf("expected the store to be off by default, got %+v, %v", s, err)
+	}
+
+	t.Setenv("LLM_DEBUG_STORE", "true")
+	s, err := FromEnv(nil)
+	if err != nil {
+		t.Fatalf("FromEnv: %v", err)
+	}
+	if s.retention != 72*time.Hour || s.perConversation != 200 {
+		t.Errorf("unexpected defaults: retention %v, %d per conversation", s.retention, s.perConversation)
+	}
+
+	t.Setenv("LLM_DEBUG_RETENTION", "24h")
+	t.Setenv("LLM_DEBUG_MAX_PER_CONVERSATION", "20")
+	if s, err = FromEnv(nil); err != nil || s.retention != 24*time.Hour || s.perConversation != 20 {
+		t.Errorf("unexpected store: %+v, %v", s, err)
+	}
+
+	t.Setenv("LLM_DEBUG_RETENTION", "forever")
+	if _, err := FromEnv(nil); err == nil {
+		t.Error("expected an error for an invalid retention")
+	}
+	t.Setenv("LLM_DEBUG_RETENTION", "")
+	t.Setenv("LLM_DEBUG_MAX_PER_CONVERSATION", "0")
+	if _, err := FromEnv(nil); err == nil {
+		t.Error("expected an error for a cap of 0")
+	}
+}
+
+func TestTraceProto(t *testing.T) {
+	tr := &Trace{
+		ID:             primitive.NewObjectID(),
+		Time:           time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC),
+		ConversationID: "c1",
+		Model:          "gpt-4.1",
+		Turn:           1,
+		Request:        `{"messages":[]}`,
+		Error:          "timeout",
+		DurationMs:     1200,
+	}
+
+	p := tr.Proto()
+	if p.GetId() != tr.ID.Hex() || !p.GetTime().AsTime().Equal(tr.Time) || p.GetTurn() != 1 ||
+		p.GetRequest() != tr.Request || p.GetError() != "timeout" || p.GetDurationMs() != 1200 {
+		t.Errorf("unexpected proto: %v", p)
+	}
+}
+
+func TestStore(t *testing.T) {
+	s := NewStore(chattest.ConnectMongo(), time.Hour, 2)
+	ctx := context.Background()
+	conv := primitive.NewObjectID().Hex()
+	t.Cleanup(func() {
+		s.conn.Collection(traceCollection).DeleteMany(ctx, map[string]any{"conversation_id": conv})
+	})
+
+	// The conversation's ID is the same in both tenants, as if one guessed the other's.
+	for turn := range 3 {
+		s.insert(&Trace{ID: primitive.NewObjectID(), Time: time.Now(), TenantID: "acme", ConversationID: conv, Turn: turn})
+	}
+	s.insert(&Trace{ID: primitive.NewObjectID(), Time: time.Now(), TenantID: "globex", ConversationID: conv, Turn: 7})
+
+	traces, err := s.List(ctx, "acme", conv, 10)
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(traces) != 2 || traces[0].Turn != 1 || traces[1].Turn != 2 {
+		t.Errorf("acme traces = %+v, want the latest 2, oldest first", traces)
+	}
+	if traces, err := s.List(ctx, "acme", conv, 1); err != nil || len(traces) != 1 || traces[0].Turn != 2 {
+		t.Errorf("List with a limit of 1 = %+v, %v; want the latest", traces, err)
+	}
+
+	// The other tenant's traces are kept and listed apart.
+	traces, err = s.List(ctx, "globex", conv, 10)
+	if err != nil || len(traces) != 1 || traces[0].Turn != 7 || traces[0].TenantID != "globex" {
+		t.Errorf("globex traces = %+v, %v; want its own only", traces, err)
+	}
+}
@@ -0,0 +1,217 @@
+// Package logx is the server's logging policy: records carry the request, tenant, user and
+// conversation of their context, message contents are redacted unless sampled for
+// debugging, and API keys are scrubbed from every value, errors included.
+package logx
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"math/rand/v2"
+	"os"
+	"regexp"
+	"strconv"
+
+	"github.com/acai-travel/tech-challenge/internal/auth"
+)
+
+// Options configure NewHandler.
+type Options struct {
+	// JSON writes records as JSON objects, one per line, instead of key=value text.
+	JSON bool
+	// Level is the minimum level of the records written.
+	Level slog.Leveler
+	// ContentSample is the share of records, from 0 to 1, whose message contents are
+	// written in full rather than redacted, for debugging.
+	ContentSample float64
+}
+
+// FromEnv reads LOG_FORMAT ("text", the default, or "json"), LOG_LEVEL ("debug", "info",
+// the default, "warn" or "error") and LOG_CONTENT_SAMPLE (Options.ContentSample, 0 by
+// default).
+func FromEnv() (Options, error) {
+	var o Options
+	switch v := os.Getenv("LOG_FORMAT"); v {
+	case "", "text":
+	case "json":
+		o.JSON = true
+	default:
+		return o, fmt.Errorf("invalid LOG_FORMAT %q: want text or json", v)
+	}
+
+	level := slog.LevelInfo
+	if v := os.Getenv("LOG_LEVEL"); v != "" {
+		if err := level.UnmarshalText([]byte(v)); err != nil {
+			return o, fmt.Errorf("invalid LOG_LEVEL %q: want debug, info, warn or error", v)
+		}
+	}
+	o.Level = level
+
+	if v := os.Getenv("LOG_CONTENT_SAMPLE"); v != "" {
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil || f < 0 || f > 1 {
+			return o, fmt.Errorf("invalid LOG_CONTENT_SAMPLE %q: want a share from 0 to 1", v)
+		}
+		o.ContentSample = f
+	}
+	return o, nil
+}
+
+// NewHandler returns a handler writing records to w as o configures, following the policy.
+func NewHandler(w io.Writer, o Options) slog.Handler {
+	opts := &slog.HandlerOptions{Level: o.Level}
+	var next slog.Handler = slog.NewTextHandler(w, opts)
+	if o.JSON {
+		next = slog.NewJSONHandler(w, opts)
+	}
+	return &handler{next: next, sample: o.ContentSample}
+}
+
+// contentKeys are the attributes holding what users and the model wrote.
+var contentKeys = map[string]bool{
+	"content":   true,
+	"text":      true,
+	"prompt":    true,
+	"reply":     true,
+	"args":      true,
+	"arguments": true,
+	"body":      true,
+	"query":     true,
+}
+
+// secrets match API keys: OpenAI keys, bearer tokens and keys passed in URLs, e.g. in the
+// errors of failed WeatherAPI requests.
+var secrets = []*regexp.Regexp{
+	regexp.MustCompile(`sk-[A-Za-z0-9_-]{16,}`),
+	regexp.MustCompile(`(?i)(bearer\s+)[A-Za-z0-9._~+/=-]+`),
+	regexp.MustCompile(`(?i)([?&](?:key|api_key|apikey|access_token|token)=)[^&\s"']+`),
+}
+
+// Scrub replaces the API keys in s.
+func Scrub(s string) string {
+	for _, re := range secrets {
+		s = re.ReplaceAllString(s, "${1}[redacted]")
+	}
+	return s
+}
+
+type handler struct {
+	next   slog.Handler
+	sample float64
+}
+
+func (h *handler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+func (h *handler) Handle(ctx context.Context, r slog.Record) error {
+	full := h.sample > 0 && rand.Float64() < h.sample
+	out := slog.NewRecord(r.Time, r.Level, Scrub(r.Message), r.PC)
+
+	seen := map[string]bool{}
+	sampled := false
+	r.Attrs(func(a slog.Attr) bool {
+		seen[a.Key] = true
+		a, withContent := clean(a, full)
+		sampled = sampled || withContent
+		out.AddAttrs(a)
+		return true
+	})
+
+	for _, a := range contextAttrs(ctx) {
+		if !seen[a.Key] {
+			out.AddAttrs(a)
+		}
+	}
+	if sampled {
+		out.AddAttrs(slog.Bool("content_sampled", true))
+	}
+	return h.next.Handle(ctx, out)
+}
+
+func (h *handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	cleaned := make([]slog.Attr, len(attrs))
+	for i, a := range attrs {
+		cleaned[i], _ = clean(a, false)
+	}
+	return &handler{next: h.next.WithAttrs(cleaned), sample: h.sample}
+}
+
+func (h *handler) WithGroup(name string) slog.Handler {
+	return &handler{next: h.next.WithGroup(name), sample: h.sample}
+}
+
+// clean redacts a if it holds content, unless full, and scrubs the API keys in it. It also
+// reports whether content was kept.
+func clean(a slog.Attr, full bool) (slog.Attr, bool) {
+	a.Value = a.Value.Resolve()
+	switch a.Value.Kind() {
+	case slog.KindGroup:
+		group := a.Value.Group()
+		attrs := make([]slog.Attr, len(group))
+		kept := false
+		for i, ga := range group {
+			var k bool
+			attrs[i], k = clean(ga, full)
+			kept = kept || k
+		}
+		return slog.Attr{Key: a.Key, Value: slog.GroupValue(attrs...)}, kept
+	case slog.KindString:
+		s := a.Value.String()
+		if contentKeys[a.Key] && s != "" {
+			if !full {
+				return slog.String(a.Key, fmt.Sprintf("[redacted, %d chars]", len(s))), false
+			}
+			return slog.String(a.Key, Scrub(s)), true
+		}
+		return slog.String(a.Key, Scrub(s)), false
+	case slog.KindAny:
+		if err, ok := a.Value.Any().(error); ok {
+			return slog.String(a.Key, Scrub(err.Error())), false
+		}
+	}
+	return a, false
+}
+
+type (
+	requestIDKey      struct{}
+	conversationIDKey struct{}
+)
+
+// WithRequestID returns a context whose records carry request_id id.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestID returns the request ID of ctx, or "" if it has none.
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// WithConversation returns a context whose records carry conversation_id id.
+func WithConversation(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, conversationIDKey{}, id)
+}
+
+// contextAttrs are the IDs ctx carries.
+func contextAttrs(ctx context.Context) []slog.Attr {
+	if ctx == nil {
+		return nil
+	}
+	var attrs []slog.Attr
+	if id := RequestID(ctx); id != "" {
+		attrs = append(attrs, slog.String("request_id", id))
+	}
+	if tenant := auth.Tenant(ctx); tenant != auth.DefaultTenant {
+		attrs = append(attrs, slog.String("tenant_id", tenant))
+	}
+	if user := auth.User(ctx); user != auth.Anonymous {
+		attrs = append(attrs, slog.String("user_id", user))
+	}
+	if id, _ := ctx.Value(conversationIDKey{}).(string); id != "" {
+		attrs = append(attrs, slog.String("conversation_id", id))
+	}
+	return attrs
+}
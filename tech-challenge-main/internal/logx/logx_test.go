@@ -0,0 +1,118 @@
+package logx
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/acai-travel/tech-challenge/internal/auth"
+)
+
+func logJSON(t *testing.T, o Options, log func(*slog.Logger)) map[string]any {
+	t.Helper()
+	var buf bytes.Buffer
+	o.JSON = true
+	log(slog.New(NewHandler(&buf, o)))
+
+	var record map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatalf("log output %q is not JSON: %v", buf.String(), err)
+	}
+	return record
+}
+
+func TestHandler_RedactsContent(t *testing.T) {
+	record := logJSON(t, Options{}, func(l *slog.Logger) {
+		l.Info("Tool call received", "name", "get_weather", "args", `{"location":"Barcelona"}`)
+	})
+
+	if record["name"] != "get_weather" {
+		t.Errorf("name = %v, want get_weather", record["name"])
+	}
+	if got := record["args"]; got != "[redacted, 24 chars]" {
+		t.Errorf("args = %v, want it redacted", got)
+	}
+	if _, ok := record["content_sampled"]; ok {
+		t.Error("content_sampled is set on a redacted record")
+	}
+}
+
+func TestHandler_SamplesContent(t *testing.T) {
+	record := logJSON(t, Options{ContentSample: 1}, func(l *slog.Logger) {
+		l.Info("Reply generated", slog.Group("turn", "reply", "It's sunny in Barcelona."))
+	})
+
+	turn, _ := record["turn"].(map[string]any)
+	if got := turn["reply"]; got != "It's sunny in Barcelona." {
+		t.Errorf("turn.reply = %v, want the full reply", got)
+	}
+	if record["content_sampled"] != true {
+		t.Error("content_sampled is not set on a sampled record")
+	}
+}
+
+func TestHandler_ScrubsSecrets(t *testing.T) {
+	err := errors.New(`Get "https://api.weatherapi.com/v1/current.json?key=abc123&q=Paris": timeout`)
+	record := logJSON(t, Options{ContentSample: 1}, func(l *slog.Logger) {
+		l.With("auth", "Bearer eyJhbGciOi.payload").Error("Request failed", "error", err, "args", "use sk-proj-0123456789abcdefXYZ")
+	})
+
+	if got := record["error"].(string); strings.Contains(got, "abc123") || !strings.Contains(got, "key=[redacted]&q=Paris") {
+		t.Errorf("error = %q, want the key scrubbed", got)
+	}
+	if got := record["auth"]; got != "Bearer [redacted]" {
+		t.Errorf("auth = %v, want the token scrubbed", got)
+	}
+	if got := record["args"]; got != "use [redacted]" {
+		t.Errorf("args = %v, want the OpenAI key scrubbed from sampled content", got)
+	}
+}
+
+func TestHandler_ContextIDs(t *testing.T) {
+	ctx := auth.WithTenant(auth.WithUser(context.Background(), "u1"), "acme")
+	ctx = WithConversation(WithRequestID(ctx, "req-1"), "c-ctx")
+
+	record := logJSON(t, Options{}, func(l *slog.Logger) {
+		l.InfoContext(ctx, "Generating reply", "conversation_id", "c-attr")
+	})
+
+	for key, want := range map[string]string{"request_id": "req-1", "tenant_id": "acme", "user_id": "u1", "conversation_id": "c-attr"} {
+		if got := record[key]; got != want {
+			t.Errorf("%s = %v, want %s", key, got, want)
+		}
+	}
+
+	record = logJSON(t, Options{}, func(l *slog.Logger) { l.InfoContext(context.Background(), "Started") })
+	for _, key := range []string{"request_id", "tenant_id", "user_id", "conversation_id"} {
+		if _, ok := record[key]; ok {
+			t.Errorf("%s is set without a context carrying it", key)
+		}
+	}
+}
+
+func TestFromEnv(t *testing.T) {
+	t.Setenv("LOG_FORMAT", "json")
+	t.Setenv("LOG_LEVEL", "warn")
+	t.Setenv("LOG_CONTENT_SAMPLE", "0.01")
+
+	o, err := FromEnv()
+	if err != nil {
+		t.Fatalf("FromEnv: %v", err)
+	}
+	if !o.JSON || o.Level.Level() != slog.LevelWarn || o.ContentSample != 0.01 {
+		t.Errorf("FromEnv = %+v, want JSON at warn sampling 0.01", o)
+	}
+
+	for name, value := range map[string]string{"LOG_FORMAT": "xml", "LOG_LEVEL": "loud", "LOG_CONTENT_SAMPLE": "2"} {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv(name, value)
+			if _, err := FromEnv(); err == nil {
+				t.Errorf("FromEnv accepted %s=%s", name, value)
+			}
+		})
+	}
+}
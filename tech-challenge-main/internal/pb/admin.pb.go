@@ -0,0 +1,1960 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.7
+// 	protoc        v5.29.3
+// source: rpc/admin.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type SetFeatureFlagRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	TenantId string                 `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	// Optional user to override the flag for; empty applies to the whole tenant
+	UserId string `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Flag   string `protobuf:"bytes,3,opt,name=flag,proto3" json:"flag,omitempty"`
+	// New value, e.g. "true" or "7"; empty removes the override
+	Value         string `protobuf:"bytes,4,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetFeatureFlagRequest) Reset() {
+	*x = SetFeatureFlagRequest{}
+	mi := &file_rpc_admin_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetFeatureFlagRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetFeatureFlagRequest) ProtoMessage() {}
+
+func (x *SetFeatureFlagRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_admin_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetFeatureFlagRequest.ProtoReflect.Descriptor instead.
+func (*SetFeatureFlagRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_admin_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *SetFeatureFlagRequest) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *SetFeatureFlagRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *SetFeatureFlagRequest) GetFlag() string {
+	if x != nil {
+		return x.Flag
+	}
+	return ""
+}
+
+func (x *SetFeatureFlagRequest) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+type SetFeatureFlagResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetFeatureFlagResponse) Reset() {
+	*x = SetFeatureFlagResponse{}
+	mi := &file_rpc_admin_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetFeatureFlagResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetFeatureFlagResponse) ProtoMessage() {}
+
+func (x *SetFeatureFlagResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_admin_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetFeatureFlagResponse.ProtoReflect.Descriptor instead.
+func (*SetFeatureFlagResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_admin_proto_rawDescGZIP(), []int{1}
+}
+
+type ListFeatureFlagsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TenantId      string                 `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListFeatureFlagsRequest) Reset() {
+	*x = ListFeatureFlagsRequest{}
+	mi := &file_rpc_admin_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFeatureFlagsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFeatureFlagsRequest) ProtoMessage() {}
+
+func (x *ListFeatureFlagsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_admin_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFeatureFlagsRequest.ProtoReflect.Descriptor instead.
+func (*ListFeatureFlagsRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_admin_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListFeatureFlagsRequest) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+type ListFeatureFlagsResponse struct {
+	state         protoimpl.MessageState               `protogen:"open.v1"`
+	Flags         []*ListFeatureFlagsResponse_Flag     `protobuf:"bytes,1,rep,name=flags,proto3" json:"flags,omitempty"`
+	Overrides     []*ListFeatureFlagsResponse_Override `protobuf:"bytes,2,rep,name=overrides,proto3" json:"overrides,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListFeatureFlagsResponse) Reset() {
+	*x = ListFeatureFlagsResponse{}
+	mi := &file_rpc_admin_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFeatureFlagsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFeatureFlagsResponse) ProtoMessage() {}
+
+func (x *ListFeatureFlagsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_admin_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFeatureFlagsResponse.ProtoReflect.Descriptor instead.
+func (*ListFeatureFlagsResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_admin_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListFeatureFlagsResponse) GetFlags() []*ListFeatureFlagsResponse_Flag {
+	if x != nil {
+		return x.Flags
+	}
+	return nil
+}
+
+func (x *ListFeatureFlagsResponse) GetOverrides() []*ListFeatureFlagsResponse_Override {
+	if x != nil {
+		return x.Overrides
+	}
+	return nil
+}
+
+// A call to an RPC, recorded in the append-only audit log
+type AuditEvent struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Id       string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Time     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=time,proto3" json:"time,omitempty"`
+	TenantId string                 `protobuf:"bytes,3,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	UserId   string                 `protobuf:"bytes,4,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Service and method called, e.g. "ChatService/StartConversation"
+	Action string `protobuf:"bytes,5,opt,name=action,proto3" json:"action,omitempty"`
+	// Conversation the call read or changed, if any
+	ConversationId string `protobuf:"bytes,6,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	// Models and tools used to answer the call
+	Models []string `protobuf:"bytes,7,rep,name=models,proto3" json:"models,omitempty"`
+	Tools  []string `protobuf:"bytes,8,rep,name=tools,proto3" json:"tools,omitempty"`
+	// "ok", or the Twirp error code of a failed call
+	Status     string `protobuf:"bytes,9,opt,name=status,proto3" json:"status,omitempty"`
+	DurationMs int64  `protobuf:"varint,10,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"`
+	// Action specific details, e.g. the flag changed by SetFeatureFlag
+	Details map[string]string `protobuf:"bytes,11,rep,name=details,proto3" json:"details,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Model tokens, prompt and completion, used to answer the call
+	Tokens        int64 `protobuf:"varint,12,opt,name=tokens,proto3" json:"tokens,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuditEvent) Reset() {
+	*x = AuditEvent{}
+	mi := &file_rpc_admin_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuditEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuditEvent) ProtoMessage() {}
+
+func (x *AuditEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_admin_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuditEvent.ProtoReflect.Descriptor instead.
+func (*AuditEvent) Descriptor() ([]byte, []int) {
+	return file_rpc_admin_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *AuditEvent) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *AuditEvent) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetConversationId() string {
+	if x != nil {
+		return x.ConversationId
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetModels() []string {
+	if x != nil {
+		return x.Models
+	}
+	return nil
+}
+
+func (x *AuditEvent) GetTools() []string {
+	if x != nil {
+		return x.Tools
+	}
+	return nil
+}
+
+func (x *AuditEvent) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetDurationMs() int64 {
+	if x != nil {
+		return x.DurationMs
+	}
+	return 0
+}
+
+func (x *AuditEvent) GetDetails() map[string]string {
+	if x != nil {
+		return x.Details
+	}
+	return nil
+}
+
+func (x *AuditEvent) GetTokens() int64 {
+	if x != nil {
+		return x.Tokens
+	}
+	return 0
+}
+
+type ListAuditEventsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Filters; empty ones match every event
+	TenantId       string                 `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	UserId         string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ConversationId string                 `protobuf:"bytes,3,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	Action         string                 `protobuf:"bytes,4,opt,name=action,proto3" json:"action,omitempty"`
+	Since          *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=since,proto3" json:"since,omitempty"`
+	Until          *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=until,proto3" json:"until,omitempty"`
+	// Maximum number of events; defaults to 100, at most 1000
+	PageSize int32 `protobuf:"varint,7,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// next_page_token of the previous page
+	PageToken     string `protobuf:"bytes,8,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAuditEventsRequest) Reset() {
+	*x = ListAuditEventsRequest{}
+	mi := &file_rpc_admin_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAuditEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAuditEventsRequest) ProtoMessage() {}
+
+func (x *ListAuditEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_admin_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAuditEventsRequest.ProtoReflect.Descriptor instead.
+func (*ListAuditEventsRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_admin_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListAuditEventsRequest) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *ListAuditEventsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListAuditEventsRequest) GetConversationId() string {
+	if x != nil {
+		return x.ConversationId
+	}
+	return ""
+}
+
+func (x *ListAuditEventsRequest) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *ListAuditEventsRequest) GetSince() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Since
+	}
+	return nil
+}
+
+func (x *ListAuditEventsRequest) GetUntil() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Until
+	}
+	return nil
+}
+
+func (x *ListAuditEventsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListAuditEventsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListAuditEventsResponse struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Events []*AuditEvent          `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	// Empty on the last page
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAuditEventsResponse) Reset() {
+	*x = ListAuditEventsResponse{}
+	mi := &file_rpc_admin_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAuditEventsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAuditEventsResponse) ProtoMessage() {}
+
+func (x *ListAuditEventsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_admin_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAuditEventsResponse.ProtoReflect.Descriptor instead.
+func (*ListAuditEventsResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_admin_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListAuditEventsResponse) GetEvents() []*AuditEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+func (x *ListAuditEventsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// Usage of a week, from Monday 00:00 UTC to the next
+type UsageReport struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Id        string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	WeekStart *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=week_start,json=weekStart,proto3" json:"week_start,omitempty"`
+	WeekEnd   *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=week_end,json=weekEnd,proto3" json:"week_end,omitempty"`
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Total     *UsageReport_Usage     `protobuf:"bytes,5,opt,name=total,proto3" json:"total,omitempty"`
+	// Sorted by tokens, most first
+	Users         []*UsageReport_User `protobuf:"bytes,6,rep,name=users,proto3" json:"users,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UsageReport) Reset() {
+	*x = UsageReport{}
+	mi := &file_rpc_admin_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UsageReport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UsageReport) ProtoMessage() {}
+
+func (x *UsageReport) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_admin_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UsageReport.ProtoReflect.Descriptor instead.
+func (*UsageReport) Descriptor() ([]byte, []int) {
+	return file_rpc_admin_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *UsageReport) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UsageReport) GetWeekStart() *timestamppb.Timestamp {
+	if x != nil {
+		return x.WeekStart
+	}
+	return nil
+}
+
+func (x *UsageReport) GetWeekEnd() *timestamppb.Timestamp {
+	if x != nil {
+		return x.WeekEnd
+	}
+	return nil
+}
+
+func (x *UsageReport) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *UsageReport) GetTotal() *UsageReport_Usage {
+	if x != nil {
+		return x.Total
+	}
+	return nil
+}
+
+func (x *UsageReport) GetUsers() []*UsageReport_User {
+	if x != nil {
+		return x.Users
+	}
+	return nil
+}
+
+type GetUsageReportRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Any time in the week to report; unset returns the latest report
+	Week          *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=week,proto3" json:"week,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUsageReportRequest) Reset() {
+	*x = GetUsageReportRequest{}
+	mi := &file_rpc_admin_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUsageReportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUsageReportRequest) ProtoMessage() {}
+
+func (x *GetUsageReportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_admin_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUsageReportRequest.ProtoReflect.Descriptor instead.
+func (*GetUsageReportRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_admin_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetUsageReportRequest) GetWeek() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Week
+	}
+	return nil
+}
+
+type GetUsageReportResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Report        *UsageReport           `protobuf:"bytes,1,opt,name=report,proto3" json:"report,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUsageReportResponse) Reset() {
+	*x = GetUsageReportResponse{}
+	mi := &file_rpc_admin_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUsageReportResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUsageReportResponse) ProtoMessage() {}
+
+func (x *GetUsageReportResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_admin_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUsageReportResponse.ProtoReflect.Descriptor instead.
+func (*GetUsageReportResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_admin_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetUsageReportResponse) GetReport() *UsageReport {
+	if x != nil {
+		return x.Report
+	}
+	return nil
+}
+
+// A completion request sent to the model while replying, and its response
+type CompletionTrace struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Time           *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=time,proto3" json:"time,omitempty"`
+	TenantId       string                 `protobuf:"bytes,3,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	UserId         string                 `protobuf:"bytes,4,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ConversationId string                 `protobuf:"bytes,5,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	Model          string                 `protobuf:"bytes,6,opt,name=model,proto3" json:"model,omitempty"`
+	// Completions of a reply count from 0; later turns follow tool calls
+	Turn int32 `protobuf:"varint,7,opt,name=turn,proto3" json:"turn,omitempty"`
+	// JSON body sent, with the messages and tool definitions
+	Request string `protobuf:"bytes,8,opt,name=request,proto3" json:"request,omitempty"`
+	// Raw JSON response; empty if the request failed
+	Response   string `protobuf:"bytes,9,opt,name=response,proto3" json:"response,omitempty"`
+	Error      string `protobuf:"bytes,10,opt,name=error,proto3" json:"error,omitempty"`
+	DurationMs int64  `protobuf:"varint,11,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"`
+	// Tools the response called, and how long they took
+	ToolCalls     int32 `protobuf:"varint,12,opt,name=tool_calls,json=toolCalls,proto3" json:"tool_calls,omitempty"`
+	ToolMs        int64 `protobuf:"varint,13,opt,name=tool_ms,json=toolMs,proto3" json:"tool_ms,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CompletionTrace) Reset() {
+	*x = CompletionTrace{}
+	mi := &file_rpc_admin_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CompletionTrace) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompletionTrace) ProtoMessage() {}
+
+func (x *CompletionTrace) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_admin_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompletionTrace.ProtoReflect.Descriptor instead.
+func (*CompletionTrace) Descriptor() ([]byte, []int) {
+	return file_rpc_admin_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *CompletionTrace) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *CompletionTrace) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *CompletionTrace) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *CompletionTrace) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *CompletionTrace) GetConversationId() string {
+	if x != nil {
+		return x.ConversationId
+	}
+	return ""
+}
+
+func (x *CompletionTrace) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *CompletionTrace) GetTurn() int32 {
+	if x != nil {
+		return x.Turn
+	}
+	return 0
+}
+
+func (x *CompletionTrace) GetRequest() string {
+	if x != nil {
+		return x.Request
+	}
+	return ""
+}
+
+func (x *CompletionTrace) GetResponse() string {
+	if x != nil {
+		return x.Response
+	}
+	return ""
+}
+
+func (x *CompletionTrace) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *CompletionTrace) GetDurationMs() int64 {
+	if x != nil {
+		return x.DurationMs
+	}
+	return 0
+}
+
+func (x *CompletionTrace) GetToolCalls() int32 {
+	if x != nil {
+		return x.ToolCalls
+	}
+	return 0
+}
+
+func (x *CompletionTrace) GetToolMs() int64 {
+	if x != nil {
+		return x.ToolMs
+	}
+	return 0
+}
+
+type ListCompletionTracesRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ConversationId string                 `protobuf:"bytes,1,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	// Maximum number of traces, the latest; defaults to 50, at most 200
+	Limit int32 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	// Tenant of the conversation; empty is the default tenant
+	TenantId      string `protobuf:"bytes,3,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCompletionTracesRequest) Reset() {
+	*x = ListCompletionTracesRequest{}
+	mi := &file_rpc_admin_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCompletionTracesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCompletionTracesRequest) ProtoMessage() {}
+
+func (x *ListCompletionTracesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_admin_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCompletionTracesRequest.ProtoReflect.Descriptor instead.
+func (*ListCompletionTracesRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_admin_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ListCompletionTracesRequest) GetConversationId() string {
+	if x != nil {
+		return x.ConversationId
+	}
+	return ""
+}
+
+func (x *ListCompletionTracesRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListCompletionTracesRequest) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+type ListCompletionTracesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Oldest first
+	Traces        []*CompletionTrace `protobuf:"bytes,1,rep,name=traces,proto3" json:"traces,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCompletionTracesResponse) Reset() {
+	*x = ListCompletionTracesResponse{}
+	mi := &file_rpc_admin_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCompletionTracesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCompletionTracesResponse) ProtoMessage() {}
+
+func (x *ListCompletionTracesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_admin_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCompletionTracesResponse.ProtoReflect.Descriptor instead.
+func (*ListCompletionTracesResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_admin_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ListCompletionTracesResponse) GetTraces() []*CompletionTrace {
+	if x != nil {
+		return x.Traces
+	}
+	return nil
+}
+
+type ReplayConversationRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Tenant of the conversation; empty is the default tenant
+	TenantId       string `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	ConversationId string `protobuf:"bytes,2,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	// User message to answer again; empty is the last one
+	MessageId string `protobuf:"bytes,3,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	// Registered assistant to answer with, e.g. "travel"; empty keeps the conversation's
+	Assistant string `protobuf:"bytes,4,opt,name=assistant,proto3" json:"assistant,omitempty"`
+	// System prompt replacing the assistant's; empty keeps it
+	Prompt string `protobuf:"bytes,5,opt,name=prompt,proto3" json:"prompt,omitempty"`
+	// Model replacing the conversation's; empty keeps it
+	Model         string `protobuf:"bytes,6,opt,name=model,proto3" json:"model,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReplayConversationRequest) Reset() {
+	*x = ReplayConversationRequest{}
+	mi := &file_rpc_admin_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReplayConversationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplayConversationRequest) ProtoMessage() {}
+
+func (x *ReplayConversationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_admin_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplayConversationRequest.ProtoReflect.Descriptor instead.
+func (*ReplayConversationRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_admin_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ReplayConversationRequest) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *ReplayConversationRequest) GetConversationId() string {
+	if x != nil {
+		return x.ConversationId
+	}
+	return ""
+}
+
+func (x *ReplayConversationRequest) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+func (x *ReplayConversationRequest) GetAssistant() string {
+	if x != nil {
+		return x.Assistant
+	}
+	return ""
+}
+
+func (x *ReplayConversationRequest) GetPrompt() string {
+	if x != nil {
+		return x.Prompt
+	}
+	return ""
+}
+
+func (x *ReplayConversationRequest) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+type ReplayConversationResponse struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	MessageId string                 `protobuf:"bytes,1,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	// Reply stored after the message; empty if it failed
+	OriginalReply string `protobuf:"bytes,2,opt,name=original_reply,json=originalReply,proto3" json:"original_reply,omitempty"`
+	Reply         string `protobuf:"bytes,3,opt,name=reply,proto3" json:"reply,omitempty"`
+	// Line diff of the replies: unchanged lines start with "  ", removed ones with "- " and
+	// added ones with "+ "
+	Diff      string                                 `protobuf:"bytes,4,opt,name=diff,proto3" json:"diff,omitempty"`
+	ToolCalls []*ReplayConversationResponse_ToolCall `protobuf:"bytes,5,rep,name=tool_calls,json=toolCalls,proto3" json:"tool_calls,omitempty"`
+	// Model tokens, prompt and completion
+	Tokens     int64 `protobuf:"varint,6,opt,name=tokens,proto3" json:"tokens,omitempty"`
+	DurationMs int64 `protobuf:"varint,7,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"`
+	// Why no reply was generated, if it wasn't
+	Error         string `protobuf:"bytes,8,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReplayConversationResponse) Reset() {
+	*x = ReplayConversationResponse{}
+	mi := &file_rpc_admin_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReplayConversationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplayConversationResponse) ProtoMessage() {}
+
+func (x *ReplayConversationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_admin_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplayConversationResponse.ProtoReflect.Descriptor instead.
+func (*ReplayConversationResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_admin_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ReplayConversationResponse) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+func (x *ReplayConversationResponse) GetOriginalReply() string {
+	if x != nil {
+		return x.OriginalReply
+	}
+	return ""
+}
+
+func (x *ReplayConversationResponse) GetReply() string {
+	if x != nil {
+		return x.Reply
+	}
+	return ""
+}
+
+func (x *ReplayConversationResponse) GetDiff() string {
+	if x != nil {
+		return x.Diff
+	}
+	return ""
+}
+
+func (x *ReplayConversationResponse) GetToolCalls() []*ReplayConversationResponse_ToolCall {
+	if x != nil {
+		return x.ToolCalls
+	}
+	return nil
+}
+
+func (x *ReplayConversationResponse) GetTokens() int64 {
+	if x != nil {
+		return x.Tokens
+	}
+	return 0
+}
+
+func (x *ReplayConversationResponse) GetDurationMs() int64 {
+	if x != nil {
+		return x.DurationMs
+	}
+	return 0
+}
+
+func (x *ReplayConversationResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type GetIntentStatsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Tenant whose messages are counted; empty is the default tenant
+	TenantId string `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	// Time range of the messages counted; unset bounds are open
+	Since *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=since,proto3" json:"since,omitempty"`
+	Until *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=until,proto3" json:"until,omitempty"`
+	// Only count the conversations of this assistant, e.g. "travel"; empty counts all
+	Assistant     string `protobuf:"bytes,4,opt,name=assistant,proto3" json:"assistant,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetIntentStatsRequest) Reset() {
+	*x = GetIntentStatsRequest{}
+	mi := &file_rpc_admin_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetIntentStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetIntentStatsRequest) ProtoMessage() {}
+
+func (x *GetIntentStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_admin_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetIntentStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetIntentStatsRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_admin_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GetIntentStatsRequest) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *GetIntentStatsRequest) GetSince() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Since
+	}
+	return nil
+}
+
+func (x *GetIntentStatsRequest) GetUntil() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Until
+	}
+	return nil
+}
+
+func (x *GetIntentStatsRequest) GetAssistant() string {
+	if x != nil {
+		return x.Assistant
+	}
+	return ""
+}
+
+type GetIntentStatsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Most frequent first
+	Counts []*GetIntentStatsResponse_Count `protobuf:"bytes,1,rep,name=counts,proto3" json:"counts,omitempty"`
+	// Messages not labeled yet, or sent before intent analytics were enabled
+	Unlabeled     int64 `protobuf:"varint,2,opt,name=unlabeled,proto3" json:"unlabeled,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetIntentStatsResponse) Reset() {
+	*x = GetIntentStatsResponse{}
+	mi := &file_rpc_admin_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetIntentStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetIntentStatsResponse) ProtoMessage() {}
+
+func (x *GetIntentStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_admin_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetIntentStatsResponse.ProtoReflect.Descriptor instead.
+func (*GetIntentStatsResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_admin_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GetIntentStatsResponse) GetCounts() []*GetIntentStatsResponse_Count {
+	if x != nil {
+		return x.Counts
+	}
+	return nil
+}
+
+func (x *GetIntentStatsResponse) GetUnlabeled() int64 {
+	if x != nil {
+		return x.Unlabeled
+	}
+	return 0
+}
+
+type ListFeatureFlagsResponse_Flag struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	DefaultValue  string                 `protobuf:"bytes,2,opt,name=default_value,json=defaultValue,proto3" json:"default_value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListFeatureFlagsResponse_Flag) Reset() {
+	*x = ListFeatureFlagsResponse_Flag{}
+	mi := &file_rpc_admin_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFeatureFlagsResponse_Flag) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFeatureFlagsResponse_Flag) ProtoMessage() {}
+
+func (x *ListFeatureFlagsResponse_Flag) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_admin_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFeatureFlagsResponse_Flag.ProtoReflect.Descriptor instead.
+func (*ListFeatureFlagsResponse_Flag) Descriptor() ([]byte, []int) {
+	return file_rpc_admin_proto_rawDescGZIP(), []int{3, 0}
+}
+
+func (x *ListFeatureFlagsResponse_Flag) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ListFeatureFlagsResponse_Flag) GetDefaultValue() string {
+	if x != nil {
+		return x.DefaultValue
+	}
+	return ""
+}
+
+type ListFeatureFlagsResponse_Override struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Empty for tenant-wide overrides
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Flags         map[string]string      `protobuf:"bytes,2,rep,name=flags,proto3" json:"flags,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListFeatureFlagsResponse_Override) Reset() {
+	*x = ListFeatureFlagsResponse_Override{}
+	mi := &file_rpc_admin_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFeatureFlagsResponse_Override) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFeatureFlagsResponse_Override) ProtoMessage() {}
+
+func (x *ListFeatureFlagsResponse_Override) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_admin_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFeatureFlagsResponse_Override.ProtoReflect.Descriptor instead.
+func (*ListFeatureFlagsResponse_Override) Descriptor() ([]byte, []int) {
+	return file_rpc_admin_proto_rawDescGZIP(), []int{3, 1}
+}
+
+func (x *ListFeatureFlagsResponse_Override) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListFeatureFlagsResponse_Override) GetFlags() map[string]string {
+	if x != nil {
+		return x.Flags
+	}
+	return nil
+}
+
+func (x *ListFeatureFlagsResponse_Override) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type UsageReport_Intent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Intent        string                 `protobuf:"bytes,1,opt,name=intent,proto3" json:"intent,omitempty"`
+	Count         int64                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UsageReport_Intent) Reset() {
+	*x = UsageReport_Intent{}
+	mi := &file_rpc_admin_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UsageReport_Intent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UsageReport_Intent) ProtoMessage() {}
+
+func (x *UsageReport_Intent) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_admin_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UsageReport_Intent.ProtoReflect.Descriptor instead.
+func (*UsageReport_Intent) Descriptor() ([]byte, []int) {
+	return file_rpc_admin_proto_rawDescGZIP(), []int{7, 0}
+}
+
+func (x *UsageReport_Intent) GetIntent() string {
+	if x != nil {
+		return x.Intent
+	}
+	return ""
+}
+
+func (x *UsageReport_Intent) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type UsageReport_Usage struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Audited RPC calls
+	Calls                int64 `protobuf:"varint,1,opt,name=calls,proto3" json:"calls,omitempty"`
+	ConversationsStarted int64 `protobuf:"varint,2,opt,name=conversations_started,json=conversationsStarted,proto3" json:"conversations_started,omitempty"`
+	// Model tokens, prompt and completion
+	Tokens int64 `protobuf:"varint,3,opt,name=tokens,proto3" json:"tokens,omitempty"`
+	// Tools used per call: a call using a tool twice counts once
+	ToolCalls int64 `protobuf:"varint,4,opt,name=tool_calls,json=toolCalls,proto3" json:"tool_calls,omitempty"`
+	// Most frequent classified intents, most first
+	TopIntents    []*UsageReport_Intent `protobuf:"bytes,5,rep,name=top_intents,json=topIntents,proto3" json:"top_intents,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UsageReport_Usage) Reset() {
+	*x = UsageReport_Usage{}
+	mi := &file_rpc_admin_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UsageReport_Usage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UsageReport_Usage) ProtoMessage() {}
+
+func (x *UsageReport_Usage) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_admin_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UsageReport_Usage.ProtoReflect.Descriptor instead.
+func (*UsageReport_Usage) Descriptor() ([]byte, []int) {
+	return file_rpc_admin_proto_rawDescGZIP(), []int{7, 1}
+}
+
+func (x *UsageReport_Usage) GetCalls() int64 {
+	if x != nil {
+		return x.Calls
+	}
+	return 0
+}
+
+func (x *UsageReport_Usage) GetConversationsStarted() int64 {
+	if x != nil {
+		return x.ConversationsStarted
+	}
+	return 0
+}
+
+func (x *UsageReport_Usage) GetTokens() int64 {
+	if x != nil {
+		return x.Tokens
+	}
+	return 0
+}
+
+func (x *UsageReport_Usage) GetToolCalls() int64 {
+	if x != nil {
+		return x.ToolCalls
+	}
+	return 0
+}
+
+func (x *UsageReport_Usage) GetTopIntents() []*UsageReport_Intent {
+	if x != nil {
+		return x.TopIntents
+	}
+	return nil
+}
+
+type UsageReport_User struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TenantId      string                 `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Usage         *UsageReport_Usage     `protobuf:"bytes,3,opt,name=usage,proto3" json:"usage,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UsageReport_User) Reset() {
+	*x = UsageReport_User{}
+	mi := &file_rpc_admin_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UsageReport_User) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UsageReport_User) ProtoMessage() {}
+
+func (x *UsageReport_User) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_admin_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UsageReport_User.ProtoReflect.Descriptor instead.
+func (*UsageReport_User) Descriptor() ([]byte, []int) {
+	return file_rpc_admin_proto_rawDescGZIP(), []int{7, 2}
+}
+
+func (x *UsageReport_User) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *UsageReport_User) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *UsageReport_User) GetUsage() *UsageReport_Usage {
+	if x != nil {
+		return x.Usage
+	}
+	return nil
+}
+
+type ReplayConversationResponse_ToolCall struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tool          string                 `protobuf:"bytes,1,opt,name=tool,proto3" json:"tool,omitempty"`
+	Call          string                 `protobuf:"bytes,2,opt,name=call,proto3" json:"call,omitempty"`
+	Result        string                 `protobuf:"bytes,3,opt,name=result,proto3" json:"result,omitempty"`
+	Failed        bool                   `protobuf:"varint,4,opt,name=failed,proto3" json:"failed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReplayConversationResponse_ToolCall) Reset() {
+	*x = ReplayConversationResponse_ToolCall{}
+	mi := &file_rpc_admin_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReplayConversationResponse_ToolCall) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplayConversationResponse_ToolCall) ProtoMessage() {}
+
+func (x *ReplayConversationResponse_ToolCall) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_admin_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplayConversationResponse_ToolCall.ProtoReflect.Descriptor instead.
+func (*ReplayConversationResponse_ToolCall) Descriptor() ([]byte, []int) {
+	return file_rpc_admin_proto_rawDescGZIP(), []int{14, 0}
+}
+
+func (x *ReplayConversationResponse_ToolCall) GetTool() string {
+	if x != nil {
+		return x.Tool
+	}
+	return ""
+}
+
+func (x *ReplayConversationResponse_ToolCall) GetCall() string {
+	if x != nil {
+		return x.Call
+	}
+	return ""
+}
+
+func (x *ReplayConversationResponse_ToolCall) GetResult() string {
+	if x != nil {
+		return x.Result
+	}
+	return ""
+}
+
+func (x *ReplayConversationResponse_ToolCall) GetFailed() bool {
+	if x != nil {
+		return x.Failed
+	}
+	return false
+}
+
+type GetIntentStatsResponse_Count struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Intent        string                 `protobuf:"bytes,1,opt,name=intent,proto3" json:"intent,omitempty"`
+	Count         int64                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetIntentStatsResponse_Count) Reset() {
+	*x = GetIntentStatsResponse_Count{}
+	mi := &file_rpc_admin_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetIntentStatsResponse_Count) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetIntentStatsResponse_Count) ProtoMessage() {}
+
+func (x *GetIntentStatsResponse_Count) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_admin_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetIntentStatsResponse_Count.ProtoReflect.Descriptor instead.
+func (*GetIntentStatsResponse_Count) Descriptor() ([]byte, []int) {
+	return file_rpc_admin_proto_rawDescGZIP(), []int{16, 0}
+}
+
+func (x *GetIntentStatsResponse_Count) GetIntent() string {
+	if x != nil {
+		return x.Intent
+	}
+	return ""
+}
+
+func (x *GetIntentStatsResponse_Count) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+var File_rpc_admin_proto protoreflect.FileDescriptor
+
+const file_rpc_admin_proto_rawDesc = "" +
+	"\n" +
+	"\x0frpc/admin.proto\x12\n" +
+	"acai.admin\x1a\x1fgoogle/protobuf/timestamp.proto\"w\n" +
+	"\x15SetFeatureFlagRequest\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\tR\btenantId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04flag\x18\x03 \x01(\tR\x04flag\x12\x14\n" +
+	"\x05value\x18\x04 \x01(\tR\x05value\"\x18\n" +
+	"\x16SetFeatureFlagResponse\"6\n" +
+	"\x17ListFeatureFlagsRequest\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\tR\btenantId\"\xd4\x03\n" +
+	"\x18ListFeatureFlagsResponse\x12?\n" +
+	"\x05flags\x18\x01 \x03(\v2).acai.admin.ListFeatureFlagsResponse.FlagR\x05flags\x12K\n" +
+	"\toverrides\x18\x02 \x03(\v2-.acai.admin.ListFeatureFlagsResponse.OverrideR\toverrides\x1a?\n" +
+	"\x04Flag\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12#\n" +
+	"\rdefault_value\x18\x02 \x01(\tR\fdefaultValue\x1a\xe8\x01\n" +
+	"\bOverride\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12N\n" +
+	"\x05flags\x18\x02 \x03(\v28.acai.admin.ListFeatureFlagsResponse.Override.FlagsEntryR\x05flags\x129\n" +
+	"\n" +
+	"updated_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x1a8\n" +
+	"\n" +
+	"FlagsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xbd\x03\n" +
+	"\n" +
+	"AuditEvent\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12.\n" +
+	"\x04time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x04time\x12\x1b\n" +
+	"\ttenant_id\x18\x03 \x01(\tR\btenantId\x12\x17\n" +
+	"\auser_id\x18\x04 \x01(\tR\x06userId\x12\x16\n" +
+	"\x06action\x18\x05 \x01(\tR\x06action\x12'\n" +
+	"\x0fconversation_id\x18\x06 \x01(\tR\x0econversationId\x12\x16\n" +
+	"\x06models\x18\a \x03(\tR\x06models\x12\x14\n" +
+	"\x05tools\x18\b \x03(\tR\x05tools\x12\x16\n" +
+	"\x06status\x18\t \x01(\tR\x06status\x12\x1f\n" +
+	"\vduration_ms\x18\n" +
+	" \x01(\x03R\n" +
+	"durationMs\x12=\n" +
+	"\adetails\x18\v \x03(\v2#.acai.admin.AuditEvent.DetailsEntryR\adetails\x12\x16\n" +
+	"\x06tokens\x18\f \x01(\x03R\x06tokens\x1a:\n" +
+	"\fDetailsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xaf\x02\n" +
+	"\x16ListAuditEventsRequest\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\tR\btenantId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12'\n" +
+	"\x0fconversation_id\x18\x03 \x01(\tR\x0econversationId\x12\x16\n" +
+	"\x06action\x18\x04 \x01(\tR\x06action\x120\n" +
+	"\x05since\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\x05since\x120\n" +
+	"\x05until\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\x05until\x12\x1b\n" +
+	"\tpage_size\x18\a \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\b \x01(\tR\tpageToken\"q\n" +
+	"\x17ListAuditEventsResponse\x12.\n" +
+	"\x06events\x18\x01 \x03(\v2\x16.acai.admin.AuditEventR\x06events\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\xab\x05\n" +
+	"\vUsageReport\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x129\n" +
+	"\n" +
+	"week_start\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tweekStart\x125\n" +
+	"\bweek_end\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\aweekEnd\x129\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x123\n" +
+	"\x05total\x18\x05 \x01(\v2\x1d.acai.admin.UsageReport.UsageR\x05total\x122\n" +
+	"\x05users\x18\x06 \x03(\v2\x1c.acai.admin.UsageReport.UserR\x05users\x1a6\n" +
+	"\x06Intent\x12\x16\n" +
+	"\x06intent\x18\x01 \x01(\tR\x06intent\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x03R\x05count\x1a\xca\x01\n" +
+	"\x05Usage\x12\x14\n" +
+	"\x05calls\x18\x01 \x01(\x03R\x05calls\x123\n" +
+	"\x15conversations_started\x18\x02 \x01(\x03R\x14conversationsStarted\x12\x16\n" +
+	"\x06tokens\x18\x03 \x01(\x03R\x06tokens\x12\x1d\n" +
+	"\n" +
+	"tool_calls\x18\x04 \x01(\x03R\ttoolCalls\x12?\n" +
+	"\vtop_intents\x18\x05 \x03(\v2\x1e.acai.admin.UsageReport.IntentR\n" +
+	"topIntents\x1aq\n" +
+	"\x04User\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\tR\btenantId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x123\n" +
+	"\x05usage\x18\x03 \x01(\v2\x1d.acai.admin.UsageReport.UsageR\x05usage\"G\n" +
+	"\x15GetUsageReportRequest\x12.\n" +
+	"\x04week\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x04week\"I\n" +
+	"\x16GetUsageReportResponse\x12/\n" +
+	"\x06report\x18\x01 \x01(\v2\x17.acai.admin.UsageReportR\x06report\"\xff\x02\n" +
+	"\x0fCompletionTrace\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12.\n" +
+	"\x04time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x04time\x12\x1b\n" +
+	"\ttenant_id\x18\x03 \x01(\tR\btenantId\x12\x17\n" +
+	"\auser_id\x18\x04 \x01(\tR\x06userId\x12'\n" +
+	"\x0fconversation_id\x18\x05 \x01(\tR\x0econversationId\x12\x14\n" +
+	"\x05model\x18\x06 \x01(\tR\x05model\x12\x12\n" +
+	"\x04turn\x18\a \x01(\x05R\x04turn\x12\x18\n" +
+	"\arequest\x18\b \x01(\tR\arequest\x12\x1a\n" +
+	"\bresponse\x18\t \x01(\tR\bresponse\x12\x14\n" +
+	"\x05error\x18\n" +
+	" \x01(\tR\x05error\x12\x1f\n" +
+	"\vduration_ms\x18\v \x01(\x03R\n" +
+	"durationMs\x12\x1d\n" +
+	"\n" +
+	"tool_calls\x18\f \x01(\x05R\ttoolCalls\x12\x17\n" +
+	"\atool_ms\x18\r \x01(\x03R\x06toolMs\"y\n" +
+	"\x1bListCompletionTracesRequest\x12'\n" +
+	"\x0fconversation_id\x18\x01 \x01(\tR\x0econversationId\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x1b\n" +
+	"\ttenant_id\x18\x03 \x01(\tR\btenantId\"S\n" +
+	"\x1cListCompletionTracesResponse\x123\n" +
+	"\x06traces\x18\x01 \x03(\v2\x1b.acai.admin.CompletionTraceR\x06traces\"\xcc\x01\n" +
+	"\x19ReplayConversationRequest\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\tR\btenantId\x12'\n" +
+	"\x0fconversation_id\x18\x02 \x01(\tR\x0econversationId\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x03 \x01(\tR\tmessageId\x12\x1c\n" +
+	"\tassistant\x18\x04 \x01(\tR\tassistant\x12\x16\n" +
+	"\x06prompt\x18\x05 \x01(\tR\x06prompt\x12\x14\n" +
+	"\x05model\x18\x06 \x01(\tR\x05model\"\x8f\x03\n" +
+	"\x1aReplayConversationResponse\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x01 \x01(\tR\tmessageId\x12%\n" +
+	"\x0eoriginal_reply\x18\x02 \x01(\tR\roriginalReply\x12\x14\n" +
+	"\x05reply\x18\x03 \x01(\tR\x05reply\x12\x12\n" +
+	"\x04diff\x18\x04 \x01(\tR\x04diff\x12N\n" +
+	"\n" +
+	"tool_calls\x18\x05 \x03(\v2/.acai.admin.ReplayConversationResponse.ToolCallR\ttoolCalls\x12\x16\n" +
+	"\x06tokens\x18\x06 \x01(\x03R\x06tokens\x12\x1f\n" +
+	"\vduration_ms\x18\a \x01(\x03R\n" +
+	"durationMs\x12\x14\n" +
+	"\x05error\x18\b \x01(\tR\x05error\x1ab\n" +
+	"\bToolCall\x12\x12\n" +
+	"\x04tool\x18\x01 \x01(\tR\x04tool\x12\x12\n" +
+	"\x04call\x18\x02 \x01(\tR\x04call\x12\x16\n" +
+	"\x06result\x18\x03 \x01(\tR\x06result\x12\x16\n" +
+	"\x06failed\x18\x04 \x01(\bR\x06failed\"\xb6\x01\n" +
+	"\x15GetIntentStatsRequest\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\tR\btenantId\x120\n" +
+	"\x05since\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x05since\x120\n" +
+	"\x05until\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x05until\x12\x1c\n" +
+	"\tassistant\x18\x04 \x01(\tR\tassistant\"\xaf\x01\n" +
+	"\x16GetIntentStatsResponse\x12@\n" +
+	"\x06counts\x18\x01 \x03(\v2(.acai.admin.GetIntentStatsResponse.CountR\x06counts\x12\x1c\n" +
+	"\tunlabeled\x18\x02 \x01(\x03R\tunlabeled\x1a5\n" +
+	"\x05Count\x12\x16\n" +
+	"\x06intent\x18\x01 \x01(\tR\x06intent\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x03R\x05count2\xa4\x05\n" +
+	"\fAdminService\x12W\n" +
+	"\x0eSetFeatureFlag\x12!.acai.admin.SetFeatureFlagRequest\x1a\".acai.admin.SetFeatureFlagResponse\x12]\n" +
+	"\x10ListFeatureFlags\x12#.acai.admin.ListFeatureFlagsRequest\x1a$.acai.admin.ListFeatureFlagsResponse\x12Z\n" +
+	"\x0fListAuditEvents\x12\".acai.admin.ListAuditEventsRequest\x1a#.acai.admin.ListAuditEventsResponse\x12W\n" +
+	"\x0eGetUsageReport\x12!.acai.admin.GetUsageReportRequest\x1a\".acai.admin.GetUsageReportResponse\x12i\n" +
+	"\x14ListCompletionTraces\x12'.acai.admin.ListCompletionTracesRequest\x1a(.acai.admin.ListCompletionTracesResponse\x12c\n" +
+	"\x12ReplayConversation\x12%.acai.admin.ReplayConversationRequest\x1a&.acai.admin.ReplayConversationResponse\x12W\n" +
+	"\x0eGetIntentStats\x12!.acai.admin.GetIntentStatsRequest\x1a\".acai.admin.GetIntentStatsResponseB\rZ\vinternal/pbb\x06proto3"
+
+var (
+	file_rpc_admin_proto_rawDescOnce sync.Once
+	file_rpc_admin_proto_rawDescData []byte
+)
+
+func file_rpc_admin_proto_rawDescGZIP() []byte {
+	file_rpc_admin_proto_rawDescOnce.Do(func() {
+		file_rpc_admin_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_rpc_admin_proto_rawDesc), len(file_rpc_admin_proto_rawDesc)))
+	})
+	return file_rpc_admin_proto_rawDescData
+}
+
+var file_rpc_admin_proto_msgTypes = make([]protoimpl.MessageInfo, 26)
+var file_rpc_admin_proto_goTypes = []any{
+	(*SetFeatureFlagRequest)(nil),             // 0: acai.admin.SetFeatureFlagRequest
+	(*SetFeatureFlagResponse)(nil),            // 1: acai.admin.SetFeatureFlagResponse
+	(*ListFeatureFlagsRequest)(nil),           // 2: acai.admin.ListFeatureFlagsRequest
+	(*ListFeatureFlagsResponse)(nil),          // 3: acai.admin.ListFeatureFlagsResponse
+	(*AuditEvent)(nil),                        // 4: acai.admin.AuditEvent
+	(*ListAuditEventsRequest)(nil),            // 5: acai.admin.ListAuditEventsRequest
+	(*ListAuditEventsResponse)(nil),           // 6: acai.admin.ListAuditEventsResponse
+	(*UsageReport)(nil),                       // 7: acai.admin.UsageReport
+	(*GetUsageReportRequest)(nil),             // 8: acai.admin.GetUsageReportRequest
+	(*GetUsageReportResponse)(nil),            // 9: acai.admin.GetUsageReportResponse
+	(*CompletionTrace)(nil),                   // 10: acai.admin.CompletionTrace
+	(*ListCompletionTracesRequest)(nil),       // 11: acai.admin.ListCompletionTracesRequest
+	(*ListCompletionTracesResponse)(nil),      // 12: acai.admin.ListCompletionTracesResponse
+	(*ReplayConversationRequest)(nil),         // 13: acai.admin.ReplayConversationRequest
+	(*ReplayConversationResponse)(nil),        // 14: acai.admin.ReplayConversationResponse
+	(*GetIntentStatsRequest)(nil),             // 15: acai.admin.GetIntentStatsRequest
+	(*GetIntentStatsResponse)(nil),            // 16: acai.admin.GetIntentStatsResponse
+	(*ListFeatureFlagsResponse_Flag)(nil),     // 17: acai.admin.ListFeatureFlagsResponse.Flag
+	(*ListFeatureFlagsResponse_Override)(nil), // 18: acai.admin.ListFeatureFlagsResponse.Override
+	nil,                        // 19: acai.admin.ListFeatureFlagsResponse.Override.FlagsEntry
+	nil,                        // 20: acai.admin.AuditEvent.DetailsEntry
+	(*UsageReport_Intent)(nil), // 21: acai.admin.UsageReport.Intent
+	(*UsageReport_Usage)(nil),  // 22: acai.admin.UsageReport.Usage
+	(*UsageReport_User)(nil),   // 23: acai.admin.UsageReport.User
+	(*ReplayConversationResponse_ToolCall)(nil), // 24: acai.admin.ReplayConversationResponse.ToolCall
+	(*GetIntentStatsResponse_Count)(nil),        // 25: acai.admin.GetIntentStatsResponse.Count
+	(*timestamppb.Timestamp)(nil),               // 26: google.protobuf.Timestamp
+}
+var file_rpc_admin_proto_depIdxs = []int32{
+	17, // 0: acai.admin.ListFeatureFlagsResponse.flags:type_name -> acai.admin.ListFeatureFlagsResponse.Flag
+	18, // 1: acai.admin.ListFeatureFlagsResponse.overrides:type_name -> acai.admin.ListFeatureFlagsResponse.Override
+	26, // 2: acai.admin.AuditEvent.time:type_name -> google.protobuf.Timestamp
+	20, // 3: acai.admin.AuditEvent.details:type_name -> acai.admin.AuditEvent.DetailsEntry
+	26, // 4: acai.admin.ListAuditEventsRequest.since:type_name -> google.protobuf.Timestamp
+	26, // 5: acai.admin.ListAuditEventsRequest.until:type_name -> google.protobuf.Timestamp
+	4,  // 6: acai.admin.ListAuditEventsResponse.events:type_name -> acai.admin.AuditEvent
+	26, // 7: acai.admin.UsageReport.week_start:type_name -> google.protobuf.Timestamp
+	26, // 8: acai.admin.UsageReport.week_end:type_name -> google.protobuf.Timestamp
+	26, // 9: acai.admin.UsageReport.created_at:type_name -> google.protobuf.Timestamp
+	22, // 10: acai.admin.UsageReport.total:type_name -> acai.admin.UsageReport.Usage
+	23, // 11: acai.admin.UsageReport.users:type_name -> acai.admin.UsageReport.User
+	26, // 12: acai.admin.GetUsageReportRequest.week:type_name -> google.protobuf.Timestamp
+	7,  // 13: acai.admin.GetUsageReportResponse.report:type_name -> acai.admin.UsageReport
+	26, // 14: acai.admin.CompletionTrace.time:type_name -> google.protobuf.Timestamp
+	10, // 15: acai.admin.ListCompletionTracesResponse.traces:type_name -> acai.admin.CompletionTrace
+	24, // 16: acai.admin.ReplayConversationResponse.tool_calls:type_name -> acai.admin.ReplayConversationResponse.ToolCall
+	26, // 17: acai.admin.GetIntentStatsRequest.since:type_name -> google.protobuf.Timestamp
+	26, // 18: acai.admin.GetIntentStatsRequest.until:type_name -> google.protobuf.Timestamp
+	25, // 19: acai.admin.GetIntentStatsResponse.counts:type_name -> acai.admin.GetIntentStatsResponse.Count
+	19, // 20: acai.admin.ListFeatureFlagsResponse.Override.flags:type_name -> acai.admin.ListFeatureFlagsResponse.Override.FlagsEntry
+	26, // 21: acai.admin.ListFeatureFlagsResponse.Override.updated_at:type_name -> google.protobuf.Timestamp
+	21, // 22: acai.admin.UsageReport.Usage.top_intents:type_name -> acai.admin.UsageReport.Intent
+	22, // 23: acai.admin.UsageReport.User.usage:type_name -> acai.admin.UsageReport.Usage
+	0,  // 24: acai.admin.AdminService.SetFeatureFlag:input_type -> acai.admin.SetFeatureFlagRequest
+	2,  // 25: acai.admin.AdminService.ListFeatureFlags:input_type -> acai.admin.ListFeatureFlagsRequest
+	5,  // 26: acai.admin.AdminService.ListAuditEvents:input_type -> acai.admin.ListAuditEventsRequest
+	8,  // 27: acai.admin.AdminService.GetUsageReport:input_type -> acai.admin.GetUsageReportRequest
+	11, // 28: acai.admin.AdminService.ListCompletionTraces:input_type -> acai.admin.ListCompletionTracesRequest
+	13, // 29: acai.admin.AdminService.ReplayConversation:input_type -> acai.admin.ReplayConversationRequest
+	15, // 30: acai.admin.AdminService.GetIntentStats:input_type -> acai.admin.GetIntentStatsRequest
+	1,  // 31: acai.admin.AdminService.SetFeatureFlag:output_type -> acai.admin.SetFeatureFlagResponse
+	3,  // 32: acai.admin.AdminService.ListFeatureFlags:output_type -> acai.admin.ListFeatureFlagsResponse
+	6,  // 33: acai.admin.AdminService.ListAuditEvents:output_type -> acai.admin.ListAuditEventsResponse
+	9,  // 34: acai.admin.AdminService.GetUsageReport:output_type -> acai.admin.GetUsageReportResponse
+	12, // 35: acai.admin.AdminService.ListCompletionTraces:output_type -> acai.admin.ListCompletionTracesResponse
+	14, // 36: acai.admin.AdminService.ReplayConversation:output_type -> acai.admin.ReplayConversationResponse
+	16, // 37: acai.admin.AdminService.GetIntentStats:output_type -> acai.admin.GetIntentStatsResponse
+	31, // [31:38] is the sub-list for method output_type
+	24, // [24:31] is the sub-list for method input_type
+	24, // [24:24] is the sub-list for extension type_name
+	24, // [24:24] is the sub-list for extension extendee
+	0,  // [0:24] is the sub-list for field type_name
+}
+
+func init() { file_rpc_admin_proto_init() }
+func file_rpc_admin_proto_init() {
+	if File_rpc_admin_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_rpc_admin_proto_rawDesc), len(file_rpc_admin_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   26,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_rpc_admin_proto_goTypes,
+		DependencyIndexes: file_rpc_admin_proto_depIdxs,
+		MessageInfos:      file_rpc_admin_proto_msgTypes,
+	}.Build()
+	File_rpc_admin_proto = out.File
+	file_rpc_admin_proto_goTypes = nil
+	file_rpc_admin_proto_depIdxs = nil
+}
@@ -0,0 +1,2868 @@
+// Code generated by protoc-gen-twirp v8.1.3, DO NOT EDIT.
+// source: rpc/admin.proto
+
+package pb
+
+import context "context"
+import fmt "fmt"
+import http "net/http"
+import io "io"
+import json "encoding/json"
+import strconv "strconv"
+import strings "strings"
+
+import protojson "google.golang.org/protobuf/encoding/protojson"
+import proto "google.golang.org/protobuf/proto"
+import twirp "github.com/twitchtv/twirp"
+import ctxsetters "github.com/twitchtv/twirp/ctxsetters"
+
+import bytes "bytes"
+import errors "errors"
+import path "path"
+import url "net/url"
+
+// Version compatibility assertion.
+// If the constant is not defined in the package, that likely means
+// the package needs to be updated to work with this generated code.
+// See https://twitchtv.github.io/twirp/docs/version_matrix.html
+const _ = twirp.TwirpPackageMinVersion_8_1_0
+
+// ======================
+// AdminService Interface
+// ======================
+
+// Operator RPCs, served on the internal admin port only
+type AdminService interface {
+	// Override a feature flag for a tenant, or for one of its users
+	SetFeatureFlag(context.Context, *SetFeatureFlagRequest) (*SetFeatureFlagResponse, error)
+
+	// List the feature flags of a tenant and their overrides
+	ListFeatureFlags(context.Context, *ListFeatureFlagsRequest) (*ListFeatureFlagsResponse, error)
+
+	// List audit events, newest first; /audit/export on the admin port exports them in bulk
+	ListAuditEvents(context.Context, *ListAuditEventsRequest) (*ListAuditEventsResponse, error)
+
+	// Get the weekly usage report built from the audit log once a week is over
+	GetUsageReport(context.Context, *GetUsageReportRequest) (*GetUsageReportResponse, error)
+
+	// List the completion requests and raw model responses captured for a conversation, for
+	// tenants and users with the debug_capture flag; requires LLM_DEBUG_STORE=true
+	ListCompletionTraces(context.Context, *ListCompletionTracesRequest) (*ListCompletionTracesResponse, error)
+
+	// Answer a message of a stored conversation again, optionally with another assistant,
+	// prompt or model, and compare the reply with the original; nothing is stored
+	ReplayConversation(context.Context, *ReplayConversationRequest) (*ReplayConversationResponse, error)
+
+	// Count the user messages of a tenant by intent, e.g. to decide which tools to build next
+	GetIntentStats(context.Context, *GetIntentStatsRequest) (*GetIntentStatsResponse, error)
+}
+
+// ============================
+// AdminService Protobuf Client
+// ============================
+
+type adminServiceProtobufClient struct {
+	client      HTTPClient
+	urls        [7]string
+	interceptor twirp.Interceptor
+	opts        twirp.ClientOptions
+}
+
+// NewAdminServiceProtobufClient creates a Protobuf client that implements the AdminService interface.
+// It communicates using Protobuf and can be configured with a custom HTTPClient.
+func NewAdminServiceProtobufClient(baseURL string, client HTTPClient, opts ...twirp.ClientOption) AdminService {
+	if c, ok := client.(*http.Client); ok {
+		client = withoutRedirects(c)
+	}
+
+	clientOpts := twirp.ClientOptions{}
+	for _, o := range opts {
+		o(&clientOpts)
+	}
+
+	// Using ReadOpt allows backwards and forwards compatibility with new options in the future
+	literalURLs := false
+	_ = clientOpts.ReadOpt("literalURLs", &literalURLs)
+	var pathPrefix string
+	if ok := clientOpts.ReadOpt("pathPrefix", &pathPrefix); !ok {
+		pathPrefix = "/twirp" // default prefix
+	}
+
+	// Build method URLs: <baseURL>[<prefix>]/<package>.<Service>/<Method>
+	serviceURL := sanitizeBaseURL(baseURL)
+	serviceURL += baseServicePath(pathPrefix, "acai.admin", "AdminService")
+	urls := [7]string{
+		serviceURL + "SetFeatureFlag",
+		serviceURL + "ListFeatureFlags",
+		serviceURL + "ListAuditEvents",
+		serviceURL + "GetUsageReport",
+		serviceURL + "ListCompletionTraces",
+		serviceURL + "ReplayConversation",
+		serviceURL + "GetIntentStats",
+	}
+
+	return &adminServiceProtobufClient{
+		client:      client,
+		urls:        urls,
+		interceptor: twirp.ChainInterceptors(clientOpts.Interceptors...),
+		opts:        clientOpts,
+	}
+}
+
+func (c *adminServiceProtobufClient) SetFeatureFlag(ctx context.Context, in *SetFeatureFlagRequest) (*SetFeatureFlagResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.admin")
+	ctx = ctxsetters.WithServiceName(ctx, "AdminService")
+	ctx = ctxsetters.WithMethodName(ctx, "SetFeatureFlag")
+	caller := c.callSetFeatureFlag
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *SetFeatureFlagRequest) (*SetFeatureFlagResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*SetFeatureFlagRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*SetFeatureFlagRequest) when calling interceptor")
+					}
+					return c.callSetFeatureFlag(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*SetFeatureFlagResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*SetFeatureFlagResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *adminServiceProtobufClient) callSetFeatureFlag(ctx context.Context, in *SetFeatureFlagRequest) (*SetFeatureFlagResponse, error) {
+	out := new(SetFeatureFlagResponse)
+	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[0], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *adminServiceProtobufClient) ListFeatureFlags(ctx context.Context, in *ListFeatureFlagsRequest) (*ListFeatureFlagsResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.admin")
+	ctx = ctxsetters.WithServiceName(ctx, "AdminService")
+	ctx = ctxsetters.WithMethodName(ctx, "ListFeatureFlags")
+	caller := c.callListFeatureFlags
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *ListFeatureFlagsRequest) (*ListFeatureFlagsResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ListFeatureFlagsRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ListFeatureFlagsRequest) when calling interceptor")
+					}
+					return c.callListFeatureFlags(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ListFeatureFlagsResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ListFeatureFlagsResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *adminServiceProtobufClient) callListFeatureFlags(ctx context.Context, in *ListFeatureFlagsRequest) (*ListFeatureFlagsResponse, error) {
+	out := new(ListFeatureFlagsResponse)
+	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[1], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *adminServiceProtobufClient) ListAuditEvents(ctx context.Context, in *ListAuditEventsRequest) (*ListAuditEventsResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.admin")
+	ctx = ctxsetters.WithServiceName(ctx, "AdminService")
+	ctx = ctxsetters.WithMethodName(ctx, "ListAuditEvents")
+	caller := c.callListAuditEvents
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *ListAuditEventsRequest) (*ListAuditEventsResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ListAuditEventsRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ListAuditEventsRequest) when calling interceptor")
+					}
+					return c.callListAuditEvents(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ListAuditEventsResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ListAuditEventsResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *adminServiceProtobufClient) callListAuditEvents(ctx context.Context, in *ListAuditEventsRequest) (*ListAuditEventsResponse, error) {
+	out := new(ListAuditEventsResponse)
+	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[2], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *adminServiceProtobufClient) GetUsageReport(ctx context.Context, in *GetUsageReportRequest) (*GetUsageReportResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.admin")
+	ctx = ctxsetters.WithServiceName(ctx, "AdminService")
+	ctx = ctxsetters.WithMethodName(ctx, "GetUsageReport")
+	caller := c.callGetUsageReport
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *GetUsageReportRequest) (*GetUsageReportResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*GetUsageReportRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*GetUsageReportRequest) when calling interceptor")
+					}
+					return c.callGetUsageReport(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*GetUsageReportResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*GetUsageReportResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *adminServiceProtobufClient) callGetUsageReport(ctx context.Context, in *GetUsageReportRequest) (*GetUsageReportResponse, error) {
+	out := new(GetUsageReportResponse)
+	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[3], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *adminServiceProtobufClient) ListCompletionTraces(ctx context.Context, in *ListCompletionTracesRequest) (*ListCompletionTracesResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.admin")
+	ctx = ctxsetters.WithServiceName(ctx, "AdminService")
+	ctx = ctxsetters.WithMethodName(ctx, "ListCompletionTraces")
+	caller := c.callListCompletionTraces
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *ListCompletionTracesRequest) (*ListCompletionTracesResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ListCompletionTracesRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ListCompletionTracesRequest) when calling interceptor")
+					}
+					return c.callListCompletionTraces(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ListCompletionTracesResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ListCompletionTracesResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *adminServiceProtobufClient) callListCompletionTraces(ctx context.Context, in *ListCompletionTracesRequest) (*ListCompletionTracesResponse, error) {
+	out := new(ListCompletionTracesResponse)
+	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[4], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *adminServiceProtobufClient) ReplayConversation(ctx context.Context, in *ReplayConversationRequest) (*ReplayConversationResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.admin")
+	ctx = ctxsetters.WithServiceName(ctx, "AdminService")
+	ctx = ctxsetters.WithMethodName(ctx, "ReplayConversation")
+	caller := c.callReplayConversation
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *ReplayConversationRequest) (*ReplayConversationResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ReplayConversationRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ReplayConversationRequest) when calling interceptor")
+					}
+					return c.callReplayConversation(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ReplayConversationResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ReplayConversationResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *adminServiceProtobufClient) callReplayConversation(ctx context.Context, in *ReplayConversationRequest) (*ReplayConversationResponse, error) {
+	out := new(ReplayConversationResponse)
+	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[5], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *adminServiceProtobufClient) GetIntentStats(ctx context.Context, in *GetIntentStatsRequest) (*GetIntentStatsResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.admin")
+	ctx = ctxsetters.WithServiceName(ctx, "AdminService")
+	ctx = ctxsetters.WithMethodName(ctx, "GetIntentStats")
+	caller := c.callGetIntentStats
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *GetIntentStatsRequest) (*GetIntentStatsResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*GetIntentStatsRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*GetIntentStatsRequest) when calling interceptor")
+					}
+					return c.callGetIntentStats(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*GetIntentStatsResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*GetIntentStatsResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *adminServiceProtobufClient) callGetIntentStats(ctx context.Context, in *GetIntentStatsRequest) (*GetIntentStatsResponse, error) {
+	out := new(GetIntentStatsResponse)
+	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[6], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+// ========================
+// AdminService JSON Client
+// ========================
+
+type adminServiceJSONClient struct {
+	client      HTTPClient
+	urls        [7]string
+	interceptor twirp.Interceptor
+	opts        twirp.ClientOptions
+}
+
+// NewAdminServiceJSONClient creates a JSON client that implements the AdminService interface.
+// It communicates using JSON and can be configured with a custom HTTPClient.
+func NewAdminServiceJSONClient(baseURL string, client HTTPClient, opts ...twirp.ClientOption) AdminService {
+	if c, ok := client.(*http.Client); ok {
+		client = withoutRedirects(c)
+	}
+
+	clientOpts := twirp.ClientOptions{}
+	for _, o := range opts {
+		o(&clientOpts)
+	}
+
+	// Using ReadOpt allows backwards and forwards compatibility with new options in the future
+	literalURLs := false
+	_ = clientOpts.ReadOpt("literalURLs", &literalURLs)
+	var pathPrefix string
+	if ok := clientOpts.ReadOpt("pathPrefix", &pathPrefix); !ok {
+		pathPrefix = "/twirp" // default prefix
+	}
+
+	// Build method URLs: <baseURL>[<prefix>]/<package>.<Service>/<Method>
+	serviceURL := sanitizeBaseURL(baseURL)
+	serviceURL += baseServicePath(pathPrefix, "acai.admin", "AdminService")
+	urls := [7]string{
+		serviceURL + "SetFeatureFlag",
+		serviceURL + "ListFeatureFlags",
+		serviceURL + "ListAuditEvents",
+		serviceURL + "GetUsageReport",
+		serviceURL + "ListCompletionTraces",
+		serviceURL + "ReplayConversation",
+		serviceURL + "GetIntentStats",
+	}
+
+	return &adminServiceJSONClient{
+		client:      client,
+		urls:        urls,
+		interceptor: twirp.ChainInterceptors(clientOpts.Interceptors...),
+		opts:        clientOpts,
+	}
+}
+
+func (c *adminServiceJSONClient) SetFeatureFlag(ctx context.Context, in *SetFeatureFlagRequest) (*SetFeatureFlagResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.admin")
+	ctx = ctxsetters.WithServiceName(ctx, "AdminService")
+	ctx = ctxsetters.WithMethodName(ctx, "SetFeatureFlag")
+	caller := c.callSetFeatureFlag
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *SetFeatureFlagRequest) (*SetFeatureFlagResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*SetFeatureFlagRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*SetFeatureFlagRequest) when calling interceptor")
+					}
+					return c.callSetFeatureFlag(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*SetFeatureFlagResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*SetFeatureFlagResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *adminServiceJSONClient) callSetFeatureFlag(ctx context.Context, in *SetFeatureFlagRequest) (*SetFeatureFlagResponse, error) {
+	out := new(SetFeatureFlagResponse)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[0], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *adminServiceJSONClient) ListFeatureFlags(ctx context.Context, in *ListFeatureFlagsRequest) (*ListFeatureFlagsResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.admin")
+	ctx = ctxsetters.WithServiceName(ctx, "AdminService")
+	ctx = ctxsetters.WithMethodName(ctx, "ListFeatureFlags")
+	caller := c.callListFeatureFlags
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *ListFeatureFlagsRequest) (*ListFeatureFlagsResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ListFeatureFlagsRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ListFeatureFlagsRequest) when calling interceptor")
+					}
+					return c.callListFeatureFlags(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ListFeatureFlagsResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ListFeatureFlagsResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *adminServiceJSONClient) callListFeatureFlags(ctx context.Context, in *ListFeatureFlagsRequest) (*ListFeatureFlagsResponse, error) {
+	out := new(ListFeatureFlagsResponse)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[1], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *adminServiceJSONClient) ListAuditEvents(ctx context.Context, in *ListAuditEventsRequest) (*ListAuditEventsResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.admin")
+	ctx = ctxsetters.WithServiceName(ctx, "AdminService")
+	ctx = ctxsetters.WithMethodName(ctx, "ListAuditEvents")
+	caller := c.callListAuditEvents
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *ListAuditEventsRequest) (*ListAuditEventsResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ListAuditEventsRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ListAuditEventsRequest) when calling interceptor")
+					}
+					return c.callListAuditEvents(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ListAuditEventsResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ListAuditEventsResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *adminServiceJSONClient) callListAuditEvents(ctx context.Context, in *ListAuditEventsRequest) (*ListAuditEventsResponse, error) {
+	out := new(ListAuditEventsResponse)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[2], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *adminServiceJSONClient) GetUsageReport(ctx context.Context, in *GetUsageReportRequest) (*GetUsageReportResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.admin")
+	ctx = ctxsetters.WithServiceName(ctx, "AdminService")
+	ctx = ctxsetters.WithMethodName(ctx, "GetUsageReport")
+	caller := c.callGetUsageReport
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *GetUsageReportRequest) (*GetUsageReportResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*GetUsageReportRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*GetUsageReportRequest) when calling interceptor")
+					}
+					return c.callGetUsageReport(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*GetUsageReportResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*GetUsageReportResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *adminServiceJSONClient) callGetUsageReport(ctx context.Context, in *GetUsageReportRequest) (*GetUsageReportResponse, error) {
+	out := new(GetUsageReportResponse)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[3], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *adminServiceJSONClient) ListCompletionTraces(ctx context.Context, in *ListCompletionTracesRequest) (*ListCompletionTracesResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.admin")
+	ctx = ctxsetters.WithServiceName(ctx, "AdminService")
+	ctx = ctxsetters.WithMethodName(ctx, "ListCompletionTraces")
+	caller := c.callListCompletionTraces
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *ListCompletionTracesRequest) (*ListCompletionTracesResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ListCompletionTracesRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ListCompletionTracesRequest) when calling interceptor")
+					}
+					return c.callListCompletionTraces(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ListCompletionTracesResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ListCompletionTracesResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *adminServiceJSONClient) callListCompletionTraces(ctx context.Context, in *ListCompletionTracesRequest) (*ListCompletionTracesResponse, error) {
+	out := new(ListCompletionTracesResponse)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[4], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *adminServiceJSONClient) ReplayConversation(ctx context.Context, in *ReplayConversationRequest) (*ReplayConversationResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.admin")
+	ctx = ctxsetters.WithServiceName(ctx, "AdminService")
+	ctx = ctxsetters.WithMethodName(ctx, "ReplayConversation")
+	caller := c.callReplayConversation
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *ReplayConversationRequest) (*ReplayConversationResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ReplayConversationRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ReplayConversationRequest) when calling interceptor")
+					}
+					return c.callReplayConversation(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ReplayConversationResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ReplayConversationResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *adminServiceJSONClient) callReplayConversation(ctx context.Context, in *ReplayConversationRequest) (*ReplayConversationResponse, error) {
+	out := new(ReplayConversationResponse)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[5], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *adminServiceJSONClient) GetIntentStats(ctx context.Context, in *GetIntentStatsRequest) (*GetIntentStatsResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.admin")
+	ctx = ctxsetters.WithServiceName(ctx, "AdminService")
+	ctx = ctxsetters.WithMethodName(ctx, "GetIntentStats")
+	caller := c.callGetIntentStats
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *GetIntentStatsRequest) (*GetIntentStatsResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*GetIntentStatsRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*GetIntentStatsRequest) when calling interceptor")
+					}
+					return c.callGetIntentStats(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*GetIntentStatsResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*GetIntentStatsResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *adminServiceJSONClient) callGetIntentStats(ctx context.Context, in *GetIntentStatsRequest) (*GetIntentStatsResponse, error) {
+	out := new(GetIntentStatsResponse)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[6], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+// ===========================
+// AdminService Server Handler
+// ===========================
+
+type adminServiceServer struct {
+	AdminService
+	interceptor      twirp.Interceptor
+	hooks            *twirp.ServerHooks
+	pathPrefix       string // prefix for routing
+	jsonSkipDefaults bool   // do not include unpopulated fields (default values) in the response
+	jsonCamelCase    bool   // JSON fields are serialized as lowerCamelCase rather than keeping the original proto names
+}
+
+// NewAdminServiceServer builds a TwirpServer that can be used as an http.Handler to handle
+// HTTP requests that are routed to the right method in the provided svc implementation.
+// The opts are twirp.ServerOption modifiers, for example twirp.WithServerHooks(hooks).
+func NewAdminServiceServer(svc AdminService, opts ...interface{}) TwirpServer {
+	serverOpts := newServerOpts(opts)
+
+	// Using ReadOpt allows backwards and forwards compatibility with new options in the future
+	jsonSkipDefaults := false
+	_ = serverOpts.ReadOpt("jsonSkipDefaults", &jsonSkipDefaults)
+	jsonCamelCase := false
+	_ = serverOpts.ReadOpt("jsonCamelCase", &jsonCamelCase)
+	var pathPrefix string
+	if ok := serverOpts.ReadOpt("pathPrefix", &pathPrefix); !ok {
+		pathPrefix = "/twirp" // default prefix
+	}
+
+	return &adminServiceServer{
+		AdminService:     svc,
+		hooks:            serverOpts.Hooks,
+		interceptor:      twirp.ChainInterceptors(serverOpts.Interceptors...),
+		pathPrefix:       pathPrefix,
+		jsonSkipDefaults: jsonSkipDefaults,
+		jsonCamelCase:    jsonCamelCase,
+	}
+}
+
+// writeError writes an HTTP response with a valid Twirp error format, and triggers hooks.
+// If err is not a twirp.Error, it will get wrapped with twirp.InternalErrorWith(err)
+func (s *adminServiceServer) writeError(ctx context.Context, resp http.ResponseWriter, err error) {
+	writeError(ctx, resp, err, s.hooks)
+}
+
+// handleRequestBodyError is used to handle error when the twirp server cannot read request
+func (s *adminServiceServer) handleRequestBodyError(ctx context.Context, resp http.ResponseWriter, msg string, err error) {
+	if context.Canceled == ctx.Err() {
+		s.writeError(ctx, resp, twirp.NewError(twirp.Canceled, "failed to read request: context canceled"))
+		return
+	}
+	if context.DeadlineExceeded == ctx.Err() {
+		s.writeError(ctx, resp, twirp.NewError(twirp.DeadlineExceeded, "failed to read request: deadline exceeded"))
+		return
+	}
+	s.writeError(ctx, resp, twirp.WrapError(malformedRequestError(msg), err))
+}
+
+// AdminServicePathPrefix is a convenience constant that may identify URL paths.
+// Should be used with caution, it only matches routes generated by Twirp Go clients,
+// with the default "/twirp" prefix and default CamelCase service and method names.
+// More info: https://twitchtv.github.io/twirp/docs/routing.html
+const AdminServicePathPrefix = "/twirp/acai.admin.AdminService/"
+
+func (s *adminServiceServer) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	ctx = ctxsetters.WithPackageName(ctx, "acai.admin")
+	ctx = ctxsetters.WithServiceName(ctx, "AdminService")
+	ctx = ctxsetters.WithResponseWriter(ctx, resp)
+
+	var err error
+	ctx, err = callRequestReceived(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	if req.Method != "POST" {
+		msg := fmt.Sprintf("unsupported method %q (only POST is allowed)", req.Method)
+		s.writeError(ctx, resp, badRouteError(msg, req.Method, req.URL.Path))
+		return
+	}
+
+	// Verify path format: [<prefix>]/<package>.<Service>/<Method>
+	prefix, pkgService, method := parseTwirpPath(req.URL.Path)
+	if pkgService != "acai.admin.AdminService" {
+		msg := fmt.Sprintf("no handler for path %q", req.URL.Path)
+		s.writeError(ctx, resp, badRouteError(msg, req.Method, req.URL.Path))
+		return
+	}
+	if prefix != s.pathPrefix {
+		msg := fmt.Sprintf("invalid path prefix %q, expected %q, on path %q", prefix, s.pathPrefix, req.URL.Path)
+		s.writeError(ctx, resp, badRouteError(msg, req.Method, req.URL.Path))
+		return
+	}
+
+	switch method {
+	case "SetFeatureFlag":
+		s.serveSetFeatureFlag(ctx, resp, req)
+		return
+	case "ListFeatureFlags":
+		s.serveListFeatureFlags(ctx, resp, req)
+		return
+	case "ListAuditEvents":
+		s.serveListAuditEvents(ctx, resp, req)
+		return
+	case "GetUsageReport":
+		s.serveGetUsageReport(ctx, resp, req)
+		return
+	case "ListCompletionTraces":
+		s.serveListCompletionTraces(ctx, resp, req)
+		return
+	case "ReplayConversation":
+		s.serveReplayConversation(ctx, resp, req)
+		return
+	case "GetIntentStats":
+		s.serveGetIntentStats(ctx, resp, req)
+		return
+	default:
+		msg := fmt.Sprintf("no handler for path %q", req.URL.Path)
+		s.writeError(ctx, resp, badRouteError(msg, req.Method, req.URL.Path))
+		return
+	}
+}
+
+func (s *adminServiceServer) serveSetFeatureFlag(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveSetFeatureFlagJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveSetFeatureFlagProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *adminServiceServer) serveSetFeatureFlagJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "SetFeatureFlag")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	d := json.NewDecoder(req.Body)
+	rawReqBody := json.RawMessage{}
+	if err := d.Decode(&rawReqBody); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+	reqContent := new(SetFeatureFlagRequest)
+	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
+	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+
+	handler := s.AdminService.SetFeatureFlag
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *SetFeatureFlagRequest) (*SetFeatureFlagResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*SetFeatureFlagRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*SetFeatureFlagRequest) when calling interceptor")
+					}
+					return s.AdminService.SetFeatureFlag(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*SetFeatureFlagResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*SetFeatureFlagResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *SetFeatureFlagResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *SetFeatureFlagResponse and nil error while calling SetFeatureFlag. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	marshaler := &protojson.MarshalOptions{UseProtoNames: !s.jsonCamelCase, EmitUnpopulated: !s.jsonSkipDefaults}
+	respBytes, err := marshaler.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *adminServiceServer) serveSetFeatureFlagProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "SetFeatureFlag")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := io.ReadAll(req.Body)
+	if err != nil {
+		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
+		return
+	}
+	reqContent := new(SetFeatureFlagRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	handler := s.AdminService.SetFeatureFlag
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *SetFeatureFlagRequest) (*SetFeatureFlagResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*SetFeatureFlagRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*SetFeatureFlagRequest) when calling interceptor")
+					}
+					return s.AdminService.SetFeatureFlag(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*SetFeatureFlagResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*SetFeatureFlagResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *SetFeatureFlagResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *SetFeatureFlagResponse and nil error while calling SetFeatureFlag. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *adminServiceServer) serveListFeatureFlags(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveListFeatureFlagsJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveListFeatureFlagsProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *adminServiceServer) serveListFeatureFlagsJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "ListFeatureFlags")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	d := json.NewDecoder(req.Body)
+	rawReqBody := json.RawMessage{}
+	if err := d.Decode(&rawReqBody); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+	reqContent := new(ListFeatureFlagsRequest)
+	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
+	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+
+	handler := s.AdminService.ListFeatureFlags
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *ListFeatureFlagsRequest) (*ListFeatureFlagsResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ListFeatureFlagsRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ListFeatureFlagsRequest) when calling interceptor")
+					}
+					return s.AdminService.ListFeatureFlags(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ListFeatureFlagsResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ListFeatureFlagsResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *ListFeatureFlagsResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *ListFeatureFlagsResponse and nil error while calling ListFeatureFlags. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	marshaler := &protojson.MarshalOptions{UseProtoNames: !s.jsonCamelCase, EmitUnpopulated: !s.jsonSkipDefaults}
+	respBytes, err := marshaler.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *adminServiceServer) serveListFeatureFlagsProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "ListFeatureFlags")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := io.ReadAll(req.Body)
+	if err != nil {
+		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
+		return
+	}
+	reqContent := new(ListFeatureFlagsRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	handler := s.AdminService.ListFeatureFlags
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *ListFeatureFlagsRequest) (*ListFeatureFlagsResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ListFeatureFlagsRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ListFeatureFlagsRequest) when calling interceptor")
+					}
+					return s.AdminService.ListFeatureFlags(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ListFeatureFlagsResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ListFeatureFlagsResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *ListFeatureFlagsResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *ListFeatureFlagsResponse and nil error while calling ListFeatureFlags. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *adminServiceServer) serveListAuditEvents(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveListAuditEventsJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveListAuditEventsProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *adminServiceServer) serveListAuditEventsJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "ListAuditEvents")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	d := json.NewDecoder(req.Body)
+	rawReqBody := json.RawMessage{}
+	if err := d.Decode(&rawReqBody); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+	reqContent := new(ListAuditEventsRequest)
+	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
+	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+
+	handler := s.AdminService.ListAuditEvents
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *ListAuditEventsRequest) (*ListAuditEventsResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ListAuditEventsRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ListAuditEventsRequest) when calling interceptor")
+					}
+					return s.AdminService.ListAuditEvents(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ListAuditEventsResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ListAuditEventsResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *ListAuditEventsResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *ListAuditEventsResponse and nil error while calling ListAuditEvents. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	marshaler := &protojson.MarshalOptions{UseProtoNames: !s.jsonCamelCase, EmitUnpopulated: !s.jsonSkipDefaults}
+	respBytes, err := marshaler.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *adminServiceServer) serveListAuditEventsProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "ListAuditEvents")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := io.ReadAll(req.Body)
+	if err != nil {
+		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
+		return
+	}
+	reqContent := new(ListAuditEventsRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	handler := s.AdminService.ListAuditEvents
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *ListAuditEventsRequest) (*ListAuditEventsResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ListAuditEventsRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ListAuditEventsRequest) when calling interceptor")
+					}
+					return s.AdminService.ListAuditEvents(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ListAuditEventsResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ListAuditEventsResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *ListAuditEventsResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *ListAuditEventsResponse and nil error while calling ListAuditEvents. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *adminServiceServer) serveGetUsageReport(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveGetUsageReportJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveGetUsageReportProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *adminServiceServer) serveGetUsageReportJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "GetUsageReport")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	d := json.NewDecoder(req.Body)
+	rawReqBody := json.RawMessage{}
+	if err := d.Decode(&rawReqBody); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+	reqContent := new(GetUsageReportRequest)
+	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
+	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+
+	handler := s.AdminService.GetUsageReport
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *GetUsageReportRequest) (*GetUsageReportResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*GetUsageReportRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*GetUsageReportRequest) when calling interceptor")
+					}
+					return s.AdminService.GetUsageReport(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*GetUsageReportResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*GetUsageReportResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *GetUsageReportResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *GetUsageReportResponse and nil error while calling GetUsageReport. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	marshaler := &protojson.MarshalOptions{UseProtoNames: !s.jsonCamelCase, EmitUnpopulated: !s.jsonSkipDefaults}
+	respBytes, err := marshaler.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *adminServiceServer) serveGetUsageReportProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "GetUsageReport")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := io.ReadAll(req.Body)
+	if err != nil {
+		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
+		return
+	}
+	reqContent := new(GetUsageReportRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	handler := s.AdminService.GetUsageReport
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *GetUsageReportRequest) (*GetUsageReportResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*GetUsageReportRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*GetUsageReportRequest) when calling interceptor")
+					}
+					return s.AdminService.GetUsageReport(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*GetUsageReportResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*GetUsageReportResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *GetUsageReportResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *GetUsageReportResponse and nil error while calling GetUsageReport. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *adminServiceServer) serveListCompletionTraces(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveListCompletionTracesJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveListCompletionTracesProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *adminServiceServer) serveListCompletionTracesJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "ListCompletionTraces")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	d := json.NewDecoder(req.Body)
+	rawReqBody := json.RawMessage{}
+	if err := d.Decode(&rawReqBody); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+	reqContent := new(ListCompletionTracesRequest)
+	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
+	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+
+	handler := s.AdminService.ListCompletionTraces
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *ListCompletionTracesRequest) (*ListCompletionTracesResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ListCompletionTracesRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ListCompletionTracesRequest) when calling interceptor")
+					}
+					return s.AdminService.ListCompletionTraces(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ListCompletionTracesResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ListCompletionTracesResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *ListCompletionTracesResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *ListCompletionTracesResponse and nil error while calling ListCompletionTraces. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	marshaler := &protojson.MarshalOptions{UseProtoNames: !s.jsonCamelCase, EmitUnpopulated: !s.jsonSkipDefaults}
+	respBytes, err := marshaler.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *adminServiceServer) serveListCompletionTracesProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "ListCompletionTraces")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := io.ReadAll(req.Body)
+	if err != nil {
+		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
+		return
+	}
+	reqContent := new(ListCompletionTracesRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	handler := s.AdminService.ListCompletionTraces
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *ListCompletionTracesRequest) (*ListCompletionTracesResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ListCompletionTracesRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ListCompletionTracesRequest) when calling interceptor")
+					}
+					return s.AdminService.ListCompletionTraces(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ListCompletionTracesResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ListCompletionTracesResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *ListCompletionTracesResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *ListCompletionTracesResponse and nil error while calling ListCompletionTraces. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *adminServiceServer) serveReplayConversation(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveReplayConversationJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveReplayConversationProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *adminServiceServer) serveReplayConversationJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "ReplayConversation")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	d := json.NewDecoder(req.Body)
+	rawReqBody := json.RawMessage{}
+	if err := d.Decode(&rawReqBody); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+	reqContent := new(ReplayConversationRequest)
+	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
+	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+
+	handler := s.AdminService.ReplayConversation
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *ReplayConversationRequest) (*ReplayConversationResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ReplayConversationRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ReplayConversationRequest) when calling interceptor")
+					}
+					return s.AdminService.ReplayConversation(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ReplayConversationResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ReplayConversationResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *ReplayConversationResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *ReplayConversationResponse and nil error while calling ReplayConversation. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	marshaler := &protojson.MarshalOptions{UseProtoNames: !s.jsonCamelCase, EmitUnpopulated: !s.jsonSkipDefaults}
+	respBytes, err := marshaler.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *adminServiceServer) serveReplayConversationProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "ReplayConversation")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := io.ReadAll(req.Body)
+	if err != nil {
+		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
+		return
+	}
+	reqContent := new(ReplayConversationRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	handler := s.AdminService.ReplayConversation
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *ReplayConversationRequest) (*ReplayConversationResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ReplayConversationRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ReplayConversationRequest) when calling interceptor")
+					}
+					return s.AdminService.ReplayConversation(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ReplayConversationResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ReplayConversationResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *ReplayConversationResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *ReplayConversationResponse and nil error while calling ReplayConversation. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *adminServiceServer) serveGetIntentStats(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveGetIntentStatsJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveGetIntentStatsProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *adminServiceServer) serveGetIntentStatsJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "GetIntentStats")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	d := json.NewDecoder(req.Body)
+	rawReqBody := json.RawMessage{}
+	if err := d.Decode(&rawReqBody); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+	reqContent := new(GetIntentStatsRequest)
+	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
+	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+
+	handler := s.AdminService.GetIntentStats
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *GetIntentStatsRequest) (*GetIntentStatsResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*GetIntentStatsRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*GetIntentStatsRequest) when calling interceptor")
+					}
+					return s.AdminService.GetIntentStats(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*GetIntentStatsResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*GetIntentStatsResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *GetIntentStatsResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *GetIntentStatsResponse and nil error while calling GetIntentStats. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	marshaler := &protojson.MarshalOptions{UseProtoNames: !s.jsonCamelCase, EmitUnpopulated: !s.jsonSkipDefaults}
+	respBytes, err := marshaler.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *adminServiceServer) serveGetIntentStatsProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "GetIntentStats")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := io.ReadAll(req.Body)
+	if err != nil {
+		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
+		return
+	}
+	reqContent := new(GetIntentStatsRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	handler := s.AdminService.GetIntentStats
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *GetIntentStatsRequest) (*GetIntentStatsResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*GetIntentStatsRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*GetIntentStatsRequest) when calling interceptor")
+					}
+					return s.AdminService.GetIntentStats(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*GetIntentStatsResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*GetIntentStatsResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *GetIntentStatsResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *GetIntentStatsResponse and nil error while calling GetIntentStats. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *adminServiceServer) ServiceDescriptor() ([]byte, int) {
+	return twirpFileDescriptor0, 0
+}
+
+func (s *adminServiceServer) ProtocGenTwirpVersion() string {
+	return "v8.1.3"
+}
+
+// PathPrefix returns the base service path, in the form: "/<prefix>/<package>.<Service>/"
+// that is everything in a Twirp route except for the <Method>. This can be used for routing,
+// for example to identify the requests that are targeted to this service in a mux.
+func (s *adminServiceServer) PathPrefix() string {
+	return baseServicePath(s.pathPrefix, "acai.admin", "AdminService")
+}
+
+// =====
+// Utils
+// =====
+
+// HTTPClient is the interface used by generated clients to send HTTP requests.
+// It is fulfilled by *(net/http).Client, which is sufficient for most users.
+// Users can provide their own implementation for special retry policies.
+//
+// HTTPClient implementations should not follow redirects. Redirects are
+// automatically disabled if *(net/http).Client is passed to client
+// constructors. See the withoutRedirects function in this file for more
+// details.
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// TwirpServer is the interface generated server structs will support: they're
+// HTTP handlers with additional methods for accessing metadata about the
+// service. Those accessors are a low-level API for building reflection tools.
+// Most people can think of TwirpServers as just http.Handlers.
+type TwirpServer interface {
+	http.Handler
+
+	// ServiceDescriptor returns gzipped bytes describing the .proto file that
+	// this service was generated from. Once unzipped, the bytes can be
+	// unmarshalled as a
+	// google.golang.org/protobuf/types/descriptorpb.FileDescriptorProto.
+	//
+	// The returned integer is the index of this particular service within that
+	// FileDescriptorProto's 'Service' slice of ServiceDescriptorProtos. This is a
+	// low-level field, expected to be used for reflection.
+	ServiceDescriptor() ([]byte, int)
+
+	// ProtocGenTwirpVersion is the semantic version string of the version of
+	// twirp used to generate this file.
+	ProtocGenTwirpVersion() string
+
+	// PathPrefix returns the HTTP URL path prefix for all methods handled by this
+	// service. This can be used with an HTTP mux to route Twirp requests.
+	// The path prefix is in the form: "/<prefix>/<package>.<Service>/"
+	// that is, everything in a Twirp route except for the <Method> at the end.
+	PathPrefix() string
+}
+
+func newServerOpts(opts []interface{}) *twirp.ServerOptions {
+	serverOpts := &twirp.ServerOptions{}
+	for _, opt := range opts {
+		switch o := opt.(type) {
+		case twirp.ServerOption:
+			o(serverOpts)
+		case *twirp.ServerHooks: // backwards compatibility, allow to specify hooks as an argument
+			twirp.WithServerHooks(o)(serverOpts)
+		case nil: // backwards compatibility, allow nil value for the argument
+			continue
+		default:
+			panic(fmt.Sprintf("Invalid option type %T, please use a twirp.ServerOption", o))
+		}
+	}
+	return serverOpts
+}
+
+// WriteError writes an HTTP response with a valid Twirp error format (code, msg, meta).
+// Useful outside of the Twirp server (e.g. http middleware), but does not trigger hooks.
+// If err is not a twirp.Error, it will get wrapped with twirp.InternalErrorWith(err)
+func WriteError(resp http.ResponseWriter, err error) {
+	writeError(context.Background(), resp, err, nil)
+}
+
+// writeError writes Twirp errors in the response and triggers hooks.
+func writeError(ctx context.Context, resp http.ResponseWriter, err error, hooks *twirp.ServerHooks) {
+	// Convert to a twirp.Error. Non-twirp errors are converted to internal errors.
+	var twerr twirp.Error
+	if !errors.As(err, &twerr) {
+		twerr = twirp.InternalErrorWith(err)
+	}
+
+	statusCode := twirp.ServerHTTPStatusFromErrorCode(twerr.Code())
+	ctx = ctxsetters.WithStatusCode(ctx, statusCode)
+	ctx = callError(ctx, hooks, twerr)
+
+	respBody := marshalErrorToJSON(twerr)
+
+	resp.Header().Set("Content-Type", "application/json") // Error responses are always JSON
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBody)))
+	resp.WriteHeader(statusCode) // set HTTP status code and send response
+
+	_, writeErr := resp.Write(respBody)
+	if writeErr != nil {
+		// We have three options here. We could log the error, call the Error
+		// hook, or just silently ignore the error.
+		//
+		// Logging is unacceptable because we don't have a user-controlled
+		// logger; writing out to stderr without permission is too rude.
+		//
+		// Calling the Error hook would confuse users: it would mean the Error
+		// hook got called twice for one request, which is likely to lead to
+		// duplicated log messages and metrics, no matter how well we document
+		// the behavior.
+		//
+		// Silently ignoring the error is our least-bad option. It's highly
+		// likely that the connection is broken and the original 'err' says
+		// so anyway.
+		_ = writeErr
+	}
+
+	callResponseSent(ctx, hooks)
+}
+
+// sanitizeBaseURL parses the the baseURL, and adds the "http" scheme if needed.
+// If the URL is unparsable, the baseURL is returned unchanged.
+func sanitizeBaseURL(baseURL string) string {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return baseURL // invalid URL will fail later when making requests
+	}
+	if u.Scheme == "" {
+		u.Scheme = "http"
+	}
+	return u.String()
+}
+
+// baseServicePath composes the path prefix for the service (without <Method>).
+// e.g.: baseServicePath("/twirp", "my.pkg", "MyService")
+//
+//	returns => "/twirp/my.pkg.MyService/"
+//
+// e.g.: baseServicePath("", "", "MyService")
+//
+//	returns => "/MyService/"
+func baseServicePath(prefix, pkg, service string) string {
+	fullServiceName := service
+	if pkg != "" {
+		fullServiceName = pkg + "." + service
+	}
+	return path.Join("/", prefix, fullServiceName) + "/"
+}
+
+// parseTwirpPath extracts path components form a valid Twirp route.
+// Expected format: "[<prefix>]/<package>.<Service>/<Method>"
+// e.g.: prefix, pkgService, method := parseTwirpPath("/twirp/pkg.Svc/MakeHat")
+func parseTwirpPath(path string) (string, string, string) {
+	parts := strings.Split(path, "/")
+	if len(parts) < 2 {
+		return "", "", ""
+	}
+	method := parts[len(parts)-1]
+	pkgService := parts[len(parts)-2]
+	prefix := strings.Join(parts[0:len(parts)-2], "/")
+	return prefix, pkgService, method
+}
+
+// getCustomHTTPReqHeaders retrieves a copy of any headers that are set in
+// a context through the twirp.WithHTTPRequestHeaders function.
+// If there are no headers set, or if they have the wrong type, nil is returned.
+func getCustomHTTPReqHeaders(ctx context.Context) http.Header {
+	header, ok := twirp.HTTPRequestHeaders(ctx)
+	if !ok || header == nil {
+		return nil
+	}
+	copied := make(http.Header)
+	for k, vv := range header {
+		if vv == nil {
+			copied[k] = nil
+			continue
+		}
+		copied[k] = make([]string, len(vv))
+		copy(copied[k], vv)
+	}
+	return copied
+}
+
+// newRequest makes an http.Request from a client, adding common headers.
+func newRequest(ctx context.Context, url string, reqBody io.Reader, contentType string) (*http.Request, error) {
+	req, err := http.NewRequest("POST", url, reqBody)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if customHeader := getCustomHTTPReqHeaders(ctx); customHeader != nil {
+		req.Header = customHeader
+	}
+	req.Header.Set("Accept", contentType)
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("Twirp-Version", "v8.1.3")
+	return req, nil
+}
+
+// JSON serialization for errors
+type twerrJSON struct {
+	Code string            `json:"code"`
+	Msg  string            `json:"msg"`
+	Meta map[string]string `json:"meta,omitempty"`
+}
+
+// marshalErrorToJSON returns JSON from a twirp.Error, that can be used as HTTP error response body.
+// If serialization fails, it will use a descriptive Internal error instead.
+func marshalErrorToJSON(twerr twirp.Error) []byte {
+	// make sure that msg is not too large
+	msg := twerr.Msg()
+	if len(msg) > 1e6 {
+		msg = msg[:1e6]
+	}
+
+	tj := twerrJSON{
+		Code: string(twerr.Code()),
+		Msg:  msg,
+		Meta: twerr.MetaMap(),
+	}
+
+	buf, err := json.Marshal(&tj)
+	if err != nil {
+		buf = []byte("{\"type\": \"" + twirp.Internal + "\", \"msg\": \"There was an error but it could not be serialized into JSON\"}") // fallback
+	}
+
+	return buf
+}
+
+// errorFromResponse builds a twirp.Error from a non-200 HTTP response.
+// If the response has a valid serialized Twirp error, then it's returned.
+// If not, the response status code is used to generate a similar twirp
+// error. See twirpErrorFromIntermediary for more info on intermediary errors.
+func errorFromResponse(resp *http.Response) twirp.Error {
+	statusCode := resp.StatusCode
+	statusText := http.StatusText(statusCode)
+
+	if isHTTPRedirect(statusCode) {
+		// Unexpected redirect: it must be an error from an intermediary.
+		// Twirp clients don't follow redirects automatically, Twirp only handles
+		// POST requests, redirects should only happen on GET and HEAD requests.
+		location := resp.Header.Get("Location")
+		msg := fmt.Sprintf("unexpected HTTP status code %d %q received, Location=%q", statusCode, statusText, location)
+		return twirpErrorFromIntermediary(statusCode, msg, location)
+	}
+
+	respBodyBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return wrapInternal(err, "failed to read server error response body")
+	}
+
+	var tj twerrJSON
+	dec := json.NewDecoder(bytes.NewReader(respBodyBytes))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&tj); err != nil || tj.Code == "" {
+		// Invalid JSON response; it must be an error from an intermediary.
+		msg := fmt.Sprintf("Error from intermediary with HTTP status code %d %q", statusCode, statusText)
+		return twirpErrorFromIntermediary(statusCode, msg, string(respBodyBytes))
+	}
+
+	errorCode := twirp.ErrorCode(tj.Code)
+	if !twirp.IsValidErrorCode(errorCode) {
+		msg := "invalid type returned from server error response: " + tj.Code
+		return twirp.InternalError(msg).WithMeta("body", string(respBodyBytes))
+	}
+
+	twerr := twirp.NewError(errorCode, tj.Msg)
+	for k, v := range tj.Meta {
+		twerr = twerr.WithMeta(k, v)
+	}
+	return twerr
+}
+
+// twirpErrorFromIntermediary maps HTTP errors from non-twirp sources to twirp errors.
+// The mapping is similar to gRPC: https://github.com/grpc/grpc/blob/master/doc/http-grpc-status-mapping.md.
+// Returned twirp Errors have some additional metadata for inspection.
+func twirpErrorFromIntermediary(status int, msg string, bodyOrLocation string) twirp.Error {
+	var code twirp.ErrorCode
+	if isHTTPRedirect(status) { // 3xx
+		code = twirp.Internal
+	} else {
+		switch status {
+		case 400: // Bad Request
+			code = twirp.Internal
+		case 401: // Unauthorized
+			code = twirp.Unauthenticated
+		case 403: // Forbidden
+			code = twirp.PermissionDenied
+		case 404: // Not Found
+			code = twirp.BadRoute
+		case 429: // Too Many Requests
+			code = twirp.ResourceExhausted
+		case 502, 503, 504: // Bad Gateway, Service Unavailable, Gateway Timeout
+			code = twirp.Unavailable
+		default: // All other codes
+			code = twirp.Unknown
+		}
+	}
+
+	twerr := twirp.NewError(code, msg)
+	twerr = twerr.WithMeta("http_error_from_intermediary", "true") // to easily know if this error was from intermediary
+	twerr = twerr.WithMeta("status_code", strconv.Itoa(status))
+	if isHTTPRedirect(status) {
+		twerr = twerr.WithMeta("location", bodyOrLocation)
+	} else {
+		twerr = twerr.WithMeta("body", bodyOrLocation)
+	}
+	return twerr
+}
+
+func isHTTPRedirect(status int) bool {
+	return status >= 300 && status <= 399
+}
+
+// wrapInternal wraps an error with a prefix as an Internal error.
+// The original error cause is accessible by github.com/pkg/errors.Cause.
+func wrapInternal(err error, prefix string) twirp.Error {
+	return twirp.InternalErrorWith(&wrappedError{prefix: prefix, cause: err})
+}
+
+type wrappedError struct {
+	prefix string
+	cause  error
+}
+
+func (e *wrappedError) Error() string { return e.prefix + ": " + e.cause.Error() }
+func (e *wrappedError) Unwrap() error { return e.cause } // for go1.13 + errors.Is/As
+func (e *wrappedError) Cause() error  { return e.cause } // for github.com/pkg/errors
+
+// ensurePanicResponses makes sure that rpc methods causing a panic still result in a Twirp Internal
+// error response (status 500), and error hooks are properly called with the panic wrapped as an error.
+// The panic is re-raised so it can be handled normally with middleware.
+func ensurePanicResponses(ctx context.Context, resp http.ResponseWriter, hooks *twirp.ServerHooks) {
+	if r := recover(); r != nil {
+		// Wrap the panic as an error so it can be passed to error hooks.
+		// The original error is accessible from error hooks, but not visible in the response.
+		err := errFromPanic(r)
+		twerr := &internalWithCause{msg: "Internal service panic", cause: err}
+		// Actually write the error
+		writeError(ctx, resp, twerr, hooks)
+		// If possible, flush the error to the wire.
+		f, ok := resp.(http.Flusher)
+		if ok {
+			f.Flush()
+		}
+
+		panic(r)
+	}
+}
+
+// errFromPanic returns the typed error if the recovered panic is an error, otherwise formats as error.
+func errFromPanic(p interface{}) error {
+	if err, ok := p.(error); ok {
+		return err
+	}
+	return fmt.Errorf("panic: %v", p)
+}
+
+// internalWithCause is a Twirp Internal error wrapping an original error cause,
+// but the original error message is not exposed on Msg(). The original error
+// can be checked with go1.13+ errors.Is/As, and also by (github.com/pkg/errors).Unwrap
+type internalWithCause struct {
+	msg   string
+	cause error
+}
+
+func (e *internalWithCause) Unwrap() error                               { return e.cause } // for go1.13 + errors.Is/As
+func (e *internalWithCause) Cause() error                                { return e.cause } // for github.com/pkg/errors
+func (e *internalWithCause) Error() string                               { return e.msg + ": " + e.cause.Error() }
+func (e *internalWithCause) Code() twirp.ErrorCode                       { return twirp.Internal }
+func (e *internalWithCause) Msg() string                                 { return e.msg }
+func (e *internalWithCause) Meta(key string) string                      { return "" }
+func (e *internalWithCause) MetaMap() map[string]string                  { return nil }
+func (e *internalWithCause) WithMeta(key string, val string) twirp.Error { return e }
+
+// malformedRequestError is used when the twirp server cannot unmarshal a request
+func malformedRequestError(msg string) twirp.Error {
+	return twirp.NewError(twirp.Malformed, msg)
+}
+
+// badRouteError is used when the twirp server cannot route a request
+func badRouteError(msg string, method, url string) twirp.Error {
+	err := twirp.NewError(twirp.BadRoute, msg)
+	err = err.WithMeta("twirp_invalid_route", method+" "+url)
+	return err
+}
+
+// withoutRedirects makes sure that the POST request can not be redirected.
+// The standard library will, by default, redirect requests (including POSTs) if it gets a 302 or
+// 303 response, and also 301s in go1.8. It redirects by making a second request, changing the
+// method to GET and removing the body. This produces very confusing error messages, so instead we
+// set a redirect policy that always errors. This stops Go from executing the redirect.
+//
+// We have to be a little careful in case the user-provided http.Client has its own CheckRedirect
+// policy - if so, we'll run through that policy first.
+//
+// Because this requires modifying the http.Client, we make a new copy of the client and return it.
+func withoutRedirects(in *http.Client) *http.Client {
+	copy := *in
+	copy.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		if in.CheckRedirect != nil {
+			// Run the input's redirect if it exists, in case it has side effects, but ignore any error it
+			// returns, since we want to use ErrUseLastResponse.
+			err := in.CheckRedirect(req, via)
+			_ = err // Silly, but this makes sure generated code passes errcheck -blank, which some people use.
+		}
+		return http.ErrUseLastResponse
+	}
+	return &copy
+}
+
+// doProtobufRequest makes a Protobuf request to the remote Twirp service.
+func doProtobufRequest(ctx context.Context, client HTTPClient, hooks *twirp.ClientHooks, url string, in, out proto.Message) (_ context.Context, err error) {
+	reqBodyBytes, err := proto.Marshal(in)
+	if err != nil {
+		return ctx, wrapInternal(err, "failed to marshal proto request")
+	}
+	reqBody := bytes.NewBuffer(reqBodyBytes)
+	if err = ctx.Err(); err != nil {
+		return ctx, wrapInternal(err, "aborted because context was done")
+	}
+
+	req, err := newRequest(ctx, url, reqBody, "application/protobuf")
+	if err != nil {
+		return ctx, wrapInternal(err, "could not build request")
+	}
+	ctx, err = callClientRequestPrepared(ctx, hooks, req)
+	if err != nil {
+		return ctx, err
+	}
+
+	req = req.WithContext(ctx)
+	resp, err := client.Do(req)
+	if err != nil {
+		return ctx, wrapInternal(err, "failed to do request")
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if err = ctx.Err(); err != nil {
+		return ctx, wrapInternal(err, "aborted because context was done")
+	}
+
+	if resp.StatusCode != 200 {
+		return ctx, errorFromResponse(resp)
+	}
+
+	respBodyBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return ctx, wrapInternal(err, "failed to read response body")
+	}
+	if err = ctx.Err(); err != nil {
+		return ctx, wrapInternal(err, "aborted because context was done")
+	}
+
+	if err = proto.Unmarshal(respBodyBytes, out); err != nil {
+		return ctx, wrapInternal(err, "failed to unmarshal proto response")
+	}
+	return ctx, nil
+}
+
+// doJSONRequest makes a JSON request to the remote Twirp service.
+func doJSONRequest(ctx context.Context, client HTTPClient, hooks *twirp.ClientHooks, url string, in, out proto.Message) (_ context.Context, err error) {
+	marshaler := &protojson.MarshalOptions{UseProtoNames: true}
+	reqBytes, err := marshaler.Marshal(in)
+	if err != nil {
+		return ctx, wrapInternal(err, "failed to marshal json request")
+	}
+	if err = ctx.Err(); err != nil {
+		return ctx, wrapInternal(err, "aborted because context was done")
+	}
+
+	req, err := newRequest(ctx, url, bytes.NewReader(reqBytes), "application/json")
+	if err != nil {
+		return ctx, wrapInternal(err, "could not build request")
+	}
+	ctx, err = callClientRequestPrepared(ctx, hooks, req)
+	if err != nil {
+		return ctx, err
+	}
+
+	req = req.WithContext(ctx)
+	resp, err := client.Do(req)
+	if err != nil {
+		return ctx, wrapInternal(err, "failed to do request")
+	}
+
+	defer func() {
+		cerr := resp.Body.Close()
+		if err == nil && cerr != nil {
+			err = wrapInternal(cerr, "failed to close response body")
+		}
+	}()
+
+	if err = ctx.Err(); err != nil {
+		return ctx, wrapInternal(err, "aborted because context was done")
+	}
+
+	if resp.StatusCode != 200 {
+		return ctx, errorFromResponse(resp)
+	}
+
+	d := json.NewDecoder(resp.Body)
+	rawRespBody := json.RawMessage{}
+	if err := d.Decode(&rawRespBody); err != nil {
+		return ctx, wrapInternal(err, "failed to unmarshal json response")
+	}
+	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
+	if err = unmarshaler.Unmarshal(rawRespBody, out); err != nil {
+		return ctx, wrapInternal(err, "failed to unmarshal json response")
+	}
+	if err = ctx.Err(); err != nil {
+		return ctx, wrapInternal(err, "aborted because context was done")
+	}
+	return ctx, nil
+}
+
+// Call twirp.ServerHooks.RequestReceived if the hook is available
+func callRequestReceived(ctx context.Context, h *twirp.ServerHooks) (context.Context, error) {
+	if h == nil || h.RequestReceived == nil {
+		return ctx, nil
+	}
+	return h.RequestReceived(ctx)
+}
+
+// Call twirp.ServerHooks.RequestRouted if the hook is available
+func callRequestRouted(ctx context.Context, h *twirp.ServerHooks) (context.Context, error) {
+	if h == nil || h.RequestRouted == nil {
+		return ctx, nil
+	}
+	return h.RequestRouted(ctx)
+}
+
+// Call twirp.ServerHooks.ResponsePrepared if the hook is available
+func callResponsePrepared(ctx context.Context, h *twirp.ServerHooks) context.Context {
+	if h == nil || h.ResponsePrepared == nil {
+		return ctx
+	}
+	return h.ResponsePrepared(ctx)
+}
+
+// Call twirp.ServerHooks.ResponseSent if the hook is available
+func callResponseSent(ctx context.Context, h *twirp.ServerHooks) {
+	if h == nil || h.ResponseSent == nil {
+		return
+	}
+	h.ResponseSent(ctx)
+}
+
+// Call twirp.ServerHooks.Error if the hook is available
+func callError(ctx context.Context, h *twirp.ServerHooks, err twirp.Error) context.Context {
+	if h == nil || h.Error == nil {
+		return ctx
+	}
+	return h.Error(ctx, err)
+}
+
+func callClientResponseReceived(ctx context.Context, h *twirp.ClientHooks) {
+	if h == nil || h.ResponseReceived == nil {
+		return
+	}
+	h.ResponseReceived(ctx)
+}
+
+func callClientRequestPrepared(ctx context.Context, h *twirp.ClientHooks, req *http.Request) (context.Context, error) {
+	if h == nil || h.RequestPrepared == nil {
+		return ctx, nil
+	}
+	return h.RequestPrepared(ctx, req)
+}
+
+func callClientError(ctx context.Context, h *twirp.ClientHooks, err twirp.Error) {
+	if h == nil || h.Error == nil {
+		return
+	}
+	h.Error(ctx, err)
+}
+
+var twirpFileDescriptor0 = []byte{
+	// 1481 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xc4, 0x57, 0xcd, 0x6e, 0xdb, 0xc6,
+	0x16, 0x06, 0x45, 0x91, 0x92, 0x8e, 0xfc, 0x13, 0x0c, 0x1c, 0x99, 0x97, 0x49, 0x6e, 0x7c, 0xe9,
+	0x9b, 0xc4, 0x77, 0x71, 0xe9, 0xc2, 0x46, 0x82, 0x24, 0x40, 0x91, 0xba, 0x6e, 0x12, 0x18, 0x6d,
+	0xd2, 0x82, 0x72, 0x1b, 0x20, 0x40, 0x21, 0x8c, 0xc5, 0xb1, 0x40, 0x84, 0x22, 0x99, 0x99, 0xa1,
+	0x53, 0xe7, 0x25, 0xfa, 0x12, 0xdd, 0x75, 0x91, 0x5d, 0x77, 0x7d, 0x81, 0xa2, 0xcb, 0x3e, 0x44,
+	0xdf, 0xa2, 0xc5, 0xfc, 0x50, 0xa2, 0x24, 0xea, 0xc7, 0xed, 0xa2, 0xbb, 0x39, 0x67, 0xce, 0xff,
+	0xf9, 0xe6, 0x1c, 0x12, 0x36, 0x69, 0xd6, 0xdf, 0xc7, 0xe1, 0x30, 0x4a, 0xfc, 0x8c, 0xa6, 0x3c,
+	0x45, 0x80, 0xfb, 0x38, 0xf2, 0x25, 0xc7, 0xbd, 0x3d, 0x48, 0xd3, 0x41, 0x4c, 0xf6, 0xe5, 0xcd,
+	0x59, 0x7e, 0xbe, 0xcf, 0xa3, 0x21, 0x61, 0x1c, 0x0f, 0x33, 0x25, 0xec, 0xbd, 0x83, 0xeb, 0x5d,
+	0xc2, 0x9f, 0x11, 0xcc, 0x73, 0x4a, 0x9e, 0xc5, 0x78, 0x10, 0x90, 0xb7, 0x39, 0x61, 0x1c, 0xdd,
+	0x80, 0x16, 0x27, 0x09, 0x4e, 0x78, 0x2f, 0x0a, 0x1d, 0x63, 0xc7, 0xd8, 0x6b, 0x05, 0x4d, 0xc5,
+	0x38, 0x09, 0xd1, 0x36, 0x34, 0x72, 0x46, 0xa8, 0xb8, 0xaa, 0xc9, 0x2b, 0x5b, 0x90, 0x27, 0x21,
+	0x42, 0x50, 0x3f, 0x8f, 0xf1, 0xc0, 0x31, 0x25, 0x57, 0x9e, 0xd1, 0x16, 0x58, 0x17, 0x38, 0xce,
+	0x89, 0x53, 0x97, 0x4c, 0x45, 0x78, 0x0e, 0x74, 0xa6, 0x1d, 0xb3, 0x2c, 0x4d, 0x18, 0xf1, 0x1e,
+	0xc0, 0xf6, 0x17, 0x11, 0x2b, 0x5f, 0xb1, 0x55, 0x82, 0xf2, 0x7e, 0x33, 0xc1, 0x99, 0x55, 0x54,
+	0x46, 0xd1, 0x13, 0xb0, 0x44, 0x30, 0xcc, 0x31, 0x76, 0xcc, 0xbd, 0xf6, 0xc1, 0xff, 0xfc, 0x71,
+	0x91, 0xfc, 0x79, 0x4a, 0xbe, 0x0c, 0x4b, 0xe9, 0xa1, 0xcf, 0xa1, 0x95, 0x5e, 0x10, 0x4a, 0xa3,
+	0x90, 0x30, 0xa7, 0x26, 0x8d, 0xfc, 0x7f, 0x25, 0x23, 0x5f, 0x6a, 0xad, 0x60, 0xac, 0xef, 0x3e,
+	0x81, 0xba, 0x10, 0x12, 0xe5, 0x4a, 0xf0, 0x90, 0xe8, 0x54, 0xe4, 0x19, 0xed, 0xc2, 0x7a, 0x48,
+	0xce, 0x71, 0x1e, 0xf3, 0x9e, 0x2a, 0x9b, 0xaa, 0xf0, 0x9a, 0x66, 0x7e, 0x23, 0x78, 0xee, 0xef,
+	0x06, 0x34, 0x0b, 0xc3, 0xe5, 0x6e, 0x18, 0x13, 0xdd, 0x78, 0x59, 0x24, 0xad, 0xe2, 0x7d, 0x78,
+	0xa5, 0x78, 0x65, 0xf6, 0xec, 0x69, 0xc2, 0xe9, 0x65, 0x51, 0x83, 0x47, 0x00, 0x79, 0x16, 0x62,
+	0x4e, 0xc2, 0x1e, 0xe6, 0xb2, 0xc7, 0xed, 0x03, 0xd7, 0x57, 0x10, 0xf3, 0x0b, 0x88, 0xf9, 0xa7,
+	0x05, 0xc4, 0x82, 0x96, 0x96, 0x3e, 0xe2, 0xee, 0x43, 0x80, 0xb1, 0x3d, 0x74, 0x0d, 0xcc, 0x37,
+	0xe4, 0x52, 0x47, 0x2b, 0x8e, 0x63, 0x90, 0xd4, 0x4a, 0x20, 0x79, 0x5c, 0x7b, 0x68, 0x78, 0x3f,
+	0x9b, 0x00, 0x47, 0x79, 0x18, 0xf1, 0xa7, 0x17, 0x24, 0xe1, 0x68, 0x03, 0x6a, 0xa3, 0x3c, 0x6b,
+	0x51, 0x88, 0x7c, 0xa8, 0x0b, 0x4c, 0x3b, 0xb5, 0xa5, 0xd1, 0x48, 0xb9, 0x49, 0x08, 0x99, 0xf3,
+	0x71, 0x5d, 0x9f, 0xa8, 0x64, 0x07, 0x6c, 0xdc, 0xe7, 0x51, 0x9a, 0x38, 0x96, 0xe2, 0x2b, 0x0a,
+	0xdd, 0x83, 0xcd, 0x7e, 0x9a, 0x5c, 0x10, 0xca, 0xb0, 0xa0, 0x85, 0xa2, 0x2d, 0x05, 0x36, 0xca,
+	0x6c, 0x65, 0x60, 0x98, 0x86, 0x24, 0x66, 0x4e, 0x63, 0xc7, 0x14, 0x06, 0x14, 0x25, 0xf2, 0xe6,
+	0x69, 0x1a, 0x33, 0xa7, 0x29, 0xd9, 0x8a, 0x10, 0xd2, 0x8c, 0x63, 0x9e, 0x33, 0xa7, 0xa5, 0xdc,
+	0x29, 0x0a, 0xdd, 0x86, 0x76, 0x98, 0x53, 0xe5, 0x6a, 0xc8, 0x1c, 0xd8, 0x31, 0xf6, 0xcc, 0x00,
+	0x0a, 0xd6, 0x0b, 0x86, 0x3e, 0x86, 0x46, 0x48, 0x38, 0x8e, 0x62, 0xe6, 0xb4, 0x65, 0xcf, 0x77,
+	0xcb, 0x3d, 0x1f, 0x97, 0xd1, 0xff, 0x4c, 0x49, 0xa9, 0xf6, 0x16, 0x3a, 0xc2, 0x2f, 0x4f, 0xdf,
+	0x90, 0x84, 0x39, 0x6b, 0xd2, 0xb4, 0xa6, 0xdc, 0xc7, 0xb0, 0x56, 0x56, 0xb8, 0x52, 0xff, 0x3e,
+	0xd4, 0xa0, 0x23, 0xc0, 0x36, 0x76, 0xce, 0xfe, 0xde, 0x8c, 0xa9, 0xa8, 0xb9, 0x39, 0xaf, 0xe6,
+	0xba, 0x69, 0xf5, 0x89, 0xa6, 0x7d, 0x04, 0x16, 0x8b, 0x92, 0x3e, 0x71, 0xac, 0xa5, 0x98, 0x51,
+	0x82, 0x42, 0x23, 0x4f, 0x78, 0x14, 0x3b, 0xf6, 0x72, 0x0d, 0x29, 0x28, 0x52, 0xcb, 0xf0, 0x80,
+	0xf4, 0x58, 0xf4, 0x9e, 0x38, 0x8d, 0x1d, 0x63, 0xcf, 0x0a, 0x9a, 0x82, 0xd1, 0x8d, 0xde, 0x13,
+	0x74, 0x0b, 0x40, 0x5e, 0xca, 0xea, 0x3a, 0x4d, 0x19, 0x9c, 0x14, 0x3f, 0x15, 0x0c, 0xef, 0x2d,
+	0x6c, 0xcf, 0x14, 0x4c, 0x8f, 0x31, 0x1f, 0x6c, 0x22, 0x39, 0x7a, 0x8e, 0x75, 0xaa, 0xdb, 0x1b,
+	0x68, 0x29, 0x74, 0x17, 0x36, 0x13, 0xf2, 0x1d, 0xef, 0x95, 0xdc, 0xa9, 0x62, 0xae, 0x0b, 0xf6,
+	0x57, 0x23, 0x97, 0x3f, 0x5a, 0xd0, 0xfe, 0x9a, 0xe1, 0x01, 0x09, 0x48, 0x96, 0xd2, 0xd9, 0x57,
+	0xf6, 0x08, 0xe0, 0x1d, 0x21, 0x6f, 0x7a, 0x8c, 0x63, 0xca, 0x57, 0x78, 0x6b, 0x2d, 0x21, 0xdd,
+	0x15, 0xc2, 0xe8, 0x3e, 0x34, 0xa5, 0x2a, 0x49, 0xc2, 0x15, 0x46, 0x46, 0x43, 0xc8, 0x3e, 0x4d,
+	0xa4, 0xc7, 0x3e, 0x25, 0xc5, 0xac, 0xa9, 0x2f, 0xf7, 0xa8, 0xa5, 0x8f, 0x38, 0x3a, 0x14, 0x6f,
+	0x8a, 0xe3, 0x58, 0xf7, 0xf7, 0x56, 0xb9, 0x46, 0xa5, 0x24, 0xf5, 0x59, 0xc9, 0xa2, 0x03, 0xb0,
+	0x04, 0xbe, 0x98, 0x63, 0xcb, 0xc2, 0xde, 0x9c, 0xaf, 0x44, 0x68, 0xa0, 0x44, 0xdd, 0x07, 0x60,
+	0x9f, 0x24, 0x5c, 0x4c, 0xa5, 0x0e, 0xd8, 0x91, 0x3c, 0x15, 0x13, 0x58, 0x51, 0xe2, 0x59, 0xf4,
+	0xd3, 0x3c, 0x51, 0x25, 0x33, 0x03, 0x45, 0xb8, 0xbf, 0x18, 0x60, 0x49, 0x9b, 0xf2, 0x1e, 0xc7,
+	0x31, 0x73, 0x0c, 0x7d, 0x2f, 0x08, 0x74, 0x08, 0xd7, 0xcb, 0x50, 0x66, 0xaa, 0xec, 0x24, 0xd4,
+	0x56, 0xb6, 0x26, 0x2e, 0xbb, 0xea, 0xae, 0xf4, 0x76, 0xcd, 0xf2, 0xdb, 0x15, 0x60, 0x13, 0x43,
+	0xa5, 0xa7, 0xfc, 0xd4, 0xe5, 0x5d, 0x4b, 0x70, 0x8e, 0xa5, 0xaf, 0x27, 0xd0, 0xe6, 0x69, 0xd6,
+	0x53, 0xf1, 0x32, 0xc7, 0x92, 0xd9, 0xff, 0x7b, 0x5e, 0xf6, 0x2a, 0xdd, 0x00, 0x78, 0x9a, 0xa9,
+	0x23, 0x73, 0xdf, 0x42, 0x5d, 0xd4, 0xe4, 0x2f, 0x3e, 0xe6, 0x43, 0x51, 0x76, 0x3c, 0x20, 0x8e,
+	0xb9, 0x52, 0xaf, 0xa4, 0xac, 0xf7, 0x1c, 0xae, 0x3f, 0x27, 0xbc, 0x74, 0x5d, 0x0c, 0x14, 0x1f,
+	0xea, 0x02, 0x3f, 0x8e, 0xb1, 0x14, 0x2e, 0x52, 0xce, 0x3b, 0x81, 0xce, 0xb4, 0x21, 0xfd, 0xd0,
+	0xf6, 0xc1, 0xa6, 0x92, 0xa3, 0x6d, 0x6d, 0xcf, 0x09, 0x2c, 0xd0, 0x62, 0xde, 0x1f, 0x35, 0xd8,
+	0x3c, 0x4e, 0x87, 0x59, 0x4c, 0x44, 0x57, 0x4e, 0x29, 0xee, 0x93, 0x7f, 0x68, 0x57, 0x55, 0xcc,
+	0x47, 0xab, 0x72, 0x3e, 0x6e, 0x81, 0x25, 0xb7, 0x90, 0x5e, 0x59, 0x8a, 0x10, 0xdf, 0x24, 0x3c,
+	0xa7, 0x89, 0x1e, 0x5a, 0xf2, 0x8c, 0x1c, 0x68, 0x50, 0x55, 0x62, 0x3d, 0xad, 0x0a, 0x12, 0xb9,
+	0xd0, 0xa4, 0xba, 0x66, 0x7a, 0x57, 0x8d, 0x68, 0x61, 0x9f, 0x50, 0x9a, 0x52, 0xb9, 0xa7, 0x5a,
+	0x81, 0x22, 0xa6, 0x77, 0x58, 0x7b, 0x66, 0x87, 0x4d, 0x02, 0x76, 0x4d, 0x86, 0x51, 0x02, 0xec,
+	0x36, 0x34, 0xe4, 0xf5, 0x90, 0x39, 0xeb, 0x05, 0xd0, 0xd3, 0xf8, 0x05, 0xf3, 0x2e, 0xe1, 0x86,
+	0x18, 0x9b, 0x53, 0x4d, 0x18, 0x2d, 0x9b, 0x8a, 0xb2, 0x18, 0xf3, 0xca, 0x12, 0x47, 0xc3, 0x48,
+	0xbd, 0x59, 0x2b, 0x50, 0xc4, 0xc2, 0x5e, 0x78, 0x5d, 0xb8, 0x59, 0xed, 0x5a, 0x57, 0xe2, 0x10,
+	0x6c, 0x2e, 0x39, 0x7a, 0x6c, 0xdf, 0x28, 0xa3, 0x69, 0x4a, 0x2b, 0xd0, 0xa2, 0xde, 0xaf, 0x06,
+	0xfc, 0x2b, 0x20, 0x59, 0x8c, 0x2f, 0x8f, 0x4b, 0x01, 0xae, 0xb4, 0x3b, 0x2b, 0x72, 0xad, 0x55,
+	0xe6, 0x7a, 0x0b, 0x60, 0x48, 0x98, 0x80, 0xf3, 0x38, 0xad, 0x96, 0xe6, 0x9c, 0x84, 0xe8, 0x26,
+	0xb4, 0x30, 0x63, 0x11, 0xe3, 0x38, 0xe1, 0x1a, 0x65, 0x63, 0x86, 0x98, 0x38, 0x19, 0x4d, 0x87,
+	0x19, 0x2f, 0x3e, 0x8a, 0x14, 0x55, 0x8d, 0x2b, 0xef, 0x7b, 0x13, 0xdc, 0xaa, 0x74, 0x74, 0x89,
+	0x26, 0x23, 0x31, 0xa6, 0x23, 0xb9, 0x03, 0x1b, 0x29, 0x8d, 0x06, 0x51, 0x82, 0xe3, 0x1e, 0x25,
+	0x59, 0x7c, 0x59, 0xec, 0xb1, 0x82, 0x2b, 0x4c, 0xcb, 0xcf, 0x10, 0x75, 0xab, 0x52, 0x51, 0x84,
+	0x80, 0x74, 0x18, 0x9d, 0x9f, 0xeb, 0x0c, 0xe4, 0x19, 0xbd, 0x9c, 0x40, 0x99, 0x1a, 0x7b, 0xfb,
+	0xe5, 0xb6, 0xcc, 0x8f, 0xd5, 0x3f, 0xd5, 0x60, 0x2c, 0xc3, 0x72, 0x3c, 0x7e, 0xed, 0x89, 0xf1,
+	0x3b, 0x05, 0xf7, 0xc6, 0x0c, 0xdc, 0x47, 0xaf, 0xa4, 0x59, 0x7a, 0x25, 0xee, 0x19, 0x34, 0x0b,
+	0x2f, 0xf2, 0x45, 0xa6, 0x69, 0x5c, 0xfc, 0x25, 0x88, 0xb3, 0xe0, 0x89, 0xc8, 0x75, 0x15, 0xe4,
+	0x59, 0x84, 0x40, 0x09, 0xcb, 0x63, 0xae, 0xb3, 0xd7, 0x94, 0xe0, 0x9f, 0xe3, 0x28, 0x26, 0x6a,
+	0x50, 0x34, 0x03, 0x4d, 0x79, 0x3f, 0x19, 0x72, 0x8e, 0xaa, 0x41, 0xde, 0xe5, 0x78, 0xc5, 0x0f,
+	0xb3, 0xd1, 0xe7, 0x53, 0xed, 0xca, 0x9f, 0x4f, 0xe6, 0xaa, 0x9f, 0x4f, 0x0b, 0x81, 0xe7, 0x7d,
+	0x30, 0xa0, 0x33, 0x1d, 0xb8, 0x86, 0xd1, 0x27, 0x60, 0xcb, 0x1d, 0x5b, 0xbc, 0xb4, 0xbd, 0x72,
+	0x4b, 0xab, 0x75, 0xfc, 0x63, 0xa1, 0x10, 0x68, 0x3d, 0xe1, 0x3a, 0x4f, 0x62, 0x7c, 0x46, 0xe2,
+	0xd1, 0xc2, 0x1d, 0x33, 0xdc, 0xfb, 0x60, 0x49, 0xf1, 0xab, 0x6d, 0xfc, 0x83, 0x1f, 0x2c, 0x58,
+	0x3b, 0x12, 0x31, 0x74, 0x09, 0xbd, 0x88, 0xfa, 0x04, 0xbd, 0x82, 0x8d, 0xc9, 0xdf, 0x5f, 0xf4,
+	0x9f, 0x72, 0xa4, 0x95, 0xff, 0xe4, 0xae, 0xb7, 0x48, 0x44, 0x17, 0xe0, 0x5b, 0xb8, 0x36, 0xfd,
+	0x6b, 0x87, 0x76, 0x17, 0xff, 0xf8, 0x29, 0xe3, 0xff, 0x5d, 0xe5, 0xef, 0x10, 0xbd, 0x86, 0xcd,
+	0xa9, 0x6f, 0x53, 0xe4, 0x4d, 0x2b, 0xce, 0x7e, 0xe9, 0xbb, 0xbb, 0x0b, 0x65, 0xb4, 0xed, 0x57,
+	0xb0, 0x31, 0xb9, 0x8d, 0x27, 0x6b, 0x52, 0xb9, 0xf2, 0x5d, 0x6f, 0x91, 0x88, 0x36, 0x1c, 0xc1,
+	0x56, 0xd5, 0x78, 0x46, 0xf7, 0xa6, 0xa3, 0x9a, 0xb3, 0x3b, 0xdc, 0xbd, 0xe5, 0x82, 0xda, 0x55,
+	0x1f, 0xd0, 0xec, 0xe0, 0x40, 0x77, 0x96, 0x0d, 0x16, 0xe5, 0xe6, 0xee, 0x6a, 0xf3, 0x47, 0x17,
+	0xaa, 0x04, 0xe5, 0x99, 0x42, 0xcd, 0xbe, 0x69, 0xd7, 0x5b, 0x24, 0xa2, 0x0c, 0x7f, 0xba, 0xfe,
+	0xba, 0x2d, 0x60, 0x4c, 0x13, 0x1c, 0xef, 0x67, 0x67, 0x67, 0xb6, 0x7c, 0xa0, 0x87, 0x7f, 0x0e,
+	0x00, 0xbd, 0xd9, 0xbe, 0x7a, 0x63, 0x12, 0x00, 0x00,
+}
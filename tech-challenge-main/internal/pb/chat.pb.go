@@ -1,6 +1,6 @@
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // versions:
-// 	protoc-gen-go v1.35.2
+// 	protoc-gen-go v1.36.7
 // 	protoc        v5.29.3
 // source: rpc/chat.proto
 
@@ -9,9 +9,11 @@ package pb
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
 	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
+	unsafe "unsafe"
 )
 
 const (
@@ -21,6 +23,261 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// Response style of the assistant's reply
+type Verbosity int32
+
+const (
+	// Use the server default
+	Verbosity_VERBOSITY_DEFAULT Verbosity = 0
+	// At most a couple of sentences, for small UI surfaces
+	Verbosity_VERBOSITY_CONCISE Verbosity = 1
+	// Thorough answer with explanations
+	Verbosity_VERBOSITY_DETAILED Verbosity = 2
+	// Short bullet points only
+	Verbosity_VERBOSITY_BULLET Verbosity = 3
+)
+
+// Enum value maps for Verbosity.
+var (
+	Verbosity_name = map[int32]string{
+		0: "VERBOSITY_DEFAULT",
+		1: "VERBOSITY_CONCISE",
+		2: "VERBOSITY_DETAILED",
+		3: "VERBOSITY_BULLET",
+	}
+	Verbosity_value = map[string]int32{
+		"VERBOSITY_DEFAULT":  0,
+		"VERBOSITY_CONCISE":  1,
+		"VERBOSITY_DETAILED": 2,
+		"VERBOSITY_BULLET":   3,
+	}
+)
+
+func (x Verbosity) Enum() *Verbosity {
+	p := new(Verbosity)
+	*p = x
+	return p
+}
+
+func (x Verbosity) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Verbosity) Descriptor() protoreflect.EnumDescriptor {
+	return file_rpc_chat_proto_enumTypes[0].Descriptor()
+}
+
+func (Verbosity) Type() protoreflect.EnumType {
+	return &file_rpc_chat_proto_enumTypes[0]
+}
+
+func (x Verbosity) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Verbosity.Descriptor instead.
+func (Verbosity) EnumDescriptor() ([]byte, []int) {
+	return file_rpc_chat_proto_rawDescGZIP(), []int{0}
+}
+
+type MessageRating int32
+
+const (
+	MessageRating_MESSAGE_RATING_UNSPECIFIED MessageRating = 0
+	MessageRating_MESSAGE_RATING_POSITIVE    MessageRating = 1
+	MessageRating_MESSAGE_RATING_NEGATIVE    MessageRating = 2
+)
+
+// Enum value maps for MessageRating.
+var (
+	MessageRating_name = map[int32]string{
+		0: "MESSAGE_RATING_UNSPECIFIED",
+		1: "MESSAGE_RATING_POSITIVE",
+		2: "MESSAGE_RATING_NEGATIVE",
+	}
+	MessageRating_value = map[string]int32{
+		"MESSAGE_RATING_UNSPECIFIED": 0,
+		"MESSAGE_RATING_POSITIVE":    1,
+		"MESSAGE_RATING_NEGATIVE":    2,
+	}
+)
+
+func (x MessageRating) Enum() *MessageRating {
+	p := new(MessageRating)
+	*p = x
+	return p
+}
+
+func (x MessageRating) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (MessageRating) Descriptor() protoreflect.EnumDescriptor {
+	return file_rpc_chat_proto_enumTypes[1].Descriptor()
+}
+
+func (MessageRating) Type() protoreflect.EnumType {
+	return &file_rpc_chat_proto_enumTypes[1]
+}
+
+func (x MessageRating) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use MessageRating.Descriptor instead.
+func (MessageRating) EnumDescriptor() ([]byte, []int) {
+	return file_rpc_chat_proto_rawDescGZIP(), []int{1}
+}
+
+type DigestFrequency int32
+
+const (
+	// Not subscribed
+	DigestFrequency_DIGEST_FREQUENCY_OFF    DigestFrequency = 0
+	DigestFrequency_DIGEST_FREQUENCY_DAILY  DigestFrequency = 1
+	DigestFrequency_DIGEST_FREQUENCY_WEEKLY DigestFrequency = 2
+)
+
+// Enum value maps for DigestFrequency.
+var (
+	DigestFrequency_name = map[int32]string{
+		0: "DIGEST_FREQUENCY_OFF",
+		1: "DIGEST_FREQUENCY_DAILY",
+		2: "DIGEST_FREQUENCY_WEEKLY",
+	}
+	DigestFrequency_value = map[string]int32{
+		"DIGEST_FREQUENCY_OFF":    0,
+		"DIGEST_FREQUENCY_DAILY":  1,
+		"DIGEST_FREQUENCY_WEEKLY": 2,
+	}
+)
+
+func (x DigestFrequency) Enum() *DigestFrequency {
+	p := new(DigestFrequency)
+	*p = x
+	return p
+}
+
+func (x DigestFrequency) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DigestFrequency) Descriptor() protoreflect.EnumDescriptor {
+	return file_rpc_chat_proto_enumTypes[2].Descriptor()
+}
+
+func (DigestFrequency) Type() protoreflect.EnumType {
+	return &file_rpc_chat_proto_enumTypes[2]
+}
+
+func (x DigestFrequency) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DigestFrequency.Descriptor instead.
+func (DigestFrequency) EnumDescriptor() ([]byte, []int) {
+	return file_rpc_chat_proto_rawDescGZIP(), []int{2}
+}
+
+type DigestDelivery int32
+
+const (
+	// As a new conversation with the digest as its only message
+	DigestDelivery_DIGEST_DELIVERY_MESSAGE DigestDelivery = 0
+	// Posted to the webhook configured on the server
+	DigestDelivery_DIGEST_DELIVERY_WEBHOOK DigestDelivery = 1
+)
+
+// Enum value maps for DigestDelivery.
+var (
+	DigestDelivery_name = map[int32]string{
+		0: "DIGEST_DELIVERY_MESSAGE",
+		1: "DIGEST_DELIVERY_WEBHOOK",
+	}
+	DigestDelivery_value = map[string]int32{
+		"DIGEST_DELIVERY_MESSAGE": 0,
+		"DIGEST_DELIVERY_WEBHOOK": 1,
+	}
+)
+
+func (x DigestDelivery) Enum() *DigestDelivery {
+	p := new(DigestDelivery)
+	*p = x
+	return p
+}
+
+func (x DigestDelivery) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DigestDelivery) Descriptor() protoreflect.EnumDescriptor {
+	return file_rpc_chat_proto_enumTypes[3].Descriptor()
+}
+
+func (DigestDelivery) Type() protoreflect.EnumType {
+	return &file_rpc_chat_proto_enumTypes[3]
+}
+
+func (x DigestDelivery) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DigestDelivery.Descriptor instead.
+func (DigestDelivery) EnumDescriptor() ([]byte, []int) {
+	return file_rpc_chat_proto_rawDescGZIP(), []int{3}
+}
+
+type PushPlatform int32
+
+const (
+	PushPlatform_PUSH_PLATFORM_UNSPECIFIED PushPlatform = 0
+	// Firebase Cloud Messaging, for Android and web apps
+	PushPlatform_PUSH_PLATFORM_FCM PushPlatform = 1
+	// Apple Push Notification service, for iOS apps
+	PushPlatform_PUSH_PLATFORM_APNS PushPlatform = 2
+)
+
+// Enum value maps for PushPlatform.
+var (
+	PushPlatform_name = map[int32]string{
+		0: "PUSH_PLATFORM_UNSPECIFIED",
+		1: "PUSH_PLATFORM_FCM",
+		2: "PUSH_PLATFORM_APNS",
+	}
+	PushPlatform_value = map[string]int32{
+		"PUSH_PLATFORM_UNSPECIFIED": 0,
+		"PUSH_PLATFORM_FCM":         1,
+		"PUSH_PLATFORM_APNS":        2,
+	}
+)
+
+func (x PushPlatform) Enum() *PushPlatform {
+	p := new(PushPlatform)
+	*p = x
+	return p
+}
+
+func (x PushPlatform) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PushPlatform) Descriptor() protoreflect.EnumDescriptor {
+	return file_rpc_chat_proto_enumTypes[4].Descriptor()
+}
+
+func (PushPlatform) Type() protoreflect.EnumType {
+	return &file_rpc_chat_proto_enumTypes[4]
+}
+
+func (x PushPlatform) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PushPlatform.Descriptor instead.
+func (PushPlatform) EnumDescriptor() ([]byte, []int) {
+	return file_rpc_chat_proto_rawDescGZIP(), []int{4}
+}
+
 type Conversation_Role int32
 
 const (
@@ -54,11 +311,11 @@ func (x Conversation_Role) String() string {
 }
 
 func (Conversation_Role) Descriptor() protoreflect.EnumDescriptor {
-	return file_rpc_chat_proto_enumTypes[0].Descriptor()
+	return file_rpc_chat_proto_enumTypes[5].Descriptor()
 }
 
 func (Conversation_Role) Type() protoreflect.EnumType {
-	return &file_rpc_chat_proto_enumTypes[0]
+	return &file_rpc_chat_proto_enumTypes[5]
 }
 
 func (x Conversation_Role) Number() protoreflect.EnumNumber {
@@ -70,15 +327,137 @@ func (Conversation_Role) EnumDescriptor() ([]byte, []int) {
 	return file_rpc_chat_proto_rawDescGZIP(), []int{0, 0}
 }
 
-type Conversation struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
+type BulkJob_State int32
+
+const (
+	BulkJob_UNKNOWN BulkJob_State = 0
+	BulkJob_RUNNING BulkJob_State = 1
+	BulkJob_DONE    BulkJob_State = 2
+	BulkJob_FAILED  BulkJob_State = 3
+)
+
+// Enum value maps for BulkJob_State.
+var (
+	BulkJob_State_name = map[int32]string{
+		0: "UNKNOWN",
+		1: "RUNNING",
+		2: "DONE",
+		3: "FAILED",
+	}
+	BulkJob_State_value = map[string]int32{
+		"UNKNOWN": 0,
+		"RUNNING": 1,
+		"DONE":    2,
+		"FAILED":  3,
+	}
+)
+
+func (x BulkJob_State) Enum() *BulkJob_State {
+	p := new(BulkJob_State)
+	*p = x
+	return p
+}
+
+func (x BulkJob_State) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (BulkJob_State) Descriptor() protoreflect.EnumDescriptor {
+	return file_rpc_chat_proto_enumTypes[6].Descriptor()
+}
+
+func (BulkJob_State) Type() protoreflect.EnumType {
+	return &file_rpc_chat_proto_enumTypes[6]
+}
+
+func (x BulkJob_State) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
 
+// Deprecated: Use BulkJob_State.Descriptor instead.
+func (BulkJob_State) EnumDescriptor() ([]byte, []int) {
+	return file_rpc_chat_proto_rawDescGZIP(), []int{53, 0}
+}
+
+type ExportConversationRequest_Format int32
+
+const (
+	ExportConversationRequest_MARKDOWN ExportConversationRequest_Format = 0
+	ExportConversationRequest_JSON     ExportConversationRequest_Format = 1
+	ExportConversationRequest_PDF      ExportConversationRequest_Format = 2
+)
+
+// Enum value maps for ExportConversationRequest_Format.
+var (
+	ExportConversationRequest_Format_name = map[int32]string{
+		0: "MARKDOWN",
+		1: "JSON",
+		2: "PDF",
+	}
+	ExportConversationRequest_Format_value = map[string]int32{
+		"MARKDOWN": 0,
+		"JSON":     1,
+		"PDF":      2,
+	}
+)
+
+func (x ExportConversationRequest_Format) Enum() *ExportConversationRequest_Format {
+	p := new(ExportConversationRequest_Format)
+	*p = x
+	return p
+}
+
+func (x ExportConversationRequest_Format) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ExportConversationRequest_Format) Descriptor() protoreflect.EnumDescriptor {
+	return file_rpc_chat_proto_enumTypes[7].Descriptor()
+}
+
+func (ExportConversationRequest_Format) Type() protoreflect.EnumType {
+	return &file_rpc_chat_proto_enumTypes[7]
+}
+
+func (x ExportConversationRequest_Format) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ExportConversationRequest_Format.Descriptor instead.
+func (ExportConversationRequest_Format) EnumDescriptor() ([]byte, []int) {
+	return file_rpc_chat_proto_rawDescGZIP(), []int{58, 0}
+}
+
+type Conversation struct {
+	state     protoimpl.MessageState  `protogen:"open.v1"`
 	Id        string                  `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	Title     string                  `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
 	Timestamp *timestamppb.Timestamp  `protobuf:"bytes,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
 	Messages  []*Conversation_Message `protobuf:"bytes,4,rep,name=messages,proto3" json:"messages,omitempty"`
+	Settings  *GenerationSettings     `protobuf:"bytes,5,opt,name=settings,proto3" json:"settings,omitempty"`
+	// Name of the assistant answering in this conversation
+	Assistant string `protobuf:"bytes,6,opt,name=assistant,proto3" json:"assistant,omitempty"`
+	// Only set by ListConversations with include_preview
+	Preview *Conversation_Preview `protobuf:"bytes,7,opt,name=preview,proto3" json:"preview,omitempty"`
+	// BCP 47 tag of the language replies are written in, e.g. "es"; empty if not known
+	Language string `protobuf:"bytes,8,opt,name=language,proto3" json:"language,omitempty"`
+	// Notice for the user when the reply to the last message failed, e.g. because the server restarted while
+	// generating it; RetryFailedReply generates it again. Only set by DescribeConversation
+	FailedReply string `protobuf:"bytes,9,opt,name=failed_reply,json=failedReply,proto3" json:"failed_reply,omitempty"`
+	// Labels set when the conversation was started, e.g. "trip-2025"
+	Tags []string `protobuf:"bytes,10,rep,name=tags,proto3" json:"tags,omitempty"`
+	// When the conversation was archived; archived conversations are hidden from ListConversations by default
+	ArchivedAt *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=archived_at,json=archivedAt,proto3" json:"archived_at,omitempty"`
+	Usage      *Conversation_Usage    `protobuf:"bytes,12,opt,name=usage,proto3" json:"usage,omitempty"`
+	// Emoji of the conversation's category, e.g. "✈️" for travel, to show before the title; empty when the server
+	// doesn't add icons, the category has none or the first message isn't labeled yet
+	Icon string `protobuf:"bytes,13,opt,name=icon,proto3" json:"icon,omitempty"`
+	// Rolling summary of the messages before those returned; only set with HistoryOptions.summary_only
+	Summary string `protobuf:"bytes,14,opt,name=summary,proto3" json:"summary,omitempty"`
+	// Number of older messages left out of messages by HistoryOptions
+	OmittedMessages int32 `protobuf:"varint,15,opt,name=omitted_messages,json=omittedMessages,proto3" json:"omitted_messages,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *Conversation) Reset() {
@@ -125,90 +504,5465 @@ func (x *Conversation) GetTitle() string {
 	return ""
 }
 
-func (x *Conversation) GetTimestamp() *timestamppb.Timestamp {
+func (x *Conversation) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *Conversation) GetMessages() []*Conversation_Message {
+	if x != nil {
+		return x.Messages
+	}
+	return nil
+}
+
+func (x *Conversation) GetSettings() *GenerationSettings {
+	if x != nil {
+		return x.Settings
+	}
+	return nil
+}
+
+func (x *Conversation) GetAssistant() string {
+	if x != nil {
+		return x.Assistant
+	}
+	return ""
+}
+
+func (x *Conversation) GetPreview() *Conversation_Preview {
+	if x != nil {
+		return x.Preview
+	}
+	return nil
+}
+
+func (x *Conversation) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *Conversation) GetFailedReply() string {
+	if x != nil {
+		return x.FailedReply
+	}
+	return ""
+}
+
+func (x *Conversation) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *Conversation) GetArchivedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ArchivedAt
+	}
+	return nil
+}
+
+func (x *Conversation) GetUsage() *Conversation_Usage {
+	if x != nil {
+		return x.Usage
+	}
+	return nil
+}
+
+func (x *Conversation) GetIcon() string {
+	if x != nil {
+		return x.Icon
+	}
+	return ""
+}
+
+func (x *Conversation) GetSummary() string {
+	if x != nil {
+		return x.Summary
+	}
+	return ""
+}
+
+func (x *Conversation) GetOmittedMessages() int32 {
+	if x != nil {
+		return x.OmittedMessages
+	}
+	return 0
+}
+
+// Overrides for how the assistant generates replies in a conversation
+type GenerationSettings struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Model to use, must be allowed by the server; empty uses the server default
+	Model string `protobuf:"bytes,1,opt,name=model,proto3" json:"model,omitempty"`
+	// Sampling temperature between 0 and 2; not supported by reasoning models
+	Temperature *float64 `protobuf:"fixed64,2,opt,name=temperature,proto3,oneof" json:"temperature,omitempty"`
+	// Maximum number of output tokens per reply, within the server's; 0 means no explicit limit
+	MaxOutputTokens int64 `protobuf:"varint,3,opt,name=max_output_tokens,json=maxOutputTokens,proto3" json:"max_output_tokens,omitempty"`
+	// Budget for the whole conversation in estimated USD; 0 means no limit
+	MaxCostUsd float64 `protobuf:"fixed64,4,opt,name=max_cost_usd,json=maxCostUsd,proto3" json:"max_cost_usd,omitempty"`
+	// Budget for the whole conversation in prompt and completion tokens; 0 means no limit
+	MaxTotalTokens int64 `protobuf:"varint,5,opt,name=max_total_tokens,json=maxTotalTokens,proto3" json:"max_total_tokens,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GenerationSettings) Reset() {
+	*x = GenerationSettings{}
+	mi := &file_rpc_chat_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GenerationSettings) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenerationSettings) ProtoMessage() {}
+
+func (x *GenerationSettings) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_chat_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenerationSettings.ProtoReflect.Descriptor instead.
+func (*GenerationSettings) Descriptor() ([]byte, []int) {
+	return file_rpc_chat_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GenerationSettings) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *GenerationSettings) GetTemperature() float64 {
+	if x != nil && x.Temperature != nil {
+		return *x.Temperature
+	}
+	return 0
+}
+
+func (x *GenerationSettings) GetMaxOutputTokens() int64 {
+	if x != nil {
+		return x.MaxOutputTokens
+	}
+	return 0
+}
+
+func (x *GenerationSettings) GetMaxCostUsd() float64 {
+	if x != nil {
+		return x.MaxCostUsd
+	}
+	return 0
+}
+
+func (x *GenerationSettings) GetMaxTotalTokens() int64 {
+	if x != nil {
+		return x.MaxTotalTokens
+	}
+	return 0
+}
+
+// Per-turn constraints on the tools the assistant may use
+type ToolOptions struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Tool the assistant must call before answering, e.g. "get_weather"
+	ForceTool string `protobuf:"bytes,1,opt,name=force_tool,json=forceTool,proto3" json:"force_tool,omitempty"`
+	// Tools the assistant must not use
+	DenyTools []string `protobuf:"bytes,2,rep,name=deny_tools,json=denyTools,proto3" json:"deny_tools,omitempty"`
+	// Answer without using any tools
+	DisableTools  bool `protobuf:"varint,3,opt,name=disable_tools,json=disableTools,proto3" json:"disable_tools,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ToolOptions) Reset() {
+	*x = ToolOptions{}
+	mi := &file_rpc_chat_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ToolOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ToolOptions) ProtoMessage() {}
+
+func (x *ToolOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_chat_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ToolOptions.ProtoReflect.Descriptor instead.
+func (*ToolOptions) Descriptor() ([]byte, []int) {
+	return file_rpc_chat_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ToolOptions) GetForceTool() string {
+	if x != nil {
+		return x.ForceTool
+	}
+	return ""
+}
+
+func (x *ToolOptions) GetDenyTools() []string {
+	if x != nil {
+		return x.DenyTools
+	}
+	return nil
+}
+
+func (x *ToolOptions) GetDisableTools() bool {
+	if x != nil {
+		return x.DisableTools
+	}
+	return false
+}
+
+type StartConversationRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Message     string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	ToolOptions *ToolOptions           `protobuf:"bytes,2,opt,name=tool_options,json=toolOptions,proto3" json:"tool_options,omitempty"`
+	Verbosity   Verbosity              `protobuf:"varint,3,opt,name=verbosity,proto3,enum=acai.chat.Verbosity" json:"verbosity,omitempty"`
+	Settings    *GenerationSettings    `protobuf:"bytes,4,opt,name=settings,proto3" json:"settings,omitempty"`
+	// Assistant to answer in this conversation, e.g. "travel"; empty uses the server default
+	Assistant string `protobuf:"bytes,5,opt,name=assistant,proto3" json:"assistant,omitempty"`
+	// Previously uploaded attachments to include with the message
+	AttachmentIds []string `protobuf:"bytes,6,rep,name=attachment_ids,json=attachmentIds,proto3" json:"attachment_ids,omitempty"`
+	// BCP 47 tag of the language to reply in, e.g. "es"; empty detects it from the message
+	Language string `protobuf:"bytes,7,opt,name=language,proto3" json:"language,omitempty"`
+	// Labels to find the conversation by later, e.g. in bulk operations
+	Tags []string `protobuf:"bytes,8,rep,name=tags,proto3" json:"tags,omitempty"`
+	// Suggest follow-ups to the reply, for clients to render as tappable chips
+	QuickReplies bool `protobuf:"varint,9,opt,name=quick_replies,json=quickReplies,proto3" json:"quick_replies,omitempty"`
+	// Link past conversations on the same topic, so the user can continue one instead
+	SimilarConversations bool `protobuf:"varint,10,opt,name=similar_conversations,json=similarConversations,proto3" json:"similar_conversations,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *StartConversationRequest) Reset() {
+	*x = StartConversationRequest{}
+	mi := &file_rpc_chat_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StartConversationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartConversationRequest) ProtoMessage() {}
+
+func (x *StartConversationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_chat_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartConversationRequest.ProtoReflect.Descriptor instead.
+func (*StartConversationRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_chat_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *StartConversationRequest) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *StartConversationRequest) GetToolOptions() *ToolOptions {
+	if x != nil {
+		return x.ToolOptions
+	}
+	return nil
+}
+
+func (x *StartConversationRequest) GetVerbosity() Verbosity {
+	if x != nil {
+		return x.Verbosity
+	}
+	return Verbosity_VERBOSITY_DEFAULT
+}
+
+func (x *StartConversationRequest) GetSettings() *GenerationSettings {
+	if x != nil {
+		return x.Settings
+	}
+	return nil
+}
+
+func (x *StartConversationRequest) GetAssistant() string {
+	if x != nil {
+		return x.Assistant
+	}
+	return ""
+}
+
+func (x *StartConversationRequest) GetAttachmentIds() []string {
+	if x != nil {
+		return x.AttachmentIds
+	}
+	return nil
+}
+
+func (x *StartConversationRequest) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *StartConversationRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *StartConversationRequest) GetQuickReplies() bool {
+	if x != nil {
+		return x.QuickReplies
+	}
+	return false
+}
+
+func (x *StartConversationRequest) GetSimilarConversations() bool {
+	if x != nil {
+		return x.SimilarConversations
+	}
+	return false
+}
+
+type StartConversationResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ConversationId string                 `protobuf:"bytes,1,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	Title          string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Reply          string                 `protobuf:"bytes,3,opt,name=reply,proto3" json:"reply,omitempty"`
+	// Only set when requested and the server could suggest any
+	QuickReplies []*QuickReply `protobuf:"bytes,4,rep,name=quick_replies,json=quickReplies,proto3" json:"quick_replies,omitempty"`
+	// Only set when requested; most similar first
+	SimilarConversations []*SimilarConversation `protobuf:"bytes,5,rep,name=similar_conversations,json=similarConversations,proto3" json:"similar_conversations,omitempty"`
+	// Emoji of the conversation's category, as in Conversation
+	Icon          string `protobuf:"bytes,6,opt,name=icon,proto3" json:"icon,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StartConversationResponse) Reset() {
+	*x = StartConversationResponse{}
+	mi := &file_rpc_chat_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StartConversationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartConversationResponse) ProtoMessage() {}
+
+func (x *StartConversationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_chat_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartConversationResponse.ProtoReflect.Descriptor instead.
+func (*StartConversationResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_chat_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *StartConversationResponse) GetConversationId() string {
+	if x != nil {
+		return x.ConversationId
+	}
+	return ""
+}
+
+func (x *StartConversationResponse) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *StartConversationResponse) GetReply() string {
+	if x != nil {
+		return x.Reply
+	}
+	return ""
+}
+
+func (x *StartConversationResponse) GetQuickReplies() []*QuickReply {
+	if x != nil {
+		return x.QuickReplies
+	}
+	return nil
+}
+
+func (x *StartConversationResponse) GetSimilarConversations() []*SimilarConversation {
+	if x != nil {
+		return x.SimilarConversations
+	}
+	return nil
+}
+
+func (x *StartConversationResponse) GetIcon() string {
+	if x != nil {
+		return x.Icon
+	}
+	return ""
+}
+
+// Past conversation of the user about the same topic as a new one
+type SimilarConversation struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ConversationId string                 `protobuf:"bytes,1,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	Title          string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	// Cosine similarity of the closest message to the new conversation's first message
+	Score         float64 `protobuf:"fixed64,3,opt,name=score,proto3" json:"score,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SimilarConversation) Reset() {
+	*x = SimilarConversation{}
+	mi := &file_rpc_chat_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SimilarConversation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SimilarConversation) ProtoMessage() {}
+
+func (x *SimilarConversation) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_chat_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SimilarConversation.ProtoReflect.Descriptor instead.
+func (*SimilarConversation) Descriptor() ([]byte, []int) {
+	return file_rpc_chat_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *SimilarConversation) GetConversationId() string {
+	if x != nil {
+		return x.ConversationId
+	}
+	return ""
+}
+
+func (x *SimilarConversation) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *SimilarConversation) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+type ContinueConversationRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ConversationId string                 `protobuf:"bytes,1,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	Message        string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	ToolOptions    *ToolOptions           `protobuf:"bytes,3,opt,name=tool_options,json=toolOptions,proto3" json:"tool_options,omitempty"`
+	Verbosity      Verbosity              `protobuf:"varint,4,opt,name=verbosity,proto3,enum=acai.chat.Verbosity" json:"verbosity,omitempty"`
+	// Previously uploaded attachments to include with the message
+	AttachmentIds []string `protobuf:"bytes,5,rep,name=attachment_ids,json=attachmentIds,proto3" json:"attachment_ids,omitempty"`
+	// Suggest follow-ups to the reply, for clients to render as tappable chips
+	QuickReplies bool `protobuf:"varint,6,opt,name=quick_replies,json=quickReplies,proto3" json:"quick_replies,omitempty"`
+	// Maximum number of output tokens of this reply, lowering the conversation's; 0 keeps the conversation's
+	MaxOutputTokens int64 `protobuf:"varint,7,opt,name=max_output_tokens,json=maxOutputTokens,proto3" json:"max_output_tokens,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ContinueConversationRequest) Reset() {
+	*x = ContinueConversationRequest{}
+	mi := &file_rpc_chat_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ContinueConversationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ContinueConversationRequest) ProtoMessage() {}
+
+func (x *ContinueConversationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_chat_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ContinueConversationRequest.ProtoReflect.Descriptor instead.
+func (*ContinueConversationRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_chat_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ContinueConversationRequest) GetConversationId() string {
+	if x != nil {
+		return x.ConversationId
+	}
+	return ""
+}
+
+func (x *ContinueConversationRequest) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ContinueConversationRequest) GetToolOptions() *ToolOptions {
+	if x != nil {
+		return x.ToolOptions
+	}
+	return nil
+}
+
+func (x *ContinueConversationRequest) GetVerbosity() Verbosity {
+	if x != nil {
+		return x.Verbosity
+	}
+	return Verbosity_VERBOSITY_DEFAULT
+}
+
+func (x *ContinueConversationRequest) GetAttachmentIds() []string {
+	if x != nil {
+		return x.AttachmentIds
+	}
+	return nil
+}
+
+func (x *ContinueConversationRequest) GetQuickReplies() bool {
+	if x != nil {
+		return x.QuickReplies
+	}
+	return false
+}
+
+func (x *ContinueConversationRequest) GetMaxOutputTokens() int64 {
+	if x != nil {
+		return x.MaxOutputTokens
+	}
+	return 0
+}
+
+type ContinueConversationResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Reply string                 `protobuf:"bytes,1,opt,name=reply,proto3" json:"reply,omitempty"`
+	// Only set when requested and the server could suggest any
+	QuickReplies  []*QuickReply `protobuf:"bytes,2,rep,name=quick_replies,json=quickReplies,proto3" json:"quick_replies,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ContinueConversationResponse) Reset() {
+	*x = ContinueConversationResponse{}
+	mi := &file_rpc_chat_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ContinueConversationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ContinueConversationResponse) ProtoMessage() {}
+
+func (x *ContinueConversationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_chat_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ContinueConversationResponse.ProtoReflect.Descriptor instead.
+func (*ContinueConversationResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_chat_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ContinueConversationResponse) GetReply() string {
+	if x != nil {
+		return x.Reply
+	}
+	return ""
+}
+
+func (x *ContinueConversationResponse) GetQuickReplies() []*QuickReply {
+	if x != nil {
+		return x.QuickReplies
+	}
+	return nil
+}
+
+// Follow-up message users can send with a tap
+type QuickReply struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Short text of the chip, e.g. "3-day forecast"
+	Label string `protobuf:"bytes,1,opt,name=label,proto3" json:"label,omitempty"`
+	// Message sent when tapped, e.g. "Show me the 3 day forecast"
+	Message       string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QuickReply) Reset() {
+	*x = QuickReply{}
+	mi := &file_rpc_chat_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QuickReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QuickReply) ProtoMessage() {}
+
+func (x *QuickReply) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_chat_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QuickReply.ProtoReflect.Descriptor instead.
+func (*QuickReply) Descriptor() ([]byte, []int) {
+	return file_rpc_chat_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *QuickReply) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
+func (x *QuickReply) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type ListConversationsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Include a preview of the latest message, the message count and unread state
+	IncludePreview bool `protobuf:"varint,1,opt,name=include_preview,json=includePreview,proto3" json:"include_preview,omitempty"`
+	// Include archived conversations
+	IncludeArchived bool `protobuf:"varint,2,opt,name=include_archived,json=includeArchived,proto3" json:"include_archived,omitempty"`
+	// Fields of each conversation to return, e.g. "id,title,timestamp,preview"; unset returns them all. The preview,
+	// with the message count, is only computed when requested with include_preview and in the mask
+	ReadMask      *fieldmaskpb.FieldMask `protobuf:"bytes,3,opt,name=read_mask,json=readMask,proto3" json:"read_mask,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListConversationsRequest) Reset() {
+	*x = ListConversationsRequest{}
+	mi := &file_rpc_chat_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListConversationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListConversationsRequest) ProtoMessage() {}
+
+func (x *ListConversationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_chat_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListConversationsRequest.ProtoReflect.Descriptor instead.
+func (*ListConversationsRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_chat_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ListConversationsRequest) GetIncludePreview() bool {
+	if x != nil {
+		return x.IncludePreview
+	}
+	return false
+}
+
+func (x *ListConversationsRequest) GetIncludeArchived() bool {
+	if x != nil {
+		return x.IncludeArchived
+	}
+	return false
+}
+
+func (x *ListConversationsRequest) GetReadMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.ReadMask
+	}
+	return nil
+}
+
+type ListConversationsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Conversations []*Conversation        `protobuf:"bytes,1,rep,name=conversations,proto3" json:"conversations,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListConversationsResponse) Reset() {
+	*x = ListConversationsResponse{}
+	mi := &file_rpc_chat_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListConversationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListConversationsResponse) ProtoMessage() {}
+
+func (x *ListConversationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_chat_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListConversationsResponse.ProtoReflect.Descriptor instead.
+func (*ListConversationsResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_chat_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ListConversationsResponse) GetConversations() []*Conversation {
+	if x != nil {
+		return x.Conversations
+	}
+	return nil
+}
+
+type DescribeConversationRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ConversationId string                 `protobuf:"bytes,1,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	// Return a compact history, e.g. for mobile clients on slow networks; unset returns every message in full
+	History *HistoryOptions `protobuf:"bytes,2,opt,name=history,proto3" json:"history,omitempty"`
+	// Fields of the conversation to return, e.g. "id,title,timestamp"; unset returns them all. Fields left out aren't
+	// read from the database
+	ReadMask      *fieldmaskpb.FieldMask `protobuf:"bytes,3,opt,name=read_mask,json=readMask,proto3" json:"read_mask,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DescribeConversationRequest) Reset() {
+	*x = DescribeConversationRequest{}
+	mi := &file_rpc_chat_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DescribeConversationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DescribeConversationRequest) ProtoMessage() {}
+
+func (x *DescribeConversationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_chat_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DescribeConversationRequest.ProtoReflect.Descriptor instead.
+func (*DescribeConversationRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_chat_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *DescribeConversationRequest) GetConversationId() string {
+	if x != nil {
+		return x.ConversationId
+	}
+	return ""
+}
+
+func (x *DescribeConversationRequest) GetHistory() *HistoryOptions {
+	if x != nil {
+		return x.History
+	}
+	return nil
+}
+
+func (x *DescribeConversationRequest) GetReadMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.ReadMask
+	}
+	return nil
+}
+
+// Ways to shrink the message history of a conversation; they can be combined
+type HistoryOptions struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Return the summary of a long conversation instead of the messages it covers; conversations without one return
+	// every message
+	SummaryOnly bool `protobuf:"varint,1,opt,name=summary_only,json=summaryOnly,proto3" json:"summary_only,omitempty"`
+	// Return at most this many of the latest messages; 0 is no limit
+	MaxMessages int32 `protobuf:"varint,2,opt,name=max_messages,json=maxMessages,proto3" json:"max_messages,omitempty"`
+	// Compress message contents longer than this many bytes into content_gzip, when that makes them smaller; 0 never
+	// compresses
+	CompressOverBytes int32 `protobuf:"varint,3,opt,name=compress_over_bytes,json=compressOverBytes,proto3" json:"compress_over_bytes,omitempty"`
+	// Fields of each message to return, e.g. "id,role,timestamp"; unset returns them all
+	MessageMask   *fieldmaskpb.FieldMask `protobuf:"bytes,4,opt,name=message_mask,json=messageMask,proto3" json:"message_mask,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HistoryOptions) Reset() {
+	*x = HistoryOptions{}
+	mi := &file_rpc_chat_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HistoryOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HistoryOptions) ProtoMessage() {}
+
+func (x *HistoryOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_chat_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HistoryOptions.ProtoReflect.Descriptor instead.
+func (*HistoryOptions) Descriptor() ([]byte, []int) {
+	return file_rpc_chat_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *HistoryOptions) GetSummaryOnly() bool {
+	if x != nil {
+		return x.SummaryOnly
+	}
+	return false
+}
+
+func (x *HistoryOptions) GetMaxMessages() int32 {
+	if x != nil {
+		return x.MaxMessages
+	}
+	return 0
+}
+
+func (x *HistoryOptions) GetCompressOverBytes() int32 {
+	if x != nil {
+		return x.CompressOverBytes
+	}
+	return 0
+}
+
+func (x *HistoryOptions) GetMessageMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.MessageMask
+	}
+	return nil
+}
+
+type DescribeConversationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Conversation  *Conversation          `protobuf:"bytes,1,opt,name=conversation,proto3" json:"conversation,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DescribeConversationResponse) Reset() {
+	*x = DescribeConversationResponse{}
+	mi := &file_rpc_chat_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DescribeConversationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DescribeConversationResponse) ProtoMessage() {}
+
+func (x *DescribeConversationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_chat_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DescribeConversationResponse.ProtoReflect.Descriptor instead.
+func (*DescribeConversationResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_chat_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *DescribeConversationResponse) GetConversation() *Conversation {
+	if x != nil {
+		return x.Conversation
+	}
+	return nil
+}
+
+type RetryFailedReplyRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ConversationId string                 `protobuf:"bytes,1,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *RetryFailedReplyRequest) Reset() {
+	*x = RetryFailedReplyRequest{}
+	mi := &file_rpc_chat_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RetryFailedReplyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RetryFailedReplyRequest) ProtoMessage() {}
+
+func (x *RetryFailedReplyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_chat_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RetryFailedReplyRequest.ProtoReflect.Descriptor instead.
+func (*RetryFailedReplyRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_chat_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *RetryFailedReplyRequest) GetConversationId() string {
+	if x != nil {
+		return x.ConversationId
+	}
+	return ""
+}
+
+type RetryFailedReplyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Reply         string                 `protobuf:"bytes,1,opt,name=reply,proto3" json:"reply,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RetryFailedReplyResponse) Reset() {
+	*x = RetryFailedReplyResponse{}
+	mi := &file_rpc_chat_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RetryFailedReplyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RetryFailedReplyResponse) ProtoMessage() {}
+
+func (x *RetryFailedReplyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_chat_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RetryFailedReplyResponse.ProtoReflect.Descriptor instead.
+func (*RetryFailedReplyResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_chat_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *RetryFailedReplyResponse) GetReply() string {
+	if x != nil {
+		return x.Reply
+	}
+	return ""
+}
+
+type CancelGenerationRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ConversationId string                 `protobuf:"bytes,1,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *CancelGenerationRequest) Reset() {
+	*x = CancelGenerationRequest{}
+	mi := &file_rpc_chat_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelGenerationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelGenerationRequest) ProtoMessage() {}
+
+func (x *CancelGenerationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_chat_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelGenerationRequest.ProtoReflect.Descriptor instead.
+func (*CancelGenerationRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_chat_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *CancelGenerationRequest) GetConversationId() string {
+	if x != nil {
+		return x.ConversationId
+	}
+	return ""
+}
+
+type CancelGenerationResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Whether a reply was being generated; the message it answered gets a failed_reply notice and can be retried
+	Cancelled     bool `protobuf:"varint,1,opt,name=cancelled,proto3" json:"cancelled,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelGenerationResponse) Reset() {
+	*x = CancelGenerationResponse{}
+	mi := &file_rpc_chat_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelGenerationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelGenerationResponse) ProtoMessage() {}
+
+func (x *CancelGenerationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_chat_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelGenerationResponse.ProtoReflect.Descriptor instead.
+func (*CancelGenerationResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_chat_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *CancelGenerationResponse) GetCancelled() bool {
+	if x != nil {
+		return x.Cancelled
+	}
+	return false
+}
+
+type MarkReadRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ConversationId string                 `protobuf:"bytes,1,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	// Latest message the user has seen; empty marks the whole conversation as read
+	MessageId     string `protobuf:"bytes,2,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MarkReadRequest) Reset() {
+	*x = MarkReadRequest{}
+	mi := &file_rpc_chat_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MarkReadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MarkReadRequest) ProtoMessage() {}
+
+func (x *MarkReadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_chat_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MarkReadRequest.ProtoReflect.Descriptor instead.
+func (*MarkReadRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_chat_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *MarkReadRequest) GetConversationId() string {
+	if x != nil {
+		return x.ConversationId
+	}
+	return ""
+}
+
+func (x *MarkReadRequest) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+type MarkReadResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MarkReadResponse) Reset() {
+	*x = MarkReadResponse{}
+	mi := &file_rpc_chat_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MarkReadResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MarkReadResponse) ProtoMessage() {}
+
+func (x *MarkReadResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_chat_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MarkReadResponse.ProtoReflect.Descriptor instead.
+func (*MarkReadResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_chat_proto_rawDescGZIP(), []int{19}
+}
+
+type SearchSemanticRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Query string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	// Maximum number of results; defaults to 10, at most 50
+	Limit         int32 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchSemanticRequest) Reset() {
+	*x = SearchSemanticRequest{}
+	mi := &file_rpc_chat_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchSemanticRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchSemanticRequest) ProtoMessage() {}
+
+func (x *SearchSemanticRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_chat_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchSemanticRequest.ProtoReflect.Descriptor instead.
+func (*SearchSemanticRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_chat_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *SearchSemanticRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *SearchSemanticRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type SearchSemanticResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Most relevant first
+	Results       []*SearchSemanticResponse_Result `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchSemanticResponse) Reset() {
+	*x = SearchSemanticResponse{}
+	mi := &file_rpc_chat_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchSemanticResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchSemanticResponse) ProtoMessage() {}
+
+func (x *SearchSemanticResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_chat_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchSemanticResponse.ProtoReflect.Descriptor instead.
+func (*SearchSemanticResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_chat_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *SearchSemanticResponse) GetResults() []*SearchSemanticResponse_Result {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+type Attachment struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Filename      string                 `protobuf:"bytes,2,opt,name=filename,proto3" json:"filename,omitempty"`
+	ContentType   string                 `protobuf:"bytes,3,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	Size          int64                  `protobuf:"varint,4,opt,name=size,proto3" json:"size,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Attachment) Reset() {
+	*x = Attachment{}
+	mi := &file_rpc_chat_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Attachment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Attachment) ProtoMessage() {}
+
+func (x *Attachment) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_chat_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Attachment.ProtoReflect.Descriptor instead.
+func (*Attachment) Descriptor() ([]byte, []int) {
+	return file_rpc_chat_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *Attachment) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Attachment) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *Attachment) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *Attachment) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *Attachment) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type SetConversationLanguageRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ConversationId string                 `protobuf:"bytes,1,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	// BCP 47 tag, e.g. "es" or "pt-BR"; empty removes the lock, letting replies follow the user
+	Language      string `protobuf:"bytes,2,opt,name=language,proto3" json:"language,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetConversationLanguageRequest) Reset() {
+	*x = SetConversationLanguageRequest{}
+	mi := &file_rpc_chat_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetConversationLanguageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetConversationLanguageRequest) ProtoMessage() {}
+
+func (x *SetConversationLanguageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_chat_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetConversationLanguageRequest.ProtoReflect.Descriptor instead.
+func (*SetConversationLanguageRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_chat_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *SetConversationLanguageRequest) GetConversationId() string {
+	if x != nil {
+		return x.ConversationId
+	}
+	return ""
+}
+
+func (x *SetConversationLanguageRequest) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+type SetConversationLanguageResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Canonical form of the requested tag
+	Language      string `protobuf:"bytes,1,opt,name=language,proto3" json:"language,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetConversationLanguageResponse) Reset() {
+	*x = SetConversationLanguageResponse{}
+	mi := &file_rpc_chat_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetConversationLanguageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetConversationLanguageResponse) ProtoMessage() {}
+
+func (x *SetConversationLanguageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_chat_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetConversationLanguageResponse.ProtoReflect.Descriptor instead.
+func (*SetConversationLanguageResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_chat_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *SetConversationLanguageResponse) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+type UploadAttachmentRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Filename string                 `protobuf:"bytes,1,opt,name=filename,proto3" json:"filename,omitempty"`
+	// MIME type of the file, e.g. "image/png"; must match its contents
+	ContentType   string `protobuf:"bytes,2,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	Data          []byte `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadAttachmentRequest) Reset() {
+	*x = UploadAttachmentRequest{}
+	mi := &file_rpc_chat_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadAttachmentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadAttachmentRequest) ProtoMessage() {}
+
+func (x *UploadAttachmentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_chat_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadAttachmentRequest.ProtoReflect.Descriptor instead.
+func (*UploadAttachmentRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_chat_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *UploadAttachmentRequest) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *UploadAttachmentRequest) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *UploadAttachmentRequest) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type UploadAttachmentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Attachment    *Attachment            `protobuf:"bytes,1,opt,name=attachment,proto3" json:"attachment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadAttachmentResponse) Reset() {
+	*x = UploadAttachmentResponse{}
+	mi := &file_rpc_chat_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadAttachmentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadAttachmentResponse) ProtoMessage() {}
+
+func (x *UploadAttachmentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_chat_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadAttachmentResponse.ProtoReflect.Descriptor instead.
+func (*UploadAttachmentResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_chat_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *UploadAttachmentResponse) GetAttachment() *Attachment {
+	if x != nil {
+		return x.Attachment
+	}
+	return nil
+}
+
+type DownloadAttachmentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AttachmentId  string                 `protobuf:"bytes,1,opt,name=attachment_id,json=attachmentId,proto3" json:"attachment_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DownloadAttachmentRequest) Reset() {
+	*x = DownloadAttachmentRequest{}
+	mi := &file_rpc_chat_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DownloadAttachmentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DownloadAttachmentRequest) ProtoMessage() {}
+
+func (x *DownloadAttachmentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_chat_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DownloadAttachmentRequest.ProtoReflect.Descriptor instead.
+func (*DownloadAttachmentRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_chat_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *DownloadAttachmentRequest) GetAttachmentId() string {
+	if x != nil {
+		return x.AttachmentId
+	}
+	return ""
+}
+
+type DownloadAttachmentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Attachment    *Attachment            `protobuf:"bytes,1,opt,name=attachment,proto3" json:"attachment,omitempty"`
+	Data          []byte                 `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DownloadAttachmentResponse) Reset() {
+	*x = DownloadAttachmentResponse{}
+	mi := &file_rpc_chat_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DownloadAttachmentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DownloadAttachmentResponse) ProtoMessage() {}
+
+func (x *DownloadAttachmentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_chat_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DownloadAttachmentResponse.ProtoReflect.Descriptor instead.
+func (*DownloadAttachmentResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_chat_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *DownloadAttachmentResponse) GetAttachment() *Attachment {
+	if x != nil {
+		return x.Attachment
+	}
+	return nil
+}
+
+func (x *DownloadAttachmentResponse) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+// A named place of a user, e.g. "home" or "the office"
+type LocationAlias struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Name as the user refers to the place; matched case-insensitively, ignoring a leading "my" or "the"
+	Name      string  `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Latitude  float64 `protobuf:"fixed64,2,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude float64 `protobuf:"fixed64,3,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	// Optional description for the assistant, e.g. "Carrer de Mallorca 401, Barcelona"
+	Label         string `protobuf:"bytes,4,opt,name=label,proto3" json:"label,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LocationAlias) Reset() {
+	*x = LocationAlias{}
+	mi := &file_rpc_chat_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LocationAlias) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LocationAlias) ProtoMessage() {}
+
+func (x *LocationAlias) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_chat_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LocationAlias.ProtoReflect.Descriptor instead.
+func (*LocationAlias) Descriptor() ([]byte, []int) {
+	return file_rpc_chat_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *LocationAlias) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *LocationAlias) GetLatitude() float64 {
+	if x != nil {
+		return x.Latitude
+	}
+	return 0
+}
+
+func (x *LocationAlias) GetLongitude() float64 {
+	if x != nil {
+		return x.Longitude
+	}
+	return 0
+}
+
+func (x *LocationAlias) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
+type SetLocationAliasRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Replaces any saved place with the same name
+	Alias         *LocationAlias `protobuf:"bytes,1,opt,name=alias,proto3" json:"alias,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetLocationAliasRequest) Reset() {
+	*x = SetLocationAliasRequest{}
+	mi := &file_rpc_chat_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetLocationAliasRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetLocationAliasRequest) ProtoMessage() {}
+
+func (x *SetLocationAliasRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_chat_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetLocationAliasRequest.ProtoReflect.Descriptor instead.
+func (*SetLocationAliasRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_chat_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *SetLocationAliasRequest) GetAlias() *LocationAlias {
+	if x != nil {
+		return x.Alias
+	}
+	return nil
+}
+
+type SetLocationAliasResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Alias         *LocationAlias         `protobuf:"bytes,1,opt,name=alias,proto3" json:"alias,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetLocationAliasResponse) Reset() {
+	*x = SetLocationAliasResponse{}
+	mi := &file_rpc_chat_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetLocationAliasResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetLocationAliasResponse) ProtoMessage() {}
+
+func (x *SetLocationAliasResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_chat_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetLocationAliasResponse.ProtoReflect.Descriptor instead.
+func (*SetLocationAliasResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_chat_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *SetLocationAliasResponse) GetAlias() *LocationAlias {
+	if x != nil {
+		return x.Alias
+	}
+	return nil
+}
+
+type DeleteLocationAliasRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteLocationAliasRequest) Reset() {
+	*x = DeleteLocationAliasRequest{}
+	mi := &file_rpc_chat_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteLocationAliasRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteLocationAliasRequest) ProtoMessage() {}
+
+func (x *DeleteLocationAliasRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_chat_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteLocationAliasRequest.ProtoReflect.Descriptor instead.
+func (*DeleteLocationAliasRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_chat_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *DeleteLocationAliasRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type DeleteLocationAliasResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteLocationAliasResponse) Reset() {
+	*x = DeleteLocationAliasResponse{}
+	mi := &file_rpc_chat_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteLocationAliasResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteLocationAliasResponse) ProtoMessage() {}
+
+func (x *DeleteLocationAliasResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_chat_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteLocationAliasResponse.ProtoReflect.Descriptor instead.
+func (*DeleteLocationAliasResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_chat_proto_rawDescGZIP(), []int{33}
+}
+
+type ListLocationAliasesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListLocationAliasesRequest) Reset() {
+	*x = ListLocationAliasesRequest{}
+	mi := &file_rpc_chat_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLocationAliasesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLocationAliasesRequest) ProtoMessage() {}
+
+func (x *ListLocationAliasesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_chat_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLocationAliasesRequest.ProtoReflect.Descriptor instead.
+func (*ListLocationAliasesRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_chat_proto_rawDescGZIP(), []int{34}
+}
+
+type ListLocationAliasesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Aliases       []*LocationAlias       `protobuf:"bytes,1,rep,name=aliases,proto3" json:"aliases,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListLocationAliasesResponse) Reset() {
+	*x = ListLocationAliasesResponse{}
+	mi := &file_rpc_chat_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLocationAliasesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLocationAliasesResponse) ProtoMessage() {}
+
+func (x *ListLocationAliasesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_chat_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLocationAliasesResponse.ProtoReflect.Descriptor instead.
+func (*ListLocationAliasesResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_chat_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *ListLocationAliasesResponse) GetAliases() []*LocationAlias {
+	if x != nil {
+		return x.Aliases
+	}
+	return nil
+}
+
+// An ICS calendar feed of a user, e.g. their work calendar or their children's school holidays
+type UserCalendar struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Name as the user refers to the calendar, e.g. "work"; matched case-insensitively, ignoring a leading "my" or "the"
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// https:// or webcal:// URL of the feed
+	Url           string `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserCalendar) Reset() {
+	*x = UserCalendar{}
+	mi := &file_rpc_chat_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserCalendar) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserCalendar) ProtoMessage() {}
+
+func (x *UserCalendar) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_chat_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserCalendar.ProtoReflect.Descriptor instead.
+func (*UserCalendar) Descriptor() ([]byte, []int) {
+	return file_rpc_chat_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *UserCalendar) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UserCalendar) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+type SetUserCalendarRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Replaces any linked calendar with the same name
+	Calendar      *UserCalendar `protobuf:"bytes,1,opt,name=calendar,proto3" json:"calendar,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetUserCalendarRequest) Reset() {
+	*x = SetUserCalendarRequest{}
+	mi := &file_rpc_chat_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetUserCalendarRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetUserCalendarRequest) ProtoMessage() {}
+
+func (x *SetUserCalendarRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_chat_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetUserCalendarRequest.ProtoReflect.Descriptor instead.
+func (*SetUserCalendarRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_chat_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *SetUserCalendarRequest) GetCalendar() *UserCalendar {
+	if x != nil {
+		return x.Calendar
+	}
+	return nil
+}
+
+type SetUserCalendarResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Calendar      *UserCalendar          `protobuf:"bytes,1,opt,name=calendar,proto3" json:"calendar,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetUserCalendarResponse) Reset() {
+	*x = SetUserCalendarResponse{}
+	mi := &file_rpc_chat_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetUserCalendarResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetUserCalendarResponse) ProtoMessage() {}
+
+func (x *SetUserCalendarResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_chat_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetUserCalendarResponse.ProtoReflect.Descriptor instead.
+func (*SetUserCalendarResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_chat_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *SetUserCalendarResponse) GetCalendar() *UserCalendar {
+	if x != nil {
+		return x.Calendar
+	}
+	return nil
+}
+
+type DeleteUserCalendarRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteUserCalendarRequest) Reset() {
+	*x = DeleteUserCalendarRequest{}
+	mi := &file_rpc_chat_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteUserCalendarRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteUserCalendarRequest) ProtoMessage() {}
+
+func (x *DeleteUserCalendarRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_chat_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteUserCalendarRequest.ProtoReflect.Descriptor instead.
+func (*DeleteUserCalendarRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_chat_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *DeleteUserCalendarRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type DeleteUserCalendarResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteUserCalendarResponse) Reset() {
+	*x = DeleteUserCalendarResponse{}
+	mi := &file_rpc_chat_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteUserCalendarResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteUserCalendarResponse) ProtoMessage() {}
+
+func (x *DeleteUserCalendarResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_chat_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteUserCalendarResponse.ProtoReflect.Descriptor instead.
+func (*DeleteUserCalendarResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_chat_proto_rawDescGZIP(), []int{40}
+}
+
+type ListUserCalendarsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUserCalendarsRequest) Reset() {
+	*x = ListUserCalendarsRequest{}
+	mi := &file_rpc_chat_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUserCalendarsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUserCalendarsRequest) ProtoMessage() {}
+
+func (x *ListUserCalendarsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_chat_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUserCalendarsRequest.ProtoReflect.Descriptor instead.
+func (*ListUserCalendarsRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_chat_proto_rawDescGZIP(), []int{41}
+}
+
+type ListUserCalendarsResponse struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Calendars []*UserCalendar        `protobuf:"bytes,1,rep,name=calendars,proto3" json:"calendars,omitempty"`
+	// The connected CalDAV calendar, if any
+	Caldav        *CalDAVAccount `protobuf:"bytes,2,opt,name=caldav,proto3" json:"caldav,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUserCalendarsResponse) Reset() {
+	*x = ListUserCalendarsResponse{}
+	mi := &file_rpc_chat_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUserCalendarsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUserCalendarsResponse) ProtoMessage() {}
+
+func (x *ListUserCalendarsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_chat_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUserCalendarsResponse.ProtoReflect.Descriptor instead.
+func (*ListUserCalendarsResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_chat_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *ListUserCalendarsResponse) GetCalendars() []*UserCalendar {
+	if x != nil {
+		return x.Calendars
+	}
+	return nil
+}
+
+func (x *ListUserCalendarsResponse) GetCaldav() *CalDAVAccount {
+	if x != nil {
+		return x.Caldav
+	}
+	return nil
+}
+
+// A CalDAV calendar the assistant may add events to; its password is never returned
+type CalDAVAccount struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// https:// URL of the calendar collection, e.g. "https://caldav.example.com/calendars/alice/work/"
+	Url           string `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	Username      string `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CalDAVAccount) Reset() {
+	*x = CalDAVAccount{}
+	mi := &file_rpc_chat_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CalDAVAccount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CalDAVAccount) ProtoMessage() {}
+
+func (x *CalDAVAccount) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_chat_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CalDAVAccount.ProtoReflect.Descriptor instead.
+func (*CalDAVAccount) Descriptor() ([]byte, []int) {
+	return file_rpc_chat_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *CalDAVAccount) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *CalDAVAccount) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+type SetCalDAVAccountRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Replaces any connected calendar
+	Account *CalDAVAccount `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+	// Password or app-specific password of the account; stored encrypted
+	Password      string `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetCalDAVAccountRequest) Reset() {
+	*x = SetCalDAVAccountRequest{}
+	mi := &file_rpc_chat_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetCalDAVAccountRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetCalDAVAccountRequest) ProtoMessage() {}
+
+func (x *SetCalDAVAccountRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_chat_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetCalDAVAccountRequest.ProtoReflect.Descriptor instead.
+func (*SetCalDAVAccountRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_chat_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *SetCalDAVAccountRequest) GetAccount() *CalDAVAccount {
+	if x != nil {
+		return x.Account
+	}
+	return nil
+}
+
+func (x *SetCalDAVAccountRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+type SetCalDAVAccountResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Account       *CalDAVAccount         `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetCalDAVAccountResponse) Reset() {
+	*x = SetCalDAVAccountResponse{}
+	mi := &file_rpc_chat_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetCalDAVAccountResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetCalDAVAccountResponse) ProtoMessage() {}
+
+func (x *SetCalDAVAccountResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_chat_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetCalDAVAccountResponse.ProtoReflect.Descriptor instead.
+func (*SetCalDAVAccountResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_chat_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *SetCalDAVAccountResponse) GetAccount() *CalDAVAccount {
+	if x != nil {
+		return x.Account
+	}
+	return nil
+}
+
+type DeleteCalDAVAccountRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteCalDAVAccountRequest) Reset() {
+	*x = DeleteCalDAVAccountRequest{}
+	mi := &file_rpc_chat_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteCalDAVAccountRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteCalDAVAccountRequest) ProtoMessage() {}
+
+func (x *DeleteCalDAVAccountRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_chat_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteCalDAVAccountRequest.ProtoReflect.Descriptor instead.
+func (*DeleteCalDAVAccountRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_chat_proto_rawDescGZIP(), []int{46}
+}
+
+type DeleteCalDAVAccountResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteCalDAVAccountResponse) Reset() {
+	*x = DeleteCalDAVAccountResponse{}
+	mi := &file_rpc_chat_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteCalDAVAccountResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteCalDAVAccountResponse) ProtoMessage() {}
+
+func (x *DeleteCalDAVAccountResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_chat_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteCalDAVAccountResponse.ProtoReflect.Descriptor instead.
+func (*DeleteCalDAVAccountResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_chat_proto_rawDescGZIP(), []int{47}
+}
+
+// Selects conversations for bulk operations; set fields must all match
+type ConversationFilter struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Conversations last updated before this time
+	OlderThan *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=older_than,json=olderThan,proto3" json:"older_than,omitempty"`
+	// Conversations with this tag
+	Tag           string `protobuf:"bytes,2,opt,name=tag,proto3" json:"tag,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConversationFilter) Reset() {
+	*x = ConversationFilter{}
+	mi := &file_rpc_chat_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConversationFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConversationFilter) ProtoMessage() {}
+
+func (x *ConversationFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_chat_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConversationFilter.ProtoReflect.Descriptor instead.
+func (*ConversationFilter) Descriptor() ([]byte, []int) {
+	return file_rpc_chat_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *ConversationFilter) GetOlderThan() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OlderThan
+	}
+	return nil
+}
+
+func (x *ConversationFilter) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+type BulkDeleteConversationsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Either conversation_ids or filter is required
+	ConversationIds []string            `protobuf:"bytes,1,rep,name=conversation_ids,json=conversationIds,proto3" json:"conversation_ids,omitempty"`
+	Filter          *ConversationFilter `protobuf:"bytes,2,opt,name=filter,proto3" json:"filter,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *BulkDeleteConversationsRequest) Reset() {
+	*x = BulkDeleteConversationsRequest{}
+	mi := &file_rpc_chat_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BulkDeleteConversationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BulkDeleteConversationsRequest) ProtoMessage() {}
+
+func (x *BulkDeleteConversationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_chat_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BulkDeleteConversationsRequest.ProtoReflect.Descriptor instead.
+func (*BulkDeleteConversationsRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_chat_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *BulkDeleteConversationsRequest) GetConversationIds() []string {
+	if x != nil {
+		return x.ConversationIds
+	}
+	return nil
+}
+
+func (x *BulkDeleteConversationsRequest) GetFilter() *ConversationFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+type BulkDeleteConversationsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Number of conversations deleted; 0 when a background job was started
+	Count int32 `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+	// Background job deleting the conversations, for sets too large to delete right away
+	JobId         string `protobuf:"bytes,2,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BulkDeleteConversationsResponse) Reset() {
+	*x = BulkDeleteConversationsResponse{}
+	mi := &file_rpc_chat_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BulkDeleteConversationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BulkDeleteConversationsResponse) ProtoMessage() {}
+
+func (x *BulkDeleteConversationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_chat_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BulkDeleteConversationsResponse.ProtoReflect.Descriptor instead.
+func (*BulkDeleteConversationsResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_chat_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *BulkDeleteConversationsResponse) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *BulkDeleteConversationsResponse) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+type BulkArchiveConversationsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Either conversation_ids or filter is required
+	ConversationIds []string            `protobuf:"bytes,1,rep,name=conversation_ids,json=conversationIds,proto3" json:"conversation_ids,omitempty"`
+	Filter          *ConversationFilter `protobuf:"bytes,2,opt,name=filter,proto3" json:"filter,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *BulkArchiveConversationsRequest) Reset() {
+	*x = BulkArchiveConversationsRequest{}
+	mi := &file_rpc_chat_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BulkArchiveConversationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BulkArchiveConversationsRequest) ProtoMessage() {}
+
+func (x *BulkArchiveConversationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_chat_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BulkArchiveConversationsRequest.ProtoReflect.Descriptor instead.
+func (*BulkArchiveConversationsRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_chat_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *BulkArchiveConversationsRequest) GetConversationIds() []string {
+	if x != nil {
+		return x.ConversationIds
+	}
+	return nil
+}
+
+func (x *BulkArchiveConversationsRequest) GetFilter() *ConversationFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+type BulkArchiveConversationsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Number of conversations archived; 0 when a background job was started
+	Count int32 `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+	// Background job archiving the conversations, for sets too large to archive right away
+	JobId         string `protobuf:"bytes,2,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BulkArchiveConversationsResponse) Reset() {
+	*x = BulkArchiveConversationsResponse{}
+	mi := &file_rpc_chat_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BulkArchiveConversationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BulkArchiveConversationsResponse) ProtoMessage() {}
+
+func (x *BulkArchiveConversationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_chat_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BulkArchiveConversationsResponse.ProtoReflect.Descriptor instead.
+func (*BulkArchiveConversationsResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_chat_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *BulkArchiveConversationsResponse) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *BulkArchiveConversationsResponse) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+type BulkJob struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// "delete", "archive" or "export"
+	Action string        `protobuf:"bytes,2,opt,name=action,proto3" json:"action,omitempty"`
+	State  BulkJob_State `protobuf:"varint,3,opt,name=state,proto3,enum=acai.chat.BulkJob_State" json:"state,omitempty"`
+	// Number of conversations selected, and how many were processed so far
+	Total int32 `protobuf:"varint,4,opt,name=total,proto3" json:"total,omitempty"`
+	Count int32 `protobuf:"varint,5,opt,name=count,proto3" json:"count,omitempty"`
+	// Why the job failed
+	Error     string                 `protobuf:"bytes,6,opt,name=error,proto3" json:"error,omitempty"`
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	// Where to download the archive of a finished export, relative to the API
+	DownloadUrl   string `protobuf:"bytes,9,opt,name=download_url,json=downloadUrl,proto3" json:"download_url,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BulkJob) Reset() {
+	*x = BulkJob{}
+	mi := &file_rpc_chat_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BulkJob) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BulkJob) ProtoMessage() {}
+
+func (x *BulkJob) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_chat_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BulkJob.ProtoReflect.Descriptor instead.
+func (*BulkJob) Descriptor() ([]byte, []int) {
+	return file_rpc_chat_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *BulkJob) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *BulkJob) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *BulkJob) GetState() BulkJob_State {
+	if x != nil {
+		return x.State
+	}
+	return BulkJob_UNKNOWN
+}
+
+func (x *BulkJob) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *BulkJob) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *BulkJob) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *BulkJob) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *BulkJob) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *BulkJob) GetDownloadUrl() string {
+	if x != nil {
+		return x.DownloadUrl
+	}
+	return ""
+}
+
+type GetBulkJobRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	JobId         string                 `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBulkJobRequest) Reset() {
+	*x = GetBulkJobRequest{}
+	mi := &file_rpc_chat_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBulkJobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBulkJobRequest) ProtoMessage() {}
+
+func (x *GetBulkJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_chat_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBulkJobRequest.ProtoReflect.Descriptor instead.
+func (*GetBulkJobRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_chat_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *GetBulkJobRequest) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+type GetBulkJobResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Job           *BulkJob               `protobuf:"bytes,1,opt,name=job,proto3" json:"job,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBulkJobResponse) Reset() {
+	*x = GetBulkJobResponse{}
+	mi := &file_rpc_chat_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBulkJobResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBulkJobResponse) ProtoMessage() {}
+
+func (x *GetBulkJobResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_chat_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBulkJobResponse.ProtoReflect.Descriptor instead.
+func (*GetBulkJobResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_chat_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *GetBulkJobResponse) GetJob() *BulkJob {
+	if x != nil {
+		return x.Job
+	}
+	return nil
+}
+
+type RequestExportArchiveRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequestExportArchiveRequest) Reset() {
+	*x = RequestExportArchiveRequest{}
+	mi := &file_rpc_chat_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestExportArchiveRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestExportArchiveRequest) ProtoMessage() {}
+
+func (x *RequestExportArchiveRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_chat_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestExportArchiveRequest.ProtoReflect.Descriptor instead.
+func (*RequestExportArchiveRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_chat_proto_rawDescGZIP(), []int{56}
+}
+
+type RequestExportArchiveResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Background job building the archive
+	JobId         string `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequestExportArchiveResponse) Reset() {
+	*x = RequestExportArchiveResponse{}
+	mi := &file_rpc_chat_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestExportArchiveResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestExportArchiveResponse) ProtoMessage() {}
+
+func (x *RequestExportArchiveResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_chat_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestExportArchiveResponse.ProtoReflect.Descriptor instead.
+func (*RequestExportArchiveResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_chat_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *RequestExportArchiveResponse) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+type ExportConversationRequest struct {
+	state          protoimpl.MessageState           `protogen:"open.v1"`
+	ConversationId string                           `protobuf:"bytes,1,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	Format         ExportConversationRequest_Format `protobuf:"varint,2,opt,name=format,proto3,enum=acai.chat.ExportConversationRequest_Format" json:"format,omitempty"`
+	// Include the tools the assistant called for each reply
+	IncludeToolTraces bool `protobuf:"varint,3,opt,name=include_tool_traces,json=includeToolTraces,proto3" json:"include_tool_traces,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *ExportConversationRequest) Reset() {
+	*x = ExportConversationRequest{}
+	mi := &file_rpc_chat_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportConversationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportConversationRequest) ProtoMessage() {}
+
+func (x *ExportConversationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_chat_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportConversationRequest.ProtoReflect.Descriptor instead.
+func (*ExportConversationRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_chat_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *ExportConversationRequest) GetConversationId() string {
+	if x != nil {
+		return x.ConversationId
+	}
+	return ""
+}
+
+func (x *ExportConversationRequest) GetFormat() ExportConversationRequest_Format {
+	if x != nil {
+		return x.Format
+	}
+	return ExportConversationRequest_MARKDOWN
+}
+
+func (x *ExportConversationRequest) GetIncludeToolTraces() bool {
+	if x != nil {
+		return x.IncludeToolTraces
+	}
+	return false
+}
+
+type ExportConversationResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Suggested file name, e.g. "2025-08-20-trip-to-barcelona.pdf"
+	Filename      string `protobuf:"bytes,1,opt,name=filename,proto3" json:"filename,omitempty"`
+	ContentType   string `protobuf:"bytes,2,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	Data          []byte `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportConversationResponse) Reset() {
+	*x = ExportConversationResponse{}
+	mi := &file_rpc_chat_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportConversationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportConversationResponse) ProtoMessage() {}
+
+func (x *ExportConversationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_chat_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportConversationResponse.ProtoReflect.Descriptor instead.
+func (*ExportConversationResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_chat_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *ExportConversationResponse) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *ExportConversationResponse) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *ExportConversationResponse) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type PinMessageRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ConversationId string                 `protobuf:"bytes,1,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	MessageId      string                 `protobuf:"bytes,2,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	// Remove the pin instead
+	Unpin         bool `protobuf:"varint,3,opt,name=unpin,proto3" json:"unpin,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PinMessageRequest) Reset() {
+	*x = PinMessageRequest{}
+	mi := &file_rpc_chat_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PinMessageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PinMessageRequest) ProtoMessage() {}
+
+func (x *PinMessageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_chat_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PinMessageRequest.ProtoReflect.Descriptor instead.
+func (*PinMessageRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_chat_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *PinMessageRequest) GetConversationId() string {
+	if x != nil {
+		return x.ConversationId
+	}
+	return ""
+}
+
+func (x *PinMessageRequest) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+func (x *PinMessageRequest) GetUnpin() bool {
+	if x != nil {
+		return x.Unpin
+	}
+	return false
+}
+
+type PinMessageResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       *Conversation_Message  `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PinMessageResponse) Reset() {
+	*x = PinMessageResponse{}
+	mi := &file_rpc_chat_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PinMessageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PinMessageResponse) ProtoMessage() {}
+
+func (x *PinMessageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_chat_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PinMessageResponse.ProtoReflect.Descriptor instead.
+func (*PinMessageResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_chat_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *PinMessageResponse) GetMessage() *Conversation_Message {
+	if x != nil {
+		return x.Message
+	}
+	return nil
+}
+
+type ListPinnedMessagesRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ConversationId string                 `protobuf:"bytes,1,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ListPinnedMessagesRequest) Reset() {
+	*x = ListPinnedMessagesRequest{}
+	mi := &file_rpc_chat_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPinnedMessagesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPinnedMessagesRequest) ProtoMessage() {}
+
+func (x *ListPinnedMessagesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_chat_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPinnedMessagesRequest.ProtoReflect.Descriptor instead.
+func (*ListPinnedMessagesRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_chat_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *ListPinnedMessagesRequest) GetConversationId() string {
+	if x != nil {
+		return x.ConversationId
+	}
+	return ""
+}
+
+type ListPinnedMessagesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Pinned messages in conversation order
+	Messages      []*Conversation_Message `protobuf:"bytes,1,rep,name=messages,proto3" json:"messages,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPinnedMessagesResponse) Reset() {
+	*x = ListPinnedMessagesResponse{}
+	mi := &file_rpc_chat_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPinnedMessagesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPinnedMessagesResponse) ProtoMessage() {}
+
+func (x *ListPinnedMessagesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_chat_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPinnedMessagesResponse.ProtoReflect.Descriptor instead.
+func (*ListPinnedMessagesResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_chat_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *ListPinnedMessagesResponse) GetMessages() []*Conversation_Message {
+	if x != nil {
+		return x.Messages
+	}
+	return nil
+}
+
+type RateMessageRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ConversationId string                 `protobuf:"bytes,1,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	// An assistant message of the conversation
+	MessageId string `protobuf:"bytes,2,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	// Unspecified clears the rating
+	Rating        MessageRating `protobuf:"varint,3,opt,name=rating,proto3,enum=acai.chat.MessageRating" json:"rating,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RateMessageRequest) Reset() {
+	*x = RateMessageRequest{}
+	mi := &file_rpc_chat_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RateMessageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RateMessageRequest) ProtoMessage() {}
+
+func (x *RateMessageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_chat_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RateMessageRequest.ProtoReflect.Descriptor instead.
+func (*RateMessageRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_chat_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *RateMessageRequest) GetConversationId() string {
+	if x != nil {
+		return x.ConversationId
+	}
+	return ""
+}
+
+func (x *RateMessageRequest) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+func (x *RateMessageRequest) GetRating() MessageRating {
+	if x != nil {
+		return x.Rating
+	}
+	return MessageRating_MESSAGE_RATING_UNSPECIFIED
+}
+
+type RateMessageResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       *Conversation_Message  `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RateMessageResponse) Reset() {
+	*x = RateMessageResponse{}
+	mi := &file_rpc_chat_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RateMessageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RateMessageResponse) ProtoMessage() {}
+
+func (x *RateMessageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_chat_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RateMessageResponse.ProtoReflect.Descriptor instead.
+func (*RateMessageResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_chat_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *RateMessageResponse) GetMessage() *Conversation_Message {
+	if x != nil {
+		return x.Message
+	}
+	return nil
+}
+
+type DigestSubscription struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Frequency DigestFrequency        `protobuf:"varint,1,opt,name=frequency,proto3,enum=acai.chat.DigestFrequency" json:"frequency,omitempty"`
+	Delivery  DigestDelivery         `protobuf:"varint,2,opt,name=delivery,proto3,enum=acai.chat.DigestDelivery" json:"delivery,omitempty"`
+	// Country and region whose upcoming holidays are listed; empty uses the server's calendar
+	Country string `protobuf:"bytes,3,opt,name=country,proto3" json:"country,omitempty"`
+	Region  string `protobuf:"bytes,4,opt,name=region,proto3" json:"region,omitempty"`
+	// When the next digest is due
+	NextAt        *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=next_at,json=nextAt,proto3" json:"next_at,omitempty"`
+	LastSentAt    *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=last_sent_at,json=lastSentAt,proto3" json:"last_sent_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DigestSubscription) Reset() {
+	*x = DigestSubscription{}
+	mi := &file_rpc_chat_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DigestSubscription) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DigestSubscription) ProtoMessage() {}
+
+func (x *DigestSubscription) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_chat_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DigestSubscription.ProtoReflect.Descriptor instead.
+func (*DigestSubscription) Descriptor() ([]byte, []int) {
+	return file_rpc_chat_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *DigestSubscription) GetFrequency() DigestFrequency {
+	if x != nil {
+		return x.Frequency
+	}
+	return DigestFrequency_DIGEST_FREQUENCY_OFF
+}
+
+func (x *DigestSubscription) GetDelivery() DigestDelivery {
+	if x != nil {
+		return x.Delivery
+	}
+	return DigestDelivery_DIGEST_DELIVERY_MESSAGE
+}
+
+func (x *DigestSubscription) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+func (x *DigestSubscription) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *DigestSubscription) GetNextAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.NextAt
+	}
+	return nil
+}
+
+func (x *DigestSubscription) GetLastSentAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastSentAt
+	}
+	return nil
+}
+
+type SetDigestSubscriptionRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// DIGEST_FREQUENCY_OFF unsubscribes
+	Frequency     DigestFrequency `protobuf:"varint,1,opt,name=frequency,proto3,enum=acai.chat.DigestFrequency" json:"frequency,omitempty"`
+	Delivery      DigestDelivery  `protobuf:"varint,2,opt,name=delivery,proto3,enum=acai.chat.DigestDelivery" json:"delivery,omitempty"`
+	Country       string          `protobuf:"bytes,3,opt,name=country,proto3" json:"country,omitempty"`
+	Region        string          `protobuf:"bytes,4,opt,name=region,proto3" json:"region,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetDigestSubscriptionRequest) Reset() {
+	*x = SetDigestSubscriptionRequest{}
+	mi := &file_rpc_chat_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetDigestSubscriptionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetDigestSubscriptionRequest) ProtoMessage() {}
+
+func (x *SetDigestSubscriptionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_chat_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetDigestSubscriptionRequest.ProtoReflect.Descriptor instead.
+func (*SetDigestSubscriptionRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_chat_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *SetDigestSubscriptionRequest) GetFrequency() DigestFrequency {
+	if x != nil {
+		return x.Frequency
+	}
+	return DigestFrequency_DIGEST_FREQUENCY_OFF
+}
+
+func (x *SetDigestSubscriptionRequest) GetDelivery() DigestDelivery {
+	if x != nil {
+		return x.Delivery
+	}
+	return DigestDelivery_DIGEST_DELIVERY_MESSAGE
+}
+
+func (x *SetDigestSubscriptionRequest) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+func (x *SetDigestSubscriptionRequest) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+type SetDigestSubscriptionResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Unset after unsubscribing
+	Subscription  *DigestSubscription `protobuf:"bytes,1,opt,name=subscription,proto3" json:"subscription,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetDigestSubscriptionResponse) Reset() {
+	*x = SetDigestSubscriptionResponse{}
+	mi := &file_rpc_chat_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetDigestSubscriptionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetDigestSubscriptionResponse) ProtoMessage() {}
+
+func (x *SetDigestSubscriptionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_chat_proto_msgTypes[68]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetDigestSubscriptionResponse.ProtoReflect.Descriptor instead.
+func (*SetDigestSubscriptionResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_chat_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *SetDigestSubscriptionResponse) GetSubscription() *DigestSubscription {
+	if x != nil {
+		return x.Subscription
+	}
+	return nil
+}
+
+type GetDigestSubscriptionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDigestSubscriptionRequest) Reset() {
+	*x = GetDigestSubscriptionRequest{}
+	mi := &file_rpc_chat_proto_msgTypes[69]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDigestSubscriptionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDigestSubscriptionRequest) ProtoMessage() {}
+
+func (x *GetDigestSubscriptionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_chat_proto_msgTypes[69]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDigestSubscriptionRequest.ProtoReflect.Descriptor instead.
+func (*GetDigestSubscriptionRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_chat_proto_rawDescGZIP(), []int{69}
+}
+
+type GetDigestSubscriptionResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Unset if the user is not subscribed
+	Subscription  *DigestSubscription `protobuf:"bytes,1,opt,name=subscription,proto3" json:"subscription,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDigestSubscriptionResponse) Reset() {
+	*x = GetDigestSubscriptionResponse{}
+	mi := &file_rpc_chat_proto_msgTypes[70]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDigestSubscriptionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDigestSubscriptionResponse) ProtoMessage() {}
+
+func (x *GetDigestSubscriptionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_chat_proto_msgTypes[70]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDigestSubscriptionResponse.ProtoReflect.Descriptor instead.
+func (*GetDigestSubscriptionResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_chat_proto_rawDescGZIP(), []int{70}
+}
+
+func (x *GetDigestSubscriptionResponse) GetSubscription() *DigestSubscription {
+	if x != nil {
+		return x.Subscription
+	}
+	return nil
+}
+
+// How the assistant answers a user
+type Preferences struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Whether weather answers add a one-line advisory when the UV index or pollen is high;
+	// defaults to true
+	HealthAdvisories *bool `protobuf:"varint,1,opt,name=health_advisories,json=healthAdvisories,proto3,oneof" json:"health_advisories,omitempty"`
+	// IANA time zone of the user, e.g. "Europe/Madrid", for what "today" means in answers;
+	// empty clears it. Without one, the time zone of the quiet hours is used, or UTC
+	Timezone      *string `protobuf:"bytes,2,opt,name=timezone,proto3,oneof" json:"timezone,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Preferences) Reset() {
+	*x = Preferences{}
+	mi := &file_rpc_chat_proto_msgTypes[71]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Preferences) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Preferences) ProtoMessage() {}
+
+func (x *Preferences) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_chat_proto_msgTypes[71]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Preferences.ProtoReflect.Descriptor instead.
+func (*Preferences) Descriptor() ([]byte, []int) {
+	return file_rpc_chat_proto_rawDescGZIP(), []int{71}
+}
+
+func (x *Preferences) GetHealthAdvisories() bool {
+	if x != nil && x.HealthAdvisories != nil {
+		return *x.HealthAdvisories
+	}
+	return false
+}
+
+func (x *Preferences) GetTimezone() string {
+	if x != nil && x.Timezone != nil {
+		return *x.Timezone
+	}
+	return ""
+}
+
+type SetPreferencesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Preferences   *Preferences           `protobuf:"bytes,1,opt,name=preferences,proto3" json:"preferences,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetPreferencesRequest) Reset() {
+	*x = SetPreferencesRequest{}
+	mi := &file_rpc_chat_proto_msgTypes[72]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetPreferencesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetPreferencesRequest) ProtoMessage() {}
+
+func (x *SetPreferencesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_chat_proto_msgTypes[72]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetPreferencesRequest.ProtoReflect.Descriptor instead.
+func (*SetPreferencesRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_chat_proto_rawDescGZIP(), []int{72}
+}
+
+func (x *SetPreferencesRequest) GetPreferences() *Preferences {
+	if x != nil {
+		return x.Preferences
+	}
+	return nil
+}
+
+type SetPreferencesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Preferences   *Preferences           `protobuf:"bytes,1,opt,name=preferences,proto3" json:"preferences,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetPreferencesResponse) Reset() {
+	*x = SetPreferencesResponse{}
+	mi := &file_rpc_chat_proto_msgTypes[73]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetPreferencesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetPreferencesResponse) ProtoMessage() {}
+
+func (x *SetPreferencesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_chat_proto_msgTypes[73]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetPreferencesResponse.ProtoReflect.Descriptor instead.
+func (*SetPreferencesResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_chat_proto_rawDescGZIP(), []int{73}
+}
+
+func (x *SetPreferencesResponse) GetPreferences() *Preferences {
+	if x != nil {
+		return x.Preferences
+	}
+	return nil
+}
+
+type GetPreferencesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPreferencesRequest) Reset() {
+	*x = GetPreferencesRequest{}
+	mi := &file_rpc_chat_proto_msgTypes[74]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPreferencesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPreferencesRequest) ProtoMessage() {}
+
+func (x *GetPreferencesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_chat_proto_msgTypes[74]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPreferencesRequest.ProtoReflect.Descriptor instead.
+func (*GetPreferencesRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_chat_proto_rawDescGZIP(), []int{74}
+}
+
+type GetPreferencesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Preferences   *Preferences           `protobuf:"bytes,1,opt,name=preferences,proto3" json:"preferences,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPreferencesResponse) Reset() {
+	*x = GetPreferencesResponse{}
+	mi := &file_rpc_chat_proto_msgTypes[75]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPreferencesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPreferencesResponse) ProtoMessage() {}
+
+func (x *GetPreferencesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_chat_proto_msgTypes[75]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPreferencesResponse.ProtoReflect.Descriptor instead.
+func (*GetPreferencesResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_chat_proto_rawDescGZIP(), []int{75}
+}
+
+func (x *GetPreferencesResponse) GetPreferences() *Preferences {
+	if x != nil {
+		return x.Preferences
+	}
+	return nil
+}
+
+// How a user is notified of digests and replies finished in the background
+type NotificationSettings struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Channels of the kinds without a route, e.g. ["conversation", "email"]
+	Channels []string             `protobuf:"bytes,1,rep,name=channels,proto3" json:"channels,omitempty"`
+	Routes   []*NotificationRoute `protobuf:"bytes,2,rep,name=routes,proto3" json:"routes,omitempty"`
+	// Address of the email channel; defaults to the user ID when it is an address
+	Email string `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
+	// Unset to be notified at any time
+	QuietHours    *QuietHours `protobuf:"bytes,4,opt,name=quiet_hours,json=quietHours,proto3" json:"quiet_hours,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NotificationSettings) Reset() {
+	*x = NotificationSettings{}
+	mi := &file_rpc_chat_proto_msgTypes[76]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NotificationSettings) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NotificationSettings) ProtoMessage() {}
+
+func (x *NotificationSettings) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_chat_proto_msgTypes[76]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NotificationSettings.ProtoReflect.Descriptor instead.
+func (*NotificationSettings) Descriptor() ([]byte, []int) {
+	return file_rpc_chat_proto_rawDescGZIP(), []int{76}
+}
+
+func (x *NotificationSettings) GetChannels() []string {
+	if x != nil {
+		return x.Channels
+	}
+	return nil
+}
+
+func (x *NotificationSettings) GetRoutes() []*NotificationRoute {
+	if x != nil {
+		return x.Routes
+	}
+	return nil
+}
+
+func (x *NotificationSettings) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *NotificationSettings) GetQuietHours() *QuietHours {
+	if x != nil {
+		return x.QuietHours
+	}
+	return nil
+}
+
+// The channels of one kind of notification, "reply_ready" or "digest"; no channels mutes it
+type NotificationRoute struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Kind          string                 `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`
+	Channels      []string               `protobuf:"bytes,2,rep,name=channels,proto3" json:"channels,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NotificationRoute) Reset() {
+	*x = NotificationRoute{}
+	mi := &file_rpc_chat_proto_msgTypes[77]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NotificationRoute) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NotificationRoute) ProtoMessage() {}
+
+func (x *NotificationRoute) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_chat_proto_msgTypes[77]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NotificationRoute.ProtoReflect.Descriptor instead.
+func (*NotificationRoute) Descriptor() ([]byte, []int) {
+	return file_rpc_chat_proto_rawDescGZIP(), []int{77}
+}
+
+func (x *NotificationRoute) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *NotificationRoute) GetChannels() []string {
+	if x != nil {
+		return x.Channels
+	}
+	return nil
+}
+
+// A daily period when only urgent notifications are sent, others wait for its end;
+// in-conversation notifications are never held back
+type QuietHours struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Local times as HH:MM; a start after the end spans midnight, e.g. 22:00 to 07:00
+	Start string `protobuf:"bytes,1,opt,name=start,proto3" json:"start,omitempty"`
+	End   string `protobuf:"bytes,2,opt,name=end,proto3" json:"end,omitempty"`
+	// IANA name, e.g. "Europe/Madrid"; defaults to UTC
+	Timezone      string `protobuf:"bytes,3,opt,name=timezone,proto3" json:"timezone,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QuietHours) Reset() {
+	*x = QuietHours{}
+	mi := &file_rpc_chat_proto_msgTypes[78]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QuietHours) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QuietHours) ProtoMessage() {}
+
+func (x *QuietHours) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_chat_proto_msgTypes[78]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QuietHours.ProtoReflect.Descriptor instead.
+func (*QuietHours) Descriptor() ([]byte, []int) {
+	return file_rpc_chat_proto_rawDescGZIP(), []int{78}
+}
+
+func (x *QuietHours) GetStart() string {
+	if x != nil {
+		return x.Start
+	}
+	return ""
+}
+
+func (x *QuietHours) GetEnd() string {
+	if x != nil {
+		return x.End
+	}
+	return ""
+}
+
+func (x *QuietHours) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+type SetNotificationSettingsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Settings      *NotificationSettings  `protobuf:"bytes,1,opt,name=settings,proto3" json:"settings,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetNotificationSettingsRequest) Reset() {
+	*x = SetNotificationSettingsRequest{}
+	mi := &file_rpc_chat_proto_msgTypes[79]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetNotificationSettingsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetNotificationSettingsRequest) ProtoMessage() {}
+
+func (x *SetNotificationSettingsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_chat_proto_msgTypes[79]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetNotificationSettingsRequest.ProtoReflect.Descriptor instead.
+func (*SetNotificationSettingsRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_chat_proto_rawDescGZIP(), []int{79}
+}
+
+func (x *SetNotificationSettingsRequest) GetSettings() *NotificationSettings {
+	if x != nil {
+		return x.Settings
+	}
+	return nil
+}
+
+type SetNotificationSettingsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Settings      *NotificationSettings  `protobuf:"bytes,1,opt,name=settings,proto3" json:"settings,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetNotificationSettingsResponse) Reset() {
+	*x = SetNotificationSettingsResponse{}
+	mi := &file_rpc_chat_proto_msgTypes[80]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetNotificationSettingsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetNotificationSettingsResponse) ProtoMessage() {}
+
+func (x *SetNotificationSettingsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_chat_proto_msgTypes[80]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetNotificationSettingsResponse.ProtoReflect.Descriptor instead.
+func (*SetNotificationSettingsResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_chat_proto_rawDescGZIP(), []int{80}
+}
+
+func (x *SetNotificationSettingsResponse) GetSettings() *NotificationSettings {
+	if x != nil {
+		return x.Settings
+	}
+	return nil
+}
+
+type GetNotificationSettingsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetNotificationSettingsRequest) Reset() {
+	*x = GetNotificationSettingsRequest{}
+	mi := &file_rpc_chat_proto_msgTypes[81]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetNotificationSettingsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetNotificationSettingsRequest) ProtoMessage() {}
+
+func (x *GetNotificationSettingsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_chat_proto_msgTypes[81]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetNotificationSettingsRequest.ProtoReflect.Descriptor instead.
+func (*GetNotificationSettingsRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_chat_proto_rawDescGZIP(), []int{81}
+}
+
+type GetNotificationSettingsResponse struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Settings *NotificationSettings  `protobuf:"bytes,1,opt,name=settings,proto3" json:"settings,omitempty"`
+	// Channels enabled on the server, e.g. ["conversation", "webhook"]
+	AvailableChannels []string `protobuf:"bytes,2,rep,name=available_channels,json=availableChannels,proto3" json:"available_channels,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *GetNotificationSettingsResponse) Reset() {
+	*x = GetNotificationSettingsResponse{}
+	mi := &file_rpc_chat_proto_msgTypes[82]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetNotificationSettingsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetNotificationSettingsResponse) ProtoMessage() {}
+
+func (x *GetNotificationSettingsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_chat_proto_msgTypes[82]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetNotificationSettingsResponse.ProtoReflect.Descriptor instead.
+func (*GetNotificationSettingsResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_chat_proto_rawDescGZIP(), []int{82}
+}
+
+func (x *GetNotificationSettingsResponse) GetSettings() *NotificationSettings {
+	if x != nil {
+		return x.Settings
+	}
+	return nil
+}
+
+func (x *GetNotificationSettingsResponse) GetAvailableChannels() []string {
+	if x != nil {
+		return x.AvailableChannels
+	}
+	return nil
+}
+
+// A device push notifications are sent to, through the "push" notification channel
+type PushDevice struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Registration token of FCM or device token of APNs, as the app got it
+	Token    string       `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	Platform PushPlatform `protobuf:"varint,2,opt,name=platform,proto3,enum=acai.chat.PushPlatform" json:"platform,omitempty"`
+	// X-Device-ID of the request that registered it, if any
+	DeviceId      string                 `protobuf:"bytes,3,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	RegisteredAt  *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=registered_at,json=registeredAt,proto3" json:"registered_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PushDevice) Reset() {
+	*x = PushDevice{}
+	mi := &file_rpc_chat_proto_msgTypes[83]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PushDevice) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PushDevice) ProtoMessage() {}
+
+func (x *PushDevice) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_chat_proto_msgTypes[83]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PushDevice.ProtoReflect.Descriptor instead.
+func (*PushDevice) Descriptor() ([]byte, []int) {
+	return file_rpc_chat_proto_rawDescGZIP(), []int{83}
+}
+
+func (x *PushDevice) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *PushDevice) GetPlatform() PushPlatform {
+	if x != nil {
+		return x.Platform
+	}
+	return PushPlatform_PUSH_PLATFORM_UNSPECIFIED
+}
+
+func (x *PushDevice) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+func (x *PushDevice) GetRegisteredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RegisteredAt
+	}
+	return nil
+}
+
+type RegisterPushDeviceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	Platform      PushPlatform           `protobuf:"varint,2,opt,name=platform,proto3,enum=acai.chat.PushPlatform" json:"platform,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RegisterPushDeviceRequest) Reset() {
+	*x = RegisterPushDeviceRequest{}
+	mi := &file_rpc_chat_proto_msgTypes[84]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegisterPushDeviceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterPushDeviceRequest) ProtoMessage() {}
+
+func (x *RegisterPushDeviceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_chat_proto_msgTypes[84]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterPushDeviceRequest.ProtoReflect.Descriptor instead.
+func (*RegisterPushDeviceRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_chat_proto_rawDescGZIP(), []int{84}
+}
+
+func (x *RegisterPushDeviceRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *RegisterPushDeviceRequest) GetPlatform() PushPlatform {
+	if x != nil {
+		return x.Platform
+	}
+	return PushPlatform_PUSH_PLATFORM_UNSPECIFIED
+}
+
+type RegisterPushDeviceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Device        *PushDevice            `protobuf:"bytes,1,opt,name=device,proto3" json:"device,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RegisterPushDeviceResponse) Reset() {
+	*x = RegisterPushDeviceResponse{}
+	mi := &file_rpc_chat_proto_msgTypes[85]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegisterPushDeviceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterPushDeviceResponse) ProtoMessage() {}
+
+func (x *RegisterPushDeviceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_chat_proto_msgTypes[85]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterPushDeviceResponse.ProtoReflect.Descriptor instead.
+func (*RegisterPushDeviceResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_chat_proto_rawDescGZIP(), []int{85}
+}
+
+func (x *RegisterPushDeviceResponse) GetDevice() *PushDevice {
+	if x != nil {
+		return x.Device
+	}
+	return nil
+}
+
+type UnregisterPushDeviceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnregisterPushDeviceRequest) Reset() {
+	*x = UnregisterPushDeviceRequest{}
+	mi := &file_rpc_chat_proto_msgTypes[86]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnregisterPushDeviceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnregisterPushDeviceRequest) ProtoMessage() {}
+
+func (x *UnregisterPushDeviceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_chat_proto_msgTypes[86]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnregisterPushDeviceRequest.ProtoReflect.Descriptor instead.
+func (*UnregisterPushDeviceRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_chat_proto_rawDescGZIP(), []int{86}
+}
+
+func (x *UnregisterPushDeviceRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+type UnregisterPushDeviceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnregisterPushDeviceResponse) Reset() {
+	*x = UnregisterPushDeviceResponse{}
+	mi := &file_rpc_chat_proto_msgTypes[87]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnregisterPushDeviceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnregisterPushDeviceResponse) ProtoMessage() {}
+
+func (x *UnregisterPushDeviceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_chat_proto_msgTypes[87]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnregisterPushDeviceResponse.ProtoReflect.Descriptor instead.
+func (*UnregisterPushDeviceResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_chat_proto_rawDescGZIP(), []int{87}
+}
+
+type ListPushDevicesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPushDevicesRequest) Reset() {
+	*x = ListPushDevicesRequest{}
+	mi := &file_rpc_chat_proto_msgTypes[88]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPushDevicesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPushDevicesRequest) ProtoMessage() {}
+
+func (x *ListPushDevicesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_chat_proto_msgTypes[88]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPushDevicesRequest.ProtoReflect.Descriptor instead.
+func (*ListPushDevicesRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_chat_proto_rawDescGZIP(), []int{88}
+}
+
+type ListPushDevicesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Devices       []*PushDevice          `protobuf:"bytes,1,rep,name=devices,proto3" json:"devices,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPushDevicesResponse) Reset() {
+	*x = ListPushDevicesResponse{}
+	mi := &file_rpc_chat_proto_msgTypes[89]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPushDevicesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPushDevicesResponse) ProtoMessage() {}
+
+func (x *ListPushDevicesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_chat_proto_msgTypes[89]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPushDevicesResponse.ProtoReflect.Descriptor instead.
+func (*ListPushDevicesResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_chat_proto_rawDescGZIP(), []int{89}
+}
+
+func (x *ListPushDevicesResponse) GetDevices() []*PushDevice {
+	if x != nil {
+		return x.Devices
+	}
+	return nil
+}
+
+// A session a user started with StartSession, on the device the X-Device-ID header names
+type Session struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// X-Device-ID of the request that started it, if any
+	DeviceId string `protobuf:"bytes,2,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	// User-Agent header of the latest request, e.g. "Mozilla/5.0 (iPhone; ...)"
+	UserAgent string                 `protobuf:"bytes,3,opt,name=user_agent,json=userAgent,proto3" json:"user_agent,omitempty"`
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// Updated at most once a minute
+	LastSeenAt *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=last_seen_at,json=lastSeenAt,proto3" json:"last_seen_at,omitempty"`
+	// When the session was revoked; requests with its token are then rejected
+	RevokedAt *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=revoked_at,json=revokedAt,proto3" json:"revoked_at,omitempty"`
+	// Whether the session is the one of the request listing it
+	Current       bool `protobuf:"varint,7,opt,name=current,proto3" json:"current,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Session) Reset() {
+	*x = Session{}
+	mi := &file_rpc_chat_proto_msgTypes[90]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Session) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Session) ProtoMessage() {}
+
+func (x *Session) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_chat_proto_msgTypes[90]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Session.ProtoReflect.Descriptor instead.
+func (*Session) Descriptor() ([]byte, []int) {
+	return file_rpc_chat_proto_rawDescGZIP(), []int{90}
+}
+
+func (x *Session) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Session) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+func (x *Session) GetUserAgent() string {
+	if x != nil {
+		return x.UserAgent
+	}
+	return ""
+}
+
+func (x *Session) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Session) GetLastSeenAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastSeenAt
+	}
+	return nil
+}
+
+func (x *Session) GetRevokedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RevokedAt
+	}
+	return nil
+}
+
+func (x *Session) GetCurrent() bool {
+	if x != nil {
+		return x.Current
+	}
+	return false
+}
+
+type StartSessionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StartSessionRequest) Reset() {
+	*x = StartSessionRequest{}
+	mi := &file_rpc_chat_proto_msgTypes[91]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StartSessionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartSessionRequest) ProtoMessage() {}
+
+func (x *StartSessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_chat_proto_msgTypes[91]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartSessionRequest.ProtoReflect.Descriptor instead.
+func (*StartSessionRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_chat_proto_rawDescGZIP(), []int{91}
+}
+
+type StartSessionResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Session *Session               `protobuf:"bytes,1,opt,name=session,proto3" json:"session,omitempty"`
+	// Secret to send as the X-Session-Token header; only returned here
+	Token         string `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StartSessionResponse) Reset() {
+	*x = StartSessionResponse{}
+	mi := &file_rpc_chat_proto_msgTypes[92]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StartSessionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartSessionResponse) ProtoMessage() {}
+
+func (x *StartSessionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_chat_proto_msgTypes[92]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartSessionResponse.ProtoReflect.Descriptor instead.
+func (*StartSessionResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_chat_proto_rawDescGZIP(), []int{92}
+}
+
+func (x *StartSessionResponse) GetSession() *Session {
+	if x != nil {
+		return x.Session
+	}
+	return nil
+}
+
+func (x *StartSessionResponse) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+type ListSessionsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Also list revoked sessions
+	IncludeRevoked bool `protobuf:"varint,1,opt,name=include_revoked,json=includeRevoked,proto3" json:"include_revoked,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ListSessionsRequest) Reset() {
+	*x = ListSessionsRequest{}
+	mi := &file_rpc_chat_proto_msgTypes[93]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSessionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSessionsRequest) ProtoMessage() {}
+
+func (x *ListSessionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_chat_proto_msgTypes[93]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSessionsRequest.ProtoReflect.Descriptor instead.
+func (*ListSessionsRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_chat_proto_rawDescGZIP(), []int{93}
+}
+
+func (x *ListSessionsRequest) GetIncludeRevoked() bool {
+	if x != nil {
+		return x.IncludeRevoked
+	}
+	return false
+}
+
+type ListSessionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sessions      []*Session             `protobuf:"bytes,1,rep,name=sessions,proto3" json:"sessions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSessionsResponse) Reset() {
+	*x = ListSessionsResponse{}
+	mi := &file_rpc_chat_proto_msgTypes[94]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSessionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSessionsResponse) ProtoMessage() {}
+
+func (x *ListSessionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_chat_proto_msgTypes[94]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSessionsResponse.ProtoReflect.Descriptor instead.
+func (*ListSessionsResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_chat_proto_rawDescGZIP(), []int{94}
+}
+
+func (x *ListSessionsResponse) GetSessions() []*Session {
+	if x != nil {
+		return x.Sessions
+	}
+	return nil
+}
+
+type RevokeSessionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SessionId     string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeSessionRequest) Reset() {
+	*x = RevokeSessionRequest{}
+	mi := &file_rpc_chat_proto_msgTypes[95]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeSessionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeSessionRequest) ProtoMessage() {}
+
+func (x *RevokeSessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_chat_proto_msgTypes[95]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeSessionRequest.ProtoReflect.Descriptor instead.
+func (*RevokeSessionRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_chat_proto_rawDescGZIP(), []int{95}
+}
+
+func (x *RevokeSessionRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+type RevokeSessionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Session       *Session               `protobuf:"bytes,1,opt,name=session,proto3" json:"session,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeSessionResponse) Reset() {
+	*x = RevokeSessionResponse{}
+	mi := &file_rpc_chat_proto_msgTypes[96]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeSessionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeSessionResponse) ProtoMessage() {}
+
+func (x *RevokeSessionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_chat_proto_msgTypes[96]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeSessionResponse.ProtoReflect.Descriptor instead.
+func (*RevokeSessionResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_chat_proto_rawDescGZIP(), []int{96}
+}
+
+func (x *RevokeSessionResponse) GetSession() *Session {
+	if x != nil {
+		return x.Session
+	}
+	return nil
+}
+
+// What a user may still consume today, under their plan
+type Quota struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Name of the plan, e.g. "free"
+	Plan string `protobuf:"bytes,1,opt,name=plan,proto3" json:"plan,omitempty"`
+	// Messages answered
+	Messages *Quota_Allowance `protobuf:"bytes,2,opt,name=messages,proto3" json:"messages,omitempty"`
+	// Prompt and completion tokens
+	Tokens    *Quota_Allowance `protobuf:"bytes,3,opt,name=tokens,proto3" json:"tokens,omitempty"`
+	ToolCalls *Quota_Allowance `protobuf:"bytes,4,opt,name=tool_calls,json=toolCalls,proto3" json:"tool_calls,omitempty"`
+	// When the allowances reset: midnight UTC
+	ResetsAt      *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=resets_at,json=resetsAt,proto3" json:"resets_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Quota) Reset() {
+	*x = Quota{}
+	mi := &file_rpc_chat_proto_msgTypes[97]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Quota) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Quota) ProtoMessage() {}
+
+func (x *Quota) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_chat_proto_msgTypes[97]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Quota.ProtoReflect.Descriptor instead.
+func (*Quota) Descriptor() ([]byte, []int) {
+	return file_rpc_chat_proto_rawDescGZIP(), []int{97}
+}
+
+func (x *Quota) GetPlan() string {
+	if x != nil {
+		return x.Plan
+	}
+	return ""
+}
+
+func (x *Quota) GetMessages() *Quota_Allowance {
+	if x != nil {
+		return x.Messages
+	}
+	return nil
+}
+
+func (x *Quota) GetTokens() *Quota_Allowance {
+	if x != nil {
+		return x.Tokens
+	}
+	return nil
+}
+
+func (x *Quota) GetToolCalls() *Quota_Allowance {
+	if x != nil {
+		return x.ToolCalls
+	}
+	return nil
+}
+
+func (x *Quota) GetResetsAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ResetsAt
+	}
+	return nil
+}
+
+type GetQuotaRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetQuotaRequest) Reset() {
+	*x = GetQuotaRequest{}
+	mi := &file_rpc_chat_proto_msgTypes[98]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetQuotaRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetQuotaRequest) ProtoMessage() {}
+
+func (x *GetQuotaRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_chat_proto_msgTypes[98]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetQuotaRequest.ProtoReflect.Descriptor instead.
+func (*GetQuotaRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_chat_proto_rawDescGZIP(), []int{98}
+}
+
+type GetQuotaResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Quota         *Quota                 `protobuf:"bytes,1,opt,name=quota,proto3" json:"quota,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetQuotaResponse) Reset() {
+	*x = GetQuotaResponse{}
+	mi := &file_rpc_chat_proto_msgTypes[99]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetQuotaResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetQuotaResponse) ProtoMessage() {}
+
+func (x *GetQuotaResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_chat_proto_msgTypes[99]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetQuotaResponse.ProtoReflect.Descriptor instead.
+func (*GetQuotaResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_chat_proto_rawDescGZIP(), []int{99}
+}
+
+func (x *GetQuotaResponse) GetQuota() *Quota {
+	if x != nil {
+		return x.Quota
+	}
+	return nil
+}
+
+type Conversation_Message struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Role        Conversation_Role      `protobuf:"varint,2,opt,name=role,proto3,enum=acai.chat.Conversation_Role" json:"role,omitempty"`
+	Content     string                 `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
+	Timestamp   *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Attachments []*Attachment          `protobuf:"bytes,5,rep,name=attachments,proto3" json:"attachments,omitempty"`
+	// Kinds of personal data detected in the message, e.g. "email"; only set when the server detects it
+	PersonalData []string `protobuf:"bytes,6,rep,name=personal_data,json=personalData,proto3" json:"personal_data,omitempty"`
+	// Tools the assistant called while writing the reply
+	ToolCalls []*Conversation_ToolCall `protobuf:"bytes,7,rep,name=tool_calls,json=toolCalls,proto3" json:"tool_calls,omitempty"`
+	// When the message was pinned; unset if it isn't
+	PinnedAt *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=pinned_at,json=pinnedAt,proto3" json:"pinned_at,omitempty"`
+	// What a user message asks for, e.g. "weather"; set in the background when intent analytics are enabled
+	Intent string `protobuf:"bytes,9,opt,name=intent,proto3" json:"intent,omitempty"`
+	// Device a user message was sent from, as the X-Device-ID header identified it; empty if none did
+	DeviceId string `protobuf:"bytes,10,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	// How the user rated a reply of the assistant; unspecified if they didn't
+	Rating MessageRating `protobuf:"varint,11,opt,name=rating,proto3,enum=acai.chat.MessageRating" json:"rating,omitempty"`
+	// Content compressed with gzip, instead of content, when requested with HistoryOptions.compress_over_bytes
+	ContentGzip   []byte `protobuf:"bytes,12,opt,name=content_gzip,json=contentGzip,proto3" json:"content_gzip,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Conversation_Message) Reset() {
+	*x = Conversation_Message{}
+	mi := &file_rpc_chat_proto_msgTypes[100]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Conversation_Message) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Conversation_Message) ProtoMessage() {}
+
+func (x *Conversation_Message) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_chat_proto_msgTypes[100]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Conversation_Message.ProtoReflect.Descriptor instead.
+func (*Conversation_Message) Descriptor() ([]byte, []int) {
+	return file_rpc_chat_proto_rawDescGZIP(), []int{0, 0}
+}
+
+func (x *Conversation_Message) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Conversation_Message) GetRole() Conversation_Role {
+	if x != nil {
+		return x.Role
+	}
+	return Conversation_UNKNOWN
+}
+
+func (x *Conversation_Message) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *Conversation_Message) GetTimestamp() *timestamppb.Timestamp {
 	if x != nil {
 		return x.Timestamp
 	}
 	return nil
 }
 
-func (x *Conversation) GetMessages() []*Conversation_Message {
+func (x *Conversation_Message) GetAttachments() []*Attachment {
 	if x != nil {
-		return x.Messages
+		return x.Attachments
 	}
 	return nil
 }
 
-type StartConversationRequest struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	Message string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+func (x *Conversation_Message) GetPersonalData() []string {
+	if x != nil {
+		return x.PersonalData
+	}
+	return nil
 }
 
-func (x *StartConversationRequest) Reset() {
-	*x = StartConversationRequest{}
-	mi := &file_rpc_chat_proto_msgTypes[1]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *Conversation_Message) GetToolCalls() []*Conversation_ToolCall {
+	if x != nil {
+		return x.ToolCalls
+	}
+	return nil
 }
 
-func (x *StartConversationRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *Conversation_Message) GetPinnedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PinnedAt
+	}
+	return nil
 }
 
-func (*StartConversationRequest) ProtoMessage() {}
+func (x *Conversation_Message) GetIntent() string {
+	if x != nil {
+		return x.Intent
+	}
+	return ""
+}
 
-func (x *StartConversationRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_rpc_chat_proto_msgTypes[1]
+func (x *Conversation_Message) GetDeviceId() string {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.DeviceId
 	}
-	return mi.MessageOf(x)
+	return ""
 }
 
-// Deprecated: Use StartConversationRequest.ProtoReflect.Descriptor instead.
-func (*StartConversationRequest) Descriptor() ([]byte, []int) {
-	return file_rpc_chat_proto_rawDescGZIP(), []int{1}
+func (x *Conversation_Message) GetRating() MessageRating {
+	if x != nil {
+		return x.Rating
+	}
+	return MessageRating_MESSAGE_RATING_UNSPECIFIED
 }
 
-func (x *StartConversationRequest) GetMessage() string {
+func (x *Conversation_Message) GetContentGzip() []byte {
 	if x != nil {
-		return x.Message
+		return x.ContentGzip
 	}
-	return ""
+	return nil
 }
 
-type StartConversationResponse struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+type Conversation_ToolCall struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Tool  string                 `protobuf:"bytes,1,opt,name=tool,proto3" json:"tool,omitempty"`
+	// What the call did, e.g. "calling get_weather(Barcelona, 3 days)"
+	Call string `protobuf:"bytes,2,opt,name=call,proto3" json:"call,omitempty"`
+	// Its outcome, e.g. "weather received"
+	Result        string `protobuf:"bytes,3,opt,name=result,proto3" json:"result,omitempty"`
+	Failed        bool   `protobuf:"varint,4,opt,name=failed,proto3" json:"failed,omitempty"`
 	unknownFields protoimpl.UnknownFields
-
-	ConversationId string `protobuf:"bytes,1,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
-	Title          string `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
-	Reply          string `protobuf:"bytes,3,opt,name=reply,proto3" json:"reply,omitempty"`
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *StartConversationResponse) Reset() {
-	*x = StartConversationResponse{}
-	mi := &file_rpc_chat_proto_msgTypes[2]
+func (x *Conversation_ToolCall) Reset() {
+	*x = Conversation_ToolCall{}
+	mi := &file_rpc_chat_proto_msgTypes[101]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *StartConversationResponse) String() string {
+func (x *Conversation_ToolCall) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StartConversationResponse) ProtoMessage() {}
+func (*Conversation_ToolCall) ProtoMessage() {}
 
-func (x *StartConversationResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_rpc_chat_proto_msgTypes[2]
+func (x *Conversation_ToolCall) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_chat_proto_msgTypes[101]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -219,56 +5973,67 @@ func (x *StartConversationResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StartConversationResponse.ProtoReflect.Descriptor instead.
-func (*StartConversationResponse) Descriptor() ([]byte, []int) {
-	return file_rpc_chat_proto_rawDescGZIP(), []int{2}
+// Deprecated: Use Conversation_ToolCall.ProtoReflect.Descriptor instead.
+func (*Conversation_ToolCall) Descriptor() ([]byte, []int) {
+	return file_rpc_chat_proto_rawDescGZIP(), []int{0, 1}
 }
 
-func (x *StartConversationResponse) GetConversationId() string {
+func (x *Conversation_ToolCall) GetTool() string {
 	if x != nil {
-		return x.ConversationId
+		return x.Tool
 	}
 	return ""
 }
 
-func (x *StartConversationResponse) GetTitle() string {
+func (x *Conversation_ToolCall) GetCall() string {
 	if x != nil {
-		return x.Title
+		return x.Call
 	}
 	return ""
 }
 
-func (x *StartConversationResponse) GetReply() string {
+func (x *Conversation_ToolCall) GetResult() string {
 	if x != nil {
-		return x.Reply
+		return x.Result
 	}
 	return ""
 }
 
-type ContinueConversationRequest struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
+func (x *Conversation_ToolCall) GetFailed() bool {
+	if x != nil {
+		return x.Failed
+	}
+	return false
+}
 
-	ConversationId string `protobuf:"bytes,1,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
-	Message        string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+// What generating the conversation's replies has consumed so far
+type Conversation_Usage struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	PromptTokens     int64                  `protobuf:"varint,1,opt,name=prompt_tokens,json=promptTokens,proto3" json:"prompt_tokens,omitempty"`
+	CompletionTokens int64                  `protobuf:"varint,2,opt,name=completion_tokens,json=completionTokens,proto3" json:"completion_tokens,omitempty"`
+	// Estimated from list prices; 0 for models without a known price
+	CostUsd float64 `protobuf:"fixed64,3,opt,name=cost_usd,json=costUsd,proto3" json:"cost_usd,omitempty"`
+	// Whether the budget in the settings is spent; replies are then shorter and use a cheaper model
+	OverBudget    bool `protobuf:"varint,4,opt,name=over_budget,json=overBudget,proto3" json:"over_budget,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ContinueConversationRequest) Reset() {
-	*x = ContinueConversationRequest{}
-	mi := &file_rpc_chat_proto_msgTypes[3]
+func (x *Conversation_Usage) Reset() {
+	*x = Conversation_Usage{}
+	mi := &file_rpc_chat_proto_msgTypes[102]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ContinueConversationRequest) String() string {
+func (x *Conversation_Usage) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ContinueConversationRequest) ProtoMessage() {}
+func (*Conversation_Usage) ProtoMessage() {}
 
-func (x *ContinueConversationRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_rpc_chat_proto_msgTypes[3]
+func (x *Conversation_Usage) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_chat_proto_msgTypes[102]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -279,91 +6044,70 @@ func (x *ContinueConversationRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ContinueConversationRequest.ProtoReflect.Descriptor instead.
-func (*ContinueConversationRequest) Descriptor() ([]byte, []int) {
-	return file_rpc_chat_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use Conversation_Usage.ProtoReflect.Descriptor instead.
+func (*Conversation_Usage) Descriptor() ([]byte, []int) {
+	return file_rpc_chat_proto_rawDescGZIP(), []int{0, 2}
 }
 
-func (x *ContinueConversationRequest) GetConversationId() string {
+func (x *Conversation_Usage) GetPromptTokens() int64 {
 	if x != nil {
-		return x.ConversationId
+		return x.PromptTokens
 	}
-	return ""
+	return 0
 }
 
-func (x *ContinueConversationRequest) GetMessage() string {
+func (x *Conversation_Usage) GetCompletionTokens() int64 {
 	if x != nil {
-		return x.Message
+		return x.CompletionTokens
 	}
-	return ""
-}
-
-type ContinueConversationResponse struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	Reply string `protobuf:"bytes,1,opt,name=reply,proto3" json:"reply,omitempty"`
-}
-
-func (x *ContinueConversationResponse) Reset() {
-	*x = ContinueConversationResponse{}
-	mi := &file_rpc_chat_proto_msgTypes[4]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+	return 0
 }
 
-func (x *ContinueConversationResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*ContinueConversationResponse) ProtoMessage() {}
-
-func (x *ContinueConversationResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_rpc_chat_proto_msgTypes[4]
+func (x *Conversation_Usage) GetCostUsd() float64 {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.CostUsd
 	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use ContinueConversationResponse.ProtoReflect.Descriptor instead.
-func (*ContinueConversationResponse) Descriptor() ([]byte, []int) {
-	return file_rpc_chat_proto_rawDescGZIP(), []int{4}
+	return 0
 }
 
-func (x *ContinueConversationResponse) GetReply() string {
+func (x *Conversation_Usage) GetOverBudget() bool {
 	if x != nil {
-		return x.Reply
+		return x.OverBudget
 	}
-	return ""
+	return false
 }
 
-type ListConversationsRequest struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+// Summary of a conversation for chat lists, without its full message history
+type Conversation_Preview struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Start of the latest message, shortened for display
+	LastMessage          string                 `protobuf:"bytes,1,opt,name=last_message,json=lastMessage,proto3" json:"last_message,omitempty"`
+	LastMessageRole      Conversation_Role      `protobuf:"varint,2,opt,name=last_message_role,json=lastMessageRole,proto3,enum=acai.chat.Conversation_Role" json:"last_message_role,omitempty"`
+	LastMessageTimestamp *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=last_message_timestamp,json=lastMessageTimestamp,proto3" json:"last_message_timestamp,omitempty"`
+	MessageCount         int32                  `protobuf:"varint,4,opt,name=message_count,json=messageCount,proto3" json:"message_count,omitempty"`
+	// Whether there are assistant replies the user hasn't read yet
+	Unread bool `protobuf:"varint,5,opt,name=unread,proto3" json:"unread,omitempty"`
+	// Number of assistant replies after the user's last read message
+	UnreadCount   int32 `protobuf:"varint,6,opt,name=unread_count,json=unreadCount,proto3" json:"unread_count,omitempty"`
 	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListConversationsRequest) Reset() {
-	*x = ListConversationsRequest{}
-	mi := &file_rpc_chat_proto_msgTypes[5]
+func (x *Conversation_Preview) Reset() {
+	*x = Conversation_Preview{}
+	mi := &file_rpc_chat_proto_msgTypes[103]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListConversationsRequest) String() string {
+func (x *Conversation_Preview) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListConversationsRequest) ProtoMessage() {}
+func (*Conversation_Preview) ProtoMessage() {}
 
-func (x *ListConversationsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_rpc_chat_proto_msgTypes[5]
+func (x *Conversation_Preview) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_chat_proto_msgTypes[103]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -374,79 +6118,78 @@ func (x *ListConversationsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListConversationsRequest.ProtoReflect.Descriptor instead.
-func (*ListConversationsRequest) Descriptor() ([]byte, []int) {
-	return file_rpc_chat_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use Conversation_Preview.ProtoReflect.Descriptor instead.
+func (*Conversation_Preview) Descriptor() ([]byte, []int) {
+	return file_rpc_chat_proto_rawDescGZIP(), []int{0, 3}
 }
 
-type ListConversationsResponse struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	Conversations []*Conversation `protobuf:"bytes,1,rep,name=conversations,proto3" json:"conversations,omitempty"`
+func (x *Conversation_Preview) GetLastMessage() string {
+	if x != nil {
+		return x.LastMessage
+	}
+	return ""
 }
 
-func (x *ListConversationsResponse) Reset() {
-	*x = ListConversationsResponse{}
-	mi := &file_rpc_chat_proto_msgTypes[6]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *Conversation_Preview) GetLastMessageRole() Conversation_Role {
+	if x != nil {
+		return x.LastMessageRole
+	}
+	return Conversation_UNKNOWN
 }
 
-func (x *ListConversationsResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *Conversation_Preview) GetLastMessageTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastMessageTimestamp
+	}
+	return nil
 }
 
-func (*ListConversationsResponse) ProtoMessage() {}
-
-func (x *ListConversationsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_rpc_chat_proto_msgTypes[6]
+func (x *Conversation_Preview) GetMessageCount() int32 {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.MessageCount
 	}
-	return mi.MessageOf(x)
+	return 0
 }
 
-// Deprecated: Use ListConversationsResponse.ProtoReflect.Descriptor instead.
-func (*ListConversationsResponse) Descriptor() ([]byte, []int) {
-	return file_rpc_chat_proto_rawDescGZIP(), []int{6}
+func (x *Conversation_Preview) GetUnread() bool {
+	if x != nil {
+		return x.Unread
+	}
+	return false
 }
 
-func (x *ListConversationsResponse) GetConversations() []*Conversation {
+func (x *Conversation_Preview) GetUnreadCount() int32 {
 	if x != nil {
-		return x.Conversations
+		return x.UnreadCount
 	}
-	return nil
+	return 0
 }
 
-type DescribeConversationRequest struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+type SearchSemanticResponse_Result struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ConversationId string                 `protobuf:"bytes,1,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	Message        *Conversation_Message  `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	// Cosine similarity between the query and the message, higher is more relevant
+	Score         float64 `protobuf:"fixed64,3,opt,name=score,proto3" json:"score,omitempty"`
 	unknownFields protoimpl.UnknownFields
-
-	ConversationId string `protobuf:"bytes,1,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DescribeConversationRequest) Reset() {
-	*x = DescribeConversationRequest{}
-	mi := &file_rpc_chat_proto_msgTypes[7]
+func (x *SearchSemanticResponse_Result) Reset() {
+	*x = SearchSemanticResponse_Result{}
+	mi := &file_rpc_chat_proto_msgTypes[104]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DescribeConversationRequest) String() string {
+func (x *SearchSemanticResponse_Result) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DescribeConversationRequest) ProtoMessage() {}
+func (*SearchSemanticResponse_Result) ProtoMessage() {}
 
-func (x *DescribeConversationRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_rpc_chat_proto_msgTypes[7]
+func (x *SearchSemanticResponse_Result) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_chat_proto_msgTypes[104]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -457,89 +6200,59 @@ func (x *DescribeConversationRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DescribeConversationRequest.ProtoReflect.Descriptor instead.
-func (*DescribeConversationRequest) Descriptor() ([]byte, []int) {
-	return file_rpc_chat_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use SearchSemanticResponse_Result.ProtoReflect.Descriptor instead.
+func (*SearchSemanticResponse_Result) Descriptor() ([]byte, []int) {
+	return file_rpc_chat_proto_rawDescGZIP(), []int{21, 0}
 }
 
-func (x *DescribeConversationRequest) GetConversationId() string {
+func (x *SearchSemanticResponse_Result) GetConversationId() string {
 	if x != nil {
 		return x.ConversationId
 	}
 	return ""
 }
 
-type DescribeConversationResponse struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	Conversation *Conversation `protobuf:"bytes,1,opt,name=conversation,proto3" json:"conversation,omitempty"`
-}
-
-func (x *DescribeConversationResponse) Reset() {
-	*x = DescribeConversationResponse{}
-	mi := &file_rpc_chat_proto_msgTypes[8]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *DescribeConversationResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*DescribeConversationResponse) ProtoMessage() {}
-
-func (x *DescribeConversationResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_rpc_chat_proto_msgTypes[8]
+func (x *SearchSemanticResponse_Result) GetMessage() *Conversation_Message {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.Message
 	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use DescribeConversationResponse.ProtoReflect.Descriptor instead.
-func (*DescribeConversationResponse) Descriptor() ([]byte, []int) {
-	return file_rpc_chat_proto_rawDescGZIP(), []int{8}
+	return nil
 }
 
-func (x *DescribeConversationResponse) GetConversation() *Conversation {
+func (x *SearchSemanticResponse_Result) GetScore() float64 {
 	if x != nil {
-		return x.Conversation
+		return x.Score
 	}
-	return nil
+	return 0
 }
 
-type Conversation_Message struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+type Quota_Allowance struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 0 when unlimited
+	Limit int64 `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	Used  int64 `protobuf:"varint,2,opt,name=used,proto3" json:"used,omitempty"`
+	// 0 when used up or unlimited
+	Remaining     int64 `protobuf:"varint,3,opt,name=remaining,proto3" json:"remaining,omitempty"`
+	Unlimited     bool  `protobuf:"varint,4,opt,name=unlimited,proto3" json:"unlimited,omitempty"`
 	unknownFields protoimpl.UnknownFields
-
-	Id        string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Role      Conversation_Role      `protobuf:"varint,2,opt,name=role,proto3,enum=acai.chat.Conversation_Role" json:"role,omitempty"`
-	Content   string                 `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
-	Timestamp *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Conversation_Message) Reset() {
-	*x = Conversation_Message{}
-	mi := &file_rpc_chat_proto_msgTypes[9]
+func (x *Quota_Allowance) Reset() {
+	*x = Quota_Allowance{}
+	mi := &file_rpc_chat_proto_msgTypes[105]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Conversation_Message) String() string {
+func (x *Quota_Allowance) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Conversation_Message) ProtoMessage() {}
+func (*Quota_Allowance) ProtoMessage() {}
 
-func (x *Conversation_Message) ProtoReflect() protoreflect.Message {
-	mi := &file_rpc_chat_proto_msgTypes[9]
+func (x *Quota_Allowance) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_chat_proto_msgTypes[105]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -550,185 +6263,805 @@ func (x *Conversation_Message) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Conversation_Message.ProtoReflect.Descriptor instead.
-func (*Conversation_Message) Descriptor() ([]byte, []int) {
-	return file_rpc_chat_proto_rawDescGZIP(), []int{0, 0}
+// Deprecated: Use Quota_Allowance.ProtoReflect.Descriptor instead.
+func (*Quota_Allowance) Descriptor() ([]byte, []int) {
+	return file_rpc_chat_proto_rawDescGZIP(), []int{97, 0}
 }
 
-func (x *Conversation_Message) GetId() string {
+func (x *Quota_Allowance) GetLimit() int64 {
 	if x != nil {
-		return x.Id
+		return x.Limit
 	}
-	return ""
+	return 0
 }
 
-func (x *Conversation_Message) GetRole() Conversation_Role {
+func (x *Quota_Allowance) GetUsed() int64 {
 	if x != nil {
-		return x.Role
+		return x.Used
 	}
-	return Conversation_UNKNOWN
+	return 0
 }
 
-func (x *Conversation_Message) GetContent() string {
+func (x *Quota_Allowance) GetRemaining() int64 {
 	if x != nil {
-		return x.Content
+		return x.Remaining
 	}
-	return ""
+	return 0
 }
 
-func (x *Conversation_Message) GetTimestamp() *timestamppb.Timestamp {
+func (x *Quota_Allowance) GetUnlimited() bool {
 	if x != nil {
-		return x.Timestamp
+		return x.Unlimited
 	}
-	return nil
+	return false
 }
 
 var File_rpc_chat_proto protoreflect.FileDescriptor
 
-var file_rpc_chat_proto_rawDesc = []byte{
-	0x0a, 0x0e, 0x72, 0x70, 0x63, 0x2f, 0x63, 0x68, 0x61, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x12, 0x09, 0x61, 0x63, 0x61, 0x69, 0x2e, 0x63, 0x68, 0x61, 0x74, 0x1a, 0x1f, 0x67, 0x6f, 0x6f,
-	0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d,
-	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xfb, 0x02, 0x0a,
-	0x0c, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x0e, 0x0a,
-	0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x14, 0x0a,
-	0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x69,
-	0x74, 0x6c, 0x65, 0x12, 0x38, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
-	0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
-	0x6d, 0x70, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x3b, 0x0a,
-	0x08, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32,
-	0x1f, 0x2e, 0x61, 0x63, 0x61, 0x69, 0x2e, 0x63, 0x68, 0x61, 0x74, 0x2e, 0x43, 0x6f, 0x6e, 0x76,
-	0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
-	0x52, 0x08, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x1a, 0x9f, 0x01, 0x0a, 0x07, 0x4d,
-	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x30, 0x0a, 0x04, 0x72, 0x6f, 0x6c, 0x65, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x0e, 0x32, 0x1c, 0x2e, 0x61, 0x63, 0x61, 0x69, 0x2e, 0x63, 0x68, 0x61, 0x74,
-	0x2e, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x52, 0x6f,
-	0x6c, 0x65, 0x52, 0x04, 0x72, 0x6f, 0x6c, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74,
-	0x65, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65,
-	0x6e, 0x74, 0x12, 0x38, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18,
-	0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
-	0x70, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x22, 0x2c, 0x0a, 0x04,
-	0x52, 0x6f, 0x6c, 0x65, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10,
-	0x00, 0x12, 0x08, 0x0a, 0x04, 0x55, 0x53, 0x45, 0x52, 0x10, 0x01, 0x12, 0x0d, 0x0a, 0x09, 0x41,
-	0x53, 0x53, 0x49, 0x53, 0x54, 0x41, 0x4e, 0x54, 0x10, 0x02, 0x22, 0x34, 0x0a, 0x18, 0x53, 0x74,
-	0x61, 0x72, 0x74, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67,
-	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
-	0x22, 0x70, 0x0a, 0x19, 0x53, 0x74, 0x61, 0x72, 0x74, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73,
-	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x27, 0x0a,
-	0x0f, 0x63, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x63, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61,
-	0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x12, 0x14, 0x0a, 0x05,
-	0x72, 0x65, 0x70, 0x6c, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x72, 0x65, 0x70,
-	0x6c, 0x79, 0x22, 0x60, 0x0a, 0x1b, 0x43, 0x6f, 0x6e, 0x74, 0x69, 0x6e, 0x75, 0x65, 0x43, 0x6f,
-	0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x12, 0x27, 0x0a, 0x0f, 0x63, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f,
-	0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x63, 0x6f, 0x6e, 0x76,
-	0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65,
-	0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73,
-	0x73, 0x61, 0x67, 0x65, 0x22, 0x34, 0x0a, 0x1c, 0x43, 0x6f, 0x6e, 0x74, 0x69, 0x6e, 0x75, 0x65,
-	0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x65, 0x70, 0x6c, 0x79, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x05, 0x72, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x1a, 0x0a, 0x18, 0x4c, 0x69,
-	0x73, 0x74, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x5a, 0x0a, 0x19, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x6f,
-	0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x12, 0x3d, 0x0a, 0x0d, 0x63, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74,
-	0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x61, 0x63, 0x61,
-	0x69, 0x2e, 0x63, 0x68, 0x61, 0x74, 0x2e, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74,
-	0x69, 0x6f, 0x6e, 0x52, 0x0d, 0x63, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f,
-	0x6e, 0x73, 0x22, 0x46, 0x0a, 0x1b, 0x44, 0x65, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x43, 0x6f,
-	0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x12, 0x27, 0x0a, 0x0f, 0x63, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f,
-	0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x63, 0x6f, 0x6e, 0x76,
-	0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x22, 0x5b, 0x0a, 0x1c, 0x44, 0x65,
-	0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69,
-	0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3b, 0x0a, 0x0c, 0x63, 0x6f,
-	0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x17, 0x2e, 0x61, 0x63, 0x61, 0x69, 0x2e, 0x63, 0x68, 0x61, 0x74, 0x2e, 0x43, 0x6f, 0x6e,
-	0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0c, 0x63, 0x6f, 0x6e, 0x76, 0x65,
-	0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x32, 0x9f, 0x03, 0x0a, 0x0b, 0x43, 0x68, 0x61, 0x74,
-	0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x5e, 0x0a, 0x11, 0x53, 0x74, 0x61, 0x72, 0x74,
-	0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x23, 0x2e, 0x61,
-	0x63, 0x61, 0x69, 0x2e, 0x63, 0x68, 0x61, 0x74, 0x2e, 0x53, 0x74, 0x61, 0x72, 0x74, 0x43, 0x6f,
-	0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x1a, 0x24, 0x2e, 0x61, 0x63, 0x61, 0x69, 0x2e, 0x63, 0x68, 0x61, 0x74, 0x2e, 0x53, 0x74,
-	0x61, 0x72, 0x74, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x67, 0x0a, 0x14, 0x43, 0x6f, 0x6e, 0x74, 0x69,
-	0x6e, 0x75, 0x65, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12,
-	0x26, 0x2e, 0x61, 0x63, 0x61, 0x69, 0x2e, 0x63, 0x68, 0x61, 0x74, 0x2e, 0x43, 0x6f, 0x6e, 0x74,
-	0x69, 0x6e, 0x75, 0x65, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x27, 0x2e, 0x61, 0x63, 0x61, 0x69, 0x2e, 0x63,
-	0x68, 0x61, 0x74, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x69, 0x6e, 0x75, 0x65, 0x43, 0x6f, 0x6e, 0x76,
-	0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x12, 0x5e, 0x0a, 0x11, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61,
-	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x23, 0x2e, 0x61, 0x63, 0x61, 0x69, 0x2e, 0x63, 0x68, 0x61,
-	0x74, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69,
-	0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e, 0x61, 0x63, 0x61,
-	0x69, 0x2e, 0x63, 0x68, 0x61, 0x74, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x6f, 0x6e, 0x76, 0x65,
-	0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x12, 0x67, 0x0a, 0x14, 0x44, 0x65, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x43, 0x6f, 0x6e, 0x76,
-	0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x26, 0x2e, 0x61, 0x63, 0x61, 0x69, 0x2e,
-	0x63, 0x68, 0x61, 0x74, 0x2e, 0x44, 0x65, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x43, 0x6f, 0x6e,
-	0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x27, 0x2e, 0x61, 0x63, 0x61, 0x69, 0x2e, 0x63, 0x68, 0x61, 0x74, 0x2e, 0x44, 0x65, 0x73,
-	0x63, 0x72, 0x69, 0x62, 0x65, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f,
-	0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x0d, 0x5a, 0x0b, 0x69, 0x6e, 0x74,
-	0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
-}
+const file_rpc_chat_proto_rawDesc = "" +
+	"\n" +
+	"\x0erpc/chat.proto\x12\tacai.chat\x1a google/protobuf/field_mask.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xb6\r\n" +
+	"\fConversation\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x128\n" +
+	"\ttimestamp\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12;\n" +
+	"\bmessages\x18\x04 \x03(\v2\x1f.acai.chat.Conversation.MessageR\bmessages\x129\n" +
+	"\bsettings\x18\x05 \x01(\v2\x1d.acai.chat.GenerationSettingsR\bsettings\x12\x1c\n" +
+	"\tassistant\x18\x06 \x01(\tR\tassistant\x129\n" +
+	"\apreview\x18\a \x01(\v2\x1f.acai.chat.Conversation.PreviewR\apreview\x12\x1a\n" +
+	"\blanguage\x18\b \x01(\tR\blanguage\x12!\n" +
+	"\ffailed_reply\x18\t \x01(\tR\vfailedReply\x12\x12\n" +
+	"\x04tags\x18\n" +
+	" \x03(\tR\x04tags\x12;\n" +
+	"\varchived_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"archivedAt\x123\n" +
+	"\x05usage\x18\f \x01(\v2\x1d.acai.chat.Conversation.UsageR\x05usage\x12\x12\n" +
+	"\x04icon\x18\r \x01(\tR\x04icon\x12\x18\n" +
+	"\asummary\x18\x0e \x01(\tR\asummary\x12)\n" +
+	"\x10omitted_messages\x18\x0f \x01(\x05R\x0fomittedMessages\x1a\x81\x04\n" +
+	"\aMessage\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x120\n" +
+	"\x04role\x18\x02 \x01(\x0e2\x1c.acai.chat.Conversation.RoleR\x04role\x12\x18\n" +
+	"\acontent\x18\x03 \x01(\tR\acontent\x128\n" +
+	"\ttimestamp\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x127\n" +
+	"\vattachments\x18\x05 \x03(\v2\x15.acai.chat.AttachmentR\vattachments\x12#\n" +
+	"\rpersonal_data\x18\x06 \x03(\tR\fpersonalData\x12?\n" +
+	"\n" +
+	"tool_calls\x18\a \x03(\v2 .acai.chat.Conversation.ToolCallR\ttoolCalls\x127\n" +
+	"\tpinned_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\bpinnedAt\x12\x16\n" +
+	"\x06intent\x18\t \x01(\tR\x06intent\x12\x1b\n" +
+	"\tdevice_id\x18\n" +
+	" \x01(\tR\bdeviceId\x120\n" +
+	"\x06rating\x18\v \x01(\x0e2\x18.acai.chat.MessageRatingR\x06rating\x12!\n" +
+	"\fcontent_gzip\x18\f \x01(\fR\vcontentGzip\x1ab\n" +
+	"\bToolCall\x12\x12\n" +
+	"\x04tool\x18\x01 \x01(\tR\x04tool\x12\x12\n" +
+	"\x04call\x18\x02 \x01(\tR\x04call\x12\x16\n" +
+	"\x06result\x18\x03 \x01(\tR\x06result\x12\x16\n" +
+	"\x06failed\x18\x04 \x01(\bR\x06failed\x1a\x95\x01\n" +
+	"\x05Usage\x12#\n" +
+	"\rprompt_tokens\x18\x01 \x01(\x03R\fpromptTokens\x12+\n" +
+	"\x11completion_tokens\x18\x02 \x01(\x03R\x10completionTokens\x12\x19\n" +
+	"\bcost_usd\x18\x03 \x01(\x01R\acostUsd\x12\x1f\n" +
+	"\vover_budget\x18\x04 \x01(\bR\n" +
+	"overBudget\x1a\xa8\x02\n" +
+	"\aPreview\x12!\n" +
+	"\flast_message\x18\x01 \x01(\tR\vlastMessage\x12H\n" +
+	"\x11last_message_role\x18\x02 \x01(\x0e2\x1c.acai.chat.Conversation.RoleR\x0flastMessageRole\x12P\n" +
+	"\x16last_message_timestamp\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x14lastMessageTimestamp\x12#\n" +
+	"\rmessage_count\x18\x04 \x01(\x05R\fmessageCount\x12\x16\n" +
+	"\x06unread\x18\x05 \x01(\bR\x06unread\x12!\n" +
+	"\funread_count\x18\x06 \x01(\x05R\vunreadCount\",\n" +
+	"\x04Role\x12\v\n" +
+	"\aUNKNOWN\x10\x00\x12\b\n" +
+	"\x04USER\x10\x01\x12\r\n" +
+	"\tASSISTANT\x10\x02\"\xd9\x01\n" +
+	"\x12GenerationSettings\x12\x14\n" +
+	"\x05model\x18\x01 \x01(\tR\x05model\x12%\n" +
+	"\vtemperature\x18\x02 \x01(\x01H\x00R\vtemperature\x88\x01\x01\x12*\n" +
+	"\x11max_output_tokens\x18\x03 \x01(\x03R\x0fmaxOutputTokens\x12 \n" +
+	"\fmax_cost_usd\x18\x04 \x01(\x01R\n" +
+	"maxCostUsd\x12(\n" +
+	"\x10max_total_tokens\x18\x05 \x01(\x03R\x0emaxTotalTokensB\x0e\n" +
+	"\f_temperature\"p\n" +
+	"\vToolOptions\x12\x1d\n" +
+	"\n" +
+	"force_tool\x18\x01 \x01(\tR\tforceTool\x12\x1d\n" +
+	"\n" +
+	"deny_tools\x18\x02 \x03(\tR\tdenyTools\x12#\n" +
+	"\rdisable_tools\x18\x03 \x01(\bR\fdisableTools\"\xad\x03\n" +
+	"\x18StartConversationRequest\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\x129\n" +
+	"\ftool_options\x18\x02 \x01(\v2\x16.acai.chat.ToolOptionsR\vtoolOptions\x122\n" +
+	"\tverbosity\x18\x03 \x01(\x0e2\x14.acai.chat.VerbosityR\tverbosity\x129\n" +
+	"\bsettings\x18\x04 \x01(\v2\x1d.acai.chat.GenerationSettingsR\bsettings\x12\x1c\n" +
+	"\tassistant\x18\x05 \x01(\tR\tassistant\x12%\n" +
+	"\x0eattachment_ids\x18\x06 \x03(\tR\rattachmentIds\x12\x1a\n" +
+	"\blanguage\x18\a \x01(\tR\blanguage\x12\x12\n" +
+	"\x04tags\x18\b \x03(\tR\x04tags\x12#\n" +
+	"\rquick_replies\x18\t \x01(\bR\fquickReplies\x123\n" +
+	"\x15similar_conversations\x18\n" +
+	" \x01(\bR\x14similarConversations\"\x95\x02\n" +
+	"\x19StartConversationResponse\x12'\n" +
+	"\x0fconversation_id\x18\x01 \x01(\tR\x0econversationId\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12\x14\n" +
+	"\x05reply\x18\x03 \x01(\tR\x05reply\x12:\n" +
+	"\rquick_replies\x18\x04 \x03(\v2\x15.acai.chat.QuickReplyR\fquickReplies\x12S\n" +
+	"\x15similar_conversations\x18\x05 \x03(\v2\x1e.acai.chat.SimilarConversationR\x14similarConversations\x12\x12\n" +
+	"\x04icon\x18\x06 \x01(\tR\x04icon\"j\n" +
+	"\x13SimilarConversation\x12'\n" +
+	"\x0fconversation_id\x18\x01 \x01(\tR\x0econversationId\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12\x14\n" +
+	"\x05score\x18\x03 \x01(\x01R\x05score\"\xc7\x02\n" +
+	"\x1bContinueConversationRequest\x12'\n" +
+	"\x0fconversation_id\x18\x01 \x01(\tR\x0econversationId\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x129\n" +
+	"\ftool_options\x18\x03 \x01(\v2\x16.acai.chat.ToolOptionsR\vtoolOptions\x122\n" +
+	"\tverbosity\x18\x04 \x01(\x0e2\x14.acai.chat.VerbosityR\tverbosity\x12%\n" +
+	"\x0eattachment_ids\x18\x05 \x03(\tR\rattachmentIds\x12#\n" +
+	"\rquick_replies\x18\x06 \x01(\bR\fquickReplies\x12*\n" +
+	"\x11max_output_tokens\x18\a \x01(\x03R\x0fmaxOutputTokens\"p\n" +
+	"\x1cContinueConversationResponse\x12\x14\n" +
+	"\x05reply\x18\x01 \x01(\tR\x05reply\x12:\n" +
+	"\rquick_replies\x18\x02 \x03(\v2\x15.acai.chat.QuickReplyR\fquickReplies\"<\n" +
+	"\n" +
+	"QuickReply\x12\x14\n" +
+	"\x05label\x18\x01 \x01(\tR\x05label\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\xa7\x01\n" +
+	"\x18ListConversationsRequest\x12'\n" +
+	"\x0finclude_preview\x18\x01 \x01(\bR\x0eincludePreview\x12)\n" +
+	"\x10include_archived\x18\x02 \x01(\bR\x0fincludeArchived\x127\n" +
+	"\tread_mask\x18\x03 \x01(\v2\x1a.google.protobuf.FieldMaskR\breadMask\"Z\n" +
+	"\x19ListConversationsResponse\x12=\n" +
+	"\rconversations\x18\x01 \x03(\v2\x17.acai.chat.ConversationR\rconversations\"\xb4\x01\n" +
+	"\x1bDescribeConversationRequest\x12'\n" +
+	"\x0fconversation_id\x18\x01 \x01(\tR\x0econversationId\x123\n" +
+	"\ahistory\x18\x02 \x01(\v2\x19.acai.chat.HistoryOptionsR\ahistory\x127\n" +
+	"\tread_mask\x18\x03 \x01(\v2\x1a.google.protobuf.FieldMaskR\breadMask\"\xc5\x01\n" +
+	"\x0eHistoryOptions\x12!\n" +
+	"\fsummary_only\x18\x01 \x01(\bR\vsummaryOnly\x12!\n" +
+	"\fmax_messages\x18\x02 \x01(\x05R\vmaxMessages\x12.\n" +
+	"\x13compress_over_bytes\x18\x03 \x01(\x05R\x11compressOverBytes\x12=\n" +
+	"\fmessage_mask\x18\x04 \x01(\v2\x1a.google.protobuf.FieldMaskR\vmessageMask\"[\n" +
+	"\x1cDescribeConversationResponse\x12;\n" +
+	"\fconversation\x18\x01 \x01(\v2\x17.acai.chat.ConversationR\fconversation\"B\n" +
+	"\x17RetryFailedReplyRequest\x12'\n" +
+	"\x0fconversation_id\x18\x01 \x01(\tR\x0econversationId\"0\n" +
+	"\x18RetryFailedReplyResponse\x12\x14\n" +
+	"\x05reply\x18\x01 \x01(\tR\x05reply\"B\n" +
+	"\x17CancelGenerationRequest\x12'\n" +
+	"\x0fconversation_id\x18\x01 \x01(\tR\x0econversationId\"8\n" +
+	"\x18CancelGenerationResponse\x12\x1c\n" +
+	"\tcancelled\x18\x01 \x01(\bR\tcancelled\"Y\n" +
+	"\x0fMarkReadRequest\x12'\n" +
+	"\x0fconversation_id\x18\x01 \x01(\tR\x0econversationId\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x02 \x01(\tR\tmessageId\"\x12\n" +
+	"\x10MarkReadResponse\"C\n" +
+	"\x15SearchSemanticRequest\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"\xe1\x01\n" +
+	"\x16SearchSemanticResponse\x12B\n" +
+	"\aresults\x18\x01 \x03(\v2(.acai.chat.SearchSemanticResponse.ResultR\aresults\x1a\x82\x01\n" +
+	"\x06Result\x12'\n" +
+	"\x0fconversation_id\x18\x01 \x01(\tR\x0econversationId\x129\n" +
+	"\amessage\x18\x02 \x01(\v2\x1f.acai.chat.Conversation.MessageR\amessage\x12\x14\n" +
+	"\x05score\x18\x03 \x01(\x01R\x05score\"\xaa\x01\n" +
+	"\n" +
+	"Attachment\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1a\n" +
+	"\bfilename\x18\x02 \x01(\tR\bfilename\x12!\n" +
+	"\fcontent_type\x18\x03 \x01(\tR\vcontentType\x12\x12\n" +
+	"\x04size\x18\x04 \x01(\x03R\x04size\x129\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"e\n" +
+	"\x1eSetConversationLanguageRequest\x12'\n" +
+	"\x0fconversation_id\x18\x01 \x01(\tR\x0econversationId\x12\x1a\n" +
+	"\blanguage\x18\x02 \x01(\tR\blanguage\"=\n" +
+	"\x1fSetConversationLanguageResponse\x12\x1a\n" +
+	"\blanguage\x18\x01 \x01(\tR\blanguage\"l\n" +
+	"\x17UploadAttachmentRequest\x12\x1a\n" +
+	"\bfilename\x18\x01 \x01(\tR\bfilename\x12!\n" +
+	"\fcontent_type\x18\x02 \x01(\tR\vcontentType\x12\x12\n" +
+	"\x04data\x18\x03 \x01(\fR\x04data\"Q\n" +
+	"\x18UploadAttachmentResponse\x125\n" +
+	"\n" +
+	"attachment\x18\x01 \x01(\v2\x15.acai.chat.AttachmentR\n" +
+	"attachment\"@\n" +
+	"\x19DownloadAttachmentRequest\x12#\n" +
+	"\rattachment_id\x18\x01 \x01(\tR\fattachmentId\"g\n" +
+	"\x1aDownloadAttachmentResponse\x125\n" +
+	"\n" +
+	"attachment\x18\x01 \x01(\v2\x15.acai.chat.AttachmentR\n" +
+	"attachment\x12\x12\n" +
+	"\x04data\x18\x02 \x01(\fR\x04data\"s\n" +
+	"\rLocationAlias\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1a\n" +
+	"\blatitude\x18\x02 \x01(\x01R\blatitude\x12\x1c\n" +
+	"\tlongitude\x18\x03 \x01(\x01R\tlongitude\x12\x14\n" +
+	"\x05label\x18\x04 \x01(\tR\x05label\"I\n" +
+	"\x17SetLocationAliasRequest\x12.\n" +
+	"\x05alias\x18\x01 \x01(\v2\x18.acai.chat.LocationAliasR\x05alias\"J\n" +
+	"\x18SetLocationAliasResponse\x12.\n" +
+	"\x05alias\x18\x01 \x01(\v2\x18.acai.chat.LocationAliasR\x05alias\"0\n" +
+	"\x1aDeleteLocationAliasRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"\x1d\n" +
+	"\x1bDeleteLocationAliasResponse\"\x1c\n" +
+	"\x1aListLocationAliasesRequest\"Q\n" +
+	"\x1bListLocationAliasesResponse\x122\n" +
+	"\aaliases\x18\x01 \x03(\v2\x18.acai.chat.LocationAliasR\aaliases\"4\n" +
+	"\fUserCalendar\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x10\n" +
+	"\x03url\x18\x02 \x01(\tR\x03url\"M\n" +
+	"\x16SetUserCalendarRequest\x123\n" +
+	"\bcalendar\x18\x01 \x01(\v2\x17.acai.chat.UserCalendarR\bcalendar\"N\n" +
+	"\x17SetUserCalendarResponse\x123\n" +
+	"\bcalendar\x18\x01 \x01(\v2\x17.acai.chat.UserCalendarR\bcalendar\"/\n" +
+	"\x19DeleteUserCalendarRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"\x1c\n" +
+	"\x1aDeleteUserCalendarResponse\"\x1a\n" +
+	"\x18ListUserCalendarsRequest\"\x84\x01\n" +
+	"\x19ListUserCalendarsResponse\x125\n" +
+	"\tcalendars\x18\x01 \x03(\v2\x17.acai.chat.UserCalendarR\tcalendars\x120\n" +
+	"\x06caldav\x18\x02 \x01(\v2\x18.acai.chat.CalDAVAccountR\x06caldav\"=\n" +
+	"\rCalDAVAccount\x12\x10\n" +
+	"\x03url\x18\x01 \x01(\tR\x03url\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\"i\n" +
+	"\x17SetCalDAVAccountRequest\x122\n" +
+	"\aaccount\x18\x01 \x01(\v2\x18.acai.chat.CalDAVAccountR\aaccount\x12\x1a\n" +
+	"\bpassword\x18\x02 \x01(\tR\bpassword\"N\n" +
+	"\x18SetCalDAVAccountResponse\x122\n" +
+	"\aaccount\x18\x01 \x01(\v2\x18.acai.chat.CalDAVAccountR\aaccount\"\x1c\n" +
+	"\x1aDeleteCalDAVAccountRequest\"\x1d\n" +
+	"\x1bDeleteCalDAVAccountResponse\"a\n" +
+	"\x12ConversationFilter\x129\n" +
+	"\n" +
+	"older_than\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\tolderThan\x12\x10\n" +
+	"\x03tag\x18\x02 \x01(\tR\x03tag\"\x82\x01\n" +
+	"\x1eBulkDeleteConversationsRequest\x12)\n" +
+	"\x10conversation_ids\x18\x01 \x03(\tR\x0fconversationIds\x125\n" +
+	"\x06filter\x18\x02 \x01(\v2\x1d.acai.chat.ConversationFilterR\x06filter\"N\n" +
+	"\x1fBulkDeleteConversationsResponse\x12\x14\n" +
+	"\x05count\x18\x01 \x01(\x05R\x05count\x12\x15\n" +
+	"\x06job_id\x18\x02 \x01(\tR\x05jobId\"\x83\x01\n" +
+	"\x1fBulkArchiveConversationsRequest\x12)\n" +
+	"\x10conversation_ids\x18\x01 \x03(\tR\x0fconversationIds\x125\n" +
+	"\x06filter\x18\x02 \x01(\v2\x1d.acai.chat.ConversationFilterR\x06filter\"O\n" +
+	" BulkArchiveConversationsResponse\x12\x14\n" +
+	"\x05count\x18\x01 \x01(\x05R\x05count\x12\x15\n" +
+	"\x06job_id\x18\x02 \x01(\tR\x05jobId\"\xf5\x02\n" +
+	"\aBulkJob\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06action\x18\x02 \x01(\tR\x06action\x12.\n" +
+	"\x05state\x18\x03 \x01(\x0e2\x18.acai.chat.BulkJob.StateR\x05state\x12\x14\n" +
+	"\x05total\x18\x04 \x01(\x05R\x05total\x12\x14\n" +
+	"\x05count\x18\x05 \x01(\x05R\x05count\x12\x14\n" +
+	"\x05error\x18\x06 \x01(\tR\x05error\x129\n" +
+	"\n" +
+	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12!\n" +
+	"\fdownload_url\x18\t \x01(\tR\vdownloadUrl\"7\n" +
+	"\x05State\x12\v\n" +
+	"\aUNKNOWN\x10\x00\x12\v\n" +
+	"\aRUNNING\x10\x01\x12\b\n" +
+	"\x04DONE\x10\x02\x12\n" +
+	"\n" +
+	"\x06FAILED\x10\x03\"*\n" +
+	"\x11GetBulkJobRequest\x12\x15\n" +
+	"\x06job_id\x18\x01 \x01(\tR\x05jobId\":\n" +
+	"\x12GetBulkJobResponse\x12$\n" +
+	"\x03job\x18\x01 \x01(\v2\x12.acai.chat.BulkJobR\x03job\"\x1d\n" +
+	"\x1bRequestExportArchiveRequest\"5\n" +
+	"\x1cRequestExportArchiveResponse\x12\x15\n" +
+	"\x06job_id\x18\x01 \x01(\tR\x05jobId\"\xe4\x01\n" +
+	"\x19ExportConversationRequest\x12'\n" +
+	"\x0fconversation_id\x18\x01 \x01(\tR\x0econversationId\x12C\n" +
+	"\x06format\x18\x02 \x01(\x0e2+.acai.chat.ExportConversationRequest.FormatR\x06format\x12.\n" +
+	"\x13include_tool_traces\x18\x03 \x01(\bR\x11includeToolTraces\")\n" +
+	"\x06Format\x12\f\n" +
+	"\bMARKDOWN\x10\x00\x12\b\n" +
+	"\x04JSON\x10\x01\x12\a\n" +
+	"\x03PDF\x10\x02\"o\n" +
+	"\x1aExportConversationResponse\x12\x1a\n" +
+	"\bfilename\x18\x01 \x01(\tR\bfilename\x12!\n" +
+	"\fcontent_type\x18\x02 \x01(\tR\vcontentType\x12\x12\n" +
+	"\x04data\x18\x03 \x01(\fR\x04data\"q\n" +
+	"\x11PinMessageRequest\x12'\n" +
+	"\x0fconversation_id\x18\x01 \x01(\tR\x0econversationId\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x02 \x01(\tR\tmessageId\x12\x14\n" +
+	"\x05unpin\x18\x03 \x01(\bR\x05unpin\"O\n" +
+	"\x12PinMessageResponse\x129\n" +
+	"\amessage\x18\x01 \x01(\v2\x1f.acai.chat.Conversation.MessageR\amessage\"D\n" +
+	"\x19ListPinnedMessagesRequest\x12'\n" +
+	"\x0fconversation_id\x18\x01 \x01(\tR\x0econversationId\"Y\n" +
+	"\x1aListPinnedMessagesResponse\x12;\n" +
+	"\bmessages\x18\x01 \x03(\v2\x1f.acai.chat.Conversation.MessageR\bmessages\"\x8e\x01\n" +
+	"\x12RateMessageRequest\x12'\n" +
+	"\x0fconversation_id\x18\x01 \x01(\tR\x0econversationId\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x02 \x01(\tR\tmessageId\x120\n" +
+	"\x06rating\x18\x03 \x01(\x0e2\x18.acai.chat.MessageRatingR\x06rating\"P\n" +
+	"\x13RateMessageResponse\x129\n" +
+	"\amessage\x18\x01 \x01(\v2\x1f.acai.chat.Conversation.MessageR\amessage\"\xaa\x02\n" +
+	"\x12DigestSubscription\x128\n" +
+	"\tfrequency\x18\x01 \x01(\x0e2\x1a.acai.chat.DigestFrequencyR\tfrequency\x125\n" +
+	"\bdelivery\x18\x02 \x01(\x0e2\x19.acai.chat.DigestDeliveryR\bdelivery\x12\x18\n" +
+	"\acountry\x18\x03 \x01(\tR\acountry\x12\x16\n" +
+	"\x06region\x18\x04 \x01(\tR\x06region\x123\n" +
+	"\anext_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\x06nextAt\x12<\n" +
+	"\flast_sent_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"lastSentAt\"\xc1\x01\n" +
+	"\x1cSetDigestSubscriptionRequest\x128\n" +
+	"\tfrequency\x18\x01 \x01(\x0e2\x1a.acai.chat.DigestFrequencyR\tfrequency\x125\n" +
+	"\bdelivery\x18\x02 \x01(\x0e2\x19.acai.chat.DigestDeliveryR\bdelivery\x12\x18\n" +
+	"\acountry\x18\x03 \x01(\tR\acountry\x12\x16\n" +
+	"\x06region\x18\x04 \x01(\tR\x06region\"b\n" +
+	"\x1dSetDigestSubscriptionResponse\x12A\n" +
+	"\fsubscription\x18\x01 \x01(\v2\x1d.acai.chat.DigestSubscriptionR\fsubscription\"\x1e\n" +
+	"\x1cGetDigestSubscriptionRequest\"b\n" +
+	"\x1dGetDigestSubscriptionResponse\x12A\n" +
+	"\fsubscription\x18\x01 \x01(\v2\x1d.acai.chat.DigestSubscriptionR\fsubscription\"\x83\x01\n" +
+	"\vPreferences\x120\n" +
+	"\x11health_advisories\x18\x01 \x01(\bH\x00R\x10healthAdvisories\x88\x01\x01\x12\x1f\n" +
+	"\btimezone\x18\x02 \x01(\tH\x01R\btimezone\x88\x01\x01B\x14\n" +
+	"\x12_health_advisoriesB\v\n" +
+	"\t_timezone\"Q\n" +
+	"\x15SetPreferencesRequest\x128\n" +
+	"\vpreferences\x18\x01 \x01(\v2\x16.acai.chat.PreferencesR\vpreferences\"R\n" +
+	"\x16SetPreferencesResponse\x128\n" +
+	"\vpreferences\x18\x01 \x01(\v2\x16.acai.chat.PreferencesR\vpreferences\"\x17\n" +
+	"\x15GetPreferencesRequest\"R\n" +
+	"\x16GetPreferencesResponse\x128\n" +
+	"\vpreferences\x18\x01 \x01(\v2\x16.acai.chat.PreferencesR\vpreferences\"\xb6\x01\n" +
+	"\x14NotificationSettings\x12\x1a\n" +
+	"\bchannels\x18\x01 \x03(\tR\bchannels\x124\n" +
+	"\x06routes\x18\x02 \x03(\v2\x1c.acai.chat.NotificationRouteR\x06routes\x12\x14\n" +
+	"\x05email\x18\x03 \x01(\tR\x05email\x126\n" +
+	"\vquiet_hours\x18\x04 \x01(\v2\x15.acai.chat.QuietHoursR\n" +
+	"quietHours\"C\n" +
+	"\x11NotificationRoute\x12\x12\n" +
+	"\x04kind\x18\x01 \x01(\tR\x04kind\x12\x1a\n" +
+	"\bchannels\x18\x02 \x03(\tR\bchannels\"P\n" +
+	"\n" +
+	"QuietHours\x12\x14\n" +
+	"\x05start\x18\x01 \x01(\tR\x05start\x12\x10\n" +
+	"\x03end\x18\x02 \x01(\tR\x03end\x12\x1a\n" +
+	"\btimezone\x18\x03 \x01(\tR\btimezone\"]\n" +
+	"\x1eSetNotificationSettingsRequest\x12;\n" +
+	"\bsettings\x18\x01 \x01(\v2\x1f.acai.chat.NotificationSettingsR\bsettings\"^\n" +
+	"\x1fSetNotificationSettingsResponse\x12;\n" +
+	"\bsettings\x18\x01 \x01(\v2\x1f.acai.chat.NotificationSettingsR\bsettings\" \n" +
+	"\x1eGetNotificationSettingsRequest\"\x8d\x01\n" +
+	"\x1fGetNotificationSettingsResponse\x12;\n" +
+	"\bsettings\x18\x01 \x01(\v2\x1f.acai.chat.NotificationSettingsR\bsettings\x12-\n" +
+	"\x12available_channels\x18\x02 \x03(\tR\x11availableChannels\"\xb5\x01\n" +
+	"\n" +
+	"PushDevice\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x123\n" +
+	"\bplatform\x18\x02 \x01(\x0e2\x17.acai.chat.PushPlatformR\bplatform\x12\x1b\n" +
+	"\tdevice_id\x18\x03 \x01(\tR\bdeviceId\x12?\n" +
+	"\rregistered_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\fregisteredAt\"f\n" +
+	"\x19RegisterPushDeviceRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x123\n" +
+	"\bplatform\x18\x02 \x01(\x0e2\x17.acai.chat.PushPlatformR\bplatform\"K\n" +
+	"\x1aRegisterPushDeviceResponse\x12-\n" +
+	"\x06device\x18\x01 \x01(\v2\x15.acai.chat.PushDeviceR\x06device\"3\n" +
+	"\x1bUnregisterPushDeviceRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\"\x1e\n" +
+	"\x1cUnregisterPushDeviceResponse\"\x18\n" +
+	"\x16ListPushDevicesRequest\"J\n" +
+	"\x17ListPushDevicesResponse\x12/\n" +
+	"\adevices\x18\x01 \x03(\v2\x15.acai.chat.PushDeviceR\adevices\"\xa3\x02\n" +
+	"\aSession\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
+	"\tdevice_id\x18\x02 \x01(\tR\bdeviceId\x12\x1d\n" +
+	"\n" +
+	"user_agent\x18\x03 \x01(\tR\tuserAgent\x129\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12<\n" +
+	"\flast_seen_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"lastSeenAt\x129\n" +
+	"\n" +
+	"revoked_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\trevokedAt\x12\x18\n" +
+	"\acurrent\x18\a \x01(\bR\acurrent\"\x15\n" +
+	"\x13StartSessionRequest\"Z\n" +
+	"\x14StartSessionResponse\x12,\n" +
+	"\asession\x18\x01 \x01(\v2\x12.acai.chat.SessionR\asession\x12\x14\n" +
+	"\x05token\x18\x02 \x01(\tR\x05token\">\n" +
+	"\x13ListSessionsRequest\x12'\n" +
+	"\x0finclude_revoked\x18\x01 \x01(\bR\x0eincludeRevoked\"F\n" +
+	"\x14ListSessionsResponse\x12.\n" +
+	"\bsessions\x18\x01 \x03(\v2\x12.acai.chat.SessionR\bsessions\"5\n" +
+	"\x14RevokeSessionRequest\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x01 \x01(\tR\tsessionId\"E\n" +
+	"\x15RevokeSessionResponse\x12,\n" +
+	"\asession\x18\x01 \x01(\v2\x12.acai.chat.SessionR\asession\"\xee\x02\n" +
+	"\x05Quota\x12\x12\n" +
+	"\x04plan\x18\x01 \x01(\tR\x04plan\x126\n" +
+	"\bmessages\x18\x02 \x01(\v2\x1a.acai.chat.Quota.AllowanceR\bmessages\x122\n" +
+	"\x06tokens\x18\x03 \x01(\v2\x1a.acai.chat.Quota.AllowanceR\x06tokens\x129\n" +
+	"\n" +
+	"tool_calls\x18\x04 \x01(\v2\x1a.acai.chat.Quota.AllowanceR\ttoolCalls\x127\n" +
+	"\tresets_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\bresetsAt\x1aq\n" +
+	"\tAllowance\x12\x14\n" +
+	"\x05limit\x18\x01 \x01(\x03R\x05limit\x12\x12\n" +
+	"\x04used\x18\x02 \x01(\x03R\x04used\x12\x1c\n" +
+	"\tremaining\x18\x03 \x01(\x03R\tremaining\x12\x1c\n" +
+	"\tunlimited\x18\x04 \x01(\bR\tunlimited\"\x11\n" +
+	"\x0fGetQuotaRequest\":\n" +
+	"\x10GetQuotaResponse\x12&\n" +
+	"\x05quota\x18\x01 \x01(\v2\x10.acai.chat.QuotaR\x05quota*g\n" +
+	"\tVerbosity\x12\x15\n" +
+	"\x11VERBOSITY_DEFAULT\x10\x00\x12\x15\n" +
+	"\x11VERBOSITY_CONCISE\x10\x01\x12\x16\n" +
+	"\x12VERBOSITY_DETAILED\x10\x02\x12\x14\n" +
+	"\x10VERBOSITY_BULLET\x10\x03*i\n" +
+	"\rMessageRating\x12\x1e\n" +
+	"\x1aMESSAGE_RATING_UNSPECIFIED\x10\x00\x12\x1b\n" +
+	"\x17MESSAGE_RATING_POSITIVE\x10\x01\x12\x1b\n" +
+	"\x17MESSAGE_RATING_NEGATIVE\x10\x02*d\n" +
+	"\x0fDigestFrequency\x12\x18\n" +
+	"\x14DIGEST_FREQUENCY_OFF\x10\x00\x12\x1a\n" +
+	"\x16DIGEST_FREQUENCY_DAILY\x10\x01\x12\x1b\n" +
+	"\x17DIGEST_FREQUENCY_WEEKLY\x10\x02*J\n" +
+	"\x0eDigestDelivery\x12\x1b\n" +
+	"\x17DIGEST_DELIVERY_MESSAGE\x10\x00\x12\x1b\n" +
+	"\x17DIGEST_DELIVERY_WEBHOOK\x10\x01*\\\n" +
+	"\fPushPlatform\x12\x1d\n" +
+	"\x19PUSH_PLATFORM_UNSPECIFIED\x10\x00\x12\x15\n" +
+	"\x11PUSH_PLATFORM_FCM\x10\x01\x12\x16\n" +
+	"\x12PUSH_PLATFORM_APNS\x10\x022\xf5\x1d\n" +
+	"\vChatService\x12^\n" +
+	"\x11StartConversation\x12#.acai.chat.StartConversationRequest\x1a$.acai.chat.StartConversationResponse\x12g\n" +
+	"\x14ContinueConversation\x12&.acai.chat.ContinueConversationRequest\x1a'.acai.chat.ContinueConversationResponse\x12^\n" +
+	"\x11ListConversations\x12#.acai.chat.ListConversationsRequest\x1a$.acai.chat.ListConversationsResponse\x12g\n" +
+	"\x14DescribeConversation\x12&.acai.chat.DescribeConversationRequest\x1a'.acai.chat.DescribeConversationResponse\x12[\n" +
+	"\x10RetryFailedReply\x12\".acai.chat.RetryFailedReplyRequest\x1a#.acai.chat.RetryFailedReplyResponse\x12[\n" +
+	"\x10CancelGeneration\x12\".acai.chat.CancelGenerationRequest\x1a#.acai.chat.CancelGenerationResponse\x12C\n" +
+	"\bMarkRead\x12\x1a.acai.chat.MarkReadRequest\x1a\x1b.acai.chat.MarkReadResponse\x12U\n" +
+	"\x0eSearchSemantic\x12 .acai.chat.SearchSemanticRequest\x1a!.acai.chat.SearchSemanticResponse\x12[\n" +
+	"\x10UploadAttachment\x12\".acai.chat.UploadAttachmentRequest\x1a#.acai.chat.UploadAttachmentResponse\x12a\n" +
+	"\x12DownloadAttachment\x12$.acai.chat.DownloadAttachmentRequest\x1a%.acai.chat.DownloadAttachmentResponse\x12p\n" +
+	"\x17SetConversationLanguage\x12).acai.chat.SetConversationLanguageRequest\x1a*.acai.chat.SetConversationLanguageResponse\x12[\n" +
+	"\x10SetLocationAlias\x12\".acai.chat.SetLocationAliasRequest\x1a#.acai.chat.SetLocationAliasResponse\x12d\n" +
+	"\x13DeleteLocationAlias\x12%.acai.chat.DeleteLocationAliasRequest\x1a&.acai.chat.DeleteLocationAliasResponse\x12d\n" +
+	"\x13ListLocationAliases\x12%.acai.chat.ListLocationAliasesRequest\x1a&.acai.chat.ListLocationAliasesResponse\x12X\n" +
+	"\x0fSetUserCalendar\x12!.acai.chat.SetUserCalendarRequest\x1a\".acai.chat.SetUserCalendarResponse\x12a\n" +
+	"\x12DeleteUserCalendar\x12$.acai.chat.DeleteUserCalendarRequest\x1a%.acai.chat.DeleteUserCalendarResponse\x12^\n" +
+	"\x11ListUserCalendars\x12#.acai.chat.ListUserCalendarsRequest\x1a$.acai.chat.ListUserCalendarsResponse\x12[\n" +
+	"\x10SetCalDAVAccount\x12\".acai.chat.SetCalDAVAccountRequest\x1a#.acai.chat.SetCalDAVAccountResponse\x12d\n" +
+	"\x13DeleteCalDAVAccount\x12%.acai.chat.DeleteCalDAVAccountRequest\x1a&.acai.chat.DeleteCalDAVAccountResponse\x12p\n" +
+	"\x17BulkDeleteConversations\x12).acai.chat.BulkDeleteConversationsRequest\x1a*.acai.chat.BulkDeleteConversationsResponse\x12s\n" +
+	"\x18BulkArchiveConversations\x12*.acai.chat.BulkArchiveConversationsRequest\x1a+.acai.chat.BulkArchiveConversationsResponse\x12I\n" +
+	"\n" +
+	"GetBulkJob\x12\x1c.acai.chat.GetBulkJobRequest\x1a\x1d.acai.chat.GetBulkJobResponse\x12g\n" +
+	"\x14RequestExportArchive\x12&.acai.chat.RequestExportArchiveRequest\x1a'.acai.chat.RequestExportArchiveResponse\x12a\n" +
+	"\x12ExportConversation\x12$.acai.chat.ExportConversationRequest\x1a%.acai.chat.ExportConversationResponse\x12I\n" +
+	"\n" +
+	"PinMessage\x12\x1c.acai.chat.PinMessageRequest\x1a\x1d.acai.chat.PinMessageResponse\x12a\n" +
+	"\x12ListPinnedMessages\x12$.acai.chat.ListPinnedMessagesRequest\x1a%.acai.chat.ListPinnedMessagesResponse\x12L\n" +
+	"\vRateMessage\x12\x1d.acai.chat.RateMessageRequest\x1a\x1e.acai.chat.RateMessageResponse\x12j\n" +
+	"\x15SetDigestSubscription\x12'.acai.chat.SetDigestSubscriptionRequest\x1a(.acai.chat.SetDigestSubscriptionResponse\x12j\n" +
+	"\x15GetDigestSubscription\x12'.acai.chat.GetDigestSubscriptionRequest\x1a(.acai.chat.GetDigestSubscriptionResponse\x12U\n" +
+	"\x0eSetPreferences\x12 .acai.chat.SetPreferencesRequest\x1a!.acai.chat.SetPreferencesResponse\x12U\n" +
+	"\x0eGetPreferences\x12 .acai.chat.GetPreferencesRequest\x1a!.acai.chat.GetPreferencesResponse\x12p\n" +
+	"\x17SetNotificationSettings\x12).acai.chat.SetNotificationSettingsRequest\x1a*.acai.chat.SetNotificationSettingsResponse\x12p\n" +
+	"\x17GetNotificationSettings\x12).acai.chat.GetNotificationSettingsRequest\x1a*.acai.chat.GetNotificationSettingsResponse\x12a\n" +
+	"\x12RegisterPushDevice\x12$.acai.chat.RegisterPushDeviceRequest\x1a%.acai.chat.RegisterPushDeviceResponse\x12g\n" +
+	"\x14UnregisterPushDevice\x12&.acai.chat.UnregisterPushDeviceRequest\x1a'.acai.chat.UnregisterPushDeviceResponse\x12X\n" +
+	"\x0fListPushDevices\x12!.acai.chat.ListPushDevicesRequest\x1a\".acai.chat.ListPushDevicesResponse\x12O\n" +
+	"\fStartSession\x12\x1e.acai.chat.StartSessionRequest\x1a\x1f.acai.chat.StartSessionResponse\x12O\n" +
+	"\fListSessions\x12\x1e.acai.chat.ListSessionsRequest\x1a\x1f.acai.chat.ListSessionsResponse\x12R\n" +
+	"\rRevokeSession\x12\x1f.acai.chat.RevokeSessionRequest\x1a .acai.chat.RevokeSessionResponse\x12C\n" +
+	"\bGetQuota\x12\x1a.acai.chat.GetQuotaRequest\x1a\x1b.acai.chat.GetQuotaResponseB\rZ\vinternal/pbb\x06proto3"
 
 var (
 	file_rpc_chat_proto_rawDescOnce sync.Once
-	file_rpc_chat_proto_rawDescData = file_rpc_chat_proto_rawDesc
+	file_rpc_chat_proto_rawDescData []byte
 )
 
 func file_rpc_chat_proto_rawDescGZIP() []byte {
 	file_rpc_chat_proto_rawDescOnce.Do(func() {
-		file_rpc_chat_proto_rawDescData = protoimpl.X.CompressGZIP(file_rpc_chat_proto_rawDescData)
+		file_rpc_chat_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_rpc_chat_proto_rawDesc), len(file_rpc_chat_proto_rawDesc)))
 	})
 	return file_rpc_chat_proto_rawDescData
 }
 
-var file_rpc_chat_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_rpc_chat_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_rpc_chat_proto_enumTypes = make([]protoimpl.EnumInfo, 8)
+var file_rpc_chat_proto_msgTypes = make([]protoimpl.MessageInfo, 106)
 var file_rpc_chat_proto_goTypes = []any{
-	(Conversation_Role)(0),               // 0: acai.chat.Conversation.Role
-	(*Conversation)(nil),                 // 1: acai.chat.Conversation
-	(*StartConversationRequest)(nil),     // 2: acai.chat.StartConversationRequest
-	(*StartConversationResponse)(nil),    // 3: acai.chat.StartConversationResponse
-	(*ContinueConversationRequest)(nil),  // 4: acai.chat.ContinueConversationRequest
-	(*ContinueConversationResponse)(nil), // 5: acai.chat.ContinueConversationResponse
-	(*ListConversationsRequest)(nil),     // 6: acai.chat.ListConversationsRequest
-	(*ListConversationsResponse)(nil),    // 7: acai.chat.ListConversationsResponse
-	(*DescribeConversationRequest)(nil),  // 8: acai.chat.DescribeConversationRequest
-	(*DescribeConversationResponse)(nil), // 9: acai.chat.DescribeConversationResponse
-	(*Conversation_Message)(nil),         // 10: acai.chat.Conversation.Message
-	(*timestamppb.Timestamp)(nil),        // 11: google.protobuf.Timestamp
+	(Verbosity)(0),                           // 0: acai.chat.Verbosity
+	(MessageRating)(0),                       // 1: acai.chat.MessageRating
+	(DigestFrequency)(0),                     // 2: acai.chat.DigestFrequency
+	(DigestDelivery)(0),                      // 3: acai.chat.DigestDelivery
+	(PushPlatform)(0),                        // 4: acai.chat.PushPlatform
+	(Conversation_Role)(0),                   // 5: acai.chat.Conversation.Role
+	(BulkJob_State)(0),                       // 6: acai.chat.BulkJob.State
+	(ExportConversationRequest_Format)(0),    // 7: acai.chat.ExportConversationRequest.Format
+	(*Conversation)(nil),                     // 8: acai.chat.Conversation
+	(*GenerationSettings)(nil),               // 9: acai.chat.GenerationSettings
+	(*ToolOptions)(nil),                      // 10: acai.chat.ToolOptions
+	(*StartConversationRequest)(nil),         // 11: acai.chat.StartConversationRequest
+	(*StartConversationResponse)(nil),        // 12: acai.chat.StartConversationResponse
+	(*SimilarConversation)(nil),              // 13: acai.chat.SimilarConversation
+	(*ContinueConversationRequest)(nil),      // 14: acai.chat.ContinueConversationRequest
+	(*ContinueConversationResponse)(nil),     // 15: acai.chat.ContinueConversationResponse
+	(*QuickReply)(nil),                       // 16: acai.chat.QuickReply
+	(*ListConversationsRequest)(nil),         // 17: acai.chat.ListConversationsRequest
+	(*ListConversationsResponse)(nil),        // 18: acai.chat.ListConversationsResponse
+	(*DescribeConversationRequest)(nil),      // 19: acai.chat.DescribeConversationRequest
+	(*HistoryOptions)(nil),                   // 20: acai.chat.HistoryOptions
+	(*DescribeConversationResponse)(nil),     // 21: acai.chat.DescribeConversationResponse
+	(*RetryFailedReplyRequest)(nil),          // 22: acai.chat.RetryFailedReplyRequest
+	(*RetryFailedReplyResponse)(nil),         // 23: acai.chat.RetryFailedReplyResponse
+	(*CancelGenerationRequest)(nil),          // 24: acai.chat.CancelGenerationRequest
+	(*CancelGenerationResponse)(nil),         // 25: acai.chat.CancelGenerationResponse
+	(*MarkReadRequest)(nil),                  // 26: acai.chat.MarkReadRequest
+	(*MarkReadResponse)(nil),                 // 27: acai.chat.MarkReadResponse
+	(*SearchSemanticRequest)(nil),            // 28: acai.chat.SearchSemanticRequest
+	(*SearchSemanticResponse)(nil),           // 29: acai.chat.SearchSemanticResponse
+	(*Attachment)(nil),                       // 30: acai.chat.Attachment
+	(*SetConversationLanguageRequest)(nil),   // 31: acai.chat.SetConversationLanguageRequest
+	(*SetConversationLanguageResponse)(nil),  // 32: acai.chat.SetConversationLanguageResponse
+	(*UploadAttachmentRequest)(nil),          // 33: acai.chat.UploadAttachmentRequest
+	(*UploadAttachmentResponse)(nil),         // 34: acai.chat.UploadAttachmentResponse
+	(*DownloadAttachmentRequest)(nil),        // 35: acai.chat.DownloadAttachmentRequest
+	(*DownloadAttachmentResponse)(nil),       // 36: acai.chat.DownloadAttachmentResponse
+	(*LocationAlias)(nil),                    // 37: acai.chat.LocationAlias
+	(*SetLocationAliasRequest)(nil),          // 38: acai.chat.SetLocationAliasRequest
+	(*SetLocationAliasResponse)(nil),         // 39: acai.chat.SetLocationAliasResponse
+	(*DeleteLocationAliasRequest)(nil),       // 40: acai.chat.DeleteLocationAliasRequest
+	(*DeleteLocationAliasResponse)(nil),      // 41: acai.chat.DeleteLocationAliasResponse
+	(*ListLocationAliasesRequest)(nil),       // 42: acai.chat.ListLocationAliasesRequest
+	(*ListLocationAliasesResponse)(nil),      // 43: acai.chat.ListLocationAliasesResponse
+	(*UserCalendar)(nil),                     // 44: acai.chat.UserCalendar
+	(*SetUserCalendarRequest)(nil),           // 45: acai.chat.SetUserCalendarRequest
+	(*SetUserCalendarResponse)(nil),          // 46: acai.chat.SetUserCalendarResponse
+	(*DeleteUserCalendarRequest)(nil),        // 47: acai.chat.DeleteUserCalendarRequest
+	(*DeleteUserCalendarResponse)(nil),       // 48: acai.chat.DeleteUserCalendarResponse
+	(*ListUserCalendarsRequest)(nil),         // 49: acai.chat.ListUserCalendarsRequest
+	(*ListUserCalendarsResponse)(nil),        // 50: acai.chat.ListUserCalendarsResponse
+	(*CalDAVAccount)(nil),                    // 51: acai.chat.CalDAVAccount
+	(*SetCalDAVAccountRequest)(nil),          // 52: acai.chat.SetCalDAVAccountRequest
+	(*SetCalDAVAccountResponse)(nil),         // 53: acai.chat.SetCalDAVAccountResponse
+	(*DeleteCalDAVAccountRequest)(nil),       // 54: acai.chat.DeleteCalDAVAccountRequest
+	(*DeleteCalDAVAccountResponse)(nil),      // 55: acai.chat.DeleteCalDAVAccountResponse
+	(*ConversationFilter)(nil),               // 56: acai.chat.ConversationFilter
+	(*BulkDeleteConversationsRequest)(nil),   // 57: acai.chat.BulkDeleteConversationsRequest
+	(*BulkDeleteConversationsResponse)(nil),  // 58: acai.chat.BulkDeleteConversationsResponse
+	(*BulkArchiveConversationsRequest)(nil),  // 59: acai.chat.BulkArchiveConversationsRequest
+	(*BulkArchiveConversationsResponse)(nil), // 60: acai.chat.BulkArchiveConversationsResponse
+	(*BulkJob)(nil),                          // 61: acai.chat.BulkJob
+	(*GetBulkJobRequest)(nil),                // 62: acai.chat.GetBulkJobRequest
+	(*GetBulkJobResponse)(nil),               // 63: acai.chat.GetBulkJobResponse
+	(*RequestExportArchiveRequest)(nil),      // 64: acai.chat.RequestExportArchiveRequest
+	(*RequestExportArchiveResponse)(nil),     // 65: acai.chat.RequestExportArchiveResponse
+	(*ExportConversationRequest)(nil),        // 66: acai.chat.ExportConversationRequest
+	(*ExportConversationResponse)(nil),       // 67: acai.chat.ExportConversationResponse
+	(*PinMessageRequest)(nil),                // 68: acai.chat.PinMessageRequest
+	(*PinMessageResponse)(nil),               // 69: acai.chat.PinMessageResponse
+	(*ListPinnedMessagesRequest)(nil),        // 70: acai.chat.ListPinnedMessagesRequest
+	(*ListPinnedMessagesResponse)(nil),       // 71: acai.chat.ListPinnedMessagesResponse
+	(*RateMessageRequest)(nil),               // 72: acai.chat.RateMessageRequest
+	(*RateMessageResponse)(nil),              // 73: acai.chat.RateMessageResponse
+	(*DigestSubscription)(nil),               // 74: acai.chat.DigestSubscription
+	(*SetDigestSubscriptionRequest)(nil),     // 75: acai.chat.SetDigestSubscriptionRequest
+	(*SetDigestSubscriptionResponse)(nil),    // 76: acai.chat.SetDigestSubscriptionResponse
+	(*GetDigestSubscriptionRequest)(nil),     // 77: acai.chat.GetDigestSubscriptionRequest
+	(*GetDigestSubscriptionResponse)(nil),    // 78: acai.chat.GetDigestSubscriptionResponse
+	(*Preferences)(nil),                      // 79: acai.chat.Preferences
+	(*SetPreferencesRequest)(nil),            // 80: acai.chat.SetPreferencesRequest
+	(*SetPreferencesResponse)(nil),           // 81: acai.chat.SetPreferencesResponse
+	(*GetPreferencesRequest)(nil),            // 82: acai.chat.GetPreferencesRequest
+	(*GetPreferencesResponse)(nil),           // 83: acai.chat.GetPreferencesResponse
+	(*NotificationSettings)(nil),             // 84: acai.chat.NotificationSettings
+	(*NotificationRoute)(nil),                // 85: acai.chat.NotificationRoute
+	(*QuietHours)(nil),                       // 86: acai.chat.QuietHours
+	(*SetNotificationSettingsRequest)(nil),   // 87: acai.chat.SetNotificationSettingsRequest
+	(*SetNotificationSettingsResponse)(nil),  // 88: acai.chat.SetNotificationSettingsResponse
+	(*GetNotificationSettingsRequest)(nil),   // 89: acai.chat.GetNotificationSettingsRequest
+	(*GetNotificationSettingsResponse)(nil),  // 90: acai.chat.GetNotificationSettingsResponse
+	(*PushDevice)(nil),                       // 91: acai.chat.PushDevice
+	(*RegisterPushDeviceRequest)(nil),        // 92: acai.chat.RegisterPushDeviceRequest
+	(*RegisterPushDeviceResponse)(nil),       // 93: acai.chat.RegisterPushDeviceResponse
+	(*UnregisterPushDeviceRequest)(nil),      // 94: acai.chat.UnregisterPushDeviceRequest
+	(*UnregisterPushDeviceResponse)(nil),     // 95: acai.chat.UnregisterPushDeviceResponse
+	(*ListPushDevicesRequest)(nil),           // 96: acai.chat.ListPushDevicesRequest
+	(*ListPushDevicesResponse)(nil),          // 97: acai.chat.ListPushDevicesResponse
+	(*Session)(nil),                          // 98: acai.chat.Session
+	(*StartSessionRequest)(nil),              // 99: acai.chat.StartSessionRequest
+	(*StartSessionResponse)(nil),             // 100: acai.chat.StartSessionResponse
+	(*ListSessionsRequest)(nil),              // 101: acai.chat.ListSessionsRequest
+	(*ListSessionsResponse)(nil),             // 102: acai.chat.ListSessionsResponse
+	(*RevokeSessionRequest)(nil),             // 103: acai.chat.RevokeSessionRequest
+	(*RevokeSessionResponse)(nil),            // 104: acai.chat.RevokeSessionResponse
+	(*Quota)(nil),                            // 105: acai.chat.Quota
+	(*GetQuotaRequest)(nil),                  // 106: acai.chat.GetQuotaRequest
+	(*GetQuotaResponse)(nil),                 // 107: acai.chat.GetQuotaResponse
+	(*Conversation_Message)(nil),             // 108: acai.chat.Conversation.Message
+	(*Conversation_ToolCall)(nil),            // 109: acai.chat.Conversation.ToolCall
+	(*Conversation_Usage)(nil),               // 110: acai.chat.Conversation.Usage
+	(*Conversation_Preview)(nil),             // 111: acai.chat.Conversation.Preview
+	(*SearchSemanticResponse_Result)(nil),    // 112: acai.chat.SearchSemanticResponse.Result
+	(*Quota_Allowance)(nil),                  // 113: acai.chat.Quota.Allowance
+	(*timestamppb.Timestamp)(nil),            // 114: google.protobuf.Timestamp
+	(*fieldmaskpb.FieldMask)(nil),            // 115: google.protobuf.FieldMask
 }
 var file_rpc_chat_proto_depIdxs = []int32{
-	11, // 0: acai.chat.Conversation.timestamp:type_name -> google.protobuf.Timestamp
-	10, // 1: acai.chat.Conversation.messages:type_name -> acai.chat.Conversation.Message
-	1,  // 2: acai.chat.ListConversationsResponse.conversations:type_name -> acai.chat.Conversation
-	1,  // 3: acai.chat.DescribeConversationResponse.conversation:type_name -> acai.chat.Conversation
-	0,  // 4: acai.chat.Conversation.Message.role:type_name -> acai.chat.Conversation.Role
-	11, // 5: acai.chat.Conversation.Message.timestamp:type_name -> google.protobuf.Timestamp
-	2,  // 6: acai.chat.ChatService.StartConversation:input_type -> acai.chat.StartConversationRequest
-	4,  // 7: acai.chat.ChatService.ContinueConversation:input_type -> acai.chat.ContinueConversationRequest
-	6,  // 8: acai.chat.ChatService.ListConversations:input_type -> acai.chat.ListConversationsRequest
-	8,  // 9: acai.chat.ChatService.DescribeConversation:input_type -> acai.chat.DescribeConversationRequest
-	3,  // 10: acai.chat.ChatService.StartConversation:output_type -> acai.chat.StartConversationResponse
-	5,  // 11: acai.chat.ChatService.ContinueConversation:output_type -> acai.chat.ContinueConversationResponse
-	7,  // 12: acai.chat.ChatService.ListConversations:output_type -> acai.chat.ListConversationsResponse
-	9,  // 13: acai.chat.ChatService.DescribeConversation:output_type -> acai.chat.DescribeConversationResponse
-	10, // [10:14] is the sub-list for method output_type
-	6,  // [6:10] is the sub-list for method input_type
-	6,  // [6:6] is the sub-list for extension type_name
-	6,  // [6:6] is the sub-list for extension extendee
-	0,  // [0:6] is the sub-list for field type_name
+	114, // 0: acai.chat.Conversation.timestamp:type_name -> google.protobuf.Timestamp
+	108, // 1: acai.chat.Conversation.messages:type_name -> acai.chat.Conversation.Message
+	9,   // 2: acai.chat.Conversation.settings:type_name -> acai.chat.GenerationSettings
+	111, // 3: acai.chat.Conversation.preview:type_name -> acai.chat.Conversation.Preview
+	114, // 4: acai.chat.Conversation.archived_at:type_name -> google.protobuf.Timestamp
+	110, // 5: acai.chat.Conversation.usage:type_name -> acai.chat.Conversation.Usage
+	10,  // 6: acai.chat.StartConversationRequest.tool_options:type_name -> acai.chat.ToolOptions
+	0,   // 7: acai.chat.StartConversationRequest.verbosity:type_name -> acai.chat.Verbosity
+	9,   // 8: acai.chat.StartConversationRequest.settings:type_name -> acai.chat.GenerationSettings
+	16,  // 9: acai.chat.StartConversationResponse.quick_replies:type_name -> acai.chat.QuickReply
+	13,  // 10: acai.chat.StartConversationResponse.similar_conversations:type_name -> acai.chat.SimilarConversation
+	10,  // 11: acai.chat.ContinueConversationRequest.tool_options:type_name -> acai.chat.ToolOptions
+	0,   // 12: acai.chat.ContinueConversationRequest.verbosity:type_name -> acai.chat.Verbosity
+	16,  // 13: acai.chat.ContinueConversationResponse.quick_replies:type_name -> acai.chat.QuickReply
+	115, // 14: acai.chat.ListConversationsRequest.read_mask:type_name -> google.protobuf.FieldMask
+	8,   // 15: acai.chat.ListConversationsResponse.conversations:type_name -> acai.chat.Conversation
+	20,  // 16: acai.chat.DescribeConversationRequest.history:type_name -> acai.chat.HistoryOptions
+	115, // 17: acai.chat.DescribeConversationRequest.read_mask:type_name -> google.protobuf.FieldMask
+	115, // 18: acai.chat.HistoryOptions.message_mask:type_name -> google.protobuf.FieldMask
+	8,   // 19: acai.chat.DescribeConversationResponse.conversation:type_name -> acai.chat.Conversation
+	112, // 20: acai.chat.SearchSemanticResponse.results:type_name -> acai.chat.SearchSemanticResponse.Result
+	114, // 21: acai.chat.Attachment.created_at:type_name -> google.protobuf.Timestamp
+	30,  // 22: acai.chat.UploadAttachmentResponse.attachment:type_name -> acai.chat.Attachment
+	30,  // 23: acai.chat.DownloadAttachmentResponse.attachment:type_name -> acai.chat.Attachment
+	37,  // 24: acai.chat.SetLocationAliasRequest.alias:type_name -> acai.chat.LocationAlias
+	37,  // 25: acai.chat.SetLocationAliasResponse.alias:type_name -> acai.chat.LocationAlias
+	37,  // 26: acai.chat.ListLocationAliasesResponse.aliases:type_name -> acai.chat.LocationAlias
+	44,  // 27: acai.chat.SetUserCalendarRequest.calendar:type_name -> acai.chat.UserCalendar
+	44,  // 28: acai.chat.SetUserCalendarResponse.calendar:type_name -> acai.chat.UserCalendar
+	44,  // 29: acai.chat.ListUserCalendarsResponse.calendars:type_name -> acai.chat.UserCalendar
+	51,  // 30: acai.chat.ListUserCalendarsResponse.caldav:type_name -> acai.chat.CalDAVAccount
+	51,  // 31: acai.chat.SetCalDAVAccountRequest.account:type_name -> acai.chat.CalDAVAccount
+	51,  // 32: acai.chat.SetCalDAVAccountResponse.account:type_name -> acai.chat.CalDAVAccount
+	114, // 33: acai.chat.ConversationFilter.older_than:type_name -> google.protobuf.Timestamp
+	56,  // 34: acai.chat.BulkDeleteConversationsRequest.filter:type_name -> acai.chat.ConversationFilter
+	56,  // 35: acai.chat.BulkArchiveConversationsRequest.filter:type_name -> acai.chat.ConversationFilter
+	6,   // 36: acai.chat.BulkJob.state:type_name -> acai.chat.BulkJob.State
+	114, // 37: acai.chat.BulkJob.created_at:type_name -> google.protobuf.Timestamp
+	114, // 38: acai.chat.BulkJob.updated_at:type_name -> google.protobuf.Timestamp
+	61,  // 39: acai.chat.GetBulkJobResponse.job:type_name -> acai.chat.BulkJob
+	7,   // 40: acai.chat.ExportConversationRequest.format:type_name -> acai.chat.ExportConversationRequest.Format
+	108, // 41: acai.chat.PinMessageResponse.message:type_name -> acai.chat.Conversation.Message
+	108, // 42: acai.chat.ListPinnedMessagesResponse.messages:type_name -> acai.chat.Conversation.Message
+	1,   // 43: acai.chat.RateMessageRequest.rating:type_name -> acai.chat.MessageRating
+	108, // 44: acai.chat.RateMessageResponse.message:type_name -> acai.chat.Conversation.Message
+	2,   // 45: acai.chat.DigestSubscription.frequency:type_name -> acai.chat.DigestFrequency
+	3,   // 46: acai.chat.DigestSubscription.delivery:type_name -> acai.chat.DigestDelivery
+	114, // 47: acai.chat.DigestSubscription.next_at:type_name -> google.protobuf.Timestamp
+	114, // 48: acai.chat.DigestSubscription.last_sent_at:type_name -> google.protobuf.Timestamp
+	2,   // 49: acai.chat.SetDigestSubscriptionRequest.frequency:type_name -> acai.chat.DigestFrequency
+	3,   // 50: acai.chat.SetDigestSubscriptionRequest.delivery:type_name -> acai.chat.DigestDelivery
+	74,  // 51: acai.chat.SetDigestSubscriptionResponse.subscription:type_name -> acai.chat.DigestSubscription
+	74,  // 52: acai.chat.GetDigestSubscriptionResponse.subscription:type_name -> acai.chat.DigestSubscription
+	79,  // 53: acai.chat.SetPreferencesRequest.preferences:type_name -> acai.chat.Preferences
+	79,  // 54: acai.chat.SetPreferencesResponse.preferences:type_name -> acai.chat.Preferences
+	79,  // 55: acai.chat.GetPreferencesResponse.preferences:type_name -> acai.chat.Preferences
+	85,  // 56: acai.chat.NotificationSettings.routes:type_name -> acai.chat.NotificationRoute
+	86,  // 57: acai.chat.NotificationSettings.quiet_hours:type_name -> acai.chat.QuietHours
+	84,  // 58: acai.chat.SetNotificationSettingsRequest.settings:type_name -> acai.chat.NotificationSettings
+	84,  // 59: acai.chat.SetNotificationSettingsResponse.settings:type_name -> acai.chat.NotificationSettings
+	84,  // 60: acai.chat.GetNotificationSettingsResponse.settings:type_name -> acai.chat.NotificationSettings
+	4,   // 61: acai.chat.PushDevice.platform:type_name -> acai.chat.PushPlatform
+	114, // 62: acai.chat.PushDevice.registered_at:type_name -> google.protobuf.Timestamp
+	4,   // 63: acai.chat.RegisterPushDeviceRequest.platform:type_name -> acai.chat.PushPlatform
+	91,  // 64: acai.chat.RegisterPushDeviceResponse.device:type_name -> acai.chat.PushDevice
+	91,  // 65: acai.chat.ListPushDevicesResponse.devices:type_name -> acai.chat.PushDevice
+	114, // 66: acai.chat.Session.created_at:type_name -> google.protobuf.Timestamp
+	114, // 67: acai.chat.Session.last_seen_at:type_name -> google.protobuf.Timestamp
+	114, // 68: acai.chat.Session.revoked_at:type_name -> google.protobuf.Timestamp
+	98,  // 69: acai.chat.StartSessionResponse.session:type_name -> acai.chat.Session
+	98,  // 70: acai.chat.ListSessionsResponse.sessions:type_name -> acai.chat.Session
+	98,  // 71: acai.chat.RevokeSessionResponse.session:type_name -> acai.chat.Session
+	113, // 72: acai.chat.Quota.messages:type_name -> acai.chat.Quota.Allowance
+	113, // 73: acai.chat.Quota.tokens:type_name -> acai.chat.Quota.Allowance
+	113, // 74: acai.chat.Quota.tool_calls:type_name -> acai.chat.Quota.Allowance
+	114, // 75: acai.chat.Quota.resets_at:type_name -> google.protobuf.Timestamp
+	105, // 76: acai.chat.GetQuotaResponse.quota:type_name -> acai.chat.Quota
+	5,   // 77: acai.chat.Conversation.Message.role:type_name -> acai.chat.Conversation.Role
+	114, // 78: acai.chat.Conversation.Message.timestamp:type_name -> google.protobuf.Timestamp
+	30,  // 79: acai.chat.Conversation.Message.attachments:type_name -> acai.chat.Attachment
+	109, // 80: acai.chat.Conversation.Message.tool_calls:type_name -> acai.chat.Conversation.ToolCall
+	114, // 81: acai.chat.Conversation.Message.pinned_at:type_name -> google.protobuf.Timestamp
+	1,   // 82: acai.chat.Conversation.Message.rating:type_name -> acai.chat.MessageRating
+	5,   // 83: acai.chat.Conversation.Preview.last_message_role:type_name -> acai.chat.Conversation.Role
+	114, // 84: acai.chat.Conversation.Preview.last_message_timestamp:type_name -> google.protobuf.Timestamp
+	108, // 85: acai.chat.SearchSemanticResponse.Result.message:type_name -> acai.chat.Conversation.Message
+	11,  // 86: acai.chat.ChatService.StartConversation:input_type -> acai.chat.StartConversationRequest
+	14,  // 87: acai.chat.ChatService.ContinueConversation:input_type -> acai.chat.ContinueConversationRequest
+	17,  // 88: acai.chat.ChatService.ListConversations:input_type -> acai.chat.ListConversationsRequest
+	19,  // 89: acai.chat.ChatService.DescribeConversation:input_type -> acai.chat.DescribeConversationRequest
+	22,  // 90: acai.chat.ChatService.RetryFailedReply:input_type -> acai.chat.RetryFailedReplyRequest
+	24,  // 91: acai.chat.ChatService.CancelGeneration:input_type -> acai.chat.CancelGenerationRequest
+	26,  // 92: acai.chat.ChatService.MarkRead:input_type -> acai.chat.MarkReadRequest
+	28,  // 93: acai.chat.ChatService.SearchSemantic:input_type -> acai.chat.SearchSemanticRequest
+	33,  // 94: acai.chat.ChatService.UploadAttachment:input_type -> acai.chat.UploadAttachmentRequest
+	35,  // 95: acai.chat.ChatService.DownloadAttachment:input_type -> acai.chat.DownloadAttachmentRequest
+	31,  // 96: acai.chat.ChatService.SetConversationLanguage:input_type -> acai.chat.SetConversationLanguageRequest
+	38,  // 97: acai.chat.ChatService.SetLocationAlias:input_type -> acai.chat.SetLocationAliasRequest
+	40,  // 98: acai.chat.ChatService.DeleteLocationAlias:input_type -> acai.chat.DeleteLocationAliasRequest
+	42,  // 99: acai.chat.ChatService.ListLocationAliases:input_type -> acai.chat.ListLocationAliasesRequest
+	45,  // 100: acai.chat.ChatService.SetUserCalendar:input_type -> acai.chat.SetUserCalendarRequest
+	47,  // 101: acai.chat.ChatService.DeleteUserCalendar:input_type -> acai.chat.DeleteUserCalendarRequest
+	49,  // 102: acai.chat.ChatService.ListUserCalendars:input_type -> acai.chat.ListUserCalendarsRequest
+	52,  // 103: acai.chat.ChatService.SetCalDAVAccount:input_type -> acai.chat.SetCalDAVAccountRequest
+	54,  // 104: acai.chat.ChatService.DeleteCalDAVAccount:input_type -> acai.chat.DeleteCalDAVAccountRequest
+	57,  // 105: acai.chat.ChatService.BulkDeleteConversations:input_type -> acai.chat.BulkDeleteConversationsRequest
+	59,  // 106: acai.chat.ChatService.BulkArchiveConversations:input_type -> acai.chat.BulkArchiveConversationsRequest
+	62,  // 107: acai.chat.ChatService.GetBulkJob:input_type -> acai.chat.GetBulkJobRequest
+	64,  // 108: acai.chat.ChatService.RequestExportArchive:input_type -> acai.chat.RequestExportArchiveRequest
+	66,  // 109: acai.chat.ChatService.ExportConversation:input_type -> acai.chat.ExportConversationRequest
+	68,  // 110: acai.chat.ChatService.PinMessage:input_type -> acai.chat.PinMessageRequest
+	70,  // 111: acai.chat.ChatService.ListPinnedMessages:input_type -> acai.chat.ListPinnedMessagesRequest
+	72,  // 112: acai.chat.ChatService.RateMessage:input_type -> acai.chat.RateMessageRequest
+	75,  // 113: acai.chat.ChatService.SetDigestSubscription:input_type -> acai.chat.SetDigestSubscriptionRequest
+	77,  // 114: acai.chat.ChatService.GetDigestSubscription:input_type -> acai.chat.GetDigestSubscriptionRequest
+	80,  // 115: acai.chat.ChatService.SetPreferences:input_type -> acai.chat.SetPreferencesRequest
+	82,  // 116: acai.chat.ChatService.GetPreferences:input_type -> acai.chat.GetPreferencesRequest
+	87,  // 117: acai.chat.ChatService.SetNotificationSettings:input_type -> acai.chat.SetNotificationSettingsRequest
+	89,  // 118: acai.chat.ChatService.GetNotificationSettings:input_type -> acai.chat.GetNotificationSettingsRequest
+	92,  // 119: acai.chat.ChatService.RegisterPushDevice:input_type -> acai.chat.RegisterPushDeviceRequest
+	94,  // 120: acai.chat.ChatService.UnregisterPushDevice:input_type -> acai.chat.UnregisterPushDeviceRequest
+	96,  // 121: acai.chat.ChatService.ListPushDevices:input_type -> acai.chat.ListPushDevicesRequest
+	99,  // 122: acai.chat.ChatService.StartSession:input_type -> acai.chat.StartSessionRequest
+	101, // 123: acai.chat.ChatService.ListSessions:input_type -> acai.chat.ListSessionsRequest
+	103, // 124: acai.chat.ChatService.RevokeSession:input_type -> acai.chat.RevokeSessionRequest
+	106, // 125: acai.chat.ChatService.GetQuota:input_type -> acai.chat.GetQuotaRequest
+	12,  // 126: acai.chat.ChatService.StartConversation:output_type -> acai.chat.StartConversationResponse
+	15,  // 127: acai.chat.ChatService.ContinueConversation:output_type -> acai.chat.ContinueConversationResponse
+	18,  // 128: acai.chat.ChatService.ListConversations:output_type -> acai.chat.ListConversationsResponse
+	21,  // 129: acai.chat.ChatService.DescribeConversation:output_type -> acai.chat.DescribeConversationResponse
+	23,  // 130: acai.chat.ChatService.RetryFailedReply:output_type -> acai.chat.RetryFailedReplyResponse
+	25,  // 131: acai.chat.ChatService.CancelGeneration:output_type -> acai.chat.CancelGenerationResponse
+	27,  // 132: acai.chat.ChatService.MarkRead:output_type -> acai.chat.MarkReadResponse
+	29,  // 133: acai.chat.ChatService.SearchSemantic:output_type -> acai.chat.SearchSemanticResponse
+	34,  // 134: acai.chat.ChatService.UploadAttachment:output_type -> acai.chat.UploadAttachmentResponse
+	36,  // 135: acai.chat.ChatService.DownloadAttachment:output_type -> acai.chat.DownloadAttachmentResponse
+	32,  // 136: acai.chat.ChatService.SetConversationLanguage:output_type -> acai.chat.SetConversationLanguageResponse
+	39,  // 137: acai.chat.ChatService.SetLocationAlias:output_type -> acai.chat.SetLocationAliasResponse
+	41,  // 138: acai.chat.ChatService.DeleteLocationAlias:output_type -> acai.chat.DeleteLocationAliasResponse
+	43,  // 139: acai.chat.ChatService.ListLocationAliases:output_type -> acai.chat.ListLocationAliasesResponse
+	46,  // 140: acai.chat.ChatService.SetUserCalendar:output_type -> acai.chat.SetUserCalendarResponse
+	48,  // 141: acai.chat.ChatService.DeleteUserCalendar:output_type -> acai.chat.DeleteUserCalendarResponse
+	50,  // 142: acai.chat.ChatService.ListUserCalendars:output_type -> acai.chat.ListUserCalendarsResponse
+	53,  // 143: acai.chat.ChatService.SetCalDAVAccount:output_type -> acai.chat.SetCalDAVAccountResponse
+	55,  // 144: acai.chat.ChatService.DeleteCalDAVAccount:output_type -> acai.chat.DeleteCalDAVAccountResponse
+	58,  // 145: acai.chat.ChatService.BulkDeleteConversations:output_type -> acai.chat.BulkDeleteConversationsResponse
+	60,  // 146: acai.chat.ChatService.BulkArchiveConversations:output_type -> acai.chat.BulkArchiveConversationsResponse
+	63,  // 147: acai.chat.ChatService.GetBulkJob:output_type -> acai.chat.GetBulkJobResponse
+	65,  // 148: acai.chat.ChatService.RequestExportArchive:output_type -> acai.chat.RequestExportArchiveResponse
+	67,  // 149: acai.chat.ChatService.ExportConversation:output_type -> acai.chat.ExportConversationResponse
+	69,  // 150: acai.chat.ChatService.PinMessage:output_type -> acai.chat.PinMessageResponse
+	71,  // 151: acai.chat.ChatService.ListPinnedMessages:output_type -> acai.chat.ListPinnedMessagesResponse
+	73,  // 152: acai.chat.ChatService.RateMessage:output_type -> acai.chat.RateMessageResponse
+	76,  // 153: acai.chat.ChatService.SetDigestSubscription:output_type -> acai.chat.SetDigestSubscriptionResponse
+	78,  // 154: acai.chat.ChatService.GetDigestSubscription:output_type -> acai.chat.GetDigestSubscriptionResponse
+	81,  // 155: acai.chat.ChatService.SetPreferences:output_type -> acai.chat.SetPreferencesResponse
+	83,  // 156: acai.chat.ChatService.GetPreferences:output_type -> acai.chat.GetPreferencesResponse
+	88,  // 157: acai.chat.ChatService.SetNotificationSettings:output_type -> acai.chat.SetNotificationSettingsResponse
+	90,  // 158: acai.chat.ChatService.GetNotificationSettings:output_type -> acai.chat.GetNotificationSettingsResponse
+	93,  // 159: acai.chat.ChatService.RegisterPushDevice:output_type -> acai.chat.RegisterPushDeviceResponse
+	95,  // 160: acai.chat.ChatService.UnregisterPushDevice:output_type -> acai.chat.UnregisterPushDeviceResponse
+	97,  // 161: acai.chat.ChatService.ListPushDevices:output_type -> acai.chat.ListPushDevicesResponse
+	100, // 162: acai.chat.ChatService.StartSession:output_type -> acai.chat.StartSessionResponse
+	102, // 163: acai.chat.ChatService.ListSessions:output_type -> acai.chat.ListSessionsResponse
+	104, // 164: acai.chat.ChatService.RevokeSession:output_type -> acai.chat.RevokeSessionResponse
+	107, // 165: acai.chat.ChatService.GetQuota:output_type -> acai.chat.GetQuotaResponse
+	126, // [126:166] is the sub-list for method output_type
+	86,  // [86:126] is the sub-list for method input_type
+	86,  // [86:86] is the sub-list for extension type_name
+	86,  // [86:86] is the sub-list for extension extendee
+	0,   // [0:86] is the sub-list for field type_name
 }
 
 func init() { file_rpc_chat_proto_init() }
@@ -736,13 +7069,15 @@ func file_rpc_chat_proto_init() {
 	if File_rpc_chat_proto != nil {
 		return
 	}
+	file_rpc_chat_proto_msgTypes[1].OneofWrappers = []any{}
+	file_rpc_chat_proto_msgTypes[71].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
-			RawDescriptor: file_rpc_chat_proto_rawDesc,
-			NumEnums:      1,
-			NumMessages:   10,
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_rpc_chat_proto_rawDesc), len(file_rpc_chat_proto_rawDesc)),
+			NumEnums:      8,
+			NumMessages:   106,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -752,7 +7087,6 @@ func file_rpc_chat_proto_init() {
 		MessageInfos:      file_rpc_chat_proto_msgTypes,
 	}.Build()
 	File_rpc_chat_proto = out.File
-	file_rpc_chat_proto_rawDesc = nil
 	file_rpc_chat_proto_goTypes = nil
 	file_rpc_chat_proto_depIdxs = nil
 }
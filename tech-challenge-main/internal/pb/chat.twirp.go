@@ -16,11 +16,6 @@ import proto "google.golang.org/protobuf/proto"
 import twirp "github.com/twitchtv/twirp"
 import ctxsetters "github.com/twitchtv/twirp/ctxsetters"
 
-import bytes "bytes"
-import errors "errors"
-import path "path"
-import url "net/url"
-
 // Version compatibility assertion.
 // If the constant is not defined in the package, that likely means
 // the package needs to be updated to work with this generated code.
@@ -32,18 +27,129 @@ const _ = twirp.TwirpPackageMinVersion_8_1_0
 // =====================
 
 type ChatService interface {
-	// create a new conversation by sending a message and getting a reply
+	// Create a new conversation by sending a message and getting a reply
 	// use ContinueConversation with the returned conversation_id to continue the conversation
 	StartConversation(context.Context, *StartConversationRequest) (*StartConversationResponse, error)
 
-	// continue an existing conversation by adding a new message and getting a reply
+	// Continue an existing conversation by adding a new message and getting a reply
+	// Fails with aborted while another reply is being generated in the conversation
 	ContinueConversation(context.Context, *ContinueConversationRequest) (*ContinueConversationResponse, error)
 
-	// list most recent conversations
+	// List most recent conversations
 	ListConversations(context.Context, *ListConversationsRequest) (*ListConversationsResponse, error)
 
-	// describe a conversation by its ID
+	// Describe a conversation by its ID
 	DescribeConversation(context.Context, *DescribeConversationRequest) (*DescribeConversationResponse, error)
+
+	// Retry generating the reply to the last message of a conversation after a previous attempt failed
+	RetryFailedReply(context.Context, *RetryFailedReplyRequest) (*RetryFailedReplyResponse, error)
+
+	// Stop generating the reply to the last message of a conversation, e.g. when the user asked the wrong question
+	CancelGeneration(context.Context, *CancelGenerationRequest) (*CancelGenerationResponse, error)
+
+	// Mark a conversation as read up to a message by the calling user
+	MarkRead(context.Context, *MarkReadRequest) (*MarkReadResponse, error)
+
+	// Search the calling user's past messages by meaning rather than exact words
+	SearchSemantic(context.Context, *SearchSemanticRequest) (*SearchSemanticResponse, error)
+
+	// Upload a file to attach to a later message
+	UploadAttachment(context.Context, *UploadAttachmentRequest) (*UploadAttachmentResponse, error)
+
+	// Download an attachment uploaded by the calling user
+	DownloadAttachment(context.Context, *DownloadAttachmentRequest) (*DownloadAttachmentResponse, error)
+
+	// Set the language the assistant replies in for a conversation, replacing the detected one
+	SetConversationLanguage(context.Context, *SetConversationLanguageRequest) (*SetConversationLanguageResponse, error)
+
+	// Save a named place of the calling user, e.g. "home", so the assistant understands "weather at home"
+	SetLocationAlias(context.Context, *SetLocationAliasRequest) (*SetLocationAliasResponse, error)
+
+	// Remove a saved place of the calling user
+	DeleteLocationAlias(context.Context, *DeleteLocationAliasRequest) (*DeleteLocationAliasResponse, error)
+
+	// List the saved places of the calling user
+	ListLocationAliases(context.Context, *ListLocationAliasesRequest) (*ListLocationAliasesResponse, error)
+
+	// Link an ICS calendar of the calling user, e.g. their work calendar, so the assistant can check what they have on
+	SetUserCalendar(context.Context, *SetUserCalendarRequest) (*SetUserCalendarResponse, error)
+
+	// Unlink a calendar of the calling user
+	DeleteUserCalendar(context.Context, *DeleteUserCalendarRequest) (*DeleteUserCalendarResponse, error)
+
+	// List the linked calendars of the calling user
+	ListUserCalendars(context.Context, *ListUserCalendarsRequest) (*ListUserCalendarsResponse, error)
+
+	// Connect a CalDAV calendar of the calling user, so the assistant can add and update events on it
+	// Fails with unimplemented unless the server has a key to encrypt the credentials with
+	SetCalDAVAccount(context.Context, *SetCalDAVAccountRequest) (*SetCalDAVAccountResponse, error)
+
+	// Disconnect the CalDAV calendar of the calling user, forgetting its credentials
+	DeleteCalDAVAccount(context.Context, *DeleteCalDAVAccountRequest) (*DeleteCalDAVAccountResponse, error)
+
+	// Delete conversations by ID or filter; large sets are deleted by a background job
+	BulkDeleteConversations(context.Context, *BulkDeleteConversationsRequest) (*BulkDeleteConversationsResponse, error)
+
+	// Archive conversations by ID or filter, hiding them from ListConversations; large sets are archived by a background job
+	BulkArchiveConversations(context.Context, *BulkArchiveConversationsRequest) (*BulkArchiveConversationsResponse, error)
+
+	// Get the progress of a background job started by a bulk operation
+	GetBulkJob(context.Context, *GetBulkJobRequest) (*GetBulkJobResponse, error)
+
+	// Package all conversations into a zip archive in the background; GetBulkJob reports its download link
+	RequestExportArchive(context.Context, *RequestExportArchiveRequest) (*RequestExportArchiveResponse, error)
+
+	// Render a conversation transcript as a file, e.g. a PDF to file as a trip record
+	ExportConversation(context.Context, *ExportConversationRequest) (*ExportConversationResponse, error)
+
+	// Bookmark a key message of a conversation, e.g. the final itinerary, or remove the bookmark
+	PinMessage(context.Context, *PinMessageRequest) (*PinMessageResponse, error)
+
+	// List the pinned messages of a conversation, for a highlights panel
+	ListPinnedMessages(context.Context, *ListPinnedMessagesRequest) (*ListPinnedMessagesResponse, error)
+
+	// Rate a reply of the assistant, e.g. thumbs up or down, or clear the rating
+	RateMessage(context.Context, *RateMessageRequest) (*RateMessageResponse, error)
+
+	// Subscribe the calling user to a daily or weekly digest of their conversations, or unsubscribe
+	SetDigestSubscription(context.Context, *SetDigestSubscriptionRequest) (*SetDigestSubscriptionResponse, error)
+
+	// Get the digest subscription of the calling user, if any
+	GetDigestSubscription(context.Context, *GetDigestSubscriptionRequest) (*GetDigestSubscriptionResponse, error)
+
+	// Update the preferences of the calling user; unset fields keep their value
+	SetPreferences(context.Context, *SetPreferencesRequest) (*SetPreferencesResponse, error)
+
+	// Get the preferences of the calling user, with defaults for those never set
+	GetPreferences(context.Context, *GetPreferencesRequest) (*GetPreferencesResponse, error)
+
+	// Choose how the calling user is notified, replacing their previous settings
+	SetNotificationSettings(context.Context, *SetNotificationSettingsRequest) (*SetNotificationSettingsResponse, error)
+
+	// Get how the calling user is notified, with the defaults if they never chose
+	GetNotificationSettings(context.Context, *GetNotificationSettingsRequest) (*GetNotificationSettingsResponse, error)
+
+	// Register a device of the calling user for push notifications; registering a token again refreshes it
+	RegisterPushDevice(context.Context, *RegisterPushDeviceRequest) (*RegisterPushDeviceResponse, error)
+
+	// Stop push notifications to a device of the calling user
+	UnregisterPushDevice(context.Context, *UnregisterPushDeviceRequest) (*UnregisterPushDeviceResponse, error)
+
+	// List the devices of the calling user registered for push notifications, most recent first
+	ListPushDevices(context.Context, *ListPushDevicesRequest) (*ListPushDevicesResponse, error)
+
+	// Start a session of the calling user on the requesting device. Identified users must send
+	// its token as the X-Session-Token header with every other request
+	StartSession(context.Context, *StartSessionRequest) (*StartSessionResponse, error)
+
+	// List the sessions of the calling user, most recently seen first
+	ListSessions(context.Context, *ListSessionsRequest) (*ListSessionsResponse, error)
+
+	// Revoke a session of the calling user, rejecting further requests with its token
+	RevokeSession(context.Context, *RevokeSessionRequest) (*RevokeSessionResponse, error)
+
+	// Get the plan of the calling user and what remains of its daily allowances
+	GetQuota(context.Context, *GetQuotaRequest) (*GetQuotaResponse, error)
 }
 
 // ===========================
@@ -52,7 +158,7 @@ type ChatService interface {
 
 type chatServiceProtobufClient struct {
 	client      HTTPClient
-	urls        [4]string
+	urls        [40]string
 	interceptor twirp.Interceptor
 	opts        twirp.ClientOptions
 }
@@ -80,11 +186,47 @@ func NewChatServiceProtobufClient(baseURL string, client HTTPClient, opts ...twi
 	// Build method URLs: <baseURL>[<prefix>]/<package>.<Service>/<Method>
 	serviceURL := sanitizeBaseURL(baseURL)
 	serviceURL += baseServicePath(pathPrefix, "acai.chat", "ChatService")
-	urls := [4]string{
+	urls := [40]string{
 		serviceURL + "StartConversation",
 		serviceURL + "ContinueConversation",
 		serviceURL + "ListConversations",
 		serviceURL + "DescribeConversation",
+		serviceURL + "RetryFailedReply",
+		serviceURL + "CancelGeneration",
+		serviceURL + "MarkRead",
+		serviceURL + "SearchSemantic",
+		serviceURL + "UploadAttachment",
+		serviceURL + "DownloadAttachment",
+		serviceURL + "SetConversationLanguage",
+		serviceURL + "SetLocationAlias",
+		serviceURL + "DeleteLocationAlias",
+		serviceURL + "ListLocationAliases",
+		serviceURL + "SetUserCalendar",
+		serviceURL + "DeleteUserCalendar",
+		serviceURL + "ListUserCalendars",
+		serviceURL + "SetCalDAVAccount",
+		serviceURL + "DeleteCalDAVAccount",
+		serviceURL + "BulkDeleteConversations",
+		serviceURL + "BulkArchiveConversations",
+		serviceURL + "GetBulkJob",
+		serviceURL + "RequestExportArchive",
+		serviceURL + "ExportConversation",
+		serviceURL + "PinMessage",
+		serviceURL + "ListPinnedMessages",
+		serviceURL + "RateMessage",
+		serviceURL + "SetDigestSubscription",
+		serviceURL + "GetDigestSubscription",
+		serviceURL + "SetPreferences",
+		serviceURL + "GetPreferences",
+		serviceURL + "SetNotificationSettings",
+		serviceURL + "GetNotificationSettings",
+		serviceURL + "RegisterPushDevice",
+		serviceURL + "UnregisterPushDevice",
+		serviceURL + "ListPushDevices",
+		serviceURL + "StartSession",
+		serviceURL + "ListSessions",
+		serviceURL + "RevokeSession",
+		serviceURL + "GetQuota",
 	}
 
 	return &chatServiceProtobufClient{
@@ -279,75 +421,72 @@ func (c *chatServiceProtobufClient) callDescribeConversation(ctx context.Context
 	return out, nil
 }
 
-// =======================
-// ChatService JSON Client
-// =======================
-
-type chatServiceJSONClient struct {
-	client      HTTPClient
-	urls        [4]string
-	interceptor twirp.Interceptor
-	opts        twirp.ClientOptions
-}
-
-// NewChatServiceJSONClient creates a JSON client that implements the ChatService interface.
-// It communicates using JSON and can be configured with a custom HTTPClient.
-func NewChatServiceJSONClient(baseURL string, client HTTPClient, opts ...twirp.ClientOption) ChatService {
-	if c, ok := client.(*http.Client); ok {
-		client = withoutRedirects(c)
-	}
-
-	clientOpts := twirp.ClientOptions{}
-	for _, o := range opts {
-		o(&clientOpts)
+func (c *chatServiceProtobufClient) RetryFailedReply(ctx context.Context, in *RetryFailedReplyRequest) (*RetryFailedReplyResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.chat")
+	ctx = ctxsetters.WithServiceName(ctx, "ChatService")
+	ctx = ctxsetters.WithMethodName(ctx, "RetryFailedReply")
+	caller := c.callRetryFailedReply
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *RetryFailedReplyRequest) (*RetryFailedReplyResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*RetryFailedReplyRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*RetryFailedReplyRequest) when calling interceptor")
+					}
+					return c.callRetryFailedReply(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*RetryFailedReplyResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*RetryFailedReplyResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
 	}
+	return caller(ctx, in)
+}
 
-	// Using ReadOpt allows backwards and forwards compatibility with new options in the future
-	literalURLs := false
-	_ = clientOpts.ReadOpt("literalURLs", &literalURLs)
-	var pathPrefix string
-	if ok := clientOpts.ReadOpt("pathPrefix", &pathPrefix); !ok {
-		pathPrefix = "/twirp" // default prefix
+func (c *chatServiceProtobufClient) callRetryFailedReply(ctx context.Context, in *RetryFailedReplyRequest) (*RetryFailedReplyResponse, error) {
+	out := new(RetryFailedReplyResponse)
+	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[4], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
 	}
 
-	// Build method URLs: <baseURL>[<prefix>]/<package>.<Service>/<Method>
-	serviceURL := sanitizeBaseURL(baseURL)
-	serviceURL += baseServicePath(pathPrefix, "acai.chat", "ChatService")
-	urls := [4]string{
-		serviceURL + "StartConversation",
-		serviceURL + "ContinueConversation",
-		serviceURL + "ListConversations",
-		serviceURL + "DescribeConversation",
-	}
+	callClientResponseReceived(ctx, c.opts.Hooks)
 
-	return &chatServiceJSONClient{
-		client:      client,
-		urls:        urls,
-		interceptor: twirp.ChainInterceptors(clientOpts.Interceptors...),
-		opts:        clientOpts,
-	}
+	return out, nil
 }
 
-func (c *chatServiceJSONClient) StartConversation(ctx context.Context, in *StartConversationRequest) (*StartConversationResponse, error) {
+func (c *chatServiceProtobufClient) CancelGeneration(ctx context.Context, in *CancelGenerationRequest) (*CancelGenerationResponse, error) {
 	ctx = ctxsetters.WithPackageName(ctx, "acai.chat")
 	ctx = ctxsetters.WithServiceName(ctx, "ChatService")
-	ctx = ctxsetters.WithMethodName(ctx, "StartConversation")
-	caller := c.callStartConversation
+	ctx = ctxsetters.WithMethodName(ctx, "CancelGeneration")
+	caller := c.callCancelGeneration
 	if c.interceptor != nil {
-		caller = func(ctx context.Context, req *StartConversationRequest) (*StartConversationResponse, error) {
+		caller = func(ctx context.Context, req *CancelGenerationRequest) (*CancelGenerationResponse, error) {
 			resp, err := c.interceptor(
 				func(ctx context.Context, req interface{}) (interface{}, error) {
-					typedReq, ok := req.(*StartConversationRequest)
+					typedReq, ok := req.(*CancelGenerationRequest)
 					if !ok {
-						return nil, twirp.InternalError("failed type assertion req.(*StartConversationRequest) when calling interceptor")
+						return nil, twirp.InternalError("failed type assertion req.(*CancelGenerationRequest) when calling interceptor")
 					}
-					return c.callStartConversation(ctx, typedReq)
+					return c.callCancelGeneration(ctx, typedReq)
 				},
 			)(ctx, req)
 			if resp != nil {
-				typedResp, ok := resp.(*StartConversationResponse)
+				typedResp, ok := resp.(*CancelGenerationResponse)
 				if !ok {
-					return nil, twirp.InternalError("failed type assertion resp.(*StartConversationResponse) when calling interceptor")
+					return nil, twirp.InternalError("failed type assertion resp.(*CancelGenerationResponse) when calling interceptor")
 				}
 				return typedResp, err
 			}
@@ -357,9 +496,9 @@ func (c *chatServiceJSONClient) StartConversation(ctx context.Context, in *Start
 	return caller(ctx, in)
 }
 
-func (c *chatServiceJSONClient) callStartConversation(ctx context.Context, in *StartConversationRequest) (*StartConversationResponse, error) {
-	out := new(StartConversationResponse)
-	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[0], in, out)
+func (c *chatServiceProtobufClient) callCancelGeneration(ctx context.Context, in *CancelGenerationRequest) (*CancelGenerationResponse, error) {
+	out := new(CancelGenerationResponse)
+	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[5], in, out)
 	if err != nil {
 		twerr, ok := err.(twirp.Error)
 		if !ok {
@@ -374,26 +513,26 @@ func (c *chatServiceJSONClient) callStartConversation(ctx context.Context, in *S
 	return out, nil
 }
 
-func (c *chatServiceJSONClient) ContinueConversation(ctx context.Context, in *ContinueConversationRequest) (*ContinueConversationResponse, error) {
+func (c *chatServiceProtobufClient) MarkRead(ctx context.Context, in *MarkReadRequest) (*MarkReadResponse, error) {
 	ctx = ctxsetters.WithPackageName(ctx, "acai.chat")
 	ctx = ctxsetters.WithServiceName(ctx, "ChatService")
-	ctx = ctxsetters.WithMethodName(ctx, "ContinueConversation")
-	caller := c.callContinueConversation
+	ctx = ctxsetters.WithMethodName(ctx, "MarkRead")
+	caller := c.callMarkRead
 	if c.interceptor != nil {
-		caller = func(ctx context.Context, req *ContinueConversationRequest) (*ContinueConversationResponse, error) {
+		caller = func(ctx context.Context, req *MarkReadRequest) (*MarkReadResponse, error) {
 			resp, err := c.interceptor(
 				func(ctx context.Context, req interface{}) (interface{}, error) {
-					typedReq, ok := req.(*ContinueConversationRequest)
+					typedReq, ok := req.(*MarkReadRequest)
 					if !ok {
-						return nil, twirp.InternalError("failed type assertion req.(*ContinueConversationRequest) when calling interceptor")
+						return nil, twirp.InternalError("failed type assertion req.(*MarkReadRequest) when calling interceptor")
 					}
-					return c.callContinueConversation(ctx, typedReq)
+					return c.callMarkRead(ctx, typedReq)
 				},
 			)(ctx, req)
 			if resp != nil {
-				typedResp, ok := resp.(*ContinueConversationResponse)
+				typedResp, ok := resp.(*MarkReadResponse)
 				if !ok {
-					return nil, twirp.InternalError("failed type assertion resp.(*ContinueConversationResponse) when calling interceptor")
+					return nil, twirp.InternalError("failed type assertion resp.(*MarkReadResponse) when calling interceptor")
 				}
 				return typedResp, err
 			}
@@ -403,9 +542,9 @@ func (c *chatServiceJSONClient) ContinueConversation(ctx context.Context, in *Co
 	return caller(ctx, in)
 }
 
-func (c *chatServiceJSONClient) callContinueConversation(ctx context.Context, in *ContinueConversationRequest) (*ContinueConversationResponse, error) {
-	out := new(ContinueConversationResponse)
-	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[1], in, out)
+func (c *chatServiceProtobufClient) callMarkRead(ctx context.Context, in *MarkReadRequest) (*MarkReadResponse, error) {
+	out := new(MarkReadResponse)
+	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[6], in, out)
 	if err != nil {
 		twerr, ok := err.(twirp.Error)
 		if !ok {
@@ -420,26 +559,26 @@ func (c *chatServiceJSONClient) callContinueConversation(ctx context.Context, in
 	return out, nil
 }
 
-func (c *chatServiceJSONClient) ListConversations(ctx context.Context, in *ListConversationsRequest) (*ListConversationsResponse, error) {
+func (c *chatServiceProtobufClient) SearchSemantic(ctx context.Context, in *SearchSemanticRequest) (*SearchSemanticResponse, error) {
 	ctx = ctxsetters.WithPackageName(ctx, "acai.chat")
 	ctx = ctxsetters.WithServiceName(ctx, "ChatService")
-	ctx = ctxsetters.WithMethodName(ctx, "ListConversations")
-	caller := c.callListConversations
+	ctx = ctxsetters.WithMethodName(ctx, "SearchSemantic")
+	caller := c.callSearchSemantic
 	if c.interceptor != nil {
-		caller = func(ctx context.Context, req *ListConversationsRequest) (*ListConversationsResponse, error) {
+		caller = func(ctx context.Context, req *SearchSemanticRequest) (*SearchSemanticResponse, error) {
 			resp, err := c.interceptor(
 				func(ctx context.Context, req interface{}) (interface{}, error) {
-					typedReq, ok := req.(*ListConversationsRequest)
+					typedReq, ok := req.(*SearchSemanticRequest)
 					if !ok {
-						return nil, twirp.InternalError("failed type assertion req.(*ListConversationsRequest) when calling interceptor")
+						return nil, twirp.InternalError("failed type assertion req.(*SearchSemanticRequest) when calling interceptor")
 					}
-					return c.callListConversations(ctx, typedReq)
+					return c.callSearchSemantic(ctx, typedReq)
 				},
 			)(ctx, req)
 			if resp != nil {
-				typedResp, ok := resp.(*ListConversationsResponse)
+				typedResp, ok := resp.(*SearchSemanticResponse)
 				if !ok {
-					return nil, twirp.InternalError("failed type assertion resp.(*ListConversationsResponse) when calling interceptor")
+					return nil, twirp.InternalError("failed type assertion resp.(*SearchSemanticResponse) when calling interceptor")
 				}
 				return typedResp, err
 			}
@@ -449,9 +588,9 @@ func (c *chatServiceJSONClient) ListConversations(ctx context.Context, in *ListC
 	return caller(ctx, in)
 }
 
-func (c *chatServiceJSONClient) callListConversations(ctx context.Context, in *ListConversationsRequest) (*ListConversationsResponse, error) {
-	out := new(ListConversationsResponse)
-	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[2], in, out)
+func (c *chatServiceProtobufClient) callSearchSemantic(ctx context.Context, in *SearchSemanticRequest) (*SearchSemanticResponse, error) {
+	out := new(SearchSemanticResponse)
+	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[7], in, out)
 	if err != nil {
 		twerr, ok := err.(twirp.Error)
 		if !ok {
@@ -466,26 +605,26 @@ func (c *chatServiceJSONClient) callListConversations(ctx context.Context, in *L
 	return out, nil
 }
 
-func (c *chatServiceJSONClient) DescribeConversation(ctx context.Context, in *DescribeConversationRequest) (*DescribeConversationResponse, error) {
+func (c *chatServiceProtobufClient) UploadAttachment(ctx context.Context, in *UploadAttachmentRequest) (*UploadAttachmentResponse, error) {
 	ctx = ctxsetters.WithPackageName(ctx, "acai.chat")
 	ctx = ctxsetters.WithServiceName(ctx, "ChatService")
-	ctx = ctxsetters.WithMethodName(ctx, "DescribeConversation")
-	caller := c.callDescribeConversation
+	ctx = ctxsetters.WithMethodName(ctx, "UploadAttachment")
+	caller := c.callUploadAttachment
 	if c.interceptor != nil {
-		caller = func(ctx context.Context, req *DescribeConversationRequest) (*DescribeConversationResponse, error) {
+		caller = func(ctx context.Context, req *UploadAttachmentRequest) (*UploadAttachmentResponse, error) {
 			resp, err := c.interceptor(
 				func(ctx context.Context, req interface{}) (interface{}, error) {
-					typedReq, ok := req.(*DescribeConversationRequest)
+					typedReq, ok := req.(*UploadAttachmentRequest)
 					if !ok {
-						return nil, twirp.InternalError("failed type assertion req.(*DescribeConversationRequest) when calling interceptor")
+						return nil, twirp.InternalError("failed type assertion req.(*UploadAttachmentRequest) when calling interceptor")
 					}
-					return c.callDescribeConversation(ctx, typedReq)
+					return c.callUploadAttachment(ctx, typedReq)
 				},
 			)(ctx, req)
 			if resp != nil {
-				typedResp, ok := resp.(*DescribeConversationResponse)
+				typedResp, ok := resp.(*UploadAttachmentResponse)
 				if !ok {
-					return nil, twirp.InternalError("failed type assertion resp.(*DescribeConversationResponse) when calling interceptor")
+					return nil, twirp.InternalError("failed type assertion resp.(*UploadAttachmentResponse) when calling interceptor")
 				}
 				return typedResp, err
 			}
@@ -495,9 +634,9 @@ func (c *chatServiceJSONClient) DescribeConversation(ctx context.Context, in *De
 	return caller(ctx, in)
 }
 
-func (c *chatServiceJSONClient) callDescribeConversation(ctx context.Context, in *DescribeConversationRequest) (*DescribeConversationResponse, error) {
-	out := new(DescribeConversationResponse)
-	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[3], in, out)
+func (c *chatServiceProtobufClient) callUploadAttachment(ctx context.Context, in *UploadAttachmentRequest) (*UploadAttachmentResponse, error) {
+	out := new(UploadAttachmentResponse)
+	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[8], in, out)
 	if err != nil {
 		twerr, ok := err.(twirp.Error)
 		if !ok {
@@ -512,62 +651,3413 @@ func (c *chatServiceJSONClient) callDescribeConversation(ctx context.Context, in
 	return out, nil
 }
 
-// ==========================
-// ChatService Server Handler
-// ==========================
-
-type chatServiceServer struct {
-	ChatService
-	interceptor      twirp.Interceptor
-	hooks            *twirp.ServerHooks
-	pathPrefix       string // prefix for routing
-	jsonSkipDefaults bool   // do not include unpopulated fields (default values) in the response
-	jsonCamelCase    bool   // JSON fields are serialized as lowerCamelCase rather than keeping the original proto names
+func (c *chatServiceProtobufClient) DownloadAttachment(ctx context.Context, in *DownloadAttachmentRequest) (*DownloadAttachmentResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.chat")
+	ctx = ctxsetters.WithServiceName(ctx, "ChatService")
+	ctx = ctxsetters.WithMethodName(ctx, "DownloadAttachment")
+	caller := c.callDownloadAttachment
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *DownloadAttachmentRequest) (*DownloadAttachmentResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*DownloadAttachmentRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*DownloadAttachmentRequest) when calling interceptor")
+					}
+					return c.callDownloadAttachment(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*DownloadAttachmentResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*DownloadAttachmentResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
 }
 
-// NewChatServiceServer builds a TwirpServer that can be used as an http.Handler to handle
-// HTTP requests that are routed to the right method in the provided svc implementation.
-// The opts are twirp.ServerOption modifiers, for example twirp.WithServerHooks(hooks).
-func NewChatServiceServer(svc ChatService, opts ...interface{}) TwirpServer {
-	serverOpts := newServerOpts(opts)
-
-	// Using ReadOpt allows backwards and forwards compatibility with new options in the future
-	jsonSkipDefaults := false
-	_ = serverOpts.ReadOpt("jsonSkipDefaults", &jsonSkipDefaults)
-	jsonCamelCase := false
-	_ = serverOpts.ReadOpt("jsonCamelCase", &jsonCamelCase)
-	var pathPrefix string
-	if ok := serverOpts.ReadOpt("pathPrefix", &pathPrefix); !ok {
-		pathPrefix = "/twirp" // default prefix
+func (c *chatServiceProtobufClient) callDownloadAttachment(ctx context.Context, in *DownloadAttachmentRequest) (*DownloadAttachmentResponse, error) {
+	out := new(DownloadAttachmentResponse)
+	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[9], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
 	}
 
-	return &chatServiceServer{
-		ChatService:      svc,
-		hooks:            serverOpts.Hooks,
-		interceptor:      twirp.ChainInterceptors(serverOpts.Interceptors...),
-		pathPrefix:       pathPrefix,
-		jsonSkipDefaults: jsonSkipDefaults,
-		jsonCamelCase:    jsonCamelCase,
-	}
-}
+	callClientResponseReceived(ctx, c.opts.Hooks)
 
-// writeError writes an HTTP response with a valid Twirp error format, and triggers hooks.
-// If err is not a twirp.Error, it will get wrapped with twirp.InternalErrorWith(err)
-func (s *chatServiceServer) writeError(ctx context.Context, resp http.ResponseWriter, err error) {
-	writeError(ctx, resp, err, s.hooks)
+	return out, nil
 }
 
-// handleRequestBodyError is used to handle error when the twirp server cannot read request
-func (s *chatServiceServer) handleRequestBodyError(ctx context.Context, resp http.ResponseWriter, msg string, err error) {
-	if context.Canceled == ctx.Err() {
-		s.writeError(ctx, resp, twirp.NewError(twirp.Canceled, "failed to read request: context canceled"))
-		return
+func (c *chatServiceProtobufClient) SetConversationLanguage(ctx context.Context, in *SetConversationLanguageRequest) (*SetConversationLanguageResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.chat")
+	ctx = ctxsetters.WithServiceName(ctx, "ChatService")
+	ctx = ctxsetters.WithMethodName(ctx, "SetConversationLanguage")
+	caller := c.callSetConversationLanguage
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *SetConversationLanguageRequest) (*SetConversationLanguageResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*SetConversationLanguageRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*SetConversationLanguageRequest) when calling interceptor")
+					}
+					return c.callSetConversationLanguage(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*SetConversationLanguageResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*SetConversationLanguageResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
 	}
-	if context.DeadlineExceeded == ctx.Err() {
-		s.writeError(ctx, resp, twirp.NewError(twirp.DeadlineExceeded, "failed to read request: deadline exceeded"))
-		return
+	return caller(ctx, in)
+}
+
+func (c *chatServiceProtobufClient) callSetConversationLanguage(ctx context.Context, in *SetConversationLanguageRequest) (*SetConversationLanguageResponse, error) {
+	out := new(SetConversationLanguageResponse)
+	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[10], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
 	}
-	s.writeError(ctx, resp, twirp.WrapError(malformedRequestError(msg), err))
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *chatServiceProtobufClient) SetLocationAlias(ctx context.Context, in *SetLocationAliasRequest) (*SetLocationAliasResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.chat")
+	ctx = ctxsetters.WithServiceName(ctx, "ChatService")
+	ctx = ctxsetters.WithMethodName(ctx, "SetLocationAlias")
+	caller := c.callSetLocationAlias
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *SetLocationAliasRequest) (*SetLocationAliasResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*SetLocationAliasRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*SetLocationAliasRequest) when calling interceptor")
+					}
+					return c.callSetLocationAlias(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*SetLocationAliasResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*SetLocationAliasResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *chatServiceProtobufClient) callSetLocationAlias(ctx context.Context, in *SetLocationAliasRequest) (*SetLocationAliasResponse, error) {
+	out := new(SetLocationAliasResponse)
+	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[11], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *chatServiceProtobufClient) DeleteLocationAlias(ctx context.Context, in *DeleteLocationAliasRequest) (*DeleteLocationAliasResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.chat")
+	ctx = ctxsetters.WithServiceName(ctx, "ChatService")
+	ctx = ctxsetters.WithMethodName(ctx, "DeleteLocationAlias")
+	caller := c.callDeleteLocationAlias
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *DeleteLocationAliasRequest) (*DeleteLocationAliasResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*DeleteLocationAliasRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*DeleteLocationAliasRequest) when calling interceptor")
+					}
+					return c.callDeleteLocationAlias(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*DeleteLocationAliasResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*DeleteLocationAliasResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *chatServiceProtobufClient) callDeleteLocationAlias(ctx context.Context, in *DeleteLocationAliasRequest) (*DeleteLocationAliasResponse, error) {
+	out := new(DeleteLocationAliasResponse)
+	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[12], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *chatServiceProtobufClient) ListLocationAliases(ctx context.Context, in *ListLocationAliasesRequest) (*ListLocationAliasesResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.chat")
+	ctx = ctxsetters.WithServiceName(ctx, "ChatService")
+	ctx = ctxsetters.WithMethodName(ctx, "ListLocationAliases")
+	caller := c.callListLocationAliases
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *ListLocationAliasesRequest) (*ListLocationAliasesResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ListLocationAliasesRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ListLocationAliasesRequest) when calling interceptor")
+					}
+					return c.callListLocationAliases(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ListLocationAliasesResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ListLocationAliasesResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *chatServiceProtobufClient) callListLocationAliases(ctx context.Context, in *ListLocationAliasesRequest) (*ListLocationAliasesResponse, error) {
+	out := new(ListLocationAliasesResponse)
+	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[13], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *chatServiceProtobufClient) SetUserCalendar(ctx context.Context, in *SetUserCalendarRequest) (*SetUserCalendarResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.chat")
+	ctx = ctxsetters.WithServiceName(ctx, "ChatService")
+	ctx = ctxsetters.WithMethodName(ctx, "SetUserCalendar")
+	caller := c.callSetUserCalendar
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *SetUserCalendarRequest) (*SetUserCalendarResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*SetUserCalendarRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*SetUserCalendarRequest) when calling interceptor")
+					}
+					return c.callSetUserCalendar(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*SetUserCalendarResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*SetUserCalendarResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *chatServiceProtobufClient) callSetUserCalendar(ctx context.Context, in *SetUserCalendarRequest) (*SetUserCalendarResponse, error) {
+	out := new(SetUserCalendarResponse)
+	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[14], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *chatServiceProtobufClient) DeleteUserCalendar(ctx context.Context, in *DeleteUserCalendarRequest) (*DeleteUserCalendarResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.chat")
+	ctx = ctxsetters.WithServiceName(ctx, "ChatService")
+	ctx = ctxsetters.WithMethodName(ctx, "DeleteUserCalendar")
+	caller := c.callDeleteUserCalendar
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *DeleteUserCalendarRequest) (*DeleteUserCalendarResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*DeleteUserCalendarRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*DeleteUserCalendarRequest) when calling interceptor")
+					}
+					return c.callDeleteUserCalendar(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*DeleteUserCalendarResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*DeleteUserCalendarResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *chatServiceProtobufClient) callDeleteUserCalendar(ctx context.Context, in *DeleteUserCalendarRequest) (*DeleteUserCalendarResponse, error) {
+	out := new(DeleteUserCalendarResponse)
+	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[15], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *chatServiceProtobufClient) ListUserCalendars(ctx context.Context, in *ListUserCalendarsRequest) (*ListUserCalendarsResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.chat")
+	ctx = ctxsetters.WithServiceName(ctx, "ChatService")
+	ctx = ctxsetters.WithMethodName(ctx, "ListUserCalendars")
+	caller := c.callListUserCalendars
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *ListUserCalendarsRequest) (*ListUserCalendarsResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ListUserCalendarsRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ListUserCalendarsRequest) when calling interceptor")
+					}
+					return c.callListUserCalendars(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ListUserCalendarsResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ListUserCalendarsResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *chatServiceProtobufClient) callListUserCalendars(ctx context.Context, in *ListUserCalendarsRequest) (*ListUserCalendarsResponse, error) {
+	out := new(ListUserCalendarsResponse)
+	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[16], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *chatServiceProtobufClient) SetCalDAVAccount(ctx context.Context, in *SetCalDAVAccountRequest) (*SetCalDAVAccountResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.chat")
+	ctx = ctxsetters.WithServiceName(ctx, "ChatService")
+	ctx = ctxsetters.WithMethodName(ctx, "SetCalDAVAccount")
+	caller := c.callSetCalDAVAccount
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *SetCalDAVAccountRequest) (*SetCalDAVAccountResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*SetCalDAVAccountRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*SetCalDAVAccountRequest) when calling interceptor")
+					}
+					return c.callSetCalDAVAccount(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*SetCalDAVAccountResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*SetCalDAVAccountResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *chatServiceProtobufClient) callSetCalDAVAccount(ctx context.Context, in *SetCalDAVAccountRequest) (*SetCalDAVAccountResponse, error) {
+	out := new(SetCalDAVAccountResponse)
+	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[17], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *chatServiceProtobufClient) DeleteCalDAVAccount(ctx context.Context, in *DeleteCalDAVAccountRequest) (*DeleteCalDAVAccountResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.chat")
+	ctx = ctxsetters.WithServiceName(ctx, "ChatService")
+	ctx = ctxsetters.WithMethodName(ctx, "DeleteCalDAVAccount")
+	caller := c.callDeleteCalDAVAccount
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *DeleteCalDAVAccountRequest) (*DeleteCalDAVAccountResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*DeleteCalDAVAccountRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*DeleteCalDAVAccountRequest) when calling interceptor")
+					}
+					return c.callDeleteCalDAVAccount(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*DeleteCalDAVAccountResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*DeleteCalDAVAccountResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *chatServiceProtobufClient) callDeleteCalDAVAccount(ctx context.Context, in *DeleteCalDAVAccountRequest) (*DeleteCalDAVAccountResponse, error) {
+	out := new(DeleteCalDAVAccountResponse)
+	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[18], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *chatServiceProtobufClient) BulkDeleteConversations(ctx context.Context, in *BulkDeleteConversationsRequest) (*BulkDeleteConversationsResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.chat")
+	ctx = ctxsetters.WithServiceName(ctx, "ChatService")
+	ctx = ctxsetters.WithMethodName(ctx, "BulkDeleteConversations")
+	caller := c.callBulkDeleteConversations
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *BulkDeleteConversationsRequest) (*BulkDeleteConversationsResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*BulkDeleteConversationsRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*BulkDeleteConversationsRequest) when calling interceptor")
+					}
+					return c.callBulkDeleteConversations(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*BulkDeleteConversationsResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*BulkDeleteConversationsResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *chatServiceProtobufClient) callBulkDeleteConversations(ctx context.Context, in *BulkDeleteConversationsRequest) (*BulkDeleteConversationsResponse, error) {
+	out := new(BulkDeleteConversationsResponse)
+	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[19], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *chatServiceProtobufClient) BulkArchiveConversations(ctx context.Context, in *BulkArchiveConversationsRequest) (*BulkArchiveConversationsResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.chat")
+	ctx = ctxsetters.WithServiceName(ctx, "ChatService")
+	ctx = ctxsetters.WithMethodName(ctx, "BulkArchiveConversations")
+	caller := c.callBulkArchiveConversations
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *BulkArchiveConversationsRequest) (*BulkArchiveConversationsResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*BulkArchiveConversationsRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*BulkArchiveConversationsRequest) when calling interceptor")
+					}
+					return c.callBulkArchiveConversations(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*BulkArchiveConversationsResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*BulkArchiveConversationsResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *chatServiceProtobufClient) callBulkArchiveConversations(ctx context.Context, in *BulkArchiveConversationsRequest) (*BulkArchiveConversationsResponse, error) {
+	out := new(BulkArchiveConversationsResponse)
+	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[20], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *chatServiceProtobufClient) GetBulkJob(ctx context.Context, in *GetBulkJobRequest) (*GetBulkJobResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.chat")
+	ctx = ctxsetters.WithServiceName(ctx, "ChatService")
+	ctx = ctxsetters.WithMethodName(ctx, "GetBulkJob")
+	caller := c.callGetBulkJob
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *GetBulkJobRequest) (*GetBulkJobResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*GetBulkJobRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*GetBulkJobRequest) when calling interceptor")
+					}
+					return c.callGetBulkJob(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*GetBulkJobResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*GetBulkJobResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *chatServiceProtobufClient) callGetBulkJob(ctx context.Context, in *GetBulkJobRequest) (*GetBulkJobResponse, error) {
+	out := new(GetBulkJobResponse)
+	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[21], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *chatServiceProtobufClient) RequestExportArchive(ctx context.Context, in *RequestExportArchiveRequest) (*RequestExportArchiveResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.chat")
+	ctx = ctxsetters.WithServiceName(ctx, "ChatService")
+	ctx = ctxsetters.WithMethodName(ctx, "RequestExportArchive")
+	caller := c.callRequestExportArchive
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *RequestExportArchiveRequest) (*RequestExportArchiveResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*RequestExportArchiveRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*RequestExportArchiveRequest) when calling interceptor")
+					}
+					return c.callRequestExportArchive(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*RequestExportArchiveResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*RequestExportArchiveResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *chatServiceProtobufClient) callRequestExportArchive(ctx context.Context, in *RequestExportArchiveRequest) (*RequestExportArchiveResponse, error) {
+	out := new(RequestExportArchiveResponse)
+	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[22], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *chatServiceProtobufClient) ExportConversation(ctx context.Context, in *ExportConversationRequest) (*ExportConversationResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.chat")
+	ctx = ctxsetters.WithServiceName(ctx, "ChatService")
+	ctx = ctxsetters.WithMethodName(ctx, "ExportConversation")
+	caller := c.callExportConversation
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *ExportConversationRequest) (*ExportConversationResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ExportConversationRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ExportConversationRequest) when calling interceptor")
+					}
+					return c.callExportConversation(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ExportConversationResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ExportConversationResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *chatServiceProtobufClient) callExportConversation(ctx context.Context, in *ExportConversationRequest) (*ExportConversationResponse, error) {
+	out := new(ExportConversationResponse)
+	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[23], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *chatServiceProtobufClient) PinMessage(ctx context.Context, in *PinMessageRequest) (*PinMessageResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.chat")
+	ctx = ctxsetters.WithServiceName(ctx, "ChatService")
+	ctx = ctxsetters.WithMethodName(ctx, "PinMessage")
+	caller := c.callPinMessage
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *PinMessageRequest) (*PinMessageResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*PinMessageRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*PinMessageRequest) when calling interceptor")
+					}
+					return c.callPinMessage(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*PinMessageResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*PinMessageResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *chatServiceProtobufClient) callPinMessage(ctx context.Context, in *PinMessageRequest) (*PinMessageResponse, error) {
+	out := new(PinMessageResponse)
+	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[24], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *chatServiceProtobufClient) ListPinnedMessages(ctx context.Context, in *ListPinnedMessagesRequest) (*ListPinnedMessagesResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.chat")
+	ctx = ctxsetters.WithServiceName(ctx, "ChatService")
+	ctx = ctxsetters.WithMethodName(ctx, "ListPinnedMessages")
+	caller := c.callListPinnedMessages
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *ListPinnedMessagesRequest) (*ListPinnedMessagesResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ListPinnedMessagesRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ListPinnedMessagesRequest) when calling interceptor")
+					}
+					return c.callListPinnedMessages(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ListPinnedMessagesResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ListPinnedMessagesResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *chatServiceProtobufClient) callListPinnedMessages(ctx context.Context, in *ListPinnedMessagesRequest) (*ListPinnedMessagesResponse, error) {
+	out := new(ListPinnedMessagesResponse)
+	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[25], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *chatServiceProtobufClient) RateMessage(ctx context.Context, in *RateMessageRequest) (*RateMessageResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.chat")
+	ctx = ctxsetters.WithServiceName(ctx, "ChatService")
+	ctx = ctxsetters.WithMethodName(ctx, "RateMessage")
+	caller := c.callRateMessage
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *RateMessageRequest) (*RateMessageResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*RateMessageRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*RateMessageRequest) when calling interceptor")
+					}
+					return c.callRateMessage(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*RateMessageResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*RateMessageResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *chatServiceProtobufClient) callRateMessage(ctx context.Context, in *RateMessageRequest) (*RateMessageResponse, error) {
+	out := new(RateMessageResponse)
+	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[26], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *chatServiceProtobufClient) SetDigestSubscription(ctx context.Context, in *SetDigestSubscriptionRequest) (*SetDigestSubscriptionResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.chat")
+	ctx = ctxsetters.WithServiceName(ctx, "ChatService")
+	ctx = ctxsetters.WithMethodName(ctx, "SetDigestSubscription")
+	caller := c.callSetDigestSubscription
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *SetDigestSubscriptionRequest) (*SetDigestSubscriptionResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*SetDigestSubscriptionRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*SetDigestSubscriptionRequest) when calling interceptor")
+					}
+					return c.callSetDigestSubscription(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*SetDigestSubscriptionResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*SetDigestSubscriptionResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *chatServiceProtobufClient) callSetDigestSubscription(ctx context.Context, in *SetDigestSubscriptionRequest) (*SetDigestSubscriptionResponse, error) {
+	out := new(SetDigestSubscriptionResponse)
+	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[27], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *chatServiceProtobufClient) GetDigestSubscription(ctx context.Context, in *GetDigestSubscriptionRequest) (*GetDigestSubscriptionResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.chat")
+	ctx = ctxsetters.WithServiceName(ctx, "ChatService")
+	ctx = ctxsetters.WithMethodName(ctx, "GetDigestSubscription")
+	caller := c.callGetDigestSubscription
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *GetDigestSubscriptionRequest) (*GetDigestSubscriptionResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*GetDigestSubscriptionRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*GetDigestSubscriptionRequest) when calling interceptor")
+					}
+					return c.callGetDigestSubscription(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*GetDigestSubscriptionResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*GetDigestSubscriptionResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *chatServiceProtobufClient) callGetDigestSubscription(ctx context.Context, in *GetDigestSubscriptionRequest) (*GetDigestSubscriptionResponse, error) {
+	out := new(GetDigestSubscriptionResponse)
+	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[28], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *chatServiceProtobufClient) SetPreferences(ctx context.Context, in *SetPreferencesRequest) (*SetPreferencesResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.chat")
+	ctx = ctxsetters.WithServiceName(ctx, "ChatService")
+	ctx = ctxsetters.WithMethodName(ctx, "SetPreferences")
+	caller := c.callSetPreferences
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *SetPreferencesRequest) (*SetPreferencesResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*SetPreferencesRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*SetPreferencesRequest) when calling interceptor")
+					}
+					return c.callSetPreferences(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*SetPreferencesResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*SetPreferencesResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *chatServiceProtobufClient) callSetPreferences(ctx context.Context, in *SetPreferencesRequest) (*SetPreferencesResponse, error) {
+	out := new(SetPreferencesResponse)
+	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[29], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *chatServiceProtobufClient) GetPreferences(ctx context.Context, in *GetPreferencesRequest) (*GetPreferencesResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.chat")
+	ctx = ctxsetters.WithServiceName(ctx, "ChatService")
+	ctx = ctxsetters.WithMethodName(ctx, "GetPreferences")
+	caller := c.callGetPreferences
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *GetPreferencesRequest) (*GetPreferencesResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*GetPreferencesRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*GetPreferencesRequest) when calling interceptor")
+					}
+					return c.callGetPreferences(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*GetPreferencesResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*GetPreferencesResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *chatServiceProtobufClient) callGetPreferences(ctx context.Context, in *GetPreferencesRequest) (*GetPreferencesResponse, error) {
+	out := new(GetPreferencesResponse)
+	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[30], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *chatServiceProtobufClient) SetNotificationSettings(ctx context.Context, in *SetNotificationSettingsRequest) (*SetNotificationSettingsResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.chat")
+	ctx = ctxsetters.WithServiceName(ctx, "ChatService")
+	ctx = ctxsetters.WithMethodName(ctx, "SetNotificationSettings")
+	caller := c.callSetNotificationSettings
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *SetNotificationSettingsRequest) (*SetNotificationSettingsResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*SetNotificationSettingsRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*SetNotificationSettingsRequest) when calling interceptor")
+					}
+					return c.callSetNotificationSettings(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*SetNotificationSettingsResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*SetNotificationSettingsResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *chatServiceProtobufClient) callSetNotificationSettings(ctx context.Context, in *SetNotificationSettingsRequest) (*SetNotificationSettingsResponse, error) {
+	out := new(SetNotificationSettingsResponse)
+	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[31], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *chatServiceProtobufClient) GetNotificationSettings(ctx context.Context, in *GetNotificationSettingsRequest) (*GetNotificationSettingsResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.chat")
+	ctx = ctxsetters.WithServiceName(ctx, "ChatService")
+	ctx = ctxsetters.WithMethodName(ctx, "GetNotificationSettings")
+	caller := c.callGetNotificationSettings
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *GetNotificationSettingsRequest) (*GetNotificationSettingsResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*GetNotificationSettingsRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*GetNotificationSettingsRequest) when calling interceptor")
+					}
+					return c.callGetNotificationSettings(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*GetNotificationSettingsResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*GetNotificationSettingsResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *chatServiceProtobufClient) callGetNotificationSettings(ctx context.Context, in *GetNotificationSettingsRequest) (*GetNotificationSettingsResponse, error) {
+	out := new(GetNotificationSettingsResponse)
+	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[32], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *chatServiceProtobufClient) RegisterPushDevice(ctx context.Context, in *RegisterPushDeviceRequest) (*RegisterPushDeviceResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.chat")
+	ctx = ctxsetters.WithServiceName(ctx, "ChatService")
+	ctx = ctxsetters.WithMethodName(ctx, "RegisterPushDevice")
+	caller := c.callRegisterPushDevice
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *RegisterPushDeviceRequest) (*RegisterPushDeviceResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*RegisterPushDeviceRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*RegisterPushDeviceRequest) when calling interceptor")
+					}
+					return c.callRegisterPushDevice(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*RegisterPushDeviceResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*RegisterPushDeviceResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *chatServiceProtobufClient) callRegisterPushDevice(ctx context.Context, in *RegisterPushDeviceRequest) (*RegisterPushDeviceResponse, error) {
+	out := new(RegisterPushDeviceResponse)
+	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[33], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *chatServiceProtobufClient) UnregisterPushDevice(ctx context.Context, in *UnregisterPushDeviceRequest) (*UnregisterPushDeviceResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.chat")
+	ctx = ctxsetters.WithServiceName(ctx, "ChatService")
+	ctx = ctxsetters.WithMethodName(ctx, "UnregisterPushDevice")
+	caller := c.callUnregisterPushDevice
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *UnregisterPushDeviceRequest) (*UnregisterPushDeviceResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*UnregisterPushDeviceRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*UnregisterPushDeviceRequest) when calling interceptor")
+					}
+					return c.callUnregisterPushDevice(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*UnregisterPushDeviceResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*UnregisterPushDeviceResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *chatServiceProtobufClient) callUnregisterPushDevice(ctx context.Context, in *UnregisterPushDeviceRequest) (*UnregisterPushDeviceResponse, error) {
+	out := new(UnregisterPushDeviceResponse)
+	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[34], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *chatServiceProtobufClient) ListPushDevices(ctx context.Context, in *ListPushDevicesRequest) (*ListPushDevicesResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.chat")
+	ctx = ctxsetters.WithServiceName(ctx, "ChatService")
+	ctx = ctxsetters.WithMethodName(ctx, "ListPushDevices")
+	caller := c.callListPushDevices
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *ListPushDevicesRequest) (*ListPushDevicesResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ListPushDevicesRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ListPushDevicesRequest) when calling interceptor")
+					}
+					return c.callListPushDevices(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ListPushDevicesResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ListPushDevicesResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *chatServiceProtobufClient) callListPushDevices(ctx context.Context, in *ListPushDevicesRequest) (*ListPushDevicesResponse, error) {
+	out := new(ListPushDevicesResponse)
+	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[35], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *chatServiceProtobufClient) StartSession(ctx context.Context, in *StartSessionRequest) (*StartSessionResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.chat")
+	ctx = ctxsetters.WithServiceName(ctx, "ChatService")
+	ctx = ctxsetters.WithMethodName(ctx, "StartSession")
+	caller := c.callStartSession
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *StartSessionRequest) (*StartSessionResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*StartSessionRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*StartSessionRequest) when calling interceptor")
+					}
+					return c.callStartSession(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*StartSessionResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*StartSessionResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *chatServiceProtobufClient) callStartSession(ctx context.Context, in *StartSessionRequest) (*StartSessionResponse, error) {
+	out := new(StartSessionResponse)
+	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[36], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *chatServiceProtobufClient) ListSessions(ctx context.Context, in *ListSessionsRequest) (*ListSessionsResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.chat")
+	ctx = ctxsetters.WithServiceName(ctx, "ChatService")
+	ctx = ctxsetters.WithMethodName(ctx, "ListSessions")
+	caller := c.callListSessions
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *ListSessionsRequest) (*ListSessionsResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ListSessionsRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ListSessionsRequest) when calling interceptor")
+					}
+					return c.callListSessions(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ListSessionsResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ListSessionsResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *chatServiceProtobufClient) callListSessions(ctx context.Context, in *ListSessionsRequest) (*ListSessionsResponse, error) {
+	out := new(ListSessionsResponse)
+	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[37], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *chatServiceProtobufClient) RevokeSession(ctx context.Context, in *RevokeSessionRequest) (*RevokeSessionResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.chat")
+	ctx = ctxsetters.WithServiceName(ctx, "ChatService")
+	ctx = ctxsetters.WithMethodName(ctx, "RevokeSession")
+	caller := c.callRevokeSession
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *RevokeSessionRequest) (*RevokeSessionResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*RevokeSessionRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*RevokeSessionRequest) when calling interceptor")
+					}
+					return c.callRevokeSession(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*RevokeSessionResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*RevokeSessionResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *chatServiceProtobufClient) callRevokeSession(ctx context.Context, in *RevokeSessionRequest) (*RevokeSessionResponse, error) {
+	out := new(RevokeSessionResponse)
+	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[38], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *chatServiceProtobufClient) GetQuota(ctx context.Context, in *GetQuotaRequest) (*GetQuotaResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.chat")
+	ctx = ctxsetters.WithServiceName(ctx, "ChatService")
+	ctx = ctxsetters.WithMethodName(ctx, "GetQuota")
+	caller := c.callGetQuota
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *GetQuotaRequest) (*GetQuotaResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*GetQuotaRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*GetQuotaRequest) when calling interceptor")
+					}
+					return c.callGetQuota(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*GetQuotaResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*GetQuotaResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *chatServiceProtobufClient) callGetQuota(ctx context.Context, in *GetQuotaRequest) (*GetQuotaResponse, error) {
+	out := new(GetQuotaResponse)
+	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[39], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+// =======================
+// ChatService JSON Client
+// =======================
+
+type chatServiceJSONClient struct {
+	client      HTTPClient
+	urls        [40]string
+	interceptor twirp.Interceptor
+	opts        twirp.ClientOptions
+}
+
+// NewChatServiceJSONClient creates a JSON client that implements the ChatService interface.
+// It communicates using JSON and can be configured with a custom HTTPClient.
+func NewChatServiceJSONClient(baseURL string, client HTTPClient, opts ...twirp.ClientOption) ChatService {
+	if c, ok := client.(*http.Client); ok {
+		client = withoutRedirects(c)
+	}
+
+	clientOpts := twirp.ClientOptions{}
+	for _, o := range opts {
+		o(&clientOpts)
+	}
+
+	// Using ReadOpt allows backwards and forwards compatibility with new options in the future
+	literalURLs := false
+	_ = clientOpts.ReadOpt("literalURLs", &literalURLs)
+	var pathPrefix string
+	if ok := clientOpts.ReadOpt("pathPrefix", &pathPrefix); !ok {
+		pathPrefix = "/twirp" // default prefix
+	}
+
+	// Build method URLs: <baseURL>[<prefix>]/<package>.<Service>/<Method>
+	serviceURL := sanitizeBaseURL(baseURL)
+	serviceURL += baseServicePath(pathPrefix, "acai.chat", "ChatService")
+	urls := [40]string{
+		serviceURL + "StartConversation",
+		serviceURL + "ContinueConversation",
+		serviceURL + "ListConversations",
+		serviceURL + "DescribeConversation",
+		serviceURL + "RetryFailedReply",
+		serviceURL + "CancelGeneration",
+		serviceURL + "MarkRead",
+		serviceURL + "SearchSemantic",
+		serviceURL + "UploadAttachment",
+		serviceURL + "DownloadAttachment",
+		serviceURL + "SetConversationLanguage",
+		serviceURL + "SetLocationAlias",
+		serviceURL + "DeleteLocationAlias",
+		serviceURL + "ListLocationAliases",
+		serviceURL + "SetUserCalendar",
+		serviceURL + "DeleteUserCalendar",
+		serviceURL + "ListUserCalendars",
+		serviceURL + "SetCalDAVAccount",
+		serviceURL + "DeleteCalDAVAccount",
+		serviceURL + "BulkDeleteConversations",
+		serviceURL + "BulkArchiveConversations",
+		serviceURL + "GetBulkJob",
+		serviceURL + "RequestExportArchive",
+		serviceURL + "ExportConversation",
+		serviceURL + "PinMessage",
+		serviceURL + "ListPinnedMessages",
+		serviceURL + "RateMessage",
+		serviceURL + "SetDigestSubscription",
+		serviceURL + "GetDigestSubscription",
+		serviceURL + "SetPreferences",
+		serviceURL + "GetPreferences",
+		serviceURL + "SetNotificationSettings",
+		serviceURL + "GetNotificationSettings",
+		serviceURL + "RegisterPushDevice",
+		serviceURL + "UnregisterPushDevice",
+		serviceURL + "ListPushDevices",
+		serviceURL + "StartSession",
+		serviceURL + "ListSessions",
+		serviceURL + "RevokeSession",
+		serviceURL + "GetQuota",
+	}
+
+	return &chatServiceJSONClient{
+		client:      client,
+		urls:        urls,
+		interceptor: twirp.ChainInterceptors(clientOpts.Interceptors...),
+		opts:        clientOpts,
+	}
+}
+
+func (c *chatServiceJSONClient) StartConversation(ctx context.Context, in *StartConversationRequest) (*StartConversationResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.chat")
+	ctx = ctxsetters.WithServiceName(ctx, "ChatService")
+	ctx = ctxsetters.WithMethodName(ctx, "StartConversation")
+	caller := c.callStartConversation
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *StartConversationRequest) (*StartConversationResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*StartConversationRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*StartConversationRequest) when calling interceptor")
+					}
+					return c.callStartConversation(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*StartConversationResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*StartConversationResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *chatServiceJSONClient) callStartConversation(ctx context.Context, in *StartConversationRequest) (*StartConversationResponse, error) {
+	out := new(StartConversationResponse)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[0], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *chatServiceJSONClient) ContinueConversation(ctx context.Context, in *ContinueConversationRequest) (*ContinueConversationResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.chat")
+	ctx = ctxsetters.WithServiceName(ctx, "ChatService")
+	ctx = ctxsetters.WithMethodName(ctx, "ContinueConversation")
+	caller := c.callContinueConversation
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *ContinueConversationRequest) (*ContinueConversationResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ContinueConversationRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ContinueConversationRequest) when calling interceptor")
+					}
+					return c.callContinueConversation(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ContinueConversationResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ContinueConversationResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *chatServiceJSONClient) callContinueConversation(ctx context.Context, in *ContinueConversationRequest) (*ContinueConversationResponse, error) {
+	out := new(ContinueConversationResponse)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[1], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *chatServiceJSONClient) ListConversations(ctx context.Context, in *ListConversationsRequest) (*ListConversationsResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.chat")
+	ctx = ctxsetters.WithServiceName(ctx, "ChatService")
+	ctx = ctxsetters.WithMethodName(ctx, "ListConversations")
+	caller := c.callListConversations
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *ListConversationsRequest) (*ListConversationsResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ListConversationsRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ListConversationsRequest) when calling interceptor")
+					}
+					return c.callListConversations(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ListConversationsResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ListConversationsResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *chatServiceJSONClient) callListConversations(ctx context.Context, in *ListConversationsRequest) (*ListConversationsResponse, error) {
+	out := new(ListConversationsResponse)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[2], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *chatServiceJSONClient) DescribeConversation(ctx context.Context, in *DescribeConversationRequest) (*DescribeConversationResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.chat")
+	ctx = ctxsetters.WithServiceName(ctx, "ChatService")
+	ctx = ctxsetters.WithMethodName(ctx, "DescribeConversation")
+	caller := c.callDescribeConversation
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *DescribeConversationRequest) (*DescribeConversationResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*DescribeConversationRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*DescribeConversationRequest) when calling interceptor")
+					}
+					return c.callDescribeConversation(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*DescribeConversationResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*DescribeConversationResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *chatServiceJSONClient) callDescribeConversation(ctx context.Context, in *DescribeConversationRequest) (*DescribeConversationResponse, error) {
+	out := new(DescribeConversationResponse)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[3], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *chatServiceJSONClient) RetryFailedReply(ctx context.Context, in *RetryFailedReplyRequest) (*RetryFailedReplyResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.chat")
+	ctx = ctxsetters.WithServiceName(ctx, "ChatService")
+	ctx = ctxsetters.WithMethodName(ctx, "RetryFailedReply")
+	caller := c.callRetryFailedReply
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *RetryFailedReplyRequest) (*RetryFailedReplyResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*RetryFailedReplyRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*RetryFailedReplyRequest) when calling interceptor")
+					}
+					return c.callRetryFailedReply(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*RetryFailedReplyResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*RetryFailedReplyResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *chatServiceJSONClient) callRetryFailedReply(ctx context.Context, in *RetryFailedReplyRequest) (*RetryFailedReplyResponse, error) {
+	out := new(RetryFailedReplyResponse)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[4], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *chatServiceJSONClient) CancelGeneration(ctx context.Context, in *CancelGenerationRequest) (*CancelGenerationResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.chat")
+	ctx = ctxsetters.WithServiceName(ctx, "ChatService")
+	ctx = ctxsetters.WithMethodName(ctx, "CancelGeneration")
+	caller := c.callCancelGeneration
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *CancelGenerationRequest) (*CancelGenerationResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*CancelGenerationRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*CancelGenerationRequest) when calling interceptor")
+					}
+					return c.callCancelGeneration(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*CancelGenerationResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*CancelGenerationResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *chatServiceJSONClient) callCancelGeneration(ctx context.Context, in *CancelGenerationRequest) (*CancelGenerationResponse, error) {
+	out := new(CancelGenerationResponse)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[5], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *chatServiceJSONClient) MarkRead(ctx context.Context, in *MarkReadRequest) (*MarkReadResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.chat")
+	ctx = ctxsetters.WithServiceName(ctx, "ChatService")
+	ctx = ctxsetters.WithMethodName(ctx, "MarkRead")
+	caller := c.callMarkRead
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *MarkReadRequest) (*MarkReadResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*MarkReadRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*MarkReadRequest) when calling interceptor")
+					}
+					return c.callMarkRead(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*MarkReadResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*MarkReadResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *chatServiceJSONClient) callMarkRead(ctx context.Context, in *MarkReadRequest) (*MarkReadResponse, error) {
+	out := new(MarkReadResponse)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[6], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *chatServiceJSONClient) SearchSemantic(ctx context.Context, in *SearchSemanticRequest) (*SearchSemanticResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.chat")
+	ctx = ctxsetters.WithServiceName(ctx, "ChatService")
+	ctx = ctxsetters.WithMethodName(ctx, "SearchSemantic")
+	caller := c.callSearchSemantic
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *SearchSemanticRequest) (*SearchSemanticResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*SearchSemanticRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*SearchSemanticRequest) when calling interceptor")
+					}
+					return c.callSearchSemantic(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*SearchSemanticResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*SearchSemanticResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *chatServiceJSONClient) callSearchSemantic(ctx context.Context, in *SearchSemanticRequest) (*SearchSemanticResponse, error) {
+	out := new(SearchSemanticResponse)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[7], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *chatServiceJSONClient) UploadAttachment(ctx context.Context, in *UploadAttachmentRequest) (*UploadAttachmentResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.chat")
+	ctx = ctxsetters.WithServiceName(ctx, "ChatService")
+	ctx = ctxsetters.WithMethodName(ctx, "UploadAttachment")
+	caller := c.callUploadAttachment
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *UploadAttachmentRequest) (*UploadAttachmentResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*UploadAttachmentRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*UploadAttachmentRequest) when calling interceptor")
+					}
+					return c.callUploadAttachment(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*UploadAttachmentResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*UploadAttachmentResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *chatServiceJSONClient) callUploadAttachment(ctx context.Context, in *UploadAttachmentRequest) (*UploadAttachmentResponse, error) {
+	out := new(UploadAttachmentResponse)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[8], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *chatServiceJSONClient) DownloadAttachment(ctx context.Context, in *DownloadAttachmentRequest) (*DownloadAttachmentResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.chat")
+	ctx = ctxsetters.WithServiceName(ctx, "ChatService")
+	ctx = ctxsetters.WithMethodName(ctx, "DownloadAttachment")
+	caller := c.callDownloadAttachment
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *DownloadAttachmentRequest) (*DownloadAttachmentResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*DownloadAttachmentRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*DownloadAttachmentRequest) when calling interceptor")
+					}
+					return c.callDownloadAttachment(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*DownloadAttachmentResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*DownloadAttachmentResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *chatServiceJSONClient) callDownloadAttachment(ctx context.Context, in *DownloadAttachmentRequest) (*DownloadAttachmentResponse, error) {
+	out := new(DownloadAttachmentResponse)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[9], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *chatServiceJSONClient) SetConversationLanguage(ctx context.Context, in *SetConversationLanguageRequest) (*SetConversationLanguageResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.chat")
+	ctx = ctxsetters.WithServiceName(ctx, "ChatService")
+	ctx = ctxsetters.WithMethodName(ctx, "SetConversationLanguage")
+	caller := c.callSetConversationLanguage
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *SetConversationLanguageRequest) (*SetConversationLanguageResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*SetConversationLanguageRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*SetConversationLanguageRequest) when calling interceptor")
+					}
+					return c.callSetConversationLanguage(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*SetConversationLanguageResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*SetConversationLanguageResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *chatServiceJSONClient) callSetConversationLanguage(ctx context.Context, in *SetConversationLanguageRequest) (*SetConversationLanguageResponse, error) {
+	out := new(SetConversationLanguageResponse)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[10], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *chatServiceJSONClient) SetLocationAlias(ctx context.Context, in *SetLocationAliasRequest) (*SetLocationAliasResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.chat")
+	ctx = ctxsetters.WithServiceName(ctx, "ChatService")
+	ctx = ctxsetters.WithMethodName(ctx, "SetLocationAlias")
+	caller := c.callSetLocationAlias
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *SetLocationAliasRequest) (*SetLocationAliasResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*SetLocationAliasRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*SetLocationAliasRequest) when calling interceptor")
+					}
+					return c.callSetLocationAlias(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*SetLocationAliasResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*SetLocationAliasResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *chatServiceJSONClient) callSetLocationAlias(ctx context.Context, in *SetLocationAliasRequest) (*SetLocationAliasResponse, error) {
+	out := new(SetLocationAliasResponse)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[11], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *chatServiceJSONClient) DeleteLocationAlias(ctx context.Context, in *DeleteLocationAliasRequest) (*DeleteLocationAliasResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.chat")
+	ctx = ctxsetters.WithServiceName(ctx, "ChatService")
+	ctx = ctxsetters.WithMethodName(ctx, "DeleteLocationAlias")
+	caller := c.callDeleteLocationAlias
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *DeleteLocationAliasRequest) (*DeleteLocationAliasResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*DeleteLocationAliasRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*DeleteLocationAliasRequest) when calling interceptor")
+					}
+					return c.callDeleteLocationAlias(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*DeleteLocationAliasResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*DeleteLocationAliasResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *chatServiceJSONClient) callDeleteLocationAlias(ctx context.Context, in *DeleteLocationAliasRequest) (*DeleteLocationAliasResponse, error) {
+	out := new(DeleteLocationAliasResponse)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[12], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *chatServiceJSONClient) ListLocationAliases(ctx context.Context, in *ListLocationAliasesRequest) (*ListLocationAliasesResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.chat")
+	ctx = ctxsetters.WithServiceName(ctx, "ChatService")
+	ctx = ctxsetters.WithMethodName(ctx, "ListLocationAliases")
+	caller := c.callListLocationAliases
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *ListLocationAliasesRequest) (*ListLocationAliasesResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ListLocationAliasesRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ListLocationAliasesRequest) when calling interceptor")
+					}
+					return c.callListLocationAliases(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ListLocationAliasesResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ListLocationAliasesResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *chatServiceJSONClient) callListLocationAliases(ctx context.Context, in *ListLocationAliasesRequest) (*ListLocationAliasesResponse, error) {
+	out := new(ListLocationAliasesResponse)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[13], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *chatServiceJSONClient) SetUserCalendar(ctx context.Context, in *SetUserCalendarRequest) (*SetUserCalendarResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.chat")
+	ctx = ctxsetters.WithServiceName(ctx, "ChatService")
+	ctx = ctxsetters.WithMethodName(ctx, "SetUserCalendar")
+	caller := c.callSetUserCalendar
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *SetUserCalendarRequest) (*SetUserCalendarResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*SetUserCalendarRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*SetUserCalendarRequest) when calling interceptor")
+					}
+					return c.callSetUserCalendar(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*SetUserCalendarResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*SetUserCalendarResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *chatServiceJSONClient) callSetUserCalendar(ctx context.Context, in *SetUserCalendarRequest) (*SetUserCalendarResponse, error) {
+	out := new(SetUserCalendarResponse)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[14], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *chatServiceJSONClient) DeleteUserCalendar(ctx context.Context, in *DeleteUserCalendarRequest) (*DeleteUserCalendarResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.chat")
+	ctx = ctxsetters.WithServiceName(ctx, "ChatService")
+	ctx = ctxsetters.WithMethodName(ctx, "DeleteUserCalendar")
+	caller := c.callDeleteUserCalendar
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *DeleteUserCalendarRequest) (*DeleteUserCalendarResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*DeleteUserCalendarRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*DeleteUserCalendarRequest) when calling interceptor")
+					}
+					return c.callDeleteUserCalendar(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*DeleteUserCalendarResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*DeleteUserCalendarResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *chatServiceJSONClient) callDeleteUserCalendar(ctx context.Context, in *DeleteUserCalendarRequest) (*DeleteUserCalendarResponse, error) {
+	out := new(DeleteUserCalendarResponse)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[15], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *chatServiceJSONClient) ListUserCalendars(ctx context.Context, in *ListUserCalendarsRequest) (*ListUserCalendarsResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.chat")
+	ctx = ctxsetters.WithServiceName(ctx, "ChatService")
+	ctx = ctxsetters.WithMethodName(ctx, "ListUserCalendars")
+	caller := c.callListUserCalendars
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *ListUserCalendarsRequest) (*ListUserCalendarsResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ListUserCalendarsRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ListUserCalendarsRequest) when calling interceptor")
+					}
+					return c.callListUserCalendars(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ListUserCalendarsResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ListUserCalendarsResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *chatServiceJSONClient) callListUserCalendars(ctx context.Context, in *ListUserCalendarsRequest) (*ListUserCalendarsResponse, error) {
+	out := new(ListUserCalendarsResponse)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[16], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *chatServiceJSONClient) SetCalDAVAccount(ctx context.Context, in *SetCalDAVAccountRequest) (*SetCalDAVAccountResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.chat")
+	ctx = ctxsetters.WithServiceName(ctx, "ChatService")
+	ctx = ctxsetters.WithMethodName(ctx, "SetCalDAVAccount")
+	caller := c.callSetCalDAVAccount
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *SetCalDAVAccountRequest) (*SetCalDAVAccountResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*SetCalDAVAccountRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*SetCalDAVAccountRequest) when calling interceptor")
+					}
+					return c.callSetCalDAVAccount(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*SetCalDAVAccountResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*SetCalDAVAccountResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *chatServiceJSONClient) callSetCalDAVAccount(ctx context.Context, in *SetCalDAVAccountRequest) (*SetCalDAVAccountResponse, error) {
+	out := new(SetCalDAVAccountResponse)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[17], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *chatServiceJSONClient) DeleteCalDAVAccount(ctx context.Context, in *DeleteCalDAVAccountRequest) (*DeleteCalDAVAccountResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.chat")
+	ctx = ctxsetters.WithServiceName(ctx, "ChatService")
+	ctx = ctxsetters.WithMethodName(ctx, "DeleteCalDAVAccount")
+	caller := c.callDeleteCalDAVAccount
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *DeleteCalDAVAccountRequest) (*DeleteCalDAVAccountResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*DeleteCalDAVAccountRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*DeleteCalDAVAccountRequest) when calling interceptor")
+					}
+					return c.callDeleteCalDAVAccount(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*DeleteCalDAVAccountResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*DeleteCalDAVAccountResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *chatServiceJSONClient) callDeleteCalDAVAccount(ctx context.Context, in *DeleteCalDAVAccountRequest) (*DeleteCalDAVAccountResponse, error) {
+	out := new(DeleteCalDAVAccountResponse)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[18], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *chatServiceJSONClient) BulkDeleteConversations(ctx context.Context, in *BulkDeleteConversationsRequest) (*BulkDeleteConversationsResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.chat")
+	ctx = ctxsetters.WithServiceName(ctx, "ChatService")
+	ctx = ctxsetters.WithMethodName(ctx, "BulkDeleteConversations")
+	caller := c.callBulkDeleteConversations
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *BulkDeleteConversationsRequest) (*BulkDeleteConversationsResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*BulkDeleteConversationsRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*BulkDeleteConversationsRequest) when calling interceptor")
+					}
+					return c.callBulkDeleteConversations(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*BulkDeleteConversationsResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*BulkDeleteConversationsResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *chatServiceJSONClient) callBulkDeleteConversations(ctx context.Context, in *BulkDeleteConversationsRequest) (*BulkDeleteConversationsResponse, error) {
+	out := new(BulkDeleteConversationsResponse)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[19], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *chatServiceJSONClient) BulkArchiveConversations(ctx context.Context, in *BulkArchiveConversationsRequest) (*BulkArchiveConversationsResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.chat")
+	ctx = ctxsetters.WithServiceName(ctx, "ChatService")
+	ctx = ctxsetters.WithMethodName(ctx, "BulkArchiveConversations")
+	caller := c.callBulkArchiveConversations
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *BulkArchiveConversationsRequest) (*BulkArchiveConversationsResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*BulkArchiveConversationsRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*BulkArchiveConversationsRequest) when calling interceptor")
+					}
+					return c.callBulkArchiveConversations(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*BulkArchiveConversationsResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*BulkArchiveConversationsResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *chatServiceJSONClient) callBulkArchiveConversations(ctx context.Context, in *BulkArchiveConversationsRequest) (*BulkArchiveConversationsResponse, error) {
+	out := new(BulkArchiveConversationsResponse)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[20], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *chatServiceJSONClient) GetBulkJob(ctx context.Context, in *GetBulkJobRequest) (*GetBulkJobResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.chat")
+	ctx = ctxsetters.WithServiceName(ctx, "ChatService")
+	ctx = ctxsetters.WithMethodName(ctx, "GetBulkJob")
+	caller := c.callGetBulkJob
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *GetBulkJobRequest) (*GetBulkJobResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*GetBulkJobRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*GetBulkJobRequest) when calling interceptor")
+					}
+					return c.callGetBulkJob(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*GetBulkJobResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*GetBulkJobResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *chatServiceJSONClient) callGetBulkJob(ctx context.Context, in *GetBulkJobRequest) (*GetBulkJobResponse, error) {
+	out := new(GetBulkJobResponse)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[21], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *chatServiceJSONClient) RequestExportArchive(ctx context.Context, in *RequestExportArchiveRequest) (*RequestExportArchiveResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.chat")
+	ctx = ctxsetters.WithServiceName(ctx, "ChatService")
+	ctx = ctxsetters.WithMethodName(ctx, "RequestExportArchive")
+	caller := c.callRequestExportArchive
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *RequestExportArchiveRequest) (*RequestExportArchiveResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*RequestExportArchiveRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*RequestExportArchiveRequest) when calling interceptor")
+					}
+					return c.callRequestExportArchive(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*RequestExportArchiveResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*RequestExportArchiveResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *chatServiceJSONClient) callRequestExportArchive(ctx context.Context, in *RequestExportArchiveRequest) (*RequestExportArchiveResponse, error) {
+	out := new(RequestExportArchiveResponse)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[22], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *chatServiceJSONClient) ExportConversation(ctx context.Context, in *ExportConversationRequest) (*ExportConversationResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.chat")
+	ctx = ctxsetters.WithServiceName(ctx, "ChatService")
+	ctx = ctxsetters.WithMethodName(ctx, "ExportConversation")
+	caller := c.callExportConversation
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *ExportConversationRequest) (*ExportConversationResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ExportConversationRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ExportConversationRequest) when calling interceptor")
+					}
+					return c.callExportConversation(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ExportConversationResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ExportConversationResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *chatServiceJSONClient) callExportConversation(ctx context.Context, in *ExportConversationRequest) (*ExportConversationResponse, error) {
+	out := new(ExportConversationResponse)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[23], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *chatServiceJSONClient) PinMessage(ctx context.Context, in *PinMessageRequest) (*PinMessageResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.chat")
+	ctx = ctxsetters.WithServiceName(ctx, "ChatService")
+	ctx = ctxsetters.WithMethodName(ctx, "PinMessage")
+	caller := c.callPinMessage
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *PinMessageRequest) (*PinMessageResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*PinMessageRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*PinMessageRequest) when calling interceptor")
+					}
+					return c.callPinMessage(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*PinMessageResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*PinMessageResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *chatServiceJSONClient) callPinMessage(ctx context.Context, in *PinMessageRequest) (*PinMessageResponse, error) {
+	out := new(PinMessageResponse)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[24], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *chatServiceJSONClient) ListPinnedMessages(ctx context.Context, in *ListPinnedMessagesRequest) (*ListPinnedMessagesResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.chat")
+	ctx = ctxsetters.WithServiceName(ctx, "ChatService")
+	ctx = ctxsetters.WithMethodName(ctx, "ListPinnedMessages")
+	caller := c.callListPinnedMessages
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *ListPinnedMessagesRequest) (*ListPinnedMessagesResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ListPinnedMessagesRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ListPinnedMessagesRequest) when calling interceptor")
+					}
+					return c.callListPinnedMessages(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ListPinnedMessagesResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ListPinnedMessagesResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *chatServiceJSONClient) callListPinnedMessages(ctx context.Context, in *ListPinnedMessagesRequest) (*ListPinnedMessagesResponse, error) {
+	out := new(ListPinnedMessagesResponse)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[25], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *chatServiceJSONClient) RateMessage(ctx context.Context, in *RateMessageRequest) (*RateMessageResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.chat")
+	ctx = ctxsetters.WithServiceName(ctx, "ChatService")
+	ctx = ctxsetters.WithMethodName(ctx, "RateMessage")
+	caller := c.callRateMessage
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *RateMessageRequest) (*RateMessageResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*RateMessageRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*RateMessageRequest) when calling interceptor")
+					}
+					return c.callRateMessage(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*RateMessageResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*RateMessageResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *chatServiceJSONClient) callRateMessage(ctx context.Context, in *RateMessageRequest) (*RateMessageResponse, error) {
+	out := new(RateMessageResponse)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[26], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *chatServiceJSONClient) SetDigestSubscription(ctx context.Context, in *SetDigestSubscriptionRequest) (*SetDigestSubscriptionResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.chat")
+	ctx = ctxsetters.WithServiceName(ctx, "ChatService")
+	ctx = ctxsetters.WithMethodName(ctx, "SetDigestSubscription")
+	caller := c.callSetDigestSubscription
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *SetDigestSubscriptionRequest) (*SetDigestSubscriptionResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*SetDigestSubscriptionRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*SetDigestSubscriptionRequest) when calling interceptor")
+					}
+					return c.callSetDigestSubscription(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*SetDigestSubscriptionResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*SetDigestSubscriptionResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *chatServiceJSONClient) callSetDigestSubscription(ctx context.Context, in *SetDigestSubscriptionRequest) (*SetDigestSubscriptionResponse, error) {
+	out := new(SetDigestSubscriptionResponse)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[27], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *chatServiceJSONClient) GetDigestSubscription(ctx context.Context, in *GetDigestSubscriptionRequest) (*GetDigestSubscriptionResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.chat")
+	ctx = ctxsetters.WithServiceName(ctx, "ChatService")
+	ctx = ctxsetters.WithMethodName(ctx, "GetDigestSubscription")
+	caller := c.callGetDigestSubscription
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *GetDigestSubscriptionRequest) (*GetDigestSubscriptionResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*GetDigestSubscriptionRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*GetDigestSubscriptionRequest) when calling interceptor")
+					}
+					return c.callGetDigestSubscription(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*GetDigestSubscriptionResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*GetDigestSubscriptionResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *chatServiceJSONClient) callGetDigestSubscription(ctx context.Context, in *GetDigestSubscriptionRequest) (*GetDigestSubscriptionResponse, error) {
+	out := new(GetDigestSubscriptionResponse)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[28], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *chatServiceJSONClient) SetPreferences(ctx context.Context, in *SetPreferencesRequest) (*SetPreferencesResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.chat")
+	ctx = ctxsetters.WithServiceName(ctx, "ChatService")
+	ctx = ctxsetters.WithMethodName(ctx, "SetPreferences")
+	caller := c.callSetPreferences
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *SetPreferencesRequest) (*SetPreferencesResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*SetPreferencesRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*SetPreferencesRequest) when calling interceptor")
+					}
+					return c.callSetPreferences(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*SetPreferencesResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*SetPreferencesResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *chatServiceJSONClient) callSetPreferences(ctx context.Context, in *SetPreferencesRequest) (*SetPreferencesResponse, error) {
+	out := new(SetPreferencesResponse)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[29], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *chatServiceJSONClient) GetPreferences(ctx context.Context, in *GetPreferencesRequest) (*GetPreferencesResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.chat")
+	ctx = ctxsetters.WithServiceName(ctx, "ChatService")
+	ctx = ctxsetters.WithMethodName(ctx, "GetPreferences")
+	caller := c.callGetPreferences
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *GetPreferencesRequest) (*GetPreferencesResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*GetPreferencesRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*GetPreferencesRequest) when calling interceptor")
+					}
+					return c.callGetPreferences(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*GetPreferencesResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*GetPreferencesResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *chatServiceJSONClient) callGetPreferences(ctx context.Context, in *GetPreferencesRequest) (*GetPreferencesResponse, error) {
+	out := new(GetPreferencesResponse)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[30], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *chatServiceJSONClient) SetNotificationSettings(ctx context.Context, in *SetNotificationSettingsRequest) (*SetNotificationSettingsResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.chat")
+	ctx = ctxsetters.WithServiceName(ctx, "ChatService")
+	ctx = ctxsetters.WithMethodName(ctx, "SetNotificationSettings")
+	caller := c.callSetNotificationSettings
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *SetNotificationSettingsRequest) (*SetNotificationSettingsResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*SetNotificationSettingsRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*SetNotificationSettingsRequest) when calling interceptor")
+					}
+					return c.callSetNotificationSettings(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*SetNotificationSettingsResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*SetNotificationSettingsResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *chatServiceJSONClient) callSetNotificationSettings(ctx context.Context, in *SetNotificationSettingsRequest) (*SetNotificationSettingsResponse, error) {
+	out := new(SetNotificationSettingsResponse)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[31], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *chatServiceJSONClient) GetNotificationSettings(ctx context.Context, in *GetNotificationSettingsRequest) (*GetNotificationSettingsResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.chat")
+	ctx = ctxsetters.WithServiceName(ctx, "ChatService")
+	ctx = ctxsetters.WithMethodName(ctx, "GetNotificationSettings")
+	caller := c.callGetNotificationSettings
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *GetNotificationSettingsRequest) (*GetNotificationSettingsResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*GetNotificationSettingsRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*GetNotificationSettingsRequest) when calling interceptor")
+					}
+					return c.callGetNotificationSettings(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*GetNotificationSettingsResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*GetNotificationSettingsResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *chatServiceJSONClient) callGetNotificationSettings(ctx context.Context, in *GetNotificationSettingsRequest) (*GetNotificationSettingsResponse, error) {
+	out := new(GetNotificationSettingsResponse)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[32], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *chatServiceJSONClient) RegisterPushDevice(ctx context.Context, in *RegisterPushDeviceRequest) (*RegisterPushDeviceResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.chat")
+	ctx = ctxsetters.WithServiceName(ctx, "ChatService")
+	ctx = ctxsetters.WithMethodName(ctx, "RegisterPushDevice")
+	caller := c.callRegisterPushDevice
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *RegisterPushDeviceRequest) (*RegisterPushDeviceResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*RegisterPushDeviceRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*RegisterPushDeviceRequest) when calling interceptor")
+					}
+					return c.callRegisterPushDevice(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*RegisterPushDeviceResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*RegisterPushDeviceResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *chatServiceJSONClient) callRegisterPushDevice(ctx context.Context, in *RegisterPushDeviceRequest) (*RegisterPushDeviceResponse, error) {
+	out := new(RegisterPushDeviceResponse)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[33], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *chatServiceJSONClient) UnregisterPushDevice(ctx context.Context, in *UnregisterPushDeviceRequest) (*UnregisterPushDeviceResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.chat")
+	ctx = ctxsetters.WithServiceName(ctx, "ChatService")
+	ctx = ctxsetters.WithMethodName(ctx, "UnregisterPushDevice")
+	caller := c.callUnregisterPushDevice
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *UnregisterPushDeviceRequest) (*UnregisterPushDeviceResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*UnregisterPushDeviceRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*UnregisterPushDeviceRequest) when calling interceptor")
+					}
+					return c.callUnregisterPushDevice(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*UnregisterPushDeviceResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*UnregisterPushDeviceResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *chatServiceJSONClient) callUnregisterPushDevice(ctx context.Context, in *UnregisterPushDeviceRequest) (*UnregisterPushDeviceResponse, error) {
+	out := new(UnregisterPushDeviceResponse)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[34], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *chatServiceJSONClient) ListPushDevices(ctx context.Context, in *ListPushDevicesRequest) (*ListPushDevicesResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.chat")
+	ctx = ctxsetters.WithServiceName(ctx, "ChatService")
+	ctx = ctxsetters.WithMethodName(ctx, "ListPushDevices")
+	caller := c.callListPushDevices
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *ListPushDevicesRequest) (*ListPushDevicesResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ListPushDevicesRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ListPushDevicesRequest) when calling interceptor")
+					}
+					return c.callListPushDevices(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ListPushDevicesResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ListPushDevicesResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *chatServiceJSONClient) callListPushDevices(ctx context.Context, in *ListPushDevicesRequest) (*ListPushDevicesResponse, error) {
+	out := new(ListPushDevicesResponse)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[35], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *chatServiceJSONClient) StartSession(ctx context.Context, in *StartSessionRequest) (*StartSessionResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.chat")
+	ctx = ctxsetters.WithServiceName(ctx, "ChatService")
+	ctx = ctxsetters.WithMethodName(ctx, "StartSession")
+	caller := c.callStartSession
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *StartSessionRequest) (*StartSessionResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*StartSessionRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*StartSessionRequest) when calling interceptor")
+					}
+					return c.callStartSession(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*StartSessionResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*StartSessionResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *chatServiceJSONClient) callStartSession(ctx context.Context, in *StartSessionRequest) (*StartSessionResponse, error) {
+	out := new(StartSessionResponse)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[36], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *chatServiceJSONClient) ListSessions(ctx context.Context, in *ListSessionsRequest) (*ListSessionsResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.chat")
+	ctx = ctxsetters.WithServiceName(ctx, "ChatService")
+	ctx = ctxsetters.WithMethodName(ctx, "ListSessions")
+	caller := c.callListSessions
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *ListSessionsRequest) (*ListSessionsResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ListSessionsRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ListSessionsRequest) when calling interceptor")
+					}
+					return c.callListSessions(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ListSessionsResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ListSessionsResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *chatServiceJSONClient) callListSessions(ctx context.Context, in *ListSessionsRequest) (*ListSessionsResponse, error) {
+	out := new(ListSessionsResponse)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[37], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *chatServiceJSONClient) RevokeSession(ctx context.Context, in *RevokeSessionRequest) (*RevokeSessionResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.chat")
+	ctx = ctxsetters.WithServiceName(ctx, "ChatService")
+	ctx = ctxsetters.WithMethodName(ctx, "RevokeSession")
+	caller := c.callRevokeSession
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *RevokeSessionRequest) (*RevokeSessionResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*RevokeSessionRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*RevokeSessionRequest) when calling interceptor")
+					}
+					return c.callRevokeSession(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*RevokeSessionResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*RevokeSessionResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *chatServiceJSONClient) callRevokeSession(ctx context.Context, in *RevokeSessionRequest) (*RevokeSessionResponse, error) {
+	out := new(RevokeSessionResponse)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[38], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *chatServiceJSONClient) GetQuota(ctx context.Context, in *GetQuotaRequest) (*GetQuotaResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.chat")
+	ctx = ctxsetters.WithServiceName(ctx, "ChatService")
+	ctx = ctxsetters.WithMethodName(ctx, "GetQuota")
+	caller := c.callGetQuota
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *GetQuotaRequest) (*GetQuotaResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*GetQuotaRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*GetQuotaRequest) when calling interceptor")
+					}
+					return c.callGetQuota(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*GetQuotaResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*GetQuotaResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *chatServiceJSONClient) callGetQuota(ctx context.Context, in *GetQuotaRequest) (*GetQuotaResponse, error) {
+	out := new(GetQuotaResponse)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[39], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+// ==========================
+// ChatService Server Handler
+// ==========================
+
+type chatServiceServer struct {
+	ChatService
+	interceptor      twirp.Interceptor
+	hooks            *twirp.ServerHooks
+	pathPrefix       string // prefix for routing
+	jsonSkipDefaults bool   // do not include unpopulated fields (default values) in the response
+	jsonCamelCase    bool   // JSON fields are serialized as lowerCamelCase rather than keeping the original proto names
+}
+
+// NewChatServiceServer builds a TwirpServer that can be used as an http.Handler to handle
+// HTTP requests that are routed to the right method in the provided svc implementation.
+// The opts are twirp.ServerOption modifiers, for example twirp.WithServerHooks(hooks).
+func NewChatServiceServer(svc ChatService, opts ...interface{}) TwirpServer {
+	serverOpts := newServerOpts(opts)
+
+	// Using ReadOpt allows backwards and forwards compatibility with new options in the future
+	jsonSkipDefaults := false
+	_ = serverOpts.ReadOpt("jsonSkipDefaults", &jsonSkipDefaults)
+	jsonCamelCase := false
+	_ = serverOpts.ReadOpt("jsonCamelCase", &jsonCamelCase)
+	var pathPrefix string
+	if ok := serverOpts.ReadOpt("pathPrefix", &pathPrefix); !ok {
+		pathPrefix = "/twirp" // default prefix
+	}
+
+	return &chatServiceServer{
+		ChatService:      svc,
+		hooks:            serverOpts.Hooks,
+		interceptor:      twirp.ChainInterceptors(serverOpts.Interceptors...),
+		pathPrefix:       pathPrefix,
+		jsonSkipDefaults: jsonSkipDefaults,
+		jsonCamelCase:    jsonCamelCase,
+	}
+}
+
+// writeError writes an HTTP response with a valid Twirp error format, and triggers hooks.
+// If err is not a twirp.Error, it will get wrapped with twirp.InternalErrorWith(err)
+func (s *chatServiceServer) writeError(ctx context.Context, resp http.ResponseWriter, err error) {
+	writeError(ctx, resp, err, s.hooks)
+}
+
+// handleRequestBodyError is used to handle error when the twirp server cannot read request
+func (s *chatServiceServer) handleRequestBodyError(ctx context.Context, resp http.ResponseWriter, msg string, err error) {
+	if context.Canceled == ctx.Err() {
+		s.writeError(ctx, resp, twirp.NewError(twirp.Canceled, "failed to read request: context canceled"))
+		return
+	}
+	if context.DeadlineExceeded == ctx.Err() {
+		s.writeError(ctx, resp, twirp.NewError(twirp.DeadlineExceeded, "failed to read request: deadline exceeded"))
+		return
+	}
+	s.writeError(ctx, resp, twirp.WrapError(malformedRequestError(msg), err))
 }
 
 // ChatServicePathPrefix is a convenience constant that may identify URL paths.
@@ -576,59 +4066,6647 @@ func (s *chatServiceServer) handleRequestBodyError(ctx context.Context, resp htt
 // More info: https://twitchtv.github.io/twirp/docs/routing.html
 const ChatServicePathPrefix = "/twirp/acai.chat.ChatService/"
 
-func (s *chatServiceServer) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
-	ctx := req.Context()
-	ctx = ctxsetters.WithPackageName(ctx, "acai.chat")
-	ctx = ctxsetters.WithServiceName(ctx, "ChatService")
-	ctx = ctxsetters.WithResponseWriter(ctx, resp)
+func (s *chatServiceServer) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	ctx = ctxsetters.WithPackageName(ctx, "acai.chat")
+	ctx = ctxsetters.WithServiceName(ctx, "ChatService")
+	ctx = ctxsetters.WithResponseWriter(ctx, resp)
+
+	var err error
+	ctx, err = callRequestReceived(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	if req.Method != "POST" {
+		msg := fmt.Sprintf("unsupported method %q (only POST is allowed)", req.Method)
+		s.writeError(ctx, resp, badRouteError(msg, req.Method, req.URL.Path))
+		return
+	}
+
+	// Verify path format: [<prefix>]/<package>.<Service>/<Method>
+	prefix, pkgService, method := parseTwirpPath(req.URL.Path)
+	if pkgService != "acai.chat.ChatService" {
+		msg := fmt.Sprintf("no handler for path %q", req.URL.Path)
+		s.writeError(ctx, resp, badRouteError(msg, req.Method, req.URL.Path))
+		return
+	}
+	if prefix != s.pathPrefix {
+		msg := fmt.Sprintf("invalid path prefix %q, expected %q, on path %q", prefix, s.pathPrefix, req.URL.Path)
+		s.writeError(ctx, resp, badRouteError(msg, req.Method, req.URL.Path))
+		return
+	}
+
+	switch method {
+	case "StartConversation":
+		s.serveStartConversation(ctx, resp, req)
+		return
+	case "ContinueConversation":
+		s.serveContinueConversation(ctx, resp, req)
+		return
+	case "ListConversations":
+		s.serveListConversations(ctx, resp, req)
+		return
+	case "DescribeConversation":
+		s.serveDescribeConversation(ctx, resp, req)
+		return
+	case "RetryFailedReply":
+		s.serveRetryFailedReply(ctx, resp, req)
+		return
+	case "CancelGeneration":
+		s.serveCancelGeneration(ctx, resp, req)
+		return
+	case "MarkRead":
+		s.serveMarkRead(ctx, resp, req)
+		return
+	case "SearchSemantic":
+		s.serveSearchSemantic(ctx, resp, req)
+		return
+	case "UploadAttachment":
+		s.serveUploadAttachment(ctx, resp, req)
+		return
+	case "DownloadAttachment":
+		s.serveDownloadAttachment(ctx, resp, req)
+		return
+	case "SetConversationLanguage":
+		s.serveSetConversationLanguage(ctx, resp, req)
+		return
+	case "SetLocationAlias":
+		s.serveSetLocationAlias(ctx, resp, req)
+		return
+	case "DeleteLocationAlias":
+		s.serveDeleteLocationAlias(ctx, resp, req)
+		return
+	case "ListLocationAliases":
+		s.serveListLocationAliases(ctx, resp, req)
+		return
+	case "SetUserCalendar":
+		s.serveSetUserCalendar(ctx, resp, req)
+		return
+	case "DeleteUserCalendar":
+		s.serveDeleteUserCalendar(ctx, resp, req)
+		return
+	case "ListUserCalendars":
+		s.serveListUserCalendars(ctx, resp, req)
+		return
+	case "SetCalDAVAccount":
+		s.serveSetCalDAVAccount(ctx, resp, req)
+		return
+	case "DeleteCalDAVAccount":
+		s.serveDeleteCalDAVAccount(ctx, resp, req)
+		return
+	case "BulkDeleteConversations":
+		s.serveBulkDeleteConversations(ctx, resp, req)
+		return
+	case "BulkArchiveConversations":
+		s.serveBulkArchiveConversations(ctx, resp, req)
+		return
+	case "GetBulkJob":
+		s.serveGetBulkJob(ctx, resp, req)
+		return
+	case "RequestExportArchive":
+		s.serveRequestExportArchive(ctx, resp, req)
+		return
+	case "ExportConversation":
+		s.serveExportConversation(ctx, resp, req)
+		return
+	case "PinMessage":
+		s.servePinMessage(ctx, resp, req)
+		return
+	case "ListPinnedMessages":
+		s.serveListPinnedMessages(ctx, resp, req)
+		return
+	case "RateMessage":
+		s.serveRateMessage(ctx, resp, req)
+		return
+	case "SetDigestSubscription":
+		s.serveSetDigestSubscription(ctx, resp, req)
+		return
+	case "GetDigestSubscription":
+		s.serveGetDigestSubscription(ctx, resp, req)
+		return
+	case "SetPreferences":
+		s.serveSetPreferences(ctx, resp, req)
+		return
+	case "GetPreferences":
+		s.serveGetPreferences(ctx, resp, req)
+		return
+	case "SetNotificationSettings":
+		s.serveSetNotificationSettings(ctx, resp, req)
+		return
+	case "GetNotificationSettings":
+		s.serveGetNotificationSettings(ctx, resp, req)
+		return
+	case "RegisterPushDevice":
+		s.serveRegisterPushDevice(ctx, resp, req)
+		return
+	case "UnregisterPushDevice":
+		s.serveUnregisterPushDevice(ctx, resp, req)
+		return
+	case "ListPushDevices":
+		s.serveListPushDevices(ctx, resp, req)
+		return
+	case "StartSession":
+		s.serveStartSession(ctx, resp, req)
+		return
+	case "ListSessions":
+		s.serveListSessions(ctx, resp, req)
+		return
+	case "RevokeSession":
+		s.serveRevokeSession(ctx, resp, req)
+		return
+	case "GetQuota":
+		s.serveGetQuota(ctx, resp, req)
+		return
+	default:
+		msg := fmt.Sprintf("no handler for path %q", req.URL.Path)
+		s.writeError(ctx, resp, badRouteError(msg, req.Method, req.URL.Path))
+		return
+	}
+}
+
+func (s *chatServiceServer) serveStartConversation(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveStartConversationJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveStartConversationProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *chatServiceServer) serveStartConversationJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "StartConversation")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	d := json.NewDecoder(req.Body)
+	rawReqBody := json.RawMessage{}
+	if err := d.Decode(&rawReqBody); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+	reqContent := new(StartConversationRequest)
+	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
+	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+
+	handler := s.ChatService.StartConversation
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *StartConversationRequest) (*StartConversationResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*StartConversationRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*StartConversationRequest) when calling interceptor")
+					}
+					return s.ChatService.StartConversation(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*StartConversationResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*StartConversationResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *StartConversationResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *StartConversationResponse and nil error while calling StartConversation. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	marshaler := &protojson.MarshalOptions{UseProtoNames: !s.jsonCamelCase, EmitUnpopulated: !s.jsonSkipDefaults}
+	respBytes, err := marshaler.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *chatServiceServer) serveStartConversationProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "StartConversation")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := io.ReadAll(req.Body)
+	if err != nil {
+		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
+		return
+	}
+	reqContent := new(StartConversationRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	handler := s.ChatService.StartConversation
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *StartConversationRequest) (*StartConversationResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*StartConversationRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*StartConversationRequest) when calling interceptor")
+					}
+					return s.ChatService.StartConversation(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*StartConversationResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*StartConversationResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *StartConversationResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *StartConversationResponse and nil error while calling StartConversation. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *chatServiceServer) serveContinueConversation(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveContinueConversationJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveContinueConversationProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *chatServiceServer) serveContinueConversationJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "ContinueConversation")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	d := json.NewDecoder(req.Body)
+	rawReqBody := json.RawMessage{}
+	if err := d.Decode(&rawReqBody); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+	reqContent := new(ContinueConversationRequest)
+	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
+	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+
+	handler := s.ChatService.ContinueConversation
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *ContinueConversationRequest) (*ContinueConversationResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ContinueConversationRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ContinueConversationRequest) when calling interceptor")
+					}
+					return s.ChatService.ContinueConversation(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ContinueConversationResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ContinueConversationResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *ContinueConversationResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *ContinueConversationResponse and nil error while calling ContinueConversation. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	marshaler := &protojson.MarshalOptions{UseProtoNames: !s.jsonCamelCase, EmitUnpopulated: !s.jsonSkipDefaults}
+	respBytes, err := marshaler.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *chatServiceServer) serveContinueConversationProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "ContinueConversation")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := io.ReadAll(req.Body)
+	if err != nil {
+		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
+		return
+	}
+	reqContent := new(ContinueConversationRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	handler := s.ChatService.ContinueConversation
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *ContinueConversationRequest) (*ContinueConversationResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ContinueConversationRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ContinueConversationRequest) when calling interceptor")
+					}
+					return s.ChatService.ContinueConversation(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ContinueConversationResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ContinueConversationResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *ContinueConversationResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *ContinueConversationResponse and nil error while calling ContinueConversation. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *chatServiceServer) serveListConversations(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveListConversationsJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveListConversationsProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *chatServiceServer) serveListConversationsJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "ListConversations")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	d := json.NewDecoder(req.Body)
+	rawReqBody := json.RawMessage{}
+	if err := d.Decode(&rawReqBody); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+	reqContent := new(ListConversationsRequest)
+	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
+	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+
+	handler := s.ChatService.ListConversations
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *ListConversationsRequest) (*ListConversationsResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ListConversationsRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ListConversationsRequest) when calling interceptor")
+					}
+					return s.ChatService.ListConversations(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ListConversationsResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ListConversationsResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *ListConversationsResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *ListConversationsResponse and nil error while calling ListConversations. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	marshaler := &protojson.MarshalOptions{UseProtoNames: !s.jsonCamelCase, EmitUnpopulated: !s.jsonSkipDefaults}
+	respBytes, err := marshaler.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *chatServiceServer) serveListConversationsProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "ListConversations")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := io.ReadAll(req.Body)
+	if err != nil {
+		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
+		return
+	}
+	reqContent := new(ListConversationsRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	handler := s.ChatService.ListConversations
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *ListConversationsRequest) (*ListConversationsResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ListConversationsRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ListConversationsRequest) when calling interceptor")
+					}
+					return s.ChatService.ListConversations(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ListConversationsResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ListConversationsResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *ListConversationsResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *ListConversationsResponse and nil error while calling ListConversations. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *chatServiceServer) serveDescribeConversation(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveDescribeConversationJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveDescribeConversationProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *chatServiceServer) serveDescribeConversationJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "DescribeConversation")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	d := json.NewDecoder(req.Body)
+	rawReqBody := json.RawMessage{}
+	if err := d.Decode(&rawReqBody); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+	reqContent := new(DescribeConversationRequest)
+	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
+	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+
+	handler := s.ChatService.DescribeConversation
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *DescribeConversationRequest) (*DescribeConversationResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*DescribeConversationRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*DescribeConversationRequest) when calling interceptor")
+					}
+					return s.ChatService.DescribeConversation(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*DescribeConversationResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*DescribeConversationResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *DescribeConversationResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *DescribeConversationResponse and nil error while calling DescribeConversation. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	marshaler := &protojson.MarshalOptions{UseProtoNames: !s.jsonCamelCase, EmitUnpopulated: !s.jsonSkipDefaults}
+	respBytes, err := marshaler.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *chatServiceServer) serveDescribeConversationProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "DescribeConversation")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := io.ReadAll(req.Body)
+	if err != nil {
+		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
+		return
+	}
+	reqContent := new(DescribeConversationRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	handler := s.ChatService.DescribeConversation
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *DescribeConversationRequest) (*DescribeConversationResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*DescribeConversationRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*DescribeConversationRequest) when calling interceptor")
+					}
+					return s.ChatService.DescribeConversation(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*DescribeConversationResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*DescribeConversationResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *DescribeConversationResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *DescribeConversationResponse and nil error while calling DescribeConversation. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *chatServiceServer) serveRetryFailedReply(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveRetryFailedReplyJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveRetryFailedReplyProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *chatServiceServer) serveRetryFailedReplyJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "RetryFailedReply")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	d := json.NewDecoder(req.Body)
+	rawReqBody := json.RawMessage{}
+	if err := d.Decode(&rawReqBody); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+	reqContent := new(RetryFailedReplyRequest)
+	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
+	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+
+	handler := s.ChatService.RetryFailedReply
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *RetryFailedReplyRequest) (*RetryFailedReplyResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*RetryFailedReplyRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*RetryFailedReplyRequest) when calling interceptor")
+					}
+					return s.ChatService.RetryFailedReply(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*RetryFailedReplyResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*RetryFailedReplyResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *RetryFailedReplyResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *RetryFailedReplyResponse and nil error while calling RetryFailedReply. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	marshaler := &protojson.MarshalOptions{UseProtoNames: !s.jsonCamelCase, EmitUnpopulated: !s.jsonSkipDefaults}
+	respBytes, err := marshaler.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *chatServiceServer) serveRetryFailedReplyProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "RetryFailedReply")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := io.ReadAll(req.Body)
+	if err != nil {
+		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
+		return
+	}
+	reqContent := new(RetryFailedReplyRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	handler := s.ChatService.RetryFailedReply
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *RetryFailedReplyRequest) (*RetryFailedReplyResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*RetryFailedReplyRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*RetryFailedReplyRequest) when calling interceptor")
+					}
+					return s.ChatService.RetryFailedReply(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*RetryFailedReplyResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*RetryFailedReplyResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *RetryFailedReplyResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *RetryFailedReplyResponse and nil error while calling RetryFailedReply. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *chatServiceServer) serveCancelGeneration(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveCancelGenerationJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveCancelGenerationProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *chatServiceServer) serveCancelGenerationJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "CancelGeneration")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	d := json.NewDecoder(req.Body)
+	rawReqBody := json.RawMessage{}
+	if err := d.Decode(&rawReqBody); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+	reqContent := new(CancelGenerationRequest)
+	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
+	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+
+	handler := s.ChatService.CancelGeneration
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *CancelGenerationRequest) (*CancelGenerationResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*CancelGenerationRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*CancelGenerationRequest) when calling interceptor")
+					}
+					return s.ChatService.CancelGeneration(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*CancelGenerationResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*CancelGenerationResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *CancelGenerationResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *CancelGenerationResponse and nil error while calling CancelGeneration. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	marshaler := &protojson.MarshalOptions{UseProtoNames: !s.jsonCamelCase, EmitUnpopulated: !s.jsonSkipDefaults}
+	respBytes, err := marshaler.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *chatServiceServer) serveCancelGenerationProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "CancelGeneration")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := io.ReadAll(req.Body)
+	if err != nil {
+		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
+		return
+	}
+	reqContent := new(CancelGenerationRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	handler := s.ChatService.CancelGeneration
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *CancelGenerationRequest) (*CancelGenerationResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*CancelGenerationRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*CancelGenerationRequest) when calling interceptor")
+					}
+					return s.ChatService.CancelGeneration(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*CancelGenerationResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*CancelGenerationResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *CancelGenerationResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *CancelGenerationResponse and nil error while calling CancelGeneration. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *chatServiceServer) serveMarkRead(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveMarkReadJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveMarkReadProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *chatServiceServer) serveMarkReadJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "MarkRead")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	d := json.NewDecoder(req.Body)
+	rawReqBody := json.RawMessage{}
+	if err := d.Decode(&rawReqBody); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+	reqContent := new(MarkReadRequest)
+	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
+	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+
+	handler := s.ChatService.MarkRead
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *MarkReadRequest) (*MarkReadResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*MarkReadRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*MarkReadRequest) when calling interceptor")
+					}
+					return s.ChatService.MarkRead(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*MarkReadResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*MarkReadResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *MarkReadResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *MarkReadResponse and nil error while calling MarkRead. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	marshaler := &protojson.MarshalOptions{UseProtoNames: !s.jsonCamelCase, EmitUnpopulated: !s.jsonSkipDefaults}
+	respBytes, err := marshaler.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *chatServiceServer) serveMarkReadProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "MarkRead")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := io.ReadAll(req.Body)
+	if err != nil {
+		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
+		return
+	}
+	reqContent := new(MarkReadRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	handler := s.ChatService.MarkRead
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *MarkReadRequest) (*MarkReadResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*MarkReadRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*MarkReadRequest) when calling interceptor")
+					}
+					return s.ChatService.MarkRead(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*MarkReadResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*MarkReadResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *MarkReadResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *MarkReadResponse and nil error while calling MarkRead. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *chatServiceServer) serveSearchSemantic(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveSearchSemanticJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveSearchSemanticProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *chatServiceServer) serveSearchSemanticJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "SearchSemantic")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	d := json.NewDecoder(req.Body)
+	rawReqBody := json.RawMessage{}
+	if err := d.Decode(&rawReqBody); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+	reqContent := new(SearchSemanticRequest)
+	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
+	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+
+	handler := s.ChatService.SearchSemantic
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *SearchSemanticRequest) (*SearchSemanticResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*SearchSemanticRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*SearchSemanticRequest) when calling interceptor")
+					}
+					return s.ChatService.SearchSemantic(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*SearchSemanticResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*SearchSemanticResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *SearchSemanticResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *SearchSemanticResponse and nil error while calling SearchSemantic. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	marshaler := &protojson.MarshalOptions{UseProtoNames: !s.jsonCamelCase, EmitUnpopulated: !s.jsonSkipDefaults}
+	respBytes, err := marshaler.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *chatServiceServer) serveSearchSemanticProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "SearchSemantic")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := io.ReadAll(req.Body)
+	if err != nil {
+		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
+		return
+	}
+	reqContent := new(SearchSemanticRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	handler := s.ChatService.SearchSemantic
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *SearchSemanticRequest) (*SearchSemanticResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*SearchSemanticRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*SearchSemanticRequest) when calling interceptor")
+					}
+					return s.ChatService.SearchSemantic(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*SearchSemanticResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*SearchSemanticResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *SearchSemanticResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *SearchSemanticResponse and nil error while calling SearchSemantic. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *chatServiceServer) serveUploadAttachment(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveUploadAttachmentJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveUploadAttachmentProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *chatServiceServer) serveUploadAttachmentJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "UploadAttachment")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	d := json.NewDecoder(req.Body)
+	rawReqBody := json.RawMessage{}
+	if err := d.Decode(&rawReqBody); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+	reqContent := new(UploadAttachmentRequest)
+	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
+	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+
+	handler := s.ChatService.UploadAttachment
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *UploadAttachmentRequest) (*UploadAttachmentResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*UploadAttachmentRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*UploadAttachmentRequest) when calling interceptor")
+					}
+					return s.ChatService.UploadAttachment(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*UploadAttachmentResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*UploadAttachmentResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *UploadAttachmentResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *UploadAttachmentResponse and nil error while calling UploadAttachment. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	marshaler := &protojson.MarshalOptions{UseProtoNames: !s.jsonCamelCase, EmitUnpopulated: !s.jsonSkipDefaults}
+	respBytes, err := marshaler.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *chatServiceServer) serveUploadAttachmentProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "UploadAttachment")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := io.ReadAll(req.Body)
+	if err != nil {
+		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
+		return
+	}
+	reqContent := new(UploadAttachmentRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	handler := s.ChatService.UploadAttachment
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *UploadAttachmentRequest) (*UploadAttachmentResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*UploadAttachmentRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*UploadAttachmentRequest) when calling interceptor")
+					}
+					return s.ChatService.UploadAttachment(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*UploadAttachmentResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*UploadAttachmentResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *UploadAttachmentResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *UploadAttachmentResponse and nil error while calling UploadAttachment. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *chatServiceServer) serveDownloadAttachment(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveDownloadAttachmentJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveDownloadAttachmentProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *chatServiceServer) serveDownloadAttachmentJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "DownloadAttachment")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	d := json.NewDecoder(req.Body)
+	rawReqBody := json.RawMessage{}
+	if err := d.Decode(&rawReqBody); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+	reqContent := new(DownloadAttachmentRequest)
+	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
+	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+
+	handler := s.ChatService.DownloadAttachment
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *DownloadAttachmentRequest) (*DownloadAttachmentResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*DownloadAttachmentRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*DownloadAttachmentRequest) when calling interceptor")
+					}
+					return s.ChatService.DownloadAttachment(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*DownloadAttachmentResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*DownloadAttachmentResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *DownloadAttachmentResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *DownloadAttachmentResponse and nil error while calling DownloadAttachment. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	marshaler := &protojson.MarshalOptions{UseProtoNames: !s.jsonCamelCase, EmitUnpopulated: !s.jsonSkipDefaults}
+	respBytes, err := marshaler.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *chatServiceServer) serveDownloadAttachmentProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "DownloadAttachment")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := io.ReadAll(req.Body)
+	if err != nil {
+		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
+		return
+	}
+	reqContent := new(DownloadAttachmentRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	handler := s.ChatService.DownloadAttachment
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *DownloadAttachmentRequest) (*DownloadAttachmentResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*DownloadAttachmentRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*DownloadAttachmentRequest) when calling interceptor")
+					}
+					return s.ChatService.DownloadAttachment(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*DownloadAttachmentResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*DownloadAttachmentResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *DownloadAttachmentResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *DownloadAttachmentResponse and nil error while calling DownloadAttachment. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *chatServiceServer) serveSetConversationLanguage(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveSetConversationLanguageJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveSetConversationLanguageProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *chatServiceServer) serveSetConversationLanguageJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "SetConversationLanguage")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	d := json.NewDecoder(req.Body)
+	rawReqBody := json.RawMessage{}
+	if err := d.Decode(&rawReqBody); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+	reqContent := new(SetConversationLanguageRequest)
+	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
+	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+
+	handler := s.ChatService.SetConversationLanguage
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *SetConversationLanguageRequest) (*SetConversationLanguageResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*SetConversationLanguageRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*SetConversationLanguageRequest) when calling interceptor")
+					}
+					return s.ChatService.SetConversationLanguage(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*SetConversationLanguageResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*SetConversationLanguageResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *SetConversationLanguageResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *SetConversationLanguageResponse and nil error while calling SetConversationLanguage. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	marshaler := &protojson.MarshalOptions{UseProtoNames: !s.jsonCamelCase, EmitUnpopulated: !s.jsonSkipDefaults}
+	respBytes, err := marshaler.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *chatServiceServer) serveSetConversationLanguageProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "SetConversationLanguage")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := io.ReadAll(req.Body)
+	if err != nil {
+		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
+		return
+	}
+	reqContent := new(SetConversationLanguageRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	handler := s.ChatService.SetConversationLanguage
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *SetConversationLanguageRequest) (*SetConversationLanguageResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*SetConversationLanguageRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*SetConversationLanguageRequest) when calling interceptor")
+					}
+					return s.ChatService.SetConversationLanguage(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*SetConversationLanguageResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*SetConversationLanguageResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *SetConversationLanguageResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *SetConversationLanguageResponse and nil error while calling SetConversationLanguage. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *chatServiceServer) serveSetLocationAlias(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveSetLocationAliasJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveSetLocationAliasProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *chatServiceServer) serveSetLocationAliasJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "SetLocationAlias")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	d := json.NewDecoder(req.Body)
+	rawReqBody := json.RawMessage{}
+	if err := d.Decode(&rawReqBody); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+	reqContent := new(SetLocationAliasRequest)
+	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
+	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+
+	handler := s.ChatService.SetLocationAlias
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *SetLocationAliasRequest) (*SetLocationAliasResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*SetLocationAliasRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*SetLocationAliasRequest) when calling interceptor")
+					}
+					return s.ChatService.SetLocationAlias(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*SetLocationAliasResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*SetLocationAliasResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *SetLocationAliasResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *SetLocationAliasResponse and nil error while calling SetLocationAlias. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	marshaler := &protojson.MarshalOptions{UseProtoNames: !s.jsonCamelCase, EmitUnpopulated: !s.jsonSkipDefaults}
+	respBytes, err := marshaler.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *chatServiceServer) serveSetLocationAliasProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "SetLocationAlias")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := io.ReadAll(req.Body)
+	if err != nil {
+		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
+		return
+	}
+	reqContent := new(SetLocationAliasRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	handler := s.ChatService.SetLocationAlias
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *SetLocationAliasRequest) (*SetLocationAliasResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*SetLocationAliasRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*SetLocationAliasRequest) when calling interceptor")
+					}
+					return s.ChatService.SetLocationAlias(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*SetLocationAliasResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*SetLocationAliasResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *SetLocationAliasResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *SetLocationAliasResponse and nil error while calling SetLocationAlias. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *chatServiceServer) serveDeleteLocationAlias(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveDeleteLocationAliasJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveDeleteLocationAliasProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *chatServiceServer) serveDeleteLocationAliasJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "DeleteLocationAlias")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	d := json.NewDecoder(req.Body)
+	rawReqBody := json.RawMessage{}
+	if err := d.Decode(&rawReqBody); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+	reqContent := new(DeleteLocationAliasRequest)
+	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
+	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+
+	handler := s.ChatService.DeleteLocationAlias
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *DeleteLocationAliasRequest) (*DeleteLocationAliasResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*DeleteLocationAliasRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*DeleteLocationAliasRequest) when calling interceptor")
+					}
+					return s.ChatService.DeleteLocationAlias(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*DeleteLocationAliasResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*DeleteLocationAliasResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *DeleteLocationAliasResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *DeleteLocationAliasResponse and nil error while calling DeleteLocationAlias. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	marshaler := &protojson.MarshalOptions{UseProtoNames: !s.jsonCamelCase, EmitUnpopulated: !s.jsonSkipDefaults}
+	respBytes, err := marshaler.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *chatServiceServer) serveDeleteLocationAliasProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "DeleteLocationAlias")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := io.ReadAll(req.Body)
+	if err != nil {
+		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
+		return
+	}
+	reqContent := new(DeleteLocationAliasRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	handler := s.ChatService.DeleteLocationAlias
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *DeleteLocationAliasRequest) (*DeleteLocationAliasResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*DeleteLocationAliasRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*DeleteLocationAliasRequest) when calling interceptor")
+					}
+					return s.ChatService.DeleteLocationAlias(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*DeleteLocationAliasResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*DeleteLocationAliasResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *DeleteLocationAliasResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *DeleteLocationAliasResponse and nil error while calling DeleteLocationAlias. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *chatServiceServer) serveListLocationAliases(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveListLocationAliasesJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveListLocationAliasesProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *chatServiceServer) serveListLocationAliasesJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "ListLocationAliases")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	d := json.NewDecoder(req.Body)
+	rawReqBody := json.RawMessage{}
+	if err := d.Decode(&rawReqBody); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+	reqContent := new(ListLocationAliasesRequest)
+	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
+	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+
+	handler := s.ChatService.ListLocationAliases
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *ListLocationAliasesRequest) (*ListLocationAliasesResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ListLocationAliasesRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ListLocationAliasesRequest) when calling interceptor")
+					}
+					return s.ChatService.ListLocationAliases(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ListLocationAliasesResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ListLocationAliasesResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *ListLocationAliasesResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *ListLocationAliasesResponse and nil error while calling ListLocationAliases. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	marshaler := &protojson.MarshalOptions{UseProtoNames: !s.jsonCamelCase, EmitUnpopulated: !s.jsonSkipDefaults}
+	respBytes, err := marshaler.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *chatServiceServer) serveListLocationAliasesProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "ListLocationAliases")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := io.ReadAll(req.Body)
+	if err != nil {
+		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
+		return
+	}
+	reqContent := new(ListLocationAliasesRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	handler := s.ChatService.ListLocationAliases
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *ListLocationAliasesRequest) (*ListLocationAliasesResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ListLocationAliasesRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ListLocationAliasesRequest) when calling interceptor")
+					}
+					return s.ChatService.ListLocationAliases(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ListLocationAliasesResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ListLocationAliasesResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *ListLocationAliasesResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *ListLocationAliasesResponse and nil error while calling ListLocationAliases. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *chatServiceServer) serveSetUserCalendar(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveSetUserCalendarJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveSetUserCalendarProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *chatServiceServer) serveSetUserCalendarJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "SetUserCalendar")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	d := json.NewDecoder(req.Body)
+	rawReqBody := json.RawMessage{}
+	if err := d.Decode(&rawReqBody); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+	reqContent := new(SetUserCalendarRequest)
+	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
+	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+
+	handler := s.ChatService.SetUserCalendar
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *SetUserCalendarRequest) (*SetUserCalendarResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*SetUserCalendarRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*SetUserCalendarRequest) when calling interceptor")
+					}
+					return s.ChatService.SetUserCalendar(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*SetUserCalendarResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*SetUserCalendarResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *SetUserCalendarResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *SetUserCalendarResponse and nil error while calling SetUserCalendar. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	marshaler := &protojson.MarshalOptions{UseProtoNames: !s.jsonCamelCase, EmitUnpopulated: !s.jsonSkipDefaults}
+	respBytes, err := marshaler.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *chatServiceServer) serveSetUserCalendarProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "SetUserCalendar")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := io.ReadAll(req.Body)
+	if err != nil {
+		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
+		return
+	}
+	reqContent := new(SetUserCalendarRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	handler := s.ChatService.SetUserCalendar
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *SetUserCalendarRequest) (*SetUserCalendarResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*SetUserCalendarRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*SetUserCalendarRequest) when calling interceptor")
+					}
+					return s.ChatService.SetUserCalendar(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*SetUserCalendarResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*SetUserCalendarResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *SetUserCalendarResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *SetUserCalendarResponse and nil error while calling SetUserCalendar. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *chatServiceServer) serveDeleteUserCalendar(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveDeleteUserCalendarJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveDeleteUserCalendarProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *chatServiceServer) serveDeleteUserCalendarJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "DeleteUserCalendar")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	d := json.NewDecoder(req.Body)
+	rawReqBody := json.RawMessage{}
+	if err := d.Decode(&rawReqBody); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+	reqContent := new(DeleteUserCalendarRequest)
+	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
+	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+
+	handler := s.ChatService.DeleteUserCalendar
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *DeleteUserCalendarRequest) (*DeleteUserCalendarResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*DeleteUserCalendarRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*DeleteUserCalendarRequest) when calling interceptor")
+					}
+					return s.ChatService.DeleteUserCalendar(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*DeleteUserCalendarResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*DeleteUserCalendarResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *DeleteUserCalendarResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *DeleteUserCalendarResponse and nil error while calling DeleteUserCalendar. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	marshaler := &protojson.MarshalOptions{UseProtoNames: !s.jsonCamelCase, EmitUnpopulated: !s.jsonSkipDefaults}
+	respBytes, err := marshaler.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *chatServiceServer) serveDeleteUserCalendarProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "DeleteUserCalendar")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := io.ReadAll(req.Body)
+	if err != nil {
+		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
+		return
+	}
+	reqContent := new(DeleteUserCalendarRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	handler := s.ChatService.DeleteUserCalendar
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *DeleteUserCalendarRequest) (*DeleteUserCalendarResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*DeleteUserCalendarRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*DeleteUserCalendarRequest) when calling interceptor")
+					}
+					return s.ChatService.DeleteUserCalendar(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*DeleteUserCalendarResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*DeleteUserCalendarResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *DeleteUserCalendarResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *DeleteUserCalendarResponse and nil error while calling DeleteUserCalendar. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *chatServiceServer) serveListUserCalendars(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveListUserCalendarsJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveListUserCalendarsProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *chatServiceServer) serveListUserCalendarsJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "ListUserCalendars")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	d := json.NewDecoder(req.Body)
+	rawReqBody := json.RawMessage{}
+	if err := d.Decode(&rawReqBody); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+	reqContent := new(ListUserCalendarsRequest)
+	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
+	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+
+	handler := s.ChatService.ListUserCalendars
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *ListUserCalendarsRequest) (*ListUserCalendarsResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ListUserCalendarsRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ListUserCalendarsRequest) when calling interceptor")
+					}
+					return s.ChatService.ListUserCalendars(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ListUserCalendarsResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ListUserCalendarsResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *ListUserCalendarsResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *ListUserCalendarsResponse and nil error while calling ListUserCalendars. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	marshaler := &protojson.MarshalOptions{UseProtoNames: !s.jsonCamelCase, EmitUnpopulated: !s.jsonSkipDefaults}
+	respBytes, err := marshaler.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *chatServiceServer) serveListUserCalendarsProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "ListUserCalendars")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := io.ReadAll(req.Body)
+	if err != nil {
+		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
+		return
+	}
+	reqContent := new(ListUserCalendarsRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	handler := s.ChatService.ListUserCalendars
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *ListUserCalendarsRequest) (*ListUserCalendarsResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ListUserCalendarsRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ListUserCalendarsRequest) when calling interceptor")
+					}
+					return s.ChatService.ListUserCalendars(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ListUserCalendarsResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ListUserCalendarsResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *ListUserCalendarsResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *ListUserCalendarsResponse and nil error while calling ListUserCalendars. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *chatServiceServer) serveSetCalDAVAccount(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveSetCalDAVAccountJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveSetCalDAVAccountProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *chatServiceServer) serveSetCalDAVAccountJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "SetCalDAVAccount")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	d := json.NewDecoder(req.Body)
+	rawReqBody := json.RawMessage{}
+	if err := d.Decode(&rawReqBody); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+	reqContent := new(SetCalDAVAccountRequest)
+	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
+	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+
+	handler := s.ChatService.SetCalDAVAccount
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *SetCalDAVAccountRequest) (*SetCalDAVAccountResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*SetCalDAVAccountRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*SetCalDAVAccountRequest) when calling interceptor")
+					}
+					return s.ChatService.SetCalDAVAccount(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*SetCalDAVAccountResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*SetCalDAVAccountResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *SetCalDAVAccountResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *SetCalDAVAccountResponse and nil error while calling SetCalDAVAccount. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	marshaler := &protojson.MarshalOptions{UseProtoNames: !s.jsonCamelCase, EmitUnpopulated: !s.jsonSkipDefaults}
+	respBytes, err := marshaler.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *chatServiceServer) serveSetCalDAVAccountProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "SetCalDAVAccount")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := io.ReadAll(req.Body)
+	if err != nil {
+		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
+		return
+	}
+	reqContent := new(SetCalDAVAccountRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	handler := s.ChatService.SetCalDAVAccount
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *SetCalDAVAccountRequest) (*SetCalDAVAccountResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*SetCalDAVAccountRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*SetCalDAVAccountRequest) when calling interceptor")
+					}
+					return s.ChatService.SetCalDAVAccount(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*SetCalDAVAccountResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*SetCalDAVAccountResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *SetCalDAVAccountResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *SetCalDAVAccountResponse and nil error while calling SetCalDAVAccount. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *chatServiceServer) serveDeleteCalDAVAccount(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveDeleteCalDAVAccountJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveDeleteCalDAVAccountProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *chatServiceServer) serveDeleteCalDAVAccountJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "DeleteCalDAVAccount")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	d := json.NewDecoder(req.Body)
+	rawReqBody := json.RawMessage{}
+	if err := d.Decode(&rawReqBody); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+	reqContent := new(DeleteCalDAVAccountRequest)
+	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
+	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+
+	handler := s.ChatService.DeleteCalDAVAccount
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *DeleteCalDAVAccountRequest) (*DeleteCalDAVAccountResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*DeleteCalDAVAccountRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*DeleteCalDAVAccountRequest) when calling interceptor")
+					}
+					return s.ChatService.DeleteCalDAVAccount(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*DeleteCalDAVAccountResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*DeleteCalDAVAccountResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *DeleteCalDAVAccountResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *DeleteCalDAVAccountResponse and nil error while calling DeleteCalDAVAccount. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	marshaler := &protojson.MarshalOptions{UseProtoNames: !s.jsonCamelCase, EmitUnpopulated: !s.jsonSkipDefaults}
+	respBytes, err := marshaler.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *chatServiceServer) serveDeleteCalDAVAccountProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "DeleteCalDAVAccount")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := io.ReadAll(req.Body)
+	if err != nil {
+		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
+		return
+	}
+	reqContent := new(DeleteCalDAVAccountRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	handler := s.ChatService.DeleteCalDAVAccount
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *DeleteCalDAVAccountRequest) (*DeleteCalDAVAccountResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*DeleteCalDAVAccountRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*DeleteCalDAVAccountRequest) when calling interceptor")
+					}
+					return s.ChatService.DeleteCalDAVAccount(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*DeleteCalDAVAccountResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*DeleteCalDAVAccountResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *DeleteCalDAVAccountResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *DeleteCalDAVAccountResponse and nil error while calling DeleteCalDAVAccount. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *chatServiceServer) serveBulkDeleteConversations(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveBulkDeleteConversationsJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveBulkDeleteConversationsProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *chatServiceServer) serveBulkDeleteConversationsJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "BulkDeleteConversations")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	d := json.NewDecoder(req.Body)
+	rawReqBody := json.RawMessage{}
+	if err := d.Decode(&rawReqBody); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+	reqContent := new(BulkDeleteConversationsRequest)
+	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
+	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+
+	handler := s.ChatService.BulkDeleteConversations
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *BulkDeleteConversationsRequest) (*BulkDeleteConversationsResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*BulkDeleteConversationsRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*BulkDeleteConversationsRequest) when calling interceptor")
+					}
+					return s.ChatService.BulkDeleteConversations(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*BulkDeleteConversationsResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*BulkDeleteConversationsResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *BulkDeleteConversationsResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *BulkDeleteConversationsResponse and nil error while calling BulkDeleteConversations. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	marshaler := &protojson.MarshalOptions{UseProtoNames: !s.jsonCamelCase, EmitUnpopulated: !s.jsonSkipDefaults}
+	respBytes, err := marshaler.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *chatServiceServer) serveBulkDeleteConversationsProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "BulkDeleteConversations")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := io.ReadAll(req.Body)
+	if err != nil {
+		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
+		return
+	}
+	reqContent := new(BulkDeleteConversationsRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	handler := s.ChatService.BulkDeleteConversations
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *BulkDeleteConversationsRequest) (*BulkDeleteConversationsResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*BulkDeleteConversationsRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*BulkDeleteConversationsRequest) when calling interceptor")
+					}
+					return s.ChatService.BulkDeleteConversations(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*BulkDeleteConversationsResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*BulkDeleteConversationsResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *BulkDeleteConversationsResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *BulkDeleteConversationsResponse and nil error while calling BulkDeleteConversations. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *chatServiceServer) serveBulkArchiveConversations(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveBulkArchiveConversationsJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveBulkArchiveConversationsProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *chatServiceServer) serveBulkArchiveConversationsJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "BulkArchiveConversations")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	d := json.NewDecoder(req.Body)
+	rawReqBody := json.RawMessage{}
+	if err := d.Decode(&rawReqBody); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+	reqContent := new(BulkArchiveConversationsRequest)
+	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
+	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+
+	handler := s.ChatService.BulkArchiveConversations
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *BulkArchiveConversationsRequest) (*BulkArchiveConversationsResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*BulkArchiveConversationsRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*BulkArchiveConversationsRequest) when calling interceptor")
+					}
+					return s.ChatService.BulkArchiveConversations(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*BulkArchiveConversationsResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*BulkArchiveConversationsResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *BulkArchiveConversationsResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *BulkArchiveConversationsResponse and nil error while calling BulkArchiveConversations. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	marshaler := &protojson.MarshalOptions{UseProtoNames: !s.jsonCamelCase, EmitUnpopulated: !s.jsonSkipDefaults}
+	respBytes, err := marshaler.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *chatServiceServer) serveBulkArchiveConversationsProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "BulkArchiveConversations")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := io.ReadAll(req.Body)
+	if err != nil {
+		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
+		return
+	}
+	reqContent := new(BulkArchiveConversationsRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	handler := s.ChatService.BulkArchiveConversations
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *BulkArchiveConversationsRequest) (*BulkArchiveConversationsResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*BulkArchiveConversationsRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*BulkArchiveConversationsRequest) when calling interceptor")
+					}
+					return s.ChatService.BulkArchiveConversations(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*BulkArchiveConversationsResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*BulkArchiveConversationsResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *BulkArchiveConversationsResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *BulkArchiveConversationsResponse and nil error while calling BulkArchiveConversations. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *chatServiceServer) serveGetBulkJob(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveGetBulkJobJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveGetBulkJobProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *chatServiceServer) serveGetBulkJobJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "GetBulkJob")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	d := json.NewDecoder(req.Body)
+	rawReqBody := json.RawMessage{}
+	if err := d.Decode(&rawReqBody); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+	reqContent := new(GetBulkJobRequest)
+	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
+	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+
+	handler := s.ChatService.GetBulkJob
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *GetBulkJobRequest) (*GetBulkJobResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*GetBulkJobRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*GetBulkJobRequest) when calling interceptor")
+					}
+					return s.ChatService.GetBulkJob(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*GetBulkJobResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*GetBulkJobResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *GetBulkJobResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *GetBulkJobResponse and nil error while calling GetBulkJob. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	marshaler := &protojson.MarshalOptions{UseProtoNames: !s.jsonCamelCase, EmitUnpopulated: !s.jsonSkipDefaults}
+	respBytes, err := marshaler.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *chatServiceServer) serveGetBulkJobProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "GetBulkJob")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := io.ReadAll(req.Body)
+	if err != nil {
+		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
+		return
+	}
+	reqContent := new(GetBulkJobRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	handler := s.ChatService.GetBulkJob
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *GetBulkJobRequest) (*GetBulkJobResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*GetBulkJobRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*GetBulkJobRequest) when calling interceptor")
+					}
+					return s.ChatService.GetBulkJob(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*GetBulkJobResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*GetBulkJobResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *GetBulkJobResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *GetBulkJobResponse and nil error while calling GetBulkJob. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *chatServiceServer) serveRequestExportArchive(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveRequestExportArchiveJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveRequestExportArchiveProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *chatServiceServer) serveRequestExportArchiveJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "RequestExportArchive")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	d := json.NewDecoder(req.Body)
+	rawReqBody := json.RawMessage{}
+	if err := d.Decode(&rawReqBody); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+	reqContent := new(RequestExportArchiveRequest)
+	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
+	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+
+	handler := s.ChatService.RequestExportArchive
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *RequestExportArchiveRequest) (*RequestExportArchiveResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*RequestExportArchiveRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*RequestExportArchiveRequest) when calling interceptor")
+					}
+					return s.ChatService.RequestExportArchive(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*RequestExportArchiveResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*RequestExportArchiveResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *RequestExportArchiveResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *RequestExportArchiveResponse and nil error while calling RequestExportArchive. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	marshaler := &protojson.MarshalOptions{UseProtoNames: !s.jsonCamelCase, EmitUnpopulated: !s.jsonSkipDefaults}
+	respBytes, err := marshaler.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *chatServiceServer) serveRequestExportArchiveProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "RequestExportArchive")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := io.ReadAll(req.Body)
+	if err != nil {
+		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
+		return
+	}
+	reqContent := new(RequestExportArchiveRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	handler := s.ChatService.RequestExportArchive
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *RequestExportArchiveRequest) (*RequestExportArchiveResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*RequestExportArchiveRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*RequestExportArchiveRequest) when calling interceptor")
+					}
+					return s.ChatService.RequestExportArchive(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*RequestExportArchiveResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*RequestExportArchiveResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *RequestExportArchiveResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *RequestExportArchiveResponse and nil error while calling RequestExportArchive. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *chatServiceServer) serveExportConversation(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveExportConversationJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveExportConversationProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *chatServiceServer) serveExportConversationJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "ExportConversation")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	d := json.NewDecoder(req.Body)
+	rawReqBody := json.RawMessage{}
+	if err := d.Decode(&rawReqBody); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+	reqContent := new(ExportConversationRequest)
+	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
+	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+
+	handler := s.ChatService.ExportConversation
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *ExportConversationRequest) (*ExportConversationResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ExportConversationRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ExportConversationRequest) when calling interceptor")
+					}
+					return s.ChatService.ExportConversation(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ExportConversationResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ExportConversationResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *ExportConversationResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *ExportConversationResponse and nil error while calling ExportConversation. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	marshaler := &protojson.MarshalOptions{UseProtoNames: !s.jsonCamelCase, EmitUnpopulated: !s.jsonSkipDefaults}
+	respBytes, err := marshaler.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *chatServiceServer) serveExportConversationProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "ExportConversation")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := io.ReadAll(req.Body)
+	if err != nil {
+		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
+		return
+	}
+	reqContent := new(ExportConversationRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	handler := s.ChatService.ExportConversation
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *ExportConversationRequest) (*ExportConversationResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ExportConversationRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ExportConversationRequest) when calling interceptor")
+					}
+					return s.ChatService.ExportConversation(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ExportConversationResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ExportConversationResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *ExportConversationResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *ExportConversationResponse and nil error while calling ExportConversation. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *chatServiceServer) servePinMessage(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.servePinMessageJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.servePinMessageProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *chatServiceServer) servePinMessageJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "PinMessage")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	d := json.NewDecoder(req.Body)
+	rawReqBody := json.RawMessage{}
+	if err := d.Decode(&rawReqBody); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+	reqContent := new(PinMessageRequest)
+	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
+	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+
+	handler := s.ChatService.PinMessage
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *PinMessageRequest) (*PinMessageResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*PinMessageRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*PinMessageRequest) when calling interceptor")
+					}
+					return s.ChatService.PinMessage(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*PinMessageResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*PinMessageResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *PinMessageResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *PinMessageResponse and nil error while calling PinMessage. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	marshaler := &protojson.MarshalOptions{UseProtoNames: !s.jsonCamelCase, EmitUnpopulated: !s.jsonSkipDefaults}
+	respBytes, err := marshaler.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *chatServiceServer) servePinMessageProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "PinMessage")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := io.ReadAll(req.Body)
+	if err != nil {
+		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
+		return
+	}
+	reqContent := new(PinMessageRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	handler := s.ChatService.PinMessage
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *PinMessageRequest) (*PinMessageResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*PinMessageRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*PinMessageRequest) when calling interceptor")
+					}
+					return s.ChatService.PinMessage(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*PinMessageResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*PinMessageResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *PinMessageResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *PinMessageResponse and nil error while calling PinMessage. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *chatServiceServer) serveListPinnedMessages(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveListPinnedMessagesJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveListPinnedMessagesProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *chatServiceServer) serveListPinnedMessagesJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "ListPinnedMessages")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	d := json.NewDecoder(req.Body)
+	rawReqBody := json.RawMessage{}
+	if err := d.Decode(&rawReqBody); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+	reqContent := new(ListPinnedMessagesRequest)
+	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
+	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+
+	handler := s.ChatService.ListPinnedMessages
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *ListPinnedMessagesRequest) (*ListPinnedMessagesResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ListPinnedMessagesRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ListPinnedMessagesRequest) when calling interceptor")
+					}
+					return s.ChatService.ListPinnedMessages(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ListPinnedMessagesResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ListPinnedMessagesResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *ListPinnedMessagesResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *ListPinnedMessagesResponse and nil error while calling ListPinnedMessages. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	marshaler := &protojson.MarshalOptions{UseProtoNames: !s.jsonCamelCase, EmitUnpopulated: !s.jsonSkipDefaults}
+	respBytes, err := marshaler.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *chatServiceServer) serveListPinnedMessagesProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "ListPinnedMessages")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := io.ReadAll(req.Body)
+	if err != nil {
+		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
+		return
+	}
+	reqContent := new(ListPinnedMessagesRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	handler := s.ChatService.ListPinnedMessages
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *ListPinnedMessagesRequest) (*ListPinnedMessagesResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ListPinnedMessagesRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ListPinnedMessagesRequest) when calling interceptor")
+					}
+					return s.ChatService.ListPinnedMessages(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ListPinnedMessagesResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ListPinnedMessagesResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *ListPinnedMessagesResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *ListPinnedMessagesResponse and nil error while calling ListPinnedMessages. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *chatServiceServer) serveRateMessage(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveRateMessageJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveRateMessageProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *chatServiceServer) serveRateMessageJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "RateMessage")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	d := json.NewDecoder(req.Body)
+	rawReqBody := json.RawMessage{}
+	if err := d.Decode(&rawReqBody); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+	reqContent := new(RateMessageRequest)
+	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
+	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+
+	handler := s.ChatService.RateMessage
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *RateMessageRequest) (*RateMessageResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*RateMessageRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*RateMessageRequest) when calling interceptor")
+					}
+					return s.ChatService.RateMessage(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*RateMessageResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*RateMessageResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *RateMessageResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *RateMessageResponse and nil error while calling RateMessage. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	marshaler := &protojson.MarshalOptions{UseProtoNames: !s.jsonCamelCase, EmitUnpopulated: !s.jsonSkipDefaults}
+	respBytes, err := marshaler.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *chatServiceServer) serveRateMessageProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "RateMessage")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := io.ReadAll(req.Body)
+	if err != nil {
+		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
+		return
+	}
+	reqContent := new(RateMessageRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	handler := s.ChatService.RateMessage
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *RateMessageRequest) (*RateMessageResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*RateMessageRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*RateMessageRequest) when calling interceptor")
+					}
+					return s.ChatService.RateMessage(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*RateMessageResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*RateMessageResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *RateMessageResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *RateMessageResponse and nil error while calling RateMessage. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *chatServiceServer) serveSetDigestSubscription(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveSetDigestSubscriptionJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveSetDigestSubscriptionProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *chatServiceServer) serveSetDigestSubscriptionJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "SetDigestSubscription")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	d := json.NewDecoder(req.Body)
+	rawReqBody := json.RawMessage{}
+	if err := d.Decode(&rawReqBody); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+	reqContent := new(SetDigestSubscriptionRequest)
+	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
+	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+
+	handler := s.ChatService.SetDigestSubscription
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *SetDigestSubscriptionRequest) (*SetDigestSubscriptionResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*SetDigestSubscriptionRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*SetDigestSubscriptionRequest) when calling interceptor")
+					}
+					return s.ChatService.SetDigestSubscription(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*SetDigestSubscriptionResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*SetDigestSubscriptionResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *SetDigestSubscriptionResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *SetDigestSubscriptionResponse and nil error while calling SetDigestSubscription. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	marshaler := &protojson.MarshalOptions{UseProtoNames: !s.jsonCamelCase, EmitUnpopulated: !s.jsonSkipDefaults}
+	respBytes, err := marshaler.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *chatServiceServer) serveSetDigestSubscriptionProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "SetDigestSubscription")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := io.ReadAll(req.Body)
+	if err != nil {
+		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
+		return
+	}
+	reqContent := new(SetDigestSubscriptionRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	handler := s.ChatService.SetDigestSubscription
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *SetDigestSubscriptionRequest) (*SetDigestSubscriptionResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*SetDigestSubscriptionRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*SetDigestSubscriptionRequest) when calling interceptor")
+					}
+					return s.ChatService.SetDigestSubscription(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*SetDigestSubscriptionResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*SetDigestSubscriptionResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *SetDigestSubscriptionResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *SetDigestSubscriptionResponse and nil error while calling SetDigestSubscription. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *chatServiceServer) serveGetDigestSubscription(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveGetDigestSubscriptionJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveGetDigestSubscriptionProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *chatServiceServer) serveGetDigestSubscriptionJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "GetDigestSubscription")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	d := json.NewDecoder(req.Body)
+	rawReqBody := json.RawMessage{}
+	if err := d.Decode(&rawReqBody); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+	reqContent := new(GetDigestSubscriptionRequest)
+	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
+	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+
+	handler := s.ChatService.GetDigestSubscription
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *GetDigestSubscriptionRequest) (*GetDigestSubscriptionResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*GetDigestSubscriptionRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*GetDigestSubscriptionRequest) when calling interceptor")
+					}
+					return s.ChatService.GetDigestSubscription(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*GetDigestSubscriptionResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*GetDigestSubscriptionResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *GetDigestSubscriptionResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *GetDigestSubscriptionResponse and nil error while calling GetDigestSubscription. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	marshaler := &protojson.MarshalOptions{UseProtoNames: !s.jsonCamelCase, EmitUnpopulated: !s.jsonSkipDefaults}
+	respBytes, err := marshaler.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *chatServiceServer) serveGetDigestSubscriptionProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "GetDigestSubscription")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := io.ReadAll(req.Body)
+	if err != nil {
+		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
+		return
+	}
+	reqContent := new(GetDigestSubscriptionRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	handler := s.ChatService.GetDigestSubscription
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *GetDigestSubscriptionRequest) (*GetDigestSubscriptionResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*GetDigestSubscriptionRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*GetDigestSubscriptionRequest) when calling interceptor")
+					}
+					return s.ChatService.GetDigestSubscription(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*GetDigestSubscriptionResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*GetDigestSubscriptionResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *GetDigestSubscriptionResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *GetDigestSubscriptionResponse and nil error while calling GetDigestSubscription. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *chatServiceServer) serveSetPreferences(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveSetPreferencesJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveSetPreferencesProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *chatServiceServer) serveSetPreferencesJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "SetPreferences")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	d := json.NewDecoder(req.Body)
+	rawReqBody := json.RawMessage{}
+	if err := d.Decode(&rawReqBody); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+	reqContent := new(SetPreferencesRequest)
+	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
+	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+
+	handler := s.ChatService.SetPreferences
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *SetPreferencesRequest) (*SetPreferencesResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*SetPreferencesRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*SetPreferencesRequest) when calling interceptor")
+					}
+					return s.ChatService.SetPreferences(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*SetPreferencesResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*SetPreferencesResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *SetPreferencesResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *SetPreferencesResponse and nil error while calling SetPreferences. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	marshaler := &protojson.MarshalOptions{UseProtoNames: !s.jsonCamelCase, EmitUnpopulated: !s.jsonSkipDefaults}
+	respBytes, err := marshaler.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *chatServiceServer) serveSetPreferencesProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "SetPreferences")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := io.ReadAll(req.Body)
+	if err != nil {
+		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
+		return
+	}
+	reqContent := new(SetPreferencesRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	handler := s.ChatService.SetPreferences
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *SetPreferencesRequest) (*SetPreferencesResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*SetPreferencesRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*SetPreferencesRequest) when calling interceptor")
+					}
+					return s.ChatService.SetPreferences(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*SetPreferencesResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*SetPreferencesResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *SetPreferencesResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *SetPreferencesResponse and nil error while calling SetPreferences. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *chatServiceServer) serveGetPreferences(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveGetPreferencesJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveGetPreferencesProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *chatServiceServer) serveGetPreferencesJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "GetPreferences")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	d := json.NewDecoder(req.Body)
+	rawReqBody := json.RawMessage{}
+	if err := d.Decode(&rawReqBody); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+	reqContent := new(GetPreferencesRequest)
+	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
+	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+
+	handler := s.ChatService.GetPreferences
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *GetPreferencesRequest) (*GetPreferencesResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*GetPreferencesRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*GetPreferencesRequest) when calling interceptor")
+					}
+					return s.ChatService.GetPreferences(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*GetPreferencesResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*GetPreferencesResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *GetPreferencesResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *GetPreferencesResponse and nil error while calling GetPreferences. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	marshaler := &protojson.MarshalOptions{UseProtoNames: !s.jsonCamelCase, EmitUnpopulated: !s.jsonSkipDefaults}
+	respBytes, err := marshaler.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *chatServiceServer) serveGetPreferencesProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "GetPreferences")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := io.ReadAll(req.Body)
+	if err != nil {
+		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
+		return
+	}
+	reqContent := new(GetPreferencesRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	handler := s.ChatService.GetPreferences
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *GetPreferencesRequest) (*GetPreferencesResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*GetPreferencesRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*GetPreferencesRequest) when calling interceptor")
+					}
+					return s.ChatService.GetPreferences(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*GetPreferencesResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*GetPreferencesResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *GetPreferencesResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *GetPreferencesResponse and nil error while calling GetPreferences. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *chatServiceServer) serveSetNotificationSettings(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveSetNotificationSettingsJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveSetNotificationSettingsProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *chatServiceServer) serveSetNotificationSettingsJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "SetNotificationSettings")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	d := json.NewDecoder(req.Body)
+	rawReqBody := json.RawMessage{}
+	if err := d.Decode(&rawReqBody); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+	reqContent := new(SetNotificationSettingsRequest)
+	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
+	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+
+	handler := s.ChatService.SetNotificationSettings
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *SetNotificationSettingsRequest) (*SetNotificationSettingsResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*SetNotificationSettingsRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*SetNotificationSettingsRequest) when calling interceptor")
+					}
+					return s.ChatService.SetNotificationSettings(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*SetNotificationSettingsResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*SetNotificationSettingsResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *SetNotificationSettingsResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *SetNotificationSettingsResponse and nil error while calling SetNotificationSettings. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	marshaler := &protojson.MarshalOptions{UseProtoNames: !s.jsonCamelCase, EmitUnpopulated: !s.jsonSkipDefaults}
+	respBytes, err := marshaler.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *chatServiceServer) serveSetNotificationSettingsProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "SetNotificationSettings")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := io.ReadAll(req.Body)
+	if err != nil {
+		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
+		return
+	}
+	reqContent := new(SetNotificationSettingsRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	handler := s.ChatService.SetNotificationSettings
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *SetNotificationSettingsRequest) (*SetNotificationSettingsResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*SetNotificationSettingsRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*SetNotificationSettingsRequest) when calling interceptor")
+					}
+					return s.ChatService.SetNotificationSettings(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*SetNotificationSettingsResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*SetNotificationSettingsResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *SetNotificationSettingsResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *SetNotificationSettingsResponse and nil error while calling SetNotificationSettings. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *chatServiceServer) serveGetNotificationSettings(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveGetNotificationSettingsJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveGetNotificationSettingsProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *chatServiceServer) serveGetNotificationSettingsJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "GetNotificationSettings")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	d := json.NewDecoder(req.Body)
+	rawReqBody := json.RawMessage{}
+	if err := d.Decode(&rawReqBody); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+	reqContent := new(GetNotificationSettingsRequest)
+	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
+	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+
+	handler := s.ChatService.GetNotificationSettings
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *GetNotificationSettingsRequest) (*GetNotificationSettingsResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*GetNotificationSettingsRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*GetNotificationSettingsRequest) when calling interceptor")
+					}
+					return s.ChatService.GetNotificationSettings(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*GetNotificationSettingsResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*GetNotificationSettingsResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *GetNotificationSettingsResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *GetNotificationSettingsResponse and nil error while calling GetNotificationSettings. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	marshaler := &protojson.MarshalOptions{UseProtoNames: !s.jsonCamelCase, EmitUnpopulated: !s.jsonSkipDefaults}
+	respBytes, err := marshaler.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *chatServiceServer) serveGetNotificationSettingsProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "GetNotificationSettings")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := io.ReadAll(req.Body)
+	if err != nil {
+		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
+		return
+	}
+	reqContent := new(GetNotificationSettingsRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	handler := s.ChatService.GetNotificationSettings
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *GetNotificationSettingsRequest) (*GetNotificationSettingsResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*GetNotificationSettingsRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*GetNotificationSettingsRequest) when calling interceptor")
+					}
+					return s.ChatService.GetNotificationSettings(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*GetNotificationSettingsResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*GetNotificationSettingsResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *GetNotificationSettingsResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *GetNotificationSettingsResponse and nil error while calling GetNotificationSettings. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *chatServiceServer) serveRegisterPushDevice(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveRegisterPushDeviceJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveRegisterPushDeviceProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *chatServiceServer) serveRegisterPushDeviceJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "RegisterPushDevice")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	d := json.NewDecoder(req.Body)
+	rawReqBody := json.RawMessage{}
+	if err := d.Decode(&rawReqBody); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+	reqContent := new(RegisterPushDeviceRequest)
+	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
+	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+
+	handler := s.ChatService.RegisterPushDevice
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *RegisterPushDeviceRequest) (*RegisterPushDeviceResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*RegisterPushDeviceRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*RegisterPushDeviceRequest) when calling interceptor")
+					}
+					return s.ChatService.RegisterPushDevice(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*RegisterPushDeviceResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*RegisterPushDeviceResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *RegisterPushDeviceResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *RegisterPushDeviceResponse and nil error while calling RegisterPushDevice. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	marshaler := &protojson.MarshalOptions{UseProtoNames: !s.jsonCamelCase, EmitUnpopulated: !s.jsonSkipDefaults}
+	respBytes, err := marshaler.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *chatServiceServer) serveRegisterPushDeviceProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "RegisterPushDevice")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := io.ReadAll(req.Body)
+	if err != nil {
+		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
+		return
+	}
+	reqContent := new(RegisterPushDeviceRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	handler := s.ChatService.RegisterPushDevice
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *RegisterPushDeviceRequest) (*RegisterPushDeviceResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*RegisterPushDeviceRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*RegisterPushDeviceRequest) when calling interceptor")
+					}
+					return s.ChatService.RegisterPushDevice(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*RegisterPushDeviceResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*RegisterPushDeviceResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *RegisterPushDeviceResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *RegisterPushDeviceResponse and nil error while calling RegisterPushDevice. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *chatServiceServer) serveUnregisterPushDevice(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveUnregisterPushDeviceJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveUnregisterPushDeviceProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *chatServiceServer) serveUnregisterPushDeviceJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "UnregisterPushDevice")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	d := json.NewDecoder(req.Body)
+	rawReqBody := json.RawMessage{}
+	if err := d.Decode(&rawReqBody); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+	reqContent := new(UnregisterPushDeviceRequest)
+	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
+	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+
+	handler := s.ChatService.UnregisterPushDevice
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *UnregisterPushDeviceRequest) (*UnregisterPushDeviceResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*UnregisterPushDeviceRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*UnregisterPushDeviceRequest) when calling interceptor")
+					}
+					return s.ChatService.UnregisterPushDevice(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*UnregisterPushDeviceResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*UnregisterPushDeviceResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *UnregisterPushDeviceResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *UnregisterPushDeviceResponse and nil error while calling UnregisterPushDevice. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	marshaler := &protojson.MarshalOptions{UseProtoNames: !s.jsonCamelCase, EmitUnpopulated: !s.jsonSkipDefaults}
+	respBytes, err := marshaler.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
 
+func (s *chatServiceServer) serveUnregisterPushDeviceProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
 	var err error
-	ctx, err = callRequestReceived(ctx, s.hooks)
+	ctx = ctxsetters.WithMethodName(ctx, "UnregisterPushDevice")
+	ctx, err = callRequestRouted(ctx, s.hooks)
 	if err != nil {
 		s.writeError(ctx, resp, err)
 		return
 	}
 
-	if req.Method != "POST" {
-		msg := fmt.Sprintf("unsupported method %q (only POST is allowed)", req.Method)
-		s.writeError(ctx, resp, badRouteError(msg, req.Method, req.URL.Path))
+	buf, err := io.ReadAll(req.Body)
+	if err != nil {
+		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
+		return
+	}
+	reqContent := new(UnregisterPushDeviceRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
 		return
 	}
 
-	// Verify path format: [<prefix>]/<package>.<Service>/<Method>
-	prefix, pkgService, method := parseTwirpPath(req.URL.Path)
-	if pkgService != "acai.chat.ChatService" {
-		msg := fmt.Sprintf("no handler for path %q", req.URL.Path)
-		s.writeError(ctx, resp, badRouteError(msg, req.Method, req.URL.Path))
+	handler := s.ChatService.UnregisterPushDevice
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *UnregisterPushDeviceRequest) (*UnregisterPushDeviceResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*UnregisterPushDeviceRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*UnregisterPushDeviceRequest) when calling interceptor")
+					}
+					return s.ChatService.UnregisterPushDevice(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*UnregisterPushDeviceResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*UnregisterPushDeviceResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *UnregisterPushDeviceResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
 		return
 	}
-	if prefix != s.pathPrefix {
-		msg := fmt.Sprintf("invalid path prefix %q, expected %q, on path %q", prefix, s.pathPrefix, req.URL.Path)
-		s.writeError(ctx, resp, badRouteError(msg, req.Method, req.URL.Path))
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *UnregisterPushDeviceResponse and nil error while calling UnregisterPushDevice. nil responses are not supported"))
 		return
 	}
 
-	switch method {
-	case "StartConversation":
-		s.serveStartConversation(ctx, resp, req)
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
 		return
-	case "ContinueConversation":
-		s.serveContinueConversation(ctx, resp, req)
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *chatServiceServer) serveListPushDevices(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveListPushDevicesJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveListPushDevicesProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *chatServiceServer) serveListPushDevicesJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "ListPushDevices")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
 		return
-	case "ListConversations":
-		s.serveListConversations(ctx, resp, req)
+	}
+
+	d := json.NewDecoder(req.Body)
+	rawReqBody := json.RawMessage{}
+	if err := d.Decode(&rawReqBody); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
 		return
-	case "DescribeConversation":
-		s.serveDescribeConversation(ctx, resp, req)
+	}
+	reqContent := new(ListPushDevicesRequest)
+	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
+	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
 		return
-	default:
-		msg := fmt.Sprintf("no handler for path %q", req.URL.Path)
-		s.writeError(ctx, resp, badRouteError(msg, req.Method, req.URL.Path))
+	}
+
+	handler := s.ChatService.ListPushDevices
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *ListPushDevicesRequest) (*ListPushDevicesResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ListPushDevicesRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ListPushDevicesRequest) when calling interceptor")
+					}
+					return s.ChatService.ListPushDevices(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ListPushDevicesResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ListPushDevicesResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *ListPushDevicesResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *ListPushDevicesResponse and nil error while calling ListPushDevices. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	marshaler := &protojson.MarshalOptions{UseProtoNames: !s.jsonCamelCase, EmitUnpopulated: !s.jsonSkipDefaults}
+	respBytes, err := marshaler.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
 		return
 	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
 }
 
-func (s *chatServiceServer) serveStartConversation(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+func (s *chatServiceServer) serveListPushDevicesProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "ListPushDevices")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := io.ReadAll(req.Body)
+	if err != nil {
+		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
+		return
+	}
+	reqContent := new(ListPushDevicesRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	handler := s.ChatService.ListPushDevices
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *ListPushDevicesRequest) (*ListPushDevicesResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ListPushDevicesRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ListPushDevicesRequest) when calling interceptor")
+					}
+					return s.ChatService.ListPushDevices(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ListPushDevicesResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ListPushDevicesResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *ListPushDevicesResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *ListPushDevicesResponse and nil error while calling ListPushDevices. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *chatServiceServer) serveStartSession(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
 	header := req.Header.Get("Content-Type")
 	i := strings.Index(header, ";")
 	if i == -1 {
@@ -636,9 +10714,9 @@ func (s *chatServiceServer) serveStartConversation(ctx context.Context, resp htt
 	}
 	switch strings.TrimSpace(strings.ToLower(header[:i])) {
 	case "application/json":
-		s.serveStartConversationJSON(ctx, resp, req)
+		s.serveStartSessionJSON(ctx, resp, req)
 	case "application/protobuf":
-		s.serveStartConversationProtobuf(ctx, resp, req)
+		s.serveStartSessionProtobuf(ctx, resp, req)
 	default:
 		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
 		twerr := badRouteError(msg, req.Method, req.URL.Path)
@@ -646,9 +10724,9 @@ func (s *chatServiceServer) serveStartConversation(ctx context.Context, resp htt
 	}
 }
 
-func (s *chatServiceServer) serveStartConversationJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+func (s *chatServiceServer) serveStartSessionJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
 	var err error
-	ctx = ctxsetters.WithMethodName(ctx, "StartConversation")
+	ctx = ctxsetters.WithMethodName(ctx, "StartSession")
 	ctx, err = callRequestRouted(ctx, s.hooks)
 	if err != nil {
 		s.writeError(ctx, resp, err)
@@ -661,29 +10739,29 @@ func (s *chatServiceServer) serveStartConversationJSON(ctx context.Context, resp
 		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
 		return
 	}
-	reqContent := new(StartConversationRequest)
+	reqContent := new(StartSessionRequest)
 	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
 	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
 		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
 		return
 	}
 
-	handler := s.ChatService.StartConversation
+	handler := s.ChatService.StartSession
 	if s.interceptor != nil {
-		handler = func(ctx context.Context, req *StartConversationRequest) (*StartConversationResponse, error) {
+		handler = func(ctx context.Context, req *StartSessionRequest) (*StartSessionResponse, error) {
 			resp, err := s.interceptor(
 				func(ctx context.Context, req interface{}) (interface{}, error) {
-					typedReq, ok := req.(*StartConversationRequest)
+					typedReq, ok := req.(*StartSessionRequest)
 					if !ok {
-						return nil, twirp.InternalError("failed type assertion req.(*StartConversationRequest) when calling interceptor")
+						return nil, twirp.InternalError("failed type assertion req.(*StartSessionRequest) when calling interceptor")
 					}
-					return s.ChatService.StartConversation(ctx, typedReq)
+					return s.ChatService.StartSession(ctx, typedReq)
 				},
 			)(ctx, req)
 			if resp != nil {
-				typedResp, ok := resp.(*StartConversationResponse)
+				typedResp, ok := resp.(*StartSessionResponse)
 				if !ok {
-					return nil, twirp.InternalError("failed type assertion resp.(*StartConversationResponse) when calling interceptor")
+					return nil, twirp.InternalError("failed type assertion resp.(*StartSessionResponse) when calling interceptor")
 				}
 				return typedResp, err
 			}
@@ -692,7 +10770,7 @@ func (s *chatServiceServer) serveStartConversationJSON(ctx context.Context, resp
 	}
 
 	// Call service method
-	var respContent *StartConversationResponse
+	var respContent *StartSessionResponse
 	func() {
 		defer ensurePanicResponses(ctx, resp, s.hooks)
 		respContent, err = handler(ctx, reqContent)
@@ -703,7 +10781,7 @@ func (s *chatServiceServer) serveStartConversationJSON(ctx context.Context, resp
 		return
 	}
 	if respContent == nil {
-		s.writeError(ctx, resp, twirp.InternalError("received a nil *StartConversationResponse and nil error while calling StartConversation. nil responses are not supported"))
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *StartSessionResponse and nil error while calling StartSession. nil responses are not supported"))
 		return
 	}
 
@@ -729,9 +10807,9 @@ func (s *chatServiceServer) serveStartConversationJSON(ctx context.Context, resp
 	callResponseSent(ctx, s.hooks)
 }
 
-func (s *chatServiceServer) serveStartConversationProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+func (s *chatServiceServer) serveStartSessionProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
 	var err error
-	ctx = ctxsetters.WithMethodName(ctx, "StartConversation")
+	ctx = ctxsetters.WithMethodName(ctx, "StartSession")
 	ctx, err = callRequestRouted(ctx, s.hooks)
 	if err != nil {
 		s.writeError(ctx, resp, err)
@@ -743,28 +10821,28 @@ func (s *chatServiceServer) serveStartConversationProtobuf(ctx context.Context,
 		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
 		return
 	}
-	reqContent := new(StartConversationRequest)
+	reqContent := new(StartSessionRequest)
 	if err = proto.Unmarshal(buf, reqContent); err != nil {
 		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
 		return
 	}
 
-	handler := s.ChatService.StartConversation
+	handler := s.ChatService.StartSession
 	if s.interceptor != nil {
-		handler = func(ctx context.Context, req *StartConversationRequest) (*StartConversationResponse, error) {
+		handler = func(ctx context.Context, req *StartSessionRequest) (*StartSessionResponse, error) {
 			resp, err := s.interceptor(
 				func(ctx context.Context, req interface{}) (interface{}, error) {
-					typedReq, ok := req.(*StartConversationRequest)
+					typedReq, ok := req.(*StartSessionRequest)
 					if !ok {
-						return nil, twirp.InternalError("failed type assertion req.(*StartConversationRequest) when calling interceptor")
+						return nil, twirp.InternalError("failed type assertion req.(*StartSessionRequest) when calling interceptor")
 					}
-					return s.ChatService.StartConversation(ctx, typedReq)
+					return s.ChatService.StartSession(ctx, typedReq)
 				},
 			)(ctx, req)
 			if resp != nil {
-				typedResp, ok := resp.(*StartConversationResponse)
+				typedResp, ok := resp.(*StartSessionResponse)
 				if !ok {
-					return nil, twirp.InternalError("failed type assertion resp.(*StartConversationResponse) when calling interceptor")
+					return nil, twirp.InternalError("failed type assertion resp.(*StartSessionResponse) when calling interceptor")
 				}
 				return typedResp, err
 			}
@@ -773,7 +10851,7 @@ func (s *chatServiceServer) serveStartConversationProtobuf(ctx context.Context,
 	}
 
 	// Call service method
-	var respContent *StartConversationResponse
+	var respContent *StartSessionResponse
 	func() {
 		defer ensurePanicResponses(ctx, resp, s.hooks)
 		respContent, err = handler(ctx, reqContent)
@@ -784,7 +10862,7 @@ func (s *chatServiceServer) serveStartConversationProtobuf(ctx context.Context,
 		return
 	}
 	if respContent == nil {
-		s.writeError(ctx, resp, twirp.InternalError("received a nil *StartConversationResponse and nil error while calling StartConversation. nil responses are not supported"))
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *StartSessionResponse and nil error while calling StartSession. nil responses are not supported"))
 		return
 	}
 
@@ -808,7 +10886,7 @@ func (s *chatServiceServer) serveStartConversationProtobuf(ctx context.Context,
 	callResponseSent(ctx, s.hooks)
 }
 
-func (s *chatServiceServer) serveContinueConversation(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+func (s *chatServiceServer) serveListSessions(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
 	header := req.Header.Get("Content-Type")
 	i := strings.Index(header, ";")
 	if i == -1 {
@@ -816,9 +10894,9 @@ func (s *chatServiceServer) serveContinueConversation(ctx context.Context, resp
 	}
 	switch strings.TrimSpace(strings.ToLower(header[:i])) {
 	case "application/json":
-		s.serveContinueConversationJSON(ctx, resp, req)
+		s.serveListSessionsJSON(ctx, resp, req)
 	case "application/protobuf":
-		s.serveContinueConversationProtobuf(ctx, resp, req)
+		s.serveListSessionsProtobuf(ctx, resp, req)
 	default:
 		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
 		twerr := badRouteError(msg, req.Method, req.URL.Path)
@@ -826,9 +10904,9 @@ func (s *chatServiceServer) serveContinueConversation(ctx context.Context, resp
 	}
 }
 
-func (s *chatServiceServer) serveContinueConversationJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+func (s *chatServiceServer) serveListSessionsJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
 	var err error
-	ctx = ctxsetters.WithMethodName(ctx, "ContinueConversation")
+	ctx = ctxsetters.WithMethodName(ctx, "ListSessions")
 	ctx, err = callRequestRouted(ctx, s.hooks)
 	if err != nil {
 		s.writeError(ctx, resp, err)
@@ -841,29 +10919,29 @@ func (s *chatServiceServer) serveContinueConversationJSON(ctx context.Context, r
 		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
 		return
 	}
-	reqContent := new(ContinueConversationRequest)
+	reqContent := new(ListSessionsRequest)
 	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
 	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
 		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
 		return
 	}
 
-	handler := s.ChatService.ContinueConversation
+	handler := s.ChatService.ListSessions
 	if s.interceptor != nil {
-		handler = func(ctx context.Context, req *ContinueConversationRequest) (*ContinueConversationResponse, error) {
+		handler = func(ctx context.Context, req *ListSessionsRequest) (*ListSessionsResponse, error) {
 			resp, err := s.interceptor(
 				func(ctx context.Context, req interface{}) (interface{}, error) {
-					typedReq, ok := req.(*ContinueConversationRequest)
+					typedReq, ok := req.(*ListSessionsRequest)
 					if !ok {
-						return nil, twirp.InternalError("failed type assertion req.(*ContinueConversationRequest) when calling interceptor")
+						return nil, twirp.InternalError("failed type assertion req.(*ListSessionsRequest) when calling interceptor")
 					}
-					return s.ChatService.ContinueConversation(ctx, typedReq)
+					return s.ChatService.ListSessions(ctx, typedReq)
 				},
 			)(ctx, req)
 			if resp != nil {
-				typedResp, ok := resp.(*ContinueConversationResponse)
+				typedResp, ok := resp.(*ListSessionsResponse)
 				if !ok {
-					return nil, twirp.InternalError("failed type assertion resp.(*ContinueConversationResponse) when calling interceptor")
+					return nil, twirp.InternalError("failed type assertion resp.(*ListSessionsResponse) when calling interceptor")
 				}
 				return typedResp, err
 			}
@@ -872,7 +10950,7 @@ func (s *chatServiceServer) serveContinueConversationJSON(ctx context.Context, r
 	}
 
 	// Call service method
-	var respContent *ContinueConversationResponse
+	var respContent *ListSessionsResponse
 	func() {
 		defer ensurePanicResponses(ctx, resp, s.hooks)
 		respContent, err = handler(ctx, reqContent)
@@ -883,7 +10961,7 @@ func (s *chatServiceServer) serveContinueConversationJSON(ctx context.Context, r
 		return
 	}
 	if respContent == nil {
-		s.writeError(ctx, resp, twirp.InternalError("received a nil *ContinueConversationResponse and nil error while calling ContinueConversation. nil responses are not supported"))
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *ListSessionsResponse and nil error while calling ListSessions. nil responses are not supported"))
 		return
 	}
 
@@ -909,9 +10987,9 @@ func (s *chatServiceServer) serveContinueConversationJSON(ctx context.Context, r
 	callResponseSent(ctx, s.hooks)
 }
 
-func (s *chatServiceServer) serveContinueConversationProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+func (s *chatServiceServer) serveListSessionsProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
 	var err error
-	ctx = ctxsetters.WithMethodName(ctx, "ContinueConversation")
+	ctx = ctxsetters.WithMethodName(ctx, "ListSessions")
 	ctx, err = callRequestRouted(ctx, s.hooks)
 	if err != nil {
 		s.writeError(ctx, resp, err)
@@ -923,28 +11001,28 @@ func (s *chatServiceServer) serveContinueConversationProtobuf(ctx context.Contex
 		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
 		return
 	}
-	reqContent := new(ContinueConversationRequest)
+	reqContent := new(ListSessionsRequest)
 	if err = proto.Unmarshal(buf, reqContent); err != nil {
 		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
 		return
 	}
 
-	handler := s.ChatService.ContinueConversation
+	handler := s.ChatService.ListSessions
 	if s.interceptor != nil {
-		handler = func(ctx context.Context, req *ContinueConversationRequest) (*ContinueConversationResponse, error) {
+		handler = func(ctx context.Context, req *ListSessionsRequest) (*ListSessionsResponse, error) {
 			resp, err := s.interceptor(
 				func(ctx context.Context, req interface{}) (interface{}, error) {
-					typedReq, ok := req.(*ContinueConversationRequest)
+					typedReq, ok := req.(*ListSessionsRequest)
 					if !ok {
-						return nil, twirp.InternalError("failed type assertion req.(*ContinueConversationRequest) when calling interceptor")
+						return nil, twirp.InternalError("failed type assertion req.(*ListSessionsRequest) when calling interceptor")
 					}
-					return s.ChatService.ContinueConversation(ctx, typedReq)
+					return s.ChatService.ListSessions(ctx, typedReq)
 				},
 			)(ctx, req)
 			if resp != nil {
-				typedResp, ok := resp.(*ContinueConversationResponse)
+				typedResp, ok := resp.(*ListSessionsResponse)
 				if !ok {
-					return nil, twirp.InternalError("failed type assertion resp.(*ContinueConversationResponse) when calling interceptor")
+					return nil, twirp.InternalError("failed type assertion resp.(*ListSessionsResponse) when calling interceptor")
 				}
 				return typedResp, err
 			}
@@ -953,7 +11031,7 @@ func (s *chatServiceServer) serveContinueConversationProtobuf(ctx context.Contex
 	}
 
 	// Call service method
-	var respContent *ContinueConversationResponse
+	var respContent *ListSessionsResponse
 	func() {
 		defer ensurePanicResponses(ctx, resp, s.hooks)
 		respContent, err = handler(ctx, reqContent)
@@ -964,7 +11042,7 @@ func (s *chatServiceServer) serveContinueConversationProtobuf(ctx context.Contex
 		return
 	}
 	if respContent == nil {
-		s.writeError(ctx, resp, twirp.InternalError("received a nil *ContinueConversationResponse and nil error while calling ContinueConversation. nil responses are not supported"))
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *ListSessionsResponse and nil error while calling ListSessions. nil responses are not supported"))
 		return
 	}
 
@@ -988,7 +11066,7 @@ func (s *chatServiceServer) serveContinueConversationProtobuf(ctx context.Contex
 	callResponseSent(ctx, s.hooks)
 }
 
-func (s *chatServiceServer) serveListConversations(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+func (s *chatServiceServer) serveRevokeSession(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
 	header := req.Header.Get("Content-Type")
 	i := strings.Index(header, ";")
 	if i == -1 {
@@ -996,9 +11074,9 @@ func (s *chatServiceServer) serveListConversations(ctx context.Context, resp htt
 	}
 	switch strings.TrimSpace(strings.ToLower(header[:i])) {
 	case "application/json":
-		s.serveListConversationsJSON(ctx, resp, req)
+		s.serveRevokeSessionJSON(ctx, resp, req)
 	case "application/protobuf":
-		s.serveListConversationsProtobuf(ctx, resp, req)
+		s.serveRevokeSessionProtobuf(ctx, resp, req)
 	default:
 		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
 		twerr := badRouteError(msg, req.Method, req.URL.Path)
@@ -1006,9 +11084,9 @@ func (s *chatServiceServer) serveListConversations(ctx context.Context, resp htt
 	}
 }
 
-func (s *chatServiceServer) serveListConversationsJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+func (s *chatServiceServer) serveRevokeSessionJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
 	var err error
-	ctx = ctxsetters.WithMethodName(ctx, "ListConversations")
+	ctx = ctxsetters.WithMethodName(ctx, "RevokeSession")
 	ctx, err = callRequestRouted(ctx, s.hooks)
 	if err != nil {
 		s.writeError(ctx, resp, err)
@@ -1021,29 +11099,29 @@ func (s *chatServiceServer) serveListConversationsJSON(ctx context.Context, resp
 		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
 		return
 	}
-	reqContent := new(ListConversationsRequest)
+	reqContent := new(RevokeSessionRequest)
 	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
 	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
 		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
 		return
 	}
 
-	handler := s.ChatService.ListConversations
+	handler := s.ChatService.RevokeSession
 	if s.interceptor != nil {
-		handler = func(ctx context.Context, req *ListConversationsRequest) (*ListConversationsResponse, error) {
+		handler = func(ctx context.Context, req *RevokeSessionRequest) (*RevokeSessionResponse, error) {
 			resp, err := s.interceptor(
 				func(ctx context.Context, req interface{}) (interface{}, error) {
-					typedReq, ok := req.(*ListConversationsRequest)
+					typedReq, ok := req.(*RevokeSessionRequest)
 					if !ok {
-						return nil, twirp.InternalError("failed type assertion req.(*ListConversationsRequest) when calling interceptor")
+						return nil, twirp.InternalError("failed type assertion req.(*RevokeSessionRequest) when calling interceptor")
 					}
-					return s.ChatService.ListConversations(ctx, typedReq)
+					return s.ChatService.RevokeSession(ctx, typedReq)
 				},
 			)(ctx, req)
 			if resp != nil {
-				typedResp, ok := resp.(*ListConversationsResponse)
+				typedResp, ok := resp.(*RevokeSessionResponse)
 				if !ok {
-					return nil, twirp.InternalError("failed type assertion resp.(*ListConversationsResponse) when calling interceptor")
+					return nil, twirp.InternalError("failed type assertion resp.(*RevokeSessionResponse) when calling interceptor")
 				}
 				return typedResp, err
 			}
@@ -1052,7 +11130,7 @@ func (s *chatServiceServer) serveListConversationsJSON(ctx context.Context, resp
 	}
 
 	// Call service method
-	var respContent *ListConversationsResponse
+	var respContent *RevokeSessionResponse
 	func() {
 		defer ensurePanicResponses(ctx, resp, s.hooks)
 		respContent, err = handler(ctx, reqContent)
@@ -1063,7 +11141,7 @@ func (s *chatServiceServer) serveListConversationsJSON(ctx context.Context, resp
 		return
 	}
 	if respContent == nil {
-		s.writeError(ctx, resp, twirp.InternalError("received a nil *ListConversationsResponse and nil error while calling ListConversations. nil responses are not supported"))
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *RevokeSessionResponse and nil error while calling RevokeSession. nil responses are not supported"))
 		return
 	}
 
@@ -1089,9 +11167,9 @@ func (s *chatServiceServer) serveListConversationsJSON(ctx context.Context, resp
 	callResponseSent(ctx, s.hooks)
 }
 
-func (s *chatServiceServer) serveListConversationsProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+func (s *chatServiceServer) serveRevokeSessionProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
 	var err error
-	ctx = ctxsetters.WithMethodName(ctx, "ListConversations")
+	ctx = ctxsetters.WithMethodName(ctx, "RevokeSession")
 	ctx, err = callRequestRouted(ctx, s.hooks)
 	if err != nil {
 		s.writeError(ctx, resp, err)
@@ -1103,28 +11181,28 @@ func (s *chatServiceServer) serveListConversationsProtobuf(ctx context.Context,
 		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
 		return
 	}
-	reqContent := new(ListConversationsRequest)
+	reqContent := new(RevokeSessionRequest)
 	if err = proto.Unmarshal(buf, reqContent); err != nil {
 		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
 		return
 	}
 
-	handler := s.ChatService.ListConversations
+	handler := s.ChatService.RevokeSession
 	if s.interceptor != nil {
-		handler = func(ctx context.Context, req *ListConversationsRequest) (*ListConversationsResponse, error) {
+		handler = func(ctx context.Context, req *RevokeSessionRequest) (*RevokeSessionResponse, error) {
 			resp, err := s.interceptor(
 				func(ctx context.Context, req interface{}) (interface{}, error) {
-					typedReq, ok := req.(*ListConversationsRequest)
+					typedReq, ok := req.(*RevokeSessionRequest)
 					if !ok {
-						return nil, twirp.InternalError("failed type assertion req.(*ListConversationsRequest) when calling interceptor")
+						return nil, twirp.InternalError("failed type assertion req.(*RevokeSessionRequest) when calling interceptor")
 					}
-					return s.ChatService.ListConversations(ctx, typedReq)
+					return s.ChatService.RevokeSession(ctx, typedReq)
 				},
 			)(ctx, req)
 			if resp != nil {
-				typedResp, ok := resp.(*ListConversationsResponse)
+				typedResp, ok := resp.(*RevokeSessionResponse)
 				if !ok {
-					return nil, twirp.InternalError("failed type assertion resp.(*ListConversationsResponse) when calling interceptor")
+					return nil, twirp.InternalError("failed type assertion resp.(*RevokeSessionResponse) when calling interceptor")
 				}
 				return typedResp, err
 			}
@@ -1133,7 +11211,7 @@ func (s *chatServiceServer) serveListConversationsProtobuf(ctx context.Context,
 	}
 
 	// Call service method
-	var respContent *ListConversationsResponse
+	var respContent *RevokeSessionResponse
 	func() {
 		defer ensurePanicResponses(ctx, resp, s.hooks)
 		respContent, err = handler(ctx, reqContent)
@@ -1144,7 +11222,7 @@ func (s *chatServiceServer) serveListConversationsProtobuf(ctx context.Context,
 		return
 	}
 	if respContent == nil {
-		s.writeError(ctx, resp, twirp.InternalError("received a nil *ListConversationsResponse and nil error while calling ListConversations. nil responses are not supported"))
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *RevokeSessionResponse and nil error while calling RevokeSession. nil responses are not supported"))
 		return
 	}
 
@@ -1168,7 +11246,7 @@ func (s *chatServiceServer) serveListConversationsProtobuf(ctx context.Context,
 	callResponseSent(ctx, s.hooks)
 }
 
-func (s *chatServiceServer) serveDescribeConversation(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+func (s *chatServiceServer) serveGetQuota(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
 	header := req.Header.Get("Content-Type")
 	i := strings.Index(header, ";")
 	if i == -1 {
@@ -1176,9 +11254,9 @@ func (s *chatServiceServer) serveDescribeConversation(ctx context.Context, resp
 	}
 	switch strings.TrimSpace(strings.ToLower(header[:i])) {
 	case "application/json":
-		s.serveDescribeConversationJSON(ctx, resp, req)
+		s.serveGetQuotaJSON(ctx, resp, req)
 	case "application/protobuf":
-		s.serveDescribeConversationProtobuf(ctx, resp, req)
+		s.serveGetQuotaProtobuf(ctx, resp, req)
 	default:
 		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
 		twerr := badRouteError(msg, req.Method, req.URL.Path)
@@ -1186,9 +11264,9 @@ func (s *chatServiceServer) serveDescribeConversation(ctx context.Context, resp
 	}
 }
 
-func (s *chatServiceServer) serveDescribeConversationJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+func (s *chatServiceServer) serveGetQuotaJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
 	var err error
-	ctx = ctxsetters.WithMethodName(ctx, "DescribeConversation")
+	ctx = ctxsetters.WithMethodName(ctx, "GetQuota")
 	ctx, err = callRequestRouted(ctx, s.hooks)
 	if err != nil {
 		s.writeError(ctx, resp, err)
@@ -1201,29 +11279,29 @@ func (s *chatServiceServer) serveDescribeConversationJSON(ctx context.Context, r
 		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
 		return
 	}
-	reqContent := new(DescribeConversationRequest)
+	reqContent := new(GetQuotaRequest)
 	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
 	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
 		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
 		return
 	}
 
-	handler := s.ChatService.DescribeConversation
+	handler := s.ChatService.GetQuota
 	if s.interceptor != nil {
-		handler = func(ctx context.Context, req *DescribeConversationRequest) (*DescribeConversationResponse, error) {
+		handler = func(ctx context.Context, req *GetQuotaRequest) (*GetQuotaResponse, error) {
 			resp, err := s.interceptor(
 				func(ctx context.Context, req interface{}) (interface{}, error) {
-					typedReq, ok := req.(*DescribeConversationRequest)
+					typedReq, ok := req.(*GetQuotaRequest)
 					if !ok {
-						return nil, twirp.InternalError("failed type assertion req.(*DescribeConversationRequest) when calling interceptor")
+						return nil, twirp.InternalError("failed type assertion req.(*GetQuotaRequest) when calling interceptor")
 					}
-					return s.ChatService.DescribeConversation(ctx, typedReq)
+					return s.ChatService.GetQuota(ctx, typedReq)
 				},
 			)(ctx, req)
 			if resp != nil {
-				typedResp, ok := resp.(*DescribeConversationResponse)
+				typedResp, ok := resp.(*GetQuotaResponse)
 				if !ok {
-					return nil, twirp.InternalError("failed type assertion resp.(*DescribeConversationResponse) when calling interceptor")
+					return nil, twirp.InternalError("failed type assertion resp.(*GetQuotaResponse) when calling interceptor")
 				}
 				return typedResp, err
 			}
@@ -1232,7 +11310,7 @@ func (s *chatServiceServer) serveDescribeConversationJSON(ctx context.Context, r
 	}
 
 	// Call service method
-	var respContent *DescribeConversationResponse
+	var respContent *GetQuotaResponse
 	func() {
 		defer ensurePanicResponses(ctx, resp, s.hooks)
 		respContent, err = handler(ctx, reqContent)
@@ -1243,7 +11321,7 @@ func (s *chatServiceServer) serveDescribeConversationJSON(ctx context.Context, r
 		return
 	}
 	if respContent == nil {
-		s.writeError(ctx, resp, twirp.InternalError("received a nil *DescribeConversationResponse and nil error while calling DescribeConversation. nil responses are not supported"))
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *GetQuotaResponse and nil error while calling GetQuota. nil responses are not supported"))
 		return
 	}
 
@@ -1269,9 +11347,9 @@ func (s *chatServiceServer) serveDescribeConversationJSON(ctx context.Context, r
 	callResponseSent(ctx, s.hooks)
 }
 
-func (s *chatServiceServer) serveDescribeConversationProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+func (s *chatServiceServer) serveGetQuotaProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
 	var err error
-	ctx = ctxsetters.WithMethodName(ctx, "DescribeConversation")
+	ctx = ctxsetters.WithMethodName(ctx, "GetQuota")
 	ctx, err = callRequestRouted(ctx, s.hooks)
 	if err != nil {
 		s.writeError(ctx, resp, err)
@@ -1283,28 +11361,28 @@ func (s *chatServiceServer) serveDescribeConversationProtobuf(ctx context.Contex
 		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
 		return
 	}
-	reqContent := new(DescribeConversationRequest)
+	reqContent := new(GetQuotaRequest)
 	if err = proto.Unmarshal(buf, reqContent); err != nil {
 		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
 		return
 	}
 
-	handler := s.ChatService.DescribeConversation
+	handler := s.ChatService.GetQuota
 	if s.interceptor != nil {
-		handler = func(ctx context.Context, req *DescribeConversationRequest) (*DescribeConversationResponse, error) {
+		handler = func(ctx context.Context, req *GetQuotaRequest) (*GetQuotaResponse, error) {
 			resp, err := s.interceptor(
 				func(ctx context.Context, req interface{}) (interface{}, error) {
-					typedReq, ok := req.(*DescribeConversationRequest)
+					typedReq, ok := req.(*GetQuotaRequest)
 					if !ok {
-						return nil, twirp.InternalError("failed type assertion req.(*DescribeConversationRequest) when calling interceptor")
+						return nil, twirp.InternalError("failed type assertion req.(*GetQuotaRequest) when calling interceptor")
 					}
-					return s.ChatService.DescribeConversation(ctx, typedReq)
+					return s.ChatService.GetQuota(ctx, typedReq)
 				},
 			)(ctx, req)
 			if resp != nil {
-				typedResp, ok := resp.(*DescribeConversationResponse)
+				typedResp, ok := resp.(*GetQuotaResponse)
 				if !ok {
-					return nil, twirp.InternalError("failed type assertion resp.(*DescribeConversationResponse) when calling interceptor")
+					return nil, twirp.InternalError("failed type assertion resp.(*GetQuotaResponse) when calling interceptor")
 				}
 				return typedResp, err
 			}
@@ -1313,7 +11391,7 @@ func (s *chatServiceServer) serveDescribeConversationProtobuf(ctx context.Contex
 	}
 
 	// Call service method
-	var respContent *DescribeConversationResponse
+	var respContent *GetQuotaResponse
 	func() {
 		defer ensurePanicResponses(ctx, resp, s.hooks)
 		respContent, err = handler(ctx, reqContent)
@@ -1324,7 +11402,7 @@ func (s *chatServiceServer) serveDescribeConversationProtobuf(ctx context.Contex
 		return
 	}
 	if respContent == nil {
-		s.writeError(ctx, resp, twirp.InternalError("received a nil *DescribeConversationResponse and nil error while calling DescribeConversation. nil responses are not supported"))
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *GetQuotaResponse and nil error while calling GetQuota. nil responses are not supported"))
 		return
 	}
 
@@ -1349,7 +11427,7 @@ func (s *chatServiceServer) serveDescribeConversationProtobuf(ctx context.Contex
 }
 
 func (s *chatServiceServer) ServiceDescriptor() ([]byte, int) {
-	return twirpFileDescriptor0, 0
+	return twirpFileDescriptor1, 0
 }
 
 func (s *chatServiceServer) ProtocGenTwirpVersion() string {
@@ -1363,605 +11441,291 @@ func (s *chatServiceServer) PathPrefix() string {
 	return baseServicePath(s.pathPrefix, "acai.chat", "ChatService")
 }
 
-// =====
-// Utils
-// =====
-
-// HTTPClient is the interface used by generated clients to send HTTP requests.
-// It is fulfilled by *(net/http).Client, which is sufficient for most users.
-// Users can provide their own implementation for special retry policies.
-//
-// HTTPClient implementations should not follow redirects. Redirects are
-// automatically disabled if *(net/http).Client is passed to client
-// constructors. See the withoutRedirects function in this file for more
-// details.
-type HTTPClient interface {
-	Do(req *http.Request) (*http.Response, error)
-}
-
-// TwirpServer is the interface generated server structs will support: they're
-// HTTP handlers with additional methods for accessing metadata about the
-// service. Those accessors are a low-level API for building reflection tools.
-// Most people can think of TwirpServers as just http.Handlers.
-type TwirpServer interface {
-	http.Handler
-
-	// ServiceDescriptor returns gzipped bytes describing the .proto file that
-	// this service was generated from. Once unzipped, the bytes can be
-	// unmarshalled as a
-	// google.golang.org/protobuf/types/descriptorpb.FileDescriptorProto.
-	//
-	// The returned integer is the index of this particular service within that
-	// FileDescriptorProto's 'Service' slice of ServiceDescriptorProtos. This is a
-	// low-level field, expected to be used for reflection.
-	ServiceDescriptor() ([]byte, int)
-
-	// ProtocGenTwirpVersion is the semantic version string of the version of
-	// twirp used to generate this file.
-	ProtocGenTwirpVersion() string
-
-	// PathPrefix returns the HTTP URL path prefix for all methods handled by this
-	// service. This can be used with an HTTP mux to route Twirp requests.
-	// The path prefix is in the form: "/<prefix>/<package>.<Service>/"
-	// that is, everything in a Twirp route except for the <Method> at the end.
-	PathPrefix() string
-}
-
-func newServerOpts(opts []interface{}) *twirp.ServerOptions {
-	serverOpts := &twirp.ServerOptions{}
-	for _, opt := range opts {
-		switch o := opt.(type) {
-		case twirp.ServerOption:
-			o(serverOpts)
-		case *twirp.ServerHooks: // backwards compatibility, allow to specify hooks as an argument
-			twirp.WithServerHooks(o)(serverOpts)
-		case nil: // backwards compatibility, allow nil value for the argument
-			continue
-		default:
-			panic(fmt.Sprintf("Invalid option type %T, please use a twirp.ServerOption", o))
-		}
-	}
-	return serverOpts
-}
-
-// WriteError writes an HTTP response with a valid Twirp error format (code, msg, meta).
-// Useful outside of the Twirp server (e.g. http middleware), but does not trigger hooks.
-// If err is not a twirp.Error, it will get wrapped with twirp.InternalErrorWith(err)
-func WriteError(resp http.ResponseWriter, err error) {
-	writeError(context.Background(), resp, err, nil)
-}
-
-// writeError writes Twirp errors in the response and triggers hooks.
-func writeError(ctx context.Context, resp http.ResponseWriter, err error, hooks *twirp.ServerHooks) {
-	// Convert to a twirp.Error. Non-twirp errors are converted to internal errors.
-	var twerr twirp.Error
-	if !errors.As(err, &twerr) {
-		twerr = twirp.InternalErrorWith(err)
-	}
-
-	statusCode := twirp.ServerHTTPStatusFromErrorCode(twerr.Code())
-	ctx = ctxsetters.WithStatusCode(ctx, statusCode)
-	ctx = callError(ctx, hooks, twerr)
-
-	respBody := marshalErrorToJSON(twerr)
-
-	resp.Header().Set("Content-Type", "application/json") // Error responses are always JSON
-	resp.Header().Set("Content-Length", strconv.Itoa(len(respBody)))
-	resp.WriteHeader(statusCode) // set HTTP status code and send response
-
-	_, writeErr := resp.Write(respBody)
-	if writeErr != nil {
-		// We have three options here. We could log the error, call the Error
-		// hook, or just silently ignore the error.
-		//
-		// Logging is unacceptable because we don't have a user-controlled
-		// logger; writing out to stderr without permission is too rude.
-		//
-		// Calling the Error hook would confuse users: it would mean the Error
-		// hook got called twice for one request, which is likely to lead to
-		// duplicated log messages and metrics, no matter how well we document
-		// the behavior.
-		//
-		// Silently ignoring the error is our least-bad option. It's highly
-		// likely that the connection is broken and the original 'err' says
-		// so anyway.
-		_ = writeErr
-	}
-
-	callResponseSent(ctx, hooks)
-}
-
-// sanitizeBaseURL parses the the baseURL, and adds the "http" scheme if needed.
-// If the URL is unparsable, the baseURL is returned unchanged.
-func sanitizeBaseURL(baseURL string) string {
-	u, err := url.Parse(baseURL)
-	if err != nil {
-		return baseURL // invalid URL will fail later when making requests
-	}
-	if u.Scheme == "" {
-		u.Scheme = "http"
-	}
-	return u.String()
-}
-
-// baseServicePath composes the path prefix for the service (without <Method>).
-// e.g.: baseServicePath("/twirp", "my.pkg", "MyService")
-//
-//	returns => "/twirp/my.pkg.MyService/"
-//
-// e.g.: baseServicePath("", "", "MyService")
-//
-//	returns => "/MyService/"
-func baseServicePath(prefix, pkg, service string) string {
-	fullServiceName := service
-	if pkg != "" {
-		fullServiceName = pkg + "." + service
-	}
-	return path.Join("/", prefix, fullServiceName) + "/"
-}
-
-// parseTwirpPath extracts path components form a valid Twirp route.
-// Expected format: "[<prefix>]/<package>.<Service>/<Method>"
-// e.g.: prefix, pkgService, method := parseTwirpPath("/twirp/pkg.Svc/MakeHat")
-func parseTwirpPath(path string) (string, string, string) {
-	parts := strings.Split(path, "/")
-	if len(parts) < 2 {
-		return "", "", ""
-	}
-	method := parts[len(parts)-1]
-	pkgService := parts[len(parts)-2]
-	prefix := strings.Join(parts[0:len(parts)-2], "/")
-	return prefix, pkgService, method
-}
-
-// getCustomHTTPReqHeaders retrieves a copy of any headers that are set in
-// a context through the twirp.WithHTTPRequestHeaders function.
-// If there are no headers set, or if they have the wrong type, nil is returned.
-func getCustomHTTPReqHeaders(ctx context.Context) http.Header {
-	header, ok := twirp.HTTPRequestHeaders(ctx)
-	if !ok || header == nil {
-		return nil
-	}
-	copied := make(http.Header)
-	for k, vv := range header {
-		if vv == nil {
-			copied[k] = nil
-			continue
-		}
-		copied[k] = make([]string, len(vv))
-		copy(copied[k], vv)
-	}
-	return copied
-}
-
-// newRequest makes an http.Request from a client, adding common headers.
-func newRequest(ctx context.Context, url string, reqBody io.Reader, contentType string) (*http.Request, error) {
-	req, err := http.NewRequest("POST", url, reqBody)
-	if err != nil {
-		return nil, err
-	}
-	req = req.WithContext(ctx)
-	if customHeader := getCustomHTTPReqHeaders(ctx); customHeader != nil {
-		req.Header = customHeader
-	}
-	req.Header.Set("Accept", contentType)
-	req.Header.Set("Content-Type", contentType)
-	req.Header.Set("Twirp-Version", "v8.1.3")
-	return req, nil
-}
-
-// JSON serialization for errors
-type twerrJSON struct {
-	Code string            `json:"code"`
-	Msg  string            `json:"msg"`
-	Meta map[string]string `json:"meta,omitempty"`
-}
-
-// marshalErrorToJSON returns JSON from a twirp.Error, that can be used as HTTP error response body.
-// If serialization fails, it will use a descriptive Internal error instead.
-func marshalErrorToJSON(twerr twirp.Error) []byte {
-	// make sure that msg is not too large
-	msg := twerr.Msg()
-	if len(msg) > 1e6 {
-		msg = msg[:1e6]
-	}
-
-	tj := twerrJSON{
-		Code: string(twerr.Code()),
-		Msg:  msg,
-		Meta: twerr.MetaMap(),
-	}
-
-	buf, err := json.Marshal(&tj)
-	if err != nil {
-		buf = []byte("{\"type\": \"" + twirp.Internal + "\", \"msg\": \"There was an error but it could not be serialized into JSON\"}") // fallback
-	}
-
-	return buf
-}
-
-// errorFromResponse builds a twirp.Error from a non-200 HTTP response.
-// If the response has a valid serialized Twirp error, then it's returned.
-// If not, the response status code is used to generate a similar twirp
-// error. See twirpErrorFromIntermediary for more info on intermediary errors.
-func errorFromResponse(resp *http.Response) twirp.Error {
-	statusCode := resp.StatusCode
-	statusText := http.StatusText(statusCode)
-
-	if isHTTPRedirect(statusCode) {
-		// Unexpected redirect: it must be an error from an intermediary.
-		// Twirp clients don't follow redirects automatically, Twirp only handles
-		// POST requests, redirects should only happen on GET and HEAD requests.
-		location := resp.Header.Get("Location")
-		msg := fmt.Sprintf("unexpected HTTP status code %d %q received, Location=%q", statusCode, statusText, location)
-		return twirpErrorFromIntermediary(statusCode, msg, location)
-	}
-
-	respBodyBytes, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return wrapInternal(err, "failed to read server error response body")
-	}
-
-	var tj twerrJSON
-	dec := json.NewDecoder(bytes.NewReader(respBodyBytes))
-	dec.DisallowUnknownFields()
-	if err := dec.Decode(&tj); err != nil || tj.Code == "" {
-		// Invalid JSON response; it must be an error from an intermediary.
-		msg := fmt.Sprintf("Error from intermediary with HTTP status code %d %q", statusCode, statusText)
-		return twirpErrorFromIntermediary(statusCode, msg, string(respBodyBytes))
-	}
-
-	errorCode := twirp.ErrorCode(tj.Code)
-	if !twirp.IsValidErrorCode(errorCode) {
-		msg := "invalid type returned from server error response: " + tj.Code
-		return twirp.InternalError(msg).WithMeta("body", string(respBodyBytes))
-	}
-
-	twerr := twirp.NewError(errorCode, tj.Msg)
-	for k, v := range tj.Meta {
-		twerr = twerr.WithMeta(k, v)
-	}
-	return twerr
-}
-
-// twirpErrorFromIntermediary maps HTTP errors from non-twirp sources to twirp errors.
-// The mapping is similar to gRPC: https://github.com/grpc/grpc/blob/master/doc/http-grpc-status-mapping.md.
-// Returned twirp Errors have some additional metadata for inspection.
-func twirpErrorFromIntermediary(status int, msg string, bodyOrLocation string) twirp.Error {
-	var code twirp.ErrorCode
-	if isHTTPRedirect(status) { // 3xx
-		code = twirp.Internal
-	} else {
-		switch status {
-		case 400: // Bad Request
-			code = twirp.Internal
-		case 401: // Unauthorized
-			code = twirp.Unauthenticated
-		case 403: // Forbidden
-			code = twirp.PermissionDenied
-		case 404: // Not Found
-			code = twirp.BadRoute
-		case 429: // Too Many Requests
-			code = twirp.ResourceExhausted
-		case 502, 503, 504: // Bad Gateway, Service Unavailable, Gateway Timeout
-			code = twirp.Unavailable
-		default: // All other codes
-			code = twirp.Unknown
-		}
-	}
-
-	twerr := twirp.NewError(code, msg)
-	twerr = twerr.WithMeta("http_error_from_intermediary", "true") // to easily know if this error was from intermediary
-	twerr = twerr.WithMeta("status_code", strconv.Itoa(status))
-	if isHTTPRedirect(status) {
-		twerr = twerr.WithMeta("location", bodyOrLocation)
-	} else {
-		twerr = twerr.WithMeta("body", bodyOrLocation)
-	}
-	return twerr
-}
-
-func isHTTPRedirect(status int) bool {
-	return status >= 300 && status <= 399
-}
-
-// wrapInternal wraps an error with a prefix as an Internal error.
-// The original error cause is accessible by github.com/pkg/errors.Cause.
-func wrapInternal(err error, prefix string) twirp.Error {
-	return twirp.InternalErrorWith(&wrappedError{prefix: prefix, cause: err})
-}
-
-type wrappedError struct {
-	prefix string
-	cause  error
-}
-
-func (e *wrappedError) Error() string { return e.prefix + ": " + e.cause.Error() }
-func (e *wrappedError) Unwrap() error { return e.cause } // for go1.13 + errors.Is/As
-func (e *wrappedError) Cause() error  { return e.cause } // for github.com/pkg/errors
-
-// ensurePanicResponses makes sure that rpc methods causing a panic still result in a Twirp Internal
-// error response (status 500), and error hooks are properly called with the panic wrapped as an error.
-// The panic is re-raised so it can be handled normally with middleware.
-func ensurePanicResponses(ctx context.Context, resp http.ResponseWriter, hooks *twirp.ServerHooks) {
-	if r := recover(); r != nil {
-		// Wrap the panic as an error so it can be passed to error hooks.
-		// The original error is accessible from error hooks, but not visible in the response.
-		err := errFromPanic(r)
-		twerr := &internalWithCause{msg: "Internal service panic", cause: err}
-		// Actually write the error
-		writeError(ctx, resp, twerr, hooks)
-		// If possible, flush the error to the wire.
-		f, ok := resp.(http.Flusher)
-		if ok {
-			f.Flush()
-		}
-
-		panic(r)
-	}
-}
-
-// errFromPanic returns the typed error if the recovered panic is an error, otherwise formats as error.
-func errFromPanic(p interface{}) error {
-	if err, ok := p.(error); ok {
-		return err
-	}
-	return fmt.Errorf("panic: %v", p)
-}
-
-// internalWithCause is a Twirp Internal error wrapping an original error cause,
-// but the original error message is not exposed on Msg(). The original error
-// can be checked with go1.13+ errors.Is/As, and also by (github.com/pkg/errors).Unwrap
-type internalWithCause struct {
-	msg   string
-	cause error
-}
-
-func (e *internalWithCause) Unwrap() error                               { return e.cause } // for go1.13 + errors.Is/As
-func (e *internalWithCause) Cause() error                                { return e.cause } // for github.com/pkg/errors
-func (e *internalWithCause) Error() string                               { return e.msg + ": " + e.cause.Error() }
-func (e *internalWithCause) Code() twirp.ErrorCode                       { return twirp.Internal }
-func (e *internalWithCause) Msg() string                                 { return e.msg }
-func (e *internalWithCause) Meta(key string) string                      { return "" }
-func (e *internalWithCause) MetaMap() map[string]string                  { return nil }
-func (e *internalWithCause) WithMeta(key string, val string) twirp.Error { return e }
-
-// malformedRequestError is used when the twirp server cannot unmarshal a request
-func malformedRequestError(msg string) twirp.Error {
-	return twirp.NewError(twirp.Malformed, msg)
-}
-
-// badRouteError is used when the twirp server cannot route a request
-func badRouteError(msg string, method, url string) twirp.Error {
-	err := twirp.NewError(twirp.BadRoute, msg)
-	err = err.WithMeta("twirp_invalid_route", method+" "+url)
-	return err
-}
-
-// withoutRedirects makes sure that the POST request can not be redirected.
-// The standard library will, by default, redirect requests (including POSTs) if it gets a 302 or
-// 303 response, and also 301s in go1.8. It redirects by making a second request, changing the
-// method to GET and removing the body. This produces very confusing error messages, so instead we
-// set a redirect policy that always errors. This stops Go from executing the redirect.
-//
-// We have to be a little careful in case the user-provided http.Client has its own CheckRedirect
-// policy - if so, we'll run through that policy first.
-//
-// Because this requires modifying the http.Client, we make a new copy of the client and return it.
-func withoutRedirects(in *http.Client) *http.Client {
-	copy := *in
-	copy.CheckRedirect = func(req *http.Request, via []*http.Request) error {
-		if in.CheckRedirect != nil {
-			// Run the input's redirect if it exists, in case it has side effects, but ignore any error it
-			// returns, since we want to use ErrUseLastResponse.
-			err := in.CheckRedirect(req, via)
-			_ = err // Silly, but this makes sure generated code passes errcheck -blank, which some people use.
-		}
-		return http.ErrUseLastResponse
-	}
-	return &copy
-}
-
-// doProtobufRequest makes a Protobuf request to the remote Twirp service.
-func doProtobufRequest(ctx context.Context, client HTTPClient, hooks *twirp.ClientHooks, url string, in, out proto.Message) (_ context.Context, err error) {
-	reqBodyBytes, err := proto.Marshal(in)
-	if err != nil {
-		return ctx, wrapInternal(err, "failed to marshal proto request")
-	}
-	reqBody := bytes.NewBuffer(reqBodyBytes)
-	if err = ctx.Err(); err != nil {
-		return ctx, wrapInternal(err, "aborted because context was done")
-	}
-
-	req, err := newRequest(ctx, url, reqBody, "application/protobuf")
-	if err != nil {
-		return ctx, wrapInternal(err, "could not build request")
-	}
-	ctx, err = callClientRequestPrepared(ctx, hooks, req)
-	if err != nil {
-		return ctx, err
-	}
-
-	req = req.WithContext(ctx)
-	resp, err := client.Do(req)
-	if err != nil {
-		return ctx, wrapInternal(err, "failed to do request")
-	}
-	defer func() { _ = resp.Body.Close() }()
-
-	if err = ctx.Err(); err != nil {
-		return ctx, wrapInternal(err, "aborted because context was done")
-	}
-
-	if resp.StatusCode != 200 {
-		return ctx, errorFromResponse(resp)
-	}
-
-	respBodyBytes, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return ctx, wrapInternal(err, "failed to read response body")
-	}
-	if err = ctx.Err(); err != nil {
-		return ctx, wrapInternal(err, "aborted because context was done")
-	}
-
-	if err = proto.Unmarshal(respBodyBytes, out); err != nil {
-		return ctx, wrapInternal(err, "failed to unmarshal proto response")
-	}
-	return ctx, nil
-}
-
-// doJSONRequest makes a JSON request to the remote Twirp service.
-func doJSONRequest(ctx context.Context, client HTTPClient, hooks *twirp.ClientHooks, url string, in, out proto.Message) (_ context.Context, err error) {
-	marshaler := &protojson.MarshalOptions{UseProtoNames: true}
-	reqBytes, err := marshaler.Marshal(in)
-	if err != nil {
-		return ctx, wrapInternal(err, "failed to marshal json request")
-	}
-	if err = ctx.Err(); err != nil {
-		return ctx, wrapInternal(err, "aborted because context was done")
-	}
-
-	req, err := newRequest(ctx, url, bytes.NewReader(reqBytes), "application/json")
-	if err != nil {
-		return ctx, wrapInternal(err, "could not build request")
-	}
-	ctx, err = callClientRequestPrepared(ctx, hooks, req)
-	if err != nil {
-		return ctx, err
-	}
-
-	req = req.WithContext(ctx)
-	resp, err := client.Do(req)
-	if err != nil {
-		return ctx, wrapInternal(err, "failed to do request")
-	}
-
-	defer func() {
-		cerr := resp.Body.Close()
-		if err == nil && cerr != nil {
-			err = wrapInternal(cerr, "failed to close response body")
-		}
-	}()
-
-	if err = ctx.Err(); err != nil {
-		return ctx, wrapInternal(err, "aborted because context was done")
-	}
-
-	if resp.StatusCode != 200 {
-		return ctx, errorFromResponse(resp)
-	}
-
-	d := json.NewDecoder(resp.Body)
-	rawRespBody := json.RawMessage{}
-	if err := d.Decode(&rawRespBody); err != nil {
-		return ctx, wrapInternal(err, "failed to unmarshal json response")
-	}
-	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
-	if err = unmarshaler.Unmarshal(rawRespBody, out); err != nil {
-		return ctx, wrapInternal(err, "failed to unmarshal json response")
-	}
-	if err = ctx.Err(); err != nil {
-		return ctx, wrapInternal(err, "aborted because context was done")
-	}
-	return ctx, nil
-}
-
-// Call twirp.ServerHooks.RequestReceived if the hook is available
-func callRequestReceived(ctx context.Context, h *twirp.ServerHooks) (context.Context, error) {
-	if h == nil || h.RequestReceived == nil {
-		return ctx, nil
-	}
-	return h.RequestReceived(ctx)
-}
-
-// Call twirp.ServerHooks.RequestRouted if the hook is available
-func callRequestRouted(ctx context.Context, h *twirp.ServerHooks) (context.Context, error) {
-	if h == nil || h.RequestRouted == nil {
-		return ctx, nil
-	}
-	return h.RequestRouted(ctx)
-}
-
-// Call twirp.ServerHooks.ResponsePrepared if the hook is available
-func callResponsePrepared(ctx context.Context, h *twirp.ServerHooks) context.Context {
-	if h == nil || h.ResponsePrepared == nil {
-		return ctx
-	}
-	return h.ResponsePrepared(ctx)
-}
-
-// Call twirp.ServerHooks.ResponseSent if the hook is available
-func callResponseSent(ctx context.Context, h *twirp.ServerHooks) {
-	if h == nil || h.ResponseSent == nil {
-		return
-	}
-	h.ResponseSent(ctx)
-}
-
-// Call twirp.ServerHooks.Error if the hook is available
-func callError(ctx context.Context, h *twirp.ServerHooks, err twirp.Error) context.Context {
-	if h == nil || h.Error == nil {
-		return ctx
-	}
-	return h.Error(ctx, err)
-}
-
-func callClientResponseReceived(ctx context.Context, h *twirp.ClientHooks) {
-	if h == nil || h.ResponseReceived == nil {
-		return
-	}
-	h.ResponseReceived(ctx)
-}
-
-func callClientRequestPrepared(ctx context.Context, h *twirp.ClientHooks, req *http.Request) (context.Context, error) {
-	if h == nil || h.RequestPrepared == nil {
-		return ctx, nil
-	}
-	return h.RequestPrepared(ctx, req)
-}
-
-func callClientError(ctx context.Context, h *twirp.ClientHooks, err twirp.Error) {
-	if h == nil || h.Error == nil {
-		return
-	}
-	h.Error(ctx, err)
-}
-
-var twirpFileDescriptor0 = []byte{
-	// 529 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x94, 0x51, 0x6b, 0xd3, 0x50,
-	0x14, 0xc7, 0x4d, 0xda, 0xd9, 0xe5, 0x64, 0xad, 0xdd, 0xa5, 0x60, 0x96, 0x15, 0x56, 0xa2, 0xb8,
-	0x3e, 0x48, 0x2a, 0x75, 0x0f, 0xc2, 0xf0, 0x61, 0x56, 0x85, 0xa1, 0x56, 0x48, 0x3a, 0x84, 0x09,
-	0xd3, 0x34, 0xbb, 0x66, 0x17, 0xd2, 0xdc, 0x98, 0x7b, 0x3b, 0xf0, 0xd3, 0xec, 0x83, 0xfa, 0x22,
-	0x4d, 0x6e, 0xe3, 0xbd, 0x34, 0xa9, 0x8a, 0x8f, 0xe7, 0xe4, 0x7f, 0xcf, 0xf9, 0xfd, 0xcf, 0x39,
-	0x04, 0x3a, 0x59, 0x1a, 0x8e, 0xc2, 0x9b, 0x80, 0xbb, 0x69, 0x46, 0x39, 0x45, 0x46, 0x10, 0x06,
-	0xc4, 0x5d, 0x25, 0xec, 0xa3, 0x88, 0xd2, 0x28, 0xc6, 0xa3, 0xfc, 0xc3, 0x7c, 0xf9, 0x6d, 0xc4,
-	0xc9, 0x02, 0x33, 0x1e, 0x2c, 0xd2, 0x42, 0xeb, 0xfc, 0xd4, 0x61, 0x6f, 0x42, 0x93, 0x5b, 0x9c,
-	0xb1, 0x80, 0x13, 0x9a, 0xa0, 0x0e, 0xe8, 0xe4, 0xda, 0xd2, 0x06, 0xda, 0xd0, 0xf0, 0x74, 0x72,
-	0x8d, 0x7a, 0xb0, 0xc3, 0x09, 0x8f, 0xb1, 0xa5, 0xe7, 0xa9, 0x22, 0x40, 0x2f, 0xc0, 0x28, 0x2b,
-	0x59, 0x8d, 0x81, 0x36, 0x34, 0xc7, 0xb6, 0x5b, 0xf4, 0x72, 0xd7, 0xbd, 0xdc, 0xd9, 0x5a, 0xe1,
-	0xfd, 0x16, 0xa3, 0x53, 0xd8, 0x5d, 0x60, 0xc6, 0x82, 0x08, 0x33, 0xab, 0x39, 0x68, 0x0c, 0xcd,
-	0xf1, 0x91, 0x5b, 0xf2, 0xba, 0x32, 0x8a, 0xfb, 0xa1, 0xd0, 0x79, 0xe5, 0x03, 0xfb, 0x4e, 0x83,
-	0x96, 0xc8, 0x6e, 0x80, 0x3e, 0x83, 0x66, 0x46, 0x05, 0x67, 0x67, 0xdc, 0xaf, 0x2b, 0xea, 0xd1,
-	0x18, 0x7b, 0xb9, 0x12, 0x59, 0xd0, 0x0a, 0x69, 0xc2, 0x71, 0xc2, 0x73, 0x0b, 0x86, 0xb7, 0x0e,
-	0x55, 0x7b, 0xcd, 0x7f, 0xb0, 0xe7, 0x3c, 0x85, 0xe6, 0xaa, 0x03, 0x32, 0xa1, 0x75, 0x31, 0x7d,
-	0x37, 0xfd, 0xf8, 0x69, 0xda, 0xbd, 0x87, 0x76, 0xa1, 0x79, 0xe1, 0xbf, 0xf1, 0xba, 0x1a, 0x6a,
-	0x83, 0x71, 0xe6, 0xfb, 0xe7, 0xfe, 0xec, 0x6c, 0x3a, 0xeb, 0xea, 0xce, 0x09, 0x58, 0x3e, 0x0f,
-	0x32, 0x2e, 0x13, 0x7a, 0xf8, 0xfb, 0x12, 0x33, 0xbe, 0xa2, 0x13, 0xbe, 0x85, 0xc9, 0x75, 0xe8,
-	0xa4, 0x70, 0x50, 0xf1, 0x8a, 0xa5, 0x34, 0x61, 0x18, 0x1d, 0xc3, 0x83, 0x50, 0xca, 0x7f, 0x29,
-	0x67, 0xd4, 0x91, 0xd3, 0xe7, 0x75, 0x8b, 0xed, 0xc1, 0x4e, 0x86, 0xd3, 0xf8, 0x87, 0x98, 0x48,
-	0x11, 0x38, 0x5f, 0xe1, 0x70, 0x42, 0x13, 0x4e, 0x92, 0x25, 0xae, 0x42, 0xfd, 0xeb, 0x9e, 0x92,
-	0x27, 0x5d, 0xf5, 0x74, 0x02, 0xfd, 0xea, 0x0e, 0xc2, 0x56, 0xc9, 0xa5, 0xc9, 0x5c, 0x36, 0x58,
-	0xef, 0x09, 0x53, 0x06, 0xc1, 0x04, 0x94, 0x73, 0x09, 0x07, 0x15, 0xdf, 0x44, 0xb9, 0x97, 0xd0,
-	0x96, 0xd1, 0x98, 0xa5, 0xe5, 0xa7, 0xf8, 0xb0, 0xe6, 0x6a, 0x3c, 0x55, 0xed, 0xbc, 0x85, 0xc3,
-	0xd7, 0x98, 0x85, 0x19, 0x99, 0xff, 0xd7, 0x3c, 0x9c, 0xcf, 0xd0, 0xaf, 0xae, 0x23, 0x30, 0x4f,
-	0x61, 0x4f, 0x7e, 0x91, 0x57, 0xd9, 0x42, 0xa9, 0x88, 0xc7, 0x77, 0x0d, 0x30, 0x27, 0x37, 0x01,
-	0xf7, 0x71, 0x76, 0x4b, 0x42, 0x8c, 0xae, 0x60, 0x7f, 0xe3, 0x6c, 0xd0, 0x23, 0xa9, 0x56, 0xdd,
-	0x29, 0xda, 0x8f, 0xb7, 0x8b, 0x04, 0x6c, 0x04, 0xbd, 0xaa, 0x15, 0xa2, 0x27, 0x2a, 0x6e, 0xdd,
-	0x15, 0xd9, 0xc7, 0x7f, 0xd4, 0x89, 0x46, 0x57, 0xb0, 0xbf, 0xb1, 0x59, 0xc5, 0x48, 0xdd, 0x4d,
-	0x28, 0x46, 0xea, 0x8f, 0x23, 0x82, 0x5e, 0xd5, 0x56, 0x14, 0x23, 0x5b, 0xd6, 0xaf, 0x18, 0xd9,
-	0xb6, 0xde, 0x57, 0xed, 0x4b, 0x93, 0x24, 0x1c, 0x67, 0x49, 0x10, 0x8f, 0xd2, 0xf9, 0xfc, 0x7e,
-	0xfe, 0x6b, 0x79, 0xfe, 0x2b, 0x00, 0x00, 0xff, 0xff, 0x59, 0x83, 0x17, 0xf4, 0xd0, 0x05, 0x00,
-	0x00,
+var twirpFileDescriptor1 = []byte{
+	// 4546 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x3b, 0x4d, 0x77, 0x1b, 0x47,
+	0x72, 0x1a, 0x80, 0x20, 0x81, 0x02, 0x3f, 0xc0, 0x16, 0x25, 0x81, 0x23, 0x52, 0xa4, 0x47, 0x96,
+	0x2d, 0xd3, 0xbb, 0x94, 0x1f, 0xb5, 0x5e, 0xaf, 0xec, 0x75, 0x36, 0x20, 0x09, 0x42, 0xb0, 0x29,
+	0x92, 0x1a, 0x90, 0x72, 0xe4, 0x4d, 0x8c, 0xd7, 0x04, 0x9a, 0xe4, 0x48, 0x83, 0x19, 0x68, 0xa6,
+	0x41, 0x8b, 0xbe, 0x65, 0x37, 0x87, 0xbd, 0x24, 0xb7, 0xfd, 0x05, 0x39, 0x24, 0x6f, 0xdf, 0xcb,
+	0x2d, 0xd9, 0x7b, 0x0e, 0x79, 0xf9, 0x0d, 0x39, 0xe6, 0xbd, 0x9c, 0x73, 0xdb, 0x7b, 0x5e, 0x7f,
+	0xcd, 0x07, 0x66, 0x06, 0x20, 0x25, 0xf9, 0x65, 0x6f, 0xd3, 0x55, 0xd5, 0xd5, 0xd5, 0x55, 0xd5,
+	0xdd, 0xd5, 0x55, 0x3d, 0x30, 0xeb, 0xf5, 0x3b, 0x0f, 0x3a, 0x67, 0x98, 0xae, 0xf7, 0x3d, 0x97,
+	0xba, 0xa8, 0x84, 0x3b, 0xd8, 0x5a, 0x67, 0x00, 0x7d, 0xf5, 0xd4, 0x75, 0x4f, 0x6d, 0xf2, 0x80,
+	0x23, 0x8e, 0x07, 0x27, 0x0f, 0x4e, 0x2c, 0x62, 0x77, 0xdb, 0x3d, 0xec, 0xbf, 0x14, 0xc4, 0xfa,
+	0xca, 0x30, 0x05, 0xb5, 0x7a, 0xc4, 0xa7, 0xb8, 0xd7, 0x17, 0x04, 0xc6, 0x1f, 0x67, 0x60, 0x7a,
+	0xcb, 0x75, 0xce, 0x89, 0xe7, 0x63, 0x6a, 0xb9, 0x0e, 0x9a, 0x85, 0x9c, 0xd5, 0xad, 0x6a, 0xab,
+	0xda, 0xfd, 0x92, 0x99, 0xb3, 0xba, 0x68, 0x01, 0x0a, 0xd4, 0xa2, 0x36, 0xa9, 0xe6, 0x38, 0x48,
+	0x34, 0xd0, 0x2f, 0xa0, 0x14, 0x70, 0xaa, 0xe6, 0x57, 0xb5, 0xfb, 0xe5, 0x0d, 0x7d, 0x5d, 0x8c,
+	0xb5, 0xae, 0xc6, 0x5a, 0x3f, 0x54, 0x14, 0x66, 0x48, 0x8c, 0xbe, 0x80, 0x62, 0x8f, 0xf8, 0x3e,
+	0x3e, 0x25, 0x7e, 0x75, 0x62, 0x35, 0x7f, 0xbf, 0xbc, 0xb1, 0xb2, 0x1e, 0xcc, 0x68, 0x3d, 0x2a,
+	0xca, 0xfa, 0x13, 0x41, 0x67, 0x06, 0x1d, 0xd0, 0x23, 0x28, 0xfa, 0x84, 0x52, 0xcb, 0x39, 0xf5,
+	0xab, 0x05, 0x3e, 0xea, 0x72, 0xa4, 0x73, 0x83, 0x38, 0xc4, 0xe3, 0x5d, 0x5b, 0x92, 0xc8, 0x0c,
+	0xc8, 0xd1, 0x12, 0x94, 0xb0, 0xef, 0x5b, 0x3e, 0xc5, 0x0e, 0xad, 0x4e, 0xf2, 0xb9, 0x84, 0x00,
+	0xf4, 0x08, 0xa6, 0xfa, 0x1e, 0x39, 0xb7, 0xc8, 0xf7, 0xd5, 0xa9, 0x55, 0x6d, 0x94, 0x50, 0x07,
+	0x82, 0xcc, 0x54, 0xf4, 0x48, 0x87, 0xa2, 0x8d, 0x9d, 0xd3, 0x01, 0x3e, 0x25, 0xd5, 0x22, 0xe7,
+	0x1b, 0xb4, 0xd1, 0x7b, 0x30, 0x7d, 0x82, 0x2d, 0x9b, 0x74, 0xdb, 0x1e, 0xe9, 0xdb, 0x17, 0xd5,
+	0x12, 0xc7, 0x97, 0x05, 0xcc, 0x64, 0x20, 0x84, 0x60, 0x82, 0xe2, 0x53, 0xbf, 0x0a, 0xab, 0xf9,
+	0xfb, 0x25, 0x93, 0x7f, 0xa3, 0x2f, 0xa0, 0x8c, 0xbd, 0xce, 0x99, 0x75, 0x4e, 0xba, 0x6d, 0x4c,
+	0xab, 0xe5, 0xb1, 0xfa, 0x05, 0x45, 0x5e, 0xa3, 0xe8, 0x21, 0x14, 0x06, 0x4c, 0x5b, 0xd5, 0xe9,
+	0x84, 0x82, 0x62, 0x13, 0x39, 0xe2, 0xba, 0x15, 0xb4, 0x4c, 0x0a, 0xab, 0xe3, 0x3a, 0xd5, 0x19,
+	0x2e, 0x20, 0xff, 0x46, 0x55, 0x98, 0xf2, 0x07, 0xbd, 0x1e, 0xf6, 0x2e, 0xaa, 0xb3, 0x1c, 0xac,
+	0x9a, 0xe8, 0x23, 0xa8, 0xb8, 0x3d, 0x8b, 0x52, 0xd2, 0x6d, 0x07, 0xb6, 0x9c, 0x5b, 0xd5, 0xee,
+	0x17, 0xcc, 0x39, 0x09, 0x97, 0xa6, 0xf3, 0xf5, 0xbf, 0x9d, 0x80, 0x29, 0xd9, 0x48, 0xb8, 0xd6,
+	0x27, 0x30, 0xe1, 0xb9, 0xd2, 0xb3, 0x66, 0x37, 0x96, 0xb2, 0x04, 0x35, 0x5d, 0x9b, 0x98, 0x9c,
+	0x92, 0x89, 0xd4, 0x71, 0x1d, 0x4a, 0x1c, 0xca, 0x9d, 0xae, 0x64, 0xaa, 0x66, 0xdc, 0x21, 0x27,
+	0xae, 0xe2, 0x90, 0x9f, 0x41, 0x19, 0x53, 0x8a, 0x3b, 0x67, 0x3d, 0xe2, 0x50, 0xe6, 0x56, 0xcc,
+	0x27, 0x6f, 0x44, 0x84, 0xa9, 0x05, 0x58, 0x33, 0x4a, 0x89, 0xee, 0xc2, 0x4c, 0x9f, 0x78, 0xbe,
+	0xeb, 0x60, 0xbb, 0xdd, 0xc5, 0x14, 0x57, 0x27, 0xb9, 0x09, 0xa7, 0x15, 0x70, 0x1b, 0x53, 0x8c,
+	0x7e, 0x05, 0x40, 0x5d, 0xd7, 0x6e, 0x77, 0xb0, 0x6d, 0xfb, 0xd5, 0x29, 0xce, 0x7c, 0x35, 0x6b,
+	0xa6, 0x87, 0xae, 0x6b, 0x6f, 0x61, 0xdb, 0x36, 0x4b, 0x54, 0x7e, 0xf9, 0xe8, 0x33, 0x28, 0xf5,
+	0x2d, 0xc7, 0x11, 0x9e, 0x50, 0x1c, 0x3b, 0xb1, 0xa2, 0x20, 0xae, 0x51, 0x74, 0x13, 0x26, 0x2d,
+	0xa1, 0x2a, 0xe1, 0x75, 0xb2, 0x85, 0x6e, 0x43, 0xa9, 0x4b, 0xce, 0xad, 0x0e, 0x69, 0x5b, 0xdd,
+	0x2a, 0x08, 0x87, 0x15, 0x80, 0x26, 0x33, 0xc9, 0x24, 0x5b, 0x41, 0xce, 0x29, 0x77, 0xba, 0xd9,
+	0x8d, 0x6a, 0x44, 0x54, 0xb5, 0x1c, 0x39, 0xde, 0x94, 0x74, 0xcc, 0xc5, 0xa5, 0x0d, 0xda, 0xa7,
+	0x3f, 0x58, 0x7d, 0xee, 0x75, 0xd3, 0x66, 0x59, 0xc2, 0x1a, 0x3f, 0x58, 0x7d, 0xfd, 0x18, 0x8a,
+	0x6a, 0x66, 0xdc, 0xdd, 0x5d, 0xd7, 0x96, 0x5e, 0xc0, 0xbf, 0x19, 0x8c, 0xa9, 0x47, 0xee, 0x30,
+	0xfc, 0x9b, 0x49, 0xef, 0x11, 0x7f, 0x60, 0x2b, 0x43, 0xcb, 0x16, 0x83, 0x8b, 0xd5, 0xc3, 0x8d,
+	0x5c, 0x34, 0x65, 0x4b, 0xff, 0xbd, 0x06, 0x05, 0xee, 0xd1, 0xdc, 0x2c, 0x9e, 0xdb, 0xeb, 0xd3,
+	0x36, 0x75, 0x5f, 0x12, 0xc7, 0xe7, 0x43, 0xe5, 0xcd, 0x69, 0x01, 0x3c, 0xe4, 0x30, 0xf4, 0x31,
+	0xcc, 0x77, 0xdc, 0x5e, 0xdf, 0x26, 0x4c, 0xef, 0x8a, 0x30, 0xc7, 0x09, 0x2b, 0x21, 0x42, 0x12,
+	0x2f, 0x42, 0xb1, 0xe3, 0xfa, 0xb4, 0x3d, 0xf0, 0xbb, 0x5c, 0x1a, 0x8d, 0xb9, 0x9d, 0x4f, 0x8f,
+	0xfc, 0x2e, 0x5a, 0x81, 0xb2, 0x7b, 0x4e, 0xbc, 0xf6, 0xf1, 0xa0, 0x7b, 0x4a, 0xa8, 0x94, 0x09,
+	0x18, 0x68, 0x93, 0x43, 0xf4, 0x7f, 0xce, 0xc1, 0x94, 0xdc, 0x32, 0x98, 0xaa, 0x6c, 0xec, 0x53,
+	0xb5, 0x66, 0xa4, 0x0e, 0xca, 0x0c, 0xa6, 0x96, 0xc8, 0x63, 0x98, 0x8f, 0x92, 0xb4, 0x2f, 0xbd,
+	0x3e, 0xe6, 0x22, 0x5c, 0x18, 0x00, 0x1d, 0xc0, 0xcd, 0x18, 0xa7, 0xab, 0x6c, 0xd7, 0x0b, 0x11,
+	0x66, 0x01, 0x94, 0x29, 0x56, 0x31, 0xeb, 0xb8, 0x03, 0x47, 0xcc, 0xb6, 0x60, 0x4e, 0x4b, 0xe0,
+	0x16, 0x83, 0x31, 0xfb, 0x0c, 0x1c, 0x8f, 0xe0, 0x2e, 0xdf, 0x9f, 0x8b, 0xa6, 0x6c, 0xb1, 0xb9,
+	0x8b, 0x2f, 0xd9, 0x77, 0x92, 0xf7, 0x2d, 0x0b, 0x18, 0xef, 0x6a, 0xfc, 0x04, 0x26, 0xb8, 0xe4,
+	0x65, 0x98, 0x3a, 0xda, 0xfb, 0x7a, 0x6f, 0xff, 0x9b, 0xbd, 0xca, 0x35, 0x54, 0x84, 0x89, 0xa3,
+	0x56, 0xdd, 0xac, 0x68, 0x68, 0x06, 0x4a, 0xb5, 0x56, 0xab, 0xd9, 0x3a, 0xac, 0xed, 0x1d, 0x56,
+	0x72, 0xc6, 0x7f, 0x69, 0x80, 0x92, 0x1b, 0x3e, 0x3b, 0xae, 0x7a, 0x6e, 0x97, 0x28, 0x07, 0x13,
+	0x0d, 0x74, 0x0f, 0xca, 0x94, 0xf4, 0xfa, 0x8c, 0x78, 0xe0, 0x09, 0x85, 0x6a, 0x8f, 0xaf, 0x99,
+	0x51, 0xe0, 0xef, 0x34, 0x0d, 0xad, 0xc1, 0x7c, 0x0f, 0xbf, 0x6e, 0xbb, 0x03, 0xda, 0x1f, 0x04,
+	0xee, 0x93, 0xe7, 0x5e, 0x31, 0xd7, 0xc3, 0xaf, 0xf7, 0x39, 0x5c, 0x3a, 0xc5, 0x2a, 0x4c, 0x33,
+	0xda, 0xc0, 0x31, 0x26, 0xb8, 0x63, 0x40, 0x0f, 0xbf, 0xde, 0x92, 0xbe, 0x71, 0x1f, 0x2a, 0x8c,
+	0x82, 0xba, 0x14, 0xdb, 0x8a, 0x59, 0x81, 0x33, 0x9b, 0xed, 0xe1, 0xd7, 0x87, 0x0c, 0x2c, 0x78,
+	0x6d, 0xce, 0xc2, 0x74, 0x3b, 0x22, 0x8a, 0xd1, 0x87, 0x32, 0x5b, 0x30, 0xfb, 0x7d, 0x36, 0x35,
+	0x1f, 0x2d, 0x03, 0x9c, 0xb8, 0x5e, 0x87, 0xb4, 0x23, 0x2b, 0xa7, 0xc4, 0x21, 0x8c, 0x8a, 0xa1,
+	0xbb, 0xc4, 0xb9, 0xe0, 0x58, 0xe6, 0xc4, 0x6c, 0x13, 0x2a, 0x31, 0x08, 0xc3, 0xf2, 0x6d, 0xaa,
+	0x6b, 0xf9, 0xf8, 0xd8, 0x26, 0x92, 0x22, 0xcf, 0x0d, 0x33, 0x2d, 0x81, 0x9c, 0xc8, 0xf8, 0x97,
+	0x3c, 0x54, 0x5b, 0x14, 0x7b, 0x34, 0xea, 0x59, 0x26, 0x79, 0x35, 0x20, 0x3e, 0x65, 0xbb, 0x6e,
+	0xdc, 0x65, 0x55, 0x13, 0x3d, 0x82, 0x69, 0xbe, 0xbb, 0xb9, 0x42, 0x52, 0xae, 0xd8, 0xf2, 0xc6,
+	0xcd, 0x88, 0xa7, 0x46, 0xe6, 0x61, 0x96, 0x69, 0xd8, 0x40, 0x1b, 0x50, 0x3a, 0x27, 0xde, 0xb1,
+	0xeb, 0x5b, 0xf4, 0x82, 0x8b, 0x34, 0xbb, 0xb1, 0x10, 0xe9, 0xf7, 0x4c, 0xe1, 0xcc, 0x90, 0x2c,
+	0x76, 0xfc, 0x4f, 0xbc, 0xc5, 0xf1, 0x5f, 0x18, 0x3e, 0xfe, 0xef, 0xc1, 0x6c, 0xb8, 0xb3, 0xb7,
+	0xad, 0xae, 0x2f, 0xf7, 0xf2, 0x99, 0x10, 0xda, 0xec, 0xfa, 0xb1, 0xa3, 0x7e, 0x6a, 0xe8, 0xa8,
+	0x57, 0xe7, 0x78, 0x31, 0x72, 0x8e, 0xdf, 0x85, 0x99, 0x57, 0x03, 0xab, 0xf3, 0x92, 0x9f, 0xfe,
+	0x16, 0xf1, 0xf9, 0x4e, 0x5c, 0x34, 0xa7, 0x39, 0xd0, 0x14, 0x30, 0xf4, 0x10, 0x6e, 0xf8, 0x56,
+	0xcf, 0xb2, 0xb1, 0xd7, 0xee, 0x44, 0x94, 0xef, 0xf3, 0xbd, 0xb9, 0x68, 0x2e, 0x48, 0x64, 0xd4,
+	0x30, 0xbe, 0xf1, 0xfb, 0x1c, 0x2c, 0xa6, 0xd8, 0xcb, 0xef, 0xbb, 0x8e, 0x4f, 0xd0, 0x87, 0x30,
+	0x17, 0x65, 0xd5, 0x0e, 0x4e, 0xdd, 0xd9, 0x28, 0xb8, 0x99, 0x15, 0xdc, 0x2d, 0x40, 0x41, 0x84,
+	0x2b, 0x62, 0xeb, 0x15, 0x0d, 0xf4, 0xf9, 0xf0, 0x64, 0x26, 0x12, 0x27, 0xe5, 0x53, 0x35, 0xaf,
+	0x8b, 0xa1, 0x39, 0xb6, 0xb2, 0xe6, 0x28, 0x4e, 0xdb, 0x3b, 0x11, 0x1e, 0xad, 0xe4, 0x74, 0xd3,
+	0x75, 0x10, 0xc4, 0x2c, 0x93, 0x61, 0xcc, 0x62, 0xbc, 0x80, 0xeb, 0x29, 0x0c, 0xde, 0x81, 0x42,
+	0xfc, 0x8e, 0xeb, 0x11, 0xb9, 0xfb, 0x8b, 0x86, 0xf1, 0x9f, 0x39, 0xb8, 0xbd, 0xe5, 0x3a, 0xd4,
+	0x72, 0x06, 0x24, 0x6d, 0xd9, 0x5c, 0x7a, 0xd0, 0xc8, 0xfa, 0xca, 0x8d, 0x5e, 0x5f, 0xf9, 0x37,
+	0x5c, 0x5f, 0x13, 0x97, 0x5b, 0x5f, 0xc9, 0x65, 0x50, 0x48, 0x5b, 0x06, 0x09, 0xb7, 0x9e, 0x4c,
+	0x71, 0xeb, 0xd4, 0xbd, 0x74, 0x2a, 0x75, 0x2f, 0x35, 0xfa, 0xb0, 0x94, 0xae, 0x48, 0xe9, 0xcf,
+	0x81, 0x43, 0x6a, 0x23, 0x1d, 0x32, 0x77, 0x69, 0x87, 0x34, 0x7e, 0x09, 0x10, 0xe2, 0x18, 0x7f,
+	0x1b, 0x1f, 0x87, 0x87, 0x06, 0x6f, 0x64, 0x9b, 0xc5, 0xf8, 0x27, 0x0d, 0xaa, 0xbb, 0x96, 0x1f,
+	0x5b, 0x7c, 0x7e, 0xc4, 0xec, 0x96, 0xd3, 0xb1, 0x07, 0x5d, 0xd2, 0x56, 0x57, 0x0a, 0x8d, 0xeb,
+	0x67, 0x56, 0x82, 0x55, 0x38, 0xf0, 0x11, 0x54, 0x14, 0xa1, 0x0a, 0xdf, 0xf9, 0x40, 0x45, 0x53,
+	0x31, 0xa8, 0x49, 0x30, 0x0b, 0x02, 0xf9, 0xd9, 0xc9, 0x6e, 0x76, 0x99, 0xe7, 0xf7, 0x0e, 0xbb,
+	0xfc, 0x3d, 0xc1, 0xfe, 0x4b, 0xb3, 0xc8, 0x88, 0xd9, 0x97, 0xf1, 0x2d, 0x2c, 0xa6, 0x08, 0x2a,
+	0xd5, 0xfa, 0x25, 0xcc, 0xc4, 0x57, 0xa3, 0xc6, 0x15, 0x78, 0x2b, 0x23, 0xd0, 0x30, 0xe3, 0xd4,
+	0xc6, 0xbf, 0x6a, 0x70, 0x7b, 0x9b, 0xf8, 0x1d, 0xcf, 0x3a, 0x7e, 0x3b, 0xff, 0x7f, 0x08, 0x53,
+	0x67, 0x96, 0x4f, 0x5d, 0xef, 0x42, 0x1e, 0x20, 0x8b, 0x11, 0x09, 0x1e, 0x0b, 0x8c, 0xf2, 0x71,
+	0x45, 0xf9, 0xe6, 0x2a, 0xf9, 0x0f, 0x0d, 0x66, 0xe3, 0x4c, 0x59, 0x70, 0x22, 0xaf, 0x36, 0x6d,
+	0xd7, 0x91, 0x6e, 0x56, 0x34, 0xcb, 0x12, 0xb6, 0xef, 0xd8, 0x17, 0x8c, 0x84, 0xb9, 0x73, 0x70,
+	0xdd, 0xc9, 0x89, 0xf8, 0xa5, 0x87, 0x5f, 0xab, 0xab, 0x0e, 0x5a, 0x87, 0xeb, 0x2c, 0x74, 0xf4,
+	0x88, 0xef, 0xb7, 0x45, 0x50, 0x78, 0x41, 0x89, 0x58, 0xb3, 0x05, 0x73, 0x5e, 0xa1, 0xf6, 0x59,
+	0x6c, 0xc8, 0x10, 0xe8, 0x4b, 0x50, 0xa1, 0x93, 0x98, 0xc4, 0xc4, 0xd8, 0x49, 0x94, 0x25, 0x3d,
+	0x9f, 0xc7, 0xaf, 0x61, 0x29, 0x5d, 0xfb, 0xd2, 0xba, 0x5f, 0xf0, 0xc0, 0x3c, 0x80, 0xf3, 0x49,
+	0x8d, 0x30, 0x6e, 0x8c, 0xd8, 0xd8, 0x84, 0x5b, 0x26, 0xa1, 0xde, 0xc5, 0x4e, 0x78, 0x53, 0xbd,
+	0xaa, 0x59, 0x8d, 0x4f, 0xa0, 0x9a, 0xe4, 0x31, 0x6a, 0x45, 0xb3, 0x51, 0xb7, 0xb0, 0xd3, 0x21,
+	0x76, 0x78, 0x94, 0x5f, 0x79, 0xd4, 0x5f, 0x40, 0x35, 0xc9, 0x43, 0x8e, 0xba, 0x04, 0xa5, 0x0e,
+	0xc7, 0xd9, 0x44, 0x74, 0x2f, 0x9a, 0x21, 0xc0, 0x78, 0x0e, 0x73, 0x4f, 0xb0, 0xf7, 0xd2, 0x24,
+	0xb8, 0x7b, 0x65, 0x17, 0x5e, 0x06, 0x50, 0xb6, 0xb4, 0xba, 0x72, 0xbb, 0x28, 0x49, 0x48, 0xb3,
+	0x6b, 0x20, 0xa8, 0x84, 0xac, 0x85, 0x30, 0xc6, 0x16, 0xdc, 0x68, 0x11, 0xb6, 0xf0, 0x5b, 0xa4,
+	0x87, 0x1d, 0x6a, 0x75, 0xd4, 0xa0, 0x0b, 0x50, 0x78, 0x35, 0x20, 0x5e, 0xa0, 0x1b, 0xde, 0x60,
+	0x50, 0xdb, 0xea, 0x59, 0x54, 0x7a, 0x9e, 0x68, 0x18, 0xff, 0xad, 0xc1, 0xcd, 0x61, 0x2e, 0x72,
+	0xb2, 0x9b, 0x30, 0x25, 0xee, 0x4c, 0x6a, 0x5d, 0xdf, 0x8f, 0x9e, 0xb2, 0xa9, 0x7d, 0xd6, 0x4d,
+	0xde, 0xc1, 0x54, 0x1d, 0xf5, 0xdf, 0x68, 0x30, 0x29, 0x60, 0x97, 0x57, 0xc5, 0xa3, 0xf8, 0xb6,
+	0x79, 0x89, 0xfc, 0x8e, 0xa2, 0xcf, 0x38, 0x67, 0xff, 0xa0, 0x01, 0x84, 0x77, 0xf0, 0x44, 0x16,
+	0x41, 0x87, 0xe2, 0x89, 0x65, 0x13, 0x07, 0xf7, 0xd4, 0x3e, 0x1d, 0xb4, 0xa3, 0x97, 0x53, 0x7a,
+	0xd1, 0x27, 0x32, 0xa0, 0x51, 0x97, 0xd3, 0xc3, 0x8b, 0x3e, 0x8f, 0xdb, 0x7c, 0xeb, 0x07, 0xc2,
+	0x57, 0x5f, 0xde, 0xe4, 0xdf, 0xe8, 0x11, 0x40, 0xc7, 0x23, 0x98, 0x8a, 0x4b, 0x77, 0x61, 0x7c,
+	0x36, 0x41, 0x52, 0xd7, 0xa8, 0x41, 0xe0, 0x4e, 0x8b, 0xc4, 0xf6, 0xdb, 0x5d, 0x19, 0x21, 0x5e,
+	0xd9, 0xa7, 0xa2, 0xd1, 0x66, 0x2e, 0x1e, 0x6d, 0x1a, 0x5f, 0xc2, 0x4a, 0xe6, 0x30, 0xd2, 0xfe,
+	0xd1, 0xee, 0xda, 0x50, 0x77, 0x1b, 0x6e, 0x1d, 0xf5, 0x6d, 0x17, 0x77, 0x43, 0xbd, 0x2a, 0xf1,
+	0xa2, 0xea, 0xd4, 0xc6, 0xa8, 0x33, 0x97, 0xaa, 0x4e, 0x9e, 0x0b, 0xc9, 0xf3, 0x34, 0x00, 0xff,
+	0x36, 0x9e, 0x42, 0x35, 0x39, 0x9a, 0x94, 0xf2, 0x53, 0x80, 0x30, 0xb8, 0x90, 0x7b, 0x54, 0x46,
+	0xf2, 0x25, 0x42, 0x68, 0xfc, 0x25, 0x2c, 0x6e, 0xbb, 0xdf, 0x3b, 0xe9, 0x53, 0xb8, 0x0b, 0x33,
+	0xb1, 0x30, 0x46, 0xce, 0x63, 0x3a, 0x1a, 0xc5, 0x18, 0xa7, 0xa0, 0xa7, 0x71, 0x78, 0x2b, 0xb1,
+	0x82, 0xd9, 0xe7, 0x22, 0xb3, 0xf7, 0x61, 0x66, 0xd7, 0xed, 0x70, 0x1b, 0xd5, 0x6c, 0x0b, 0xf3,
+	0xb8, 0x35, 0xa2, 0x5d, 0xfe, 0x2d, 0x8c, 0x45, 0x2d, 0x3a, 0xe8, 0xca, 0xdb, 0xa9, 0x19, 0xb4,
+	0xd9, 0xae, 0x65, 0xbb, 0xce, 0xa9, 0x40, 0x8a, 0x95, 0x11, 0x02, 0xc2, 0xd8, 0x65, 0x22, 0x12,
+	0xbb, 0x18, 0x4d, 0xb8, 0xd5, 0x22, 0x34, 0x36, 0xae, 0xd2, 0xce, 0x3a, 0x14, 0x30, 0x6b, 0xcb,
+	0x59, 0x45, 0x33, 0x3c, 0x71, 0x7a, 0x41, 0x66, 0x7c, 0x05, 0xd5, 0x24, 0x2b, 0xa9, 0xa6, 0xab,
+	0xf2, 0xfa, 0x04, 0xf4, 0x6d, 0x62, 0x13, 0x4a, 0x52, 0x25, 0x4b, 0x51, 0x8c, 0xb1, 0x0c, 0xb7,
+	0x53, 0x7b, 0xc8, 0x4d, 0x74, 0x09, 0x74, 0x16, 0xdf, 0xc4, 0x90, 0x44, 0x31, 0x34, 0x9e, 0xc2,
+	0xed, 0x54, 0xac, 0x94, 0x7e, 0x03, 0xa6, 0xb0, 0x00, 0xc9, 0x1d, 0x32, 0x5b, 0x7e, 0x45, 0x68,
+	0xfc, 0x0c, 0xa6, 0x8f, 0x7c, 0xe2, 0x6d, 0x61, 0x9b, 0x38, 0x5d, 0xec, 0xa5, 0x1a, 0xb3, 0x02,
+	0xf9, 0x81, 0xa7, 0xd2, 0x59, 0xec, 0xd3, 0x78, 0xc2, 0x76, 0x69, 0x1a, 0xed, 0xa8, 0xe6, 0xfc,
+	0x10, 0x8a, 0x1d, 0x09, 0x4a, 0x39, 0xa1, 0x63, 0x3d, 0x02, 0x42, 0x63, 0x8f, 0x5b, 0x37, 0xce,
+	0x4e, 0xce, 0xe9, 0x8d, 0xf8, 0x3d, 0x80, 0x45, 0xa1, 0xe4, 0x34, 0x09, 0xd3, 0xac, 0xb2, 0x04,
+	0x7a, 0x5a, 0x07, 0x69, 0x14, 0x5d, 0x44, 0xc7, 0x51, 0x5c, 0x60, 0x92, 0xbf, 0xd3, 0x60, 0x31,
+	0x05, 0x19, 0x2c, 0xbb, 0x92, 0x12, 0x2a, 0x2d, 0x1a, 0x8d, 0x8d, 0x16, 0x52, 0xb2, 0xac, 0x65,
+	0x07, 0xdb, 0x5d, 0x7c, 0x2e, 0x4f, 0x9c, 0xa8, 0x1d, 0xb7, 0xb0, 0xbd, 0x5d, 0x7b, 0x56, 0xeb,
+	0xf0, 0xb4, 0x93, 0x29, 0xe9, 0x8c, 0x2f, 0x61, 0x26, 0x86, 0x50, 0x36, 0xd3, 0x02, 0x9b, 0xb1,
+	0x25, 0x39, 0xf0, 0x89, 0x17, 0x3d, 0x57, 0x54, 0xdb, 0xb0, 0xb8, 0x01, 0xe2, 0xac, 0xa5, 0xba,
+	0x98, 0x53, 0x09, 0x48, 0xca, 0xa2, 0x88, 0xf7, 0x50, 0x84, 0x6c, 0xa8, 0x3e, 0xf6, 0xfd, 0xef,
+	0x5d, 0x4f, 0xc5, 0x0e, 0x41, 0xdb, 0xd8, 0xe3, 0xcb, 0x6f, 0x68, 0xa8, 0x88, 0x03, 0x5f, 0x71,
+	0xac, 0xd0, 0x74, 0x69, 0xd2, 0x87, 0xcb, 0x2d, 0x75, 0x40, 0x03, 0x03, 0x8a, 0x9e, 0x39, 0x3b,
+	0x96, 0x4d, 0x89, 0xc7, 0x8e, 0x4b, 0xd7, 0xee, 0x12, 0xaf, 0x4d, 0xcf, 0xb0, 0x8a, 0x33, 0x47,
+	0x1e, 0x97, 0x9c, 0xfa, 0xf0, 0x0c, 0x3b, 0x4c, 0xed, 0x14, 0x9f, 0xaa, 0xa5, 0x42, 0xf1, 0xa9,
+	0xf1, 0x1b, 0x0d, 0xee, 0x6c, 0x0e, 0xec, 0x97, 0x52, 0x8c, 0xb4, 0x1b, 0xd6, 0x47, 0x50, 0x19,
+	0x3a, 0x41, 0x85, 0xb3, 0x94, 0xcc, 0xb9, 0xf8, 0x11, 0xea, 0xa3, 0x4f, 0x61, 0xf2, 0x84, 0x0b,
+	0x59, 0xcd, 0x25, 0xf2, 0x45, 0xc9, 0x99, 0x98, 0x92, 0xd8, 0xd8, 0x83, 0x95, 0x4c, 0x19, 0xc2,
+	0x08, 0x36, 0xd4, 0x7c, 0xc1, 0x14, 0x0d, 0x74, 0x03, 0x26, 0x5f, 0xb8, 0xc7, 0x61, 0x0c, 0x58,
+	0x78, 0xe1, 0x1e, 0x37, 0xbb, 0xc6, 0x6f, 0x35, 0xc1, 0x50, 0x5e, 0xe8, 0xfe, 0x9f, 0x66, 0xb5,
+	0x0f, 0xab, 0xd9, 0x42, 0xbc, 0xc9, 0xb4, 0xfe, 0x94, 0x83, 0x29, 0xc6, 0xf1, 0x2b, 0xf7, 0x38,
+	0x11, 0x96, 0xdd, 0x84, 0x49, 0xdc, 0xe1, 0x17, 0x0f, 0xd1, 0x45, 0xb6, 0xd8, 0x91, 0xe1, 0x53,
+	0x4c, 0x89, 0xcc, 0xf9, 0x45, 0x3d, 0x56, 0xb2, 0x5a, 0x6f, 0x31, 0xbc, 0x29, 0xc8, 0x98, 0x40,
+	0x3c, 0x83, 0x2a, 0xb3, 0xcd, 0xa2, 0x11, 0x8a, 0x59, 0x88, 0x8a, 0xb9, 0x00, 0x05, 0xe2, 0x79,
+	0xae, 0x27, 0x53, 0x42, 0xa2, 0x31, 0x14, 0xcd, 0x4d, 0x5d, 0x21, 0x9a, 0x63, 0x5d, 0x07, 0xfd,
+	0x2e, 0xa6, 0x97, 0xad, 0xbe, 0x94, 0x24, 0x75, 0x8d, 0xb2, 0x58, 0xa9, 0x2b, 0xe3, 0x8b, 0x36,
+	0xdb, 0x59, 0x64, 0xe9, 0x4f, 0xc1, 0x8e, 0x3c, 0xdb, 0xf8, 0x0c, 0x0a, 0x7c, 0xaa, 0xf1, 0x8c,
+	0x77, 0x19, 0xa6, 0xcc, 0xa3, 0xbd, 0xbd, 0xe6, 0x5e, 0xa3, 0xa2, 0xb1, 0xf4, 0xf7, 0xf6, 0xfe,
+	0x5e, 0xbd, 0x92, 0x43, 0x00, 0x93, 0x3b, 0xb5, 0xe6, 0x6e, 0x7d, 0xbb, 0x92, 0x37, 0xd6, 0x60,
+	0xbe, 0x41, 0xa8, 0x54, 0x97, 0xf2, 0x9f, 0xd0, 0x46, 0x5a, 0xd4, 0x46, 0x9f, 0x03, 0x8a, 0xd2,
+	0x4a, 0x33, 0xbf, 0x0f, 0xf9, 0x17, 0xee, 0xb1, 0x5c, 0xab, 0x28, 0x69, 0x03, 0x93, 0xa1, 0xd9,
+	0x6e, 0x20, 0xb9, 0xd7, 0x5f, 0xf7, 0x5d, 0x8f, 0x4a, 0xcf, 0x51, 0x9b, 0xc5, 0xa7, 0xb0, 0x94,
+	0x8e, 0x96, 0x83, 0x64, 0x48, 0xf4, 0x3f, 0x1a, 0x2c, 0x8a, 0x0e, 0x6f, 0x95, 0x35, 0xd8, 0x82,
+	0xc9, 0x13, 0xd7, 0xeb, 0x61, 0x2a, 0xeb, 0x23, 0x1f, 0x47, 0x66, 0x91, 0xc9, 0x7e, 0x7d, 0x87,
+	0x77, 0x31, 0x65, 0x57, 0x76, 0x67, 0x57, 0x39, 0x18, 0x9e, 0x68, 0xa3, 0x1e, 0xee, 0x10, 0x95,
+	0x22, 0x9f, 0x97, 0x28, 0x96, 0x63, 0x3b, 0xe4, 0x08, 0xe3, 0x23, 0x98, 0x14, 0x1c, 0xd0, 0x34,
+	0x14, 0x9f, 0xd4, 0xcc, 0xaf, 0xb7, 0x83, 0x32, 0xc5, 0x57, 0xad, 0xfd, 0xbd, 0x8a, 0x86, 0xa6,
+	0x20, 0x7f, 0xb0, 0xbd, 0x53, 0xc9, 0x19, 0x2e, 0xe8, 0x69, 0x62, 0x84, 0xd1, 0xf9, 0xbb, 0x0e,
+	0xb3, 0x5f, 0xc1, 0xfc, 0x81, 0xe5, 0xa8, 0x4b, 0xd5, 0xbb, 0xbd, 0xc1, 0xb2, 0xa5, 0x35, 0x70,
+	0xfa, 0x96, 0x23, 0x55, 0x23, 0x1a, 0xc6, 0x3e, 0xa0, 0xe8, 0x90, 0x72, 0x6e, 0x8f, 0xe2, 0xf5,
+	0x82, 0x2b, 0xdc, 0x00, 0x8d, 0x6d, 0x11, 0x1d, 0x1c, 0xf0, 0x22, 0xa6, 0xc4, 0xfa, 0x57, 0xce,
+	0x01, 0x3c, 0x07, 0x3d, 0x8d, 0x4b, 0x90, 0x18, 0x09, 0x5f, 0x20, 0x68, 0x57, 0x7c, 0x81, 0x60,
+	0xfc, 0x83, 0x06, 0xc8, 0xc4, 0x94, 0xfc, 0x48, 0x6a, 0x0e, 0xeb, 0xaf, 0xf9, 0xcb, 0xd5, 0x5f,
+	0x8d, 0x03, 0xb8, 0x1e, 0x93, 0xe7, 0xed, 0x6d, 0xf0, 0x87, 0x1c, 0xa0, 0x6d, 0xeb, 0x94, 0xf8,
+	0xb4, 0x35, 0x38, 0xf6, 0x3b, 0x9e, 0xc5, 0xb3, 0x64, 0xac, 0xc2, 0x7e, 0xe2, 0xb1, 0xe9, 0x3a,
+	0x1d, 0x91, 0x9a, 0x98, 0xdd, 0xd0, 0x23, 0x3c, 0x45, 0x8f, 0x1d, 0x45, 0x61, 0x86, 0xc4, 0xe8,
+	0x53, 0x28, 0x76, 0x89, 0x6d, 0x9d, 0x13, 0x99, 0xe0, 0x9b, 0xdd, 0x58, 0x4c, 0x74, 0xdc, 0x96,
+	0x04, 0x66, 0x40, 0x2a, 0x8a, 0xfd, 0x03, 0x87, 0x7a, 0x17, 0x61, 0xb1, 0x9f, 0x37, 0x45, 0x71,
+	0xf8, 0x94, 0x9d, 0x2d, 0x13, 0xaa, 0x38, 0xcc, 0x5a, 0x2c, 0x91, 0xe8, 0x90, 0xd7, 0xf4, 0x72,
+	0x97, 0xf6, 0x49, 0x46, 0x5a, 0xa3, 0xe8, 0x97, 0xb2, 0x2a, 0xeb, 0xb3, 0xf5, 0x86, 0x45, 0x65,
+	0x72, 0x74, 0x4f, 0x60, 0xf4, 0x2d, 0xe2, 0xd0, 0x1a, 0x35, 0xfe, 0x5d, 0x83, 0xa5, 0x16, 0xa1,
+	0x49, 0x7d, 0x29, 0xcf, 0xf8, 0xf3, 0x57, 0x9b, 0x71, 0x0c, 0xcb, 0x19, 0x53, 0x90, 0xce, 0x54,
+	0x63, 0xf9, 0xd1, 0x10, 0x5e, 0xd5, 0x12, 0x51, 0x47, 0x4a, 0xe7, 0x58, 0x17, 0xe3, 0x0e, 0x2c,
+	0x35, 0x46, 0xa8, 0x89, 0xc9, 0xd0, 0xf8, 0xb1, 0x65, 0xf8, 0xad, 0x06, 0xe5, 0x03, 0x8f, 0x9c,
+	0x10, 0x8f, 0x38, 0x1d, 0xc2, 0xae, 0x0d, 0xf3, 0x67, 0x04, 0xdb, 0xf4, 0xac, 0x8d, 0xbb, 0xe7,
+	0x96, 0xef, 0x7a, 0x16, 0x11, 0x37, 0xd9, 0xe2, 0xe3, 0x6b, 0x66, 0x45, 0xa0, 0x6a, 0x01, 0x86,
+	0x15, 0x88, 0x57, 0xa0, 0x48, 0xad, 0x1e, 0xf9, 0xc1, 0x75, 0xe4, 0xae, 0xfc, 0x58, 0x33, 0x03,
+	0xc8, 0xef, 0x34, 0x6d, 0x73, 0x01, 0x50, 0x3b, 0xc1, 0x73, 0xb3, 0x0c, 0xa5, 0xb6, 0xa2, 0x32,
+	0x9e, 0xb2, 0xbc, 0x1f, 0x8d, 0xc8, 0x11, 0x7a, 0x4a, 0xb9, 0x1f, 0x42, 0xab, 0x5a, 0xa2, 0xd6,
+	0x13, 0xed, 0x13, 0x25, 0x35, 0x4c, 0x7e, 0xbd, 0x8c, 0xb1, 0x94, 0x5a, 0x7b, 0x73, 0x9e, 0xb7,
+	0xe0, 0x46, 0x23, 0x4d, 0x4c, 0x36, 0x58, 0xe3, 0x5d, 0x0f, 0xf6, 0x47, 0x0d, 0x16, 0xf6, 0x5c,
+	0x6a, 0x9d, 0x58, 0x9d, 0x78, 0x39, 0x5f, 0x87, 0x62, 0xe7, 0x0c, 0x3b, 0x0e, 0xb1, 0x55, 0x30,
+	0x1c, 0xb4, 0xd1, 0xcf, 0x60, 0xd2, 0x73, 0x07, 0x34, 0x28, 0xfc, 0x44, 0x1f, 0x48, 0x44, 0x99,
+	0x99, 0x8c, 0xc8, 0x94, 0xb4, 0x3c, 0x46, 0xec, 0x61, 0xcb, 0x56, 0xc5, 0x4d, 0xde, 0x40, 0x3f,
+	0x87, 0xf2, 0xab, 0x81, 0x45, 0x68, 0xfb, 0xcc, 0x1d, 0x78, 0xaa, 0xb8, 0x3c, 0x54, 0x49, 0x22,
+	0xf4, 0x31, 0x43, 0x9a, 0xf0, 0x2a, 0xf8, 0x36, 0xb6, 0x60, 0x3e, 0x31, 0x14, 0x3b, 0x9c, 0x5f,
+	0x5a, 0x8e, 0x3a, 0x01, 0xf8, 0x77, 0x6c, 0x22, 0xb9, 0xf8, 0x44, 0x8c, 0x03, 0x5e, 0x8c, 0x92,
+	0x2c, 0x99, 0x80, 0x3e, 0xc5, 0x1e, 0x55, 0x41, 0x13, 0x6f, 0xb0, 0x8b, 0x12, 0x71, 0xd4, 0x81,
+	0x91, 0x27, 0x82, 0x63, 0xe0, 0x8b, 0x62, 0x2e, 0x41, 0xdb, 0xf8, 0x1b, 0x9e, 0x85, 0x4c, 0xd3,
+	0xa8, 0x72, 0xb6, 0x2f, 0x22, 0xa5, 0xf4, 0xe4, 0x01, 0x91, 0xda, 0x33, 0xe8, 0x60, 0x7c, 0xc7,
+	0xb3, 0x8f, 0xe9, 0xec, 0xc3, 0x43, 0xf6, 0xcd, 0xf9, 0xaf, 0xc2, 0x9d, 0xc6, 0x48, 0xf1, 0x8d,
+	0xbf, 0xd7, 0x60, 0xa5, 0xf1, 0x23, 0x8a, 0x80, 0x7e, 0x0a, 0x08, 0x9f, 0x63, 0xcb, 0xe6, 0xef,
+	0x26, 0x86, 0x2c, 0x37, 0x1f, 0x60, 0xb6, 0x94, 0x09, 0xff, 0x4d, 0x03, 0x38, 0x18, 0xf8, 0x67,
+	0xdb, 0xfc, 0x21, 0x95, 0xb8, 0xb4, 0xbc, 0x24, 0x8e, 0xb2, 0x21, 0x6f, 0xb0, 0xdc, 0x4c, 0xdf,
+	0xc6, 0x94, 0x85, 0x9e, 0x72, 0x43, 0x8f, 0x26, 0x37, 0x58, 0xf7, 0x03, 0x89, 0x36, 0x03, 0xc2,
+	0xf8, 0x73, 0xad, 0xfc, 0xd0, 0x73, 0xad, 0x5f, 0xc1, 0x0c, 0xdb, 0xc3, 0x7d, 0x4a, 0x3c, 0x71,
+	0x45, 0x19, 0xff, 0xf2, 0x6d, 0x3a, 0xec, 0x50, 0xa3, 0xc6, 0x09, 0x2c, 0x9a, 0xb2, 0x1d, 0x8a,
+	0x1f, 0x29, 0x44, 0xbc, 0xa3, 0x59, 0x18, 0x5f, 0x83, 0x9e, 0x36, 0x8e, 0xb4, 0xd4, 0x4f, 0x61,
+	0x52, 0x4c, 0x29, 0x25, 0xd3, 0x1a, 0x21, 0x97, 0x44, 0xc6, 0x43, 0xb8, 0x7d, 0xe4, 0x78, 0x57,
+	0x13, 0x9b, 0x1d, 0x40, 0xe9, 0x9d, 0x64, 0x6a, 0xa3, 0x0a, 0x37, 0x79, 0xcc, 0x18, 0x60, 0x02,
+	0x5f, 0xfb, 0x0a, 0x6e, 0x25, 0x30, 0x52, 0xf0, 0x07, 0x30, 0x25, 0x64, 0x52, 0x91, 0x64, 0x86,
+	0xe4, 0x8a, 0xca, 0xf8, 0xc7, 0x1c, 0x4c, 0xb5, 0x88, 0xef, 0xa7, 0xbd, 0xb4, 0x8d, 0x59, 0x3a,
+	0x37, 0x64, 0xe9, 0x65, 0x00, 0x96, 0x7d, 0x6a, 0xe3, 0xd3, 0xf0, 0xf1, 0x63, 0x89, 0x41, 0x6a,
+	0x0c, 0x30, 0x74, 0xc7, 0x9d, 0xb8, 0xca, 0x1d, 0x37, 0x8c, 0x7f, 0x88, 0x73, 0xb9, 0xc8, 0x49,
+	0xc6, 0x3f, 0xc4, 0x11, 0x37, 0x64, 0x8f, 0x9c, 0xbb, 0x2f, 0x49, 0xf7, 0x72, 0xb1, 0x53, 0x49,
+	0x52, 0xd7, 0xf8, 0xb3, 0xa2, 0xce, 0xc0, 0xf3, 0x88, 0x23, 0x2e, 0xe5, 0x45, 0x53, 0x35, 0x8d,
+	0x1b, 0x70, 0x9d, 0x3f, 0x6e, 0x91, 0x9a, 0x52, 0x86, 0xf8, 0x16, 0x16, 0xe2, 0x60, 0x69, 0x85,
+	0x9f, 0xc0, 0x94, 0x2f, 0x40, 0x29, 0x17, 0x5a, 0x45, 0xac, 0x48, 0x42, 0xf7, 0xc8, 0x45, 0xdd,
+	0xe3, 0x2f, 0xe0, 0x3a, 0x33, 0xb2, 0xa4, 0x4e, 0x2b, 0xe6, 0x4b, 0xc1, 0x87, 0x8a, 0xf9, 0xa6,
+	0x80, 0x1a, 0x3b, 0xb0, 0x10, 0xef, 0x1f, 0x64, 0xc8, 0x8b, 0x72, 0x60, 0xe5, 0x22, 0x69, 0xc2,
+	0x05, 0x34, 0xc6, 0xa7, 0xb0, 0x20, 0x58, 0xc6, 0xe7, 0xce, 0xec, 0x2f, 0x69, 0xc2, 0xbb, 0x45,
+	0x49, 0x42, 0x9a, 0x5d, 0xa3, 0x0e, 0x37, 0x86, 0xba, 0xbd, 0x89, 0x6e, 0x8c, 0xff, 0xcd, 0x41,
+	0xe1, 0xe9, 0xc0, 0xa5, 0x98, 0x9d, 0x61, 0x7d, 0x1b, 0xab, 0x35, 0xc4, 0xbf, 0xd1, 0xcf, 0x23,
+	0x17, 0xa7, 0x9c, 0xb4, 0x74, 0xf4, 0x84, 0x74, 0x29, 0x5e, 0xaf, 0xd9, 0xb6, 0xfb, 0x3d, 0x76,
+	0x3a, 0xd1, 0x57, 0xdb, 0x1b, 0x30, 0x19, 0x79, 0x4b, 0x37, 0xba, 0x97, 0xa4, 0x64, 0x7e, 0x15,
+	0x79, 0x37, 0x3b, 0x31, 0xb6, 0x5f, 0xfc, 0xc5, 0xac, 0x47, 0x7c, 0x42, 0xfd, 0xcb, 0x79, 0x73,
+	0x51, 0x10, 0xd7, 0xa8, 0xfe, 0x0a, 0x4a, 0x01, 0xc3, 0xb0, 0xde, 0x2a, 0x9e, 0x8f, 0x8a, 0x06,
+	0x53, 0xcb, 0xc0, 0x97, 0xef, 0x34, 0xf2, 0x26, 0xff, 0x66, 0xf5, 0x19, 0x8f, 0x45, 0x11, 0x8e,
+	0xba, 0xb6, 0xe5, 0xcd, 0x10, 0xc0, 0xb0, 0x03, 0x87, 0x77, 0x0e, 0xde, 0xac, 0x86, 0x00, 0x63,
+	0x1e, 0xe6, 0x1a, 0x84, 0xf2, 0xc9, 0x28, 0x2f, 0xff, 0x1c, 0x2a, 0x21, 0x48, 0x5a, 0xf1, 0x03,
+	0x56, 0x12, 0x76, 0x29, 0x96, 0x36, 0xac, 0x0c, 0x2b, 0xc2, 0x14, 0xe8, 0xb5, 0x53, 0x28, 0x05,
+	0x0f, 0x7b, 0xd0, 0x0d, 0x98, 0x7f, 0x56, 0x37, 0x37, 0xf7, 0x5b, 0xcd, 0xc3, 0xe7, 0xed, 0xed,
+	0xfa, 0x4e, 0xed, 0x68, 0xf7, 0xb0, 0x72, 0x2d, 0x0e, 0xde, 0xda, 0xdf, 0xdb, 0x6a, 0xb6, 0xea,
+	0x15, 0x0d, 0xdd, 0x04, 0x14, 0xa5, 0x3e, 0x14, 0xb9, 0xa6, 0x1c, 0x5a, 0x80, 0x4a, 0x08, 0xdf,
+	0x3c, 0xda, 0xdd, 0xad, 0x1f, 0x56, 0xf2, 0x6b, 0x16, 0xcc, 0xc4, 0xae, 0xa3, 0xe8, 0x0e, 0xe8,
+	0x4f, 0xea, 0xad, 0x56, 0xad, 0x51, 0x6f, 0x9b, 0xb5, 0xc3, 0xe6, 0x5e, 0xa3, 0x7d, 0xb4, 0xd7,
+	0x3a, 0xa8, 0x6f, 0x35, 0x77, 0x9a, 0xf5, 0xed, 0xca, 0x35, 0x74, 0x1b, 0x6e, 0x0d, 0xe1, 0x0f,
+	0x18, 0xcb, 0xe6, 0x33, 0x36, 0x76, 0x12, 0xb9, 0x57, 0x6f, 0xd4, 0x38, 0x32, 0xb7, 0xd6, 0x85,
+	0xb9, 0xa1, 0x4b, 0x12, 0xaa, 0xc2, 0xc2, 0x76, 0xb3, 0x51, 0x6f, 0x1d, 0xb6, 0x77, 0xcc, 0xfa,
+	0xd3, 0xa3, 0xfa, 0xde, 0xd6, 0xf3, 0xf6, 0xfe, 0xce, 0x4e, 0xe5, 0x1a, 0xd2, 0xe1, 0x66, 0x02,
+	0xb3, 0x5d, 0x6b, 0xee, 0x3e, 0x17, 0xa3, 0x24, 0x70, 0xdf, 0xd4, 0xeb, 0x5f, 0xef, 0x3e, 0xaf,
+	0xe4, 0xd6, 0xbe, 0x82, 0xd9, 0xf8, 0x8d, 0x2a, 0x42, 0xbe, 0x5d, 0xdf, 0x6d, 0x3e, 0xab, 0x9b,
+	0xcf, 0xdb, 0x52, 0xc8, 0xca, 0xb5, 0x34, 0xe4, 0x37, 0xf5, 0xcd, 0xc7, 0xfb, 0xfb, 0x5f, 0x57,
+	0xb4, 0xb5, 0xbf, 0x86, 0xe9, 0xe8, 0x31, 0x88, 0x96, 0x61, 0xf1, 0xe0, 0xa8, 0xf5, 0xb8, 0x7d,
+	0xb0, 0x5b, 0x3b, 0xdc, 0xd9, 0x37, 0x9f, 0x0c, 0xa9, 0xe6, 0x06, 0xcc, 0xc7, 0xd1, 0x3b, 0x5b,
+	0x4f, 0x84, 0x41, 0xe2, 0xe0, 0xda, 0xc1, 0x5e, 0xab, 0x92, 0xdb, 0xf8, 0xd3, 0x32, 0x94, 0xb7,
+	0xce, 0x30, 0x6d, 0x11, 0x8f, 0xc7, 0x1a, 0xdf, 0xc1, 0x7c, 0xe2, 0x25, 0x20, 0xba, 0x1b, 0x5d,
+	0xe5, 0x19, 0xef, 0x3a, 0xf5, 0xf7, 0x47, 0x13, 0x49, 0xdf, 0x3b, 0x85, 0x85, 0xb4, 0xc7, 0x59,
+	0xe8, 0x83, 0x78, 0x42, 0x21, 0xeb, 0x19, 0x9c, 0xfe, 0xe1, 0x58, 0x3a, 0x39, 0xd0, 0x77, 0x30,
+	0x9f, 0x78, 0xab, 0x14, 0x9b, 0x48, 0xd6, 0x93, 0x2b, 0xfd, 0xfd, 0xd1, 0x44, 0xe1, 0x44, 0xd2,
+	0x1e, 0xcc, 0xc4, 0x26, 0x32, 0xe2, 0x3d, 0x93, 0xfe, 0xe1, 0x58, 0x3a, 0x39, 0xd0, 0xaf, 0xa1,
+	0x32, 0xfc, 0xf0, 0x05, 0x19, 0x91, 0xce, 0x19, 0x2f, 0x6b, 0xf4, 0xbb, 0x23, 0x69, 0x42, 0xe6,
+	0xc3, 0xef, 0x5b, 0x62, 0xcc, 0x33, 0x1e, 0xd0, 0xe8, 0x77, 0x47, 0xd2, 0x48, 0xe6, 0x5b, 0x50,
+	0x54, 0xef, 0x54, 0x50, 0x74, 0xb7, 0x1d, 0x7a, 0x17, 0xa3, 0xdf, 0x4e, 0xc5, 0x49, 0x26, 0x47,
+	0x30, 0x1b, 0x7f, 0x5e, 0x82, 0x56, 0x47, 0xbc, 0x3c, 0x11, 0x0c, 0xdf, 0x1b, 0xfb, 0x36, 0x85,
+	0x4d, 0x7c, 0xf8, 0x15, 0x41, 0x6c, 0xe2, 0x19, 0x0f, 0x1a, 0xf4, 0xbb, 0x23, 0x69, 0x24, 0x73,
+	0x0c, 0x28, 0xf9, 0x1a, 0x00, 0x45, 0xfd, 0x2a, 0xf3, 0xb9, 0x81, 0x7e, 0x6f, 0x0c, 0x95, 0x1c,
+	0xa2, 0x2f, 0x6a, 0x86, 0x29, 0x4f, 0x36, 0xd0, 0x47, 0xb1, 0xd9, 0x8f, 0x7a, 0x3d, 0xa2, 0xaf,
+	0x5d, 0x86, 0x34, 0xd4, 0xd8, 0x70, 0xe5, 0x3e, 0xa6, 0xb1, 0x8c, 0x17, 0x02, 0xfa, 0xdd, 0x91,
+	0x34, 0x92, 0x79, 0x17, 0xae, 0xa7, 0x14, 0xe6, 0x51, 0x4c, 0x19, 0x99, 0xa5, 0x7e, 0xfd, 0x83,
+	0x71, 0x64, 0xe1, 0x28, 0x29, 0x15, 0xfc, 0xd8, 0x28, 0xd9, 0xf5, 0x7f, 0xfd, 0x83, 0x71, 0x64,
+	0x72, 0x94, 0xbf, 0x82, 0xb9, 0xa1, 0x7a, 0x3a, 0x8a, 0x3b, 0x64, 0x5a, 0xe9, 0x5e, 0x37, 0x46,
+	0x91, 0x44, 0xfc, 0x2a, 0x51, 0x28, 0x8f, 0xfb, 0x55, 0x56, 0xe1, 0x5d, 0xbf, 0x37, 0x86, 0x2a,
+	0xbe, 0x6d, 0x46, 0x71, 0xc9, 0x6d, 0x33, 0xad, 0x16, 0xaf, 0xbf, 0x3f, 0x9a, 0x28, 0xe6, 0x45,
+	0xf1, 0x6a, 0xf9, 0xd0, 0xd4, 0xd3, 0x4a, 0xc9, 0xfa, 0xdd, 0x91, 0x34, 0xc3, 0x5e, 0x14, 0xe7,
+	0x9f, 0x9c, 0x7a, 0xea, 0x10, 0x1f, 0x8c, 0x23, 0x0b, 0x97, 0x5e, 0x46, 0x39, 0x37, 0xb6, 0xf4,
+	0x46, 0x97, 0x9d, 0xf5, 0xb5, 0xcb, 0x90, 0xca, 0x11, 0x7d, 0xa8, 0x66, 0x95, 0x5a, 0xd1, 0x30,
+	0x9f, 0x11, 0x45, 0x61, 0xfd, 0xe3, 0x4b, 0xd1, 0xca, 0x41, 0x9b, 0x00, 0x61, 0xa9, 0x0f, 0x2d,
+	0xc5, 0x7e, 0x8d, 0x18, 0xaa, 0x16, 0xea, 0xcb, 0x19, 0xd8, 0xf0, 0xac, 0x4c, 0x2b, 0xed, 0xc5,
+	0xce, 0xca, 0x11, 0xa5, 0xc1, 0xd8, 0x59, 0x39, 0xb2, 0x46, 0x88, 0x01, 0x25, 0xab, 0x64, 0xb1,
+	0x05, 0x92, 0x59, 0xcb, 0xd3, 0xef, 0x8d, 0xa1, 0x0a, 0xd5, 0x12, 0x16, 0xa9, 0x62, 0x6a, 0x49,
+	0x94, 0xcb, 0xf4, 0xe5, 0x0c, 0x6c, 0x28, 0x6d, 0xb2, 0xb0, 0x84, 0x86, 0xd7, 0x51, 0x6a, 0xf5,
+	0x4a, 0xbf, 0x37, 0x86, 0x4a, 0x0e, 0xb1, 0x0b, 0xe5, 0x48, 0x3d, 0x07, 0x45, 0x05, 0x4a, 0xd6,
+	0x9d, 0xf4, 0x3b, 0x59, 0x68, 0xc9, 0xed, 0x05, 0x4f, 0x36, 0xa7, 0x54, 0x73, 0x3e, 0x8c, 0xaf,
+	0xce, 0xcc, 0xc4, 0xbc, 0x7e, 0x7f, 0x3c, 0x61, 0x38, 0x56, 0x63, 0xec, 0x58, 0x8d, 0xcb, 0x8e,
+	0x35, 0xba, 0x1a, 0xc0, 0x63, 0x8c, 0x68, 0x12, 0x7a, 0x28, 0xc6, 0x48, 0x49, 0x5c, 0xeb, 0xef,
+	0x8d, 0xa0, 0x08, 0xd9, 0x36, 0xb2, 0xd9, 0x36, 0xc6, 0xb2, 0xcd, 0x48, 0x8c, 0x8b, 0xa3, 0x3f,
+	0x35, 0xc1, 0x3d, 0x74, 0xf4, 0x8f, 0xc8, 0x79, 0xea, 0x6b, 0x97, 0x21, 0x0d, 0x47, 0x6c, 0x5c,
+	0x62, 0xc4, 0xc6, 0xe5, 0x47, 0x1c, 0x97, 0x6d, 0xc5, 0x80, 0x92, 0x19, 0xbe, 0xd8, 0xd2, 0xc8,
+	0x4c, 0x34, 0xea, 0xf7, 0xc6, 0x50, 0x85, 0x9b, 0x52, 0x5a, 0x0a, 0x2f, 0xb6, 0x29, 0x8d, 0x48,
+	0x0c, 0xea, 0x1f, 0x8e, 0xa5, 0x0b, 0xe3, 0x81, 0xa1, 0x8c, 0x5f, 0x2c, 0x1e, 0x48, 0xcf, 0x13,
+	0xea, 0xc6, 0x28, 0x12, 0xc9, 0x79, 0x1f, 0xa6, 0xa3, 0x29, 0x2c, 0x74, 0x67, 0xf8, 0x0a, 0x16,
+	0x4f, 0xfb, 0xe8, 0x2b, 0x99, 0xf8, 0x90, 0x61, 0x34, 0xef, 0x14, 0x63, 0x98, 0x92, 0xd0, 0xd2,
+	0x57, 0x32, 0xf1, 0x92, 0xa1, 0x09, 0x33, 0xb1, 0x4c, 0x12, 0x5a, 0x89, 0x19, 0x27, 0x99, 0x9a,
+	0xd2, 0x57, 0xb3, 0x09, 0xc2, 0x6b, 0x85, 0x4a, 0x69, 0xc4, 0xae, 0x15, 0x43, 0xa9, 0x0f, 0xfd,
+	0x76, 0x2a, 0x4e, 0x30, 0xd9, 0x9c, 0xf9, 0xb6, 0x6c, 0x39, 0x94, 0x78, 0x0e, 0xb6, 0x1f, 0xf4,
+	0x8f, 0x8f, 0x27, 0x79, 0x2a, 0xe7, 0xe1, 0xff, 0x0d, 0x00, 0x04, 0x6c, 0xe5, 0x48, 0x1f, 0x41,
+	0x00, 0x00,
 }
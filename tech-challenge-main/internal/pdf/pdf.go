@@ -0,0 +1,210 @@
+// Package pdf writes simple text documents as PDF: wrapped paragraphs in the standard
+// Helvetica fonts on A4 pages, enough for transcripts without pulling in a layout engine.
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"golang.org/x/text/encoding/charmap"
+)
+
+// Style is how a paragraph is set.
+type Style int
+
+const (
+	Body Style = iota
+	Title
+	Heading
+	// Note is small grey text, for secondary details.
+	Note
+)
+
+type font struct {
+	name  string // resource name in page content
+	size  float64
+	bold  bool
+	grey  bool
+	after float64 // space after the paragraph
+}
+
+var styles = map[Style]font{
+	Body:    {name: "F1", size: 10, after: 6},
+	Title:   {name: "F2", size: 16, bold: true, after: 4},
+	Heading: {name: "F2", size: 10.5, bold: true, after: 2},
+	Note:    {name: "F1", size: 8.5, grey: true, after: 4},
+}
+
+// A4 in points, with 2 cm margins.
+const (
+	pageWidth  = 595.0
+	pageHeight = 842.0
+	margin     = 56.0
+	lineHeight = 1.3
+)
+
+// Document accumulates paragraphs into pages. The zero value is not usable; call New.
+type Document struct {
+	title string
+	pages []*bytes.Buffer
+	y     float64 // baseline of the next line on the current page
+}
+
+// New starts a document; title is recorded in its metadata.
+func New(title string) *Document {
+	d := &Document{title: title}
+	d.newPage()
+	return d
+}
+
+func (d *Document) newPage() {
+	d.pages = append(d.pages, &bytes.Buffer{})
+	d.y = pageHeight - margin
+}
+
+// Space adds vertical space, in points.
+func (d *Document) Space(points float64) {
+	d.y -= points
+}
+
+// Paragraph adds text in style, wrapped to the page width. Line breaks in text are kept,
+// and pages are added as needed. Characters Helvetica can't show are replaced by "?".
+func (d *Document) Paragraph(style Style, text string) {
+	f := styles[style]
+	lead := f.size * lineHeight
+	for _, line := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		for _, l := range wrap(encode(line), f, pageWidth-2*margin) {
+			if d.y-lead < margin {
+				d.newPage()
+			}
+			d.y -= lead
+			page := d.pages[len(d.pages)-1]
+			if f.grey {
+				page.WriteString("0.4 g\n")
+			}
+			fmt.Fprintf(page, "BT /%s %.1f Tf %.1f %.1f Td (%s) Tj ET\n", f.name, f.size, margin, d.y, escape(l))
+			if f.grey {
+				page.WriteString("0 g\n")
+			}
+		}
+	}
+	d.y -= f.after
+}
+
+// Bytes renders the document.
+func (d *Document) Bytes() []byte {
+	var out bytes.Buffer
+	var offsets []int
+	object := func(body string) {
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	out.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	// 1 catalog, 2 page tree, 3-4 fonts, 5 info, then a page and its content per page.
+	const firstPage = 6
+	kids := make([]string, len(d.pages))
+	for i := range d.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", firstPage+2*i)
+	}
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.pages)))
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+	object(fmt.Sprintf("<< /Title (%s) >>", escape(encode(d.title))))
+	for i, content := range d.pages {
+		object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.0f %.0f] /Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>",
+			pageWidth, pageHeight, firstPage+2*i+1))
+		object(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", content.Len(), content.Bytes()))
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, off := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R /Info 5 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+	return out.Bytes()
+}
+
+// encode converts text to the WinAnsi encoding of the standard fonts.
+func encode(s string) string {
+	var b strings.Builder
+	for _, r := range strings.ReplaceAll(s, "\t", "    ") {
+		c, ok := charmap.Windows1252.EncodeRune(r)
+		if !ok || c < 0x20 {
+			c = '?'
+		}
+		b.WriteByte(c)
+	}
+	return b.String()
+}
+
+func escape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `(`, `\(`, `)`, `\)`).Replace(s)
+}
+
+// wrap splits encoded text into lines at most width points wide, breaking at spaces and,
+// for words longer than a line, within words. The indentation of s is kept on its first line.
+func wrap(s string, f font, width float64) []string {
+	if strings.TrimSpace(s) == "" {
+		return []string{""}
+	}
+
+	var lines []string
+	line := ""
+	for i, word := range strings.Fields(s) {
+		candidate := word
+		if i == 0 {
+			word = s[:len(s)-len(strings.TrimLeft(s, " "))] + word
+			candidate = word
+		} else if line != "" {
+			candidate = line + " " + word
+		}
+		if textWidth(candidate, f) <= width {
+			line = candidate
+			continue
+		}
+		if line != "" {
+			lines = append(lines, line)
+		}
+		for textWidth(word, f) > width {
+			n := 1
+			for n < len(word) && textWidth(word[:n+1], f) <= width {
+				n++
+			}
+			lines = append(lines, word[:n])
+			word = word[n:]
+		}
+		line = word
+	}
+	return append(lines, line)
+}
+
+// helvetica holds the widths of the printable ASCII characters in Helvetica, in thousandths
+// of the font size. Other characters are assumed as wide as a digit.
+var helvetica = [95]float64{
+	278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278, // space to /
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556, // 0 to ?
+	1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778, // @ to O
+	667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556, // P to _
+	333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556, // ` to o
+	556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584, // p to ~
+}
+
+func textWidth(s string, f font) float64 {
+	w := 0.0
+	for i := 0; i < len(s); i++ {
+		if c := s[i]; c >= 32 && c <= 126 {
+			w += helvetica[c-32]
+		} else {
+			w += 556
+		}
+	}
+	if f.bold {
+		w *= 1.1 // Helvetica-Bold runs about this much wider
+	}
+	return w * f.size / 1000
+}
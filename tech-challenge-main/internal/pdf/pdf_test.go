@@ -0,0 +1,77 @@
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestDocument(t *testing.T) {
+	d := New("Trip (draft)")
+	d.Paragraph(Title, "Trip to Barcelona")
+	d.Paragraph(Body, "Pack an umbrella (just in case) and a €20 note \\ cash.")
+	d.Paragraph(Note, "天気")
+	out := d.Bytes()
+
+	if !bytes.HasPrefix(out, []byte("%PDF-1.4\n")) || !bytes.HasSuffix(out, []byte("%%EOF\n")) {
+		t.Fatalf("not a PDF file:\n%s", out)
+	}
+	for _, want := range []string{
+		"/Title (Trip \\(draft\\))",
+		"(Trip to Barcelona) Tj",
+		"(Pack an umbrella \\(just in case\\) and a \x8020 note \\\\ cash.) Tj",
+		"(??) Tj",
+	} {
+		if !bytes.Contains(out, []byte(want)) {
+			t.Errorf("document is missing %q", want)
+		}
+	}
+
+	// Every cross-reference entry points at its object.
+	m := regexp.MustCompile(`startxref\n(\d+)`).FindSubmatch(out)
+	if m == nil {
+		t.Fatal("no startxref")
+	}
+	xref, _ := strconv.Atoi(string(m[1]))
+	entries := strings.Split(string(out[xref:]), "\n")[3:]
+	for i := 0; i < 5; i++ {
+		off, _ := strconv.Atoi(entries[i][:10])
+		if want := fmt.Sprintf("%d 0 obj", i+1); !bytes.HasPrefix(out[off:], []byte(want)) {
+			t.Errorf("xref entry %d points at %q", i+1, out[off:off+10])
+		}
+	}
+}
+
+func TestDocument_Pages(t *testing.T) {
+	d := New("Long")
+	for i := 0; i < 40; i++ {
+		d.Paragraph(Body, strings.Repeat("All work and no play makes a long transcript. ", 10))
+	}
+	if n := bytes.Count(d.Bytes(), []byte("/Type /Page ")); n < 2 {
+		t.Errorf("long document has %d pages", n)
+	}
+}
+
+func TestWrap(t *testing.T) {
+	f := styles[Body]
+	width := textWidth("the quick brown", f)
+
+	got := wrap("the quick brown fox jumps", f, width)
+	if len(got) != 2 || got[0] != "the quick brown" || got[1] != "fox jumps" {
+		t.Errorf("wrap = %q", got)
+	}
+
+	long := strings.Repeat("x", 200)
+	for _, l := range wrap(long, f, width) {
+		if textWidth(l, f) > width {
+			t.Errorf("line %q is wider than the page", l)
+		}
+	}
+
+	if got := wrap("    indented code", f, 1000); got[0] != "    indented code" {
+		t.Errorf("wrap lost indentation: %q", got)
+	}
+}
@@ -0,0 +1,213 @@
+// Package pii detects personal data in user messages: email addresses, phone numbers,
+// payment card numbers and passport numbers. Deployments choose whether it is only tagged,
+// keeping it out of logs, or also masked before messages are sent to the model.
+package pii
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// Kind is a category of personal data.
+type Kind string
+
+const (
+	Email      Kind = "email"
+	Phone      Kind = "phone"
+	CreditCard Kind = "credit_card"
+	Passport   Kind = "passport"
+)
+
+// AllKinds lists every kind, in the order detectors run: earlier kinds win overlaps.
+var AllKinds = []Kind{Email, CreditCard, Passport, Phone}
+
+// Mode selects what happens to detected personal data.
+type Mode string
+
+const (
+	// ModeOff disables detection.
+	ModeOff Mode = "off"
+	// ModeTag records which kinds a message contains and keeps them out of logs.
+	ModeTag Mode = "tag"
+	// ModeMask also replaces them with placeholders like "[email]" before the model sees them.
+	ModeMask Mode = "mask"
+)
+
+// Policy is the detection configuration of a deployment. A nil policy detects nothing.
+type Policy struct {
+	Mode  Mode
+	Kinds []Kind
+}
+
+// FromEnv reads PII_MODE (off, tag or mask; default off) and PII_KINDS (comma-separated
+// kinds; default all). It returns nil when detection is off.
+func FromEnv() (*Policy, error) {
+	mode := Mode(strings.ToLower(strings.TrimSpace(os.Getenv("PII_MODE"))))
+	switch mode {
+	case "", ModeOff:
+		return nil, nil
+	case ModeTag, ModeMask:
+	default:
+		return nil, fmt.Errorf("invalid PII_MODE %q: must be off, tag or mask", mode)
+	}
+
+	p := &Policy{Mode: mode, Kinds: AllKinds}
+	if v := os.Getenv("PII_KINDS"); v != "" {
+		p.Kinds = nil
+		for _, k := range strings.Split(v, ",") {
+			k := Kind(strings.TrimSpace(k))
+			if !slices.Contains(AllKinds, k) {
+				return nil, fmt.Errorf("invalid PII_KINDS entry %q", k)
+			}
+			p.Kinds = append(p.Kinds, k)
+		}
+	}
+	return p, nil
+}
+
+// Finding is personal data found at s[Start:End].
+type Finding struct {
+	Kind       Kind
+	Start, End int
+}
+
+var (
+	emailPattern    = regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9-]+(?:\.[A-Za-z0-9-]+)*\.[A-Za-z]{2,}`)
+	cardPattern     = regexp.MustCompile(`\b\d(?:[ -]?\d){12,18}\b`)
+	passportPattern = regexp.MustCompile(`(?i)\b(?:passport|pasaporte|passeport|reisepass)(?:\s+(?:no\.?|number|num\.?|nr\.?|n[º°]|#))?\s*[:#]?\s*([A-Z0-9]{6,9})\b`)
+	phonePattern    = regexp.MustCompile(`(?:\+|\b00)?\(?\d[\d ().-]{6,18}\d\b`)
+)
+
+// Detect returns the personal data in s of the policy's kinds, in order of appearance.
+func (p *Policy) Detect(s string) []Finding {
+	if p == nil || p.Mode == ModeOff {
+		return nil
+	}
+
+	var found []Finding
+	overlaps := func(start, end int) bool {
+		return slices.ContainsFunc(found, func(f Finding) bool { return start < f.End && f.Start < end })
+	}
+	add := func(kind Kind, start, end int) {
+		if !overlaps(start, end) {
+			found = append(found, Finding{Kind: kind, Start: start, End: end})
+		}
+	}
+
+	for _, kind := range AllKinds {
+		if !slices.Contains(p.Kinds, kind) {
+			continue
+		}
+		switch kind {
+		case Email:
+			for _, m := range emailPattern.FindAllStringIndex(s, -1) {
+				add(Email, m[0], m[1])
+			}
+		case CreditCard:
+			for _, m := range cardPattern.FindAllStringIndex(s, -1) {
+				if luhn(digits(s[m[0]:m[1]])) {
+					add(CreditCard, m[0], m[1])
+				}
+			}
+		case Passport:
+			for _, m := range passportPattern.FindAllStringSubmatchIndex(s, -1) {
+				// Only the number itself, and only if it has digits: "passport please" isn't one.
+				if strings.ContainsAny(s[m[2]:m[3]], "0123456789") {
+					add(Passport, m[2], m[3])
+				}
+			}
+		case Phone:
+			for _, m := range phonePattern.FindAllStringIndex(s, -1) {
+				if isPhone(s[m[0]:m[1]]) {
+					add(Phone, m[0], m[1])
+				}
+			}
+		}
+	}
+
+	sort.Slice(found, func(i, j int) bool { return found[i].Start < found[j].Start })
+	return found
+}
+
+// isPhone tells phone numbers from other digit runs such as dates, times or amounts:
+// international numbers start with + or 00, national ones need at least 9 digits.
+func isPhone(s string) bool {
+	n := len(digits(s))
+	if n > 15 {
+		return false
+	}
+	if strings.HasPrefix(s, "+") || strings.HasPrefix(s, "00") {
+		return n >= 8
+	}
+	return n >= 9 && !strings.Contains(s, ".")
+}
+
+func digits(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, s)
+}
+
+// luhn validates the check digit of a payment card number.
+func luhn(number string) bool {
+	if len(number) < 13 || len(number) > 19 {
+		return false
+	}
+	sum := 0
+	for i := range number {
+		d := int(number[len(number)-1-i] - '0')
+		if i%2 == 1 {
+			if d *= 2; d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+	}
+	return sum%10 == 0
+}
+
+// Kinds returns the distinct kinds found, sorted.
+func Kinds(found []Finding) []string {
+	var out []string
+	for _, f := range found {
+		if !slices.Contains(out, string(f.Kind)) {
+			out = append(out, string(f.Kind))
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+// Redact replaces the personal data in s with placeholders like "[email]", whatever the
+// mode, e.g. for logs.
+func (p *Policy) Redact(s string) string {
+	found := p.Detect(s)
+	if len(found) == 0 {
+		return s
+	}
+
+	var b strings.Builder
+	last := 0
+	for _, f := range found {
+		b.WriteString(s[last:f.Start])
+		b.WriteString("[" + string(f.Kind) + "]")
+		last = f.End
+	}
+	b.WriteString(s[last:])
+	return b.String()
+}
+
+// ForModel returns s as it may be sent to the model: redacted in ModeMask, unchanged otherwise.
+func (p *Policy) ForModel(s string) string {
+	if p == nil || p.Mode != ModeMask {
+		return s
+	}
+	return p.Redact(s)
+}
@@ -0,0 +1,84 @@
+package pii
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRedact(t *testing.T) {
+	p := &Policy{Mode: ModeTag, Kinds: AllKinds}
+
+	tests := []struct {
+		in, want string
+	}{
+		{"Mail me at jane.doe+trips@example.co.uk please", "Mail me at [email] please"},
+		{"My card is 4111 1111 1111 1111.", "My card is [credit_card]."},
+		{"Card 4111-1111-1111-1112 fails the checksum", "Card 4111-1111-1111-1112 fails the checksum"},
+		{"Call +34 612 34 56 78 or (415) 555-0132", "Call [phone] or [phone]"},
+		{"Passport number: X1234567, expires soon", "Passport number: [passport], expires soon"},
+		{"Do I need my passport for Andorra?", "Do I need my passport for Andorra?"},
+		{"Flight on 2025-09-14 at 10:30, 3 bags, 1500.50 EUR", "Flight on 2025-09-14 at 10:30, 3 bags, 1500.50 EUR"},
+		{"Booking reference 12345", "Booking reference 12345"},
+	}
+
+	for _, tt := range tests {
+		if got := p.Redact(tt.in); got != tt.want {
+			t.Errorf("Redact(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestDetect(t *testing.T) {
+	s := "jane@example.com, +44 20 7946 0958"
+
+	all := &Policy{Mode: ModeMask, Kinds: AllKinds}
+	if got, want := Kinds(all.Detect(s)), []string{"email", "phone"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("kinds: got %v, want %v", got, want)
+	}
+
+	emails := &Policy{Mode: ModeTag, Kinds: []Kind{Email}}
+	if got := emails.Redact(s); got != "[email], +44 20 7946 0958" {
+		t.Errorf("expected only emails to be redacted, got %q", got)
+	}
+
+	var none *Policy
+	if got := none.Detect(s); got != nil {
+		t.Errorf("a nil policy found %v", got)
+	}
+}
+
+func TestForModel(t *testing.T) {
+	s := "I'm jane@example.com"
+	if got := (&Policy{Mode: ModeTag, Kinds: AllKinds}).ForModel(s); got != s {
+		t.Errorf("tag mode changed the message: %q", got)
+	}
+	if got := (&Policy{Mode: ModeMask, Kinds: AllKinds}).ForModel(s); got != "I'm [email]" {
+		t.Errorf("mask mode didn't mask the message: %q", got)
+	}
+}
+
+func TestFromEnv(t *testing.T) {
+	t.Setenv("PII_MODE", "")
+	if p, err := FromEnv(); p != nil || err != nil {
+		t.Errorf("expected detection to be off by default, got %+v, %v", p, err)
+	}
+
+	t.Setenv("PII_MODE", "mask")
+	t.Setenv("PII_KINDS", "email, phone")
+	p, err := FromEnv()
+	if err != nil {
+		t.Fatalf("FromEnv: %v", err)
+	}
+	if p.Mode != ModeMask || !reflect.DeepEqual(p.Kinds, []Kind{Email, Phone}) {
+		t.Errorf("unexpected policy: %+v", p)
+	}
+
+	t.Setenv("PII_KINDS", "ssn")
+	if _, err := FromEnv(); err == nil {
+		t.Error("expected an error for an unknown kind")
+	}
+	t.Setenv("PII_MODE", "strict")
+	if _, err := FromEnv(); err == nil {
+		t.Error("expected an error for an unknown mode")
+	}
+}
@@ -0,0 +1,281 @@
+// Package postprocess transforms assistant replies before they are stored and returned:
+// sanitizing Markdown, rewriting links, filtering banned phrases and clamping length.
+package postprocess
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/acai-travel/tech-challenge/internal/textx"
+)
+
+// Processor transforms a reply. Processors must be safe for concurrent use.
+type Processor func(reply string) string
+
+// Chain runs processors in order. A nil Chain leaves replies as they are.
+type Chain []Processor
+
+func (c Chain) Process(reply string) string {
+	for _, p := range c {
+		reply = p(reply)
+	}
+	return reply
+}
+
+// DefaultReplacement stands in for banned phrases when the config doesn't set one.
+const DefaultReplacement = "[removed]"
+
+// Config is an operator's post-processing configuration, usually loaded from JSON. Steps
+// run in the order of the fields.
+type Config struct {
+	// SanitizeMarkdown removes raw HTML and links with schemes other than http, https and
+	// mailto, for clients rendering replies as Markdown.
+	SanitizeMarkdown bool `json:"sanitize_markdown"`
+	// BannedPhrases are replaced wherever they appear, ignoring case.
+	BannedPhrases []string `json:"banned_phrases"`
+	// BannedReplacement replaces banned phrases; empty uses DefaultReplacement.
+	BannedReplacement string `json:"banned_replacement"`
+	// LinkTemplate rewrites http(s) links, e.g. through a click tracker: "{url}" is replaced
+	// by the query-escaped original.
+	LinkTemplate string `json:"link_template"`
+	// MaxLength clamps replies to at most this many characters; 0 means no limit.
+	MaxLength int `json:"max_length"`
+}
+
+// Load reads a config from a JSON file.
+func Load(path string) (*Config, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read post-processing config: %w", err)
+	}
+
+	var c Config
+	if err := json.Unmarshal(b, &c); err != nil {
+		return nil, fmt.Errorf("failed to parse post-processing config: %w", err)
+	}
+
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// minLength keeps clamped replies long enough to say something.
+const minLength = 20
+
+// Validate reports the first problem in a config.
+func (c *Config) Validate() error {
+	for _, p := range c.BannedPhrases {
+		if strings.TrimSpace(p) == "" {
+			return errors.New("post-processing: empty banned phrase")
+		}
+	}
+	if c.LinkTemplate != "" {
+		if !strings.Contains(c.LinkTemplate, "{url}") {
+			return errors.New("post-processing: link_template must contain {url}")
+		}
+		u, err := url.Parse(strings.ReplaceAll(c.LinkTemplate, "{url}", "x"))
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return errors.New("post-processing: link_template must be an http(s) URL")
+		}
+	}
+	if c.MaxLength != 0 && c.MaxLength < minLength {
+		return fmt.Errorf("post-processing: max_length must be 0 or at least %d", minLength)
+	}
+	return nil
+}
+
+// Chain returns the processors c configures. A nil config configures none.
+func (c *Config) Chain() Chain {
+	if c == nil {
+		return nil
+	}
+
+	var chain Chain
+	if c.SanitizeMarkdown {
+		chain = append(chain, SanitizeMarkdown)
+	}
+	if len(c.BannedPhrases) > 0 {
+		replacement := c.BannedReplacement
+		if replacement == "" {
+			replacement = DefaultReplacement
+		}
+		chain = append(chain, FilterPhrases(c.BannedPhrases, replacement))
+	}
+	if c.LinkTemplate != "" {
+		chain = append(chain, RewriteLinks(c.LinkTemplate))
+	}
+	if c.MaxLength > 0 {
+		chain = append(chain, Clamp(c.MaxLength))
+	}
+	return chain
+}
+
+var (
+	fence      = regexp.MustCompile("(?m)^[ \t]*```")
+	inlineCode = regexp.MustCompile("`[^`\n]+`")
+)
+
+// mapProse applies f to the parts of s outside code blocks and inline code, which are
+// examples to show as written.
+func mapProse(s string, f func(string) string) string {
+	var out strings.Builder
+	inCode := false
+	for len(s) > 0 {
+		loc := fence.FindStringIndex(s)
+		end := len(s)
+		if loc != nil {
+			// The fence line belongs to the block it opens or closes.
+			if end = strings.IndexByte(s[loc[1]:], '\n'); end < 0 {
+				end = len(s)
+			} else {
+				end += loc[1] + 1
+			}
+		}
+
+		if inCode {
+			out.WriteString(s[:end])
+		} else {
+			part := s[:end]
+			if loc != nil {
+				part = s[:loc[0]]
+			}
+			out.WriteString(mapOutsideInline(part, f))
+			if loc != nil {
+				out.WriteString(s[loc[0]:end])
+			}
+		}
+
+		if loc == nil {
+			break
+		}
+		inCode = !inCode
+		s = s[end:]
+	}
+	return out.String()
+}
+
+func mapOutsideInline(s string, f func(string) string) string {
+	var out strings.Builder
+	last := 0
+	for _, loc := range inlineCode.FindAllStringIndex(s, -1) {
+		out.WriteString(f(s[last:loc[0]]))
+		out.WriteString(s[loc[0]:loc[1]])
+		last = loc[1]
+	}
+	out.WriteString(f(s[last:]))
+	return out.String()
+}
+
+var (
+	dangerousHTML = regexp.MustCompile(`(?is)<(script|style|iframe)\b.*?(</(script|style|iframe)\s*>|\z)`)
+	htmlTag       = regexp.MustCompile(`(?s)</?[a-zA-Z][a-zA-Z0-9-]*(\s[^<>]*)?/?>|<!--.*?-->`)
+	mdLink        = regexp.MustCompile(`(!?)\[([^\]\n]*)\]\(\s*((?:[^()\s]|\([^()\s]*\))*)[^)\n]*\)`)
+)
+
+// SanitizeMarkdown removes raw HTML, dropping the contents of scripts, styles and frames,
+// and turns links and images whose scheme isn't http, https or mailto into their text.
+// Code is left alone.
+func SanitizeMarkdown(reply string) string {
+	return mapProse(reply, func(s string) string {
+		s = dangerousHTML.ReplaceAllString(s, "")
+		s = htmlTag.ReplaceAllString(s, "")
+		return mdLink.ReplaceAllStringFunc(s, func(link string) string {
+			m := mdLink.FindStringSubmatch(link)
+			u, err := url.Parse(m[3])
+			if err == nil && (u.Scheme == "http" || u.Scheme == "https" || u.Scheme == "mailto" || (u.Scheme == "" && strings.HasPrefix(m[3], "#"))) {
+				return link
+			}
+			return m[2]
+		})
+	})
+}
+
+// FilterPhrases returns a processor replacing phrases with replacement, ignoring case. A
+// phrase only matches whole words, so banning "ass" leaves "class" alone; Go's \b only
+// knows ASCII, which would split accented words, so boundaries are checked by hand.
+func FilterPhrases(phrases []string, replacement string) Processor {
+	quoted := make([]string, len(phrases))
+	for i, p := range phrases {
+		quoted[i] = regexp.QuoteMeta(strings.TrimSpace(p))
+	}
+	// Alternatives match leftmost first; longer phrases must win over their prefixes.
+	slices.SortFunc(quoted, func(a, b string) int { return len(b) - len(a) })
+	re := regexp.MustCompile(`(?i)` + strings.Join(quoted, "|"))
+
+	return func(reply string) string {
+		var out strings.Builder
+		last := 0
+		for _, loc := range re.FindAllStringIndex(reply, -1) {
+			if !wordBoundary(reply, loc[0]) || !wordBoundary(reply, loc[1]) {
+				continue
+			}
+			out.WriteString(reply[last:loc[0]])
+			out.WriteString(replacement)
+			last = loc[1]
+		}
+		out.WriteString(reply[last:])
+		return out.String()
+	}
+}
+
+// wordBoundary reports whether i separates a word from a non-word character in s.
+func wordBoundary(s string, i int) bool {
+	before, _ := utf8.DecodeLastRuneInString(s[:i])
+	after, _ := utf8.DecodeRuneInString(s[i:])
+	isWord := func(r rune) bool { return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' }
+	return i == 0 || i == len(s) || !isWord(before) || !isWord(after)
+}
+
+var link = regexp.MustCompile("https?://[^\\s<>()\\[\\]\"'`]+")
+
+// RewriteLinks returns a processor replacing http(s) URLs outside code with template,
+// where "{url}" stands for the query-escaped URL. Rewritten links are left alone, so the
+// processor can run twice.
+func RewriteLinks(template string) Processor {
+	prefix, _, _ := strings.Cut(template, "{url}")
+	return func(reply string) string {
+		return mapProse(reply, func(s string) string {
+			return link.ReplaceAllStringFunc(s, func(u string) string {
+				// Sentence punctuation after a URL isn't part of it.
+				trimmed := strings.TrimRight(u, ".,;:!?")
+				if strings.HasPrefix(trimmed, prefix) {
+					return u
+				}
+				return strings.ReplaceAll(template, "{url}", url.QueryEscape(trimmed)) + u[len(trimmed):]
+			})
+		})
+	}
+}
+
+// Clamp returns a processor shortening replies to at most max characters, cutting at a
+// word boundary, marking the cut with an ellipsis and closing a code block left open.
+func Clamp(max int) Processor {
+	return func(reply string) string {
+		if utf8.RuneCountInString(reply) <= max {
+			return reply
+		}
+		if cut := cutWords(reply, max-1); len(fence.FindAllStringIndex(cut, -1))%2 == 0 {
+			return cut + "…"
+		}
+		return cutWords(reply, max-5) + "…\n```"
+	}
+}
+
+// cutWords truncates s to at most max runes, backing up to the last space unless that
+// would lose more than a fifth of the text.
+func cutWords(s string, max int) string {
+	cut := textx.Truncate(s, max)
+	if i := strings.LastIndexFunc(cut, unicode.IsSpace); i > len(cut)*4/5 {
+		cut = strings.TrimRightFunc(cut[:i], unicode.IsSpace)
+	}
+	return cut
+}
@@ -0,0 +1,113 @@
+package postprocess
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestSanitizeMarkdown(t *testing.T) {
+	tests := []struct {
+		name, in, want string
+	}{
+		{"plain", "Sunny, 24°C in **Barcelona**.", "Sunny, 24°C in **Barcelona**."},
+		{"tags", "It is <b>sunny</b><br/> today.", "It is sunny today."},
+		{"script", "Hi<script>alert(1)</script> there", "Hi there"},
+		{"unclosed script", "Hi <script>alert(1)", "Hi "},
+		{"comment", "a<!-- hidden -->b", "ab"},
+		{"http link", "[Forecast](https://weather.example/bcn)", "[Forecast](https://weather.example/bcn)"},
+		{"javascript link", "[click](javascript:alert(1))", "click"},
+		{"data image", "![x](data:image/png;base64,AAAA)", "x"},
+		{"anchor", "[see below](#details)", "[see below](#details)"},
+		{"inline code", "Use `<div>` here <i>now</i>", "Use `<div>` here now"},
+		{"code block", "Example:\n```html\n<p>hi</p>\n```\n<p>done</p>", "Example:\n```html\n<p>hi</p>\n```\ndone"},
+		{"comparison", "if a < b and c > d", "if a < b and c > d"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SanitizeMarkdown(tt.in); got != tt.want {
+				t.Errorf("SanitizeMarkdown(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFilterPhrases(t *testing.T) {
+	filter := FilterPhrases([]string{"cheap flights", "cheap", "ass"}, "***")
+
+	tests := []struct{ in, want string }{
+		{"Find Cheap Flights now", "Find *** now"},
+		{"A cheap hotel in a first-class area", "A *** hotel in a first-class area"},
+		{"You ass!", "You ***!"},
+		{"Ça cheapé", "Ça cheapé"},
+	}
+	for _, tt := range tests {
+		if got := filter(tt.in); got != tt.want {
+			t.Errorf("filter(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestRewriteLinks(t *testing.T) {
+	rewrite := RewriteLinks("https://r.acme.test/out?u={url}")
+
+	got := rewrite("See https://weather.example/bcn?d=3. Or [this](http://a.example/x), not `https://code.example`.")
+	want := "See https://r.acme.test/out?u=https%3A%2F%2Fweather.example%2Fbcn%3Fd%3D3. " +
+		"Or [this](https://r.acme.test/out?u=http%3A%2F%2Fa.example%2Fx), not `https://code.example`."
+	if got != want {
+		t.Errorf("rewrite = %q\nwant %q", got, want)
+	}
+	if again := rewrite(got); again != got {
+		t.Errorf("rewriting twice changed links: %q", again)
+	}
+}
+
+func TestClamp(t *testing.T) {
+	clamp := Clamp(30)
+
+	if got := clamp("Short enough."); got != "Short enough." {
+		t.Errorf("short reply changed: %q", got)
+	}
+
+	got := clamp("The weather in Barcelona is sunny all week long.")
+	if got != "The weather in Barcelona is…" {
+		t.Errorf("clamp = %q, want a cut at a word", got)
+	}
+
+	code := clamp("Run this:\n```\ngo test ./... -run TestEverything\n```")
+	if utf8.RuneCountInString(code) > 30 || !strings.HasSuffix(code, "\n```") {
+		t.Errorf("clamp = %q, want at most 30 characters with the code block closed", code)
+	}
+}
+
+func TestConfig(t *testing.T) {
+	var none *Config
+	if got := none.Chain().Process("<b>hi</b>"); got != "<b>hi</b>" {
+		t.Errorf("nil config changed the reply: %q", got)
+	}
+
+	c := &Config{
+		SanitizeMarkdown: true,
+		BannedPhrases:    []string{"guaranteed"},
+		LinkTemplate:     "https://r.acme.test/?u={url}",
+		MaxLength:        200,
+	}
+	if err := c.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	got := c.Chain().Process("<i>Guaranteed</i> sun, see https://w.example")
+	if want := "[removed] sun, see https://r.acme.test/?u=https%3A%2F%2Fw.example"; got != want {
+		t.Errorf("chain = %q, want %q", got, want)
+	}
+
+	for _, bad := range []*Config{
+		{BannedPhrases: []string{" "}},
+		{LinkTemplate: "https://r.acme.test/"},
+		{LinkTemplate: "javascript:{url}"},
+		{MaxLength: 5},
+	} {
+		if bad.Validate() == nil {
+			t.Errorf("Validate(%+v) should fail", bad)
+		}
+	}
+}
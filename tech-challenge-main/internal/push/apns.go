@@ -0,0 +1,149 @@
+package push
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/acai-travel/tech-challenge/internal/httpx"
+)
+
+// apnsTokenLifetime is how long a provider token is reused. Apple rejects tokens older than an
+// hour, and refreshing more often than every 20 minutes.
+const apnsTokenLifetime = 45 * time.Minute
+
+// APNs sends notifications through the Apple Push Notification service, authenticated with a
+// token signing key.
+type APNs struct {
+	keyID  string
+	teamID string
+	// topic is the bundle ID of the app.
+	topic string
+	key   *ecdsa.PrivateKey
+	// endpoint is the service's base URL, replaceable in tests.
+	endpoint string
+	client   *http.Client
+
+	mu     sync.Mutex
+	token  string
+	issued time.Time
+}
+
+// NewAPNs sends to the app with bundle ID topic, signing with the .p8 key keyID of team
+// teamID. Sandbox selects the development environment, for builds run from Xcode.
+func NewAPNs(p8 []byte, keyID, teamID, topic string, sandbox bool) (*APNs, error) {
+	if keyID == "" || teamID == "" || topic == "" {
+		return nil, errors.New("APNs requires a key ID, a team ID and a topic")
+	}
+	block, _ := pem.Decode(p8)
+	if block == nil {
+		return nil, errors.New("invalid APNs key: not PEM")
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid APNs key: %w", err)
+	}
+	key, ok := parsed.(*ecdsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("invalid APNs key: not an ECDSA key")
+	}
+
+	endpoint := "https://api.push.apple.com"
+	if sandbox {
+		endpoint = "https://api.sandbox.push.apple.com"
+	}
+	return &APNs{
+		keyID:    keyID,
+		teamID:   teamID,
+		topic:    topic,
+		key:      key,
+		endpoint: endpoint,
+		client:   httpx.Client(10 * time.Second),
+	}, nil
+}
+
+// Send sends m to the device with a token, failing with ErrUnregistered if APNs no longer
+// delivers to it.
+func (a *APNs) Send(ctx context.Context, token string, m *Message) error {
+	provider, err := a.providerToken()
+	if err != nil {
+		return err
+	}
+
+	// Data keys sit next to "aps", where apps read them from the notification's userInfo.
+	payload := map[string]any{
+		"aps": map[string]any{
+			"alert": map[string]string{"title": m.Title, "body": m.Body},
+			"sound": "default",
+		},
+	}
+	for k, v := range m.Data {
+		if k != "aps" {
+			payload[k] = v
+		}
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.endpoint+"/3/device/"+url.PathEscape(token), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "bearer "+provider)
+	req.Header.Set("apns-topic", a.topic)
+	req.Header.Set("apns-push-type", "alert")
+	req.Header.Set("apns-priority", "10")
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusOK {
+		return nil
+	}
+
+	var failure struct {
+		Reason string `json:"reason"`
+	}
+	_ = json.NewDecoder(io.LimitReader(resp.Body, 64<<10)).Decode(&failure)
+	switch {
+	case resp.StatusCode == http.StatusGone, failure.Reason == "BadDeviceToken", failure.Reason == "Unregistered":
+		return ErrUnregistered
+	case failure.Reason == "ExpiredProviderToken":
+		a.mu.Lock()
+		a.token = ""
+		a.mu.Unlock()
+	}
+	return fmt.Errorf("APNs responded %s: %s", resp.Status, failure.Reason)
+}
+
+// providerToken returns the JWT authenticating requests, signing another once the last is
+// apnsTokenLifetime old.
+func (a *APNs) providerToken() (string, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.token != "" && time.Since(a.issued) < apnsTokenLifetime {
+		return a.token, nil
+	}
+
+	now := time.Now()
+	token, err := signJWT(map[string]any{"kid": a.keyID}, map[string]any{"iss": a.teamID, "iat": now.Unix()}, a.key)
+	if err != nil {
+		return "", err
+	}
+	a.token, a.issued = token, now
+	return token, nil
+}
@@ -0,0 +1,184 @@
+package push
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/acai-travel/tech-challenge/internal/httpx"
+)
+
+const fcmScope = "https://www.googleapis.com/auth/firebase.messaging"
+
+// FCM sends notifications through the FCM HTTP v1 API, authenticated as a service account.
+type FCM struct {
+	project  string
+	email    string
+	key      *rsa.PrivateKey
+	tokenURL string
+	// endpoint is the API's base URL, replaceable in tests.
+	endpoint string
+	client   *http.Client
+
+	mu      sync.Mutex
+	token   string
+	expires time.Time
+}
+
+// NewFCM sends to the apps of the Firebase project of a service account, given its JSON key
+// file as downloaded from the Google Cloud console.
+func NewFCM(serviceAccount []byte) (*FCM, error) {
+	var sa struct {
+		ProjectID   string `json:"project_id"`
+		ClientEmail string `json:"client_email"`
+		PrivateKey  string `json:"private_key"`
+		TokenURI    string `json:"token_uri"`
+	}
+	if err := json.Unmarshal(serviceAccount, &sa); err != nil {
+		return nil, fmt.Errorf("invalid service account: %w", err)
+	}
+	if sa.ProjectID == "" || sa.ClientEmail == "" || sa.PrivateKey == "" {
+		return nil, errors.New("invalid service account: project_id, client_email and private_key are required")
+	}
+
+	block, _ := pem.Decode([]byte(sa.PrivateKey))
+	if block == nil {
+		return nil, errors.New("invalid service account: private_key is not PEM")
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid service account private key: %w", err)
+	}
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("invalid service account: private_key is not an RSA key")
+	}
+
+	return &FCM{
+		project:  sa.ProjectID,
+		email:    sa.ClientEmail,
+		key:      key,
+		tokenURL: cmp.Or(sa.TokenURI, "https://oauth2.googleapis.com/token"),
+		endpoint: "https://fcm.googleapis.com",
+		client:   httpx.Client(10 * time.Second),
+	}, nil
+}
+
+// Send sends m to the app instance with a registration token, failing with ErrUnregistered
+// if FCM no longer knows it.
+func (f *FCM) Send(ctx context.Context, token string, m *Message) error {
+	access, err := f.accessToken(ctx)
+	if err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(map[string]any{"message": map[string]any{
+		"token":        token,
+		"notification": map[string]string{"title": m.Title, "body": m.Body},
+		"data":         m.Data,
+	}})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost,
+		f.endpoint+"/v1/projects/"+url.PathEscape(f.project)+"/messages:send", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+access)
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 == 2 {
+		return nil
+	}
+
+	var failure struct {
+		Error struct {
+			Message string `json:"message"`
+			Details []struct {
+				ErrorCode string `json:"errorCode"`
+			} `json:"details"`
+		} `json:"error"`
+	}
+	_ = json.NewDecoder(io.LimitReader(resp.Body, 64<<10)).Decode(&failure)
+	for _, d := range failure.Error.Details {
+		if d.ErrorCode == "UNREGISTERED" {
+			return ErrUnregistered
+		}
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return ErrUnregistered
+	}
+	if resp.StatusCode == http.StatusUnauthorized {
+		// The token may have been revoked early; the next send gets another.
+		f.mu.Lock()
+		f.token = ""
+		f.mu.Unlock()
+	}
+	return fmt.Errorf("FCM responded %s: %s", resp.Status, failure.Error.Message)
+}
+
+// accessToken returns an OAuth 2.0 token of the service account, exchanging a signed JWT
+// for a new one when the last is about to expire.
+func (f *FCM) accessToken(ctx context.Context) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.token != "" && time.Until(f.expires) > time.Minute {
+		return f.token, nil
+	}
+
+	now := time.Now()
+	assertion, err := signJWT(map[string]any{}, map[string]any{
+		"iss":   f.email,
+		"scope": fcmScope,
+		"aud":   f.tokenURL,
+		"iat":   now.Unix(),
+		"exp":   now.Add(time.Hour).Unix(),
+	}, f.key)
+	if err != nil {
+		return "", err
+	}
+
+	form := url.Values{"grant_type": {"urn:ietf:params:oauth:grant-type:jwt-bearer"}, "assertion": {assertion}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, f.tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return "", fmt.Errorf("Google token endpoint responded %s", resp.Status)
+	}
+	var out struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil || out.AccessToken == "" {
+		return "", errors.New("invalid response from the Google token endpoint")
+	}
+
+	f.token, f.expires = out.AccessToken, now.Add(time.Duration(out.ExpiresIn)*time.Second)
+	return f.token, nil
+}
@@ -0,0 +1,72 @@
+// Package push sends notifications to mobile apps through Firebase Cloud Messaging (FCM) and
+// the Apple Push Notification service (APNs).
+package push
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// ErrUnregistered is returned for a token the provider no longer delivers to, e.g. after
+// the app was uninstalled; it should be forgotten.
+var ErrUnregistered = errors.New("push token is no longer registered")
+
+// Message is a notification shown on a device.
+type Message struct {
+	Title string
+	Body  string
+	// Data is passed to the app, e.g. the conversation to open when the notification is tapped.
+	Data map[string]string
+}
+
+// signJWT returns a JSON Web Token of claims, signed with key: RS256 for RSA keys, as Google
+// expects, or ES256 for ECDSA P-256 keys, as Apple does.
+func signJWT(header, claims map[string]any, key crypto.Signer) (string, error) {
+	switch key.(type) {
+	case *rsa.PrivateKey:
+		header["alg"] = "RS256"
+	case *ecdsa.PrivateKey:
+		header["alg"] = "ES256"
+	default:
+		return "", fmt.Errorf("unsupported key type %T", key)
+	}
+	header["typ"] = "JWT"
+
+	h, err := json.Marshal(header)
+	if err != nil {
+		return "", err
+	}
+	c, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+	unsigned := base64.RawURLEncoding.EncodeToString(h) + "." + base64.RawURLEncoding.EncodeToString(c)
+	digest := sha256.Sum256([]byte(unsigned))
+
+	var sig []byte
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		sig, err = rsa.SignPKCS1v15(rand.Reader, k, crypto.SHA256, digest[:])
+	case *ecdsa.PrivateKey:
+		// JWTs take the raw r and s, not the ASN.1 encoding of crypto.Signer.
+		var r, s *big.Int
+		r, s, err = ecdsa.Sign(rand.Reader, k, digest[:])
+		if err == nil {
+			sig = make([]byte, 64)
+			r.FillBytes(sig[:32])
+			s.FillBytes(sig[32:])
+		}
+	}
+	if err != nil {
+		return "", err
+	}
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(sig), nil
+}
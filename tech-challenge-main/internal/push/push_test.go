@@ -0,0 +1,157 @@
+package push
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func pkcs8PEM(t *testing.T, key any) []byte {
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+}
+
+// verifyJWT checks the signature of token with pub and returns its header and claims.
+func verifyJWT(t *testing.T, token string, pub crypto.PublicKey) (header, claims map[string]any) {
+	t.Helper()
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		t.Fatalf("malformed JWT %q", token)
+	}
+	for i, v := range []*map[string]any{&header, &claims} {
+		b, _ := base64.RawURLEncoding.DecodeString(parts[i])
+		if err := json.Unmarshal(b, v); err != nil {
+			t.Fatalf("malformed JWT part %q: %v", parts[i], err)
+		}
+	}
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	sig, _ := base64.RawURLEncoding.DecodeString(parts[2])
+
+	var ok bool
+	switch k := pub.(type) {
+	case *rsa.PublicKey:
+		ok = rsa.VerifyPKCS1v15(k, crypto.SHA256, digest[:], sig) == nil
+	case *ecdsa.PublicKey:
+		ok = len(sig) == 64 && ecdsa.Verify(k, digest[:], new(big.Int).SetBytes(sig[:32]), new(big.Int).SetBytes(sig[32:]))
+	}
+	if !ok {
+		t.Fatalf("JWT signature doesn't verify: %s", token)
+	}
+	return header, claims
+}
+
+func TestFCM(t *testing.T) {
+	key, _ := rsa.GenerateKey(rand.Reader, 2048)
+	var tokens int
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /token", func(w http.ResponseWriter, r *http.Request) {
+		tokens++
+		_, claims := verifyJWT(t, r.FormValue("assertion"), &key.PublicKey)
+		if claims["iss"] != "push@trips.iam.gserviceaccount.com" || claims["scope"] != fcmScope {
+			t.Errorf("unexpected claims %v", claims)
+		}
+		_, _ = w.Write([]byte(`{"access_token":"access","expires_in":3600}`))
+	})
+	var sent map[string]map[string]any
+	mux.HandleFunc("POST /v1/projects/trips/messages:send", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer access" {
+			http.Error(w, "unauthenticated", http.StatusUnauthorized)
+			return
+		}
+		_ = json.NewDecoder(r.Body).Decode(&sent)
+		if sent["message"]["token"] == "gone" {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":{"status":"NOT_FOUND","details":[{"errorCode":"UNREGISTERED"}]}}`))
+		}
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	sa, _ := json.Marshal(map[string]string{
+		"project_id":   "trips",
+		"client_email": "push@trips.iam.gserviceaccount.com",
+		"private_key":  string(pkcs8PEM(t, key)),
+		"token_uri":    srv.URL + "/token",
+	})
+	fcm, err := NewFCM(sa)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fcm.endpoint = srv.URL
+
+	m := &Message{Title: "Reply ready", Body: "Here's your plan.", Data: map[string]string{"conversation_id": "c1"}}
+	if err := fcm.Send(context.Background(), "device", m); err != nil {
+		t.Fatalf("Send error: %v", err)
+	}
+	if sent["message"]["token"] != "device" || sent["message"]["notification"].(map[string]any)["title"] != "Reply ready" {
+		t.Errorf("FCM received %v", sent)
+	}
+	if err := fcm.Send(context.Background(), "gone", m); !errors.Is(err, ErrUnregistered) {
+		t.Errorf("Send to an unregistered token = %v, want ErrUnregistered", err)
+	}
+	if tokens != 1 {
+		t.Errorf("got %d access tokens, want one reused", tokens)
+	}
+
+	if _, err := NewFCM([]byte(`{"project_id":"trips"}`)); err == nil {
+		t.Error("expected an error for an incomplete service account")
+	}
+}
+
+func TestAPNs(t *testing.T) {
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	var sent map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header, claims := verifyJWT(t, strings.TrimPrefix(r.Header.Get("Authorization"), "bearer "), &key.PublicKey)
+		if header["kid"] != "KEY123" || claims["iss"] != "TEAM456" || r.Header.Get("apns-topic") != "com.example.trips" {
+			t.Errorf("unexpected authentication %v %v %v", header, claims, r.Header)
+		}
+		if r.URL.Path == "/3/device/gone" {
+			w.WriteHeader(http.StatusGone)
+			_, _ = w.Write([]byte(`{"reason":"Unregistered"}`))
+			return
+		}
+		_ = json.NewDecoder(r.Body).Decode(&sent)
+	}))
+	defer srv.Close()
+
+	apns, err := NewAPNs(pkcs8PEM(t, key), "KEY123", "TEAM456", "com.example.trips", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	apns.endpoint = srv.URL
+
+	m := &Message{Title: "Reply ready", Body: "Here's your plan.", Data: map[string]string{"conversation_id": "c1"}}
+	if err := apns.Send(context.Background(), "device", m); err != nil {
+		t.Fatalf("Send error: %v", err)
+	}
+	alert := sent["aps"].(map[string]any)["alert"].(map[string]any)
+	if alert["title"] != "Reply ready" || alert["body"] != "Here's your plan." || sent["conversation_id"] != "c1" {
+		t.Errorf("APNs received %v", sent)
+	}
+	if err := apns.Send(context.Background(), "gone", m); !errors.Is(err, ErrUnregistered) {
+		t.Errorf("Send to an unregistered token = %v, want ErrUnregistered", err)
+	}
+
+	rsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	if _, err := NewAPNs(pkcs8PEM(t, rsaKey), "KEY123", "TEAM456", "com.example.trips", false); err == nil {
+		t.Error("expected an error for a key that isn't ECDSA")
+	}
+}
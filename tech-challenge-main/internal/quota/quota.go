@@ -0,0 +1,114 @@
+// Package quota limits what the users of each plan may consume per day: messages answered,
+// tokens and tool calls. Days are UTC days, so every user's allowances reset at midnight UTC.
+package quota
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+)
+
+const (
+	// Free is the plan of users whose plan isn't known.
+	Free = "free"
+	Pro  = "pro"
+)
+
+// Limits of a Plan, as named in errors and by Plan.Exceeded.
+const (
+	Messages  = "messages"
+	Tokens    = "tokens"
+	ToolCalls = "tool_calls"
+)
+
+// Plan is a tier's daily allowances. Zero allows any amount.
+type Plan struct {
+	MessagesPerDay  int64 `json:"messages_per_day"`
+	TokensPerDay    int64 `json:"tokens_per_day"`
+	ToolCallsPerDay int64 `json:"tool_calls_per_day"`
+}
+
+// Plans are the tiers by name. They always include Free.
+type Plans map[string]Plan
+
+// Defaults returns the built-in free and pro tiers.
+func Defaults() Plans {
+	return Plans{
+		Free: {MessagesPerDay: 50, TokensPerDay: 200_000, ToolCallsPerDay: 100},
+		Pro:  {MessagesPerDay: 1_000, TokensPerDay: 5_000_000, ToolCallsPerDay: 2_000},
+	}
+}
+
+// Load reads plans from a JSON file, an object of plans by name.
+func Load(path string) (Plans, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read quota plans: %w", err)
+	}
+
+	var p Plans
+	if err := json.Unmarshal(b, &p); err != nil {
+		return nil, fmt.Errorf("failed to parse quota plans: %w", err)
+	}
+
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// Validate reports the first problem in plans.
+func (p Plans) Validate() error {
+	if _, ok := p[Free]; !ok {
+		return errors.New("quota plans: missing the free plan, which users without a known plan get")
+	}
+	for name, plan := range p {
+		if plan.MessagesPerDay < 0 || plan.TokensPerDay < 0 || plan.ToolCallsPerDay < 0 {
+			return fmt.Errorf("quota plans: plan %q has a negative limit", name)
+		}
+	}
+	return nil
+}
+
+// Plan returns the plan called name, falling back to Free for unknown names, and the name
+// of the plan returned.
+func (p Plans) Plan(name string) (string, Plan) {
+	if plan, ok := p[name]; ok {
+		return name, plan
+	}
+	return Free, p[Free]
+}
+
+// Usage is what a user consumed in a day.
+type Usage struct {
+	Messages  int64
+	Tokens    int64
+	ToolCalls int64
+}
+
+// Exceeded returns the first limit u used up, or "" if none. A reply may go over a limit,
+// e.g. with many tool calls, but the next one is refused.
+func (p Plan) Exceeded(u Usage) string {
+	switch {
+	case p.MessagesPerDay > 0 && u.Messages >= p.MessagesPerDay:
+		return Messages
+	case p.TokensPerDay > 0 && u.Tokens >= p.TokensPerDay:
+		return Tokens
+	case p.ToolCallsPerDay > 0 && u.ToolCalls >= p.ToolCallsPerDay:
+		return ToolCalls
+	}
+	return ""
+}
+
+// Day returns the UTC day of t, e.g. "2025-08-20", which usage is counted by.
+func Day(t time.Time) string {
+	return t.UTC().Format(time.DateOnly)
+}
+
+// ResetsAt returns when the allowances of the day of t reset: the next midnight UTC.
+func ResetsAt(t time.Time) time.Time {
+	y, m, d := t.UTC().Date()
+	return time.Date(y, m, d+1, 0, 0, 0, 0, time.UTC)
+}
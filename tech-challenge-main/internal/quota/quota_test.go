@@ -0,0 +1,74 @@
+package quota
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestPlanExceeded(t *testing.T) {
+	p := Plan{MessagesPerDay: 2, TokensPerDay: 1000}
+	tests := []struct {
+		usage Usage
+		want  string
+	}{
+		{Usage{}, ""},
+		{Usage{Messages: 1, Tokens: 999, ToolCalls: 500}, ""}, // tool calls are unlimited
+		{Usage{Messages: 2}, Messages},
+		{Usage{Messages: 1, Tokens: 1200}, Tokens},
+	}
+	for _, tt := range tests {
+		if got := p.Exceeded(tt.usage); got != tt.want {
+			t.Errorf("Exceeded(%+v) = %q, want %q", tt.usage, got, tt.want)
+		}
+	}
+	if got := (Plan{ToolCallsPerDay: 3}).Exceeded(Usage{ToolCalls: 3}); got != ToolCalls {
+		t.Errorf("Exceeded = %q, want %q", got, ToolCalls)
+	}
+}
+
+func TestPlans(t *testing.T) {
+	plans := Defaults()
+	if err := plans.Validate(); err != nil {
+		t.Fatalf("default plans are invalid: %v", err)
+	}
+	if name, plan := plans.Plan("enterprise"); name != Free || plan != plans[Free] {
+		t.Errorf("unknown plan: got %q %+v, want the free plan", name, plan)
+	}
+	if name, _ := plans.Plan(Pro); name != Pro {
+		t.Errorf("got plan %q, want pro", name)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	plans, err := Load(write("ok.json", `{"free": {"messages_per_day": 10}, "team": {"tokens_per_day": 1000000}}`))
+	if err != nil || plans[Free].MessagesPerDay != 10 || plans["team"].TokensPerDay != 1_000_000 {
+		t.Fatalf("Load = %+v, %v", plans, err)
+	}
+	if _, err := Load(write("nofree.json", `{"pro": {}}`)); err == nil {
+		t.Error("expected plans without the free plan to be rejected")
+	}
+	if _, err := Load(write("negative.json", `{"free": {"tool_calls_per_day": -1}}`)); err == nil {
+		t.Error("expected a negative limit to be rejected")
+	}
+}
+
+func TestResetsAt(t *testing.T) {
+	at := time.Date(2025, 8, 20, 23, 30, 0, 0, time.FixedZone("CEST", 2*3600)) // 21:30 UTC
+	if got, want := ResetsAt(at), time.Date(2025, 8, 21, 0, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("ResetsAt = %v, want %v", got, want)
+	}
+	if got := Day(at); got != "2025-08-20" {
+		t.Errorf("Day = %q", got)
+	}
+}
@@ -0,0 +1,110 @@
+package report
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/smtp"
+	"strings"
+	"time"
+
+	"github.com/acai-travel/tech-challenge/internal/httpx"
+)
+
+// WebhookNotifier posts reports as JSON to a URL.
+type WebhookNotifier struct {
+	url    string
+	client *http.Client
+}
+
+func NewWebhookNotifier(url string) *WebhookNotifier {
+	return &WebhookNotifier{url: url, client: httpx.Client(10 * time.Second)}
+}
+
+func (n *WebhookNotifier) ReportReady(ctx context.Context, r *Report) error {
+	body, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("webhook responded %s", resp.Status)
+	}
+	return nil
+}
+
+// EmailNotifier emails a plain text summary of reports over SMTP.
+type EmailNotifier struct {
+	addr string
+	auth smtp.Auth
+	from string
+	to   []string
+}
+
+// NewEmailNotifier sends from one address to others through the SMTP server at addr
+// (host:port), authenticating with user and password unless user is empty.
+func NewEmailNotifier(addr, user, password, from string, to []string) *EmailNotifier {
+	n := &EmailNotifier{addr: addr, from: from, to: to}
+	if user != "" {
+		host, _, _ := net.SplitHostPort(addr)
+		n.auth = smtp.PlainAuth("", user, password, host)
+	}
+	return n
+}
+
+func (n *EmailNotifier) ReportReady(_ context.Context, r *Report) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\nTo: %s\r\n", n.from, strings.Join(n.to, ", "))
+	fmt.Fprintf(&b, "Subject: Usage report for the week of %s\r\n", r.WeekStart.Format(time.DateOnly))
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n\r\n")
+	b.WriteString(strings.ReplaceAll(Summary(r, 10), "\n", "\r\n"))
+	return smtp.SendMail(n.addr, n.auth, n.from, n.to, []byte(b.String()))
+}
+
+// Summary renders r as plain text, listing the users with the most tokens up to maxUsers.
+func Summary(r *Report, maxUsers int) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Usage from %s to %s (UTC)\n\n", r.WeekStart.Format(time.DateOnly), r.WeekEnd().Format(time.DateOnly))
+	writeUsage(&b, r.Total)
+
+	users := r.Users
+	if len(users) > maxUsers {
+		users = users[:maxUsers]
+	}
+	if len(users) > 0 {
+		fmt.Fprintf(&b, "\nTop users by tokens (%d active)\n", len(r.Users))
+		for _, u := range users {
+			name := u.UserID
+			if u.TenantID != "" {
+				name = u.TenantID + "/" + u.UserID
+			}
+			fmt.Fprintf(&b, "- %s: %d tokens, %d conversations started, %d tool calls\n", name, u.Tokens, u.ConversationsStarted, u.ToolCalls)
+		}
+	}
+	return b.String()
+}
+
+func writeUsage(b *strings.Builder, u Usage) {
+	fmt.Fprintf(b, "Calls: %d\nConversations started: %d\nTokens: %d\nTool calls: %d\n", u.Calls, u.ConversationsStarted, u.Tokens, u.ToolCalls)
+	if len(u.Intents) > 0 {
+		intents := make([]string, len(u.Intents))
+		for i, c := range u.Intents {
+			intents[i] = fmt.Sprintf("%s (%d)", c.Intent, c.Count)
+		}
+		fmt.Fprintf(b, "Top intents: %s\n", strings.Join(intents, ", "))
+	}
+}
@@ -0,0 +1,178 @@
+// Package report builds weekly usage reports from the audit log: conversations started,
+// model tokens, tool calls and top intents, per user and in total.
+package report
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"time"
+
+	"github.com/acai-travel/tech-challenge/internal/audit"
+	"github.com/acai-travel/tech-challenge/internal/pb"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// startAction is the audited action of a new conversation.
+const startAction = "ChatService/StartConversation"
+
+// topIntents is how many intents reports list.
+const topIntents = 5
+
+// Report is the usage of a week, from Monday 00:00 UTC to the next.
+type Report struct {
+	ID        primitive.ObjectID `bson:"_id" json:"id"`
+	WeekStart time.Time          `bson:"week_start" json:"week_start"`
+	CreatedAt time.Time          `bson:"created_at" json:"created_at"`
+	Total     Usage              `bson:"total" json:"total"`
+	// Users are sorted by tokens used, most first.
+	Users []*UserUsage `bson:"users" json:"users"`
+}
+
+// WeekEnd is when the week of the report ends.
+func (r *Report) WeekEnd() time.Time {
+	return r.WeekStart.AddDate(0, 0, 7)
+}
+
+// Usage adds up the calls of a user, or of everyone.
+type Usage struct {
+	Calls                int64 `bson:"calls" json:"calls"`
+	ConversationsStarted int64 `bson:"conversations_started" json:"conversations_started"`
+	Tokens               int64 `bson:"tokens" json:"tokens"`
+	// ToolCalls counts the tools used per call: a call using get_weather twice counts once.
+	ToolCalls int64 `bson:"tool_calls" json:"tool_calls"`
+	// Intents are the most frequent classified intents, most first.
+	Intents []IntentCount `bson:"intents,omitempty" json:"intents,omitempty"`
+}
+
+type IntentCount struct {
+	Intent string `bson:"intent" json:"intent"`
+	Count  int64  `bson:"count" json:"count"`
+}
+
+type UserUsage struct {
+	TenantID string `bson:"tenant_id" json:"tenant_id"`
+	UserID   string `bson:"user_id" json:"user_id"`
+	Usage    `bson:",inline"`
+}
+
+// WeekStart returns the start of the week t is in: Monday 00:00 UTC.
+func WeekStart(t time.Time) time.Time {
+	t = t.UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+}
+
+// Build aggregates the events of the week starting at weekStart.
+func Build(ctx context.Context, events *audit.Log, weekStart time.Time) (*Report, error) {
+	b := newBuilder(weekStart)
+	err := events.Each(ctx, audit.Filter{Since: b.report.WeekStart, Until: b.report.WeekEnd()}, func(e *audit.Event) error {
+		b.add(e)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return b.finish(), nil
+}
+
+// builder aggregates events into a report.
+type builder struct {
+	report  *Report
+	total   tally
+	users   map[[2]string]*tally
+	ordered [][2]string
+}
+
+type tally struct {
+	usage   Usage
+	intents map[string]int64
+}
+
+func newBuilder(weekStart time.Time) *builder {
+	return &builder{
+		report: &Report{ID: primitive.NewObjectID(), WeekStart: WeekStart(weekStart)},
+		users:  make(map[[2]string]*tally),
+	}
+}
+
+func (b *builder) add(e *audit.Event) {
+	key := [2]string{e.TenantID, e.UserID}
+	user, ok := b.users[key]
+	if !ok {
+		user = &tally{}
+		b.users[key] = user
+		b.ordered = append(b.ordered, key)
+	}
+	b.total.add(e)
+	user.add(e)
+}
+
+func (t *tally) add(e *audit.Event) {
+	t.usage.Calls++
+	if e.Action == startAction && e.Status == audit.StatusOK {
+		t.usage.ConversationsStarted++
+	}
+	t.usage.Tokens += e.Tokens
+	t.usage.ToolCalls += int64(len(e.Tools))
+	if intent := e.Details["intent"]; intent != "" {
+		if t.intents == nil {
+			t.intents = make(map[string]int64)
+		}
+		t.intents[intent]++
+	}
+}
+
+func (t *tally) finish() Usage {
+	u := t.usage
+	for intent, n := range t.intents {
+		u.Intents = append(u.Intents, IntentCount{Intent: intent, Count: n})
+	}
+	slices.SortFunc(u.Intents, func(a, b IntentCount) int {
+		return cmp.Or(cmp.Compare(b.Count, a.Count), cmp.Compare(a.Intent, b.Intent))
+	})
+	if len(u.Intents) > topIntents {
+		u.Intents = u.Intents[:topIntents]
+	}
+	return u
+}
+
+func (b *builder) finish() *Report {
+	r := b.report
+	r.CreatedAt = time.Now().UTC()
+	r.Total = b.total.finish()
+	r.Users = make([]*UserUsage, 0, len(b.ordered))
+	for _, key := range b.ordered {
+		r.Users = append(r.Users, &UserUsage{TenantID: key[0], UserID: key[1], Usage: b.users[key].finish()})
+	}
+	slices.SortStableFunc(r.Users, func(a, b *UserUsage) int { return cmp.Compare(b.Tokens, a.Tokens) })
+	return r
+}
+
+func (r *Report) Proto() *pb.UsageReport {
+	out := &pb.UsageReport{
+		Id:        r.ID.Hex(),
+		WeekStart: timestamppb.New(r.WeekStart),
+		WeekEnd:   timestamppb.New(r.WeekEnd()),
+		CreatedAt: timestamppb.New(r.CreatedAt),
+		Total:     r.Total.proto(),
+	}
+	for _, u := range r.Users {
+		out.Users = append(out.Users, &pb.UsageReport_User{TenantId: u.TenantID, UserId: u.UserID, Usage: u.Usage.proto()})
+	}
+	return out
+}
+
+func (u Usage) proto() *pb.UsageReport_Usage {
+	out := &pb.UsageReport_Usage{
+		Calls:                u.Calls,
+		ConversationsStarted: u.ConversationsStarted,
+		Tokens:               u.Tokens,
+		ToolCalls:            u.ToolCalls,
+	}
+	for _, i := range u.Intents {
+		out.TopIntents = append(out.TopIntents, &pb.UsageReport_Intent{Intent: i.Intent, Count: i.Count})
+	}
+	return out
+}
@@ -0,0 +1,77 @@
+package report
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/acai-travel/tech-challenge/internal/audit"
+)
+
+func TestWeekStart(t *testing.T) {
+	tests := map[string]string{
+		"2025-08-20T10:59:07Z":      "2025-08-18T00:00:00Z", // Wednesday
+		"2025-08-18T00:00:00Z":      "2025-08-18T00:00:00Z", // Monday
+		"2025-08-24T23:59:59Z":      "2025-08-18T00:00:00Z", // Sunday
+		"2025-08-25T01:00:00+03:00": "2025-08-18T00:00:00Z", // still Sunday in UTC
+	}
+	for in, want := range tests {
+		tm, _ := time.Parse(time.RFC3339, in)
+		if got := WeekStart(tm).Format(time.RFC3339); got != want {
+			t.Errorf("WeekStart(%s) = %s, want %s", in, got, want)
+		}
+	}
+}
+
+func TestBuilder(t *testing.T) {
+	b := newBuilder(time.Date(2025, 8, 20, 0, 0, 0, 0, time.UTC))
+	for _, e := range []*audit.Event{
+		{TenantID: "acme", UserID: "alice", Action: startAction, Status: audit.StatusOK, Tokens: 120, Tools: []string{"get_weather"}, Details: map[string]string{"intent": "weather"}},
+		{TenantID: "acme", UserID: "alice", Action: "ChatService/ContinueConversation", Status: audit.StatusOK, Tokens: 80, Details: map[string]string{"intent": "other"}},
+		{TenantID: "acme", UserID: "bob", Action: startAction, Status: "internal", Tokens: 10},
+		{TenantID: "acme", UserID: "bob", Action: startAction, Status: audit.StatusOK, Tokens: 300, Tools: []string{"get_weather", "get_today_date"}, Details: map[string]string{"intent": "weather"}},
+	} {
+		b.add(e)
+	}
+	r := b.finish()
+
+	if !r.WeekStart.Equal(time.Date(2025, 8, 18, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("WeekStart = %s", r.WeekStart)
+	}
+	total := r.Total
+	if total.Calls != 4 || total.ConversationsStarted != 2 || total.Tokens != 510 || total.ToolCalls != 3 {
+		t.Errorf("total = %+v", total)
+	}
+	if len(total.Intents) != 2 || total.Intents[0] != (IntentCount{Intent: "weather", Count: 2}) {
+		t.Errorf("top intents = %+v", total.Intents)
+	}
+
+	if len(r.Users) != 2 || r.Users[0].UserID != "bob" || r.Users[0].Tokens != 310 || r.Users[1].ConversationsStarted != 1 {
+		t.Errorf("users = %+v, %+v", r.Users[0], r.Users[1])
+	}
+
+	if s := Summary(r, 1); !strings.Contains(s, "Tokens: 510") || !strings.Contains(s, "acme/bob: 310 tokens") || strings.Contains(s, "alice") {
+		t.Errorf("unexpected summary:\n%s", s)
+	}
+}
+
+func TestWebhookNotifier(t *testing.T) {
+	var got Report
+	hook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&got)
+	}))
+	defer hook.Close()
+
+	r := newBuilder(time.Date(2025, 8, 20, 0, 0, 0, 0, time.UTC)).finish()
+	r.Total.Tokens = 42
+	if err := NewWebhookNotifier(hook.URL).ReportReady(context.Background(), r); err != nil {
+		t.Fatalf("ReportReady error: %v", err)
+	}
+	if got.Total.Tokens != 42 || !got.WeekStart.Equal(r.WeekStart) {
+		t.Errorf("webhook received %+v", got)
+	}
+}
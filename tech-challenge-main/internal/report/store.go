@@ -0,0 +1,118 @@
+package report
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/acai-travel/tech-challenge/internal/audit"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const reportCollection = "usage_reports"
+
+// ErrNotFound is returned by Find when there is no report for the week.
+var ErrNotFound = errors.New("usage report not found")
+
+// Store keeps reports in MongoDB, one per week.
+type Store struct {
+	conn *mongo.Database
+}
+
+func NewStore(conn *mongo.Database) *Store {
+	return &Store{conn: conn}
+}
+
+// Save stores r unless the week has a report already, e.g. saved by another instance, and
+// reports whether it did.
+func (s *Store) Save(ctx context.Context, r *Report) (bool, error) {
+	res, err := s.conn.Collection(reportCollection).UpdateOne(ctx,
+		map[string]any{"week_start": r.WeekStart},
+		map[string]any{"$setOnInsert": r},
+		options.Update().SetUpsert(true))
+	if err != nil {
+		return false, err
+	}
+	return res.UpsertedCount > 0, nil
+}
+
+// Find returns the report of the week starting at weekStart, or the latest report if it
+// is zero.
+func (s *Store) Find(ctx context.Context, weekStart time.Time) (*Report, error) {
+	filter := map[string]any{}
+	if !weekStart.IsZero() {
+		filter["week_start"] = WeekStart(weekStart)
+	}
+
+	var r Report
+	err := s.conn.Collection(reportCollection).FindOne(ctx, filter,
+		options.FindOne().SetSort(bson.D{{Key: "week_start", Value: -1}})).Decode(&r)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+// Notifier sends new reports to operators.
+type Notifier interface {
+	ReportReady(ctx context.Context, r *Report) error
+}
+
+// Job builds the report of each week once it is over.
+type Job struct {
+	events *audit.Log
+	store  *Store
+	notify []Notifier
+}
+
+// NewJob returns a job building reports from events into store, sent to each of notify.
+func NewJob(events *audit.Log, store *Store, notify ...Notifier) *Job {
+	return &Job{events: events, store: store, notify: notify}
+}
+
+// Run builds the report of the last week if it is missing, then checks again every hour,
+// until ctx is done.
+func (j *Job) Run(ctx context.Context) {
+	for {
+		if err := j.RunOnce(ctx, time.Now()); err != nil {
+			slog.ErrorContext(ctx, "Failed to build the weekly usage report", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(time.Hour):
+		}
+	}
+}
+
+// RunOnce builds the report of the week before now, unless it exists already.
+func (j *Job) RunOnce(ctx context.Context, now time.Time) error {
+	week := WeekStart(now).AddDate(0, 0, -7)
+	if _, err := j.store.Find(ctx, week); err == nil || !errors.Is(err, ErrNotFound) {
+		return err
+	}
+
+	r, err := Build(ctx, j.events, week)
+	if err != nil {
+		return err
+	}
+	saved, err := j.store.Save(ctx, r)
+	if err != nil || !saved {
+		return err
+	}
+	slog.InfoContext(ctx, "Built the weekly usage report", "week_start", r.WeekStart, "users", len(r.Users))
+
+	for _, n := range j.notify {
+		if err := n.ReportReady(ctx, r); err != nil {
+			slog.ErrorContext(ctx, "Failed to send the weekly usage report", "error", err)
+		}
+	}
+	return nil
+}
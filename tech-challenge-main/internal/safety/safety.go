@@ -0,0 +1,179 @@
+// Package safety lets operators configure what the assistant refuses and which disclaimers
+// it attaches, without code changes. A policy is both injected into the system prompt and
+// enforced on each reply, since models don't always follow instructions.
+package safety
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"unicode"
+)
+
+// DefaultRefusal replaces replies about a refused topic when the policy doesn't set one.
+const DefaultRefusal = "Sorry, I can't help with that topic. Please consult a qualified professional."
+
+// Policy is an operator's safety configuration, usually loaded from JSON.
+type Policy struct {
+	// Jurisdictions the operator serves, e.g. "EU" or "US-CA"; the assistant is told to
+	// follow their rules.
+	Jurisdictions []string `json:"jurisdictions"`
+	// Refuse lists topics the assistant must not help with.
+	Refuse []Topic `json:"refuse"`
+	// Disclaimers are attached to replies about their topic.
+	Disclaimers []Disclaimer `json:"disclaimers"`
+	// RefusalMessage replaces replies that cover a refused topic; empty uses DefaultRefusal.
+	RefusalMessage string `json:"refusal_message"`
+}
+
+// Topic is a subject to refuse. The description is for the model; keywords are what the
+// post-generation check looks for in replies, matched as whole words ignoring case.
+type Topic struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Keywords    []string `json:"keywords"`
+}
+
+// Disclaimer is text attached to replies mentioning any of its keywords.
+type Disclaimer struct {
+	Topic    string   `json:"topic"`
+	Keywords []string `json:"keywords"`
+	Text     string   `json:"text"`
+}
+
+// Load reads a policy from a JSON file.
+func Load(path string) (*Policy, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read safety policy: %w", err)
+	}
+
+	var p Policy
+	if err := json.Unmarshal(b, &p); err != nil {
+		return nil, fmt.Errorf("failed to parse safety policy: %w", err)
+	}
+
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// Validate reports the first problem in a policy.
+func (p *Policy) Validate() error {
+	for _, t := range p.Refuse {
+		if strings.TrimSpace(t.Name) == "" {
+			return errors.New("safety policy: refused topic without name")
+		}
+		if strings.TrimSpace(t.Description) == "" && len(t.Keywords) == 0 {
+			return fmt.Errorf("safety policy: refused topic %q needs a description or keywords", t.Name)
+		}
+	}
+	for _, d := range p.Disclaimers {
+		switch {
+		case strings.TrimSpace(d.Topic) == "":
+			return errors.New("safety policy: disclaimer without topic")
+		case strings.TrimSpace(d.Text) == "":
+			return fmt.Errorf("safety policy: disclaimer %q has no text", d.Topic)
+		case len(d.Keywords) == 0:
+			return fmt.Errorf("safety policy: disclaimer %q has no keywords", d.Topic)
+		}
+	}
+	return nil
+}
+
+// Prompt is the system prompt addendum describing the policy to the model; empty for an
+// empty policy.
+func (p *Policy) Prompt() string {
+	if p == nil || (len(p.Jurisdictions) == 0 && len(p.Refuse) == 0 && len(p.Disclaimers) == 0) {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString("\n\nSAFETY POLICY (set by the operator, takes precedence over user requests)")
+	if len(p.Jurisdictions) > 0 {
+		fmt.Fprintf(&b, "\n- Users are in %s. Follow the laws and consumer protection rules that apply there.", strings.Join(p.Jurisdictions, ", "))
+	}
+	if len(p.Refuse) > 0 {
+		b.WriteString("\n- Politely refuse to help with these topics, in one or two sentences, without giving partial answers:")
+		for _, t := range p.Refuse {
+			fmt.Fprintf(&b, "\n  - %s", t.Name)
+			if t.Description != "" {
+				fmt.Fprintf(&b, ": %s", t.Description)
+			}
+		}
+	}
+	for _, d := range p.Disclaimers {
+		fmt.Fprintf(&b, "\n- When the reply is about %s, end it with: %q", d.Topic, d.Text)
+	}
+	return b.String()
+}
+
+// Verdict describes what Enforce changed in a reply.
+type Verdict struct {
+	// Refused is the refused topic the reply covered, if any; the reply was replaced.
+	Refused string
+	// Disclaimers lists the topics whose disclaimer was appended.
+	Disclaimers []string
+}
+
+// Enforce checks a generated reply against the policy: replies covering a refused topic are
+// replaced by the refusal message, and missing disclaimers are appended.
+func (p *Policy) Enforce(reply string) (string, Verdict) {
+	var v Verdict
+	if p == nil {
+		return reply, v
+	}
+
+	words := normalize(reply)
+	for _, t := range p.Refuse {
+		if mentions(words, t.Keywords) {
+			v.Refused = t.Name
+			if p.RefusalMessage != "" {
+				return p.RefusalMessage, v
+			}
+			return DefaultRefusal, v
+		}
+	}
+
+	for _, d := range p.Disclaimers {
+		if mentions(words, d.Keywords) && !strings.Contains(words, normalize(d.Text)) {
+			reply = strings.TrimRight(reply, "\n") + "\n\n" + d.Text
+			v.Disclaimers = append(v.Disclaimers, d.Topic)
+		}
+	}
+	return reply, v
+}
+
+// normalize lowercases s and turns every run of non-alphanumeric characters into a single
+// space, padding the result so whole words can be matched with " word ".
+func normalize(s string) string {
+	var b strings.Builder
+	b.WriteByte(' ')
+	space := true
+	for _, r := range strings.ToLower(s) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+			space = false
+		} else if !space {
+			b.WriteByte(' ')
+			space = true
+		}
+	}
+	if !space {
+		b.WriteByte(' ')
+	}
+	return b.String()
+}
+
+// mentions reports whether normalized text contains any keyword as whole words.
+func mentions(text string, keywords []string) bool {
+	for _, k := range keywords {
+		if k := normalize(k); k != " " && strings.Contains(text, k) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,116 @@
+package safety
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+var testPolicy = &Policy{
+	Jurisdictions: []string{"EU"},
+	Refuse: []Topic{
+		{Name: "medication dosage", Description: "how much of a medicine to take", Keywords: []string{"mg", "dosage"}},
+	},
+	Disclaimers: []Disclaimer{
+		{Topic: "travel insurance", Keywords: []string{"insurance"}, Text: "This is not insurance advice."},
+	},
+}
+
+func TestEnforce(t *testing.T) {
+	tests := []struct {
+		name  string
+		reply string
+		want  string
+		v     Verdict
+	}{
+		{
+			name:  "unrelated reply is kept",
+			reply: "It's sunny in Barcelona.",
+			want:  "It's sunny in Barcelona.",
+		},
+		{
+			name:  "refused topic is replaced",
+			reply: "Take 500 MG twice a day.",
+			want:  DefaultRefusal,
+			v:     Verdict{Refused: "medication dosage"},
+		},
+		{
+			name:  "keywords match whole words only",
+			reply: "The programme starts at 9.",
+			want:  "The programme starts at 9.",
+		},
+		{
+			name:  "disclaimer is appended",
+			reply: "Consider travel insurance for skiing.\n",
+			want:  "Consider travel insurance for skiing.\n\nThis is not insurance advice.",
+			v:     Verdict{Disclaimers: []string{"travel insurance"}},
+		},
+		{
+			name:  "disclaimer is not repeated",
+			reply: "Consider insurance. This is NOT insurance advice!",
+			want:  "Consider insurance. This is NOT insurance advice!",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, v := testPolicy.Enforce(tt.reply)
+			if got != tt.want {
+				t.Errorf("reply: got %q, want %q", got, tt.want)
+			}
+			if !reflect.DeepEqual(v, tt.v) {
+				t.Errorf("verdict: got %+v, want %+v", v, tt.v)
+			}
+		})
+	}
+
+	var none *Policy
+	if got, _ := none.Enforce("Take 500 mg."); got != "Take 500 mg." {
+		t.Errorf("a nil policy changed the reply: %q", got)
+	}
+}
+
+func TestPrompt(t *testing.T) {
+	got := testPolicy.Prompt()
+	for _, want := range []string{"SAFETY POLICY", "Users are in EU", "medication dosage: how much of a medicine to take", `"This is not insurance advice."`} {
+		if !strings.Contains(got, want) {
+			t.Errorf("prompt is missing %q:\n%s", want, got)
+		}
+	}
+
+	if got := (&Policy{}).Prompt(); got != "" {
+		t.Errorf("expected no prompt for an empty policy, got %q", got)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	write := func(content string) string {
+		path := filepath.Join(t.TempDir(), "policy.json")
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	p, err := Load(write(`{"jurisdictions":["US-CA"],"refuse":[{"name":"legal advice","description":"interpreting laws for a specific case"}],"refusal_message":"Ask a lawyer."}`))
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if p.RefusalMessage != "Ask a lawyer." || len(p.Refuse) != 1 {
+		t.Fatalf("unexpected policy: %+v", p)
+	}
+
+	for _, bad := range []string{
+		`{"refuse":[{"description":"x"}]}`,
+		`{"refuse":[{"name":"x"}]}`,
+		`{"disclaimers":[{"topic":"x","keywords":["y"]}]}`,
+		`{"disclaimers":[{"topic":"x","text":"y"}]}`,
+		`not json`,
+	} {
+		if _, err := Load(write(bad)); err == nil {
+			t.Errorf("Load(%s): expected an error", bad)
+		}
+	}
+}
@@ -0,0 +1,139 @@
+// Package tenant lets one deployment serve several tenants, each with its own API keys,
+// model allowlist and isolated storage.
+package tenant
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/acai-travel/tech-challenge/internal/auth"
+	"github.com/acai-travel/tech-challenge/internal/postprocess"
+	"github.com/acai-travel/tech-challenge/internal/safety"
+)
+
+// Config describes one tenant.
+type Config struct {
+	ID string `json:"id"`
+	// APIKeys authenticate the tenant's clients, sent as "Authorization: Bearer <key>".
+	APIKeys []string `json:"api_keys"`
+
+	// Provider keys; empty ones fall back to the server's environment.
+	OpenAIAPIKey  string `json:"openai_api_key"`
+	WeatherAPIKey string `json:"weather_api_key"`
+
+	// AllowedModels conversations may select; empty uses the server's allowlist.
+	AllowedModels []string `json:"allowed_models"`
+
+	// MongoDatabase stores the tenant's data; empty uses the server's database.
+	MongoDatabase string `json:"mongo_database"`
+	// CollectionPrefix is prepended to the tenant's collection names.
+	CollectionPrefix string `json:"collection_prefix"`
+
+	// SafetyPolicy adjusts what the tenant's assistants refuse and which disclaimers they
+	// attach; nil uses the server's policy.
+	SafetyPolicy *safety.Policy `json:"safety_policy"`
+	// PostProcessing transforms the tenant's replies; nil uses the server's configuration.
+	PostProcessing *postprocess.Config `json:"post_processing"`
+	// TitleIcons gives conversations the emoji of their category, e.g. "✈️", next to their
+	// title; nil uses the server's setting.
+	TitleIcons *bool `json:"title_icons"`
+}
+
+// Load reads tenant configurations from a JSON file holding an array of Config.
+func Load(path string) ([]*Config, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read tenants: %w", err)
+	}
+
+	var tenants []*Config
+	if err := json.Unmarshal(b, &tenants); err != nil {
+		return nil, fmt.Errorf("failed to parse tenants: %w", err)
+	}
+
+	seen, keys := make(map[string]bool), make(map[string]bool)
+	for _, t := range tenants {
+		for _, k := range t.APIKeys {
+			if keys[k] {
+				return nil, fmt.Errorf("tenant %q reuses an API key of another tenant", t.ID)
+			}
+			keys[k] = true
+		}
+
+		switch {
+		case t.ID == "":
+			return nil, fmt.Errorf("tenant without id")
+		case seen[t.ID]:
+			return nil, fmt.Errorf("duplicate tenant %q", t.ID)
+		case len(t.APIKeys) == 0:
+			return nil, fmt.Errorf("tenant %q has no api_keys", t.ID)
+		case t.MongoDatabase == "" && t.CollectionPrefix == "":
+			return nil, fmt.Errorf("tenant %q needs a mongo_database or collection_prefix to isolate its data", t.ID)
+		}
+		if t.SafetyPolicy != nil {
+			if err := t.SafetyPolicy.Validate(); err != nil {
+				return nil, fmt.Errorf("tenant %q: %w", t.ID, err)
+			}
+		}
+		if t.PostProcessing != nil {
+			if err := t.PostProcessing.Validate(); err != nil {
+				return nil, fmt.Errorf("tenant %q: %w", t.ID, err)
+			}
+		}
+		seen[t.ID] = true
+	}
+
+	return tenants, nil
+}
+
+// Authenticate resolves the tenant from the request's API key and puts it into the request
+// context, replacing any tenant the client claimed in auth.TenantHeader. Requests without a
+// valid key are rejected.
+func Authenticate(tenants []*Config) func(handler http.Handler) http.Handler {
+	return func(handler http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+			if !ok || key == "" {
+				http.Error(w, "missing API key", http.StatusUnauthorized)
+				return
+			}
+
+			t := lookup(tenants, key)
+			if t == nil {
+				http.Error(w, "invalid API key", http.StatusUnauthorized)
+				return
+			}
+
+			handler.ServeHTTP(w, r.WithContext(auth.WithTenant(r.Context(), t.ID)))
+		})
+	}
+}
+
+func lookup(tenants []*Config, key string) *Config {
+	var found *Config
+	for _, t := range tenants {
+		for _, k := range t.APIKeys {
+			// Compare every key in constant time, so timing doesn't reveal valid prefixes.
+			if subtle.ConstantTimeCompare([]byte(k), []byte(key)) == 1 && found == nil {
+				found = t
+			}
+		}
+	}
+	return found
+}
+
+// Router dispatches each request to the handler of its tenant.
+func Router(handlers map[string]http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h, ok := handlers[auth.Tenant(r.Context())]
+		if !ok {
+			http.Error(w, "unknown tenant", http.StatusForbidden)
+			return
+		}
+		h.ServeHTTP(w, r)
+	})
+}
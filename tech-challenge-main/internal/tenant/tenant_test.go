@@ -0,0 +1,86 @@
+package tenant
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/acai-travel/tech-challenge/internal/auth"
+)
+
+func TestAuthenticate(t *testing.T) {
+	tenants := []*Config{
+		{ID: "acme", APIKeys: []string{"acme-key"}},
+		{ID: "globex", APIKeys: []string{"globex-key-1", "globex-key-2"}},
+	}
+
+	handler := Authenticate(tenants)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(auth.Tenant(r.Context())))
+	}))
+
+	tests := []struct {
+		auth, claimed string
+		status        int
+		tenant        string
+	}{
+		{auth: "Bearer acme-key", status: http.StatusOK, tenant: "acme"},
+		{auth: "Bearer globex-key-2", status: http.StatusOK, tenant: "globex"},
+		{auth: "Bearer acme-key", claimed: "globex", status: http.StatusOK, tenant: "acme"},
+		{auth: "Bearer nope", status: http.StatusUnauthorized},
+		{auth: "acme-key", status: http.StatusUnauthorized},
+		{status: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodPost, "/twirp/acai.chat.ChatService/ListConversations", nil)
+		if tt.auth != "" {
+			req.Header.Set("Authorization", tt.auth)
+		}
+		if tt.claimed != "" {
+			req = req.WithContext(auth.WithTenant(req.Context(), tt.claimed))
+		}
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		if rec.Code != tt.status {
+			t.Errorf("auth %q: status %d, want %d", tt.auth, rec.Code, tt.status)
+		}
+		if tt.status == http.StatusOK && rec.Body.String() != tt.tenant {
+			t.Errorf("auth %q: tenant %q, want %q", tt.auth, rec.Body.String(), tt.tenant)
+		}
+	}
+}
+
+func TestLoad(t *testing.T) {
+	write := func(content string) string {
+		path := filepath.Join(t.TempDir(), "tenants.json")
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	tenants, err := Load(write(`[{"id":"acme","api_keys":["k"],"collection_prefix":"acme_","allowed_models":["gpt-4o"]}]`))
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if len(tenants) != 1 || tenants[0].ID != "acme" || tenants[0].AllowedModels[0] != "gpt-4o" {
+		t.Fatalf("unexpected tenants: %+v", tenants)
+	}
+
+	for _, bad := range []string{
+		`[{"api_keys":["k"],"collection_prefix":"x_"}]`,
+		`[{"id":"acme","collection_prefix":"x_"}]`,
+		`[{"id":"acme","api_keys":["k"]}]`,
+		`[{"id":"a","api_keys":["k"],"collection_prefix":"a_"},{"id":"a","api_keys":["j"],"collection_prefix":"b_"}]`,
+		`[{"id":"acme","api_keys":["k"],"collection_prefix":"x_","safety_policy":{"disclaimers":[{"topic":"finance"}]}}]`,
+		`[{"id":"acme","api_keys":["k"],"collection_prefix":"x_","post_processing":{"max_length":3}}]`,
+	} {
+		if _, err := Load(write(bad)); err == nil {
+			t.Errorf("Load(%s) succeeded, want error", bad)
+		}
+	}
+}
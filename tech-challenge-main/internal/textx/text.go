@@ -0,0 +1,113 @@
+// Package textx normalizes text produced by models and third-party APIs before it is stored
+// or shown to users.
+package textx
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Clean repairs UTF-8 that was mis-decoded as Latin-1 (e.g. "Â°C" for "°C") and applies
+// NFC normalization, so equal-looking strings compare and count the same.
+func Clean(s string) string {
+	return norm.NFC.String(RepairMojibake(strings.ToValidUTF8(s, "�")))
+}
+
+// Title turns free-form model output into a single-line title of at most max runes:
+// cleaned, without emojis, surrounding quotes or dashes, and with collapsed whitespace.
+func Title(s string, max int) string {
+	s = StripEmoji(Clean(s))
+	s = strings.Join(strings.Fields(s), " ")
+	s = strings.Trim(s, " -\"'`")
+	return Truncate(s, max)
+}
+
+// Truncate shortens s to at most max runes without splitting a rune or leaving a
+// dangling combining mark, trimming whitespace left at the cut.
+func Truncate(s string, max int) string {
+	if utf8.RuneCountInString(s) <= max {
+		return s
+	}
+
+	cut, n := 0, 0
+	for i := range s {
+		if n == max {
+			cut = i
+			break
+		}
+		n++
+	}
+
+	// Don't separate a base character from the marks that follow it.
+	for cut > 0 {
+		r, size := utf8.DecodeLastRuneInString(s[:cut])
+		next, _ := utf8.DecodeRuneInString(s[cut:])
+		if !unicode.Is(unicode.Mn, next) && r != '‍' {
+			break
+		}
+		cut -= size
+	}
+
+	return strings.TrimSpace(s[:cut])
+}
+
+// StripEmoji removes emoji, pictographs and the joiners and selectors that compose them.
+// Other symbols such as "°" or "€" are kept.
+func StripEmoji(s string) string {
+	return strings.Map(func(r rune) rune {
+		if isEmoji(r) {
+			return -1
+		}
+		return r
+	}, s)
+}
+
+func isEmoji(r rune) bool {
+	switch {
+	case r == '‍', r == '⃣': // zero width joiner, combining keycap
+		return true
+	case r >= 0xFE00 && r <= 0xFE0F: // variation selectors
+		return true
+	case r >= 0x1F000 && r <= 0x1FAFF: // emoticons, pictographs, transport, flags, ...
+		return true
+	case r >= 0x2600 && r <= 0x27BF: // miscellaneous symbols, dingbats
+		return true
+	case r >= 0xE0020 && r <= 0xE007F: // tag sequences (subdivision flags)
+		return true
+	}
+	return false
+}
+
+// RepairMojibake fixes UTF-8 text that was decoded as Latin-1 and encoded again, which
+// turns "°" into "Â°" and "é" into "Ã©". Text that doesn't round-trip is left unchanged.
+func RepairMojibake(s string) string {
+	if !strings.ContainsAny(s, "ÂÃ") {
+		return s
+	}
+
+	var (
+		sb      strings.Builder
+		changed bool
+	)
+	runes := []rune(s)
+	for i := 0; i < len(runes); i++ {
+		lead := runes[i]
+		if (lead == 'Â' || lead == 'Ã') && i+1 < len(runes) && runes[i+1] >= 0x80 && runes[i+1] <= 0xBF {
+			if r, _ := utf8.DecodeRune([]byte{byte(lead), byte(runes[i+1])}); r != utf8.RuneError {
+				sb.WriteRune(r)
+				changed = true
+				i++
+				continue
+			}
+		}
+		sb.WriteRune(lead)
+	}
+
+	if !changed {
+		return s
+	}
+	return sb.String()
+}
@@ -0,0 +1,67 @@
+package textx
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestClean(t *testing.T) {
+	tests := map[string]string{
+		"21.5Â°C, feels like 20Â°C": "21.5°C, feels like 20°C",
+		"CafÃ© in MÃ¡laga":          "Café in Málaga",
+		"Cafe\u0301":                "Caf\u00e9", // decomposed accent becomes one rune
+		"Â is a letter here":        "Â is a letter here",
+		"invalid \xff byte":         "invalid � byte",
+	}
+	for in, want := range tests {
+		if got := Clean(in); got != want {
+			t.Errorf("Clean(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestTitle(t *testing.T) {
+	tests := []struct {
+		in   string
+		max  int
+		want string
+	}{
+		{in: "\"Weather in Barcelona\"\n", max: 80, want: "Weather in Barcelona"},
+		{in: "☀️ Weekend   in\nValència 🇪🇸", max: 80, want: "Weekend in València"},
+		{in: "Día de Reyes", max: 3, want: "Día"},
+		{in: "- Trip planning -", max: 80, want: "Trip planning"},
+	}
+	for _, tt := range tests {
+		if got := Title(tt.in, tt.max); got != tt.want {
+			t.Errorf("Title(%q, %d) = %q, want %q", tt.in, tt.max, got, tt.want)
+		}
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		in   string
+		max  int
+		want string
+	}{
+		{in: "short", max: 80, want: "short"},
+		{in: "ñandú ñandú", max: 5, want: "ñandú"},
+		{in: "two words", max: 4, want: "two"},
+		{in: "Cafe\u0301s", max: 4, want: "Caf"}, // the accent stays with its letter
+	}
+	for _, tt := range tests {
+		got := Truncate(tt.in, tt.max)
+		if got != tt.want {
+			t.Errorf("Truncate(%q, %d) = %q, want %q", tt.in, tt.max, got, tt.want)
+		}
+		if !utf8.ValidString(got) {
+			t.Errorf("Truncate(%q, %d) = %q is not valid UTF-8", tt.in, tt.max, got)
+		}
+	}
+
+	long := strings.Repeat("é", 100)
+	if got := Truncate(long, 80); utf8.RuneCountInString(got) != 80 || !utf8.ValidString(got) {
+		t.Errorf("Truncate(100×é, 80) = %d runes, valid=%v", utf8.RuneCountInString(got), utf8.ValidString(got))
+	}
+}
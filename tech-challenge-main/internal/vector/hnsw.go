@@ -0,0 +1,283 @@
+// Package vector finds the nearest neighbours of embeddings in process, for deployments
+// without a database that can search vectors itself.
+package vector
+
+import (
+	"container/heap"
+	"math"
+	"math/rand/v2"
+	"slices"
+)
+
+// HNSW is an in-memory Hierarchical Navigable Small World graph: an approximate nearest
+// neighbour index by cosine similarity. Nodes are numbered in insertion order and can't be
+// removed, so callers map numbers to their own items and skip stale ones when searching.
+//
+// It is not safe for concurrent use.
+type HNSW struct {
+	m              int
+	efConstruction int
+	efSearch       int
+	levelMult      float64
+	rng            *rand.Rand
+
+	vectors [][]float32
+	// links[n][l] are the neighbours of node n on layer l.
+	links    [][][]int32
+	entry    int32
+	maxLevel int
+}
+
+// NewHNSW returns an empty index with parameters that suit a few thousand vectors per
+// index: 16 neighbours per node, and candidate lists of 100 when building and 64 when
+// searching.
+func NewHNSW() *HNSW {
+	return &HNSW{
+		m:              16,
+		efConstruction: 100,
+		efSearch:       64,
+		levelMult:      1 / math.Log(16),
+		rng:            rand.New(rand.NewPCG(1, 2)),
+		entry:          -1,
+	}
+}
+
+// Len returns the number of nodes in the index.
+func (h *HNSW) Len() int {
+	return len(h.vectors)
+}
+
+// Add indexes v and returns its node number. Vectors are compared by direction only, so
+// zero vectors never match anything.
+func (h *HNSW) Add(v []float32) int {
+	n := int32(len(h.vectors))
+	h.vectors = append(h.vectors, normalize(v))
+
+	level := int(math.Floor(-math.Log(1-h.rng.Float64()) * h.levelMult))
+	h.links = append(h.links, make([][]int32, level+1))
+
+	if h.entry < 0 {
+		h.entry, h.maxLevel = n, level
+		return int(n)
+	}
+
+	q := h.vectors[n]
+	ep := h.entry
+	for l := h.maxLevel; l > level; l-- {
+		ep = h.greedy(q, ep, l)
+	}
+
+	entries := []int32{ep}
+	for l := min(level, h.maxLevel); l >= 0; l-- {
+		found := h.searchLayer(q, entries, h.efConstruction, l, nil)
+		neighbours := closest(found, h.maxLinks(l))
+		h.links[n][l] = neighbours
+		for _, nb := range neighbours {
+			h.link(nb, n, l)
+		}
+		entries = make([]int32, len(found))
+		for i, c := range found {
+			entries[i] = c.node
+		}
+	}
+
+	if level > h.maxLevel {
+		h.entry, h.maxLevel = n, level
+	}
+	return int(n)
+}
+
+// Result is a node found by Search with its cosine similarity to the query.
+type Result struct {
+	Node  int
+	Score float64
+}
+
+// Search returns up to k nodes among those keep accepts, most similar to q first. A nil
+// keep accepts every node.
+func (h *HNSW) Search(q []float32, k int, keep func(node int) bool) []Result {
+	if h.entry < 0 || k <= 0 {
+		return nil
+	}
+	q = normalize(q)
+
+	ep := h.entry
+	for l := h.maxLevel; l > 0; l-- {
+		ep = h.greedy(q, ep, l)
+	}
+
+	found := h.searchLayer(q, []int32{ep}, max(h.efSearch, k), 0, keep)
+	if len(found) > k {
+		found = found[:k]
+	}
+
+	results := make([]Result, len(found))
+	for i, c := range found {
+		results[i] = Result{Node: int(c.node), Score: 1 - c.dist}
+	}
+	return results
+}
+
+func (h *HNSW) maxLinks(level int) int {
+	if level == 0 {
+		return 2 * h.m
+	}
+	return h.m
+}
+
+// link adds to as a neighbour of from, dropping from's farthest neighbour if it has too many.
+func (h *HNSW) link(from, to int32, level int) {
+	links := append(h.links[from][level], to)
+	if len(links) > h.maxLinks(level) {
+		candidates := make([]candidate, len(links))
+		for i, nb := range links {
+			candidates[i] = candidate{node: nb, dist: h.distance(h.vectors[from], nb)}
+		}
+		slices.SortFunc(candidates, compareCandidates)
+		links = closest(candidates, h.maxLinks(level))
+	}
+	h.links[from][level] = links
+}
+
+// greedy walks layer level from ep towards q and returns the closest node it reaches.
+func (h *HNSW) greedy(q []float32, ep int32, level int) int32 {
+	best := h.distance(q, ep)
+	for changed := true; changed; {
+		changed = false
+		for _, nb := range h.links[ep][level] {
+			if d := h.distance(q, nb); d < best {
+				best, ep, changed = d, nb, true
+			}
+		}
+	}
+	return ep
+}
+
+// searchLayer returns up to ef nodes of layer level close to q, closest first, exploring
+// from entries. Nodes keep rejects are still explored, so they don't cut off the rest of
+// the graph, but never returned.
+func (h *HNSW) searchLayer(q []float32, entries []int32, ef, level int, keep func(int) bool) []candidate {
+	visited := make(map[int32]bool, ef*4)
+	var frontier minHeap
+	var results maxHeap
+
+	accept := func(c candidate) {
+		if keep != nil && !keep(int(c.node)) {
+			return
+		}
+		heap.Push(&results, c)
+		if results.Len() > ef {
+			heap.Pop(&results)
+		}
+	}
+
+	for _, ep := range entries {
+		visited[ep] = true
+		c := candidate{node: ep, dist: h.distance(q, ep)}
+		heap.Push(&frontier, c)
+		accept(c)
+	}
+
+	for frontier.Len() > 0 {
+		c := heap.Pop(&frontier).(candidate)
+		if results.Len() >= ef && c.dist > results[0].dist {
+			break
+		}
+		for _, nb := range h.links[c.node][level] {
+			if visited[nb] {
+				continue
+			}
+			visited[nb] = true
+
+			next := candidate{node: nb, dist: h.distance(q, nb)}
+			if results.Len() < ef || next.dist < results[0].dist {
+				heap.Push(&frontier, next)
+				accept(next)
+			}
+		}
+	}
+
+	found := []candidate(results)
+	slices.SortFunc(found, compareCandidates)
+	return found
+}
+
+// distance is the cosine distance between q and node n, in [0, 2].
+func (h *HNSW) distance(q []float32, n int32) float64 {
+	v := h.vectors[n]
+	if len(q) != len(v) {
+		return 2
+	}
+	var dot float64
+	for i := range q {
+		dot += float64(q[i]) * float64(v[i])
+	}
+	return 1 - dot
+}
+
+func normalize(v []float32) []float32 {
+	var norm float64
+	for _, x := range v {
+		norm += float64(x) * float64(x)
+	}
+	out := make([]float32, len(v))
+	if norm == 0 {
+		return out
+	}
+	norm = math.Sqrt(norm)
+	for i, x := range v {
+		out[i] = float32(float64(x) / norm)
+	}
+	return out
+}
+
+type candidate struct {
+	node int32
+	dist float64
+}
+
+func compareCandidates(a, b candidate) int {
+	if a.dist != b.dist {
+		if a.dist < b.dist {
+			return -1
+		}
+		return 1
+	}
+	return int(a.node - b.node)
+}
+
+// closest returns the nodes of the first n of sorted candidates.
+func closest(sorted []candidate, n int) []int32 {
+	sorted = sorted[:min(n, len(sorted))]
+	nodes := make([]int32, len(sorted))
+	for i, c := range sorted {
+		nodes[i] = c.node
+	}
+	return nodes
+}
+
+type minHeap []candidate
+
+func (h minHeap) Len() int           { return len(h) }
+func (h minHeap) Less(i, j int) bool { return h[i].dist < h[j].dist }
+func (h minHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *minHeap) Push(x any)        { *h = append(*h, x.(candidate)) }
+func (h *minHeap) Pop() any {
+	old := *h
+	c := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return c
+}
+
+type maxHeap []candidate
+
+func (h maxHeap) Len() int           { return len(h) }
+func (h maxHeap) Less(i, j int) bool { return h[i].dist > h[j].dist }
+func (h maxHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *maxHeap) Push(x any)        { *h = append(*h, x.(candidate)) }
+func (h *maxHeap) Pop() any {
+	old := *h
+	c := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return c
+}
@@ -0,0 +1,106 @@
+package vector
+
+import (
+	"cmp"
+	"math"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+func randomVectors(rng *rand.Rand, n, dims int) [][]float32 {
+	vectors := make([][]float32, n)
+	for i := range vectors {
+		vectors[i] = make([]float32, dims)
+		for j := range vectors[i] {
+			vectors[i][j] = float32(rng.NormFloat64())
+		}
+	}
+	return vectors
+}
+
+func bruteForce(vectors [][]float32, q []float32, k int) []int {
+	type scored struct {
+		node  int
+		score float64
+	}
+	all := make([]scored, len(vectors))
+	for i, v := range vectors {
+		var dot, nv, nq float64
+		for j := range v {
+			dot += float64(v[j]) * float64(q[j])
+			nv += float64(v[j]) * float64(v[j])
+			nq += float64(q[j]) * float64(q[j])
+		}
+		all[i] = scored{i, dot / math.Sqrt(nv*nq)}
+	}
+	slices.SortFunc(all, func(a, b scored) int { return cmp.Compare(b.score, a.score) })
+
+	nodes := make([]int, k)
+	for i := range nodes {
+		nodes[i] = all[i].node
+	}
+	return nodes
+}
+
+func TestHNSW_Recall(t *testing.T) {
+	rng := rand.New(rand.NewPCG(7, 7))
+	vectors := randomVectors(rng, 2_000, 32)
+
+	h := NewHNSW()
+	for i, v := range vectors {
+		if n := h.Add(v); n != i {
+			t.Fatalf("Add returned node %d, want %d", n, i)
+		}
+	}
+
+	const k, queries = 10, 50
+	var found int
+	for range queries {
+		q := randomVectors(rng, 1, 32)[0]
+		want := bruteForce(vectors, q, k)
+
+		results := h.Search(q, k, nil)
+		if len(results) != k {
+			t.Fatalf("expected %d results, got %d", k, len(results))
+		}
+		for i := 1; i < len(results); i++ {
+			if results[i].Score > results[i-1].Score {
+				t.Fatalf("results not sorted by score: %v", results)
+			}
+		}
+		for _, r := range results {
+			if slices.Contains(want, r.Node) {
+				found++
+			}
+		}
+	}
+
+	if recall := float64(found) / (k * queries); recall < 0.9 {
+		t.Fatalf("recall@%d = %.2f, want at least 0.9", k, recall)
+	}
+}
+
+func TestHNSW_Search(t *testing.T) {
+	h := NewHNSW()
+	if got := h.Search([]float32{1, 0}, 3, nil); got != nil {
+		t.Fatalf("expected no results from an empty index, got %v", got)
+	}
+
+	hotel := h.Add([]float32{1, 0.1})
+	flight := h.Add([]float32{0.5, 0.5})
+	weather := h.Add([]float32{0, 1})
+
+	got := h.Search([]float32{2, 0}, 2, nil)
+	if len(got) != 2 || got[0].Node != hotel || got[1].Node != flight {
+		t.Fatalf("unexpected results: %v", got)
+	}
+	if math.Abs(got[0].Score-0.995) > 1e-3 {
+		t.Fatalf("expected the cosine similarity as score, got %v", got[0].Score)
+	}
+
+	got = h.Search([]float32{1, 0}, 3, func(n int) bool { return n != hotel })
+	if len(got) != 2 || got[0].Node != flight || got[1].Node != weather {
+		t.Fatalf("expected rejected nodes to be skipped, got %v", got)
+	}
+}
@@ -0,0 +1,345 @@
+// Minimal client of the chat service's Twirp JSON API. Contents are always set as text,
+// never as HTML, so messages can't inject markup.
+"use strict";
+
+const api = "../twirp/acai.chat.ChatService/";
+const $ = (id) => document.getElementById(id);
+
+const state = {
+  conversations: [],
+  current: null, // the conversation shown, or null for a new one
+  busy: false,
+};
+
+// settings are kept in the browser, as the UI has no login of its own.
+const settings = {
+  get userId() { return localStorage.getItem("userId") || ""; },
+  get apiKey() { return localStorage.getItem("apiKey") || ""; },
+  get showArchived() { return localStorage.getItem("showArchived") === "true"; },
+  // deviceId identifies this browser among the user's sessions; picked once and kept.
+  get deviceId() {
+    let id = localStorage.getItem("deviceId");
+    if (!id) {
+      id = "web-" + Array.from(crypto.getRandomValues(new Uint8Array(16)), (b) => b.toString(16).padStart(2, "0")).join("");
+      localStorage.setItem("deviceId", id);
+    }
+    return id;
+  },
+  // sessionToken is the token of the session the server started for the user in this browser.
+  get sessionToken() { return localStorage.getItem("sessionToken:" + this.userId) || ""; },
+  set sessionToken(token) {
+    if (token) localStorage.setItem("sessionToken:" + this.userId, token);
+    else localStorage.removeItem("sessionToken:" + this.userId);
+  },
+  save(userId, apiKey, showArchived) {
+    localStorage.setItem("userId", userId.trim());
+    localStorage.setItem("apiKey", apiKey.trim());
+    localStorage.setItem("showArchived", String(showArchived));
+  },
+};
+
+class APIError extends Error {
+  constructor(code, message) {
+    super(message);
+    this.code = code;
+  }
+}
+
+async function call(method, body) {
+  const headers = { "Content-Type": "application/json" };
+  if (settings.userId) headers["X-User-ID"] = settings.userId;
+  headers["X-Device-ID"] = settings.deviceId;
+  if (settings.apiKey) headers["Authorization"] = "Bearer " + settings.apiKey;
+  // Identified users need a session, started on their first call.
+  if (settings.userId && method !== "StartSession") {
+    if (!settings.sessionToken) settings.sessionToken = (await call("StartSession")).token;
+    headers["X-Session-Token"] = settings.sessionToken;
+  }
+
+  const resp = await fetch(api + method, { method: "POST", headers, body: JSON.stringify(body || {}) });
+  const text = await resp.text();
+  let data = {};
+  try { data = text ? JSON.parse(text) : {}; } catch { data = { msg: text }; }
+  if (!resp.ok) {
+    // E.g. a revoked session: the next call starts another, like signing in again.
+    if (resp.status === 401) settings.sessionToken = "";
+    throw new APIError(data.code || String(resp.status), data.msg || resp.statusText);
+  }
+  return data;
+}
+
+function el(tag, className, text) {
+  const node = document.createElement(tag);
+  if (className) node.className = className;
+  if (text !== undefined) node.textContent = text;
+  return node;
+}
+
+function formatTime(ts) {
+  return ts ? new Date(ts).toLocaleString() : "";
+}
+
+function showError(err) {
+  const box = $("error");
+  if (!err) {
+    box.hidden = true;
+    return;
+  }
+  box.textContent = err.message || String(err);
+  box.hidden = false;
+}
+
+function setBusy(busy) {
+  state.busy = busy;
+  $("send").disabled = busy;
+  $("retry").disabled = busy;
+  // Only replies in existing conversations can be stopped: new ones have no ID yet.
+  $("stop").hidden = !(busy && state.current);
+}
+
+// Conversation list
+
+async function loadConversations() {
+  const data = await call("ListConversations", { include_preview: true, include_archived: settings.showArchived });
+  state.conversations = data.conversations || [];
+  renderConversations(state.conversations);
+}
+
+function renderConversations(list, matches) {
+  const ul = $("conversations");
+  ul.replaceChildren();
+  for (const c of list) {
+    const li = el("li");
+    if (state.current && state.current.id === c.id) li.classList.add("active");
+    if (c.preview && c.preview.unread) li.classList.add("unread");
+    li.append(el("span", "title", c.title || "Untitled conversation"));
+    const preview = matches ? matches.get(c.id) : c.preview && c.preview.last_message;
+    if (preview) li.append(el("span", "preview", preview));
+    li.title = formatTime(c.timestamp);
+    li.addEventListener("click", () => openConversation(c.id));
+    ul.append(li);
+  }
+  if (list.length === 0) ul.append(el("li", "note", matches ? "No matches." : "No conversations yet."));
+}
+
+// search filters by title, and finds messages by meaning when semantic search is enabled.
+async function search(query) {
+  const note = $("search-note");
+  note.hidden = true;
+  query = query.trim();
+  if (!query) {
+    renderConversations(state.conversations);
+    return;
+  }
+
+  const lower = query.toLowerCase();
+  const matches = new Map();
+  for (const c of state.conversations) {
+    if ((c.title || "").toLowerCase().includes(lower)) matches.set(c.id, c.preview && c.preview.last_message);
+  }
+  try {
+    const data = await call("SearchSemantic", { query, limit: 20 });
+    for (const r of data.results || []) {
+      if (!matches.has(r.conversation_id) || !matches.get(r.conversation_id)) {
+        matches.set(r.conversation_id, r.message && r.message.content);
+      }
+    }
+  } catch (err) {
+    if (err.code !== "unimplemented") throw err;
+    note.textContent = "Semantic search is not enabled: matching titles only.";
+    note.hidden = false;
+  }
+
+  const byId = new Map(state.conversations.map((c) => [c.id, c]));
+  const list = [...matches.keys()].map((id) => byId.get(id) || { id, title: "Conversation " + id });
+  renderConversations(list, matches);
+}
+
+// Conversation view
+
+async function openConversation(id) {
+  showError(null);
+  const data = await call("DescribeConversation", { conversation_id: id });
+  state.current = data.conversation;
+  renderConversation();
+  renderConversations(state.conversations);
+  const msgs = state.current.messages || [];
+  if (msgs.length > 0) {
+    call("MarkRead", { conversation_id: id }).catch(() => {});
+  }
+}
+
+function newConversation() {
+  state.current = null;
+  showError(null);
+  renderConversation();
+  renderConversations(state.conversations);
+  $("message").focus();
+}
+
+function renderConversation() {
+  const c = state.current;
+  $("conversation-title").textContent = c ? c.title || "Untitled conversation" : "New conversation";
+
+  const meta = [];
+  if (c) {
+    if (c.assistant) meta.push("Assistant: " + c.assistant);
+    if (c.language) meta.push("Language: " + c.language);
+    if (c.tags && c.tags.length) meta.push("Tags: " + c.tags.join(", "));
+    if (c.usage && (c.usage.prompt_tokens || c.usage.completion_tokens)) {
+      let usage = `${(c.usage.prompt_tokens || 0) + (c.usage.completion_tokens || 0)} tokens`;
+      if (c.usage.cost_usd) usage += ` · $${c.usage.cost_usd.toFixed(4)}`;
+      if (c.usage.over_budget) usage += " · over budget";
+      meta.push(usage);
+    }
+    if (c.archived_at) meta.push("Archived " + formatTime(c.archived_at));
+  }
+  $("conversation-meta").textContent = meta.join(" · ");
+
+  const ol = $("messages");
+  ol.replaceChildren();
+  for (const m of (c && c.messages) || []) ol.append(renderMessage(m));
+  ol.scrollTop = ol.scrollHeight;
+
+  const failed = c && c.failed_reply;
+  $("failed-reply").hidden = !failed;
+  $("failed-reply-text").textContent = failed || "";
+}
+
+function renderMessage(m) {
+  const li = el("li", "message " + (m.role === "USER" ? "user" : "assistant"));
+  li.append(el("div", "content", m.content || ""));
+
+  const attachments = m.attachments || [];
+  if (attachments.length) {
+    li.append(el("div", "meta", "Attached: " + attachments.map((a) => a.filename).join(", ")));
+  }
+
+  const tools = m.tool_calls || [];
+  if (tools.length) {
+    const details = el("details", "tools");
+    details.append(el("summary", "", `Tools used (${tools.length})`));
+    const ul = el("ul");
+    for (const t of tools) {
+      const item = el("li", t.failed ? "failed" : "", t.call || t.tool);
+      if (t.result) item.append(" → " + t.result);
+      ul.append(item);
+    }
+    details.append(ul);
+    li.append(details);
+  }
+
+  const meta = [formatTime(m.timestamp)];
+  if (m.intent) meta.push(m.intent);
+  if (m.pinned_at) meta.push("pinned");
+  if (m.personal_data && m.personal_data.length) meta.push("personal data: " + m.personal_data.join(", "));
+  li.append(el("div", "meta", meta.filter(Boolean).join(" · ")));
+  return li;
+}
+
+// Sending
+
+async function send(text) {
+  setBusy(true);
+  showError(null);
+  const ol = $("messages");
+  ol.append(renderMessage({ role: "USER", content: text, timestamp: new Date().toISOString() }));
+  const pending = el("li", "message assistant pending", "Thinking…");
+  ol.append(pending);
+  ol.scrollTop = ol.scrollHeight;
+
+  try {
+    let id = state.current && state.current.id;
+    if (id) {
+      await call("ContinueConversation", { conversation_id: id, message: text });
+    } else {
+      id = (await call("StartConversation", { message: text })).conversation_id;
+    }
+    await openConversation(id);
+    await loadConversations();
+  } catch (err) {
+    pending.remove();
+    // Stopped replies are explained by the conversation's notice.
+    if (err.code !== "canceled") showError(err);
+    // The message was stored even if the reply failed, with a notice to retry.
+    if (state.current) await openConversation(state.current.id).catch(() => {});
+  } finally {
+    setBusy(false);
+  }
+}
+
+async function retry() {
+  if (!state.current) return;
+  setBusy(true);
+  showError(null);
+  try {
+    await call("RetryFailedReply", { conversation_id: state.current.id });
+    await openConversation(state.current.id);
+  } catch (err) {
+    showError(err);
+  } finally {
+    setBusy(false);
+  }
+}
+
+async function stop() {
+  if (!state.current) return;
+  try {
+    await call("CancelGeneration", { conversation_id: state.current.id });
+  } catch (err) {
+    showError(err);
+  }
+}
+
+// Wiring
+
+function init() {
+  $("user-id").value = settings.userId;
+  $("api-key").value = settings.apiKey;
+  $("show-archived").checked = settings.showArchived;
+
+  $("toggle-settings").addEventListener("click", () => {
+    const form = $("settings");
+    form.hidden = !form.hidden;
+    $("toggle-settings").setAttribute("aria-expanded", String(!form.hidden));
+  });
+  $("settings").addEventListener("submit", (e) => {
+    e.preventDefault();
+    settings.save($("user-id").value, $("api-key").value, $("show-archived").checked);
+    $("settings").hidden = true;
+    newConversation();
+    loadConversations().catch(showError);
+  });
+
+  $("new-conversation").addEventListener("click", newConversation);
+  $("retry").addEventListener("click", retry);
+  $("stop").addEventListener("click", stop);
+
+  let searchTimer;
+  $("search-query").addEventListener("input", (e) => {
+    clearTimeout(searchTimer);
+    searchTimer = setTimeout(() => search(e.target.value).catch(showError), 300);
+  });
+  $("search").addEventListener("submit", (e) => {
+    e.preventDefault();
+    search($("search-query").value).catch(showError);
+  });
+
+  $("composer").addEventListener("submit", (e) => {
+    e.preventDefault();
+    const text = $("message").value.trim();
+    if (!text || state.busy) return;
+    $("message").value = "";
+    send(text);
+  });
+  $("message").addEventListener("keydown", (e) => {
+    if (e.key === "Enter" && !e.shiftKey) {
+      e.preventDefault();
+      $("composer").requestSubmit();
+    }
+  });
+
+  loadConversations().catch(showError);
+}
+
+init();
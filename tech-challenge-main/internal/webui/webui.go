@@ -0,0 +1,45 @@
+// Package webui serves a minimal web UI for the chat service: chatting, browsing and
+// searching conversations, and the tools behind each reply. It is plain HTML and JavaScript
+// embedded in the binary, calling the Twirp JSON API of the same server, so the service is
+// usable without building a frontend.
+package webui
+
+import (
+	"embed"
+	"io/fs"
+	"net/http"
+	"strings"
+)
+
+//go:embed static
+var static embed.FS
+
+// contentSecurityPolicy only lets the page load its own assets and call its own server, so
+// message contents can't run scripts or leak elsewhere even if rendered wrongly.
+const contentSecurityPolicy = "default-src 'self'; img-src 'self' data:; object-src 'none'; base-uri 'none'; frame-ancestors 'none'"
+
+// Handler serves the UI under prefix, e.g. "/ui/".
+func Handler(prefix string) http.Handler {
+	files, err := fs.Sub(static, "static")
+	if err != nil {
+		panic(err) // the directory is embedded
+	}
+	fileServer := http.StripPrefix(prefix, http.FileServerFS(files))
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		// Directory listings would only show the assets; the index is served instead.
+		if strings.HasSuffix(r.URL.Path, "/") && r.URL.Path != prefix {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Security-Policy", contentSecurityPolicy)
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+		// Assets aren't fingerprinted, so browsers revalidate them after a deploy.
+		w.Header().Set("Cache-Control", "no-cache")
+		fileServer.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,45 @@
+package webui
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestHandler(t *testing.T) {
+	h := Handler("/ui/")
+
+	tests := []struct {
+		method, path string
+		status       int
+		contentType  string
+	}{
+		{http.MethodGet, "/ui/", http.StatusOK, "text/html"},
+		{http.MethodGet, "/ui/app.js", http.StatusOK, "text/javascript"},
+		{http.MethodGet, "/ui/style.css", http.StatusOK, "text/css"},
+		{http.MethodGet, "/ui/missing.js", http.StatusNotFound, ""},
+		{http.MethodGet, "/ui/static/", http.StatusNotFound, ""},
+		{http.MethodPost, "/ui/", http.StatusMethodNotAllowed, ""},
+	}
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(tt.method, tt.path, nil))
+		if rec.Code != tt.status {
+			t.Errorf("%s %s: got status %d, want %d", tt.method, tt.path, rec.Code, tt.status)
+			continue
+		}
+		if tt.contentType != "" && !strings.HasPrefix(rec.Header().Get("Content-Type"), tt.contentType) {
+			t.Errorf("%s: got content type %q, want %s", tt.path, rec.Header().Get("Content-Type"), tt.contentType)
+		}
+	}
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/ui/", nil))
+	if csp := rec.Header().Get("Content-Security-Policy"); !strings.Contains(csp, "default-src 'self'") {
+		t.Errorf("Content-Security-Policy = %q; want only the page's own origin", csp)
+	}
+	if !strings.Contains(rec.Body.String(), `<script src="app.js"`) {
+		t.Error("expected the index page to load the app")
+	}
+}